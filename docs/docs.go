@@ -976,7 +976,7 @@ const docTemplate = `{
         },
         "/customers": {
             "get": {
-                "description": "List customers with pagination and search",
+                "description": "List customers with pagination and search, with each customer's loyalty points balance",
                 "consumes": [
                     "application/json"
                 ],
@@ -1109,7 +1109,7 @@ const docTemplate = `{
         },
         "/customers/{id}": {
             "get": {
-                "description": "Get customer by ID",
+                "description": "Get customer by ID, with the loyalty points balance and latest points history",
                 "consumes": [
                     "application/json"
                 ],
@@ -1299,9 +1299,9 @@ const docTemplate = `{
                 ]
             }
         },
-        "/orders": {
+        "/inventory/ingredients": {
             "get": {
-                "description": "Get a list of orders with filtering by status and user (Roles: admin, manager, cashier)",
+                "description": "Get ingredients with their stock in grams, millilitres or pieces. Filter by name with search, or only those at or below their threshold with low_stock_only (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1309,9 +1309,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Inventory"
                 ],
-                "summary": "List orders",
+                "summary": "List ingredients",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1321,38 +1321,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Number of orders per page",
+                        "description": "Items per page",
                         "name": "limit",
                         "in": "query"
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "enum": [
-                                "open",
-                                "in_progress",
-                                "served",
-                                "paid",
-                                "cancelled"
-                            ],
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "Order statuses",
-                        "name": "statuses",
+                        "type": "string",
+                        "description": "Search by name",
+                        "name": "search",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Filter by User ID",
-                        "name": "user_id",
+                        "type": "boolean",
+                        "description": "Only ingredients at or below their low stock threshold",
+                        "name": "low_stock_only",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Orders retrieved successfully",
+                        "description": "Ingredients retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1362,7 +1350,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_orders.PagedOrderResponse"
+                                            "$ref": "#/definitions/internal_inventory.ListIngredientsResponse"
                                         }
                                     }
                                 }
@@ -1376,7 +1364,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve orders",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -1389,7 +1377,7 @@ const docTemplate = `{
                 ]
             },
             "post": {
-                "description": "Create a new order with multiple items (Roles: admin, manager, cashier)",
+                "description": "Create an ingredient counted in g, ml or pcs. A starting stock is written to the ingredient ledger as a restock (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1397,23 +1385,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Inventory"
                 ],
-                "summary": "Create an order",
+                "summary": "Create ingredient",
                 "parameters": [
                     {
-                        "description": "Create order details",
+                        "description": "Ingredient details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_orders.CreateOrderRequest"
+                            "$ref": "#/definitions/internal_inventory.CreateIngredientRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Order created successfully",
+                        "description": "Ingredient created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1423,7 +1411,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
+                                            "$ref": "#/definitions/internal_inventory.IngredientResponse"
                                         }
                                     }
                                 }
@@ -1436,8 +1424,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Ingredient with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to create order",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -1445,14 +1439,13 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
             }
         },
-        "/orders/webhook/midtrans": {
-            "post": {
-                "description": "Webhook for Midtrans to notify order payment status updates",
+        "/inventory/ingredients/{id}": {
+            "get": {
+                "description": "Get a single ingredient with its current stock (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1460,45 +1453,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Inventory"
                 ],
-                "summary": "Midtrans Payment Notification Callback",
+                "summary": "Get ingredient by ID",
                 "parameters": [
                     {
-                        "description": "Midtrans Notification Payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_pkg_payment.MidtransNotificationPayload"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Ingredient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Notification received successfully",
+                        "description": "Ingredient retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_inventory.IngredientResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid notification format",
+                        "description": "Invalid ingredient ID",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ingredient not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to handle notification",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/orders/{id}": {
-            "get": {
-                "description": "Retrieve detailed information of a specific order by its ID (Roles: admin, manager, cashier)",
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "delete": {
+                "description": "Delete an ingredient that no product or option recipe uses any more (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1506,14 +1519,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Inventory"
                 ],
-                "summary": "Get an order by ID",
+                "summary": "Delete ingredient",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Order ID",
+                        "description": "Ingredient ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1521,37 +1534,31 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order retrieved successfully",
+                        "description": "Ingredient deleted successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID format",
+                        "description": "Invalid ingredient ID",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Order not found",
+                        "description": "Ingredient not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ingredient is used by product recipes",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve order",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -1559,14 +1566,11 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
-            }
-        },
-        "/orders/{id}/apply-promotion": {
-            "post": {
-                "description": "Apply a specific promotion to an existing order by its ID (Roles: admin, manager, cashier)",
+            },
+            "patch": {
+                "description": "Update an ingredient. Stock is the new absolute level; the difference goes to the ingredient ledger as change_type (restock when it rises, correction otherwise, or damage) (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1574,31 +1578,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Inventory"
                 ],
-                "summary": "Apply promotion to an order",
+                "summary": "Update ingredient",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Order ID",
+                        "description": "Ingredient ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Promotion details",
+                        "description": "Fields to update",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_orders.ApplyPromotionRequest"
+                            "$ref": "#/definitions/internal_inventory.UpdateIngredientRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Promotion applied successfully",
+                        "description": "Ingredient updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1608,7 +1612,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
+                                            "$ref": "#/definitions/internal_inventory.IngredientResponse"
                                         }
                                     }
                                 }
@@ -1616,19 +1620,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID format or request body",
+                        "description": "Invalid ingredient ID or request body",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Order or Promotion not found",
+                        "description": "Ingredient not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ingredient with this name already exists",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to apply promotion",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -1636,14 +1646,13 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
             }
         },
-        "/orders/{id}/cancel": {
-            "post": {
-                "description": "Cancel an existing order with a reason (Roles: admin, manager, cashier)",
+        "/inventory/ingredients/{id}/stock-history": {
+            "get": {
+                "description": "Get the ingredient ledger: sales and refunds (referencing the order), restocks, corrections and damage, newest first (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1651,55 +1660,64 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Inventory"
                 ],
-                "summary": "Cancel an order",
+                "summary": "Get ingredient stock history",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Order ID",
+                        "description": "Ingredient ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Cancel order details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_orders.CancelOrderRequest"
-                        }
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order cancelled successfully",
+                        "description": "Ingredient stock history retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_inventory.PagedIngredientStockHistoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID format or request body",
+                        "description": "Invalid ingredient ID or query parameters",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Order not found",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Order cannot be cancelled",
+                        "description": "Ingredient not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to cancel order",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -1707,14 +1725,13 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
             }
         },
-        "/orders/{id}/items": {
-            "patch": {
-                "description": "Update, add, or remove items in an existing open order (Roles: admin, manager, cashier)",
+        "/kds/items/{id}/bump": {
+            "post": {
+                "description": "Move an order line one step forward: queued -\u003e cooking -\u003e ready -\u003e served. The order becomes in_progress once a line starts and served once every line is served (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1722,31 +1739,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Kitchen"
                 ],
-                "summary": "Update items in an order",
+                "summary": "Bump a kitchen item",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Order ID",
+                        "description": "Order Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update order items",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_orders.UpdateOrderItemsRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order items updated successfully",
+                        "description": "Item bumped successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1756,7 +1764,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
+                                            "$ref": "#/definitions/internal_kitchen.KitchenItemResponse"
                                         }
                                     }
                                 }
@@ -1764,25 +1772,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID format or request body",
+                        "description": "Invalid order item ID",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Order not found",
+                        "description": "Order item not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Order version conflict",
+                        "description": "Item is already served, its order is cancelled, or another station moved it first",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update order items",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -1795,9 +1803,9 @@ const docTemplate = `{
                 ]
             }
         },
-        "/orders/{id}/pay/manual": {
+        "/kds/items/{id}/priority": {
             "post": {
-                "description": "Process a manual payment (Cash) and finalize an order (Roles: admin, manager, cashier)",
+                "description": "Flag or unflag an order line as priority; tickets holding a priority line are listed first (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1805,31 +1813,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Kitchen"
                 ],
-                "summary": "Confirm manual payment for an order",
+                "summary": "Set kitchen item priority",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Order ID",
+                        "description": "Order Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Manual payment details",
+                        "description": "Priority flag",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_orders.ConfirmManualPaymentRequest"
+                            "$ref": "#/definitions/internal_kitchen.SetItemPriorityRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Payment completed successfully",
+                        "description": "Item priority updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1839,7 +1847,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
+                                            "$ref": "#/definitions/internal_kitchen.KitchenItemResponse"
                                         }
                                     }
                                 }
@@ -1847,25 +1855,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID format or request body",
+                        "description": "Invalid order item ID or request body",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Order not found",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Order might have been paid, cancelled, or version conflict",
+                        "description": "Order item not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to complete payment",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -1878,9 +1880,9 @@ const docTemplate = `{
                 ]
             }
         },
-        "/orders/{id}/pay/midtrans": {
+        "/kds/items/{id}/recall": {
             "post": {
-                "description": "Create a QRIS/Gopay payment session via Midtrans for an existing order (Roles: admin, manager, cashier)",
+                "description": "Move an order line one step back, e.g. a served dish that has to be remade. A served order returns to in_progress (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1888,14 +1890,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Kitchen"
                 ],
-                "summary": "Initiate Midtrans payment for an order",
+                "summary": "Recall a kitchen item",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Order ID",
+                        "description": "Order Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1903,7 +1905,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "QRIS payment initiated successfully",
+                        "description": "Item recalled successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1913,7 +1915,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_orders.MidtransPaymentResponse"
+                                            "$ref": "#/definitions/internal_kitchen.KitchenItemResponse"
                                         }
                                     }
                                 }
@@ -1921,19 +1923,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID format",
+                        "description": "Invalid order item ID",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Order not found",
+                        "description": "Order item not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to process payment",
+                    "409": {
+                        "description": "Item is still queued, its order is cancelled, or another station moved it first",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -1946,9 +1954,9 @@ const docTemplate = `{
                 ]
             }
         },
-        "/orders/{id}/print": {
-            "post": {
-                "description": "Trigger printing of invoice for a specific order (Roles: admin, manager, cashier)",
+        "/kds/stations": {
+            "get": {
+                "description": "Get every kitchen station with the categories routed to it (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1956,34 +1964,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Printer"
-                ],
-                "summary": "Print invoice for an order",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Kitchen"
                 ],
+                "summary": "List kitchen stations",
                 "responses": {
                     "200": {
-                        "description": "Invoice sent to printer",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid order ID",
+                        "description": "Kitchen stations retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_kitchen.KitchenStationResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
-                        "description": "Failed to print invoice",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -1994,11 +2001,9 @@ const docTemplate = `{
                     "manager",
                     "cashier"
                 ]
-            }
-        },
-        "/orders/{id}/print-data": {
-            "get": {
-                "description": "Get raw invoice print data for FE printing (Roles: admin, manager, cashier)",
+            },
+            "post": {
+                "description": "Create a kitchen station (e.g. bar, grill, pastry) and route categories to it. A category already routed elsewhere moves to the new station (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2006,34 +2011,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Printer"
+                    "Kitchen"
                 ],
-                "summary": "Get invoice print data",
+                "summary": "Create kitchen station",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Kitchen station details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_kitchen.CreateKitchenStationRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Invoice print data",
+                    "201": {
+                        "description": "Kitchen station created successfully",
                         "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_kitchen.KitchenStationResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID",
+                        "description": "Invalid request body or unknown category",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Kitchen station with this name already exists",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to generate print data",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -2041,14 +2065,13 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
             }
         },
-        "/orders/{id}/refund": {
-            "post": {
-                "description": "Refund a paid order by ID",
+        "/kds/stations/{id}": {
+            "get": {
+                "description": "Get a single kitchen station with its categories (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2056,30 +2079,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "Kitchen"
                 ],
-                "summary": "Refund a paid order",
+                "summary": "Get kitchen station by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Order ID (UUID)",
+                        "type": "integer",
+                        "description": "Kitchen Station ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Refund Request Body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_orders.RefundOrderRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Kitchen station retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2089,7 +2103,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
+                                            "$ref": "#/definitions/internal_kitchen.KitchenStationResponse"
                                         }
                                     }
                                 }
@@ -2097,13 +2111,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid kitchen station ID",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Kitchen station not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -2114,12 +2128,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/orders/{id}/update-status": {
-            "post": {
-                "description": "Update the status of an existing order (e.g., to in_progress, served) (Roles: admin, manager, cashier)",
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "put": {
+                "description": "Update a kitchen station. When category_ids is sent it replaces the station's categories (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2127,31 +2144,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Kitchen"
                 ],
-                "summary": "Update order operational status",
+                "summary": "Update kitchen station",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Order ID",
+                        "type": "integer",
+                        "description": "Kitchen Station ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Order status details",
+                        "description": "Kitchen station fields to update",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_orders.UpdateOrderStatusRequest"
+                            "$ref": "#/definitions/internal_kitchen.UpdateKitchenStationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order status updated successfully",
+                        "description": "Kitchen station updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2161,7 +2177,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
+                                            "$ref": "#/definitions/internal_kitchen.KitchenStationResponse"
                                         }
                                     }
                                 }
@@ -2169,25 +2185,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID format or request body",
+                        "description": "Invalid ID, request body or unknown category",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Order not found",
+                        "description": "Kitchen station not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Invalid status transition",
+                        "description": "Kitchen station with this name already exists",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update order status",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -2195,14 +2211,11 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
-            }
-        },
-        "/payment-methods": {
-            "get": {
-                "description": "Get a list of all active payment methods (e.g., Cash, QRIS)",
+            },
+            "delete": {
+                "description": "Delete a kitchen station. Lines already routed to it stay on their orders without a station (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2210,29 +2223,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payment Methods"
+                    "Kitchen"
+                ],
+                "summary": "Delete kitchen station",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Kitchen Station ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List payment methods",
                 "responses": {
                     "200": {
-                        "description": "List of payment methods retrieved successfully",
+                        "description": "Kitchen station deleted successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/internal_payment_methods.PaymentMethodResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid kitchen station ID",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Kitchen station not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
@@ -2244,14 +2263,13 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
             }
         },
-        "/products": {
+        "/kds/tickets": {
             "get": {
-                "description": "Get a list of products with filtering by category and search term (Roles: authenticated)",
+                "description": "Get the open tickets for the kitchen display, one per order and station. Priority tickets come first. Filter by station_id; served lines are hidden unless include_served=true (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2259,38 +2277,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Kitchen"
                 ],
-                "summary": "List products",
+                "summary": "List kitchen tickets",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit the number of products returned",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search products by name",
-                        "name": "search",
+                        "description": "Only tickets of this station",
+                        "name": "station_id",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Search products by category ID",
-                        "name": "category_id",
+                        "type": "boolean",
+                        "description": "Include lines that were already served",
+                        "name": "include_served",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Products retrieved successfully",
+                        "description": "Kitchen tickets retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2300,7 +2306,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_products.ListProductsResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_kitchen.KitchenTicketResponse"
+                                            }
                                         }
                                     }
                                 }
@@ -2314,7 +2323,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve products",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -2325,9 +2334,11 @@ const docTemplate = `{
                     "manager",
                     "cashier"
                 ]
-            },
-            "post": {
-                "description": "Create a new product with multiple options (Roles: admin, manager)",
+            }
+        },
+        "/modifier-groups": {
+            "get": {
+                "description": "List the active modifier groups and their selection rules (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2335,23 +2346,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
-                ],
-                "summary": "Create a new product",
-                "parameters": [
-                    {
-                        "description": "Product create request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_products.CreateProductRequest"
-                        }
-                    }
+                    "Modifier Groups"
                 ],
+                "summary": "List modifier groups",
                 "responses": {
-                    "201": {
-                        "description": "Product created successfully",
+                    "200": {
+                        "description": "Modifier groups retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2361,27 +2361,18 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_products.ProductResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_products.ModifierGroupResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Product with same name already exists",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to create product",
+                    "500": {
+                        "description": "Failed to retrieve modifier groups",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -2389,13 +2380,12 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager"
+                    "manager",
+                    "cashier"
                 ]
-            }
-        },
-        "/products/trash": {
-            "get": {
-                "description": "Get a list of deleted products with pagination and filtering (Roles: admin)",
+            },
+            "post": {
+                "description": "Create a group of options with selection rules, e.g. \"Size: pick exactly 1\" (min 1, max 1) or \"Toppings: up to 3\" (max 3). Options of any product join it through modifier_group_id (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2403,38 +2393,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Modifier Groups"
                 ],
-                "summary": "List deleted products",
+                "summary": "Create a modifier group",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit the number of products returned",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search products by name",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Search products by category ID",
-                        "name": "category_id",
-                        "in": "query"
+                        "description": "Modifier group create request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_products.CreateModifierGroupRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Deleted products retrieved successfully",
+                    "201": {
+                        "description": "Modifier group created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2444,7 +2419,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_products.ListProductsResponse"
+                                            "$ref": "#/definitions/internal_products.ModifierGroupResponse"
                                         }
                                     }
                                 }
@@ -2452,75 +2427,33 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to retrieve deleted products",
+                        "description": "Invalid request body or selection rules",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
-                    }
-                },
-                "x-roles": [
-                    "admin"
-                ]
-            }
-        },
-        "/products/trash/restore-bulk": {
-            "post": {
-                "description": "Restore multiple deleted products by their IDs (Roles: admin)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Products"
-                ],
-                "summary": "Bulk restore deleted products",
-                "parameters": [
-                    {
-                        "description": "Bulk restore request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_products.RestoreBulkRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Products restored successfully",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "409": {
+                        "description": "Modifier group with this name already exists",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to restore products",
+                        "description": "Failed to create modifier group",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     }
                 },
                 "x-roles": [
-                    "admin"
+                    "admin",
+                    "manager"
                 ]
             }
         },
-        "/products/trash/{id}": {
+        "/modifier-groups/{id}": {
             "get": {
-                "description": "Retrieve detailed information of a specific deleted product by its ID (Roles: admin)",
+                "description": "Get a modifier group with the options of every product that uses it (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2528,14 +2461,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Modifier Groups"
                 ],
-                "summary": "Get a deleted product",
+                "summary": "Get a modifier group",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Product ID",
+                        "description": "Modifier group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2543,7 +2476,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Deleted product retrieved successfully",
+                        "description": "Modifier group retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2553,7 +2486,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_products.ProductResponse"
+                                            "$ref": "#/definitions/internal_products.ModifierGroupResponse"
                                         }
                                     }
                                 }
@@ -2561,32 +2494,32 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID format",
+                        "description": "Invalid modifier group ID format",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Product not found",
+                        "description": "Modifier group not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve deleted product",
+                        "description": "Failed to retrieve modifier group",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     }
                 },
                 "x-roles": [
-                    "admin"
+                    "admin",
+                    "manager",
+                    "cashier"
                 ]
-            }
-        },
-        "/products/trash/{id}/restore": {
-            "post": {
-                "description": "Restore a specific deleted product by its ID (Roles: admin)",
+            },
+            "delete": {
+                "description": "Remove a modifier group. Its options stay on their products without selection rules (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2594,14 +2527,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Modifier Groups"
                 ],
-                "summary": "Restore a deleted product",
+                "summary": "Delete a modifier group",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Product ID",
+                        "description": "Modifier group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2609,38 +2542,37 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product restored successfully",
+                        "description": "Modifier group deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID format",
+                        "description": "Invalid modifier group ID format",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Product not found",
+                        "description": "Modifier group not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to restore product",
+                        "description": "Failed to delete modifier group",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     }
                 },
                 "x-roles": [
-                    "admin"
+                    "admin",
+                    "manager"
                 ]
-            }
-        },
-        "/products/{id}": {
-            "get": {
-                "description": "Retrieve detailed information of a specific product by its ID (Roles: admin, manager, cashier)",
+            },
+            "patch": {
+                "description": "Update a modifier group's name or selection rules. The rules apply to every product using the group (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2648,22 +2580,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Modifier Groups"
                 ],
-                "summary": "Get a product by ID",
+                "summary": "Update a modifier group",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Product ID",
+                        "description": "Modifier group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Modifier group update request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_products.UpdateModifierGroupRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product retrieved successfully",
+                        "description": "Modifier group updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2673,7 +2614,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_products.ProductResponse"
+                                            "$ref": "#/definitions/internal_products.ModifierGroupResponse"
                                         }
                                     }
                                 }
@@ -2681,19 +2622,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID format",
+                        "description": "Invalid ID format, request body or selection rules",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Product not found",
+                        "description": "Modifier group not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Name already in use or a product has more defaults than the new limit",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve product",
+                        "description": "Failed to update modifier group",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -2701,12 +2648,13 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
-            },
-            "delete": {
-                "description": "Delete a product by its ID (Roles: admin)",
+            }
+        },
+        "/orders": {
+            "get": {
+                "description": "Get a list of orders with filtering by status and user (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2714,51 +2662,87 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Delete a product",
+                "summary": "List orders",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of orders per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "open",
+                                "in_progress",
+                                "served",
+                                "paid",
+                                "cancelled"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Order statuses",
+                        "name": "statuses",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by User ID",
+                        "name": "user_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product deleted successfully",
+                        "description": "Orders retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_orders.PagedOrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID format",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Product not found",
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete product",
+                        "description": "Failed to retrieve orders",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     }
                 },
                 "x-roles": [
-                    "admin"
+                    "admin",
+                    "manager",
+                    "cashier"
                 ]
             },
-            "patch": {
-                "description": "Update details of a specific product by its ID (Roles: admin, manager)",
+            "post": {
+                "description": "Create a new order with multiple items (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2766,31 +2750,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Update a product",
+                "summary": "Create an order",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Product update request",
-                        "name": "body",
+                        "description": "Create order details",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_products.UpdateProductRequest"
+                            "$ref": "#/definitions/internal_orders.CreateOrderRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Product updated successfully",
+                    "201": {
+                        "description": "Order created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2800,7 +2776,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_products.ProductResponse"
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -2808,19 +2784,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid ID format or request body",
+                        "description": "Invalid request body, variant or options; data.errors lists each ModifierViolation",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Product not found",
+                    "409": {
+                        "description": "Not enough ingredients",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update product",
+                        "description": "Failed to create order",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -2828,43 +2804,24 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager"
+                    "manager",
+                    "cashier"
                 ]
             }
         },
-        "/products/{id}/image": {
-            "post": {
-                "description": "Upload an image for a product by ID (Roles: admin, manager)",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/orders/calling-board": {
+            "get": {
+                "description": "Get today's queue numbers split into orders being prepared and orders ready for pickup. Live updates are published on the calling_board websocket topic (Roles: admin, manager, cashier)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
-                ],
-                "summary": "Upload an image for a product",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Image file",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "Orders"
                 ],
+                "summary": "Get calling board",
                 "responses": {
                     "200": {
-                        "description": "Product image uploaded successfully",
+                        "description": "Calling board retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2874,41 +2831,76 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_products.ProductResponse"
+                                            "$ref": "#/definitions/internal_orders.CallingBoardResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid ID format or image file is missing",
+                    "500": {
+                        "description": "Failed to retrieve calling board",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/orders/webhook/midtrans": {
+            "post": {
+                "description": "Webhook for Midtrans to notify order payment status updates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Midtrans Payment Notification Callback",
+                "parameters": [
+                    {
+                        "description": "Midtrans Notification Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_pkg_payment.MidtransNotificationPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification received successfully",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
                     },
-                    "404": {
-                        "description": "Product not found",
+                    "400": {
+                        "description": "Invalid notification format",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to upload image",
+                        "description": "Failed to handle notification",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     }
-                },
-                "x-roles": [
-                    "admin",
-                    "manager"
-                ]
+                }
             }
         },
-        "/products/{id}/stock-history": {
+        "/orders/{id}": {
             "get": {
-                "description": "Get stock history for a specific product by its ID with pagination (Roles: admin, manager)",
+                "description": "Retrieve detailed information of a specific order by its ID (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2916,34 +2908,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Get stock history for a product",
+                "summary": "Get an order by ID",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Product ID",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Stock history retrieved successfully",
+                        "description": "Order retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2953,7 +2933,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_products.PagedStockHistoryResponse"
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -2961,19 +2941,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid ID format or query parameters",
+                        "description": "Invalid order ID format",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Product not found",
+                        "description": "Order not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve stock history",
+                        "description": "Failed to retrieve order",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -2981,13 +2961,14 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager"
+                    "manager",
+                    "cashier"
                 ]
             }
         },
-        "/products/{product_id}/options": {
+        "/orders/{id}/apply-promotion": {
             "post": {
-                "description": "Create a new product option for a parent product (Roles: admin, manager)",
+                "description": "Apply a promotion to an open order by its ID or by one of its codes; a promotion that has codes can only be applied with a code. The new promotion takes precedence over the order's other promotions: an exclusive one replaces them, a stackable one is combined with the stackable ones, and auto-apply promotions are picked again around it. Usage limits are checked now and again when the order is settled, which records the redemptions (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2995,31 +2976,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Create a product option",
+                "summary": "Apply promotion to an order",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Product ID",
-                        "name": "product_id",
+                        "description": "Order ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Product option create request",
-                        "name": "body",
+                        "description": "Promotion details",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_products.CreateProductOptionRequestStandalone"
+                            "$ref": "#/definitions/internal_orders.ApplyPromotionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Product option created successfully",
+                    "200": {
+                        "description": "Promotion applied successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -3029,7 +3010,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_products.ProductOptionResponse"
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -3037,19 +3018,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID format or request body",
+                        "description": "Invalid order ID format or request body",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Parent product not found",
+                        "description": "Order, promotion or code not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Promotion not applicable, its redemption limit reached, or the order can no longer change",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to create product option",
+                        "description": "Failed to apply promotion",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3057,13 +3044,14 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager"
+                    "manager",
+                    "cashier"
                 ]
             }
         },
-        "/products/{product_id}/options/{option_id}": {
-            "patch": {
-                "description": "Update details of a specific product option by its ID (Roles: admin, manager)",
+        "/orders/{id}/cancel": {
+            "post": {
+                "description": "Cancel an existing order with a reason (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3071,69 +3059,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Update a product option",
+                "summary": "Cancel an order",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Product ID",
-                        "name": "product_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Option ID",
-                        "name": "option_id",
+                        "description": "Order ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Product option update request",
-                        "name": "body",
+                        "description": "Cancel order details",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_products.UpdateProductOptionRequest"
+                            "$ref": "#/definitions/internal_orders.CancelOrderRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product option updated successfully",
+                        "description": "Order cancelled successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/internal_products.ProductOptionResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID format or request body",
+                        "description": "Invalid order ID format or request body",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Product or option not found",
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order cannot be cancelled",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update product option",
+                        "description": "Failed to cancel order",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3141,51 +3115,46 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager"
+                    "manager",
+                    "cashier"
                 ]
             }
         },
-        "/products/{product_id}/options/{option_id}/image": {
-            "post": {
-                "description": "Upload image for a specific product option (Roles: admin, manager)",
+        "/orders/{id}/items": {
+            "patch": {
+                "description": "Update, add, or remove items in an existing open order (Roles: admin, manager, cashier)",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Orders"
                 ],
-                "summary": "Upload product option image",
+                "summary": "Update items in an order",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Product ID",
-                        "name": "product_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Option ID",
-                        "name": "option_id",
+                        "description": "Order ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Product option image",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
+                        "description": "Update order items",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_orders.UpdateOrderItemsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product option image uploaded successfully",
+                        "description": "Order items updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -3195,7 +3164,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_products.ProductOptionResponse"
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -3203,19 +3172,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid ID format or missing file",
+                        "description": "Invalid order ID format, request body, variant or options; data.errors lists each ModifierViolation",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Product or option not found",
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order can no longer change, version conflict or not enough ingredients",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to upload product option image",
+                        "description": "Failed to update order items",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3223,13 +3198,14 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager"
+                    "manager",
+                    "cashier"
                 ]
             }
         },
-        "/promotions": {
-            "get": {
-                "description": "Get a list of promotions with pagination and optional trash filter (Roles: admin, manager, cashier)",
+        "/orders/{id}/loyalty/redeem": {
+            "post": {
+                "description": "Spend the customer's loyalty points as a discount on an open order before it is paid, each point worth the configured point value. The discount is taken after the order's promotions and the order is repriced. Points can also be spent as a tender through a payment method of kind loyalty_points (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3237,32 +3213,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Promotions"
+                    "Orders"
                 ],
-                "summary": "List all promotions",
+                "summary": "Redeem loyalty points as a discount",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "boolean",
-                        "description": "Show trash items",
-                        "name": "trash",
-                        "in": "query"
+                        "description": "Points to redeem",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_orders.RedeemPointsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Promotions retrieved successfully",
+                        "description": "Points redeemed successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -3272,7 +3247,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_promotions.PagedPromotionResponse"
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -3280,13 +3255,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid order ID format or request body",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not open or already being paid, points cannot be redeemed on it, or the customer does not have enough points",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to redeem points",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3298,32 +3285,28 @@ const docTemplate = `{
                     "cashier"
                 ]
             },
-            "post": {
-                "description": "Create a new promotion with rules and targets (Roles: admin, manager)",
-                "consumes": [
-                    "application/json"
-                ],
+            "delete": {
+                "description": "Give the points redeemed as a discount on an open, unpaid order back to the customer and reprice the order without them (Roles: admin, manager, cashier)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Promotions"
+                    "Orders"
                 ],
-                "summary": "Create a new promotion",
+                "summary": "Cancel a loyalty points redemption",
                 "parameters": [
                     {
-                        "description": "Promotion details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_promotions.CreatePromotionRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Promotion created successfully",
+                    "200": {
+                        "description": "Points redemption cancelled successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -3333,7 +3316,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_promotions.PromotionResponse"
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -3341,13 +3324,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation failed",
+                        "description": "Invalid order ID format",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found or no points redeemed on it",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not open or already being paid",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to cancel points redemption",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3355,13 +3350,14 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager"
+                    "manager",
+                    "cashier"
                 ]
             }
         },
-        "/promotions/{id}": {
-            "get": {
-                "description": "Retrieve details of a specific promotion by its ID (Roles: admin, manager, cashier)",
+        "/orders/{id}/merge": {
+            "post": {
+                "description": "Move all items (with options) of the source orders into the target order, re-check its promotion and cancel the sources. Only open orders without payments or a pending QRIS charge can be merged, target included (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3369,22 +3365,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Promotions"
+                    "Orders"
                 ],
-                "summary": "Get a promotion by ID",
+                "summary": "Merge orders",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Promotion ID",
+                        "description": "Target Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Orders to merge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_orders.MergeOrdersRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Promotion retrieved successfully",
+                        "description": "Orders merged successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -3394,7 +3399,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_promotions.PromotionResponse"
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -3402,19 +3407,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid promotion ID format",
+                        "description": "Invalid order ID format, request body or merge",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Promotion not found",
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "An order is not open, has payments or a pending QRIS charge, or version conflict",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to merge orders",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3425,9 +3436,11 @@ const docTemplate = `{
                     "manager",
                     "cashier"
                 ]
-            },
-            "put": {
-                "description": "Update details of an existing promotion by its ID (Roles: admin, manager)",
+            }
+        },
+        "/orders/{id}/pay/manual": {
+            "post": {
+                "description": "Process a manual (non-gateway) payment and finalize an order. Change is only given by methods that allow it; methods requiring a reference need reference_number. A method the applied promotion does not accept is rejected unless remove_ineligible_promotion is set, which drops the promotion and reprices the order (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3435,31 +3448,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Promotions"
+                    "Orders"
                 ],
-                "summary": "Update a promotion",
+                "summary": "Confirm manual payment for an order",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Promotion ID",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Promotion details",
+                        "description": "Manual payment details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_promotions.UpdatePromotionRequest"
+                            "$ref": "#/definitions/internal_orders.ConfirmManualPaymentRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Promotion updated successfully",
+                        "description": "Payment completed successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -3469,7 +3482,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_promotions.PromotionResponse"
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -3477,19 +3490,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid project ID format or request body",
+                        "description": "Invalid order ID format or request body",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Promotion not found",
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order might have been paid, cancelled, version conflict, payment method not allowed by the promotion, promotion limit reached, or loyalty points cannot be redeemed",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to complete payment",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3497,11 +3516,14 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager"
+                    "manager",
+                    "cashier"
                 ]
-            },
-            "delete": {
-                "description": "Soft delete a promotion by its ID (Roles: admin, manager)",
+            }
+        },
+        "/orders/{id}/pay/midtrans": {
+            "post": {
+                "description": "Create a QRIS/Gopay payment session via Midtrans for an existing order (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3509,34 +3531,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Promotions"
+                    "Orders"
                 ],
-                "summary": "Delete (deactivate) a promotion",
+                "summary": "Initiate Midtrans payment for an order",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Promotion ID",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Promotion deleted successfully",
+                    "400": {
+                        "description": "Invalid order ID format",
                         "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid promotion ID format",
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order already paid, the applied promotion does not accept the payment gateway, or its limit is reached",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to process payment",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3544,13 +3572,14 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager"
+                    "manager",
+                    "cashier"
                 ]
             }
         },
-        "/promotions/{id}/restore": {
+        "/orders/{id}/payments": {
             "post": {
-                "description": "Restore a soft-deleted promotion by its ID (Roles: admin, manager)",
+                "description": "Record one tender of a split or multi-tender payment. The order is settled once its tenders cover net_total; only methods that allow change may exceed the outstanding balance. A method the applied promotion does not accept is rejected unless remove_ineligible_promotion is set (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3558,34 +3587,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Promotions"
+                    "Orders"
                 ],
-                "summary": "Restore a deleted promotion",
+                "summary": "Add a tender to an order (split payment)",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Promotion ID",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Tender details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_orders.AddOrderPaymentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Promotion restored successfully",
+                        "description": "Payment recorded successfully",
                         "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid promotion ID format",
+                        "description": "Invalid order ID format, request body or tender amount",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order already paid, cancelled, version conflict, payment method not allowed by the promotion, promotion limit reached, or loyalty points cannot be redeemed",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to record payment",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3593,13 +3655,14 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager"
-                ]
+                    "manager",
+                    "cashier"
+                ]
             }
         },
-        "/reports/cancellations": {
-            "get": {
-                "description": "Get statistics on order cancellations grouped by reason (Roles: admin, manager, cashier)",
+        "/orders/{id}/print": {
+            "post": {
+                "description": "Trigger printing of invoice for a specific order (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3607,55 +3670,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Printer"
                 ],
-                "summary": "Get cancellation reports",
+                "summary": "Print invoice for an order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Cancellation reports retrieved successfully",
+                        "description": "Invoice sent to printer",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/internal_report.CancellationReportResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid order ID",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to print invoice",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3668,9 +3710,9 @@ const docTemplate = `{
                 ]
             }
         },
-        "/reports/cashier-performance": {
+        "/orders/{id}/print-data": {
             "get": {
-                "description": "Get order counts and sales totals handled by each cashier (Roles: admin, manager, cashier)",
+                "description": "Get raw invoice print data for FE printing (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3678,55 +3720,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Printer"
                 ],
-                "summary": "Get cashier performance",
+                "summary": "Get invoice print data",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Cashier performance data retrieved successfully",
+                        "description": "Invoice print data",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/internal_report.CashierPerformanceResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid order ID",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to generate print data",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3739,9 +3760,9 @@ const docTemplate = `{
                 ]
             }
         },
-        "/reports/dashboard-summary": {
-            "get": {
-                "description": "Get high-level summary metrics (totals) for the dashboard (Roles: admin, manager, cashier)",
+        "/orders/{id}/promotions/auto-apply": {
+            "post": {
+                "description": "Reprice an open order with the promotion engine: promotions the cashier applied are kept while they apply and the eligible auto-apply promotions are combined around them following their stacking and priority. Each promotion's part of the discount is stored on the order (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3749,28 +3770,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Orders"
                 ],
-                "summary": "Get dashboard summary",
+                "summary": "Auto-apply promotions to an order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Dashboard summary retrieved successfully",
+                        "description": "Promotions applied successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -3780,7 +3795,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_report.DashboardSummaryResponse"
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -3788,13 +3803,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid order ID format or order cannot be modified",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order was modified concurrently",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to apply promotions",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3807,9 +3834,9 @@ const docTemplate = `{
                 ]
             }
         },
-        "/reports/low-stock": {
+        "/orders/{id}/promotions/evaluate": {
             "get": {
-                "description": "Get products with stock below threshold",
+                "description": "Dry run of the promotion engine on an open order: every promotion on the order or currently active, with the discount each would give, why an ineligible one does not apply, and the combination the stacking rules would pick. Nothing is saved (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3817,26 +3844,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Orders"
                 ],
-                "summary": "Get low stock products",
+                "summary": "Evaluate promotions for an order",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Threshold (default: 5)",
-                        "name": "threshold",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Export format (csv)",
-                        "name": "export",
-                        "in": "query"
+                        "format": "uuid",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Low stock products retrieved successfully",
+                        "description": "Promotions evaluated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -3846,18 +3869,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/internal_report.LowStockProductResponse"
-                                            }
+                                            "$ref": "#/definitions/internal_orders.PromotionEvaluationResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Invalid order ID format or order is not open",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to evaluate promotions",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -3865,13 +3897,14 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager"
+                    "manager",
+                    "cashier"
                 ]
             }
         },
-        "/reports/payment-methods": {
-            "get": {
-                "description": "Get usage counts and totals for each payment method (Roles: admin, manager, cashier)",
+        "/orders/{id}/refund": {
+            "post": {
+                "description": "Refund a paid order by ID. Without ` + "`" + `items` + "`" + ` every remaining unit is refunded and the order is closed; with ` + "`" + `items` + "`" + ` only the selected lines and quantities are refunded, priced pro-rata including discount and tax. Set ` + "`" + `restock` + "`" + ` to false to skip returning goods to stock",
                 "consumes": [
                     "application/json"
                 ],
@@ -3879,28 +3912,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "orders"
                 ],
-                "summary": "Get payment method performance",
+                "summary": "Refund a paid order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
+                        "description": "Order ID (UUID)",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Refund Request Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_orders.RefundOrderRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Payment method performance data retrieved successfully",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -3910,10 +3945,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/internal_report.PaymentMethodPerformanceResponse"
-                                            }
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -3921,28 +3953,35 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     }
-                },
-                "x-roles": [
-                    "admin",
-                    "manager",
-                    "cashier"
-                ]
+                }
             }
         },
-        "/reports/products": {
-            "get": {
-                "description": "Get sales performance metrics for each product (Roles: admin, manager, cashier)",
+        "/orders/{id}/reopen": {
+            "post": {
+                "description": "Move an unpaid in_progress or served order back to open so its items can be changed. The reason is kept in the status history (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3950,28 +3989,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Orders"
                 ],
-                "summary": "Get product performance",
+                "summary": "Reopen an order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Reopen details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_orders.ReopenOrderRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product performance data retrieved successfully",
+                        "description": "Order reopened successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -3981,10 +4023,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/internal_report.ProductPerformanceResponse"
-                                            }
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -3992,13 +4031,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid order ID format or request body",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition or version conflict",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to reopen order",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4006,14 +4057,13 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
             }
         },
-        "/reports/profit-products": {
-            "get": {
-                "description": "Get profitability metrics for each product sold (Roles: admin, manager, cashier)",
+        "/orders/{id}/scan": {
+            "post": {
+                "description": "Look up a scanned barcode or typed SKU and add the product or variant to an open order: one more on its existing line, or a new line with the default options (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4021,28 +4071,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Orders"
                 ],
-                "summary": "Get product profit reports",
+                "summary": "Scan an item into an order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Scanned code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_orders.ScanOrderItemRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product profit reports retrieved successfully",
+                        "description": "Item added successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4052,10 +4105,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/internal_report.ProductProfitResponse"
-                                            }
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -4063,13 +4113,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid order ID format, request body, variant or options; data.errors lists each ModifierViolation",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found or no product has this code",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not open, version conflict or not enough stock or ingredients",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to add scanned item",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4082,9 +4144,9 @@ const docTemplate = `{
                 ]
             }
         },
-        "/reports/profit-summary": {
-            "get": {
-                "description": "Get gross profit analytics grouped by date (Roles: admin, manager, cashier)",
+        "/orders/{id}/split": {
+            "post": {
+                "description": "Move selected items or quantities into new child orders (one per part), or split evenly by guest count with ` + "`" + `ways` + "`" + `. Items are not divided, so an even split needs at least as many item units as ways; split payments on one bill instead. Each bill carries its share of the discount together with the promotions behind it. Only open, unpaid orders can be split (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4092,28 +4154,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Orders"
                 ],
-                "summary": "Get profit summary",
+                "summary": "Split an order (split bill)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Split details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_orders.SplitOrderRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profit summary retrieved successfully",
+                        "description": "Order split successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4123,10 +4188,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/internal_report.ProfitSummaryResponse"
-                                            }
+                                            "$ref": "#/definitions/internal_orders.SplitOrderResponse"
                                         }
                                     }
                                 }
@@ -4134,13 +4196,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid order ID format, request body or split",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not open, already paid, or version conflict",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to split order",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4153,9 +4227,9 @@ const docTemplate = `{
                 ]
             }
         },
-        "/reports/promotions": {
-            "get": {
-                "description": "Get metrics of promotions usage",
+        "/orders/{id}/update-status": {
+            "post": {
+                "description": "Move an order along its lifecycle: open -\u003e in_progress -\u003e served -\u003e paid. Marking an order paid requires its tenders to cover the net total; reopening and cancelling have their own endpoints (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4163,34 +4237,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Orders"
                 ],
-                "summary": "Get promotion performance",
+                "summary": "Update order operational status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Export format (csv)",
-                        "name": "export",
-                        "in": "query"
+                        "description": "Order status details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_orders.UpdateOrderStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Promotion performance retrieved successfully",
+                        "description": "Order status updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4200,10 +4271,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/internal_report.PromotionPerformanceResponse"
-                                            }
+                                            "$ref": "#/definitions/internal_orders.OrderDetailResponse"
                                         }
                                     }
                                 }
@@ -4211,13 +4279,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid order ID format or request body",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to update order status",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4225,13 +4305,14 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager"
+                    "manager",
+                    "cashier"
                 ]
             }
         },
-        "/reports/sales": {
+        "/payment-methods": {
             "get": {
-                "description": "Get aggregated sales data grouped by date within a specified range (Roles: admin, manager, cashier)",
+                "description": "Get a list of all active payment methods (e.g., Cash, QRIS). Set include_inactive=true to also return deactivated methods.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4239,28 +4320,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Payment Methods"
                 ],
-                "summary": "Get sales reports",
+                "summary": "List payment methods",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Include deactivated payment methods",
+                        "name": "include_inactive",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sales reports retrieved successfully",
+                        "description": "List of payment methods retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4272,7 +4345,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/internal_report.SalesReport"
+                                                "$ref": "#/definitions/internal_payment_methods.PaymentMethodResponse"
                                             }
                                         }
                                     }
@@ -4280,14 +4353,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid query parameters",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
-                        }
-                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4298,11 +4365,9 @@ const docTemplate = `{
                     "manager",
                     "cashier"
                 ]
-            }
-        },
-        "/reports/shift-summary": {
-            "get": {
-                "description": "Get historical shifts and their cash differences",
+            },
+            "post": {
+                "description": "Create a new payment method with its kind and behaviour flags (Roles: admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4310,34 +4375,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Payment Methods"
                 ],
-                "summary": "Get shift summary records",
+                "summary": "Create payment method",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start Date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End Date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Export format (csv)",
-                        "name": "export",
-                        "in": "query"
+                        "description": "Payment method details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_payment_methods.CreatePaymentMethodRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Shift summary retrieved successfully",
+                    "201": {
+                        "description": "Payment method created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4347,10 +4401,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/internal_report.ShiftSummaryResponse"
-                                            }
+                                            "$ref": "#/definitions/internal_payment_methods.PaymentMethodResponse"
                                         }
                                     }
                                 }
@@ -4358,27 +4409,32 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Payment method with this name already exists",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     }
                 },
                 "x-roles": [
-                    "admin",
-                    "manager"
+                    "admin"
                 ]
             }
         },
-        "/settings/branding": {
-            "get": {
-                "description": "Retrieve branding settings (app name, logo, footer text, theme colors) for the application (Roles: authenticated)",
+        "/payment-methods/reorder": {
+            "put": {
+                "description": "Set the display order of payment methods; IDs are ordered first to last (Roles: admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4386,67 +4442,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Settings"
+                    "Payment Methods"
                 ],
-                "summary": "Get branding settings",
-                "responses": {
-                    "200": {
-                        "description": "Branding settings fetched successfully",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/internal_settings.BrandingSettingsResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
-                        }
-                    }
-                },
-                "x-roles": [
-                    "admin",
-                    "manager",
-                    "cashier"
-                ]
-            },
-            "put": {
-                "description": "Update application branding settings (Roles: admin)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Settings"
-                ],
-                "summary": "Update branding settings",
+                "summary": "Reorder payment methods",
                 "parameters": [
                     {
-                        "description": "Branding update request",
+                        "description": "Payment method IDs in display order",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_settings.UpdateBrandingRequest"
+                            "$ref": "#/definitions/internal_payment_methods.ReorderPaymentMethodsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Branding settings updated successfully",
+                        "description": "Payment methods reordered successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4456,7 +4468,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_settings.BrandingSettingsResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_payment_methods.PaymentMethodResponse"
+                                            }
                                         }
                                     }
                                 }
@@ -4464,13 +4479,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation failure",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4481,31 +4496,31 @@ const docTemplate = `{
                 ]
             }
         },
-        "/settings/branding/logo": {
-            "post": {
-                "description": "Upload and update the application logo image (Roles: admin)",
+        "/payment-methods/{id}": {
+            "get": {
+                "description": "Get a single payment method including its kind and flags (Roles: admin, manager, cashier)",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Settings"
+                    "Payment Methods"
                 ],
-                "summary": "Update app logo",
+                "summary": "Get payment method by ID",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Logo image file",
-                        "name": "logo",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "Payment Method ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Logo updated successfully",
+                        "description": "Payment method retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4515,10 +4530,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "string"
-                                            }
+                                            "$ref": "#/definitions/internal_payment_methods.PaymentMethodResponse"
                                         }
                                     }
                                 }
@@ -4526,57 +4538,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Logo file is required or file size exceeds limit",
+                        "description": "Invalid payment method ID",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Payment method not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
-                    }
-                },
-                "x-roles": [
-                    "admin"
-                ]
-            }
-        },
-        "/settings/printer": {
-            "get": {
-                "description": "Retrieve printer settings like connection string and paper width (Roles: authenticated)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Settings"
-                ],
-                "summary": "Get printer settings",
-                "responses": {
-                    "200": {
-                        "description": "Printer settings fetched successfully",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/internal_settings.PrinterSettingsResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4589,7 +4563,7 @@ const docTemplate = `{
                 ]
             },
             "put": {
-                "description": "Update global printer configuration (Roles: admin)",
+                "description": "Rename, change kind or flags, and activate or deactivate a payment method (Roles: admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4597,23 +4571,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Settings"
+                    "Payment Methods"
                 ],
-                "summary": "Update printer settings",
+                "summary": "Update payment method",
                 "parameters": [
                     {
-                        "description": "Printer settings update request",
+                        "type": "integer",
+                        "description": "Payment Method ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_settings.UpdatePrinterSettingsRequest"
+                            "$ref": "#/definitions/internal_payment_methods.UpdatePaymentMethodRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Printer settings updated successfully",
+                        "description": "Payment method updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4623,7 +4604,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_settings.PrinterSettingsResponse"
+                                            "$ref": "#/definitions/internal_payment_methods.PaymentMethodResponse"
                                         }
                                     }
                                 }
@@ -4631,45 +4612,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation failure",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Payment method not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
-                    }
-                },
-                "x-roles": [
-                    "admin"
-                ]
-            }
-        },
-        "/settings/printer/discover": {
-            "get": {
-                "description": "Scan local network for thermal printers on port 9100 (Roles: admin)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Printer"
-                ],
-                "summary": "Discover network printers",
-                "responses": {
-                    "200": {
-                        "description": "List of discovered printers",
+                    },
+                    "409": {
+                        "description": "Payment method with this name already exists",
                         "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to discover printers",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4678,11 +4639,9 @@ const docTemplate = `{
                 "x-roles": [
                     "admin"
                 ]
-            }
-        },
-        "/settings/printer/test": {
-            "post": {
-                "description": "Send a test print command to the configured printer (Roles: admin)",
+            },
+            "delete": {
+                "description": "Delete a payment method that has never been used; used methods should be deactivated instead (Roles: admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4690,18 +4649,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Printer"
+                    "Payment Methods"
+                ],
+                "summary": "Delete payment method",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Method ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Test printer connection",
                 "responses": {
                     "200": {
-                        "description": "Test print command sent associated with configured printer",
+                        "description": "Payment method deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Invalid payment method ID",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment method not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Payment method is in use",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to send test print",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4712,9 +4698,9 @@ const docTemplate = `{
                 ]
             }
         },
-        "/shifts/cash-transaction": {
-            "post": {
-                "description": "Record a manual cash entry or exit within the active shift (Roles: admin, manager, cashier)",
+        "/products": {
+            "get": {
+                "description": "Get a list of products with filtering by category and search term (Roles: authenticated)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4722,23 +4708,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shifts"
+                    "Products"
                 ],
-                "summary": "Create a cash transaction (Drop/Expense/In)",
+                "summary": "List products",
                 "parameters": [
                     {
-                        "description": "Cash Transaction Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_shift.CashTransactionRequest"
-                        }
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of products returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search products by name, or by exact SKU or barcode",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Search products by category ID",
+                        "name": "category_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Cash transaction created successfully",
+                    "200": {
+                        "description": "Products retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4748,7 +4749,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_shift.CashTransactionResponse"
+                                            "$ref": "#/definitions/internal_products.ListProductsResponse"
                                         }
                                     }
                                 }
@@ -4756,19 +4757,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation failure",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "No open shift found",
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to retrieve products",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4779,11 +4774,9 @@ const docTemplate = `{
                     "manager",
                     "cashier"
                 ]
-            }
-        },
-        "/shifts/current": {
-            "get": {
-                "description": "Check for and retrieve the details of an active shift session for the authenticated user (Roles: admin, manager, cashier)",
+            },
+            "post": {
+                "description": "Create a new product with multiple options, an optional SKU and barcodes (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4791,12 +4784,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shifts"
+                    "Products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product create request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_products.CreateProductRequest"
+                        }
+                    }
                 ],
-                "summary": "Get current open shift",
                 "responses": {
-                    "200": {
-                        "description": "Open shift retrieved successfully",
+                    "201": {
+                        "description": "Product created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4806,21 +4810,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_shift.ShiftResponse"
+                                            "$ref": "#/definitions/internal_products.ProductResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "404": {
-                        "description": "No open shift found",
+                    "400": {
+                        "description": "Invalid request body, modifier group or barcode",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Too many default options, or SKU or barcode already in use",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to create product",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4828,14 +4838,13 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
             }
         },
-        "/shifts/end": {
+        "/products/barcodes/generate": {
             "post": {
-                "description": "Close the active shift session for the authenticated user (Roles: admin, manager, cashier)",
+                "description": "Give every product without variants, and every variant, that has no barcode yet a generated in-store EAN-13 code (prefix 20) (Roles: admin, manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4843,23 +4852,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shifts"
-                ],
-                "summary": "End current shift",
-                "parameters": [
-                    {
-                        "description": "End Shift Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_shift.EndShiftRequest"
-                        }
-                    }
+                    "Products"
                 ],
+                "summary": "Generate missing barcodes",
                 "responses": {
                     "200": {
-                        "description": "Shift ended successfully",
+                        "description": "Barcodes generated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4869,27 +4867,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_shift.ShiftResponse"
+                                            "$ref": "#/definitions/internal_products.GenerateBarcodesResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body or validation failure",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "No open shift found",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
-                        }
-                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to generate barcodes",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4897,68 +4883,48 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
             }
         },
-        "/shifts/start": {
-            "post": {
-                "description": "Create a new shift session for the authenticated user (Roles: admin, manager, cashier)",
-                "consumes": [
-                    "application/json"
-                ],
+        "/products/export": {
+            "get": {
+                "description": "Download every active product in the format the import reads, so it can be edited and imported again (Roles: admin, manager)",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Shifts"
+                    "Products"
                 ],
-                "summary": "Start a new shift",
+                "summary": "Export products to CSV or XLSX",
                 "parameters": [
                     {
-                        "description": "Start Shift Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_shift.StartShiftRequest"
-                        }
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Shift started successfully",
+                    "200": {
+                        "description": "Catalog file",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/internal_shift.ShiftResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation failure",
-                        "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "User already has an open shift",
+                        "description": "Unknown format",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to export products",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -4966,97 +4932,41 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
             }
         },
-        "/users": {
-            "get": {
-                "description": "Retrieve a list of users with pagination, filtering, and sorting (Roles: admin, manager, cashier)",
+        "/products/import": {
+            "post": {
+                "description": "Create and update products from a CSV or XLSX file with the columns id, sku, name, categories, price, cost_price, stock, options and image_url; only name is required. Rows match products by id, else by SKU, and empty cells keep the current value. Categories are names separated by \"|\", options \"Name:price\" separated by \"|\"; listed options are added or repriced, others are kept. By default the import is a dry run that returns the diff per row; send dry_run=false to apply it, which only happens when no row has errors. Stock changes are written to the stock history and images are downloaded from image_url (Roles: admin, manager)",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Products"
                 ],
-                "summary": "Get all users",
+                "summary": "Import products from CSV or XLSX",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number (default 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page (default 10)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by username or email",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "admin",
-                            "cashier",
-                            "manager"
-                        ],
-                        "type": "string",
-                        "description": "Filter by User Role",
-                        "name": "role",
-                        "in": "query"
+                        "type": "file",
+                        "description": "CSV or XLSX file, at most 5MB and 2000 products",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     },
                     {
                         "type": "boolean",
-                        "description": "Filter by Active Status",
-                        "name": "is_active",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "active",
-                            "deleted",
-                            "all"
-                        ],
-                        "type": "string",
-                        "description": "Filter by Account Status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "created_at",
-                            "username"
-                        ],
-                        "type": "string",
-                        "description": "Sort by column",
-                        "name": "sortBy",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "Sort direction",
-                        "name": "sortOrder",
+                        "description": "Only return the diff (default true)",
+                        "name": "dry_run",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Users retrieved successfully",
+                        "description": "Import checked or applied",
                         "schema": {
                             "allOf": [
                                 {
@@ -5066,7 +4976,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_user.UsersResponse"
+                                            "$ref": "#/definitions/internal_products.ImportProductsResponse"
                                         }
                                     }
                                 }
@@ -5074,13 +4984,31 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "File is missing or unreadable",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
+                    "422": {
+                        "description": "Some rows have errors; nothing was changed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_products.ImportProductsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to import products",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -5088,12 +5016,13 @@ const docTemplate = `{
                 },
                 "x-roles": [
                     "admin",
-                    "manager",
-                    "cashier"
+                    "manager"
                 ]
-            },
-            "post": {
-                "description": "Create a new user account (Roles: admin)",
+            }
+        },
+        "/products/lookup": {
+            "get": {
+                "description": "Find the product, or variant, a scanned barcode or typed SKU belongs to. Barcodes match exactly, SKUs ignore case (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -5101,23 +5030,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Products"
                 ],
-                "summary": "Create user",
+                "summary": "Look up a barcode or SKU",
                 "parameters": [
                     {
-                        "description": "New user details",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_user.CreateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Barcode or SKU",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User created successfully",
+                    "200": {
+                        "description": "Product found",
                         "schema": {
                             "allOf": [
                                 {
@@ -5127,7 +5054,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_user.ProfileResponse"
+                                            "$ref": "#/definitions/internal_products.ProductLookupResponse"
                                         }
                                     }
                                 }
@@ -5135,32 +5062,34 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation failed",
+                        "description": "Code is missing",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "User, username, or email already exists",
+                    "404": {
+                        "description": "No product or variant has this code",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to look up code",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     }
                 },
                 "x-roles": [
-                    "admin"
+                    "admin",
+                    "manager",
+                    "cashier"
                 ]
             }
         },
-        "/users/{id}": {
+        "/products/trash": {
             "get": {
-                "description": "Retrieve detailed profile for a specific user by their ID (Roles: admin, manager)",
+                "description": "Get a list of deleted products with pagination and filtering (Roles: admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -5168,22 +5097,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Products"
                 ],
-                "summary": "Get user by ID",
+                "summary": "List deleted products",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of products returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Search products by name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Search products by category ID",
+                        "name": "category_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User retrieved successfully",
+                        "description": "Deleted products retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -5193,7 +5138,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_user.ProfileResponse"
+                                            "$ref": "#/definitions/internal_products.ListProductsResponse"
                                         }
                                     }
                                 }
@@ -5201,31 +5146,75 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID format",
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "500": {
+                        "description": "Failed to retrieve deleted products",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        },
+        "/products/trash/restore-bulk": {
+            "post": {
+                "description": "Restore multiple deleted products by their IDs (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Bulk restore deleted products",
+                "parameters": [
+                    {
+                        "description": "Bulk restore request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_products.RestoreBulkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products restored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to restore products",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     }
                 },
                 "x-roles": [
-                    "admin",
-                    "manager"
+                    "admin"
                 ]
-            },
-            "put": {
-                "description": "Update details of an existing user account (Roles: admin)",
+            }
+        },
+        "/products/trash/{id}": {
+            "get": {
+                "description": "Retrieve detailed information of a specific deleted product by its ID (Roles: admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -5233,31 +5222,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Products"
                 ],
-                "summary": "Update user",
+                "summary": "Get a deleted product",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "User ID",
+                        "description": "Product ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "User update details",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_user.UpdateUserRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User updated successfully",
+                        "description": "Deleted product retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -5267,7 +5247,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/internal_user.ProfileResponse"
+                                            "$ref": "#/definitions/internal_products.ProductResponse"
                                         }
                                     }
                                 }
@@ -5275,31 +5255,73 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation failed",
+                        "description": "Invalid product ID format",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - higher role assignment attempt",
+                    "404": {
+                        "description": "Product not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "500": {
+                        "description": "Failed to retrieve deleted product",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        },
+        "/products/trash/{id}/restore": {
+            "post": {
+                "description": "Restore a specific deleted product by its ID (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Restore a deleted product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product restored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID format",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Username or email already exists",
+                    "404": {
+                        "description": "Product not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to restore product",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -5308,9 +5330,11 @@ const docTemplate = `{
                 "x-roles": [
                     "admin"
                 ]
-            },
-            "delete": {
-                "description": "Hard delete a user from the system by their ID (Roles: admin)",
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Retrieve detailed information of a specific product by its ID (Roles: admin, manager, cashier)",
                 "consumes": [
                     "application/json"
                 ],
@@ -5318,14 +5342,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Products"
                 ],
-                "summary": "Delete user",
+                "summary": "Get a product by ID",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "User ID",
+                        "description": "Product ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -5333,38 +5357,50 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "Product retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_products.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID format",
+                        "description": "Invalid product ID format",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Product not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to retrieve product",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     }
                 },
                 "x-roles": [
-                    "admin"
+                    "admin",
+                    "manager",
+                    "cashier"
                 ]
-            }
-        },
-        "/users/{id}/toggle-status": {
-            "post": {
-                "description": "Toggle the is_active status of a user (Roles: admin)",
+            },
+            "delete": {
+                "description": "Delete a product by its ID (Roles: admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -5372,14 +5408,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Products"
                 ],
-                "summary": "Toggle user status",
+                "summary": "Delete a product",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "User ID",
+                        "description": "Product ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -5387,25 +5423,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User status toggled successfully",
+                        "description": "Product deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID format",
+                        "description": "Invalid product ID format",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Product not found",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to delete product",
                         "schema": {
                             "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
                         }
@@ -5414,29 +5450,5514 @@ const docTemplate = `{
                 "x-roles": [
                     "admin"
                 ]
-            }
-        }
-    },
-    "definitions": {
-        "POS-kasir_internal_activitylog_repository.LogActionType": {
-            "type": "string",
-            "enum": [
-                "CREATE",
-                "UPDATE",
-                "DELETE",
-                "CANCEL",
-                "APPLY_PROMOTION",
-                "PROCESS_PAYMENT",
-                "REGISTER",
-                "UPDATE_PASSWORD",
-                "UPDATE_AVATAR",
-                "LOGIN_SUCCESS",
-                "LOGIN_FAILED",
-                "RESTORE"
-            ],
-            "x-enum-varnames": [
-                "LogActionTypeCREATE",
-                "LogActionTypeUPDATE",
+            },
+            "patch": {
+                "description": "Update details of a specific product by its ID (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product update request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_products.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_products.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or request body",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Product stock is kept by its variants or SKU already in use",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update product",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/products/{id}/barcodes": {
+            "post": {
+                "description": "Add a barcode to a product or one of its variants. EAN-13, UPC-A and EAN-8 codes must have a valid check digit; set generate for a new in-store EAN-13 code instead (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Add a barcode to a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Barcode to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_products.AddProductBarcodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Barcode added successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_products.ProductBarcodeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format, request body or barcode",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product or variant not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Barcode already in use",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to add barcode",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/products/{id}/barcodes/{barcode_id}": {
+            "delete": {
+                "description": "Remove one of a product's barcodes (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Remove a barcode from a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Barcode ID",
+                        "name": "barcode_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Barcode removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Barcode not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to remove barcode",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/products/{id}/image": {
+            "post": {
+                "description": "Upload an image for a product by ID (Roles: admin, manager)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Upload an image for a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product image uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_products.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or image file is missing",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to upload image",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/products/{id}/recipe": {
+            "get": {
+                "description": "Get what one unit of a product consumes, and what each of its options adds or swaps (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get product recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product recipe retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_inventory.ProductRecipeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "put": {
+                "description": "Replace the recipe of one unit of a product. Products with a recipe are made to order: sales draw down their ingredients instead of the product stock. An empty list removes the recipe (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Set product recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recipe lines",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_inventory.SetProductRecipeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product recipe saved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_inventory.ProductRecipeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID, request body, duplicate or unknown ingredient",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Product has variants, which keep their own stock",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/products/{id}/stock-history": {
+            "get": {
+                "description": "Get stock history for a specific product by its ID with pagination (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get stock history for a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Only the history of this variant",
+                        "name": "variant_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock history retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_products.PagedStockHistoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve stock history",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/products/{product_id}/options": {
+            "post": {
+                "description": "Create a new product option for a parent product (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create a product option",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product option create request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_products.CreateProductOptionRequestStandalone"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Product option created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_products.ProductOptionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID format, request body or modifier group",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Parent product not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Too many default options in the modifier group",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create product option",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/products/{product_id}/options/{option_id}": {
+            "patch": {
+                "description": "Update details of a specific product option by its ID (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update a product option",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Option ID",
+                        "name": "option_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product option update request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_products.UpdateProductOptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product option updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_products.ProductOptionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format, request body or modifier group",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product or option not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Too many default options in the modifier group",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update product option",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/products/{product_id}/options/{option_id}/image": {
+            "post": {
+                "description": "Upload image for a specific product option (Roles: admin, manager)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Upload product option image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Option ID",
+                        "name": "option_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Product option image",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product option image uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_products.ProductOptionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format or missing file",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product or option not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to upload product option image",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/products/{product_id}/options/{option_id}/recipe": {
+            "put": {
+                "description": "Replace what choosing an option adds to the product recipe. A line with replaces_ingredient_id swaps that ingredient of the product recipe, e.g. oat milk instead of milk (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Set product option recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product Option ID",
+                        "name": "option_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recipe lines",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_inventory.SetOptionRecipeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Option recipe saved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_inventory.ProductRecipeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid IDs, request body, duplicate or unknown ingredient, or a replacement outside the product recipe",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product or option not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/products/{product_id}/variants": {
+            "get": {
+                "description": "List a product's variant attributes and its active variants (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "List product variants",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product variants retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_products.ProductVariantsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID format",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve product variants",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/products/{product_id}/variants/generate": {
+            "post": {
+                "description": "Create one variant per combination of attribute values (e.g. size x colour). Existing combinations are kept; new variants start without stock (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Generate product variants",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant attributes",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_products.GenerateVariantsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product variants generated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_products.ProductVariantsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format, request body or variant matrix",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Product cannot take these variants",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to generate product variants",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/products/{product_id}/variants/{variant_id}": {
+            "delete": {
+                "description": "Remove a variant that has no stock left. Past orders keep referring to it (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete a product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product variant deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product or variant not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Variant still has stock",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete product variant",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            },
+            "patch": {
+                "description": "Update a variant's SKU, barcode, price override, cost price or stock. Stock changes are recorded in the stock history (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update a product variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant update request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_products.UpdateVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product variant updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_products.ProductVariantResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format, request body or barcode check digit",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product or variant not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "SKU or barcode already in use",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update product variant",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/promotions": {
+            "get": {
+                "description": "Get a list of promotions with pagination and optional trash filter. available_now tells whether a promotion can be applied at the moment, given its dates and schedule (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "List all promotions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Show trash items",
+                        "name": "trash",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotions retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_promotions.PagedPromotionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "post": {
+                "description": "Create a new promotion with rules and targets. Besides percentage and fixed_amount discounts it supports buy_x_get_y (buy_quantity, get_quantity, discount_value percent off), bundle_price (bundle_quantity units for discount_value) and tiered (tiers) promotions. auto_apply promotions are picked for orders automatically; stacking (exclusive or stackable) and priority decide how promotions combine. schedules limit the promotion to recurring day-of-week and time-of-day windows (e.g. happy hour) and excluded_dates skip days such as holidays, both in the store's time zone (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Create a new promotion",
+                "parameters": [
+                    {
+                        "description": "Promotion details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_promotions.CreatePromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Promotion created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_promotions.PromotionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, validation failed or settings missing for the discount type",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/promotions/{id}": {
+            "get": {
+                "description": "Retrieve details of a specific promotion by its ID (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Get a promotion by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_promotions.PromotionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID format",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "put": {
+                "description": "Update details of an existing promotion by its ID. Rules, targets, tiers, schedules and excluded dates are replaced by the ones in the request (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Update a promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promotion details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_promotions.UpdatePromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_promotions.PromotionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID format or request body, or settings missing for the discount type",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            },
+            "delete": {
+                "description": "Soft delete a promotion by its ID (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Delete (deactivate) a promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID format",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/promotions/{id}/codes": {
+            "get": {
+                "description": "List the codes of a promotion with how often each was redeemed, optionally of one generated batch (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "List promotion codes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Only codes of this batch",
+                        "name": "batch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion codes retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_promotions.PagedPromotionCodeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID format or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            },
+            "post": {
+                "description": "Create one code anyone can use to apply the promotion, e.g. a campaign code. Codes are case-insensitive and stored upper-case; max_uses caps the redemptions of this code on top of the promotion's own limits (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Create a shared promotion code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Code details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_promotions.CreatePromotionCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Promotion code created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_promotions.PromotionCodeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID format or request body",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Code is already taken",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/promotions/{id}/codes/batch": {
+            "post": {
+                "description": "Generate a batch of unique random codes that can each be redeemed once, e.g. to hand out per customer. The codes share a batch_id to list them by (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Generate single-use promotion codes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Batch details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_promotions.GeneratePromotionCodesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Promotion codes generated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_promotions.GeneratePromotionCodesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID format or request body",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/promotions/{id}/codes/{code_id}": {
+            "delete": {
+                "description": "Stop a code from being applied to new orders. Orders that already carry it keep it, and the code stays reserved (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Deactivate a promotion code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Promotion code ID",
+                        "name": "code_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion code deactivated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_promotions.PromotionCodeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Code not found or already deactivated",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/promotions/{id}/restore": {
+            "post": {
+                "description": "Restore a soft-deleted promotion by its ID (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Restore a deleted promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion restored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID format",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/purchase-orders": {
+            "get": {
+                "description": "Get purchase orders newest first with their supplier and total cost, filtered by status or supplier (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchasing"
+                ],
+                "summary": "List purchase orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "sent",
+                            "partially_received",
+                            "received",
+                            "closed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Filter by supplier",
+                        "name": "supplier_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase orders retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_purchasing.PagedPurchaseOrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "post": {
+                "description": "Create a draft purchase order. Each product may appear once, with the quantity and agreed unit cost (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchasing"
+                ],
+                "summary": "Create purchase order",
+                "parameters": [
+                    {
+                        "description": "Purchase order details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_purchasing.PurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Purchase order created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_purchasing.PurchaseOrderDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, unknown supplier or products",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/purchase-orders/{id}": {
+            "get": {
+                "description": "Get a purchase order with its lines, what is still outstanding and every goods receipt booked against it (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchasing"
+                ],
+                "summary": "Get purchase order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Purchase Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase order retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_purchasing.PurchaseOrderDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid purchase order ID",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "put": {
+                "description": "Replace the supplier, expected date, notes and lines of a purchase order that is still a draft (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchasing"
+                ],
+                "summary": "Update purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Purchase Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Purchase order details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_purchasing.PurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase order updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_purchasing.PurchaseOrderDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid purchase order ID, request body, unknown supplier or products",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order is no longer a draft",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/purchase-orders/{id}/close": {
+            "post": {
+                "description": "Close a purchase order. A draft is cancelled; a sent order stops waiting for what is still outstanding (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchasing"
+                ],
+                "summary": "Close purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Purchase Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase order closed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_purchasing.PurchaseOrderDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid purchase order ID",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order is already closed",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/purchase-orders/{id}/receive": {
+            "post": {
+                "description": "Book a goods receipt against a sent purchase order. Received quantities are added to product stock as restocks referencing the purchase order, and each product's cost becomes the received unit cost. The order becomes partially_received or received (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchasing"
+                ],
+                "summary": "Receive goods",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Purchase Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Received lines",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_purchasing.ReceivePurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Goods received successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_purchasing.PurchaseOrderDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid purchase order ID, request body, or lines exceeding what is outstanding",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order is not waiting for goods",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/purchase-orders/{id}/send": {
+            "post": {
+                "description": "Mark a draft purchase order as sent to the supplier. Its lines can no longer be changed and it can be received (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchasing"
+                ],
+                "summary": "Send purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Purchase Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purchase order sent successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_purchasing.PurchaseOrderDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid purchase order ID",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order is not a draft",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/reports/cancellations": {
+            "get": {
+                "description": "Get statistics on order cancellations grouped by reason (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get cancellation reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cancellation reports retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_report.CancellationReportResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/reports/cashier-performance": {
+            "get": {
+                "description": "Get order counts and sales totals handled by each cashier (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get cashier performance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cashier performance data retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_report.CashierPerformanceResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/reports/dashboard-summary": {
+            "get": {
+                "description": "Get high-level summary metrics (totals) for the dashboard (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get dashboard summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dashboard summary retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_report.DashboardSummaryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/reports/inventory-valuation": {
+            "get": {
+                "description": "Get the value of the stock on hand per product from its cost layers, under the store's costing method (moving average or FIFO). Both values are returned per product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get inventory valuation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export format (csv)",
+                        "name": "export",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Inventory valuation retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_report.InventoryValuationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/reports/low-stock": {
+            "get": {
+                "description": "Get products with stock below threshold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get low stock products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Threshold (default: 5)",
+                        "name": "threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export format (csv)",
+                        "name": "export",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Low stock products retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_report.LowStockProductResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/reports/payment-methods": {
+            "get": {
+                "description": "Get usage counts and totals for each payment method (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get payment method performance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment method performance data retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_report.PaymentMethodPerformanceResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/reports/products": {
+            "get": {
+                "description": "Get sales performance metrics for each product (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get product performance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product performance data retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_report.ProductPerformanceResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/reports/profit-products": {
+            "get": {
+                "description": "Get profitability metrics for each product sold (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get product profit reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product profit reports retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_report.ProductProfitResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/reports/profit-summary": {
+            "get": {
+                "description": "Get gross profit analytics grouped by date (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get profit summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profit summary retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_report.ProfitSummaryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/reports/promotions": {
+            "get": {
+                "description": "Get metrics of promotions usage, with the redemptions of each promotion code and the usage in each schedule window (e.g. happy hour)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get promotion performance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export format (csv)",
+                        "name": "export",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion performance retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_report.PromotionPerformanceResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/reports/purchase-orders/outstanding": {
+            "get": {
+                "description": "Get sent and partially received purchase orders with what is still to be delivered and its value, earliest expected first. Orders past their expected date are flagged overdue",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get outstanding purchase orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export format (csv)",
+                        "name": "export",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Outstanding purchase orders retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_report.OutstandingPurchaseOrderResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/reports/sales": {
+            "get": {
+                "description": "Get aggregated sales data grouped by date within a specified range (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get sales reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sales reports retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_report.SalesReport"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/reports/shift-summary": {
+            "get": {
+                "description": "Get historical shifts and their cash differences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get shift summary records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export format (csv)",
+                        "name": "export",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shift summary retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_report.ShiftSummaryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/reports/supplier-spend": {
+            "get": {
+                "description": "Get the value of goods received from each supplier within a date range, with receipt and purchase order counts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get supplier spend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start Date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End Date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export format (csv)",
+                        "name": "export",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier spend retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/internal_report.SupplierSpendResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/settings/branding": {
+            "get": {
+                "description": "Retrieve branding settings (app name, logo, footer text, theme colors) for the application (Roles: authenticated)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get branding settings",
+                "responses": {
+                    "200": {
+                        "description": "Branding settings fetched successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_settings.BrandingSettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "put": {
+                "description": "Update application branding settings (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update branding settings",
+                "parameters": [
+                    {
+                        "description": "Branding update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_settings.UpdateBrandingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Branding settings updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_settings.BrandingSettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failure",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        },
+        "/settings/branding/logo": {
+            "post": {
+                "description": "Upload and update the application logo image (Roles: admin)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update app logo",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Logo image file",
+                        "name": "logo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Logo updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Logo file is required or file size exceeds limit",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        },
+        "/settings/loyalty": {
+            "get": {
+                "description": "Retrieve how customers earn loyalty points (spend per point, category multipliers, promotion bonuses), what a point is worth when redeemed and when points expire (Roles: authenticated)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get loyalty settings",
+                "responses": {
+                    "200": {
+                        "description": "Loyalty settings fetched successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_settings.LoyaltySettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "put": {
+                "description": "Update the loyalty points rules. expiry_days 0 keeps points forever; category multipliers and promotion bonuses replace the current ones (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update loyalty settings",
+                "parameters": [
+                    {
+                        "description": "Loyalty settings update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_settings.UpdateLoyaltySettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Loyalty settings updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_settings.LoyaltySettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failure",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        },
+        "/settings/operations": {
+            "get": {
+                "description": "Retrieve the store time zone and how daily queue numbers are issued (prefix, reset time, digits) (Roles: authenticated)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get operational settings",
+                "responses": {
+                    "200": {
+                        "description": "Operational settings fetched successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_settings.OperationalSettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "put": {
+                "description": "Update the store time zone, the queue number prefix and padding, and the local time at which queue numbers reset (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update operational settings",
+                "parameters": [
+                    {
+                        "description": "Operational settings update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_settings.UpdateOperationalSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Operational settings updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_settings.OperationalSettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failure",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        },
+        "/settings/printer": {
+            "get": {
+                "description": "Retrieve printer settings like connection string and paper width (Roles: authenticated)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get printer settings",
+                "responses": {
+                    "200": {
+                        "description": "Printer settings fetched successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_settings.PrinterSettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "put": {
+                "description": "Update global printer configuration (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update printer settings",
+                "parameters": [
+                    {
+                        "description": "Printer settings update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_settings.UpdatePrinterSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Printer settings updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_settings.PrinterSettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failure",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        },
+        "/settings/printer/discover": {
+            "get": {
+                "description": "Scan local network for thermal printers on port 9100 (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Printer"
+                ],
+                "summary": "Discover network printers",
+                "responses": {
+                    "200": {
+                        "description": "List of discovered printers",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to discover printers",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        },
+        "/settings/printer/test": {
+            "post": {
+                "description": "Send a test print command to the configured printer (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Printer"
+                ],
+                "summary": "Test printer connection",
+                "responses": {
+                    "200": {
+                        "description": "Test print command sent associated with configured printer",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to send test print",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        },
+        "/settings/tax": {
+            "get": {
+                "description": "Retrieve tax and service charge rules used to calculate order totals (Roles: authenticated)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get tax settings",
+                "responses": {
+                    "200": {
+                        "description": "Tax settings fetched successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_settings.TaxSettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "put": {
+                "description": "Update tax rate, service charge, per-order-type rules, category exemptions and rounding mode (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update tax settings",
+                "parameters": [
+                    {
+                        "description": "Tax settings update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_settings.UpdateTaxSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tax settings updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_settings.TaxSettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failure",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        },
+        "/shifts/cash-transaction": {
+            "post": {
+                "description": "Record a manual cash entry or exit within the active shift (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shifts"
+                ],
+                "summary": "Create a cash transaction (Drop/Expense/In)",
+                "parameters": [
+                    {
+                        "description": "Cash Transaction Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_shift.CashTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Cash transaction created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_shift.CashTransactionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failure",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No open shift found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/shifts/current": {
+            "get": {
+                "description": "Check for and retrieve the details of an active shift session for the authenticated user (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shifts"
+                ],
+                "summary": "Get current open shift",
+                "responses": {
+                    "200": {
+                        "description": "Open shift retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_shift.ShiftResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "No open shift found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/shifts/end": {
+            "post": {
+                "description": "Close the active shift session and return the cash reconciliation (sales and refunds by payment method, cash in/out, over/short) (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shifts"
+                ],
+                "summary": "End current shift",
+                "parameters": [
+                    {
+                        "description": "End Shift Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_shift.EndShiftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shift ended successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_shift.ShiftResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failure",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No open shift found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/shifts/start": {
+            "post": {
+                "description": "Create a new shift session for the authenticated user (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shifts"
+                ],
+                "summary": "Start a new shift",
+                "parameters": [
+                    {
+                        "description": "Start Shift Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_shift.StartShiftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Shift started successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_shift.ShiftResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failure",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already has an open shift",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/stock-takes": {
+            "get": {
+                "description": "Get stock take sessions with how many of their products have been counted (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Takes"
+                ],
+                "summary": "List stock takes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "open",
+                            "approved",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock takes retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_stocktake.PagedStockTakeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "post": {
+                "description": "Open a physical count of the whole store or one category. The stock and cost of every product in scope is snapshotted; a product can only be in one open stock take (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Takes"
+                ],
+                "summary": "Start stock take",
+                "parameters": [
+                    {
+                        "description": "Stock take scope",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_stocktake.CreateStockTakeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Stock take started successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_stocktake.StockTakeDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, unknown category or nothing to count",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Products are already being counted in another open stock take",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/stock-takes/{id}": {
+            "get": {
+                "description": "Get a stock take with each product's system, expected and counted quantity, its variance in units and cost, and who counted it (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Takes"
+                ],
+                "summary": "Get stock take by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Stock Take ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock take retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_stocktake.StockTakeDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock take ID",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock take not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/stock-takes/{id}/approve": {
+            "post": {
+                "description": "Post the variance of every counted product to its stock as a correction in stock_history referencing the stock take, in one transaction. Uncounted products keep their stock (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Takes"
+                ],
+                "summary": "Approve stock take",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Stock Take ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock take approved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_stocktake.StockTakeDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock take ID",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock take not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock take is no longer open",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/stock-takes/{id}/cancel": {
+            "post": {
+                "description": "Close an open stock take without changing any stock (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Takes"
+                ],
+                "summary": "Cancel stock take",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Stock Take ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock take cancelled successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_stocktake.StockTakeDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock take ID",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock take not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock take is no longer open",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/stock-takes/{id}/counts": {
+            "post": {
+                "description": "Record what the current user counted. Recounting a product replaces their earlier count, and counts by different staff add up. Each product's expected quantity moves to its stock at this moment so sales during the count are not treated as variance (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock Takes"
+                ],
+                "summary": "Record counts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Stock Take ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Counted quantities",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_stocktake.RecordCountsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Counts recorded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_stocktake.StockTakeDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid stock take ID, request body, or products outside the stock take",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock take not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock take is no longer open",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            }
+        },
+        "/suppliers": {
+            "get": {
+                "description": "Get suppliers, optionally filtered by name (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchasing"
+                ],
+                "summary": "List suppliers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Suppliers retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_purchasing.PagedSupplierResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "post": {
+                "description": "Create a supplier to order stock from (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchasing"
+                ],
+                "summary": "Create supplier",
+                "parameters": [
+                    {
+                        "description": "Supplier details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_purchasing.CreateSupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Supplier created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_purchasing.SupplierResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Supplier with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/suppliers/{id}": {
+            "get": {
+                "description": "Get a single supplier with its contact details (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchasing"
+                ],
+                "summary": "Get supplier by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_purchasing.SupplierResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid supplier ID",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "put": {
+                "description": "Replace a supplier's name and contact details (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchasing"
+                ],
+                "summary": "Update supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_purchasing.UpdateSupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_purchasing.SupplierResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid supplier ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Supplier with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            },
+            "delete": {
+                "description": "Delete a supplier that has no purchase orders waiting to be sent or received (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchasing"
+                ],
+                "summary": "Delete supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid supplier ID",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Supplier has open purchase orders",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Retrieve a list of users with pagination, filtering, and sorting (Roles: admin, manager, cashier)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by username or email",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "admin",
+                            "cashier",
+                            "manager"
+                        ],
+                        "type": "string",
+                        "description": "Filter by User Role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by Active Status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "deleted",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Filter by Account Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "username"
+                        ],
+                        "type": "string",
+                        "description": "Sort by column",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction",
+                        "name": "sortOrder",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_user.UsersResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager",
+                    "cashier"
+                ]
+            },
+            "post": {
+                "description": "Create a new user account (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create user",
+                "parameters": [
+                    {
+                        "description": "New user details",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_user.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_user.ProfileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User, username, or email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Retrieve detailed profile for a specific user by their ID (Roles: admin, manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_user.ProfileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID format",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin",
+                    "manager"
+                ]
+            },
+            "put": {
+                "description": "Update details of an existing user account (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User update details",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_user.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/internal_user.ProfileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - higher role assignment attempt",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Username or email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            },
+            "delete": {
+                "description": "Hard delete a user from the system by their ID (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID format",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        },
+        "/users/{id}/toggle-status": {
+            "post": {
+                "description": "Toggle the is_active status of a user (Roles: admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Toggle user status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User status toggled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID format",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/POS-kasir_internal_common.ErrorResponse"
+                        }
+                    }
+                },
+                "x-roles": [
+                    "admin"
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "POS-kasir_internal_activitylog_repository.LogActionType": {
+            "type": "string",
+            "enum": [
+                "CREATE",
+                "UPDATE",
+                "DELETE",
+                "CANCEL",
+                "APPLY_PROMOTION",
+                "PROCESS_PAYMENT",
+                "REGISTER",
+                "UPDATE_PASSWORD",
+                "UPDATE_AVATAR",
+                "LOGIN_SUCCESS",
+                "LOGIN_FAILED",
+                "RESTORE"
+            ],
+            "x-enum-varnames": [
+                "LogActionTypeCREATE",
+                "LogActionTypeUPDATE",
                 "LogActionTypeDELETE",
                 "LogActionTypeCANCEL",
                 "LogActionTypeAPPLYPROMOTION",
@@ -5449,404 +10970,2507 @@ const docTemplate = `{
                 "LogActionTypeRESTORE"
             ]
         },
-        "POS-kasir_internal_activitylog_repository.LogEntityType": {
-            "type": "string",
-            "enum": [
-                "PRODUCT",
-                "CATEGORY",
-                "PROMOTION",
-                "ORDER",
-                "USER",
-                "SETTINGS",
-                "SHIFT",
-                "PAYMENT_METHOD",
-                "CANCELLATION_REASON"
+        "POS-kasir_internal_activitylog_repository.LogEntityType": {
+            "type": "string",
+            "enum": [
+                "PRODUCT",
+                "CATEGORY",
+                "PROMOTION",
+                "ORDER",
+                "USER",
+                "SETTINGS",
+                "SHIFT",
+                "PAYMENT_METHOD",
+                "CANCELLATION_REASON",
+                "KITCHEN_STATION",
+                "INGREDIENT",
+                "SUPPLIER",
+                "PURCHASE_ORDER",
+                "STOCK_TAKE",
+                "MODIFIER_GROUP"
+            ],
+            "x-enum-varnames": [
+                "LogEntityTypePRODUCT",
+                "LogEntityTypeCATEGORY",
+                "LogEntityTypePROMOTION",
+                "LogEntityTypeORDER",
+                "LogEntityTypeUSER",
+                "LogEntityTypeSETTINGS",
+                "LogEntityTypeSHIFT",
+                "LogEntityTypePAYMENTMETHOD",
+                "LogEntityTypeCANCELLATIONREASON",
+                "LogEntityTypeKITCHENSTATION",
+                "LogEntityTypeINGREDIENT",
+                "LogEntityTypeSUPPLIER",
+                "LogEntityTypePURCHASEORDER",
+                "LogEntityTypeSTOCKTAKE",
+                "LogEntityTypeMODIFIERGROUP"
+            ]
+        },
+        "POS-kasir_internal_common.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {},
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "POS-kasir_internal_common.SuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "POS-kasir_internal_common_pagination.Pagination": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total_data": {
+                    "type": "integer"
+                },
+                "total_page": {
+                    "type": "integer"
+                }
+            }
+        },
+        "POS-kasir_internal_customers_repository.LoyaltyEntryType": {
+            "type": "string",
+            "enum": [
+                "earn",
+                "redeem",
+                "expire",
+                "reversal"
+            ],
+            "x-enum-varnames": [
+                "LoyaltyEntryTypeEarn",
+                "LoyaltyEntryTypeRedeem",
+                "LoyaltyEntryTypeExpire",
+                "LoyaltyEntryTypeReversal"
+            ]
+        },
+        "POS-kasir_internal_customers_repository.LoyaltyRedemptionKind": {
+            "type": "string",
+            "enum": [
+                "discount",
+                "tender"
+            ],
+            "x-enum-varnames": [
+                "LoyaltyRedemptionKindDiscount",
+                "LoyaltyRedemptionKindTender"
+            ]
+        },
+        "POS-kasir_internal_inventory_repository.IngredientUnit": {
+            "type": "string",
+            "enum": [
+                "g",
+                "ml",
+                "pcs"
+            ],
+            "x-enum-varnames": [
+                "IngredientUnitG",
+                "IngredientUnitMl",
+                "IngredientUnitPcs"
+            ]
+        },
+        "POS-kasir_internal_kitchen_repository.OrderItemStatus": {
+            "type": "string",
+            "enum": [
+                "queued",
+                "cooking",
+                "ready",
+                "served"
+            ],
+            "x-enum-varnames": [
+                "OrderItemStatusQueued",
+                "OrderItemStatusCooking",
+                "OrderItemStatusReady",
+                "OrderItemStatusServed"
+            ]
+        },
+        "POS-kasir_internal_kitchen_repository.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "in_progress",
+                "served",
+                "paid",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "OrderStatusOpen",
+                "OrderStatusInProgress",
+                "OrderStatusServed",
+                "OrderStatusPaid",
+                "OrderStatusCancelled"
+            ]
+        },
+        "POS-kasir_internal_kitchen_repository.OrderType": {
+            "type": "string",
+            "enum": [
+                "dine_in",
+                "takeaway"
+            ],
+            "x-enum-varnames": [
+                "OrderTypeDineIn",
+                "OrderTypeTakeaway"
+            ]
+        },
+        "POS-kasir_internal_orders_repository.OrderItemStatus": {
+            "type": "string",
+            "enum": [
+                "queued",
+                "cooking",
+                "ready",
+                "served"
+            ],
+            "x-enum-varnames": [
+                "OrderItemStatusQueued",
+                "OrderItemStatusCooking",
+                "OrderItemStatusReady",
+                "OrderItemStatusServed"
+            ]
+        },
+        "POS-kasir_internal_orders_repository.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "in_progress",
+                "served",
+                "paid",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "OrderStatusOpen",
+                "OrderStatusInProgress",
+                "OrderStatusServed",
+                "OrderStatusPaid",
+                "OrderStatusCancelled"
+            ]
+        },
+        "POS-kasir_internal_orders_repository.OrderType": {
+            "type": "string",
+            "enum": [
+                "dine_in",
+                "takeaway"
+            ],
+            "x-enum-varnames": [
+                "OrderTypeDineIn",
+                "OrderTypeTakeaway"
+            ]
+        },
+        "POS-kasir_internal_orders_repository.PromotionStacking": {
+            "type": "string",
+            "enum": [
+                "exclusive",
+                "stackable"
+            ],
+            "x-enum-varnames": [
+                "PromotionStackingExclusive",
+                "PromotionStackingStackable"
+            ]
+        },
+        "POS-kasir_internal_payment_methods_repository.PaymentMethodKind": {
+            "type": "string",
+            "enum": [
+                "cash",
+                "card",
+                "e_wallet",
+                "gateway",
+                "voucher",
+                "on_account",
+                "loyalty_points"
+            ],
+            "x-enum-varnames": [
+                "PaymentMethodKindCash",
+                "PaymentMethodKindCard",
+                "PaymentMethodKindEWallet",
+                "PaymentMethodKindGateway",
+                "PaymentMethodKindVoucher",
+                "PaymentMethodKindOnAccount",
+                "PaymentMethodKindLoyaltyPoints"
+            ]
+        },
+        "POS-kasir_internal_promotions_repository.DiscountType": {
+            "type": "string",
+            "enum": [
+                "percentage",
+                "fixed_amount",
+                "buy_x_get_y",
+                "bundle_price",
+                "tiered"
+            ],
+            "x-enum-varnames": [
+                "DiscountTypePercentage",
+                "DiscountTypeFixedAmount",
+                "DiscountTypeBuyXGetY",
+                "DiscountTypeBundlePrice",
+                "DiscountTypeTiered"
+            ]
+        },
+        "POS-kasir_internal_promotions_repository.PromotionRuleType": {
+            "type": "string",
+            "enum": [
+                "MINIMUM_ORDER_AMOUNT",
+                "REQUIRED_PRODUCT",
+                "REQUIRED_CATEGORY",
+                "ALLOWED_PAYMENT_METHOD",
+                "ALLOWED_ORDER_TYPE"
+            ],
+            "x-enum-varnames": [
+                "PromotionRuleTypeMINIMUMORDERAMOUNT",
+                "PromotionRuleTypeREQUIREDPRODUCT",
+                "PromotionRuleTypeREQUIREDCATEGORY",
+                "PromotionRuleTypeALLOWEDPAYMENTMETHOD",
+                "PromotionRuleTypeALLOWEDORDERTYPE"
+            ]
+        },
+        "POS-kasir_internal_promotions_repository.PromotionScope": {
+            "type": "string",
+            "enum": [
+                "ORDER",
+                "ITEM"
+            ],
+            "x-enum-varnames": [
+                "PromotionScopeORDER",
+                "PromotionScopeITEM"
+            ]
+        },
+        "POS-kasir_internal_promotions_repository.PromotionStacking": {
+            "type": "string",
+            "enum": [
+                "exclusive",
+                "stackable"
+            ],
+            "x-enum-varnames": [
+                "PromotionStackingExclusive",
+                "PromotionStackingStackable"
+            ]
+        },
+        "POS-kasir_internal_promotions_repository.PromotionTargetType": {
+            "type": "string",
+            "enum": [
+                "PRODUCT",
+                "CATEGORY"
+            ],
+            "x-enum-varnames": [
+                "PromotionTargetTypePRODUCT",
+                "PromotionTargetTypeCATEGORY"
+            ]
+        },
+        "POS-kasir_internal_purchasing_repository.PurchaseOrderStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "sent",
+                "partially_received",
+                "received",
+                "closed"
+            ],
+            "x-enum-varnames": [
+                "PurchaseOrderStatusDraft",
+                "PurchaseOrderStatusSent",
+                "PurchaseOrderStatusPartiallyReceived",
+                "PurchaseOrderStatusReceived",
+                "PurchaseOrderStatusClosed"
+            ]
+        },
+        "POS-kasir_internal_shift_repository.CashTransactionType": {
+            "type": "string",
+            "enum": [
+                "cash_in",
+                "cash_out"
+            ],
+            "x-enum-varnames": [
+                "CashTransactionTypeCashIn",
+                "CashTransactionTypeCashOut"
+            ]
+        },
+        "POS-kasir_internal_shift_repository.ShiftStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "closed"
+            ],
+            "x-enum-varnames": [
+                "ShiftStatusOpen",
+                "ShiftStatusClosed"
+            ]
+        },
+        "POS-kasir_internal_stocktake_repository.StockTakeScope": {
+            "type": "string",
+            "enum": [
+                "full",
+                "category"
+            ],
+            "x-enum-varnames": [
+                "StockTakeScopeFull",
+                "StockTakeScopeCategory"
+            ]
+        },
+        "POS-kasir_internal_stocktake_repository.StockTakeStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "approved",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "StockTakeStatusOpen",
+                "StockTakeStatusApproved",
+                "StockTakeStatusCancelled"
+            ]
+        },
+        "POS-kasir_internal_user_repository.UserRole": {
+            "type": "string",
+            "enum": [
+                "admin",
+                "cashier",
+                "manager"
+            ],
+            "x-enum-varnames": [
+                "UserRoleAdmin",
+                "UserRoleCashier",
+                "UserRoleManager"
+            ]
+        },
+        "POS-kasir_pkg_payment.MidtransNotificationPayload": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "fraud_status": {
+                    "type": "string"
+                },
+                "gross_amount": {
+                    "type": "string"
+                },
+                "merchant_id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "payment_type": {
+                    "type": "string"
+                },
+                "signature_key": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "string"
+                },
+                "status_message": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "transaction_status": {
+                    "type": "string"
+                },
+                "transaction_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "fiber.Map": {
+            "type": "object",
+            "additionalProperties": {}
+        },
+        "internal_activitylog.ActivityLogListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_activitylog.ActivityLogResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_activitylog.ActivityLogResponse": {
+            "type": "object",
+            "properties": {
+                "action_type": {
+                    "$ref": "#/definitions/POS-kasir_internal_activitylog_repository.LogActionType"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "$ref": "#/definitions/POS-kasir_internal_activitylog_repository.LogEntityType"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_cancellation_reasons.CancellationReasonResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_categories.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_categories.CategoryWithCountResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "product_count": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_categories.CreateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                }
+            }
+        },
+        "internal_customers.CreateCustomerRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "internal_customers.CustomerResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "points_balance": {
+                    "type": "integer"
+                },
+                "points_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_customers.PointsEntryResponse"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_customers.PagedCustomerResponse": {
+            "type": "object",
+            "properties": {
+                "customers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_customers.CustomerResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/POS-kasir_internal_common_pagination.Pagination"
+                }
+            }
+        },
+        "internal_customers.PointsEntryResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "entry_type": {
+                    "$ref": "#/definitions/POS-kasir_internal_customers_repository.LoyaltyEntryType"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "redeemed_as": {
+                    "$ref": "#/definitions/POS-kasir_internal_customers_repository.LoyaltyRedemptionKind"
+                }
+            }
+        },
+        "internal_customers.UpdateCustomerRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "internal_inventory.CreateIngredientRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "unit"
+            ],
+            "properties": {
+                "cost_per_unit": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "low_stock_threshold": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "unit": {
+                    "enum": [
+                        "g",
+                        "ml",
+                        "pcs"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/POS-kasir_internal_inventory_repository.IngredientUnit"
+                        }
+                    ]
+                }
+            }
+        },
+        "internal_inventory.IngredientResponse": {
+            "type": "object",
+            "properties": {
+                "cost_per_unit": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_low_stock": {
+                    "type": "boolean"
+                },
+                "low_stock_threshold": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "$ref": "#/definitions/POS-kasir_internal_inventory_repository.IngredientUnit"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_inventory.IngredientStockHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "change_amount": {
+                    "type": "integer"
+                },
+                "change_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "current_stock": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "previous_stock": {
+                    "type": "integer"
+                },
+                "reference_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_inventory.ListIngredientsResponse": {
+            "type": "object",
+            "properties": {
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_inventory.IngredientResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/POS-kasir_internal_common_pagination.Pagination"
+                }
+            }
+        },
+        "internal_inventory.OptionRecipeItemRequest": {
+            "type": "object",
+            "required": [
+                "ingredient_id",
+                "quantity"
+            ],
+            "properties": {
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "replaces_ingredient_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_inventory.OptionRecipeItemResponse": {
+            "type": "object",
+            "properties": {
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "ingredient_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "replaces_ingredient_id": {
+                    "type": "string"
+                },
+                "replaces_ingredient_name": {
+                    "type": "string"
+                },
+                "unit": {
+                    "$ref": "#/definitions/POS-kasir_internal_inventory_repository.IngredientUnit"
+                }
+            }
+        },
+        "internal_inventory.OptionRecipeResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_inventory.OptionRecipeItemResponse"
+                    }
+                },
+                "option_id": {
+                    "type": "string"
+                },
+                "option_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_inventory.PagedIngredientStockHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_inventory.IngredientStockHistoryResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/POS-kasir_internal_common_pagination.Pagination"
+                }
+            }
+        },
+        "internal_inventory.ProductRecipeResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_inventory.RecipeItemResponse"
+                    }
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_inventory.OptionRecipeResponse"
+                    }
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_inventory.RecipeItemRequest": {
+            "type": "object",
+            "required": [
+                "ingredient_id",
+                "quantity"
+            ],
+            "properties": {
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_inventory.RecipeItemResponse": {
+            "type": "object",
+            "properties": {
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "ingredient_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "$ref": "#/definitions/POS-kasir_internal_inventory_repository.IngredientUnit"
+                }
+            }
+        },
+        "internal_inventory.SetOptionRecipeRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_inventory.OptionRecipeItemRequest"
+                    }
+                }
+            }
+        },
+        "internal_inventory.SetProductRecipeRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_inventory.RecipeItemRequest"
+                    }
+                }
+            }
+        },
+        "internal_inventory.UpdateIngredientRequest": {
+            "type": "object",
+            "properties": {
+                "change_type": {
+                    "type": "string",
+                    "enum": [
+                        "restock",
+                        "correction",
+                        "damage"
+                    ]
+                },
+                "cost_per_unit": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "low_stock_threshold": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "internal_kitchen.CreateKitchenStationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "sort_order": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "internal_kitchen.KitchenItemResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "is_priority": {
+                    "type": "boolean"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "order_status": {
+                    "$ref": "#/definitions/POS-kasir_internal_kitchen_repository.OrderStatus"
+                },
+                "prep_status": {
+                    "$ref": "#/definitions/POS-kasir_internal_kitchen_repository.OrderItemStatus"
+                },
+                "queued_at": {
+                    "type": "string"
+                },
+                "ready_at": {
+                    "type": "string"
+                },
+                "served_at": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "station_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_kitchen.KitchenStationResponse": {
+            "type": "object",
+            "properties": {
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_kitchen.KitchenTicketItemResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "is_priority": {
+                    "type": "boolean"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "prep_status": {
+                    "$ref": "#/definitions/POS-kasir_internal_kitchen_repository.OrderItemStatus"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "queued_at": {
+                    "type": "string"
+                },
+                "ready_at": {
+                    "type": "string"
+                },
+                "served_at": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "station_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_kitchen.KitchenTicketResponse": {
+            "type": "object",
+            "properties": {
+                "is_priority": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_kitchen.KitchenTicketItemResponse"
+                    }
+                },
+                "order_created_at": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "order_status": {
+                    "$ref": "#/definitions/POS-kasir_internal_kitchen_repository.OrderStatus"
+                },
+                "order_type": {
+                    "$ref": "#/definitions/POS-kasir_internal_kitchen_repository.OrderType"
+                },
+                "station_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_kitchen.SetItemPriorityRequest": {
+            "type": "object",
+            "properties": {
+                "is_priority": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_kitchen.UpdateKitchenStationRequest": {
+            "type": "object",
+            "properties": {
+                "category_ids": {
+                    "description": "CategoryIDs replaces the station's categories when present; an empty list unmaps them all.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "sort_order": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "internal_orders.AddOrderPaymentRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "payment_method_id",
+                "version"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "reference_number": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "remove_ineligible_promotion": {
+                    "description": "RemoveIneligiblePromotion strips a promotion that does not accept this payment method instead of rejecting the payment.",
+                    "type": "boolean"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_orders.AppliedPromotionResponse": {
+            "type": "object",
+            "properties": {
+                "auto_applied": {
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "discount_amount": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "promotion_code_id": {
+                    "type": "string"
+                },
+                "promotion_id": {
+                    "type": "string"
+                },
+                "promotion_name": {
+                    "type": "string"
+                },
+                "schedule_window": {
+                    "type": "string"
+                },
+                "stacking": {
+                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.PromotionStacking"
+                }
+            }
+        },
+        "internal_orders.ApplyPromotionRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "promotion_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_orders.CallingBoardEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "queue_number": {
+                    "type": "string"
+                },
+                "ready_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderType"
+                }
+            }
+        },
+        "internal_orders.CallingBoardResponse": {
+            "type": "object",
+            "properties": {
+                "business_date": {
+                    "type": "string"
+                },
+                "preparing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.CallingBoardEntry"
+                    }
+                },
+                "ready": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.CallingBoardEntry"
+                    }
+                }
+            }
+        },
+        "internal_orders.CancelOrderRequest": {
+            "type": "object",
+            "required": [
+                "cancellation_reason_id"
+            ],
+            "properties": {
+                "cancellation_notes": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "cancellation_reason_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_orders.ConfirmManualPaymentRequest": {
+            "type": "object",
+            "required": [
+                "payment_method_id",
+                "version"
+            ],
+            "properties": {
+                "cash_received": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "reference_number": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "remove_ineligible_promotion": {
+                    "description": "RemoveIneligiblePromotion strips a promotion that does not accept this payment method instead of rejecting the payment.",
+                    "type": "boolean"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_orders.CreateOrderItemOptionRequest": {
+            "type": "object",
+            "required": [
+                "product_option_id"
+            ],
+            "properties": {
+                "product_option_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_orders.CreateOrderItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.CreateOrderItemOptionRequest"
+                    }
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_orders.CreateOrderRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "type"
+            ],
+            "properties": {
+                "customer_id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.CreateOrderItemRequest"
+                    }
+                },
+                "type": {
+                    "enum": [
+                        "dine_in",
+                        "takeaway"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderType"
+                        }
+                    ]
+                }
+            }
+        },
+        "internal_orders.MergeOrdersRequest": {
+            "type": "object",
+            "required": [
+                "source_order_ids",
+                "version"
+            ],
+            "properties": {
+                "source_order_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_orders.OrderDetailResponse": {
+            "type": "object",
+            "properties": {
+                "amount_paid": {
+                    "type": "integer"
+                },
+                "amount_refunded": {
+                    "type": "integer"
+                },
+                "applied_promotion_code_id": {
+                    "type": "string"
+                },
+                "applied_promotion_id": {
+                    "type": "string"
+                },
+                "balance_due": {
+                    "type": "integer"
+                },
+                "business_date": {
+                    "type": "string"
+                },
+                "cash_received": {
+                    "type": "integer"
+                },
+                "change_due": {
+                    "type": "integer"
+                },
+                "child_order_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "string"
+                },
+                "discount_amount": {
+                    "type": "integer"
+                },
+                "gross_total": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.OrderItemResponse"
+                    }
+                },
+                "loyalty": {
+                    "$ref": "#/definitions/internal_orders.OrderLoyaltyResponse"
+                },
+                "net_total": {
+                    "type": "integer"
+                },
+                "parent_order_id": {
+                    "type": "string"
+                },
+                "payment_gateway_reference": {
+                    "type": "string"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.OrderPaymentResponse"
+                    }
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.AppliedPromotionResponse"
+                    }
+                },
+                "queue_number": {
+                    "type": "string"
+                },
+                "refunds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.OrderRefundResponse"
+                    }
+                },
+                "service_charge_amount": {
+                    "type": "integer"
+                },
+                "service_charge_rate": {
+                    "type": "number"
+                },
+                "status": {
+                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderStatus"
+                },
+                "status_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.OrderStatusHistoryResponse"
+                    }
+                },
+                "tax_amount": {
+                    "type": "integer"
+                },
+                "tax_inclusive": {
+                    "type": "boolean"
+                },
+                "tax_rate": {
+                    "type": "number"
+                },
+                "type": {
+                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderType"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_orders.OrderItemOptionResponse": {
+            "type": "object",
+            "properties": {
+                "option_name": {
+                    "type": "string"
+                },
+                "price_at_sale": {
+                    "type": "integer"
+                },
+                "product_option_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_orders.OrderItemResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "is_priority": {
+                    "type": "boolean"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.OrderItemOptionResponse"
+                    }
+                },
+                "prep_status": {
+                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderItemStatus"
+                },
+                "price_at_sale": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "station_id": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "string"
+                },
+                "variant_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_orders.OrderListResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_paid": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.OrderItemResponse"
+                    }
+                },
+                "net_total": {
+                    "type": "integer"
+                },
+                "queue_number": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderType"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_orders.OrderLoyaltyResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "discount_amount": {
+                    "type": "integer"
+                },
+                "points_earned": {
+                    "type": "integer"
+                },
+                "points_redeemed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_orders.OrderPaymentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "change_amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "payment_method_name": {
+                    "type": "string"
+                },
+                "reference_number": {
+                    "type": "string"
+                },
+                "tendered_amount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_orders.OrderRefundItemResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "order_item_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "restocked": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_orders.OrderRefundResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.OrderRefundItemResponse"
+                    }
+                },
+                "order_payment_id": {
+                    "type": "string"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_orders.OrderStatusHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "changed_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderStatus"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderStatus"
+                }
+            }
+        },
+        "internal_orders.PagedOrderResponse": {
+            "type": "object",
+            "properties": {
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.OrderListResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/POS-kasir_internal_common_pagination.Pagination"
+                }
+            }
+        },
+        "internal_orders.PromotionEvaluationResponse": {
+            "type": "object",
+            "properties": {
+                "current_discount": {
+                    "type": "integer"
+                },
+                "discount_amount": {
+                    "type": "integer"
+                },
+                "gross_total": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.PromotionEvaluationResult"
+                    }
+                },
+                "selected": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.AppliedPromotionResponse"
+                    }
+                }
+            }
+        },
+        "internal_orders.PromotionEvaluationResult": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "auto_apply": {
+                    "type": "boolean"
+                },
+                "discount_amount": {
+                    "type": "integer"
+                },
+                "eligible": {
+                    "type": "boolean"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "promotion_id": {
+                    "type": "string"
+                },
+                "promotion_name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requires_code": {
+                    "type": "boolean"
+                },
+                "selected": {
+                    "type": "boolean"
+                },
+                "stacking": {
+                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.PromotionStacking"
+                }
+            }
+        },
+        "internal_orders.RedeemPointsRequest": {
+            "type": "object",
+            "required": [
+                "points"
+            ],
+            "properties": {
+                "points": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "internal_orders.RefundOrderItem": {
+            "type": "object",
+            "required": [
+                "order_item_id",
+                "quantity"
+            ],
+            "properties": {
+                "order_item_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_orders.RefundOrderRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.RefundOrderItem"
+                    }
+                },
+                "order_payment_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "restock": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_orders.ReopenOrderRequest": {
+            "type": "object",
+            "required": [
+                "reason",
+                "version"
             ],
-            "x-enum-varnames": [
-                "LogEntityTypePRODUCT",
-                "LogEntityTypeCATEGORY",
-                "LogEntityTypePROMOTION",
-                "LogEntityTypeORDER",
-                "LogEntityTypeUSER",
-                "LogEntityTypeSETTINGS",
-                "LogEntityTypeSHIFT",
-                "LogEntityTypePAYMENTMETHOD",
-                "LogEntityTypeCANCELLATIONREASON"
-            ]
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_orders.ScanOrderItemRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_orders.SplitOrderItem": {
+            "type": "object",
+            "required": [
+                "order_item_id",
+                "quantity"
+            ],
+            "properties": {
+                "order_item_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_orders.SplitOrderPart": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.SplitOrderItem"
+                    }
+                }
+            }
+        },
+        "internal_orders.SplitOrderRequest": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "parts": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.SplitOrderPart"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "ways": {
+                    "type": "integer",
+                    "maximum": 20,
+                    "minimum": 2
+                }
+            }
+        },
+        "internal_orders.SplitOrderResponse": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.OrderDetailResponse"
+                    }
+                },
+                "parent": {
+                    "$ref": "#/definitions/internal_orders.OrderDetailResponse"
+                }
+            }
+        },
+        "internal_orders.UpdateOrderItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.CreateOrderItemOptionRequest"
+                    }
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_orders.UpdateOrderItemsRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "version"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/internal_orders.UpdateOrderItemRequest"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_orders.UpdateOrderStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "enum": [
+                        "open",
+                        "in_progress",
+                        "served",
+                        "paid",
+                        "cancelled"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "internal_payment_methods.CreatePaymentMethodRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "allows_change": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "enum": [
+                        "cash",
+                        "card",
+                        "e_wallet",
+                        "gateway",
+                        "voucher",
+                        "on_account",
+                        "loyalty_points"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/POS-kasir_internal_payment_methods_repository.PaymentMethodKind"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "opens_cash_drawer": {
+                    "type": "boolean"
+                },
+                "requires_reference": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_payment_methods.PaymentMethodResponse": {
+            "type": "object",
+            "properties": {
+                "allows_change": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "$ref": "#/definitions/POS-kasir_internal_payment_methods_repository.PaymentMethodKind"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opens_cash_drawer": {
+                    "type": "boolean"
+                },
+                "requires_reference": {
+                    "type": "boolean"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_payment_methods.ReorderPaymentMethodsRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "internal_payment_methods.UpdatePaymentMethodRequest": {
+            "type": "object",
+            "properties": {
+                "allows_change": {
+                    "type": "boolean"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "enum": [
+                        "cash",
+                        "card",
+                        "e_wallet",
+                        "gateway",
+                        "voucher",
+                        "on_account",
+                        "loyalty_points"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/POS-kasir_internal_payment_methods_repository.PaymentMethodKind"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "opens_cash_drawer": {
+                    "type": "boolean"
+                },
+                "requires_reference": {
+                    "type": "boolean"
+                }
+            }
         },
-        "POS-kasir_internal_common.ErrorResponse": {
+        "internal_products.AddProductBarcodeRequest": {
             "type": "object",
             "properties": {
-                "data": {},
-                "error": {},
-                "message": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1
+                },
+                "generate": {
+                    "type": "boolean"
+                },
+                "symbology": {
+                    "type": "string",
+                    "enum": [
+                        "ean13",
+                        "ean8",
+                        "upc_a",
+                        "internal"
+                    ]
+                },
+                "variant_id": {
                     "type": "string"
                 }
             }
         },
-        "POS-kasir_internal_common.SuccessResponse": {
+        "internal_products.CreateModifierGroupRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "data": {},
-                "message": {
-                    "type": "string"
+                "max_select": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "min_select": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
                 }
             }
         },
-        "POS-kasir_internal_common_pagination.Pagination": {
+        "internal_products.CreateProductOptionRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "current_page": {
-                    "type": "integer"
+                "additional_price": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "per_page": {
-                    "type": "integer"
+                "is_default": {
+                    "type": "boolean"
                 },
-                "total_data": {
-                    "type": "integer"
+                "modifier_group_id": {
+                    "type": "string"
                 },
-                "total_page": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
                 }
             }
         },
-        "POS-kasir_internal_orders_repository.OrderStatus": {
-            "type": "string",
-            "enum": [
-                "open",
-                "in_progress",
-                "served",
-                "paid",
-                "cancelled"
-            ],
-            "x-enum-varnames": [
-                "OrderStatusOpen",
-                "OrderStatusInProgress",
-                "OrderStatusServed",
-                "OrderStatusPaid",
-                "OrderStatusCancelled"
-            ]
-        },
-        "POS-kasir_internal_orders_repository.OrderType": {
-            "type": "string",
-            "enum": [
-                "dine_in",
-                "takeaway"
-            ],
-            "x-enum-varnames": [
-                "OrderTypeDineIn",
-                "OrderTypeTakeaway"
-            ]
-        },
-        "POS-kasir_internal_promotions_repository.DiscountType": {
-            "type": "string",
-            "enum": [
-                "percentage",
-                "fixed_amount"
-            ],
-            "x-enum-varnames": [
-                "DiscountTypePercentage",
-                "DiscountTypeFixedAmount"
-            ]
-        },
-        "POS-kasir_internal_promotions_repository.PromotionRuleType": {
-            "type": "string",
-            "enum": [
-                "MINIMUM_ORDER_AMOUNT",
-                "REQUIRED_PRODUCT",
-                "REQUIRED_CATEGORY",
-                "ALLOWED_PAYMENT_METHOD",
-                "ALLOWED_ORDER_TYPE"
-            ],
-            "x-enum-varnames": [
-                "PromotionRuleTypeMINIMUMORDERAMOUNT",
-                "PromotionRuleTypeREQUIREDPRODUCT",
-                "PromotionRuleTypeREQUIREDCATEGORY",
-                "PromotionRuleTypeALLOWEDPAYMENTMETHOD",
-                "PromotionRuleTypeALLOWEDORDERTYPE"
-            ]
-        },
-        "POS-kasir_internal_promotions_repository.PromotionScope": {
-            "type": "string",
-            "enum": [
-                "ORDER",
-                "ITEM"
-            ],
-            "x-enum-varnames": [
-                "PromotionScopeORDER",
-                "PromotionScopeITEM"
-            ]
-        },
-        "POS-kasir_internal_promotions_repository.PromotionTargetType": {
-            "type": "string",
-            "enum": [
-                "PRODUCT",
-                "CATEGORY"
-            ],
-            "x-enum-varnames": [
-                "PromotionTargetTypePRODUCT",
-                "PromotionTargetTypeCATEGORY"
-            ]
-        },
-        "POS-kasir_internal_shift_repository.CashTransactionType": {
-            "type": "string",
-            "enum": [
-                "cash_in",
-                "cash_out"
-            ],
-            "x-enum-varnames": [
-                "CashTransactionTypeCashIn",
-                "CashTransactionTypeCashOut"
-            ]
-        },
-        "POS-kasir_internal_shift_repository.ShiftStatus": {
-            "type": "string",
-            "enum": [
-                "open",
-                "closed"
-            ],
-            "x-enum-varnames": [
-                "ShiftStatusOpen",
-                "ShiftStatusClosed"
-            ]
-        },
-        "POS-kasir_internal_user_repository.UserRole": {
-            "type": "string",
-            "enum": [
-                "admin",
-                "cashier",
-                "manager"
-            ],
-            "x-enum-varnames": [
-                "UserRoleAdmin",
-                "UserRoleCashier",
-                "UserRoleManager"
-            ]
-        },
-        "POS-kasir_pkg_payment.MidtransNotificationPayload": {
+        "internal_products.CreateProductOptionRequestStandalone": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "currency": {
-                    "type": "string"
+                "additional_price": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "fraud_status": {
-                    "type": "string"
+                "is_default": {
+                    "type": "boolean"
                 },
-                "gross_amount": {
+                "modifier_group_id": {
                     "type": "string"
                 },
-                "merchant_id": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "internal_products.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "barcodes",
+                "cost_price",
+                "name",
+                "price",
+                "stock"
+            ],
+            "properties": {
+                "barcodes": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "order_id": {
-                    "type": "string"
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "payment_type": {
-                    "type": "string"
+                "cost_price": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "signature_key": {
-                    "type": "string"
+                "generate_barcode": {
+                    "type": "boolean"
                 },
-                "status_code": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
                 },
-                "status_message": {
-                    "type": "string"
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_products.CreateProductOptionRequest"
+                    }
                 },
-                "transaction_id": {
-                    "type": "string"
+                "price": {
+                    "type": "number"
                 },
-                "transaction_status": {
-                    "type": "string"
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1
                 },
-                "transaction_time": {
-                    "type": "string"
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "internal_products.GenerateBarcodesResponse": {
+            "type": "object",
+            "properties": {
+                "barcodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_products.ProductBarcodeResponse"
+                    }
+                },
+                "generated": {
+                    "type": "integer"
                 }
             }
         },
-        "fiber.Map": {
-            "type": "object",
-            "additionalProperties": {}
-        },
-        "internal_activitylog.ActivityLogListResponse": {
+        "internal_products.GenerateVariantsRequest": {
             "type": "object",
+            "required": [
+                "attributes",
+                "sku_prefix"
+            ],
             "properties": {
-                "limit": {
-                    "type": "integer"
-                },
-                "logs": {
+                "attributes": {
                     "type": "array",
+                    "maxItems": 3,
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/internal_activitylog.ActivityLogResponse"
+                        "$ref": "#/definitions/internal_products.VariantAttributeRequest"
                     }
                 },
-                "page": {
-                    "type": "integer"
+                "cost_price": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "total_items": {
-                    "type": "integer"
+                "price": {
+                    "type": "number"
                 },
-                "total_pages": {
-                    "type": "integer"
+                "sku_prefix": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "minLength": 1
                 }
             }
         },
-        "internal_activitylog.ActivityLogResponse": {
+        "internal_products.ImportFieldChange": {
             "type": "object",
             "properties": {
-                "action_type": {
-                    "$ref": "#/definitions/POS-kasir_internal_activitylog_repository.LogActionType"
-                },
-                "created_at": {
+                "field": {
                     "type": "string"
                 },
-                "details": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "entity_id": {
-                    "type": "string"
-                },
-                "entity_type": {
-                    "$ref": "#/definitions/POS-kasir_internal_activitylog_repository.LogEntityType"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "user_id": {
+                "from": {
                     "type": "string"
                 },
-                "user_name": {
+                "to": {
                     "type": "string"
                 }
             }
         },
-        "internal_cancellation_reasons.CancellationReasonResponse": {
+        "internal_products.ImportProductsResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "is_active": {
+                "dry_run": {
                     "type": "boolean"
                 },
-                "reason": {
-                    "type": "string"
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_products.ImportRowResult"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/internal_products.ImportSummary"
                 }
             }
         },
-        "internal_categories.CategoryResponse": {
+        "internal_products.ImportRowResult": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "action": {
                     "type": "string"
                 },
-                "id": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_products.ImportFieldChange"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "line": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "updated_at": {
+                "product_id": {
+                    "type": "string"
+                },
+                "sku": {
                     "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "internal_categories.CategoryWithCountResponse": {
+        "internal_products.ImportSummary": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "create": {
+                    "type": "integer"
                 },
-                "id": {
+                "error": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "skip": {
+                    "type": "integer"
                 },
-                "product_count": {
+                "total": {
                     "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
+                "update": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_categories.CreateCategoryRequest": {
+        "internal_products.ListProductsResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
+                "pagination": {
+                    "$ref": "#/definitions/POS-kasir_internal_common_pagination.Pagination"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_products.ProductListResponse"
+                    }
                 }
             }
         },
-        "internal_customers.CreateCustomerRequest": {
+        "internal_products.ModifierGroupOptionResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "address": {
+                "additional_price": {
+                    "type": "number"
+                },
+                "id": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string",
-                    "maxLength": 255
+                "is_default": {
+                    "type": "boolean"
                 },
                 "name": {
-                    "type": "string",
-                    "maxLength": 100
+                    "type": "string"
                 },
-                "phone": {
-                    "type": "string",
-                    "maxLength": 20
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
                 }
             }
         },
-        "internal_customers.CustomerResponse": {
+        "internal_products.ModifierGroupResponse": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "created_at": {
+                "id": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
+                "max_select": {
+                    "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "min_select": {
+                    "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "phone": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_products.ModifierGroupOptionResponse"
+                    }
                 }
             }
         },
-        "internal_customers.PagedCustomerResponse": {
+        "internal_products.PagedStockHistoryResponse": {
             "type": "object",
             "properties": {
-                "customers": {
+                "history": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/internal_customers.CustomerResponse"
+                        "$ref": "#/definitions/internal_products.StockHistoryResponse"
                     }
                 },
                 "pagination": {
@@ -5854,517 +13478,773 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_customers.UpdateCustomerRequest": {
+        "internal_products.ProductBarcodeResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "address": {
+                "code": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string",
-                    "maxLength": 255
+                "created_at": {
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100
+                "id": {
+                    "type": "string"
                 },
-                "phone": {
-                    "type": "string",
-                    "maxLength": 20
+                "is_generated": {
+                    "type": "boolean"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "symbology": {
+                    "type": "string"
+                },
+                "variant_id": {
+                    "type": "string"
                 }
             }
         },
-        "internal_orders.ApplyPromotionRequest": {
+        "internal_products.ProductCategoryResponse": {
             "type": "object",
-            "required": [
-                "promotion_id"
-            ],
             "properties": {
-                "promotion_id": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "internal_orders.CancelOrderRequest": {
+        "internal_products.ProductListResponse": {
             "type": "object",
-            "required": [
-                "cancellation_reason_id"
-            ],
             "properties": {
-                "cancellation_notes": {
-                    "type": "string",
-                    "maxLength": 255
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_products.ProductCategoryResponse"
+                    }
                 },
-                "cancellation_reason_id": {
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
                     "type": "integer"
                 }
             }
         },
-        "internal_orders.ConfirmManualPaymentRequest": {
+        "internal_products.ProductLookupResponse": {
             "type": "object",
-            "required": [
-                "payment_method_id",
-                "version"
-            ],
             "properties": {
-                "cash_received": {
-                    "type": "integer",
-                    "minimum": 0
+                "code": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "matched_by": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
                 },
-                "payment_method_id": {
-                    "type": "integer"
+                "sku": {
+                    "type": "string"
                 },
-                "version": {
+                "stock": {
                     "type": "integer"
+                },
+                "variant_id": {
+                    "type": "string"
+                },
+                "variant_name": {
+                    "type": "string"
                 }
             }
         },
-        "internal_orders.CreateOrderItemOptionRequest": {
+        "internal_products.ProductOptionResponse": {
             "type": "object",
-            "required": [
-                "product_option_id"
-            ],
             "properties": {
-                "product_option_id": {
+                "additional_price": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "modifier_group_id": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "internal_orders.CreateOrderItemRequest": {
+        "internal_products.ProductResponse": {
             "type": "object",
-            "required": [
-                "product_id",
-                "quantity"
-            ],
             "properties": {
-                "options": {
+                "barcodes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/internal_orders.CreateOrderItemOptionRequest"
+                        "$ref": "#/definitions/internal_products.ProductBarcodeResponse"
                     }
                 },
-                "product_id": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_products.ProductCategoryResponse"
+                    }
+                },
+                "cost_price": {
+                    "type": "number"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
-                }
-            }
-        },
-        "internal_orders.CreateOrderRequest": {
-            "type": "object",
-            "required": [
-                "items",
-                "type"
-            ],
-            "properties": {
-                "customer_id": {
+                "deleted_at": {
                     "type": "string"
                 },
-                "items": {
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "modifier_groups": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/internal_orders.CreateOrderItemRequest"
+                        "$ref": "#/definitions/internal_products.ModifierGroupResponse"
                     }
                 },
-                "type": {
-                    "enum": [
-                        "dine_in",
-                        "takeaway"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderType"
-                        }
-                    ]
-                }
-            }
-        },
-        "internal_orders.MidtransPaymentResponse": {
-            "type": "object",
-            "properties": {
-                "actions": {
+                "name": {
+                    "type": "string"
+                },
+                "options": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/internal_orders.PaymentAction"
+                        "$ref": "#/definitions/internal_products.ProductOptionResponse"
                     }
                 },
-                "expiry_time": {
-                    "type": "string"
+                "price": {
+                    "type": "number"
                 },
-                "gross_amount": {
+                "sku": {
                     "type": "string"
                 },
-                "order_id": {
-                    "type": "string"
+                "stock": {
+                    "type": "integer"
                 },
-                "qr_string": {
+                "updated_at": {
                     "type": "string"
                 },
-                "transaction_id": {
-                    "type": "string"
+                "variant_attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_products.VariantAttributeResponse"
+                    }
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_products.ProductVariantResponse"
+                    }
                 }
             }
         },
-        "internal_orders.OrderDetailResponse": {
+        "internal_products.ProductVariantResponse": {
             "type": "object",
             "properties": {
-                "applied_promotion_id": {
-                    "type": "string"
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "cash_received": {
-                    "type": "integer"
+                "barcode": {
+                    "type": "string"
                 },
-                "change_due": {
-                    "type": "integer"
+                "cost_price": {
+                    "type": "number"
                 },
-                "created_at": {
+                "id": {
                     "type": "string"
                 },
-                "customer_id": {
+                "name": {
                     "type": "string"
                 },
-                "discount_amount": {
-                    "type": "integer"
+                "price": {
+                    "type": "number"
                 },
-                "gross_total": {
-                    "type": "integer"
+                "price_override": {
+                    "type": "number"
                 },
-                "id": {
+                "sku": {
                     "type": "string"
                 },
-                "items": {
+                "stock": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_products.ProductVariantsResponse": {
+            "type": "object",
+            "properties": {
+                "attributes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/internal_orders.OrderItemResponse"
+                        "$ref": "#/definitions/internal_products.VariantAttributeResponse"
                     }
                 },
-                "net_total": {
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_products.ProductVariantResponse"
+                    }
+                }
+            }
+        },
+        "internal_products.RestoreBulkRequest": {
+            "type": "object",
+            "required": [
+                "product_ids"
+            ],
+            "properties": {
+                "product_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_products.StockHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "change_amount": {
                     "type": "integer"
                 },
-                "payment_gateway_reference": {
+                "change_type": {
                     "type": "string"
                 },
-                "payment_method_id": {
-                    "type": "integer"
+                "created_at": {
+                    "type": "string"
                 },
-                "service_charge_amount": {
+                "created_by": {
+                    "type": "string"
+                },
+                "current_stock": {
                     "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderStatus"
+                "id": {
+                    "type": "string"
                 },
-                "tax_amount": {
-                    "type": "integer"
+                "note": {
+                    "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderType"
+                "previous_stock": {
+                    "type": "integer"
                 },
-                "updated_at": {
+                "product_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "reference_id": {
                     "type": "string"
                 },
-                "version": {
-                    "type": "integer"
+                "variant_id": {
+                    "type": "string"
                 }
             }
         },
-        "internal_orders.OrderItemOptionResponse": {
+        "internal_products.UpdateModifierGroupRequest": {
             "type": "object",
             "properties": {
-                "option_name": {
-                    "type": "string"
+                "clear_max_select": {
+                    "type": "boolean"
                 },
-                "price_at_sale": {
-                    "type": "integer"
+                "max_select": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "product_option_id": {
-                    "type": "string"
+                "min_select": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
                 }
             }
         },
-        "internal_orders.OrderItemResponse": {
+        "internal_products.UpdateProductOptionRequest": {
             "type": "object",
             "properties": {
-                "id": {
+                "additional_price": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "clear_modifier_group": {
+                    "type": "boolean"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "modifier_group_id": {
                     "type": "string"
                 },
-                "options": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "internal_products.UpdateProductRequest": {
+            "type": "object",
+            "properties": {
+                "category_ids": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/internal_orders.OrderItemOptionResponse"
+                        "type": "integer"
                     }
                 },
-                "price_at_sale": {
-                    "type": "integer"
+                "change_type": {
+                    "type": "string",
+                    "enum": [
+                        "sale",
+                        "restock",
+                        "correction",
+                        "return",
+                        "damage"
+                    ]
+                },
+                "cost_price": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "product_id": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
                 },
-                "product_name": {
-                    "type": "string"
+                "note": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "quantity": {
-                    "type": "integer"
+                "price": {
+                    "type": "number"
                 },
-                "subtotal": {
-                    "type": "integer"
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "internal_orders.OrderListResponse": {
+        "internal_products.UpdateVariantRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "barcode": {
+                    "type": "string",
+                    "maxLength": 64
                 },
-                "id": {
-                    "type": "string"
+                "change_type": {
+                    "type": "string",
+                    "enum": [
+                        "sale",
+                        "restock",
+                        "correction",
+                        "return",
+                        "damage"
+                    ]
                 },
-                "is_paid": {
+                "clear_price": {
                     "type": "boolean"
                 },
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_orders.OrderItemResponse"
-                    }
-                },
-                "net_total": {
-                    "type": "integer"
+                "cost_price": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "queue_number": {
-                    "type": "string"
+                "note": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "status": {
-                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderStatus"
+                "price": {
+                    "type": "number"
                 },
-                "type": {
-                    "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderType"
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1
                 },
-                "user_id": {
-                    "type": "string"
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "internal_orders.PagedOrderResponse": {
+        "internal_products.VariantAttributeRequest": {
             "type": "object",
+            "required": [
+                "name",
+                "values"
+            ],
             "properties": {
-                "orders": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                },
+                "values": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/internal_orders.OrderListResponse"
+                        "type": "string"
                     }
-                },
-                "pagination": {
-                    "$ref": "#/definitions/POS-kasir_internal_common_pagination.Pagination"
                 }
             }
         },
-        "internal_orders.PaymentAction": {
+        "internal_products.VariantAttributeResponse": {
             "type": "object",
             "properties": {
-                "method": {
+                "id": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "url": {
-                    "type": "string"
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "internal_orders.RefundOrderRequest": {
+        "internal_promotions.CreatePromotionCodeRequest": {
             "type": "object",
             "required": [
-                "reason"
+                "code"
             ],
             "properties": {
-                "reason": {
-                    "type": "string"
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 3
+                },
+                "max_uses": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_orders.UpdateOrderItemRequest": {
+        "internal_promotions.CreatePromotionRequest": {
             "type": "object",
             "required": [
-                "product_id",
-                "quantity"
+                "discount_type",
+                "end_date",
+                "name",
+                "scope",
+                "start_date"
             ],
             "properties": {
-                "options": {
+                "auto_apply": {
+                    "description": "Evaluation: auto-apply promotions are picked for an order without the cashier naming them. An exclusive\npromotion (the default) is never combined with others, stackable ones combine; the highest priority wins\nfirst, the larger discount breaks ties.",
+                    "type": "boolean"
+                },
+                "budget_amount": {
+                    "type": "integer"
+                },
+                "bundle_quantity": {
+                    "type": "integer"
+                },
+                "buy_quantity": {
+                    "description": "Item mechanics, see DiscountType",
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "enum": [
+                        "percentage",
+                        "fixed_amount",
+                        "buy_x_get_y",
+                        "bundle_price",
+                        "tiered"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/POS-kasir_internal_promotions_repository.DiscountType"
+                        }
+                    ]
+                },
+                "discount_value": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "excluded_dates": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/internal_orders.CreateOrderItemOptionRequest"
+                        "type": "string"
                     }
                 },
-                "product_id": {
-                    "type": "string"
+                "get_quantity": {
+                    "type": "integer"
                 },
-                "quantity": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_discount_amount": {
                     "type": "integer"
-                }
-            }
-        },
-        "internal_orders.UpdateOrderItemsRequest": {
-            "type": "object",
-            "required": [
-                "items",
-                "version"
-            ],
-            "properties": {
-                "items": {
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "per_customer_limit": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "rules": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/internal_orders.UpdateOrderItemRequest"
+                        "$ref": "#/definitions/internal_promotions.CreatePromotionRuleRequest"
                     }
                 },
-                "version": {
+                "schedules": {
+                    "description": "Recurring schedule within StartDate and EndDate; without schedules the promotion runs all day. Excluded\ndates (YYYY-MM-DD, store dates) such as public holidays are skipped either way.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_promotions.CreatePromotionScheduleRequest"
+                    }
+                },
+                "scope": {
+                    "enum": [
+                        "ORDER",
+                        "ITEM"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionScope"
+                        }
+                    ]
+                },
+                "stacking": {
+                    "enum": [
+                        "exclusive",
+                        "stackable"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionStacking"
+                        }
+                    ]
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_promotions.CreatePromotionTargetRequest"
+                    }
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_promotions.CreatePromotionTierRequest"
+                    }
+                },
+                "total_redemption_limit": {
+                    "description": "Usage limits; left out means unlimited. The budget caps the total discount given across redemptions.",
                     "type": "integer"
                 }
             }
         },
-        "internal_orders.UpdateOrderStatusRequest": {
+        "internal_promotions.CreatePromotionRuleRequest": {
             "type": "object",
             "required": [
-                "status"
+                "rule_type",
+                "rule_value"
             ],
             "properties": {
-                "status": {
+                "description": {
+                    "type": "string"
+                },
+                "rule_type": {
                     "enum": [
-                        "open",
-                        "in_progress",
-                        "served",
-                        "paid",
-                        "cancelled"
+                        "MINIMUM_ORDER_AMOUNT",
+                        "REQUIRED_PRODUCT",
+                        "REQUIRED_CATEGORY",
+                        "ALLOWED_PAYMENT_METHOD",
+                        "ALLOWED_ORDER_TYPE"
                     ],
                     "allOf": [
                         {
-                            "$ref": "#/definitions/POS-kasir_internal_orders_repository.OrderStatus"
+                            "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionRuleType"
                         }
                     ]
+                },
+                "rule_value": {
+                    "description": "RuleValue for ALLOWED_ORDER_TYPE is a comma separated list of order types (dine_in, takeaway);\nfor ALLOWED_PAYMENT_METHOD a comma separated list of payment method IDs or names.",
+                    "type": "string"
                 }
             }
         },
-        "internal_payment_methods.PaymentMethodResponse": {
+        "internal_promotions.CreatePromotionScheduleRequest": {
             "type": "object",
+            "required": [
+                "days_of_week",
+                "end_time",
+                "start_time"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
+                "days_of_week": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "is_active": {
-                    "type": "boolean"
+                "end_time": {
+                    "type": "string",
+                    "example": "17:00"
                 },
-                "name": {
-                    "type": "string"
+                "start_time": {
+                    "type": "string",
+                    "example": "15:00"
                 }
             }
         },
-        "internal_products.CreateProductOptionRequest": {
+        "internal_promotions.CreatePromotionTargetRequest": {
             "type": "object",
             "required": [
-                "name"
+                "target_id",
+                "target_type"
             ],
             "properties": {
-                "additional_price": {
-                    "type": "number",
-                    "minimum": 0
+                "target_id": {
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                "target_type": {
+                    "enum": [
+                        "PRODUCT",
+                        "CATEGORY"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionTargetType"
+                        }
+                    ]
                 }
             }
         },
-        "internal_products.CreateProductOptionRequestStandalone": {
+        "internal_promotions.CreatePromotionTierRequest": {
             "type": "object",
             "required": [
-                "name"
+                "discount_type",
+                "discount_value"
             ],
             "properties": {
-                "additional_price": {
-                    "type": "number",
-                    "minimum": 0
+                "discount_type": {
+                    "enum": [
+                        "percentage",
+                        "fixed_amount"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/POS-kasir_internal_promotions_repository.DiscountType"
+                        }
+                    ]
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                "discount_value": {
+                    "type": "integer"
+                },
+                "min_amount": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "internal_products.CreateProductRequest": {
+        "internal_promotions.GeneratePromotionCodesRequest": {
             "type": "object",
             "required": [
-                "cost_price",
-                "name",
-                "price",
-                "stock"
+                "count"
             ],
             "properties": {
-                "category_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "count": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
                 },
-                "cost_price": {
-                    "type": "number",
-                    "minimum": 0
+                "length": {
+                    "description": "Length of the random part of each code, 8 when left out",
+                    "type": "integer",
+                    "maximum": 16,
+                    "minimum": 6
                 },
-                "name": {
+                "prefix": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
-                },
-                "options": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_products.CreateProductOptionRequest"
-                    }
-                },
-                "price": {
-                    "type": "number"
-                },
-                "stock": {
-                    "type": "integer",
-                    "minimum": 0
+                    "maxLength": 16
                 }
             }
         },
-        "internal_products.ListProductsResponse": {
+        "internal_promotions.GeneratePromotionCodesResponse": {
             "type": "object",
             "properties": {
-                "pagination": {
-                    "$ref": "#/definitions/POS-kasir_internal_common_pagination.Pagination"
+                "batch_id": {
+                    "type": "string"
                 },
-                "products": {
+                "codes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/internal_products.ProductListResponse"
+                        "$ref": "#/definitions/internal_promotions.PromotionCodeResponse"
                     }
                 }
             }
         },
-        "internal_products.PagedStockHistoryResponse": {
+        "internal_promotions.PagedPromotionCodeResponse": {
             "type": "object",
             "properties": {
-                "history": {
+                "codes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/internal_products.StockHistoryResponse"
+                        "$ref": "#/definitions/internal_promotions.PromotionCodeResponse"
                     }
                 },
                 "pagination": {
@@ -6372,74 +14252,70 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_products.ProductCategoryResponse": {
+        "internal_promotions.PagedPromotionResponse": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
+                "pagination": {
+                    "$ref": "#/definitions/POS-kasir_internal_common_pagination.Pagination"
                 },
-                "name": {
-                    "type": "string"
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_promotions.PromotionResponse"
+                    }
                 }
             }
         },
-        "internal_products.ProductListResponse": {
+        "internal_promotions.PromotionCodeResponse": {
             "type": "object",
             "properties": {
-                "categories": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_products.ProductCategoryResponse"
-                    }
+                "batch_id": {
+                    "type": "string"
                 },
-                "deleted_at": {
+                "code": {
                     "type": "string"
                 },
-                "id": {
+                "created_at": {
                     "type": "string"
                 },
-                "image_url": {
+                "deactivated_at": {
                     "type": "string"
                 },
-                "name": {
+                "id": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "stock": {
+                "max_uses": {
                     "type": "integer"
-                }
-            }
-        },
-        "internal_products.ProductOptionResponse": {
-            "type": "object",
-            "properties": {
-                "additional_price": {
-                    "type": "number"
-                },
-                "id": {
-                    "type": "string"
                 },
-                "image_url": {
+                "promotion_id": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "redemption_count": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_products.ProductResponse": {
+        "internal_promotions.PromotionResponse": {
             "type": "object",
             "properties": {
-                "categories": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_products.ProductCategoryResponse"
-                    }
+                "auto_apply": {
+                    "type": "boolean"
                 },
-                "cost_price": {
-                    "type": "number"
+                "available_now": {
+                    "description": "AvailableNow is whether the promotion is active, within its dates and on schedule at the store's\ncurrent time",
+                    "type": "boolean"
+                },
+                "budget_amount": {
+                    "type": "integer"
+                },
+                "bundle_quantity": {
+                    "type": "integer"
+                },
+                "buy_quantity": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
@@ -6447,155 +14323,192 @@ const docTemplate = `{
                 "deleted_at": {
                     "type": "string"
                 },
-                "id": {
+                "description": {
                     "type": "string"
                 },
-                "image_url": {
-                    "type": "string"
+                "discount_type": {
+                    "$ref": "#/definitions/POS-kasir_internal_promotions_repository.DiscountType"
                 },
-                "name": {
+                "discount_value": {
+                    "type": "integer"
+                },
+                "end_date": {
                     "type": "string"
                 },
-                "options": {
+                "excluded_dates": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/internal_products.ProductOptionResponse"
+                        "type": "string"
                     }
                 },
-                "price": {
-                    "type": "number"
+                "get_quantity": {
+                    "type": "integer"
                 },
-                "stock": {
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_discount_amount": {
                     "type": "integer"
                 },
-                "updated_at": {
+                "name": {
                     "type": "string"
-                }
-            }
-        },
-        "internal_products.RestoreBulkRequest": {
-            "type": "object",
-            "required": [
-                "product_ids"
-            ],
-            "properties": {
-                "product_ids": {
+                },
+                "per_customer_limit": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "rules": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/internal_promotions.PromotionRuleResponse"
                     }
-                }
-            }
-        },
-        "internal_products.StockHistoryResponse": {
-            "type": "object",
-            "properties": {
-                "change_amount": {
-                    "type": "integer"
                 },
-                "change_type": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_promotions.PromotionScheduleResponse"
+                    }
                 },
-                "created_by": {
-                    "type": "string"
+                "scope": {
+                    "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionScope"
                 },
-                "current_stock": {
-                    "type": "integer"
+                "stacking": {
+                    "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionStacking"
                 },
-                "id": {
+                "start_date": {
                     "type": "string"
                 },
-                "note": {
-                    "type": "string"
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_promotions.PromotionTargetResponse"
+                    }
                 },
-                "previous_stock": {
-                    "type": "integer"
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_promotions.PromotionTierResponse"
+                    }
                 },
-                "product_id": {
-                    "type": "string"
+                "total_redemption_limit": {
+                    "type": "integer"
                 },
-                "reference_id": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "internal_products.UpdateProductOptionRequest": {
+        "internal_promotions.PromotionRuleResponse": {
             "type": "object",
             "properties": {
-                "additional_price": {
-                    "type": "number",
-                    "minimum": 0
+                "description": {
+                    "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                "id": {
+                    "type": "string"
+                },
+                "rule_type": {
+                    "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionRuleType"
+                },
+                "rule_value": {
+                    "type": "string"
                 }
             }
         },
-        "internal_products.UpdateProductRequest": {
+        "internal_promotions.PromotionScheduleResponse": {
             "type": "object",
             "properties": {
-                "category_ids": {
+                "days_of_week": {
                     "type": "array",
                     "items": {
                         "type": "integer"
                     }
                 },
-                "change_type": {
-                    "type": "string",
-                    "enum": [
-                        "sale",
-                        "restock",
-                        "correction",
-                        "return",
-                        "damage"
-                    ]
+                "end_time": {
+                    "type": "string"
                 },
-                "cost_price": {
-                    "type": "number",
-                    "minimum": 0
+                "id": {
+                    "type": "string"
                 },
-                "name": {
+                "label": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
+                    "example": "Mon-Fri 15:00-17:00"
                 },
-                "note": {
-                    "type": "string",
-                    "maxLength": 255
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_promotions.PromotionTargetResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "target_id": {
+                    "type": "string"
                 },
-                "stock": {
-                    "type": "integer",
-                    "minimum": 0
+                "target_type": {
+                    "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionTargetType"
                 }
             }
         },
-        "internal_promotions.CreatePromotionRequest": {
+        "internal_promotions.PromotionTierResponse": {
+            "type": "object",
+            "properties": {
+                "discount_type": {
+                    "$ref": "#/definitions/POS-kasir_internal_promotions_repository.DiscountType"
+                },
+                "discount_value": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "min_amount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_promotions.UpdatePromotionRequest": {
             "type": "object",
             "required": [
                 "discount_type",
-                "discount_value",
                 "end_date",
                 "name",
                 "scope",
                 "start_date"
             ],
             "properties": {
+                "auto_apply": {
+                    "description": "Evaluation: auto-apply promotions are picked for an order without the cashier naming them. An exclusive\npromotion (the default) is never combined with others, stackable ones combine; the highest priority wins\nfirst, the larger discount breaks ties.",
+                    "type": "boolean"
+                },
+                "budget_amount": {
+                    "type": "integer"
+                },
+                "bundle_quantity": {
+                    "type": "integer"
+                },
+                "buy_quantity": {
+                    "description": "Item mechanics, see DiscountType",
+                    "type": "integer"
+                },
                 "description": {
                     "type": "string"
                 },
                 "discount_type": {
                     "enum": [
                         "percentage",
-                        "fixed_amount"
+                        "fixed_amount",
+                        "buy_x_get_y",
+                        "bundle_price",
+                        "tiered"
                     ],
                     "allOf": [
                         {
@@ -6604,11 +14517,21 @@ const docTemplate = `{
                     ]
                 },
                 "discount_value": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 },
                 "end_date": {
                     "type": "string"
                 },
+                "excluded_dates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "get_quantity": {
+                    "type": "integer"
+                },
                 "is_active": {
                     "type": "boolean"
                 },
@@ -6619,12 +14542,25 @@ const docTemplate = `{
                     "type": "string",
                     "minLength": 3
                 },
+                "per_customer_limit": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
                 "rules": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/internal_promotions.CreatePromotionRuleRequest"
                     }
                 },
+                "schedules": {
+                    "description": "Recurring schedule within StartDate and EndDate; without schedules the promotion runs all day. Excluded\ndates (YYYY-MM-DD, store dates) such as public holidays are skipped either way.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_promotions.CreatePromotionScheduleRequest"
+                    }
+                },
                 "scope": {
                     "enum": [
                         "ORDER",
@@ -6636,6 +14572,17 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "stacking": {
+                    "enum": [
+                        "exclusive",
+                        "stackable"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionStacking"
+                        }
+                    ]
+                },
                 "start_date": {
                     "type": "string"
                 },
@@ -6644,228 +14591,403 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/internal_promotions.CreatePromotionTargetRequest"
                     }
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_promotions.CreatePromotionTierRequest"
+                    }
+                },
+                "total_redemption_limit": {
+                    "description": "Usage limits; left out means unlimited. The budget caps the total discount given across redemptions.",
+                    "type": "integer"
                 }
             }
         },
-        "internal_promotions.CreatePromotionRuleRequest": {
+        "internal_purchasing.CreateSupplierRequest": {
             "type": "object",
             "required": [
-                "rule_type",
-                "rule_value"
+                "name"
             ],
             "properties": {
-                "description": {
+                "address": {
                     "type": "string"
                 },
-                "rule_type": {
-                    "enum": [
-                        "MINIMUM_ORDER_AMOUNT",
-                        "REQUIRED_PRODUCT",
-                        "REQUIRED_CATEGORY",
-                        "ALLOWED_PAYMENT_METHOD",
-                        "ALLOWED_ORDER_TYPE"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionRuleType"
-                        }
-                    ]
+                "contact_name": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "rule_value": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "internal_purchasing.GoodsReceiptItemRequest": {
+            "type": "object",
+            "required": [
+                "purchase_order_item_id",
+                "quantity"
+            ],
+            "properties": {
+                "purchase_order_item_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "internal_purchasing.GoodsReceiptItemResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "purchase_order_item_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_purchasing.GoodsReceiptResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_purchasing.GoodsReceiptItemResponse"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "receipt_number": {
+                    "type": "string"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "received_by": {
+                    "type": "string"
+                },
+                "supplier_reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_purchasing.PagedPurchaseOrderResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/POS-kasir_internal_common_pagination.Pagination"
+                },
+                "purchase_orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_purchasing.PurchaseOrderResponse"
+                    }
+                }
+            }
+        },
+        "internal_purchasing.PagedSupplierResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/POS-kasir_internal_common_pagination.Pagination"
+                },
+                "suppliers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_purchasing.SupplierResponse"
+                    }
+                }
+            }
+        },
+        "internal_purchasing.PurchaseOrderDetailResponse": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "expected_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_purchasing.PurchaseOrderItemResponse"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "po_number": {
+                    "type": "string"
+                },
+                "receipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_purchasing.GoodsReceiptResponse"
+                    }
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/POS-kasir_internal_purchasing_repository.PurchaseOrderStatus"
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "supplier_name": {
+                    "type": "string"
+                },
+                "total_cost": {
+                    "type": "number"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "internal_promotions.CreatePromotionTargetRequest": {
+        "internal_purchasing.PurchaseOrderItemRequest": {
             "type": "object",
             "required": [
-                "target_id",
-                "target_type"
+                "product_id",
+                "quantity"
             ],
             "properties": {
-                "target_id": {
+                "product_id": {
                     "type": "string"
                 },
-                "target_type": {
-                    "enum": [
-                        "PRODUCT",
-                        "CATEGORY"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionTargetType"
-                        }
-                    ]
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "type": "number",
+                    "minimum": 0
                 }
             }
         },
-        "internal_promotions.PagedPromotionResponse": {
+        "internal_purchasing.PurchaseOrderItemResponse": {
             "type": "object",
             "properties": {
-                "pagination": {
-                    "$ref": "#/definitions/POS-kasir_internal_common_pagination.Pagination"
+                "id": {
+                    "type": "string"
                 },
-                "promotions": {
+                "line_total": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity_ordered": {
+                    "type": "integer"
+                },
+                "quantity_outstanding": {
+                    "type": "integer"
+                },
+                "quantity_received": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_purchasing.PurchaseOrderRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "supplier_id"
+            ],
+            "properties": {
+                "expected_date": {
+                    "type": "string"
+                },
+                "items": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/internal_promotions.PromotionResponse"
+                        "$ref": "#/definitions/internal_purchasing.PurchaseOrderItemRequest"
                     }
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "supplier_id": {
+                    "type": "string"
                 }
             }
         },
-        "internal_promotions.PromotionResponse": {
+        "internal_purchasing.PurchaseOrderResponse": {
             "type": "object",
             "properties": {
+                "closed_at": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "deleted_at": {
+                "created_by": {
                     "type": "string"
                 },
-                "description": {
+                "expected_date": {
                     "type": "string"
                 },
-                "discount_type": {
-                    "$ref": "#/definitions/POS-kasir_internal_promotions_repository.DiscountType"
+                "id": {
+                    "type": "string"
                 },
-                "discount_value": {
-                    "type": "integer"
+                "notes": {
+                    "type": "string"
                 },
-                "end_date": {
+                "po_number": {
                     "type": "string"
                 },
-                "id": {
+                "received_at": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "sent_at": {
+                    "type": "string"
                 },
-                "max_discount_amount": {
-                    "type": "integer"
+                "status": {
+                    "$ref": "#/definitions/POS-kasir_internal_purchasing_repository.PurchaseOrderStatus"
                 },
-                "name": {
+                "supplier_id": {
                     "type": "string"
                 },
-                "rules": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_promotions.PromotionRuleResponse"
-                    }
+                "supplier_name": {
+                    "type": "string"
                 },
-                "scope": {
-                    "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionScope"
+                "total_cost": {
+                    "type": "number"
                 },
-                "start_date": {
+                "updated_at": {
                     "type": "string"
-                },
-                "targets": {
+                }
+            }
+        },
+        "internal_purchasing.ReceivePurchaseOrderRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/internal_promotions.PromotionTargetResponse"
+                        "$ref": "#/definitions/internal_purchasing.GoodsReceiptItemRequest"
                     }
                 },
-                "updated_at": {
-                    "type": "string"
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "supplier_reference": {
+                    "type": "string",
+                    "maxLength": 100
                 }
             }
         },
-        "internal_promotions.PromotionRuleResponse": {
+        "internal_purchasing.SupplierResponse": {
             "type": "object",
             "properties": {
-                "description": {
+                "address": {
                     "type": "string"
                 },
-                "id": {
+                "contact_name": {
                     "type": "string"
                 },
-                "rule_type": {
-                    "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionRuleType"
+                "created_at": {
+                    "type": "string"
                 },
-                "rule_value": {
+                "email": {
                     "type": "string"
-                }
-            }
-        },
-        "internal_promotions.PromotionTargetResponse": {
-            "type": "object",
-            "properties": {
+                },
                 "id": {
                     "type": "string"
                 },
-                "target_id": {
+                "name": {
                     "type": "string"
                 },
-                "target_type": {
-                    "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionTargetType"
+                "phone": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "internal_promotions.UpdatePromotionRequest": {
+        "internal_purchasing.UpdateSupplierRequest": {
             "type": "object",
             "required": [
-                "discount_type",
-                "discount_value",
-                "end_date",
-                "name",
-                "scope",
-                "start_date"
+                "name"
             ],
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "discount_type": {
-                    "enum": [
-                        "percentage",
-                        "fixed_amount"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/POS-kasir_internal_promotions_repository.DiscountType"
-                        }
-                    ]
-                },
-                "discount_value": {
-                    "type": "integer"
-                },
-                "end_date": {
+                "address": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "contact_name": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "max_discount_amount": {
-                    "type": "integer"
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
                 },
                 "name": {
                     "type": "string",
-                    "minLength": 3
-                },
-                "rules": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_promotions.CreatePromotionRuleRequest"
-                    }
-                },
-                "scope": {
-                    "enum": [
-                        "ORDER",
-                        "ITEM"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/POS-kasir_internal_promotions_repository.PromotionScope"
-                        }
-                    ]
-                },
-                "start_date": {
-                    "type": "string"
+                    "maxLength": 100,
+                    "minLength": 2
                 },
-                "targets": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_promotions.CreatePromotionTargetRequest"
-                    }
+                "phone": {
+                    "type": "string",
+                    "maxLength": 20
                 }
             }
         },
@@ -6917,17 +15039,111 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_report.LowStockProductResponse": {
+        "internal_report.InventoryValuationItem": {
+            "type": "object",
+            "properties": {
+                "average_cost": {
+                    "type": "number"
+                },
+                "average_value": {
+                    "type": "number"
+                },
+                "fifo_value": {
+                    "type": "number"
+                },
+                "layer_quantity": {
+                    "type": "integer"
+                },
+                "open_layers": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_report.InventoryValuationResponse": {
+            "type": "object",
+            "properties": {
+                "costing_method": {
+                    "type": "string",
+                    "example": "fifo"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_report.InventoryValuationItem"
+                    }
+                },
+                "total_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_report.LowStockProductResponse": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "string"
+                },
+                "variant_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_report.OutstandingPurchaseOrderResponse": {
             "type": "object",
             "properties": {
-                "product_id": {
+                "expected_date": {
                     "type": "string"
                 },
-                "product_name": {
+                "outstanding_value": {
+                    "type": "number"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "po_number": {
                     "type": "string"
                 },
-                "stock": {
+                "purchase_order_id": {
+                    "type": "string"
+                },
+                "quantity_ordered": {
+                    "type": "integer"
+                },
+                "quantity_received": {
                     "type": "integer"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_name": {
+                    "type": "string"
                 }
             }
         },
@@ -7033,9 +15249,36 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_report.PromotionCodePerformanceResponse": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "promotion_code_id": {
+                    "type": "string"
+                },
+                "redemption_count": {
+                    "type": "integer"
+                },
+                "total_discount_given": {
+                    "type": "integer"
+                }
+            }
+        },
         "internal_report.PromotionPerformanceResponse": {
             "type": "object",
             "properties": {
+                "codes": {
+                    "description": "Codes breaks the redemptions down per promotion code; promotions applied by ID have none",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_report.PromotionCodePerformanceResponse"
+                    }
+                },
                 "promotion_id": {
                     "type": "string"
                 },
@@ -7050,6 +15293,31 @@ const docTemplate = `{
                 },
                 "usage_count": {
                     "type": "integer"
+                },
+                "windows": {
+                    "description": "Windows breaks the usage down per schedule window the promotion was granted in",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_report.PromotionWindowPerformanceResponse"
+                    }
+                }
+            }
+        },
+        "internal_report.PromotionWindowPerformanceResponse": {
+            "type": "object",
+            "properties": {
+                "total_discount_given": {
+                    "type": "integer"
+                },
+                "total_sales_with_promotion": {
+                    "type": "integer"
+                },
+                "usage_count": {
+                    "type": "integer"
+                },
+                "window": {
+                    "description": "Window is the label of the schedule window, e.g. \"Mon-Fri 15:00-17:00\"; null for uses outside any schedule",
+                    "type": "string"
                 }
             }
         },
@@ -7099,6 +15367,29 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_report.SupplierSpendResponse": {
+            "type": "object",
+            "properties": {
+                "purchase_order_count": {
+                    "type": "integer"
+                },
+                "receipt_count": {
+                    "type": "integer"
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "supplier_name": {
+                    "type": "string"
+                },
+                "total_quantity": {
+                    "type": "integer"
+                },
+                "total_spend": {
+                    "type": "number"
+                }
+            }
+        },
         "internal_settings.BrandingSettingsResponse": {
             "type": "object",
             "properties": {
@@ -7113,6 +15404,91 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_settings.LoyaltyCategoryMultiplier": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "multiplier": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 2
+                }
+            }
+        },
+        "internal_settings.LoyaltyPromotionBonus": {
+            "type": "object",
+            "required": [
+                "promotion_id"
+            ],
+            "properties": {
+                "bonus_points": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "promotion_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_settings.LoyaltySettingsResponse": {
+            "type": "object",
+            "properties": {
+                "category_multipliers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_settings.LoyaltyCategoryMultiplier"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "expiry_days": {
+                    "type": "integer",
+                    "example": 365
+                },
+                "min_redeem_points": {
+                    "type": "integer"
+                },
+                "point_value": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "promotion_bonuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_settings.LoyaltyPromotionBonus"
+                    }
+                },
+                "spend_per_point": {
+                    "type": "integer",
+                    "example": 10000
+                }
+            }
+        },
+        "internal_settings.OperationalSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "costing_method": {
+                    "type": "string",
+                    "example": "average"
+                },
+                "queue_number_digits": {
+                    "type": "integer"
+                },
+                "queue_prefix": {
+                    "type": "string"
+                },
+                "queue_reset_time": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_settings.PrinterSettingsResponse": {
             "type": "object",
             "properties": {
@@ -7130,6 +15506,50 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_settings.TaxSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "rounding_mode": {
+                    "type": "string"
+                },
+                "service_charge_enabled": {
+                    "type": "boolean"
+                },
+                "service_charge_order_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "service_charge_rate": {
+                    "type": "number"
+                },
+                "service_charge_taxable": {
+                    "type": "boolean"
+                },
+                "tax_enabled": {
+                    "type": "boolean"
+                },
+                "tax_exempt_category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "tax_inclusive": {
+                    "type": "boolean"
+                },
+                "tax_order_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tax_rate": {
+                    "type": "number"
+                }
+            }
+        },
         "internal_settings.UpdateBrandingRequest": {
             "type": "object",
             "required": [
@@ -7150,6 +15570,74 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_settings.UpdateLoyaltySettingsRequest": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "category_multipliers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_settings.LoyaltyCategoryMultiplier"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "expiry_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "min_redeem_points": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "point_value": {
+                    "type": "integer"
+                },
+                "promotion_bonuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_settings.LoyaltyPromotionBonus"
+                    }
+                },
+                "spend_per_point": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_settings.UpdateOperationalSettingsRequest": {
+            "type": "object",
+            "required": [
+                "queue_reset_time",
+                "timezone"
+            ],
+            "properties": {
+                "costing_method": {
+                    "type": "string",
+                    "enum": [
+                        "average",
+                        "fifo"
+                    ]
+                },
+                "queue_number_digits": {
+                    "type": "integer",
+                    "maximum": 6,
+                    "minimum": 1
+                },
+                "queue_prefix": {
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "queue_reset_time": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_settings.UpdatePrinterSettingsRequest": {
             "type": "object",
             "required": [
@@ -7162,22 +15650,82 @@ const docTemplate = `{
                 "auto_print": {
                     "type": "boolean"
                 },
-                "connection": {
-                    "type": "string"
+                "connection": {
+                    "type": "string"
+                },
+                "paper_width": {
+                    "type": "string",
+                    "enum": [
+                        "58mm",
+                        "80mm"
+                    ]
+                },
+                "print_method": {
+                    "type": "string",
+                    "enum": [
+                        "BE",
+                        "FE"
+                    ]
+                }
+            }
+        },
+        "internal_settings.UpdateTaxSettingsRequest": {
+            "type": "object",
+            "required": [
+                "rounding_mode",
+                "service_charge_enabled",
+                "service_charge_taxable",
+                "tax_enabled",
+                "tax_inclusive"
+            ],
+            "properties": {
+                "rounding_mode": {
+                    "type": "string",
+                    "enum": [
+                        "round",
+                        "floor",
+                        "ceil"
+                    ]
+                },
+                "service_charge_enabled": {
+                    "type": "boolean"
+                },
+                "service_charge_order_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "service_charge_rate": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "service_charge_taxable": {
+                    "type": "boolean"
+                },
+                "tax_enabled": {
+                    "type": "boolean"
+                },
+                "tax_exempt_category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "tax_inclusive": {
+                    "type": "boolean"
                 },
-                "paper_width": {
-                    "type": "string",
-                    "enum": [
-                        "58mm",
-                        "80mm"
-                    ]
+                "tax_order_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "print_method": {
-                    "type": "string",
-                    "enum": [
-                        "BE",
-                        "FE"
-                    ]
+                "tax_rate": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
                 }
             }
         },
@@ -7256,6 +15804,76 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_shift.PaymentMethodTotal": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "is_cash": {
+                    "type": "boolean"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "payment_method_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_shift.ShiftReconciliation": {
+            "type": "object",
+            "properties": {
+                "actual_cash": {
+                    "type": "integer"
+                },
+                "cash_in": {
+                    "type": "integer"
+                },
+                "cash_out": {
+                    "description": "Payouts and drops",
+                    "type": "integer"
+                },
+                "cash_received": {
+                    "type": "integer"
+                },
+                "cash_refunds": {
+                    "type": "integer"
+                },
+                "cash_sales": {
+                    "description": "CashReceived - ChangeGiven",
+                    "type": "integer"
+                },
+                "change_given": {
+                    "type": "integer"
+                },
+                "expected_cash": {
+                    "type": "integer"
+                },
+                "over_short": {
+                    "description": "Actual - Expected",
+                    "type": "integer"
+                },
+                "refunds_by_payment_method": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_shift.PaymentMethodTotal"
+                    }
+                },
+                "sales_by_payment_method": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_shift.PaymentMethodTotal"
+                    }
+                },
+                "start_cash": {
+                    "type": "integer"
+                }
+            }
+        },
         "internal_shift.ShiftResponse": {
             "type": "object",
             "properties": {
@@ -7275,6 +15893,9 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "reconciliation": {
+                    "$ref": "#/definitions/internal_shift.ShiftReconciliation"
+                },
                 "start_cash": {
                     "type": "integer"
                 },
@@ -7304,6 +15925,243 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_stocktake.CreateStockTakeRequest": {
+            "type": "object",
+            "required": [
+                "scope"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "scope": {
+                    "enum": [
+                        "full",
+                        "category"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/POS-kasir_internal_stocktake_repository.StockTakeScope"
+                        }
+                    ]
+                }
+            }
+        },
+        "internal_stocktake.PagedStockTakeResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/POS-kasir_internal_common_pagination.Pagination"
+                },
+                "stock_takes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_stocktake.StockTakeResponse"
+                    }
+                }
+            }
+        },
+        "internal_stocktake.RecordCountsRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/internal_stocktake.StockTakeCountItemRequest"
+                    }
+                }
+            }
+        },
+        "internal_stocktake.StockTakeCountItemRequest": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "internal_stocktake.StockTakeCountResponse": {
+            "type": "object",
+            "properties": {
+                "counted_at": {
+                    "type": "string"
+                },
+                "counted_by": {
+                    "type": "string"
+                },
+                "counted_by_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_stocktake.StockTakeDetailResponse": {
+            "type": "object",
+            "properties": {
+                "approved_at": {
+                    "type": "string"
+                },
+                "approved_by": {
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "counted_count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "item_count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_stocktake.StockTakeItemResponse"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "scope": {
+                    "$ref": "#/definitions/POS-kasir_internal_stocktake_repository.StockTakeScope"
+                },
+                "status": {
+                    "$ref": "#/definitions/POS-kasir_internal_stocktake_repository.StockTakeStatus"
+                },
+                "total_variance": {
+                    "type": "integer"
+                },
+                "total_variance_cost": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_stocktake.StockTakeItemResponse": {
+            "type": "object",
+            "properties": {
+                "counted_quantity": {
+                    "type": "integer"
+                },
+                "counts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_stocktake.StockTakeCountResponse"
+                    }
+                },
+                "expected_quantity": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "sold_during_count": {
+                    "type": "integer"
+                },
+                "system_quantity": {
+                    "type": "integer"
+                },
+                "unit_cost": {
+                    "type": "number"
+                },
+                "variance": {
+                    "type": "integer"
+                },
+                "variance_cost": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_stocktake.StockTakeResponse": {
+            "type": "object",
+            "properties": {
+                "approved_at": {
+                    "type": "string"
+                },
+                "approved_by": {
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "counted_count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "item_count": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "scope": {
+                    "$ref": "#/definitions/POS-kasir_internal_stocktake_repository.StockTakeScope"
+                },
+                "status": {
+                    "$ref": "#/definitions/POS-kasir_internal_stocktake_repository.StockTakeStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_user.CreateUserRequest": {
             "type": "object",
             "required": [
@@ -25,6 +25,7 @@ var (
 	ErrOrderNotModifiable      = errors.New("order cannot be modified, it might have been paid or already cancelled")
 	ErrInvalidStatusTransition = errors.New("invalid status transition for the order")
 	ErrPromotionNotApplicable  = errors.New("promotion is not applicable to the order items")
+	ErrPromotionPaymentMethod  = errors.New("payment method is not allowed by the applied promotion")
 	ErrFileTooLarge            = errors.New("file size exceeds the maximum limit")
 	ErrFileTypeNotSupported    = errors.New("file type is not supported")
 	ErrImageNotSquare          = errors.New("image must be square")
@@ -42,6 +43,60 @@ var (
 	ErrAvatarNotSquare         = errors.New("avatar must be square")
 	ErrUploadAvatar            = errors.New("failed to upload avatar, please try again later")
 	ErrAvatarLink              = errors.New("failed to generate avatar link, please try again later")
+	ErrPaymentMethodExists     = errors.New("payment method with this name already exists")
+	ErrPaymentMethodInUse      = errors.New("payment method has been used by orders and cannot be deleted")
+	ErrPaymentMethodInvalid    = errors.New("payment method is invalid or inactive")
+	ErrPaymentReferenceMissing = errors.New("payment method requires a reference number")
+	ErrPaymentExceedsBalance   = errors.New("payment amount exceeds the outstanding balance")
+	ErrOrderAlreadyPaid        = errors.New("order already paid")
+	ErrOrderSplitInvalid       = errors.New("order split is invalid: items, quantities or guest count do not fit the order")
+	ErrOrderMergeInvalid       = errors.New("order merge is invalid: source orders must be distinct and differ from the target")
+	ErrRefundInvalid           = errors.New("refund is invalid: items, quantities or tender exceed what remains refundable")
+	ErrOrderNotRefundable      = errors.New("only paid orders can be refunded")
+	ErrOrderRefundRequired     = errors.New("order has payments that must be refunded before it can be cancelled")
+	ErrKitchenStationExists    = errors.New("kitchen station with this name already exists")
+	ErrPrepStatusTransition    = errors.New("order item cannot move to that preparation status")
+	ErrIngredientExists        = errors.New("ingredient with this name already exists")
+	ErrIngredientNotFound      = errors.New("ingredient not found")
+	ErrIngredientInUse         = errors.New("ingredient is used by product recipes and cannot be deleted")
+	ErrInsufficientIngredient  = errors.New("insufficient ingredient stock")
+	ErrRecipeInvalid           = errors.New("recipe is invalid: ingredients must be unique and replacements must be part of the product recipe")
+	ErrSupplierExists          = errors.New("supplier with this name already exists")
+	ErrSupplierNotFound        = errors.New("supplier not found")
+	ErrSupplierInUse           = errors.New("supplier has open purchase orders and cannot be deleted")
+	ErrPurchaseOrderInvalid    = errors.New("purchase order is invalid: products must exist, be listed once and not have variants")
+	ErrPurchaseOrderStatus     = errors.New("purchase order status does not allow this action")
+	ErrGoodsReceiptInvalid     = errors.New("goods receipt is invalid: lines must belong to the purchase order and not exceed the outstanding quantity")
+	ErrStockTakeStatus         = errors.New("stock take is no longer open")
+	ErrStockTakeInvalid        = errors.New("stock take count is invalid: products must be part of the stock take and listed once")
+	ErrStockTakeEmpty          = errors.New("stock take has no products to count")
+	ErrStockTakeOverlap        = errors.New("some products are already being counted in another open stock take")
+	ErrVariantRequired         = errors.New("product has variants: choose a variant")
+	ErrVariantNotFound         = errors.New("variant not found")
+	ErrVariantSKUExists        = errors.New("variant with this SKU or barcode already exists")
+	ErrVariantHasStock         = errors.New("variant still has stock and cannot be deleted")
+	ErrVariantInvalid          = errors.New("variant is invalid: attributes and values must be unique, not empty and short enough for the SKU, with at most 100 variants per product")
+	ErrProductHasVariants      = errors.New("product has variants, which keep their own stock")
+	ErrProductMadeToOrder      = errors.New("product is made to order from a recipe and cannot have variants")
+	ErrProductHasStock         = errors.New("product still has stock of its own: bring it to zero before adding variants")
+	ErrModifierGroupExists     = errors.New("modifier group with this name already exists")
+	ErrModifierGroupNotFound   = errors.New("modifier group not found")
+	ErrModifierGroupInvalid    = errors.New("modifier group is invalid: max_select must be at least 1 and not below min_select")
+	ErrModifierTooManyDefaults = errors.New("too many default options: a product cannot have more defaults in a modifier group than its max_select")
+	ErrInvalidModifiers        = errors.New("order item options do not satisfy their modifier groups")
+	ErrSKUExists               = errors.New("SKU is already used by another product or variant")
+	ErrBarcodeExists           = errors.New("barcode is already used by another product or variant")
+	ErrBarcodeInvalid          = errors.New("barcode is invalid: EAN-13, EAN-8 and UPC-A codes need their digit count and a correct check digit, internal codes only letters, digits and dashes")
+	ErrBarcodeNotFound         = errors.New("barcode not found")
+	ErrProductCodeNotFound     = errors.New("no product or variant has this SKU or barcode")
+	ErrImportFileInvalid       = errors.New("import file is invalid: upload a CSV or XLSX file of at most 5MB whose first row names the columns, name among them")
+	ErrImportHasErrors         = errors.New("import has rows with errors, nothing was changed")
+	ErrPromotionCodeNotFound   = errors.New("promotion code not found or no longer active")
+	ErrPromotionCodeExists     = errors.New("promotion code is already taken")
+	ErrPromotionLimitReached   = errors.New("promotion has reached its redemption limit")
+	ErrPromotionInvalid        = errors.New("promotion is invalid: buy_x_get_y needs buy and get quantities and at most 100 percent off, bundle_price a bundle quantity, tiered distinct tiers with percentages of at most 100")
+	ErrPointsNotRedeemable     = errors.New("loyalty points cannot be redeemed on this order")
+	ErrInsufficientPoints      = errors.New("customer does not have enough loyalty points")
 )
 
 type ErrorResponse struct {
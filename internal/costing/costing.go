@@ -0,0 +1,143 @@
+// Package costing keeps the cost layers behind product stock. Every unit that enters stock belongs to a layer
+// with the cost it came in at and sales draw from the oldest layer first, whatever the store's costing method.
+// The method only decides what a sale costs: the product's moving average cost, or the layers it drew from.
+package costing
+
+import (
+	"POS-kasir/internal/costing/repository"
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	MethodAverage = "average"
+	MethodFIFO    = "fifo"
+)
+
+// Receive adds quantity units bought or counted at unitCost as a new layer and folds them into the product's
+// moving average cost. previousStock is the product's stock before the units arrived.
+func Receive(ctx context.Context, q repository.Querier, productID uuid.UUID, previousStock, quantity int32, unitCost pgtype.Numeric, source repository.CostLayerSource, referenceID pgtype.UUID) error {
+	if quantity <= 0 {
+		return nil
+	}
+
+	if err := q.CreateCostLayer(ctx, repository.CreateCostLayerParams{
+		ProductID:   productID,
+		Source:      source,
+		ReferenceID: referenceID,
+		Quantity:    quantity,
+		UnitCost:    unitCost,
+	}); err != nil {
+		return fmt.Errorf("failed to create cost layer for %s: %w", productID, err)
+	}
+	if err := q.ApplyAverageCost(ctx, repository.ApplyAverageCostParams{
+		PreviousStock: previousStock,
+		Quantity:      quantity,
+		UnitCost:      unitCost,
+		ID:            productID,
+	}); err != nil {
+		return fmt.Errorf("failed to update average cost for %s: %w", productID, err)
+	}
+	return nil
+}
+
+// Consume takes quantity units out of the product's oldest layers and returns what they cost in total. Units
+// the layers cannot cover are costed at fallback. orderItemID links the draw to a sale so it can be restored.
+func Consume(ctx context.Context, q repository.Querier, productID uuid.UUID, quantity int32, orderItemID pgtype.UUID, fallback pgtype.Numeric) (float64, error) {
+	if quantity <= 0 {
+		return 0, nil
+	}
+
+	drawn, err := q.ConsumeCostLayers(ctx, repository.ConsumeCostLayersParams{
+		ProductID:   productID,
+		Quantity:    quantity,
+		OrderItemID: orderItemID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to consume cost layers for %s: %w", productID, err)
+	}
+
+	var total float64
+	covered := int32(0)
+	for _, d := range drawn {
+		total += float64(d.Quantity) * numericToFloat(d.UnitCost)
+		covered += d.Quantity
+	}
+	if covered < quantity {
+		total += float64(quantity-covered) * numericToFloat(fallback)
+	}
+	return total, nil
+}
+
+// Restore puts back quantity units an order item drew, most recent draw first, and returns what they cost in
+// total. Units without a recorded draw come back as a return layer at costAtSale, the unit cost the line was
+// sold at, which is also what the moving average absorbs. previousStock is the stock before the return.
+func Restore(ctx context.Context, q repository.Querier, productID, orderItemID uuid.UUID, previousStock, quantity int32, costAtSale pgtype.Numeric) (float64, error) {
+	if quantity <= 0 {
+		return 0, nil
+	}
+
+	given, err := q.RestoreCostLayers(ctx, repository.RestoreCostLayersParams{
+		OrderItemID: orderItemID,
+		Quantity:    quantity,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore cost layers for %s: %w", productID, err)
+	}
+
+	var total float64
+	restored := int32(0)
+	for _, g := range given {
+		total += float64(g.Quantity) * numericToFloat(g.UnitCost)
+		restored += g.Quantity
+	}
+
+	if missing := quantity - restored; missing > 0 {
+		total += float64(missing) * numericToFloat(costAtSale)
+		if err := q.CreateCostLayer(ctx, repository.CreateCostLayerParams{
+			ProductID:   productID,
+			Source:      repository.CostLayerSourceReturn,
+			ReferenceID: pgtype.UUID{Bytes: orderItemID, Valid: true},
+			Quantity:    missing,
+			UnitCost:    costAtSale,
+		}); err != nil {
+			return 0, fmt.Errorf("failed to create return layer for %s: %w", productID, err)
+		}
+	}
+
+	if err := q.ApplyAverageCost(ctx, repository.ApplyAverageCostParams{
+		PreviousStock: previousStock,
+		Quantity:      quantity,
+		UnitCost:      costAtSale,
+		ID:            productID,
+	}); err != nil {
+		return 0, fmt.Errorf("failed to update average cost for %s: %w", productID, err)
+	}
+	return total, nil
+}
+
+// UnitCost spreads a total cost over quantity units, rounded to the cent like the cost columns.
+func UnitCost(total float64, quantity int32) pgtype.Numeric {
+	var n pgtype.Numeric
+	if quantity <= 0 {
+		_ = n.Scan("0")
+		return n
+	}
+	_ = n.Scan(fmt.Sprintf("%.2f", math.Round(total/float64(quantity)*100)/100))
+	return n
+}
+
+func numericToFloat(n pgtype.Numeric) float64 {
+	if !n.Valid {
+		return 0
+	}
+	f, err := n.Float64Value()
+	if err != nil {
+		return 0
+	}
+	return f.Float64
+}
@@ -0,0 +1,133 @@
+package costing_test
+
+import (
+	"POS-kasir/internal/costing"
+	"POS-kasir/internal/costing/repository"
+	"POS-kasir/mocks"
+	"POS-kasir/pkg/utils"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func numeric(t *testing.T, f float64) pgtype.Numeric {
+	n, err := utils.Float64ToNumeric(f)
+	assert.NoError(t, err)
+	return n
+}
+
+func TestReceive(t *testing.T) {
+	productID := uuid.New()
+	referenceID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	t.Run("LayersAndAveragesTheUnits", func(t *testing.T) {
+		mockRepo := mocks.NewMockCostingRepo(gomock.NewController(t))
+		ctx := context.Background()
+		cost := numeric(t, 12000)
+
+		gomock.InOrder(
+			mockRepo.EXPECT().CreateCostLayer(ctx, repository.CreateCostLayerParams{
+				ProductID:   productID,
+				Source:      repository.CostLayerSourcePurchase,
+				ReferenceID: referenceID,
+				Quantity:    4,
+				UnitCost:    cost,
+			}).Return(nil),
+			mockRepo.EXPECT().ApplyAverageCost(ctx, repository.ApplyAverageCostParams{
+				PreviousStock: 3,
+				Quantity:      4,
+				UnitCost:      cost,
+				ID:            productID,
+			}).Return(nil),
+		)
+
+		err := costing.Receive(ctx, mockRepo, productID, 3, 4, cost, repository.CostLayerSourcePurchase, referenceID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("NothingReceived", func(t *testing.T) {
+		mockRepo := mocks.NewMockCostingRepo(gomock.NewController(t))
+
+		err := costing.Receive(context.Background(), mockRepo, productID, 3, 0, numeric(t, 12000), repository.CostLayerSourceOpening, pgtype.UUID{})
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestConsume(t *testing.T) {
+	productID := uuid.New()
+	itemID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	t.Run("OldestLayersFirst", func(t *testing.T) {
+		mockRepo := mocks.NewMockCostingRepo(gomock.NewController(t))
+		ctx := context.Background()
+
+		mockRepo.EXPECT().ConsumeCostLayers(ctx, repository.ConsumeCostLayersParams{ProductID: productID, Quantity: 5, OrderItemID: itemID}).
+			Return([]repository.ConsumeCostLayersRow{
+				{Quantity: 2, UnitCost: numeric(t, 10000)},
+				{Quantity: 3, UnitCost: numeric(t, 12000)},
+			}, nil)
+
+		total, err := costing.Consume(ctx, mockRepo, productID, 5, itemID, numeric(t, 11000))
+
+		assert.NoError(t, err)
+		assert.Equal(t, float64(56000), total)
+	})
+
+	t.Run("ShortfallCostedAtFallback", func(t *testing.T) {
+		mockRepo := mocks.NewMockCostingRepo(gomock.NewController(t))
+		ctx := context.Background()
+
+		mockRepo.EXPECT().ConsumeCostLayers(ctx, gomock.Any()).
+			Return([]repository.ConsumeCostLayersRow{{Quantity: 1, UnitCost: numeric(t, 10000)}}, nil)
+
+		total, err := costing.Consume(ctx, mockRepo, productID, 3, itemID, numeric(t, 11000))
+
+		assert.NoError(t, err)
+		assert.Equal(t, float64(32000), total)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	productID := uuid.New()
+	itemID := uuid.New()
+
+	t.Run("GivesBackDrawsAndLayersTheRest", func(t *testing.T) {
+		mockRepo := mocks.NewMockCostingRepo(gomock.NewController(t))
+		ctx := context.Background()
+		costAtSale := numeric(t, 11000)
+
+		gomock.InOrder(
+			mockRepo.EXPECT().RestoreCostLayers(ctx, repository.RestoreCostLayersParams{OrderItemID: itemID, Quantity: 3}).
+				Return([]repository.RestoreCostLayersRow{{Quantity: 2, UnitCost: numeric(t, 12000)}}, nil),
+			mockRepo.EXPECT().CreateCostLayer(ctx, repository.CreateCostLayerParams{
+				ProductID:   productID,
+				Source:      repository.CostLayerSourceReturn,
+				ReferenceID: pgtype.UUID{Bytes: itemID, Valid: true},
+				Quantity:    1,
+				UnitCost:    costAtSale,
+			}).Return(nil),
+			mockRepo.EXPECT().ApplyAverageCost(ctx, repository.ApplyAverageCostParams{
+				PreviousStock: 7,
+				Quantity:      3,
+				UnitCost:      costAtSale,
+				ID:            productID,
+			}).Return(nil),
+		)
+
+		total, err := costing.Restore(ctx, mockRepo, productID, itemID, 7, 3, costAtSale)
+
+		assert.NoError(t, err)
+		assert.Equal(t, float64(35000), total)
+	})
+}
+
+func TestUnitCost(t *testing.T) {
+	assert.Equal(t, 3333.33, utils.NumericToFloat64(costing.UnitCost(10000, 3)))
+	assert.Equal(t, float64(0), utils.NumericToFloat64(costing.UnitCost(10000, 0)))
+}
@@ -0,0 +1,61 @@
+-- name: CreateCostLayer :exec
+INSERT INTO cost_layers (product_id, source, reference_id, quantity_received, quantity_remaining, unit_cost)
+VALUES (sqlc.arg(product_id), sqlc.arg(source), sqlc.arg(reference_id), sqlc.arg(quantity)::int, sqlc.arg(quantity)::int, sqlc.arg(unit_cost));
+
+-- name: ApplyAverageCost :exec
+-- Melebur biaya unit yang masuk ke harga pokok rata-rata bergerak; previous_stock adalah stok sebelum barang masuk.
+UPDATE products
+SET cost_price = ROUND(
+    (GREATEST(sqlc.arg(previous_stock)::int, 0) * cost_price + sqlc.arg(quantity)::int * sqlc.arg(unit_cost)::numeric)
+    / (GREATEST(sqlc.arg(previous_stock)::int, 0) + sqlc.arg(quantity)::int), 2)
+WHERE id = sqlc.arg(id);
+
+-- name: ConsumeCostLayers :many
+-- Mengambil kuantitas dari lapisan biaya tertua dan mencatat pemakaiannya; hasilnya kuantitas dan biaya per lapisan yang terpakai.
+WITH locked AS (
+    SELECT id, quantity_remaining, unit_cost, created_at
+    FROM cost_layers
+    WHERE product_id = sqlc.arg(product_id) AND quantity_remaining > 0
+    ORDER BY created_at, id
+    FOR UPDATE
+), open_layers AS (
+    SELECT id, quantity_remaining, unit_cost,
+           SUM(quantity_remaining) OVER (ORDER BY created_at, id) - quantity_remaining AS taken_before
+    FROM locked
+), taken AS (
+    SELECT id, LEAST(quantity_remaining, sqlc.arg(quantity)::int - taken_before)::int AS quantity, unit_cost
+    FROM open_layers
+    WHERE taken_before < sqlc.arg(quantity)::int
+), drawn AS (
+    UPDATE cost_layers l
+    SET quantity_remaining = l.quantity_remaining - t.quantity
+    FROM taken t
+    WHERE l.id = t.id
+)
+INSERT INTO cost_layer_consumptions (cost_layer_id, order_item_id, quantity, unit_cost)
+SELECT id, sqlc.narg(order_item_id), quantity, unit_cost FROM taken
+RETURNING quantity, unit_cost;
+
+-- name: RestoreCostLayers :many
+-- Mengembalikan pemakaian lapisan biaya sebuah item pesanan, yang terakhir diambil lebih dulu.
+WITH consumed AS (
+    SELECT id, cost_layer_id, quantity, unit_cost,
+           SUM(quantity) OVER (ORDER BY created_at DESC, id DESC) - quantity AS given_before
+    FROM cost_layer_consumptions
+    WHERE order_item_id = sqlc.arg(order_item_id) AND quantity > 0
+), given AS (
+    SELECT id, cost_layer_id, LEAST(quantity, sqlc.arg(quantity)::int - given_before)::int AS quantity, unit_cost
+    FROM consumed
+    WHERE given_before < sqlc.arg(quantity)::int
+), restocked AS (
+    UPDATE cost_layers l
+    SET quantity_remaining = l.quantity_remaining + g.quantity
+    FROM (SELECT cost_layer_id, SUM(quantity)::int AS quantity FROM given GROUP BY cost_layer_id) g
+    WHERE l.id = g.cost_layer_id
+), released AS (
+    UPDATE cost_layer_consumptions c
+    SET quantity = c.quantity - g.quantity
+    FROM given g
+    WHERE c.id = g.id
+)
+SELECT quantity, unit_cost FROM given;
@@ -2,6 +2,7 @@ package customers
 
 import (
 	"POS-kasir/internal/common/pagination"
+	"POS-kasir/internal/customers/repository"
 	"time"
 
 	"github.com/google/uuid"
@@ -26,13 +27,29 @@ type ListCustomersRequest struct {
 }
 
 type CustomerResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	Name      string     `json:"name"`
-	Phone     *string    `json:"phone,omitempty"`
-	Email     *string    `json:"email,omitempty"`
-	Address   *string    `json:"address,omitempty"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID            uuid.UUID             `json:"id"`
+	Name          string                `json:"name"`
+	Phone         *string               `json:"phone,omitempty"`
+	Email         *string               `json:"email,omitempty"`
+	Address       *string               `json:"address,omitempty"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+	PointsBalance int64                 `json:"points_balance"`
+	PointsHistory []PointsEntryResponse `json:"points_history,omitempty"`
+}
+
+// PointsEntryResponse is one line of a customer's loyalty points ledger. Earned and returned points are
+// positive; redeemed, expired and taken back points negative.
+type PointsEntryResponse struct {
+	ID          uuid.UUID                         `json:"id"`
+	OrderID     *uuid.UUID                        `json:"order_id,omitempty"`
+	EntryType   repository.LoyaltyEntryType       `json:"entry_type"`
+	RedeemedAs  *repository.LoyaltyRedemptionKind `json:"redeemed_as,omitempty"`
+	Points      int32                             `json:"points"`
+	Amount      int64                             `json:"amount,omitempty"`
+	ExpiresAt   *time.Time                        `json:"expires_at,omitempty"`
+	Description *string                           `json:"description,omitempty"`
+	CreatedAt   time.Time                         `json:"created_at"`
 }
 
 type PagedCustomerResponse struct {
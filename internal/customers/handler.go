@@ -67,7 +67,7 @@ func (h *CustomerHandler) CreateCustomerHandler(c fiber.Ctx) error {
 
 // GetCustomerHandler retrieves a customer by ID
 // @Summary      Get a customer
-// @Description  Get customer by ID
+// @Description  Get customer by ID, with the loyalty points balance and latest points history
 // @Tags         Customers
 // @Accept       json
 // @Produce      json
@@ -179,7 +179,7 @@ func (h *CustomerHandler) DeleteCustomerHandler(c fiber.Ctx) error {
 
 // ListCustomersHandler retrieves a list of customers
 // @Summary      List customers
-// @Description  List customers with pagination and search
+// @Description  List customers with pagination and search, with each customer's loyalty points balance
 // @Tags         Customers
 // @Accept       json
 // @Produce      json
@@ -65,6 +65,30 @@ func (q *Queries) DeleteCustomer(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const expireLoyaltyPoints = `-- name: ExpireLoyaltyPoints :execrows
+WITH lapsed AS (
+    SELECT id, customer_id, remaining FROM loyalty_point_entries
+    WHERE remaining > 0 AND expires_at <= NOW()
+        FOR UPDATE
+), cleared AS (
+    UPDATE loyalty_point_entries e
+    SET remaining = 0
+    FROM lapsed
+    WHERE e.id = lapsed.id
+)
+INSERT INTO loyalty_point_entries (customer_id, entry_type, points, description)
+SELECT customer_id, 'expire', -remaining, 'Points expired'
+FROM lapsed
+`
+
+func (q *Queries) ExpireLoyaltyPoints(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, expireLoyaltyPoints)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getCustomerByID = `-- name: GetCustomerByID :one
 SELECT id, name, phone, email, address, created_at, updated_at, deleted_at FROM customers WHERE id = $1 AND deleted_at IS NULL
 `
@@ -85,6 +109,103 @@ func (q *Queries) GetCustomerByID(ctx context.Context, id uuid.UUID) (Customer,
 	return i, err
 }
 
+const getCustomerPointsBalance = `-- name: GetCustomerPointsBalance :one
+SELECT (
+    COALESCE(SUM(points), 0) - COALESCE(SUM(remaining) FILTER (WHERE expires_at <= NOW()), 0)
+)::bigint AS balance
+FROM loyalty_point_entries
+WHERE customer_id = $1
+`
+
+func (q *Queries) GetCustomerPointsBalance(ctx context.Context, customerID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, getCustomerPointsBalance, customerID)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const getCustomersPointsBalances = `-- name: GetCustomersPointsBalances :many
+SELECT
+    customer_id,
+    (COALESCE(SUM(points), 0) - COALESCE(SUM(remaining) FILTER (WHERE expires_at <= NOW()), 0))::bigint AS balance
+FROM loyalty_point_entries
+WHERE customer_id = ANY($1::uuid[])
+GROUP BY customer_id
+`
+
+type GetCustomersPointsBalancesRow struct {
+	CustomerID uuid.UUID `json:"customer_id"`
+	Balance    int64     `json:"balance"`
+}
+
+func (q *Queries) GetCustomersPointsBalances(ctx context.Context, customerIds []uuid.UUID) ([]GetCustomersPointsBalancesRow, error) {
+	rows, err := q.db.Query(ctx, getCustomersPointsBalances, customerIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCustomersPointsBalancesRow{}
+	for rows.Next() {
+		var i GetCustomersPointsBalancesRow
+		if err := rows.Scan(
+			&i.CustomerID,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomerPointEntries = `-- name: ListCustomerPointEntries :many
+SELECT id, customer_id, order_id, entry_type, redeemed_as, points, remaining, amount, expires_at, description, created_by, created_at FROM loyalty_point_entries
+WHERE customer_id = $1
+ORDER BY created_at DESC, id
+LIMIT $2
+`
+
+type ListCustomerPointEntriesParams struct {
+	CustomerID uuid.UUID `json:"customer_id"`
+	Limit      int32     `json:"limit"`
+}
+
+func (q *Queries) ListCustomerPointEntries(ctx context.Context, arg ListCustomerPointEntriesParams) ([]LoyaltyPointEntry, error) {
+	rows, err := q.db.Query(ctx, listCustomerPointEntries, arg.CustomerID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LoyaltyPointEntry{}
+	for rows.Next() {
+		var i LoyaltyPointEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.CustomerID,
+			&i.OrderID,
+			&i.EntryType,
+			&i.RedeemedAs,
+			&i.Points,
+			&i.Remaining,
+			&i.Amount,
+			&i.ExpiresAt,
+			&i.Description,
+			&i.CreatedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCustomers = `-- name: ListCustomers :many
 SELECT id, name, phone, email, address, created_at, updated_at, deleted_at FROM customers 
 WHERE deleted_at IS NULL
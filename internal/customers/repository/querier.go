@@ -14,7 +14,11 @@ type Querier interface {
 	CountCustomers(ctx context.Context) (int64, error)
 	CreateCustomer(ctx context.Context, arg CreateCustomerParams) (Customer, error)
 	DeleteCustomer(ctx context.Context, id uuid.UUID) error
+	ExpireLoyaltyPoints(ctx context.Context) (int64, error)
 	GetCustomerByID(ctx context.Context, id uuid.UUID) (Customer, error)
+	GetCustomerPointsBalance(ctx context.Context, customerID uuid.UUID) (int64, error)
+	GetCustomersPointsBalances(ctx context.Context, customerIds []uuid.UUID) ([]GetCustomersPointsBalancesRow, error)
+	ListCustomerPointEntries(ctx context.Context, arg ListCustomerPointEntriesParams) ([]LoyaltyPointEntry, error)
 	ListCustomers(ctx context.Context, arg ListCustomersParams) ([]Customer, error)
 	UpdateCustomer(ctx context.Context, arg UpdateCustomerParams) (Customer, error)
 }
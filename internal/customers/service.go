@@ -17,8 +17,12 @@ type ICustomerService interface {
 	UpdateCustomer(ctx context.Context, id uuid.UUID, req UpdateCustomerRequest) (*CustomerResponse, error)
 	DeleteCustomer(ctx context.Context, id uuid.UUID) error
 	ListCustomers(ctx context.Context, req ListCustomersRequest) (*PagedCustomerResponse, error)
+	ExpirePoints(ctx context.Context) (int64, error)
 }
 
+// pointsHistoryLimit is how many of the latest ledger lines a customer is shown with.
+const pointsHistoryLimit = 50
+
 type CustomerService struct {
 	repo repository.Querier
 	log  logger.ILogger
@@ -50,7 +54,25 @@ func (s *CustomerService) GetCustomer(ctx context.Context, id uuid.UUID) (*Custo
 		}
 		return nil, err
 	}
-	return mapToCustomerResponse(cust), nil
+
+	response := mapToCustomerResponse(cust)
+	response.PointsBalance, err = s.repo.GetCustomerPointsBalance(ctx, id)
+	if err != nil {
+		s.log.Errorf("GetCustomerPointsBalance failed", "error", err)
+		return nil, err
+	}
+	entries, err := s.repo.ListCustomerPointEntries(ctx, repository.ListCustomerPointEntriesParams{
+		CustomerID: id,
+		Limit:      pointsHistoryLimit,
+	})
+	if err != nil {
+		s.log.Errorf("ListCustomerPointEntries failed", "error", err)
+		return nil, err
+	}
+	for _, e := range entries {
+		response.PointsHistory = append(response.PointsHistory, mapToPointsEntryResponse(e))
+	}
+	return response, nil
 }
 
 func (s *CustomerService) UpdateCustomer(ctx context.Context, id uuid.UUID, req UpdateCustomerRequest) (*CustomerResponse, error) {
@@ -103,9 +125,27 @@ func (s *CustomerService) ListCustomers(ctx context.Context, req ListCustomersRe
 		return nil, err
 	}
 
+	ids := make([]uuid.UUID, len(custs))
+	for i, c := range custs {
+		ids[i] = c.ID
+	}
+	balances := make(map[uuid.UUID]int64, len(custs))
+	if len(ids) > 0 {
+		rows, err := s.repo.GetCustomersPointsBalances(ctx, ids)
+		if err != nil {
+			s.log.Errorf("GetCustomersPointsBalances failed", "error", err)
+			return nil, err
+		}
+		for _, row := range rows {
+			balances[row.CustomerID] = row.Balance
+		}
+	}
+
 	var responses []CustomerResponse
 	for _, c := range custs {
-		responses = append(responses, *mapToCustomerResponse(c))
+		response := mapToCustomerResponse(c)
+		response.PointsBalance = balances[c.ID]
+		responses = append(responses, *response)
 	}
 
 	return &PagedCustomerResponse{
@@ -114,6 +154,17 @@ func (s *CustomerService) ListCustomers(ctx context.Context, req ListCustomersRe
 	}, nil
 }
 
+// ExpirePoints writes off the points whose lots have lapsed and returns how many lots it wrote off. Balances
+// already leave lapsed points out; this records their expiry in the ledger.
+func (s *CustomerService) ExpirePoints(ctx context.Context) (int64, error) {
+	expired, err := s.repo.ExpireLoyaltyPoints(ctx)
+	if err != nil {
+		s.log.Errorf("ExpireLoyaltyPoints failed", "error", err)
+		return 0, err
+	}
+	return expired, nil
+}
+
 func mapToCustomerResponse(c repository.Customer) *CustomerResponse {
 	return &CustomerResponse{
 		ID:        c.ID,
@@ -125,3 +176,25 @@ func mapToCustomerResponse(c repository.Customer) *CustomerResponse {
 		UpdatedAt: c.UpdatedAt.Time,
 	}
 }
+
+func mapToPointsEntryResponse(e repository.LoyaltyPointEntry) PointsEntryResponse {
+	response := PointsEntryResponse{
+		ID:          e.ID,
+		EntryType:   e.EntryType,
+		Points:      e.Points,
+		Amount:      e.Amount,
+		Description: e.Description,
+		CreatedAt:   e.CreatedAt.Time,
+	}
+	if e.OrderID.Valid {
+		orderID := uuid.UUID(e.OrderID.Bytes)
+		response.OrderID = &orderID
+	}
+	if e.RedeemedAs.Valid {
+		response.RedeemedAs = &e.RedeemedAs.LoyaltyRedemptionKind
+	}
+	if e.ExpiresAt.Valid {
+		response.ExpiresAt = &e.ExpiresAt.Time
+	}
+	return response
+}
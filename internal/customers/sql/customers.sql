@@ -23,3 +23,39 @@ RETURNING *;
 
 -- name: DeleteCustomer :exec
 UPDATE customers SET deleted_at = NOW() WHERE id = $1;
+
+-- name: GetCustomerPointsBalance :one
+SELECT (
+    COALESCE(SUM(points), 0) - COALESCE(SUM(remaining) FILTER (WHERE expires_at <= NOW()), 0)
+)::bigint AS balance
+FROM loyalty_point_entries
+WHERE customer_id = $1;
+
+-- name: GetCustomersPointsBalances :many
+SELECT
+    customer_id,
+    (COALESCE(SUM(points), 0) - COALESCE(SUM(remaining) FILTER (WHERE expires_at <= NOW()), 0))::bigint AS balance
+FROM loyalty_point_entries
+WHERE customer_id = ANY(sqlc.arg(customer_ids)::uuid[])
+GROUP BY customer_id;
+
+-- name: ListCustomerPointEntries :many
+SELECT * FROM loyalty_point_entries
+WHERE customer_id = $1
+ORDER BY created_at DESC, id
+LIMIT $2;
+
+-- name: ExpireLoyaltyPoints :execrows
+WITH lapsed AS (
+    SELECT id, customer_id, remaining FROM loyalty_point_entries
+    WHERE remaining > 0 AND expires_at <= NOW()
+        FOR UPDATE
+), cleared AS (
+    UPDATE loyalty_point_entries e
+    SET remaining = 0
+    FROM lapsed
+    WHERE e.id = lapsed.id
+)
+INSERT INTO loyalty_point_entries (customer_id, entry_type, points, description)
+SELECT customer_id, 'expire', -remaining, 'Points expired'
+FROM lapsed;
@@ -0,0 +1,121 @@
+package inventory
+
+import (
+	"POS-kasir/internal/common/pagination"
+	"POS-kasir/internal/inventory/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateIngredientRequest struct {
+	Name              string                    `json:"name" validate:"required,min=2,max=100"`
+	Unit              repository.IngredientUnit `json:"unit" validate:"required,oneof=g ml pcs"`
+	Stock             int32                     `json:"stock" validate:"gte=0"`
+	LowStockThreshold int32                     `json:"low_stock_threshold" validate:"gte=0"`
+	CostPerUnit       float64                   `json:"cost_per_unit" validate:"gte=0"`
+}
+
+// UpdateIngredientRequest changes an ingredient. Stock is the new absolute level; the difference is
+// written to the ingredient ledger with ChangeType (restock when it goes up, correction otherwise).
+type UpdateIngredientRequest struct {
+	Name              *string  `json:"name" validate:"omitempty,min=2,max=100"`
+	Stock             *int32   `json:"stock" validate:"omitempty,gte=0"`
+	LowStockThreshold *int32   `json:"low_stock_threshold" validate:"omitempty,gte=0"`
+	CostPerUnit       *float64 `json:"cost_per_unit" validate:"omitempty,gte=0"`
+	Note              *string  `json:"note" validate:"omitempty,max=255"`
+	ChangeType        *string  `json:"change_type" validate:"omitempty,oneof=restock correction damage"`
+}
+
+type ListIngredientsRequest struct {
+	pagination.PaginationRequest
+	LowStockOnly bool `query:"low_stock_only"`
+}
+
+type IngredientResponse struct {
+	ID                uuid.UUID                 `json:"id"`
+	Name              string                    `json:"name"`
+	Unit              repository.IngredientUnit `json:"unit"`
+	Stock             int32                     `json:"stock"`
+	LowStockThreshold int32                     `json:"low_stock_threshold"`
+	IsLowStock        bool                      `json:"is_low_stock"`
+	CostPerUnit       float64                   `json:"cost_per_unit"`
+	CreatedAt         time.Time                 `json:"created_at"`
+	UpdatedAt         time.Time                 `json:"updated_at"`
+}
+
+type ListIngredientsResponse struct {
+	Ingredients []IngredientResponse  `json:"ingredients"`
+	Pagination  pagination.Pagination `json:"pagination"`
+}
+
+type ListIngredientStockHistoryRequest struct {
+	pagination.PaginationRequest
+}
+
+type IngredientStockHistoryResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	IngredientID  uuid.UUID  `json:"ingredient_id"`
+	ChangeAmount  int32      `json:"change_amount"`
+	PreviousStock int32      `json:"previous_stock"`
+	CurrentStock  int32      `json:"current_stock"`
+	ChangeType    string     `json:"change_type"`
+	ReferenceID   *uuid.UUID `json:"reference_id,omitempty"`
+	Note          *string    `json:"note,omitempty"`
+	CreatedBy     *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type PagedIngredientStockHistoryResponse struct {
+	History    []IngredientStockHistoryResponse `json:"history"`
+	Pagination pagination.Pagination            `json:"pagination"`
+}
+
+type RecipeItemRequest struct {
+	IngredientID uuid.UUID `json:"ingredient_id" validate:"required"`
+	Quantity     int32     `json:"quantity" validate:"required,gt=0"`
+}
+
+// OptionRecipeItemRequest adds an ingredient when the option is chosen. With ReplacesIngredientID set the
+// option swaps that ingredient of the product recipe for this one, e.g. oat milk instead of milk.
+type OptionRecipeItemRequest struct {
+	IngredientID         uuid.UUID  `json:"ingredient_id" validate:"required"`
+	Quantity             int32      `json:"quantity" validate:"required,gt=0"`
+	ReplacesIngredientID *uuid.UUID `json:"replaces_ingredient_id"`
+}
+
+// SetProductRecipeRequest replaces the recipe of one unit of the product; an empty list removes it,
+// after which the product is tracked by its own stock again.
+type SetProductRecipeRequest struct {
+	Items []RecipeItemRequest `json:"items" validate:"dive"`
+}
+
+type SetOptionRecipeRequest struct {
+	Items []OptionRecipeItemRequest `json:"items" validate:"dive"`
+}
+
+type RecipeItemResponse struct {
+	IngredientID   uuid.UUID                 `json:"ingredient_id"`
+	IngredientName string                    `json:"ingredient_name"`
+	Unit           repository.IngredientUnit `json:"unit"`
+	Quantity       int32                     `json:"quantity"`
+}
+
+type OptionRecipeItemResponse struct {
+	RecipeItemResponse
+	ReplacesIngredientID   *uuid.UUID `json:"replaces_ingredient_id,omitempty"`
+	ReplacesIngredientName *string    `json:"replaces_ingredient_name,omitempty"`
+}
+
+type OptionRecipeResponse struct {
+	OptionID   uuid.UUID                  `json:"option_id"`
+	OptionName string                     `json:"option_name"`
+	Items      []OptionRecipeItemResponse `json:"items"`
+}
+
+type ProductRecipeResponse struct {
+	ProductID   uuid.UUID              `json:"product_id"`
+	ProductName string                 `json:"product_name"`
+	Items       []RecipeItemResponse   `json:"items"`
+	Options     []OptionRecipeResponse `json:"options"`
+}
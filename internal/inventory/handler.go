@@ -0,0 +1,387 @@
+package inventory
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/pkg/logger"
+	"POS-kasir/pkg/validator"
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type IInventoryHandler interface {
+	ListIngredientsHandler(c fiber.Ctx) error
+	GetIngredientHandler(c fiber.Ctx) error
+	CreateIngredientHandler(c fiber.Ctx) error
+	UpdateIngredientHandler(c fiber.Ctx) error
+	DeleteIngredientHandler(c fiber.Ctx) error
+	GetStockHistoryHandler(c fiber.Ctx) error
+	GetProductRecipeHandler(c fiber.Ctx) error
+	SetProductRecipeHandler(c fiber.Ctx) error
+	SetOptionRecipeHandler(c fiber.Ctx) error
+}
+
+type InventoryHandler struct {
+	service IInventoryService
+	log     logger.ILogger
+}
+
+func NewInventoryHandler(service IInventoryService, log logger.ILogger) IInventoryHandler {
+	return &InventoryHandler{service: service, log: log}
+}
+
+// ListIngredientsHandler
+// @Summary      List ingredients
+// @Description  Get ingredients with their stock in grams, millilitres or pieces. Filter by name with search, or only those at or below their threshold with low_stock_only (Roles: admin, manager, cashier)
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        search query string false "Search by name"
+// @Param        low_stock_only query bool false "Only ingredients at or below their low stock threshold"
+// @Success      200 {object} common.SuccessResponse{data=ListIngredientsResponse} "Ingredients retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid query parameters"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /inventory/ingredients [get]
+func (h *InventoryHandler) ListIngredientsHandler(c fiber.Ctx) error {
+	var req ListIngredientsRequest
+	if err := c.Bind().Query(&req); err != nil {
+		h.log.Warnf("ListIngredientsHandler | Failed to parse query parameters: %v", err)
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid query parameters"})
+	}
+
+	ingredients, err := h.service.ListIngredients(c.RequestCtx(), req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve ingredients"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Ingredients retrieved successfully",
+		Data:    ingredients,
+	})
+}
+
+// GetIngredientHandler
+// @Summary      Get ingredient by ID
+// @Description  Get a single ingredient with its current stock (Roles: admin, manager, cashier)
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Ingredient ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=IngredientResponse} "Ingredient retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid ingredient ID"
+// @Failure      404 {object} common.ErrorResponse "Ingredient not found"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /inventory/ingredients/{id} [get]
+func (h *InventoryHandler) GetIngredientHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid ingredient ID format"})
+	}
+
+	ingredient, err := h.service.GetIngredient(c.RequestCtx(), id)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Ingredient not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve ingredient"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Ingredient retrieved successfully",
+		Data:    ingredient,
+	})
+}
+
+// CreateIngredientHandler
+// @Summary      Create ingredient
+// @Description  Create an ingredient counted in g, ml or pcs. A starting stock is written to the ingredient ledger as a restock (Roles: admin, manager)
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Param        request body CreateIngredientRequest true "Ingredient details"
+// @Success      201 {object} common.SuccessResponse{data=IngredientResponse} "Ingredient created successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body"
+// @Failure      409 {object} common.ErrorResponse "Ingredient with this name already exists"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /inventory/ingredients [post]
+func (h *InventoryHandler) CreateIngredientHandler(c fiber.Ctx) error {
+	var req CreateIngredientRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("CreateIngredientHandler | Failed to parse request body: %v", err)
+		return h.bindError(c, err)
+	}
+
+	ingredient, err := h.service.CreateIngredient(c.RequestCtx(), req)
+	if err != nil {
+		if errors.Is(err, common.ErrIngredientExists) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to create ingredient"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(common.SuccessResponse{
+		Message: "Ingredient created successfully",
+		Data:    ingredient,
+	})
+}
+
+// UpdateIngredientHandler
+// @Summary      Update ingredient
+// @Description  Update an ingredient. Stock is the new absolute level; the difference goes to the ingredient ledger as change_type (restock when it rises, correction otherwise, or damage) (Roles: admin, manager)
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Ingredient ID" Format(uuid)
+// @Param        request body UpdateIngredientRequest true "Fields to update"
+// @Success      200 {object} common.SuccessResponse{data=IngredientResponse} "Ingredient updated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid ingredient ID or request body"
+// @Failure      404 {object} common.ErrorResponse "Ingredient not found"
+// @Failure      409 {object} common.ErrorResponse "Ingredient with this name already exists"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /inventory/ingredients/{id} [patch]
+func (h *InventoryHandler) UpdateIngredientHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid ingredient ID format"})
+	}
+
+	var req UpdateIngredientRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("UpdateIngredientHandler | Failed to parse request body: %v", err)
+		return h.bindError(c, err)
+	}
+
+	ingredient, err := h.service.UpdateIngredient(c.RequestCtx(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Ingredient not found"})
+		case errors.Is(err, common.ErrIngredientExists):
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to update ingredient"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Ingredient updated successfully",
+		Data:    ingredient,
+	})
+}
+
+// DeleteIngredientHandler
+// @Summary      Delete ingredient
+// @Description  Delete an ingredient that no product or option recipe uses any more (Roles: admin, manager)
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Ingredient ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse "Ingredient deleted successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid ingredient ID"
+// @Failure      404 {object} common.ErrorResponse "Ingredient not found"
+// @Failure      409 {object} common.ErrorResponse "Ingredient is used by product recipes"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /inventory/ingredients/{id} [delete]
+func (h *InventoryHandler) DeleteIngredientHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid ingredient ID format"})
+	}
+
+	if err := h.service.DeleteIngredient(c.RequestCtx(), id); err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Ingredient not found"})
+		case errors.Is(err, common.ErrIngredientInUse):
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to delete ingredient"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{Message: "Ingredient deleted successfully"})
+}
+
+// GetStockHistoryHandler
+// @Summary      Get ingredient stock history
+// @Description  Get the ingredient ledger: sales and refunds (referencing the order), restocks, corrections and damage, newest first (Roles: admin, manager)
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Ingredient ID" Format(uuid)
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Success      200 {object} common.SuccessResponse{data=PagedIngredientStockHistoryResponse} "Ingredient stock history retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid ingredient ID or query parameters"
+// @Failure      404 {object} common.ErrorResponse "Ingredient not found"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /inventory/ingredients/{id}/stock-history [get]
+func (h *InventoryHandler) GetStockHistoryHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid ingredient ID format"})
+	}
+
+	var req ListIngredientStockHistoryRequest
+	if err := c.Bind().Query(&req); err != nil {
+		h.log.Warnf("GetStockHistoryHandler | Failed to parse query parameters: %v", err)
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid query parameters"})
+	}
+
+	history, err := h.service.GetStockHistory(c.RequestCtx(), id, req)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Ingredient not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve ingredient stock history"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Ingredient stock history retrieved successfully",
+		Data:    history,
+	})
+}
+
+// GetProductRecipeHandler
+// @Summary      Get product recipe
+// @Description  Get what one unit of a product consumes, and what each of its options adds or swaps (Roles: admin, manager, cashier)
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Product ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=ProductRecipeResponse} "Product recipe retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid product ID"
+// @Failure      404 {object} common.ErrorResponse "Product not found"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /products/{id}/recipe [get]
+func (h *InventoryHandler) GetProductRecipeHandler(c fiber.Ctx) error {
+	productID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid product ID format"})
+	}
+
+	recipe, err := h.service.GetProductRecipe(c.RequestCtx(), productID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Product not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve product recipe"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Product recipe retrieved successfully",
+		Data:    recipe,
+	})
+}
+
+// SetProductRecipeHandler
+// @Summary      Set product recipe
+// @Description  Replace the recipe of one unit of a product. Products with a recipe are made to order: sales draw down their ingredients instead of the product stock. An empty list removes the recipe (Roles: admin, manager)
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Product ID" Format(uuid)
+// @Param        request body SetProductRecipeRequest true "Recipe lines"
+// @Success      200 {object} common.SuccessResponse{data=ProductRecipeResponse} "Product recipe saved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid product ID, request body, duplicate or unknown ingredient"
+// @Failure      404 {object} common.ErrorResponse "Product not found"
+// @Failure      409 {object} common.ErrorResponse "Product has variants, which keep their own stock"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /products/{id}/recipe [put]
+func (h *InventoryHandler) SetProductRecipeHandler(c fiber.Ctx) error {
+	productID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid product ID format"})
+	}
+
+	var req SetProductRecipeRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("SetProductRecipeHandler | Failed to parse request body: %v", err)
+		return h.bindError(c, err)
+	}
+
+	recipe, err := h.service.SetProductRecipe(c.RequestCtx(), productID, req)
+	if err != nil {
+		return h.recipeError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Product recipe saved successfully",
+		Data:    recipe,
+	})
+}
+
+// SetOptionRecipeHandler
+// @Summary      Set product option recipe
+// @Description  Replace what choosing an option adds to the product recipe. A line with replaces_ingredient_id swaps that ingredient of the product recipe, e.g. oat milk instead of milk (Roles: admin, manager)
+// @Tags         Inventory
+// @Accept       json
+// @Produce      json
+// @Param        product_id path string true "Product ID" Format(uuid)
+// @Param        option_id path string true "Product Option ID" Format(uuid)
+// @Param        request body SetOptionRecipeRequest true "Recipe lines"
+// @Success      200 {object} common.SuccessResponse{data=ProductRecipeResponse} "Option recipe saved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid IDs, request body, duplicate or unknown ingredient, or a replacement outside the product recipe"
+// @Failure      404 {object} common.ErrorResponse "Product or option not found"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /products/{product_id}/options/{option_id}/recipe [put]
+func (h *InventoryHandler) SetOptionRecipeHandler(c fiber.Ctx) error {
+	productID, err := fiber.Convert(c.Params("product_id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid product ID format"})
+	}
+	optionID, err := fiber.Convert(c.Params("option_id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid option ID format"})
+	}
+
+	var req SetOptionRecipeRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("SetOptionRecipeHandler | Failed to parse request body: %v", err)
+		return h.bindError(c, err)
+	}
+
+	recipe, err := h.service.SetOptionRecipe(c.RequestCtx(), productID, optionID, req)
+	if err != nil {
+		return h.recipeError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Option recipe saved successfully",
+		Data:    recipe,
+	})
+}
+
+func (h *InventoryHandler) bindError(c fiber.Ctx, err error) error {
+	var ve *validator.ValidationErrors
+	if errors.As(err, &ve) {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+			Message: "Validation failed",
+			Error:   ve.Error(),
+			Data:    map[string]interface{}{"errors": ve.Errors},
+		})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+}
+
+func (h *InventoryHandler) recipeError(c fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, common.ErrNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Product or option not found"})
+	case errors.Is(err, common.ErrRecipeInvalid), errors.Is(err, common.ErrIngredientNotFound):
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, common.ErrProductHasVariants):
+		return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to save recipe"})
+}
@@ -0,0 +1,158 @@
+package inventory_test
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/inventory"
+	"POS-kasir/internal/inventory/repository"
+	"POS-kasir/mocks"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInventoryHandler_CreateIngredientHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIInventoryService(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	handler := inventory.NewInventoryHandler(mockService, mockLogger)
+
+	app := fiber.New()
+	app.Post("/inventory/ingredients", handler.CreateIngredientHandler)
+
+	body := `{"name":"Milk","unit":"ml","stock":5000,"low_stock_threshold":1000,"cost_per_unit":20}`
+	expectedReq := inventory.CreateIngredientRequest{Name: "Milk", Unit: repository.IngredientUnitMl, Stock: 5000, LowStockThreshold: 1000, CostPerUnit: 20}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().CreateIngredient(gomock.Any(), expectedReq).
+			Return(&inventory.IngredientResponse{ID: uuid.New(), Name: "Milk", Unit: repository.IngredientUnitMl, Stock: 5000}, nil)
+
+		req := httptest.NewRequest("POST", "/inventory/ingredients", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+		assert.Equal(t, "ml", result["data"].(map[string]interface{})["unit"])
+	})
+
+	t.Run("NameTaken", func(t *testing.T) {
+		mockService.EXPECT().CreateIngredient(gomock.Any(), expectedReq).Return(nil, common.ErrIngredientExists)
+
+		req := httptest.NewRequest("POST", "/inventory/ingredients", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("InvalidBody", func(t *testing.T) {
+		mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any())
+
+		req := httptest.NewRequest("POST", "/inventory/ingredients", strings.NewReader("{invalid"))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestInventoryHandler_DeleteIngredientHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIInventoryService(ctrl)
+	handler := inventory.NewInventoryHandler(mockService, mocks.NewMockILogger(ctrl))
+
+	app := fiber.New()
+	app.Delete("/inventory/ingredients/:id", handler.DeleteIngredientHandler)
+
+	id := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().DeleteIngredient(gomock.Any(), id).Return(nil)
+
+		resp, _ := app.Test(httptest.NewRequest("DELETE", "/inventory/ingredients/"+id.String(), nil))
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("UsedByRecipe", func(t *testing.T) {
+		mockService.EXPECT().DeleteIngredient(gomock.Any(), id).Return(common.ErrIngredientInUse)
+
+		resp, _ := app.Test(httptest.NewRequest("DELETE", "/inventory/ingredients/"+id.String(), nil))
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService.EXPECT().DeleteIngredient(gomock.Any(), id).Return(common.ErrNotFound)
+
+		resp, _ := app.Test(httptest.NewRequest("DELETE", "/inventory/ingredients/"+id.String(), nil))
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		resp, _ := app.Test(httptest.NewRequest("DELETE", "/inventory/ingredients/not-a-uuid", nil))
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestInventoryHandler_SetProductRecipeHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIInventoryService(ctrl)
+	handler := inventory.NewInventoryHandler(mockService, mocks.NewMockILogger(ctrl))
+
+	app := fiber.New()
+	app.Put("/products/:id/recipe", handler.SetProductRecipeHandler)
+
+	productID := uuid.New()
+	milk := uuid.New()
+	body := `{"items":[{"ingredient_id":"` + milk.String() + `","quantity":200}]}`
+	expectedReq := inventory.SetProductRecipeRequest{Items: []inventory.RecipeItemRequest{{IngredientID: milk, Quantity: 200}}}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().SetProductRecipe(gomock.Any(), productID, expectedReq).
+			Return(&inventory.ProductRecipeResponse{ProductID: productID, ProductName: "Latte"}, nil)
+
+		req := httptest.NewRequest("PUT", "/products/"+productID.String()+"/recipe", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("UnknownIngredient", func(t *testing.T) {
+		mockService.EXPECT().SetProductRecipe(gomock.Any(), productID, expectedReq).Return(nil, common.ErrIngredientNotFound)
+
+		req := httptest.NewRequest("PUT", "/products/"+productID.String()+"/recipe", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("ProductNotFound", func(t *testing.T) {
+		mockService.EXPECT().SetProductRecipe(gomock.Any(), productID, expectedReq).Return(nil, common.ErrNotFound)
+
+		req := httptest.NewRequest("PUT", "/products/"+productID.String()+"/recipe", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
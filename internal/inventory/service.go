@@ -0,0 +1,651 @@
+package inventory
+
+import (
+	"POS-kasir/internal/activitylog"
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/common/pagination"
+	"POS-kasir/internal/common/store"
+	"POS-kasir/internal/inventory/repository"
+	"POS-kasir/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type IInventoryService interface {
+	ListIngredients(ctx context.Context, req ListIngredientsRequest) (*ListIngredientsResponse, error)
+	GetIngredient(ctx context.Context, id uuid.UUID) (*IngredientResponse, error)
+	CreateIngredient(ctx context.Context, req CreateIngredientRequest) (*IngredientResponse, error)
+	UpdateIngredient(ctx context.Context, id uuid.UUID, req UpdateIngredientRequest) (*IngredientResponse, error)
+	DeleteIngredient(ctx context.Context, id uuid.UUID) error
+	GetStockHistory(ctx context.Context, id uuid.UUID, req ListIngredientStockHistoryRequest) (*PagedIngredientStockHistoryResponse, error)
+	GetProductRecipe(ctx context.Context, productID uuid.UUID) (*ProductRecipeResponse, error)
+	SetProductRecipe(ctx context.Context, productID uuid.UUID, req SetProductRecipeRequest) (*ProductRecipeResponse, error)
+	SetOptionRecipe(ctx context.Context, productID, optionID uuid.UUID, req SetOptionRecipeRequest) (*ProductRecipeResponse, error)
+}
+
+type InventoryService struct {
+	store           store.Store
+	repo            repository.Querier
+	activityService activitylog.IActivityService
+	log             logger.ILogger
+}
+
+func NewInventoryService(store store.Store, repo repository.Querier, activityService activitylog.IActivityService, log logger.ILogger) IInventoryService {
+	return &InventoryService{
+		store:           store,
+		repo:            repo,
+		activityService: activityService,
+		log:             log,
+	}
+}
+
+func (s *InventoryService) ListIngredients(ctx context.Context, req ListIngredientsRequest) (*ListIngredientsResponse, error) {
+	req.SetDefaults()
+
+	var search *string
+	if req.Search != "" {
+		search = &req.Search
+	}
+
+	ingredients, err := s.repo.ListIngredients(ctx, repository.ListIngredientsParams{
+		Limit:        int32(req.Limit),
+		Offset:       int32((req.Page - 1) * req.Limit),
+		SearchText:   search,
+		LowStockOnly: req.LowStockOnly,
+	})
+	if err != nil {
+		s.log.Errorf("ListIngredients | Failed to list ingredients: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	total, err := s.repo.CountIngredients(ctx, repository.CountIngredientsParams{
+		SearchText:   search,
+		LowStockOnly: req.LowStockOnly,
+	})
+	if err != nil {
+		s.log.Errorf("ListIngredients | Failed to count ingredients: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	response := make([]IngredientResponse, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		response = append(response, toIngredientResponse(ingredient))
+	}
+
+	return &ListIngredientsResponse{
+		Ingredients: response,
+		Pagination:  pagination.BuildPagination(req.Page, int(total), req.Limit),
+	}, nil
+}
+
+func (s *InventoryService) GetIngredient(ctx context.Context, id uuid.UUID) (*IngredientResponse, error) {
+	ingredient, err := s.getIngredient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toIngredientResponse(ingredient)
+	return &response, nil
+}
+
+func (s *InventoryService) CreateIngredient(ctx context.Context, req CreateIngredientRequest) (*IngredientResponse, error) {
+	if err := s.ensureNameAvailable(ctx, req.Name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	var ingredient repository.Ingredient
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+
+		var err error
+		ingredient, err = qtx.CreateIngredient(ctx, repository.CreateIngredientParams{
+			Name:              req.Name,
+			Unit:              req.Unit,
+			Stock:             req.Stock,
+			LowStockThreshold: req.LowStockThreshold,
+			CostPerUnit:       toNumeric(req.CostPerUnit),
+		})
+		if err != nil {
+			return err
+		}
+
+		if ingredient.Stock == 0 {
+			return nil
+		}
+		_, err = qtx.CreateIngredientStockHistory(ctx, repository.CreateIngredientStockHistoryParams{
+			IngredientID:  ingredient.ID,
+			ChangeAmount:  ingredient.Stock,
+			PreviousStock: 0,
+			CurrentStock:  ingredient.Stock,
+			ChangeType:    repository.StockChangeTypeRestock,
+			Note:          stringPtr("Initial stock"),
+			CreatedBy:     actorUUID(ctx),
+		})
+		return err
+	})
+	if txErr != nil {
+		s.log.Errorf("CreateIngredient | Failed to create ingredient: %v", txErr)
+		return nil, common.ErrInternal
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeCREATE, ingredient.ID, map[string]interface{}{
+		"name":  ingredient.Name,
+		"unit":  ingredient.Unit,
+		"stock": ingredient.Stock,
+	})
+
+	response := toIngredientResponse(ingredient)
+	return &response, nil
+}
+
+func (s *InventoryService) UpdateIngredient(ctx context.Context, id uuid.UUID, req UpdateIngredientRequest) (*IngredientResponse, error) {
+	if _, err := s.getIngredient(ctx, id); err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		if err := s.ensureNameAvailable(ctx, *req.Name, id); err != nil {
+			return nil, err
+		}
+	}
+
+	var ingredient repository.Ingredient
+	var changeAmount int32
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+
+		existing, err := qtx.GetIngredientForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		params := repository.UpdateIngredientParams{
+			ID:                existing.ID,
+			Name:              existing.Name,
+			Stock:             existing.Stock,
+			LowStockThreshold: existing.LowStockThreshold,
+			CostPerUnit:       existing.CostPerUnit,
+		}
+		if req.Name != nil {
+			params.Name = *req.Name
+		}
+		if req.Stock != nil {
+			params.Stock = *req.Stock
+		}
+		if req.LowStockThreshold != nil {
+			params.LowStockThreshold = *req.LowStockThreshold
+		}
+		if req.CostPerUnit != nil {
+			params.CostPerUnit = toNumeric(*req.CostPerUnit)
+		}
+
+		ingredient, err = qtx.UpdateIngredient(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		changeAmount = ingredient.Stock - existing.Stock
+		if changeAmount == 0 {
+			return nil
+		}
+
+		changeType := repository.StockChangeTypeCorrection
+		if req.ChangeType != nil {
+			changeType = repository.StockChangeType(*req.ChangeType)
+		} else if changeAmount > 0 {
+			changeType = repository.StockChangeTypeRestock
+		}
+
+		_, err = qtx.CreateIngredientStockHistory(ctx, repository.CreateIngredientStockHistoryParams{
+			IngredientID:  ingredient.ID,
+			ChangeAmount:  changeAmount,
+			PreviousStock: existing.Stock,
+			CurrentStock:  ingredient.Stock,
+			ChangeType:    changeType,
+			Note:          req.Note,
+			CreatedBy:     actorUUID(ctx),
+		})
+		return err
+	})
+	if txErr != nil {
+		if errors.Is(txErr, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("UpdateIngredient | Failed to update ingredient: %v", txErr)
+		return nil, common.ErrInternal
+	}
+
+	details := map[string]interface{}{
+		"name":                ingredient.Name,
+		"low_stock_threshold": ingredient.LowStockThreshold,
+	}
+	if changeAmount != 0 {
+		details["stock"] = ingredient.Stock
+		details["change_amount"] = changeAmount
+	}
+	s.logActivity(ctx, activitylog_repo.LogActionTypeUPDATE, id, details)
+
+	response := toIngredientResponse(ingredient)
+	return &response, nil
+}
+
+func (s *InventoryService) DeleteIngredient(ctx context.Context, id uuid.UUID) error {
+	ingredient, err := s.getIngredient(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	recipes, err := s.repo.CountRecipesUsingIngredient(ctx, id)
+	if err != nil {
+		s.log.Errorf("DeleteIngredient | Failed to count recipes using ingredient: %v", err)
+		return common.ErrInternal
+	}
+	if recipes > 0 {
+		return common.ErrIngredientInUse
+	}
+
+	if err := s.repo.SoftDeleteIngredient(ctx, id); err != nil {
+		s.log.Errorf("DeleteIngredient | Failed to delete ingredient: %v", err)
+		return common.ErrInternal
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeDELETE, id, map[string]interface{}{
+		"deleted_ingredient_name": ingredient.Name,
+	})
+	return nil
+}
+
+func (s *InventoryService) GetStockHistory(ctx context.Context, id uuid.UUID, req ListIngredientStockHistoryRequest) (*PagedIngredientStockHistoryResponse, error) {
+	if _, err := s.getIngredient(ctx, id); err != nil {
+		return nil, err
+	}
+	req.SetDefaults()
+
+	history, err := s.repo.ListIngredientStockHistory(ctx, repository.ListIngredientStockHistoryParams{
+		IngredientID: id,
+		Limit:        int32(req.Limit),
+		Offset:       int32((req.Page - 1) * req.Limit),
+	})
+	if err != nil {
+		s.log.Errorf("GetStockHistory | Failed to list ingredient stock history: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	total, err := s.repo.CountIngredientStockHistory(ctx, id)
+	if err != nil {
+		s.log.Errorf("GetStockHistory | Failed to count ingredient stock history: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	response := make([]IngredientStockHistoryResponse, 0, len(history))
+	for _, h := range history {
+		response = append(response, IngredientStockHistoryResponse{
+			ID:            h.ID,
+			IngredientID:  h.IngredientID,
+			ChangeAmount:  h.ChangeAmount,
+			PreviousStock: h.PreviousStock,
+			CurrentStock:  h.CurrentStock,
+			ChangeType:    string(h.ChangeType),
+			ReferenceID:   uuidPtr(h.ReferenceID),
+			Note:          h.Note,
+			CreatedBy:     uuidPtr(h.CreatedBy),
+			CreatedAt:     h.CreatedAt.Time,
+		})
+	}
+
+	return &PagedIngredientStockHistoryResponse{
+		History:    response,
+		Pagination: pagination.BuildPagination(req.Page, int(total), req.Limit),
+	}, nil
+}
+
+func (s *InventoryService) GetProductRecipe(ctx context.Context, productID uuid.UUID) (*ProductRecipeResponse, error) {
+	product, err := s.repo.GetRecipeProduct(ctx, productID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("GetProductRecipe | Failed to get product: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	items, err := s.repo.ListProductRecipeItems(ctx, productID)
+	if err != nil {
+		s.log.Errorf("GetProductRecipe | Failed to list product recipe: %v", err)
+		return nil, common.ErrInternal
+	}
+	options, err := s.repo.ListRecipeProductOptions(ctx, productID)
+	if err != nil {
+		s.log.Errorf("GetProductRecipe | Failed to list product options: %v", err)
+		return nil, common.ErrInternal
+	}
+	optionItems, err := s.repo.ListOptionRecipeItemsByProduct(ctx, productID)
+	if err != nil {
+		s.log.Errorf("GetProductRecipe | Failed to list option recipes: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	return buildProductRecipe(product, items, options, optionItems), nil
+}
+
+func (s *InventoryService) SetProductRecipe(ctx context.Context, productID uuid.UUID, req SetProductRecipeRequest) (*ProductRecipeResponse, error) {
+	product, err := s.getRecipeProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	// A made-to-order product has no stock of its own, while each variant keeps one
+	if product.HasVariants && len(req.Items) > 0 {
+		return nil, common.ErrProductHasVariants
+	}
+
+	ingredientIDs := make([]uuid.UUID, 0, len(req.Items))
+	for _, item := range req.Items {
+		ingredientIDs = append(ingredientIDs, item.IngredientID)
+	}
+	if hasDuplicates(ingredientIDs) {
+		return nil, common.ErrRecipeInvalid
+	}
+	if err := s.ensureIngredientsExist(ctx, ingredientIDs); err != nil {
+		return nil, err
+	}
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+
+		if err := qtx.DeleteProductRecipe(ctx, productID); err != nil {
+			return err
+		}
+		for _, item := range req.Items {
+			if err := qtx.CreateProductRecipeItem(ctx, repository.CreateProductRecipeItemParams{
+				ProductID:    productID,
+				IngredientID: item.IngredientID,
+				Quantity:     item.Quantity,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		s.log.Errorf("SetProductRecipe | Failed to save product recipe: %v", txErr)
+		return nil, common.ErrInternal
+	}
+
+	s.logRecipeChange(ctx, productID, map[string]interface{}{
+		"product_id": productID.String(),
+		"recipe":     req.Items,
+	})
+
+	return s.GetProductRecipe(ctx, productID)
+}
+
+func (s *InventoryService) SetOptionRecipe(ctx context.Context, productID, optionID uuid.UUID, req SetOptionRecipeRequest) (*ProductRecipeResponse, error) {
+	if _, err := s.getRecipeProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	options, err := s.repo.ListRecipeProductOptions(ctx, productID)
+	if err != nil {
+		s.log.Errorf("SetOptionRecipe | Failed to list product options: %v", err)
+		return nil, common.ErrInternal
+	}
+	found := false
+	for _, option := range options {
+		if option.ID == optionID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, common.ErrNotFound
+	}
+
+	productItems, err := s.repo.ListProductRecipeItems(ctx, productID)
+	if err != nil {
+		s.log.Errorf("SetOptionRecipe | Failed to list product recipe: %v", err)
+		return nil, common.ErrInternal
+	}
+	inRecipe := make(map[uuid.UUID]bool, len(productItems))
+	for _, item := range productItems {
+		inRecipe[item.IngredientID] = true
+	}
+
+	ingredientIDs := make([]uuid.UUID, 0, len(req.Items))
+	var replaced []uuid.UUID
+	for _, item := range req.Items {
+		ingredientIDs = append(ingredientIDs, item.IngredientID)
+		if item.ReplacesIngredientID != nil {
+			// Only an ingredient of the product recipe can be swapped out
+			if *item.ReplacesIngredientID == item.IngredientID || !inRecipe[*item.ReplacesIngredientID] {
+				return nil, common.ErrRecipeInvalid
+			}
+			replaced = append(replaced, *item.ReplacesIngredientID)
+		}
+	}
+	if hasDuplicates(ingredientIDs) || hasDuplicates(replaced) {
+		return nil, common.ErrRecipeInvalid
+	}
+	if err := s.ensureIngredientsExist(ctx, ingredientIDs); err != nil {
+		return nil, err
+	}
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+
+		if err := qtx.DeleteOptionRecipe(ctx, optionID); err != nil {
+			return err
+		}
+		for _, item := range req.Items {
+			replaces := pgtype.UUID{}
+			if item.ReplacesIngredientID != nil {
+				replaces = pgtype.UUID{Bytes: *item.ReplacesIngredientID, Valid: true}
+			}
+			if err := qtx.CreateOptionRecipeItem(ctx, repository.CreateOptionRecipeItemParams{
+				ProductOptionID:      optionID,
+				IngredientID:         item.IngredientID,
+				Quantity:             item.Quantity,
+				ReplacesIngredientID: replaces,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		s.log.Errorf("SetOptionRecipe | Failed to save option recipe: %v", txErr)
+		return nil, common.ErrInternal
+	}
+
+	s.logRecipeChange(ctx, productID, map[string]interface{}{
+		"product_id": productID.String(),
+		"option_id":  optionID.String(),
+		"recipe":     req.Items,
+	})
+
+	return s.GetProductRecipe(ctx, productID)
+}
+
+func (s *InventoryService) getIngredient(ctx context.Context, id uuid.UUID) (repository.Ingredient, error) {
+	ingredient, err := s.repo.GetIngredient(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warnf("getIngredient | Ingredient not found: id=%s", id)
+			return ingredient, common.ErrNotFound
+		}
+		s.log.Errorf("getIngredient | Failed to get ingredient: %v", err)
+		return ingredient, common.ErrInternal
+	}
+	return ingredient, nil
+}
+
+func (s *InventoryService) getRecipeProduct(ctx context.Context, productID uuid.UUID) (repository.GetRecipeProductRow, error) {
+	product, err := s.repo.GetRecipeProduct(ctx, productID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warnf("getRecipeProduct | Product not found: id=%s", productID)
+			return product, common.ErrNotFound
+		}
+		s.log.Errorf("getRecipeProduct | Failed to get product: %v", err)
+		return product, common.ErrInternal
+	}
+	return product, nil
+}
+
+// ensureNameAvailable rejects a name already used by another ingredient; exceptID is the ingredient being renamed.
+func (s *InventoryService) ensureNameAvailable(ctx context.Context, name string, exceptID uuid.UUID) error {
+	existing, err := s.repo.GetIngredientByName(ctx, name)
+	if err == nil {
+		if existing.ID != exceptID {
+			return common.ErrIngredientExists
+		}
+		return nil
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	s.log.Errorf("ensureNameAvailable | Failed to check ingredient name: %v", err)
+	return common.ErrInternal
+}
+
+func (s *InventoryService) ensureIngredientsExist(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	found, err := s.repo.GetIngredientsByIDs(ctx, ids)
+	if err != nil {
+		s.log.Errorf("ensureIngredientsExist | Failed to get ingredients: %v", err)
+		return common.ErrInternal
+	}
+	if len(found) != len(ids) {
+		return common.ErrIngredientNotFound
+	}
+	return nil
+}
+
+func (s *InventoryService) logActivity(ctx context.Context, action activitylog_repo.LogActionType, id uuid.UUID, details map[string]interface{}) {
+	actorID, ok := ctx.Value(common.UserIDKey).(uuid.UUID)
+	if !ok {
+		s.log.Warnf("InventoryService | Actor user ID not found in context for activity logging")
+	}
+
+	s.activityService.Log(
+		ctx,
+		actorID,
+		action,
+		activitylog_repo.LogEntityTypeINGREDIENT,
+		id.String(),
+		details,
+	)
+}
+
+// logRecipeChange records recipe edits against the product they belong to.
+func (s *InventoryService) logRecipeChange(ctx context.Context, productID uuid.UUID, details map[string]interface{}) {
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeUPDATE,
+		activitylog_repo.LogEntityTypePRODUCT,
+		productID.String(),
+		details,
+	)
+}
+
+func buildProductRecipe(product repository.GetRecipeProductRow, items []repository.ListProductRecipeItemsRow, options []repository.ListRecipeProductOptionsRow, optionItems []repository.ListOptionRecipeItemsByProductRow) *ProductRecipeResponse {
+	response := &ProductRecipeResponse{
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		Items:       make([]RecipeItemResponse, 0, len(items)),
+		Options:     make([]OptionRecipeResponse, 0, len(options)),
+	}
+	for _, item := range items {
+		response.Items = append(response.Items, RecipeItemResponse{
+			IngredientID:   item.IngredientID,
+			IngredientName: item.IngredientName,
+			Unit:           item.Unit,
+			Quantity:       item.Quantity,
+		})
+	}
+
+	byOption := make(map[uuid.UUID][]OptionRecipeItemResponse)
+	for _, item := range optionItems {
+		byOption[item.ProductOptionID] = append(byOption[item.ProductOptionID], OptionRecipeItemResponse{
+			RecipeItemResponse: RecipeItemResponse{
+				IngredientID:   item.IngredientID,
+				IngredientName: item.IngredientName,
+				Unit:           item.Unit,
+				Quantity:       item.Quantity,
+			},
+			ReplacesIngredientID:   uuidPtr(item.ReplacesIngredientID),
+			ReplacesIngredientName: item.ReplacesIngredientName,
+		})
+	}
+	for _, option := range options {
+		recipe := byOption[option.ID]
+		if recipe == nil {
+			recipe = []OptionRecipeItemResponse{}
+		}
+		response.Options = append(response.Options, OptionRecipeResponse{
+			OptionID:   option.ID,
+			OptionName: option.Name,
+			Items:      recipe,
+		})
+	}
+	return response
+}
+
+func toIngredientResponse(ingredient repository.Ingredient) IngredientResponse {
+	cost, _ := ingredient.CostPerUnit.Float64Value()
+	return IngredientResponse{
+		ID:                ingredient.ID,
+		Name:              ingredient.Name,
+		Unit:              ingredient.Unit,
+		Stock:             ingredient.Stock,
+		LowStockThreshold: ingredient.LowStockThreshold,
+		IsLowStock:        ingredient.Stock <= ingredient.LowStockThreshold,
+		CostPerUnit:       cost.Float64,
+		CreatedAt:         ingredient.CreatedAt.Time,
+		UpdatedAt:         ingredient.UpdatedAt.Time,
+	}
+}
+
+func toNumeric(value float64) pgtype.Numeric {
+	numeric := pgtype.Numeric{}
+	_ = numeric.Scan(fmt.Sprintf("%.4f", value))
+	return numeric
+}
+
+func hasDuplicates(ids []uuid.UUID) bool {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return true
+		}
+		seen[id] = true
+	}
+	return false
+}
+
+func actorUUID(ctx context.Context) pgtype.UUID {
+	if actorID, ok := ctx.Value(common.UserIDKey).(uuid.UUID); ok {
+		return pgtype.UUID{Bytes: actorID, Valid: true}
+	}
+	return pgtype.UUID{}
+}
+
+func uuidPtr(id pgtype.UUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	value := uuid.UUID(id.Bytes)
+	return &value
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,317 @@
+package inventory_test
+
+import (
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/inventory"
+	"POS-kasir/internal/inventory/repository"
+	"POS-kasir/mocks"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var ingredientColumns = []string{
+	"id", "name", "unit", "stock", "low_stock_threshold", "cost_per_unit", "created_at", "updated_at", "deleted_at",
+}
+
+var ingredientHistoryColumns = []string{
+	"id", "ingredient_id", "change_amount", "previous_stock", "current_stock",
+	"change_type", "reference_id", "note", "created_by", "created_at",
+}
+
+func allowAllLoggerCalls(mockLogger *mocks.MockILogger) {
+	mockLogger.EXPECT().Warnf(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+}
+
+func setupTest(t *testing.T) (*mocks.MockStore, *mocks.MockInventoryRepo, *mocks.MockIActivityService, inventory.IInventoryService) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	mockRepo := mocks.NewMockInventoryRepo(ctrl)
+	mockActivity := mocks.NewMockIActivityService(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	allowAllLoggerCalls(mockLogger)
+
+	service := inventory.NewInventoryService(mockStore, mockRepo, mockActivity, mockLogger)
+	return mockStore, mockRepo, mockActivity, service
+}
+
+func TestInventoryService_CreateIngredient(t *testing.T) {
+	req := inventory.CreateIngredientRequest{Name: "Milk", Unit: repository.IngredientUnitMl, Stock: 5000, LowStockThreshold: 1000, CostPerUnit: 20}
+
+	t.Run("InitialStockIsLedgered", func(t *testing.T) {
+		mockStore, mockRepo, mockActivity, service := setupTest(t)
+		ctx := context.Background()
+		now := time.Now()
+		id := uuid.New()
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		mockRepo.EXPECT().GetIngredientByName(ctx, "Milk").Return(repository.Ingredient{}, pgx.ErrNoRows)
+		mockStore.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+			return fn(mockPgx)
+		})
+		mockPgx.ExpectQuery("INSERT INTO ingredients").
+			WithArgs("Milk", repository.IngredientUnitMl, int32(5000), int32(1000), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(ingredientColumns).
+				AddRow(id, "Milk", repository.IngredientUnitMl, int32(5000), int32(1000), pgtype.Numeric{}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}))
+		mockPgx.ExpectQuery("INSERT INTO ingredient_stock_history").
+			WithArgs(id, int32(5000), int32(0), int32(5000), repository.StockChangeTypeRestock, pgtype.UUID{}, pgxmock.AnyArg(), pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(ingredientHistoryColumns).
+				AddRow(uuid.New(), id, int32(5000), int32(0), int32(5000), repository.StockChangeTypeRestock, pgtype.UUID{}, nil, pgtype.UUID{}, pgtype.Timestamptz{Time: now, Valid: true}))
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), activitylog_repo.LogActionTypeCREATE, activitylog_repo.LogEntityTypeINGREDIENT, id.String(), gomock.Any())
+
+		resp, err := service.CreateIngredient(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, id, resp.ID)
+		assert.False(t, resp.IsLowStock)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("NameTaken", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetIngredientByName(ctx, "Milk").Return(repository.Ingredient{ID: uuid.New(), Name: "Milk"}, nil)
+
+		resp, err := service.CreateIngredient(ctx, req)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrIngredientExists)
+	})
+}
+
+func TestInventoryService_UpdateIngredient(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("StockChangeIsLedgered", func(t *testing.T) {
+		mockStore, mockRepo, mockActivity, service := setupTest(t)
+		ctx := context.Background()
+		now := time.Now()
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		stock := int32(800)
+		changeType := "damage"
+		note := "Spilled"
+		existing := []interface{}{id, "Milk", repository.IngredientUnitMl, int32(1000), int32(500), pgtype.Numeric{}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}}
+
+		mockRepo.EXPECT().GetIngredient(ctx, id).Return(repository.Ingredient{ID: id, Name: "Milk", Stock: 1000}, nil)
+		mockStore.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+			return fn(mockPgx)
+		})
+		mockPgx.ExpectQuery("SELECT .* FROM ingredients").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(ingredientColumns).AddRow(existing...))
+		mockPgx.ExpectQuery("UPDATE ingredients").
+			WithArgs(id, "Milk", int32(800), int32(500), pgtype.Numeric{}).
+			WillReturnRows(pgxmock.NewRows(ingredientColumns).
+				AddRow(id, "Milk", repository.IngredientUnitMl, int32(800), int32(500), pgtype.Numeric{}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}))
+		mockPgx.ExpectQuery("INSERT INTO ingredient_stock_history").
+			WithArgs(id, int32(-200), int32(1000), int32(800), repository.StockChangeTypeDamage, pgtype.UUID{}, &note, pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(ingredientHistoryColumns).
+				AddRow(uuid.New(), id, int32(-200), int32(1000), int32(800), repository.StockChangeTypeDamage, pgtype.UUID{}, &note, pgtype.UUID{}, pgtype.Timestamptz{Time: now, Valid: true}))
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), activitylog_repo.LogActionTypeUPDATE, activitylog_repo.LogEntityTypeINGREDIENT, id.String(), gomock.Any())
+
+		resp, err := service.UpdateIngredient(ctx, id, inventory.UpdateIngredientRequest{Stock: &stock, ChangeType: &changeType, Note: &note})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(800), resp.Stock)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetIngredient(ctx, id).Return(repository.Ingredient{}, pgx.ErrNoRows)
+
+		resp, err := service.UpdateIngredient(ctx, id, inventory.UpdateIngredientRequest{})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+}
+
+func TestInventoryService_DeleteIngredient(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		_, mockRepo, mockActivity, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetIngredient(ctx, id).Return(repository.Ingredient{ID: id, Name: "Milk"}, nil)
+		mockRepo.EXPECT().CountRecipesUsingIngredient(ctx, id).Return(int64(0), nil)
+		mockRepo.EXPECT().SoftDeleteIngredient(ctx, id).Return(nil)
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), activitylog_repo.LogActionTypeDELETE, activitylog_repo.LogEntityTypeINGREDIENT, id.String(), gomock.Any())
+
+		assert.NoError(t, service.DeleteIngredient(ctx, id))
+	})
+
+	t.Run("UsedByRecipe", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetIngredient(ctx, id).Return(repository.Ingredient{ID: id, Name: "Milk"}, nil)
+		mockRepo.EXPECT().CountRecipesUsingIngredient(ctx, id).Return(int64(2), nil)
+
+		assert.ErrorIs(t, service.DeleteIngredient(ctx, id), common.ErrIngredientInUse)
+	})
+}
+
+func TestInventoryService_SetProductRecipe(t *testing.T) {
+	productID := uuid.New()
+	milk := uuid.New()
+	espresso := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockStore, mockRepo, mockActivity, service := setupTest(t)
+		ctx := context.Background()
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		product := repository.GetRecipeProductRow{ID: productID, Name: "Latte"}
+		mockRepo.EXPECT().GetRecipeProduct(ctx, productID).Return(product, nil).Times(2)
+		mockRepo.EXPECT().GetIngredientsByIDs(ctx, []uuid.UUID{espresso, milk}).Return([]repository.Ingredient{{ID: espresso}, {ID: milk}}, nil)
+		mockStore.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+			return fn(mockPgx)
+		})
+		mockPgx.ExpectExec("DELETE FROM product_recipe_items").
+			WithArgs(productID).
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mockPgx.ExpectExec("INSERT INTO product_recipe_items").
+			WithArgs(productID, espresso, int32(18)).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPgx.ExpectExec("INSERT INTO product_recipe_items").
+			WithArgs(productID, milk, int32(200)).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), activitylog_repo.LogActionTypeUPDATE, activitylog_repo.LogEntityTypePRODUCT, productID.String(), gomock.Any())
+
+		oatOption := uuid.New()
+		oat := uuid.New()
+		mockRepo.EXPECT().ListProductRecipeItems(ctx, productID).Return([]repository.ListProductRecipeItemsRow{
+			{IngredientID: espresso, IngredientName: "Espresso", Unit: repository.IngredientUnitG, Quantity: 18},
+			{IngredientID: milk, IngredientName: "Milk", Unit: repository.IngredientUnitMl, Quantity: 200},
+		}, nil)
+		mockRepo.EXPECT().ListRecipeProductOptions(ctx, productID).Return([]repository.ListRecipeProductOptionsRow{
+			{ID: oatOption, Name: "Oat milk"},
+			{ID: uuid.New(), Name: "Extra hot"},
+		}, nil)
+		replaced := "Milk"
+		mockRepo.EXPECT().ListOptionRecipeItemsByProduct(ctx, productID).Return([]repository.ListOptionRecipeItemsByProductRow{
+			{ProductOptionID: oatOption, IngredientID: oat, IngredientName: "Oat milk", Unit: repository.IngredientUnitMl, Quantity: 200, ReplacesIngredientID: pgtype.UUID{Bytes: milk, Valid: true}, ReplacesIngredientName: &replaced},
+		}, nil)
+
+		resp, err := service.SetProductRecipe(ctx, productID, inventory.SetProductRecipeRequest{Items: []inventory.RecipeItemRequest{
+			{IngredientID: espresso, Quantity: 18},
+			{IngredientID: milk, Quantity: 200},
+		}})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Items, 2)
+		assert.Len(t, resp.Options, 2)
+		assert.Equal(t, milk, *resp.Options[0].Items[0].ReplacesIngredientID)
+		// Options without a recipe are listed with an empty one
+		assert.NotNil(t, resp.Options[1].Items)
+		assert.Empty(t, resp.Options[1].Items)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("DuplicateIngredient", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetRecipeProduct(ctx, productID).Return(repository.GetRecipeProductRow{ID: productID}, nil)
+
+		resp, err := service.SetProductRecipe(ctx, productID, inventory.SetProductRecipeRequest{Items: []inventory.RecipeItemRequest{
+			{IngredientID: milk, Quantity: 100},
+			{IngredientID: milk, Quantity: 100},
+		}})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrRecipeInvalid)
+	})
+
+	t.Run("ProductWithVariants", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetRecipeProduct(ctx, productID).Return(repository.GetRecipeProductRow{ID: productID, HasVariants: true}, nil)
+
+		resp, err := service.SetProductRecipe(ctx, productID, inventory.SetProductRecipeRequest{Items: []inventory.RecipeItemRequest{
+			{IngredientID: milk, Quantity: 100},
+		}})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrProductHasVariants)
+	})
+
+	t.Run("UnknownIngredient", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetRecipeProduct(ctx, productID).Return(repository.GetRecipeProductRow{ID: productID}, nil)
+		mockRepo.EXPECT().GetIngredientsByIDs(ctx, []uuid.UUID{milk}).Return([]repository.Ingredient{}, nil)
+
+		resp, err := service.SetProductRecipe(ctx, productID, inventory.SetProductRecipeRequest{Items: []inventory.RecipeItemRequest{
+			{IngredientID: milk, Quantity: 100},
+		}})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrIngredientNotFound)
+	})
+}
+
+func TestInventoryService_SetOptionRecipe(t *testing.T) {
+	productID := uuid.New()
+	optionID := uuid.New()
+	milk := uuid.New()
+	oat := uuid.New()
+	sugar := uuid.New()
+
+	t.Run("ReplacementMustBeInProductRecipe", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetRecipeProduct(ctx, productID).Return(repository.GetRecipeProductRow{ID: productID}, nil)
+		mockRepo.EXPECT().ListRecipeProductOptions(ctx, productID).Return([]repository.ListRecipeProductOptionsRow{{ID: optionID, Name: "Oat milk"}}, nil)
+		mockRepo.EXPECT().ListProductRecipeItems(ctx, productID).Return([]repository.ListProductRecipeItemsRow{{IngredientID: milk, Quantity: 200}}, nil)
+
+		resp, err := service.SetOptionRecipe(ctx, productID, optionID, inventory.SetOptionRecipeRequest{Items: []inventory.OptionRecipeItemRequest{
+			{IngredientID: oat, Quantity: 200, ReplacesIngredientID: &sugar},
+		}})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrRecipeInvalid)
+	})
+
+	t.Run("OptionOfAnotherProduct", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetRecipeProduct(ctx, productID).Return(repository.GetRecipeProductRow{ID: productID}, nil)
+		mockRepo.EXPECT().ListRecipeProductOptions(ctx, productID).Return([]repository.ListRecipeProductOptionsRow{{ID: uuid.New(), Name: "Large"}}, nil)
+
+		resp, err := service.SetOptionRecipe(ctx, productID, optionID, inventory.SetOptionRecipeRequest{})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+}
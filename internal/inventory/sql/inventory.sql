@@ -0,0 +1,142 @@
+-- name: ListIngredients :many
+-- Mengambil bahan baku aktif, opsional difilter nama dan stok menipis.
+SELECT * FROM ingredients
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(search_text)::text IS NULL OR name ILIKE '%' || sqlc.narg(search_text) || '%')
+  AND (NOT sqlc.arg(low_stock_only)::bool OR stock <= low_stock_threshold)
+ORDER BY name ASC
+LIMIT $1 OFFSET $2;
+
+-- name: CountIngredients :one
+SELECT COUNT(*) FROM ingredients
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(search_text)::text IS NULL OR name ILIKE '%' || sqlc.narg(search_text) || '%')
+  AND (NOT sqlc.arg(low_stock_only)::bool OR stock <= low_stock_threshold);
+
+-- name: GetIngredient :one
+SELECT * FROM ingredients
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetIngredientForUpdate :one
+-- Mengunci baris bahan baku selama penyesuaian stok.
+SELECT * FROM ingredients
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: GetIngredientByName :one
+-- Dipakai untuk memastikan nama bahan baku unik (tanpa membedakan huruf besar/kecil).
+SELECT * FROM ingredients
+WHERE lower(name) = lower(sqlc.arg(name)::text) AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetIngredientsByIDs :many
+SELECT * FROM ingredients
+WHERE id = ANY(sqlc.arg(ids)::uuid[]) AND deleted_at IS NULL;
+
+-- name: CreateIngredient :one
+INSERT INTO ingredients (name, unit, stock, low_stock_threshold, cost_per_unit)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: UpdateIngredient :one
+UPDATE ingredients
+SET
+    name = $2,
+    stock = $3,
+    low_stock_threshold = $4,
+    cost_per_unit = $5
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: SoftDeleteIngredient :exec
+UPDATE ingredients SET deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: CountRecipesUsingIngredient :one
+-- Menghitung resep produk dan opsi yang masih memakai bahan baku ini.
+SELECT
+    (SELECT COUNT(*) FROM product_recipe_items pri WHERE pri.ingredient_id = sqlc.arg(ingredient_id)::uuid)
+  + (SELECT COUNT(*) FROM product_option_recipe_items pori
+     WHERE pori.ingredient_id = sqlc.arg(ingredient_id)::uuid OR pori.replaces_ingredient_id = sqlc.arg(ingredient_id)::uuid)
+    AS recipe_count;
+
+-- name: CreateIngredientStockHistory :one
+INSERT INTO ingredient_stock_history (
+    ingredient_id,
+    change_amount,
+    previous_stock,
+    current_stock,
+    change_type,
+    reference_id,
+    note,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: ListIngredientStockHistory :many
+SELECT * FROM ingredient_stock_history
+WHERE ingredient_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountIngredientStockHistory :one
+SELECT COUNT(*) FROM ingredient_stock_history
+WHERE ingredient_id = $1;
+
+-- name: GetRecipeProduct :one
+-- Produk aktif yang resepnya sedang dibaca atau diubah.
+SELECT p.id, p.name,
+       EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = p.id AND pv.deleted_at IS NULL) AS has_variants
+FROM products p
+WHERE p.id = $1 AND p.deleted_at IS NULL;
+
+-- name: ListRecipeProductOptions :many
+-- Opsi aktif sebuah produk; opsi tanpa resep tetap ditampilkan.
+SELECT id, name FROM product_options
+WHERE product_id = $1 AND deleted_at IS NULL
+ORDER BY name;
+
+-- name: ListProductRecipeItems :many
+SELECT
+    pri.ingredient_id,
+    i.name AS ingredient_name,
+    i.unit,
+    pri.quantity
+FROM product_recipe_items pri
+JOIN ingredients i ON i.id = pri.ingredient_id
+WHERE pri.product_id = $1
+ORDER BY i.name;
+
+-- name: ListOptionRecipeItemsByProduct :many
+-- Resep semua opsi sebuah produk, termasuk bahan yang digantikan.
+SELECT
+    pori.product_option_id,
+    pori.ingredient_id,
+    i.name AS ingredient_name,
+    i.unit,
+    pori.quantity,
+    pori.replaces_ingredient_id,
+    r.name AS replaces_ingredient_name
+FROM product_option_recipe_items pori
+JOIN product_options po ON po.id = pori.product_option_id
+JOIN ingredients i ON i.id = pori.ingredient_id
+LEFT JOIN ingredients r ON r.id = pori.replaces_ingredient_id
+WHERE po.product_id = $1
+ORDER BY pori.product_option_id, i.name;
+
+-- name: DeleteProductRecipe :exec
+DELETE FROM product_recipe_items
+WHERE product_id = $1;
+
+-- name: CreateProductRecipeItem :exec
+INSERT INTO product_recipe_items (product_id, ingredient_id, quantity)
+VALUES ($1, $2, $3);
+
+-- name: DeleteOptionRecipe :exec
+DELETE FROM product_option_recipe_items
+WHERE product_option_id = $1;
+
+-- name: CreateOptionRecipeItem :exec
+INSERT INTO product_option_recipe_items (product_option_id, ingredient_id, quantity, replaces_ingredient_id)
+VALUES ($1, $2, $3, $4);
@@ -0,0 +1,80 @@
+package kitchen
+
+import (
+	"POS-kasir/internal/kitchen/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type KitchenStationResponse struct {
+	ID          int32     `json:"id"`
+	Name        string    `json:"name"`
+	SortOrder   int32     `json:"sort_order"`
+	IsActive    bool      `json:"is_active"`
+	CategoryIDs []int32   `json:"category_ids"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type CreateKitchenStationRequest struct {
+	Name        string  `json:"name" validate:"required,min=2,max=50"`
+	SortOrder   int32   `json:"sort_order" validate:"gte=0"`
+	CategoryIDs []int32 `json:"category_ids" validate:"omitempty,dive,gt=0"`
+}
+
+type UpdateKitchenStationRequest struct {
+	Name      *string `json:"name" validate:"omitempty,min=2,max=50"`
+	SortOrder *int32  `json:"sort_order" validate:"omitempty,gte=0"`
+	IsActive  *bool   `json:"is_active"`
+	// CategoryIDs replaces the station's categories when present; an empty list unmaps them all.
+	CategoryIDs *[]int32 `json:"category_ids" validate:"omitempty,dive,gt=0"`
+}
+
+type ListKitchenTicketsRequest struct {
+	StationID     *int32 `query:"station_id" validate:"omitempty,gt=0"`
+	IncludeServed bool   `query:"include_served"`
+}
+
+type SetItemPriorityRequest struct {
+	IsPriority bool `json:"is_priority"`
+}
+
+type KitchenTicketItemResponse struct {
+	ID          uuid.UUID                  `json:"id"`
+	ProductID   uuid.UUID                  `json:"product_id"`
+	ProductName string                     `json:"product_name"`
+	Quantity    int32                      `json:"quantity"`
+	Options     []string                   `json:"options"`
+	StationID   *int32                     `json:"station_id,omitempty"`
+	PrepStatus  repository.OrderItemStatus `json:"prep_status"`
+	IsPriority  bool                       `json:"is_priority"`
+	QueuedAt    time.Time                  `json:"queued_at"`
+	StartedAt   *time.Time                 `json:"started_at,omitempty"`
+	ReadyAt     *time.Time                 `json:"ready_at,omitempty"`
+	ServedAt    *time.Time                 `json:"served_at,omitempty"`
+}
+
+// KitchenTicketResponse is one order as seen by one station: only the lines routed to that station are listed.
+type KitchenTicketResponse struct {
+	OrderID        uuid.UUID                   `json:"order_id"`
+	StationID      *int32                      `json:"station_id,omitempty"`
+	OrderType      repository.OrderType        `json:"order_type"`
+	OrderStatus    repository.OrderStatus      `json:"order_status"`
+	OrderCreatedAt time.Time                   `json:"order_created_at"`
+	IsPriority     bool                        `json:"is_priority"`
+	Items          []KitchenTicketItemResponse `json:"items"`
+}
+
+type KitchenItemResponse struct {
+	ID          uuid.UUID                  `json:"id"`
+	OrderID     uuid.UUID                  `json:"order_id"`
+	StationID   *int32                     `json:"station_id,omitempty"`
+	PrepStatus  repository.OrderItemStatus `json:"prep_status"`
+	IsPriority  bool                       `json:"is_priority"`
+	QueuedAt    time.Time                  `json:"queued_at"`
+	StartedAt   *time.Time                 `json:"started_at,omitempty"`
+	ReadyAt     *time.Time                 `json:"ready_at,omitempty"`
+	ServedAt    *time.Time                 `json:"served_at,omitempty"`
+	OrderStatus repository.OrderStatus     `json:"order_status"`
+}
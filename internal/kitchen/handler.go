@@ -0,0 +1,347 @@
+package kitchen
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/pkg/logger"
+	"POS-kasir/pkg/validator"
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type IKitchenHandler interface {
+	ListStationsHandler(c fiber.Ctx) error
+	GetStationHandler(c fiber.Ctx) error
+	CreateStationHandler(c fiber.Ctx) error
+	UpdateStationHandler(c fiber.Ctx) error
+	DeleteStationHandler(c fiber.Ctx) error
+	ListTicketsHandler(c fiber.Ctx) error
+	BumpItemHandler(c fiber.Ctx) error
+	RecallItemHandler(c fiber.Ctx) error
+	SetItemPriorityHandler(c fiber.Ctx) error
+}
+
+type KitchenHandler struct {
+	service IKitchenService
+	log     logger.ILogger
+}
+
+func NewKitchenHandler(service IKitchenService, log logger.ILogger) IKitchenHandler {
+	return &KitchenHandler{service: service, log: log}
+}
+
+// ListStationsHandler
+// @Summary      List kitchen stations
+// @Description  Get every kitchen station with the categories routed to it (Roles: admin, manager, cashier)
+// @Tags         Kitchen
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} common.SuccessResponse{data=[]KitchenStationResponse} "Kitchen stations retrieved successfully"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /kds/stations [get]
+func (h *KitchenHandler) ListStationsHandler(c fiber.Ctx) error {
+	stations, err := h.service.ListStations(c.RequestCtx())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve kitchen stations"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Kitchen stations retrieved successfully",
+		Data:    stations,
+	})
+}
+
+// GetStationHandler
+// @Summary      Get kitchen station by ID
+// @Description  Get a single kitchen station with its categories (Roles: admin, manager, cashier)
+// @Tags         Kitchen
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Kitchen Station ID"
+// @Success      200 {object} common.SuccessResponse{data=KitchenStationResponse} "Kitchen station retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid kitchen station ID"
+// @Failure      404 {object} common.ErrorResponse "Kitchen station not found"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /kds/stations/{id} [get]
+func (h *KitchenHandler) GetStationHandler(c fiber.Ctx) error {
+	id := fiber.Params[int](c, "id")
+	if id == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid kitchen station ID format. ID must be a number."})
+	}
+
+	station, err := h.service.GetStation(c.RequestCtx(), int32(id))
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Kitchen station not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve kitchen station"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Kitchen station retrieved successfully",
+		Data:    station,
+	})
+}
+
+// CreateStationHandler
+// @Summary      Create kitchen station
+// @Description  Create a kitchen station (e.g. bar, grill, pastry) and route categories to it. A category already routed elsewhere moves to the new station (Roles: admin, manager)
+// @Tags         Kitchen
+// @Accept       json
+// @Produce      json
+// @Param        request body CreateKitchenStationRequest true "Kitchen station details"
+// @Success      201 {object} common.SuccessResponse{data=KitchenStationResponse} "Kitchen station created successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body or unknown category"
+// @Failure      409 {object} common.ErrorResponse "Kitchen station with this name already exists"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /kds/stations [post]
+func (h *KitchenHandler) CreateStationHandler(c fiber.Ctx) error {
+	var req CreateKitchenStationRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("CreateStationHandler | Failed to parse request body: %v", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data:    map[string]interface{}{"errors": ve.Errors},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+	}
+
+	station, err := h.service.CreateStation(c.RequestCtx(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrKitchenStationExists):
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		case errors.Is(err, common.ErrCategoryNotFound):
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to create kitchen station"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(common.SuccessResponse{
+		Message: "Kitchen station created successfully",
+		Data:    station,
+	})
+}
+
+// UpdateStationHandler
+// @Summary      Update kitchen station
+// @Description  Update a kitchen station. When category_ids is sent it replaces the station's categories (Roles: admin, manager)
+// @Tags         Kitchen
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Kitchen Station ID"
+// @Param        request body UpdateKitchenStationRequest true "Kitchen station fields to update"
+// @Success      200 {object} common.SuccessResponse{data=KitchenStationResponse} "Kitchen station updated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid ID, request body or unknown category"
+// @Failure      404 {object} common.ErrorResponse "Kitchen station not found"
+// @Failure      409 {object} common.ErrorResponse "Kitchen station with this name already exists"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /kds/stations/{id} [put]
+func (h *KitchenHandler) UpdateStationHandler(c fiber.Ctx) error {
+	id := fiber.Params[int](c, "id")
+	if id == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid kitchen station ID format. ID must be a number."})
+	}
+
+	var req UpdateKitchenStationRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("UpdateStationHandler | Failed to parse request body: %v", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data:    map[string]interface{}{"errors": ve.Errors},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+	}
+
+	station, err := h.service.UpdateStation(c.RequestCtx(), int32(id), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Kitchen station not found"})
+		case errors.Is(err, common.ErrKitchenStationExists):
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		case errors.Is(err, common.ErrCategoryNotFound):
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to update kitchen station"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Kitchen station updated successfully",
+		Data:    station,
+	})
+}
+
+// DeleteStationHandler
+// @Summary      Delete kitchen station
+// @Description  Delete a kitchen station. Lines already routed to it stay on their orders without a station (Roles: admin, manager)
+// @Tags         Kitchen
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Kitchen Station ID"
+// @Success      200 {object} common.SuccessResponse "Kitchen station deleted successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid kitchen station ID"
+// @Failure      404 {object} common.ErrorResponse "Kitchen station not found"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /kds/stations/{id} [delete]
+func (h *KitchenHandler) DeleteStationHandler(c fiber.Ctx) error {
+	id := fiber.Params[int](c, "id")
+	if id == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid kitchen station ID format. ID must be a number."})
+	}
+
+	if err := h.service.DeleteStation(c.RequestCtx(), int32(id)); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Kitchen station not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to delete kitchen station"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{Message: "Kitchen station deleted successfully"})
+}
+
+// ListTicketsHandler
+// @Summary      List kitchen tickets
+// @Description  Get the open tickets for the kitchen display, one per order and station. Priority tickets come first. Filter by station_id; served lines are hidden unless include_served=true (Roles: admin, manager, cashier)
+// @Tags         Kitchen
+// @Accept       json
+// @Produce      json
+// @Param        station_id query int false "Only tickets of this station"
+// @Param        include_served query bool false "Include lines that were already served"
+// @Success      200 {object} common.SuccessResponse{data=[]KitchenTicketResponse} "Kitchen tickets retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid query parameters"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /kds/tickets [get]
+func (h *KitchenHandler) ListTicketsHandler(c fiber.Ctx) error {
+	var req ListKitchenTicketsRequest
+	if err := c.Bind().Query(&req); err != nil {
+		h.log.Warnf("ListTicketsHandler | Failed to parse query parameters: %v", err)
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid query parameters"})
+	}
+
+	tickets, err := h.service.ListTickets(c.RequestCtx(), req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve kitchen tickets"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Kitchen tickets retrieved successfully",
+		Data:    tickets,
+	})
+}
+
+// BumpItemHandler
+// @Summary      Bump a kitchen item
+// @Description  Move an order line one step forward: queued -> cooking -> ready -> served. The order becomes in_progress once a line starts and served once every line is served (Roles: admin, manager, cashier)
+// @Tags         Kitchen
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order Item ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=KitchenItemResponse} "Item bumped successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order item ID"
+// @Failure      404 {object} common.ErrorResponse "Order item not found"
+// @Failure      409 {object} common.ErrorResponse "Item is already served, its order is cancelled, or another station moved it first"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /kds/items/{id}/bump [post]
+func (h *KitchenHandler) BumpItemHandler(c fiber.Ctx) error {
+	return h.moveItem(c, h.service.BumpItem, "Item bumped successfully")
+}
+
+// RecallItemHandler
+// @Summary      Recall a kitchen item
+// @Description  Move an order line one step back, e.g. a served dish that has to be remade. A served order returns to in_progress (Roles: admin, manager, cashier)
+// @Tags         Kitchen
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order Item ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=KitchenItemResponse} "Item recalled successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order item ID"
+// @Failure      404 {object} common.ErrorResponse "Order item not found"
+// @Failure      409 {object} common.ErrorResponse "Item is still queued, its order is cancelled, or another station moved it first"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /kds/items/{id}/recall [post]
+func (h *KitchenHandler) RecallItemHandler(c fiber.Ctx) error {
+	return h.moveItem(c, h.service.RecallItem, "Item recalled successfully")
+}
+
+// SetItemPriorityHandler
+// @Summary      Set kitchen item priority
+// @Description  Flag or unflag an order line as priority; tickets holding a priority line are listed first (Roles: admin, manager, cashier)
+// @Tags         Kitchen
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order Item ID" Format(uuid)
+// @Param        request body SetItemPriorityRequest true "Priority flag"
+// @Success      200 {object} common.SuccessResponse{data=KitchenItemResponse} "Item priority updated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order item ID or request body"
+// @Failure      404 {object} common.ErrorResponse "Order item not found"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /kds/items/{id}/priority [post]
+func (h *KitchenHandler) SetItemPriorityHandler(c fiber.Ctx) error {
+	itemID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid order item ID format"})
+	}
+
+	var req SetItemPriorityRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("SetItemPriorityHandler | Failed to parse request body: %v", err)
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+	}
+
+	item, err := h.service.SetItemPriority(c.RequestCtx(), itemID, req)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order item not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to update item priority"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Item priority updated successfully",
+		Data:    item,
+	})
+}
+
+func (h *KitchenHandler) moveItem(c fiber.Ctx, move func(ctx context.Context, itemID uuid.UUID) (*KitchenItemResponse, error), message string) error {
+	itemID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid order item ID format"})
+	}
+
+	item, err := move(c.RequestCtx(), itemID)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order item not found"})
+		case errors.Is(err, common.ErrPrepStatusTransition):
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Invalid preparation status change", Error: err.Error()})
+		}
+		h.log.Errorf("Failed to move kitchen item: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to update kitchen item"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: message,
+		Data:    item,
+	})
+}
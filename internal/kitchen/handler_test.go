@@ -0,0 +1,189 @@
+package kitchen_test
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/kitchen"
+	"POS-kasir/internal/kitchen/repository"
+	"POS-kasir/mocks"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestKitchenHandler_CreateStationHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIKitchenService(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	handler := kitchen.NewKitchenHandler(mockService, mockLogger)
+
+	app := fiber.New()
+	app.Post("/kds/stations", handler.CreateStationHandler)
+
+	body := `{"name":"Grill","sort_order":1,"category_ids":[3]}`
+	expectedReq := kitchen.CreateKitchenStationRequest{Name: "Grill", SortOrder: 1, CategoryIDs: []int32{3}}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().CreateStation(gomock.Any(), expectedReq).
+			Return(&kitchen.KitchenStationResponse{ID: 7, Name: "Grill", SortOrder: 1, IsActive: true, CategoryIDs: []int32{3}}, nil)
+
+		req := httptest.NewRequest("POST", "/kds/stations", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+		assert.Equal(t, "Grill", result["data"].(map[string]interface{})["name"])
+	})
+
+	t.Run("NameTaken", func(t *testing.T) {
+		mockService.EXPECT().CreateStation(gomock.Any(), expectedReq).Return(nil, common.ErrKitchenStationExists)
+
+		req := httptest.NewRequest("POST", "/kds/stations", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("UnknownCategory", func(t *testing.T) {
+		mockService.EXPECT().CreateStation(gomock.Any(), expectedReq).Return(nil, common.ErrCategoryNotFound)
+
+		req := httptest.NewRequest("POST", "/kds/stations", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("InvalidBody", func(t *testing.T) {
+		mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any())
+
+		req := httptest.NewRequest("POST", "/kds/stations", strings.NewReader("{invalid"))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestKitchenHandler_ListTicketsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIKitchenService(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	handler := kitchen.NewKitchenHandler(mockService, mockLogger)
+
+	app := fiber.New()
+	app.Get("/kds/tickets", handler.ListTicketsHandler)
+
+	t.Run("FilteredByStation", func(t *testing.T) {
+		station := int32(2)
+		mockService.EXPECT().ListTickets(gomock.Any(), kitchen.ListKitchenTicketsRequest{StationID: &station}).
+			Return([]kitchen.KitchenTicketResponse{{OrderID: uuid.New(), StationID: &station}}, nil)
+
+		req := httptest.NewRequest("GET", "/kds/tickets?station_id=2", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+		assert.Len(t, result["data"].([]interface{}), 1)
+	})
+
+	t.Run("ServiceError", func(t *testing.T) {
+		mockService.EXPECT().ListTickets(gomock.Any(), gomock.Any()).Return(nil, errors.New("service error"))
+
+		req := httptest.NewRequest("GET", "/kds/tickets", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestKitchenHandler_BumpItemHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIKitchenService(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	handler := kitchen.NewKitchenHandler(mockService, mockLogger)
+
+	app := fiber.New()
+	app.Post("/kds/items/:id/bump", handler.BumpItemHandler)
+
+	itemID := uuid.New()
+	url := fmt.Sprintf("/kds/items/%s/bump", itemID)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().BumpItem(gomock.Any(), itemID).
+			Return(&kitchen.KitchenItemResponse{ID: itemID, PrepStatus: repository.OrderItemStatusCooking, OrderStatus: repository.OrderStatusInProgress}, nil)
+
+		resp, _ := app.Test(httptest.NewRequest("POST", url, nil))
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+		assert.Equal(t, "cooking", result["data"].(map[string]interface{})["prep_status"])
+	})
+
+	t.Run("AlreadyServed", func(t *testing.T) {
+		mockService.EXPECT().BumpItem(gomock.Any(), itemID).Return(nil, common.ErrPrepStatusTransition)
+
+		resp, _ := app.Test(httptest.NewRequest("POST", url, nil))
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService.EXPECT().BumpItem(gomock.Any(), itemID).Return(nil, common.ErrNotFound)
+
+		resp, _ := app.Test(httptest.NewRequest("POST", url, nil))
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		resp, _ := app.Test(httptest.NewRequest("POST", "/kds/items/not-a-uuid/bump", nil))
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestKitchenHandler_SetItemPriorityHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIKitchenService(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	handler := kitchen.NewKitchenHandler(mockService, mockLogger)
+
+	app := fiber.New()
+	app.Post("/kds/items/:id/priority", handler.SetItemPriorityHandler)
+
+	itemID := uuid.New()
+
+	mockService.EXPECT().SetItemPriority(gomock.Any(), itemID, kitchen.SetItemPriorityRequest{IsPriority: true}).
+		Return(&kitchen.KitchenItemResponse{ID: itemID, IsPriority: true}, nil)
+
+	req := httptest.NewRequest("POST", fmt.Sprintf("/kds/items/%s/priority", itemID), strings.NewReader(`{"is_priority":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
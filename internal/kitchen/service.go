@@ -0,0 +1,570 @@
+package kitchen
+
+import (
+	"POS-kasir/internal/activitylog"
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/common/store"
+	"POS-kasir/internal/kitchen/repository"
+	"POS-kasir/internal/orders"
+	orders_repo "POS-kasir/internal/orders/repository"
+	ws "POS-kasir/internal/websocket"
+	"POS-kasir/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type IKitchenService interface {
+	ListStations(ctx context.Context) ([]KitchenStationResponse, error)
+	GetStation(ctx context.Context, id int32) (*KitchenStationResponse, error)
+	CreateStation(ctx context.Context, req CreateKitchenStationRequest) (*KitchenStationResponse, error)
+	UpdateStation(ctx context.Context, id int32, req UpdateKitchenStationRequest) (*KitchenStationResponse, error)
+	DeleteStation(ctx context.Context, id int32) error
+	ListTickets(ctx context.Context, req ListKitchenTicketsRequest) ([]KitchenTicketResponse, error)
+	BumpItem(ctx context.Context, itemID uuid.UUID) (*KitchenItemResponse, error)
+	RecallItem(ctx context.Context, itemID uuid.UUID) (*KitchenItemResponse, error)
+	SetItemPriority(ctx context.Context, itemID uuid.UUID, req SetItemPriorityRequest) (*KitchenItemResponse, error)
+}
+
+type KitchenService struct {
+	store           store.Store
+	repo            repository.Querier
+	orderService    orders.IOrderService
+	activityService activitylog.IActivityService
+	log             logger.ILogger
+	wsHub           *ws.Hub
+}
+
+func NewKitchenService(store store.Store, repo repository.Querier, orderService orders.IOrderService, activityService activitylog.IActivityService, log logger.ILogger, wsHub *ws.Hub) IKitchenService {
+	return &KitchenService{
+		store:           store,
+		repo:            repo,
+		orderService:    orderService,
+		activityService: activityService,
+		log:             log,
+		wsHub:           wsHub,
+	}
+}
+
+// prepFlow is the order in which a line moves through the kitchen; bump moves one step forward, recall one step back.
+var prepFlow = []repository.OrderItemStatus{
+	repository.OrderItemStatusQueued,
+	repository.OrderItemStatusCooking,
+	repository.OrderItemStatusReady,
+	repository.OrderItemStatusServed,
+}
+
+func stepPrepStatus(current repository.OrderItemStatus, step int) (repository.OrderItemStatus, bool) {
+	for i, status := range prepFlow {
+		if status == current {
+			next := i + step
+			if next < 0 || next >= len(prepFlow) {
+				return current, false
+			}
+			return prepFlow[next], true
+		}
+	}
+	return current, false
+}
+
+func (s *KitchenService) ListStations(ctx context.Context) ([]KitchenStationResponse, error) {
+	stations, err := s.repo.ListKitchenStations(ctx)
+	if err != nil {
+		s.log.Errorf("ListStations | Failed to list kitchen stations: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	response := make([]KitchenStationResponse, 0, len(stations))
+	for _, st := range stations {
+		response = append(response, toStationResponse(repository.KitchenStation{
+			ID: st.ID, Name: st.Name, SortOrder: st.SortOrder, IsActive: st.IsActive, CreatedAt: st.CreatedAt, UpdatedAt: st.UpdatedAt,
+		}, st.CategoryIds))
+	}
+	return response, nil
+}
+
+func (s *KitchenService) GetStation(ctx context.Context, id int32) (*KitchenStationResponse, error) {
+	st, err := s.repo.GetKitchenStation(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warnf("GetStation | Kitchen station not found: id=%d", id)
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("GetStation | Failed to get kitchen station: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	response := toStationResponse(repository.KitchenStation{
+		ID: st.ID, Name: st.Name, SortOrder: st.SortOrder, IsActive: st.IsActive, CreatedAt: st.CreatedAt, UpdatedAt: st.UpdatedAt,
+	}, st.CategoryIds)
+	return &response, nil
+}
+
+func (s *KitchenService) CreateStation(ctx context.Context, req CreateKitchenStationRequest) (*KitchenStationResponse, error) {
+	if err := s.ensureNameAvailable(ctx, req.Name, 0); err != nil {
+		return nil, err
+	}
+	categoryIDs := uniqueCategoryIDs(req.CategoryIDs)
+	if err := s.ensureCategoriesExist(ctx, categoryIDs); err != nil {
+		return nil, err
+	}
+
+	var station repository.KitchenStation
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+
+		var err error
+		station, err = qtx.CreateKitchenStation(ctx, repository.CreateKitchenStationParams{
+			Name:      req.Name,
+			SortOrder: req.SortOrder,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(categoryIDs) == 0 {
+			return nil
+		}
+		return qtx.AssignKitchenStationCategories(ctx, repository.AssignKitchenStationCategoriesParams{
+			CategoryIds: categoryIDs,
+			StationID:   station.ID,
+		})
+	})
+	if txErr != nil {
+		s.log.Errorf("CreateStation | Failed to create kitchen station: %v", txErr)
+		return nil, common.ErrInternal
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeCREATE, station.ID, map[string]interface{}{
+		"name":         station.Name,
+		"category_ids": categoryIDs,
+	})
+
+	return s.GetStation(ctx, station.ID)
+}
+
+func (s *KitchenService) UpdateStation(ctx context.Context, id int32, req UpdateKitchenStationRequest) (*KitchenStationResponse, error) {
+	existing, err := s.repo.GetKitchenStation(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warnf("UpdateStation | Kitchen station not found: id=%d", id)
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("UpdateStation | Failed to get kitchen station: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	params := repository.UpdateKitchenStationParams{
+		ID:        existing.ID,
+		Name:      existing.Name,
+		SortOrder: existing.SortOrder,
+		IsActive:  existing.IsActive,
+	}
+	if req.Name != nil && *req.Name != existing.Name {
+		if err := s.ensureNameAvailable(ctx, *req.Name, id); err != nil {
+			return nil, err
+		}
+		params.Name = *req.Name
+	}
+	if req.SortOrder != nil {
+		params.SortOrder = *req.SortOrder
+	}
+	if req.IsActive != nil {
+		params.IsActive = *req.IsActive
+	}
+
+	var categoryIDs []int32
+	if req.CategoryIDs != nil {
+		categoryIDs = uniqueCategoryIDs(*req.CategoryIDs)
+		if err := s.ensureCategoriesExist(ctx, categoryIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+
+		if _, err := qtx.UpdateKitchenStation(ctx, params); err != nil {
+			return err
+		}
+		if req.CategoryIDs == nil {
+			return nil
+		}
+
+		if err := qtx.ClearKitchenStationCategories(ctx, id); err != nil {
+			return err
+		}
+		if len(categoryIDs) == 0 {
+			return nil
+		}
+		return qtx.AssignKitchenStationCategories(ctx, repository.AssignKitchenStationCategoriesParams{
+			CategoryIds: categoryIDs,
+			StationID:   id,
+		})
+	})
+	if txErr != nil {
+		if errors.Is(txErr, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("UpdateStation | Failed to update kitchen station: %v", txErr)
+		return nil, common.ErrInternal
+	}
+
+	details := map[string]interface{}{
+		"name":       params.Name,
+		"sort_order": params.SortOrder,
+		"is_active":  params.IsActive,
+	}
+	if req.CategoryIDs != nil {
+		details["category_ids"] = categoryIDs
+	}
+	s.logActivity(ctx, activitylog_repo.LogActionTypeUPDATE, id, details)
+
+	return s.GetStation(ctx, id)
+}
+
+func (s *KitchenService) DeleteStation(ctx context.Context, id int32) error {
+	if _, err := s.repo.GetKitchenStation(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warnf("DeleteStation | Kitchen station not found: id=%d", id)
+			return common.ErrNotFound
+		}
+		s.log.Errorf("DeleteStation | Failed to get kitchen station: %v", err)
+		return common.ErrInternal
+	}
+
+	if err := s.repo.DeleteKitchenStation(ctx, id); err != nil {
+		s.log.Errorf("DeleteStation | Failed to delete kitchen station: %v", err)
+		return common.ErrInternal
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeDELETE, id, map[string]interface{}{
+		"deleted_kitchen_station_id": id,
+	})
+	return nil
+}
+
+// ListTickets groups the lines still being prepared into one ticket per order and station.
+// Tickets holding a priority line come first, otherwise tickets keep the order they were placed in.
+func (s *KitchenService) ListTickets(ctx context.Context, req ListKitchenTicketsRequest) ([]KitchenTicketResponse, error) {
+	rows, err := s.repo.ListKitchenTicketItems(ctx, repository.ListKitchenTicketItemsParams{
+		StationID:     req.StationID,
+		IncludeServed: req.IncludeServed,
+	})
+	if err != nil {
+		s.log.Errorf("ListTickets | Failed to list kitchen ticket items: %v", err)
+		return nil, common.ErrInternal
+	}
+	return buildTickets(rows), nil
+}
+
+func (s *KitchenService) BumpItem(ctx context.Context, itemID uuid.UUID) (*KitchenItemResponse, error) {
+	return s.moveItem(ctx, itemID, 1)
+}
+
+func (s *KitchenService) RecallItem(ctx context.Context, itemID uuid.UUID) (*KitchenItemResponse, error) {
+	return s.moveItem(ctx, itemID, -1)
+}
+
+func (s *KitchenService) SetItemPriority(ctx context.Context, itemID uuid.UUID, req SetItemPriorityRequest) (*KitchenItemResponse, error) {
+	item, err := s.getItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.SetOrderItemPriority(ctx, repository.SetOrderItemPriorityParams{
+		ID:         itemID,
+		IsPriority: req.IsPriority,
+	})
+	if err != nil {
+		s.log.Errorf("SetItemPriority | Failed to update order item priority: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	s.broadcastTicket(ctx, updated.OrderID, updated.StationID)
+
+	response := toItemResponse(updated, item.OrderStatus)
+	return &response, nil
+}
+
+// moveItem bumps (step 1) or recalls (step -1) a line, then rolls the change up into the order status.
+func (s *KitchenService) moveItem(ctx context.Context, itemID uuid.UUID, step int) (*KitchenItemResponse, error) {
+	item, err := s.getItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.OrderStatus == repository.OrderStatusCancelled {
+		return nil, fmt.Errorf("%w: order is cancelled", common.ErrPrepStatusTransition)
+	}
+
+	next, ok := stepPrepStatus(item.PrepStatus, step)
+	if !ok {
+		return nil, fmt.Errorf("%w: item is already '%s'", common.ErrPrepStatusTransition, item.PrepStatus)
+	}
+
+	updated, err := s.repo.UpdateOrderItemPrepStatus(ctx, repository.UpdateOrderItemPrepStatusParams{
+		ID:            itemID,
+		PrepStatus:    next,
+		CurrentStatus: item.PrepStatus,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Another screen moved the line first
+			return nil, fmt.Errorf("%w: item was updated by another station", common.ErrPrepStatusTransition)
+		}
+		s.log.Errorf("moveItem | Failed to update order item prep status: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	orderStatus := s.rollUpOrderStatus(ctx, updated.OrderID, item.OrderStatus)
+	s.broadcastTicket(ctx, updated.OrderID, updated.StationID)
+	// Ready and served lines move the order across the guests' calling board
+	s.orderService.PublishCallingBoard(ctx)
+
+	response := toItemResponse(updated, orderStatus)
+	return &response, nil
+}
+
+// rollUpOrderStatus derives the order status from its lines: once any line has started the order is in progress,
+// and once every line is served the order is served. Paid and cancelled orders keep their status.
+// Transitions go through the order service so they follow the lifecycle and land in the status history.
+func (s *KitchenService) rollUpOrderStatus(ctx context.Context, orderID uuid.UUID, current repository.OrderStatus) repository.OrderStatus {
+	if current != repository.OrderStatusOpen && current != repository.OrderStatusInProgress && current != repository.OrderStatusServed {
+		return current
+	}
+
+	summary, err := s.repo.GetOrderPrepSummary(ctx, orderID)
+	if err != nil {
+		s.log.Warnf("rollUpOrderStatus | Failed to summarise order items: %v", err)
+		return current
+	}
+
+	target := current
+	switch {
+	case summary.TotalItems > 0 && summary.ServedItems == summary.TotalItems:
+		target = repository.OrderStatusServed
+	case summary.QueuedItems < summary.TotalItems:
+		target = repository.OrderStatusInProgress
+	}
+	if target == current {
+		return current
+	}
+
+	steps := []repository.OrderStatus{target}
+	if current == repository.OrderStatusOpen && target == repository.OrderStatusServed {
+		steps = []repository.OrderStatus{repository.OrderStatusInProgress, repository.OrderStatusServed}
+	}
+	for _, step := range steps {
+		if _, err := s.orderService.UpdateOperationalStatus(ctx, orderID, orders.UpdateOrderStatusRequest{
+			Status: orders_repo.OrderStatus(step),
+		}); err != nil {
+			s.log.Warnf("rollUpOrderStatus | Failed to move order %s to %s: %v", orderID, step, err)
+			return current
+		}
+		current = step
+	}
+	return current
+}
+
+func (s *KitchenService) getItem(ctx context.Context, itemID uuid.UUID) (repository.GetKitchenOrderItemRow, error) {
+	item, err := s.repo.GetKitchenOrderItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warnf("Order item not found for kitchen action: id=%s", itemID)
+			return item, common.ErrNotFound
+		}
+		s.log.Errorf("Failed to get order item for kitchen action: %v", err)
+		return item, common.ErrInternal
+	}
+	return item, nil
+}
+
+// broadcastTicket pushes the station's current ticket for the order to connected displays.
+func (s *KitchenService) broadcastTicket(ctx context.Context, orderID uuid.UUID, stationID *int32) {
+	if s.wsHub == nil {
+		return
+	}
+
+	rows, err := s.repo.ListKitchenTicketItems(ctx, repository.ListKitchenTicketItemsParams{
+		StationID:     stationID,
+		OrderID:       pgtype.UUID{Bytes: orderID, Valid: true},
+		IncludeServed: true,
+	})
+	if err != nil {
+		s.log.Warnf("broadcastTicket | Failed to load kitchen ticket: %v", err)
+		return
+	}
+
+	ticket := KitchenTicketResponse{OrderID: orderID, StationID: stationID, Items: []KitchenTicketItemResponse{}}
+	for _, t := range buildTickets(rows) {
+		if int32PtrEqual(t.StationID, stationID) {
+			ticket = t
+			break
+		}
+	}
+	s.wsHub.Publish(ws.KitchenEvent(ws.EventKitchenTicketUpdated, stationID, ticket))
+}
+
+// ensureNameAvailable rejects names already used by another kitchen station.
+func (s *KitchenService) ensureNameAvailable(ctx context.Context, name string, selfID int32) error {
+	existing, err := s.repo.GetKitchenStationByName(ctx, name)
+	if err == nil {
+		if existing.ID != selfID {
+			return common.ErrKitchenStationExists
+		}
+		return nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		s.log.Errorf("Failed to check kitchen station name: %v", err)
+		return common.ErrInternal
+	}
+	return nil
+}
+
+func (s *KitchenService) ensureCategoriesExist(ctx context.Context, categoryIDs []int32) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+	count, err := s.repo.CountExistingCategories(ctx, categoryIDs)
+	if err != nil {
+		s.log.Errorf("Failed to check kitchen station categories: %v", err)
+		return common.ErrInternal
+	}
+	if count != int64(len(categoryIDs)) {
+		return common.ErrCategoryNotFound
+	}
+	return nil
+}
+
+func (s *KitchenService) logActivity(ctx context.Context, action activitylog_repo.LogActionType, id int32, details map[string]interface{}) {
+	actorID, ok := ctx.Value(common.UserIDKey).(uuid.UUID)
+	if !ok {
+		s.log.Warnf("KitchenService | Actor user ID not found in context for activity logging")
+	}
+
+	s.activityService.Log(
+		ctx,
+		actorID,
+		action,
+		activitylog_repo.LogEntityTypeKITCHENSTATION,
+		strconv.Itoa(int(id)),
+		details,
+	)
+}
+
+func uniqueCategoryIDs(ids []int32) []int32 {
+	seen := make(map[int32]bool, len(ids))
+	unique := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func buildTickets(rows []repository.ListKitchenTicketItemsRow) []KitchenTicketResponse {
+	type ticketKey struct {
+		orderID   uuid.UUID
+		stationID int32
+		routed    bool
+	}
+
+	tickets := make([]KitchenTicketResponse, 0)
+	index := make(map[ticketKey]int)
+	for _, row := range rows {
+		key := ticketKey{orderID: row.OrderID}
+		if row.StationID != nil {
+			key.stationID, key.routed = *row.StationID, true
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(tickets)
+			index[key] = i
+			tickets = append(tickets, KitchenTicketResponse{
+				OrderID:        row.OrderID,
+				StationID:      row.StationID,
+				OrderType:      row.OrderType,
+				OrderStatus:    row.OrderStatus,
+				OrderCreatedAt: row.OrderCreatedAt.Time,
+				Items:          []KitchenTicketItemResponse{},
+			})
+		}
+
+		tickets[i].IsPriority = tickets[i].IsPriority || row.IsPriority
+		tickets[i].Items = append(tickets[i].Items, KitchenTicketItemResponse{
+			ID:          row.ID,
+			ProductID:   row.ProductID,
+			ProductName: row.ProductName,
+			Quantity:    row.Quantity,
+			Options:     row.OptionNames,
+			StationID:   row.StationID,
+			PrepStatus:  row.PrepStatus,
+			IsPriority:  row.IsPriority,
+			QueuedAt:    row.QueuedAt.Time,
+			StartedAt:   timestamptzToTimePtr(row.StartedAt),
+			ReadyAt:     timestamptzToTimePtr(row.ReadyAt),
+			ServedAt:    timestamptzToTimePtr(row.ServedAt),
+		})
+	}
+
+	sort.SliceStable(tickets, func(a, b int) bool {
+		return tickets[a].IsPriority && !tickets[b].IsPriority
+	})
+	return tickets
+}
+
+func toStationResponse(station repository.KitchenStation, categoryIDs []int32) KitchenStationResponse {
+	if categoryIDs == nil {
+		categoryIDs = []int32{}
+	}
+	return KitchenStationResponse{
+		ID:          station.ID,
+		Name:        station.Name,
+		SortOrder:   station.SortOrder,
+		IsActive:    station.IsActive,
+		CategoryIDs: categoryIDs,
+		CreatedAt:   station.CreatedAt.Time,
+		UpdatedAt:   station.UpdatedAt.Time,
+	}
+}
+
+func toItemResponse(item repository.OrderItem, orderStatus repository.OrderStatus) KitchenItemResponse {
+	return KitchenItemResponse{
+		ID:          item.ID,
+		OrderID:     item.OrderID,
+		StationID:   item.StationID,
+		PrepStatus:  item.PrepStatus,
+		IsPriority:  item.IsPriority,
+		QueuedAt:    item.QueuedAt.Time,
+		StartedAt:   timestamptzToTimePtr(item.StartedAt),
+		ReadyAt:     timestamptzToTimePtr(item.ReadyAt),
+		ServedAt:    timestamptzToTimePtr(item.ServedAt),
+		OrderStatus: orderStatus,
+	}
+}
+
+func timestamptzToTimePtr(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ts.Time
+	return &t
+}
+
+func int32PtrEqual(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,400 @@
+package kitchen_test
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/kitchen"
+	"POS-kasir/internal/kitchen/repository"
+	"POS-kasir/internal/orders"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func allowAllLoggerCalls(mockLogger *mocks.MockILogger) {
+	mockLogger.EXPECT().Warnf(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+}
+
+func setupTest(t *testing.T) (*mocks.MockStore, *mocks.MockKitchenRepo, *mocks.MockIOrderService, *mocks.MockIActivityService, kitchen.IKitchenService) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	mockRepo := mocks.NewMockKitchenRepo(ctrl)
+	mockOrderService := mocks.NewMockIOrderService(ctrl)
+	mockActivity := mocks.NewMockIActivityService(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	allowAllLoggerCalls(mockLogger)
+	mockOrderService.EXPECT().PublishCallingBoard(gomock.Any()).AnyTimes()
+
+	service := kitchen.NewKitchenService(mockStore, mockRepo, mockOrderService, mockActivity, mockLogger, nil)
+	return mockStore, mockRepo, mockOrderService, mockActivity, service
+}
+
+func TestKitchenService_ListTickets(t *testing.T) {
+	_, mockRepo, _, _, service := setupTest(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	grill := int32(1)
+	bar := int32(2)
+	firstOrder := uuid.New()
+	secondOrder := uuid.New()
+
+	mockRepo.EXPECT().ListKitchenTicketItems(ctx, repository.ListKitchenTicketItemsParams{}).Return([]repository.ListKitchenTicketItemsRow{
+		{ID: uuid.New(), OrderID: firstOrder, ProductName: "Steak", Quantity: 1, StationID: &grill, PrepStatus: repository.OrderItemStatusCooking, OrderCreatedAt: pgtype.Timestamptz{Time: now, Valid: true}, StartedAt: pgtype.Timestamptz{Time: now, Valid: true}},
+		{ID: uuid.New(), OrderID: firstOrder, ProductName: "Iced Tea", Quantity: 2, StationID: &bar, PrepStatus: repository.OrderItemStatusQueued, OrderCreatedAt: pgtype.Timestamptz{Time: now, Valid: true}},
+		{ID: uuid.New(), OrderID: firstOrder, ProductName: "Burger", Quantity: 1, StationID: &grill, PrepStatus: repository.OrderItemStatusQueued, OrderCreatedAt: pgtype.Timestamptz{Time: now, Valid: true}},
+		{ID: uuid.New(), OrderID: secondOrder, ProductName: "Wings", Quantity: 1, StationID: &grill, PrepStatus: repository.OrderItemStatusQueued, IsPriority: true, OrderCreatedAt: pgtype.Timestamptz{Time: now.Add(time.Minute), Valid: true}},
+	}, nil)
+
+	tickets, err := service.ListTickets(ctx, kitchen.ListKitchenTicketsRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, tickets, 3)
+	// The rushed ticket jumps the queue, the others keep their order
+	assert.Equal(t, secondOrder, tickets[0].OrderID)
+	assert.True(t, tickets[0].IsPriority)
+	assert.Equal(t, firstOrder, tickets[1].OrderID)
+	assert.Equal(t, grill, *tickets[1].StationID)
+	assert.Len(t, tickets[1].Items, 2)
+	assert.NotNil(t, tickets[1].Items[0].StartedAt)
+	assert.Nil(t, tickets[1].Items[1].StartedAt)
+	assert.Equal(t, bar, *tickets[2].StationID)
+}
+
+func TestKitchenService_BumpItem(t *testing.T) {
+	itemID := uuid.New()
+	orderID := uuid.New()
+	station := int32(1)
+
+	t.Run("FirstLineStartsTheOrder", func(t *testing.T) {
+		_, mockRepo, mockOrderService, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenOrderItem(ctx, itemID).Return(repository.GetKitchenOrderItemRow{
+			ID: itemID, OrderID: orderID, StationID: &station, PrepStatus: repository.OrderItemStatusQueued, OrderStatus: repository.OrderStatusOpen,
+		}, nil)
+		mockRepo.EXPECT().UpdateOrderItemPrepStatus(ctx, repository.UpdateOrderItemPrepStatusParams{
+			ID: itemID, PrepStatus: repository.OrderItemStatusCooking, CurrentStatus: repository.OrderItemStatusQueued,
+		}).Return(repository.OrderItem{
+			ID: itemID, OrderID: orderID, StationID: &station, PrepStatus: repository.OrderItemStatusCooking, StartedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		}, nil)
+		mockRepo.EXPECT().GetOrderPrepSummary(ctx, orderID).Return(repository.GetOrderPrepSummaryRow{TotalItems: 2, QueuedItems: 1}, nil)
+		mockOrderService.EXPECT().UpdateOperationalStatus(ctx, orderID, orders.UpdateOrderStatusRequest{Status: orders_repo.OrderStatusInProgress}).
+			Return(&orders.OrderDetailResponse{ID: orderID, Status: orders_repo.OrderStatusInProgress}, nil)
+
+		resp, err := service.BumpItem(ctx, itemID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, repository.OrderItemStatusCooking, resp.PrepStatus)
+		assert.NotNil(t, resp.StartedAt)
+		assert.Equal(t, repository.OrderStatusInProgress, resp.OrderStatus)
+	})
+
+	t.Run("LastLineServesTheOrder", func(t *testing.T) {
+		_, mockRepo, mockOrderService, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenOrderItem(ctx, itemID).Return(repository.GetKitchenOrderItemRow{
+			ID: itemID, OrderID: orderID, PrepStatus: repository.OrderItemStatusReady, OrderStatus: repository.OrderStatusOpen,
+		}, nil)
+		mockRepo.EXPECT().UpdateOrderItemPrepStatus(ctx, gomock.Any()).Return(repository.OrderItem{
+			ID: itemID, OrderID: orderID, PrepStatus: repository.OrderItemStatusServed,
+		}, nil)
+		mockRepo.EXPECT().GetOrderPrepSummary(ctx, orderID).Return(repository.GetOrderPrepSummaryRow{TotalItems: 1, ServedItems: 1}, nil)
+
+		// Open orders pass through in_progress on their way to served
+		gomock.InOrder(
+			mockOrderService.EXPECT().UpdateOperationalStatus(ctx, orderID, orders.UpdateOrderStatusRequest{Status: orders_repo.OrderStatusInProgress}).Return(&orders.OrderDetailResponse{}, nil),
+			mockOrderService.EXPECT().UpdateOperationalStatus(ctx, orderID, orders.UpdateOrderStatusRequest{Status: orders_repo.OrderStatusServed}).Return(&orders.OrderDetailResponse{}, nil),
+		)
+
+		resp, err := service.BumpItem(ctx, itemID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, repository.OrderStatusServed, resp.OrderStatus)
+	})
+
+	t.Run("PaidOrderKeepsItsStatus", func(t *testing.T) {
+		_, mockRepo, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenOrderItem(ctx, itemID).Return(repository.GetKitchenOrderItemRow{
+			ID: itemID, OrderID: orderID, PrepStatus: repository.OrderItemStatusReady, OrderStatus: repository.OrderStatusPaid,
+		}, nil)
+		mockRepo.EXPECT().UpdateOrderItemPrepStatus(ctx, gomock.Any()).Return(repository.OrderItem{
+			ID: itemID, OrderID: orderID, PrepStatus: repository.OrderItemStatusServed,
+		}, nil)
+
+		resp, err := service.BumpItem(ctx, itemID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, repository.OrderStatusPaid, resp.OrderStatus)
+	})
+
+	t.Run("AlreadyServed", func(t *testing.T) {
+		_, mockRepo, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenOrderItem(ctx, itemID).Return(repository.GetKitchenOrderItemRow{
+			ID: itemID, OrderID: orderID, PrepStatus: repository.OrderItemStatusServed, OrderStatus: repository.OrderStatusServed,
+		}, nil)
+
+		resp, err := service.BumpItem(ctx, itemID)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrPrepStatusTransition)
+	})
+
+	t.Run("CancelledOrder", func(t *testing.T) {
+		_, mockRepo, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenOrderItem(ctx, itemID).Return(repository.GetKitchenOrderItemRow{
+			ID: itemID, OrderID: orderID, PrepStatus: repository.OrderItemStatusQueued, OrderStatus: repository.OrderStatusCancelled,
+		}, nil)
+
+		resp, err := service.BumpItem(ctx, itemID)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrPrepStatusTransition)
+	})
+
+	t.Run("MovedByAnotherStation", func(t *testing.T) {
+		_, mockRepo, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenOrderItem(ctx, itemID).Return(repository.GetKitchenOrderItemRow{
+			ID: itemID, OrderID: orderID, PrepStatus: repository.OrderItemStatusQueued, OrderStatus: repository.OrderStatusInProgress,
+		}, nil)
+		mockRepo.EXPECT().UpdateOrderItemPrepStatus(ctx, gomock.Any()).Return(repository.OrderItem{}, pgx.ErrNoRows)
+
+		resp, err := service.BumpItem(ctx, itemID)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrPrepStatusTransition)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, mockRepo, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenOrderItem(ctx, itemID).Return(repository.GetKitchenOrderItemRow{}, pgx.ErrNoRows)
+
+		resp, err := service.BumpItem(ctx, itemID)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+}
+
+func TestKitchenService_RecallItem(t *testing.T) {
+	itemID := uuid.New()
+	orderID := uuid.New()
+
+	t.Run("ServedOrderGoesBackInProgress", func(t *testing.T) {
+		_, mockRepo, mockOrderService, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenOrderItem(ctx, itemID).Return(repository.GetKitchenOrderItemRow{
+			ID: itemID, OrderID: orderID, PrepStatus: repository.OrderItemStatusServed, OrderStatus: repository.OrderStatusServed,
+		}, nil)
+		mockRepo.EXPECT().UpdateOrderItemPrepStatus(ctx, repository.UpdateOrderItemPrepStatusParams{
+			ID: itemID, PrepStatus: repository.OrderItemStatusReady, CurrentStatus: repository.OrderItemStatusServed,
+		}).Return(repository.OrderItem{ID: itemID, OrderID: orderID, PrepStatus: repository.OrderItemStatusReady}, nil)
+		mockRepo.EXPECT().GetOrderPrepSummary(ctx, orderID).Return(repository.GetOrderPrepSummaryRow{TotalItems: 2, ServedItems: 1}, nil)
+		mockOrderService.EXPECT().UpdateOperationalStatus(ctx, orderID, orders.UpdateOrderStatusRequest{Status: orders_repo.OrderStatusInProgress}).
+			Return(&orders.OrderDetailResponse{}, nil)
+
+		resp, err := service.RecallItem(ctx, itemID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, repository.OrderItemStatusReady, resp.PrepStatus)
+		assert.Equal(t, repository.OrderStatusInProgress, resp.OrderStatus)
+	})
+
+	t.Run("StillQueued", func(t *testing.T) {
+		_, mockRepo, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenOrderItem(ctx, itemID).Return(repository.GetKitchenOrderItemRow{
+			ID: itemID, OrderID: orderID, PrepStatus: repository.OrderItemStatusQueued, OrderStatus: repository.OrderStatusOpen,
+		}, nil)
+
+		resp, err := service.RecallItem(ctx, itemID)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrPrepStatusTransition)
+	})
+}
+
+func TestKitchenService_SetItemPriority(t *testing.T) {
+	_, mockRepo, _, _, service := setupTest(t)
+	ctx := context.Background()
+	itemID := uuid.New()
+
+	mockRepo.EXPECT().GetKitchenOrderItem(ctx, itemID).Return(repository.GetKitchenOrderItemRow{
+		ID: itemID, PrepStatus: repository.OrderItemStatusQueued, OrderStatus: repository.OrderStatusOpen,
+	}, nil)
+	mockRepo.EXPECT().SetOrderItemPriority(ctx, repository.SetOrderItemPriorityParams{ID: itemID, IsPriority: true}).
+		Return(repository.OrderItem{ID: itemID, PrepStatus: repository.OrderItemStatusQueued, IsPriority: true}, nil)
+
+	resp, err := service.SetItemPriority(ctx, itemID, kitchen.SetItemPriorityRequest{IsPriority: true})
+
+	assert.NoError(t, err)
+	assert.True(t, resp.IsPriority)
+	assert.Equal(t, repository.OrderStatusOpen, resp.OrderStatus)
+}
+
+func TestKitchenService_CreateStation(t *testing.T) {
+	req := kitchen.CreateKitchenStationRequest{Name: "Grill", SortOrder: 1, CategoryIDs: []int32{3, 4, 3}}
+
+	t.Run("Success", func(t *testing.T) {
+		mockStore, mockRepo, _, mockActivity, service := setupTest(t)
+		ctx := context.Background()
+		now := time.Now()
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		mockRepo.EXPECT().GetKitchenStationByName(ctx, "Grill").Return(repository.KitchenStation{}, pgx.ErrNoRows)
+		// Duplicate category IDs are collapsed before they are checked and stored
+		mockRepo.EXPECT().CountExistingCategories(ctx, []int32{3, 4}).Return(int64(2), nil)
+		mockStore.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+			return fn(mockPgx)
+		})
+		mockPgx.ExpectQuery("INSERT INTO kitchen_stations").
+			WithArgs("Grill", int32(1)).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "sort_order", "is_active", "created_at", "updated_at"}).
+				AddRow(int32(7), "Grill", int32(1), true, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}))
+		mockPgx.ExpectExec("INSERT INTO kitchen_station_categories").
+			WithArgs([]int32{3, 4}, int32(7)).
+			WillReturnResult(pgxmock.NewResult("INSERT", 2))
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "7", gomock.Any())
+		mockRepo.EXPECT().GetKitchenStation(ctx, int32(7)).Return(repository.GetKitchenStationRow{
+			ID: 7, Name: "Grill", SortOrder: 1, IsActive: true, CategoryIds: []int32{3, 4},
+		}, nil)
+
+		resp, err := service.CreateStation(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(7), resp.ID)
+		assert.Equal(t, []int32{3, 4}, resp.CategoryIDs)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("NameTaken", func(t *testing.T) {
+		_, mockRepo, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenStationByName(ctx, "Grill").Return(repository.KitchenStation{ID: 2, Name: "Grill"}, nil)
+
+		resp, err := service.CreateStation(ctx, req)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrKitchenStationExists)
+	})
+
+	t.Run("UnknownCategory", func(t *testing.T) {
+		_, mockRepo, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenStationByName(ctx, "Grill").Return(repository.KitchenStation{}, pgx.ErrNoRows)
+		mockRepo.EXPECT().CountExistingCategories(ctx, []int32{3, 4}).Return(int64(1), nil)
+
+		resp, err := service.CreateStation(ctx, req)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrCategoryNotFound)
+	})
+}
+
+func TestKitchenService_UpdateStation(t *testing.T) {
+	t.Run("ClearsCategories", func(t *testing.T) {
+		mockStore, mockRepo, _, mockActivity, service := setupTest(t)
+		ctx := context.Background()
+		now := time.Now()
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		inactive := false
+		noCategories := []int32{}
+
+		mockRepo.EXPECT().GetKitchenStation(ctx, int32(7)).Return(repository.GetKitchenStationRow{
+			ID: 7, Name: "Grill", SortOrder: 1, IsActive: true, CategoryIds: []int32{3},
+		}, nil)
+		mockStore.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+			return fn(mockPgx)
+		})
+		mockPgx.ExpectQuery("UPDATE kitchen_stations").
+			WithArgs(int32(7), "Grill", int32(1), false).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "sort_order", "is_active", "created_at", "updated_at"}).
+				AddRow(int32(7), "Grill", int32(1), false, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}))
+		mockPgx.ExpectExec("DELETE FROM kitchen_station_categories").
+			WithArgs(int32(7)).
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "7", gomock.Any())
+		mockRepo.EXPECT().GetKitchenStation(ctx, int32(7)).Return(repository.GetKitchenStationRow{
+			ID: 7, Name: "Grill", SortOrder: 1, IsActive: false,
+		}, nil)
+
+		resp, err := service.UpdateStation(ctx, 7, kitchen.UpdateKitchenStationRequest{IsActive: &inactive, CategoryIDs: &noCategories})
+
+		assert.NoError(t, err)
+		assert.False(t, resp.IsActive)
+		assert.Empty(t, resp.CategoryIDs)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, mockRepo, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenStation(ctx, int32(9)).Return(repository.GetKitchenStationRow{}, pgx.ErrNoRows)
+
+		resp, err := service.UpdateStation(ctx, 9, kitchen.UpdateKitchenStationRequest{})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+}
+
+func TestKitchenService_DeleteStation(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		_, mockRepo, _, mockActivity, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenStation(ctx, int32(7)).Return(repository.GetKitchenStationRow{ID: 7}, nil)
+		mockRepo.EXPECT().DeleteKitchenStation(ctx, int32(7)).Return(nil)
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "7", gomock.Any())
+
+		assert.NoError(t, service.DeleteStation(ctx, 7))
+	})
+
+	t.Run("RepositoryError", func(t *testing.T) {
+		_, mockRepo, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetKitchenStation(ctx, int32(7)).Return(repository.GetKitchenStationRow{ID: 7}, nil)
+		mockRepo.EXPECT().DeleteKitchenStation(ctx, int32(7)).Return(errors.New("db error"))
+
+		assert.ErrorIs(t, service.DeleteStation(ctx, 7), common.ErrInternal)
+	})
+}
@@ -0,0 +1,142 @@
+-- name: ListKitchenStations :many
+-- Mengambil semua stasiun dapur beserta kategori yang dipetakan ke masing-masing stasiun.
+SELECT
+    ks.*,
+    ARRAY(
+        SELECT ksc.category_id FROM kitchen_station_categories ksc
+        WHERE ksc.station_id = ks.id
+        ORDER BY ksc.category_id
+    )::int[] AS category_ids
+FROM kitchen_stations ks
+ORDER BY ks.sort_order, ks.id;
+
+-- name: GetKitchenStation :one
+-- Mengambil satu stasiun dapur beserta kategorinya.
+SELECT
+    ks.*,
+    ARRAY(
+        SELECT ksc.category_id FROM kitchen_station_categories ksc
+        WHERE ksc.station_id = ks.id
+        ORDER BY ksc.category_id
+    )::int[] AS category_ids
+FROM kitchen_stations ks
+WHERE ks.id = $1
+LIMIT 1;
+
+-- name: GetKitchenStationByName :one
+-- Dipakai untuk memastikan nama stasiun unik.
+SELECT * FROM kitchen_stations
+WHERE name = $1
+LIMIT 1;
+
+-- name: CreateKitchenStation :one
+INSERT INTO kitchen_stations (name, sort_order)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpdateKitchenStation :one
+UPDATE kitchen_stations
+SET
+    name = $2,
+    sort_order = $3,
+    is_active = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteKitchenStation :exec
+-- Item pesanan yang sudah diarahkan ke stasiun ini kehilangan stasiunnya (ON DELETE SET NULL).
+DELETE FROM kitchen_stations
+WHERE id = $1;
+
+-- name: ClearKitchenStationCategories :exec
+DELETE FROM kitchen_station_categories
+WHERE station_id = $1;
+
+-- name: AssignKitchenStationCategories :exec
+-- Memetakan kategori ke stasiun; kategori yang sudah dipetakan ke stasiun lain dipindahkan ke stasiun ini.
+INSERT INTO kitchen_station_categories (category_id, station_id)
+SELECT unnest(sqlc.arg(category_ids)::int[]), sqlc.arg(station_id)::int
+ON CONFLICT (category_id) DO UPDATE SET station_id = EXCLUDED.station_id;
+
+-- name: CountExistingCategories :one
+-- Menghitung berapa banyak ID kategori yang benar-benar ada.
+SELECT count(*) FROM categories
+WHERE id = ANY(sqlc.arg(category_ids)::int[]);
+
+-- name: ListKitchenTicketItems :many
+-- Mengambil item pesanan yang masih perlu disiapkan untuk layar dapur (KDS).
+-- Pesanan yang dibatalkan tidak ditampilkan; item yang sudah disajikan hanya ikut bila diminta.
+SELECT
+    oi.id,
+    oi.order_id,
+    oi.product_id,
+    p.name AS product_name,
+    oi.quantity,
+    oi.station_id,
+    oi.prep_status,
+    oi.is_priority,
+    oi.queued_at,
+    oi.started_at,
+    oi.ready_at,
+    oi.served_at,
+    o.type AS order_type,
+    o.status AS order_status,
+    o.created_at AS order_created_at,
+    ARRAY(
+        SELECT po.name FROM order_item_options oio
+        JOIN product_options po ON po.id = oio.product_option_id
+        WHERE oio.order_item_id = oi.id
+        ORDER BY po.name
+    )::text[] AS option_names
+FROM order_items oi
+JOIN orders o ON o.id = oi.order_id
+JOIN products p ON p.id = oi.product_id
+WHERE o.status <> 'cancelled'
+  AND (sqlc.narg(station_id)::int IS NULL OR oi.station_id = sqlc.narg(station_id)::int)
+  AND (sqlc.narg(order_id)::uuid IS NULL OR oi.order_id = sqlc.narg(order_id)::uuid)
+  AND (sqlc.arg(include_served)::bool OR oi.prep_status <> 'served')
+ORDER BY o.created_at, oi.order_id, oi.queued_at, oi.id;
+
+-- name: GetKitchenOrderItem :one
+-- Mengambil satu item pesanan beserta status pesanannya untuk aksi KDS.
+SELECT oi.*, o.status AS order_status
+FROM order_items oi
+JOIN orders o ON o.id = oi.order_id
+WHERE oi.id = $1
+LIMIT 1;
+
+-- name: UpdateOrderItemPrepStatus :one
+-- Mengubah status penyiapan item; waktu tahap berikutnya dihapus saat item di-recall.
+-- Hanya berhasil bila status saat ini masih sama (mencegah bump ganda dari dua layar).
+UPDATE order_items
+SET
+    prep_status = sqlc.arg(prep_status)::order_item_status,
+    started_at = CASE
+        WHEN sqlc.arg(prep_status)::order_item_status = 'queued' THEN NULL
+        ELSE COALESCE(started_at, now())
+    END,
+    ready_at = CASE
+        WHEN sqlc.arg(prep_status)::order_item_status IN ('queued', 'cooking') THEN NULL
+        ELSE COALESCE(ready_at, now())
+    END,
+    served_at = CASE
+        WHEN sqlc.arg(prep_status)::order_item_status = 'served' THEN COALESCE(served_at, now())
+        ELSE NULL
+    END
+WHERE id = sqlc.arg(id) AND prep_status = sqlc.arg(current_status)::order_item_status
+RETURNING *;
+
+-- name: SetOrderItemPriority :one
+UPDATE order_items
+SET is_priority = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: GetOrderPrepSummary :one
+-- Ringkasan status penyiapan seluruh item sebuah pesanan, dipakai untuk menurunkan status pesanan.
+SELECT
+    count(*) AS total_items,
+    count(*) FILTER (WHERE prep_status = 'queued') AS queued_items,
+    count(*) FILTER (WHERE prep_status = 'served') AS served_items
+FROM order_items
+WHERE order_id = $1;
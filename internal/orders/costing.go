@@ -0,0 +1,70 @@
+package orders
+
+import (
+	"POS-kasir/internal/costing"
+	costing_repo "POS-kasir/internal/costing/repository"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// costedLine is an order line as far as its cost of goods is concerned: the units it has already sold and
+// the unit cost it carries for them.
+type costedLine struct {
+	ItemID    uuid.UUID
+	ProductID uuid.UUID
+	Quantity  int32
+	CostPrice pgtype.Numeric
+}
+
+// drawLineCost takes quantity more units of the line's product out of its cost layers. Under FIFO the line
+// is re-costed at what all of its units drew; under the moving average it keeps the cost it was sold at.
+func drawLineCost(ctx context.Context, qtx *orders_repo.Queries, qCost costing_repo.Querier, method string, line costedLine, quantity int32, fallback pgtype.Numeric) error {
+	drawn, err := costing.Consume(ctx, qCost, line.ProductID, quantity, pgtype.UUID{Bytes: line.ItemID, Valid: true}, fallback)
+	if err != nil {
+		return err
+	}
+	if method != costing.MethodFIFO {
+		return nil
+	}
+
+	total := lineCost(line) + drawn
+	return setLineCost(ctx, qtx, line.ItemID, costing.UnitCost(total, line.Quantity+quantity))
+}
+
+// returnLineCost puts quantity of the line's units back into the product's cost layers. previousStock is the
+// product's stock before they came back. Under FIFO what stays on the line keeps the cost of the units left.
+func returnLineCost(ctx context.Context, qtx *orders_repo.Queries, qCost costing_repo.Querier, method string, line costedLine, previousStock, quantity int32) error {
+	restored, err := costing.Restore(ctx, qCost, line.ProductID, line.ItemID, previousStock, quantity, line.CostPrice)
+	if err != nil {
+		return err
+	}
+	remaining := line.Quantity - quantity
+	if method != costing.MethodFIFO || remaining <= 0 {
+		return nil
+	}
+
+	total := lineCost(line) - restored
+	if total < 0 {
+		total = 0
+	}
+	return setLineCost(ctx, qtx, line.ItemID, costing.UnitCost(total, remaining))
+}
+
+func lineCost(line costedLine) float64 {
+	if !line.CostPrice.Valid {
+		return 0
+	}
+	f, _ := line.CostPrice.Float64Value()
+	return f.Float64 * float64(line.Quantity)
+}
+
+func setLineCost(ctx context.Context, qtx *orders_repo.Queries, itemID uuid.UUID, cost pgtype.Numeric) error {
+	if err := qtx.SetOrderItemCost(ctx, orders_repo.SetOrderItemCostParams{ID: itemID, CostPriceAtSale: cost}); err != nil {
+		return fmt.Errorf("failed to update cost of item %s: %w", itemID, err)
+	}
+	return nil
+}
@@ -9,16 +9,68 @@ import (
 	"github.com/google/uuid"
 )
 
+// ApplyPromotionRequest names a promotion by its ID or by one of its codes. A promotion that has codes can
+// only be applied with a code.
 type ApplyPromotionRequest struct {
-	PromotionID uuid.UUID `json:"promotion_id" validate:"required"`
+	PromotionID uuid.UUID `json:"promotion_id" validate:"required_without=Code"`
+	Code        string    `json:"code" validate:"required_without=PromotionID,omitempty,max=64"`
+}
+
+// RedeemPointsRequest spends the customer's loyalty points as a discount on an open order.
+type RedeemPointsRequest struct {
+	Points int64 `json:"points" validate:"required,gt=0" example:"100"`
+}
+
+// AppliedPromotionResponse is one promotion on an order and its part of the order's discount, in the
+// order the promotions were stacked.
+type AppliedPromotionResponse struct {
+	PromotionID     uuid.UUID                    `json:"promotion_id"`
+	PromotionName   string                       `json:"promotion_name"`
+	PromotionCodeID *uuid.UUID                   `json:"promotion_code_id,omitempty"`
+	Code            *string                      `json:"code,omitempty"`
+	DiscountAmount  int64                        `json:"discount_amount"`
+	AutoApplied     bool                         `json:"auto_applied"`
+	Stacking        repository.PromotionStacking `json:"stacking"`
+	Priority        int32                        `json:"priority"`
+	ScheduleWindow  *string                      `json:"schedule_window,omitempty"`
+}
+
+// PromotionEvaluationResult is what one promotion would do for an order. Reason explains why an ineligible
+// promotion does not apply.
+type PromotionEvaluationResult struct {
+	PromotionID    uuid.UUID                    `json:"promotion_id"`
+	PromotionName  string                       `json:"promotion_name"`
+	Stacking       repository.PromotionStacking `json:"stacking"`
+	Priority       int32                        `json:"priority"`
+	AutoApply      bool                         `json:"auto_apply"`
+	RequiresCode   bool                         `json:"requires_code"`
+	Eligible       bool                         `json:"eligible"`
+	DiscountAmount int64                        `json:"discount_amount"`
+	Reason         string                       `json:"reason,omitempty"`
+	Applied        bool                         `json:"applied"`
+	Selected       bool                         `json:"selected"`
+}
+
+// PromotionEvaluationResponse is a dry run of the promotions on an order: every promotion considered, the
+// ones the stacking rules would combine and the discount they would give. Nothing is saved.
+type PromotionEvaluationResponse struct {
+	OrderID         uuid.UUID                   `json:"order_id"`
+	GrossTotal      int64                       `json:"gross_total"`
+	CurrentDiscount int64                       `json:"current_discount"`
+	DiscountAmount  int64                       `json:"discount_amount"`
+	Promotions      []PromotionEvaluationResult `json:"promotions"`
+	Selected        []AppliedPromotionResponse  `json:"selected"`
 }
 
 type CreateOrderItemOptionRequest struct {
 	ProductOptionID uuid.UUID `json:"product_option_id" validate:"required"`
 }
 
+// CreateOrderItemRequest orders a product. VariantID is required for a product with variants. Options must
+// satisfy the product's modifier groups; a group left empty gets its default options.
 type CreateOrderItemRequest struct {
 	ProductID uuid.UUID                      `json:"product_id" validate:"required"`
+	VariantID *uuid.UUID                     `json:"variant_id,omitempty"`
 	Quantity  int32                          `json:"quantity" validate:"required,gt=0"`
 	Options   []CreateOrderItemOptionRequest `json:"options" validate:"dive"`
 }
@@ -40,8 +92,11 @@ type CancelOrderRequest struct {
 	CancellationNotes    string `json:"cancellation_notes" validate:"omitempty,max=255"`
 }
 
+// UpdateOrderItemRequest sets the quantity of a line, identified by its product and variant. Options only
+// apply to a line being added, checked like CreateOrderItemRequest; lines already on the order keep theirs.
 type UpdateOrderItemRequest struct {
 	ProductID uuid.UUID                      `json:"product_id" validate:"required"`
+	VariantID *uuid.UUID                     `json:"variant_id,omitempty"`
 	Quantity  int32                          `json:"quantity" validate:"required,gt=0"`
 	Options   []CreateOrderItemOptionRequest `json:"options" validate:"dive"`
 }
@@ -51,16 +106,69 @@ type UpdateOrderItemsRequest struct {
 	Items   []UpdateOrderItemRequest `json:"items" validate:"required,min=1,dive"`
 }
 
+// ScanOrderItemRequest adds what a scanned barcode or typed SKU stands for to an open order. Quantity
+// defaults to 1. Version is optional so rapid scans do not conflict with each other; when given it must match.
+type ScanOrderItemRequest struct {
+	Code     string `json:"code" validate:"required,max=64"`
+	Quantity int32  `json:"quantity" validate:"omitempty,gt=0"`
+	Version  *int32 `json:"version,omitempty"`
+}
+
 type ConfirmManualPaymentRequest struct {
-	PaymentMethodID int32 `json:"payment_method_id" validate:"required,gt=0"`
-	CashReceived    int64 `json:"cash_received" validate:"omitempty,gte=0"`
-	Version         int32 `json:"version" validate:"required"`
+	PaymentMethodID int32  `json:"payment_method_id" validate:"required,gt=0"`
+	CashReceived    int64  `json:"cash_received" validate:"omitempty,gte=0"`
+	ReferenceNumber string `json:"reference_number" validate:"omitempty,max=255"`
+	Version         int32  `json:"version" validate:"required"`
+	// RemoveIneligiblePromotion strips a promotion that does not accept this payment method instead of rejecting the payment.
+	RemoveIneligiblePromotion bool `json:"remove_ineligible_promotion"`
+}
+
+type AddOrderPaymentRequest struct {
+	PaymentMethodID int32  `json:"payment_method_id" validate:"required,gt=0"`
+	Amount          int64  `json:"amount" validate:"required,gt=0"`
+	ReferenceNumber string `json:"reference_number" validate:"omitempty,max=255"`
+	Version         int32  `json:"version" validate:"required"`
+	// RemoveIneligiblePromotion strips a promotion that does not accept this payment method instead of rejecting the payment.
+	RemoveIneligiblePromotion bool `json:"remove_ineligible_promotion"`
+}
+
+type SplitOrderItem struct {
+	OrderItemID uuid.UUID `json:"order_item_id" validate:"required"`
+	Quantity    int32     `json:"quantity" validate:"required,gt=0"`
+}
+
+type SplitOrderPart struct {
+	Items []SplitOrderItem `json:"items" validate:"required,min=1,dive"`
+}
+
+// SplitOrderRequest splits by explicit parts (one child order per part) or evenly by guest count.
+// Items are never divided, so an even split needs at least as many item units as ways.
+type SplitOrderRequest struct {
+	Parts   []SplitOrderPart `json:"parts" validate:"required_without=Ways,omitempty,min=1,dive"`
+	Ways    int32            `json:"ways" validate:"required_without=Parts,omitempty,min=2,max=20"`
+	Version int32            `json:"version" validate:"required"`
+}
+
+type MergeOrdersRequest struct {
+	SourceOrderIDs []uuid.UUID `json:"source_order_ids" validate:"required,min=1,dive,required"`
+	Version        int32       `json:"version" validate:"required"`
+}
+
+type SplitOrderResponse struct {
+	Parent   OrderDetailResponse   `json:"parent"`
+	Children []OrderDetailResponse `json:"children"`
 }
 
 type UpdateOrderStatusRequest struct {
 	Status repository.OrderStatus `json:"status" validate:"required,oneof=open in_progress served paid cancelled"`
 }
 
+// ReopenOrderRequest moves an unpaid order back to open; only managers may do this.
+type ReopenOrderRequest struct {
+	Reason  string `json:"reason" validate:"required"`
+	Version int32  `json:"version" validate:"required"`
+}
+
 type OrderItemOptionResponse struct {
 	ProductOptionID uuid.UUID `json:"product_option_id"`
 	OptionName      string    `json:"option_name,omitempty"`
@@ -68,36 +176,101 @@ type OrderItemOptionResponse struct {
 }
 
 type OrderItemResponse struct {
-	ID          uuid.UUID                 `json:"id"`
-	ProductID   uuid.UUID                 `json:"product_id"`
-	ProductName string                    `json:"product_name,omitempty"`
-	Quantity    int32                     `json:"quantity"`
-	PriceAtSale int64                     `json:"price_at_sale"`
-	Subtotal    int64                     `json:"subtotal"`
-	Options     []OrderItemOptionResponse `json:"options,omitempty"`
+	ID          uuid.UUID                  `json:"id"`
+	ProductID   uuid.UUID                  `json:"product_id"`
+	ProductName string                     `json:"product_name,omitempty"`
+	VariantID   *uuid.UUID                 `json:"variant_id,omitempty"`
+	VariantName string                     `json:"variant_name,omitempty"`
+	Quantity    int32                      `json:"quantity"`
+	PriceAtSale int64                      `json:"price_at_sale"`
+	Subtotal    int64                      `json:"subtotal"`
+	Options     []OrderItemOptionResponse  `json:"options,omitempty"`
+	StationID   *int32                     `json:"station_id,omitempty"`
+	PrepStatus  repository.OrderItemStatus `json:"prep_status,omitempty"`
+	IsPriority  bool                       `json:"is_priority"`
 }
 
 type OrderDetailResponse struct {
-	ID                      uuid.UUID              `json:"id"`
-	UserID                  *uuid.UUID             `json:"user_id,omitempty"`
-	CustomerID              *uuid.UUID             `json:"customer_id,omitempty"`
-	Type                    repository.OrderType   `json:"type"`
-	Status                  repository.OrderStatus `json:"status"`
-	GrossTotal              int64                  `json:"gross_total"`
-	DiscountAmount          int64                  `json:"discount_amount"`
-	NetTotal                int64                  `json:"net_total"`
-	TaxAmount               int64                  `json:"tax_amount"`
-	ServiceChargeAmount     int64                  `json:"service_charge_amount"`
-	PaymentMethodID         *int32                 `json:"payment_method_id,omitempty"`
-	PaymentGatewayReference *string                `json:"payment_gateway_reference,omitempty"`
-	CashReceived            *int64                 `json:"cash_received,omitempty"`
-	ChangeDue               *int64                 `json:"change_due,omitempty"`
-	AppliedPromotionID      *uuid.UUID             `json:"applied_promotion_id,omitempty"`
-	CreatedAt               time.Time           `json:"created_at"`
-	UpdatedAt               time.Time           `json:"updated_at"`
-	Version                 int32               `json:"version"`
-	Items                   []OrderItemResponse `json:"items"`
-	}
+	ID                      uuid.UUID                    `json:"id"`
+	UserID                  *uuid.UUID                   `json:"user_id,omitempty"`
+	CustomerID              *uuid.UUID                   `json:"customer_id,omitempty"`
+	Type                    repository.OrderType         `json:"type"`
+	Status                  repository.OrderStatus       `json:"status"`
+	GrossTotal              int64                        `json:"gross_total"`
+	DiscountAmount          int64                        `json:"discount_amount"`
+	NetTotal                int64                        `json:"net_total"`
+	TaxAmount               int64                        `json:"tax_amount"`
+	ServiceChargeAmount     int64                        `json:"service_charge_amount"`
+	TaxRate                 float64                      `json:"tax_rate"`
+	ServiceChargeRate       float64                      `json:"service_charge_rate"`
+	TaxInclusive            bool                         `json:"tax_inclusive"`
+	PaymentMethodID         *int32                       `json:"payment_method_id,omitempty"`
+	PaymentGatewayReference *string                      `json:"payment_gateway_reference,omitempty"`
+	CashReceived            *int64                       `json:"cash_received,omitempty"`
+	ChangeDue               *int64                       `json:"change_due,omitempty"`
+	AppliedPromotionID      *uuid.UUID                   `json:"applied_promotion_id,omitempty"`
+	AppliedPromotionCodeID  *uuid.UUID                   `json:"applied_promotion_code_id,omitempty"`
+	CreatedAt               time.Time                    `json:"created_at"`
+	UpdatedAt               time.Time                    `json:"updated_at"`
+	Version                 int32                        `json:"version"`
+	QueueNumber             string                       `json:"queue_number,omitempty"`
+	BusinessDate            string                       `json:"business_date,omitempty"`
+	Items                   []OrderItemResponse          `json:"items"`
+	ParentOrderID           *uuid.UUID                   `json:"parent_order_id,omitempty"`
+	ChildOrderIDs           []uuid.UUID                  `json:"child_order_ids,omitempty"`
+	Payments                []OrderPaymentResponse       `json:"payments"`
+	AmountPaid              int64                        `json:"amount_paid"`
+	BalanceDue              int64                        `json:"balance_due"`
+	Refunds                 []OrderRefundResponse        `json:"refunds"`
+	AmountRefunded          int64                        `json:"amount_refunded"`
+	StatusHistory           []OrderStatusHistoryResponse `json:"status_history"`
+	Promotions              []AppliedPromotionResponse   `json:"promotions,omitempty"`
+	Loyalty                 *OrderLoyaltyResponse        `json:"loyalty,omitempty"`
+}
+
+// OrderLoyaltyResponse is what an order did to its customer's loyalty points, and the balance the customer has now.
+type OrderLoyaltyResponse struct {
+	PointsEarned   int64 `json:"points_earned"`
+	PointsRedeemed int64 `json:"points_redeemed"`
+	DiscountAmount int64 `json:"discount_amount"`
+	Balance        int64 `json:"balance"`
+}
+
+type OrderStatusHistoryResponse struct {
+	FromStatus *repository.OrderStatus `json:"from_status,omitempty"`
+	ToStatus   repository.OrderStatus  `json:"to_status"`
+	ChangedBy  *uuid.UUID              `json:"changed_by,omitempty"`
+	Note       *string                 `json:"note,omitempty"`
+	CreatedAt  time.Time               `json:"created_at"`
+}
+
+type OrderPaymentResponse struct {
+	ID                uuid.UUID `json:"id"`
+	PaymentMethodID   int32     `json:"payment_method_id"`
+	PaymentMethodName string    `json:"payment_method_name"`
+	Amount            int64     `json:"amount"`
+	TenderedAmount    int64     `json:"tendered_amount"`
+	ChangeAmount      int64     `json:"change_amount"`
+	ReferenceNumber   *string   `json:"reference_number,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type OrderRefundResponse struct {
+	ID              uuid.UUID                 `json:"id"`
+	OrderPaymentID  *uuid.UUID                `json:"order_payment_id,omitempty"`
+	PaymentMethodID *int32                    `json:"payment_method_id,omitempty"`
+	Amount          int64                     `json:"amount"`
+	Reason          *string                   `json:"reason,omitempty"`
+	Items           []OrderRefundItemResponse `json:"items,omitempty"`
+	CreatedAt       time.Time                 `json:"created_at"`
+}
+
+type OrderRefundItemResponse struct {
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	Quantity    int32     `json:"quantity"`
+	Amount      int64     `json:"amount"`
+	Restocked   bool      `json:"restocked"`
+}
 
 type OrderListResponse struct {
 	ID          uuid.UUID              `json:"id"`
@@ -111,13 +284,41 @@ type OrderListResponse struct {
 	IsPaid      bool                   `json:"is_paid"`
 }
 
+// CallingBoardEntry is one queue number shown on the customer-facing calling board.
+type CallingBoardEntry struct {
+	OrderID     uuid.UUID            `json:"order_id"`
+	QueueNumber string               `json:"queue_number"`
+	Type        repository.OrderType `json:"type"`
+	CreatedAt   time.Time            `json:"created_at"`
+	ReadyAt     *time.Time           `json:"ready_at,omitempty"`
+}
+
+// CallingBoardResponse splits the business day's waiting orders into those still being prepared
+// and those ready for pickup, most recently ready first.
+type CallingBoardResponse struct {
+	BusinessDate string              `json:"business_date"`
+	Preparing    []CallingBoardEntry `json:"preparing"`
+	Ready        []CallingBoardEntry `json:"ready"`
+}
+
 type PagedOrderResponse struct {
 	Orders     []OrderListResponse   `json:"orders"`
 	Pagination pagination.Pagination `json:"pagination"`
 }
 
+// RefundOrderItem selects Quantity units of an order line to refund.
+type RefundOrderItem struct {
+	OrderItemID uuid.UUID `json:"order_item_id" validate:"required"`
+	Quantity    int32     `json:"quantity" validate:"required,gt=0"`
+}
+
+// RefundOrderRequest refunds the whole order when Items is empty, otherwise only the selected lines.
+// Restock defaults to true; set it to false for goods that cannot go back on the shelf (e.g. spoiled food).
 type RefundOrderRequest struct {
-	Reason string `json:"reason" validate:"required"`
+	Reason         string            `json:"reason" validate:"required"`
+	Items          []RefundOrderItem `json:"items,omitempty" validate:"omitempty,dive"`
+	Restock        *bool             `json:"restock,omitempty"`
+	OrderPaymentID *uuid.UUID        `json:"order_payment_id,omitempty"`
 }
 
 type MidtransPaymentResponse struct {
@@ -134,3 +335,15 @@ type PaymentAction struct {
 	Method string `json:"method"`
 	URL    string `json:"url"`
 }
+
+// LowStockThreshold matches the default threshold of the low stock report.
+const LowStockThreshold = 5
+
+// StockAlert is the payload of a low stock websocket event.
+type StockAlert struct {
+	ProductID   uuid.UUID  `json:"product_id"`
+	ProductName string     `json:"product_name"`
+	VariantID   *uuid.UUID `json:"variant_id,omitempty"`
+	VariantName string     `json:"variant_name,omitempty"`
+	Stock       int32      `json:"stock"`
+}
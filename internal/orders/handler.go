@@ -18,11 +18,21 @@ type IOrderHandler interface {
 	InitiateMidtransPaymentHandler(c fiber.Ctx) error
 	MidtransNotificationHandler(c fiber.Ctx) error
 	ListOrdersHandler(c fiber.Ctx) error
+	GetCallingBoardHandler(c fiber.Ctx) error
 	CancelOrderHandler(c fiber.Ctx) error
 	UpdateOrderItemsHandler(c fiber.Ctx) error
+	ScanOrderItemHandler(c fiber.Ctx) error
 	ConfirmManualPaymentHandler(c fiber.Ctx) error
+	AddOrderPaymentHandler(c fiber.Ctx) error
+	SplitOrderHandler(c fiber.Ctx) error
+	MergeOrdersHandler(c fiber.Ctx) error
 	UpdateOperationalStatusHandler(c fiber.Ctx) error
+	ReopenOrderHandler(c fiber.Ctx) error
 	ApplyPromotionHandler(c fiber.Ctx) error
+	EvaluatePromotionsHandler(c fiber.Ctx) error
+	AutoApplyPromotionsHandler(c fiber.Ctx) error
+	RedeemPointsHandler(c fiber.Ctx) error
+	CancelPointsRedemptionHandler(c fiber.Ctx) error
 	RefundOrderHandler(c fiber.Ctx) error
 }
 
@@ -40,7 +50,7 @@ func NewOrderHandler(orderService IOrderService, log logger.ILogger) IOrderHandl
 
 // ApplyPromotionHandler applies a promotion to an order
 // @Summary      Apply promotion to an order
-// @Description  Apply a specific promotion to an existing order by its ID (Roles: admin, manager, cashier)
+// @Description  Apply a promotion to an open order by its ID or by one of its codes; a promotion that has codes can only be applied with a code. The new promotion takes precedence over the order's other promotions: an exclusive one replaces them, a stackable one is combined with the stackable ones, and auto-apply promotions are picked again around it. Usage limits are checked now and again when the order is settled, which records the redemptions (Roles: admin, manager, cashier)
 // @Tags         Orders
 // @Accept       json
 // @Produce      json
@@ -48,7 +58,8 @@ func NewOrderHandler(orderService IOrderService, log logger.ILogger) IOrderHandl
 // @Param        request body ApplyPromotionRequest true "Promotion details"
 // @Success      200 {object} common.SuccessResponse{data=OrderDetailResponse} "Promotion applied successfully"
 // @Failure      400 {object} common.ErrorResponse "Invalid order ID format or request body"
-// @Failure      404 {object} common.ErrorResponse "Order or Promotion not found"
+// @Failure      404 {object} common.ErrorResponse "Order, promotion or code not found"
+// @Failure      409 {object} common.ErrorResponse "Promotion not applicable, its redemption limit reached, or the order can no longer change"
 // @Failure      500 {object} common.ErrorResponse "Failed to apply promotion"
 // @x-roles      ["admin", "manager", "cashier"]
 // @Router       /orders/{id}/apply-promotion [post]
@@ -80,9 +91,15 @@ func (h *OrderHandler) ApplyPromotionHandler(c fiber.Ctx) error {
 		if errors.Is(err, common.ErrNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order or Promotion not found"})
 		}
-		if errors.Is(err, common.ErrPromotionNotApplicable) {
+		if errors.Is(err, common.ErrPromotionCodeNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		if errors.Is(err, common.ErrPromotionNotApplicable) || errors.Is(err, common.ErrPromotionLimitReached) {
 			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Promotion cannot be applied", Error: err.Error()})
 		}
+		if errors.Is(err, common.ErrOrderNotModifiable) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order cannot be modified", Error: err.Error()})
+		}
 		h.log.Errorf("Failed to apply promotion in service", "error", err, "orderID", orderID)
 		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to apply promotion"})
 	}
@@ -93,9 +110,185 @@ func (h *OrderHandler) ApplyPromotionHandler(c fiber.Ctx) error {
 	})
 }
 
+// EvaluatePromotionsHandler previews the promotions of an order
+// @Summary      Evaluate promotions for an order
+// @Description  Dry run of the promotion engine on an open order: every promotion on the order or currently active, with the discount each would give, why an ineligible one does not apply, and the combination the stacking rules would pick. Nothing is saved (Roles: admin, manager, cashier)
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=PromotionEvaluationResponse} "Promotions evaluated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order ID format or order is not open"
+// @Failure      404 {object} common.ErrorResponse "Order not found"
+// @Failure      500 {object} common.ErrorResponse "Failed to evaluate promotions"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /orders/{id}/promotions/evaluate [get]
+func (h *OrderHandler) EvaluatePromotionsHandler(c fiber.Ctx) error {
+
+	orderID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid order ID format"})
+	}
+
+	evaluation, err := h.orderService.EvaluatePromotions(c.RequestCtx(), orderID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order not found"})
+		}
+		if errors.Is(err, common.ErrOrderNotModifiable) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		h.log.Errorf("Failed to evaluate promotions in service", "error", err, "orderID", orderID)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to evaluate promotions"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Promotions evaluated successfully",
+		Data:    evaluation,
+	})
+}
+
+// AutoApplyPromotionsHandler applies the best promotions to an order
+// @Summary      Auto-apply promotions to an order
+// @Description  Reprice an open order with the promotion engine: promotions the cashier applied are kept while they apply and the eligible auto-apply promotions are combined around them following their stacking and priority. Each promotion's part of the discount is stored on the order (Roles: admin, manager, cashier)
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=OrderDetailResponse} "Promotions applied successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order ID format or order cannot be modified"
+// @Failure      404 {object} common.ErrorResponse "Order not found"
+// @Failure      409 {object} common.ErrorResponse "Order was modified concurrently"
+// @Failure      500 {object} common.ErrorResponse "Failed to apply promotions"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /orders/{id}/promotions/auto-apply [post]
+func (h *OrderHandler) AutoApplyPromotionsHandler(c fiber.Ctx) error {
+
+	orderID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid order ID format"})
+	}
+
+	orderResponse, err := h.orderService.AutoApplyPromotions(c.RequestCtx(), orderID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order not found"})
+		}
+		if errors.Is(err, common.ErrOrderNotModifiable) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		if errors.Is(err, common.ErrOrderConflict) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		h.log.Errorf("Failed to auto-apply promotions in service", "error", err, "orderID", orderID)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to apply promotions"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Promotions applied successfully",
+		Data:    orderResponse,
+	})
+}
+
+// RedeemPointsHandler spends the customer's loyalty points as a discount on an order
+// @Summary      Redeem loyalty points as a discount
+// @Description  Spend the customer's loyalty points as a discount on an open order before it is paid, each point worth the configured point value. The discount is taken after the order's promotions and the order is repriced. Points can also be spent as a tender through a payment method of kind loyalty_points (Roles: admin, manager, cashier)
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order ID" Format(uuid)
+// @Param        request body RedeemPointsRequest true "Points to redeem"
+// @Success      200 {object} common.SuccessResponse{data=OrderDetailResponse} "Points redeemed successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order ID format or request body"
+// @Failure      404 {object} common.ErrorResponse "Order not found"
+// @Failure      409 {object} common.ErrorResponse "Order is not open or already being paid, points cannot be redeemed on it, or the customer does not have enough points"
+// @Failure      500 {object} common.ErrorResponse "Failed to redeem points"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /orders/{id}/loyalty/redeem [post]
+func (h *OrderHandler) RedeemPointsHandler(c fiber.Ctx) error {
+
+	orderID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid order ID format"})
+	}
+
+	var req RedeemPointsRequest
+	if err := c.Bind().Body(&req); err != nil {
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data: map[string]interface{}{
+					"errors": ve.Errors,
+				},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+	}
+
+	orderResponse, err := h.orderService.RedeemPoints(c.RequestCtx(), orderID, req)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order not found"})
+		}
+		if errors.Is(err, common.ErrOrderNotModifiable) || errors.Is(err, common.ErrOrderConflict) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order cannot be modified", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrPointsNotRedeemable) || errors.Is(err, common.ErrInsufficientPoints) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Loyalty points cannot be redeemed", Error: err.Error()})
+		}
+		h.log.Errorf("Failed to redeem loyalty points in service", "error", err, "orderID", orderID)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to redeem points"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Points redeemed successfully",
+		Data:    orderResponse,
+	})
+}
+
+// CancelPointsRedemptionHandler gives points redeemed as a discount back to the customer
+// @Summary      Cancel a loyalty points redemption
+// @Description  Give the points redeemed as a discount on an open, unpaid order back to the customer and reprice the order without them (Roles: admin, manager, cashier)
+// @Tags         Orders
+// @Produce      json
+// @Param        id path string true "Order ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=OrderDetailResponse} "Points redemption cancelled successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order ID format"
+// @Failure      404 {object} common.ErrorResponse "Order not found or no points redeemed on it"
+// @Failure      409 {object} common.ErrorResponse "Order is not open or already being paid"
+// @Failure      500 {object} common.ErrorResponse "Failed to cancel points redemption"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /orders/{id}/loyalty/redeem [delete]
+func (h *OrderHandler) CancelPointsRedemptionHandler(c fiber.Ctx) error {
+
+	orderID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid order ID format"})
+	}
+
+	orderResponse, err := h.orderService.CancelPointsRedemption(c.RequestCtx(), orderID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order or points redemption not found"})
+		}
+		if errors.Is(err, common.ErrOrderNotModifiable) || errors.Is(err, common.ErrOrderConflict) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order cannot be modified", Error: err.Error()})
+		}
+		h.log.Errorf("Failed to cancel loyalty points redemption in service", "error", err, "orderID", orderID)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to cancel points redemption"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Points redemption cancelled successfully",
+		Data:    orderResponse,
+	})
+}
+
 // UpdateOperationalStatusHandler updates the operational status of an order
 // @Summary      Update order operational status
-// @Description  Update the status of an existing order (e.g., to in_progress, served) (Roles: admin, manager, cashier)
+// @Description  Move an order along its lifecycle: open -> in_progress -> served -> paid. Marking an order paid requires its tenders to cover the net total; reopening and cancelling have their own endpoints (Roles: admin, manager, cashier)
 // @Tags         Orders
 // @Accept       json
 // @Produce      json
@@ -150,9 +343,68 @@ func (h *OrderHandler) UpdateOperationalStatusHandler(c fiber.Ctx) error {
 	})
 }
 
+// ReopenOrderHandler moves an unpaid order back to open
+// @Summary      Reopen an order
+// @Description  Move an unpaid in_progress or served order back to open so its items can be changed. The reason is kept in the status history (Roles: admin, manager)
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order ID" Format(uuid)
+// @Param        request body ReopenOrderRequest true "Reopen details"
+// @Success      200 {object} common.SuccessResponse{data=OrderDetailResponse} "Order reopened successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order ID format or request body"
+// @Failure      404 {object} common.ErrorResponse "Order not found"
+// @Failure      409 {object} common.ErrorResponse "Invalid status transition or version conflict"
+// @Failure      500 {object} common.ErrorResponse "Failed to reopen order"
+// @x-roles      ["admin", "manager"]
+// @Router       /orders/{id}/reopen [post]
+func (h *OrderHandler) ReopenOrderHandler(c fiber.Ctx) error {
+	orderID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warnf("Invalid order ID format for reopen", "error", err, "id", orderID)
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid order ID format"})
+	}
+
+	var req ReopenOrderRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("Cannot parse reopen order request body", "error", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data: map[string]interface{}{
+					"errors": ve.Errors,
+				},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+	}
+
+	orderResponse, err := h.orderService.ReopenOrder(c.RequestCtx(), orderID, req)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order not found"})
+		}
+		if errors.Is(err, common.ErrInvalidStatusTransition) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Invalid status transition", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrOrderConflict) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order version conflict", Error: err.Error()})
+		}
+		h.log.Errorf("Failed to reopen order in service", "error", err, "orderID", orderID)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to reopen order"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Order reopened successfully",
+		Data:    orderResponse,
+	})
+}
+
 // ConfirmManualPaymentHandler confirms manual payment for an order
 // @Summary      Confirm manual payment for an order
-// @Description  Process a manual payment (Cash) and finalize an order (Roles: admin, manager, cashier)
+// @Description  Process a manual (non-gateway) payment and finalize an order. Change is only given by methods that allow it; methods requiring a reference need reference_number. A method the applied promotion does not accept is rejected unless remove_ineligible_promotion is set, which drops the promotion and reprices the order (Roles: admin, manager, cashier)
 // @Tags         Orders
 // @Accept       json
 // @Produce      json
@@ -161,7 +413,7 @@ func (h *OrderHandler) UpdateOperationalStatusHandler(c fiber.Ctx) error {
 // @Success      200 {object} common.SuccessResponse{data=OrderDetailResponse} "Payment completed successfully"
 // @Failure      400 {object} common.ErrorResponse "Invalid order ID format or request body"
 // @Failure      404 {object} common.ErrorResponse "Order not found"
-// @Failure      409 {object} common.ErrorResponse "Order might have been paid, cancelled, or version conflict"
+// @Failure      409 {object} common.ErrorResponse "Order might have been paid, cancelled, version conflict, payment method not allowed by the promotion, promotion limit reached, or loyalty points cannot be redeemed"
 // @Failure      500 {object} common.ErrorResponse "Failed to complete payment"
 // @x-roles      ["admin", "manager", "cashier"]
 // @Router       /orders/{id}/pay/manual [post]
@@ -197,9 +449,24 @@ func (h *OrderHandler) ConfirmManualPaymentHandler(c fiber.Ctx) error {
 		if errors.Is(err, common.ErrOrderNotModifiable) {
 			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order cannot be processed", Error: "Order might have been paid or cancelled."})
 		}
+		if errors.Is(err, common.ErrOrderAlreadyPaid) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order already paid"})
+		}
 		if errors.Is(err, common.ErrOrderConflict) {
 			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order version conflict", Error: err.Error()})
 		}
+		if errors.Is(err, common.ErrPromotionPaymentMethod) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Payment method not allowed by promotion", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrPromotionLimitReached) || errors.Is(err, common.ErrPromotionNotApplicable) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Promotion cannot be redeemed", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrPointsNotRedeemable) || errors.Is(err, common.ErrInsufficientPoints) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Loyalty points cannot be redeemed", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrPaymentMethodInvalid) || errors.Is(err, common.ErrPaymentReferenceMissing) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
 		h.log.Errorf("Failed to complete manual payment in service", "error", err, "orderID", orderID)
 		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to complete payment"})
 	}
@@ -210,6 +477,204 @@ func (h *OrderHandler) ConfirmManualPaymentHandler(c fiber.Ctx) error {
 	})
 }
 
+// AddOrderPaymentHandler records a single tender against an order
+// @Summary      Add a tender to an order (split payment)
+// @Description  Record one tender of a split or multi-tender payment. The order is settled once its tenders cover net_total; only methods that allow change may exceed the outstanding balance. A method the applied promotion does not accept is rejected unless remove_ineligible_promotion is set (Roles: admin, manager, cashier)
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order ID" Format(uuid)
+// @Param        request body AddOrderPaymentRequest true "Tender details"
+// @Success      200 {object} common.SuccessResponse{data=OrderDetailResponse} "Payment recorded successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order ID format, request body or tender amount"
+// @Failure      404 {object} common.ErrorResponse "Order not found"
+// @Failure      409 {object} common.ErrorResponse "Order already paid, cancelled, version conflict, payment method not allowed by the promotion, promotion limit reached, or loyalty points cannot be redeemed"
+// @Failure      500 {object} common.ErrorResponse "Failed to record payment"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /orders/{id}/payments [post]
+func (h *OrderHandler) AddOrderPaymentHandler(c fiber.Ctx) error {
+	orderID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warnf("Invalid order ID format for order payment", "error", err, "id", orderID)
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid order ID format"})
+	}
+
+	var req AddOrderPaymentRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("Cannot parse order payment request body", "error", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data: map[string]interface{}{
+					"errors": ve.Errors,
+				},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+	}
+
+	orderResponse, err := h.orderService.AddOrderPayment(c.RequestCtx(), orderID, req)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order not found"})
+		}
+		if errors.Is(err, common.ErrOrderNotModifiable) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order cannot be processed", Error: "Order might have been cancelled."})
+		}
+		if errors.Is(err, common.ErrOrderAlreadyPaid) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order already paid"})
+		}
+		if errors.Is(err, common.ErrOrderConflict) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order version conflict", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrPromotionPaymentMethod) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Payment method not allowed by promotion", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrPromotionLimitReached) || errors.Is(err, common.ErrPromotionNotApplicable) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Promotion cannot be redeemed", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrPointsNotRedeemable) || errors.Is(err, common.ErrInsufficientPoints) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Loyalty points cannot be redeemed", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrPaymentMethodInvalid) || errors.Is(err, common.ErrPaymentReferenceMissing) || errors.Is(err, common.ErrPaymentExceedsBalance) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		h.log.Errorf("Failed to record order payment in service", "error", err, "orderID", orderID)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to record payment"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Payment recorded successfully",
+		Data:    orderResponse,
+	})
+}
+
+// SplitOrderHandler splits an open order into child orders
+// @Summary      Split an order (split bill)
+// @Description  Move selected items or quantities into new child orders (one per part), or split evenly by guest count with `ways`. Items are not divided, so an even split needs at least as many item units as ways; split payments on one bill instead. Each bill carries its share of the discount together with the promotions behind it. Only open, unpaid orders can be split (Roles: admin, manager, cashier)
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order ID" Format(uuid)
+// @Param        request body SplitOrderRequest true "Split details"
+// @Success      200 {object} common.SuccessResponse{data=SplitOrderResponse} "Order split successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order ID format, request body or split"
+// @Failure      404 {object} common.ErrorResponse "Order not found"
+// @Failure      409 {object} common.ErrorResponse "Order is not open, already paid, or version conflict"
+// @Failure      500 {object} common.ErrorResponse "Failed to split order"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /orders/{id}/split [post]
+func (h *OrderHandler) SplitOrderHandler(c fiber.Ctx) error {
+	orderID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warnf("Invalid order ID format for split", "error", err, "id", orderID)
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid order ID format"})
+	}
+
+	var req SplitOrderRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("Cannot parse split order request body", "error", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data: map[string]interface{}{
+					"errors": ve.Errors,
+				},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+	}
+
+	splitResponse, err := h.orderService.SplitOrder(c.RequestCtx(), orderID, req)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order not found"})
+		}
+		if errors.Is(err, common.ErrOrderSplitInvalid) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		if errors.Is(err, common.ErrOrderNotModifiable) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order cannot be split", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrOrderConflict) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order version conflict", Error: err.Error()})
+		}
+		h.log.Errorf("Failed to split order in service", "error", err, "orderID", orderID)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to split order"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Order split successfully",
+		Data:    splitResponse,
+	})
+}
+
+// MergeOrdersHandler merges open orders into a target order
+// @Summary      Merge orders
+// @Description  Move all items (with options) of the source orders into the target order, re-check its promotion and cancel the sources. Only open orders without payments or a pending QRIS charge can be merged, target included (Roles: admin, manager, cashier)
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Target Order ID" Format(uuid)
+// @Param        request body MergeOrdersRequest true "Orders to merge"
+// @Success      200 {object} common.SuccessResponse{data=OrderDetailResponse} "Orders merged successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order ID format, request body or merge"
+// @Failure      404 {object} common.ErrorResponse "Order not found"
+// @Failure      409 {object} common.ErrorResponse "An order is not open, has payments or a pending QRIS charge, or version conflict"
+// @Failure      500 {object} common.ErrorResponse "Failed to merge orders"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /orders/{id}/merge [post]
+func (h *OrderHandler) MergeOrdersHandler(c fiber.Ctx) error {
+	orderID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warnf("Invalid order ID format for merge", "error", err, "id", orderID)
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid order ID format"})
+	}
+
+	var req MergeOrdersRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("Cannot parse merge orders request body", "error", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data: map[string]interface{}{
+					"errors": ve.Errors,
+				},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+	}
+
+	orderResponse, err := h.orderService.MergeOrders(c.RequestCtx(), orderID, req)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order not found"})
+		}
+		if errors.Is(err, common.ErrOrderMergeInvalid) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		if errors.Is(err, common.ErrOrderNotModifiable) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Orders cannot be merged", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrOrderConflict) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order version conflict", Error: err.Error()})
+		}
+		h.log.Errorf("Failed to merge orders in service", "error", err, "orderID", orderID)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to merge orders"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Orders merged successfully",
+		Data:    orderResponse,
+	})
+}
+
 // UpdateOrderItemsHandler updates items in an order
 // @Summary      Update items in an order
 // @Description  Update, add, or remove items in an existing open order (Roles: admin, manager, cashier)
@@ -219,9 +684,9 @@ func (h *OrderHandler) ConfirmManualPaymentHandler(c fiber.Ctx) error {
 // @Param        id path string true "Order ID" Format(uuid)
 // @Param        request body UpdateOrderItemsRequest true "Update order items"
 // @Success      200 {object} common.SuccessResponse{data=OrderDetailResponse} "Order items updated successfully"
-// @Failure      400 {object} common.ErrorResponse "Invalid order ID format or request body"
+// @Failure      400 {object} common.ErrorResponse "Invalid order ID format, request body, variant or options; data.errors lists each ModifierViolation"
 // @Failure      404 {object} common.ErrorResponse "Order not found"
-// @Failure      409 {object} common.ErrorResponse "Order version conflict"
+// @Failure      409 {object} common.ErrorResponse "Order can no longer change, version conflict or not enough ingredients"
 // @Failure      500 {object} common.ErrorResponse "Failed to update order items"
 // @x-roles      ["admin", "manager", "cashier"]
 // @Router       /orders/{id}/items [patch]
@@ -248,9 +713,29 @@ func (h *OrderHandler) UpdateOrderItemsHandler(c fiber.Ctx) error {
 
 	updatedOrder, err := h.orderService.UpdateOrderItems(c.RequestCtx(), orderID, req)
 	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order not found"})
+		}
+		if errors.Is(err, common.ErrOrderNotModifiable) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order cannot be modified", Error: err.Error()})
+		}
 		if errors.Is(err, common.ErrOrderConflict) {
 			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order has been updated by another user", Error: err.Error()})
 		}
+		if errors.Is(err, common.ErrInsufficientIngredient) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Not enough ingredients", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrVariantRequired) || errors.Is(err, common.ErrVariantNotFound) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		var me *ModifierError
+		if errors.As(err, &me) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: common.ErrInvalidModifiers.Error(),
+				Error:   me.Error(),
+				Data:    map[string]interface{}{"errors": me.Violations},
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to update order items"})
 	}
 
@@ -260,6 +745,80 @@ func (h *OrderHandler) UpdateOrderItemsHandler(c fiber.Ctx) error {
 	})
 }
 
+// ScanOrderItemHandler adds a scanned item to an order
+// @Summary      Scan an item into an order
+// @Description  Look up a scanned barcode or typed SKU and add the product or variant to an open order: one more on its existing line, or a new line with the default options (Roles: admin, manager, cashier)
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Order ID" Format(uuid)
+// @Param        request body ScanOrderItemRequest true "Scanned code"
+// @Success      200 {object} common.SuccessResponse{data=OrderDetailResponse} "Item added successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order ID format, request body, variant or options; data.errors lists each ModifierViolation"
+// @Failure      404 {object} common.ErrorResponse "Order not found or no product has this code"
+// @Failure      409 {object} common.ErrorResponse "Order is not open, version conflict or not enough stock or ingredients"
+// @Failure      500 {object} common.ErrorResponse "Failed to add scanned item"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /orders/{id}/scan [post]
+func (h *OrderHandler) ScanOrderItemHandler(c fiber.Ctx) error {
+	orderID, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid order ID format"})
+	}
+
+	var req ScanOrderItemRequest
+	if err := c.Bind().Body(&req); err != nil {
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data: map[string]interface{}{
+					"errors": ve.Errors,
+				},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+	}
+
+	updatedOrder, err := h.orderService.ScanOrderItem(c.RequestCtx(), orderID, req)
+	if err != nil {
+		if errors.Is(err, common.ErrProductCodeNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order not found"})
+		}
+		if errors.Is(err, common.ErrOrderNotModifiable) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		if errors.Is(err, common.ErrOrderConflict) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order has been updated by another user", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrInsufficientIngredient) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Not enough ingredients", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrVariantRequired) || errors.Is(err, common.ErrVariantNotFound) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		var me *ModifierError
+		if errors.As(err, &me) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: common.ErrInvalidModifiers.Error(),
+				Error:   me.Error(),
+				Data:    map[string]interface{}{"errors": me.Violations},
+			})
+		}
+		h.log.Error("Failed to add scanned item", "error", err, "orderID", orderID)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to add scanned item"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Item added successfully",
+		Data:    updatedOrder,
+	})
+}
+
 // CancelOrderHandler cancels an order
 // @Summary      Cancel an order
 // @Description  Cancel an existing order with a reason (Roles: admin, manager, cashier)
@@ -307,6 +866,9 @@ func (h *OrderHandler) CancelOrderHandler(c fiber.Ctx) error {
 		if errors.Is(err, common.ErrOrderNotCancellable) {
 			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order cannot be cancelled", Error: "Order might have been paid or already cancelled."})
 		}
+		if errors.Is(err, common.ErrOrderRefundRequired) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order cannot be cancelled", Error: err.Error()})
+		}
 		h.log.Errorf("Failed to cancel order in service", "error", err, "orderID", orderID)
 		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to cancel order"})
 	}
@@ -386,6 +948,28 @@ func (h *OrderHandler) ListOrdersHandler(c fiber.Ctx) error {
 	})
 }
 
+// GetCallingBoardHandler returns the queue numbers guests are waiting for
+// @Summary      Get calling board
+// @Description  Get today's queue numbers split into orders being prepared and orders ready for pickup. Live updates are published on the calling_board websocket topic (Roles: admin, manager, cashier)
+// @Tags         Orders
+// @Produce      json
+// @Success      200 {object} common.SuccessResponse{data=CallingBoardResponse} "Calling board retrieved successfully"
+// @Failure      500 {object} common.ErrorResponse "Failed to retrieve calling board"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /orders/calling-board [get]
+func (h *OrderHandler) GetCallingBoardHandler(c fiber.Ctx) error {
+	board, err := h.orderService.GetCallingBoard(c.RequestCtx())
+	if err != nil {
+		h.log.Errorf("Failed to get calling board from service", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve calling board"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Calling board retrieved successfully",
+		Data:    board,
+	})
+}
+
 // CreateOrderHandler creates a new order
 // @Summary      Create an order
 // @Description  Create a new order with multiple items (Roles: admin, manager, cashier)
@@ -394,7 +978,8 @@ func (h *OrderHandler) ListOrdersHandler(c fiber.Ctx) error {
 // @Produce      json
 // @Param        request body CreateOrderRequest true "Create order details"
 // @Success      201 {object} common.SuccessResponse{data=OrderDetailResponse} "Order created successfully"
-// @Failure      400 {object} common.ErrorResponse "Invalid request body"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body, variant or options; data.errors lists each ModifierViolation"
+// @Failure      409 {object} common.ErrorResponse "Not enough ingredients"
 // @Failure      500 {object} common.ErrorResponse "Failed to create order"
 // @x-roles      ["admin", "manager", "cashier"]
 // @Router       /orders [post]
@@ -418,6 +1003,20 @@ func (h *OrderHandler) CreateOrderHandler(c fiber.Ctx) error {
 
 	orderResponse, err := h.orderService.CreateOrder(c.RequestCtx(), req)
 	if err != nil {
+		if errors.Is(err, common.ErrInsufficientIngredient) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Not enough ingredients", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrVariantRequired) || errors.Is(err, common.ErrVariantNotFound) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		var me *ModifierError
+		if errors.As(err, &me) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: common.ErrInvalidModifiers.Error(),
+				Error:   me.Error(),
+				Data:    map[string]interface{}{"errors": me.Violations},
+			})
+		}
 		h.log.Errorf("Failed to create order in service", "error", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to create order"})
 	}
@@ -472,9 +1071,10 @@ func (h *OrderHandler) GetOrderHandler(c fiber.Ctx) error {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "Order ID" Format(uuid)
-// @Success      200 {object} common.SuccessResponse{data=MidtransPaymentResponse} "QRIS payment initiated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid order ID format or no active gateway payment method"
 // @Failure      400 {object} common.ErrorResponse "Invalid order ID format"
 // @Failure      404 {object} common.ErrorResponse "Order not found"
+// @Failure      409 {object} common.ErrorResponse "Order already paid, the applied promotion does not accept the payment gateway, or its limit is reached"
 // @Failure      500 {object} common.ErrorResponse "Failed to process payment"
 // @x-roles      ["admin", "manager", "cashier"]
 // @Router       /orders/{id}/pay/midtrans [post]
@@ -491,6 +1091,18 @@ func (h *OrderHandler) InitiateMidtransPaymentHandler(c fiber.Ctx) error {
 		if errors.Is(err, common.ErrNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order not found"})
 		}
+		if errors.Is(err, common.ErrPaymentMethodInvalid) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "No active payment gateway method is configured"})
+		}
+		if errors.Is(err, common.ErrOrderAlreadyPaid) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order already paid"})
+		}
+		if errors.Is(err, common.ErrPromotionPaymentMethod) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Payment method not allowed by promotion", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrPromotionLimitReached) || errors.Is(err, common.ErrPromotionNotApplicable) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Promotion cannot be redeemed", Error: err.Error()})
+		}
 		h.log.Errorf("Failed to process payment in service", "error", err, "orderID", orderID)
 		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to process payment: " + err.Error()})
 	}
@@ -532,7 +1144,7 @@ func (h *OrderHandler) MidtransNotificationHandler(c fiber.Ctx) error {
 
 // RefundOrderHandler godoc
 // @Summary      Refund a paid order
-// @Description  Refund a paid order by ID
+// @Description  Refund a paid order by ID. Without `items` every remaining unit is refunded and the order is closed; with `items` only the selected lines and quantities are refunded, priced pro-rata including discount and tax. Set `restock` to false to skip returning goods to stock
 // @Tags         orders
 // @Accept       json
 // @Produce      json
@@ -541,6 +1153,7 @@ func (h *OrderHandler) MidtransNotificationHandler(c fiber.Ctx) error {
 // @Success      200   {object}  common.SuccessResponse{data=OrderDetailResponse}
 // @Failure      400   {object}  common.ErrorResponse
 // @Failure      404   {object}  common.ErrorResponse
+// @Failure      409   {object}  common.ErrorResponse
 // @Failure      500   {object}  common.ErrorResponse
 // @Router       /orders/{id}/refund [post]
 func (h *OrderHandler) RefundOrderHandler(c fiber.Ctx) error {
@@ -567,9 +1180,15 @@ func (h *OrderHandler) RefundOrderHandler(c fiber.Ctx) error {
 		if errors.Is(err, common.ErrNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Order not found"})
 		}
-		if err.Error() == "only paid orders can be refunded" {
+		if errors.Is(err, common.ErrOrderNotRefundable) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order cannot be refunded", Error: err.Error()})
+		}
+		if errors.Is(err, common.ErrRefundInvalid) {
 			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
 		}
+		if errors.Is(err, common.ErrOrderNotModifiable) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: "Order has already been refunded or cancelled"})
+		}
 		h.log.Errorf("Failed to refund order", "error", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to refund order"})
 	}
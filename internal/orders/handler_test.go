@@ -187,6 +187,40 @@ func TestOrderHandler_GetOrderHandler(t *testing.T) {
 	})
 }
 
+// ====================== GetCallingBoardHandler ======================
+
+func TestOrderHandler_GetCallingBoardHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Get("/orders/calling-board", handler.GetCallingBoardHandler)
+
+		mockService.EXPECT().GetCallingBoard(gomock.Any()).Return(&orders.CallingBoardResponse{
+			BusinessDate: "2026-10-17",
+			Preparing:    []orders.CallingBoardEntry{{OrderID: uuid.New(), QueueNumber: "004"}},
+			Ready:        []orders.CallingBoardEntry{{OrderID: uuid.New(), QueueNumber: "003"}},
+		}, nil)
+
+		req := httptest.NewRequest("GET", "/orders/calling-board", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("InternalError", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Get("/orders/calling-board", handler.GetCallingBoardHandler)
+
+		mockService.EXPECT().GetCallingBoard(gomock.Any()).Return(nil, errors.New("db error"))
+
+		req := httptest.NewRequest("GET", "/orders/calling-board", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
 // ====================== InitiateMidtransPaymentHandler ======================
 
 func TestOrderHandler_InitiateMidtransPaymentHandler(t *testing.T) {
@@ -515,6 +549,23 @@ func TestOrderHandler_CancelOrderHandler(t *testing.T) {
 		assert.Equal(t, http.StatusConflict, resp.StatusCode)
 	})
 
+	t.Run("RefundRequired", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/cancel", handler.CancelOrderHandler)
+
+		reqBody := orders.CancelOrderRequest{CancellationReasonID: 1}
+		body, _ := json.Marshal(reqBody)
+
+		mockService.EXPECT().CancelOrder(gomock.Any(), orderID, gomock.Any()).Return(common.ErrOrderRefundRequired)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/cancel", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
 	t.Run("InternalError", func(t *testing.T) {
 		mockService, mockLogger, handler, app := setupHandlerTest(t)
 		allowAllHandlerLoggerCalls(mockLogger)
@@ -671,6 +722,24 @@ func TestOrderHandler_ConfirmManualPaymentHandler(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 
+	t.Run("PromotionRejectsPaymentMethod", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/pay/manual", handler.ConfirmManualPaymentHandler)
+
+		reqBody := orders.ConfirmManualPaymentRequest{PaymentMethodID: 1, CashReceived: 50000, Version: 1}
+		body, _ := json.Marshal(reqBody)
+
+		mockService.EXPECT().ConfirmManualPayment(gomock.Any(), orderID, gomock.Any()).
+			Return(nil, fmt.Errorf("%w: Cash cannot be used, the applied promotion only accepts QRIS", common.ErrPromotionPaymentMethod))
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/pay/manual", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		mockService, mockLogger, handler, app := setupHandlerTest(t)
 		allowAllHandlerLoggerCalls(mockLogger)
@@ -723,6 +792,284 @@ func TestOrderHandler_ConfirmManualPaymentHandler(t *testing.T) {
 	})
 }
 
+// ====================== AddOrderPaymentHandler ======================
+
+func TestOrderHandler_AddOrderPaymentHandler(t *testing.T) {
+	orderID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/payments", handler.AddOrderPaymentHandler)
+
+		reqBody := orders.AddOrderPaymentRequest{PaymentMethodID: 1, Amount: 20000, Version: 1}
+		body, _ := json.Marshal(reqBody)
+
+		mockService.EXPECT().AddOrderPayment(gomock.Any(), orderID, reqBody).Return(&orders.OrderDetailResponse{
+			ID:         orderID,
+			Status:     orders_repo.OrderStatusOpen,
+			AmountPaid: 20000,
+			BalanceDue: 10000,
+		}, nil)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/payments", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		_, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/payments", handler.AddOrderPaymentHandler)
+
+		// Missing amount
+		body, _ := json.Marshal(orders.AddOrderPaymentRequest{PaymentMethodID: 1, Version: 1})
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/payments", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("ExceedsBalance", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/payments", handler.AddOrderPaymentHandler)
+
+		body, _ := json.Marshal(orders.AddOrderPaymentRequest{PaymentMethodID: 4, Amount: 90000, Version: 1})
+
+		mockService.EXPECT().AddOrderPayment(gomock.Any(), orderID, gomock.Any()).Return(nil, common.ErrPaymentExceedsBalance)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/payments", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("AlreadyPaid", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/payments", handler.AddOrderPaymentHandler)
+
+		body, _ := json.Marshal(orders.AddOrderPaymentRequest{PaymentMethodID: 1, Amount: 10000, Version: 2})
+
+		mockService.EXPECT().AddOrderPayment(gomock.Any(), orderID, gomock.Any()).Return(nil, common.ErrOrderAlreadyPaid)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/payments", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
+// ====================== SplitOrderHandler ======================
+
+func TestOrderHandler_SplitOrderHandler(t *testing.T) {
+	orderID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/split", handler.SplitOrderHandler)
+
+		reqBody := orders.SplitOrderRequest{Ways: 3, Version: 2}
+		body, _ := json.Marshal(reqBody)
+
+		mockService.EXPECT().SplitOrder(gomock.Any(), orderID, reqBody).Return(&orders.SplitOrderResponse{
+			Parent:   orders.OrderDetailResponse{ID: orderID},
+			Children: []orders.OrderDetailResponse{{ID: uuid.New()}, {ID: uuid.New()}},
+		}, nil)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/split", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		_, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/split", handler.SplitOrderHandler)
+
+		// Neither parts nor ways
+		body, _ := json.Marshal(orders.SplitOrderRequest{Version: 2})
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/split", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("InvalidSplit", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/split", handler.SplitOrderHandler)
+
+		body, _ := json.Marshal(orders.SplitOrderRequest{Ways: 10, Version: 2})
+
+		mockService.EXPECT().SplitOrder(gomock.Any(), orderID, gomock.Any()).Return(nil, common.ErrOrderSplitInvalid)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/split", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("NotModifiable", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/split", handler.SplitOrderHandler)
+
+		body, _ := json.Marshal(orders.SplitOrderRequest{Ways: 2, Version: 2})
+
+		mockService.EXPECT().SplitOrder(gomock.Any(), orderID, gomock.Any()).Return(nil, common.ErrOrderNotModifiable)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/split", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestOrderHandler_MergeOrdersHandler(t *testing.T) {
+	orderID := uuid.New()
+	sourceID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/merge", handler.MergeOrdersHandler)
+
+		reqBody := orders.MergeOrdersRequest{SourceOrderIDs: []uuid.UUID{sourceID}, Version: 2}
+		body, _ := json.Marshal(reqBody)
+
+		mockService.EXPECT().MergeOrders(gomock.Any(), orderID, reqBody).Return(&orders.OrderDetailResponse{ID: orderID}, nil)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/merge", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		_, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/merge", handler.MergeOrdersHandler)
+
+		body, _ := json.Marshal(orders.MergeOrdersRequest{Version: 2})
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/merge", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("InvalidMerge", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/merge", handler.MergeOrdersHandler)
+
+		body, _ := json.Marshal(orders.MergeOrdersRequest{SourceOrderIDs: []uuid.UUID{orderID}, Version: 2})
+
+		mockService.EXPECT().MergeOrders(gomock.Any(), orderID, gomock.Any()).Return(nil, common.ErrOrderMergeInvalid)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/merge", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("NotModifiable", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/merge", handler.MergeOrdersHandler)
+
+		body, _ := json.Marshal(orders.MergeOrdersRequest{SourceOrderIDs: []uuid.UUID{sourceID}, Version: 2})
+
+		mockService.EXPECT().MergeOrders(gomock.Any(), orderID, gomock.Any()).Return(nil, common.ErrOrderNotModifiable)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/merge", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestOrderHandler_RefundOrderHandler(t *testing.T) {
+	orderID := uuid.New()
+	itemID := uuid.New()
+
+	t.Run("PartialSuccess", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/refund", handler.RefundOrderHandler)
+
+		restock := false
+		reqBody := orders.RefundOrderRequest{
+			Reason:  "Spoiled",
+			Items:   []orders.RefundOrderItem{{OrderItemID: itemID, Quantity: 1}},
+			Restock: &restock,
+		}
+		body, _ := json.Marshal(reqBody)
+
+		mockService.EXPECT().RefundOrder(gomock.Any(), orderID, reqBody).Return(&orders.OrderDetailResponse{ID: orderID, AmountRefunded: 11100}, nil)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/refund", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("InvalidRefund", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/refund", handler.RefundOrderHandler)
+
+		body, _ := json.Marshal(orders.RefundOrderRequest{
+			Reason: "Wrong item",
+			Items:  []orders.RefundOrderItem{{OrderItemID: itemID, Quantity: 5}},
+		})
+
+		mockService.EXPECT().RefundOrder(gomock.Any(), orderID, gomock.Any()).Return(nil, common.ErrRefundInvalid)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/refund", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("NotPaid", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/refund", handler.RefundOrderHandler)
+
+		body, _ := json.Marshal(orders.RefundOrderRequest{Reason: "Wrong item"})
+
+		mockService.EXPECT().RefundOrder(gomock.Any(), orderID, gomock.Any()).Return(nil, common.ErrOrderNotRefundable)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/refund", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
 // ====================== UpdateOperationalStatusHandler ======================
 
 func TestOrderHandler_UpdateOperationalStatusHandler(t *testing.T) {
@@ -844,6 +1191,57 @@ func TestOrderHandler_UpdateOperationalStatusHandler(t *testing.T) {
 
 // ====================== ApplyPromotionHandler ======================
 
+func TestOrderHandler_ReopenOrderHandler(t *testing.T) {
+	orderID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/reopen", handler.ReopenOrderHandler)
+
+		reqBody := orders.ReopenOrderRequest{Reason: "Guest wants to add a dish", Version: 3}
+		body, _ := json.Marshal(reqBody)
+
+		mockService.EXPECT().ReopenOrder(gomock.Any(), orderID, reqBody).Return(&orders.OrderDetailResponse{ID: orderID}, nil)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/reopen", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		_, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/reopen", handler.ReopenOrderHandler)
+
+		body, _ := json.Marshal(orders.ReopenOrderRequest{Version: 3})
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/reopen", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("InvalidTransition", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/reopen", handler.ReopenOrderHandler)
+
+		body, _ := json.Marshal(orders.ReopenOrderRequest{Reason: "Too late", Version: 3})
+
+		mockService.EXPECT().ReopenOrder(gomock.Any(), orderID, gomock.Any()).Return(nil, common.ErrInvalidStatusTransition)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/reopen", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
 func TestOrderHandler_ApplyPromotionHandler(t *testing.T) {
 	orderID := uuid.New()
 	promoID := uuid.New()
@@ -959,6 +1357,231 @@ func TestOrderHandler_ApplyPromotionHandler(t *testing.T) {
 	})
 }
 
+func TestOrderHandler_EvaluatePromotionsHandler(t *testing.T) {
+	orderID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService, _, handler, app := setupHandlerTest(t)
+		app.Get("/orders/:id/promotions/evaluate", handler.EvaluatePromotionsHandler)
+
+		mockService.EXPECT().EvaluatePromotions(gomock.Any(), orderID).Return(&orders.PromotionEvaluationResponse{
+			OrderID:        orderID,
+			DiscountAmount: 5000,
+		}, nil)
+
+		req := httptest.NewRequest("GET", "/orders/"+orderID.String()+"/promotions/evaluate", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		_, _, handler, app := setupHandlerTest(t)
+		app.Get("/orders/:id/promotions/evaluate", handler.EvaluatePromotionsHandler)
+
+		req := httptest.NewRequest("GET", "/orders/bad-id/promotions/evaluate", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("NotOpen", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Get("/orders/:id/promotions/evaluate", handler.EvaluatePromotionsHandler)
+
+		mockService.EXPECT().EvaluatePromotions(gomock.Any(), orderID).Return(nil, common.ErrOrderNotModifiable)
+
+		req := httptest.NewRequest("GET", "/orders/"+orderID.String()+"/promotions/evaluate", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Get("/orders/:id/promotions/evaluate", handler.EvaluatePromotionsHandler)
+
+		mockService.EXPECT().EvaluatePromotions(gomock.Any(), orderID).Return(nil, common.ErrNotFound)
+
+		req := httptest.NewRequest("GET", "/orders/"+orderID.String()+"/promotions/evaluate", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestOrderHandler_AutoApplyPromotionsHandler(t *testing.T) {
+	orderID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService, _, handler, app := setupHandlerTest(t)
+		app.Post("/orders/:id/promotions/auto-apply", handler.AutoApplyPromotionsHandler)
+
+		mockService.EXPECT().AutoApplyPromotions(gomock.Any(), orderID).Return(&orders.OrderDetailResponse{
+			ID:     orderID,
+			Status: orders_repo.OrderStatusOpen,
+		}, nil)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/promotions/auto-apply", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("NotModifiable", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/promotions/auto-apply", handler.AutoApplyPromotionsHandler)
+
+		mockService.EXPECT().AutoApplyPromotions(gomock.Any(), orderID).Return(nil, common.ErrOrderNotModifiable)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/promotions/auto-apply", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("InternalError", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/promotions/auto-apply", handler.AutoApplyPromotionsHandler)
+
+		mockService.EXPECT().AutoApplyPromotions(gomock.Any(), orderID).Return(nil, errors.New("db error"))
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/promotions/auto-apply", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestOrderHandler_RedeemPointsHandler(t *testing.T) {
+	orderID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService, _, handler, app := setupHandlerTest(t)
+		app.Post("/orders/:id/loyalty/redeem", handler.RedeemPointsHandler)
+
+		body, _ := json.Marshal(orders.RedeemPointsRequest{Points: 100})
+
+		mockService.EXPECT().RedeemPoints(gomock.Any(), orderID, orders.RedeemPointsRequest{Points: 100}).Return(&orders.OrderDetailResponse{
+			ID:      orderID,
+			Status:  orders_repo.OrderStatusOpen,
+			Loyalty: &orders.OrderLoyaltyResponse{PointsRedeemed: 100, DiscountAmount: 10000, Balance: 400},
+		}, nil)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/loyalty/redeem", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		_, _, handler, app := setupHandlerTest(t)
+		app.Post("/orders/:id/loyalty/redeem", handler.RedeemPointsHandler)
+
+		body, _ := json.Marshal(orders.RedeemPointsRequest{Points: 0})
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/loyalty/redeem", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("InsufficientPoints", func(t *testing.T) {
+		mockService, _, handler, app := setupHandlerTest(t)
+		app.Post("/orders/:id/loyalty/redeem", handler.RedeemPointsHandler)
+
+		body, _ := json.Marshal(orders.RedeemPointsRequest{Points: 500})
+
+		mockService.EXPECT().RedeemPoints(gomock.Any(), orderID, gomock.Any()).Return(nil, fmt.Errorf("%w: the balance is 120 points", common.ErrInsufficientPoints))
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/loyalty/redeem", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("NotRedeemable", func(t *testing.T) {
+		mockService, _, handler, app := setupHandlerTest(t)
+		app.Post("/orders/:id/loyalty/redeem", handler.RedeemPointsHandler)
+
+		body, _ := json.Marshal(orders.RedeemPointsRequest{Points: 100})
+
+		mockService.EXPECT().RedeemPoints(gomock.Any(), orderID, gomock.Any()).Return(nil, common.ErrPointsNotRedeemable)
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/loyalty/redeem", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("InternalError", func(t *testing.T) {
+		mockService, mockLogger, handler, app := setupHandlerTest(t)
+		allowAllHandlerLoggerCalls(mockLogger)
+		app.Post("/orders/:id/loyalty/redeem", handler.RedeemPointsHandler)
+
+		body, _ := json.Marshal(orders.RedeemPointsRequest{Points: 100})
+
+		mockService.EXPECT().RedeemPoints(gomock.Any(), orderID, gomock.Any()).Return(nil, errors.New("db error"))
+
+		req := httptest.NewRequest("POST", "/orders/"+orderID.String()+"/loyalty/redeem", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestOrderHandler_CancelPointsRedemptionHandler(t *testing.T) {
+	orderID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService, _, handler, app := setupHandlerTest(t)
+		app.Delete("/orders/:id/loyalty/redeem", handler.CancelPointsRedemptionHandler)
+
+		mockService.EXPECT().CancelPointsRedemption(gomock.Any(), orderID).Return(&orders.OrderDetailResponse{
+			ID:     orderID,
+			Status: orders_repo.OrderStatusOpen,
+		}, nil)
+
+		req := httptest.NewRequest("DELETE", "/orders/"+orderID.String()+"/loyalty/redeem", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("NothingRedeemed", func(t *testing.T) {
+		mockService, _, handler, app := setupHandlerTest(t)
+		app.Delete("/orders/:id/loyalty/redeem", handler.CancelPointsRedemptionHandler)
+
+		mockService.EXPECT().CancelPointsRedemption(gomock.Any(), orderID).Return(nil, common.ErrNotFound)
+
+		req := httptest.NewRequest("DELETE", "/orders/"+orderID.String()+"/loyalty/redeem", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("NotModifiable", func(t *testing.T) {
+		mockService, _, handler, app := setupHandlerTest(t)
+		app.Delete("/orders/:id/loyalty/redeem", handler.CancelPointsRedemptionHandler)
+
+		mockService.EXPECT().CancelPointsRedemption(gomock.Any(), orderID).Return(nil, common.ErrOrderNotModifiable)
+
+		req := httptest.NewRequest("DELETE", "/orders/"+orderID.String()+"/loyalty/redeem", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
 // ====================== Helpers ======================
 
 // Suppress unused imports
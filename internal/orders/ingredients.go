@@ -0,0 +1,177 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/pkg/utils"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// recipeBook holds the recipes of the products and options on an order. Products with a recipe are made
+// to order: their ingredients are drawn down instead of the product's own stock.
+type recipeBook struct {
+	products map[uuid.UUID][]orders_repo.ProductRecipeItem
+	options  map[uuid.UUID][]orders_repo.ProductOptionRecipeItem
+}
+
+// ingredientNeeds maps an ingredient to a quantity in its own unit.
+type ingredientNeeds map[uuid.UUID]int32
+
+func loadRecipeBook(ctx context.Context, qtx *orders_repo.Queries, productIDs, optionIDs []uuid.UUID) (*recipeBook, error) {
+	book := &recipeBook{
+		products: make(map[uuid.UUID][]orders_repo.ProductRecipeItem),
+		options:  make(map[uuid.UUID][]orders_repo.ProductOptionRecipeItem),
+	}
+	if len(productIDs) == 0 {
+		return book, nil
+	}
+
+	productRecipes, err := qtx.GetProductRecipes(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load product recipes: %w", err)
+	}
+	for _, item := range productRecipes {
+		book.products[item.ProductID] = append(book.products[item.ProductID], item)
+	}
+
+	if len(optionIDs) == 0 {
+		return book, nil
+	}
+	optionRecipes, err := qtx.GetOptionRecipes(ctx, optionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load option recipes: %w", err)
+	}
+	for _, item := range optionRecipes {
+		book.options[item.ProductOptionID] = append(book.options[item.ProductOptionID], item)
+	}
+	return book, nil
+}
+
+func (b *recipeBook) madeToOrder(productID uuid.UUID) bool {
+	return len(b.products[productID]) > 0
+}
+
+// add puts what quantity units of the product with these options consume into needs. An option line
+// with a replaced ingredient swaps it out of the product recipe, e.g. oat milk instead of milk.
+func (b *recipeBook) add(needs ingredientNeeds, productID uuid.UUID, optionIDs []uuid.UUID, quantity int32) {
+	perUnit := make(map[uuid.UUID]int32)
+	for _, item := range b.products[productID] {
+		perUnit[item.IngredientID] += item.Quantity
+	}
+	for _, optionID := range optionIDs {
+		for _, item := range b.options[optionID] {
+			if item.ReplacesIngredientID.Valid {
+				delete(perUnit, uuid.UUID(item.ReplacesIngredientID.Bytes))
+			}
+		}
+	}
+	for _, optionID := range optionIDs {
+		for _, item := range b.options[optionID] {
+			perUnit[item.IngredientID] += item.Quantity
+		}
+	}
+
+	for ingredientID, qty := range perUnit {
+		needs[ingredientID] += qty * quantity
+	}
+}
+
+// orderItemOptions groups the options chosen on each line of an order.
+func orderItemOptions(ctx context.Context, qtx *orders_repo.Queries, orderID uuid.UUID) (map[uuid.UUID][]uuid.UUID, []uuid.UUID, error) {
+	rows, err := qtx.ListOrderItemOptionIDs(ctx, orderID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load order item options: %w", err)
+	}
+	byItem := make(map[uuid.UUID][]uuid.UUID, len(rows))
+	optionIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		byItem[row.OrderItemID] = append(byItem[row.OrderItemID], row.ProductOptionID)
+		optionIDs = append(optionIDs, row.ProductOptionID)
+	}
+	return byItem, optionIDs, nil
+}
+
+// loadOrderRecipes loads the recipes behind lines already on an order, with the options each line was ordered with.
+func loadOrderRecipes(ctx context.Context, qtx *orders_repo.Queries, orderID uuid.UUID, items []orders_repo.OrderItem) (*recipeBook, map[uuid.UUID][]uuid.UUID, error) {
+	if len(items) == 0 {
+		book, err := loadRecipeBook(ctx, qtx, nil, nil)
+		return book, nil, err
+	}
+
+	itemOptions, optionIDs, err := orderItemOptions(ctx, qtx, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	productIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	book, err := loadRecipeBook(ctx, qtx, productIDs, optionIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return book, itemOptions, nil
+}
+
+// moveIngredients posts one ledger entry per ingredient. Sales take stock and fail with
+// ErrInsufficientIngredient when an ingredient runs short; every other change type gives stock back.
+func moveIngredients(ctx context.Context, qtx *orders_repo.Queries, needs ingredientNeeds, changeType orders_repo.StockChangeType, orderID uuid.UUID, createdBy pgtype.UUID, note string) error {
+	if len(needs) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(needs))
+	for id, qty := range needs {
+		if qty != 0 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ingredients, err := qtx.GetIngredientsForUpdate(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to lock ingredients: %w", err)
+	}
+
+	sale := changeType == orders_repo.StockChangeTypeSale
+	if sale && len(ingredients) != len(ids) {
+		return fmt.Errorf("%w: a recipe ingredient is no longer available", common.ErrInsufficientIngredient)
+	}
+
+	for _, ingredient := range ingredients {
+		change := needs[ingredient.ID]
+		if sale {
+			if ingredient.Stock < change {
+				return fmt.Errorf("%w: %s needs %d %s, %d left", common.ErrInsufficientIngredient, ingredient.Name, change, ingredient.Unit, ingredient.Stock)
+			}
+			change = -change
+		}
+
+		if err := qtx.AdjustIngredientStock(ctx, orders_repo.AdjustIngredientStockParams{
+			ChangeAmount: change,
+			ID:           ingredient.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to update ingredient stock: %w", err)
+		}
+
+		if _, err := qtx.CreateIngredientStockHistory(ctx, orders_repo.CreateIngredientStockHistoryParams{
+			IngredientID:  ingredient.ID,
+			ChangeAmount:  change,
+			PreviousStock: ingredient.Stock,
+			CurrentStock:  ingredient.Stock + change,
+			ChangeType:    changeType,
+			ReferenceID:   pgtype.UUID{Bytes: orderID, Valid: true},
+			Note:          utils.StringPtr(note),
+			CreatedBy:     createdBy,
+		}); err != nil {
+			return fmt.Errorf("failed to log ingredient stock history: %w", err)
+		}
+	}
+	return nil
+}
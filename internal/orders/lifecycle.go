@@ -0,0 +1,129 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// statusGuard is a precondition an order must meet before it may enter a status.
+type statusGuard int
+
+const (
+	guardNone statusGuard = iota
+	// guardFullyPaid requires the tenders to cover the net total.
+	guardFullyPaid
+	// guardRefunded requires everything that was paid to have been refunded.
+	guardRefunded
+	// guardUnpaid requires that no tender has been taken yet.
+	guardUnpaid
+)
+
+// transitionVia names the operation that is allowed to perform a transition.
+type transitionVia int
+
+const (
+	// viaOperational transitions go through the update-status endpoint.
+	viaOperational transitionVia = iota
+	// viaReopen transitions go through the manager-only reopen endpoint.
+	viaReopen
+	// viaCancel transitions go through the cancel and refund endpoints, which also return stock.
+	viaCancel
+)
+
+type statusTransition struct {
+	Guard statusGuard
+	Via   transitionVia
+}
+
+// orderLifecycle lists every allowed status change. Orders move open -> in_progress -> served -> paid,
+// where paid is the final status once the bill is settled; cancelled and paid orders never reopen.
+var orderLifecycle = map[orders_repo.OrderStatus]map[orders_repo.OrderStatus]statusTransition{
+	orders_repo.OrderStatusOpen: {
+		orders_repo.OrderStatusInProgress: {Guard: guardNone, Via: viaOperational},
+		orders_repo.OrderStatusPaid:       {Guard: guardFullyPaid, Via: viaOperational},
+		orders_repo.OrderStatusCancelled:  {Guard: guardRefunded, Via: viaCancel},
+	},
+	orders_repo.OrderStatusInProgress: {
+		orders_repo.OrderStatusServed:    {Guard: guardNone, Via: viaOperational},
+		orders_repo.OrderStatusPaid:      {Guard: guardFullyPaid, Via: viaOperational},
+		orders_repo.OrderStatusOpen:      {Guard: guardUnpaid, Via: viaReopen},
+		orders_repo.OrderStatusCancelled: {Guard: guardRefunded, Via: viaCancel},
+	},
+	orders_repo.OrderStatusServed: {
+		orders_repo.OrderStatusInProgress: {Guard: guardNone, Via: viaOperational},
+		orders_repo.OrderStatusPaid:       {Guard: guardFullyPaid, Via: viaOperational},
+		orders_repo.OrderStatusOpen:       {Guard: guardUnpaid, Via: viaReopen},
+		orders_repo.OrderStatusCancelled:  {Guard: guardRefunded, Via: viaCancel},
+	},
+	orders_repo.OrderStatusPaid: {
+		orders_repo.OrderStatusCancelled: {Guard: guardRefunded, Via: viaCancel},
+	},
+	orders_repo.OrderStatusCancelled: {},
+}
+
+// orderBalance is what has been paid and refunded on an order, as seen by the lifecycle guards.
+type orderBalance struct {
+	NetTotal int64
+	Paid     int64
+	Refunded int64
+}
+
+// newOrderBalance sums the tenders and refunds of an order. Orders paid before tenders were
+// recorded count as fully paid when they carry a payment method.
+func newOrderBalance(order orders_repo.Order, payments []orders_repo.OrderPayment, refunds []orders_repo.OrderRefund) orderBalance {
+	balance := orderBalance{NetTotal: order.NetTotal, Paid: sumTenderAmounts(payments)}
+	if balance.Paid == 0 && order.PaymentMethodID != nil {
+		balance.Paid = order.NetTotal
+	}
+	for _, r := range refunds {
+		balance.Refunded += r.Amount
+	}
+	return balance
+}
+
+// checkStatusTransition validates a status change against the lifecycle, the operation performing it and its guard.
+func checkStatusTransition(from, to orders_repo.OrderStatus, via transitionVia, balance orderBalance) error {
+	transition, ok := orderLifecycle[from][to]
+	if !ok || transition.Via != via {
+		return fmt.Errorf("%w: invalid status transition from '%s' to '%s'", common.ErrInvalidStatusTransition, from, to)
+	}
+
+	switch transition.Guard {
+	case guardFullyPaid:
+		if balance.Paid < balance.NetTotal {
+			return fmt.Errorf("%w: order has an outstanding balance of %d", common.ErrInvalidStatusTransition, balance.NetTotal-balance.Paid)
+		}
+	case guardRefunded:
+		if balance.Paid > balance.Refunded {
+			return common.ErrOrderRefundRequired
+		}
+	case guardUnpaid:
+		if balance.Paid > 0 {
+			return fmt.Errorf("%w: orders with payments cannot be reopened", common.ErrInvalidStatusTransition)
+		}
+	}
+	return nil
+}
+
+// recordStatusChange appends a transition to the order's status history. from is nil for a newly created order.
+func recordStatusChange(ctx context.Context, q orders_repo.Querier, orderID uuid.UUID, from *orders_repo.OrderStatus, to orders_repo.OrderStatus, note *string) error {
+	actorID, userIdOk := ctx.Value(common.UserIDKey).(uuid.UUID)
+
+	var fromStatus orders_repo.NullOrderStatus
+	if from != nil {
+		fromStatus = orders_repo.NullOrderStatus{OrderStatus: *from, Valid: true}
+	}
+
+	return q.CreateOrderStatusHistory(ctx, orders_repo.CreateOrderStatusHistoryParams{
+		OrderID:    orderID,
+		FromStatus: fromStatus,
+		ToStatus:   to,
+		ChangedBy:  pgtype.UUID{Bytes: actorID, Valid: userIdOk},
+		Note:       note,
+	})
+}
@@ -0,0 +1,550 @@
+package orders
+
+import (
+	activity_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/internal/settings"
+	ws "POS-kasir/internal/websocket"
+	"POS-kasir/pkg/utils"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RedeemPoints spends the customer's loyalty points as a discount on an open order, before it is paid. The
+// discount is taken after the order's promotions, and the order is repriced.
+func (s *OrderService) RedeemPoints(ctx context.Context, orderID uuid.UUID, req RedeemPointsRequest) (*OrderDetailResponse, error) {
+	var finalOrder orders_repo.GetOrderWithDetailsRow
+	var amount int64
+
+	taxRules, err := s.settingsService.GetTaxSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load tax settings", "error", err)
+		return nil, err
+	}
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := orders_repo.New(tx)
+		order, err := s.openUnpaidOrder(ctx, qtx, orderID)
+		if err != nil {
+			return err
+		}
+
+		rules, err := s.loyaltyRules(ctx, order.CustomerID)
+		if err != nil {
+			return err
+		}
+		if rules == nil {
+			return fmt.Errorf("%w: the loyalty program must be on and the order needs a customer", common.ErrPointsNotRedeemable)
+		}
+
+		pointsDiscount, err := orderPointsDiscount(ctx, qtx, order)
+		if err != nil {
+			return err
+		}
+		if !order.AppliedPromotionID.Valid && order.DiscountAmount > pointsDiscount {
+			return fmt.Errorf("%w: the order carries a discount from the bill it was split from", common.ErrPointsNotRedeemable)
+		}
+
+		amount = req.Points * rules.PointValue
+		if left := order.GrossTotal - order.DiscountAmount; amount > left {
+			return fmt.Errorf("%w: %d points are worth %d, more than the %d left to discount", common.ErrPointsNotRedeemable, req.Points, amount, left)
+		}
+
+		if err := redeemPoints(ctx, qtx, rules, order.ID, order.CustomerID.Bytes, req.Points, amount, orders_repo.LoyaltyRedemptionKindDiscount); err != nil {
+			return err
+		}
+		if _, _, err := s.reselectPromotions(ctx, tx, qtx, order, true, taxRules); err != nil {
+			return err
+		}
+
+		finalOrder, err = qtx.GetOrderWithDetails(ctx, orderID)
+		return err
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activity_repo.LogActionTypeUPDATE,
+		activity_repo.LogEntityTypeORDER,
+		orderID.String(),
+		map[string]interface{}{
+			"action": "redeem_points",
+			"points": req.Points,
+			"amount": amount,
+		},
+	)
+
+	if s.wsHub != nil {
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
+	}
+
+	return s.buildOrderDetailResponseFromQueryResult(ctx, finalOrder)
+}
+
+// CancelPointsRedemption gives the points redeemed as a discount on an open order back to the customer and
+// reprices the order without them.
+func (s *OrderService) CancelPointsRedemption(ctx context.Context, orderID uuid.UUID) (*OrderDetailResponse, error) {
+	var finalOrder orders_repo.GetOrderWithDetailsRow
+	var points int64
+
+	taxRules, err := s.settingsService.GetTaxSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load tax settings", "error", err)
+		return nil, err
+	}
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := orders_repo.New(tx)
+		order, err := s.openUnpaidOrder(ctx, qtx, orderID)
+		if err != nil {
+			return err
+		}
+		if !order.CustomerID.Valid {
+			return common.ErrNotFound
+		}
+
+		summary, err := qtx.GetOrderLoyaltySummary(ctx, pgtype.UUID{Bytes: orderID, Valid: true})
+		if err != nil {
+			return fmt.Errorf("failed to get order loyalty points: %w", err)
+		}
+		if summary.DiscountPoints <= 0 {
+			return common.ErrNotFound
+		}
+		points = summary.DiscountPoints
+
+		expiresAt, err := s.returnedPointsExpiry(ctx)
+		if err != nil {
+			return err
+		}
+		if err := returnPoints(ctx, qtx, orderID, order.CustomerID.Bytes, orders_repo.LoyaltyRedemptionKindDiscount, summary.DiscountPoints, summary.DiscountAmount, expiresAt, "Redemption Cancelled"); err != nil {
+			return err
+		}
+		if _, _, err := s.reselectPromotions(ctx, tx, qtx, order, true, taxRules); err != nil {
+			return err
+		}
+
+		finalOrder, err = qtx.GetOrderWithDetails(ctx, orderID)
+		return err
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activity_repo.LogActionTypeUPDATE,
+		activity_repo.LogEntityTypeORDER,
+		orderID.String(),
+		map[string]interface{}{
+			"action": "cancel_points_redemption",
+			"points": points,
+		},
+	)
+
+	if s.wsHub != nil {
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
+	}
+
+	return s.buildOrderDetailResponseFromQueryResult(ctx, finalOrder)
+}
+
+// loyaltyRules returns the rules an order earns and redeems points by, or nil when the loyalty program is off
+// or the order has no customer to credit.
+func (s *OrderService) loyaltyRules(ctx context.Context, customerID pgtype.UUID) (*settings.LoyaltySettingsResponse, error) {
+	if !customerID.Valid {
+		return nil, nil
+	}
+	rules, err := s.settingsService.GetLoyaltySettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loyalty settings: %w", err)
+	}
+	if !rules.Enabled {
+		return nil, nil
+	}
+	return rules, nil
+}
+
+// pointsExpiry is when points credited at now lapse; they never do when the policy has no expiry.
+func pointsExpiry(rules *settings.LoyaltySettingsResponse, now time.Time) pgtype.Timestamptz {
+	if rules.ExpiryDays <= 0 {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: now.AddDate(0, 0, rules.ExpiryDays), Valid: true}
+}
+
+// earnablePoints is what a settled order earns: one point per SpendPerPoint of each line's net value, weighted
+// by the highest multiplier among the categories of the line's product, plus the bonus of each promotion applied
+// to the order. The part of the bill paid with points earns nothing.
+func earnablePoints(rules *settings.LoyaltySettingsResponse, items []orders_repo.OrderItem, categories map[uuid.UUID][]int32, netTotal, paidWithPoints int64, promotionIDs []uuid.UUID) int64 {
+	var weighted int64
+	for _, item := range items {
+		multiplier := 1.0
+		if ids := categories[item.ProductID]; len(ids) > 0 {
+			multiplier = 0
+			for _, id := range ids {
+				multiplier = max(multiplier, rules.CategoryMultiplier(id))
+			}
+		}
+		weighted += int64(math.Round(float64(item.NetSubtotal) * multiplier))
+	}
+	if paidWithPoints > 0 && netTotal > 0 {
+		weighted = weighted * (netTotal - min(paidWithPoints, netTotal)) / netTotal
+	}
+
+	var points int64
+	if rules.SpendPerPoint > 0 {
+		points = weighted / rules.SpendPerPoint
+	}
+	for _, id := range promotionIDs {
+		points += rules.PromotionBonus(id)
+	}
+	return points
+}
+
+// earnPoints credits the customer of a settling order with the points it earns, as a lot that lapses by the
+// expiry policy. An order earns once, so settling it again after it was reopened does not add more.
+func (s *OrderService) earnPoints(ctx context.Context, q orders_repo.Querier, order orders_repo.Order) error {
+	rules, err := s.loyaltyRules(ctx, order.CustomerID)
+	if err != nil || rules == nil {
+		return err
+	}
+
+	summary, err := q.GetOrderLoyaltySummary(ctx, pgtype.UUID{Bytes: order.ID, Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to get order loyalty points: %w", err)
+	}
+	if summary.PointsEarned > 0 {
+		return nil
+	}
+
+	items, err := q.GetOrderItemsByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order items: %w", err)
+	}
+	productIDs := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
+	}
+	categories := make(map[uuid.UUID][]int32)
+	if len(rules.CategoryMultipliers) > 0 && len(productIDs) > 0 {
+		rows, err := q.GetProductCategoryIDs(ctx, productIDs)
+		if err != nil {
+			return fmt.Errorf("failed to get product categories: %w", err)
+		}
+		for _, row := range rows {
+			categories[row.ProductID] = append(categories[row.ProductID], row.CategoryID)
+		}
+	}
+
+	applied, err := orderPromotions(ctx, q, order.ID, order.AppliedPromotionID)
+	if err != nil {
+		return err
+	}
+	promotionIDs := make([]uuid.UUID, len(applied))
+	for i, p := range applied {
+		promotionIDs[i] = p.PromotionID
+	}
+
+	points := earnablePoints(rules, items, categories, order.NetTotal, summary.TenderAmount, promotionIDs)
+	if points <= 0 {
+		return nil
+	}
+
+	actorID, userIdOk := ctx.Value(common.UserIDKey).(uuid.UUID)
+	if _, err := q.CreateLoyaltyPointEntry(ctx, orders_repo.CreateLoyaltyPointEntryParams{
+		CustomerID:  order.CustomerID.Bytes,
+		OrderID:     pgtype.UUID{Bytes: order.ID, Valid: true},
+		EntryType:   orders_repo.LoyaltyEntryTypeEarn,
+		Points:      int32(points),
+		Remaining:   int32(points),
+		ExpiresAt:   pointsExpiry(rules, time.Now()),
+		Description: utils.StringPtr("Order Paid"),
+		CreatedBy:   pgtype.UUID{Bytes: actorID, Valid: userIdOk},
+	}); err != nil {
+		return fmt.Errorf("failed to record earned points: %w", err)
+	}
+	return nil
+}
+
+// consumeLots takes points from a customer's lots, the soonest to lapse first, and returns how many it took;
+// it takes fewer when the lots do not hold enough.
+func consumeLots(ctx context.Context, q orders_repo.Querier, customerID uuid.UUID, points int64) (int64, error) {
+	lots, err := q.ListCustomerPointLots(ctx, customerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get customer points: %w", err)
+	}
+	var taken int64
+	for _, lot := range lots {
+		if taken == points {
+			break
+		}
+		take := min(int64(lot.Remaining), points-taken)
+		if err := q.ConsumePointLot(ctx, orders_repo.ConsumePointLotParams{ID: lot.ID, Remaining: int32(take)}); err != nil {
+			return 0, fmt.Errorf("failed to consume points: %w", err)
+		}
+		taken += take
+	}
+	return taken, nil
+}
+
+// redeemPoints spends a customer's points on an order, as a discount or a tender worth amount. The customer is
+// locked first so concurrent redemptions cannot spend the same points twice.
+func redeemPoints(ctx context.Context, q orders_repo.Querier, rules *settings.LoyaltySettingsResponse, orderID uuid.UUID, customerID uuid.UUID, points, amount int64, kind orders_repo.LoyaltyRedemptionKind) error {
+	if points < rules.MinRedeemPoints {
+		return fmt.Errorf("%w: at least %d points must be redeemed at once", common.ErrPointsNotRedeemable, rules.MinRedeemPoints)
+	}
+	if _, err := q.LockCustomer(ctx, customerID); err != nil {
+		return fmt.Errorf("failed to lock customer: %w", err)
+	}
+
+	taken, err := consumeLots(ctx, q, customerID, points)
+	if err != nil {
+		return err
+	}
+	if taken < points {
+		return fmt.Errorf("%w: the balance is %d points", common.ErrInsufficientPoints, taken)
+	}
+
+	description := "Redeemed as discount"
+	if kind == orders_repo.LoyaltyRedemptionKindTender {
+		description = "Redeemed as payment"
+	}
+	actorID, userIdOk := ctx.Value(common.UserIDKey).(uuid.UUID)
+	if _, err := q.CreateLoyaltyPointEntry(ctx, orders_repo.CreateLoyaltyPointEntryParams{
+		CustomerID:  customerID,
+		OrderID:     pgtype.UUID{Bytes: orderID, Valid: true},
+		EntryType:   orders_repo.LoyaltyEntryTypeRedeem,
+		RedeemedAs:  orders_repo.NullLoyaltyRedemptionKind{LoyaltyRedemptionKind: kind, Valid: true},
+		Points:      int32(-points),
+		Amount:      amount,
+		Description: &description,
+		CreatedBy:   pgtype.UUID{Bytes: actorID, Valid: userIdOk},
+	}); err != nil {
+		return fmt.Errorf("failed to record redeemed points: %w", err)
+	}
+	return nil
+}
+
+// payWithPoints redeems the points a tender of amount is worth. Only whole points are redeemed, so the amount
+// has to be a multiple of the point value.
+func (s *OrderService) payWithPoints(ctx context.Context, q orders_repo.Querier, order orders_repo.Order, amount int64) error {
+	rules, err := s.loyaltyRules(ctx, order.CustomerID)
+	if err != nil {
+		return err
+	}
+	if rules == nil {
+		return fmt.Errorf("%w: the loyalty program must be on and the order needs a customer", common.ErrPointsNotRedeemable)
+	}
+	if amount%rules.PointValue != 0 {
+		return fmt.Errorf("%w: the amount must be a multiple of the point value %d", common.ErrPointsNotRedeemable, rules.PointValue)
+	}
+	return redeemPoints(ctx, q, rules, order.ID, order.CustomerID.Bytes, amount/rules.PointValue, amount, orders_repo.LoyaltyRedemptionKindTender)
+}
+
+// orderPointsDiscount is the part of an order's discount paid with loyalty points. Orders without a customer
+// cannot redeem points, so they are not queried.
+func orderPointsDiscount(ctx context.Context, q orders_repo.Querier, order orders_repo.Order) (int64, error) {
+	if !order.CustomerID.Valid {
+		return 0, nil
+	}
+	summary, err := q.GetOrderLoyaltySummary(ctx, pgtype.UUID{Bytes: order.ID, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get order loyalty points: %w", err)
+	}
+	return summary.DiscountAmount, nil
+}
+
+// withPointsDiscount adds a points discount to the promotions' line discounts, spread over what each line has
+// left after the promotions.
+func withPointsDiscount(lines map[uuid.UUID]int64, items []orders_repo.OrderItem, discount int64) map[uuid.UUID]int64 {
+	if discount <= 0 {
+		return lines
+	}
+	weights := make([]int64, len(items))
+	for i, item := range items {
+		weights[i] = max(item.Subtotal-lines[item.ID], 0)
+	}
+	combined := make(map[uuid.UUID]int64, len(items))
+	for id, share := range lines {
+		combined[id] = share
+	}
+	for i, share := range prorate(discount, weights) {
+		combined[items[i].ID] += share
+	}
+	return combined
+}
+
+// takeBackPoints reverses points an order earned, as far as the customer still holds them. Like a redemption
+// it locks the customer first, so the two cannot take from the same lots.
+func takeBackPoints(ctx context.Context, q orders_repo.Querier, orderID uuid.UUID, customerID uuid.UUID, points int64, note string) error {
+	if points <= 0 {
+		return nil
+	}
+	if _, err := q.LockCustomer(ctx, customerID); err != nil {
+		return fmt.Errorf("failed to lock customer: %w", err)
+	}
+	taken, err := consumeLots(ctx, q, customerID, points)
+	if err != nil || taken == 0 {
+		return err
+	}
+	actorID, userIdOk := ctx.Value(common.UserIDKey).(uuid.UUID)
+	if _, err := q.CreateLoyaltyPointEntry(ctx, orders_repo.CreateLoyaltyPointEntryParams{
+		CustomerID:  customerID,
+		OrderID:     pgtype.UUID{Bytes: orderID, Valid: true},
+		EntryType:   orders_repo.LoyaltyEntryTypeReversal,
+		Points:      int32(-taken),
+		Description: &note,
+		CreatedBy:   pgtype.UUID{Bytes: actorID, Valid: userIdOk},
+	}); err != nil {
+		return fmt.Errorf("failed to reverse earned points: %w", err)
+	}
+	return nil
+}
+
+// returnPoints gives redeemed points back to the customer as a new lot, together with the amount they were
+// redeemed for.
+func returnPoints(ctx context.Context, q orders_repo.Querier, orderID uuid.UUID, customerID uuid.UUID, kind orders_repo.LoyaltyRedemptionKind, points, amount int64, expiresAt pgtype.Timestamptz, note string) error {
+	if points <= 0 {
+		return nil
+	}
+	actorID, userIdOk := ctx.Value(common.UserIDKey).(uuid.UUID)
+	if _, err := q.CreateLoyaltyPointEntry(ctx, orders_repo.CreateLoyaltyPointEntryParams{
+		CustomerID:  customerID,
+		OrderID:     pgtype.UUID{Bytes: orderID, Valid: true},
+		EntryType:   orders_repo.LoyaltyEntryTypeReversal,
+		RedeemedAs:  orders_repo.NullLoyaltyRedemptionKind{LoyaltyRedemptionKind: kind, Valid: true},
+		Points:      int32(points),
+		Remaining:   int32(points),
+		Amount:      amount,
+		ExpiresAt:   expiresAt,
+		Description: &note,
+		CreatedBy:   pgtype.UUID{Bytes: actorID, Valid: userIdOk},
+	}); err != nil {
+		return fmt.Errorf("failed to return redeemed points: %w", err)
+	}
+	return nil
+}
+
+// returnedPointsExpiry is when returned points lapse. They are returned even after the program was turned off,
+// so the policy is read regardless.
+func (s *OrderService) returnedPointsExpiry(ctx context.Context) (pgtype.Timestamptz, error) {
+	rules, err := s.settingsService.GetLoyaltySettings(ctx)
+	if err != nil {
+		return pgtype.Timestamptz{}, fmt.Errorf("failed to get loyalty settings: %w", err)
+	}
+	return pointsExpiry(rules, time.Now()), nil
+}
+
+// releasePoints undoes what a cancelled or fully refunded order still holds in the loyalty ledger: the points
+// it earned are taken back and the points spent on it, as a discount or a tender, returned.
+func (s *OrderService) releasePoints(ctx context.Context, q orders_repo.Querier, orderID uuid.UUID, customerID pgtype.UUID, note string) error {
+	if !customerID.Valid {
+		return nil
+	}
+	summary, err := q.GetOrderLoyaltySummary(ctx, pgtype.UUID{Bytes: orderID, Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to get order loyalty points: %w", err)
+	}
+
+	if err := takeBackPoints(ctx, q, orderID, customerID.Bytes, summary.PointsEarned-summary.PointsEarnedReversed, note); err != nil {
+		return err
+	}
+	if summary.DiscountPoints <= 0 && summary.TenderPoints <= 0 {
+		return nil
+	}
+	expiresAt, err := s.returnedPointsExpiry(ctx)
+	if err != nil {
+		return err
+	}
+	if err := returnPoints(ctx, q, orderID, customerID.Bytes, orders_repo.LoyaltyRedemptionKindDiscount, summary.DiscountPoints, summary.DiscountAmount, expiresAt, note); err != nil {
+		return err
+	}
+	return returnPoints(ctx, q, orderID, customerID.Bytes, orders_repo.LoyaltyRedemptionKindTender, summary.TenderPoints, summary.TenderAmount, expiresAt, note)
+}
+
+// refundPoints settles a refund with the loyalty ledger. A full refund releases everything the order holds. A
+// partial one returns the points behind the part refunded through the points tender, and takes back the points
+// earned in proportion to what has been refunded so far; points spent on a discount come back with the full refund.
+func (s *OrderService) refundPoints(ctx context.Context, q orders_repo.Querier, order orders_repo.Order, allocations []refundAllocation, refunded int64, full bool) error {
+	if !order.CustomerID.Valid {
+		return nil
+	}
+	if full {
+		return s.releasePoints(ctx, q, order.ID, order.CustomerID, "Order Refunded")
+	}
+
+	summary, err := q.GetOrderLoyaltySummary(ctx, pgtype.UUID{Bytes: order.ID, Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to get order loyalty points: %w", err)
+	}
+
+	if summary.TenderAmount > 0 {
+		var throughPoints int64
+		for _, allocation := range allocations {
+			if allocation.PaymentMethodID == nil || allocation.Amount == 0 {
+				continue
+			}
+			method, err := q.GetPaymentMethodByID(ctx, *allocation.PaymentMethodID)
+			if err != nil {
+				return fmt.Errorf("failed to get payment method: %w", err)
+			}
+			if method.Kind == orders_repo.PaymentMethodKindLoyaltyPoints {
+				throughPoints += allocation.Amount
+			}
+		}
+		if throughPoints > 0 {
+			throughPoints = min(throughPoints, summary.TenderAmount)
+			expiresAt, err := s.returnedPointsExpiry(ctx)
+			if err != nil {
+				return err
+			}
+			points := summary.TenderPoints * throughPoints / summary.TenderAmount
+			if err := returnPoints(ctx, q, order.ID, order.CustomerID.Bytes, orders_repo.LoyaltyRedemptionKindTender, points, throughPoints, expiresAt, "Order Refunded"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if summary.PointsEarned == 0 || order.NetTotal <= 0 {
+		return nil
+	}
+	due := summary.PointsEarned*min(refunded, order.NetTotal)/order.NetTotal - summary.PointsEarnedReversed
+	return takeBackPoints(ctx, q, order.ID, order.CustomerID.Bytes, due, "Order Refunded")
+}
+
+// orderLoyaltyResponse summarises what an order did to its customer's points. Orders without a customer have
+// none, so they are not queried.
+func (s *OrderService) orderLoyaltyResponse(ctx context.Context, orderID uuid.UUID, customerID pgtype.UUID) (*OrderLoyaltyResponse, error) {
+	if !customerID.Valid {
+		return nil, nil
+	}
+	summary, err := s.ordersRepo.GetOrderLoyaltySummary(ctx, pgtype.UUID{Bytes: orderID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.ordersRepo.GetCustomerPointsBalance(ctx, customerID.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &OrderLoyaltyResponse{
+		PointsEarned:   summary.PointsEarned - summary.PointsEarnedReversed,
+		PointsRedeemed: summary.DiscountPoints + summary.TenderPoints,
+		DiscountAmount: summary.DiscountAmount,
+		Balance:        balance,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package orders
+
+import (
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/internal/settings"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEarnablePoints(t *testing.T) {
+	coffee, pastry := uuid.New(), uuid.New()
+	bonusPromo := uuid.New()
+	rules := &settings.LoyaltySettingsResponse{
+		Enabled:             true,
+		SpendPerPoint:       10000,
+		PointValue:          100,
+		CategoryMultipliers: []settings.LoyaltyCategoryMultiplier{{CategoryID: 1, Multiplier: 2}, {CategoryID: 3, Multiplier: 0}},
+		PromotionBonuses:    []settings.LoyaltyPromotionBonus{{PromotionID: bonusPromo, BonusPoints: 50}},
+	}
+	items := []orders_repo.OrderItem{
+		{ProductID: coffee, NetSubtotal: 45000},
+		{ProductID: pastry, NetSubtotal: 30000},
+	}
+
+	t.Run("PerAmountSpent", func(t *testing.T) {
+		// Partial points are dropped: 75000 earns 7
+		assert.Equal(t, int64(7), earnablePoints(rules, items, nil, 75000, 0, nil))
+	})
+
+	t.Run("CategoryMultiplier", func(t *testing.T) {
+		// Coffee counts double, pastry once: 90000 + 30000
+		categories := map[uuid.UUID][]int32{coffee: {1}, pastry: {2}}
+		assert.Equal(t, int64(12), earnablePoints(rules, items, categories, 75000, 0, nil))
+
+		// A product in several categories takes the highest multiplier; a zero multiplier earns nothing
+		categories = map[uuid.UUID][]int32{coffee: {3, 1}, pastry: {3}}
+		assert.Equal(t, int64(9), earnablePoints(rules, items, categories, 75000, 0, nil))
+	})
+
+	t.Run("PaidWithPoints", func(t *testing.T) {
+		// A third of the bill was paid with points, so only two thirds earn
+		assert.Equal(t, int64(5), earnablePoints(rules, items, nil, 75000, 25000, nil))
+		assert.Equal(t, int64(0), earnablePoints(rules, items, nil, 75000, 75000, nil))
+	})
+
+	t.Run("PromotionBonus", func(t *testing.T) {
+		assert.Equal(t, int64(57), earnablePoints(rules, items, nil, 75000, 0, []uuid.UUID{bonusPromo, uuid.New()}))
+	})
+}
+
+func TestWithPointsDiscount(t *testing.T) {
+	latte, croissant := uuid.New(), uuid.New()
+	items := []orders_repo.OrderItem{
+		{ID: latte, Subtotal: 30000},
+		{ID: croissant, Subtotal: 20000},
+	}
+	promotions := map[uuid.UUID]int64{latte: 10000}
+
+	// The points discount is spread over what the promotions left: 20000 and 20000
+	lines := withPointsDiscount(promotions, items, 8000)
+	assert.Equal(t, map[uuid.UUID]int64{latte: 14000, croissant: 4000}, lines)
+	assert.Equal(t, map[uuid.UUID]int64{latte: 10000}, promotions)
+
+	assert.Equal(t, promotions, withPointsDiscount(promotions, items, 0))
+}
+
+func TestTakeBackPoints(t *testing.T) {
+	mockPgx, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPgx.Close()
+
+	orderID, customerID, lotID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	// The customer is locked before the lots are read, as a redemption does
+	mockPgx.ExpectQuery("SELECT id AS customer_id FROM customers").
+		WithArgs(customerID).
+		WillReturnRows(pgxmock.NewRows([]string{"customer_id"}).AddRow(customerID))
+	mockPgx.ExpectQuery("FROM loyalty_point_entries").
+		WithArgs(customerID).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "customer_id", "order_id", "entry_type", "redeemed_as", "points", "remaining", "amount", "expires_at", "description", "created_by", "created_at",
+		}).AddRow(
+			lotID, customerID, pgtype.UUID{}, orders_repo.LoyaltyEntryTypeEarn, orders_repo.NullLoyaltyRedemptionKind{}, int32(10), int32(4), int64(0), pgtype.Timestamptz{}, nil, pgtype.UUID{}, pgtype.Timestamptz{Time: now, Valid: true},
+		))
+
+	// Only the 4 points the customer still holds are taken back
+	mockPgx.ExpectExec("UPDATE loyalty_point_entries").
+		WithArgs(lotID, int32(4)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mockPgx.ExpectQuery("INSERT INTO loyalty_point_entries").
+		WithArgs(customerID, pgtype.UUID{Bytes: orderID, Valid: true}, orders_repo.LoyaltyEntryTypeReversal, orders_repo.NullLoyaltyRedemptionKind{}, int32(-4), int32(0), int64(0), pgtype.Timestamptz{}, pgxmock.AnyArg(), pgtype.UUID{}).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "customer_id", "order_id", "entry_type", "redeemed_as", "points", "remaining", "amount", "expires_at", "description", "created_by", "created_at",
+		}).AddRow(
+			uuid.New(), customerID, pgtype.UUID{Bytes: orderID, Valid: true}, orders_repo.LoyaltyEntryTypeReversal, orders_repo.NullLoyaltyRedemptionKind{}, int32(-4), int32(0), int64(0), pgtype.Timestamptz{}, nil, pgtype.UUID{}, pgtype.Timestamptz{Time: now, Valid: true},
+		))
+
+	err = takeBackPoints(context.Background(), orders_repo.New(mockPgx), orderID, customerID, 10, "Order Cancelled")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mockPgx.ExpectationsWereMet())
+}
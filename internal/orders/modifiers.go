@@ -0,0 +1,202 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Codes of a ModifierViolation.
+const (
+	ModifierTooFew          = "too_few"
+	ModifierTooMany         = "too_many"
+	ModifierUnknownOption   = "unknown_option"
+	ModifierDuplicateOption = "duplicate_option"
+)
+
+// ModifierViolation is one way an order line breaks the rules of its options. Line is the index of the item
+// in the request, so the POS can show the message next to it.
+type ModifierViolation struct {
+	Line      int        `json:"line"`
+	ProductID uuid.UUID  `json:"product_id"`
+	Code      string     `json:"code"`
+	GroupID   *uuid.UUID `json:"group_id,omitempty"`
+	GroupName string     `json:"group_name,omitempty"`
+	OptionID  *uuid.UUID `json:"option_id,omitempty"`
+	MinSelect *int32     `json:"min_select,omitempty"`
+	MaxSelect *int32     `json:"max_select,omitempty"`
+	Selected  int        `json:"selected"`
+	Message   string     `json:"message"`
+}
+
+// ModifierError carries every violation found on an order, so all lines can be fixed in one go.
+// It matches common.ErrInvalidModifiers.
+type ModifierError struct {
+	Violations []ModifierViolation
+}
+
+func (e *ModifierError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message)
+	}
+	return common.ErrInvalidModifiers.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ModifierError) Is(target error) bool {
+	return target == common.ErrInvalidModifiers
+}
+
+// modifierRules holds the active options of the products on an order, each with the modifier group it
+// belongs to, if any.
+type modifierRules struct {
+	options   map[uuid.UUID]orders_repo.GetOrderableOptionsRow
+	byProduct map[uuid.UUID][]orders_repo.GetOrderableOptionsRow
+}
+
+func loadModifierRules(ctx context.Context, qtx *orders_repo.Queries, productIDs []uuid.UUID) (*modifierRules, error) {
+	rules := &modifierRules{
+		options:   make(map[uuid.UUID]orders_repo.GetOrderableOptionsRow),
+		byProduct: make(map[uuid.UUID][]orders_repo.GetOrderableOptionsRow),
+	}
+	if len(productIDs) == 0 {
+		return rules, nil
+	}
+
+	rows, err := qtx.GetOrderableOptions(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch options: %w", err)
+	}
+	for _, row := range rows {
+		rules.options[row.ID] = row
+		rules.byProduct[row.ProductID] = append(rules.byProduct[row.ProductID], row)
+	}
+	return rules, nil
+}
+
+// selectOptions resolves the options of one order line. Groups the line picks nothing from get their default
+// options; the selection is then checked against each group's min and max.
+func (r *modifierRules) selectOptions(line int, productID uuid.UUID, requested []CreateOrderItemOptionRequest) ([]orders_repo.GetOrderableOptionsRow, []ModifierViolation) {
+	var (
+		selected   []orders_repo.GetOrderableOptionsRow
+		violations []ModifierViolation
+	)
+	seen := make(map[uuid.UUID]bool, len(requested))
+	counts := make(map[uuid.UUID]int)
+
+	for _, req := range requested {
+		optionID := req.ProductOptionID
+		opt, ok := r.options[optionID]
+		if !ok || opt.ProductID != productID {
+			violations = append(violations, ModifierViolation{
+				Line:      line,
+				ProductID: productID,
+				Code:      ModifierUnknownOption,
+				OptionID:  &optionID,
+				Message:   fmt.Sprintf("option %s is not available for this product", optionID),
+			})
+			continue
+		}
+		if seen[optionID] {
+			violations = append(violations, ModifierViolation{
+				Line:      line,
+				ProductID: productID,
+				Code:      ModifierDuplicateOption,
+				OptionID:  &optionID,
+				Message:   fmt.Sprintf("%s is chosen more than once", opt.Name),
+			})
+			continue
+		}
+		seen[optionID] = true
+		selected = append(selected, opt)
+		if opt.GroupID.Valid {
+			counts[opt.GroupID.Bytes]++
+		}
+	}
+
+	// Groups in the order their options are listed, each with its defaults
+	var groups []orders_repo.GetOrderableOptionsRow
+	defaults := make(map[uuid.UUID][]orders_repo.GetOrderableOptionsRow)
+	for _, opt := range r.byProduct[productID] {
+		if !opt.GroupID.Valid {
+			continue
+		}
+		groupID := opt.GroupID.Bytes
+		if _, ok := defaults[groupID]; !ok {
+			groups = append(groups, opt)
+			defaults[groupID] = nil
+		}
+		if opt.IsDefault {
+			defaults[groupID] = append(defaults[groupID], opt)
+		}
+	}
+
+	for _, group := range groups {
+		groupID := uuid.UUID(group.GroupID.Bytes)
+		if counts[groupID] == 0 {
+			selected = append(selected, defaults[groupID]...)
+			counts[groupID] = len(defaults[groupID])
+		}
+
+		count := counts[groupID]
+		minSelect := int32(0)
+		if group.MinSelect != nil {
+			minSelect = *group.MinSelect
+		}
+		name := ""
+		if group.GroupName != nil {
+			name = *group.GroupName
+		}
+
+		violation := ModifierViolation{
+			Line:      line,
+			ProductID: productID,
+			GroupID:   &groupID,
+			GroupName: name,
+			MinSelect: group.MinSelect,
+			MaxSelect: group.MaxSelect,
+			Selected:  count,
+		}
+		switch {
+		case count < int(minSelect):
+			violation.Code = ModifierTooFew
+			violation.Message = fmt.Sprintf("%s: choose at least %d", name, minSelect)
+		case group.MaxSelect != nil && count > int(*group.MaxSelect):
+			violation.Code = ModifierTooMany
+			violation.Message = fmt.Sprintf("%s: choose at most %d", name, *group.MaxSelect)
+		default:
+			continue
+		}
+		violations = append(violations, violation)
+	}
+
+	return selected, violations
+}
+
+// selectedOptionIDs lists the IDs of the options picked for a line.
+func selectedOptionIDs(options []orders_repo.GetOrderableOptionsRow) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(options))
+	for _, opt := range options {
+		ids = append(ids, opt.ID)
+	}
+	return ids
+}
+
+// createItemOptions records the options picked for a line added to an order, at their current price.
+func createItemOptions(ctx context.Context, qtx *orders_repo.Queries, itemID uuid.UUID, options []orders_repo.GetOrderableOptionsRow) error {
+	for _, opt := range options {
+		_, err := qtx.CreateOrderItemOption(ctx, orders_repo.CreateOrderItemOptionParams{
+			OrderItemID:     itemID,
+			ProductOptionID: opt.ID,
+			PriceAtSale:     opt.AdditionalPrice,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add option %s: %w", opt.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package orders
+
+import (
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/internal/settings"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// pricingLine is a single order line as seen by the tax engine.
+type pricingLine struct {
+	ProductID uuid.UUID
+	Subtotal  int64
+}
+
+// orderTotals is the result of applying the tax and service charge rules to an order.
+type orderTotals struct {
+	GrossTotal          int64
+	DiscountAmount      int64
+	TaxAmount           int64
+	ServiceChargeAmount int64
+	NetTotal            int64
+	TaxRate             float64
+	ServiceChargeRate   float64
+	TaxInclusive        bool
+}
+
+// calculateOrderTotals computes gross, tax, service charge and net totals for a set of lines.
+// The order discount is allocated pro-rata over the lines so exempt items never carry tax.
+// All arithmetic is done in integer basis points to keep rounding deterministic.
+func calculateOrderTotals(orderType orders_repo.OrderType, lines []pricingLine, exemptProducts map[uuid.UUID]bool, discountAmount int64, rules *settings.TaxSettingsResponse) orderTotals {
+	var grossTotal, taxableGross int64
+	for _, line := range lines {
+		grossTotal += line.Subtotal
+		if !exemptProducts[line.ProductID] {
+			taxableGross += line.Subtotal
+		}
+	}
+
+	if discountAmount > grossTotal {
+		discountAmount = grossTotal
+	}
+	if discountAmount < 0 {
+		discountAmount = 0
+	}
+	netSales := grossTotal - discountAmount
+
+	totals := orderTotals{
+		GrossTotal:     grossTotal,
+		DiscountAmount: discountAmount,
+		NetTotal:       netSales,
+	}
+	if rules == nil || grossTotal == 0 {
+		return totals
+	}
+
+	mode := rules.RoundingMode
+	taxBP := rateToBasisPoints(rules.TaxRate)
+	serviceBP := rateToBasisPoints(rules.ServiceChargeRate)
+	taxApplies := rules.TaxEnabled && taxBP > 0 && containsOrderType(rules.TaxOrderTypes, orderType)
+	serviceApplies := rules.ServiceChargeEnabled && serviceBP > 0 && containsOrderType(rules.ServiceChargeOrderTypes, orderType)
+
+	taxableSales := divRound(taxableGross*netSales, grossTotal, "round")
+
+	var includedTax int64
+	serviceBase := netSales
+	if taxApplies && rules.TaxInclusive {
+		includedTax = divRound(taxableSales*taxBP, 10000+taxBP, mode)
+		serviceBase = netSales - includedTax
+	}
+
+	if serviceApplies {
+		totals.ServiceChargeAmount = divRound(serviceBase*serviceBP, 10000, mode)
+		totals.ServiceChargeRate = rules.ServiceChargeRate
+	}
+
+	if taxApplies {
+		var serviceTax int64
+		if rules.ServiceChargeTaxable {
+			serviceTax = divRound(totals.ServiceChargeAmount*taxBP, 10000, mode)
+		}
+		if rules.TaxInclusive {
+			totals.TaxAmount = includedTax + serviceTax
+			totals.NetTotal = netSales + totals.ServiceChargeAmount + serviceTax
+		} else {
+			totals.TaxAmount = divRound(taxableSales*taxBP, 10000, mode) + serviceTax
+			totals.NetTotal = netSales + totals.ServiceChargeAmount + totals.TaxAmount
+		}
+		totals.TaxRate = rules.TaxRate
+		totals.TaxInclusive = rules.TaxInclusive
+	} else {
+		totals.NetTotal = netSales + totals.ServiceChargeAmount
+	}
+
+	return totals
+}
+
+func rateToBasisPoints(rate float64) int64 {
+	if rate <= 0 {
+		return 0
+	}
+	return int64(math.Round(rate * 100))
+}
+
+func containsOrderType(types []string, orderType orders_repo.OrderType) bool {
+	for _, t := range types {
+		if t == string(orderType) {
+			return true
+		}
+	}
+	return false
+}
+
+// divRound divides two non-negative integers using the configured rounding mode.
+func divRound(numerator, denominator int64, mode string) int64 {
+	if denominator == 0 {
+		return 0
+	}
+	switch mode {
+	case "floor":
+		return numerator / denominator
+	case "ceil":
+		return (numerator + denominator - 1) / denominator
+	default:
+		return (numerator + denominator/2) / denominator
+	}
+}
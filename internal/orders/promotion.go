@@ -0,0 +1,298 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/internal/promotions"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// evaluatePromotion runs a promotion's rule checks against the given order lines and returns the
+// discount it grants, allocated over the lines. Rule failures wrap common.ErrPromotionNotApplicable.
+// ALLOWED_PAYMENT_METHOD rules are left to checkPromotionPaymentMethod, since the tender is only known
+// at payment time.
+func (s *OrderService) evaluatePromotion(ctx context.Context, q orders_repo.Querier, promo orders_repo.Promotion, orderType orders_repo.OrderType, grossTotal int64, orderItems []orders_repo.OrderItem) (promotionDiscount, error) {
+	now := time.Now()
+	if !promo.IsActive {
+		return promotionDiscount{}, fmt.Errorf("%w: promotion is not active", common.ErrPromotionNotApplicable)
+	}
+	if now.Before(promo.StartDate.Time) {
+		return promotionDiscount{}, fmt.Errorf("%w: promotion has not started yet", common.ErrPromotionNotApplicable)
+	}
+	if now.After(promo.EndDate.Time) {
+		return promotionDiscount{}, fmt.Errorf("%w: promotion has expired", common.ErrPromotionNotApplicable)
+	}
+
+	window, err := s.matchPromotionSchedule(ctx, q, promo.ID, now)
+	if err != nil {
+		return promotionDiscount{}, err
+	}
+
+	rules, err := q.GetPromotionRules(ctx, promo.ID)
+	if err != nil {
+		return promotionDiscount{}, fmt.Errorf("failed to get promotion rules: %w", err)
+	}
+
+	productCategoryCache := make(map[uuid.UUID][]int)
+	getProductCategories := func(id uuid.UUID) []int {
+		if cats, ok := productCategoryCache[id]; ok {
+			return cats
+		}
+		var cats []int
+		rows, err := q.GetProductCategoryIDs(ctx, []uuid.UUID{id})
+		if err == nil {
+			for _, row := range rows {
+				cats = append(cats, int(row.CategoryID))
+			}
+		}
+		productCategoryCache[id] = cats
+		return cats
+	}
+
+	var allowedOrderTypes []string
+	for _, rule := range rules {
+		switch rule.RuleType {
+		case orders_repo.PromotionRuleTypeMINIMUMORDERAMOUNT:
+			minAmount, err := strconv.ParseInt(rule.RuleValue, 10, 64)
+			if err != nil {
+				s.log.Warnf("Invalid rule value for MINIMUM_ORDER_AMOUNT: %s", rule.RuleValue)
+				continue
+			}
+			if grossTotal < minAmount {
+				return promotionDiscount{}, fmt.Errorf("%w: minimum order amount not met (min: %d)", common.ErrPromotionNotApplicable, minAmount)
+			}
+
+		case orders_repo.PromotionRuleTypeREQUIREDPRODUCT:
+			requiredProductID, err := uuid.Parse(rule.RuleValue)
+			if err != nil {
+				s.log.Warnf("Invalid rule value for REQUIRED_PRODUCT: %s", rule.RuleValue)
+				continue
+			}
+			found := false
+			for _, item := range orderItems {
+				if item.ProductID == requiredProductID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return promotionDiscount{}, fmt.Errorf("%w: required product not found in order", common.ErrPromotionNotApplicable)
+			}
+
+		case orders_repo.PromotionRuleTypeREQUIREDCATEGORY:
+			requiredCategoryID, err := strconv.Atoi(rule.RuleValue)
+			if err != nil {
+				s.log.Warnf("Invalid rule value for REQUIRED_CATEGORY: %s", rule.RuleValue)
+				continue
+			}
+			found := false
+			for _, item := range orderItems {
+				cats := getProductCategories(item.ProductID)
+				for _, cid := range cats {
+					if cid == requiredCategoryID {
+						found = true
+						break
+					}
+				}
+				if found {
+					break
+				}
+			}
+			if !found {
+				return promotionDiscount{}, fmt.Errorf("%w: required category item not found in order", common.ErrPromotionNotApplicable)
+			}
+
+		case orders_repo.PromotionRuleTypeALLOWEDORDERTYPE:
+			allowedOrderTypes = append(allowedOrderTypes, splitRuleValues(rule.RuleValue)...)
+		}
+	}
+
+	if len(allowedOrderTypes) > 0 && !containsFold(allowedOrderTypes, string(orderType)) {
+		return promotionDiscount{}, fmt.Errorf("%w: promotion is only valid for %s orders", common.ErrPromotionNotApplicable, strings.Join(allowedOrderTypes, ", "))
+	}
+
+	eligible := orderItems
+	if promo.Scope == orders_repo.PromotionScopeITEM {
+		targets, err := q.GetPromotionTargets(ctx, promo.ID)
+		if err != nil {
+			return promotionDiscount{}, fmt.Errorf("failed to get promotion targets: %w", err)
+		}
+
+		eligible = nil
+		for _, item := range orderItems {
+			isEligible := false
+			for _, target := range targets {
+				if target.TargetType == orders_repo.PromotionTargetTypePRODUCT {
+					if target.TargetID == item.ProductID.String() {
+						isEligible = true
+						break
+					}
+				} else if target.TargetType == orders_repo.PromotionTargetTypeCATEGORY {
+					targetCatID, _ := strconv.Atoi(target.TargetID)
+					cats := getProductCategories(item.ProductID)
+					for _, cid := range cats {
+						if cid == targetCatID {
+							isEligible = true
+							break
+						}
+					}
+				}
+			}
+			if isEligible {
+				eligible = append(eligible, item)
+			}
+		}
+	}
+
+	var tiers []orders_repo.PromotionTier
+	if promo.DiscountType == orders_repo.DiscountTypeTiered {
+		tiers, err = q.GetPromotionTiers(ctx, promo.ID)
+		if err != nil {
+			return promotionDiscount{}, fmt.Errorf("failed to get promotion tiers: %w", err)
+		}
+	}
+
+	discount, err := computeDiscount(promo, tiers, eligible)
+	if err != nil {
+		return promotionDiscount{}, err
+	}
+	if window != nil {
+		label := window.String()
+		discount.Window = &label
+	}
+	return discount, nil
+}
+
+// matchPromotionSchedule checks a promotion's recurring windows and excluded dates against the store's clock
+// and returns the window now falls in, or nil when the promotion runs all day.
+func (s *OrderService) matchPromotionSchedule(ctx context.Context, q orders_repo.Querier, promotionID uuid.UUID, now time.Time) (*promotions.Window, error) {
+	schedules, err := q.GetPromotionSchedules(ctx, promotionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotion schedules: %w", err)
+	}
+	excludedDates, err := q.GetPromotionExcludedDates(ctx, promotionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotion excluded dates: %w", err)
+	}
+	if len(schedules) == 0 && len(excludedDates) == 0 {
+		return nil, nil
+	}
+
+	operational, err := s.settingsService.GetOperationalSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load operational settings: %w", err)
+	}
+
+	windows := make([]promotions.Window, 0, len(schedules))
+	for _, sch := range schedules {
+		windows = append(windows, promotions.NewWindow(sch.DaysOfWeek, sch.StartTime, sch.EndTime))
+	}
+	dates := make([]pgtype.Date, 0, len(excludedDates))
+	for _, d := range excludedDates {
+		dates = append(dates, d.ExcludedDate)
+	}
+	return promotions.NewSchedule(windows, dates).Match(now.In(operational.Location()))
+}
+
+// checkPromotionPaymentMethod enforces the ALLOWED_PAYMENT_METHOD rules of a promotion applied to an order
+// for a tender. Rule values name payment methods by ID or by name; several rules widen the allow list.
+func checkPromotionPaymentMethod(ctx context.Context, q orders_repo.Querier, promotionID uuid.UUID, method orders_repo.PaymentMethod) error {
+	rules, err := q.GetPromotionRules(ctx, promotionID)
+	if err != nil {
+		return fmt.Errorf("failed to get promotion rules: %w", err)
+	}
+
+	var allowed []string
+	for _, rule := range rules {
+		if rule.RuleType == orders_repo.PromotionRuleTypeALLOWEDPAYMENTMETHOD {
+			allowed = append(allowed, splitRuleValues(rule.RuleValue)...)
+		}
+	}
+
+	if len(allowed) == 0 || containsFold(allowed, strconv.Itoa(int(method.ID))) || containsFold(allowed, method.Name) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s cannot be used, the applied promotion only accepts %s", common.ErrPromotionPaymentMethod, method.Name, strings.Join(allowed, ", "))
+}
+
+// enforcePromotionPaymentMethod checks a tender against every promotion applied to the order. When the
+// tender is not allowed and removePromotion is set, the promotions that reject it are stripped and the order
+// repriced with the others instead of failing; the returned order then carries the new totals and version.
+func (s *OrderService) enforcePromotionPaymentMethod(ctx context.Context, tx pgx.Tx, qtx *orders_repo.Queries, order orders_repo.Order, method orders_repo.PaymentMethod, version int32, removePromotion bool) (orders_repo.Order, bool, error) {
+	applied, err := orderPromotions(ctx, qtx, order.ID, order.AppliedPromotionID)
+	if err != nil {
+		return order, false, err
+	}
+
+	var kept []pinnedPromotion
+	var rejection error
+	for _, p := range applied {
+		err := checkPromotionPaymentMethod(ctx, qtx, p.PromotionID, method)
+		if err == nil {
+			kept = append(kept, pinnedPromotion{PromotionID: p.PromotionID, CodeID: p.PromotionCodeID, Auto: p.AutoApplied})
+			continue
+		}
+		if !errors.Is(err, common.ErrPromotionPaymentMethod) {
+			return order, false, err
+		}
+		if rejection == nil {
+			rejection = err
+		}
+	}
+	if rejection == nil {
+		return order, false, nil
+	}
+	if !removePromotion {
+		return order, false, rejection
+	}
+
+	taxRules, err := s.settingsService.GetTaxSettings(ctx)
+	if err != nil {
+		return order, false, err
+	}
+
+	orderItems, err := qtx.GetOrderItemsByOrderID(ctx, order.ID)
+	if err != nil {
+		return order, false, fmt.Errorf("failed to get order items: %w", err)
+	}
+
+	// No auto-apply promotions are picked here, they might not accept the tender either
+	remaining, _, err := s.promotionCandidates(ctx, qtx, order, orderItems, kept, nil)
+	if err != nil {
+		return order, false, err
+	}
+	updated, err := s.savePromotions(ctx, qtx, order, orderItems, combinePromotions(selectPromotions(remaining, nil), orderItems), true, version, taxRules)
+	if err != nil {
+		return order, false, err
+	}
+	return updated, true, nil
+}
+
+// splitRuleValues reads a rule value that may list several comma separated values.
+func splitRuleValues(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,273 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/pkg/utils"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// promotionDiscount is what a promotion grants an order: the total and each line's share of it, by order item ID.
+// The shares are stored on order_items.discount_amount so per-line revenue and profit net out the discount.
+// Window labels the schedule window the promotion was granted in, nil for promotions without windows.
+type promotionDiscount struct {
+	Amount int64
+	Lines  map[uuid.UUID]int64
+	Window *string
+}
+
+// computeDiscount works out the discount a promotion's mechanics grant on its eligible lines. Buy-x-get-y and
+// bundle discounts land on the units they are taken off; amounts off a total are spread over the eligible lines.
+func computeDiscount(promo orders_repo.Promotion, tiers []orders_repo.PromotionTier, eligible []orders_repo.OrderItem) (promotionDiscount, error) {
+	var base int64
+	for _, item := range eligible {
+		base += item.Subtotal
+	}
+
+	value := utils.NumericToInt64(promo.DiscountValue)
+	var lines map[uuid.UUID]int64
+
+	switch promo.DiscountType {
+	case orders_repo.DiscountTypeBuyXGetY:
+		buy, get := int32Value(promo.BuyQuantity), int32Value(promo.GetQuantity)
+		lines = buyXGetYDiscount(eligible, buy, get, value)
+		if len(lines) == 0 {
+			return promotionDiscount{}, fmt.Errorf("%w: buy %d to get %d, not enough eligible items in the order", common.ErrPromotionNotApplicable, buy, get)
+		}
+
+	case orders_repo.DiscountTypeBundlePrice:
+		size := int32Value(promo.BundleQuantity)
+		var complete bool
+		lines, complete = bundlePriceDiscount(eligible, size, value)
+		if !complete {
+			return promotionDiscount{}, fmt.Errorf("%w: the bundle price needs %d eligible items", common.ErrPromotionNotApplicable, size)
+		}
+
+	case orders_repo.DiscountTypeTiered:
+		tier, ok := tierFor(tiers, base)
+		if !ok {
+			minAmount := int64(0)
+			if len(tiers) > 0 {
+				minAmount = tiers[0].MinAmount
+			}
+			return promotionDiscount{}, fmt.Errorf("%w: minimum order amount not met (min: %d)", common.ErrPromotionNotApplicable, minAmount)
+		}
+		lines = spreadDiscount(amountOff(tier.DiscountType, utils.NumericToInt64(tier.DiscountValue), base), eligible)
+
+	default:
+		lines = spreadDiscount(amountOff(promo.DiscountType, value, base), eligible)
+	}
+
+	discount := promotionDiscount{Lines: lines}
+	for _, share := range lines {
+		discount.Amount += share
+	}
+
+	if maxDisc := utils.NumericToInt64(promo.MaxDiscountAmount); maxDisc > 0 && discount.Amount > maxDisc {
+		ids := make([]uuid.UUID, 0, len(lines))
+		for _, item := range eligible {
+			if _, ok := lines[item.ID]; ok {
+				ids = append(ids, item.ID)
+			}
+		}
+		weights := make([]int64, len(ids))
+		for i, id := range ids {
+			weights[i] = lines[id]
+		}
+		capped := make(map[uuid.UUID]int64, len(ids))
+		for i, share := range prorate(maxDisc, weights) {
+			capped[ids[i]] = share
+		}
+		discount = promotionDiscount{Amount: maxDisc, Lines: capped}
+	}
+
+	return discount, nil
+}
+
+// amountOff is a percentage or fixed amount taken off base, never more than base.
+func amountOff(discountType orders_repo.DiscountType, value, base int64) int64 {
+	amount := value
+	if discountType == orders_repo.DiscountTypePercentage {
+		amount = base * value / 100
+	}
+	return min(amount, base)
+}
+
+// tierFor picks the highest tier whose threshold base reaches; tiers are ordered by min_amount.
+func tierFor(tiers []orders_repo.PromotionTier, base int64) (orders_repo.PromotionTier, bool) {
+	var picked orders_repo.PromotionTier
+	found := false
+	for _, tier := range tiers {
+		if base >= tier.MinAmount {
+			picked, found = tier, true
+		}
+	}
+	return picked, found
+}
+
+// saleUnit is one unit of an order line, the granularity buy-x-get-y and bundle discounts work on.
+type saleUnit struct {
+	ItemID uuid.UUID
+	Price  int64
+}
+
+// saleUnits breaks lines into single units, most expensive first. A line's rounding remainder goes to its
+// first unit so the units of a line add up to its subtotal.
+func saleUnits(items []orders_repo.OrderItem) []saleUnit {
+	var units []saleUnit
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			continue
+		}
+		qty := int64(item.Quantity)
+		price := item.Subtotal / qty
+		for i := int64(0); i < qty; i++ {
+			unit := saleUnit{ItemID: item.ID, Price: price}
+			if i == 0 {
+				unit.Price += item.Subtotal % qty
+			}
+			units = append(units, unit)
+		}
+	}
+	sort.SliceStable(units, func(i, j int) bool { return units[i].Price > units[j].Price })
+	return units
+}
+
+// buyXGetYDiscount takes percent off the get cheapest units of every buy+get eligible units. Grouping the
+// units from the most expensive down means the discounted units are never pricier than the ones paid for.
+func buyXGetYDiscount(items []orders_repo.OrderItem, buy, get int32, percent int64) map[uuid.UUID]int64 {
+	group := int(buy + get)
+	if buy <= 0 || get <= 0 {
+		return nil
+	}
+	units := saleUnits(items)
+	lines := make(map[uuid.UUID]int64)
+	for start := 0; start+group <= len(units); start += group {
+		for _, unit := range units[start+int(buy) : start+group] {
+			lines[unit.ItemID] += unit.Price * percent / 100
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return lines
+}
+
+// bundlePriceDiscount sells every size eligible units for price, bundling the most expensive units first. The
+// saving of a bundle is spread over its units by price. It reports whether at least one bundle was complete.
+func bundlePriceDiscount(items []orders_repo.OrderItem, size int32, price int64) (map[uuid.UUID]int64, bool) {
+	if size <= 0 {
+		return nil, false
+	}
+	units := saleUnits(items)
+	lines := make(map[uuid.UUID]int64)
+	complete := false
+	for start := 0; start+int(size) <= len(units); start += int(size) {
+		complete = true
+		bundle := units[start : start+int(size)]
+		weights := make([]int64, len(bundle))
+		var total int64
+		for i, unit := range bundle {
+			weights[i] = unit.Price
+			total += unit.Price
+		}
+		if total <= price {
+			continue
+		}
+		for i, share := range prorate(total-price, weights) {
+			lines[bundle[i].ItemID] += share
+		}
+	}
+	return lines, complete
+}
+
+// spreadDiscount allocates an amount off a total over the lines in proportion to their subtotals.
+func spreadDiscount(amount int64, items []orders_repo.OrderItem) map[uuid.UUID]int64 {
+	weights := make([]int64, len(items))
+	for i, item := range items {
+		weights[i] = item.Subtotal
+	}
+	lines := make(map[uuid.UUID]int64, len(items))
+	for i, share := range prorate(amount, weights) {
+		if share > 0 {
+			lines[items[i].ID] += share
+		}
+	}
+	return lines
+}
+
+// prorate splits amount over weights by the largest remainder method, so the shares add up to amount and no
+// share exceeds its weight as long as amount does not exceed their sum.
+func prorate(amount int64, weights []int64) []int64 {
+	shares := make([]int64, len(weights))
+	var total int64
+	for _, w := range weights {
+		total += w
+	}
+	if amount <= 0 || total <= 0 {
+		return shares
+	}
+	amount = min(amount, total)
+
+	remainders := make([]int64, len(weights))
+	left := amount
+	for i, w := range weights {
+		shares[i] = amount * w / total
+		remainders[i] = amount * w % total
+		left -= shares[i]
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for _, i := range order {
+		if left == 0 {
+			break
+		}
+		if remainders[i] > 0 {
+			shares[i]++
+			left--
+		}
+	}
+	return shares
+}
+
+// saveLineDiscounts stores each line's share of the order discount; lines without a share are reset to none.
+func saveLineDiscounts(ctx context.Context, qtx *orders_repo.Queries, orderID uuid.UUID, items []orders_repo.OrderItem, lines map[uuid.UUID]int64) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(items))
+	amounts := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+		amounts[i] = lines[item.ID]
+	}
+	if err := qtx.SetOrderItemDiscounts(ctx, orders_repo.SetOrderItemDiscountsParams{Ids: ids, DiscountAmounts: amounts, OrderID: orderID}); err != nil {
+		return fmt.Errorf("failed to allocate discount to order items: %w", err)
+	}
+	return nil
+}
+
+// spreadOrderDiscount re-allocates a discount that was already granted over the order's current lines, in
+// proportion to their subtotals, after lines were added, changed or moved.
+func spreadOrderDiscount(ctx context.Context, qtx *orders_repo.Queries, orderID uuid.UUID, discount int64) error {
+	items, err := qtx.GetOrderItemsByOrderID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order items: %w", err)
+	}
+	return saveLineDiscounts(ctx, qtx, orderID, items, spreadDiscount(discount, items))
+}
+
+func int32Value(v *int32) int32 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
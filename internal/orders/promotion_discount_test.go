@@ -0,0 +1,128 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/pkg/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeDiscount(t *testing.T) {
+	int32Ptr := func(v int32) *int32 { return &v }
+	line := func(qty int32, price int64) orders_repo.OrderItem {
+		return orders_repo.OrderItem{ID: uuid.New(), Quantity: qty, PriceAtSale: price, Subtotal: int64(qty) * price}
+	}
+	sum := func(lines map[uuid.UUID]int64) int64 {
+		var total int64
+		for _, share := range lines {
+			total += share
+		}
+		return total
+	}
+
+	t.Run("BuyTwoGetOneFree", func(t *testing.T) {
+		promo := orders_repo.Promotion{
+			DiscountType:  orders_repo.DiscountTypeBuyXGetY,
+			DiscountValue: utils.Int64ToNumeric(100),
+			BuyQuantity:   int32Ptr(2),
+			GetQuantity:   int32Ptr(1),
+		}
+		latte, croissant := line(2, 30000), line(1, 20000)
+
+		// The cheapest of the three units goes free
+		discount, err := computeDiscount(promo, nil, []orders_repo.OrderItem{latte, croissant})
+		require.NoError(t, err)
+		assert.Equal(t, int64(20000), discount.Amount)
+		assert.Equal(t, map[uuid.UUID]int64{croissant.ID: 20000}, discount.Lines)
+
+		_, err = computeDiscount(promo, nil, []orders_repo.OrderItem{latte})
+		assert.ErrorIs(t, err, common.ErrPromotionNotApplicable)
+	})
+
+	t.Run("SecondCupHalfPrice", func(t *testing.T) {
+		promo := orders_repo.Promotion{
+			DiscountType:  orders_repo.DiscountTypeBuyXGetY,
+			DiscountValue: utils.Int64ToNumeric(50),
+			BuyQuantity:   int32Ptr(1),
+			GetQuantity:   int32Ptr(1),
+		}
+		cups := line(3, 25000)
+
+		// Only one pair is complete, the third cup is paid in full
+		discount, err := computeDiscount(promo, nil, []orders_repo.OrderItem{cups})
+		require.NoError(t, err)
+		assert.Equal(t, int64(12500), discount.Amount)
+		assert.Equal(t, int64(12500), discount.Lines[cups.ID])
+	})
+
+	t.Run("BundlePrice", func(t *testing.T) {
+		promo := orders_repo.Promotion{
+			DiscountType:   orders_repo.DiscountTypeBundlePrice,
+			DiscountValue:  utils.Int64ToNumeric(50000),
+			BundleQuantity: int32Ptr(3),
+		}
+		croissant, danish, muffin := line(1, 22000), line(1, 20000), line(2, 15000)
+
+		// The three priciest pastries (22000 + 20000 + 15000) are sold for 50000; the fourth is paid in full
+		discount, err := computeDiscount(promo, nil, []orders_repo.OrderItem{croissant, danish, muffin})
+		require.NoError(t, err)
+		assert.Equal(t, int64(7000), discount.Amount)
+		assert.Equal(t, discount.Amount, sum(discount.Lines))
+		assert.Equal(t, int64(2702), discount.Lines[croissant.ID])
+		assert.Equal(t, int64(2456), discount.Lines[danish.ID])
+		assert.Equal(t, int64(1842), discount.Lines[muffin.ID])
+
+		_, err = computeDiscount(promo, nil, []orders_repo.OrderItem{croissant, danish})
+		assert.ErrorIs(t, err, common.ErrPromotionNotApplicable)
+	})
+
+	t.Run("Tiered", func(t *testing.T) {
+		promo := orders_repo.Promotion{DiscountType: orders_repo.DiscountTypeTiered}
+		tiers := []orders_repo.PromotionTier{
+			{MinAmount: 100000, DiscountType: orders_repo.DiscountTypePercentage, DiscountValue: utils.Int64ToNumeric(10)},
+			{MinAmount: 250000, DiscountType: orders_repo.DiscountTypePercentage, DiscountValue: utils.Int64ToNumeric(15)},
+		}
+
+		discount, err := computeDiscount(promo, tiers, []orders_repo.OrderItem{line(1, 60000), line(1, 90000)})
+		require.NoError(t, err)
+		assert.Equal(t, int64(15000), discount.Amount)
+
+		items := []orders_repo.OrderItem{line(2, 100000), line(1, 50000)}
+		discount, err = computeDiscount(promo, tiers, items)
+		require.NoError(t, err)
+		assert.Equal(t, int64(37500), discount.Amount)
+		assert.Equal(t, int64(30000), discount.Lines[items[0].ID])
+		assert.Equal(t, int64(7500), discount.Lines[items[1].ID])
+
+		_, err = computeDiscount(promo, tiers, []orders_repo.OrderItem{line(1, 99999)})
+		assert.ErrorIs(t, err, common.ErrPromotionNotApplicable)
+	})
+
+	t.Run("MaxDiscountCapsLineShares", func(t *testing.T) {
+		promo := orders_repo.Promotion{
+			DiscountType:      orders_repo.DiscountTypePercentage,
+			DiscountValue:     utils.Int64ToNumeric(50),
+			MaxDiscountAmount: utils.Int64ToNumeric(10000),
+		}
+		items := []orders_repo.OrderItem{line(1, 30000), line(1, 10000)}
+
+		discount, err := computeDiscount(promo, nil, items)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10000), discount.Amount)
+		assert.Equal(t, int64(7500), discount.Lines[items[0].ID])
+		assert.Equal(t, int64(2500), discount.Lines[items[1].ID])
+	})
+}
+
+func TestProrate(t *testing.T) {
+	shares := prorate(100, []int64{500, 500, 500})
+	assert.Equal(t, []int64{34, 33, 33}, shares)
+
+	// Never more than the weights add up to
+	assert.Equal(t, []int64{3000, 7000}, prorate(50000, []int64{3000, 7000}))
+	assert.Equal(t, []int64{0, 0}, prorate(100, []int64{0, 0}))
+}
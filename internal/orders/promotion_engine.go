@@ -0,0 +1,542 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/internal/settings"
+	"POS-kasir/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// pinnedPromotion is a promotion that is kept on an order while it applies: one the cashier applied by its ID
+// or a code, or, when an order is repriced without picking new promotions, one that was auto-applied.
+type pinnedPromotion struct {
+	PromotionID uuid.UUID
+	CodeID      pgtype.UUID
+	Auto        bool
+}
+
+// promotionCandidate is a promotion that applies to an order, with the discount it gives on its own.
+type promotionCandidate struct {
+	Promotion orders_repo.Promotion
+	CodeID    pgtype.UUID
+	Auto      bool
+	Discount  promotionDiscount
+}
+
+// appliedPromotion is a selected promotion with its part of the order discount once combined with the others.
+type appliedPromotion struct {
+	promotionCandidate
+	Amount int64
+}
+
+// promotionSelection is the outcome of the evaluation: the promotions an order gets in the order they are
+// applied, their combined discount and each line's share of it.
+type promotionSelection struct {
+	Applied []appliedPromotion
+	Amount  int64
+	Lines   map[uuid.UUID]int64
+}
+
+// promotionCandidates evaluates the pinned promotions, in their order, and the auto-apply promotions among
+// active against an order. Promotions whose rules or limits are not met are left out, as are promotions that
+// can only be applied with a code, unless one is pinned with it.
+func (s *OrderService) promotionCandidates(ctx context.Context, q orders_repo.Querier, order orders_repo.Order, items []orders_repo.OrderItem, pinned []pinnedPromotion, active []orders_repo.Promotion) (manual, auto []promotionCandidate, err error) {
+	seen := make(map[uuid.UUID]bool, len(pinned))
+	for _, p := range pinned {
+		if seen[p.PromotionID] {
+			continue
+		}
+		seen[p.PromotionID] = true
+
+		promo, err := q.GetPromotionByID(ctx, p.PromotionID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return nil, nil, err
+		}
+		discount, err := s.evaluateCandidate(ctx, q, promo, p.CodeID, order, items)
+		if err != nil {
+			if isPromotionRejection(err) {
+				s.log.Infof("Promotion %s no longer applies to order %s: %v", promo.ID, order.ID, err)
+				continue
+			}
+			return nil, nil, err
+		}
+		manual = append(manual, promotionCandidate{Promotion: promo, CodeID: p.CodeID, Auto: p.Auto, Discount: discount})
+	}
+
+	for _, promo := range active {
+		if !promo.AutoApply || seen[promo.ID] {
+			continue
+		}
+		hasCodes, err := q.PromotionHasCodes(ctx, promo.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hasCodes {
+			continue
+		}
+		discount, err := s.evaluateCandidate(ctx, q, promo, pgtype.UUID{}, order, items)
+		if err != nil {
+			if isPromotionRejection(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		auto = append(auto, promotionCandidate{Promotion: promo, Auto: true, Discount: discount})
+	}
+
+	return manual, auto, nil
+}
+
+// evaluateCandidate checks a promotion's rules and usage limits against an order and works out its discount.
+func (s *OrderService) evaluateCandidate(ctx context.Context, q orders_repo.Querier, promo orders_repo.Promotion, codeID pgtype.UUID, order orders_repo.Order, items []orders_repo.OrderItem) (promotionDiscount, error) {
+	discount, err := s.evaluatePromotion(ctx, q, promo, order.Type, order.GrossTotal, items)
+	if err != nil {
+		return promotionDiscount{}, err
+	}
+	if err := promotionLimitsMet(ctx, q, promo, codeID, order.CustomerID, orderFamily(order.ID, order.ParentOrderID), discount.Amount); err != nil {
+		return promotionDiscount{}, err
+	}
+	return discount, nil
+}
+
+func isPromotionRejection(err error) bool {
+	return errors.Is(err, common.ErrPromotionNotApplicable) || errors.Is(err, common.ErrPromotionLimitReached)
+}
+
+// selectPromotions decides which candidates an order gets, so the same order and promotions always give the
+// same result:
+//   - manual candidates keep their order of precedence. If the first is exclusive it is applied alone,
+//     otherwise the stackable manual candidates are kept and the exclusive ones dropped; stackable
+//     auto-apply candidates then join them.
+//   - without manual candidates, the best exclusive auto-apply candidate competes with the stack of all
+//     stackable ones. The higher priority wins, on equal priority the larger discount, and on a tie the
+//     exclusive one.
+//
+// Auto-apply candidates are ranked by priority, then discount, then promotion ID.
+func selectPromotions(manual, auto []promotionCandidate) []promotionCandidate {
+	auto = rankCandidates(auto)
+
+	var stack []promotionCandidate
+	for _, c := range auto {
+		if c.Promotion.Stacking == orders_repo.PromotionStackingStackable {
+			stack = append(stack, c)
+		}
+	}
+
+	if len(manual) > 0 {
+		if manual[0].Promotion.Stacking != orders_repo.PromotionStackingStackable {
+			return manual[:1]
+		}
+		var selected []promotionCandidate
+		for _, c := range manual {
+			if c.Promotion.Stacking == orders_repo.PromotionStackingStackable {
+				selected = append(selected, c)
+			}
+		}
+		return append(selected, stack...)
+	}
+
+	var exclusive *promotionCandidate
+	for i := range auto {
+		if auto[i].Promotion.Stacking != orders_repo.PromotionStackingStackable {
+			exclusive = &auto[i]
+			break
+		}
+	}
+
+	switch {
+	case exclusive == nil:
+		return stack
+	case len(stack) == 0:
+		return []promotionCandidate{*exclusive}
+	case exclusive.Promotion.Priority != stack[0].Promotion.Priority:
+		if exclusive.Promotion.Priority > stack[0].Promotion.Priority {
+			return []promotionCandidate{*exclusive}
+		}
+		return stack
+	}
+
+	var stacked int64
+	for _, c := range stack {
+		stacked += c.Discount.Amount
+	}
+	if stacked > exclusive.Discount.Amount {
+		return stack
+	}
+	return []promotionCandidate{*exclusive}
+}
+
+func rankCandidates(candidates []promotionCandidate) []promotionCandidate {
+	ranked := append([]promotionCandidate(nil), candidates...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Promotion.Priority != b.Promotion.Priority {
+			return a.Promotion.Priority > b.Promotion.Priority
+		}
+		if a.Discount.Amount != b.Discount.Amount {
+			return a.Discount.Amount > b.Discount.Amount
+		}
+		return a.Promotion.ID.String() < b.Promotion.ID.String()
+	})
+	return ranked
+}
+
+// combinePromotions adds up the discounts of the selected promotions in order. Each promotion is worked out
+// on the undiscounted lines, so a line's combined discount is capped at its subtotal and a later promotion
+// gives what is left of it. An auto-applied promotion left with nothing to give is dropped.
+func combinePromotions(selected []promotionCandidate, items []orders_repo.OrderItem) promotionSelection {
+	subtotals := make(map[uuid.UUID]int64, len(items))
+	for _, item := range items {
+		subtotals[item.ID] = item.Subtotal
+	}
+
+	sel := promotionSelection{Lines: make(map[uuid.UUID]int64)}
+	for _, c := range selected {
+		var amount int64
+		for _, item := range items {
+			share := min(c.Discount.Lines[item.ID], subtotals[item.ID]-sel.Lines[item.ID])
+			if share <= 0 {
+				continue
+			}
+			sel.Lines[item.ID] += share
+			amount += share
+		}
+		if amount == 0 && c.Auto {
+			continue
+		}
+		sel.Applied = append(sel.Applied, appliedPromotion{promotionCandidate: c, Amount: amount})
+		sel.Amount += amount
+	}
+	return sel
+}
+
+// savePromotions reprices an order with a selection and stores each promotion's part of the discount; the
+// first promotion becomes the order's applied promotion. Points the customer redeemed as a discount are added
+// on top. When nothing is selected and the order had no promotions, only the totals are recalculated.
+func (s *OrderService) savePromotions(ctx context.Context, qtx *orders_repo.Queries, order orders_repo.Order, items []orders_repo.OrderItem, sel promotionSelection, hadPromotions bool, version int32, taxRules *settings.TaxSettingsResponse) (orders_repo.Order, error) {
+	lines := make([]pricingLine, len(items))
+	for i, item := range items {
+		lines[i] = pricingLine{ProductID: item.ProductID, Subtotal: item.Subtotal}
+	}
+
+	// Points redeemed as a discount are taken after the promotions
+	pointsDiscount, err := orderPointsDiscount(ctx, qtx, order)
+	if err != nil {
+		return order, err
+	}
+
+	updated, err := s.recalculateOrderTotals(ctx, qtx, order.ID, order.Type, lines, sel.Amount+pointsDiscount, version, taxRules)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return order, common.ErrOrderConflict
+		}
+		return order, err
+	}
+	if len(sel.Applied) == 0 && !hadPromotions && pointsDiscount == 0 {
+		return updated, nil
+	}
+
+	if err := saveLineDiscounts(ctx, qtx, order.ID, items, withPointsDiscount(sel.Lines, items, pointsDiscount)); err != nil {
+		return order, err
+	}
+	if len(sel.Applied) == 0 && !hadPromotions {
+		return updated, nil
+	}
+	if err := qtx.DeleteOrderPromotions(ctx, order.ID); err != nil {
+		return order, fmt.Errorf("failed to clear order promotions: %w", err)
+	}
+
+	primary := orders_repo.UpdateOrderAppliedPromotionParams{ID: order.ID}
+	for i, a := range sel.Applied {
+		if err := qtx.CreateOrderPromotion(ctx, orders_repo.CreateOrderPromotionParams{
+			OrderID:         order.ID,
+			PromotionID:     a.Promotion.ID,
+			PromotionCodeID: a.CodeID,
+			DiscountAmount:  a.Amount,
+			AutoApplied:     a.Auto,
+			Position:        int32(i),
+			ScheduleWindow:  a.Discount.Window,
+		}); err != nil {
+			return order, fmt.Errorf("failed to save order promotion: %w", err)
+		}
+		if i == 0 {
+			primary.AppliedPromotionID = pgtype.UUID{Bytes: a.Promotion.ID, Valid: true}
+			primary.AppliedPromotionCodeID = a.CodeID
+		}
+	}
+	if err := qtx.UpdateOrderAppliedPromotion(ctx, primary); err != nil {
+		return order, fmt.Errorf("failed to update applied promotion: %w", err)
+	}
+
+	updated.AppliedPromotionID = primary.AppliedPromotionID
+	updated.AppliedPromotionCodeID = primary.AppliedPromotionCodeID
+	return updated, nil
+}
+
+// repricePromotions re-evaluates an order's promotions after its lines changed: the manual promotions that
+// still apply are kept and auto-apply promotions picked around them. A discount that no promotion accounts
+// for is only spread over the current lines.
+func (s *OrderService) repricePromotions(ctx context.Context, tx pgx.Tx, qtx *orders_repo.Queries, order orders_repo.Order, taxRules *settings.TaxSettingsResponse) (orders_repo.Order, promotionSelection, error) {
+	var pointsDiscount int64
+	if !order.AppliedPromotionID.Valid && order.DiscountAmount > 0 {
+		var err error
+		if pointsDiscount, err = orderPointsDiscount(ctx, qtx, order); err != nil {
+			return order, promotionSelection{}, err
+		}
+		if pointsDiscount == 0 {
+			return order, promotionSelection{}, spreadOrderDiscount(ctx, qtx, order.ID, order.DiscountAmount)
+		}
+	}
+	return s.reselectPromotions(ctx, tx, qtx, order, pointsDiscount > 0, taxRules)
+}
+
+// reselectPromotions keeps the manual promotions on an order that still apply, picks the auto-apply promotions
+// around them and saves the result. An order without promotions is left alone unless it had a discount to
+// reprice.
+func (s *OrderService) reselectPromotions(ctx context.Context, tx pgx.Tx, qtx *orders_repo.Queries, order orders_repo.Order, hadDiscount bool, taxRules *settings.TaxSettingsResponse) (orders_repo.Order, promotionSelection, error) {
+	current, err := orderPromotions(ctx, qtx, order.ID, order.AppliedPromotionID)
+	if err != nil {
+		return order, promotionSelection{}, err
+	}
+	var pinned []pinnedPromotion
+	for _, p := range current {
+		if !p.AutoApplied {
+			pinned = append(pinned, pinnedPromotion{PromotionID: p.PromotionID, CodeID: p.PromotionCodeID})
+		}
+	}
+
+	active, err := qtx.ListActivePromotions(ctx)
+	if err != nil {
+		return order, promotionSelection{}, fmt.Errorf("failed to list active promotions: %w", err)
+	}
+	if len(current) == 0 && !hasAutoApply(active) && !hadDiscount {
+		return order, promotionSelection{}, nil
+	}
+
+	items, err := qtx.GetOrderItemsByOrderID(ctx, order.ID)
+	if err != nil {
+		return order, promotionSelection{}, fmt.Errorf("failed to get order items: %w", err)
+	}
+	manual, auto, err := s.promotionCandidates(ctx, qtx, order, items, pinned, active)
+	if err != nil {
+		return order, promotionSelection{}, err
+	}
+
+	sel := combinePromotions(selectPromotions(manual, auto), items)
+	updated, err := s.savePromotions(ctx, qtx, order, items, sel, len(current) > 0 || hadDiscount, order.Version, taxRules)
+	return updated, sel, err
+}
+
+// withoutPromotion leaves a promotion that is already being applied out of the auto-apply candidates.
+func withoutPromotion(promotions []orders_repo.Promotion, id uuid.UUID) []orders_repo.Promotion {
+	var rest []orders_repo.Promotion
+	for _, promo := range promotions {
+		if promo.ID != id {
+			rest = append(rest, promo)
+		}
+	}
+	return rest
+}
+
+func hasAutoApply(promotions []orders_repo.Promotion) bool {
+	for _, promo := range promotions {
+		if promo.AutoApply {
+			return true
+		}
+	}
+	return false
+}
+
+// scaleOrderPromotions shrinks each stored promotion amount in proportion when an order keeps only part of
+// its discount, so the amounts still add up to the order's discount.
+func scaleOrderPromotions(ctx context.Context, qtx *orders_repo.Queries, order orders_repo.Order, discount int64) error {
+	applied, err := orderPromotions(ctx, qtx, order.ID, order.AppliedPromotionID)
+	if err != nil || len(applied) == 0 {
+		return err
+	}
+
+	weights := make([]int64, len(applied))
+	for i, p := range applied {
+		weights[i] = p.DiscountAmount
+	}
+	for i, share := range prorate(discount, weights) {
+		if err := qtx.UpdateOrderPromotionDiscount(ctx, orders_repo.UpdateOrderPromotionDiscountParams{
+			OrderID:        order.ID,
+			PromotionID:    applied[i].PromotionID,
+			DiscountAmount: share,
+		}); err != nil {
+			return fmt.Errorf("failed to update order promotion: %w", err)
+		}
+	}
+	return nil
+}
+
+// carryOrderPromotions puts the promotions of the order a bill was split from on the bill, each with its share
+// of the bill's discount, so that the bill records and releases its own redemption. Promotions with no share
+// are left off.
+func carryOrderPromotions(ctx context.Context, qtx *orders_repo.Queries, orderID uuid.UUID, promotions []orders_repo.GetOrderPromotionsRow, discount int64) error {
+	weights := make([]int64, len(promotions))
+	for i, p := range promotions {
+		weights[i] = p.DiscountAmount
+	}
+
+	var primary *orders_repo.GetOrderPromotionsRow
+	for i, share := range prorate(discount, weights) {
+		if share <= 0 {
+			continue
+		}
+		p := promotions[i]
+		if err := qtx.CreateOrderPromotion(ctx, orders_repo.CreateOrderPromotionParams{
+			OrderID:         orderID,
+			PromotionID:     p.PromotionID,
+			PromotionCodeID: p.PromotionCodeID,
+			DiscountAmount:  share,
+			AutoApplied:     p.AutoApplied,
+			Position:        p.Position,
+			ScheduleWindow:  p.ScheduleWindow,
+		}); err != nil {
+			return fmt.Errorf("failed to save order promotion: %w", err)
+		}
+		if primary == nil {
+			primary = &promotions[i]
+		}
+	}
+	if primary == nil {
+		return nil
+	}
+
+	if err := qtx.UpdateOrderAppliedPromotion(ctx, orders_repo.UpdateOrderAppliedPromotionParams{
+		ID:                     orderID,
+		AppliedPromotionID:     pgtype.UUID{Bytes: primary.PromotionID, Valid: true},
+		AppliedPromotionCodeID: primary.PromotionCodeID,
+	}); err != nil {
+		return fmt.Errorf("failed to update applied promotion: %w", err)
+	}
+	return nil
+}
+
+// evaluateOrderPromotions works out, without saving anything, what each promotion would give an order and
+// which of them the stacking rules would combine. The order's own promotions come first, then the active
+// ones by priority; a promotion that needs a code is only selected when it is already on the order.
+func (s *OrderService) evaluateOrderPromotions(ctx context.Context, q orders_repo.Querier, order orders_repo.Order, items []orders_repo.OrderItem, current []orders_repo.GetOrderPromotionsRow, active []orders_repo.Promotion) (*PromotionEvaluationResponse, error) {
+	response := &PromotionEvaluationResponse{
+		OrderID:         order.ID,
+		GrossTotal:      order.GrossTotal,
+		CurrentDiscount: order.DiscountAmount,
+		Promotions:      []PromotionEvaluationResult{},
+		Selected:        []AppliedPromotionResponse{},
+	}
+
+	onOrder := make(map[uuid.UUID]orders_repo.GetOrderPromotionsRow, len(current))
+	promotions := make([]orders_repo.Promotion, 0, len(current)+len(active))
+	for _, p := range current {
+		promo, err := q.GetPromotionByID(ctx, p.PromotionID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return nil, err
+		}
+		onOrder[p.PromotionID] = p
+		promotions = append(promotions, promo)
+	}
+	for _, promo := range active {
+		if _, ok := onOrder[promo.ID]; !ok {
+			promotions = append(promotions, promo)
+		}
+	}
+
+	var manual, auto []promotionCandidate
+	for _, promo := range promotions {
+		applied, isApplied := onOrder[promo.ID]
+		result := PromotionEvaluationResult{
+			PromotionID:   promo.ID,
+			PromotionName: promo.Name,
+			Stacking:      promo.Stacking,
+			Priority:      promo.Priority,
+			AutoApply:     promo.AutoApply,
+			Applied:       isApplied,
+		}
+
+		hasCodes, err := q.PromotionHasCodes(ctx, promo.ID)
+		if err != nil {
+			return nil, err
+		}
+		result.RequiresCode = hasCodes
+
+		discount, err := s.evaluateCandidate(ctx, q, promo, applied.PromotionCodeID, order, items)
+		switch {
+		case err == nil:
+			result.Eligible = true
+			result.DiscountAmount = discount.Amount
+		case isPromotionRejection(err):
+			result.Reason = err.Error()
+		default:
+			return nil, err
+		}
+		response.Promotions = append(response.Promotions, result)
+
+		if !result.Eligible {
+			continue
+		}
+		candidate := promotionCandidate{Promotion: promo, CodeID: applied.PromotionCodeID, Discount: discount}
+		switch {
+		case isApplied && !applied.AutoApplied:
+			manual = append(manual, candidate)
+		case promo.AutoApply && !hasCodes:
+			candidate.Auto = true
+			auto = append(auto, candidate)
+		}
+	}
+
+	sel := combinePromotions(selectPromotions(manual, auto), items)
+	response.DiscountAmount = sel.Amount
+	selected := make(map[uuid.UUID]bool, len(sel.Applied))
+	for _, a := range sel.Applied {
+		selected[a.Promotion.ID] = true
+		selectedPromotion := AppliedPromotionResponse{
+			PromotionID:    a.Promotion.ID,
+			PromotionName:  a.Promotion.Name,
+			DiscountAmount: a.Amount,
+			AutoApplied:    a.Auto,
+			Stacking:       a.Promotion.Stacking,
+			Priority:       a.Promotion.Priority,
+		}
+		if a.CodeID.Valid {
+			codeID := uuid.UUID(a.CodeID.Bytes)
+			selectedPromotion.PromotionCodeID = &codeID
+			selectedPromotion.Code = onOrder[a.Promotion.ID].Code
+		}
+		response.Selected = append(response.Selected, selectedPromotion)
+	}
+	for i := range response.Promotions {
+		response.Promotions[i].Selected = selected[response.Promotions[i].PromotionID]
+	}
+
+	return response, nil
+}
+
+func appliedPromotionResponse(p orders_repo.GetOrderPromotionsRow) AppliedPromotionResponse {
+	return AppliedPromotionResponse{
+		PromotionID:     p.PromotionID,
+		PromotionName:   p.PromotionName,
+		PromotionCodeID: utils.NullableUUIDToPointer(p.PromotionCodeID),
+		Code:            p.Code,
+		DiscountAmount:  p.DiscountAmount,
+		AutoApplied:     p.AutoApplied,
+		Stacking:        p.Stacking,
+		Priority:        p.Priority,
+		ScheduleWindow:  p.ScheduleWindow,
+	}
+}
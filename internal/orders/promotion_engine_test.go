@@ -0,0 +1,154 @@
+package orders
+
+import (
+	orders_repo "POS-kasir/internal/orders/repository"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectPromotions(t *testing.T) {
+	lineID := uuid.New()
+	candidate := func(name string, stacking orders_repo.PromotionStacking, priority int32, amount int64, auto bool) promotionCandidate {
+		return promotionCandidate{
+			Promotion: orders_repo.Promotion{ID: uuid.New(), Name: name, Stacking: stacking, Priority: priority},
+			Auto:      auto,
+			Discount:  promotionDiscount{Amount: amount, Lines: map[uuid.UUID]int64{lineID: amount}},
+		}
+	}
+	names := func(selected []promotionCandidate) []string {
+		var out []string
+		for _, c := range selected {
+			out = append(out, c.Promotion.Name)
+		}
+		return out
+	}
+	exclusive, stackable := orders_repo.PromotionStackingExclusive, orders_repo.PromotionStackingStackable
+
+	t.Run("ManualExclusiveStandsAlone", func(t *testing.T) {
+		manual := []promotionCandidate{candidate("voucher", exclusive, 0, 5000, false)}
+		auto := []promotionCandidate{candidate("happy hour", stackable, 10, 3000, true)}
+
+		assert.Equal(t, []string{"voucher"}, names(selectPromotions(manual, auto)))
+	})
+
+	t.Run("ManualStackableJoinsAutoStack", func(t *testing.T) {
+		manual := []promotionCandidate{
+			candidate("voucher", stackable, 0, 5000, false),
+			candidate("old exclusive", exclusive, 0, 9000, false),
+		}
+		auto := []promotionCandidate{
+			candidate("member", stackable, 1, 1000, true),
+			candidate("happy hour", stackable, 5, 3000, true),
+			candidate("weekend", exclusive, 9, 8000, true),
+		}
+
+		assert.Equal(t, []string{"voucher", "happy hour", "member"}, names(selectPromotions(manual, auto)))
+	})
+
+	t.Run("HigherPriorityWins", func(t *testing.T) {
+		auto := []promotionCandidate{
+			candidate("happy hour", stackable, 1, 3000, true),
+			candidate("member", stackable, 1, 3000, true),
+			candidate("weekend", exclusive, 2, 1000, true),
+		}
+
+		assert.Equal(t, []string{"weekend"}, names(selectPromotions(nil, auto)))
+	})
+
+	t.Run("LargerDiscountWinsOnEqualPriority", func(t *testing.T) {
+		auto := []promotionCandidate{
+			candidate("weekend", exclusive, 1, 5000, true),
+			candidate("happy hour", stackable, 1, 3000, true),
+			candidate("member", stackable, 1, 2500, true),
+		}
+
+		assert.Equal(t, []string{"happy hour", "member"}, names(selectPromotions(nil, auto)))
+	})
+
+	t.Run("ExclusiveWinsTie", func(t *testing.T) {
+		auto := []promotionCandidate{
+			candidate("happy hour", stackable, 0, 2000, true),
+			candidate("weekend", exclusive, 0, 2000, true),
+		}
+
+		assert.Equal(t, []string{"weekend"}, names(selectPromotions(nil, auto)))
+	})
+
+	t.Run("BestExclusiveOnly", func(t *testing.T) {
+		auto := []promotionCandidate{
+			candidate("small", exclusive, 0, 1000, true),
+			candidate("big", exclusive, 0, 4000, true),
+		}
+
+		assert.Equal(t, []string{"big"}, names(selectPromotions(nil, auto)))
+	})
+}
+
+func TestCombinePromotions(t *testing.T) {
+	latte, croissant := uuid.New(), uuid.New()
+	items := []orders_repo.OrderItem{
+		{ID: latte, Subtotal: 30000},
+		{ID: croissant, Subtotal: 20000},
+	}
+	candidate := func(auto bool, lines map[uuid.UUID]int64) promotionCandidate {
+		var amount int64
+		for _, share := range lines {
+			amount += share
+		}
+		return promotionCandidate{
+			Promotion: orders_repo.Promotion{ID: uuid.New()},
+			Auto:      auto,
+			Discount:  promotionDiscount{Amount: amount, Lines: lines},
+		}
+	}
+
+	first := candidate(false, map[uuid.UUID]int64{latte: 25000, croissant: 5000})
+	second := candidate(true, map[uuid.UUID]int64{latte: 10000, croissant: 10000})
+	spent := candidate(true, map[uuid.UUID]int64{latte: 3000})
+
+	sel := combinePromotions([]promotionCandidate{first, second, spent}, items)
+
+	// The second promotion only gets what is left of the latte; the third has nothing left to give
+	assert.Len(t, sel.Applied, 2)
+	assert.Equal(t, int64(30000), sel.Applied[0].Amount)
+	assert.Equal(t, int64(15000), sel.Applied[1].Amount)
+	assert.Equal(t, int64(45000), sel.Amount)
+	assert.Equal(t, map[uuid.UUID]int64{latte: 30000, croissant: 15000}, sel.Lines)
+}
+
+func TestCarryOrderPromotions(t *testing.T) {
+	mockPgx, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mockPgx.Close()
+
+	childID, voucher, happyHour, member := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	codeID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	window := "happy_hour"
+	promotions := []orders_repo.GetOrderPromotionsRow{
+		{PromotionID: voucher, PromotionCodeID: codeID, DiscountAmount: 6000},
+		{PromotionID: happyHour, DiscountAmount: 3000, AutoApplied: true, Position: 1, ScheduleWindow: &window},
+		{PromotionID: member, DiscountAmount: 0, AutoApplied: true, Position: 2},
+	}
+
+	// The bill's 3000 is shared 2:1 like the parent's discount; a promotion without a share stays off
+	mockPgx.ExpectExec("INSERT INTO order_promotions").
+		WithArgs(childID, voucher, codeID, int64(2000), false, int32(0), (*string)(nil)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mockPgx.ExpectExec("INSERT INTO order_promotions").
+		WithArgs(childID, happyHour, pgtype.UUID{}, int64(1000), true, int32(1), &window).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mockPgx.ExpectExec("UPDATE orders").
+		WithArgs(childID, pgtype.UUID{Bytes: voucher, Valid: true}, codeID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err = carryOrderPromotions(context.Background(), orders_repo.New(mockPgx), childID, promotions, 3000)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mockPgx.ExpectationsWereMet())
+}
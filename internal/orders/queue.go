@@ -0,0 +1,126 @@
+package orders
+
+import (
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/internal/settings"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	ws "POS-kasir/internal/websocket"
+)
+
+const businessDateLayout = "2006-01-02"
+
+// businessDate returns the business day now falls in. A day starts at resetTime (HH:MM) in loc,
+// so with a 04:00 reset an order taken at 01:30 still belongs to the previous day.
+func businessDate(now time.Time, loc *time.Location, resetTime string) time.Time {
+	local := now.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	reset, err := time.Parse("15:04", resetTime)
+	if err != nil {
+		return day
+	}
+	if local.Hour()*60+local.Minute() < reset.Hour()*60+reset.Minute() {
+		return day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// formatQueueNumber renders a daily sequence number for guests, e.g. prefix "A" with 3 digits gives "A007".
+func formatQueueNumber(prefix string, digits int, seq int32) string {
+	return fmt.Sprintf("%s%0*d", strings.TrimSpace(prefix), digits, seq)
+}
+
+// assignQueueNumber takes the next number of the current business day. It must run inside the order
+// transaction: the counter row stays locked until commit, so concurrent orders never share a number.
+func assignQueueNumber(ctx context.Context, qtx *orders_repo.Queries, opts *settings.OperationalSettingsResponse, now time.Time) (string, pgtype.Date, error) {
+	day := pgtype.Date{Time: businessDate(now, opts.Location(), opts.QueueResetTime), Valid: true}
+
+	seq, err := qtx.NextQueueNumber(ctx, day)
+	if err != nil {
+		return "", day, fmt.Errorf("failed to assign queue number: %w", err)
+	}
+	return formatQueueNumber(opts.QueuePrefix, opts.QueueNumberDigits, seq), day, nil
+}
+
+// GetCallingBoard lists the current business day's orders guests are waiting for: orders still being
+// prepared, and orders whose every line is ready for pickup. Orders leave the board once served.
+func (s *OrderService) GetCallingBoard(ctx context.Context) (*CallingBoardResponse, error) {
+	opts, err := s.settingsService.GetOperationalSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load operational settings", "error", err)
+		return nil, err
+	}
+
+	day := businessDate(time.Now(), opts.Location(), opts.QueueResetTime)
+	rows, err := s.ordersRepo.ListCallingBoardOrders(ctx, pgtype.Date{Time: day, Valid: true})
+	if err != nil {
+		s.log.Error("Failed to list calling board orders", "error", err)
+		return nil, err
+	}
+
+	return buildCallingBoard(day, rows), nil
+}
+
+func buildCallingBoard(day time.Time, rows []orders_repo.ListCallingBoardOrdersRow) *CallingBoardResponse {
+	board := &CallingBoardResponse{
+		BusinessDate: day.Format(businessDateLayout),
+		Preparing:    []CallingBoardEntry{},
+		Ready:        []CallingBoardEntry{},
+	}
+
+	for _, row := range rows {
+		entry := CallingBoardEntry{
+			OrderID:   row.ID,
+			Type:      row.Type,
+			CreatedAt: row.CreatedAt.Time,
+		}
+		if row.QueueNumber != nil {
+			entry.QueueNumber = *row.QueueNumber
+		}
+
+		if row.TotalItems > 0 && row.ReadyItems == row.TotalItems {
+			if row.ReadyAt.Valid {
+				readyAt := row.ReadyAt.Time
+				entry.ReadyAt = &readyAt
+			}
+			board.Ready = append(board.Ready, entry)
+		} else {
+			board.Preparing = append(board.Preparing, entry)
+		}
+	}
+
+	// Most recently called numbers go first so the screen highlights them
+	sort.SliceStable(board.Ready, func(a, b int) bool {
+		return readyAfter(board.Ready[a], board.Ready[b])
+	})
+
+	return board
+}
+
+func readyAfter(a, b CallingBoardEntry) bool {
+	if a.ReadyAt == nil || b.ReadyAt == nil {
+		return a.ReadyAt != nil
+	}
+	return a.ReadyAt.After(*b.ReadyAt)
+}
+
+// PublishCallingBoard pushes the current calling board to subscribed screens.
+func (s *OrderService) PublishCallingBoard(ctx context.Context) {
+	if s.wsHub == nil {
+		return
+	}
+
+	board, err := s.GetCallingBoard(ctx)
+	if err != nil {
+		s.log.Warnf("PublishCallingBoard | Failed to build calling board: %v", err)
+		return
+	}
+	s.wsHub.Publish(ws.CallingBoardEvent(board))
+}
@@ -0,0 +1,52 @@
+package orders
+
+import (
+	orders_repo "POS-kasir/internal/orders/repository"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusinessDate(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	assert.NoError(t, err)
+
+	// 2026-10-17 01:30 in Jakarta is still the 16th in UTC
+	now := time.Date(2026, 10, 16, 18, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "2026-10-17", businessDate(now, jakarta, "00:00").Format(businessDateLayout))
+	// With a 04:00 reset the late-night order belongs to the previous day
+	assert.Equal(t, "2026-10-16", businessDate(now, jakarta, "04:00").Format(businessDateLayout))
+	assert.Equal(t, "2026-10-17", businessDate(now.Add(3*time.Hour), jakarta, "04:00").Format(businessDateLayout))
+}
+
+func TestFormatQueueNumber(t *testing.T) {
+	assert.Equal(t, "007", formatQueueNumber("", 3, 7))
+	assert.Equal(t, "A07", formatQueueNumber(" A ", 2, 7))
+	// Busy days outgrow the padding instead of wrapping
+	assert.Equal(t, "1234", formatQueueNumber("", 3, 1234))
+}
+
+func TestBuildCallingBoard(t *testing.T) {
+	day := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+	cooking, earlier, later := uuid.New(), uuid.New(), uuid.New()
+	num := func(s string) *string { return &s }
+
+	board := buildCallingBoard(day, []orders_repo.ListCallingBoardOrdersRow{
+		{ID: cooking, QueueNumber: num("001"), TotalItems: 2, ReadyItems: 1},
+		{ID: earlier, QueueNumber: num("002"), TotalItems: 1, ReadyItems: 1, ReadyAt: pgtype.Timestamptz{Time: now.Add(-time.Minute), Valid: true}},
+		{ID: later, QueueNumber: num("003"), TotalItems: 2, ReadyItems: 2, ServedItems: 1, ReadyAt: pgtype.Timestamptz{Time: now, Valid: true}},
+	})
+
+	assert.Equal(t, "2026-10-17", board.BusinessDate)
+	assert.Len(t, board.Preparing, 1)
+	assert.Equal(t, "001", board.Preparing[0].QueueNumber)
+	assert.Len(t, board.Ready, 2)
+	// The latest called number comes first
+	assert.Equal(t, later, board.Ready[0].OrderID)
+	assert.Equal(t, earlier, board.Ready[1].OrderID)
+}
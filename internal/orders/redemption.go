@@ -0,0 +1,165 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// resolvePromotion returns the promotion an apply request names, and the code it was named by. A promotion
+// that has codes can only be applied with one of them, so single-use codes cannot be bypassed by its ID.
+func resolvePromotion(ctx context.Context, q orders_repo.Querier, req ApplyPromotionRequest) (uuid.UUID, pgtype.UUID, error) {
+	if req.Code == "" {
+		hasCodes, err := q.PromotionHasCodes(ctx, req.PromotionID)
+		if err != nil {
+			return uuid.Nil, pgtype.UUID{}, err
+		}
+		if hasCodes {
+			return uuid.Nil, pgtype.UUID{}, fmt.Errorf("%w: promotion can only be applied with one of its codes", common.ErrPromotionNotApplicable)
+		}
+		return req.PromotionID, pgtype.UUID{}, nil
+	}
+
+	code, err := q.GetActivePromotionCode(ctx, req.Code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, pgtype.UUID{}, common.ErrPromotionCodeNotFound
+		}
+		return uuid.Nil, pgtype.UUID{}, err
+	}
+	if req.PromotionID != uuid.Nil && req.PromotionID != code.PromotionID {
+		return uuid.Nil, pgtype.UUID{}, common.ErrPromotionCodeNotFound
+	}
+	return code.PromotionID, pgtype.UUID{Bytes: code.ID, Valid: true}, nil
+}
+
+// orderFamily identifies the bills of one split: the order they were split from. The bills share their
+// promotions, so the family counts as a single redemption.
+func orderFamily(orderID uuid.UUID, parentOrderID pgtype.UUID) pgtype.UUID {
+	if parentOrderID.Valid {
+		return parentOrderID
+	}
+	return pgtype.UUID{Bytes: orderID, Valid: true}
+}
+
+// checkPromotionLimits checks whether one more redemption of a promotion, granting discount, stays within
+// the promotion's limits and those of the code it is applied with. Redemptions by the order's own family
+// are not counted again; their discount still comes out of the budget.
+func checkPromotionLimits(ctx context.Context, q orders_repo.Querier, promotionID uuid.UUID, codeID pgtype.UUID, customerID pgtype.UUID, family pgtype.UUID, discount int64) error {
+	promo, err := q.GetPromotionByID(ctx, promotionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return common.ErrNotFound
+		}
+		return err
+	}
+	return promotionLimitsMet(ctx, q, promo, codeID, customerID, family, discount)
+}
+
+// promotionLimitsMet is checkPromotionLimits for a promotion that is already loaded.
+func promotionLimitsMet(ctx context.Context, q orders_repo.Querier, promo orders_repo.Promotion, codeID pgtype.UUID, customerID pgtype.UUID, family pgtype.UUID, discount int64) error {
+	var codeMaxUses *int32
+	if codeID.Valid {
+		code, err := q.GetPromotionCodeByID(ctx, codeID.Bytes)
+		if err != nil {
+			return fmt.Errorf("failed to get promotion code: %w", err)
+		}
+		codeMaxUses = code.MaxUses
+	}
+
+	if promo.TotalRedemptionLimit == nil && promo.PerCustomerLimit == nil && promo.BudgetAmount == nil && codeMaxUses == nil {
+		return nil
+	}
+
+	stats, err := q.GetPromotionRedemptionStats(ctx, orders_repo.GetPromotionRedemptionStatsParams{
+		PromotionID:     promo.ID,
+		CustomerID:      customerID,
+		PromotionCodeID: codeID,
+		OrderFamilyID:   family,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get promotion redemptions: %w", err)
+	}
+	return redemptionLimitsMet(promo, codeMaxUses, customerID.Valid, stats, discount)
+}
+
+func redemptionLimitsMet(promo orders_repo.Promotion, codeMaxUses *int32, hasCustomer bool, stats orders_repo.GetPromotionRedemptionStatsRow, discount int64) error {
+	if codeMaxUses != nil && stats.CodeRedemptions >= int64(*codeMaxUses) {
+		if *codeMaxUses == 1 {
+			return fmt.Errorf("%w: the code has already been used", common.ErrPromotionLimitReached)
+		}
+		return fmt.Errorf("%w: the code has been used %d of %d times", common.ErrPromotionLimitReached, stats.CodeRedemptions, *codeMaxUses)
+	}
+	if promo.TotalRedemptionLimit != nil && stats.TotalRedemptions >= int64(*promo.TotalRedemptionLimit) {
+		return fmt.Errorf("%w: redeemed %d of %d times", common.ErrPromotionLimitReached, stats.TotalRedemptions, *promo.TotalRedemptionLimit)
+	}
+	if promo.PerCustomerLimit != nil {
+		if !hasCustomer {
+			return fmt.Errorf("%w: promotion is limited per customer, attach a customer to the order first", common.ErrPromotionNotApplicable)
+		}
+		if stats.CustomerRedemptions >= int64(*promo.PerCustomerLimit) {
+			return fmt.Errorf("%w: the customer has used it %d of %d times", common.ErrPromotionLimitReached, stats.CustomerRedemptions, *promo.PerCustomerLimit)
+		}
+	}
+	if promo.BudgetAmount != nil && stats.DiscountTotal+discount > *promo.BudgetAmount {
+		return fmt.Errorf("%w: discount budget left is %d", common.ErrPromotionLimitReached, max(*promo.BudgetAmount-stats.DiscountTotal, 0))
+	}
+	return nil
+}
+
+// redeemPromotion records the redemption of each promotion on a settling order, in the transaction that
+// settles it. The promotion rows are locked so concurrent payments cannot both take the last redemption.
+func redeemPromotion(ctx context.Context, qtx *orders_repo.Queries, order orders_repo.Order) error {
+	applied, err := orderPromotions(ctx, qtx, order.ID, order.AppliedPromotionID)
+	if err != nil {
+		return err
+	}
+	for _, p := range applied {
+		if _, err := qtx.LockPromotion(ctx, p.PromotionID); err != nil {
+			return fmt.Errorf("failed to lock promotion: %w", err)
+		}
+		if err := checkPromotionLimits(ctx, qtx, p.PromotionID, p.PromotionCodeID, order.CustomerID, orderFamily(order.ID, order.ParentOrderID), p.DiscountAmount); err != nil {
+			return err
+		}
+		if err := qtx.CreatePromotionRedemption(ctx, orders_repo.CreatePromotionRedemptionParams{
+			PromotionID:     p.PromotionID,
+			PromotionCodeID: p.PromotionCodeID,
+			OrderID:         order.ID,
+			CustomerID:      order.CustomerID,
+			DiscountAmount:  p.DiscountAmount,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderPromotions returns the promotions applied to an order. Orders without an applied promotion have none,
+// so they are not queried.
+func orderPromotions(ctx context.Context, q orders_repo.Querier, orderID uuid.UUID, appliedPromotionID pgtype.UUID) ([]orders_repo.GetOrderPromotionsRow, error) {
+	if !appliedPromotionID.Valid {
+		return nil, nil
+	}
+	applied, err := q.GetOrderPromotions(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order promotions: %w", err)
+	}
+	return applied, nil
+}
+
+// releasePromotion gives a cancelled or fully refunded order's redemption back to the promotion's limits.
+func releasePromotion(ctx context.Context, q orders_repo.Querier, orderID uuid.UUID, promotionID pgtype.UUID) error {
+	if !promotionID.Valid {
+		return nil
+	}
+	if err := q.ReleasePromotionRedemptions(ctx, orderID); err != nil {
+		return fmt.Errorf("failed to release promotion redemption: %w", err)
+	}
+	return nil
+}
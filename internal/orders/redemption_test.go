@@ -0,0 +1,56 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedemptionLimitsMet(t *testing.T) {
+	int32Ptr := func(v int32) *int32 { return &v }
+	int64Ptr := func(v int64) *int64 { return &v }
+
+	t.Run("NoLimits", func(t *testing.T) {
+		stats := orders_repo.GetPromotionRedemptionStatsRow{TotalRedemptions: 1000, DiscountTotal: 1_000_000}
+		assert.NoError(t, redemptionLimitsMet(orders_repo.Promotion{}, nil, false, stats, 5000))
+	})
+
+	t.Run("SingleUseCodeAlreadyUsed", func(t *testing.T) {
+		stats := orders_repo.GetPromotionRedemptionStatsRow{CodeRedemptions: 1}
+		err := redemptionLimitsMet(orders_repo.Promotion{}, int32Ptr(1), false, stats, 5000)
+		assert.ErrorIs(t, err, common.ErrPromotionLimitReached)
+		assert.Contains(t, err.Error(), "already been used")
+	})
+
+	t.Run("TotalLimit", func(t *testing.T) {
+		promo := orders_repo.Promotion{TotalRedemptionLimit: int32Ptr(10)}
+		assert.NoError(t, redemptionLimitsMet(promo, nil, false, orders_repo.GetPromotionRedemptionStatsRow{TotalRedemptions: 9}, 0))
+		assert.ErrorIs(t, redemptionLimitsMet(promo, nil, false, orders_repo.GetPromotionRedemptionStatsRow{TotalRedemptions: 10}, 0), common.ErrPromotionLimitReached)
+	})
+
+	t.Run("PerCustomerLimitNeedsCustomer", func(t *testing.T) {
+		promo := orders_repo.Promotion{PerCustomerLimit: int32Ptr(1)}
+		err := redemptionLimitsMet(promo, nil, false, orders_repo.GetPromotionRedemptionStatsRow{}, 0)
+		assert.ErrorIs(t, err, common.ErrPromotionNotApplicable)
+	})
+
+	t.Run("PerCustomerLimit", func(t *testing.T) {
+		promo := orders_repo.Promotion{PerCustomerLimit: int32Ptr(2)}
+		assert.NoError(t, redemptionLimitsMet(promo, nil, true, orders_repo.GetPromotionRedemptionStatsRow{TotalRedemptions: 50, CustomerRedemptions: 1}, 0))
+		assert.ErrorIs(t, redemptionLimitsMet(promo, nil, true, orders_repo.GetPromotionRedemptionStatsRow{CustomerRedemptions: 2}, 0), common.ErrPromotionLimitReached)
+	})
+
+	t.Run("Budget", func(t *testing.T) {
+		promo := orders_repo.Promotion{BudgetAmount: int64Ptr(100000)}
+		stats := orders_repo.GetPromotionRedemptionStatsRow{DiscountTotal: 95000}
+
+		// The discount that exactly uses up the budget is still granted
+		assert.NoError(t, redemptionLimitsMet(promo, nil, false, stats, 5000))
+
+		err := redemptionLimitsMet(promo, nil, false, stats, 5001)
+		assert.ErrorIs(t, err, common.ErrPromotionLimitReached)
+		assert.Contains(t, err.Error(), "budget left is 5000")
+	})
+}
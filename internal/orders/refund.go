@@ -0,0 +1,230 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/internal/settings"
+	"POS-kasir/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// refundLine is Quantity units of an order line being refunded and their share of the refund amount.
+type refundLine struct {
+	Item     orders_repo.OrderItem
+	Quantity int32
+	Amount   int64
+}
+
+// refundAllocation is the part of a refund returned through a single tender.
+type refundAllocation struct {
+	OrderPaymentID  pgtype.UUID
+	PaymentMethodID *int32
+	Amount          int64
+}
+
+// lineValue is the net value of qty units of an order line; refunding the whole line yields its exact net subtotal.
+func lineValue(item orders_repo.OrderItem, qty int32) int64 {
+	if item.Quantity == 0 {
+		return 0
+	}
+	return item.NetSubtotal * int64(qty) / int64(item.Quantity)
+}
+
+// refundPricing prices units of an order's lines the way the order was priced: tax only on the lines that
+// were taxed and service charge on the service base, at the rates frozen on the order.
+type refundPricing struct {
+	OrderType orders_repo.OrderType
+	Rules     *settings.TaxSettingsResponse
+	Exempt    map[uuid.UUID]bool
+}
+
+// orderTaxRules returns the rules an order was priced with: the rates recorded on the order, and the current
+// settings for what the order does not record.
+func orderTaxRules(order orders_repo.Order, current *settings.TaxSettingsResponse) *settings.TaxSettingsResponse {
+	rules := &settings.TaxSettingsResponse{
+		TaxRate:           utils.NumericToFloat64(order.TaxRate),
+		TaxInclusive:      order.TaxInclusive,
+		ServiceChargeRate: utils.NumericToFloat64(order.ServiceChargeRate),
+	}
+	rules.TaxEnabled = rules.TaxRate > 0
+	rules.ServiceChargeEnabled = rules.ServiceChargeRate > 0
+	rules.TaxOrderTypes = []string{string(order.Type)}
+	rules.ServiceChargeOrderTypes = []string{string(order.Type)}
+	if current != nil {
+		rules.TaxExemptCategoryIDs = current.TaxExemptCategoryIDs
+		rules.ServiceChargeTaxable = current.ServiceChargeTaxable
+		rules.RoundingMode = current.RoundingMode
+	}
+	return rules
+}
+
+// price is what qty units of each line would total with tax and service charge.
+func (p refundPricing) price(items []orders_repo.OrderItem, qty map[uuid.UUID]int32) int64 {
+	lines := make([]pricingLine, 0, len(items))
+	for _, item := range items {
+		if qty[item.ID] > 0 {
+			lines = append(lines, pricingLine{ProductID: item.ProductID, Subtotal: lineValue(item, qty[item.ID])})
+		}
+	}
+	return calculateOrderTotals(p.OrderType, lines, p.Exempt, 0, p.Rules).NetTotal
+}
+
+// planRefund validates the selected lines against what has already been refunded and prices them against the
+// order's net total. Each line is priced with the tax and service charge it was charged, so a tax-exempt line
+// refunds no tax; order discounts are already part of the lines' net subtotals. An empty selection refunds
+// every remaining unit. It reports whether the order is fully refunded afterwards.
+func planRefund(order orders_repo.Order, items []orders_repo.OrderItem, pricing refundPricing, refundedQty map[uuid.UUID]int32, alreadyRefunded int64, selection []RefundOrderItem) ([]refundLine, int64, bool, error) {
+	itemsByID := make(map[uuid.UUID]orders_repo.OrderItem, len(items))
+	for _, item := range items {
+		itemsByID[item.ID] = item
+	}
+
+	requested := make(map[uuid.UUID]int32)
+	var lineOrder []uuid.UUID
+	if len(selection) == 0 {
+		for _, item := range items {
+			if left := item.Quantity - refundedQty[item.ID]; left > 0 {
+				requested[item.ID] = left
+				lineOrder = append(lineOrder, item.ID)
+			}
+		}
+	} else {
+		for _, sel := range selection {
+			if _, ok := itemsByID[sel.OrderItemID]; !ok {
+				return nil, 0, false, common.ErrRefundInvalid
+			}
+			if _, seen := requested[sel.OrderItemID]; !seen {
+				lineOrder = append(lineOrder, sel.OrderItemID)
+			}
+			requested[sel.OrderItemID] += sel.Quantity
+		}
+	}
+	if len(lineOrder) == 0 {
+		return nil, 0, false, common.ErrRefundInvalid
+	}
+
+	ordered := make(map[uuid.UUID]int32, len(items))
+	refundedAfter := make(map[uuid.UUID]int32, len(items))
+	fullyRefunded := true
+	for _, item := range items {
+		after := refundedQty[item.ID] + requested[item.ID]
+		if after > item.Quantity {
+			return nil, 0, false, common.ErrRefundInvalid
+		}
+		if after < item.Quantity {
+			fullyRefunded = false
+		}
+		ordered[item.ID] = item.Quantity
+		refundedAfter[item.ID] = after
+	}
+	// The order's own pricing scales the result, so the refunds of every unit add up to its net total
+	baseTotal := pricing.price(items, ordered)
+	valueBefore := pricing.price(items, refundedQty)
+	valueAfter := pricing.price(items, refundedAfter)
+
+	remaining := order.NetTotal - alreadyRefunded
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	// Pricing the cumulative refund and taking the difference keeps rounding from drifting across partial refunds
+	var amount int64
+	if fullyRefunded {
+		amount = remaining
+	} else if baseTotal > 0 {
+		amount = order.NetTotal*valueAfter/baseTotal - order.NetTotal*valueBefore/baseTotal
+	}
+	if amount > remaining {
+		amount = remaining
+	}
+
+	lines := make([]refundLine, 0, len(lineOrder))
+	lineValues := make(map[uuid.UUID]int64, len(lineOrder))
+	var requestedValue int64
+	for _, id := range lineOrder {
+		lineValues[id] = pricing.price([]orders_repo.OrderItem{itemsByID[id]}, requested)
+		requestedValue += lineValues[id]
+	}
+	var allocated int64
+	for i, id := range lineOrder {
+		item := itemsByID[id]
+		share := int64(0)
+		if i == len(lineOrder)-1 {
+			share = amount - allocated
+		} else if requestedValue > 0 {
+			share = amount * lineValues[id] / requestedValue
+		}
+		allocated += share
+		lines = append(lines, refundLine{Item: item, Quantity: requested[id], Amount: share})
+	}
+
+	return lines, amount, fullyRefunded, nil
+}
+
+// allocateRefund spreads a refund over the order's tenders, most recent first, never returning more through a
+// tender than it still holds. A preferred tender must cover the whole amount on its own. Orders paid before
+// tenders were recorded fall back to the order's payment method.
+func allocateRefund(amount int64, payments []orders_repo.OrderPayment, refunds []orders_repo.OrderRefund, preferred *uuid.UUID, fallbackMethod *int32) ([]refundAllocation, error) {
+	if len(payments) == 0 {
+		if preferred != nil {
+			return nil, common.ErrRefundInvalid
+		}
+		return []refundAllocation{{PaymentMethodID: fallbackMethod, Amount: amount}}, nil
+	}
+
+	refundedByTender := make(map[uuid.UUID]int64)
+	for _, r := range refunds {
+		if r.OrderPaymentID.Valid {
+			refundedByTender[r.OrderPaymentID.Bytes] += r.Amount
+		}
+	}
+
+	if preferred != nil {
+		for _, p := range payments {
+			if p.ID != *preferred {
+				continue
+			}
+			if p.Amount-refundedByTender[p.ID] < amount {
+				return nil, common.ErrRefundInvalid
+			}
+			return []refundAllocation{{
+				OrderPaymentID:  pgtype.UUID{Bytes: p.ID, Valid: true},
+				PaymentMethodID: &p.PaymentMethodID,
+				Amount:          amount,
+			}}, nil
+		}
+		return nil, common.ErrRefundInvalid
+	}
+
+	var allocations []refundAllocation
+	left := amount
+	for i := len(payments) - 1; i >= 0 && left > 0; i-- {
+		p := payments[i]
+		available := p.Amount - refundedByTender[p.ID]
+		if available <= 0 {
+			continue
+		}
+		portion := min(available, left)
+		allocations = append(allocations, refundAllocation{
+			OrderPaymentID:  pgtype.UUID{Bytes: p.ID, Valid: true},
+			PaymentMethodID: &p.PaymentMethodID,
+			Amount:          portion,
+		})
+		left -= portion
+	}
+	if left > 0 {
+		return nil, common.ErrRefundInvalid
+	}
+
+	// Zero-value refunds (e.g. free items) are still booked against the latest tender
+	if len(allocations) == 0 {
+		last := payments[len(payments)-1]
+		allocations = append(allocations, refundAllocation{
+			OrderPaymentID:  pgtype.UUID{Bytes: last.ID, Valid: true},
+			PaymentMethodID: &last.PaymentMethodID,
+		})
+	}
+	return allocations, nil
+}
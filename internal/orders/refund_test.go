@@ -0,0 +1,69 @@
+package orders
+
+import (
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/internal/settings"
+	"POS-kasir/pkg/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRefund(t *testing.T) {
+	coffee, bread := uuid.New(), uuid.New()
+	taxRate, _ := utils.Float64ToNumeric(11)
+	serviceRate, _ := utils.Float64ToNumeric(5)
+
+	// A dine-in order with 11% exclusive tax and a taxable 5% service charge; bread is tax exempt.
+	// Coffee: 20000 + 1000 service + 2200 tax + 110 service tax. Bread: 10000 + 500 service + 55 service tax.
+	order := orders_repo.Order{
+		Type:                orders_repo.OrderTypeDineIn,
+		GrossTotal:          30000,
+		TaxAmount:           2365,
+		ServiceChargeAmount: 1500,
+		NetTotal:            33865,
+		TaxRate:             taxRate,
+		ServiceChargeRate:   serviceRate,
+	}
+	items := []orders_repo.OrderItem{
+		{ID: uuid.New(), ProductID: coffee, Quantity: 2, Subtotal: 20000, NetSubtotal: 20000},
+		{ID: uuid.New(), ProductID: bread, Quantity: 2, Subtotal: 10000, NetSubtotal: 10000},
+	}
+	pricing := refundPricing{
+		OrderType: order.Type,
+		Rules:     orderTaxRules(order, &settings.TaxSettingsResponse{ServiceChargeTaxable: true, RoundingMode: "round"}),
+		Exempt:    map[uuid.UUID]bool{bread: true},
+	}
+
+	t.Run("ExemptLine", func(t *testing.T) {
+		// The bread carries its service charge but none of the tax on the coffee
+		lines, amount, full, err := planRefund(order, items, pricing, nil, 0, []RefundOrderItem{{OrderItemID: items[1].ID, Quantity: 2}})
+		require.NoError(t, err)
+		assert.False(t, full)
+		assert.Equal(t, int64(10555), amount)
+		require.Len(t, lines, 1)
+		assert.Equal(t, int64(10555), lines[0].Amount)
+	})
+
+	t.Run("SharesFollowEachLinesTax", func(t *testing.T) {
+		lines, amount, _, err := planRefund(order, items, pricing, nil, 0, []RefundOrderItem{
+			{OrderItemID: items[0].ID, Quantity: 1},
+			{OrderItemID: items[1].ID, Quantity: 1},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(16933), amount)
+		require.Len(t, lines, 2)
+		assert.Equal(t, int64(11655), lines[0].Amount)
+		assert.Equal(t, int64(5278), lines[1].Amount)
+	})
+
+	t.Run("LastRefundTakesTheRest", func(t *testing.T) {
+		refunded := map[uuid.UUID]int32{items[1].ID: 2}
+		_, amount, full, err := planRefund(order, items, pricing, refunded, 10555, nil)
+		require.NoError(t, err)
+		assert.True(t, full)
+		assert.Equal(t, int64(23310), amount)
+	})
+}
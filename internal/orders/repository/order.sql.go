@@ -43,6 +43,43 @@ type BatchCreateOrderItemOptionsParams struct {
 	PriceAtSale     int64     `json:"price_at_sale"`
 }
 
+const addVariantStock = `-- name: AddVariantStock :one
+UPDATE product_variants
+SET stock = stock + $1::int
+WHERE id = $2
+RETURNING stock
+`
+
+type AddVariantStockParams struct {
+	Quantity int32     `json:"quantity"`
+	ID       uuid.UUID `json:"id"`
+}
+
+// Menambahkan stok varian kembali (pembatalan, refund, pengurangan qty).
+func (q *Queries) AddVariantStock(ctx context.Context, arg AddVariantStockParams) (int32, error) {
+	row := q.db.QueryRow(ctx, addVariantStock, arg.Quantity, arg.ID)
+	var stock int32
+	err := row.Scan(&stock)
+	return stock, err
+}
+
+const adjustIngredientStock = `-- name: AdjustIngredientStock :exec
+UPDATE ingredients
+SET stock = stock + $1::int
+WHERE id = $2
+`
+
+type AdjustIngredientStockParams struct {
+	ChangeAmount int32     `json:"change_amount"`
+	ID           uuid.UUID `json:"id"`
+}
+
+// Menambah (positif) atau mengurangi (negatif) stok bahan baku.
+func (q *Queries) AdjustIngredientStock(ctx context.Context, arg AdjustIngredientStockParams) error {
+	_, err := q.db.Exec(ctx, adjustIngredientStock, arg.ChangeAmount, arg.ID)
+	return err
+}
+
 const batchCreateOrderItems = `-- name: BatchCreateOrderItems :many
 INSERT INTO order_items (
     order_id,
@@ -51,7 +88,8 @@ INSERT INTO order_items (
     price_at_sale,
     subtotal,
     net_subtotal,
-    cost_price_at_sale
+    cost_price_at_sale,
+    variant_id
 )
 SELECT
     $1 AS order_id,
@@ -60,8 +98,10 @@ SELECT
     unnest($4::numeric[]) AS price_at_sale,
     unnest($5::numeric[]) AS subtotal,
     unnest($6::numeric[]) AS net_subtotal,
-    unnest($7::numeric[]) AS cost_price_at_sale
-RETURNING id, order_id, product_id, quantity, price_at_sale, subtotal, discount_amount, net_subtotal, cost_price_at_sale
+    unnest($7::numeric[]) AS cost_price_at_sale,
+    -- uuid.Nil marks a line without a variant
+    NULLIF(unnest($8::uuid[]), '00000000-0000-0000-0000-000000000000'::uuid) AS variant_id
+RETURNING id, order_id, product_id, quantity, price_at_sale, subtotal, discount_amount, net_subtotal, cost_price_at_sale, station_id, prep_status, is_priority, queued_at, started_at, ready_at, served_at, variant_id
 `
 
 type BatchCreateOrderItemsParams struct {
@@ -72,6 +112,7 @@ type BatchCreateOrderItemsParams struct {
 	Subtotals        []pgtype.Numeric `json:"subtotals"`
 	NetSubtotals     []pgtype.Numeric `json:"net_subtotals"`
 	CostPricesAtSale []pgtype.Numeric `json:"cost_prices_at_sale"`
+	VariantIds       []uuid.UUID      `json:"variant_ids"`
 }
 
 // Memasukkan banyak item sekaligus menggunakan array (Bulk Insert).
@@ -84,6 +125,7 @@ func (q *Queries) BatchCreateOrderItems(ctx context.Context, arg BatchCreateOrde
 		arg.Subtotals,
 		arg.NetSubtotals,
 		arg.CostPricesAtSale,
+		arg.VariantIds,
 	)
 	if err != nil {
 		return nil, err
@@ -102,6 +144,14 @@ func (q *Queries) BatchCreateOrderItems(ctx context.Context, arg BatchCreateOrde
 			&i.DiscountAmount,
 			&i.NetSubtotal,
 			&i.CostPriceAtSale,
+			&i.StationID,
+			&i.PrepStatus,
+			&i.IsPriority,
+			&i.QueuedAt,
+			&i.StartedAt,
+			&i.ReadyAt,
+			&i.ServedAt,
+			&i.VariantID,
 		); err != nil {
 			return nil, err
 		}
@@ -137,6 +187,57 @@ func (q *Queries) BatchDecreaseProductStock(ctx context.Context, arg BatchDecrea
 	return err
 }
 
+const bumpOrderVersion = `-- name: BumpOrderVersion :one
+UPDATE orders
+SET version = version + 1
+WHERE id = $1 AND version = $2
+RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id, tax_rate, service_charge_rate, tax_inclusive, shift_id, parent_order_id, queue_number, business_date, applied_promotion_code_id
+`
+
+type BumpOrderVersionParams struct {
+	ID      uuid.UUID `json:"id"`
+	Version int32     `json:"version"`
+}
+
+// Menaikkan versi pesanan setelah pembayaran parsial (optimistic locking).
+func (q *Queries) BumpOrderVersion(ctx context.Context, arg BumpOrderVersionParams) (Order, error) {
+	row := q.db.QueryRow(ctx, bumpOrderVersion, arg.ID, arg.Version)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.GrossTotal,
+		&i.DiscountAmount,
+		&i.NetTotal,
+		&i.AppliedPromotionID,
+		&i.PaymentMethodID,
+		&i.PaymentGatewayReference,
+		&i.CashReceived,
+		&i.ChangeDue,
+		&i.CancellationReasonID,
+		&i.CancellationNotes,
+		&i.PaymentUrl,
+		&i.PaymentToken,
+		&i.Version,
+		&i.TaxAmount,
+		&i.ServiceChargeAmount,
+		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
+	)
+	return i, err
+}
+
 const cancelOrder = `-- name: CancelOrder :one
 UPDATE orders
 SET
@@ -145,7 +246,7 @@ SET
     cancellation_notes = $3
 WHERE
     id = $1 AND status = 'open'
-RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id
+RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id, tax_rate, service_charge_rate, tax_inclusive, shift_id, parent_order_id, queue_number, business_date, applied_promotion_code_id
 `
 
 type CancelOrderParams struct {
@@ -182,10 +283,69 @@ func (q *Queries) CancelOrder(ctx context.Context, arg CancelOrderParams) (Order
 		&i.TaxAmount,
 		&i.ServiceChargeAmount,
 		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
 	)
 	return i, err
 }
 
+const clearOrderPaymentGateway = `-- name: ClearOrderPaymentGateway :exec
+UPDATE orders
+SET
+    payment_gateway_reference = NULL,
+    payment_url = NULL,
+    payment_token = NULL
+WHERE
+    id = $1
+`
+
+// Menghapus tagihan payment gateway yang masih tertunda dari pesanan.
+func (q *Queries) ClearOrderPaymentGateway(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, clearOrderPaymentGateway, id)
+	return err
+}
+
+const consumePointLot = `-- name: ConsumePointLot :exec
+UPDATE loyalty_point_entries
+SET remaining = remaining - $2
+WHERE id = $1
+`
+
+type ConsumePointLotParams struct {
+	ID        uuid.UUID `json:"id"`
+	Remaining int32     `json:"remaining"`
+}
+
+// Mengurangi sisa poin sebuah perolehan saat poinnya ditukar atau ditarik kembali.
+func (q *Queries) ConsumePointLot(ctx context.Context, arg ConsumePointLotParams) error {
+	_, err := q.db.Exec(ctx, consumePointLot, arg.ID, arg.Remaining)
+	return err
+}
+
+const copyOrderItemOptions = `-- name: CopyOrderItemOptions :exec
+INSERT INTO order_item_options (order_item_id, product_option_id, price_at_sale)
+SELECT $1::uuid, src.product_option_id, src.price_at_sale
+FROM order_item_options src
+WHERE src.order_item_id = $2::uuid
+`
+
+type CopyOrderItemOptionsParams struct {
+	TargetOrderItemID uuid.UUID `json:"target_order_item_id"`
+	SourceOrderItemID uuid.UUID `json:"source_order_item_id"`
+}
+
+// Menyalin opsi dari satu baris item ke baris item lain (dipakai saat memecah kuantitas).
+func (q *Queries) CopyOrderItemOptions(ctx context.Context, arg CopyOrderItemOptionsParams) error {
+	_, err := q.db.Exec(ctx, copyOrderItemOptions, arg.TargetOrderItemID, arg.SourceOrderItemID)
+	return err
+}
+
 const countOrders = `-- name: CountOrders :one
 SELECT count(*) FROM orders
 WHERE
@@ -207,20 +367,144 @@ func (q *Queries) CountOrders(ctx context.Context, arg CountOrdersParams) (int64
 	return count, err
 }
 
+const createIngredientStockHistory = `-- name: CreateIngredientStockHistory :one
+INSERT INTO ingredient_stock_history (
+    ingredient_id,
+    change_amount,
+    previous_stock,
+    current_stock,
+    change_type,
+    reference_id,
+    note,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING id, ingredient_id, change_amount, previous_stock, current_stock, change_type, reference_id, note, created_by, created_at
+`
+
+type CreateIngredientStockHistoryParams struct {
+	IngredientID  uuid.UUID       `json:"ingredient_id"`
+	ChangeAmount  int32           `json:"change_amount"`
+	PreviousStock int32           `json:"previous_stock"`
+	CurrentStock  int32           `json:"current_stock"`
+	ChangeType    StockChangeType `json:"change_type"`
+	ReferenceID   pgtype.UUID     `json:"reference_id"`
+	Note          *string         `json:"note"`
+	CreatedBy     pgtype.UUID     `json:"created_by"`
+}
+
+func (q *Queries) CreateIngredientStockHistory(ctx context.Context, arg CreateIngredientStockHistoryParams) (IngredientStockHistory, error) {
+	row := q.db.QueryRow(ctx, createIngredientStockHistory,
+		arg.IngredientID,
+		arg.ChangeAmount,
+		arg.PreviousStock,
+		arg.CurrentStock,
+		arg.ChangeType,
+		arg.ReferenceID,
+		arg.Note,
+		arg.CreatedBy,
+	)
+	var i IngredientStockHistory
+	err := row.Scan(
+		&i.ID,
+		&i.IngredientID,
+		&i.ChangeAmount,
+		&i.PreviousStock,
+		&i.CurrentStock,
+		&i.ChangeType,
+		&i.ReferenceID,
+		&i.Note,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createLoyaltyPointEntry = `-- name: CreateLoyaltyPointEntry :one
+INSERT INTO loyalty_point_entries (
+    customer_id,
+    order_id,
+    entry_type,
+    redeemed_as,
+    points,
+    remaining,
+    amount,
+    expires_at,
+    description,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, customer_id, order_id, entry_type, redeemed_as, points, remaining, amount, expires_at, description, created_by, created_at
+`
+
+type CreateLoyaltyPointEntryParams struct {
+	CustomerID  uuid.UUID                 `json:"customer_id"`
+	OrderID     pgtype.UUID               `json:"order_id"`
+	EntryType   LoyaltyEntryType          `json:"entry_type"`
+	RedeemedAs  NullLoyaltyRedemptionKind `json:"redeemed_as"`
+	Points      int32                     `json:"points"`
+	Remaining   int32                     `json:"remaining"`
+	Amount      int64                     `json:"amount"`
+	ExpiresAt   pgtype.Timestamptz        `json:"expires_at"`
+	Description *string                   `json:"description"`
+	CreatedBy   pgtype.UUID               `json:"created_by"`
+}
+
+// Mencatat satu baris buku poin pelanggan.
+func (q *Queries) CreateLoyaltyPointEntry(ctx context.Context, arg CreateLoyaltyPointEntryParams) (LoyaltyPointEntry, error) {
+	row := q.db.QueryRow(ctx, createLoyaltyPointEntry,
+		arg.CustomerID,
+		arg.OrderID,
+		arg.EntryType,
+		arg.RedeemedAs,
+		arg.Points,
+		arg.Remaining,
+		arg.Amount,
+		arg.ExpiresAt,
+		arg.Description,
+		arg.CreatedBy,
+	)
+	var i LoyaltyPointEntry
+	err := row.Scan(
+		&i.ID,
+		&i.CustomerID,
+		&i.OrderID,
+		&i.EntryType,
+		&i.RedeemedAs,
+		&i.Points,
+		&i.Remaining,
+		&i.Amount,
+		&i.ExpiresAt,
+		&i.Description,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createOrder = `-- name: CreateOrder :one
-INSERT INTO orders (user_id, type, customer_id )
-VALUES ($1, $2, $3 )
-RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id
+INSERT INTO orders (user_id, type, customer_id, shift_id, queue_number, business_date)
+VALUES ($1, $2, $3, (SELECT s.id FROM shifts s WHERE s.user_id = $1 AND s.status = 'open' LIMIT 1), $4, $5)
+RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id, tax_rate, service_charge_rate, tax_inclusive, shift_id, parent_order_id, queue_number, business_date, applied_promotion_code_id
 `
 
 type CreateOrderParams struct {
-	UserID     pgtype.UUID `json:"user_id"`
-	Type       OrderType   `json:"type"`
-	CustomerID pgtype.UUID `json:"customer_id"`
+	UserID       pgtype.UUID `json:"user_id"`
+	Type         OrderType   `json:"type"`
+	CustomerID   pgtype.UUID `json:"customer_id"`
+	QueueNumber  *string     `json:"queue_number"`
+	BusinessDate pgtype.Date `json:"business_date"`
 }
 
+// Pesanan otomatis ditautkan ke shift kasir yang sedang terbuka.
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
-	row := q.db.QueryRow(ctx, createOrder, arg.UserID, arg.Type, arg.CustomerID)
+	row := q.db.QueryRow(ctx, createOrder,
+		arg.UserID,
+		arg.Type,
+		arg.CustomerID,
+		arg.QueueNumber,
+		arg.BusinessDate,
+	)
 	var i Order
 	err := row.Scan(
 		&i.ID,
@@ -245,6 +529,14 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order
 		&i.TaxAmount,
 		&i.ServiceChargeAmount,
 		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
 	)
 	return i, err
 }
@@ -257,10 +549,11 @@ INSERT INTO order_items (
     price_at_sale,
     subtotal,
     net_subtotal,
-    cost_price_at_sale
+    cost_price_at_sale,
+    variant_id
 ) VALUES (
-             $1, $2, $3, $4, $5, $6, $7
-         ) RETURNING id, order_id, product_id, quantity, price_at_sale, subtotal, discount_amount, net_subtotal, cost_price_at_sale
+             $1, $2, $3, $4, $5, $6, $7, $8
+         ) RETURNING id, order_id, product_id, quantity, price_at_sale, subtotal, discount_amount, net_subtotal, cost_price_at_sale, station_id, prep_status, is_priority, queued_at, started_at, ready_at, served_at, variant_id
 `
 
 type CreateOrderItemParams struct {
@@ -271,6 +564,7 @@ type CreateOrderItemParams struct {
 	Subtotal        int64          `json:"subtotal"`
 	NetSubtotal     int64          `json:"net_subtotal"`
 	CostPriceAtSale pgtype.Numeric `json:"cost_price_at_sale"`
+	VariantID       pgtype.UUID    `json:"variant_id"`
 }
 
 // Menambahkan satu item produk ke dalam pesanan.
@@ -283,6 +577,7 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		arg.Subtotal,
 		arg.NetSubtotal,
 		arg.CostPriceAtSale,
+		arg.VariantID,
 	)
 	var i OrderItem
 	err := row.Scan(
@@ -295,6 +590,14 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		&i.DiscountAmount,
 		&i.NetSubtotal,
 		&i.CostPriceAtSale,
+		&i.StationID,
+		&i.PrepStatus,
+		&i.IsPriority,
+		&i.QueuedAt,
+		&i.StartedAt,
+		&i.ReadyAt,
+		&i.ServedAt,
+		&i.VariantID,
 	)
 	return i, err
 }
@@ -328,129 +631,602 @@ func (q *Queries) CreateOrderItemOption(ctx context.Context, arg CreateOrderItem
 	return i, err
 }
 
-const createStockHistory = `-- name: CreateStockHistory :one
-INSERT INTO stock_history (
-    product_id,
-    change_amount,
-    previous_stock,
-    current_stock,
-    change_type,
-    reference_id,
-    note,
-    created_by
+const createOrderPayment = `-- name: CreateOrderPayment :one
+INSERT INTO order_payments (
+    order_id, payment_method_id, amount, tendered_amount, change_amount, reference_number, shift_id, created_by
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, product_id, change_amount, previous_stock, current_stock, change_type, reference_id, note, created_by, created_at
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    COALESCE(
+        (SELECT s.id FROM shifts s WHERE s.user_id = $7 AND s.status = 'open' LIMIT 1),
+        $8::uuid
+    ),
+    $7
+) RETURNING id, order_id, payment_method_id, amount, tendered_amount, change_amount, reference_number, shift_id, created_by, created_at
 `
 
-type CreateStockHistoryParams struct {
-	ProductID     uuid.UUID       `json:"product_id"`
-	ChangeAmount  int32           `json:"change_amount"`
-	PreviousStock int32           `json:"previous_stock"`
-	CurrentStock  int32           `json:"current_stock"`
-	ChangeType    StockChangeType `json:"change_type"`
-	ReferenceID   pgtype.UUID     `json:"reference_id"`
-	Note          *string         `json:"note"`
-	CreatedBy     pgtype.UUID     `json:"created_by"`
+type CreateOrderPaymentParams struct {
+	OrderID         uuid.UUID   `json:"order_id"`
+	PaymentMethodID int32       `json:"payment_method_id"`
+	Amount          int64       `json:"amount"`
+	TenderedAmount  int64       `json:"tendered_amount"`
+	ChangeAmount    int64       `json:"change_amount"`
+	ReferenceNumber *string     `json:"reference_number"`
+	CreatedBy       pgtype.UUID `json:"created_by"`
+	OrderShiftID    pgtype.UUID `json:"order_shift_id"`
 }
 
-func (q *Queries) CreateStockHistory(ctx context.Context, arg CreateStockHistoryParams) (StockHistory, error) {
-	row := q.db.QueryRow(ctx, createStockHistory,
-		arg.ProductID,
+// Mencatat satu baris tender (split payment) pada shift kasir yang memprosesnya (atau shift asal pesanan).
+func (q *Queries) CreateOrderPayment(ctx context.Context, arg CreateOrderPaymentParams) (OrderPayment, error) {
+	row := q.db.QueryRow(ctx, createOrderPayment,
+		arg.OrderID,
+		arg.PaymentMethodID,
+		arg.Amount,
+		arg.TenderedAmount,
 		arg.ChangeAmount,
-		arg.PreviousStock,
-		arg.CurrentStock,
-		arg.ChangeType,
-		arg.ReferenceID,
-		arg.Note,
+		arg.ReferenceNumber,
 		arg.CreatedBy,
+		arg.OrderShiftID,
 	)
-	var i StockHistory
+	var i OrderPayment
 	err := row.Scan(
 		&i.ID,
-		&i.ProductID,
+		&i.OrderID,
+		&i.PaymentMethodID,
+		&i.Amount,
+		&i.TenderedAmount,
 		&i.ChangeAmount,
-		&i.PreviousStock,
-		&i.CurrentStock,
-		&i.ChangeType,
-		&i.ReferenceID,
-		&i.Note,
+		&i.ReferenceNumber,
+		&i.ShiftID,
 		&i.CreatedBy,
 		&i.CreatedAt,
 	)
 	return i, err
 }
 
-const decreaseProductStock = `-- name: DecreaseProductStock :one
-UPDATE products
-SET stock = stock - $2
-WHERE id = $1
-RETURNING id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price
+const createOrderPromotion = `-- name: CreateOrderPromotion :exec
+INSERT INTO order_promotions (order_id, promotion_id, promotion_code_id, discount_amount, auto_applied, position, schedule_window)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 `
 
-type DecreaseProductStockParams struct {
-	ID    uuid.UUID `json:"id"`
-	Stock int32     `json:"stock"`
+type CreateOrderPromotionParams struct {
+	OrderID         uuid.UUID   `json:"order_id"`
+	PromotionID     uuid.UUID   `json:"promotion_id"`
+	PromotionCodeID pgtype.UUID `json:"promotion_code_id"`
+	DiscountAmount  int64       `json:"discount_amount"`
+	AutoApplied     bool        `json:"auto_applied"`
+	Position        int32       `json:"position"`
+	ScheduleWindow  *string     `json:"schedule_window"`
 }
 
-// Mengurangi stok produk.
-func (q *Queries) DecreaseProductStock(ctx context.Context, arg DecreaseProductStockParams) (Product, error) {
-	row := q.db.QueryRow(ctx, decreaseProductStock, arg.ID, arg.Stock)
-	var i Product
+// Menyimpan satu promosi yang diterapkan pada pesanan beserta bagian diskonnya dan jendela jadwal saat diterapkan.
+func (q *Queries) CreateOrderPromotion(ctx context.Context, arg CreateOrderPromotionParams) error {
+	_, err := q.db.Exec(ctx, createOrderPromotion,
+		arg.OrderID,
+		arg.PromotionID,
+		arg.PromotionCodeID,
+		arg.DiscountAmount,
+		arg.AutoApplied,
+		arg.Position,
+		arg.ScheduleWindow,
+	)
+	return err
+}
+
+const createOrderRefund = `-- name: CreateOrderRefund :one
+INSERT INTO order_refunds (
+    order_id, shift_id, payment_method_id, order_payment_id, amount, reason, created_by
+) VALUES (
+    $1,
+    COALESCE(
+        (SELECT s.id FROM shifts s WHERE s.user_id = $2 AND s.status = 'open' LIMIT 1),
+        $3::uuid
+    ),
+    $4,
+    $5,
+    $6,
+    $7,
+    $2
+) RETURNING id, order_id, shift_id, payment_method_id, amount, reason, created_by, created_at, order_payment_id
+`
+
+type CreateOrderRefundParams struct {
+	OrderID         uuid.UUID   `json:"order_id"`
+	CreatedBy       pgtype.UUID `json:"created_by"`
+	OrderShiftID    pgtype.UUID `json:"order_shift_id"`
+	PaymentMethodID *int32      `json:"payment_method_id"`
+	OrderPaymentID  pgtype.UUID `json:"order_payment_id"`
+	Amount          int64       `json:"amount"`
+	Reason          *string     `json:"reason"`
+}
+
+// Mencatat pengembalian dana pada shift kasir yang memprosesnya (atau shift asal pesanan).
+func (q *Queries) CreateOrderRefund(ctx context.Context, arg CreateOrderRefundParams) (OrderRefund, error) {
+	row := q.db.QueryRow(ctx, createOrderRefund,
+		arg.OrderID,
+		arg.CreatedBy,
+		arg.OrderShiftID,
+		arg.PaymentMethodID,
+		arg.OrderPaymentID,
+		arg.Amount,
+		arg.Reason,
+	)
+	var i OrderRefund
 	err := row.Scan(
 		&i.ID,
-		&i.Name,
-		&i.ImageUrl,
-		&i.Price,
-		&i.Stock,
+		&i.OrderID,
+		&i.ShiftID,
+		&i.PaymentMethodID,
+		&i.Amount,
+		&i.Reason,
+		&i.CreatedBy,
 		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
-		&i.CostPrice,
+		&i.OrderPaymentID,
 	)
 	return i, err
 }
 
-const deleteOrderItem = `-- name: DeleteOrderItem :exec
-DELETE FROM order_items WHERE id = $1 AND order_id = $2
+const createOrderRefundItem = `-- name: CreateOrderRefundItem :one
+INSERT INTO order_refund_items (
+    refund_id, order_item_id, quantity, amount, restocked
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, refund_id, order_item_id, quantity, amount, restocked, created_at
 `
 
-type DeleteOrderItemParams struct {
-	ID      uuid.UUID `json:"id"`
-	OrderID uuid.UUID `json:"order_id"`
+type CreateOrderRefundItemParams struct {
+	RefundID    uuid.UUID `json:"refund_id"`
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	Quantity    int32     `json:"quantity"`
+	Amount      int64     `json:"amount"`
+	Restocked   bool      `json:"restocked"`
 }
 
-// Menghapus satu item dari pesanan.
-func (q *Queries) DeleteOrderItem(ctx context.Context, arg DeleteOrderItemParams) error {
-	_, err := q.db.Exec(ctx, deleteOrderItem, arg.ID, arg.OrderID)
-	return err
+// Mencatat baris item (dan jumlahnya) yang dikembalikan dalam satu refund.
+func (q *Queries) CreateOrderRefundItem(ctx context.Context, arg CreateOrderRefundItemParams) (OrderRefundItem, error) {
+	row := q.db.QueryRow(ctx, createOrderRefundItem,
+		arg.RefundID,
+		arg.OrderItemID,
+		arg.Quantity,
+		arg.Amount,
+		arg.Restocked,
+	)
+	var i OrderRefundItem
+	err := row.Scan(
+		&i.ID,
+		&i.RefundID,
+		&i.OrderItemID,
+		&i.Quantity,
+		&i.Amount,
+		&i.Restocked,
+		&i.CreatedAt,
+	)
+	return i, err
 }
 
-const deleteOrderItemOptionsByOrderItemID = `-- name: DeleteOrderItemOptionsByOrderItemID :exec
-DELETE FROM order_item_options WHERE order_item_id = $1
+const createOrderStatusHistory = `-- name: CreateOrderStatusHistory :exec
+INSERT INTO order_status_history (
+    order_id, from_status, to_status, changed_by, note
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+)
 `
 
-func (q *Queries) DeleteOrderItemOptionsByOrderItemID(ctx context.Context, orderItemID uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteOrderItemOptionsByOrderItemID, orderItemID)
+type CreateOrderStatusHistoryParams struct {
+	OrderID    uuid.UUID       `json:"order_id"`
+	FromStatus NullOrderStatus `json:"from_status"`
+	ToStatus   OrderStatus     `json:"to_status"`
+	ChangedBy  pgtype.UUID     `json:"changed_by"`
+	Note       *string         `json:"note"`
+}
+
+// Mencatat satu transisi status pesanan (from_status NULL untuk pesanan baru).
+func (q *Queries) CreateOrderStatusHistory(ctx context.Context, arg CreateOrderStatusHistoryParams) error {
+	_, err := q.db.Exec(ctx, createOrderStatusHistory,
+		arg.OrderID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.ChangedBy,
+		arg.Note,
+	)
 	return err
 }
 
-const deleteOrderItemsByOrderID = `-- name: DeleteOrderItemsByOrderID :exec
-DELETE FROM order_items WHERE order_id = $1
+const createPromotionRedemption = `-- name: CreatePromotionRedemption :exec
+INSERT INTO promotion_redemptions (promotion_id, promotion_code_id, order_id, customer_id, discount_amount)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (order_id, promotion_id) WHERE status = 'redeemed' DO NOTHING
 `
 
-func (q *Queries) DeleteOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteOrderItemsByOrderID, orderID)
+type CreatePromotionRedemptionParams struct {
+	PromotionID     uuid.UUID   `json:"promotion_id"`
+	PromotionCodeID pgtype.UUID `json:"promotion_code_id"`
+	OrderID         uuid.UUID   `json:"order_id"`
+	CustomerID      pgtype.UUID `json:"customer_id"`
+	DiscountAmount  int64       `json:"discount_amount"`
+}
+
+// Mencatat penukaran promosi saat pesanan lunas; promosi pesanan yang sudah tercatat dilewati.
+func (q *Queries) CreatePromotionRedemption(ctx context.Context, arg CreatePromotionRedemptionParams) error {
+	_, err := q.db.Exec(ctx, createPromotionRedemption,
+		arg.PromotionID,
+		arg.PromotionCodeID,
+		arg.OrderID,
+		arg.CustomerID,
+		arg.DiscountAmount,
+	)
 	return err
 }
 
-const getOptionsForProducts = `-- name: GetOptionsForProducts :many
-SELECT id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at FROM product_options
-WHERE product_id = ANY($1::uuid[])
+const createSplitOrder = `-- name: CreateSplitOrder :one
+INSERT INTO orders (user_id, type, customer_id, shift_id, parent_order_id, queue_number, business_date)
+SELECT p.user_id, p.type, p.customer_id, p.shift_id, COALESCE(p.parent_order_id, p.id), p.queue_number, p.business_date
+FROM orders p
+WHERE p.id = $1
+RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id, tax_rate, service_charge_rate, tax_inclusive, shift_id, parent_order_id, queue_number, business_date, applied_promotion_code_id
 `
 
-// Mengambil semua varian untuk beberapa produk.
-func (q *Queries) GetOptionsForProducts(ctx context.Context, dollar_1 []uuid.UUID) ([]ProductOption, error) {
+// Membuat pesanan anak hasil split bill; kasir, jenis, pelanggan, shift, dan nomor antrian mengikuti pesanan induk.
+// Bill yang dipecah lagi tetap menjadi anak pesanan asalnya.
+func (q *Queries) CreateSplitOrder(ctx context.Context, id uuid.UUID) (Order, error) {
+	row := q.db.QueryRow(ctx, createSplitOrder, id)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.GrossTotal,
+		&i.DiscountAmount,
+		&i.NetTotal,
+		&i.AppliedPromotionID,
+		&i.PaymentMethodID,
+		&i.PaymentGatewayReference,
+		&i.CashReceived,
+		&i.ChangeDue,
+		&i.CancellationReasonID,
+		&i.CancellationNotes,
+		&i.PaymentUrl,
+		&i.PaymentToken,
+		&i.Version,
+		&i.TaxAmount,
+		&i.ServiceChargeAmount,
+		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
+	)
+	return i, err
+}
+
+const createStockHistory = `-- name: CreateStockHistory :one
+INSERT INTO stock_history (
+    product_id,
+    change_amount,
+    previous_stock,
+    current_stock,
+    change_type,
+    reference_id,
+    note,
+    created_by,
+    variant_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, product_id, change_amount, previous_stock, current_stock, change_type, reference_id, note, created_by, created_at, variant_id
+`
+
+type CreateStockHistoryParams struct {
+	ProductID     uuid.UUID       `json:"product_id"`
+	ChangeAmount  int32           `json:"change_amount"`
+	PreviousStock int32           `json:"previous_stock"`
+	CurrentStock  int32           `json:"current_stock"`
+	ChangeType    StockChangeType `json:"change_type"`
+	ReferenceID   pgtype.UUID     `json:"reference_id"`
+	Note          *string         `json:"note"`
+	CreatedBy     pgtype.UUID     `json:"created_by"`
+	VariantID     pgtype.UUID     `json:"variant_id"`
+}
+
+func (q *Queries) CreateStockHistory(ctx context.Context, arg CreateStockHistoryParams) (StockHistory, error) {
+	row := q.db.QueryRow(ctx, createStockHistory,
+		arg.ProductID,
+		arg.ChangeAmount,
+		arg.PreviousStock,
+		arg.CurrentStock,
+		arg.ChangeType,
+		arg.ReferenceID,
+		arg.Note,
+		arg.CreatedBy,
+		arg.VariantID,
+	)
+	var i StockHistory
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.ChangeAmount,
+		&i.PreviousStock,
+		&i.CurrentStock,
+		&i.ChangeType,
+		&i.ReferenceID,
+		&i.Note,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.VariantID,
+	)
+	return i, err
+}
+
+const decreaseProductStock = `-- name: DecreaseProductStock :one
+UPDATE products
+SET stock = stock - $2
+WHERE id = $1
+RETURNING id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, sku
+`
+
+type DecreaseProductStockParams struct {
+	ID    uuid.UUID `json:"id"`
+	Stock int32     `json:"stock"`
+}
+
+// Mengurangi stok produk.
+func (q *Queries) DecreaseProductStock(ctx context.Context, arg DecreaseProductStockParams) (Product, error) {
+	row := q.db.QueryRow(ctx, decreaseProductStock, arg.ID, arg.Stock)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ImageUrl,
+		&i.Price,
+		&i.Stock,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CostPrice,
+		&i.Sku,
+	)
+	return i, err
+}
+
+const decreaseVariantStock = `-- name: DecreaseVariantStock :one
+UPDATE product_variants
+SET stock = stock - $1::int
+WHERE id = $2
+RETURNING stock
+`
+
+type DecreaseVariantStockParams struct {
+	Quantity int32     `json:"quantity"`
+	ID       uuid.UUID `json:"id"`
+}
+
+// Mengurangi stok varian; stok produk induk diperbarui oleh trigger.
+func (q *Queries) DecreaseVariantStock(ctx context.Context, arg DecreaseVariantStockParams) (int32, error) {
+	row := q.db.QueryRow(ctx, decreaseVariantStock, arg.Quantity, arg.ID)
+	var stock int32
+	err := row.Scan(&stock)
+	return stock, err
+}
+
+const deleteOrderItem = `-- name: DeleteOrderItem :exec
+DELETE FROM order_items WHERE id = $1 AND order_id = $2
+`
+
+type DeleteOrderItemParams struct {
+	ID      uuid.UUID `json:"id"`
+	OrderID uuid.UUID `json:"order_id"`
+}
+
+// Menghapus satu item dari pesanan.
+func (q *Queries) DeleteOrderItem(ctx context.Context, arg DeleteOrderItemParams) error {
+	_, err := q.db.Exec(ctx, deleteOrderItem, arg.ID, arg.OrderID)
+	return err
+}
+
+const deleteOrderItemOptionsByOrderItemID = `-- name: DeleteOrderItemOptionsByOrderItemID :exec
+DELETE FROM order_item_options WHERE order_item_id = $1
+`
+
+func (q *Queries) DeleteOrderItemOptionsByOrderItemID(ctx context.Context, orderItemID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteOrderItemOptionsByOrderItemID, orderItemID)
+	return err
+}
+
+const deleteOrderItemsByOrderID = `-- name: DeleteOrderItemsByOrderID :exec
+DELETE FROM order_items WHERE order_id = $1
+`
+
+func (q *Queries) DeleteOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteOrderItemsByOrderID, orderID)
+	return err
+}
+
+const deleteOrderPromotions = `-- name: DeleteOrderPromotions :exec
+DELETE FROM order_promotions
+WHERE order_id = $1
+`
+
+// Menghapus promosi pesanan sebelum hasil evaluasi terbaru disimpan.
+func (q *Queries) DeleteOrderPromotions(ctx context.Context, orderID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteOrderPromotions, orderID)
+	return err
+}
+
+const getActivePaymentMethodByKind = `-- name: GetActivePaymentMethodByKind :one
+SELECT id, name, is_active, created_at, updated_at, kind, sort_order, opens_cash_drawer, requires_reference, allows_change FROM payment_methods
+WHERE kind = $1 AND is_active = true
+ORDER BY sort_order, id
+LIMIT 1
+`
+
+// Mengambil metode pembayaran aktif pertama untuk jenis tertentu (mis. 'gateway' untuk Midtrans).
+func (q *Queries) GetActivePaymentMethodByKind(ctx context.Context, kind PaymentMethodKind) (PaymentMethod, error) {
+	row := q.db.QueryRow(ctx, getActivePaymentMethodByKind, kind)
+	var i PaymentMethod
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Kind,
+		&i.SortOrder,
+		&i.OpensCashDrawer,
+		&i.RequiresReference,
+		&i.AllowsChange,
+	)
+	return i, err
+}
+
+const getActivePromotionCode = `-- name: GetActivePromotionCode :one
+SELECT id, promotion_id, code, batch_id, max_uses, created_at, deactivated_at FROM promotion_codes
+WHERE code = upper($1) AND deactivated_at IS NULL
+LIMIT 1
+`
+
+// Mencari kode promosi yang masih aktif (tidak peka huruf besar/kecil).
+func (q *Queries) GetActivePromotionCode(ctx context.Context, code string) (PromotionCode, error) {
+	row := q.db.QueryRow(ctx, getActivePromotionCode, code)
+	var i PromotionCode
+	err := row.Scan(
+		&i.ID,
+		&i.PromotionID,
+		&i.Code,
+		&i.BatchID,
+		&i.MaxUses,
+		&i.CreatedAt,
+		&i.DeactivatedAt,
+	)
+	return i, err
+}
+
+const getCancellationReasonByReason = `-- name: GetCancellationReasonByReason :one
+SELECT id, reason, description, is_active, created_at, updated_at FROM cancellation_reasons
+WHERE reason = $1
+LIMIT 1
+`
+
+// Mengambil alasan pembatalan sistem (mis. 'Order Merged') berdasarkan teksnya.
+func (q *Queries) GetCancellationReasonByReason(ctx context.Context, reason string) (CancellationReason, error) {
+	row := q.db.QueryRow(ctx, getCancellationReasonByReason, reason)
+	var i CancellationReason
+	err := row.Scan(
+		&i.ID,
+		&i.Reason,
+		&i.Description,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCustomerPointsBalance = `-- name: GetCustomerPointsBalance :one
+SELECT (
+    COALESCE(SUM(points), 0) - COALESCE(SUM(remaining) FILTER (WHERE expires_at <= NOW()), 0)
+)::bigint AS balance
+FROM loyalty_point_entries
+WHERE customer_id = $1
+`
+
+// Menghitung saldo poin pelanggan; sisa poin yang sudah kedaluwarsa tidak dihitung walau belum dicatat.
+func (q *Queries) GetCustomerPointsBalance(ctx context.Context, customerID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, getCustomerPointsBalance, customerID)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const getIngredientsForUpdate = `-- name: GetIngredientsForUpdate :many
+SELECT id, name, unit, stock, low_stock_threshold, cost_per_unit, created_at, updated_at, deleted_at FROM ingredients
+WHERE id = ANY($1::uuid[]) AND deleted_at IS NULL
+ORDER BY id
+FOR UPDATE
+`
+
+// Mengunci bahan baku yang dipakai pesanan; urutan id mencegah deadlock antar transaksi.
+func (q *Queries) GetIngredientsForUpdate(ctx context.Context, ids []uuid.UUID) ([]Ingredient, error) {
+	rows, err := q.db.Query(ctx, getIngredientsForUpdate, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Ingredient{}
+	for rows.Next() {
+		var i Ingredient
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Unit,
+			&i.Stock,
+			&i.LowStockThreshold,
+			&i.CostPerUnit,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOptionRecipes = `-- name: GetOptionRecipes :many
+SELECT product_option_id, ingredient_id, quantity, replaces_ingredient_id FROM product_option_recipe_items
+WHERE product_option_id = ANY($1::uuid[])
+`
+
+// Mengambil tambahan/pengganti bahan baku untuk beberapa opsi produk.
+func (q *Queries) GetOptionRecipes(ctx context.Context, optionIds []uuid.UUID) ([]ProductOptionRecipeItem, error) {
+	rows, err := q.db.Query(ctx, getOptionRecipes, optionIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductOptionRecipeItem{}
+	for rows.Next() {
+		var i ProductOptionRecipeItem
+		if err := rows.Scan(
+			&i.ProductOptionID,
+			&i.IngredientID,
+			&i.Quantity,
+			&i.ReplacesIngredientID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOptionsForProducts = `-- name: GetOptionsForProducts :many
+SELECT id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at, modifier_group_id, is_default FROM product_options
+WHERE product_id = ANY($1::uuid[])
+`
+
+// Mengambil semua varian untuk beberapa produk.
+func (q *Queries) GetOptionsForProducts(ctx context.Context, dollar_1 []uuid.UUID) ([]ProductOption, error) {
 	rows, err := q.db.Query(ctx, getOptionsForProducts, dollar_1)
 	if err != nil {
 		return nil, err
@@ -468,6 +1244,8 @@ func (q *Queries) GetOptionsForProducts(ctx context.Context, dollar_1 []uuid.UUI
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.ModifierGroupID,
+			&i.IsDefault,
 		); err != nil {
 			return nil, err
 		}
@@ -480,7 +1258,7 @@ func (q *Queries) GetOptionsForProducts(ctx context.Context, dollar_1 []uuid.UUI
 }
 
 const getOrderByGatewayRef = `-- name: GetOrderByGatewayRef :one
-SELECT id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id FROM orders
+SELECT id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id, tax_rate, service_charge_rate, tax_inclusive, shift_id, parent_order_id, queue_number, business_date, applied_promotion_code_id FROM orders
 WHERE payment_gateway_reference = $1
 LIMIT 1
 `
@@ -512,21 +1290,27 @@ func (q *Queries) GetOrderByGatewayRef(ctx context.Context, paymentGatewayRefere
 		&i.TaxAmount,
 		&i.ServiceChargeAmount,
 		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
 	)
 	return i, err
 }
 
-const getOrderForUpdate = `-- name: GetOrderForUpdate :one
-SELECT id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id FROM orders
+const getOrder = `-- name: GetOrder :one
+SELECT id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id, tax_rate, service_charge_rate, tax_inclusive, shift_id, parent_order_id, queue_number, business_date, applied_promotion_code_id FROM orders
 WHERE id = $1
 LIMIT 1
-    FOR UPDATE
 `
 
-// Mengambil satu pesanan dan mengunci barisnya untuk pembaruan (mencegah race condition).
-// Penting untuk digunakan di dalam transaksi sebelum mengupdate total.
-func (q *Queries) GetOrderForUpdate(ctx context.Context, id uuid.UUID) (Order, error) {
-	row := q.db.QueryRow(ctx, getOrderForUpdate, id)
+// Mengambil satu pesanan tanpa mengunci barisnya, untuk pembacaan saja.
+func (q *Queries) GetOrder(ctx context.Context, id uuid.UUID) (Order, error) {
+	row := q.db.QueryRow(ctx, getOrder, id)
 	var i Order
 	err := row.Scan(
 		&i.ID,
@@ -551,17 +1335,72 @@ func (q *Queries) GetOrderForUpdate(ctx context.Context, id uuid.UUID) (Order, e
 		&i.TaxAmount,
 		&i.ServiceChargeAmount,
 		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
 	)
 	return i, err
 }
 
-const getOrderItem = `-- name: GetOrderItem :one
-SELECT id, order_id, product_id, quantity, price_at_sale, subtotal, discount_amount, net_subtotal, cost_price_at_sale FROM order_items WHERE id = $1 AND order_id = $2
+const getOrderForUpdate = `-- name: GetOrderForUpdate :one
+SELECT id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id, tax_rate, service_charge_rate, tax_inclusive, shift_id, parent_order_id, queue_number, business_date, applied_promotion_code_id FROM orders
+WHERE id = $1
+LIMIT 1
+    FOR UPDATE
 `
 
-type GetOrderItemParams struct {
-	ID      uuid.UUID `json:"id"`
-	OrderID uuid.UUID `json:"order_id"`
+// Mengambil satu pesanan dan mengunci barisnya untuk pembaruan (mencegah race condition).
+// Penting untuk digunakan di dalam transaksi sebelum mengupdate total.
+func (q *Queries) GetOrderForUpdate(ctx context.Context, id uuid.UUID) (Order, error) {
+	row := q.db.QueryRow(ctx, getOrderForUpdate, id)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.GrossTotal,
+		&i.DiscountAmount,
+		&i.NetTotal,
+		&i.AppliedPromotionID,
+		&i.PaymentMethodID,
+		&i.PaymentGatewayReference,
+		&i.CashReceived,
+		&i.ChangeDue,
+		&i.CancellationReasonID,
+		&i.CancellationNotes,
+		&i.PaymentUrl,
+		&i.PaymentToken,
+		&i.Version,
+		&i.TaxAmount,
+		&i.ServiceChargeAmount,
+		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
+	)
+	return i, err
+}
+
+const getOrderItem = `-- name: GetOrderItem :one
+SELECT id, order_id, product_id, quantity, price_at_sale, subtotal, discount_amount, net_subtotal, cost_price_at_sale, station_id, prep_status, is_priority, queued_at, started_at, ready_at, served_at, variant_id FROM order_items WHERE id = $1 AND order_id = $2
+`
+
+type GetOrderItemParams struct {
+	ID      uuid.UUID `json:"id"`
+	OrderID uuid.UUID `json:"order_id"`
 }
 
 // Mengambil satu item pesanan untuk validasi sebelum update/delete.
@@ -578,12 +1417,20 @@ func (q *Queries) GetOrderItem(ctx context.Context, arg GetOrderItemParams) (Ord
 		&i.DiscountAmount,
 		&i.NetSubtotal,
 		&i.CostPriceAtSale,
+		&i.StationID,
+		&i.PrepStatus,
+		&i.IsPriority,
+		&i.QueuedAt,
+		&i.StartedAt,
+		&i.ReadyAt,
+		&i.ServedAt,
+		&i.VariantID,
 	)
 	return i, err
 }
 
 const getOrderItemsByOrderID = `-- name: GetOrderItemsByOrderID :many
-SELECT id, order_id, product_id, quantity, price_at_sale, subtotal, discount_amount, net_subtotal, cost_price_at_sale FROM order_items WHERE order_id = $1
+SELECT id, order_id, product_id, quantity, price_at_sale, subtotal, discount_amount, net_subtotal, cost_price_at_sale, station_id, prep_status, is_priority, queued_at, started_at, ready_at, served_at, variant_id FROM order_items WHERE order_id = $1
 `
 
 // Mengambil semua item dari sebuah pesanan untuk menghitung ulang total.
@@ -606,6 +1453,111 @@ func (q *Queries) GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID)
 			&i.DiscountAmount,
 			&i.NetSubtotal,
 			&i.CostPriceAtSale,
+			&i.StationID,
+			&i.PrepStatus,
+			&i.IsPriority,
+			&i.QueuedAt,
+			&i.StartedAt,
+			&i.ReadyAt,
+			&i.ServedAt,
+			&i.VariantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrderLoyaltySummary = `-- name: GetOrderLoyaltySummary :one
+SELECT
+    COALESCE(SUM(points) FILTER (WHERE entry_type = 'earn'), 0)::bigint AS points_earned,
+    COALESCE(-SUM(points) FILTER (WHERE entry_type = 'reversal' AND redeemed_as IS NULL), 0)::bigint AS points_earned_reversed,
+    COALESCE(-SUM(points) FILTER (WHERE redeemed_as = 'discount'), 0)::bigint AS discount_points,
+    COALESCE(SUM(CASE WHEN entry_type = 'redeem' THEN amount ELSE -amount END) FILTER (WHERE redeemed_as = 'discount'), 0)::bigint AS discount_amount,
+    COALESCE(-SUM(points) FILTER (WHERE redeemed_as = 'tender'), 0)::bigint AS tender_points,
+    COALESCE(SUM(CASE WHEN entry_type = 'redeem' THEN amount ELSE -amount END) FILTER (WHERE redeemed_as = 'tender'), 0)::bigint AS tender_amount
+FROM loyalty_point_entries
+WHERE order_id = $1
+`
+
+type GetOrderLoyaltySummaryRow struct {
+	PointsEarned         int64 `json:"points_earned"`
+	PointsEarnedReversed int64 `json:"points_earned_reversed"`
+	DiscountPoints       int64 `json:"discount_points"`
+	DiscountAmount       int64 `json:"discount_amount"`
+	TenderPoints         int64 `json:"tender_points"`
+	TenderAmount         int64 `json:"tender_amount"`
+}
+
+// Merangkum poin sebuah pesanan: yang diperoleh dan yang sudah ditarik kembali, serta yang ditukar sebagai diskon atau pembayaran.
+func (q *Queries) GetOrderLoyaltySummary(ctx context.Context, orderID pgtype.UUID) (GetOrderLoyaltySummaryRow, error) {
+	row := q.db.QueryRow(ctx, getOrderLoyaltySummary, orderID)
+	var i GetOrderLoyaltySummaryRow
+	err := row.Scan(
+		&i.PointsEarned,
+		&i.PointsEarnedReversed,
+		&i.DiscountPoints,
+		&i.DiscountAmount,
+		&i.TenderPoints,
+		&i.TenderAmount,
+	)
+	return i, err
+}
+
+const getOrderPromotions = `-- name: GetOrderPromotions :many
+SELECT
+    op.order_id, op.promotion_id, op.promotion_code_id, op.discount_amount, op.auto_applied, op.position, op.created_at, op.schedule_window,
+    p.name AS promotion_name, p.stacking, p.priority,
+    pc.code
+FROM order_promotions op
+JOIN promotions p ON p.id = op.promotion_id
+LEFT JOIN promotion_codes pc ON pc.id = op.promotion_code_id
+WHERE op.order_id = $1
+ORDER BY op.position
+`
+
+type GetOrderPromotionsRow struct {
+	OrderID         uuid.UUID          `json:"order_id"`
+	PromotionID     uuid.UUID          `json:"promotion_id"`
+	PromotionCodeID pgtype.UUID        `json:"promotion_code_id"`
+	DiscountAmount  int64              `json:"discount_amount"`
+	AutoApplied     bool               `json:"auto_applied"`
+	Position        int32              `json:"position"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	ScheduleWindow  *string            `json:"schedule_window"`
+	PromotionName   string             `json:"promotion_name"`
+	Stacking        PromotionStacking  `json:"stacking"`
+	Priority        int32              `json:"priority"`
+	Code            *string            `json:"code"`
+}
+
+// Mengambil promosi yang diterapkan pada pesanan beserta bagian diskonnya, sesuai urutan penerapan.
+func (q *Queries) GetOrderPromotions(ctx context.Context, orderID uuid.UUID) ([]GetOrderPromotionsRow, error) {
+	rows, err := q.db.Query(ctx, getOrderPromotions, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOrderPromotionsRow{}
+	for rows.Next() {
+		var i GetOrderPromotionsRow
+		if err := rows.Scan(
+			&i.OrderID,
+			&i.PromotionID,
+			&i.PromotionCodeID,
+			&i.DiscountAmount,
+			&i.AutoApplied,
+			&i.Position,
+			&i.CreatedAt,
+			&i.ScheduleWindow,
+			&i.PromotionName,
+			&i.Stacking,
+			&i.Priority,
+			&i.Code,
 		); err != nil {
 			return nil, err
 		}
@@ -619,18 +1571,52 @@ func (q *Queries) GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID)
 
 const getOrderWithDetails = `-- name: GetOrderWithDetails :one
 SELECT
-    o.id, o.user_id, o.type, o.status, o.created_at, o.updated_at, o.gross_total, o.discount_amount, o.net_total, o.applied_promotion_id, o.payment_method_id, o.payment_gateway_reference, o.cash_received, o.change_due, o.cancellation_reason_id, o.cancellation_notes, o.payment_url, o.payment_token, o.version, o.tax_amount, o.service_charge_amount, o.customer_id,
+    o.id, o.user_id, o.type, o.status, o.created_at, o.updated_at, o.gross_total, o.discount_amount, o.net_total, o.applied_promotion_id, o.payment_method_id, o.payment_gateway_reference, o.cash_received, o.change_due, o.cancellation_reason_id, o.cancellation_notes, o.payment_url, o.payment_token, o.version, o.tax_amount, o.service_charge_amount, o.customer_id, o.tax_rate, o.service_charge_rate, o.tax_inclusive, o.shift_id, o.parent_order_id, o.queue_number, o.business_date, o.applied_promotion_code_id,
     COALESCE(
             (SELECT json_agg(items)
              FROM (
                       SELECT
-                          oi.id, oi.order_id, oi.product_id, oi.quantity, oi.price_at_sale, oi.subtotal, oi.discount_amount, oi.net_subtotal, oi.cost_price_at_sale,
+                          oi.id, oi.order_id, oi.product_id, oi.quantity, oi.price_at_sale, oi.subtotal, oi.discount_amount, oi.net_subtotal, oi.cost_price_at_sale, oi.station_id, oi.prep_status, oi.is_priority, oi.queued_at, oi.started_at, oi.ready_at, oi.served_at, oi.variant_id,
                           (SELECT json_agg(oio.*) FROM order_item_options oio WHERE oio.order_item_id = oi.id) AS options
                       FROM order_items oi
                       WHERE oi.order_id = o.id
                   ) AS items),
             '[]'::json
-    ) AS items
+    ) AS items,
+    COALESCE(
+            (SELECT json_agg(payments ORDER BY payments.created_at, payments.id)
+             FROM (
+                      SELECT
+                          op.id, op.order_id, op.payment_method_id, op.amount, op.tendered_amount, op.change_amount, op.reference_number, op.shift_id, op.created_by, op.created_at,
+                          pm.name AS payment_method_name
+                      FROM order_payments op
+                      JOIN payment_methods pm ON pm.id = op.payment_method_id
+                      WHERE op.order_id = o.id
+                  ) AS payments),
+            '[]'::json
+    ) AS payments,
+    ARRAY(
+        SELECT c.id FROM orders c
+        WHERE c.parent_order_id = o.id
+        ORDER BY c.created_at, c.id
+    )::uuid[] AS child_order_ids,
+    COALESCE(
+            (SELECT json_agg(refunds ORDER BY refunds.created_at, refunds.id)
+             FROM (
+                      SELECT
+                          r.id, r.order_id, r.shift_id, r.payment_method_id, r.amount, r.reason, r.created_by, r.created_at, r.order_payment_id,
+                          (SELECT json_agg(ri.*) FROM order_refund_items ri WHERE ri.refund_id = r.id) AS items
+                      FROM order_refunds r
+                      WHERE r.order_id = o.id
+                  ) AS refunds),
+            '[]'::json
+    ) AS refunds,
+    COALESCE(
+            (SELECT json_agg(h.* ORDER BY h.created_at, h.id)
+             FROM order_status_history h
+             WHERE h.order_id = o.id),
+            '[]'::json
+    ) AS status_history
 FROM
     orders o
 WHERE
@@ -661,7 +1647,19 @@ type GetOrderWithDetailsRow struct {
 	TaxAmount               int64              `json:"tax_amount"`
 	ServiceChargeAmount     int64              `json:"service_charge_amount"`
 	CustomerID              pgtype.UUID        `json:"customer_id"`
+	TaxRate                 pgtype.Numeric     `json:"tax_rate"`
+	ServiceChargeRate       pgtype.Numeric     `json:"service_charge_rate"`
+	TaxInclusive            bool               `json:"tax_inclusive"`
+	ShiftID                 pgtype.UUID        `json:"shift_id"`
+	ParentOrderID           pgtype.UUID        `json:"parent_order_id"`
+	QueueNumber             *string            `json:"queue_number"`
+	BusinessDate            pgtype.Date        `json:"business_date"`
+	AppliedPromotionCodeID  pgtype.UUID        `json:"applied_promotion_code_id"`
 	Items                   interface{}        `json:"items"`
+	Payments                interface{}        `json:"payments"`
+	ChildOrderIds           []uuid.UUID        `json:"child_order_ids"`
+	Refunds                 interface{}        `json:"refunds"`
+	StatusHistory           interface{}        `json:"status_history"`
 }
 
 // Mengambil detail lengkap pesanan, termasuk item dan opsinya dalam format JSON.
@@ -691,52 +1689,663 @@ func (q *Queries) GetOrderWithDetails(ctx context.Context, id uuid.UUID) (GetOrd
 		&i.TaxAmount,
 		&i.ServiceChargeAmount,
 		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
 		&i.Items,
+		&i.Payments,
+		&i.ChildOrderIds,
+		&i.Refunds,
+		&i.StatusHistory,
 	)
 	return i, err
 }
 
-const getProductByID = `-- name: GetProductByID :one
-SELECT id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price FROM products WHERE id = $1
+const getOrderableOptions = `-- name: GetOrderableOptions :many
+SELECT po.id, po.product_id, po.name, po.additional_price, po.is_default,
+       mg.id AS group_id, mg.name AS group_name, mg.min_select, mg.max_select
+FROM product_options po
+LEFT JOIN modifier_groups mg ON mg.id = po.modifier_group_id AND mg.deleted_at IS NULL
+WHERE po.product_id = ANY($1::uuid[]) AND po.deleted_at IS NULL
+ORDER BY po.product_id, mg.name, po.name
+`
+
+type GetOrderableOptionsRow struct {
+	ID              uuid.UUID   `json:"id"`
+	ProductID       uuid.UUID   `json:"product_id"`
+	Name            string      `json:"name"`
+	AdditionalPrice int64       `json:"additional_price"`
+	IsDefault       bool        `json:"is_default"`
+	GroupID         pgtype.UUID `json:"group_id"`
+	GroupName       *string     `json:"group_name"`
+	MinSelect       *int32      `json:"min_select"`
+	MaxSelect       *int32      `json:"max_select"`
+}
+
+// Opsi aktif beberapa produk beserta aturan grup modifiernya; kolom grup kosong bila opsi tidak masuk grup.
+func (q *Queries) GetOrderableOptions(ctx context.Context, productIds []uuid.UUID) ([]GetOrderableOptionsRow, error) {
+	rows, err := q.db.Query(ctx, getOrderableOptions, productIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOrderableOptionsRow{}
+	for rows.Next() {
+		var i GetOrderableOptionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.Name,
+			&i.AdditionalPrice,
+			&i.IsDefault,
+			&i.GroupID,
+			&i.GroupName,
+			&i.MinSelect,
+			&i.MaxSelect,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPaymentMethodByID = `-- name: GetPaymentMethodByID :one
+SELECT id, name, is_active, created_at, updated_at, kind, sort_order, opens_cash_drawer, requires_reference, allows_change FROM payment_methods
+WHERE id = $1
+LIMIT 1
+`
+
+// Mengambil metode pembayaran beserta jenis dan flag-nya untuk validasi pembayaran.
+func (q *Queries) GetPaymentMethodByID(ctx context.Context, id int32) (PaymentMethod, error) {
+	row := q.db.QueryRow(ctx, getPaymentMethodByID, id)
+	var i PaymentMethod
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Kind,
+		&i.SortOrder,
+		&i.OpensCashDrawer,
+		&i.RequiresReference,
+		&i.AllowsChange,
+	)
+	return i, err
+}
+
+const getProductByCode = `-- name: GetProductByCode :one
+WITH matches AS (
+    SELECT pb.product_id, pb.variant_id, 1 AS rank
+    FROM product_barcodes pb
+    WHERE pb.code = $1
+    UNION ALL
+    SELECT pv.product_id, pv.id, 1
+    FROM product_variants pv
+    WHERE pv.barcode = $1 AND pv.deleted_at IS NULL
+    UNION ALL
+    SELECT pv.product_id, pv.id, 2
+    FROM product_variants pv
+    WHERE lower(pv.sku) = lower($1) AND pv.deleted_at IS NULL
+    UNION ALL
+    SELECT p.id, NULL::uuid, 2
+    FROM products p
+    WHERE lower(p.sku) = lower($1) AND p.deleted_at IS NULL
+)
+SELECT m.product_id, m.variant_id
+FROM matches m
+JOIN products p ON p.id = m.product_id AND p.deleted_at IS NULL
+LEFT JOIN product_variants pv ON pv.id = m.variant_id AND pv.deleted_at IS NULL
+WHERE m.variant_id IS NULL OR pv.id IS NOT NULL
+ORDER BY m.rank
+LIMIT 1
+`
+
+type GetProductByCodeRow struct {
+	ProductID uuid.UUID   `json:"product_id"`
+	VariantID pgtype.UUID `json:"variant_id"`
+}
+
+// Produk (atau varian) untuk kode yang dipindai: barcode lebih dulu, lalu SKU tanpa membedakan huruf besar/kecil.
+func (q *Queries) GetProductByCode(ctx context.Context, code string) (GetProductByCodeRow, error) {
+	row := q.db.QueryRow(ctx, getProductByCode, code)
+	var i GetProductByCodeRow
+	err := row.Scan(
+		&i.ProductID,
+		&i.VariantID,
+	)
+	return i, err
+}
+
+const getProductByID = `-- name: GetProductByID :one
+SELECT id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, sku FROM products WHERE id = $1
+`
+
+func (q *Queries) GetProductByID(ctx context.Context, id uuid.UUID) (Product, error) {
+	row := q.db.QueryRow(ctx, getProductByID, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ImageUrl,
+		&i.Price,
+		&i.Stock,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CostPrice,
+		&i.Sku,
+	)
+	return i, err
+}
+
+const getProductCategoryIDs = `-- name: GetProductCategoryIDs :many
+SELECT product_id, category_id FROM product_categories
+WHERE product_id = ANY($1::uuid[])
+`
+
+type GetProductCategoryIDsRow struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	CategoryID int32     `json:"category_id"`
+}
+
+// Mengambil pasangan produk-kategori untuk menentukan item yang bebas pajak.
+func (q *Queries) GetProductCategoryIDs(ctx context.Context, productIds []uuid.UUID) ([]GetProductCategoryIDsRow, error) {
+	rows, err := q.db.Query(ctx, getProductCategoryIDs, productIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProductCategoryIDsRow{}
+	for rows.Next() {
+		var i GetProductCategoryIDsRow
+		if err := rows.Scan(&i.ProductID, &i.CategoryID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductOptionsByIDs = `-- name: GetProductOptionsByIDs :many
+SELECT id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at, modifier_group_id, is_default FROM product_options
+WHERE id = ANY($1::uuid[])
+`
+
+func (q *Queries) GetProductOptionsByIDs(ctx context.Context, ids []uuid.UUID) ([]ProductOption, error) {
+	rows, err := q.db.Query(ctx, getProductOptionsByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductOption{}
+	for rows.Next() {
+		var i ProductOption
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.Name,
+			&i.AdditionalPrice,
+			&i.ImageUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ModifierGroupID,
+			&i.IsDefault,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductRecipes = `-- name: GetProductRecipes :many
+SELECT product_id, ingredient_id, quantity FROM product_recipe_items
+WHERE product_id = ANY($1::uuid[])
+`
+
+// Mengambil resep (bahan baku per unit) untuk beberapa produk.
+func (q *Queries) GetProductRecipes(ctx context.Context, productIds []uuid.UUID) ([]ProductRecipeItem, error) {
+	rows, err := q.db.Query(ctx, getProductRecipes, productIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductRecipeItem{}
+	for rows.Next() {
+		var i ProductRecipeItem
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.IngredientID,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductsByIDs = `-- name: GetProductsByIDs :many
+SELECT id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, sku FROM products
+WHERE id = ANY($1::uuid[])
+`
+
+// Mengambil beberapa produk berdasarkan array ID. Ini untuk menghindari N+1 query.
+func (q *Queries) GetProductsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([]Product, error) {
+	rows, err := q.db.Query(ctx, getProductsByIDs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Product{}
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ImageUrl,
+			&i.Price,
+			&i.Stock,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CostPrice,
+			&i.Sku,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductsForUpdate = `-- name: GetProductsForUpdate :many
+SELECT id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, sku FROM products
+WHERE id = ANY($1::uuid[])
+    FOR UPDATE
+`
+
+// Mengambil produk sekaligus mengunci barisnya (Row-Level Locking).
+// Transaksi lain yang mencoba update produk ini harus menunggu sampai transaksi ini selesai.
+func (q *Queries) GetProductsForUpdate(ctx context.Context, dollar_1 []uuid.UUID) ([]Product, error) {
+	rows, err := q.db.Query(ctx, getProductsForUpdate, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Product{}
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ImageUrl,
+			&i.Price,
+			&i.Stock,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CostPrice,
+			&i.Sku,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPromotionByID = `-- name: GetPromotionByID :one
+SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority FROM promotions WHERE id = $1
+`
+
+func (q *Queries) GetPromotionByID(ctx context.Context, id uuid.UUID) (Promotion, error) {
+	row := q.db.QueryRow(ctx, getPromotionByID, id)
+	var i Promotion
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Scope,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.MaxDiscountAmount,
+		&i.StartDate,
+		&i.EndDate,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.TotalRedemptionLimit,
+		&i.PerCustomerLimit,
+		&i.BudgetAmount,
+		&i.BuyQuantity,
+		&i.GetQuantity,
+		&i.BundleQuantity,
+		&i.AutoApply,
+		&i.Stacking,
+		&i.Priority,
+	)
+	return i, err
+}
+
+const getPromotionCodeByID = `-- name: GetPromotionCodeByID :one
+SELECT id, promotion_id, code, batch_id, max_uses, created_at, deactivated_at FROM promotion_codes
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetPromotionCodeByID(ctx context.Context, id uuid.UUID) (PromotionCode, error) {
+	row := q.db.QueryRow(ctx, getPromotionCodeByID, id)
+	var i PromotionCode
+	err := row.Scan(
+		&i.ID,
+		&i.PromotionID,
+		&i.Code,
+		&i.BatchID,
+		&i.MaxUses,
+		&i.CreatedAt,
+		&i.DeactivatedAt,
+	)
+	return i, err
+}
+
+const getPromotionExcludedDates = `-- name: GetPromotionExcludedDates :many
+SELECT promotion_id, excluded_date FROM promotion_excluded_dates
+WHERE promotion_id = $1
+ORDER BY excluded_date
+`
+
+// Mengambil tanggal-tanggal di mana promosi tidak berlaku.
+func (q *Queries) GetPromotionExcludedDates(ctx context.Context, promotionID uuid.UUID) ([]PromotionExcludedDate, error) {
+	rows, err := q.db.Query(ctx, getPromotionExcludedDates, promotionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PromotionExcludedDate{}
+	for rows.Next() {
+		var i PromotionExcludedDate
+		if err := rows.Scan(
+			&i.PromotionID,
+			&i.ExcludedDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPromotionRedemptionStats = `-- name: GetPromotionRedemptionStats :one
+SELECT
+    COUNT(DISTINCT COALESCE(o.parent_order_id, o.id)) FILTER (WHERE COALESCE(o.parent_order_id, o.id) IS DISTINCT FROM $4) AS total_redemptions,
+    COUNT(DISTINCT COALESCE(o.parent_order_id, o.id)) FILTER (WHERE pr.customer_id = $2 AND COALESCE(o.parent_order_id, o.id) IS DISTINCT FROM $4) AS customer_redemptions,
+    COUNT(DISTINCT COALESCE(o.parent_order_id, o.id)) FILTER (WHERE pr.promotion_code_id = $3 AND COALESCE(o.parent_order_id, o.id) IS DISTINCT FROM $4) AS code_redemptions,
+    COALESCE(SUM(pr.discount_amount), 0)::bigint AS discount_total
+FROM promotion_redemptions pr
+JOIN orders o ON o.id = pr.order_id
+WHERE pr.promotion_id = $1 AND pr.status = 'redeemed'
+`
+
+type GetPromotionRedemptionStatsParams struct {
+	PromotionID     uuid.UUID   `json:"promotion_id"`
+	CustomerID      pgtype.UUID `json:"customer_id"`
+	PromotionCodeID pgtype.UUID `json:"promotion_code_id"`
+	OrderFamilyID   pgtype.UUID `json:"order_family_id"`
+}
+
+type GetPromotionRedemptionStatsRow struct {
+	TotalRedemptions    int64 `json:"total_redemptions"`
+	CustomerRedemptions int64 `json:"customer_redemptions"`
+	CodeRedemptions     int64 `json:"code_redemptions"`
+	DiscountTotal       int64 `json:"discount_total"`
+}
+
+// Menghitung penukaran aktif sebuah promosi: total, per pelanggan, per kode, dan total diskonnya.
+// Bill hasil split dihitung sebagai satu penukaran, dan keluarga pesanan yang sedang diperiksa tidak ikut dihitung.
+func (q *Queries) GetPromotionRedemptionStats(ctx context.Context, arg GetPromotionRedemptionStatsParams) (GetPromotionRedemptionStatsRow, error) {
+	row := q.db.QueryRow(ctx, getPromotionRedemptionStats,
+		arg.PromotionID,
+		arg.CustomerID,
+		arg.PromotionCodeID,
+		arg.OrderFamilyID,
+	)
+	var i GetPromotionRedemptionStatsRow
+	err := row.Scan(
+		&i.TotalRedemptions,
+		&i.CustomerRedemptions,
+		&i.CodeRedemptions,
+		&i.DiscountTotal,
+	)
+	return i, err
+}
+
+const getPromotionRules = `-- name: GetPromotionRules :many
+SELECT id, promotion_id, rule_type, rule_value, description, created_at, updated_at FROM promotion_rules WHERE promotion_id = $1
+`
+
+func (q *Queries) GetPromotionRules(ctx context.Context, promotionID uuid.UUID) ([]PromotionRule, error) {
+	rows, err := q.db.Query(ctx, getPromotionRules, promotionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PromotionRule{}
+	for rows.Next() {
+		var i PromotionRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.PromotionID,
+			&i.RuleType,
+			&i.RuleValue,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPromotionSchedules = `-- name: GetPromotionSchedules :many
+SELECT id, promotion_id, days_of_week, start_time, end_time, created_at FROM promotion_schedules
+WHERE promotion_id = $1
+ORDER BY start_time, id
+`
+
+// Mengambil jadwal berulang sebuah promosi.
+func (q *Queries) GetPromotionSchedules(ctx context.Context, promotionID uuid.UUID) ([]PromotionSchedule, error) {
+	rows, err := q.db.Query(ctx, getPromotionSchedules, promotionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PromotionSchedule{}
+	for rows.Next() {
+		var i PromotionSchedule
+		if err := rows.Scan(
+			&i.ID,
+			&i.PromotionID,
+			&i.DaysOfWeek,
+			&i.StartTime,
+			&i.EndTime,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPromotionTargets = `-- name: GetPromotionTargets :many
+SELECT id, promotion_id, target_type, target_id, created_at, updated_at FROM promotion_targets WHERE promotion_id = $1
+`
+
+func (q *Queries) GetPromotionTargets(ctx context.Context, promotionID uuid.UUID) ([]PromotionTarget, error) {
+	rows, err := q.db.Query(ctx, getPromotionTargets, promotionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PromotionTarget{}
+	for rows.Next() {
+		var i PromotionTarget
+		if err := rows.Scan(
+			&i.ID,
+			&i.PromotionID,
+			&i.TargetType,
+			&i.TargetID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPromotionTiers = `-- name: GetPromotionTiers :many
+SELECT id, promotion_id, min_amount, discount_type, discount_value, created_at FROM promotion_tiers WHERE promotion_id = $1 ORDER BY min_amount
 `
 
-func (q *Queries) GetProductByID(ctx context.Context, id uuid.UUID) (Product, error) {
-	row := q.db.QueryRow(ctx, getProductByID, id)
-	var i Product
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.ImageUrl,
-		&i.Price,
-		&i.Stock,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
-		&i.CostPrice,
-	)
-	return i, err
+func (q *Queries) GetPromotionTiers(ctx context.Context, promotionID uuid.UUID) ([]PromotionTier, error) {
+	rows, err := q.db.Query(ctx, getPromotionTiers, promotionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PromotionTier{}
+	for rows.Next() {
+		var i PromotionTier
+		if err := rows.Scan(
+			&i.ID,
+			&i.PromotionID,
+			&i.MinAmount,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getProductOptionsByIDs = `-- name: GetProductOptionsByIDs :many
-SELECT id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at FROM product_options
-WHERE id = ANY($1::uuid[])
+const getRefundedItemQuantities = `-- name: GetRefundedItemQuantities :many
+SELECT
+    ri.order_item_id,
+    COALESCE(SUM(ri.quantity), 0)::int AS refunded_quantity,
+    COALESCE(SUM(ri.quantity) FILTER (WHERE ri.restocked), 0)::int AS restocked_quantity
+FROM order_refund_items ri
+JOIN order_refunds r ON r.id = ri.refund_id
+WHERE r.order_id = $1
+GROUP BY ri.order_item_id
 `
 
-func (q *Queries) GetProductOptionsByIDs(ctx context.Context, ids []uuid.UUID) ([]ProductOption, error) {
-	rows, err := q.db.Query(ctx, getProductOptionsByIDs, ids)
+type GetRefundedItemQuantitiesRow struct {
+	OrderItemID       uuid.UUID `json:"order_item_id"`
+	RefundedQuantity  int32     `json:"refunded_quantity"`
+	RestockedQuantity int32     `json:"restocked_quantity"`
+}
+
+// Menjumlahkan kuantitas yang sudah direfund (dan yang dikembalikan ke stok) per baris item sebuah pesanan.
+func (q *Queries) GetRefundedItemQuantities(ctx context.Context, orderID uuid.UUID) ([]GetRefundedItemQuantitiesRow, error) {
+	rows, err := q.db.Query(ctx, getRefundedItemQuantities, orderID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ProductOption{}
+	items := []GetRefundedItemQuantitiesRow{}
 	for rows.Next() {
-		var i ProductOption
+		var i GetRefundedItemQuantitiesRow
+		if err := rows.Scan(
+			&i.OrderItemID,
+			&i.RefundedQuantity,
+			&i.RestockedQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVariantsForOrder = `-- name: GetVariantsForOrder :many
+SELECT id, product_id, name, sku, barcode, price, cost_price, stock, created_at, updated_at, deleted_at FROM product_variants
+WHERE product_id = ANY($1::uuid[]) AND deleted_at IS NULL
+ORDER BY id
+FOR UPDATE
+`
+
+// Mengunci varian aktif dari produk-produk pada pesanan; urutan id mencegah deadlock antar transaksi.
+func (q *Queries) GetVariantsForOrder(ctx context.Context, productIds []uuid.UUID) ([]ProductVariant, error) {
+	rows, err := q.db.Query(ctx, getVariantsForOrder, productIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductVariant{}
+	for rows.Next() {
+		var i ProductVariant
 		if err := rows.Scan(
 			&i.ID,
 			&i.ProductID,
 			&i.Name,
-			&i.AdditionalPrice,
-			&i.ImageUrl,
+			&i.Sku,
+			&i.Barcode,
+			&i.Price,
+			&i.CostPrice,
+			&i.Stock,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
@@ -751,31 +2360,48 @@ func (q *Queries) GetProductOptionsByIDs(ctx context.Context, ids []uuid.UUID) (
 	return items, nil
 }
 
-const getProductsByIDs = `-- name: GetProductsByIDs :many
-SELECT id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price FROM products
-WHERE id = ANY($1::uuid[])
+const listActivePromotions = `-- name: ListActivePromotions :many
+SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority FROM promotions
+WHERE is_active = true
+  AND deleted_at IS NULL
+  AND start_date <= NOW()
+  AND end_date >= NOW()
+ORDER BY priority DESC, id
 `
 
-// Mengambil beberapa produk berdasarkan array ID. Ini untuk menghindari N+1 query.
-func (q *Queries) GetProductsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([]Product, error) {
-	rows, err := q.db.Query(ctx, getProductsByIDs, dollar_1)
+// Mengambil promosi yang aktif dan sedang berlaku, dari prioritas tertinggi.
+func (q *Queries) ListActivePromotions(ctx context.Context) ([]Promotion, error) {
+	rows, err := q.db.Query(ctx, listActivePromotions)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Product{}
+	items := []Promotion{}
 	for rows.Next() {
-		var i Product
+		var i Promotion
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
-			&i.ImageUrl,
-			&i.Price,
-			&i.Stock,
+			&i.Description,
+			&i.Scope,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.MaxDiscountAmount,
+			&i.StartDate,
+			&i.EndDate,
+			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
-			&i.CostPrice,
+			&i.TotalRedemptionLimit,
+			&i.PerCustomerLimit,
+			&i.BudgetAmount,
+			&i.BuyQuantity,
+			&i.GetQuantity,
+			&i.BundleQuantity,
+			&i.AutoApply,
+			&i.Stacking,
+			&i.Priority,
 		); err != nil {
 			return nil, err
 		}
@@ -787,33 +2413,99 @@ func (q *Queries) GetProductsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([
 	return items, nil
 }
 
-const getProductsForUpdate = `-- name: GetProductsForUpdate :many
-SELECT id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price FROM products
-WHERE id = ANY($1::uuid[])
+const listCallingBoardOrders = `-- name: ListCallingBoardOrders :many
+SELECT
+    o.id,
+    o.queue_number,
+    o.type,
+    o.created_at,
+    count(oi.id) AS total_items,
+    count(oi.id) FILTER (WHERE oi.prep_status IN ('ready', 'served')) AS ready_items,
+    count(oi.id) FILTER (WHERE oi.prep_status = 'served') AS served_items,
+    max(oi.ready_at)::timestamptz AS ready_at
+FROM orders o
+JOIN order_items oi ON oi.order_id = o.id
+WHERE o.business_date = $1
+  AND o.queue_number IS NOT NULL
+  AND o.status NOT IN ('served', 'cancelled')
+GROUP BY o.id
+HAVING count(oi.id) FILTER (WHERE oi.prep_status = 'served') < count(oi.id)
+ORDER BY o.created_at, o.id
+`
+
+type ListCallingBoardOrdersRow struct {
+	ID          uuid.UUID          `json:"id"`
+	QueueNumber *string            `json:"queue_number"`
+	Type        OrderType          `json:"type"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	TotalItems  int64              `json:"total_items"`
+	ReadyItems  int64              `json:"ready_items"`
+	ServedItems int64              `json:"served_items"`
+	ReadyAt     pgtype.Timestamptz `json:"ready_at"`
+}
+
+// Pesanan hari bisnis ini yang masih ditunggu tamu, beserta ringkasan status dapur per pesanan.
+func (q *Queries) ListCallingBoardOrders(ctx context.Context, businessDate pgtype.Date) ([]ListCallingBoardOrdersRow, error) {
+	rows, err := q.db.Query(ctx, listCallingBoardOrders, businessDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCallingBoardOrdersRow{}
+	for rows.Next() {
+		var i ListCallingBoardOrdersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.QueueNumber,
+			&i.Type,
+			&i.CreatedAt,
+			&i.TotalItems,
+			&i.ReadyItems,
+			&i.ServedItems,
+			&i.ReadyAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomerPointLots = `-- name: ListCustomerPointLots :many
+SELECT id, customer_id, order_id, entry_type, redeemed_as, points, remaining, amount, expires_at, description, created_by, created_at FROM loyalty_point_entries
+WHERE customer_id = $1
+  AND remaining > 0
+  AND (expires_at IS NULL OR expires_at > NOW())
+ORDER BY expires_at NULLS LAST, created_at, id
     FOR UPDATE
 `
 
-// Mengambil produk sekaligus mengunci barisnya (Row-Level Locking).
-// Transaksi lain yang mencoba update produk ini harus menunggu sampai transaksi ini selesai.
-func (q *Queries) GetProductsForUpdate(ctx context.Context, dollar_1 []uuid.UUID) ([]Product, error) {
-	rows, err := q.db.Query(ctx, getProductsForUpdate, dollar_1)
+// Mengambil poin pelanggan yang masih tersisa dan belum kedaluwarsa, yang paling cepat kedaluwarsa lebih dulu.
+func (q *Queries) ListCustomerPointLots(ctx context.Context, customerID uuid.UUID) ([]LoyaltyPointEntry, error) {
+	rows, err := q.db.Query(ctx, listCustomerPointLots, customerID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Product{}
+	items := []LoyaltyPointEntry{}
 	for rows.Next() {
-		var i Product
+		var i LoyaltyPointEntry
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
-			&i.ImageUrl,
-			&i.Price,
-			&i.Stock,
+			&i.CustomerID,
+			&i.OrderID,
+			&i.EntryType,
+			&i.RedeemedAs,
+			&i.Points,
+			&i.Remaining,
+			&i.Amount,
+			&i.ExpiresAt,
+			&i.Description,
+			&i.CreatedBy,
 			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.DeletedAt,
-			&i.CostPrice,
 		); err != nil {
 			return nil, err
 		}
@@ -825,52 +2517,69 @@ func (q *Queries) GetProductsForUpdate(ctx context.Context, dollar_1 []uuid.UUID
 	return items, nil
 }
 
-const getPromotionByID = `-- name: GetPromotionByID :one
-SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at FROM promotions WHERE id = $1
+const listOrderItemOptionIDs = `-- name: ListOrderItemOptionIDs :many
+SELECT oio.order_item_id, oio.product_option_id
+FROM order_item_options oio
+JOIN order_items oi ON oi.id = oio.order_item_id
+WHERE oi.order_id = $1
 `
 
-func (q *Queries) GetPromotionByID(ctx context.Context, id uuid.UUID) (Promotion, error) {
-	row := q.db.QueryRow(ctx, getPromotionByID, id)
-	var i Promotion
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Description,
-		&i.Scope,
-		&i.DiscountType,
-		&i.DiscountValue,
-		&i.MaxDiscountAmount,
-		&i.StartDate,
-		&i.EndDate,
-		&i.IsActive,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
-	)
-	return i, err
+type ListOrderItemOptionIDsRow struct {
+	OrderItemID     uuid.UUID `json:"order_item_id"`
+	ProductOptionID uuid.UUID `json:"product_option_id"`
 }
 
-const getPromotionRules = `-- name: GetPromotionRules :many
-SELECT id, promotion_id, rule_type, rule_value, description, created_at, updated_at FROM promotion_rules WHERE promotion_id = $1
+// Opsi yang dipilih per baris item sebuah pesanan, untuk menghitung bahan baku yang dipakai.
+func (q *Queries) ListOrderItemOptionIDs(ctx context.Context, orderID uuid.UUID) ([]ListOrderItemOptionIDsRow, error) {
+	rows, err := q.db.Query(ctx, listOrderItemOptionIDs, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListOrderItemOptionIDsRow{}
+	for rows.Next() {
+		var i ListOrderItemOptionIDsRow
+		if err := rows.Scan(
+			&i.OrderItemID,
+			&i.ProductOptionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderPayments = `-- name: ListOrderPayments :many
+SELECT id, order_id, payment_method_id, amount, tendered_amount, change_amount, reference_number, shift_id, created_by, created_at FROM order_payments
+WHERE order_id = $1
+ORDER BY created_at, id
 `
 
-func (q *Queries) GetPromotionRules(ctx context.Context, promotionID uuid.UUID) ([]PromotionRule, error) {
-	rows, err := q.db.Query(ctx, getPromotionRules, promotionID)
+// Mengambil semua baris tender sebuah pesanan sesuai urutan pembayaran.
+func (q *Queries) ListOrderPayments(ctx context.Context, orderID uuid.UUID) ([]OrderPayment, error) {
+	rows, err := q.db.Query(ctx, listOrderPayments, orderID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []PromotionRule{}
+	items := []OrderPayment{}
 	for rows.Next() {
-		var i PromotionRule
+		var i OrderPayment
 		if err := rows.Scan(
 			&i.ID,
-			&i.PromotionID,
-			&i.RuleType,
-			&i.RuleValue,
-			&i.Description,
+			&i.OrderID,
+			&i.PaymentMethodID,
+			&i.Amount,
+			&i.TenderedAmount,
+			&i.ChangeAmount,
+			&i.ReferenceNumber,
+			&i.ShiftID,
+			&i.CreatedBy,
 			&i.CreatedAt,
-			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -882,26 +2591,32 @@ func (q *Queries) GetPromotionRules(ctx context.Context, promotionID uuid.UUID)
 	return items, nil
 }
 
-const getPromotionTargets = `-- name: GetPromotionTargets :many
-SELECT id, promotion_id, target_type, target_id, created_at, updated_at FROM promotion_targets WHERE promotion_id = $1
+const listOrderRefunds = `-- name: ListOrderRefunds :many
+SELECT id, order_id, shift_id, payment_method_id, amount, reason, created_by, created_at, order_payment_id FROM order_refunds
+WHERE order_id = $1
+ORDER BY created_at, id
 `
 
-func (q *Queries) GetPromotionTargets(ctx context.Context, promotionID uuid.UUID) ([]PromotionTarget, error) {
-	rows, err := q.db.Query(ctx, getPromotionTargets, promotionID)
+// Mengambil semua refund sebuah pesanan (untuk menghitung sisa dana per tender).
+func (q *Queries) ListOrderRefunds(ctx context.Context, orderID uuid.UUID) ([]OrderRefund, error) {
+	rows, err := q.db.Query(ctx, listOrderRefunds, orderID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []PromotionTarget{}
+	items := []OrderRefund{}
 	for rows.Next() {
-		var i PromotionTarget
+		var i OrderRefund
 		if err := rows.Scan(
 			&i.ID,
-			&i.PromotionID,
-			&i.TargetType,
-			&i.TargetID,
+			&i.OrderID,
+			&i.ShiftID,
+			&i.PaymentMethodID,
+			&i.Amount,
+			&i.Reason,
+			&i.CreatedBy,
 			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.OrderPaymentID,
 		); err != nil {
 			return nil, err
 		}
@@ -922,7 +2637,8 @@ SELECT
     gross_total,
     net_total,
     created_at,
-    payment_method_id
+    payment_method_id,
+    queue_number
 FROM orders
 WHERE
     ($3::text[] IS NULL OR status = ANY($3::text[]::order_status[]))
@@ -949,6 +2665,7 @@ type ListOrdersRow struct {
 	NetTotal        int64              `json:"net_total"`
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 	PaymentMethodID *int32             `json:"payment_method_id"`
+	QueueNumber     *string            `json:"queue_number"`
 }
 
 func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]ListOrdersRow, error) {
@@ -974,6 +2691,7 @@ func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]ListO
 			&i.NetTotal,
 			&i.CreatedAt,
 			&i.PaymentMethodID,
+			&i.QueueNumber,
 		); err != nil {
 			return nil, err
 		}
@@ -985,19 +2703,92 @@ func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]ListO
 	return items, nil
 }
 
+const lockCustomer = `-- name: LockCustomer :one
+SELECT id AS customer_id FROM customers
+WHERE id = $1
+    FOR UPDATE
+`
+
+// Mengunci baris pelanggan agar perolehan dan penukaran poinnya tidak balapan.
+func (q *Queries) LockCustomer(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, lockCustomer, id)
+	var customer_id uuid.UUID
+	err := row.Scan(&customer_id)
+	return customer_id, err
+}
+
+const lockPromotion = `-- name: LockPromotion :one
+SELECT id AS promotion_id FROM promotions
+WHERE id = $1
+    FOR UPDATE
+`
+
+// Mengunci baris promosi agar pengecekan batas penukaran dan pencatatannya tidak balapan.
+func (q *Queries) LockPromotion(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, lockPromotion, id)
+	var promotion_id uuid.UUID
+	err := row.Scan(&promotion_id)
+	return promotion_id, err
+}
+
+const moveOrderItem = `-- name: MoveOrderItem :exec
+UPDATE order_items
+SET order_id = $1
+WHERE id = $2 AND order_id = $3
+`
+
+type MoveOrderItemParams struct {
+	TargetOrderID uuid.UUID `json:"target_order_id"`
+	ID            uuid.UUID `json:"id"`
+	OrderID       uuid.UUID `json:"order_id"`
+}
+
+// Memindahkan satu baris item (beserta opsinya) ke pesanan lain.
+func (q *Queries) MoveOrderItem(ctx context.Context, arg MoveOrderItemParams) error {
+	_, err := q.db.Exec(ctx, moveOrderItem, arg.TargetOrderID, arg.ID, arg.OrderID)
+	return err
+}
+
+const nextQueueNumber = `-- name: NextQueueNumber :one
+INSERT INTO order_queue_counters (business_date, last_number)
+VALUES ($1, 1)
+ON CONFLICT (business_date) DO UPDATE
+SET last_number = order_queue_counters.last_number + 1,
+    updated_at = now()
+RETURNING last_number
+`
+
+// Mengambil nomor antrian berikutnya untuk hari bisnis; baris counter terkunci sampai transaksi selesai.
+func (q *Queries) NextQueueNumber(ctx context.Context, businessDate pgtype.Date) (int32, error) {
+	row := q.db.QueryRow(ctx, nextQueueNumber, businessDate)
+	var last_number int32
+	err := row.Scan(&last_number)
+	return last_number, err
+}
+
+const promotionHasCodes = `-- name: PromotionHasCodes :one
+SELECT EXISTS(SELECT 1 FROM promotion_codes WHERE promotion_id = $1)
+`
+
+// Memeriksa apakah promosi punya kode; promosi berkode hanya bisa dipakai lewat kodenya.
+func (q *Queries) PromotionHasCodes(ctx context.Context, promotionID uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, promotionHasCodes, promotionID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const refundOrder = `-- name: RefundOrder :one
 UPDATE orders
 SET
     status = 'cancelled',
-    payment_method_id = NULL,
-    cash_received = NULL,
-    change_due = NULL,
     version = version + 1
 WHERE
     id = $1
-RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id
+RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id, tax_rate, service_charge_rate, tax_inclusive, shift_id, parent_order_id, queue_number, business_date, applied_promotion_code_id
 `
 
+// Data pembayaran dipertahankan agar rekonsiliasi shift tetap mencatat penjualan aslinya.
 func (q *Queries) RefundOrder(ctx context.Context, id uuid.UUID) (Order, error) {
 	row := q.db.QueryRow(ctx, refundOrder, id)
 	var i Order
@@ -1024,23 +2815,85 @@ func (q *Queries) RefundOrder(ctx context.Context, id uuid.UUID) (Order, error)
 		&i.TaxAmount,
 		&i.ServiceChargeAmount,
 		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
 	)
 	return i, err
 }
 
+const releasePromotionRedemptions = `-- name: ReleasePromotionRedemptions :exec
+UPDATE promotion_redemptions
+SET status = 'released', released_at = NOW()
+WHERE order_id = $1 AND status = 'redeemed'
+`
+
+// Melepas penukaran promosi sebuah pesanan yang dibatalkan atau di-refund penuh.
+func (q *Queries) ReleasePromotionRedemptions(ctx context.Context, orderID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, releasePromotionRedemptions, orderID)
+	return err
+}
+
+const setOrderItemCost = `-- name: SetOrderItemCost :exec
+UPDATE order_items
+SET cost_price_at_sale = $2
+WHERE id = $1
+`
+
+type SetOrderItemCostParams struct {
+	ID              uuid.UUID      `json:"id"`
+	CostPriceAtSale pgtype.Numeric `json:"cost_price_at_sale"`
+}
+
+// Menyimpan harga pokok per unit sebuah item pesanan sesuai lapisan biaya yang dipakai (metode FIFO).
+func (q *Queries) SetOrderItemCost(ctx context.Context, arg SetOrderItemCostParams) error {
+	_, err := q.db.Exec(ctx, setOrderItemCost, arg.ID, arg.CostPriceAtSale)
+	return err
+}
+
+const setOrderItemDiscounts = `-- name: SetOrderItemDiscounts :exec
+UPDATE order_items oi
+SET discount_amount = d.discount_amount,
+    net_subtotal = oi.subtotal - d.discount_amount
+FROM (
+    SELECT unnest($1::uuid[]) AS id,
+           unnest($2::bigint[]) AS discount_amount
+) d
+WHERE oi.id = d.id AND oi.order_id = $3
+`
+
+type SetOrderItemDiscountsParams struct {
+	Ids             []uuid.UUID `json:"ids"`
+	DiscountAmounts []int64     `json:"discount_amounts"`
+	OrderID         uuid.UUID   `json:"order_id"`
+}
+
+// Menyimpan bagian diskon promosi per baris item; net_subtotal dihitung ulang dari subtotal.
+func (q *Queries) SetOrderItemDiscounts(ctx context.Context, arg SetOrderItemDiscountsParams) error {
+	_, err := q.db.Exec(ctx, setOrderItemDiscounts, arg.Ids, arg.DiscountAmounts, arg.OrderID)
+	return err
+}
+
 const updateOrderAppliedPromotion = `-- name: UpdateOrderAppliedPromotion :exec
 UPDATE orders
-SET applied_promotion_id = $2
+SET applied_promotion_id = $2,
+    applied_promotion_code_id = $3
 WHERE id = $1
 `
 
 type UpdateOrderAppliedPromotionParams struct {
-	ID                 uuid.UUID   `json:"id"`
-	AppliedPromotionID pgtype.UUID `json:"applied_promotion_id"`
+	ID                     uuid.UUID   `json:"id"`
+	AppliedPromotionID     pgtype.UUID `json:"applied_promotion_id"`
+	AppliedPromotionCodeID pgtype.UUID `json:"applied_promotion_code_id"`
 }
 
 func (q *Queries) UpdateOrderAppliedPromotion(ctx context.Context, arg UpdateOrderAppliedPromotionParams) error {
-	_, err := q.db.Exec(ctx, updateOrderAppliedPromotion, arg.ID, arg.AppliedPromotionID)
+	_, err := q.db.Exec(ctx, updateOrderAppliedPromotion, arg.ID, arg.AppliedPromotionID, arg.AppliedPromotionCodeID)
 	return err
 }
 
@@ -1052,7 +2905,7 @@ SET
     net_subtotal = $5
 WHERE
     id = $1 AND order_id = $2
-RETURNING id, order_id, product_id, quantity, price_at_sale, subtotal, discount_amount, net_subtotal, cost_price_at_sale
+RETURNING id, order_id, product_id, quantity, price_at_sale, subtotal, discount_amount, net_subtotal, cost_price_at_sale, station_id, prep_status, is_priority, queued_at, started_at, ready_at, served_at, variant_id
 `
 
 type UpdateOrderItemQuantityParams struct {
@@ -1084,6 +2937,14 @@ func (q *Queries) UpdateOrderItemQuantity(ctx context.Context, arg UpdateOrderIt
 		&i.DiscountAmount,
 		&i.NetSubtotal,
 		&i.CostPriceAtSale,
+		&i.StationID,
+		&i.PrepStatus,
+		&i.IsPriority,
+		&i.QueuedAt,
+		&i.StartedAt,
+		&i.ReadyAt,
+		&i.ServedAt,
+		&i.VariantID,
 	)
 	return i, err
 }
@@ -1098,7 +2959,7 @@ SET
     version = version + 1
 WHERE
     id = $1 AND version = $5
-RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id
+RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id, tax_rate, service_charge_rate, tax_inclusive, shift_id, parent_order_id, queue_number, business_date, applied_promotion_code_id
 `
 
 type UpdateOrderManualPaymentParams struct {
@@ -1143,6 +3004,14 @@ func (q *Queries) UpdateOrderManualPayment(ctx context.Context, arg UpdateOrderM
 		&i.TaxAmount,
 		&i.ServiceChargeAmount,
 		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
 	)
 	return i, err
 }
@@ -1189,11 +3058,30 @@ func (q *Queries) UpdateOrderPaymentUrl(ctx context.Context, arg UpdateOrderPaym
 	return err
 }
 
+const updateOrderPromotionDiscount = `-- name: UpdateOrderPromotionDiscount :exec
+UPDATE order_promotions
+SET discount_amount = $3
+WHERE order_id = $1 AND promotion_id = $2
+`
+
+type UpdateOrderPromotionDiscountParams struct {
+	OrderID        uuid.UUID `json:"order_id"`
+	PromotionID    uuid.UUID `json:"promotion_id"`
+	DiscountAmount int64     `json:"discount_amount"`
+}
+
+// Memperbarui bagian diskon sebuah promosi pesanan, misalnya setelah pesanan dipecah.
+func (q *Queries) UpdateOrderPromotionDiscount(ctx context.Context, arg UpdateOrderPromotionDiscountParams) error {
+	_, err := q.db.Exec(ctx, updateOrderPromotionDiscount, arg.OrderID, arg.PromotionID, arg.DiscountAmount)
+	return err
+}
+
 const updateOrderStatus = `-- name: UpdateOrderStatus :one
 UPDATE orders
-SET status = $2
+SET status = $2,
+    version = version + 1
 WHERE id = $1
-RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id
+RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id, tax_rate, service_charge_rate, tax_inclusive, shift_id, parent_order_id, queue_number, business_date, applied_promotion_code_id
 `
 
 type UpdateOrderStatusParams struct {
@@ -1229,6 +3117,14 @@ func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusPa
 		&i.TaxAmount,
 		&i.ServiceChargeAmount,
 		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
 	)
 	return i, err
 }
@@ -1240,7 +3136,7 @@ SET
     payment_method_id = COALESCE($3, payment_method_id),
     version = version + 1
 WHERE payment_gateway_reference = $1 AND status <> 'paid' -- Mencegah update ganda
-RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id
+RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id, tax_rate, service_charge_rate, tax_inclusive, shift_id, parent_order_id, queue_number, business_date, applied_promotion_code_id
 `
 
 type UpdateOrderStatusByGatewayRefParams struct {
@@ -1276,6 +3172,14 @@ func (q *Queries) UpdateOrderStatusByGatewayRef(ctx context.Context, arg UpdateO
 		&i.TaxAmount,
 		&i.ServiceChargeAmount,
 		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
 	)
 	return i, err
 }
@@ -1288,20 +3192,26 @@ SET
     net_total = $4,
     tax_amount = $5,
     service_charge_amount = $6,
+    tax_rate = $8,
+    service_charge_rate = $9,
+    tax_inclusive = $10,
     version = version + 1
 WHERE
     id = $1 AND version = $7
-RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id
+RETURNING id, user_id, type, status, created_at, updated_at, gross_total, discount_amount, net_total, applied_promotion_id, payment_method_id, payment_gateway_reference, cash_received, change_due, cancellation_reason_id, cancellation_notes, payment_url, payment_token, version, tax_amount, service_charge_amount, customer_id, tax_rate, service_charge_rate, tax_inclusive, shift_id, parent_order_id, queue_number, business_date, applied_promotion_code_id
 `
 
 type UpdateOrderTotalsParams struct {
-	ID                  uuid.UUID `json:"id"`
-	GrossTotal          int64     `json:"gross_total"`
-	DiscountAmount      int64     `json:"discount_amount"`
-	NetTotal            int64     `json:"net_total"`
-	TaxAmount           int64     `json:"tax_amount"`
-	ServiceChargeAmount int64     `json:"service_charge_amount"`
-	Version             int32     `json:"version"`
+	ID                  uuid.UUID      `json:"id"`
+	GrossTotal          int64          `json:"gross_total"`
+	DiscountAmount      int64          `json:"discount_amount"`
+	NetTotal            int64          `json:"net_total"`
+	TaxAmount           int64          `json:"tax_amount"`
+	ServiceChargeAmount int64          `json:"service_charge_amount"`
+	Version             int32          `json:"version"`
+	TaxRate             pgtype.Numeric `json:"tax_rate"`
+	ServiceChargeRate   pgtype.Numeric `json:"service_charge_rate"`
+	TaxInclusive        bool           `json:"tax_inclusive"`
 }
 
 // Memperbarui total harga, diskon, dan total bersih dari sebuah pesanan.
@@ -1314,6 +3224,9 @@ func (q *Queries) UpdateOrderTotals(ctx context.Context, arg UpdateOrderTotalsPa
 		arg.TaxAmount,
 		arg.ServiceChargeAmount,
 		arg.Version,
+		arg.TaxRate,
+		arg.ServiceChargeRate,
+		arg.TaxInclusive,
 	)
 	var i Order
 	err := row.Scan(
@@ -1339,6 +3252,14 @@ func (q *Queries) UpdateOrderTotals(ctx context.Context, arg UpdateOrderTotalsPa
 		&i.TaxAmount,
 		&i.ServiceChargeAmount,
 		&i.CustomerID,
+		&i.TaxRate,
+		&i.ServiceChargeRate,
+		&i.TaxInclusive,
+		&i.ShiftID,
+		&i.ParentOrderID,
+		&i.QueueNumber,
+		&i.BusinessDate,
+		&i.AppliedPromotionCodeID,
 	)
 	return i, err
 }
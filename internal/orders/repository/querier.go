@@ -8,35 +8,85 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
 	// Menambahkan stok kembali ke sebuah produk (digunakan saat pesanan dibatalkan).
 	AddProductStock(ctx context.Context, arg AddProductStockParams) (AddProductStockRow, error)
+	// Menambahkan stok varian kembali (pembatalan, refund, pengurangan qty).
+	AddVariantStock(ctx context.Context, arg AddVariantStockParams) (int32, error)
+	// Menambah (positif) atau mengurangi (negatif) stok bahan baku.
+	AdjustIngredientStock(ctx context.Context, arg AdjustIngredientStockParams) error
 	BatchCreateOrderItemOptions(ctx context.Context, arg []BatchCreateOrderItemOptionsParams) (int64, error)
 	// Memasukkan banyak item sekaligus menggunakan array (Bulk Insert).
 	BatchCreateOrderItems(ctx context.Context, arg BatchCreateOrderItemsParams) ([]OrderItem, error)
 	// Mengurangi stok banyak produk sekaligus berdasarkan pasangan ID dan Qty.
 	BatchDecreaseProductStock(ctx context.Context, arg BatchDecreaseProductStockParams) error
+	// Menaikkan versi pesanan setelah pembayaran parsial (optimistic locking).
+	BumpOrderVersion(ctx context.Context, arg BumpOrderVersionParams) (Order, error)
 	// Mengubah status pesanan menjadi 'cancelled' dan mencatat alasannya.
 	// Hanya bisa membatalkan pesanan yang statusnya 'open'.
 	CancelOrder(ctx context.Context, arg CancelOrderParams) (Order, error)
+	// Menghapus tagihan payment gateway yang masih tertunda dari pesanan.
+	ClearOrderPaymentGateway(ctx context.Context, id uuid.UUID) error
+	// Mengurangi sisa poin sebuah perolehan saat poinnya ditukar atau ditarik kembali.
+	ConsumePointLot(ctx context.Context, arg ConsumePointLotParams) error
+	// Menyalin opsi dari satu baris item ke baris item lain (dipakai saat memecah kuantitas).
+	CopyOrderItemOptions(ctx context.Context, arg CopyOrderItemOptionsParams) error
 	// Menghitung total pesanan dengan filter.
 	CountOrders(ctx context.Context, arg CountOrdersParams) (int64, error)
+	CreateIngredientStockHistory(ctx context.Context, arg CreateIngredientStockHistoryParams) (IngredientStockHistory, error)
+	// Mencatat satu baris buku poin pelanggan.
+	CreateLoyaltyPointEntry(ctx context.Context, arg CreateLoyaltyPointEntryParams) (LoyaltyPointEntry, error)
+	// Pesanan otomatis ditautkan ke shift kasir yang sedang terbuka.
 	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
 	// Menambahkan satu item produk ke dalam pesanan.
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error)
 	// Menambahkan satu varian/opsi ke dalam sebuah order item.
 	CreateOrderItemOption(ctx context.Context, arg CreateOrderItemOptionParams) (OrderItemOption, error)
+	// Mencatat satu baris tender (split payment) pada shift kasir yang memprosesnya (atau shift asal pesanan).
+	CreateOrderPayment(ctx context.Context, arg CreateOrderPaymentParams) (OrderPayment, error)
+	// Menyimpan satu promosi yang diterapkan pada pesanan beserta bagian diskonnya dan jendela jadwal saat diterapkan.
+	CreateOrderPromotion(ctx context.Context, arg CreateOrderPromotionParams) error
+	// Mencatat pengembalian dana pada shift kasir yang memprosesnya (atau shift asal pesanan).
+	CreateOrderRefund(ctx context.Context, arg CreateOrderRefundParams) (OrderRefund, error)
+	// Mencatat baris item (dan jumlahnya) yang dikembalikan dalam satu refund.
+	CreateOrderRefundItem(ctx context.Context, arg CreateOrderRefundItemParams) (OrderRefundItem, error)
+	// Mencatat satu transisi status pesanan (from_status NULL untuk pesanan baru).
+	CreateOrderStatusHistory(ctx context.Context, arg CreateOrderStatusHistoryParams) error
+	// Mencatat penukaran promosi saat pesanan lunas; promosi pesanan yang sudah tercatat dilewati.
+	CreatePromotionRedemption(ctx context.Context, arg CreatePromotionRedemptionParams) error
+	// Membuat pesanan anak hasil split bill; kasir, jenis, pelanggan, shift, dan nomor antrian mengikuti pesanan induk.
+	// Bill yang dipecah lagi tetap menjadi anak pesanan asalnya.
+	CreateSplitOrder(ctx context.Context, id uuid.UUID) (Order, error)
 	CreateStockHistory(ctx context.Context, arg CreateStockHistoryParams) (StockHistory, error)
 	// Mengurangi stok produk.
 	DecreaseProductStock(ctx context.Context, arg DecreaseProductStockParams) (Product, error)
+	// Mengurangi stok varian; stok produk induk diperbarui oleh trigger.
+	DecreaseVariantStock(ctx context.Context, arg DecreaseVariantStockParams) (int32, error)
 	// Menghapus satu item dari pesanan.
 	DeleteOrderItem(ctx context.Context, arg DeleteOrderItemParams) error
 	DeleteOrderItemOptionsByOrderItemID(ctx context.Context, orderItemID uuid.UUID) error
 	DeleteOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) error
+	// Menghapus promosi pesanan sebelum hasil evaluasi terbaru disimpan.
+	DeleteOrderPromotions(ctx context.Context, orderID uuid.UUID) error
+	// Mengambil metode pembayaran aktif pertama untuk jenis tertentu (mis. 'gateway' untuk Midtrans).
+	GetActivePaymentMethodByKind(ctx context.Context, kind PaymentMethodKind) (PaymentMethod, error)
+	// Mencari kode promosi yang masih aktif (tidak peka huruf besar/kecil).
+	GetActivePromotionCode(ctx context.Context, code string) (PromotionCode, error)
+	// Mengambil alasan pembatalan sistem (mis. 'Order Merged') berdasarkan teksnya.
+	GetCancellationReasonByReason(ctx context.Context, reason string) (CancellationReason, error)
+	// Menghitung saldo poin pelanggan; sisa poin yang sudah kedaluwarsa tidak dihitung walau belum dicatat.
+	GetCustomerPointsBalance(ctx context.Context, customerID uuid.UUID) (int64, error)
+	// Mengunci bahan baku yang dipakai pesanan; urutan id mencegah deadlock antar transaksi.
+	GetIngredientsForUpdate(ctx context.Context, ids []uuid.UUID) ([]Ingredient, error)
+	// Mengambil tambahan/pengganti bahan baku untuk beberapa opsi produk.
+	GetOptionRecipes(ctx context.Context, optionIds []uuid.UUID) ([]ProductOptionRecipeItem, error)
 	// Mengambil semua varian untuk beberapa produk.
 	GetOptionsForProducts(ctx context.Context, dollar_1 []uuid.UUID) ([]ProductOption, error)
+	// Mengambil satu pesanan tanpa mengunci barisnya, untuk pembacaan saja.
+	GetOrder(ctx context.Context, id uuid.UUID) (Order, error)
 	// Mengambil pesanan berdasarkan referensi dari payment gateway.
 	GetOrderByGatewayRef(ctx context.Context, paymentGatewayReference *string) (Order, error)
 	// Mengambil satu pesanan dan mengunci barisnya untuk pembaruan (mencegah race condition).
@@ -46,20 +96,76 @@ type Querier interface {
 	GetOrderItem(ctx context.Context, arg GetOrderItemParams) (OrderItem, error)
 	// Mengambil semua item dari sebuah pesanan untuk menghitung ulang total.
 	GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) ([]OrderItem, error)
+	// Merangkum poin sebuah pesanan: yang diperoleh dan yang sudah ditarik kembali, serta yang ditukar sebagai diskon atau pembayaran.
+	GetOrderLoyaltySummary(ctx context.Context, orderID pgtype.UUID) (GetOrderLoyaltySummaryRow, error)
+	// Mengambil promosi yang diterapkan pada pesanan beserta bagian diskonnya, sesuai urutan penerapan.
+	GetOrderPromotions(ctx context.Context, orderID uuid.UUID) ([]GetOrderPromotionsRow, error)
 	// Mengambil detail lengkap pesanan, termasuk item dan opsinya dalam format JSON.
 	GetOrderWithDetails(ctx context.Context, id uuid.UUID) (GetOrderWithDetailsRow, error)
+	// Opsi aktif beberapa produk beserta aturan grup modifiernya; kolom grup kosong bila opsi tidak masuk grup.
+	GetOrderableOptions(ctx context.Context, productIds []uuid.UUID) ([]GetOrderableOptionsRow, error)
+	// Mengambil metode pembayaran beserta jenis dan flag-nya untuk validasi pembayaran.
+	GetPaymentMethodByID(ctx context.Context, id int32) (PaymentMethod, error)
+	// Produk (atau varian) untuk kode yang dipindai: barcode lebih dulu, lalu SKU tanpa membedakan huruf besar/kecil.
+	GetProductByCode(ctx context.Context, code string) (GetProductByCodeRow, error)
 	GetProductByID(ctx context.Context, id uuid.UUID) (Product, error)
+	// Mengambil pasangan produk-kategori untuk menentukan item yang bebas pajak.
+	GetProductCategoryIDs(ctx context.Context, productIds []uuid.UUID) ([]GetProductCategoryIDsRow, error)
 	GetProductOptionsByIDs(ctx context.Context, ids []uuid.UUID) ([]ProductOption, error)
+	// Mengambil resep (bahan baku per unit) untuk beberapa produk.
+	GetProductRecipes(ctx context.Context, productIds []uuid.UUID) ([]ProductRecipeItem, error)
 	// Mengambil beberapa produk berdasarkan array ID. Ini untuk menghindari N+1 query.
 	GetProductsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([]Product, error)
 	// Mengambil produk sekaligus mengunci barisnya (Row-Level Locking).
 	// Transaksi lain yang mencoba update produk ini harus menunggu sampai transaksi ini selesai.
 	GetProductsForUpdate(ctx context.Context, dollar_1 []uuid.UUID) ([]Product, error)
 	GetPromotionByID(ctx context.Context, id uuid.UUID) (Promotion, error)
+	GetPromotionCodeByID(ctx context.Context, id uuid.UUID) (PromotionCode, error)
+	// Mengambil tanggal-tanggal di mana promosi tidak berlaku.
+	GetPromotionExcludedDates(ctx context.Context, promotionID uuid.UUID) ([]PromotionExcludedDate, error)
+	// Menghitung penukaran aktif sebuah promosi: total, per pelanggan, per kode, dan total diskonnya.
+	// Bill hasil split dihitung sebagai satu penukaran, dan keluarga pesanan yang sedang diperiksa tidak ikut dihitung.
+	GetPromotionRedemptionStats(ctx context.Context, arg GetPromotionRedemptionStatsParams) (GetPromotionRedemptionStatsRow, error)
 	GetPromotionRules(ctx context.Context, promotionID uuid.UUID) ([]PromotionRule, error)
+	// Mengambil jadwal berulang sebuah promosi.
+	GetPromotionSchedules(ctx context.Context, promotionID uuid.UUID) ([]PromotionSchedule, error)
 	GetPromotionTargets(ctx context.Context, promotionID uuid.UUID) ([]PromotionTarget, error)
+	GetPromotionTiers(ctx context.Context, promotionID uuid.UUID) ([]PromotionTier, error)
+	// Menjumlahkan kuantitas yang sudah direfund (dan yang dikembalikan ke stok) per baris item sebuah pesanan.
+	GetRefundedItemQuantities(ctx context.Context, orderID uuid.UUID) ([]GetRefundedItemQuantitiesRow, error)
+	// Mengunci varian aktif dari produk-produk pada pesanan; urutan id mencegah deadlock antar transaksi.
+	GetVariantsForOrder(ctx context.Context, productIds []uuid.UUID) ([]ProductVariant, error)
+	// Mengambil promosi yang aktif dan sedang berlaku, dari prioritas tertinggi.
+	ListActivePromotions(ctx context.Context) ([]Promotion, error)
+	// Pesanan hari bisnis ini yang masih ditunggu tamu, beserta ringkasan status dapur per pesanan.
+	ListCallingBoardOrders(ctx context.Context, businessDate pgtype.Date) ([]ListCallingBoardOrdersRow, error)
+	// Mengambil poin pelanggan yang masih tersisa dan belum kedaluwarsa, yang paling cepat kedaluwarsa lebih dulu.
+	ListCustomerPointLots(ctx context.Context, customerID uuid.UUID) ([]LoyaltyPointEntry, error)
+	// Opsi yang dipilih per baris item sebuah pesanan, untuk menghitung bahan baku yang dipakai.
+	ListOrderItemOptionIDs(ctx context.Context, orderID uuid.UUID) ([]ListOrderItemOptionIDsRow, error)
+	// Mengambil semua baris tender sebuah pesanan sesuai urutan pembayaran.
+	ListOrderPayments(ctx context.Context, orderID uuid.UUID) ([]OrderPayment, error)
+	// Mengambil semua refund sebuah pesanan (untuk menghitung sisa dana per tender).
+	ListOrderRefunds(ctx context.Context, orderID uuid.UUID) ([]OrderRefund, error)
 	ListOrders(ctx context.Context, arg ListOrdersParams) ([]ListOrdersRow, error)
+	// Mengunci baris pelanggan agar perolehan dan penukaran poinnya tidak balapan.
+	LockCustomer(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	// Mengunci baris promosi agar pengecekan batas penukaran dan pencatatannya tidak balapan.
+	LockPromotion(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	// Memindahkan satu baris item (beserta opsinya) ke pesanan lain.
+	MoveOrderItem(ctx context.Context, arg MoveOrderItemParams) error
+	// Mengambil nomor antrian berikutnya untuk hari bisnis; baris counter terkunci sampai transaksi selesai.
+	NextQueueNumber(ctx context.Context, businessDate pgtype.Date) (int32, error)
+	// Memeriksa apakah promosi punya kode; promosi berkode hanya bisa dipakai lewat kodenya.
+	PromotionHasCodes(ctx context.Context, promotionID uuid.UUID) (bool, error)
+	// Data pembayaran dipertahankan agar rekonsiliasi shift tetap mencatat penjualan aslinya.
 	RefundOrder(ctx context.Context, id uuid.UUID) (Order, error)
+	// Melepas penukaran promosi sebuah pesanan yang dibatalkan atau di-refund penuh.
+	ReleasePromotionRedemptions(ctx context.Context, orderID uuid.UUID) error
+	// Menyimpan harga pokok per unit sebuah item pesanan sesuai lapisan biaya yang dipakai (metode FIFO).
+	SetOrderItemCost(ctx context.Context, arg SetOrderItemCostParams) error
+	// Menyimpan bagian diskon promosi per baris item; net_subtotal dihitung ulang dari subtotal.
+	SetOrderItemDiscounts(ctx context.Context, arg SetOrderItemDiscountsParams) error
 	UpdateOrderAppliedPromotion(ctx context.Context, arg UpdateOrderAppliedPromotionParams) error
 	// Update qty dan subtotal. Penting: Tambahkan validasi stok/constraint di level aplikasi
 	// atau pastikan trigger handle pengurangan stok jika qty bertambah.
@@ -71,6 +177,8 @@ type Querier interface {
 	UpdateOrderPaymentInfo(ctx context.Context, arg UpdateOrderPaymentInfoParams) error
 	// Menyimpan URL pembayaran (QR string atau deep link) dan token.
 	UpdateOrderPaymentUrl(ctx context.Context, arg UpdateOrderPaymentUrlParams) error
+	// Memperbarui bagian diskon sebuah promosi pesanan, misalnya setelah pesanan dipecah.
+	UpdateOrderPromotionDiscount(ctx context.Context, arg UpdateOrderPromotionDiscountParams) error
 	// Memperbarui status operasional sebuah pesanan.
 	// Validasi transisi status dilakukan di level aplikasi/service.
 	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error)
@@ -0,0 +1,69 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"POS-kasir/pkg/utils"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ScanOrderItem adds the product behind a scanned barcode or typed SKU to an open order: the quantity goes
+// onto the line the order already has for that product and variant, or onto a new line with the default
+// options, so the cashier never has to browse the catalog.
+func (s *OrderService) ScanOrderItem(ctx context.Context, orderID uuid.UUID, req ScanOrderItemRequest) (*OrderDetailResponse, error) {
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+
+	match, err := s.ordersRepo.GetProductByCode(ctx, strings.TrimSpace(req.Code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrProductCodeNotFound
+		}
+		s.log.Error("Failed to look up scanned code", "error", err, "code", req.Code)
+		return nil, err
+	}
+
+	scanned := UpdateOrderItemRequest{
+		ProductID: match.ProductID,
+		VariantID: utils.NullableUUIDToPointer(match.VariantID),
+		Quantity:  quantity,
+	}
+	return s.updateOrderItems(ctx, orderID, req.Version, func(existing []orders_repo.OrderItem) []UpdateOrderItemRequest {
+		return scannedOrderLines(existing, scanned)
+	})
+}
+
+// scannedOrderLines keeps the order's lines as they are and adds the scanned quantity to the line
+// updateOrderItems matches for its product and variant, or appends the scan as a new line.
+func scannedOrderLines(existing []orders_repo.OrderItem, scanned UpdateOrderItemRequest) []UpdateOrderItemRequest {
+	lines := make([]UpdateOrderItemRequest, 0, len(existing)+1)
+	index := make(map[lineKey]int, len(existing))
+	for _, item := range existing {
+		line := UpdateOrderItemRequest{
+			ProductID: item.ProductID,
+			VariantID: utils.NullableUUIDToPointer(item.VariantID),
+			Quantity:  item.Quantity,
+		}
+		// Like updateOrderItems, the last line of a product and variant is the one that gets updated
+		key := itemLineKey(item)
+		if i, ok := index[key]; ok {
+			lines[i] = line
+			continue
+		}
+		index[key] = len(lines)
+		lines = append(lines, line)
+	}
+
+	if i, ok := index[requestLineKey(scanned.ProductID, scanned.VariantID)]; ok {
+		lines[i].Quantity += scanned.Quantity
+		return lines
+	}
+	return append(lines, scanned)
+}
@@ -0,0 +1,38 @@
+package orders
+
+import (
+	orders_repo "POS-kasir/internal/orders/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScannedOrderLines(t *testing.T) {
+	coffee := uuid.New()
+	tea := uuid.New()
+	large := uuid.New()
+
+	existing := []orders_repo.OrderItem{
+		{ProductID: coffee, Quantity: 2},
+		{ProductID: tea, VariantID: pgtype.UUID{Bytes: large, Valid: true}, Quantity: 1},
+	}
+
+	// Scanning what the order already has adds to that line
+	lines := scannedOrderLines(existing, UpdateOrderItemRequest{ProductID: tea, VariantID: &large, Quantity: 1})
+	assert.Len(t, lines, 2)
+	assert.Equal(t, int32(2), lines[0].Quantity)
+	assert.Equal(t, int32(2), lines[1].Quantity)
+
+	// The same product without the variant is a different line
+	lines = scannedOrderLines(existing, UpdateOrderItemRequest{ProductID: tea, Quantity: 3})
+	assert.Len(t, lines, 3)
+	assert.Equal(t, tea, lines[2].ProductID)
+	assert.Nil(t, lines[2].VariantID)
+	assert.Equal(t, int32(3), lines[2].Quantity)
+
+	// An empty order gets the scan as its first line
+	lines = scannedOrderLines(nil, UpdateOrderItemRequest{ProductID: coffee, Quantity: 1})
+	assert.Equal(t, []UpdateOrderItemRequest{{ProductID: coffee, Quantity: 1}}, lines)
+}
@@ -4,13 +4,16 @@ import (
 	"POS-kasir/internal/activitylog"
 	activity_repo "POS-kasir/internal/activitylog/repository"
 	"POS-kasir/internal/common"
+	"POS-kasir/internal/common/middleware"
 	"POS-kasir/internal/common/pagination"
 	"POS-kasir/internal/common/store"
+	costing_repo "POS-kasir/internal/costing/repository"
 	orders_repo "POS-kasir/internal/orders/repository"
 	products_repo "POS-kasir/internal/products/repository"
+	"POS-kasir/internal/settings"
 	"POS-kasir/pkg/logger"
-	"strconv"
-	"time"
+	"sort"
+	"strings"
 
 	"POS-kasir/pkg/payment"
 	"POS-kasir/pkg/utils"
@@ -18,7 +21,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -35,10 +41,21 @@ type IOrderService interface {
 	ListOrders(ctx context.Context, req ListOrdersRequest) (*PagedOrderResponse, error)
 	CancelOrder(ctx context.Context, orderID uuid.UUID, req CancelOrderRequest) error
 	UpdateOrderItems(ctx context.Context, orderID uuid.UUID, req UpdateOrderItemsRequest) (*OrderDetailResponse, error)
+	ScanOrderItem(ctx context.Context, orderID uuid.UUID, req ScanOrderItemRequest) (*OrderDetailResponse, error)
 	ConfirmManualPayment(ctx context.Context, orderID uuid.UUID, req ConfirmManualPaymentRequest) (*OrderDetailResponse, error)
+	AddOrderPayment(ctx context.Context, orderID uuid.UUID, req AddOrderPaymentRequest) (*OrderDetailResponse, error)
+	SplitOrder(ctx context.Context, orderID uuid.UUID, req SplitOrderRequest) (*SplitOrderResponse, error)
+	MergeOrders(ctx context.Context, targetOrderID uuid.UUID, req MergeOrdersRequest) (*OrderDetailResponse, error)
+	ReopenOrder(ctx context.Context, orderID uuid.UUID, req ReopenOrderRequest) (*OrderDetailResponse, error)
 	UpdateOperationalStatus(ctx context.Context, orderID uuid.UUID, req UpdateOrderStatusRequest) (*OrderDetailResponse, error)
 	ApplyPromotion(ctx context.Context, orderID uuid.UUID, req ApplyPromotionRequest) (*OrderDetailResponse, error)
+	EvaluatePromotions(ctx context.Context, orderID uuid.UUID) (*PromotionEvaluationResponse, error)
+	AutoApplyPromotions(ctx context.Context, orderID uuid.UUID) (*OrderDetailResponse, error)
 	RefundOrder(ctx context.Context, orderID uuid.UUID, req RefundOrderRequest) (*OrderDetailResponse, error)
+	RedeemPoints(ctx context.Context, orderID uuid.UUID, req RedeemPointsRequest) (*OrderDetailResponse, error)
+	CancelPointsRedemption(ctx context.Context, orderID uuid.UUID) (*OrderDetailResponse, error)
+	GetCallingBoard(ctx context.Context) (*CallingBoardResponse, error)
+	PublishCallingBoard(ctx context.Context)
 }
 
 type OrderService struct {
@@ -47,70 +64,39 @@ type OrderService struct {
 	productsRepo    products_repo.Querier
 	midtransService payment.IMidtrans
 	activityService activitylog.IActivityService
+	settingsService settings.ISettingsService
 	log             logger.ILogger
 	wsHub           *ws.Hub
 }
 
-func NewOrderService(store store.Store, ordersRepo orders_repo.Querier, productsRepo products_repo.Querier, midtransService payment.IMidtrans, activityService activitylog.IActivityService, log logger.ILogger, wsHub *ws.Hub) IOrderService {
+func NewOrderService(store store.Store, ordersRepo orders_repo.Querier, productsRepo products_repo.Querier, midtransService payment.IMidtrans, activityService activitylog.IActivityService, settingsService settings.ISettingsService, log logger.ILogger, wsHub *ws.Hub) IOrderService {
 	return &OrderService{
 		store:           store,
 		ordersRepo:      ordersRepo,
 		productsRepo:    productsRepo,
 		midtransService: midtransService,
 		activityService: activityService,
+		settingsService: settingsService,
 		log:             log,
 		wsHub:           wsHub,
 	}
 }
 
-var allowedStatusTransitions = map[orders_repo.OrderStatus]map[orders_repo.OrderStatus]bool{
-	orders_repo.OrderStatusOpen: {
-		orders_repo.OrderStatusInProgress: true,
-		orders_repo.OrderStatusServed:     true,
-		orders_repo.OrderStatusPaid:       true,
-		orders_repo.OrderStatusCancelled:  true,
-		orders_repo.OrderStatusOpen:       true,
-	},
-	orders_repo.OrderStatusInProgress: {
-		orders_repo.OrderStatusInProgress: true,
-		orders_repo.OrderStatusServed:     true,
-		orders_repo.OrderStatusPaid:       true,
-		orders_repo.OrderStatusCancelled:  true,
-		orders_repo.OrderStatusOpen:       true,
-	},
-	orders_repo.OrderStatusServed: {
-		orders_repo.OrderStatusServed:     true,
-		orders_repo.OrderStatusPaid:       true,
-		orders_repo.OrderStatusInProgress: true,
-		orders_repo.OrderStatusCancelled:  true,
-		orders_repo.OrderStatusOpen:       true,
-	},
-	orders_repo.OrderStatusPaid: {
-		orders_repo.OrderStatusPaid:       true,
-		orders_repo.OrderStatusServed:     true,
-		orders_repo.OrderStatusInProgress: true,
-		orders_repo.OrderStatusOpen:       true,
-		orders_repo.OrderStatusCancelled:  true,
-	},
-	orders_repo.OrderStatusCancelled: {
-		orders_repo.OrderStatusCancelled:  true,
-		orders_repo.OrderStatusOpen:       true,
-		orders_repo.OrderStatusInProgress: true,
-	},
-}
-
 func (s *OrderService) ApplyPromotion(ctx context.Context, orderID uuid.UUID, req ApplyPromotionRequest) (*OrderDetailResponse, error) {
 	var finalOrder orders_repo.GetOrderWithDetailsRow
+	var appliedPromotionID uuid.UUID
+
+	taxRules, err := s.settingsService.GetTaxSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load tax settings", "error", err)
+		return nil, err
+	}
 
 	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
 		qtx := orders_repo.New(tx)
-		order, err := qtx.GetOrderForUpdate(ctx, orderID)
+		order, err := s.openUnpaidOrder(ctx, qtx, orderID)
 		if err != nil {
-			return common.ErrNotFound
-		}
-
-		if order.Status != orders_repo.OrderStatusOpen {
-			return common.ErrOrderNotModifiable
+			return err
 		}
 
 		orderItems, err := qtx.GetOrderItemsByOrderID(ctx, orderID)
@@ -118,186 +104,139 @@ func (s *OrderService) ApplyPromotion(ctx context.Context, orderID uuid.UUID, re
 			return fmt.Errorf("failed to get order items: %w", err)
 		}
 
-		promo, err := qtx.GetPromotionByID(ctx, req.PromotionID)
+		promotionID, codeID, err := resolvePromotion(ctx, qtx, req)
+		if err != nil {
+			return err
+		}
+		appliedPromotionID = promotionID
+
+		promo, err := qtx.GetPromotionByID(ctx, promotionID)
 		if err != nil {
 			return common.ErrNotFound
 		}
+		discount, err := s.evaluateCandidate(ctx, qtx, promo, codeID, order, orderItems)
+		if err != nil {
+			return err
+		}
 
-		now := time.Now()
-		if !promo.IsActive {
-			return fmt.Errorf("%w: promotion is not active", common.ErrPromotionNotApplicable)
+		// The new promotion takes precedence over the ones the cashier applied before; auto-apply
+		// promotions are picked again around them
+		manual := []promotionCandidate{{Promotion: promo, CodeID: codeID, Discount: discount}}
+		current, err := orderPromotions(ctx, qtx, orderID, order.AppliedPromotionID)
+		if err != nil {
+			return err
 		}
-		if now.Before(promo.StartDate.Time) {
-			return fmt.Errorf("%w: promotion has not started yet", common.ErrPromotionNotApplicable)
+		pinned := make([]pinnedPromotion, 0, len(current))
+		for _, p := range current {
+			if !p.AutoApplied && p.PromotionID != promotionID {
+				pinned = append(pinned, pinnedPromotion{PromotionID: p.PromotionID, CodeID: p.PromotionCodeID})
+			}
 		}
-		if now.After(promo.EndDate.Time) {
-			return fmt.Errorf("%w: promotion has expired", common.ErrPromotionNotApplicable)
+		active, err := qtx.ListActivePromotions(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list active promotions: %w", err)
 		}
-
-		rules, err := qtx.GetPromotionRules(ctx, promo.ID)
+		others, auto, err := s.promotionCandidates(ctx, qtx, order, orderItems, pinned, withoutPromotion(active, promotionID))
 		if err != nil {
-			return fmt.Errorf("failed to get promotion rules: %w", err)
+			return err
 		}
 
-		productCategoryCache := make(map[uuid.UUID][]int)
-		getProductCategories := func(id uuid.UUID) []int {
-			if cats, ok := productCategoryCache[id]; ok {
-				return cats
-			}
-			var cats []int
-			rows, err := tx.Query(ctx, "SELECT category_id FROM product_categories WHERE product_id = $1", id)
-			if err == nil {
-				defer rows.Close()
-				for rows.Next() {
-					var cid int
-					if err := rows.Scan(&cid); err == nil {
-						cats = append(cats, cid)
-					}
-				}
-			}
-			productCategoryCache[id] = cats
-			return cats
+		sel := combinePromotions(selectPromotions(append(manual, others...), auto), orderItems)
+		if _, err := s.savePromotions(ctx, qtx, order, orderItems, sel, true, order.Version, taxRules); err != nil {
+			return err
 		}
 
-		for _, rule := range rules {
-			switch rule.RuleType {
-			case orders_repo.PromotionRuleTypeMINIMUMORDERAMOUNT:
-				minAmount, err := strconv.ParseInt(rule.RuleValue, 10, 64)
-				if err != nil {
-					s.log.Warnf("Invalid rule value for MINIMUM_ORDER_AMOUNT: %s", rule.RuleValue)
-					continue
-				}
-				if order.GrossTotal < minAmount {
-					return fmt.Errorf("%w: minimum order amount not met (min: %d)", common.ErrPromotionNotApplicable, minAmount)
-				}
+		finalOrder, err = qtx.GetOrderWithDetails(ctx, orderID)
+		return err
+	})
 
-			case orders_repo.PromotionRuleTypeREQUIREDPRODUCT:
-				requiredProductID, err := uuid.Parse(rule.RuleValue)
-				if err != nil {
-					s.log.Warnf("Invalid rule value for REQUIRED_PRODUCT: %s", rule.RuleValue)
-					continue
-				}
-				found := false
-				for _, item := range orderItems {
-					if item.ProductID == requiredProductID {
-						found = true
-						break
-					}
-				}
-				if !found {
-					return fmt.Errorf("%w: required product not found in order", common.ErrPromotionNotApplicable)
-				}
+	if txErr != nil {
+		return nil, txErr
+	}
 
-			case orders_repo.PromotionRuleTypeREQUIREDCATEGORY:
-				requiredCategoryID, err := strconv.Atoi(rule.RuleValue)
-				if err != nil {
-					s.log.Warnf("Invalid rule value for REQUIRED_CATEGORY: %s", rule.RuleValue)
-					continue
-				}
-				found := false
-				for _, item := range orderItems {
-					cats := getProductCategories(item.ProductID)
-					for _, cid := range cats {
-						if cid == requiredCategoryID {
-							found = true
-							break
-						}
-					}
-					if found {
-						break
-					}
-				}
-				if !found {
-					return fmt.Errorf("%w: required category item not found in order", common.ErrPromotionNotApplicable)
-				}
-			}
-		}
+	actorID, ok := ctx.Value(common.UserIDKey).(uuid.UUID)
+	if !ok {
+		s.log.Warnf("UpdateOrder | Actor user ID not found in context for activity logging")
+	}
 
-		var discountAmount int64
-		grossTotal := order.GrossTotal
+	logDetails := map[string]interface{}{
+		"updated_order_id":     orderID,
+		"updated_order_status": finalOrder.Status,
+		"promotion_id":         appliedPromotionID,
+	}
+	if req.Code != "" {
+		logDetails["promotion_code"] = req.Code
+	}
 
-		if promo.Scope == orders_repo.PromotionScopeITEM {
-			targets, err := qtx.GetPromotionTargets(ctx, promo.ID)
-			if err != nil {
-				return fmt.Errorf("failed to get promotion targets: %w", err)
-			}
-
-			var eligibleTotal int64
-			for _, item := range orderItems {
-				isEligible := false
-				for _, target := range targets {
-					if target.TargetType == orders_repo.PromotionTargetTypePRODUCT {
-						if target.TargetID == item.ProductID.String() {
-							isEligible = true
-							break
-						}
-					} else if target.TargetType == orders_repo.PromotionTargetTypeCATEGORY {
-						targetCatID, _ := strconv.Atoi(target.TargetID)
-						cats := getProductCategories(item.ProductID)
-						for _, cid := range cats {
-							if cid == targetCatID {
-								isEligible = true
-								break
-							}
-						}
-					}
-				}
-				if isEligible {
-					eligibleTotal += item.Subtotal
-				}
-			}
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activity_repo.LogActionTypeAPPLYPROMOTION,
+		activity_repo.LogEntityTypeORDER,
+		orderID.String(),
+		logDetails,
+	)
 
-			if eligibleTotal > 0 {
-				if promo.DiscountType == orders_repo.DiscountTypePercentage {
-					percentage := utils.NumericToInt64(promo.DiscountValue)
-					discountAmount = (eligibleTotal * percentage) / 100
-				} else {
-					discountAmount = utils.NumericToInt64(promo.DiscountValue)
-				}
-			}
+	if s.wsHub != nil {
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
+	}
 
-		} else {
+	return s.buildOrderDetailResponseFromQueryResult(ctx, finalOrder)
+}
 
-			if promo.DiscountType == orders_repo.DiscountTypePercentage {
-				percentage := utils.NumericToInt64(promo.DiscountValue)
-				discountAmount = (grossTotal * percentage) / 100
-			} else {
-				discountAmount = utils.NumericToInt64(promo.DiscountValue)
-			}
+// EvaluatePromotions is a dry run of the promotion engine on an open order: it lists the promotions on the
+// order and every active promotion with the discount each would give on its own, and the combination the
+// stacking rules would pick. Nothing on the order changes.
+func (s *OrderService) EvaluatePromotions(ctx context.Context, orderID uuid.UUID) (*PromotionEvaluationResponse, error) {
+	// A dry run only reads, so it takes no lock that would hold up the cashier working on the order
+	order, err := s.ordersRepo.GetOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
 		}
+		return nil, err
+	}
+	if order.Status != orders_repo.OrderStatusOpen {
+		return nil, common.ErrOrderNotModifiable
+	}
 
-		maxDisc := utils.NumericToInt64(promo.MaxDiscountAmount)
-		if maxDisc > 0 && discountAmount > maxDisc {
-			discountAmount = maxDisc
-		}
+	items, err := s.ordersRepo.GetOrderItemsByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order items: %w", err)
+	}
+	current, err := orderPromotions(ctx, s.ordersRepo, orderID, order.AppliedPromotionID)
+	if err != nil {
+		return nil, err
+	}
+	active, err := s.ordersRepo.ListActivePromotions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active promotions: %w", err)
+	}
 
-		if discountAmount > grossTotal {
-			discountAmount = grossTotal
-		}
+	return s.evaluateOrderPromotions(ctx, s.ordersRepo, order, items, current, active)
+}
 
-		taxAmount := order.TaxAmount
-		serviceChargeAmount := order.ServiceChargeAmount
-		netTotal := grossTotal - discountAmount + taxAmount + serviceChargeAmount
+// AutoApplyPromotions reprices an open order with the promotion engine: the promotions the cashier applied
+// are kept while they apply, and the auto-apply promotions are picked again around them.
+func (s *OrderService) AutoApplyPromotions(ctx context.Context, orderID uuid.UUID) (*OrderDetailResponse, error) {
+	var finalOrder orders_repo.GetOrderWithDetailsRow
+	var sel promotionSelection
 
-		_, err = qtx.UpdateOrderTotals(ctx, orders_repo.UpdateOrderTotalsParams{
-			ID:                  orderID,
-			GrossTotal:          grossTotal,
-			DiscountAmount:      discountAmount,
-			NetTotal:            netTotal,
-			TaxAmount:           taxAmount,
-			ServiceChargeAmount: serviceChargeAmount,
-			Version:             order.Version,
-		})
+	taxRules, err := s.settingsService.GetTaxSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load tax settings", "error", err)
+		return nil, err
+	}
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := orders_repo.New(tx)
+		order, err := s.openUnpaidOrder(ctx, qtx, orderID)
 		if err != nil {
 			return err
 		}
 
-		err = qtx.UpdateOrderAppliedPromotion(ctx, orders_repo.UpdateOrderAppliedPromotionParams{
-			ID:                 orderID,
-			AppliedPromotionID: pgtype.UUID{Bytes: promo.ID, Valid: true},
-		})
-		if err != nil {
-			return fmt.Errorf("failed to update applied promotion: %w", err)
+		if _, sel, err = s.repricePromotions(ctx, tx, qtx, order, taxRules); err != nil {
+			return err
 		}
 
 		finalOrder, err = qtx.GetOrderWithDetails(ctx, orderID)
@@ -308,15 +247,16 @@ func (s *OrderService) ApplyPromotion(ctx context.Context, orderID uuid.UUID, re
 		return nil, txErr
 	}
 
-	actorID, ok := ctx.Value(common.UserIDKey).(uuid.UUID)
-	if !ok {
-		s.log.Warnf("UpdateOrder | Actor user ID not found in context for activity logging")
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	promotionIDs := make([]string, 0, len(sel.Applied))
+	for _, a := range sel.Applied {
+		promotionIDs = append(promotionIDs, a.Promotion.ID.String())
 	}
-
 	logDetails := map[string]interface{}{
 		"updated_order_id":     orderID,
 		"updated_order_status": finalOrder.Status,
-		"promotion_id":         req.PromotionID,
+		"promotion_ids":        promotionIDs,
+		"discount_amount":      finalOrder.DiscountAmount,
 	}
 
 	s.activityService.Log(
@@ -329,71 +269,155 @@ func (s *OrderService) ApplyPromotion(ctx context.Context, orderID uuid.UUID, re
 	)
 
 	if s.wsHub != nil {
-		s.wsHub.BroadcastEvent(ws.EventOrderUpdated, map[string]interface{}{"order_id": orderID})
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
 	}
 
 	return s.buildOrderDetailResponseFromQueryResult(ctx, finalOrder)
 }
 
 func (s *OrderService) UpdateOperationalStatus(ctx context.Context, orderID uuid.UUID, req UpdateOrderStatusRequest) (*OrderDetailResponse, error) {
+	var finalOrder orders_repo.GetOrderWithDetailsRow
+	var currentStatus orders_repo.OrderStatus
+	newStatus := req.Status
 
-	order, err := s.ordersRepo.GetOrderWithDetails(ctx, orderID)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			s.log.Warn("Order not found for status update", "orderID", orderID)
-			return nil, common.ErrNotFound
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := orders_repo.New(tx)
+
+		order, err := qtx.GetOrderForUpdate(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				s.log.Warn("Order not found for status update", "orderID", orderID)
+				return common.ErrNotFound
+			}
+			return err
 		}
-		s.log.Error("Failed to get order for status update", "error", err)
-		return nil, err
-	}
+		currentStatus = order.Status
 
-	currentStatus := order.Status
-	newStatus := req.Status
+		if currentStatus != newStatus {
+			if err := s.transitionOrderStatus(ctx, qtx, order, newStatus, viaOperational, nil); err != nil {
+				return err
+			}
+		}
+
+		finalOrder, err = qtx.GetOrderWithDetails(ctx, orderID)
+		return err
+	})
 
-	if currentStatus == newStatus {
-		return s.GetOrder(ctx, orderID)
+	if txErr != nil {
+		if errors.Is(txErr, common.ErrInvalidStatusTransition) {
+			s.log.Warn("Rejected order status transition", "orderID", orderID, "currentStatus", currentStatus, "newStatus", newStatus, "error", txErr)
+		} else {
+			s.log.Error("Failed to update order status", "error", txErr, "orderID", orderID)
+		}
+		return nil, txErr
 	}
 
-	allowed, ok := allowedStatusTransitions[currentStatus][newStatus]
-	if !ok || !allowed {
-		errMsg := fmt.Sprintf("invalid status transition from '%s' to '%s'", currentStatus, newStatus)
-		s.log.Warn(errMsg, "orderID", orderID, "currentStatus", currentStatus, "newStatus", newStatus)
-		return nil, fmt.Errorf("%w: %s", common.ErrInvalidStatusTransition, errMsg)
+	if currentStatus != newStatus {
+		actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+		logDetails := map[string]interface{}{
+			"order_id":    orderID.String(),
+			"status_from": currentStatus,
+			"status_to":   newStatus,
+		}
+		s.activityService.Log(
+			ctx,
+			actorID,
+			activity_repo.LogActionTypeUPDATE,
+			activity_repo.LogEntityTypeORDER,
+			orderID.String(),
+			logDetails,
+		)
+
+		if s.wsHub != nil {
+			s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
+			s.PublishCallingBoard(ctx)
+		}
 	}
 
-	_, err = s.ordersRepo.UpdateOrderStatus(ctx, orders_repo.UpdateOrderStatusParams{
-		ID:     orderID,
-		Status: newStatus,
+	return s.buildOrderDetailResponseFromQueryResult(ctx, finalOrder)
+}
+
+// ReopenOrder moves an unpaid in-progress or served order back to open so its items can be edited again.
+// The route is restricted to managers; the reason is kept in the status history.
+func (s *OrderService) ReopenOrder(ctx context.Context, orderID uuid.UUID, req ReopenOrderRequest) (*OrderDetailResponse, error) {
+	var finalOrder orders_repo.GetOrderWithDetailsRow
+	var previousStatus orders_repo.OrderStatus
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := orders_repo.New(tx)
+
+		order, err := qtx.GetOrderForUpdate(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return common.ErrNotFound
+			}
+			return err
+		}
+		if order.Version != req.Version {
+			return common.ErrOrderConflict
+		}
+		previousStatus = order.Status
+
+		if err := s.transitionOrderStatus(ctx, qtx, order, orders_repo.OrderStatusOpen, viaReopen, &req.Reason); err != nil {
+			return err
+		}
+
+		finalOrder, err = qtx.GetOrderWithDetails(ctx, orderID)
+		return err
 	})
-	if err != nil {
-		s.log.Error("Failed to update order status in repository", "error", err, "orderID", orderID)
-		return nil, err
+
+	if txErr != nil {
+		s.log.Warn("Failed to reopen order", "error", txErr, "orderID", orderID)
+		return nil, txErr
 	}
 
 	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
-	logDetails := map[string]interface{}{
-		"order_id":    orderID.String(),
-		"status_from": currentStatus,
-		"status_to":   newStatus,
-	}
 	s.activityService.Log(
 		ctx,
 		actorID,
 		activity_repo.LogActionTypeUPDATE,
 		activity_repo.LogEntityTypeORDER,
 		orderID.String(),
-		logDetails,
+		map[string]interface{}{
+			"action":      "reopen",
+			"status_from": previousStatus,
+			"status_to":   orders_repo.OrderStatusOpen,
+			"reason":      req.Reason,
+		},
 	)
 
 	if s.wsHub != nil {
-		s.wsHub.BroadcastEvent(ws.EventOrderUpdated, map[string]interface{}{"order_id": orderID})
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
+		s.PublishCallingBoard(ctx)
+	}
+
+	return s.buildOrderDetailResponseFromQueryResult(ctx, finalOrder)
+}
+
+// transitionOrderStatus checks a locked order against the lifecycle, then updates its status and records the change.
+func (s *OrderService) transitionOrderStatus(ctx context.Context, qtx *orders_repo.Queries, order orders_repo.Order, to orders_repo.OrderStatus, via transitionVia, note *string) error {
+	payments, err := qtx.ListOrderPayments(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	refunds, err := qtx.ListOrderRefunds(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkStatusTransition(order.Status, to, via, newOrderBalance(order, payments, refunds)); err != nil {
+		return err
 	}
 
-	return s.GetOrder(ctx, orderID)
+	if _, err := qtx.UpdateOrderStatus(ctx, orders_repo.UpdateOrderStatusParams{ID: order.ID, Status: to}); err != nil {
+		return err
+	}
+	return recordStatusChange(ctx, qtx, order.ID, &order.Status, to, note)
 }
 
 func (s *OrderService) ConfirmManualPayment(ctx context.Context, orderID uuid.UUID, req ConfirmManualPaymentRequest) (*OrderDetailResponse, error) {
 	var finalOrder orders_repo.GetOrderWithDetailsRow
+	var promotionRemoved bool
 
 	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
 		qtx := orders_repo.New(tx)
@@ -410,35 +434,40 @@ func (s *OrderService) ConfirmManualPayment(ctx context.Context, orderID uuid.UU
 		}
 
 		if order.PaymentMethodID != nil {
-			return fmt.Errorf("order already paid")
+			return common.ErrOrderAlreadyPaid
 		}
 
-		netTotal := order.NetTotal
-		cashReceived := req.CashReceived
+		method, referenceNumber, err := resolveTenderMethod(ctx, qtx, req.PaymentMethodID, req.ReferenceNumber)
+		if err != nil {
+			return err
+		}
 
-		if req.PaymentMethodID == 3 {
-			if cashReceived == 0 {
-				cashReceived = netTotal
-			}
+		version := req.Version
+		order, promotionRemoved, err = s.enforcePromotionPaymentMethod(ctx, tx, qtx, order, method, version, req.RemoveIneligiblePromotion)
+		if err != nil {
+			return err
+		}
+		if promotionRemoved {
+			version = order.Version
 		}
 
-		if cashReceived < netTotal {
-			return fmt.Errorf("uang kurang: tagihan %d, diterima %d", netTotal, cashReceived)
+		payments, err := qtx.ListOrderPayments(ctx, orderID)
+		if err != nil {
+			return err
 		}
 
-		changeDue := cashReceived - netTotal
+		// A manual confirmation settles whatever is still outstanding in one tender
+		balance := order.NetTotal - sumTenderAmounts(payments)
+		tendered := req.CashReceived
+		if !method.AllowsChange {
+			tendered = balance
+		}
 
-		_, err = qtx.UpdateOrderManualPayment(ctx, orders_repo.UpdateOrderManualPaymentParams{
-			ID:              orderID,
-			PaymentMethodID: utils.Int32Ptr(int(req.PaymentMethodID)),
-			CashReceived:    &cashReceived,
-			ChangeDue:       &changeDue,
-			Version:         req.Version,
-		})
-		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return common.ErrOrderConflict
-			}
+		if tendered < balance {
+			return fmt.Errorf("uang kurang: tagihan %d, diterima %d", balance, tendered)
+		}
+
+		if err := s.recordTender(ctx, qtx, order, payments, method, tendered, referenceNumber, version); err != nil {
 			return err
 		}
 
@@ -456,6 +485,9 @@ func (s *OrderService) ConfirmManualPayment(ctx context.Context, orderID uuid.UU
 		"payment_method_id": req.PaymentMethodID,
 		"amount":            finalOrder.NetTotal,
 	}
+	if promotionRemoved {
+		logDetails["promotion_removed"] = true
+	}
 	s.activityService.Log(
 		ctx,
 		actorID,
@@ -466,70 +498,459 @@ func (s *OrderService) ConfirmManualPayment(ctx context.Context, orderID uuid.UU
 	)
 
 	if s.wsHub != nil {
-		s.wsHub.BroadcastEvent(ws.EventOrderUpdated, map[string]interface{}{"order_id": orderID})
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
 	}
 
 	return s.buildOrderDetailResponseFromQueryResult(ctx, finalOrder)
 }
 
-func (s *OrderService) UpdateOrderItems(ctx context.Context, orderID uuid.UUID, req UpdateOrderItemsRequest) (*OrderDetailResponse, error) {
+func (s *OrderService) AddOrderPayment(ctx context.Context, orderID uuid.UUID, req AddOrderPaymentRequest) (*OrderDetailResponse, error) {
 	var finalOrder orders_repo.GetOrderWithDetailsRow
-	actorID, userIdOk := ctx.Value(common.UserIDKey).(uuid.UUID)
+	var promotionRemoved bool
 
 	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
 		qtx := orders_repo.New(tx)
-		qPrd := products_repo.New(tx)
-
 		order, err := qtx.GetOrderForUpdate(ctx, orderID)
 		if err != nil {
-			return common.ErrNotFound
+			if errors.Is(err, pgx.ErrNoRows) {
+				return common.ErrNotFound
+			}
+			return err
 		}
-		if order.Status != orders_repo.OrderStatusOpen {
+
+		if order.Status == orders_repo.OrderStatusCancelled {
 			return common.ErrOrderNotModifiable
 		}
 
-		if order.Version != req.Version {
-			return common.ErrOrderConflict
+		if order.PaymentMethodID != nil {
+			return common.ErrOrderAlreadyPaid
 		}
 
-		existingItems, err := qtx.GetOrderItemsByOrderID(ctx, orderID)
+		method, referenceNumber, err := resolveTenderMethod(ctx, qtx, req.PaymentMethodID, req.ReferenceNumber)
 		if err != nil {
 			return err
 		}
 
-		currentMap := make(map[uuid.UUID]orders_repo.OrderItem)
-		for _, item := range existingItems {
-			currentMap[item.ProductID] = item
+		version := req.Version
+		order, promotionRemoved, err = s.enforcePromotionPaymentMethod(ctx, tx, qtx, order, method, version, req.RemoveIneligiblePromotion)
+		if err != nil {
+			return err
 		}
-
-		reqMap := make(map[uuid.UUID]int32)
-		for _, item := range req.Items {
-			reqMap[item.ProductID] = item.Quantity
+		if promotionRemoved {
+			version = order.Version
 		}
 
-		var grossTotal int64 = 0
+		payments, err := qtx.ListOrderPayments(ctx, orderID)
+		if err != nil {
+			return err
+		}
 
-		for _, reqItem := range req.Items {
-			product, err := qtx.GetProductByID(ctx, reqItem.ProductID)
-			if err != nil {
-				return err
-			}
+		if err := s.recordTender(ctx, qtx, order, payments, method, req.Amount, referenceNumber, version); err != nil {
+			return err
+		}
 
-			price := product.Price
+		finalOrder, err = qtx.GetOrderWithDetails(ctx, orderID)
+		return err
+	})
 
-			subtotal := price * int64(reqItem.Quantity)
-			grossTotal += subtotal
+	if txErr != nil {
+		return nil, txErr
+	}
 
-			if existingItem, exists := currentMap[reqItem.ProductID]; exists {
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activity_repo.LogActionTypePROCESSPAYMENT,
+		activity_repo.LogEntityTypeORDER,
+		orderID.String(),
+		map[string]interface{}{
+			"order_id":          orderID.String(),
+			"payment_method_id": req.PaymentMethodID,
+			"amount":            req.Amount,
+			"split_payment":     true,
+			"promotion_removed": promotionRemoved,
+		},
+	)
+
+	if s.wsHub != nil {
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
+	}
+
+	return s.buildOrderDetailResponseFromQueryResult(ctx, finalOrder)
+}
+
+// resolveTenderMethod loads a payment method usable for a manual tender and normalises its reference number.
+func resolveTenderMethod(ctx context.Context, qtx *orders_repo.Queries, methodID int32, reference string) (orders_repo.PaymentMethod, *string, error) {
+	method, err := qtx.GetPaymentMethodByID(ctx, methodID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return method, nil, common.ErrPaymentMethodInvalid
+		}
+		return method, nil, err
+	}
+
+	// Gateway payments are settled through the Midtrans notification flow
+	if !method.IsActive || method.Kind == orders_repo.PaymentMethodKindGateway {
+		return method, nil, common.ErrPaymentMethodInvalid
+	}
+
+	var referenceNumber *string
+	if ref := strings.TrimSpace(reference); ref != "" {
+		referenceNumber = &ref
+	}
+	if method.RequiresReference && referenceNumber == nil {
+		return method, nil, common.ErrPaymentReferenceMissing
+	}
+
+	return method, referenceNumber, nil
+}
+
+// recordTender appends a tender to the order's payment ledger. Methods that allow change may
+// over-tender the outstanding balance; the order is settled once the tenders cover net_total.
+func (s *OrderService) recordTender(ctx context.Context, qtx *orders_repo.Queries, order orders_repo.Order, payments []orders_repo.OrderPayment, method orders_repo.PaymentMethod, tendered int64, referenceNumber *string, version int32) error {
+	balance := order.NetTotal - sumTenderAmounts(payments)
+	if balance < 0 {
+		balance = 0
+	}
+
+	applied := tendered
+	if applied > balance {
+		if !method.AllowsChange {
+			return common.ErrPaymentExceedsBalance
+		}
+		applied = balance
+	}
+
+	if applied > 0 {
+		if err := s.withdrawPendingCharge(ctx, qtx, order, payments); err != nil {
+			return err
+		}
+	}
+
+	if applied > 0 && method.Kind == orders_repo.PaymentMethodKindLoyaltyPoints {
+		if err := s.payWithPoints(ctx, qtx, order, applied); err != nil {
+			return err
+		}
+	}
+
+	if applied > 0 {
+		actorID, userIdOk := ctx.Value(common.UserIDKey).(uuid.UUID)
+		payment, err := qtx.CreateOrderPayment(ctx, orders_repo.CreateOrderPaymentParams{
+			OrderID:         order.ID,
+			PaymentMethodID: method.ID,
+			Amount:          applied,
+			TenderedAmount:  tendered,
+			ChangeAmount:    tendered - applied,
+			ReferenceNumber: referenceNumber,
+			CreatedBy:       pgtype.UUID{Bytes: actorID, Valid: userIdOk},
+			OrderShiftID:    order.ShiftID,
+		})
+		if err != nil {
+			return err
+		}
+		payments = append(payments, payment)
+	}
+
+	if applied < balance {
+		if _, err := qtx.BumpOrderVersion(ctx, orders_repo.BumpOrderVersionParams{ID: order.ID, Version: version}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return common.ErrOrderConflict
+			}
+			return err
+		}
+		return nil
+	}
+
+	// The order-level payment fields summarise the ledger: the largest tender is the primary method
+	primaryMethodID := method.ID
+	var largest, totalTendered, totalChange int64
+	for _, p := range payments {
+		if p.Amount > largest {
+			largest = p.Amount
+			primaryMethodID = p.PaymentMethodID
+		}
+		totalTendered += p.TenderedAmount
+		totalChange += p.ChangeAmount
+	}
+
+	updated, err := qtx.UpdateOrderManualPayment(ctx, orders_repo.UpdateOrderManualPaymentParams{
+		ID:              order.ID,
+		PaymentMethodID: &primaryMethodID,
+		CashReceived:    &totalTendered,
+		ChangeDue:       &totalChange,
+		Version:         version,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return common.ErrOrderConflict
+		}
+		return err
+	}
+
+	if err := redeemPromotion(ctx, qtx, updated); err != nil {
+		return err
+	}
+	if err := s.earnPoints(ctx, qtx, updated); err != nil {
+		return err
+	}
+
+	// Settling an open order sends it to the kitchen
+	if updated.Status != order.Status {
+		return recordStatusChange(ctx, qtx, order.ID, &order.Status, updated.Status, nil)
+	}
+	return nil
+}
+
+// withdrawPendingCharge cancels a QRIS charge still open on the order once the bill is paid another way, so
+// that the customer cannot pay the amount it was issued for on top of it.
+func (s *OrderService) withdrawPendingCharge(ctx context.Context, qtx *orders_repo.Queries, order orders_repo.Order, payments []orders_repo.OrderPayment) error {
+	if order.PaymentGatewayReference == nil || *order.PaymentGatewayReference == "" {
+		return nil
+	}
+	for _, p := range payments {
+		if p.ReferenceNumber != nil && *p.ReferenceNumber == *order.PaymentGatewayReference {
+			return nil // the charge has already settled
+		}
+	}
+
+	s.log.Infof("Cancelling Midtrans transaction for order %s", order.ID)
+	if _, err := s.midtransService.CancelTransaction(order.ID.String()); err != nil {
+		s.log.Errorf("Failed to cancel Midtrans transaction for order %s: %v", order.ID, err)
+		return fmt.Errorf("failed to cancel payment gateway transaction: %w", err)
+	}
+	return qtx.ClearOrderPaymentGateway(ctx, order.ID)
+}
+
+// openUnpaidOrder locks an order whose lines and discounts can still change: open, without any tender taken
+// on it and without a pending QRIS charge. Once a tender is taken the bill is fixed, so that it cannot drop
+// below what was already paid.
+func (s *OrderService) openUnpaidOrder(ctx context.Context, qtx *orders_repo.Queries, orderID uuid.UUID) (orders_repo.Order, error) {
+	order, err := qtx.GetOrderForUpdate(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return order, common.ErrNotFound
+		}
+		return order, err
+	}
+	if order.Status != orders_repo.OrderStatusOpen || order.PaymentMethodID != nil {
+		return order, common.ErrOrderNotModifiable
+	}
+	payments, err := qtx.ListOrderPayments(ctx, orderID)
+	if err != nil {
+		return order, err
+	}
+	if len(payments) > 0 {
+		return order, common.ErrOrderNotModifiable
+	}
+	if err := checkNoPendingCharge(order); err != nil {
+		return order, err
+	}
+	return order, nil
+}
+
+// checkNoPendingCharge stops a bill from changing while a QRIS charge for it is open: the customer pays the
+// amount the charge was issued for, which would no longer match the bill.
+func checkNoPendingCharge(order orders_repo.Order) error {
+	if order.PaymentGatewayReference != nil && *order.PaymentGatewayReference != "" {
+		return fmt.Errorf("%w: a QRIS payment is pending, wait for it to settle or expire", common.ErrOrderNotModifiable)
+	}
+	return nil
+}
+
+func sumTenderAmounts(payments []orders_repo.OrderPayment) int64 {
+	var total int64
+	for _, p := range payments {
+		total += p.Amount
+	}
+	return total
+}
+
+func (s *OrderService) UpdateOrderItems(ctx context.Context, orderID uuid.UUID, req UpdateOrderItemsRequest) (*OrderDetailResponse, error) {
+	return s.updateOrderItems(ctx, orderID, &req.Version, func([]orders_repo.OrderItem) []UpdateOrderItemRequest {
+		return req.Items
+	})
+}
+
+// orderLinesFunc returns the full list of lines an order should have, given the lines it has now.
+type orderLinesFunc func(existing []orders_repo.OrderItem) []UpdateOrderItemRequest
+
+// updateOrderItems brings an open order to the lines returned by linesFor, under the order's lock. Lines
+// matched by (product, variant) change quantity, new ones are added and those left out are removed. The
+// version is checked when given.
+func (s *OrderService) updateOrderItems(ctx context.Context, orderID uuid.UUID, version *int32, linesFor orderLinesFunc) (*OrderDetailResponse, error) {
+	var finalOrder orders_repo.GetOrderWithDetailsRow
+	var stockAlerts []StockAlert
+	actorID, userIdOk := ctx.Value(common.UserIDKey).(uuid.UUID)
+
+	taxRules, err := s.settingsService.GetTaxSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load tax settings", "error", err)
+		return nil, err
+	}
+
+	operational, err := s.settingsService.GetOperationalSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load operational settings", "error", err)
+		return nil, err
+	}
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := orders_repo.New(tx)
+		qPrd := products_repo.New(tx)
+		qCost := costing_repo.New(tx)
+
+		order, err := s.openUnpaidOrder(ctx, qtx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if version != nil && order.Version != *version {
+			return common.ErrOrderConflict
+		}
+
+		existingItems, err := qtx.GetOrderItemsByOrderID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		items := linesFor(existingItems)
+
+		currentMap := make(map[lineKey]orders_repo.OrderItem)
+		productIDs := make([]uuid.UUID, 0, len(existingItems)+len(items))
+		for _, item := range existingItems {
+			currentMap[itemLineKey(item)] = item
+			productIDs = append(productIDs, item.ProductID)
+		}
+
+		for _, item := range items {
+			productIDs = append(productIDs, item.ProductID)
+		}
+
+		variants, err := loadVariantBook(ctx, qtx, productIDs)
+		if err != nil {
+			return err
+		}
+
+		// New lines pick their options against the modifier groups; every line is checked before anything is taken
+		var (
+			newProductIDs []uuid.UUID
+			violations    []ModifierViolation
+		)
+		for _, item := range items {
+			if _, exists := currentMap[requestLineKey(item.ProductID, item.VariantID)]; !exists {
+				newProductIDs = append(newProductIDs, item.ProductID)
+			}
+		}
+		rules, err := loadModifierRules(ctx, qtx, newProductIDs)
+		if err != nil {
+			return err
+		}
+		newLineOptions := make(map[int][]orders_repo.GetOrderableOptionsRow)
+		for i, item := range items {
+			if _, exists := currentMap[requestLineKey(item.ProductID, item.VariantID)]; exists {
+				continue
+			}
+			selected, lineViolations := rules.selectOptions(i, item.ProductID, item.Options)
+			newLineOptions[i] = selected
+			violations = append(violations, lineViolations...)
+		}
+		if len(violations) > 0 {
+			return &ModifierError{Violations: violations}
+		}
+
+		// Lines keep the options they were ordered with, so their recipes decide what goes back or is taken
+		itemOptions, optionIDs, err := orderItemOptions(ctx, qtx, orderID)
+		if err != nil {
+			return err
+		}
+		for _, selected := range newLineOptions {
+			optionIDs = append(optionIDs, selectedOptionIDs(selected)...)
+		}
+		book, err := loadRecipeBook(ctx, qtx, productIDs, optionIDs)
+		if err != nil {
+			return err
+		}
+		taken := make(ingredientNeeds)
+		returned := make(ingredientNeeds)
+
+		var lines []pricingLine
+
+		createdBy := pgtype.UUID{Bytes: actorID, Valid: userIdOk}
+		for i, reqItem := range items {
+			product, err := qtx.GetProductByID(ctx, reqItem.ProductID)
+			if err != nil {
+				return err
+			}
+
+			key := requestLineKey(reqItem.ProductID, reqItem.VariantID)
+			existingItem, exists := currentMap[key]
+			newOptions := newLineOptions[i]
+
+			// A line already on the order keeps its variant even if that variant has since been removed
+			variant, err := variants.resolve(reqItem.ProductID, reqItem.VariantID)
+			if err != nil && !(exists && errors.Is(err, common.ErrVariantNotFound)) {
+				return err
+			}
+
+			// A line already on the order keeps the unit price it was rung up at, its options included
+			price := existingItem.PriceAtSale
+			if !exists {
+				price = variantPrice(product.Price, variant)
+				for _, option := range newOptions {
+					price += option.AdditionalPrice
+				}
+			}
+
+			subtotal := price * int64(reqItem.Quantity)
+			lines = append(lines, pricingLine{ProductID: reqItem.ProductID, Subtotal: subtotal})
+
+			if exists && existingItem.VariantID.Valid {
+				qtyDiff := reqItem.Quantity - existingItem.Quantity
+				if qtyDiff > 0 {
+					book.add(taken, reqItem.ProductID, itemOptions[existingItem.ID], qtyDiff)
+					if variant == nil {
+						return common.ErrVariantNotFound
+					}
+					previous, err := variants.take(ctx, qtx, variant.ID, qtyDiff, orderID, createdBy, "Order Item Qty Increase")
+					if err != nil {
+						return err
+					}
+					stockAlerts = appendVariantStockAlert(stockAlerts, product.Name, previous, previous.Stock-qtyDiff)
+				} else if qtyDiff < 0 {
+					book.add(returned, reqItem.ProductID, itemOptions[existingItem.ID], -qtyDiff)
+					if err := returnVariantStock(ctx, qtx, existingItem, -qtyDiff, orders_repo.StockChangeTypeReturn, createdBy, "Order Item Qty Decrease"); err != nil {
+						return err
+					}
+				}
+
+				qtx.UpdateOrderItemQuantity(ctx, orders_repo.UpdateOrderItemQuantityParams{
+					ID:          existingItem.ID,
+					OrderID:     orderID,
+					Quantity:    reqItem.Quantity,
+					Subtotal:    subtotal,
+					NetSubtotal: subtotal,
+				})
+
+				delete(currentMap, key)
+			} else if exists {
 
 				qtyDiff := reqItem.Quantity - existingItem.Quantity
 
 				if qtyDiff > 0 {
+					book.add(taken, reqItem.ProductID, itemOptions[existingItem.ID], qtyDiff)
+				} else if qtyDiff < 0 {
+					book.add(returned, reqItem.ProductID, itemOptions[existingItem.ID], -qtyDiff)
+				}
+
+				madeToOrder := book.madeToOrder(reqItem.ProductID)
+				line := costedLine{ItemID: existingItem.ID, ProductID: reqItem.ProductID, Quantity: existingItem.Quantity, CostPrice: existingItem.CostPriceAtSale}
+				if qtyDiff > 0 && !madeToOrder {
 
 					if product.Stock < qtyDiff {
 						return fmt.Errorf("insufficient stock for update %s", product.Name)
 					}
 					qPrd.DecreaseProductStock(ctx, products_repo.DecreaseProductStockParams{ID: reqItem.ProductID, Quantity: qtyDiff})
+					stockAlerts = appendStockAlert(stockAlerts, reqItem.ProductID, product.Name, product.Stock, product.Stock-qtyDiff)
 
 					// Log Stock Decrease
 					qtx.CreateStockHistory(ctx, orders_repo.CreateStockHistoryParams{
@@ -542,7 +963,10 @@ func (s *OrderService) UpdateOrderItems(ctx context.Context, orderID uuid.UUID,
 						Note:          utils.StringPtr("Order Item Qty Increase"),
 						CreatedBy:     pgtype.UUID{Bytes: actorID, Valid: userIdOk},
 					})
-				} else if qtyDiff < 0 {
+					if err := drawLineCost(ctx, qtx, qCost, operational.CostingMethod, line, qtyDiff, product.CostPrice); err != nil {
+						return err
+					}
+				} else if qtyDiff < 0 && !madeToOrder {
 
 					restoreQty := -qtyDiff
 					qtx.AddProductStock(ctx, orders_repo.AddProductStockParams{ID: reqItem.ProductID, Stock: restoreQty})
@@ -558,102 +982,598 @@ func (s *OrderService) UpdateOrderItems(ctx context.Context, orderID uuid.UUID,
 						Note:          utils.StringPtr("Order Item Qty Decrease"),
 						CreatedBy:     pgtype.UUID{Bytes: actorID, Valid: userIdOk},
 					})
+					if err := returnLineCost(ctx, qtx, qCost, operational.CostingMethod, line, product.Stock, restoreQty); err != nil {
+						return err
+					}
 				}
 
-				qtx.UpdateOrderItemQuantity(ctx, orders_repo.UpdateOrderItemQuantityParams{
-					ID:          existingItem.ID,
-					OrderID:     orderID,
-					Quantity:    reqItem.Quantity,
-					Subtotal:    subtotal,
-					NetSubtotal: subtotal,
-				})
+				qtx.UpdateOrderItemQuantity(ctx, orders_repo.UpdateOrderItemQuantityParams{
+					ID:          existingItem.ID,
+					OrderID:     orderID,
+					Quantity:    reqItem.Quantity,
+					Subtotal:    subtotal,
+					NetSubtotal: subtotal,
+				})
+
+				delete(currentMap, key)
+
+			} else if variant != nil {
+				book.add(taken, reqItem.ProductID, selectedOptionIDs(newOptions), reqItem.Quantity)
+				newItem, err := qtx.CreateOrderItem(ctx, orders_repo.CreateOrderItemParams{
+					OrderID:         orderID,
+					ProductID:       reqItem.ProductID,
+					Quantity:        reqItem.Quantity,
+					PriceAtSale:     price,
+					Subtotal:        subtotal,
+					NetSubtotal:     subtotal,
+					CostPriceAtSale: variant.CostPrice,
+					VariantID:       pgtype.UUID{Bytes: variant.ID, Valid: true},
+				})
+				if err != nil {
+					return fmt.Errorf("failed to add item %s: %w", reqItem.ProductID, err)
+				}
+				if err := createItemOptions(ctx, qtx, newItem.ID, newOptions); err != nil {
+					return err
+				}
+				previous, err := variants.take(ctx, qtx, variant.ID, newItem.Quantity, orderID, createdBy, "Order Item Added")
+				if err != nil {
+					return err
+				}
+				stockAlerts = appendVariantStockAlert(stockAlerts, product.Name, previous, previous.Stock-newItem.Quantity)
+			} else {
+				book.add(taken, reqItem.ProductID, selectedOptionIDs(newOptions), reqItem.Quantity)
+
+				if !book.madeToOrder(reqItem.ProductID) {
+					if product.Stock < reqItem.Quantity {
+						return fmt.Errorf("insufficient stock for new item %s", product.Name)
+					}
+
+					qPrd.DecreaseProductStock(ctx, products_repo.DecreaseProductStockParams{ID: reqItem.ProductID, Quantity: reqItem.Quantity})
+					stockAlerts = appendStockAlert(stockAlerts, reqItem.ProductID, product.Name, product.Stock, product.Stock-reqItem.Quantity)
+
+					// Log Stock Decrease (New Item)
+					qtx.CreateStockHistory(ctx, orders_repo.CreateStockHistoryParams{
+						ProductID:     reqItem.ProductID,
+						ChangeAmount:  -reqItem.Quantity,
+						PreviousStock: product.Stock,
+						CurrentStock:  product.Stock - reqItem.Quantity,
+						ChangeType:    orders_repo.StockChangeTypeSale,
+						ReferenceID:   pgtype.UUID{Bytes: orderID, Valid: true},
+						Note:          utils.StringPtr("Order Item Added"),
+						CreatedBy:     pgtype.UUID{Bytes: actorID, Valid: userIdOk},
+					})
+				}
+
+				costPrice := 0.0
+				if product.CostPrice.Valid {
+					f, _ := product.CostPrice.Float64Value()
+					costPrice = f.Float64
+				}
+				numericCost := pgtype.Numeric{}
+				numericCost.Scan(fmt.Sprintf("%f", costPrice))
+
+				newItem, err := qtx.CreateOrderItem(ctx, orders_repo.CreateOrderItemParams{
+					OrderID:         orderID,
+					ProductID:       reqItem.ProductID,
+					Quantity:        reqItem.Quantity,
+					PriceAtSale:     price,
+					Subtotal:        subtotal,
+					NetSubtotal:     subtotal,
+					CostPriceAtSale: numericCost,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to add item %s: %w", reqItem.ProductID, err)
+				}
+				if err := createItemOptions(ctx, qtx, newItem.ID, newOptions); err != nil {
+					return err
+				}
+				if !book.madeToOrder(reqItem.ProductID) {
+					line := costedLine{ItemID: newItem.ID, ProductID: reqItem.ProductID}
+					if err := drawLineCost(ctx, qtx, qCost, operational.CostingMethod, line, reqItem.Quantity, numericCost); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		for key, item := range currentMap {
+			productID := key.ProductID
+			book.add(returned, productID, itemOptions[item.ID], item.Quantity)
+
+			if item.VariantID.Valid {
+				if err := returnVariantStock(ctx, qtx, item, item.Quantity, orders_repo.StockChangeTypeReturn, createdBy, "Order Item Removed"); err != nil {
+					return err
+				}
+				qtx.DeleteOrderItem(ctx, orders_repo.DeleteOrderItemParams{ID: item.ID, OrderID: orderID})
+				continue
+			}
+
+			if !book.madeToOrder(productID) {
+				params := orders_repo.AddProductStockParams{ID: productID, Stock: item.Quantity}
+				qtx.AddProductStock(ctx, params)
+
+				prod, err := qtx.GetProductByID(ctx, productID)
+				if err == nil {
+					qtx.CreateStockHistory(ctx, orders_repo.CreateStockHistoryParams{
+						ProductID:     productID,
+						ChangeAmount:  item.Quantity,
+						PreviousStock: prod.Stock,
+						CurrentStock:  prod.Stock + item.Quantity,
+						ChangeType:    orders_repo.StockChangeTypeReturn,
+						ReferenceID:   pgtype.UUID{Bytes: orderID, Valid: true},
+						Note:          utils.StringPtr("Order Item Removed"),
+						CreatedBy:     pgtype.UUID{Bytes: actorID, Valid: userIdOk},
+					})
+
+					// The line is deleted below, so its layer draws are given back first
+					line := costedLine{ItemID: item.ID, ProductID: productID, Quantity: item.Quantity, CostPrice: item.CostPriceAtSale}
+					if err := returnLineCost(ctx, qtx, qCost, operational.CostingMethod, line, prod.Stock-item.Quantity, item.Quantity); err != nil {
+						return err
+					}
+				} else {
+					s.log.Warn("Failed to fetch product for stock history logging on item delete", "productID", productID)
+				}
+			}
+
+			qtx.DeleteOrderItem(ctx, orders_repo.DeleteOrderItemParams{ID: item.ID, OrderID: orderID})
+		}
+
+		// Give back first so a swap within the same update can reuse what was freed
+		if err := moveIngredients(ctx, qtx, returned, orders_repo.StockChangeTypeReturn, orderID, createdBy, "Order Items Updated"); err != nil {
+			return err
+		}
+		if err := moveIngredients(ctx, qtx, taken, orders_repo.StockChangeTypeSale, orderID, createdBy, "Order Items Updated"); err != nil {
+			return err
+		}
+
+		updated, err := s.recalculateOrderTotals(ctx, qtx, orderID, order.Type, lines, order.DiscountAmount, order.Version, taxRules)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return common.ErrOrderConflict
+			}
+			return err
+		}
+		// The lines changed, so the promotions are re-evaluated against them
+		if _, _, err := s.repricePromotions(ctx, tx, qtx, updated, taxRules); err != nil {
+			return err
+		}
+
+		finalOrder, err = qtx.GetOrderWithDetails(ctx, orderID)
+		return err
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	logDetails := map[string]interface{}{
+		"updated_order_id":     orderID,
+		"updated_order_status": finalOrder.Status,
+	}
+
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activity_repo.LogActionTypeAPPLYPROMOTION,
+		activity_repo.LogEntityTypeORDER,
+		orderID.String(),
+		logDetails,
+	)
+
+	if s.wsHub != nil {
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
+		s.PublishCallingBoard(ctx)
+		s.publishStockAlerts(stockAlerts)
+	}
+
+	return s.buildOrderDetailResponseFromQueryResult(ctx, finalOrder)
+}
+
+// appendStockAlert records a product whose stock a sale has just taken down to the low stock threshold.
+func appendStockAlert(alerts []StockAlert, productID uuid.UUID, name string, previousStock, currentStock int32) []StockAlert {
+	if previousStock > LowStockThreshold && currentStock <= LowStockThreshold {
+		alerts = append(alerts, StockAlert{ProductID: productID, ProductName: name, Stock: currentStock})
+	}
+	return alerts
+}
+
+// publishStockAlerts sends low stock alerts to managers listening on the stock topic.
+func (s *OrderService) publishStockAlerts(alerts []StockAlert) {
+	for _, alert := range alerts {
+		s.wsHub.Publish(ws.Message{
+			Type:    ws.EventStockLow,
+			Payload: alert,
+			Topics:  []string{ws.TopicStock},
+			MinRole: middleware.UserRoleManager,
+		})
+	}
+}
+
+// SplitOrder moves lines (or part of their quantity) from an open, unpaid order into new child orders.
+// Stock was already taken when the items were ordered, so moving units between orders leaves stock untouched.
+func (s *OrderService) SplitOrder(ctx context.Context, orderID uuid.UUID, req SplitOrderRequest) (*SplitOrderResponse, error) {
+	if (len(req.Parts) > 0) == (req.Ways > 0) {
+		return nil, common.ErrOrderSplitInvalid
+	}
+
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+
+	taxRules, err := s.settingsService.GetTaxSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load tax settings", "error", err)
+		return nil, err
+	}
+
+	var finalParent orders_repo.GetOrderWithDetailsRow
+	var finalChildren []orders_repo.GetOrderWithDetailsRow
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := orders_repo.New(tx)
+
+		// A bill that has started collecting tenders can no longer be re-shaped
+		order, err := s.openUnpaidOrder(ctx, qtx, orderID)
+		if err != nil {
+			return err
+		}
+		if order.Version != req.Version {
+			return common.ErrOrderConflict
+		}
+
+		// Points spent on the bill belong to its customer and cannot be divided
+		pointsDiscount, err := orderPointsDiscount(ctx, qtx, order)
+		if err != nil {
+			return err
+		}
+		if pointsDiscount > 0 {
+			return fmt.Errorf("%w: cancel the points redemption before splitting", common.ErrOrderNotModifiable)
+		}
+
+		items, err := qtx.GetOrderItemsByOrderID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		var moves []splitMove
+		childCount := len(req.Parts)
+		if childCount > 0 {
+			moves, err = planItemSplit(items, req.Parts)
+		} else {
+			childCount = int(req.Ways) - 1
+			moves, err = planEvenSplit(items, int(req.Ways))
+		}
+		if err != nil {
+			return err
+		}
+
+		splitNote := fmt.Sprintf("Split from order %s", orderID)
+		children := make([]orders_repo.Order, childCount)
+		for i := range children {
+			children[i], err = qtx.CreateSplitOrder(ctx, orderID)
+			if err != nil {
+				return err
+			}
+			if err := recordStatusChange(ctx, qtx, children[i].ID, nil, children[i].Status, &splitNote); err != nil {
+				return err
+			}
+		}
+
+		itemByID := make(map[uuid.UUID]orders_repo.OrderItem, len(items))
+		remaining := make(map[uuid.UUID]int32, len(items))
+		var originalGross int64
+		for _, item := range items {
+			itemByID[item.ID] = item
+			remaining[item.ID] = item.Quantity
+			originalGross += item.Subtotal
+		}
+
+		childLines := make([][]pricingLine, childCount)
+		childGross := make([]int64, childCount)
+		movedWhole := make(map[uuid.UUID]bool)
+		for _, move := range moves {
+			item := itemByID[move.ItemID]
+			child := children[move.Part]
+			subtotal := item.PriceAtSale * int64(move.Quantity)
+			remaining[item.ID] -= move.Quantity
+
+			if remaining[item.ID] == 0 && move.Quantity == item.Quantity {
+				// The whole line goes over, options included
+				if err := qtx.MoveOrderItem(ctx, orders_repo.MoveOrderItemParams{TargetOrderID: child.ID, ID: item.ID, OrderID: orderID}); err != nil {
+					return err
+				}
+				movedWhole[item.ID] = true
+			} else {
+				newItem, err := qtx.CreateOrderItem(ctx, orders_repo.CreateOrderItemParams{
+					OrderID:         child.ID,
+					ProductID:       item.ProductID,
+					Quantity:        move.Quantity,
+					PriceAtSale:     item.PriceAtSale,
+					Subtotal:        subtotal,
+					NetSubtotal:     subtotal,
+					CostPriceAtSale: item.CostPriceAtSale,
+					VariantID:       item.VariantID,
+				})
+				if err != nil {
+					return err
+				}
+				if err := qtx.CopyOrderItemOptions(ctx, orders_repo.CopyOrderItemOptionsParams{TargetOrderItemID: newItem.ID, SourceOrderItemID: item.ID}); err != nil {
+					return err
+				}
+			}
+
+			childLines[move.Part] = append(childLines[move.Part], pricingLine{ProductID: item.ProductID, Subtotal: subtotal})
+			childGross[move.Part] += subtotal
+		}
+
+		var parentLines []pricingLine
+		for _, item := range items {
+			left := remaining[item.ID]
+			switch {
+			case left == item.Quantity:
+				parentLines = append(parentLines, pricingLine{ProductID: item.ProductID, Subtotal: item.Subtotal})
+			case movedWhole[item.ID]:
+				// Already re-parented to a child order
+			case left == 0:
+				if err := qtx.DeleteOrderItem(ctx, orders_repo.DeleteOrderItemParams{ID: item.ID, OrderID: orderID}); err != nil {
+					return err
+				}
+			default:
+				subtotal := item.PriceAtSale * int64(left)
+				if _, err := qtx.UpdateOrderItemQuantity(ctx, orders_repo.UpdateOrderItemQuantityParams{
+					ID:          item.ID,
+					OrderID:     orderID,
+					Quantity:    left,
+					Subtotal:    subtotal,
+					NetSubtotal: subtotal,
+				}); err != nil {
+					return err
+				}
+				parentLines = append(parentLines, pricingLine{ProductID: item.ProductID, Subtotal: subtotal})
+			}
+		}
+
+		parentDiscount, childDiscounts := allocateSplitDiscount(order.DiscountAmount, originalGross, childGross)
+		promotions, err := orderPromotions(ctx, qtx, orderID, order.AppliedPromotionID)
+		if err != nil {
+			return err
+		}
+
+		for i, child := range children {
+			if _, err := s.recalculateOrderTotals(ctx, qtx, child.ID, child.Type, childLines[i], childDiscounts[i], child.Version, taxRules); err != nil {
+				return err
+			}
+			if order.DiscountAmount > 0 {
+				if err := spreadOrderDiscount(ctx, qtx, child.ID, childDiscounts[i]); err != nil {
+					return err
+				}
+			}
+			// Each bill takes the promotions along with its share of the discount
+			if err := carryOrderPromotions(ctx, qtx, child.ID, promotions, childDiscounts[i]); err != nil {
+				return err
+			}
+		}
+
+		if _, err := s.recalculateOrderTotals(ctx, qtx, orderID, order.Type, parentLines, parentDiscount, req.Version, taxRules); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return common.ErrOrderConflict
+			}
+			return err
+		}
+		if order.DiscountAmount > 0 {
+			if err := spreadOrderDiscount(ctx, qtx, orderID, parentDiscount); err != nil {
+				return err
+			}
+		}
+		// The parent keeps its promotions, each scaled down to what is left of the discount
+		if err := scaleOrderPromotions(ctx, qtx, order, parentDiscount); err != nil {
+			return err
+		}
+
+		finalParent, err = qtx.GetOrderWithDetails(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			detail, err := qtx.GetOrderWithDetails(ctx, child.ID)
+			if err != nil {
+				return err
+			}
+			finalChildren = append(finalChildren, detail)
+		}
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	parentResponse, err := s.buildOrderDetailResponseFromQueryResult(ctx, finalParent)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SplitOrderResponse{Parent: *parentResponse}
+	childIDs := make([]string, 0, len(finalChildren))
+	for _, child := range finalChildren {
+		childResponse, err := s.buildOrderDetailResponseFromQueryResult(ctx, child)
+		if err != nil {
+			return nil, err
+		}
+		response.Children = append(response.Children, *childResponse)
+		childIDs = append(childIDs, child.ID.String())
+	}
+
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activity_repo.LogActionTypeUPDATE,
+		activity_repo.LogEntityTypeORDER,
+		orderID.String(),
+		map[string]interface{}{
+			"action":          "split",
+			"child_order_ids": childIDs,
+			"ways":            req.Ways,
+		},
+	)
+
+	if s.wsHub != nil {
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
+		for _, child := range finalChildren {
+			s.wsHub.Publish(ws.OrderEvent(ws.EventOrderCreated, child.ID, map[string]interface{}{"order_id": child.ID, "parent_order_id": orderID}))
+		}
+		s.PublishCallingBoard(ctx)
+	}
+
+	return response, nil
+}
+
+// mergedCancellationReason is the system cancellation reason recorded on orders merged into another.
+const mergedCancellationReason = "Order Merged"
+
+// MergeOrders moves every line of the source orders into the target order and cancels the sources.
+// Items keep their options and stock is untouched because the units simply change orders.
+func (s *OrderService) MergeOrders(ctx context.Context, targetOrderID uuid.UUID, req MergeOrdersRequest) (*OrderDetailResponse, error) {
+	seen := map[uuid.UUID]bool{targetOrderID: true}
+	for _, id := range req.SourceOrderIDs {
+		if seen[id] {
+			return nil, common.ErrOrderMergeInvalid
+		}
+		seen[id] = true
+	}
+
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+
+	taxRules, err := s.settingsService.GetTaxSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load tax settings", "error", err)
+		return nil, err
+	}
+
+	var finalOrder orders_repo.GetOrderWithDetailsRow
+	var droppedPromotionIDs []string
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := orders_repo.New(tx)
 
-				delete(currentMap, reqItem.ProductID)
+		// Lock in a stable order so concurrent merges of overlapping orders cannot deadlock
+		lockIDs := append([]uuid.UUID{targetOrderID}, req.SourceOrderIDs...)
+		sort.Slice(lockIDs, func(i, j int) bool { return lockIDs[i].String() < lockIDs[j].String() })
 
-			} else {
-				if product.Stock < reqItem.Quantity {
-					return fmt.Errorf("insufficient stock for new item %s", product.Name)
-				}
+		// Tenders or a pending gateway charge belong to their own bill, so neither the target nor a source may carry one
+		locked := make(map[uuid.UUID]orders_repo.Order, len(lockIDs))
+		for _, id := range lockIDs {
+			order, err := s.openUnpaidOrder(ctx, qtx, id)
+			if err != nil {
+				return err
+			}
+			locked[id] = order
+		}
 
-				qPrd.DecreaseProductStock(ctx, products_repo.DecreaseProductStockParams{ID: reqItem.ProductID, Quantity: reqItem.Quantity})
-
-				// Log Stock Decrease (New Item)
-				qtx.CreateStockHistory(ctx, orders_repo.CreateStockHistoryParams{
-					ProductID:     reqItem.ProductID,
-					ChangeAmount:  -reqItem.Quantity,
-					PreviousStock: product.Stock,
-					CurrentStock:  product.Stock - reqItem.Quantity,
-					ChangeType:    orders_repo.StockChangeTypeSale,
-					ReferenceID:   pgtype.UUID{Bytes: orderID, Valid: true},
-					Note:          utils.StringPtr("Order Item Added"),
-					CreatedBy:     pgtype.UUID{Bytes: actorID, Valid: userIdOk},
-				})
+		target := locked[targetOrderID]
+		if target.Version != req.Version {
+			return common.ErrOrderConflict
+		}
 
-				costPrice := 0.0
-				if product.CostPrice.Valid {
-					f, _ := product.CostPrice.Float64Value()
-					costPrice = f.Float64
-				}
-				numericCost := pgtype.Numeric{}
-				numericCost.Scan(fmt.Sprintf("%f", costPrice))
+		reason, err := qtx.GetCancellationReasonByReason(ctx, mergedCancellationReason)
+		if err != nil {
+			return fmt.Errorf("failed to load merge cancellation reason: %w", err)
+		}
+		notes := fmt.Sprintf("Merged into order %s", targetOrderID)
 
-				qtx.CreateOrderItem(ctx, orders_repo.CreateOrderItemParams{
-					OrderID:         orderID,
-					ProductID:       reqItem.ProductID,
-					Quantity:        reqItem.Quantity,
-					PriceAtSale:     price,
-					Subtotal:        subtotal,
-					NetSubtotal:     subtotal,
-					CostPriceAtSale: numericCost,
-				})
+		// The target's own promotions take precedence over any the sources carried; a promotion keeps the code it was applied with
+		targetPromotions, err := orderPromotions(ctx, qtx, targetOrderID, target.AppliedPromotionID)
+		if err != nil {
+			return err
+		}
+		var pinned []pinnedPromotion
+		for _, p := range targetPromotions {
+			if !p.AutoApplied {
+				pinned = append(pinned, pinnedPromotion{PromotionID: p.PromotionID, CodeID: p.PromotionCodeID})
 			}
 		}
+		hadPromotions := target.AppliedPromotionID.Valid
 
-		for productID, item := range currentMap {
+		for _, sourceID := range req.SourceOrderIDs {
+			source := locked[sourceID]
 
-			params := orders_repo.AddProductStockParams{ID: productID, Stock: item.Quantity}
-			qtx.AddProductStock(ctx, params)
+			pointsDiscount, err := orderPointsDiscount(ctx, qtx, source)
+			if err != nil {
+				return err
+			}
+			if pointsDiscount > 0 {
+				return fmt.Errorf("%w: cancel the points redemption on order %s before merging", common.ErrOrderNotModifiable, sourceID)
+			}
 
-			prod, err := qtx.GetProductByID(ctx, productID)
-			if err == nil {
-				qtx.CreateStockHistory(ctx, orders_repo.CreateStockHistoryParams{
-					ProductID:     productID,
-					ChangeAmount:  item.Quantity,
-					PreviousStock: prod.Stock,
-					CurrentStock:  prod.Stock + item.Quantity,
-					ChangeType:    orders_repo.StockChangeTypeReturn,
-					ReferenceID:   pgtype.UUID{Bytes: orderID, Valid: true},
-					Note:          utils.StringPtr("Order Item Removed"),
-					CreatedBy:     pgtype.UUID{Bytes: actorID, Valid: userIdOk},
-				})
-			} else {
-				s.log.Warn("Failed to fetch product for stock history logging on item delete", "productID", productID)
+			items, err := qtx.GetOrderItemsByOrderID(ctx, sourceID)
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				if err := qtx.MoveOrderItem(ctx, orders_repo.MoveOrderItemParams{TargetOrderID: targetOrderID, ID: item.ID, OrderID: sourceID}); err != nil {
+					return err
+				}
 			}
 
-			qtx.DeleteOrderItem(ctx, orders_repo.DeleteOrderItemParams{ID: item.ID, OrderID: orderID})
+			if _, err := qtx.CancelOrder(ctx, orders_repo.CancelOrderParams{
+				ID:                   sourceID,
+				CancellationReasonID: &reason.ID,
+				CancellationNotes:    &notes,
+			}); err != nil {
+				return err
+			}
+			if err := recordStatusChange(ctx, qtx, sourceID, &source.Status, orders_repo.OrderStatusCancelled, &notes); err != nil {
+				return err
+			}
+
+			sourcePromotions, err := orderPromotions(ctx, qtx, sourceID, source.AppliedPromotionID)
+			if err != nil {
+				return err
+			}
+			for _, p := range sourcePromotions {
+				if !p.AutoApplied {
+					pinned = append(pinned, pinnedPromotion{PromotionID: p.PromotionID, CodeID: p.PromotionCodeID})
+				}
+			}
+			hadPromotions = hadPromotions || source.AppliedPromotionID.Valid || source.DiscountAmount > 0
+		}
+
+		mergedItems, err := qtx.GetOrderItemsByOrderID(ctx, targetOrderID)
+		if err != nil {
+			return err
 		}
 
-		taxAmount := order.TaxAmount
-		serviceChargeAmount := order.ServiceChargeAmount
-		netTotal := grossTotal - order.DiscountAmount + taxAmount + serviceChargeAmount
+		var grossTotal int64
+		for _, item := range mergedItems {
+			grossTotal += item.Subtotal
+		}
+		merged := target
+		merged.GrossTotal = grossTotal
 
-		_, err = qtx.UpdateOrderTotals(ctx, orders_repo.UpdateOrderTotalsParams{
-			ID:                  orderID,
-			GrossTotal:          grossTotal,
-			NetTotal:            netTotal,
-			DiscountAmount:      order.DiscountAmount,
-			TaxAmount:           taxAmount,
-			ServiceChargeAmount: serviceChargeAmount,
-			Version:             req.Version,
-		})
+		// Re-run the promotion rule checks against the merged bill and pick auto-apply promotions for it.
+		// Moved lines may still carry a share of the discount of the bill they came from, which is reset.
+		active, err := qtx.ListActivePromotions(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list active promotions: %w", err)
+		}
+		manual, auto, err := s.promotionCandidates(ctx, qtx, merged, mergedItems, pinned, active)
 		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return common.ErrOrderConflict
-			}
+			return err
+		}
+		sel := combinePromotions(selectPromotions(manual, auto), mergedItems)
+		if _, err := s.savePromotions(ctx, qtx, merged, mergedItems, sel, hadPromotions, req.Version, taxRules); err != nil {
 			return err
 		}
 
-		finalOrder, err = qtx.GetOrderWithDetails(ctx, orderID)
+		kept := make(map[uuid.UUID]bool, len(sel.Applied))
+		for _, a := range sel.Applied {
+			kept[a.Promotion.ID] = true
+		}
+		for _, p := range targetPromotions {
+			if !kept[p.PromotionID] {
+				droppedPromotionIDs = append(droppedPromotionIDs, p.PromotionID.String())
+			}
+		}
+
+		finalOrder, err = qtx.GetOrderWithDetails(ctx, targetOrderID)
 		return err
 	})
 
@@ -661,27 +1581,108 @@ func (s *OrderService) UpdateOrderItems(ctx context.Context, orderID uuid.UUID,
 		return nil, txErr
 	}
 
+	sourceIDs := make([]string, len(req.SourceOrderIDs))
+	for i, id := range req.SourceOrderIDs {
+		sourceIDs[i] = id.String()
+	}
 	logDetails := map[string]interface{}{
-		"updated_order_id":     orderID,
-		"updated_order_status": finalOrder.Status,
+		"action":           "merge",
+		"source_order_ids": sourceIDs,
+	}
+	if len(droppedPromotionIDs) > 0 {
+		logDetails["dropped_promotion_ids"] = droppedPromotionIDs
 	}
-
 	s.activityService.Log(
 		ctx,
 		actorID,
-		activity_repo.LogActionTypeAPPLYPROMOTION,
+		activity_repo.LogActionTypeUPDATE,
 		activity_repo.LogEntityTypeORDER,
-		orderID.String(),
+		targetOrderID.String(),
 		logDetails,
 	)
 
 	if s.wsHub != nil {
-		s.wsHub.BroadcastEvent(ws.EventOrderUpdated, map[string]interface{}{"order_id": orderID})
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, targetOrderID, map[string]interface{}{"order_id": targetOrderID}))
+		for _, sourceID := range req.SourceOrderIDs {
+			s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, sourceID, map[string]interface{}{"order_id": sourceID, "merged_into": targetOrderID}))
+		}
+		s.PublishCallingBoard(ctx)
 	}
 
 	return s.buildOrderDetailResponseFromQueryResult(ctx, finalOrder)
 }
 
+// recalculateOrderTotals applies the configured tax and service charge rules to the given lines
+// and persists the result. Every path that changes order totals must go through here.
+func (s *OrderService) recalculateOrderTotals(ctx context.Context, qtx *orders_repo.Queries, orderID uuid.UUID, orderType orders_repo.OrderType, lines []pricingLine, discountAmount int64, version int32, taxRules *settings.TaxSettingsResponse) (orders_repo.Order, error) {
+	productIDs := make([]uuid.UUID, len(lines))
+	for i, line := range lines {
+		productIDs[i] = line.ProductID
+	}
+	exemptProducts, err := taxExemptProducts(ctx, qtx, productIDs, taxRules)
+	if err != nil {
+		return orders_repo.Order{}, err
+	}
+
+	totals := calculateOrderTotals(orderType, lines, exemptProducts, discountAmount, taxRules)
+
+	taxRate, _ := utils.Float64ToNumeric(totals.TaxRate)
+	serviceChargeRate, _ := utils.Float64ToNumeric(totals.ServiceChargeRate)
+
+	return qtx.UpdateOrderTotals(ctx, orders_repo.UpdateOrderTotalsParams{
+		ID:                  orderID,
+		GrossTotal:          totals.GrossTotal,
+		DiscountAmount:      totals.DiscountAmount,
+		NetTotal:            totals.NetTotal,
+		TaxAmount:           totals.TaxAmount,
+		ServiceChargeAmount: totals.ServiceChargeAmount,
+		Version:             version,
+		TaxRate:             taxRate,
+		ServiceChargeRate:   serviceChargeRate,
+		TaxInclusive:        totals.TaxInclusive,
+	})
+}
+
+// taxExemptProducts returns which of the products fall in one of the rules' tax-exempt categories.
+func taxExemptProducts(ctx context.Context, qtx *orders_repo.Queries, productIDs []uuid.UUID, taxRules *settings.TaxSettingsResponse) (map[uuid.UUID]bool, error) {
+	exemptProducts := make(map[uuid.UUID]bool)
+	if taxRules == nil || len(taxRules.TaxExemptCategoryIDs) == 0 || len(productIDs) == 0 {
+		return exemptProducts, nil
+	}
+	exemptCategories := make(map[int32]bool, len(taxRules.TaxExemptCategoryIDs))
+	for _, id := range taxRules.TaxExemptCategoryIDs {
+		exemptCategories[id] = true
+	}
+
+	productCategories, err := qtx.GetProductCategoryIDs(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product categories: %w", err)
+	}
+	for _, pc := range productCategories {
+		if exemptCategories[pc.CategoryID] {
+			exemptProducts[pc.ProductID] = true
+		}
+	}
+	return exemptProducts, nil
+}
+
+// variantNames looks up the names of the variants sold on an order, including ones removed since.
+func (s *OrderService) variantNames(ctx context.Context, variantIDs []uuid.UUID) map[uuid.UUID]string {
+	names := make(map[uuid.UUID]string, len(variantIDs))
+	if len(variantIDs) == 0 {
+		return names
+	}
+	variants, err := s.productsRepo.GetVariantsByIDs(ctx, variantIDs)
+	if err != nil {
+		s.log.Warn("Failed to fetch variant names for order items", "error", err)
+		return names
+	}
+	for _, v := range variants {
+		names[v.ID] = v.Name
+	}
+	return names
+}
+
 func (s *OrderService) buildOrderDetailResponseFromQueryResult(ctx context.Context, orderWithDetails orders_repo.GetOrderWithDetailsRow) (*OrderDetailResponse, error) {
 	var itemResponses []OrderItemResponse
 
@@ -706,8 +1707,12 @@ func (s *OrderService) buildOrderDetailResponseFromQueryResult(ctx context.Conte
 		// Collect IDs
 		var productIDs []uuid.UUID
 		var optionIDs []uuid.UUID
+		var variantIDs []uuid.UUID
 		for _, tempItem := range tempItems {
 			productIDs = append(productIDs, tempItem.ProductID)
+			if tempItem.VariantID.Valid {
+				variantIDs = append(variantIDs, tempItem.VariantID.Bytes)
+			}
 			for _, opt := range tempItem.Options {
 				optionIDs = append(optionIDs, opt.ProductOptionID)
 			}
@@ -738,6 +1743,8 @@ func (s *OrderService) buildOrderDetailResponseFromQueryResult(ctx context.Conte
 			}
 		}
 
+		variantNameMap := s.variantNames(ctx, variantIDs)
+
 		for _, tempItem := range tempItems {
 			var optionResponses []OrderItemOptionResponse
 			for _, opt := range tempItem.Options {
@@ -749,16 +1756,122 @@ func (s *OrderService) buildOrderDetailResponseFromQueryResult(ctx context.Conte
 				})
 			}
 			pName := productNameMap[tempItem.ProductID]
+			variantID := utils.NullableUUIDToPointer(tempItem.VariantID)
+			var variantName string
+			if variantID != nil {
+				variantName = variantNameMap[*variantID]
+			}
 			itemResponses = append(itemResponses, OrderItemResponse{
 				ID:          tempItem.ID,
 				ProductID:   tempItem.ProductID,
 				ProductName: pName,
+				VariantID:   variantID,
+				VariantName: variantName,
 				Quantity:    tempItem.Quantity,
 				PriceAtSale: tempItem.PriceAtSale,
 				Subtotal:    tempItem.Subtotal,
 				Options:     optionResponses,
+				StationID:   tempItem.StationID,
+				PrepStatus:  tempItem.PrepStatus,
+				IsPriority:  tempItem.IsPriority,
+			})
+		}
+	}
+
+	payments, err := decodeOrderPayments(orderWithDetails.Payments)
+	if err != nil {
+		s.log.Error("Failed to unmarshal order payments JSON", "error", err)
+		return nil, fmt.Errorf("could not parse order payments")
+	}
+
+	var amountPaid int64
+	paymentResponses := make([]OrderPaymentResponse, 0, len(payments))
+	for _, p := range payments {
+		amountPaid += p.Amount
+		paymentResponses = append(paymentResponses, OrderPaymentResponse{
+			ID:                p.ID,
+			PaymentMethodID:   p.PaymentMethodID,
+			PaymentMethodName: p.PaymentMethodName,
+			Amount:            p.Amount,
+			TenderedAmount:    p.TenderedAmount,
+			ChangeAmount:      p.ChangeAmount,
+			ReferenceNumber:   p.ReferenceNumber,
+			CreatedAt:         p.CreatedAt.Time,
+		})
+	}
+
+	balanceDue := orderWithDetails.NetTotal - amountPaid
+	if balanceDue < 0 || orderWithDetails.PaymentMethodID != nil {
+		balanceDue = 0
+	}
+
+	refunds, err := decodeOrderRefunds(orderWithDetails.Refunds)
+	if err != nil {
+		s.log.Error("Failed to unmarshal order refunds JSON", "error", err)
+		return nil, fmt.Errorf("could not parse order refunds")
+	}
+
+	var amountRefunded int64
+	refundResponses := make([]OrderRefundResponse, 0, len(refunds))
+	for _, r := range refunds {
+		amountRefunded += r.Amount
+		refundItems := make([]OrderRefundItemResponse, 0, len(r.Items))
+		for _, ri := range r.Items {
+			refundItems = append(refundItems, OrderRefundItemResponse{
+				OrderItemID: ri.OrderItemID,
+				Quantity:    ri.Quantity,
+				Amount:      ri.Amount,
+				Restocked:   ri.Restocked,
 			})
 		}
+		refundResponses = append(refundResponses, OrderRefundResponse{
+			ID:              r.ID,
+			OrderPaymentID:  utils.NullableUUIDToPointer(r.OrderPaymentID),
+			PaymentMethodID: r.PaymentMethodID,
+			Amount:          r.Amount,
+			Reason:          r.Reason,
+			Items:           refundItems,
+			CreatedAt:       r.CreatedAt.Time,
+		})
+	}
+
+	history, err := decodeOrderStatusHistory(orderWithDetails.StatusHistory)
+	if err != nil {
+		s.log.Error("Failed to unmarshal order status history JSON", "error", err)
+		return nil, fmt.Errorf("could not parse order status history")
+	}
+
+	historyResponses := make([]OrderStatusHistoryResponse, 0, len(history))
+	for _, h := range history {
+		historyResponses = append(historyResponses, OrderStatusHistoryResponse{
+			FromStatus: h.FromStatus,
+			ToStatus:   h.ToStatus,
+			ChangedBy:  utils.NullableUUIDToPointer(h.ChangedBy),
+			Note:       h.Note,
+			CreatedAt:  h.CreatedAt.Time,
+		})
+	}
+
+	var promotionResponses []AppliedPromotionResponse
+	applied, err := orderPromotions(ctx, s.ordersRepo, orderWithDetails.ID, orderWithDetails.AppliedPromotionID)
+	if err != nil {
+		s.log.Warn("Failed to fetch promotions for order detail", "error", err)
+	}
+	for _, p := range applied {
+		promotionResponses = append(promotionResponses, appliedPromotionResponse(p))
+	}
+
+	loyalty, err := s.orderLoyaltyResponse(ctx, orderWithDetails.ID, orderWithDetails.CustomerID)
+	if err != nil {
+		s.log.Warn("Failed to fetch loyalty points for order detail", "error", err)
+	}
+
+	var queueNumber, day string
+	if orderWithDetails.QueueNumber != nil {
+		queueNumber = *orderWithDetails.QueueNumber
+	}
+	if orderWithDetails.BusinessDate.Valid {
+		day = orderWithDetails.BusinessDate.Time.Format(businessDateLayout)
 	}
 
 	return &OrderDetailResponse{
@@ -772,24 +1885,128 @@ func (s *OrderService) buildOrderDetailResponseFromQueryResult(ctx context.Conte
 		NetTotal:                orderWithDetails.NetTotal,
 		TaxAmount:               orderWithDetails.TaxAmount,
 		ServiceChargeAmount:     orderWithDetails.ServiceChargeAmount,
+		TaxRate:                 utils.NumericToFloat64(orderWithDetails.TaxRate),
+		ServiceChargeRate:       utils.NumericToFloat64(orderWithDetails.ServiceChargeRate),
+		TaxInclusive:            orderWithDetails.TaxInclusive,
 		PaymentMethodID:         orderWithDetails.PaymentMethodID,
 		PaymentGatewayReference: orderWithDetails.PaymentGatewayReference,
 		CashReceived:            orderWithDetails.CashReceived,
 		ChangeDue:               orderWithDetails.ChangeDue,
 		AppliedPromotionID:      utils.NullableUUIDToPointer(orderWithDetails.AppliedPromotionID),
+		AppliedPromotionCodeID:  utils.NullableUUIDToPointer(orderWithDetails.AppliedPromotionCodeID),
+		ParentOrderID:           utils.NullableUUIDToPointer(orderWithDetails.ParentOrderID),
+		ChildOrderIDs:           orderWithDetails.ChildOrderIds,
 		CreatedAt:               orderWithDetails.CreatedAt.Time,
 		UpdatedAt:               orderWithDetails.UpdatedAt.Time,
 		Version:                 orderWithDetails.Version,
+		QueueNumber:             queueNumber,
+		BusinessDate:            day,
 		Items:                   itemResponses,
+		Payments:                paymentResponses,
+		AmountPaid:              amountPaid,
+		BalanceDue:              balanceDue,
+		Refunds:                 refundResponses,
+		AmountRefunded:          amountRefunded,
+		StatusHistory:           historyResponses,
+		Promotions:              promotionResponses,
+		Loyalty:                 loyalty,
 	}, nil
 }
 
+type orderPaymentLine struct {
+	orders_repo.OrderPayment
+	PaymentMethodName string `json:"payment_method_name"`
+}
+
+// decodeOrderPayments converts the aggregated payments JSON column into typed tender lines.
+func decodeOrderPayments(raw interface{}) ([]orderPaymentLine, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	paymentsJSON, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var payments []orderPaymentLine
+	if err := json.Unmarshal(paymentsJSON, &payments); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+type orderRefundLine struct {
+	orders_repo.OrderRefund
+	Items []orders_repo.OrderRefundItem `json:"items"`
+}
+
+// decodeOrderRefunds converts the aggregated refunds JSON column into typed refund records.
+func decodeOrderRefunds(raw interface{}) ([]orderRefundLine, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	refundsJSON, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var refunds []orderRefundLine
+	if err := json.Unmarshal(refundsJSON, &refunds); err != nil {
+		return nil, err
+	}
+	return refunds, nil
+}
+
+// orderStatusHistoryLine mirrors orders_repo.OrderStatusHistory with a JSON-friendly nullable from status.
+type orderStatusHistoryLine struct {
+	FromStatus *orders_repo.OrderStatus `json:"from_status"`
+	ToStatus   orders_repo.OrderStatus  `json:"to_status"`
+	ChangedBy  pgtype.UUID              `json:"changed_by"`
+	Note       *string                  `json:"note"`
+	CreatedAt  pgtype.Timestamptz       `json:"created_at"`
+}
+
+// decodeOrderStatusHistory converts the aggregated status history JSON column into typed transitions.
+func decodeOrderStatusHistory(raw interface{}) ([]orderStatusHistoryLine, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	historyJSON, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []orderStatusHistoryLine
+	if err := json.Unmarshal(historyJSON, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func totalPaid(payments []orderPaymentLine) int64 {
+	var total int64
+	for _, p := range payments {
+		total += p.Amount
+	}
+	return total
+}
+
 func (s *OrderService) CancelOrder(ctx context.Context, orderID uuid.UUID, req CancelOrderRequest) error {
 	actorID, userIdOk := ctx.Value(common.UserIDKey).(uuid.UUID)
 
+	operational, err := s.settingsService.GetOperationalSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load operational settings", "error", err)
+		return err
+	}
+
 	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
 		qtx := orders_repo.New(tx)
 		qPrd := products_repo.New(tx)
+		qCost := costing_repo.New(tx)
 		orderWithDetails, err := qtx.GetOrderWithDetails(ctx, orderID)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
@@ -805,6 +2022,24 @@ func (s *OrderService) CancelOrder(ctx context.Context, orderID uuid.UUID, req C
 			return common.ErrOrderNotCancellable
 		}
 
+		// Tenders already taken on the order have to be refunded before it can be cancelled
+		payments, err := decodeOrderPayments(orderWithDetails.Payments)
+		if err != nil {
+			return err
+		}
+		refunds, err := decodeOrderRefunds(orderWithDetails.Refunds)
+		if err != nil {
+			return err
+		}
+		balance := orderBalance{NetTotal: orderWithDetails.NetTotal, Paid: totalPaid(payments)}
+		for _, r := range refunds {
+			balance.Refunded += r.Amount
+		}
+		if err := checkStatusTransition(orderWithDetails.Status, orders_repo.OrderStatusCancelled, viaCancel, balance); err != nil {
+			s.log.Warn("Attempted to cancel an order with unrefunded payments", "orderID", orderID, "error", err)
+			return err
+		}
+
 		// Cancel Midtrans Transaction if exists
 		if orderWithDetails.PaymentGatewayReference != nil && *orderWithDetails.PaymentGatewayReference != "" {
 			s.log.Infof("Cancelling Midtrans transaction for order %s", orderID)
@@ -832,86 +2067,82 @@ func (s *OrderService) CancelOrder(ctx context.Context, orderID uuid.UUID, req C
 			return err
 		}
 
-		if orderWithDetails.Items != nil {
-			switch v := orderWithDetails.Items.(type) {
-			case []byte:
-				var items []orders_repo.OrderItem
-				if err := json.Unmarshal(v, &items); err != nil {
-					return err
-				}
-				for _, item := range items {
-					// Fetch product to get stock
-					prod, err := qPrd.GetProductByID(ctx, item.ProductID)
-					if err != nil {
-						s.log.Error("Failed to fetch product for stock return", "error", err)
-						return err
-					}
+		if err := recordStatusChange(ctx, qtx, orderID, &orderWithDetails.Status, orders_repo.OrderStatusCancelled, &req.CancellationNotes); err != nil {
+			return err
+		}
+		if err := releasePromotion(ctx, qtx, orderID, orderWithDetails.AppliedPromotionID); err != nil {
+			return err
+		}
+		if err := s.releasePoints(ctx, qtx, orderID, orderWithDetails.CustomerID, "Order Cancelled"); err != nil {
+			return err
+		}
 
-					_, stockErr := qPrd.AddProductStock(ctx, products_repo.AddProductStockParams{
-						ID:       item.ProductID,
-						Quantity: item.Quantity,
-					})
-					if stockErr != nil {
-						s.log.Error("Failed to restore stock for product", "error", stockErr, "productID", item.ProductID)
-						return stockErr
-					}
+		// Made-to-order lines give their ingredients back; the other lines restock their product below
+		orderItems, err := qtx.GetOrderItemsByOrderID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		book, itemOptions, err := loadOrderRecipes(ctx, qtx, orderID, orderItems)
+		if err != nil {
+			return err
+		}
+		returned := make(ingredientNeeds)
+		for _, item := range orderItems {
+			book.add(returned, item.ProductID, itemOptions[item.ID], item.Quantity)
+		}
+		if err := moveIngredients(ctx, qtx, returned, orders_repo.StockChangeTypeReturn, orderID, pgtype.UUID{Bytes: actorID, Valid: userIdOk}, "Order Cancelled"); err != nil {
+			return err
+		}
 
-					// Log Stock History
-					qtx.CreateStockHistory(ctx, orders_repo.CreateStockHistoryParams{
-						ProductID:     item.ProductID,
-						ChangeAmount:  item.Quantity,
-						PreviousStock: prod.Stock,
-						CurrentStock:  prod.Stock + item.Quantity,
-						ChangeType:    orders_repo.StockChangeTypeReturn,
-						ReferenceID:   pgtype.UUID{Bytes: orderID, Valid: true},
-						Note:          utils.StringPtr("Order Cancelled"),
-						CreatedBy:     pgtype.UUID{Bytes: actorID, Valid: userIdOk},
-					})
-				}
-			case []interface{}:
-				for _, item := range v {
-					if itemMap, ok := item.(map[string]interface{}); ok {
-						productID, ok := itemMap["product_id"].(uuid.UUID)
-						if !ok {
-							s.log.Error("Invalid product ID in order items", "item", item)
-							continue
-						}
-						quantity, ok := itemMap["quantity"].(float64)
-						if !ok {
-							s.log.Error("Invalid quantity in order items", "item", item)
-							continue
-						}
-
-						// Fetch product
-						prod, err := qPrd.GetProductByID(ctx, productID)
-						if err != nil {
-							return err
-						}
-
-						_, stockErr := qPrd.AddProductStock(ctx, products_repo.AddProductStockParams{
-							ID:       productID,
-							Quantity: int32(quantity),
-						})
-						if stockErr != nil {
-							s.log.Error("Failed to restore stock for product", "error", stockErr, "productID", productID)
-							return stockErr
-						}
-
-						// Log Stock History
-						qtx.CreateStockHistory(ctx, orders_repo.CreateStockHistoryParams{
-							ProductID:     productID,
-							ChangeAmount:  int32(quantity),
-							PreviousStock: prod.Stock,
-							CurrentStock:  prod.Stock + int32(quantity),
-							ChangeType:    orders_repo.StockChangeTypeReturn,
-							ReferenceID:   pgtype.UUID{Bytes: orderID, Valid: true},
-							Note:          utils.StringPtr("Order Cancelled"),
-							CreatedBy:     pgtype.UUID{Bytes: actorID, Valid: userIdOk},
-						})
-					} else {
-						s.log.Error("Unexpected item type in order items", "item", item)
-					}
-				}
+		// Variant lines go back on their variant, which keeps its own cost instead of cost layers
+		for _, item := range orderItems {
+			if !item.VariantID.Valid {
+				continue
+			}
+			if err := returnVariantStock(ctx, qtx, item, item.Quantity, orders_repo.StockChangeTypeReturn, pgtype.UUID{Bytes: actorID, Valid: userIdOk}, "Order Cancelled"); err != nil {
+				return err
+			}
+		}
+
+		// The remaining lines restock their product and give their units back to the cost layers
+		for _, item := range orderItems {
+			if item.VariantID.Valid || book.madeToOrder(item.ProductID) {
+				continue
+			}
+
+			prod, err := qPrd.GetProductByID(ctx, item.ProductID)
+			if err != nil {
+				s.log.Error("Failed to fetch product for stock return", "error", err)
+				return err
+			}
+
+			_, stockErr := qPrd.AddProductStock(ctx, products_repo.AddProductStockParams{
+				ID:       item.ProductID,
+				Quantity: item.Quantity,
+			})
+			if stockErr != nil {
+				s.log.Error("Failed to restore stock for product", "error", stockErr, "productID", item.ProductID)
+				return stockErr
+			}
+
+			if _, err := qtx.CreateStockHistory(ctx, orders_repo.CreateStockHistoryParams{
+				ProductID:     item.ProductID,
+				ChangeAmount:  item.Quantity,
+				PreviousStock: prod.Stock,
+				CurrentStock:  prod.Stock + item.Quantity,
+				ChangeType:    orders_repo.StockChangeTypeReturn,
+				ReferenceID:   pgtype.UUID{Bytes: orderID, Valid: true},
+				Note:          utils.StringPtr("Order Cancelled"),
+				CreatedBy:     pgtype.UUID{Bytes: actorID, Valid: userIdOk},
+			}); err != nil {
+				s.log.Error("Failed to record stock history for product", "error", err, "productID", item.ProductID)
+				return err
+			}
+
+			line := costedLine{ItemID: item.ID, ProductID: item.ProductID, Quantity: item.Quantity, CostPrice: item.CostPriceAtSale}
+			if err := returnLineCost(ctx, qtx, qCost, operational.CostingMethod, line, prod.Stock, item.Quantity); err != nil {
+				s.log.Error("Failed to restore cost layers for product", "error", err, "productID", item.ProductID)
+				return err
 			}
 		}
 
@@ -939,7 +2170,8 @@ func (s *OrderService) CancelOrder(ctx context.Context, orderID uuid.UUID, req C
 	s.log.Info("Order cancelled successfully", "orderID", orderID)
 
 	if s.wsHub != nil {
-		s.wsHub.BroadcastEvent(ws.EventOrderUpdated, map[string]interface{}{"order_id": orderID})
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
+		s.PublishCallingBoard(ctx)
 	}
 
 	return nil
@@ -948,11 +2180,27 @@ func (s *OrderService) CancelOrder(ctx context.Context, orderID uuid.UUID, req C
 func (s *OrderService) RefundOrder(ctx context.Context, orderID uuid.UUID, req RefundOrderRequest) (*OrderDetailResponse, error) {
 	actorID, userIdOk := ctx.Value(common.UserIDKey).(uuid.UUID)
 
+	restock := req.Restock == nil || *req.Restock
+
 	var finalOrder orders_repo.GetOrderWithDetailsRow
+	var refundedAmount int64
+	var fullRefund bool
+
+	taxRules, err := s.settingsService.GetTaxSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load tax settings", "error", err)
+		return nil, err
+	}
+	operational, err := s.settingsService.GetOperationalSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load operational settings", "error", err)
+		return nil, err
+	}
 
 	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
 		qtx := orders_repo.New(tx)
 		qPrd := products_repo.New(tx)
+		qCost := costing_repo.New(tx)
 
 		order, err := qtx.GetOrderForUpdate(ctx, orderID)
 		if err != nil {
@@ -963,44 +2211,177 @@ func (s *OrderService) RefundOrder(ctx context.Context, orderID uuid.UUID, req R
 		}
 
 		if order.PaymentMethodID == nil {
-			return errors.New("only paid orders can be refunded")
+			return common.ErrOrderNotRefundable
+		}
+
+		if order.Status == orders_repo.OrderStatusCancelled {
+			return common.ErrOrderNotModifiable
 		}
 
-		_, err = qtx.RefundOrder(ctx, orderID)
+		items, err := qtx.GetOrderItemsByOrderID(ctx, orderID)
 		if err != nil {
 			return err
 		}
 
-		items, err := qtx.GetOrderItemsByOrderID(ctx, orderID)
+		refundedRows, err := qtx.GetRefundedItemQuantities(ctx, orderID)
 		if err != nil {
 			return err
 		}
+		refundedQty := make(map[uuid.UUID]int32, len(refundedRows))
+		restockedQty := make(map[uuid.UUID]int32, len(refundedRows))
+		for _, row := range refundedRows {
+			refundedQty[row.OrderItemID] = row.RefundedQuantity
+			restockedQty[row.OrderItemID] = row.RestockedQuantity
+		}
 
-		for _, item := range items {
-			prod, err := qPrd.GetProductByID(ctx, item.ProductID)
+		previousRefunds, err := qtx.ListOrderRefunds(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		var alreadyRefunded int64
+		for _, r := range previousRefunds {
+			alreadyRefunded += r.Amount
+		}
+
+		// Lines are refunded with the tax and service charge they were charged
+		pricing := refundPricing{OrderType: order.Type, Rules: orderTaxRules(order, taxRules)}
+		productIDs := make([]uuid.UUID, len(items))
+		for i, item := range items {
+			productIDs[i] = item.ProductID
+		}
+		if pricing.Exempt, err = taxExemptProducts(ctx, qtx, productIDs, pricing.Rules); err != nil {
+			return err
+		}
+
+		lines, amount, fullyRefunded, err := planRefund(order, items, pricing, refundedQty, alreadyRefunded, req.Items)
+		if err != nil {
+			return err
+		}
+
+		payments, err := qtx.ListOrderPayments(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		// Each portion is refunded through the tender it was paid with
+		allocations, err := allocateRefund(amount, payments, previousRefunds, req.OrderPaymentID, order.PaymentMethodID)
+		if err != nil {
+			return err
+		}
+
+		var refundIDs []uuid.UUID
+		for _, allocation := range allocations {
+			refund, err := qtx.CreateOrderRefund(ctx, orders_repo.CreateOrderRefundParams{
+				OrderID:         orderID,
+				CreatedBy:       pgtype.UUID{Bytes: actorID, Valid: userIdOk},
+				OrderShiftID:    order.ShiftID,
+				PaymentMethodID: allocation.PaymentMethodID,
+				OrderPaymentID:  allocation.OrderPaymentID,
+				Amount:          allocation.Amount,
+				Reason:          utils.StringPtr(req.Reason),
+			})
+			if err != nil {
+				return err
+			}
+			refundIDs = append(refundIDs, refund.ID)
+		}
+
+		if err := s.refundPoints(ctx, qtx, order, allocations, alreadyRefunded+amount, fullyRefunded); err != nil {
+			return err
+		}
+
+		var book *recipeBook
+		var itemOptions map[uuid.UUID][]uuid.UUID
+		returned := make(ingredientNeeds)
+		if restock {
+			refundedItems := make([]orders_repo.OrderItem, 0, len(lines))
+			for _, line := range lines {
+				refundedItems = append(refundedItems, line.Item)
+			}
+			book, itemOptions, err = loadOrderRecipes(ctx, qtx, orderID, refundedItems)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, line := range lines {
+			// Lines are recorded once, on the first refund record, so refunded quantities are never double counted
+			if _, err := qtx.CreateOrderRefundItem(ctx, orders_repo.CreateOrderRefundItemParams{
+				RefundID:    refundIDs[0],
+				OrderItemID: line.Item.ID,
+				Quantity:    line.Quantity,
+				Amount:      line.Amount,
+				Restocked:   restock,
+			}); err != nil {
+				return err
+			}
+
+			if !restock {
+				continue
+			}
+
+			book.add(returned, line.Item.ProductID, itemOptions[line.Item.ID], line.Quantity)
+			if line.Item.VariantID.Valid {
+				if err := returnVariantStock(ctx, qtx, line.Item, line.Quantity, orders_repo.StockChangeTypeReturn, pgtype.UUID{Bytes: actorID, Valid: userIdOk}, "Order Refunded: "+req.Reason); err != nil {
+					return err
+				}
+				continue
+			}
+			if book.madeToOrder(line.Item.ProductID) {
+				continue
+			}
+
+			prod, err := qPrd.GetProductByID(ctx, line.Item.ProductID)
 			if err != nil {
 				return err
 			}
 
 			_, stockErr := qPrd.AddProductStock(ctx, products_repo.AddProductStockParams{
-				ID:       item.ProductID,
-				Quantity: item.Quantity,
+				ID:       line.Item.ProductID,
+				Quantity: line.Quantity,
 			})
 			if stockErr != nil {
 				return stockErr
 			}
 
 			qtx.CreateStockHistory(ctx, orders_repo.CreateStockHistoryParams{
-				ProductID:     item.ProductID,
-				ChangeAmount:  item.Quantity,
+				ProductID:     line.Item.ProductID,
+				ChangeAmount:  line.Quantity,
 				PreviousStock: prod.Stock,
-				CurrentStock:  prod.Stock + item.Quantity,
+				CurrentStock:  prod.Stock + line.Quantity,
 				ChangeType:    orders_repo.StockChangeTypeReturn,
 				ReferenceID:   pgtype.UUID{Bytes: orderID, Valid: true},
 				Note:          utils.StringPtr("Order Refunded: " + req.Reason),
 				CreatedBy:     pgtype.UUID{Bytes: actorID, Valid: userIdOk},
 			})
+
+			// Units restocked by an earlier refund no longer carry the line's cost
+			costed := costedLine{ItemID: line.Item.ID, ProductID: line.Item.ProductID, Quantity: line.Item.Quantity - restockedQty[line.Item.ID], CostPrice: line.Item.CostPriceAtSale}
+			if err := returnLineCost(ctx, qtx, qCost, operational.CostingMethod, costed, prod.Stock, line.Quantity); err != nil {
+				return err
+			}
+		}
+
+		if err := moveIngredients(ctx, qtx, returned, orders_repo.StockChangeTypeReturn, orderID, pgtype.UUID{Bytes: actorID, Valid: userIdOk}, "Order Refunded: "+req.Reason); err != nil {
+			return err
+		}
+
+		// A fully refunded order is closed; a partial refund only bumps the version
+		if fullyRefunded {
+			if _, err := qtx.RefundOrder(ctx, orderID); err != nil {
+				return err
+			}
+			if err := recordStatusChange(ctx, qtx, orderID, &order.Status, orders_repo.OrderStatusCancelled, utils.StringPtr("Refunded: "+req.Reason)); err != nil {
+				return err
+			}
+			if err := releasePromotion(ctx, qtx, orderID, order.AppliedPromotionID); err != nil {
+				return err
+			}
+		} else if _, err := qtx.BumpOrderVersion(ctx, orders_repo.BumpOrderVersionParams{ID: orderID, Version: order.Version}); err != nil {
+			return err
 		}
+		refundedAmount = amount
+		fullRefund = fullyRefunded
 
 		finalOrder, err = qtx.GetOrderWithDetails(ctx, orderID)
 		return err
@@ -1018,13 +2399,18 @@ func (s *OrderService) RefundOrder(ctx context.Context, orderID uuid.UUID, req R
 		activity_repo.LogEntityTypeORDER,
 		orderID.String(),
 		map[string]interface{}{
-			"action": "refund",
-			"reason": req.Reason,
+			"action":  "refund",
+			"reason":  req.Reason,
+			"amount":  refundedAmount,
+			"full":    fullRefund,
+			"items":   len(req.Items),
+			"restock": restock,
 		},
 	)
 
 	if s.wsHub != nil {
-		s.wsHub.BroadcastEvent(ws.EventOrderUpdated, map[string]interface{}{"order_id": orderID})
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
+		s.PublishCallingBoard(ctx)
 	}
 
 	return s.buildOrderDetailResponseFromQueryResult(ctx, finalOrder)
@@ -1100,9 +2486,14 @@ func (s *OrderService) ListOrders(ctx context.Context, req ListOrdersRequest) (*
 		}
 
 		var productIDs []uuid.UUID
+		var variantIDs []uuid.UUID
 		for _, item := range items {
 			productIDs = append(productIDs, item.ProductID)
+			if item.VariantID.Valid {
+				variantIDs = append(variantIDs, item.VariantID.Bytes)
+			}
 		}
+		variantNameMap := s.variantNames(ctx, variantIDs)
 
 		var productMap map[uuid.UUID]string
 		if len(productIDs) > 0 {
@@ -1127,17 +2518,28 @@ func (s *OrderService) ListOrders(ctx context.Context, req ListOrdersRequest) (*
 				}
 			}
 
+			variantID := utils.NullableUUIDToPointer(item.VariantID)
+			var variantName string
+			if variantID != nil {
+				variantName = variantNameMap[*variantID]
+			}
+
 			itemResponses = append(itemResponses, OrderItemResponse{
 				ID:          item.ID,
 				ProductID:   item.ProductID,
 				ProductName: name,
+				VariantID:   variantID,
+				VariantName: variantName,
 				Quantity:    item.Quantity,
 				PriceAtSale: item.PriceAtSale,
 				Subtotal:    item.Subtotal,
 			})
 		}
 
-		queueNumber := order.ID.String()[len(order.ID.String())-4:]
+		var queueNumber string
+		if order.QueueNumber != nil {
+			queueNumber = *order.QueueNumber
+		}
 
 		isPaid := false
 		if order.PaymentMethodID != nil {
@@ -1152,7 +2554,7 @@ func (s *OrderService) ListOrders(ctx context.Context, req ListOrdersRequest) (*
 			NetTotal:    netTotal,
 			CreatedAt:   order.CreatedAt.Time,
 			Items:       itemResponses,
-			QueueNumber: "#" + queueNumber,
+			QueueNumber: queueNumber,
 			IsPaid:      isPaid,
 		})
 	}
@@ -1172,12 +2574,25 @@ func (s *OrderService) ListOrders(ctx context.Context, req ListOrdersRequest) (*
 func (s *OrderService) CreateOrder(ctx context.Context, req CreateOrderRequest) (*OrderDetailResponse, error) {
 	var newOrderID uuid.UUID
 	var finalOrder orders_repo.GetOrderWithDetailsRow
+	var stockAlerts []StockAlert
 
 	actorID, ok := ctx.Value(common.UserIDKey).(uuid.UUID)
 	if !ok {
 		s.log.Warn("Actor user ID not found in context for order creation")
 	}
 
+	taxRules, err := s.settingsService.GetTaxSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load tax settings", "error", err)
+		return nil, err
+	}
+
+	operational, err := s.settingsService.GetOperationalSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load operational settings", "error", err)
+		return nil, err
+	}
+
 	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
 		qtx := orders_repo.New(tx)
 		qPrd := products_repo.New(tx)
@@ -1188,16 +2603,27 @@ func (s *OrderService) CreateOrder(ctx context.Context, req CreateOrderRequest)
 			nullCustomerID.Bytes = *req.CustomerID
 		}
 
+		queueNumber, day, err := assignQueueNumber(ctx, qtx, operational, time.Now())
+		if err != nil {
+			return err
+		}
+
 		orderHeader, err := qtx.CreateOrder(ctx, orders_repo.CreateOrderParams{
-			UserID:     pgtype.UUID{Bytes: actorID, Valid: ok},
-			Type:       req.Type,
-			CustomerID: nullCustomerID,
+			UserID:       pgtype.UUID{Bytes: actorID, Valid: ok},
+			Type:         req.Type,
+			CustomerID:   nullCustomerID,
+			QueueNumber:  &queueNumber,
+			BusinessDate: day,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create order header: %w", err)
 		}
 		newOrderID = orderHeader.ID
 
+		if err := recordStatusChange(ctx, qtx, orderHeader.ID, nil, orderHeader.Status, nil); err != nil {
+			return fmt.Errorf("failed to record order status: %w", err)
+		}
+
 		productIDs := make([]uuid.UUID, len(req.Items))
 		for i, item := range req.Items {
 			productIDs[i] = item.ProductID
@@ -1213,23 +2639,37 @@ func (s *OrderService) CreateOrder(ctx context.Context, req CreateOrderRequest)
 			productMap[p.ID] = p
 		}
 
-		var allOptionIDs []uuid.UUID
-		for _, item := range req.Items {
-			for _, opt := range item.Options {
-				allOptionIDs = append(allOptionIDs, opt.ProductOptionID)
-			}
+		variants, err := loadVariantBook(ctx, qtx, productIDs)
+		if err != nil {
+			return err
 		}
 
-		optionMap := make(map[uuid.UUID]products_repo.ProductOption)
-		if len(allOptionIDs) > 0 {
-			options, err := qPrd.GetProductOptionsByIDs(ctx, allOptionIDs)
-			if err != nil {
-				return fmt.Errorf("failed to fetch options: %w", err)
-			}
-			for _, opt := range options {
-				optionMap[opt.ID] = opt
-			}
+		rules, err := loadModifierRules(ctx, qtx, productIDs)
+		if err != nil {
+			return err
+		}
+
+		// Every line is checked before anything is taken, so the POS gets all violations at once
+		var (
+			allOptionIDs []uuid.UUID
+			violations   []ModifierViolation
+		)
+		lineOptions := make([][]orders_repo.GetOrderableOptionsRow, len(req.Items))
+		for i, item := range req.Items {
+			selected, lineViolations := rules.selectOptions(i, item.ProductID, item.Options)
+			lineOptions[i] = selected
+			violations = append(violations, lineViolations...)
+			allOptionIDs = append(allOptionIDs, selectedOptionIDs(selected)...)
+		}
+		if len(violations) > 0 {
+			return &ModifierError{Violations: violations}
+		}
+
+		book, err := loadRecipeBook(ctx, qtx, productIDs, allOptionIDs)
+		if err != nil {
+			return err
 		}
+		needs := make(ingredientNeeds)
 
 		var (
 			itemOrderIDs   []uuid.UUID
@@ -1239,34 +2679,40 @@ func (s *OrderService) CreateOrder(ctx context.Context, req CreateOrderRequest)
 			itemSubtotals  []pgtype.Numeric
 			itemNetSubs    []pgtype.Numeric
 			itemCostPrices []pgtype.Numeric
+			itemVariantIDs []uuid.UUID
 			stockUpdateIDs []uuid.UUID
 			stockUpdateQty []int32
 		)
 
-		var grossTotal int64 = 0
+		var lines []pricingLine
 
-		for _, itemReq := range req.Items {
+		for i, itemReq := range req.Items {
 			product, exists := productMap[itemReq.ProductID]
 			if !exists {
 				return fmt.Errorf("product %s not found", itemReq.ProductID)
 			}
 
-			if product.Stock < itemReq.Quantity {
+			variant, err := variants.resolve(itemReq.ProductID, itemReq.VariantID)
+			if err != nil {
+				return err
+			}
+
+			// Made-to-order products are limited by their ingredients, checked once the whole order is known.
+			// Variant stock is checked as it is taken.
+			madeToOrder := variant == nil && book.madeToOrder(itemReq.ProductID)
+			if variant == nil && !madeToOrder && product.Stock < itemReq.Quantity {
 				return fmt.Errorf("insufficient stock for %s: available %d, requested %d", product.Name, product.Stock, itemReq.Quantity)
 			}
 
-			priceAtSale := product.Price
+			priceAtSale := variantPrice(product.Price, variant)
 
-			for _, optReq := range itemReq.Options {
-				option, exists := optionMap[optReq.ProductOptionID]
-				if !exists {
-					return fmt.Errorf("option %s not found (or belongs to different product)", optReq.ProductOptionID)
-				}
+			for _, option := range lineOptions[i] {
 				priceAtSale += option.AdditionalPrice
 			}
+			book.add(needs, itemReq.ProductID, selectedOptionIDs(lineOptions[i]), itemReq.Quantity)
 
 			subtotal := priceAtSale * int64(itemReq.Quantity)
-			grossTotal += subtotal
+			lines = append(lines, pricingLine{ProductID: itemReq.ProductID, Subtotal: subtotal})
 
 			itemOrderIDs = append(itemOrderIDs, newOrderID)
 			itemProductIDs = append(itemProductIDs, itemReq.ProductID)
@@ -1275,17 +2721,26 @@ func (s *OrderService) CreateOrder(ctx context.Context, req CreateOrderRequest)
 			itemSubtotals = append(itemSubtotals, utils.Int64ToNumeric(subtotal))
 			itemNetSubs = append(itemNetSubs, utils.Int64ToNumeric(subtotal))
 
+			unitCost := product.CostPrice
+			variantID := uuid.Nil
+			if variant != nil {
+				unitCost = variant.CostPrice
+				variantID = variant.ID
+			}
 			costPrice := 0.0
-			if product.CostPrice.Valid {
-				f, _ := product.CostPrice.Float64Value()
+			if unitCost.Valid {
+				f, _ := unitCost.Float64Value()
 				costPrice = f.Float64
 			}
 			numericCost := pgtype.Numeric{}
 			numericCost.Scan(fmt.Sprintf("%f", costPrice))
 			itemCostPrices = append(itemCostPrices, numericCost)
+			itemVariantIDs = append(itemVariantIDs, variantID)
 
-			stockUpdateIDs = append(stockUpdateIDs, itemReq.ProductID)
-			stockUpdateQty = append(stockUpdateQty, itemReq.Quantity)
+			if variant == nil && !madeToOrder {
+				stockUpdateIDs = append(stockUpdateIDs, itemReq.ProductID)
+				stockUpdateQty = append(stockUpdateQty, itemReq.Quantity)
+			}
 		}
 
 		createdItems, err := qtx.BatchCreateOrderItems(ctx, orders_repo.BatchCreateOrderItemsParams{
@@ -1296,6 +2751,7 @@ func (s *OrderService) CreateOrder(ctx context.Context, req CreateOrderRequest)
 			Subtotals:        itemSubtotals,
 			NetSubtotals:     itemNetSubs,
 			CostPricesAtSale: itemCostPrices,
+			VariantIds:       itemVariantIDs,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to batch insert items: %w", err)
@@ -1307,15 +2763,13 @@ func (s *OrderService) CreateOrder(ctx context.Context, req CreateOrderRequest)
 			return fmt.Errorf("mismatch between requested items (%d) and created items (%d)", len(req.Items), len(createdItems))
 		}
 
-		for i, reqItem := range req.Items {
+		for i := range req.Items {
 			createdItem := createdItems[i]
 
-			for _, optReq := range reqItem.Options {
-				option, _ := optionMap[optReq.ProductOptionID]
-
+			for _, option := range lineOptions[i] {
 				batchOptionParams = append(batchOptionParams, orders_repo.BatchCreateOrderItemOptionsParams{
 					OrderItemID:     createdItem.ID,
-					ProductOptionID: optReq.ProductOptionID,
+					ProductOptionID: option.ID,
 					PriceAtSale:     option.AdditionalPrice,
 				})
 			}
@@ -1331,12 +2785,14 @@ func (s *OrderService) CreateOrder(ctx context.Context, req CreateOrderRequest)
 			}
 		}
 
-		err = qtx.BatchDecreaseProductStock(ctx, orders_repo.BatchDecreaseProductStockParams{
-			ProductIds: stockUpdateIDs,
-			Quantities: stockUpdateQty,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to batch update stock: %w", err)
+		if len(stockUpdateIDs) > 0 {
+			err = qtx.BatchDecreaseProductStock(ctx, orders_repo.BatchDecreaseProductStockParams{
+				ProductIds: stockUpdateIDs,
+				Quantities: stockUpdateQty,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to batch update stock: %w", err)
+			}
 		}
 
 		for i, pID := range stockUpdateIDs {
@@ -1344,6 +2800,7 @@ func (s *OrderService) CreateOrder(ctx context.Context, req CreateOrderRequest)
 			product := productMap[pID]
 			previousStock := product.Stock
 			currentStock := previousStock - qty
+			stockAlerts = appendStockAlert(stockAlerts, pID, product.Name, previousStock, currentStock)
 
 			_, err := qtx.CreateStockHistory(ctx, orders_repo.CreateStockHistoryParams{
 				ProductID:     pID,
@@ -1362,22 +2819,40 @@ func (s *OrderService) CreateOrder(ctx context.Context, req CreateOrderRequest)
 			}
 		}
 
-		taxAmount := int64(float64(grossTotal) * 0.11) // Default 11% tax
-		serviceChargeAmount := int64(0)
-		netTotal := grossTotal + taxAmount + serviceChargeAmount
+		for _, item := range createdItems {
+			if !item.VariantID.Valid {
+				continue
+			}
+			previous, err := variants.take(ctx, qtx, item.VariantID.Bytes, item.Quantity, newOrderID, pgtype.UUID{Bytes: actorID, Valid: ok}, "Order Created")
+			if err != nil {
+				return err
+			}
+			stockAlerts = appendVariantStockAlert(stockAlerts, productMap[item.ProductID].Name, previous, previous.Stock-item.Quantity)
+		}
+
+		// Variants carry their own cost price rather than cost layers
+		qCost := costing_repo.New(tx)
+		for _, item := range createdItems {
+			if item.VariantID.Valid || book.madeToOrder(item.ProductID) {
+				continue
+			}
+			line := costedLine{ItemID: item.ID, ProductID: item.ProductID}
+			if err := drawLineCost(ctx, qtx, qCost, operational.CostingMethod, line, item.Quantity, item.CostPriceAtSale); err != nil {
+				return err
+			}
+		}
 
-		_, err = qtx.UpdateOrderTotals(ctx, orders_repo.UpdateOrderTotalsParams{
-			ID:                  newOrderID,
-			GrossTotal:          grossTotal,
-			NetTotal:            netTotal,
-			DiscountAmount:      0,
-			TaxAmount:           taxAmount,
-			ServiceChargeAmount: serviceChargeAmount,
-			Version:             1,
-		})
+		if err := moveIngredients(ctx, qtx, needs, orders_repo.StockChangeTypeSale, newOrderID, pgtype.UUID{Bytes: actorID, Valid: ok}, "Order Created"); err != nil {
+			return err
+		}
+
+		created, err := s.recalculateOrderTotals(ctx, qtx, newOrderID, req.Type, lines, 0, orderHeader.Version, taxRules)
 		if err != nil {
 			return fmt.Errorf("failed to update order totals: %w", err)
 		}
+		if _, _, err := s.repricePromotions(ctx, tx, qtx, created, taxRules); err != nil {
+			return err
+		}
 
 		finalOrder, err = qtx.GetOrderWithDetails(ctx, newOrderID)
 		return err
@@ -1403,7 +2878,9 @@ func (s *OrderService) CreateOrder(ctx context.Context, req CreateOrderRequest)
 	)
 
 	if s.wsHub != nil {
-		s.wsHub.BroadcastEvent(ws.EventOrderCreated, map[string]interface{}{"order_id": newOrderID})
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderCreated, newOrderID, map[string]interface{}{"order_id": newOrderID}))
+		s.publishStockAlerts(stockAlerts)
+		s.PublishCallingBoard(ctx)
 	}
 
 	return s.buildOrderDetailResponseFromQueryResult(ctx, finalOrder)
@@ -1447,7 +2924,42 @@ func (s *OrderService) InitiateMidtransPayment(ctx context.Context, orderID uuid
 
 	}
 
-	chargeResp, err := s.midtransService.CreateQRISCharge(order.ID.String(), order.NetTotal)
+	gatewayMethod, err := s.ordersRepo.GetActivePaymentMethodByKind(ctx, orders_repo.PaymentMethodKindGateway)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warn("No active gateway payment method configured", "orderID", orderID)
+			return nil, common.ErrPaymentMethodInvalid
+		}
+		return nil, err
+	}
+
+	applied, err := orderPromotions(ctx, s.ordersRepo, orderID, order.AppliedPromotionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range applied {
+		if err := checkPromotionPaymentMethod(ctx, s.ordersRepo, p.PromotionID, gatewayMethod); err != nil {
+			return nil, err
+		}
+	}
+
+	// A gateway charge cannot be undone once the customer pays, so the promotions' limits are checked up front
+	for _, p := range applied {
+		if err := checkPromotionLimits(ctx, s.ordersRepo, p.PromotionID, p.PromotionCodeID, order.CustomerID, orderFamily(order.ID, order.ParentOrderID), p.DiscountAmount); err != nil {
+			return nil, err
+		}
+	}
+
+	payments, err := decodeOrderPayments(order.Payments)
+	if err != nil {
+		return nil, err
+	}
+	paid := totalPaid(payments)
+	if paid >= order.NetTotal && order.NetTotal > 0 {
+		return nil, common.ErrOrderAlreadyPaid
+	}
+
+	chargeResp, err := s.midtransService.CreateQRISCharge(order.ID.String(), order.NetTotal-paid)
 	if err != nil {
 		return nil, err
 	}
@@ -1511,6 +3023,15 @@ func (s *OrderService) InitiateMidtransPayment(ctx context.Context, orderID uuid
 	return response, nil
 }
 
+// gatewayAmount reads a Midtrans gross_amount such as "15000.00" in whole rupiah.
+func gatewayAmount(v string) (int64, error) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 || f != math.Trunc(f) || f > math.MaxInt64 {
+		return 0, fmt.Errorf("invalid gross amount %q", v)
+	}
+	return int64(f), nil
+}
+
 func (s *OrderService) HandleMidtransNotification(ctx context.Context, payload payment.MidtransNotificationPayload) error {
 	s.log.Infof("Handling Midtrans notification for Order ID: %s", payload.OrderID)
 
@@ -1525,60 +3046,217 @@ func (s *OrderService) HandleMidtransNotification(ctx context.Context, payload p
 		return common.ErrNotFound
 	}
 
-	order, err := s.ordersRepo.GetOrderWithDetails(ctx, orderIDFromPayload)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			s.log.Warn("Order not found for Midtrans notification", "orderID", payload.OrderID)
-			return common.ErrNotFound
+	// The order is locked for the whole settlement, so that duplicate notifications arriving together are
+	// handled one after the other and a failed step leaves nothing behind
+	var (
+		previousStatus orders_repo.OrderStatus
+		newStatus      orders_repo.OrderStatus
+		updatedOrder   orders_repo.Order
+		handled        bool
+		rejectReason   string
+		balance        int64
+		charged        int64
+	)
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := orders_repo.New(tx)
+		order, err := qtx.GetOrderForUpdate(ctx, orderIDFromPayload)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				s.log.Warn("Order not found for Midtrans notification", "orderID", payload.OrderID)
+				return common.ErrNotFound
+			}
+			s.log.Error("Failed to get order for notification", "error", err)
+			return err
 		}
-		s.log.Error("Failed to get order for notification", "error", err)
-		return err
-	}
+		previousStatus = order.Status
+		updatedOrder = order
 
-	if order.Status == orders_repo.OrderStatusPaid || order.Status == orders_repo.OrderStatusCancelled {
-		s.log.Warn("Received notification for an already finalized order", "orderID", order.ID, "status", order.Status)
-		return nil
-	}
+		if order.Status == orders_repo.OrderStatusPaid || order.Status == orders_repo.OrderStatusCancelled {
+			s.log.Warn("Received notification for an already finalized order", "orderID", order.ID, "status", order.Status)
+			return nil
+		}
 
-	var newStatus orders_repo.OrderStatus
-	var paymentMethodID *int32
+		// Only the charge the order is waiting on is acted upon; any other was replaced or withdrawn
+		pending := order.PaymentGatewayReference != nil && *order.PaymentGatewayReference == payload.TransactionID
 
-	switch payload.TransactionStatus {
-	case "settlement", "capture":
-		// If order is still 'open', move to 'in_progress' instead of 'paid'
-		// This follows the new flow where 'paid' is the final status after 'served'
-		if order.Status == orders_repo.OrderStatusOpen {
-			newStatus = orders_repo.OrderStatusInProgress
-		} else {
+		var paymentMethodID *int32
+
+		switch payload.TransactionStatus {
+		case "settlement", "capture":
+			// If order is still 'open', move to 'in_progress' instead of 'paid'
+			// This follows the new flow where 'paid' is the final status after 'served'
+			if order.Status == orders_repo.OrderStatusOpen {
+				newStatus = orders_repo.OrderStatusInProgress
+			} else {
+				newStatus = order.Status
+			}
+			gatewayMethod, err := qtx.GetActivePaymentMethodByKind(ctx, orders_repo.PaymentMethodKindGateway)
+			if err != nil {
+				s.log.Error("Failed to resolve gateway payment method for notification", "error", err, "orderID", order.ID)
+				return err
+			}
+			paymentMethodID = &gatewayMethod.ID
+		case "cancel", "deny", "expire":
+			// A failed charge only withdraws itself: the order stays as it is, to be paid another way or
+			// cancelled by the cashier
+			if !pending {
+				s.log.Infof("Ignoring Midtrans %s notification for a charge the order is not waiting on", payload.TransactionStatus)
+				return nil
+			}
+			payments, err := qtx.ListOrderPayments(ctx, order.ID)
+			if err != nil {
+				s.log.Error("Failed to get order payments for notification", "error", err, "orderID", order.ID)
+				return err
+			}
+			if order.PaymentMethodID != nil || sumTenderAmounts(payments) >= order.NetTotal {
+				s.log.Infof("Ignoring Midtrans %s notification for an order that is already paid", payload.TransactionStatus)
+				return nil
+			}
+			if err := qtx.ClearOrderPaymentGateway(ctx, order.ID); err != nil {
+				s.log.Error("Failed to clear the pending charge from notification", "error", err, "orderID", order.ID)
+				return err
+			}
+			updatedOrder.PaymentGatewayReference = nil
+			updatedOrder.PaymentUrl = nil
+			updatedOrder.PaymentToken = nil
 			newStatus = order.Status
+			handled = true
+			return nil
+		default:
+			s.log.Infof("Ignoring Midtrans notification with status: %s", payload.TransactionStatus)
+			return nil
+		}
+
+		// Midtrans may send the same settlement more than once; the first one recorded the tender
+		var payments []orders_repo.OrderPayment
+		if paymentMethodID != nil {
+			payments, err = qtx.ListOrderPayments(ctx, order.ID)
+			if err != nil {
+				s.log.Error("Failed to get order payments for notification", "error", err, "orderID", order.ID)
+				return err
+			}
+			for _, p := range payments {
+				if p.ReferenceNumber != nil && *p.ReferenceNumber == payload.TransactionID {
+					s.log.Warn("Received a duplicate settlement notification", "orderID", order.ID, "transactionID", payload.TransactionID)
+					return nil
+				}
+			}
+
+			// The tender is what the customer was charged, which has to be what the bill still owes
+			balance = order.NetTotal - sumTenderAmounts(payments)
+			if !pending {
+				s.log.Error("Midtrans settlement is not for the order's pending charge", "orderID", order.ID, "transactionID", payload.TransactionID)
+				rejectReason = "transaction is not the order's pending charge"
+				return nil
+			}
+			charged, err = gatewayAmount(payload.GrossAmount)
+			if err != nil || charged != balance {
+				s.log.Error("Midtrans settlement does not match the order balance", "orderID", order.ID, "transactionID", payload.TransactionID, "grossAmount", payload.GrossAmount, "balance", balance)
+				rejectReason = "gross amount does not match the order balance"
+				return nil
+			}
+		}
+
+		updatedOrder, err = qtx.UpdateOrderStatusByGatewayRef(ctx, orders_repo.UpdateOrderStatusByGatewayRefParams{
+			PaymentGatewayReference: &payload.TransactionID,
+			Status:                  newStatus,
+			PaymentMethodID:         paymentMethodID,
+		})
+		if err != nil {
+			s.log.Error("Failed to update order status from notification", "error", err, "orderID", order.ID)
+			return err
 		}
-		paymentMethodID = utils.Int32Ptr(2) // QRIS/Midtrans
-	case "cancel", "deny", "expire":
-		newStatus = orders_repo.OrderStatusCancelled
-	default:
-		s.log.Infof("Ignoring Midtrans notification with status: %s", payload.TransactionStatus)
+
+		if newStatus != order.Status {
+			if err := recordStatusChange(ctx, qtx, updatedOrder.ID, &order.Status, newStatus, utils.StringPtr("Midtrans: "+payload.TransactionStatus)); err != nil {
+				s.log.Error("Failed to record order status change from notification", "error", err, "orderID", order.ID)
+				return err
+			}
+		}
+
+		// The customer has paid by now, so the redemption is recorded even past the promotion's limits
+		if paymentMethodID != nil {
+			applied, err := orderPromotions(ctx, qtx, updatedOrder.ID, updatedOrder.AppliedPromotionID)
+			if err != nil {
+				s.log.Error("Failed to get order promotions from notification", "error", err, "orderID", order.ID)
+				return err
+			}
+			for _, p := range applied {
+				if err := qtx.CreatePromotionRedemption(ctx, orders_repo.CreatePromotionRedemptionParams{
+					PromotionID:     p.PromotionID,
+					PromotionCodeID: p.PromotionCodeID,
+					OrderID:         updatedOrder.ID,
+					CustomerID:      updatedOrder.CustomerID,
+					DiscountAmount:  p.DiscountAmount,
+				}); err != nil {
+					s.log.Error("Failed to record promotion redemption from notification", "error", err, "orderID", order.ID)
+					return err
+				}
+			}
+			if err := s.earnPoints(ctx, qtx, updatedOrder); err != nil {
+				s.log.Error("Failed to credit loyalty points from notification", "error", err, "orderID", order.ID)
+				return err
+			}
+		}
+		if charged > 0 {
+			_, err = qtx.CreateOrderPayment(ctx, orders_repo.CreateOrderPaymentParams{
+				OrderID:         updatedOrder.ID,
+				PaymentMethodID: *paymentMethodID,
+				Amount:          charged,
+				TenderedAmount:  charged,
+				ReferenceNumber: &payload.TransactionID,
+				CreatedBy:       updatedOrder.UserID,
+				OrderShiftID:    updatedOrder.ShiftID,
+			})
+			if err != nil {
+				s.log.Error("Failed to record gateway tender from notification", "error", err, "orderID", order.ID)
+				return err
+			}
+		}
+
+		handled = true
 		return nil
+	})
+	if txErr != nil {
+		return txErr
 	}
 
-	updatedOrder, err := s.ordersRepo.UpdateOrderStatusByGatewayRef(ctx, orders_repo.UpdateOrderStatusByGatewayRefParams{
-		PaymentGatewayReference: &payload.TransactionID,
-		Status:                  newStatus,
-		PaymentMethodID:         paymentMethodID,
-	})
-	if err != nil {
-		s.log.Error("Failed to update order status from notification", "error", err, "orderID", order.ID)
-		return err
+	// Orders taken without a cashier account are logged without an actor
+	actorID := uuid.Nil
+	if updatedOrder.UserID.Valid {
+		actorID = updatedOrder.UserID.Bytes
+	}
+	if rejectReason != "" {
+		// The money was taken but not recorded, which someone has to settle by hand
+		s.activityService.Log(
+			ctx,
+			actorID,
+			activity_repo.LogActionTypePROCESSPAYMENT,
+			activity_repo.LogEntityTypeORDER,
+			updatedOrder.ID.String(),
+			map[string]interface{}{
+				"payment_gateway": "midtrans",
+				"gateway_status":  payload.TransactionStatus,
+				"transaction_id":  payload.TransactionID,
+				"gross_amount":    payload.GrossAmount,
+				"balance":         balance,
+				"rejected":        rejectReason,
+			},
+		)
+		return nil
+	}
+	if !handled {
+		return nil
 	}
 
-	userUUID := utils.NullableUUIDToPointer(updatedOrder.UserID)
 	s.activityService.Log(
 		ctx,
-		*userUUID,
+		actorID,
 		activity_repo.LogActionTypeUPDATE,
 		activity_repo.LogEntityTypeORDER,
 		updatedOrder.ID.String(),
 		map[string]interface{}{
-			"status_from":     order.Status,
+			"status_from":     previousStatus,
 			"status_to":       newStatus,
 			"payment_gateway": "midtrans",
 			"gateway_status":  payload.TransactionStatus,
@@ -1588,7 +3266,7 @@ func (s *OrderService) HandleMidtransNotification(ctx context.Context, payload p
 	s.log.Info("Successfully updated order status from notification", "orderID", updatedOrder.ID, "newStatus", newStatus)
 
 	if s.wsHub != nil {
-		s.wsHub.BroadcastEvent(ws.EventOrderUpdated, map[string]interface{}{"order_id": updatedOrder.ID})
+		s.wsHub.Publish(ws.OrderEvent(ws.EventOrderUpdated, updatedOrder.ID, map[string]interface{}{"order_id": updatedOrder.ID}))
 	}
 
 	return nil
@@ -3,9 +3,11 @@ package orders_test
 import (
 	"POS-kasir/internal/common"
 	"POS-kasir/internal/common/pagination"
+	costing_repo "POS-kasir/internal/costing/repository"
 	"POS-kasir/internal/orders"
 	orders_repo "POS-kasir/internal/orders/repository"
 	products_repo "POS-kasir/internal/products/repository"
+	"POS-kasir/internal/settings"
 	"POS-kasir/mocks"
 	"POS-kasir/pkg/payment"
 	"POS-kasir/pkg/utils"
@@ -28,6 +30,14 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+var productVariantColumns = []string{
+	"id", "product_id", "name", "sku", "barcode", "price", "cost_price", "stock", "created_at", "updated_at", "deleted_at",
+}
+
+var orderableOptionColumns = []string{
+	"id", "product_id", "name", "additional_price", "is_default", "group_id", "group_name", "min_select", "max_select",
+}
+
 // setupTest creates basic mocks for tests that don't need pgxmock.
 func setupTest(t *testing.T) (*mocks.MockStore, *mocks.MockOrderQuerier, *mocks.MockProductQuerier, *mocks.MockIMidtrans, *mocks.MockIActivityService, *mocks.MockILogger, orders.IOrderService) {
 	ctrl := gomock.NewController(t)
@@ -38,10 +48,36 @@ func setupTest(t *testing.T) (*mocks.MockStore, *mocks.MockOrderQuerier, *mocks.
 	mockActivity := mocks.NewMockIActivityService(ctrl)
 	mockLogger := mocks.NewMockILogger(ctrl)
 
-	service := orders.NewOrderService(mockStore, mockOrderRepo, mockProductRepo, mockMidtrans, mockActivity, mockLogger, nil)
+	service := orders.NewOrderService(mockStore, mockOrderRepo, mockProductRepo, mockMidtrans, mockActivity, newMockTaxSettings(ctrl), mockLogger, nil)
 	return mockStore, mockOrderRepo, mockProductRepo, mockMidtrans, mockActivity, mockLogger, service
 }
 
+// newMockTaxSettings returns a settings service that serves the default tax rules (11% exclusive, no service charge)
+// and the default queue numbering (three digits, no prefix, reset at midnight).
+func newMockTaxSettings(ctrl *gomock.Controller) *mocks.MockISettingsService {
+	mockSettings := mocks.NewMockISettingsService(ctrl)
+	mockSettings.EXPECT().GetTaxSettings(gomock.Any()).Return(&settings.TaxSettingsResponse{
+		TaxEnabled:              true,
+		TaxRate:                 11,
+		TaxOrderTypes:           []string{"dine_in", "takeaway"},
+		ServiceChargeOrderTypes: []string{"dine_in"},
+		ServiceChargeTaxable:    true,
+		RoundingMode:            "round",
+	}, nil).AnyTimes()
+	mockSettings.EXPECT().GetOperationalSettings(gomock.Any()).Return(&settings.OperationalSettingsResponse{
+		Timezone:          "Asia/Jakarta",
+		QueueResetTime:    "00:00",
+		QueueNumberDigits: 3,
+	}, nil).AnyTimes()
+	mockSettings.EXPECT().GetLoyaltySettings(gomock.Any()).Return(&settings.LoyaltySettingsResponse{
+		Enabled:       true,
+		SpendPerPoint: 10000,
+		PointValue:    100,
+		ExpiryDays:    365,
+	}, nil).AnyTimes()
+	return mockSettings
+}
+
 // allowAllLoggerCalls sets up AnyTimes expectations for all logger methods
 // to prevent strict mock failures from variadic argument count mismatches.
 func allowAllLoggerCalls(mockLogger *mocks.MockILogger) {
@@ -84,10 +120,56 @@ func setupTestWithPgxMock(t *testing.T) (pgxmock.PgxPoolIface, *mocks.MockStore,
 		t.Fatalf("failed to create pgxmock pool: %v", err)
 	}
 
-	service := orders.NewOrderService(mockStore, mockOrderRepo, mockProductRepo, mockMidtrans, mockActivity, mockLogger, nil)
+	service := orders.NewOrderService(mockStore, mockOrderRepo, mockProductRepo, mockMidtrans, mockActivity, newMockTaxSettings(ctrl), mockLogger, nil)
 	return mockPgx, mockStore, mockOrderRepo, mockProductRepo, mockMidtrans, mockActivity, mockLogger, service
 }
 
+// expectNoActivePromotions answers the promotion engine's lookup of active promotions with none, so repricing
+// an order leaves its totals alone.
+func expectNoActivePromotions(mockPgx pgxmock.PgxPoolIface) {
+	mockPgx.ExpectQuery("SELECT .* FROM promotions WHERE is_active").
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "name", "description", "scope", "discount_type",
+			"discount_value", "max_discount_amount", "start_date", "end_date",
+			"is_active", "created_at", "updated_at", "deleted_at",
+			"total_redemption_limit", "per_customer_limit", "budget_amount", "buy_quantity", "get_quantity", "bundle_quantity",
+			"auto_apply", "stacking", "priority",
+		}))
+}
+
+// expectNoPromotionSchedule answers the schedule lookups of a promotion that runs all day.
+func expectNoPromotionSchedule(mockPgx pgxmock.PgxPoolIface) {
+	mockPgx.ExpectQuery("SELECT .* FROM promotion_schedules").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "promotion_id", "days_of_week", "start_time", "end_time", "created_at"}))
+	mockPgx.ExpectQuery("SELECT .* FROM promotion_excluded_dates").
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{"promotion_id", "excluded_date"}))
+}
+
+// expectNoOrderPayments answers the lookup of an order's tenders with none, so the order can still be edited.
+func expectNoOrderPayments(mockPgx pgxmock.PgxPoolIface, orderID uuid.UUID) {
+	mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+		WithArgs(orderID).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "order_id", "payment_method_id", "amount", "tendered_amount", "change_amount",
+			"reference_number", "shift_id", "created_by", "created_at",
+		}))
+}
+
+// expectOrderPromotion answers the lookup of an order's promotions with a single manually applied one.
+func expectOrderPromotion(mockPgx pgxmock.PgxPoolIface, orderID, promoID uuid.UUID, discount int64) {
+	mockPgx.ExpectQuery("SELECT .* FROM order_promotions").
+		WithArgs(orderID).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"order_id", "promotion_id", "promotion_code_id", "discount_amount", "auto_applied", "position", "created_at", "schedule_window",
+			"promotion_name", "stacking", "priority", "code",
+		}).AddRow(
+			orderID, promoID, pgtype.UUID{}, discount, false, int32(0), pgtype.Timestamptz{Time: time.Now(), Valid: true}, nil,
+			"Promo", orders_repo.PromotionStackingExclusive, int32(0), nil,
+		))
+}
+
 func TestOrderService_GetOrder(t *testing.T) {
 	_, mockRepo, _, _, _, mockLogger, service := setupTest(t)
 	ctx := context.Background()
@@ -151,10 +233,11 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		"gross_total", "discount_amount", "net_total", "applied_promotion_id",
 		"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
 		"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+		"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
 	}
 
 	// 19-column GetOrderWithDetails row (18 + items)
-	orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items")
+	orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items", "payments", "child_order_ids", "refunds", "status_history")
 
 	makeOrderRow := func(grossTotal, netTotal int64) []interface{} {
 		return []interface{}{
@@ -162,7 +245,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 			orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
 			pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
 			grossTotal, int64(0), netTotal, pgtype.UUID{},
-			nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{},
+			nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
 		}
 	}
 
@@ -190,32 +273,58 @@ func TestOrderService_CreateOrder(t *testing.T) {
 			},
 		)
 
-		// 1. CreateOrder (INSERT INTO orders)
+		// 1. The order takes the next queue number of the business day, then CreateOrder (INSERT INTO orders)
+		mockPgx.ExpectQuery("INSERT INTO order_queue_counters").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"last_number"}).AddRow(int32(7)))
+		queueNumber := "007"
 		mockPgx.ExpectQuery("INSERT INTO orders").
-			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), &queueNumber, pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(0, 0)...))
 
-		// 2. GetProductsForUpdate (SELECT ... FOR UPDATE) — 9 columns: id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price
+		// 1b. The new order starts its status history
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(pgxmock.AnyArg(), orders_repo.NullOrderStatus{}, orders_repo.OrderStatusOpen, pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// 2. GetProductsForUpdate (SELECT ... FOR UPDATE) — 10 columns: id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, sku
 		mockPgx.ExpectQuery("SELECT .* FROM products WHERE id = ANY").
 			WithArgs(pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "name", "image_url", "price", "stock",
-				"created_at", "updated_at", "deleted_at", "cost_price",
+				"created_at", "updated_at", "deleted_at", "cost_price", "sku",
 			}).AddRow(
 				productID, "Test Product", nil, int64(10000), int32(10),
-				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true},
+				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true}, nil,
 			))
 
+		// 2a. The product is not sold in variants and has no options
+		mockPgx.ExpectQuery("SELECT .* FROM product_variants").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(productVariantColumns))
+		mockPgx.ExpectQuery("FROM product_options po").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderableOptionColumns))
+
+		// 2b. The product has no recipe, so it is sold from its own stock
+		mockPgx.ExpectQuery("SELECT .* FROM product_recipe_items").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"product_id", "ingredient_id", "quantity"}))
+
+		itemID := uuid.New()
+
 		// 3. BatchCreateOrderItems (INSERT INTO order_items)
 		mockPgx.ExpectQuery("INSERT INTO order_items").
-			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "order_id", "product_id", "quantity", "price_at_sale",
 				"subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale",
+				"station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id",
 			}).AddRow(
-				uuid.New(), newOrderID, productID, int32(1), int64(10000),
+				itemID, newOrderID, productID, int32(1), int64(10000),
 				int64(10000), int64(0), int64(10000),
 				pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true},
+				nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil,
 			))
 
 		// 4. BatchDecreaseProductStock (UPDATE products)
@@ -225,10 +334,10 @@ func TestOrderService_CreateOrder(t *testing.T) {
 
 		// 5. CreateStockHistory (INSERT INTO stock_history)
 		mockPgx.ExpectQuery("INSERT INTO stock_history").
-			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "product_id", "change_amount", "previous_stock", "current_stock",
-				"change_type", "reference_id", "note", "created_by", "created_at",
+				"change_type", "reference_id", "note", "created_by", "created_at", "variant_id",
 			}).AddRow(
 				uuid.New(), productID, int32(-1), int32(10), int32(9),
 				orders_repo.StockChangeTypeSale,
@@ -236,18 +345,26 @@ func TestOrderService_CreateOrder(t *testing.T) {
 				utils.StringPtr("Order Created"),
 				pgtype.UUID{Bytes: userID, Valid: true},
 				now,
+				nil,
 			))
 
-		// 6. UpdateOrderTotals (UPDATE orders) - takes 7 args: id, gross_total, discount_amount, net_total, tax_amount, service_charge_amount, version
+		// 5b. The sold unit is drawn from the product's oldest cost layer; the store costs at the moving average
+		mockPgx.ExpectQuery("WITH locked AS").
+			WithArgs(productID, int32(1), pgtype.UUID{Bytes: itemID, Valid: true}).
+			WillReturnRows(pgxmock.NewRows([]string{"quantity", "unit_cost"}).
+				AddRow(int32(1), pgtype.Numeric{Int: big.NewInt(4500), Exp: 0, Valid: true}))
+
+		// 6. UpdateOrderTotals (UPDATE orders) - takes 10 args: id, gross_total, discount_amount, net_total, tax_amount, service_charge_amount, version, tax_rate, service_charge_rate, tax_inclusive
 		mockPgx.ExpectQuery("UPDATE orders").
-			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(10000, 10000)...))
+		expectNoActivePromotions(mockPgx)
 
 		// 7. GetOrderWithDetails (SELECT ... FROM orders o WHERE o.id) - Items is nil to bypass JSON unmarshal
 		mockPgx.ExpectQuery("SELECT .* FROM orders o").
 			WithArgs(pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
-				append(makeOrderRow(10000, 10000), nil)...,
+				append(makeOrderRow(10000, 10000), nil, nil, nil, nil, nil)...,
 			))
 
 		// Activity Log (returns nothing)
@@ -264,6 +381,210 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		assert.NoError(t, mockPgx.ExpectationsWereMet())
 	})
 
+	t.Run("InsufficientIngredient", func(t *testing.T) {
+		allowAllLoggerCalls(mockLogger)
+
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		milkID := uuid.New()
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("INSERT INTO order_queue_counters").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"last_number"}).AddRow(int32(8)))
+		mockPgx.ExpectQuery("INSERT INTO orders").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(0, 0)...))
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// A made-to-order product is not limited by its own (empty) stock
+		mockPgx.ExpectQuery("SELECT .* FROM products WHERE id = ANY").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "name", "image_url", "price", "stock",
+				"created_at", "updated_at", "deleted_at", "cost_price", "sku",
+			}).AddRow(
+				productID, "Latte", nil, int64(10000), int32(0),
+				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true}, nil,
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM product_variants").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(productVariantColumns))
+		mockPgx.ExpectQuery("FROM product_options po").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderableOptionColumns))
+		mockPgx.ExpectQuery("SELECT .* FROM product_recipe_items").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"product_id", "ingredient_id", "quantity"}).
+				AddRow(productID, milkID, int32(200)))
+
+		mockPgx.ExpectQuery("INSERT INTO order_items").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "order_id", "product_id", "quantity", "price_at_sale",
+				"subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale",
+				"station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id",
+			}).AddRow(
+				uuid.New(), newOrderID, productID, int32(2), int64(10000),
+				int64(20000), int64(0), int64(20000),
+				pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true},
+				nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil,
+			))
+
+		// Two lattes need 400 ml of milk; only 300 ml is left
+		mockPgx.ExpectQuery("SELECT .* FROM ingredients").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "name", "unit", "stock", "low_stock_threshold", "cost_per_unit",
+				"created_at", "updated_at", "deleted_at",
+			}).AddRow(
+				milkID, "Milk", orders_repo.IngredientUnitMl, int32(300), int32(0), pgtype.Numeric{},
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{},
+			))
+
+		resp, err := service.CreateOrder(ctx, orders.CreateOrderRequest{
+			Type:  orders_repo.OrderTypeDineIn,
+			Items: []orders.CreateOrderItemRequest{{ProductID: productID, Quantity: 2}},
+		})
+
+		assert.ErrorIs(t, err, common.ErrInsufficientIngredient)
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("VariantRequired", func(t *testing.T) {
+		allowAllLoggerCalls(mockLogger)
+
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("INSERT INTO order_queue_counters").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"last_number"}).AddRow(int32(9)))
+		mockPgx.ExpectQuery("INSERT INTO orders").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(0, 0)...))
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// The T-shirt keeps its stock per size, so a line without a size cannot be sold
+		mockPgx.ExpectQuery("SELECT .* FROM products WHERE id = ANY").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "name", "image_url", "price", "stock",
+				"created_at", "updated_at", "deleted_at", "cost_price", "sku",
+			}).AddRow(
+				productID, "T-Shirt", nil, int64(10000), int32(5),
+				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true}, nil,
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM product_variants").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(productVariantColumns).AddRow(
+				uuid.New(), productID, "M", "TS-M", nil, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true}, int32(5),
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{},
+			))
+		mockPgx.ExpectQuery("FROM product_options po").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderableOptionColumns))
+		mockPgx.ExpectQuery("SELECT .* FROM product_recipe_items").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"product_id", "ingredient_id", "quantity"}))
+
+		resp, err := service.CreateOrder(ctx, orders.CreateOrderRequest{
+			Type:  orders_repo.OrderTypeDineIn,
+			Items: []orders.CreateOrderItemRequest{{ProductID: productID, Quantity: 1}},
+		})
+
+		assert.ErrorIs(t, err, common.ErrVariantRequired)
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("InvalidModifiers", func(t *testing.T) {
+		allowAllLoggerCalls(mockLogger)
+
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		sizeGroupID := uuid.New()
+		toppingGroupID := uuid.New()
+		regularID, largeID := uuid.New(), uuid.New()
+		bobaID, jellyID := uuid.New(), uuid.New()
+		one := int32(1)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("INSERT INTO order_queue_counters").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"last_number"}).AddRow(int32(10)))
+		mockPgx.ExpectQuery("INSERT INTO orders").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(0, 0)...))
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPgx.ExpectQuery("SELECT .* FROM products WHERE id = ANY").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "name", "image_url", "price", "stock",
+				"created_at", "updated_at", "deleted_at", "cost_price", "sku",
+			}).AddRow(
+				productID, "Milk Tea", nil, int64(10000), int32(10),
+				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true}, nil,
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM product_variants").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(productVariantColumns))
+
+		// Size: pick exactly 1 (no default); Toppings: up to 1
+		sizeName, toppingName := "Size", "Toppings"
+		mockPgx.ExpectQuery("FROM product_options po").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderableOptionColumns).
+				AddRow(regularID, productID, "Regular", int64(0), false, pgtype.UUID{Bytes: sizeGroupID, Valid: true}, &sizeName, &one, &one).
+				AddRow(largeID, productID, "Large", int64(3000), false, pgtype.UUID{Bytes: sizeGroupID, Valid: true}, &sizeName, &one, &one).
+				AddRow(bobaID, productID, "Boba", int64(2000), false, pgtype.UUID{Bytes: toppingGroupID, Valid: true}, &toppingName, nil, &one).
+				AddRow(jellyID, productID, "Jelly", int64(2000), false, pgtype.UUID{Bytes: toppingGroupID, Valid: true}, &toppingName, nil, &one))
+
+		resp, err := service.CreateOrder(ctx, orders.CreateOrderRequest{
+			Type: orders_repo.OrderTypeDineIn,
+			Items: []orders.CreateOrderItemRequest{{
+				ProductID: productID,
+				Quantity:  1,
+				Options: []orders.CreateOrderItemOptionRequest{
+					{ProductOptionID: bobaID},
+					{ProductOptionID: jellyID},
+				},
+			}},
+		})
+
+		assert.ErrorIs(t, err, common.ErrInvalidModifiers)
+		assert.Nil(t, resp)
+		var me *orders.ModifierError
+		if assert.ErrorAs(t, err, &me) && assert.Len(t, me.Violations, 2) {
+			assert.Equal(t, orders.ModifierTooFew, me.Violations[0].Code)
+			assert.Equal(t, "Size", me.Violations[0].GroupName)
+			assert.Equal(t, 0, me.Violations[0].Selected)
+			assert.Equal(t, orders.ModifierTooMany, me.Violations[1].Code)
+			assert.Equal(t, 2, me.Violations[1].Selected)
+		}
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
 	t.Run("TransactionError", func(t *testing.T) {
 		allowAllLoggerCalls(mockLogger)
 
@@ -305,6 +626,8 @@ func TestOrderService_InitiateMidtransPayment(t *testing.T) {
 		Items:      nil,
 	}
 
+	gatewayMethod := orders_repo.PaymentMethod{ID: 2, Name: "QRIS Dinamis", Kind: orders_repo.PaymentMethodKindGateway, IsActive: true}
+
 	t.Run("Success", func(t *testing.T) {
 		_, mockOrderRepo, _, mockMidtrans, mockActivity, mockLogger, service := setupTest(t)
 		allowAllLoggerCalls(mockLogger)
@@ -313,6 +636,7 @@ func TestOrderService_InitiateMidtransPayment(t *testing.T) {
 
 		// Order has no existing payment reference
 		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(baseOrder, nil)
+		mockOrderRepo.EXPECT().GetActivePaymentMethodByKind(ctx, orders_repo.PaymentMethodKindGateway).Return(gatewayMethod, nil)
 
 		// Midtrans charge succeeds
 		mockMidtrans.EXPECT().CreateQRISCharge(orderID.String(), int64(25000)).Return(&coreapi.ChargeResponse{
@@ -383,6 +707,97 @@ func TestOrderService_InitiateMidtransPayment(t *testing.T) {
 		assert.Contains(t, err.Error(), "db error")
 	})
 
+	t.Run("NoActiveGatewayMethod", func(t *testing.T) {
+		_, mockOrderRepo, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+
+		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(baseOrder, nil)
+		mockOrderRepo.EXPECT().GetActivePaymentMethodByKind(ctx, orders_repo.PaymentMethodKindGateway).Return(orders_repo.PaymentMethod{}, pgx.ErrNoRows)
+
+		resp, err := service.InitiateMidtransPayment(ctx, orderID)
+
+		assert.ErrorIs(t, err, common.ErrPaymentMethodInvalid)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("PromotionRejectsGateway", func(t *testing.T) {
+		_, mockOrderRepo, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+		promoID := uuid.New()
+
+		promotedOrder := baseOrder
+		promotedOrder.AppliedPromotionID = pgtype.UUID{Bytes: promoID, Valid: true}
+
+		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(promotedOrder, nil)
+		mockOrderRepo.EXPECT().GetActivePaymentMethodByKind(ctx, orders_repo.PaymentMethodKindGateway).Return(gatewayMethod, nil)
+		mockOrderRepo.EXPECT().GetOrderPromotions(ctx, orderID).Return([]orders_repo.GetOrderPromotionsRow{
+			{OrderID: orderID, PromotionID: promoID},
+		}, nil)
+		mockOrderRepo.EXPECT().GetPromotionRules(ctx, promoID).Return([]orders_repo.PromotionRule{
+			{PromotionID: promoID, RuleType: orders_repo.PromotionRuleTypeALLOWEDPAYMENTMETHOD, RuleValue: "cash"},
+		}, nil)
+
+		resp, err := service.InitiateMidtransPayment(ctx, orderID)
+
+		assert.ErrorIs(t, err, common.ErrPromotionPaymentMethod)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("PromotionAllowsGatewayByID", func(t *testing.T) {
+		_, mockOrderRepo, _, mockMidtrans, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+		promoID := uuid.New()
+
+		promotedOrder := baseOrder
+		promotedOrder.AppliedPromotionID = pgtype.UUID{Bytes: promoID, Valid: true}
+
+		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(promotedOrder, nil)
+		mockOrderRepo.EXPECT().GetActivePaymentMethodByKind(ctx, orders_repo.PaymentMethodKindGateway).Return(gatewayMethod, nil)
+		mockOrderRepo.EXPECT().GetOrderPromotions(ctx, orderID).Return([]orders_repo.GetOrderPromotionsRow{
+			{OrderID: orderID, PromotionID: promoID},
+		}, nil)
+		mockOrderRepo.EXPECT().GetPromotionRules(ctx, promoID).Return([]orders_repo.PromotionRule{
+			{PromotionID: promoID, RuleType: orders_repo.PromotionRuleTypeALLOWEDPAYMENTMETHOD, RuleValue: "2"},
+		}, nil)
+		mockOrderRepo.EXPECT().GetPromotionByID(ctx, promoID).Return(orders_repo.Promotion{ID: promoID}, nil)
+		mockMidtrans.EXPECT().CreateQRISCharge(orderID.String(), int64(25000)).Return(nil, errors.New("midtrans down"))
+
+		resp, err := service.InitiateMidtransPayment(ctx, orderID)
+
+		// The rule passed, so the request reached the gateway
+		assert.EqualError(t, err, "midtrans down")
+		assert.Nil(t, resp)
+	})
+
+	t.Run("PromotionLimitReached", func(t *testing.T) {
+		_, mockOrderRepo, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+		promoID := uuid.New()
+		limit := int32(100)
+
+		promotedOrder := baseOrder
+		promotedOrder.AppliedPromotionID = pgtype.UUID{Bytes: promoID, Valid: true}
+
+		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(promotedOrder, nil)
+		mockOrderRepo.EXPECT().GetActivePaymentMethodByKind(ctx, orders_repo.PaymentMethodKindGateway).Return(gatewayMethod, nil)
+		mockOrderRepo.EXPECT().GetOrderPromotions(ctx, orderID).Return([]orders_repo.GetOrderPromotionsRow{
+			{OrderID: orderID, PromotionID: promoID},
+		}, nil)
+		mockOrderRepo.EXPECT().GetPromotionRules(ctx, promoID).Return(nil, nil)
+		mockOrderRepo.EXPECT().GetPromotionByID(ctx, promoID).Return(orders_repo.Promotion{ID: promoID, TotalRedemptionLimit: &limit}, nil)
+		mockOrderRepo.EXPECT().GetPromotionRedemptionStats(ctx, gomock.Any()).Return(orders_repo.GetPromotionRedemptionStatsRow{TotalRedemptions: 100}, nil)
+
+		resp, err := service.InitiateMidtransPayment(ctx, orderID)
+
+		// The customer must not be charged for a discount that can no longer be redeemed
+		assert.ErrorIs(t, err, common.ErrPromotionLimitReached)
+		assert.Nil(t, resp)
+	})
+
 	t.Run("CreateQRISChargeError", func(t *testing.T) {
 		_, mockOrderRepo, _, mockMidtrans, _, mockLogger, service := setupTest(t)
 		allowAllLoggerCalls(mockLogger)
@@ -390,6 +805,7 @@ func TestOrderService_InitiateMidtransPayment(t *testing.T) {
 		ctx := context.Background()
 
 		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(baseOrder, nil)
+		mockOrderRepo.EXPECT().GetActivePaymentMethodByKind(ctx, orders_repo.PaymentMethodKindGateway).Return(gatewayMethod, nil)
 		mockMidtrans.EXPECT().CreateQRISCharge(orderID.String(), int64(25000)).Return(nil, errors.New("midtrans unavailable"))
 
 		resp, err := service.InitiateMidtransPayment(ctx, orderID)
@@ -406,6 +822,7 @@ func TestOrderService_InitiateMidtransPayment(t *testing.T) {
 		ctx := context.Background()
 
 		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(baseOrder, nil)
+		mockOrderRepo.EXPECT().GetActivePaymentMethodByKind(ctx, orders_repo.PaymentMethodKindGateway).Return(gatewayMethod, nil)
 		mockMidtrans.EXPECT().CreateQRISCharge(orderID.String(), int64(25000)).Return(&coreapi.ChargeResponse{
 			TransactionID: txnID,
 			OrderID:       orderID.String(),
@@ -427,6 +844,7 @@ func TestOrderService_HandleMidtransNotification(t *testing.T) {
 	userID := uuid.New()
 	now := time.Now()
 	txnID := "midtrans-txn-789"
+	payMethodID := int32(2)
 
 	basePayload := payment.MidtransNotificationPayload{
 		OrderID:           orderID.String(),
@@ -434,47 +852,98 @@ func TestOrderService_HandleMidtransNotification(t *testing.T) {
 		TransactionStatus: "settlement",
 		StatusCode:        "200",
 		SignatureKey:      "valid-signature",
+		GrossAmount:       "25000.00",
 	}
 
-	baseOrder := orders_repo.GetOrderWithDetailsRow{
-		ID:        orderID,
-		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
-		Status:    orders_repo.OrderStatusOpen,
-		CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
-		UpdatedAt: pgtype.Timestamptz{Time: now, Valid: true},
-		Items:     nil,
+	orderColumns := []string{
+		"id", "user_id", "type", "status", "created_at", "updated_at",
+		"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+		"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+		"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+		"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
 	}
-
-	updatedOrder := orders_repo.Order{
-		ID:        orderID,
-		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
-		Status:    orders_repo.OrderStatusPaid,
-		CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
-		UpdatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	paymentMethodColumns := []string{
+		"id", "name", "is_active", "created_at", "updated_at",
+		"kind", "sort_order", "opens_cash_drawer", "requires_reference", "allows_change",
+	}
+	orderPaymentColumns := []string{
+		"id", "order_id", "payment_method_id", "amount", "tendered_amount", "change_amount",
+		"reference_number", "shift_id", "created_by", "created_at",
+	}
+	makeOrderRow := func(status orders_repo.OrderStatus, methodID *int32) []interface{} {
+		return []interface{}{
+			orderID, pgtype.UUID{Bytes: userID, Valid: true},
+			orders_repo.OrderTypeTakeaway, status,
+			pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			int64(25000), int64(0), int64(25000), pgtype.UUID{},
+			methodID, &txnID, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+		}
+	}
+	execTx := func(mockStore *mocks.MockStore, mockPgx pgxmock.PgxPoolIface) {
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+	}
+	expectOrderLock := func(mockPgx pgxmock.PgxPoolIface, status orders_repo.OrderStatus) {
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(status, nil)...))
+	}
+	expectGatewayMethod := func(mockPgx pgxmock.PgxPoolIface) {
+		mockPgx.ExpectQuery("SELECT .* FROM payment_methods").
+			WithArgs(orders_repo.PaymentMethodKindGateway).
+			WillReturnRows(pgxmock.NewRows(paymentMethodColumns).AddRow(
+				payMethodID, "QRIS Dinamis", true, now, now, orders_repo.PaymentMethodKindGateway, int32(2), false, false, false,
+			))
 	}
 
 	t.Run("SettlementSuccess", func(t *testing.T) {
-		_, mockOrderRepo, _, mockMidtrans, mockActivity, mockLogger, service := setupTest(t)
+		mockPgx, mockStore, _, _, mockMidtrans, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
 		allowAllLoggerCalls(mockLogger)
 		ctx := context.Background()
 
-		mockMidtrans.EXPECT().VerifyNotificationSignature(basePayload).Return(nil)
-		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(baseOrder, nil)
-		payMethodID := int32(2)
-		mockOrderRepo.EXPECT().UpdateOrderStatusByGatewayRef(ctx, orders_repo.UpdateOrderStatusByGatewayRefParams{
-			PaymentGatewayReference: &txnID,
-			Status:                  orders_repo.OrderStatusInProgress,
-			PaymentMethodID:         &payMethodID,
-		}).Return(updatedOrder, nil)
+		// Part of the bill was already paid in cash, so the charge was for the rest
+		partialPayload := basePayload
+		partialPayload.GrossAmount = "15000.00"
+
+		mockMidtrans.EXPECT().VerifyNotificationSignature(partialPayload).Return(nil)
+		execTx(mockStore, mockPgx)
+		expectOrderLock(mockPgx, orders_repo.OrderStatusOpen)
+		expectGatewayMethod(mockPgx)
+
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns).AddRow(
+				uuid.New(), orderID, int32(1), int64(10000), int64(10000), int64(0), nil, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(&txnID, orders_repo.OrderStatusInProgress, &payMethodID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusInProgress, &payMethodID)...))
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(orderID, orders_repo.NullOrderStatus{OrderStatus: orders_repo.OrderStatusOpen, Valid: true}, orders_repo.OrderStatusInProgress, pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// The settled gateway charge is recorded as a tender for the amount charged
+		mockPgx.ExpectQuery("INSERT INTO order_payments").
+			WithArgs(orderID, payMethodID, int64(15000), int64(15000), int64(0), &txnID, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns).AddRow(
+				uuid.New(), orderID, payMethodID, int64(15000), int64(15000), int64(0), &txnID, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
 		mockActivity.EXPECT().Log(gomock.Any(), userID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 
-		err := service.HandleMidtransNotification(ctx, basePayload)
+		err := service.HandleMidtransNotification(ctx, partialPayload)
 
 		assert.NoError(t, err)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
 	})
 
 	t.Run("CaptureSuccess", func(t *testing.T) {
-		_, mockOrderRepo, _, mockMidtrans, mockActivity, mockLogger, service := setupTest(t)
+		mockPgx, mockStore, _, _, mockMidtrans, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
 		allowAllLoggerCalls(mockLogger)
 		ctx := context.Background()
 
@@ -482,51 +951,210 @@ func TestOrderService_HandleMidtransNotification(t *testing.T) {
 		capturePayload.TransactionStatus = "capture"
 
 		mockMidtrans.EXPECT().VerifyNotificationSignature(capturePayload).Return(nil)
-		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(baseOrder, nil)
-		mockOrderRepo.EXPECT().UpdateOrderStatusByGatewayRef(ctx, gomock.Any()).Return(updatedOrder, nil)
+		execTx(mockStore, mockPgx)
+		expectOrderLock(mockPgx, orders_repo.OrderStatusOpen)
+		expectGatewayMethod(mockPgx)
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(&txnID, orders_repo.OrderStatusInProgress, &payMethodID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusInProgress, &payMethodID)...))
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPgx.ExpectQuery("INSERT INTO order_payments").
+			WithArgs(orderID, payMethodID, int64(25000), int64(25000), int64(0), &txnID, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns).AddRow(
+				uuid.New(), orderID, payMethodID, int64(25000), int64(25000), int64(0), &txnID, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
 		mockActivity.EXPECT().Log(gomock.Any(), userID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 
 		err := service.HandleMidtransNotification(ctx, capturePayload)
 
 		assert.NoError(t, err)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
 	})
 
-	t.Run("CancelExpire", func(t *testing.T) {
-		_, mockOrderRepo, _, mockMidtrans, mockActivity, mockLogger, service := setupTest(t)
+	t.Run("DuplicateSettlement", func(t *testing.T) {
+		mockPgx, mockStore, _, _, mockMidtrans, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
 		allowAllLoggerCalls(mockLogger)
 		ctx := context.Background()
 
-		cancelPayload := basePayload
-		cancelPayload.TransactionStatus = "expire"
-
-		cancelledOrder := updatedOrder
-		cancelledOrder.Status = orders_repo.OrderStatusCancelled
-
-		mockMidtrans.EXPECT().VerifyNotificationSignature(cancelPayload).Return(nil)
-		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(baseOrder, nil)
-		mockOrderRepo.EXPECT().UpdateOrderStatusByGatewayRef(ctx, gomock.Any()).Return(cancelledOrder, nil)
-		mockActivity.EXPECT().Log(gomock.Any(), userID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+		mockMidtrans.EXPECT().VerifyNotificationSignature(basePayload).Return(nil)
+		execTx(mockStore, mockPgx)
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusInProgress, &payMethodID)...))
+		expectGatewayMethod(mockPgx)
+
+		// The first notification already recorded the gateway tender; nothing is recorded twice
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns).AddRow(
+				uuid.New(), orderID, payMethodID, int64(25000), int64(25000), int64(0), &txnID, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
 
-		err := service.HandleMidtransNotification(ctx, cancelPayload)
+		err := service.HandleMidtransNotification(ctx, basePayload)
 
 		assert.NoError(t, err)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
 	})
 
-	t.Run("SignatureVerificationFailed", func(t *testing.T) {
-		_, _, _, mockMidtrans, _, mockLogger, service := setupTest(t)
+	t.Run("AmountMismatchIsRejected", func(t *testing.T) {
+		mockPgx, mockStore, _, _, mockMidtrans, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
 		allowAllLoggerCalls(mockLogger)
 		ctx := context.Background()
 
-		mockMidtrans.EXPECT().VerifyNotificationSignature(basePayload).Return(errors.New("invalid signature"))
+		// The charge was issued for a bill that has since grown
+		stalePayload := basePayload
+		stalePayload.GrossAmount = "20000.00"
 
-		err := service.HandleMidtransNotification(ctx, basePayload)
+		mockMidtrans.EXPECT().VerifyNotificationSignature(stalePayload).Return(nil)
+		execTx(mockStore, mockPgx)
+		expectOrderLock(mockPgx, orders_repo.OrderStatusOpen)
+		expectGatewayMethod(mockPgx)
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
 
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "signature verification failed")
-	})
+		// Nothing is recorded on the order; the mismatch is left in the activity log
+		mockActivity.EXPECT().Log(gomock.Any(), userID, activitylog_repo.LogActionTypePROCESSPAYMENT, activitylog_repo.LogEntityTypeORDER, orderID.String(), gomock.Any()).
+			Do(func(_ context.Context, _ uuid.UUID, _ activitylog_repo.LogActionType, _ activitylog_repo.LogEntityType, _ string, details map[string]interface{}) {
+				assert.Equal(t, int64(25000), details["balance"])
+				assert.Equal(t, "20000.00", details["gross_amount"])
+			})
 
-	t.Run("InvalidOrderID", func(t *testing.T) {
-		_, _, _, mockMidtrans, _, mockLogger, service := setupTest(t)
+		err := service.HandleMidtransNotification(ctx, stalePayload)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("ExpireClearsPendingCharge", func(t *testing.T) {
+		mockPgx, mockStore, _, _, mockMidtrans, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+
+		expirePayload := basePayload
+		expirePayload.TransactionStatus = "expire"
+
+		mockMidtrans.EXPECT().VerifyNotificationSignature(expirePayload).Return(nil)
+		execTx(mockStore, mockPgx)
+		expectOrderLock(mockPgx, orders_repo.OrderStatusOpen)
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+
+		// The order stays open to be paid another way; only the charge is withdrawn
+		mockPgx.ExpectExec("UPDATE orders").
+			WithArgs(orderID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockActivity.EXPECT().Log(gomock.Any(), userID, activitylog_repo.LogActionTypeUPDATE, activitylog_repo.LogEntityTypeORDER, orderID.String(), gomock.Any()).
+			Do(func(_ context.Context, _ uuid.UUID, _ activitylog_repo.LogActionType, _ activitylog_repo.LogEntityType, _ string, details map[string]interface{}) {
+				assert.Equal(t, orders_repo.OrderStatusOpen, details["status_to"])
+			})
+
+		err := service.HandleMidtransNotification(ctx, expirePayload)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("ExpireIgnoredOnceBalanceIsCovered", func(t *testing.T) {
+		mockPgx, mockStore, _, _, mockMidtrans, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+
+		expirePayload := basePayload
+		expirePayload.TransactionStatus = "expire"
+
+		mockMidtrans.EXPECT().VerifyNotificationSignature(expirePayload).Return(nil)
+		execTx(mockStore, mockPgx)
+		// The customer paid in cash instead and the order is already being prepared
+		expectOrderLock(mockPgx, orders_repo.OrderStatusInProgress)
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns).AddRow(
+				uuid.New(), orderID, int32(1), int64(25000), int64(30000), int64(5000), nil, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+
+		err := service.HandleMidtransNotification(ctx, expirePayload)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("FailureForAnotherChargeIgnored", func(t *testing.T) {
+		mockPgx, mockStore, _, _, mockMidtrans, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+
+		// The order has since been charged again under a new transaction
+		stalePayload := basePayload
+		stalePayload.TransactionStatus = "deny"
+		stalePayload.TransactionID = "midtrans-txn-old"
+
+		mockMidtrans.EXPECT().VerifyNotificationSignature(stalePayload).Return(nil)
+		execTx(mockStore, mockPgx)
+		expectOrderLock(mockPgx, orders_repo.OrderStatusOpen)
+
+		err := service.HandleMidtransNotification(ctx, stalePayload)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("OrderWithoutCashier", func(t *testing.T) {
+		mockPgx, mockStore, _, _, mockMidtrans, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+
+		cancelPayload := basePayload
+		cancelPayload.TransactionStatus = "cancel"
+
+		mockMidtrans.EXPECT().VerifyNotificationSignature(cancelPayload).Return(nil)
+		execTx(mockStore, mockPgx)
+		row := makeOrderRow(orders_repo.OrderStatusOpen, nil)
+		row[1] = pgtype.UUID{}
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(row...))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+		mockPgx.ExpectExec("UPDATE orders").
+			WithArgs(orderID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockActivity.EXPECT().Log(gomock.Any(), uuid.Nil, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+		err := service.HandleMidtransNotification(ctx, cancelPayload)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("SignatureVerificationFailed", func(t *testing.T) {
+		_, _, _, mockMidtrans, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+
+		mockMidtrans.EXPECT().VerifyNotificationSignature(basePayload).Return(errors.New("invalid signature"))
+
+		err := service.HandleMidtransNotification(ctx, basePayload)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "signature verification failed")
+	})
+
+	t.Run("InvalidOrderID", func(t *testing.T) {
+		_, _, _, mockMidtrans, _, mockLogger, service := setupTest(t)
 		allowAllLoggerCalls(mockLogger)
 		ctx := context.Background()
 
@@ -541,12 +1169,16 @@ func TestOrderService_HandleMidtransNotification(t *testing.T) {
 	})
 
 	t.Run("OrderNotFound", func(t *testing.T) {
-		_, mockOrderRepo, _, mockMidtrans, _, mockLogger, service := setupTest(t)
+		mockPgx, mockStore, _, _, mockMidtrans, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
 		allowAllLoggerCalls(mockLogger)
 		ctx := context.Background()
 
 		mockMidtrans.EXPECT().VerifyNotificationSignature(basePayload).Return(nil)
-		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(orders_repo.GetOrderWithDetailsRow{}, pgx.ErrNoRows)
+		execTx(mockStore, mockPgx)
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnError(pgx.ErrNoRows)
 
 		err := service.HandleMidtransNotification(ctx, basePayload)
 
@@ -554,23 +1186,24 @@ func TestOrderService_HandleMidtransNotification(t *testing.T) {
 	})
 
 	t.Run("AlreadyFinalizedOrder", func(t *testing.T) {
-		_, mockOrderRepo, _, mockMidtrans, _, mockLogger, service := setupTest(t)
+		mockPgx, mockStore, _, _, mockMidtrans, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
 		allowAllLoggerCalls(mockLogger)
 		ctx := context.Background()
 
-		paidOrder := baseOrder
-		paidOrder.Status = orders_repo.OrderStatusPaid
-
 		mockMidtrans.EXPECT().VerifyNotificationSignature(basePayload).Return(nil)
-		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(paidOrder, nil)
+		execTx(mockStore, mockPgx)
+		expectOrderLock(mockPgx, orders_repo.OrderStatusPaid)
 
 		err := service.HandleMidtransNotification(ctx, basePayload)
 
 		assert.NoError(t, err) // Should return nil (idempotent)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
 	})
 
 	t.Run("UnknownStatusIgnored", func(t *testing.T) {
-		_, mockOrderRepo, _, mockMidtrans, _, mockLogger, service := setupTest(t)
+		mockPgx, mockStore, _, _, mockMidtrans, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
 		allowAllLoggerCalls(mockLogger)
 		ctx := context.Background()
 
@@ -578,21 +1211,31 @@ func TestOrderService_HandleMidtransNotification(t *testing.T) {
 		pendingPayload.TransactionStatus = "pending"
 
 		mockMidtrans.EXPECT().VerifyNotificationSignature(pendingPayload).Return(nil)
-		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(baseOrder, nil)
+		execTx(mockStore, mockPgx)
+		expectOrderLock(mockPgx, orders_repo.OrderStatusOpen)
 
 		err := service.HandleMidtransNotification(ctx, pendingPayload)
 
 		assert.NoError(t, err) // Should return nil (ignored)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
 	})
 
 	t.Run("UpdateStatusError", func(t *testing.T) {
-		_, mockOrderRepo, _, mockMidtrans, _, mockLogger, service := setupTest(t)
+		mockPgx, mockStore, _, _, mockMidtrans, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
 		allowAllLoggerCalls(mockLogger)
 		ctx := context.Background()
 
 		mockMidtrans.EXPECT().VerifyNotificationSignature(basePayload).Return(nil)
-		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(baseOrder, nil)
-		mockOrderRepo.EXPECT().UpdateOrderStatusByGatewayRef(ctx, gomock.Any()).Return(orders_repo.Order{}, errors.New("db error"))
+		execTx(mockStore, mockPgx)
+		expectOrderLock(mockPgx, orders_repo.OrderStatusOpen)
+		expectGatewayMethod(mockPgx)
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnError(errors.New("db error"))
 
 		err := service.HandleMidtransNotification(ctx, basePayload)
 
@@ -601,6 +1244,39 @@ func TestOrderService_HandleMidtransNotification(t *testing.T) {
 	})
 }
 
+func TestOrderService_GetCallingBoard(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		_, mockOrderRepo, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+
+		queueNumber := "004"
+		mockOrderRepo.EXPECT().ListCallingBoardOrders(ctx, gomock.Any()).Return([]orders_repo.ListCallingBoardOrdersRow{
+			{ID: uuid.New(), QueueNumber: &queueNumber, Type: orders_repo.OrderTypeTakeaway, TotalItems: 2, ReadyItems: 2},
+		}, nil)
+
+		resp, err := service.GetCallingBoard(ctx)
+
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Preparing)
+		assert.Len(t, resp.Ready, 1)
+		assert.Equal(t, "004", resp.Ready[0].QueueNumber)
+	})
+
+	t.Run("RepoError", func(t *testing.T) {
+		_, mockOrderRepo, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+
+		mockOrderRepo.EXPECT().ListCallingBoardOrders(ctx, gomock.Any()).Return(nil, errors.New("db error"))
+
+		resp, err := service.GetCallingBoard(ctx)
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+	})
+}
+
 func TestOrderService_ListOrders(t *testing.T) {
 	orderID := uuid.New()
 	userID := uuid.New()
@@ -624,6 +1300,7 @@ func TestOrderService_ListOrders(t *testing.T) {
 		// ListOrders and CountOrders are called concurrently via goroutines,
 		// so we use gomock.Any() for context matching
 		payMethodID := int32(1)
+		queueNumber := "012"
 		mockOrderRepo.EXPECT().ListOrders(gomock.Any(), gomock.Any()).Return([]orders_repo.ListOrdersRow{
 			{
 				ID:              orderID,
@@ -634,6 +1311,7 @@ func TestOrderService_ListOrders(t *testing.T) {
 				NetTotal:        15000,
 				CreatedAt:       pgtype.Timestamptz{Time: now, Valid: true},
 				PaymentMethodID: &payMethodID,
+				QueueNumber:     &queueNumber,
 			},
 		}, nil)
 		mockOrderRepo.EXPECT().CountOrders(gomock.Any(), gomock.Any()).Return(int64(1), nil)
@@ -667,6 +1345,7 @@ func TestOrderService_ListOrders(t *testing.T) {
 		assert.Equal(t, orders_repo.OrderStatusOpen, resp.Orders[0].Status)
 		assert.Equal(t, int64(15000), resp.Orders[0].NetTotal)
 		assert.True(t, resp.Orders[0].IsPaid)
+		assert.Equal(t, "012", resp.Orders[0].QueueNumber)
 		assert.Len(t, resp.Orders[0].Items, 1)
 		assert.Equal(t, "Nasi Goreng", resp.Orders[0].Items[0].ProductName)
 		assert.Equal(t, 1, resp.Pagination.CurrentPage)
@@ -751,18 +1430,19 @@ func TestOrderService_CancelOrder(t *testing.T) {
 			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
 			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
 			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
 		}
-		orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items")
+		orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items", "payments", "child_order_ids", "refunds", "status_history")
 		now := time.Now()
 
 		productID := uuid.New()
 		itemID := uuid.New()
 
-		// Build items JSON for GetOrderWithDetails to return
-		items := []orders_repo.OrderItem{
-			{ID: itemID, OrderID: orderID, ProductID: productID, Quantity: 2, PriceAtSale: 10000, Subtotal: 20000},
+		// pgx hands the aggregated items back decoded, with ids as strings; the lines to restock are read from
+		// order_items instead
+		items := []interface{}{
+			map[string]interface{}{"id": itemID.String(), "product_id": productID.String(), "quantity": float64(2), "variant_id": nil},
 		}
-		itemsJSON, _ := json.Marshal(items)
 
 		// ExecTx: execute callback with pgxmock
 		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -779,8 +1459,8 @@ func TestOrderService_CancelOrder(t *testing.T) {
 				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
 				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
 				int64(20000), int64(0), int64(20000), pgtype.UUID{},
-				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{},
-				itemsJSON,
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+				items, nil, nil, nil, nil,
 			))
 
 		// 2. CancelOrder (UPDATE orders SET status='cancelled')
@@ -791,38 +1471,63 @@ func TestOrderService_CancelOrder(t *testing.T) {
 				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusCancelled,
 				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
 				int64(20000), int64(0), int64(20000), pgtype.UUID{},
-				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{},
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			))
+
+		// 2b. The cancellation is recorded in the status history
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(orderID, orders_repo.NullOrderStatus{OrderStatus: orders_repo.OrderStatusOpen, Valid: true}, orders_repo.OrderStatusCancelled, pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// 2c. Order lines and their options are checked against recipes; none are made to order
+		mockPgx.ExpectQuery("SELECT .* FROM order_items WHERE order_id").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "order_id", "product_id", "quantity", "price_at_sale",
+				"subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale",
+				"station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id",
+			}).AddRow(
+				itemID, orderID, productID, int32(2), int64(10000),
+				int64(20000), int64(0), int64(20000),
+				pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true},
+				nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil,
 			))
+		mockPgx.ExpectQuery("FROM order_item_options").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{"order_item_id", "product_option_id"}))
+		mockPgx.ExpectQuery("SELECT .* FROM product_recipe_items").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"product_id", "ingredient_id", "quantity"}))
 
 		// 3. For each item: GetProductByID (from products_repo.New(tx) — 11 cols: id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, options, categories)
 		mockPgx.ExpectQuery("SELECT .* FROM products p WHERE").
 			WithArgs(pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "name", "image_url", "price", "stock",
-				"created_at", "updated_at", "deleted_at", "cost_price", "options", "categories",
+				"created_at", "updated_at", "deleted_at", "cost_price", "sku", "options", "categories",
 			}).AddRow(
 				productID, "Test Product", nil, int64(10000), int32(8),
-				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true},
+				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true}, nil,
 				nil, nil,
 			))
 
-		// 4. AddProductStock (from products_repo.New(tx) — 9 cols: id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price)
+		// 4. AddProductStock (from products_repo.New(tx) — 10 cols: id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, sku)
 		mockPgx.ExpectQuery("UPDATE products").
 			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "name", "image_url", "price", "stock",
-				"created_at", "updated_at", "deleted_at", "cost_price",
+				"created_at", "updated_at", "deleted_at", "cost_price", "sku",
 			}).AddRow(
 				productID, "Test Product", nil, int64(10000), int32(10),
-				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true},
+				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true}, nil,
 			))
 
 		// 5. CreateStockHistory
 		mockPgx.ExpectQuery("INSERT INTO stock_history").
-			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "product_id", "change_amount", "previous_stock", "current_stock",
-				"change_type", "reference_id", "note", "created_by", "created_at",
+				"change_type", "reference_id", "note", "created_by", "created_at", "variant_id",
 			}).AddRow(
 				uuid.New(), productID, int32(2), int32(8), int32(10),
 				orders_repo.StockChangeTypeReturn,
@@ -830,8 +1535,18 @@ func TestOrderService_CancelOrder(t *testing.T) {
 				utils.StringPtr("Order Cancelled"),
 				pgtype.UUID{Bytes: userID, Valid: true},
 				now,
+				nil,
 			))
 
+		// 6. The units go back into the cost layers they were drawn from and the average cost absorbs them
+		mockPgx.ExpectQuery("WITH consumed AS").
+			WithArgs(itemID, int32(2)).
+			WillReturnRows(pgxmock.NewRows([]string{"quantity", "unit_cost"}).
+				AddRow(int32(2), pgtype.Numeric{Int: big.NewInt(4500), Exp: 0, Valid: true}))
+		mockPgx.ExpectExec("UPDATE products SET cost_price").
+			WithArgs(int32(8), int32(2), pgxmock.AnyArg(), productID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
 		// Activity log after successful cancellation
 		mockActivity.EXPECT().Log(
 			gomock.Any(),
@@ -915,15 +1630,16 @@ func TestOrderService_UpdateOrderItems(t *testing.T) {
 			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
 			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
 			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
 		}
-		orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items")
+		orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items", "payments", "child_order_ids", "refunds", "status_history")
 
 		existingItemID := uuid.New()
 
 		// Build items: marshal OrderItem to JSON, then unmarshal to []interface{}
 		// This matches how pgx scans JSON columns into interface{}
 		rawItems := []orders_repo.OrderItem{
-			{ID: existingItemID, OrderID: orderID, ProductID: productID, Quantity: 3, PriceAtSale: 10000, Subtotal: 30000, DiscountAmount: 0, NetSubtotal: 30000, CostPriceAtSale: pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true}},
+			{ID: existingItemID, OrderID: orderID, ProductID: productID, Quantity: 3, PriceAtSale: 12000, Subtotal: 36000, DiscountAmount: 0, NetSubtotal: 36000, CostPriceAtSale: pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true}},
 		}
 		rawJSON, _ := json.Marshal(rawItems)
 		var itemsForPgxMock interface{}
@@ -935,7 +1651,7 @@ func TestOrderService_UpdateOrderItems(t *testing.T) {
 				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
 				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
 				grossTotal, int64(0), netTotal, pgtype.UUID{},
-				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{},
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
 			}
 		}
 
@@ -950,47 +1666,63 @@ func TestOrderService_UpdateOrderItems(t *testing.T) {
 		mockPgx.ExpectQuery("SELECT .* FROM orders").
 			WithArgs(pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(10000, 10000)...))
+		expectNoOrderPayments(mockPgx, orderID)
 
-		// 2. GetOrderItemsByOrderID - returns existing item (same product, qty=1)
+		// 2. GetOrderItemsByOrderID - returns existing item (same product, qty=1), rung up at 12000 with a 2000 option
 		mockPgx.ExpectQuery("SELECT .* FROM order_items WHERE order_id").
 			WithArgs(pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "order_id", "product_id", "quantity", "price_at_sale",
 				"subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale",
+				"station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id",
 			}).AddRow(
-				existingItemID, orderID, productID, int32(1), int64(10000),
-				int64(10000), int64(0), int64(10000),
+				existingItemID, orderID, productID, int32(1), int64(12000),
+				int64(12000), int64(0), int64(12000),
 				pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true},
+				nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil,
 			))
 
-		// 3. GetProductByID (from products_repo.New(tx) via orders_repo — 9 cols from orders_repo.GetProductByID)
+		// 2a. The product is not sold in variants
+		mockPgx.ExpectQuery("SELECT .* FROM product_variants").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(productVariantColumns))
+
+		// 2b. Options and recipes of the lines; the product has no recipe
+		mockPgx.ExpectQuery("FROM order_item_options").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{"order_item_id", "product_option_id"}))
+		mockPgx.ExpectQuery("SELECT .* FROM product_recipe_items").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"product_id", "ingredient_id", "quantity"}))
+
+		// 3. GetProductByID (from products_repo.New(tx) via orders_repo — 10 cols from orders_repo.GetProductByID)
 		mockPgx.ExpectQuery("SELECT .* FROM products WHERE id").
 			WithArgs(pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "name", "image_url", "price", "stock",
-				"created_at", "updated_at", "deleted_at", "cost_price",
+				"created_at", "updated_at", "deleted_at", "cost_price", "sku",
 			}).AddRow(
 				productID, "Test Product", nil, int64(10000), int32(10),
-				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true},
+				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true}, nil,
 			))
 
-		// 4. qtyDiff=2 (3-1) > 0 → DecreaseProductStock (products_repo — 9 cols)
+		// 4. qtyDiff=2 (3-1) > 0 → DecreaseProductStock (products_repo — 10 cols)
 		mockPgx.ExpectQuery("UPDATE products").
 			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "name", "image_url", "price", "stock",
-				"created_at", "updated_at", "deleted_at", "cost_price",
+				"created_at", "updated_at", "deleted_at", "cost_price", "sku",
 			}).AddRow(
 				productID, "Test Product", nil, int64(10000), int32(8),
-				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true},
+				now, now, nil, pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true}, nil,
 			))
 
 		// 5. CreateStockHistory
 		mockPgx.ExpectQuery("INSERT INTO stock_history").
-			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "product_id", "change_amount", "previous_stock", "current_stock",
-				"change_type", "reference_id", "note", "created_by", "created_at",
+				"change_type", "reference_id", "note", "created_by", "created_at", "variant_id",
 			}).AddRow(
 				uuid.New(), productID, int32(-2), int32(10), int32(8),
 				orders_repo.StockChangeTypeSale,
@@ -998,30 +1730,41 @@ func TestOrderService_UpdateOrderItems(t *testing.T) {
 				utils.StringPtr("Order Item Qty Increase"),
 				pgtype.UUID{Bytes: userID, Valid: true},
 				now,
+				nil,
 			))
 
-		// 6. UpdateOrderItemQuantity
+		// 5b. The two extra units are drawn from the product's cost layers against the existing line
+		mockPgx.ExpectQuery("WITH locked AS").
+			WithArgs(productID, int32(2), pgtype.UUID{Bytes: existingItemID, Valid: true}).
+			WillReturnRows(pgxmock.NewRows([]string{"quantity", "unit_cost"}).
+				AddRow(int32(2), pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true}))
+
+		// 6. UpdateOrderItemQuantity — the line keeps its own unit price rather than the product's
 		mockPgx.ExpectQuery("UPDATE order_items").
-			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WithArgs(existingItemID, orderID, int32(3), int64(36000), int64(36000)).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "order_id", "product_id", "quantity", "price_at_sale",
 				"subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale",
+				"station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id",
 			}).AddRow(
-				existingItemID, orderID, productID, int32(3), int64(10000),
-				int64(30000), int64(0), int64(30000),
+				existingItemID, orderID, productID, int32(3), int64(12000),
+				int64(36000), int64(0), int64(36000),
 				pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true},
+				nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil,
 			))
 
-		// 7. UpdateOrderTotals — 7 args: id, gross_total, discount_amount, net_total, tax_amount, service_charge_amount, version
+		// 7. UpdateOrderTotals — 10 args: id, gross_total, discount_amount, net_total, tax_amount, service_charge_amount, version, tax_rate, service_charge_rate, tax_inclusive
+		// Default rules: 11% exclusive tax on 36000 gross -> 3960 tax, 39960 net.
 		mockPgx.ExpectQuery("UPDATE orders").
-			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
-			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(30000, 30000)...))
+			WithArgs(pgxmock.AnyArg(), int64(36000), int64(0), int64(39960), int64(3960), int64(0), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), false).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(36000, 39960)...))
+		expectNoActivePromotions(mockPgx)
 
 		// 8. GetOrderWithDetails (final, with items for buildOrderDetailResponseFromQueryResult)
 		mockPgx.ExpectQuery("SELECT .* FROM orders o").
 			WithArgs(pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
-				append(makeOrderRow(30000, 30000), itemsForPgxMock)...,
+				append(makeOrderRow(36000, 39960), itemsForPgxMock, nil, nil, nil, nil)...,
 			))
 
 		// Activity log
@@ -1094,6 +1837,102 @@ func TestOrderService_UpdateOrderItems(t *testing.T) {
 		assert.ErrorIs(t, err, common.ErrOrderNotModifiable)
 		assert.Nil(t, resp)
 	})
+
+	t.Run("TenderTaken", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		now := time.Now()
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		// The order is still open, but part of the bill has been paid
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "user_id", "type", "status", "created_at", "updated_at",
+				"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+				"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+				"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+				"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+			}).AddRow(
+				orderID, pgtype.UUID{Bytes: userID, Valid: true},
+				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+				int64(30000), int64(0), int64(30000), pgtype.UUID{},
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "order_id", "payment_method_id", "amount", "tendered_amount", "change_amount",
+				"reference_number", "shift_id", "created_by", "created_at",
+			}).AddRow(
+				uuid.New(), orderID, int32(1), int64(10000), int64(10000), int64(0), nil, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+
+		resp, err := service.UpdateOrderItems(ctx, orderID, orders.UpdateOrderItemsRequest{
+			Version: 1,
+			Items:   reqs,
+		})
+
+		assert.ErrorIs(t, err, common.ErrOrderNotModifiable)
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("QRISChargePending", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		now := time.Now()
+		txnID := "midtrans-txn-1"
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		// A QRIS charge was issued for the current total and has not settled yet
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "user_id", "type", "status", "created_at", "updated_at",
+				"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+				"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+				"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+				"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+			}).AddRow(
+				orderID, pgtype.UUID{Bytes: userID, Valid: true},
+				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+				int64(30000), int64(0), int64(30000), pgtype.UUID{},
+				nil, &txnID, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "order_id", "payment_method_id", "amount", "tendered_amount", "change_amount",
+				"reference_number", "shift_id", "created_by", "created_at",
+			}))
+
+		resp, err := service.UpdateOrderItems(ctx, orderID, orders.UpdateOrderItemsRequest{
+			Version: 1,
+			Items:   reqs,
+		})
+
+		assert.ErrorIs(t, err, common.ErrOrderNotModifiable)
+		assert.ErrorContains(t, err, "QRIS payment is pending")
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
 }
 
 func TestOrderService_ConfirmManualPayment(t *testing.T) {
@@ -1105,6 +1944,15 @@ func TestOrderService_ConfirmManualPayment(t *testing.T) {
 		CashReceived:    50000,
 	}
 
+	paymentMethodColumns := []string{
+		"id", "name", "is_active", "created_at", "updated_at",
+		"kind", "sort_order", "opens_cash_drawer", "requires_reference", "allows_change",
+	}
+	orderPaymentColumns := []string{
+		"id", "order_id", "payment_method_id", "amount", "tendered_amount", "change_amount",
+		"reference_number", "shift_id", "created_by", "created_at",
+	}
+
 	t.Run("Success", func(t *testing.T) {
 		mockPgx, mockStore, _, mockProductRepo, _, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
 		defer mockPgx.Close()
@@ -1117,8 +1965,9 @@ func TestOrderService_ConfirmManualPayment(t *testing.T) {
 			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
 			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
 			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
 		}
-		orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items")
+		orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items", "payments", "child_order_ids", "refunds", "status_history")
 
 		productID := uuid.New()
 		paymentMethodID := int32(1)
@@ -1139,7 +1988,7 @@ func TestOrderService_ConfirmManualPayment(t *testing.T) {
 				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
 				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
 				int64(40000), int64(0), int64(40000), pgtype.UUID{},
-				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{},
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
 			}
 		}
 
@@ -1149,7 +1998,7 @@ func TestOrderService_ConfirmManualPayment(t *testing.T) {
 				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusPaid,
 				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
 				int64(40000), int64(0), int64(40000), pgtype.UUID{},
-				&paymentMethodID, nil, &cashReceived, &changeDue, nil, nil, nil, nil, int32(2), int64(0), int64(0), pgtype.UUID{},
+				&paymentMethodID, nil, &cashReceived, &changeDue, nil, nil, nil, nil, int32(2), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
 			}
 		}
 
@@ -1165,16 +2014,40 @@ func TestOrderService_ConfirmManualPayment(t *testing.T) {
 			WithArgs(pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow()...))
 
-		// 2. UpdateOrderManualPayment — 5 args: id, payment_method_id, cash_received, change_due, version
+		// 2. GetPaymentMethodByID — cash allows change
+		mockPgx.ExpectQuery("SELECT .* FROM payment_methods").
+			WithArgs(paymentMethodID).
+			WillReturnRows(pgxmock.NewRows(paymentMethodColumns).AddRow(
+				paymentMethodID, "Cash", true, now, now, orders_repo.PaymentMethodKindCash, int32(1), true, false, true,
+			))
+
+		// 3. ListOrderPayments — no earlier tenders
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+
+		// 4. CreateOrderPayment — single tender covering the whole bill
+		mockPgx.ExpectQuery("INSERT INTO order_payments").
+			WithArgs(orderID, paymentMethodID, int64(40000), cashReceived, changeDue, (*string)(nil), pgtype.UUID{Bytes: userID, Valid: true}, pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns).AddRow(
+				uuid.New(), orderID, paymentMethodID, int64(40000), cashReceived, changeDue, nil, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+
+		// 5. UpdateOrderManualPayment — 5 args: id, payment_method_id, cash_received, change_due, version
 		mockPgx.ExpectQuery("UPDATE orders").
-			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WithArgs(orderID, &paymentMethodID, &cashReceived, &changeDue, int32(0)).
 			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makePaidOrderRow()...))
 
-		// 3. GetOrderWithDetails (final, with items)
+		// 5b. Settling the open order moves it on, which lands in the status history
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(orderID, orders_repo.NullOrderStatus{OrderStatus: orders_repo.OrderStatusOpen, Valid: true}, pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// 6. GetOrderWithDetails (final, with items)
 		mockPgx.ExpectQuery("SELECT .* FROM orders o").
 			WithArgs(pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
-				append(makePaidOrderRow(), itemsForPgxMock)...,
+				append(makePaidOrderRow(), itemsForPgxMock, nil, nil, nil, nil)...,
 			))
 
 		// Activity log after successful payment
@@ -1240,121 +2113,1202 @@ func TestOrderService_ConfirmManualPayment(t *testing.T) {
 		assert.ErrorIs(t, err, common.ErrOrderNotModifiable)
 		assert.Nil(t, resp)
 	})
-}
-
-func TestOrderService_UpdateOperationalStatus(t *testing.T) {
-	orderID := uuid.New()
-	userID := uuid.New()
-	now := time.Now()
 
-	t.Run("Success", func(t *testing.T) {
-		_, mockOrderRepo, _, _, mockActivity, mockLogger, service := setupTest(t)
+	t.Run("PendingChargeIsCancelledFirst", func(t *testing.T) {
+		mockPgx, mockStore, _, _, mockMidtrans, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
 		allowAllLoggerCalls(mockLogger)
 		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		now := time.Now()
+		txnID := "midtrans-txn-1"
 
-		req := orders.UpdateOrderStatusRequest{
-			Status: orders_repo.OrderStatusInProgress,
+		orderColumns := []string{
+			"id", "user_id", "type", "status", "created_at", "updated_at",
+			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
 		}
 
-		// GetOrderWithDetails for status check
-		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(orders_repo.GetOrderWithDetailsRow{
-			ID:        orderID,
-			UserID:    pgtype.UUID{Bytes: userID, Valid: true},
-			Status:    orders_repo.OrderStatusOpen,
-			CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
-			UpdatedAt: pgtype.Timestamptz{Time: now, Valid: true},
-			Items:     nil,
-		}, nil)
-
-		// UpdateOrderStatus
-		mockOrderRepo.EXPECT().UpdateOrderStatus(ctx, orders_repo.UpdateOrderStatusParams{
-			ID:     orderID,
-			Status: orders_repo.OrderStatusInProgress,
-		}).Return(orders_repo.Order{}, nil)
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
 
-		// Activity log
-		mockActivity.EXPECT().Log(gomock.Any(), userID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+		// The customer was shown a QR code but pays in cash instead
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(
+				orderID, pgtype.UUID{Bytes: userID, Valid: true},
+				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+				int64(40000), int64(0), int64(40000), pgtype.UUID{},
+				nil, &txnID, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM payment_methods").
+			WithArgs(int32(1)).
+			WillReturnRows(pgxmock.NewRows(paymentMethodColumns).AddRow(
+				int32(1), "Cash", true, now, now, orders_repo.PaymentMethodKindCash, int32(1), true, false, true,
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
 
-		// GetOrder (called by s.GetOrder at end) — returns the updated order
-		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(orders_repo.GetOrderWithDetailsRow{
-			ID:        orderID,
-			UserID:    pgtype.UUID{Bytes: userID, Valid: true},
-			Status:    orders_repo.OrderStatusInProgress,
-			CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
-			UpdatedAt: pgtype.Timestamptz{Time: now, Valid: true},
-			Items:     nil,
-		}, nil)
+		// The charge could not be withdrawn, so the cash is not taken either
+		mockMidtrans.EXPECT().CancelTransaction(orderID.String()).Return(nil, errors.New("gateway unavailable"))
 
-		resp, err := service.UpdateOperationalStatus(ctx, orderID, req)
+		resp, err := service.ConfirmManualPayment(ctx, orderID, req)
 
-		assert.NoError(t, err)
-		assert.NotNil(t, resp)
-		assert.Equal(t, orders_repo.OrderStatusInProgress, resp.Status)
+		assert.ErrorContains(t, err, "failed to cancel payment gateway transaction")
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
 	})
 
-	t.Run("OrderNotFound", func(t *testing.T) {
-		_, mockOrderRepo, _, _, _, mockLogger, service := setupTest(t)
+	t.Run("ReferenceRequired", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
 		allowAllLoggerCalls(mockLogger)
-		ctx := context.Background()
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		now := time.Now()
 
-		req := orders.UpdateOrderStatusRequest{
-			Status: orders_repo.OrderStatusInProgress,
-		}
+		orderColumns := []string{
+			"id", "user_id", "type", "status", "created_at", "updated_at",
+			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+		}
+		cardID := int32(4)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(
+				orderID, pgtype.UUID{Bytes: userID, Valid: true},
+				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+				int64(40000), int64(0), int64(40000), pgtype.UUID{},
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM payment_methods").
+			WithArgs(cardID).
+			WillReturnRows(pgxmock.NewRows(paymentMethodColumns).AddRow(
+				cardID, "Debit Card", true, now, now, orders_repo.PaymentMethodKindCard, int32(4), false, true, false,
+			))
+
+		resp, err := service.ConfirmManualPayment(ctx, orderID, orders.ConfirmManualPaymentRequest{PaymentMethodID: cardID, Version: 1})
+
+		assert.ErrorIs(t, err, common.ErrPaymentReferenceMissing)
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("GatewayMethodRejected", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		now := time.Now()
+
+		orderColumns := []string{
+			"id", "user_id", "type", "status", "created_at", "updated_at",
+			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+		}
+		gatewayID := int32(2)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(
+				orderID, pgtype.UUID{Bytes: userID, Valid: true},
+				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+				int64(40000), int64(0), int64(40000), pgtype.UUID{},
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM payment_methods").
+			WithArgs(gatewayID).
+			WillReturnRows(pgxmock.NewRows(paymentMethodColumns).AddRow(
+				gatewayID, "QRIS Dinamis", true, now, now, orders_repo.PaymentMethodKindGateway, int32(2), false, false, false,
+			))
+
+		resp, err := service.ConfirmManualPayment(ctx, orderID, orders.ConfirmManualPaymentRequest{PaymentMethodID: gatewayID, Version: 1})
+
+		assert.ErrorIs(t, err, common.ErrPaymentMethodInvalid)
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("PromotionRejectsPaymentMethod", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		now := time.Now()
+		promoID := uuid.New()
+
+		orderColumns := []string{
+			"id", "user_id", "type", "status", "created_at", "updated_at",
+			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+		}
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(
+				orderID, pgtype.UUID{Bytes: userID, Valid: true},
+				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+				int64(40000), int64(4000), int64(36000), pgtype.UUID{Bytes: promoID, Valid: true},
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM payment_methods").
+			WithArgs(int32(1)).
+			WillReturnRows(pgxmock.NewRows(paymentMethodColumns).AddRow(
+				int32(1), "Cash", true, now, now, orders_repo.PaymentMethodKindCash, int32(1), true, false, true,
+			))
+		expectOrderPromotion(mockPgx, orderID, promoID, 4000)
+		mockPgx.ExpectQuery("SELECT .* FROM promotion_rules WHERE promotion_id").
+			WithArgs(promoID).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "promotion_id", "rule_type", "rule_value", "description", "created_at", "updated_at",
+			}).AddRow(
+				uuid.New(), promoID, orders_repo.PromotionRuleTypeALLOWEDPAYMENTMETHOD, "QRIS, 3", nil,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+
+		resp, err := service.ConfirmManualPayment(ctx, orderID, orders.ConfirmManualPaymentRequest{PaymentMethodID: 1, CashReceived: 40000, Version: 1})
+
+		assert.ErrorIs(t, err, common.ErrPromotionPaymentMethod)
+		assert.Contains(t, err.Error(), "QRIS, 3")
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+}
+
+func TestOrderService_AddOrderPayment(t *testing.T) {
+	orderID := uuid.New()
+	userID := uuid.New()
+	cardID := int32(4)
+
+	orderColumns := []string{
+		"id", "user_id", "type", "status", "created_at", "updated_at",
+		"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+		"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+		"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+		"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+	}
+	orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items", "payments", "child_order_ids", "refunds", "status_history")
+	paymentMethodColumns := []string{
+		"id", "name", "is_active", "created_at", "updated_at",
+		"kind", "sort_order", "opens_cash_drawer", "requires_reference", "allows_change",
+	}
+	orderPaymentColumns := []string{
+		"id", "order_id", "payment_method_id", "amount", "tendered_amount", "change_amount",
+		"reference_number", "shift_id", "created_by", "created_at",
+	}
+
+	makeOpenOrderRow := func(now time.Time, version int32) []interface{} {
+		return []interface{}{
+			orderID, pgtype.UUID{Bytes: userID, Valid: true},
+			orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
+			pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			int64(40000), int64(0), int64(40000), pgtype.UUID{},
+			nil, nil, nil, nil, nil, nil, nil, nil, version, int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+		}
+	}
+
+	t.Run("LoyaltyTenderNeedsEnoughPoints", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		now := time.Now()
+		customerID := uuid.New()
+		lotID := uuid.New()
+		pointsID := int32(7)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		orderRow := makeOpenOrderRow(now, 1)
+		orderRow[21] = pgtype.UUID{Bytes: customerID, Valid: true}
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(orderRow...))
+		mockPgx.ExpectQuery("SELECT .* FROM payment_methods").
+			WithArgs(pointsID).
+			WillReturnRows(pgxmock.NewRows(paymentMethodColumns).AddRow(
+				pointsID, "Loyalty Points", true, now, now, orders_repo.PaymentMethodKindLoyaltyPoints, int32(7), false, false, false,
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+
+		// 15000 is 150 points at 100 each, but the customer only holds 100
+		mockPgx.ExpectQuery("SELECT id AS customer_id FROM customers").
+			WithArgs(customerID).
+			WillReturnRows(pgxmock.NewRows([]string{"customer_id"}).AddRow(customerID))
+		mockPgx.ExpectQuery("SELECT .* FROM loyalty_point_entries").
+			WithArgs(customerID).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "customer_id", "order_id", "entry_type", "redeemed_as", "points", "remaining", "amount", "expires_at", "description", "created_by", "created_at",
+			}).AddRow(
+				lotID, customerID, pgtype.UUID{}, orders_repo.LoyaltyEntryTypeEarn, orders_repo.NullLoyaltyRedemptionKind{}, int32(100), int32(100), int64(0), pgtype.Timestamptz{}, nil, pgtype.UUID{}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+		mockPgx.ExpectExec("UPDATE loyalty_point_entries").
+			WithArgs(lotID, int32(100)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		resp, err := service.AddOrderPayment(ctx, orderID, orders.AddOrderPaymentRequest{PaymentMethodID: pointsID, Amount: 15000, Version: 1})
+
+		assert.ErrorIs(t, err, common.ErrInsufficientPoints)
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("PartialTenderKeepsOrderOpen", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		now := time.Now()
+		paymentID := uuid.New()
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOpenOrderRow(now, 1)...))
+		mockPgx.ExpectQuery("SELECT .* FROM payment_methods").
+			WithArgs(cardID).
+			WillReturnRows(pgxmock.NewRows(paymentMethodColumns).AddRow(
+				cardID, "Debit Card", true, now, now, orders_repo.PaymentMethodKindCard, int32(4), false, false, false,
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+		mockPgx.ExpectQuery("INSERT INTO order_payments").
+			WithArgs(orderID, cardID, int64(15000), int64(15000), int64(0), (*string)(nil), pgtype.UUID{Bytes: userID, Valid: true}, pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns).AddRow(
+				paymentID, orderID, cardID, int64(15000), int64(15000), int64(0), nil, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+
+		// Balance still outstanding: only the version is bumped, the order stays unpaid
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(orderID, int32(1)).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOpenOrderRow(now, 2)...))
+
+		paymentsJSON, _ := json.Marshal([]map[string]interface{}{{
+			"id": paymentID, "order_id": orderID, "payment_method_id": cardID, "payment_method_name": "Debit Card",
+			"amount": 15000, "tendered_amount": 15000, "change_amount": 0, "created_at": now.Format(time.RFC3339Nano),
+		}})
+		var paymentsForPgxMock interface{}
+		json.Unmarshal(paymentsJSON, &paymentsForPgxMock)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders o").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
+				append(makeOpenOrderRow(now, 2), nil, paymentsForPgxMock, nil, nil, nil)...,
+			))
+
+		mockActivity.EXPECT().Log(gomock.Any(), userID, activitylog_repo.LogActionTypePROCESSPAYMENT, activitylog_repo.LogEntityTypeORDER, orderID.String(), gomock.Any())
+
+		resp, err := service.AddOrderPayment(ctx, orderID, orders.AddOrderPaymentRequest{PaymentMethodID: cardID, Amount: 15000, Version: 1})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Nil(t, resp.PaymentMethodID)
+		assert.Equal(t, int64(15000), resp.AmountPaid)
+		assert.Equal(t, int64(25000), resp.BalanceDue)
+		assert.Len(t, resp.Payments, 1)
+		assert.Equal(t, "Debit Card", resp.Payments[0].PaymentMethodName)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("RemovesIneligiblePromotion", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		now := time.Now()
+		promoID := uuid.New()
+		productID := uuid.New()
+		paymentID := uuid.New()
+
+		promotedOrderRow := makeOpenOrderRow(now, 1)
+		promotedOrderRow[7] = int64(4000)
+		promotedOrderRow[8] = int64(36000)
+		promotedOrderRow[9] = pgtype.UUID{Bytes: promoID, Valid: true}
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(promotedOrderRow...))
+		mockPgx.ExpectQuery("SELECT .* FROM payment_methods").
+			WithArgs(cardID).
+			WillReturnRows(pgxmock.NewRows(paymentMethodColumns).AddRow(
+				cardID, "Debit Card", true, now, now, orders_repo.PaymentMethodKindCard, int32(4), false, false, false,
+			))
+		expectOrderPromotion(mockPgx, orderID, promoID, 4000)
+		mockPgx.ExpectQuery("SELECT .* FROM promotion_rules WHERE promotion_id").
+			WithArgs(promoID).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "promotion_id", "rule_type", "rule_value", "description", "created_at", "updated_at",
+			}).AddRow(
+				uuid.New(), promoID, orders_repo.PromotionRuleTypeALLOWEDPAYMENTMETHOD, "QRIS", nil,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+
+		// The promotion is dropped: the order is repriced without a discount and unlinked from it
+		mockPgx.ExpectQuery("SELECT .* FROM order_items WHERE order_id").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "order_id", "product_id", "quantity", "price_at_sale",
+				"subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale",
+				"station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id",
+			}).AddRow(
+				uuid.New(), orderID, productID, int32(4), int64(10000),
+				int64(40000), int64(0), int64(40000), pgtype.Numeric{},
+				nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil,
+			))
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(orderID, pgxmock.AnyArg(), int64(0), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), int32(1), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOpenOrderRow(now, 2)...))
+		mockPgx.ExpectExec("UPDATE order_items").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), orderID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPgx.ExpectExec("DELETE FROM order_promotions").
+			WithArgs(orderID).
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mockPgx.ExpectExec("UPDATE orders").
+			WithArgs(orderID, pgtype.UUID{}, pgtype.UUID{}).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+		mockPgx.ExpectQuery("INSERT INTO order_payments").
+			WithArgs(orderID, cardID, int64(15000), int64(15000), int64(0), (*string)(nil), pgtype.UUID{Bytes: userID, Valid: true}, pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns).AddRow(
+				paymentID, orderID, cardID, int64(15000), int64(15000), int64(0), nil, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+
+		// The tender is checked against the repriced order's version
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(orderID, int32(2)).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOpenOrderRow(now, 3)...))
+		mockPgx.ExpectQuery("SELECT .* FROM orders o").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
+				append(makeOpenOrderRow(now, 3), nil, nil, nil, nil, nil)...,
+			))
+
+		mockActivity.EXPECT().Log(gomock.Any(), userID, activitylog_repo.LogActionTypePROCESSPAYMENT, activitylog_repo.LogEntityTypeORDER, orderID.String(), gomock.Any()).
+			Do(func(_ context.Context, _ uuid.UUID, _ activitylog_repo.LogActionType, _ activitylog_repo.LogEntityType, _ string, details map[string]interface{}) {
+				assert.Equal(t, true, details["promotion_removed"])
+			})
+
+		resp, err := service.AddOrderPayment(ctx, orderID, orders.AddOrderPaymentRequest{
+			PaymentMethodID: cardID, Amount: 15000, Version: 1, RemoveIneligiblePromotion: true,
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Nil(t, resp.AppliedPromotionID)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("ExceedsBalanceWithoutChange", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		now := time.Now()
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOpenOrderRow(now, 1)...))
+		mockPgx.ExpectQuery("SELECT .* FROM payment_methods").
+			WithArgs(cardID).
+			WillReturnRows(pgxmock.NewRows(paymentMethodColumns).AddRow(
+				cardID, "Debit Card", true, now, now, orders_repo.PaymentMethodKindCard, int32(4), false, false, false,
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns).AddRow(
+				uuid.New(), orderID, int32(1), int64(30000), int64(30000), int64(0), nil, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+
+		resp, err := service.AddOrderPayment(ctx, orderID, orders.AddOrderPaymentRequest{PaymentMethodID: cardID, Amount: 20000, Version: 1})
+
+		assert.ErrorIs(t, err, common.ErrPaymentExceedsBalance)
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+}
+
+func TestOrderService_SplitOrder(t *testing.T) {
+	orderID := uuid.New()
+	childID := uuid.New()
+	userID := uuid.New()
+	itemA := uuid.New()
+	itemB := uuid.New()
+	productA := uuid.New()
+	productB := uuid.New()
+
+	orderColumns := []string{
+		"id", "user_id", "type", "status", "created_at", "updated_at",
+		"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+		"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+		"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+		"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+	}
+	orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items", "payments", "child_order_ids", "refunds", "status_history")
+	orderItemColumns := []string{"id", "order_id", "product_id", "quantity", "price_at_sale", "subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale", "station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id"}
+	orderPaymentColumns := []string{
+		"id", "order_id", "payment_method_id", "amount", "tendered_amount", "change_amount",
+		"reference_number", "shift_id", "created_by", "created_at",
+	}
+
+	makeOrderRow := func(id uuid.UUID, gross, net int64, version int32, parent pgtype.UUID) []interface{} {
+		now := time.Now()
+		return []interface{}{
+			id, pgtype.UUID{Bytes: userID, Valid: true},
+			orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
+			pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			gross, int64(0), net, pgtype.UUID{},
+			nil, nil, nil, nil, nil, nil, nil, nil, version, int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, parent, nil, pgtype.Date{}, pgtype.UUID{},
+		}
+	}
+
+	expectParentLoaded := func(mockPgx pgxmock.PgxPoolIface) {
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orderID, 40000, 44400, 3, pgtype.UUID{})...))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+		mockPgx.ExpectQuery("SELECT .* FROM order_items").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderItemColumns).
+				AddRow(itemA, orderID, productA, int32(2), int64(10000), int64(20000), int64(0), int64(20000), pgtype.Numeric{}, nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil).
+				AddRow(itemB, orderID, productB, int32(1), int64(20000), int64(20000), int64(0), int64(20000), pgtype.Numeric{}, nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil))
+	}
+
+	t.Run("ByItems", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		parentLink := pgtype.UUID{Bytes: orderID, Valid: true}
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		expectParentLoaded(mockPgx)
+
+		// Child order inherits the parent's cashier, type and shift
+		mockPgx.ExpectQuery("INSERT INTO orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(childID, 0, 0, 0, parentLink)...))
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(childID, orders_repo.NullOrderStatus{}, orders_repo.OrderStatusOpen, pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// One of the two units of item A is copied into the child with its options
+		newItemID := uuid.New()
+		mockPgx.ExpectQuery("INSERT INTO order_items").
+			WithArgs(childID, productA, int32(1), int64(10000), int64(10000), int64(10000), pgtype.Numeric{}, pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(orderItemColumns).
+				AddRow(newItemID, childID, productA, int32(1), int64(10000), int64(10000), int64(0), int64(10000), pgtype.Numeric{}, nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil))
+		mockPgx.ExpectExec("INSERT INTO order_item_options").
+			WithArgs(newItemID, itemA).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// Item B moves over as a whole line
+		mockPgx.ExpectExec("UPDATE order_items").
+			WithArgs(childID, itemB, orderID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		// Parent keeps one unit of item A
+		mockPgx.ExpectQuery("UPDATE order_items").
+			WithArgs(itemA, orderID, int32(1), int64(10000), int64(10000)).
+			WillReturnRows(pgxmock.NewRows(orderItemColumns).
+				AddRow(itemA, orderID, productA, int32(1), int64(10000), int64(10000), int64(0), int64(10000), pgtype.Numeric{}, nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil))
+
+		// Child totals: 30000 gross + 11% tax
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(childID, int64(30000), int64(0), int64(33300), int64(3300), int64(0), int32(0), pgxmock.AnyArg(), pgxmock.AnyArg(), false).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(childID, 30000, 33300, 1, parentLink)...))
+
+		// Parent totals: 10000 gross + 11% tax, guarded by the requested version
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(orderID, int64(10000), int64(0), int64(11100), int64(1100), int64(0), int32(3), pgxmock.AnyArg(), pgxmock.AnyArg(), false).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orderID, 10000, 11100, 4, pgtype.UUID{})...))
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders o").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
+				append(makeOrderRow(orderID, 10000, 11100, 4, pgtype.UUID{}), nil, nil, []uuid.UUID{childID}, nil, nil)...,
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM orders o").
+			WithArgs(childID).
+			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
+				append(makeOrderRow(childID, 30000, 33300, 1, parentLink), nil, nil, nil, nil, nil)...,
+			))
+
+		mockActivity.EXPECT().Log(gomock.Any(), userID, activitylog_repo.LogActionTypeUPDATE, activitylog_repo.LogEntityTypeORDER, orderID.String(), gomock.Any())
+
+		resp, err := service.SplitOrder(ctx, orderID, orders.SplitOrderRequest{
+			Parts: []orders.SplitOrderPart{{Items: []orders.SplitOrderItem{
+				{OrderItemID: itemA, Quantity: 1},
+				{OrderItemID: itemB, Quantity: 1},
+			}}},
+			Version: 3,
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, []uuid.UUID{childID}, resp.Parent.ChildOrderIDs)
+		assert.Len(t, resp.Children, 1)
+		assert.Equal(t, &orderID, resp.Children[0].ParentOrderID)
+		assert.Equal(t, int64(33300), resp.Children[0].NetTotal)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("MovingEverythingIsRejected", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+		expectParentLoaded(mockPgx)
+
+		resp, err := service.SplitOrder(ctx, orderID, orders.SplitOrderRequest{
+			Parts: []orders.SplitOrderPart{{Items: []orders.SplitOrderItem{
+				{OrderItemID: itemA, Quantity: 2},
+				{OrderItemID: itemB, Quantity: 1},
+			}}},
+			Version: 3,
+		})
+
+		assert.ErrorIs(t, err, common.ErrOrderSplitInvalid)
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("MoreGuestsThanUnits", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+		expectParentLoaded(mockPgx)
+
+		resp, err := service.SplitOrder(ctx, orderID, orders.SplitOrderRequest{Ways: 4, Version: 3})
+
+		assert.ErrorIs(t, err, common.ErrOrderSplitInvalid)
+		assert.ErrorContains(t, err, "too few to split 4 ways")
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("PartsAndWaysAreExclusive", func(t *testing.T) {
+		_, _, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+
+		resp, err := service.SplitOrder(context.Background(), orderID, orders.SplitOrderRequest{
+			Parts:   []orders.SplitOrderPart{{Items: []orders.SplitOrderItem{{OrderItemID: itemA, Quantity: 1}}}},
+			Ways:    2,
+			Version: 3,
+		})
+
+		assert.ErrorIs(t, err, common.ErrOrderSplitInvalid)
+		assert.Nil(t, resp)
+	})
+}
+
+func TestOrderService_MergeOrders(t *testing.T) {
+	// Fixed IDs so the row-lock order (sorted by ID) is predictable
+	targetID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	sourceID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	userID := uuid.New()
+	itemA := uuid.New()
+	itemB := uuid.New()
+	productA := uuid.New()
+	productB := uuid.New()
+
+	orderColumns := []string{
+		"id", "user_id", "type", "status", "created_at", "updated_at",
+		"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+		"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+		"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+		"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+	}
+	orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items", "payments", "child_order_ids", "refunds", "status_history")
+	orderItemColumns := []string{"id", "order_id", "product_id", "quantity", "price_at_sale", "subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale", "station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id"}
+	orderPaymentColumns := []string{
+		"id", "order_id", "payment_method_id", "amount", "tendered_amount", "change_amount",
+		"reference_number", "shift_id", "created_by", "created_at",
+	}
+
+	makeOrderRow := func(id uuid.UUID, status orders_repo.OrderStatus, gross, net int64, version int32) []interface{} {
+		now := time.Now()
+		return []interface{}{
+			id, pgtype.UUID{Bytes: userID, Valid: true},
+			orders_repo.OrderTypeDineIn, status,
+			pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			gross, int64(0), net, pgtype.UUID{},
+			nil, nil, nil, nil, nil, nil, nil, nil, version, int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		// Both orders are locked and checked for tenders
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(targetID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(targetID, orders_repo.OrderStatusOpen, 10000, 11100, 2)...))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(targetID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(sourceID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(sourceID, orders_repo.OrderStatusOpen, 20000, 22200, 1)...))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(sourceID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+
+		mockPgx.ExpectQuery("SELECT .* FROM cancellation_reasons").
+			WithArgs("Order Merged").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "reason", "description", "is_active", "created_at", "updated_at"}).
+				AddRow(int32(9), "Order Merged", nil, true, pgtype.Timestamptz{}, pgtype.Timestamptz{}))
+
+		mockPgx.ExpectQuery("SELECT .* FROM order_items").
+			WithArgs(sourceID).
+			WillReturnRows(pgxmock.NewRows(orderItemColumns).
+				AddRow(itemB, sourceID, productB, int32(1), int64(20000), int64(20000), int64(0), int64(20000), pgtype.Numeric{}, nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil))
+
+		// The line moves with its options; the source is then cancelled with the system reason
+		mockPgx.ExpectExec("UPDATE order_items").
+			WithArgs(targetID, itemB, sourceID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(sourceID, pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(sourceID, orders_repo.OrderStatusCancelled, 20000, 22200, 1)...))
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(sourceID, orders_repo.NullOrderStatus{OrderStatus: orders_repo.OrderStatusOpen, Valid: true}, orders_repo.OrderStatusCancelled, pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		mockPgx.ExpectQuery("SELECT .* FROM order_items").
+			WithArgs(targetID).
+			WillReturnRows(pgxmock.NewRows(orderItemColumns).
+				AddRow(itemA, targetID, productA, int32(1), int64(10000), int64(10000), int64(0), int64(10000), pgtype.Numeric{}, nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil).
+				AddRow(itemB, targetID, productB, int32(1), int64(20000), int64(20000), int64(0), int64(20000), pgtype.Numeric{}, nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil))
+
+		// Neither order had a promotion and none auto-apply, so the merged order stays undiscounted
+		expectNoActivePromotions(mockPgx)
+
+		// Merged totals: 30000 gross + 11% tax, guarded by the requested version
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(targetID, int64(30000), int64(0), int64(33300), int64(3300), int64(0), int32(2), pgxmock.AnyArg(), pgxmock.AnyArg(), false).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(targetID, orders_repo.OrderStatusOpen, 30000, 33300, 3)...))
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders o").
+			WithArgs(targetID).
+			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
+				append(makeOrderRow(targetID, orders_repo.OrderStatusOpen, 30000, 33300, 3), nil, nil, nil, nil, nil)...,
+			))
+
+		mockActivity.EXPECT().Log(gomock.Any(), userID, activitylog_repo.LogActionTypeUPDATE, activitylog_repo.LogEntityTypeORDER, targetID.String(), gomock.Any())
+
+		resp, err := service.MergeOrders(ctx, targetID, orders.MergeOrdersRequest{
+			SourceOrderIDs: []uuid.UUID{sourceID},
+			Version:        2,
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, int64(33300), resp.NetTotal)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("SourcePaid", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(targetID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(targetID, orders_repo.OrderStatusOpen, 10000, 11100, 2)...))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(targetID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(sourceID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(sourceID, orders_repo.OrderStatusPaid, 20000, 22200, 1)...))
+
+		resp, err := service.MergeOrders(context.Background(), targetID, orders.MergeOrdersRequest{
+			SourceOrderIDs: []uuid.UUID{sourceID},
+			Version:        2,
+		})
+
+		assert.ErrorIs(t, err, common.ErrOrderNotModifiable)
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("TargetHasTender", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		// Part of the target's bill is paid, so its total cannot grow
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(targetID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(targetID, orders_repo.OrderStatusOpen, 10000, 11100, 2)...))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(targetID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns).AddRow(
+				uuid.New(), targetID, int32(1), int64(5000), int64(5000), int64(0), nil, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: time.Now(), Valid: true},
+			))
+
+		resp, err := service.MergeOrders(context.Background(), targetID, orders.MergeOrdersRequest{
+			SourceOrderIDs: []uuid.UUID{sourceID},
+			Version:        2,
+		})
+
+		assert.ErrorIs(t, err, common.ErrOrderNotModifiable)
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("MergeIntoItself", func(t *testing.T) {
+		_, _, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+
+		resp, err := service.MergeOrders(context.Background(), targetID, orders.MergeOrdersRequest{
+			SourceOrderIDs: []uuid.UUID{targetID},
+			Version:        2,
+		})
+
+		assert.ErrorIs(t, err, common.ErrOrderMergeInvalid)
+		assert.Nil(t, resp)
+	})
+}
+
+func TestOrderService_UpdateOperationalStatus(t *testing.T) {
+	orderID := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+
+	orderColumns := []string{
+		"id", "user_id", "type", "status", "created_at", "updated_at",
+		"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+		"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+		"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+		"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+	}
+	orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items", "payments", "child_order_ids", "refunds", "status_history")
+	orderPaymentColumns := []string{"id", "order_id", "payment_method_id", "amount", "tendered_amount", "change_amount", "reference_number", "shift_id", "created_by", "created_at"}
+	refundColumns := []string{"id", "order_id", "shift_id", "payment_method_id", "amount", "reason", "created_by", "created_at", "order_payment_id"}
+
+	makeOrderRow := func(status orders_repo.OrderStatus) []interface{} {
+		return []interface{}{
+			orderID, pgtype.UUID{Bytes: userID, Valid: true},
+			orders_repo.OrderTypeDineIn, status,
+			pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			int64(20000), int64(0), int64(20000), pgtype.UUID{},
+			nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+		}
+	}
+	execTx := func(mockStore *mocks.MockStore, mockPgx pgxmock.PgxPoolIface) {
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		execTx(mockStore, mockPgx)
+
+		// 1. GetOrderForUpdate
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusOpen)...))
+
+		// 2. The lifecycle guards look at tenders and refunds
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+		mockPgx.ExpectQuery("SELECT .* FROM order_refunds").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(refundColumns))
+
+		// 3. UpdateOrderStatus
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(orderID, orders_repo.OrderStatusInProgress).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusInProgress)...))
+
+		// 4. The transition lands in the status history
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(orderID, orders_repo.NullOrderStatus{OrderStatus: orders_repo.OrderStatusOpen, Valid: true}, orders_repo.OrderStatusInProgress, pgtype.UUID{Bytes: userID, Valid: true}, (*string)(nil)).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// 5. GetOrderWithDetails
+		mockPgx.ExpectQuery("SELECT .* FROM orders o").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
+				append(makeOrderRow(orders_repo.OrderStatusInProgress), nil, nil, nil, nil, nil)...,
+			))
+
+		mockActivity.EXPECT().Log(gomock.Any(), userID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+		resp, err := service.UpdateOperationalStatus(ctx, orderID, orders.UpdateOrderStatusRequest{Status: orders_repo.OrderStatusInProgress})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, orders_repo.OrderStatusInProgress, resp.Status)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("OrderNotFound", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+		execTx(mockStore, mockPgx)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnError(pgx.ErrNoRows)
+
+		resp, err := service.UpdateOperationalStatus(ctx, orderID, orders.UpdateOrderStatusRequest{Status: orders_repo.OrderStatusInProgress})
+
+		assert.ErrorIs(t, err, common.ErrNotFound)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("InvalidTransition", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+		execTx(mockStore, mockPgx)
+
+		// Cancelled is terminal
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusCancelled)...))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+		mockPgx.ExpectQuery("SELECT .* FROM order_refunds").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(refundColumns))
+
+		resp, err := service.UpdateOperationalStatus(ctx, orderID, orders.UpdateOrderStatusRequest{Status: orders_repo.OrderStatusPaid})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrInvalidStatusTransition)
+	})
+
+	t.Run("PaidWithOutstandingBalance", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+		execTx(mockStore, mockPgx)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusServed)...))
+		// Only part of the bill has been tendered
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns).AddRow(
+				uuid.New(), orderID, int32(1), int64(5000), int64(5000), int64(0), nil, pgtype.UUID{}, pgtype.UUID{}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM order_refunds").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(refundColumns))
+
+		resp, err := service.UpdateOperationalStatus(ctx, orderID, orders.UpdateOrderStatusRequest{Status: orders_repo.OrderStatusPaid})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrInvalidStatusTransition)
+	})
+
+	t.Run("UpdateError", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+		execTx(mockStore, mockPgx)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusOpen)...))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+		mockPgx.ExpectQuery("SELECT .* FROM order_refunds").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(refundColumns))
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(orderID, orders_repo.OrderStatusInProgress).
+			WillReturnError(errors.New("db error"))
+
+		resp, err := service.UpdateOperationalStatus(ctx, orderID, orders.UpdateOrderStatusRequest{Status: orders_repo.OrderStatusInProgress})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "db error")
+	})
+}
+
+func TestOrderService_ReopenOrder(t *testing.T) {
+	orderID := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+
+	orderColumns := []string{
+		"id", "user_id", "type", "status", "created_at", "updated_at",
+		"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+		"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+		"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+		"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+	}
+	orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items", "payments", "child_order_ids", "refunds", "status_history")
+	orderPaymentColumns := []string{"id", "order_id", "payment_method_id", "amount", "tendered_amount", "change_amount", "reference_number", "shift_id", "created_by", "created_at"}
+	refundColumns := []string{"id", "order_id", "shift_id", "payment_method_id", "amount", "reason", "created_by", "created_at", "order_payment_id"}
 
-		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(
-			orders_repo.GetOrderWithDetailsRow{}, pgx.ErrNoRows,
+	makeOrderRow := func(status orders_repo.OrderStatus) []interface{} {
+		return []interface{}{
+			orderID, pgtype.UUID{Bytes: userID, Valid: true},
+			orders_repo.OrderTypeDineIn, status,
+			pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			int64(20000), int64(0), int64(20000), pgtype.UUID{},
+			nil, nil, nil, nil, nil, nil, nil, nil, int32(2), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+		}
+	}
+	req := orders.ReopenOrderRequest{Reason: "Guest wants to add a dish", Version: 2}
+
+	t.Run("Success", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
 		)
 
-		resp, err := service.UpdateOperationalStatus(ctx, orderID, req)
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusServed)...))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns))
+		mockPgx.ExpectQuery("SELECT .* FROM order_refunds").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(refundColumns))
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(orderID, orders_repo.OrderStatusOpen).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusOpen)...))
+
+		// The reason is kept as the history note
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(orderID, orders_repo.NullOrderStatus{OrderStatus: orders_repo.OrderStatusServed, Valid: true}, orders_repo.OrderStatusOpen, pgtype.UUID{Bytes: userID, Valid: true}, &req.Reason).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
-		assert.ErrorIs(t, err, common.ErrNotFound)
-		assert.Nil(t, resp)
+		mockPgx.ExpectQuery("SELECT .* FROM orders o").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
+				append(makeOrderRow(orders_repo.OrderStatusOpen), nil, nil, nil, nil, nil)...,
+			))
+
+		mockActivity.EXPECT().Log(gomock.Any(), userID, gomock.Any(), gomock.Any(), orderID.String(), gomock.Any())
+
+		resp, err := service.ReopenOrder(ctx, orderID, req)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, orders_repo.OrderStatusOpen, resp.Status)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
 	})
 
-	t.Run("InvalidTransition", func(t *testing.T) {
-		_, mockOrderRepo, _, _, _, mockLogger, service := setupTest(t)
+	t.Run("VersionConflict", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
 		allowAllLoggerCalls(mockLogger)
 		ctx := context.Background()
 
-		// Cancelled → Paid is NOT in the allowed transitions
-		req := orders.UpdateOrderStatusRequest{
-			Status: orders_repo.OrderStatusPaid,
-		}
-
-		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(orders_repo.GetOrderWithDetailsRow{
-			ID:     orderID,
-			Status: orders_repo.OrderStatusCancelled,
-			Items:  nil,
-		}, nil)
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusServed)...))
 
-		resp, err := service.UpdateOperationalStatus(ctx, orderID, req)
+		resp, err := service.ReopenOrder(ctx, orderID, orders.ReopenOrderRequest{Reason: "stale", Version: 1})
 
-		assert.Error(t, err)
 		assert.Nil(t, resp)
-		assert.ErrorIs(t, err, common.ErrInvalidStatusTransition)
+		assert.ErrorIs(t, err, common.ErrOrderConflict)
 	})
 
-	t.Run("UpdateError", func(t *testing.T) {
-		_, mockOrderRepo, _, _, _, mockLogger, service := setupTest(t)
+	t.Run("WithPaymentsRejected", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
 		allowAllLoggerCalls(mockLogger)
 		ctx := context.Background()
 
-		req := orders.UpdateOrderStatusRequest{
-			Status: orders_repo.OrderStatusInProgress,
-		}
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusInProgress)...))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderPaymentColumns).AddRow(
+				uuid.New(), orderID, int32(1), int64(5000), int64(5000), int64(0), nil, pgtype.UUID{}, pgtype.UUID{}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM order_refunds").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(refundColumns))
+
+		resp, err := service.ReopenOrder(ctx, orderID, req)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrInvalidStatusTransition)
+	})
+}
+
+func TestOrderService_EvaluatePromotions(t *testing.T) {
+	orderID := uuid.New()
+
+	t.Run("ReadsWithoutLockingTheOrder", func(t *testing.T) {
+		// No ExecTx or GetOrderForUpdate is expected: the dry run must not hold the order's row lock
+		_, mockRepo, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+		promoID := uuid.New()
 
-		mockOrderRepo.EXPECT().GetOrderWithDetails(ctx, orderID).Return(orders_repo.GetOrderWithDetailsRow{
-			ID:     orderID,
-			Status: orders_repo.OrderStatusOpen,
-			Items:  nil,
+		mockRepo.EXPECT().GetOrder(gomock.Any(), orderID).Return(orders_repo.Order{
+			ID: orderID, Status: orders_repo.OrderStatusOpen, GrossTotal: 50000,
+		}, nil)
+		mockRepo.EXPECT().GetOrderItemsByOrderID(gomock.Any(), orderID).Return([]orders_repo.OrderItem{}, nil)
+		mockRepo.EXPECT().ListActivePromotions(gomock.Any()).Return([]orders_repo.Promotion{
+			{ID: promoID, Name: "Paused", IsActive: false, AutoApply: true},
 		}, nil)
-		mockOrderRepo.EXPECT().UpdateOrderStatus(ctx, gomock.Any()).Return(orders_repo.Order{}, errors.New("db error"))
+		mockRepo.EXPECT().PromotionHasCodes(gomock.Any(), promoID).Return(false, nil)
 
-		resp, err := service.UpdateOperationalStatus(ctx, orderID, req)
+		resp, err := service.EvaluatePromotions(context.Background(), orderID)
 
-		assert.Error(t, err)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(50000), resp.GrossTotal)
+		assert.Len(t, resp.Promotions, 1)
+		assert.False(t, resp.Promotions[0].Eligible)
+		assert.Empty(t, resp.Selected)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, mockRepo, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+
+		mockRepo.EXPECT().GetOrder(gomock.Any(), orderID).Return(orders_repo.Order{}, pgx.ErrNoRows)
+
+		resp, err := service.EvaluatePromotions(context.Background(), orderID)
+
+		assert.ErrorIs(t, err, common.ErrNotFound)
 		assert.Nil(t, resp)
-		assert.Contains(t, err.Error(), "db error")
 	})
 }
 
@@ -1379,8 +3333,9 @@ func TestOrderService_ApplyPromotion(t *testing.T) {
 			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
 			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
 			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
 		}
-		orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items")
+		orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items", "payments", "child_order_ids", "refunds", "status_history")
 
 		productID := uuid.New()
 		itemID := uuid.New()
@@ -1391,122 +3346,317 @@ func TestOrderService_ApplyPromotion(t *testing.T) {
 				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
 				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
 				grossTotal, discountAmount, netTotal, pgtype.UUID{},
-				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{},
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
 			}
 		}
 
-		// ExecTx: execute callback with pgxmock
+		// ExecTx: execute callback with pgxmock
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		// 1. GetOrderForUpdate (order with grossTotal=50000)
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(50000, 0, 50000)...))
+		expectNoOrderPayments(mockPgx, orderID)
+
+		// 2. GetOrderItemsByOrderID
+		mockPgx.ExpectQuery("SELECT .* FROM order_items WHERE order_id").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "order_id", "product_id", "quantity", "price_at_sale",
+				"subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale",
+				"station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id",
+			}).AddRow(
+				itemID, orderID, productID, int32(5), int64(10000),
+				int64(50000), int64(0), int64(50000),
+				pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true},
+				nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil,
+			))
+
+		// 3. No codes, so the promotion can be applied by ID
+		mockPgx.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM promotion_codes").
+			WithArgs(promoID).
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+
+		// 4. GetPromotionByID (ORDER scope, percentage 10%, active)
+		promotionRows := func() *pgxmock.Rows {
+			return pgxmock.NewRows([]string{
+				"id", "name", "description", "scope", "discount_type",
+				"discount_value", "max_discount_amount", "start_date", "end_date",
+				"is_active", "created_at", "updated_at", "deleted_at",
+				"total_redemption_limit", "per_customer_limit", "budget_amount", "buy_quantity", "get_quantity", "bundle_quantity",
+				"auto_apply", "stacking", "priority",
+			}).AddRow(
+				promoID, "10% Off", nil, orders_repo.PromotionScopeORDER, orders_repo.DiscountTypePercentage,
+				pgtype.Numeric{Int: big.NewInt(10), Exp: 0, Valid: true},
+				pgtype.Numeric{Int: big.NewInt(0), Exp: 0, Valid: true},
+				pgtype.Timestamptz{Time: now.Add(-24 * time.Hour), Valid: true},
+				pgtype.Timestamptz{Time: now.Add(24 * time.Hour), Valid: true},
+				true, pgtype.Timestamptz{Time: now, Valid: true},
+				pgtype.Timestamptz{Time: now, Valid: true},
+				pgtype.Timestamptz{}, nil, nil, nil, nil, nil, nil,
+				false, orders_repo.PromotionStackingExclusive, int32(0),
+			)
+		}
+		mockPgx.ExpectQuery("SELECT .* FROM promotions WHERE id").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(promotionRows())
+
+		expectNoPromotionSchedule(mockPgx)
+		mockPgx.ExpectQuery("SELECT .* FROM promotion_rules WHERE promotion_id").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "promotion_id", "rule_type", "rule_value", "description", "created_at", "updated_at",
+			}))
+
+		// The promotion has no redemption limits, so no redemptions are counted; no promotions auto-apply
+		expectNoActivePromotions(mockPgx)
+
+		// 5. UpdateOrderTotals — 10 args: id, gross_total, discount_amount, net_total, tax_amount, service_charge_amount, version, tax_rate, service_charge_rate, tax_inclusive
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(50000, 5000, 45000)...))
+
+		// 6. The discount is allocated to the order's lines
+		mockPgx.ExpectExec("UPDATE order_items").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), orderID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		// 7. The promotion's part of the discount is stored, then it becomes the order's applied promotion
+		mockPgx.ExpectExec("DELETE FROM order_promotions").
+			WithArgs(orderID).
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mockPgx.ExpectExec("INSERT INTO order_promotions").
+			WithArgs(orderID, promoID, pgtype.UUID{}, int64(5000), false, int32(0), (*string)(nil)).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPgx.ExpectExec("UPDATE orders").
+			WithArgs(orderID, pgtype.UUID{Bytes: promoID, Valid: true}, pgtype.UUID{}).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		// 8. GetOrderWithDetails (final)
+		mockPgx.ExpectQuery("SELECT .* FROM orders o").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
+				append(makeOrderRow(50000, 5000, 45000), nil, nil, nil, nil, nil)...,
+			))
+
+		// Activity log after successful promotion application
+		mockActivity.EXPECT().Log(
+			gomock.Any(),
+			userID,
+			activitylog_repo.LogActionTypeAPPLYPROMOTION,
+			activitylog_repo.LogEntityTypeORDER,
+			orderID.String(),
+			gomock.Any(),
+		)
+
+		// Items=nil, so buildOrderDetailResponseFromQueryResult skips item fetch
+		_ = mockProductRepo
+
+		resp, err := service.ApplyPromotion(ctx, orderID, req)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, orderID, resp.ID)
+		assert.Equal(t, int64(45000), resp.NetTotal)
+		assert.Equal(t, int64(5000), resp.DiscountAmount)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("TransactionError", func(t *testing.T) {
+		mockStore, _, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).Return(errors.New("transaction failed"))
+
+		resp, err := service.ApplyPromotion(ctx, orderID, req)
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("OrderNotFound", func(t *testing.T) {
+		mockStore, _, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).Return(common.ErrNotFound)
+
+		resp, err := service.ApplyPromotion(ctx, orderID, req)
+
+		assert.ErrorIs(t, err, common.ErrNotFound)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("OrderTypeNotAllowed", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		now := time.Now()
+
+		orderColumns := []string{
+			"id", "user_id", "type", "status", "created_at", "updated_at",
+			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+		}
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(
+				orderID, pgtype.UUID{Bytes: userID, Valid: true},
+				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+				int64(50000), int64(0), int64(50000), pgtype.UUID{},
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			))
+		expectNoOrderPayments(mockPgx, orderID)
+		mockPgx.ExpectQuery("SELECT .* FROM order_items WHERE order_id").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "order_id", "product_id", "quantity", "price_at_sale",
+				"subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale",
+				"station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id",
+			}).AddRow(
+				uuid.New(), orderID, uuid.New(), int32(5), int64(10000),
+				int64(50000), int64(0), int64(50000), pgtype.Numeric{},
+				nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil,
+			))
+		mockPgx.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM promotion_codes").
+			WithArgs(promoID).
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+		mockPgx.ExpectQuery("SELECT .* FROM promotions WHERE id").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "name", "description", "scope", "discount_type",
+				"discount_value", "max_discount_amount", "start_date", "end_date",
+				"is_active", "created_at", "updated_at", "deleted_at",
+				"total_redemption_limit", "per_customer_limit", "budget_amount", "buy_quantity", "get_quantity", "bundle_quantity",
+				"auto_apply", "stacking", "priority",
+			}).AddRow(
+				promoID, "Takeaway Treat", nil, orders_repo.PromotionScopeORDER, orders_repo.DiscountTypePercentage,
+				pgtype.Numeric{Int: big.NewInt(10), Exp: 0, Valid: true},
+				pgtype.Numeric{Int: big.NewInt(0), Exp: 0, Valid: true},
+				pgtype.Timestamptz{Time: now.Add(-24 * time.Hour), Valid: true},
+				pgtype.Timestamptz{Time: now.Add(24 * time.Hour), Valid: true},
+				true, pgtype.Timestamptz{Time: now, Valid: true},
+				pgtype.Timestamptz{Time: now, Valid: true},
+				pgtype.Timestamptz{}, nil, nil, nil, nil, nil, nil,
+				false, orders_repo.PromotionStackingExclusive, int32(0),
+			))
+		expectNoPromotionSchedule(mockPgx)
+		mockPgx.ExpectQuery("SELECT .* FROM promotion_rules WHERE promotion_id").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "promotion_id", "rule_type", "rule_value", "description", "created_at", "updated_at",
+			}).AddRow(
+				uuid.New(), promoID, orders_repo.PromotionRuleTypeALLOWEDORDERTYPE, "takeaway", nil,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+			))
+
+		resp, err := service.ApplyPromotion(ctx, orderID, req)
+
+		assert.ErrorIs(t, err, common.ErrPromotionNotApplicable)
+		assert.Contains(t, err.Error(), "takeaway")
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("ExcludedDate", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		now := time.Now()
+
+		orderColumns := []string{
+			"id", "user_id", "type", "status", "created_at", "updated_at",
+			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+		}
+
 		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(ctx context.Context, fn func(pgx.Tx) error) error {
 				return fn(mockPgx)
 			},
 		)
 
-		// 1. GetOrderForUpdate (order with grossTotal=50000)
 		mockPgx.ExpectQuery("SELECT .* FROM orders").
 			WithArgs(pgxmock.AnyArg()).
-			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(50000, 0, 50000)...))
-
-		// 2. GetOrderItemsByOrderID
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(
+				orderID, pgtype.UUID{Bytes: userID, Valid: true},
+				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+				int64(50000), int64(0), int64(50000), pgtype.UUID{},
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			))
+		expectNoOrderPayments(mockPgx, orderID)
 		mockPgx.ExpectQuery("SELECT .* FROM order_items WHERE order_id").
 			WithArgs(pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "order_id", "product_id", "quantity", "price_at_sale",
 				"subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale",
+				"station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id",
 			}).AddRow(
-				itemID, orderID, productID, int32(5), int64(10000),
-				int64(50000), int64(0), int64(50000),
-				pgtype.Numeric{Int: big.NewInt(5000), Exp: 0, Valid: true},
+				uuid.New(), orderID, uuid.New(), int32(5), int64(10000),
+				int64(50000), int64(0), int64(50000), pgtype.Numeric{},
+				nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil,
 			))
-
-		// 3. GetPromotionByID (ORDER scope, percentage 10%, active)
+		mockPgx.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM promotion_codes").
+			WithArgs(promoID).
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
 		mockPgx.ExpectQuery("SELECT .* FROM promotions WHERE id").
 			WithArgs(pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{
 				"id", "name", "description", "scope", "discount_type",
 				"discount_value", "max_discount_amount", "start_date", "end_date",
 				"is_active", "created_at", "updated_at", "deleted_at",
+				"total_redemption_limit", "per_customer_limit", "budget_amount", "buy_quantity", "get_quantity", "bundle_quantity",
+				"auto_apply", "stacking", "priority",
 			}).AddRow(
-				promoID, "10% Off", nil, orders_repo.PromotionScopeORDER, orders_repo.DiscountTypePercentage,
+				promoID, "Happy Hour", nil, orders_repo.PromotionScopeORDER, orders_repo.DiscountTypePercentage,
 				pgtype.Numeric{Int: big.NewInt(10), Exp: 0, Valid: true},
 				pgtype.Numeric{Int: big.NewInt(0), Exp: 0, Valid: true},
 				pgtype.Timestamptz{Time: now.Add(-24 * time.Hour), Valid: true},
 				pgtype.Timestamptz{Time: now.Add(24 * time.Hour), Valid: true},
 				true, pgtype.Timestamptz{Time: now, Valid: true},
 				pgtype.Timestamptz{Time: now, Valid: true},
-				pgtype.Timestamptz{},
+				pgtype.Timestamptz{}, nil, nil, nil, nil, nil, nil,
+				false, orders_repo.PromotionStackingExclusive, int32(0),
 			))
-
-		mockPgx.ExpectQuery("SELECT .* FROM promotion_rules WHERE promotion_id").
+		// Today in the store's time zone is a holiday the promotion does not run on
+		jakarta, _ := time.LoadLocation("Asia/Jakarta")
+		today := now.In(jakarta)
+		mockPgx.ExpectQuery("SELECT .* FROM promotion_schedules").
 			WithArgs(pgxmock.AnyArg()).
-			WillReturnRows(pgxmock.NewRows([]string{
-				"id", "promotion_id", "rule_type", "rule_value", "description", "created_at", "updated_at",
-			}))
-
-		// 5. UpdateOrderTotals — 7 args: id, gross_total, discount_amount, net_total, tax_amount, service_charge_amount, version
-		mockPgx.ExpectQuery("UPDATE orders").
-			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
-			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(50000, 5000, 45000)...))
-
-		// 6. UpdateOrderAppliedPromotion (exec, not query)
-		mockPgx.ExpectExec("UPDATE orders").
-			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
-			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
-
-		// 7. GetOrderWithDetails (final)
-		mockPgx.ExpectQuery("SELECT .* FROM orders o").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "promotion_id", "days_of_week", "start_time", "end_time", "created_at"}))
+		mockPgx.ExpectQuery("SELECT .* FROM promotion_excluded_dates").
 			WithArgs(pgxmock.AnyArg()).
-			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
-				append(makeOrderRow(50000, 5000, 45000), nil)...,
+			WillReturnRows(pgxmock.NewRows([]string{"promotion_id", "excluded_date"}).AddRow(
+				promoID, pgtype.Date{Time: time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC), Valid: true},
 			))
 
-		// Activity log after successful promotion application
-		mockActivity.EXPECT().Log(
-			gomock.Any(),
-			userID,
-			activitylog_repo.LogActionTypeAPPLYPROMOTION,
-			activitylog_repo.LogEntityTypeORDER,
-			orderID.String(),
-			gomock.Any(),
-		)
-
-		// Items=nil, so buildOrderDetailResponseFromQueryResult skips item fetch
-		_ = mockProductRepo
-
-		resp, err := service.ApplyPromotion(ctx, orderID, req)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, resp)
-		assert.Equal(t, orderID, resp.ID)
-		assert.Equal(t, int64(45000), resp.NetTotal)
-		assert.Equal(t, int64(5000), resp.DiscountAmount)
-		assert.NoError(t, mockPgx.ExpectationsWereMet())
-	})
-
-	t.Run("TransactionError", func(t *testing.T) {
-		mockStore, _, _, _, _, mockLogger, service := setupTest(t)
-		allowAllLoggerCalls(mockLogger)
-		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
-
-		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).Return(errors.New("transaction failed"))
-
-		resp, err := service.ApplyPromotion(ctx, orderID, req)
-
-		assert.Error(t, err)
-		assert.Nil(t, resp)
-	})
-
-	t.Run("OrderNotFound", func(t *testing.T) {
-		mockStore, _, _, _, _, mockLogger, service := setupTest(t)
-		allowAllLoggerCalls(mockLogger)
-		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
-
-		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).Return(common.ErrNotFound)
-
 		resp, err := service.ApplyPromotion(ctx, orderID, req)
 
-		assert.ErrorIs(t, err, common.ErrNotFound)
+		assert.ErrorIs(t, err, common.ErrPromotionNotApplicable)
+		assert.Contains(t, err.Error(), "does not run on")
 		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
 	})
 
 	t.Run("PromotionNotApplicable", func(t *testing.T) {
@@ -1547,8 +3697,9 @@ func TestOrderService_RefundOrder(t *testing.T) {
 			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
 			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
 			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
 		}
-		orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items")
+		orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items", "payments", "child_order_ids", "refunds", "status_history")
 
 		payMethodID := int32(1)
 
@@ -1567,44 +3718,105 @@ func TestOrderService_RefundOrder(t *testing.T) {
 				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusInProgress,
 				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
 				int64(20000), int64(0), int64(20000), pgtype.UUID{},
-				&payMethodID, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{},
-			))
-
-		// 2. RefundOrder (SQL query)
-		mockPgx.ExpectQuery("UPDATE orders").
-			WithArgs(pgxmock.AnyArg()).
-			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(
-				orderID, pgtype.UUID{Bytes: userID, Valid: true},
-				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusCancelled,
-				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
-				int64(20000), int64(0), int64(20000), pgtype.UUID{},
-				nil, nil, nil, nil, nil, nil, nil, nil, int32(2), int64(0), int64(0), pgtype.UUID{},
+				&payMethodID, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
 			))
 
-		// 3. GetOrderItemsByOrderID
+		// 2. GetOrderItemsByOrderID
 		productID := uuid.New()
+		itemID := uuid.New()
 		mockPgx.ExpectQuery("SELECT .* FROM order_items").
 			WithArgs(pgxmock.AnyArg()).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "order_id", "product_id", "quantity", "price_at_sale", "subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale"}).
-				AddRow(uuid.New(), orderID, productID, int32(1), int64(20000), int64(20000), int64(0), int64(20000), pgtype.Numeric{}))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "order_id", "product_id", "quantity", "price_at_sale", "subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale", "station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id"}).
+				AddRow(itemID, orderID, productID, int32(1), int64(20000), int64(20000), int64(0), int64(20000), pgtype.Numeric{Int: big.NewInt(6000), Exp: 0, Valid: true}, nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil))
+
+		// 2a. Nothing has been refunded yet
+		mockPgx.ExpectQuery("SELECT .* FROM order_refund_items").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{"order_item_id", "refunded_quantity", "restocked_quantity"}))
+		refundColumns := []string{"id", "order_id", "shift_id", "payment_method_id", "amount", "reason", "created_by", "created_at", "order_payment_id"}
+		mockPgx.ExpectQuery("SELECT .* FROM order_refunds").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(refundColumns))
+
+		// 2b. ListOrderPayments — the bill was split between cash and card
+		cardMethodID := int32(4)
+		cashPaymentID := uuid.New()
+		cardPaymentID := uuid.New()
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "order_id", "payment_method_id", "amount", "tendered_amount", "change_amount", "reference_number", "shift_id", "created_by", "created_at"}).
+				AddRow(cashPaymentID, orderID, payMethodID, int64(15000), int64(15000), int64(0), nil, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}).
+				AddRow(cardPaymentID, orderID, cardMethodID, int64(5000), int64(5000), int64(0), nil, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}))
+
+		// 2c. CreateOrderRefund — one refund per tender (latest first), against the method it was paid with
+		cardRefundID := uuid.New()
+		mockPgx.ExpectQuery("INSERT INTO order_refunds").
+			WithArgs(orderID, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.UUID{}, &cardMethodID, pgtype.UUID{Bytes: cardPaymentID, Valid: true}, int64(5000), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(refundColumns).
+				AddRow(cardRefundID, orderID, pgtype.UUID{}, &cardMethodID, int64(5000), &req.Reason, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.UUID{Bytes: cardPaymentID, Valid: true}))
+		mockPgx.ExpectQuery("INSERT INTO order_refunds").
+			WithArgs(orderID, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.UUID{}, &payMethodID, pgtype.UUID{Bytes: cashPaymentID, Valid: true}, int64(15000), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(refundColumns).
+				AddRow(uuid.New(), orderID, pgtype.UUID{}, &payMethodID, int64(15000), &req.Reason, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.UUID{Bytes: cashPaymentID, Valid: true}))
+
+		// 2d. Options and recipes of the restocked lines; the product has no recipe
+		mockPgx.ExpectQuery("FROM order_item_options").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{"order_item_id", "product_option_id"}))
+		mockPgx.ExpectQuery("SELECT .* FROM product_recipe_items").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"product_id", "ingredient_id", "quantity"}))
+
+		// 3. CreateOrderRefundItem — the whole line, restocked
+		mockPgx.ExpectQuery("INSERT INTO order_refund_items").
+			WithArgs(cardRefundID, itemID, int32(1), int64(20000), true).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "refund_id", "order_item_id", "quantity", "amount", "restocked", "created_at"}).
+				AddRow(uuid.New(), cardRefundID, itemID, int32(1), int64(20000), true, pgtype.Timestamptz{Time: now, Valid: true}))
 
 		// 4. GetProductByID (from products repo - returns 11 columns: 9 product fields + options + categories)
 		mockPgx.ExpectQuery("SELECT .* FROM products").
 			WithArgs(pgxmock.AnyArg()).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "image_url", "price", "stock", "created_at", "updated_at", "deleted_at", "cost_price", "options", "categories"}).
-				AddRow(productID, "Test Product", nil, int64(20000), int32(9), now, now, nil, pgtype.Numeric{}, "[]", "[]"))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "image_url", "price", "stock", "created_at", "updated_at", "deleted_at", "cost_price", "sku", "options", "categories"}).
+				AddRow(productID, "Test Product", nil, int64(20000), int32(9), now, now, nil, pgtype.Numeric{}, nil, "[]", "[]"))
 
-		// 5. AddProductStock (from products repo - returns 9 columns)
+		// 5. AddProductStock (from products repo - returns 10 columns)
 		mockPgx.ExpectQuery("UPDATE products SET stock").
 			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "image_url", "price", "stock", "created_at", "updated_at", "deleted_at", "cost_price"}).
-				AddRow(productID, "Test Product", nil, int64(20000), int32(10), now, now, nil, pgtype.Numeric{}))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "image_url", "price", "stock", "created_at", "updated_at", "deleted_at", "cost_price", "sku"}).
+				AddRow(productID, "Test Product", nil, int64(20000), int32(10), now, now, nil, pgtype.Numeric{}, nil))
 
 		// 6. CreateStockHistory
 		mockPgx.ExpectQuery("INSERT INTO stock_history").
-			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
 			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 
+		// 6a. The sale drew no cost layer, so the unit comes back as a return layer at the cost it was sold at
+		mockPgx.ExpectQuery("WITH consumed AS").
+			WithArgs(itemID, int32(1)).
+			WillReturnRows(pgxmock.NewRows([]string{"quantity", "unit_cost"}))
+		mockPgx.ExpectExec("INSERT INTO cost_layers").
+			WithArgs(productID, costing_repo.CostLayerSourceReturn, pgtype.UUID{Bytes: itemID, Valid: true}, int32(1), pgtype.Numeric{Int: big.NewInt(6000), Exp: 0, Valid: true}).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPgx.ExpectExec("UPDATE products SET cost_price").
+			WithArgs(int32(9), int32(1), pgtype.Numeric{Int: big.NewInt(6000), Exp: 0, Valid: true}, productID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		// 6b. RefundOrder — everything has been returned, so the order is closed
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(
+				orderID, pgtype.UUID{Bytes: userID, Valid: true},
+				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusCancelled,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+				int64(20000), int64(0), int64(20000), pgtype.UUID{},
+				&payMethodID, nil, nil, nil, nil, nil, nil, nil, int32(2), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			))
+
+		// 6c. The closing transition lands in the status history
+		mockPgx.ExpectExec("INSERT INTO order_status_history").
+			WithArgs(orderID, orders_repo.NullOrderStatus{OrderStatus: orders_repo.OrderStatusInProgress, Valid: true}, orders_repo.OrderStatusCancelled, pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
 		// 7. GetOrderWithDetails (final response)
 		mockPgx.ExpectQuery("SELECT .* FROM orders o").
 			WithArgs(pgxmock.AnyArg()).
@@ -1613,8 +3825,8 @@ func TestOrderService_RefundOrder(t *testing.T) {
 				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusCancelled,
 				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
 				int64(20000), int64(0), int64(20000), pgtype.UUID{},
-				nil, nil, nil, nil, nil, nil, nil, nil, int32(2), int64(0), int64(0), pgtype.UUID{},
-				nil,
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(2), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+				nil, nil, nil, nil, nil,
 			))
 
 		// Activity Log
@@ -1627,6 +3839,162 @@ func TestOrderService_RefundOrder(t *testing.T) {
 		assert.NoError(t, mockPgx.ExpectationsWereMet())
 	})
 
+	t.Run("PartialWithoutRestock", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, mockActivity, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+
+		orderColumns := []string{
+			"id", "user_id", "type", "status", "created_at", "updated_at",
+			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+		}
+		orderWithDetailsColumns := append(append([]string{}, orderColumns...), "items", "payments", "child_order_ids", "refunds", "status_history")
+		refundColumns := []string{"id", "order_id", "shift_id", "payment_method_id", "amount", "reason", "created_by", "created_at", "order_payment_id"}
+
+		payMethodID := int32(1)
+		paymentID := uuid.New()
+		refundID := uuid.New()
+		itemA := uuid.New()
+		itemB := uuid.New()
+
+		makeOrderRow := func(status orders_repo.OrderStatus, version int32) []interface{} {
+			return []interface{}{
+				orderID, pgtype.UUID{Bytes: userID, Valid: true},
+				orders_repo.OrderTypeDineIn, status,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+				int64(40000), int64(0), int64(44400), pgtype.UUID{},
+				&payMethodID, nil, nil, nil, nil, nil, nil, nil, version, int64(4400), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			}
+		}
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusPaid, 3)...))
+		mockPgx.ExpectQuery("SELECT .* FROM order_items").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "order_id", "product_id", "quantity", "price_at_sale", "subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale", "station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id"}).
+				AddRow(itemA, orderID, uuid.New(), int32(2), int64(10000), int64(20000), int64(0), int64(20000), pgtype.Numeric{}, nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil).
+				AddRow(itemB, orderID, uuid.New(), int32(1), int64(20000), int64(20000), int64(0), int64(20000), pgtype.Numeric{}, nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil))
+		mockPgx.ExpectQuery("SELECT .* FROM order_refund_items").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{"order_item_id", "refunded_quantity", "restocked_quantity"}))
+		mockPgx.ExpectQuery("SELECT .* FROM order_refunds").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(refundColumns))
+		mockPgx.ExpectQuery("SELECT .* FROM order_payments").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "order_id", "payment_method_id", "amount", "tendered_amount", "change_amount", "reference_number", "shift_id", "created_by", "created_at"}).
+				AddRow(paymentID, orderID, payMethodID, int64(44400), int64(50000), int64(5600), nil, pgtype.UUID{}, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}))
+
+		// One of four net units (10000 of 40000) refunds a quarter of the taxed total
+		mockPgx.ExpectQuery("INSERT INTO order_refunds").
+			WithArgs(orderID, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.UUID{}, &payMethodID, pgtype.UUID{Bytes: paymentID, Valid: true}, int64(11100), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(refundColumns).
+				AddRow(refundID, orderID, pgtype.UUID{}, &payMethodID, int64(11100), &req.Reason, pgtype.UUID{Bytes: userID, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.UUID{Bytes: paymentID, Valid: true}))
+		mockPgx.ExpectQuery("INSERT INTO order_refund_items").
+			WithArgs(refundID, itemA, int32(1), int64(11100), false).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "refund_id", "order_item_id", "quantity", "amount", "restocked", "created_at"}).
+				AddRow(uuid.New(), refundID, itemA, int32(1), int64(11100), false, pgtype.Timestamptz{Time: now, Valid: true}))
+
+		// No stock movement; the order stays paid and only its version moves
+		mockPgx.ExpectQuery("UPDATE orders").
+			WithArgs(orderID, int32(3)).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(makeOrderRow(orders_repo.OrderStatusPaid, 4)...))
+
+		refundsJSON := []map[string]interface{}{{
+			"id": refundID, "order_id": orderID, "payment_method_id": payMethodID, "order_payment_id": paymentID,
+			"amount": 11100, "reason": req.Reason,
+			"items": []map[string]interface{}{{"order_item_id": itemA, "quantity": 1, "amount": 11100, "restocked": false}},
+		}}
+		mockPgx.ExpectQuery("SELECT .* FROM orders o").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderWithDetailsColumns).AddRow(
+				append(makeOrderRow(orders_repo.OrderStatusPaid, 4), nil, nil, nil, refundsJSON, nil)...,
+			))
+
+		mockActivity.EXPECT().Log(gomock.Any(), userID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+		restock := false
+		resp, err := service.RefundOrder(ctx, orderID, orders.RefundOrderRequest{
+			Reason:  req.Reason,
+			Items:   []orders.RefundOrderItem{{OrderItemID: itemA, Quantity: 1}},
+			Restock: &restock,
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, orders_repo.OrderStatusPaid, resp.Status)
+		assert.Equal(t, int64(11100), resp.AmountRefunded)
+		assert.Len(t, resp.Refunds, 1)
+		assert.Equal(t, &paymentID, resp.Refunds[0].OrderPaymentID)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("QuantityExceedsRemaining", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+
+		orderColumns := []string{
+			"id", "user_id", "type", "status", "created_at", "updated_at",
+			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+		}
+		payMethodID := int32(1)
+		itemID := uuid.New()
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(
+				orderID, pgtype.UUID{Bytes: userID, Valid: true},
+				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusPaid,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+				int64(20000), int64(0), int64(22200), pgtype.UUID{},
+				&payMethodID, nil, nil, nil, nil, nil, nil, nil, int32(2), int64(2200), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			))
+		mockPgx.ExpectQuery("SELECT .* FROM order_items").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "order_id", "product_id", "quantity", "price_at_sale", "subtotal", "discount_amount", "net_subtotal", "cost_price_at_sale", "station_id", "prep_status", "is_priority", "queued_at", "started_at", "ready_at", "served_at", "variant_id"}).
+				AddRow(itemID, orderID, uuid.New(), int32(2), int64(10000), int64(20000), int64(0), int64(20000), pgtype.Numeric{}, nil, orders_repo.OrderItemStatusQueued, false, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, nil))
+
+		// One of the two units was already refunded earlier
+		mockPgx.ExpectQuery("SELECT .* FROM order_refund_items").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{"order_item_id", "refunded_quantity", "restocked_quantity"}).AddRow(itemID, int32(1), int32(1)))
+		mockPgx.ExpectQuery("SELECT .* FROM order_refunds").
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "order_id", "shift_id", "payment_method_id", "amount", "reason", "created_by", "created_at", "order_payment_id"}).
+				AddRow(uuid.New(), orderID, pgtype.UUID{}, &payMethodID, int64(11100), nil, pgtype.UUID{}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.UUID{}))
+
+		resp, err := service.RefundOrder(context.Background(), orderID, orders.RefundOrderRequest{
+			Reason: req.Reason,
+			Items:  []orders.RefundOrderItem{{OrderItemID: itemID, Quantity: 2}},
+		})
+
+		assert.ErrorIs(t, err, common.ErrRefundInvalid)
+		assert.Nil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
 	t.Run("NotPaid", func(t *testing.T) {
 		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
 		defer mockPgx.Close()
@@ -1638,6 +4006,7 @@ func TestOrderService_RefundOrder(t *testing.T) {
 			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
 			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
 			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
 		}
 
 		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -1654,12 +4023,100 @@ func TestOrderService_RefundOrder(t *testing.T) {
 				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusOpen,
 				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
 				int64(20000), int64(0), int64(20000), pgtype.UUID{},
-				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{},
+				nil, nil, nil, nil, nil, nil, nil, nil, int32(1), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
+			))
+
+		_, err := service.RefundOrder(ctx, orderID, req)
+
+		assert.ErrorIs(t, err, common.ErrOrderNotRefundable)
+	})
+
+	t.Run("AlreadyRefunded", func(t *testing.T) {
+		mockPgx, mockStore, _, _, _, _, mockLogger, service := setupTestWithPgxMock(t)
+		defer mockPgx.Close()
+		allowAllLoggerCalls(mockLogger)
+		ctx := context.Background()
+
+		orderColumns := []string{
+			"id", "user_id", "type", "status", "created_at", "updated_at",
+			"gross_total", "discount_amount", "net_total", "applied_promotion_id",
+			"payment_method_id", "payment_gateway_reference", "cash_received", "change_due",
+			"cancellation_reason_id", "cancellation_notes", "payment_url", "payment_token", "version", "tax_amount", "service_charge_amount", "customer_id",
+			"tax_rate", "service_charge_rate", "tax_inclusive", "shift_id", "parent_order_id", "queue_number", "business_date", "applied_promotion_code_id",
+		}
+		payMethodID := int32(1)
+
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(pgx.Tx) error) error {
+				return fn(mockPgx)
+			},
+		)
+
+		// 1. GetOrderForUpdate (already cancelled but payment info retained)
+		mockPgx.ExpectQuery("SELECT .* FROM orders").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(orderColumns).AddRow(
+				orderID, pgtype.UUID{Bytes: userID, Valid: true},
+				orders_repo.OrderTypeDineIn, orders_repo.OrderStatusCancelled,
+				pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true},
+				int64(20000), int64(0), int64(20000), pgtype.UUID{},
+				&payMethodID, nil, nil, nil, nil, nil, nil, nil, int32(2), int64(0), int64(0), pgtype.UUID{}, pgtype.Numeric{}, pgtype.Numeric{}, false, pgtype.UUID{}, pgtype.UUID{}, nil, pgtype.Date{}, pgtype.UUID{},
 			))
 
 		_, err := service.RefundOrder(ctx, orderID, req)
 
+		assert.ErrorIs(t, err, common.ErrOrderNotModifiable)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+}
+
+func TestOrderService_ScanOrderItem(t *testing.T) {
+	orderID := uuid.New()
+	userID := uuid.New()
+	productID := uuid.New()
+
+	t.Run("CodeNotFound", func(t *testing.T) {
+		_, mockRepo, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+
+		mockRepo.EXPECT().GetProductByCode(gomock.Any(), "8991234567890").Return(orders_repo.GetProductByCodeRow{}, pgx.ErrNoRows)
+
+		resp, err := service.ScanOrderItem(ctx, orderID, orders.ScanOrderItemRequest{Code: " 8991234567890 "})
+
+		assert.ErrorIs(t, err, common.ErrProductCodeNotFound)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("LookupError", func(t *testing.T) {
+		_, mockRepo, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+
+		mockRepo.EXPECT().GetProductByCode(gomock.Any(), "SKU-1").Return(orders_repo.GetProductByCodeRow{}, errors.New("db down"))
+
+		resp, err := service.ScanOrderItem(ctx, orderID, orders.ScanOrderItemRequest{Code: "SKU-1"})
+
 		assert.Error(t, err)
-		assert.Equal(t, "only paid orders can be refunded", err.Error())
+		assert.Nil(t, resp)
+	})
+
+	t.Run("OrderNotModifiable", func(t *testing.T) {
+		mockStore, mockRepo, _, _, _, mockLogger, service := setupTest(t)
+		allowAllLoggerCalls(mockLogger)
+
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+
+		mockRepo.EXPECT().GetProductByCode(gomock.Any(), "SKU-1").Return(orders_repo.GetProductByCodeRow{
+			ProductID: productID,
+		}, nil)
+		mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).Return(common.ErrOrderNotModifiable)
+
+		resp, err := service.ScanOrderItem(ctx, orderID, orders.ScanOrderItemRequest{Code: "SKU-1"})
+
+		assert.ErrorIs(t, err, common.ErrOrderNotModifiable)
+		assert.Nil(t, resp)
 	})
 }
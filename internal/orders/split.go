@@ -0,0 +1,126 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// splitMove moves Quantity units of a parent order line into the child order at index Part.
+type splitMove struct {
+	Part     int
+	ItemID   uuid.UUID
+	Quantity int32
+}
+
+// planItemSplit turns explicitly selected lines and quantities into moves, one child order per part.
+// The parent must keep at least one unit so it never ends up empty.
+func planItemSplit(items []orders_repo.OrderItem, parts []SplitOrderPart) ([]splitMove, error) {
+	remaining := make(map[uuid.UUID]int32, len(items))
+	for _, item := range items {
+		remaining[item.ID] = item.Quantity
+	}
+
+	var moves []splitMove
+	for partIdx, part := range parts {
+		partQty := make(map[uuid.UUID]int32)
+		var order []uuid.UUID
+		for _, sel := range part.Items {
+			left, ok := remaining[sel.OrderItemID]
+			if !ok || sel.Quantity > left {
+				return nil, common.ErrOrderSplitInvalid
+			}
+			remaining[sel.OrderItemID] = left - sel.Quantity
+			if _, seen := partQty[sel.OrderItemID]; !seen {
+				order = append(order, sel.OrderItemID)
+			}
+			partQty[sel.OrderItemID] += sel.Quantity
+		}
+		for _, id := range order {
+			moves = append(moves, splitMove{Part: partIdx, ItemID: id, Quantity: partQty[id]})
+		}
+	}
+
+	var kept int32
+	for _, qty := range remaining {
+		kept += qty
+	}
+	if kept == 0 {
+		return nil, common.ErrOrderSplitInvalid
+	}
+	return moves, nil
+}
+
+// planEvenSplit spreads the order over `ways` bills (the parent plus ways-1 children). Items are
+// indivisible, so units are dealt most expensive first to whichever bill currently has the lowest
+// total, which keeps the bills as even as the items allow. An order with fewer units than ways is
+// rejected: a bill can't be left without items.
+func planEvenSplit(items []orders_repo.OrderItem, ways int) ([]splitMove, error) {
+	type unit struct {
+		itemIdx int
+		price   int64
+	}
+
+	var units []unit
+	for i, item := range items {
+		for q := int32(0); q < item.Quantity; q++ {
+			units = append(units, unit{itemIdx: i, price: item.PriceAtSale})
+		}
+	}
+	if ways < 2 {
+		return nil, common.ErrOrderSplitInvalid
+	}
+	if len(units) < ways {
+		return nil, fmt.Errorf("%w: the order has %d items, too few to split %d ways; take several payments on the one bill instead", common.ErrOrderSplitInvalid, len(units), ways)
+	}
+
+	sort.SliceStable(units, func(a, b int) bool { return units[a].price > units[b].price })
+
+	totals := make([]int64, ways)
+	counts := make([]int, ways)
+	assigned := make([]map[int]int32, ways)
+	for i := range assigned {
+		assigned[i] = make(map[int]int32)
+	}
+
+	for _, u := range units {
+		bill := 0
+		for b := 1; b < ways; b++ {
+			if totals[b] < totals[bill] || (totals[b] == totals[bill] && counts[b] < counts[bill]) {
+				bill = b
+			}
+		}
+		totals[bill] += u.price
+		counts[bill]++
+		assigned[bill][u.itemIdx]++
+	}
+
+	// Bill 0 stays on the parent order; the others become children in order
+	var moves []splitMove
+	for bill := 1; bill < ways; bill++ {
+		for i, item := range items {
+			if qty := assigned[bill][i]; qty > 0 {
+				moves = append(moves, splitMove{Part: bill - 1, ItemID: item.ID, Quantity: qty})
+			}
+		}
+	}
+	return moves, nil
+}
+
+// allocateSplitDiscount shares the parent's discount over the bills in proportion to their gross,
+// leaving the rounding remainder on the parent.
+func allocateSplitDiscount(discount, originalGross int64, childGross []int64) (parent int64, children []int64) {
+	children = make([]int64, len(childGross))
+	parent = discount
+	if discount <= 0 || originalGross <= 0 {
+		return parent, children
+	}
+	for i, gross := range childGross {
+		children[i] = discount * gross / originalGross
+		parent -= children[i]
+	}
+	return parent, children
+}
@@ -1,8 +1,38 @@
 -- name: CreateOrder :one
-INSERT INTO orders (user_id, type, customer_id )
-VALUES ($1, $2, $3 )
+-- Pesanan otomatis ditautkan ke shift kasir yang sedang terbuka.
+INSERT INTO orders (user_id, type, customer_id, shift_id, queue_number, business_date)
+VALUES ($1, $2, $3, (SELECT s.id FROM shifts s WHERE s.user_id = $1 AND s.status = 'open' LIMIT 1), $4, $5)
 RETURNING *;
 
+-- name: NextQueueNumber :one
+-- Mengambil nomor antrian berikutnya untuk hari bisnis; baris counter terkunci sampai transaksi selesai.
+INSERT INTO order_queue_counters (business_date, last_number)
+VALUES ($1, 1)
+ON CONFLICT (business_date) DO UPDATE
+SET last_number = order_queue_counters.last_number + 1,
+    updated_at = now()
+RETURNING last_number;
+
+-- name: ListCallingBoardOrders :many
+-- Pesanan hari bisnis ini yang masih ditunggu tamu, beserta ringkasan status dapur per pesanan.
+SELECT
+    o.id,
+    o.queue_number,
+    o.type,
+    o.created_at,
+    count(oi.id) AS total_items,
+    count(oi.id) FILTER (WHERE oi.prep_status IN ('ready', 'served')) AS ready_items,
+    count(oi.id) FILTER (WHERE oi.prep_status = 'served') AS served_items,
+    max(oi.ready_at)::timestamptz AS ready_at
+FROM orders o
+JOIN order_items oi ON oi.order_id = o.id
+WHERE o.business_date = $1
+  AND o.queue_number IS NOT NULL
+  AND o.status NOT IN ('served', 'cancelled')
+GROUP BY o.id
+HAVING count(oi.id) FILTER (WHERE oi.prep_status = 'served') < count(oi.id)
+ORDER BY o.created_at, o.id;
+
 -- name: DeleteOrderItemsByOrderID :exec
 DELETE FROM order_items WHERE order_id = $1;
 
@@ -16,9 +46,10 @@ INSERT INTO order_items (
     price_at_sale,
     subtotal,
     net_subtotal,
-    cost_price_at_sale
+    cost_price_at_sale,
+    variant_id
 ) VALUES (
-             $1, $2, $3, $4, $5, $6, $7
+             $1, $2, $3, $4, $5, $6, $7, $8
          ) RETURNING *;
 
 -- name: CreateOrderItemOption :one
@@ -31,6 +62,12 @@ INSERT INTO order_item_options (
              $1, $2, $3
          ) RETURNING *;
 
+-- name: GetOrder :one
+-- Mengambil satu pesanan tanpa mengunci barisnya, untuk pembacaan saja.
+SELECT * FROM orders
+WHERE id = $1
+LIMIT 1;
+
 -- name: GetOrderForUpdate :one
 -- Mengambil satu pesanan dan mengunci barisnya untuk pembaruan (mencegah race condition).
 -- Penting untuk digunakan di dalam transaksi sebelum mengupdate total.
@@ -48,6 +85,9 @@ SET
     net_total = $4,
     tax_amount = $5,
     service_charge_amount = $6,
+    tax_rate = $8,
+    service_charge_rate = $9,
+    tax_inclusive = $10,
     version = version + 1
 WHERE
     id = $1 AND version = $7
@@ -55,7 +95,8 @@ RETURNING *;
 
 -- name: UpdateOrderAppliedPromotion :exec
 UPDATE orders
-SET applied_promotion_id = $2
+SET applied_promotion_id = $2,
+    applied_promotion_code_id = $3
 WHERE id = $1;
 
 -- name: GetOrderWithDetails :one
@@ -72,7 +113,41 @@ SELECT
                       WHERE oi.order_id = o.id
                   ) AS items),
             '[]'::json
-    ) AS items
+    ) AS items,
+    COALESCE(
+            (SELECT json_agg(payments ORDER BY payments.created_at, payments.id)
+             FROM (
+                      SELECT
+                          op.*,
+                          pm.name AS payment_method_name
+                      FROM order_payments op
+                      JOIN payment_methods pm ON pm.id = op.payment_method_id
+                      WHERE op.order_id = o.id
+                  ) AS payments),
+            '[]'::json
+    ) AS payments,
+    ARRAY(
+        SELECT c.id FROM orders c
+        WHERE c.parent_order_id = o.id
+        ORDER BY c.created_at, c.id
+    )::uuid[] AS child_order_ids,
+    COALESCE(
+            (SELECT json_agg(refunds ORDER BY refunds.created_at, refunds.id)
+             FROM (
+                      SELECT
+                          r.*,
+                          (SELECT json_agg(ri.*) FROM order_refund_items ri WHERE ri.refund_id = r.id) AS items
+                      FROM order_refunds r
+                      WHERE r.order_id = o.id
+                  ) AS refunds),
+            '[]'::json
+    ) AS refunds,
+    COALESCE(
+            (SELECT json_agg(h.* ORDER BY h.created_at, h.id)
+             FROM order_status_history h
+             WHERE h.order_id = o.id),
+            '[]'::json
+    ) AS status_history
 FROM
     orders o
 WHERE
@@ -113,7 +188,8 @@ SELECT
     gross_total,
     net_total,
     created_at,
-    payment_method_id
+    payment_method_id,
+    queue_number
 FROM orders
 WHERE
     (sqlc.narg(statuses)::text[] IS NULL OR status = ANY(sqlc.narg(statuses)::text[]::order_status[]))
@@ -210,16 +286,15 @@ RETURNING *;
 -- Memperbarui status operasional sebuah pesanan.
 -- Validasi transisi status dilakukan di level aplikasi/service.
 UPDATE orders
-SET status = $2
+SET status = $2,
+    version = version + 1
 WHERE id = $1
 RETURNING *;
 -- name: RefundOrder :one
+-- Data pembayaran dipertahankan agar rekonsiliasi shift tetap mencatat penjualan aslinya.
 UPDATE orders
 SET
     status = 'cancelled',
-    payment_method_id = NULL,
-    cash_received = NULL,
-    change_due = NULL,
     version = version + 1
 WHERE
     id = $1
@@ -245,7 +320,8 @@ INSERT INTO order_items (
     price_at_sale,
     subtotal,
     net_subtotal,
-    cost_price_at_sale
+    cost_price_at_sale,
+    variant_id
 )
 SELECT
     sqlc.arg(order_id) AS order_id,
@@ -254,7 +330,9 @@ SELECT
     unnest(sqlc.arg(prices_at_sale)::numeric[]) AS price_at_sale,
     unnest(sqlc.arg(subtotals)::numeric[]) AS subtotal,
     unnest(sqlc.arg(net_subtotals)::numeric[]) AS net_subtotal,
-    unnest(sqlc.arg(cost_prices_at_sale)::numeric[]) AS cost_price_at_sale
+    unnest(sqlc.arg(cost_prices_at_sale)::numeric[]) AS cost_price_at_sale,
+    -- uuid.Nil marks a line without a variant
+    NULLIF(unnest(sqlc.arg(variant_ids)::uuid[]), '00000000-0000-0000-0000-000000000000'::uuid) AS variant_id
 RETURNING *;
 
 -- name: BatchDecreaseProductStock :exec
@@ -292,9 +370,24 @@ SET
 WHERE
     id = $1;
 
+-- name: ClearOrderPaymentGateway :exec
+-- Menghapus tagihan payment gateway yang masih tertunda dari pesanan.
+UPDATE orders
+SET
+    payment_gateway_reference = NULL,
+    payment_url = NULL,
+    payment_token = NULL
+WHERE
+    id = $1;
+
 -- name: GetProductByID :one
 SELECT * FROM products WHERE id = $1;
 
+-- name: GetProductCategoryIDs :many
+-- Mengambil pasangan produk-kategori untuk menentukan item yang bebas pajak.
+SELECT product_id, category_id FROM product_categories
+WHERE product_id = ANY(sqlc.arg(product_ids)::uuid[]);
+
 -- name: GetPromotionByID :one
 SELECT * FROM promotions WHERE id = $1;
 
@@ -304,6 +397,21 @@ SELECT * FROM promotion_rules WHERE promotion_id = $1;
 -- name: GetPromotionTargets :many
 SELECT * FROM promotion_targets WHERE promotion_id = $1;
 
+-- name: GetPromotionTiers :many
+SELECT * FROM promotion_tiers WHERE promotion_id = $1 ORDER BY min_amount;
+
+-- name: GetPromotionSchedules :many
+-- Mengambil jadwal berulang sebuah promosi.
+SELECT * FROM promotion_schedules
+WHERE promotion_id = $1
+ORDER BY start_time, id;
+
+-- name: GetPromotionExcludedDates :many
+-- Mengambil tanggal-tanggal di mana promosi tidak berlaku.
+SELECT * FROM promotion_excluded_dates
+WHERE promotion_id = $1
+ORDER BY excluded_date;
+
 -- name: CreateStockHistory :one
 INSERT INTO stock_history (
     product_id,
@@ -313,7 +421,392 @@ INSERT INTO stock_history (
     change_type,
     reference_id,
     note,
+    created_by,
+    variant_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetVariantsForOrder :many
+-- Mengunci varian aktif dari produk-produk pada pesanan; urutan id mencegah deadlock antar transaksi.
+SELECT * FROM product_variants
+WHERE product_id = ANY(sqlc.arg(product_ids)::uuid[]) AND deleted_at IS NULL
+ORDER BY id
+FOR UPDATE;
+
+-- name: DecreaseVariantStock :one
+-- Mengurangi stok varian; stok produk induk diperbarui oleh trigger.
+UPDATE product_variants
+SET stock = stock - sqlc.arg(quantity)::int
+WHERE id = sqlc.arg(id)
+RETURNING stock;
+
+-- name: AddVariantStock :one
+-- Menambahkan stok varian kembali (pembatalan, refund, pengurangan qty).
+UPDATE product_variants
+SET stock = stock + sqlc.arg(quantity)::int
+WHERE id = sqlc.arg(id)
+RETURNING stock;
+
+-- name: CreateOrderRefund :one
+-- Mencatat pengembalian dana pada shift kasir yang memprosesnya (atau shift asal pesanan).
+INSERT INTO order_refunds (
+    order_id, shift_id, payment_method_id, order_payment_id, amount, reason, created_by
+) VALUES (
+    sqlc.arg(order_id),
+    COALESCE(
+        (SELECT s.id FROM shifts s WHERE s.user_id = sqlc.narg(created_by) AND s.status = 'open' LIMIT 1),
+        sqlc.narg(order_shift_id)::uuid
+    ),
+    sqlc.narg(payment_method_id),
+    sqlc.narg(order_payment_id),
+    sqlc.arg(amount),
+    sqlc.narg(reason),
+    sqlc.narg(created_by)
+) RETURNING *;
+
+-- name: CreateOrderRefundItem :one
+-- Mencatat baris item (dan jumlahnya) yang dikembalikan dalam satu refund.
+INSERT INTO order_refund_items (
+    refund_id, order_item_id, quantity, amount, restocked
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetRefundedItemQuantities :many
+-- Menjumlahkan kuantitas yang sudah direfund (dan yang dikembalikan ke stok) per baris item sebuah pesanan.
+SELECT
+    ri.order_item_id,
+    COALESCE(SUM(ri.quantity), 0)::int AS refunded_quantity,
+    COALESCE(SUM(ri.quantity) FILTER (WHERE ri.restocked), 0)::int AS restocked_quantity
+FROM order_refund_items ri
+JOIN order_refunds r ON r.id = ri.refund_id
+WHERE r.order_id = $1
+GROUP BY ri.order_item_id;
+
+-- name: ListOrderRefunds :many
+-- Mengambil semua refund sebuah pesanan (untuk menghitung sisa dana per tender).
+SELECT * FROM order_refunds
+WHERE order_id = $1
+ORDER BY created_at, id;
+
+-- name: GetPaymentMethodByID :one
+-- Mengambil metode pembayaran beserta jenis dan flag-nya untuk validasi pembayaran.
+SELECT * FROM payment_methods
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetActivePaymentMethodByKind :one
+-- Mengambil metode pembayaran aktif pertama untuk jenis tertentu (mis. 'gateway' untuk Midtrans).
+SELECT * FROM payment_methods
+WHERE kind = $1 AND is_active = true
+ORDER BY sort_order, id
+LIMIT 1;
+
+-- name: CreateOrderPayment :one
+-- Mencatat satu baris tender (split payment) pada shift kasir yang memprosesnya (atau shift asal pesanan).
+INSERT INTO order_payments (
+    order_id, payment_method_id, amount, tendered_amount, change_amount, reference_number, shift_id, created_by
+) VALUES (
+    sqlc.arg(order_id),
+    sqlc.arg(payment_method_id),
+    sqlc.arg(amount),
+    sqlc.arg(tendered_amount),
+    sqlc.arg(change_amount),
+    sqlc.narg(reference_number),
+    COALESCE(
+        (SELECT s.id FROM shifts s WHERE s.user_id = sqlc.narg(created_by) AND s.status = 'open' LIMIT 1),
+        sqlc.narg(order_shift_id)::uuid
+    ),
+    sqlc.narg(created_by)
+) RETURNING *;
+
+-- name: ListOrderPayments :many
+-- Mengambil semua baris tender sebuah pesanan sesuai urutan pembayaran.
+SELECT * FROM order_payments
+WHERE order_id = $1
+ORDER BY created_at, id;
+
+-- name: BumpOrderVersion :one
+-- Menaikkan versi pesanan setelah pembayaran parsial (optimistic locking).
+UPDATE orders
+SET version = version + 1
+WHERE id = $1 AND version = $2
+RETURNING *;
+
+-- name: CreateSplitOrder :one
+-- Membuat pesanan anak hasil split bill; kasir, jenis, pelanggan, shift, dan nomor antrian mengikuti pesanan induk.
+-- Bill yang dipecah lagi tetap menjadi anak pesanan asalnya.
+INSERT INTO orders (user_id, type, customer_id, shift_id, parent_order_id, queue_number, business_date)
+SELECT p.user_id, p.type, p.customer_id, p.shift_id, COALESCE(p.parent_order_id, p.id), p.queue_number, p.business_date
+FROM orders p
+WHERE p.id = $1
+RETURNING *;
+
+-- name: MoveOrderItem :exec
+-- Memindahkan satu baris item (beserta opsinya) ke pesanan lain.
+UPDATE order_items
+SET order_id = sqlc.arg(target_order_id)
+WHERE id = sqlc.arg(id) AND order_id = sqlc.arg(order_id);
+
+-- name: CopyOrderItemOptions :exec
+-- Menyalin opsi dari satu baris item ke baris item lain (dipakai saat memecah kuantitas).
+INSERT INTO order_item_options (order_item_id, product_option_id, price_at_sale)
+SELECT sqlc.arg(target_order_item_id)::uuid, src.product_option_id, src.price_at_sale
+FROM order_item_options src
+WHERE src.order_item_id = sqlc.arg(source_order_item_id)::uuid;
+
+-- name: GetCancellationReasonByReason :one
+-- Mengambil alasan pembatalan sistem (mis. 'Order Merged') berdasarkan teksnya.
+SELECT * FROM cancellation_reasons
+WHERE reason = $1
+LIMIT 1;
+
+-- name: CreateOrderStatusHistory :exec
+-- Mencatat satu transisi status pesanan (from_status NULL untuk pesanan baru).
+INSERT INTO order_status_history (
+    order_id, from_status, to_status, changed_by, note
+) VALUES (
+    sqlc.arg(order_id),
+    sqlc.narg(from_status),
+    sqlc.arg(to_status),
+    sqlc.narg(changed_by),
+    sqlc.narg(note)
+);
+
+-- name: GetProductRecipes :many
+-- Mengambil resep (bahan baku per unit) untuk beberapa produk.
+SELECT * FROM product_recipe_items
+WHERE product_id = ANY(sqlc.arg(product_ids)::uuid[]);
+
+-- name: GetOptionRecipes :many
+-- Mengambil tambahan/pengganti bahan baku untuk beberapa opsi produk.
+SELECT * FROM product_option_recipe_items
+WHERE product_option_id = ANY(sqlc.arg(option_ids)::uuid[]);
+
+-- name: ListOrderItemOptionIDs :many
+-- Opsi yang dipilih per baris item sebuah pesanan, untuk menghitung bahan baku yang dipakai.
+SELECT oio.order_item_id, oio.product_option_id
+FROM order_item_options oio
+JOIN order_items oi ON oi.id = oio.order_item_id
+WHERE oi.order_id = $1;
+
+-- name: GetIngredientsForUpdate :many
+-- Mengunci bahan baku yang dipakai pesanan; urutan id mencegah deadlock antar transaksi.
+SELECT * FROM ingredients
+WHERE id = ANY(sqlc.arg(ids)::uuid[]) AND deleted_at IS NULL
+ORDER BY id
+FOR UPDATE;
+
+-- name: AdjustIngredientStock :exec
+-- Menambah (positif) atau mengurangi (negatif) stok bahan baku.
+UPDATE ingredients
+SET stock = stock + sqlc.arg(change_amount)::int
+WHERE id = sqlc.arg(id);
+
+-- name: CreateIngredientStockHistory :one
+INSERT INTO ingredient_stock_history (
+    ingredient_id,
+    change_amount,
+    previous_stock,
+    current_stock,
+    change_type,
+    reference_id,
+    note,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: SetOrderItemCost :exec
+-- Menyimpan harga pokok per unit sebuah item pesanan sesuai lapisan biaya yang dipakai (metode FIFO).
+UPDATE order_items
+SET cost_price_at_sale = $2
+WHERE id = $1;
+
+-- name: GetOrderableOptions :many
+-- Opsi aktif beberapa produk beserta aturan grup modifiernya; kolom grup kosong bila opsi tidak masuk grup.
+SELECT po.id, po.product_id, po.name, po.additional_price, po.is_default,
+       mg.id AS group_id, mg.name AS group_name, mg.min_select, mg.max_select
+FROM product_options po
+LEFT JOIN modifier_groups mg ON mg.id = po.modifier_group_id AND mg.deleted_at IS NULL
+WHERE po.product_id = ANY(sqlc.arg(product_ids)::uuid[]) AND po.deleted_at IS NULL
+ORDER BY po.product_id, mg.name, po.name;
+
+-- name: GetProductByCode :one
+-- Produk (atau varian) untuk kode yang dipindai: barcode lebih dulu, lalu SKU tanpa membedakan huruf besar/kecil.
+WITH matches AS (
+    SELECT pb.product_id, pb.variant_id, 1 AS rank
+    FROM product_barcodes pb
+    WHERE pb.code = sqlc.arg(code)
+    UNION ALL
+    SELECT pv.product_id, pv.id, 1
+    FROM product_variants pv
+    WHERE pv.barcode = sqlc.arg(code) AND pv.deleted_at IS NULL
+    UNION ALL
+    SELECT pv.product_id, pv.id, 2
+    FROM product_variants pv
+    WHERE lower(pv.sku) = lower(sqlc.arg(code)) AND pv.deleted_at IS NULL
+    UNION ALL
+    SELECT p.id, NULL::uuid, 2
+    FROM products p
+    WHERE lower(p.sku) = lower(sqlc.arg(code)) AND p.deleted_at IS NULL
+)
+SELECT m.product_id, m.variant_id
+FROM matches m
+JOIN products p ON p.id = m.product_id AND p.deleted_at IS NULL
+LEFT JOIN product_variants pv ON pv.id = m.variant_id AND pv.deleted_at IS NULL
+WHERE m.variant_id IS NULL OR pv.id IS NOT NULL
+ORDER BY m.rank
+LIMIT 1;
+
+-- name: GetActivePromotionCode :one
+-- Mencari kode promosi yang masih aktif (tidak peka huruf besar/kecil).
+SELECT * FROM promotion_codes
+WHERE code = upper($1) AND deactivated_at IS NULL
+LIMIT 1;
+
+-- name: GetPromotionCodeByID :one
+SELECT * FROM promotion_codes
+WHERE id = $1
+LIMIT 1;
+
+-- name: PromotionHasCodes :one
+-- Memeriksa apakah promosi punya kode; promosi berkode hanya bisa dipakai lewat kodenya.
+SELECT EXISTS(SELECT 1 FROM promotion_codes WHERE promotion_id = $1);
+
+-- name: LockPromotion :one
+-- Mengunci baris promosi agar pengecekan batas penukaran dan pencatatannya tidak balapan.
+SELECT id AS promotion_id FROM promotions
+WHERE id = $1
+    FOR UPDATE;
+
+-- name: GetPromotionRedemptionStats :one
+-- Menghitung penukaran aktif sebuah promosi: total, per pelanggan, per kode, dan total diskonnya.
+-- Bill hasil split dihitung sebagai satu penukaran, dan keluarga pesanan yang sedang diperiksa tidak ikut dihitung.
+SELECT
+    COUNT(DISTINCT COALESCE(o.parent_order_id, o.id)) FILTER (WHERE COALESCE(o.parent_order_id, o.id) IS DISTINCT FROM sqlc.narg(order_family_id)) AS total_redemptions,
+    COUNT(DISTINCT COALESCE(o.parent_order_id, o.id)) FILTER (WHERE pr.customer_id = sqlc.narg(customer_id) AND COALESCE(o.parent_order_id, o.id) IS DISTINCT FROM sqlc.narg(order_family_id)) AS customer_redemptions,
+    COUNT(DISTINCT COALESCE(o.parent_order_id, o.id)) FILTER (WHERE pr.promotion_code_id = sqlc.narg(promotion_code_id) AND COALESCE(o.parent_order_id, o.id) IS DISTINCT FROM sqlc.narg(order_family_id)) AS code_redemptions,
+    COALESCE(SUM(pr.discount_amount), 0)::bigint AS discount_total
+FROM promotion_redemptions pr
+JOIN orders o ON o.id = pr.order_id
+WHERE pr.promotion_id = sqlc.arg(promotion_id) AND pr.status = 'redeemed';
+
+-- name: CreatePromotionRedemption :exec
+-- Mencatat penukaran promosi saat pesanan lunas; promosi pesanan yang sudah tercatat dilewati.
+INSERT INTO promotion_redemptions (promotion_id, promotion_code_id, order_id, customer_id, discount_amount)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (order_id, promotion_id) WHERE status = 'redeemed' DO NOTHING;
+
+-- name: ReleasePromotionRedemptions :exec
+-- Melepas penukaran promosi sebuah pesanan yang dibatalkan atau di-refund penuh.
+UPDATE promotion_redemptions
+SET status = 'released', released_at = NOW()
+WHERE order_id = $1 AND status = 'redeemed';
+
+-- name: SetOrderItemDiscounts :exec
+-- Menyimpan bagian diskon promosi per baris item; net_subtotal dihitung ulang dari subtotal.
+UPDATE order_items oi
+SET discount_amount = d.discount_amount,
+    net_subtotal = oi.subtotal - d.discount_amount
+FROM (
+    SELECT unnest(sqlc.arg(ids)::uuid[]) AS id,
+           unnest(sqlc.arg(discount_amounts)::bigint[]) AS discount_amount
+) d
+WHERE oi.id = d.id AND oi.order_id = sqlc.arg(order_id);
+
+-- name: ListActivePromotions :many
+-- Mengambil promosi yang aktif dan sedang berlaku, dari prioritas tertinggi.
+SELECT * FROM promotions
+WHERE is_active = true
+  AND deleted_at IS NULL
+  AND start_date <= NOW()
+  AND end_date >= NOW()
+ORDER BY priority DESC, id;
+
+-- name: GetOrderPromotions :many
+-- Mengambil promosi yang diterapkan pada pesanan beserta bagian diskonnya, sesuai urutan penerapan.
+SELECT
+    op.order_id, op.promotion_id, op.promotion_code_id, op.discount_amount, op.auto_applied, op.position, op.created_at, op.schedule_window,
+    p.name AS promotion_name, p.stacking, p.priority,
+    pc.code
+FROM order_promotions op
+JOIN promotions p ON p.id = op.promotion_id
+LEFT JOIN promotion_codes pc ON pc.id = op.promotion_code_id
+WHERE op.order_id = $1
+ORDER BY op.position;
+
+-- name: DeleteOrderPromotions :exec
+-- Menghapus promosi pesanan sebelum hasil evaluasi terbaru disimpan.
+DELETE FROM order_promotions
+WHERE order_id = $1;
+
+-- name: CreateOrderPromotion :exec
+-- Menyimpan satu promosi yang diterapkan pada pesanan beserta bagian diskonnya dan jendela jadwal saat diterapkan.
+INSERT INTO order_promotions (order_id, promotion_id, promotion_code_id, discount_amount, auto_applied, position, schedule_window)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: UpdateOrderPromotionDiscount :exec
+-- Memperbarui bagian diskon sebuah promosi pesanan, misalnya setelah pesanan dipecah.
+UPDATE order_promotions
+SET discount_amount = $3
+WHERE order_id = $1 AND promotion_id = $2;
+
+-- name: LockCustomer :one
+-- Mengunci baris pelanggan agar perolehan dan penukaran poinnya tidak balapan.
+SELECT id AS customer_id FROM customers
+WHERE id = $1
+    FOR UPDATE;
+
+-- name: GetCustomerPointsBalance :one
+-- Menghitung saldo poin pelanggan; sisa poin yang sudah kedaluwarsa tidak dihitung walau belum dicatat.
+SELECT (
+    COALESCE(SUM(points), 0) - COALESCE(SUM(remaining) FILTER (WHERE expires_at <= NOW()), 0)
+)::bigint AS balance
+FROM loyalty_point_entries
+WHERE customer_id = $1;
+
+-- name: ListCustomerPointLots :many
+-- Mengambil poin pelanggan yang masih tersisa dan belum kedaluwarsa, yang paling cepat kedaluwarsa lebih dulu.
+SELECT * FROM loyalty_point_entries
+WHERE customer_id = $1
+  AND remaining > 0
+  AND (expires_at IS NULL OR expires_at > NOW())
+ORDER BY expires_at NULLS LAST, created_at, id
+    FOR UPDATE;
+
+-- name: ConsumePointLot :exec
+-- Mengurangi sisa poin sebuah perolehan saat poinnya ditukar atau ditarik kembali.
+UPDATE loyalty_point_entries
+SET remaining = remaining - $2
+WHERE id = $1;
+
+-- name: CreateLoyaltyPointEntry :one
+-- Mencatat satu baris buku poin pelanggan.
+INSERT INTO loyalty_point_entries (
+    customer_id,
+    order_id,
+    entry_type,
+    redeemed_as,
+    points,
+    remaining,
+    amount,
+    expires_at,
+    description,
     created_by
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 ) RETURNING *;
+
+-- name: GetOrderLoyaltySummary :one
+-- Merangkum poin sebuah pesanan: yang diperoleh dan yang sudah ditarik kembali, serta yang ditukar sebagai diskon atau pembayaran.
+SELECT
+    COALESCE(SUM(points) FILTER (WHERE entry_type = 'earn'), 0)::bigint AS points_earned,
+    COALESCE(-SUM(points) FILTER (WHERE entry_type = 'reversal' AND redeemed_as IS NULL), 0)::bigint AS points_earned_reversed,
+    COALESCE(-SUM(points) FILTER (WHERE redeemed_as = 'discount'), 0)::bigint AS discount_points,
+    COALESCE(SUM(CASE WHEN entry_type = 'redeem' THEN amount ELSE -amount END) FILTER (WHERE redeemed_as = 'discount'), 0)::bigint AS discount_amount,
+    COALESCE(-SUM(points) FILTER (WHERE redeemed_as = 'tender'), 0)::bigint AS tender_points,
+    COALESCE(SUM(CASE WHEN entry_type = 'redeem' THEN amount ELSE -amount END) FILTER (WHERE redeemed_as = 'tender'), 0)::bigint AS tender_amount
+FROM loyalty_point_entries
+WHERE order_id = $1;
@@ -0,0 +1,147 @@
+package orders
+
+import (
+	"POS-kasir/internal/common"
+	orders_repo "POS-kasir/internal/orders/repository"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// variantBook holds the active variants of the products on an order, locked for the transaction. A product
+// with variants is sold and stocked per variant; its own stock is kept as the sum of its variants.
+type variantBook struct {
+	variants map[uuid.UUID]orders_repo.ProductVariant
+	counts   map[uuid.UUID]int
+}
+
+func loadVariantBook(ctx context.Context, qtx *orders_repo.Queries, productIDs []uuid.UUID) (*variantBook, error) {
+	book := &variantBook{
+		variants: make(map[uuid.UUID]orders_repo.ProductVariant),
+		counts:   make(map[uuid.UUID]int),
+	}
+	if len(productIDs) == 0 {
+		return book, nil
+	}
+
+	variants, err := qtx.GetVariantsForOrder(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock product variants: %w", err)
+	}
+	for _, v := range variants {
+		book.variants[v.ID] = v
+		book.counts[v.ProductID]++
+	}
+	return book, nil
+}
+
+// lineKey identifies an order line by what it sells; uuid.Nil stands for no variant.
+type lineKey struct {
+	ProductID uuid.UUID
+	VariantID uuid.UUID
+}
+
+func itemLineKey(item orders_repo.OrderItem) lineKey {
+	key := lineKey{ProductID: item.ProductID}
+	if item.VariantID.Valid {
+		key.VariantID = item.VariantID.Bytes
+	}
+	return key
+}
+
+// requestLineKey identifies the order line a requested item refers to.
+func requestLineKey(productID uuid.UUID, variantID *uuid.UUID) lineKey {
+	key := lineKey{ProductID: productID}
+	if variantID != nil {
+		key.VariantID = *variantID
+	}
+	return key
+}
+
+// resolve returns the variant a line of the product is sold as, or nil for a product without variants.
+func (b *variantBook) resolve(productID uuid.UUID, variantID *uuid.UUID) (*orders_repo.ProductVariant, error) {
+	if variantID == nil {
+		if b.counts[productID] > 0 {
+			return nil, common.ErrVariantRequired
+		}
+		return nil, nil
+	}
+	v, ok := b.variants[*variantID]
+	if !ok || v.ProductID != productID {
+		return nil, common.ErrVariantNotFound
+	}
+	return &v, nil
+}
+
+// take sells quantity units of a variant and records the sale in stock_history.
+func (b *variantBook) take(ctx context.Context, qtx *orders_repo.Queries, variantID uuid.UUID, quantity int32, orderID uuid.UUID, createdBy pgtype.UUID, note string) (orders_repo.ProductVariant, error) {
+	v := b.variants[variantID]
+	if v.Stock < quantity {
+		return v, fmt.Errorf("insufficient stock for %s: available %d, requested %d", v.Name, v.Stock, quantity)
+	}
+
+	current, err := qtx.DecreaseVariantStock(ctx, orders_repo.DecreaseVariantStockParams{Quantity: quantity, ID: variantID})
+	if err != nil {
+		return v, fmt.Errorf("failed to update stock of variant %s: %w", variantID, err)
+	}
+	if _, err := qtx.CreateStockHistory(ctx, orders_repo.CreateStockHistoryParams{
+		ProductID:     v.ProductID,
+		ChangeAmount:  -quantity,
+		PreviousStock: v.Stock,
+		CurrentStock:  current,
+		ChangeType:    orders_repo.StockChangeTypeSale,
+		ReferenceID:   pgtype.UUID{Bytes: orderID, Valid: true},
+		Note:          &note,
+		CreatedBy:     createdBy,
+		VariantID:     pgtype.UUID{Bytes: variantID, Valid: true},
+	}); err != nil {
+		return v, fmt.Errorf("failed to log stock history for variant %s: %w", variantID, err)
+	}
+
+	previous := v
+	v.Stock = current
+	b.variants[variantID] = v
+	return previous, nil
+}
+
+// returnVariantStock puts quantity units of an order line back on its variant's stock.
+func returnVariantStock(ctx context.Context, qtx *orders_repo.Queries, item orders_repo.OrderItem, quantity int32, changeType orders_repo.StockChangeType, createdBy pgtype.UUID, note string) error {
+	variantID := uuid.UUID(item.VariantID.Bytes)
+	current, err := qtx.AddVariantStock(ctx, orders_repo.AddVariantStockParams{Quantity: quantity, ID: variantID})
+	if err != nil {
+		return fmt.Errorf("failed to return stock to variant %s: %w", variantID, err)
+	}
+	if _, err := qtx.CreateStockHistory(ctx, orders_repo.CreateStockHistoryParams{
+		ProductID:     item.ProductID,
+		ChangeAmount:  quantity,
+		PreviousStock: current - quantity,
+		CurrentStock:  current,
+		ChangeType:    changeType,
+		ReferenceID:   pgtype.UUID{Bytes: item.OrderID, Valid: true},
+		Note:          &note,
+		CreatedBy:     createdBy,
+		VariantID:     item.VariantID,
+	}); err != nil {
+		return fmt.Errorf("failed to log stock history for variant %s: %w", variantID, err)
+	}
+	return nil
+}
+
+// variantPrice is what a unit of the product sells for as this variant, before options.
+func variantPrice(productPrice int64, v *orders_repo.ProductVariant) int64 {
+	if v != nil && v.Price != nil {
+		return *v.Price
+	}
+	return productPrice
+}
+
+// appendVariantStockAlert is appendStockAlert for a variant that a sale has just taken down to the threshold.
+func appendVariantStockAlert(alerts []StockAlert, productName string, v orders_repo.ProductVariant, currentStock int32) []StockAlert {
+	if v.Stock > LowStockThreshold && currentStock <= LowStockThreshold {
+		variantID := v.ID
+		alerts = append(alerts, StockAlert{ProductID: v.ProductID, ProductName: productName, VariantID: &variantID, VariantName: v.Name, Stock: currentStock})
+	}
+	return alerts
+}
@@ -1,10 +1,44 @@
 package payment_methods
 
-import "time"
+import (
+	"POS-kasir/internal/payment_methods/repository"
+	"time"
+)
 
 type PaymentMethodResponse struct {
-	ID        int32     `json:"id"`
-	Name      string    `json:"name"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
+	ID                int32                        `json:"id"`
+	Name              string                       `json:"name"`
+	Kind              repository.PaymentMethodKind `json:"kind"`
+	SortOrder         int32                        `json:"sort_order"`
+	OpensCashDrawer   bool                         `json:"opens_cash_drawer"`
+	RequiresReference bool                         `json:"requires_reference"`
+	AllowsChange      bool                         `json:"allows_change"`
+	IsActive          bool                         `json:"is_active"`
+	CreatedAt         time.Time                    `json:"created_at"`
+	UpdatedAt         time.Time                    `json:"updated_at"`
+}
+
+type ListPaymentMethodsRequest struct {
+	IncludeInactive bool `query:"include_inactive"`
+}
+
+type CreatePaymentMethodRequest struct {
+	Name              string                       `json:"name" validate:"required,min=2,max=50"`
+	Kind              repository.PaymentMethodKind `json:"kind" validate:"required,oneof=cash card e_wallet gateway voucher on_account loyalty_points"`
+	OpensCashDrawer   bool                         `json:"opens_cash_drawer"`
+	RequiresReference bool                         `json:"requires_reference"`
+	AllowsChange      bool                         `json:"allows_change"`
+}
+
+type UpdatePaymentMethodRequest struct {
+	Name              *string                       `json:"name" validate:"omitempty,min=2,max=50"`
+	Kind              *repository.PaymentMethodKind `json:"kind" validate:"omitempty,oneof=cash card e_wallet gateway voucher on_account loyalty_points"`
+	OpensCashDrawer   *bool                         `json:"opens_cash_drawer"`
+	RequiresReference *bool                         `json:"requires_reference"`
+	AllowsChange      *bool                         `json:"allows_change"`
+	IsActive          *bool                         `json:"is_active"`
+}
+
+type ReorderPaymentMethodsRequest struct {
+	IDs []int32 `json:"ids" validate:"required,min=1,dive,gt=0"`
 }
@@ -3,12 +3,19 @@ package payment_methods
 import (
 	"POS-kasir/internal/common"
 	"POS-kasir/pkg/logger"
+	"POS-kasir/pkg/validator"
+	"errors"
 
 	"github.com/gofiber/fiber/v3"
 )
 
 type IPaymentMethodHandler interface {
 	ListPaymentMethodsHandler(c fiber.Ctx) error
+	GetPaymentMethodHandler(c fiber.Ctx) error
+	CreatePaymentMethodHandler(c fiber.Ctx) error
+	UpdatePaymentMethodHandler(c fiber.Ctx) error
+	ReorderPaymentMethodsHandler(c fiber.Ctx) error
+	DeletePaymentMethodHandler(c fiber.Ctx) error
 }
 
 type PaymentMethodHandler struct {
@@ -22,16 +29,30 @@ func NewPaymentMethodHandler(service IPaymentMethodService, log logger.ILogger)
 
 // ListPaymentMethodsHandler
 // @Summary      List payment methods
-// @Description  Get a list of all active payment methods (e.g., Cash, QRIS)
+// @Description  Get a list of all active payment methods (e.g., Cash, QRIS). Set include_inactive=true to also return deactivated methods.
 // @Tags         Payment Methods
 // @Accept       json
 // @Produce      json
+// @Param        include_inactive query bool false "Include deactivated payment methods"
 // @Success      200 {object} common.SuccessResponse{data=[]PaymentMethodResponse} "List of payment methods retrieved successfully"
 // @Failure      500 {object} common.ErrorResponse "Internal Server Error"
 // @x-roles      ["admin", "manager", "cashier"]
 // @Router       /payment-methods [get]
 func (h *PaymentMethodHandler) ListPaymentMethodsHandler(c fiber.Ctx) error {
-	methods, err := h.service.ListPaymentMethods(c.RequestCtx())
+	var req ListPaymentMethodsRequest
+	if err := c.Bind().Query(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid query parameters"})
+	}
+
+	var (
+		methods []PaymentMethodResponse
+		err     error
+	)
+	if req.IncludeInactive {
+		methods, err = h.service.ListAllPaymentMethods(c.RequestCtx())
+	} else {
+		methods, err = h.service.ListPaymentMethods(c.RequestCtx())
+	}
 	if err != nil {
 		h.log.Error("Failed to get payment methods from service", "error", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve payment methods"})
@@ -42,3 +63,208 @@ func (h *PaymentMethodHandler) ListPaymentMethodsHandler(c fiber.Ctx) error {
 		Data:    methods,
 	})
 }
+
+// GetPaymentMethodHandler
+// @Summary      Get payment method by ID
+// @Description  Get a single payment method including its kind and flags (Roles: admin, manager, cashier)
+// @Tags         Payment Methods
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Payment Method ID"
+// @Success      200 {object} common.SuccessResponse{data=PaymentMethodResponse} "Payment method retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid payment method ID"
+// @Failure      404 {object} common.ErrorResponse "Payment method not found"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /payment-methods/{id} [get]
+func (h *PaymentMethodHandler) GetPaymentMethodHandler(c fiber.Ctx) error {
+	id := fiber.Params[int](c, "id")
+	if id == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid payment method ID format. ID must be a number."})
+	}
+
+	method, err := h.service.GetPaymentMethodByID(c.RequestCtx(), int32(id))
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Payment method not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve payment method"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Payment method retrieved successfully",
+		Data:    method,
+	})
+}
+
+// CreatePaymentMethodHandler
+// @Summary      Create payment method
+// @Description  Create a new payment method with its kind and behaviour flags (Roles: admin)
+// @Tags         Payment Methods
+// @Accept       json
+// @Produce      json
+// @Param        request body CreatePaymentMethodRequest true "Payment method details"
+// @Success      201 {object} common.SuccessResponse{data=PaymentMethodResponse} "Payment method created successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body"
+// @Failure      409 {object} common.ErrorResponse "Payment method with this name already exists"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin"]
+// @Router       /payment-methods [post]
+func (h *PaymentMethodHandler) CreatePaymentMethodHandler(c fiber.Ctx) error {
+	var req CreatePaymentMethodRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("CreatePaymentMethodHandler | Failed to parse request body: %v", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data:    map[string]interface{}{"errors": ve.Errors},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+	}
+
+	method, err := h.service.CreatePaymentMethod(c.RequestCtx(), req)
+	if err != nil {
+		if errors.Is(err, common.ErrPaymentMethodExists) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to create payment method"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(common.SuccessResponse{
+		Message: "Payment method created successfully",
+		Data:    method,
+	})
+}
+
+// UpdatePaymentMethodHandler
+// @Summary      Update payment method
+// @Description  Rename, change kind or flags, and activate or deactivate a payment method (Roles: admin)
+// @Tags         Payment Methods
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Payment Method ID"
+// @Param        request body UpdatePaymentMethodRequest true "Fields to update"
+// @Success      200 {object} common.SuccessResponse{data=PaymentMethodResponse} "Payment method updated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body"
+// @Failure      404 {object} common.ErrorResponse "Payment method not found"
+// @Failure      409 {object} common.ErrorResponse "Payment method with this name already exists"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin"]
+// @Router       /payment-methods/{id} [put]
+func (h *PaymentMethodHandler) UpdatePaymentMethodHandler(c fiber.Ctx) error {
+	id := fiber.Params[int](c, "id")
+	if id == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid payment method ID format. ID must be a number."})
+	}
+
+	var req UpdatePaymentMethodRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("UpdatePaymentMethodHandler | Failed to parse request body: %v", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data:    map[string]interface{}{"errors": ve.Errors},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+	}
+
+	method, err := h.service.UpdatePaymentMethod(c.RequestCtx(), int32(id), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Payment method not found"})
+		case errors.Is(err, common.ErrPaymentMethodExists):
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to update payment method"})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Payment method updated successfully",
+		Data:    method,
+	})
+}
+
+// ReorderPaymentMethodsHandler
+// @Summary      Reorder payment methods
+// @Description  Set the display order of payment methods; IDs are ordered first to last (Roles: admin)
+// @Tags         Payment Methods
+// @Accept       json
+// @Produce      json
+// @Param        request body ReorderPaymentMethodsRequest true "Payment method IDs in display order"
+// @Success      200 {object} common.SuccessResponse{data=[]PaymentMethodResponse} "Payment methods reordered successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin"]
+// @Router       /payment-methods/reorder [put]
+func (h *PaymentMethodHandler) ReorderPaymentMethodsHandler(c fiber.Ctx) error {
+	var req ReorderPaymentMethodsRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("ReorderPaymentMethodsHandler | Failed to parse request body: %v", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data:    map[string]interface{}{"errors": ve.Errors},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+	}
+
+	methods, err := h.service.ReorderPaymentMethods(c.RequestCtx(), req)
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidInput) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Payment method IDs must be unique"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to reorder payment methods"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Payment methods reordered successfully",
+		Data:    methods,
+	})
+}
+
+// DeletePaymentMethodHandler
+// @Summary      Delete payment method
+// @Description  Delete a payment method that has never been used; used methods should be deactivated instead (Roles: admin)
+// @Tags         Payment Methods
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "Payment Method ID"
+// @Success      200 {object} common.SuccessResponse "Payment method deleted successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid payment method ID"
+// @Failure      404 {object} common.ErrorResponse "Payment method not found"
+// @Failure      409 {object} common.ErrorResponse "Payment method is in use"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin"]
+// @Router       /payment-methods/{id} [delete]
+func (h *PaymentMethodHandler) DeletePaymentMethodHandler(c fiber.Ctx) error {
+	id := fiber.Params[int](c, "id")
+	if id == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid payment method ID format. ID must be a number."})
+	}
+
+	if err := h.service.DeletePaymentMethod(c.RequestCtx(), int32(id)); err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Payment method not found"})
+		case errors.Is(err, common.ErrPaymentMethodInUse):
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to delete payment method"})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Payment method deleted successfully",
+	})
+}
@@ -1,12 +1,14 @@
 package payment_methods_test
 
 import (
+	"POS-kasir/internal/common"
 	"POS-kasir/internal/payment_methods"
 	"POS-kasir/mocks"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -67,4 +69,82 @@ func TestPaymentMethodHandler_ListPaymentMethodsHandler(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 	})
+
+	t.Run("IncludeInactive", func(t *testing.T) {
+		mockService.EXPECT().ListAllPaymentMethods(gomock.Any()).Return([]payment_methods.PaymentMethodResponse{
+			{ID: 1, Name: "Cash", IsActive: true},
+			{ID: 4, Name: "Voucher", IsActive: false},
+		}, nil)
+
+		req := httptest.NewRequest("GET", "/payment-methods?include_inactive=true", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestPaymentMethodHandler_CreatePaymentMethodHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIPaymentMethodService(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	handler := payment_methods.NewPaymentMethodHandler(mockService, mockLogger)
+
+	app := fiber.New()
+	app.Post("/payment-methods", handler.CreatePaymentMethodHandler)
+
+	body := `{"name":"Debit Card","kind":"card","requires_reference":true}`
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().CreatePaymentMethod(gomock.Any(), payment_methods.CreatePaymentMethodRequest{
+			Name:              "Debit Card",
+			Kind:              "card",
+			RequiresReference: true,
+		}).Return(&payment_methods.PaymentMethodResponse{ID: 4, Name: "Debit Card", Kind: "card"}, nil)
+
+		req := httptest.NewRequest("POST", "/payment-methods", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		mockService.EXPECT().CreatePaymentMethod(gomock.Any(), gomock.Any()).Return(nil, common.ErrPaymentMethodExists)
+
+		req := httptest.NewRequest("POST", "/payment-methods", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestPaymentMethodHandler_DeletePaymentMethodHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIPaymentMethodService(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	handler := payment_methods.NewPaymentMethodHandler(mockService, mockLogger)
+
+	app := fiber.New()
+	app.Delete("/payment-methods/:id", handler.DeletePaymentMethodHandler)
+
+	t.Run("InUse", func(t *testing.T) {
+		mockService.EXPECT().DeletePaymentMethod(gomock.Any(), int32(1)).Return(common.ErrPaymentMethodInUse)
+
+		req := httptest.NewRequest("DELETE", "/payment-methods/1", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/payment-methods/abc", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
 }
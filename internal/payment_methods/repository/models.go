@@ -12,6 +12,50 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type BarcodeSymbology string
+
+const (
+	BarcodeSymbologyEan13    BarcodeSymbology = "ean13"
+	BarcodeSymbologyEan8     BarcodeSymbology = "ean8"
+	BarcodeSymbologyUpcA     BarcodeSymbology = "upc_a"
+	BarcodeSymbologyInternal BarcodeSymbology = "internal"
+)
+
+func (e *BarcodeSymbology) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BarcodeSymbology(s)
+	case string:
+		*e = BarcodeSymbology(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BarcodeSymbology: %T", src)
+	}
+	return nil
+}
+
+type NullBarcodeSymbology struct {
+	BarcodeSymbology BarcodeSymbology `json:"barcode_symbology"`
+	Valid            bool             `json:"valid"` // Valid is true if BarcodeSymbology is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBarcodeSymbology) Scan(value interface{}) error {
+	if value == nil {
+		ns.BarcodeSymbology, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BarcodeSymbology.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBarcodeSymbology) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BarcodeSymbology), nil
+}
+
 type CashTransactionType string
 
 const (
@@ -54,11 +98,58 @@ func (ns NullCashTransactionType) Value() (driver.Value, error) {
 	return string(ns.CashTransactionType), nil
 }
 
+type CostLayerSource string
+
+const (
+	CostLayerSourceOpening    CostLayerSource = "opening"
+	CostLayerSourcePurchase   CostLayerSource = "purchase"
+	CostLayerSourceAdjustment CostLayerSource = "adjustment"
+	CostLayerSourceReturn     CostLayerSource = "return"
+)
+
+func (e *CostLayerSource) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CostLayerSource(s)
+	case string:
+		*e = CostLayerSource(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CostLayerSource: %T", src)
+	}
+	return nil
+}
+
+type NullCostLayerSource struct {
+	CostLayerSource CostLayerSource `json:"cost_layer_source"`
+	Valid           bool            `json:"valid"` // Valid is true if CostLayerSource is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCostLayerSource) Scan(value interface{}) error {
+	if value == nil {
+		ns.CostLayerSource, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CostLayerSource.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCostLayerSource) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CostLayerSource), nil
+}
+
 type DiscountType string
 
 const (
 	DiscountTypePercentage  DiscountType = "percentage"
 	DiscountTypeFixedAmount DiscountType = "fixed_amount"
+	DiscountTypeBuyXGetY    DiscountType = "buy_x_get_y"
+	DiscountTypeBundlePrice DiscountType = "bundle_price"
+	DiscountTypeTiered      DiscountType = "tiered"
 )
 
 func (e *DiscountType) Scan(src interface{}) error {
@@ -96,6 +187,49 @@ func (ns NullDiscountType) Value() (driver.Value, error) {
 	return string(ns.DiscountType), nil
 }
 
+type IngredientUnit string
+
+const (
+	IngredientUnitG   IngredientUnit = "g"
+	IngredientUnitMl  IngredientUnit = "ml"
+	IngredientUnitPcs IngredientUnit = "pcs"
+)
+
+func (e *IngredientUnit) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = IngredientUnit(s)
+	case string:
+		*e = IngredientUnit(s)
+	default:
+		return fmt.Errorf("unsupported scan type for IngredientUnit: %T", src)
+	}
+	return nil
+}
+
+type NullIngredientUnit struct {
+	IngredientUnit IngredientUnit `json:"ingredient_unit"`
+	Valid          bool           `json:"valid"` // Valid is true if IngredientUnit is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullIngredientUnit) Scan(value interface{}) error {
+	if value == nil {
+		ns.IngredientUnit, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.IngredientUnit.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullIngredientUnit) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.IngredientUnit), nil
+}
+
 type LogActionType string
 
 const (
@@ -160,6 +294,12 @@ const (
 	LogEntityTypeSHIFT              LogEntityType = "SHIFT"
 	LogEntityTypePAYMENTMETHOD      LogEntityType = "PAYMENT_METHOD"
 	LogEntityTypeCANCELLATIONREASON LogEntityType = "CANCELLATION_REASON"
+	LogEntityTypeKITCHENSTATION     LogEntityType = "KITCHEN_STATION"
+	LogEntityTypeINGREDIENT         LogEntityType = "INGREDIENT"
+	LogEntityTypeSUPPLIER           LogEntityType = "SUPPLIER"
+	LogEntityTypePURCHASEORDER      LogEntityType = "PURCHASE_ORDER"
+	LogEntityTypeSTOCKTAKE          LogEntityType = "STOCK_TAKE"
+	LogEntityTypeMODIFIERGROUP      LogEntityType = "MODIFIER_GROUP"
 )
 
 func (e *LogEntityType) Scan(src interface{}) error {
@@ -197,6 +337,136 @@ func (ns NullLogEntityType) Value() (driver.Value, error) {
 	return string(ns.LogEntityType), nil
 }
 
+type LoyaltyEntryType string
+
+const (
+	LoyaltyEntryTypeEarn     LoyaltyEntryType = "earn"
+	LoyaltyEntryTypeRedeem   LoyaltyEntryType = "redeem"
+	LoyaltyEntryTypeExpire   LoyaltyEntryType = "expire"
+	LoyaltyEntryTypeReversal LoyaltyEntryType = "reversal"
+)
+
+func (e *LoyaltyEntryType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LoyaltyEntryType(s)
+	case string:
+		*e = LoyaltyEntryType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LoyaltyEntryType: %T", src)
+	}
+	return nil
+}
+
+type NullLoyaltyEntryType struct {
+	LoyaltyEntryType LoyaltyEntryType `json:"loyalty_entry_type"`
+	Valid            bool             `json:"valid"` // Valid is true if LoyaltyEntryType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLoyaltyEntryType) Scan(value interface{}) error {
+	if value == nil {
+		ns.LoyaltyEntryType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LoyaltyEntryType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLoyaltyEntryType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LoyaltyEntryType), nil
+}
+
+type LoyaltyRedemptionKind string
+
+const (
+	LoyaltyRedemptionKindDiscount LoyaltyRedemptionKind = "discount"
+	LoyaltyRedemptionKindTender   LoyaltyRedemptionKind = "tender"
+)
+
+func (e *LoyaltyRedemptionKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LoyaltyRedemptionKind(s)
+	case string:
+		*e = LoyaltyRedemptionKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LoyaltyRedemptionKind: %T", src)
+	}
+	return nil
+}
+
+type NullLoyaltyRedemptionKind struct {
+	LoyaltyRedemptionKind LoyaltyRedemptionKind `json:"loyalty_redemption_kind"`
+	Valid                 bool                  `json:"valid"` // Valid is true if LoyaltyRedemptionKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLoyaltyRedemptionKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.LoyaltyRedemptionKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LoyaltyRedemptionKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLoyaltyRedemptionKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LoyaltyRedemptionKind), nil
+}
+
+type OrderItemStatus string
+
+const (
+	OrderItemStatusQueued  OrderItemStatus = "queued"
+	OrderItemStatusCooking OrderItemStatus = "cooking"
+	OrderItemStatusReady   OrderItemStatus = "ready"
+	OrderItemStatusServed  OrderItemStatus = "served"
+)
+
+func (e *OrderItemStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OrderItemStatus(s)
+	case string:
+		*e = OrderItemStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OrderItemStatus: %T", src)
+	}
+	return nil
+}
+
+type NullOrderItemStatus struct {
+	OrderItemStatus OrderItemStatus `json:"order_item_status"`
+	Valid           bool            `json:"valid"` // Valid is true if OrderItemStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOrderItemStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.OrderItemStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OrderItemStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOrderItemStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OrderItemStatus), nil
+}
+
 type OrderStatus string
 
 const (
@@ -284,6 +554,53 @@ func (ns NullOrderType) Value() (driver.Value, error) {
 	return string(ns.OrderType), nil
 }
 
+type PaymentMethodKind string
+
+const (
+	PaymentMethodKindCash          PaymentMethodKind = "cash"
+	PaymentMethodKindCard          PaymentMethodKind = "card"
+	PaymentMethodKindEWallet       PaymentMethodKind = "e_wallet"
+	PaymentMethodKindGateway       PaymentMethodKind = "gateway"
+	PaymentMethodKindVoucher       PaymentMethodKind = "voucher"
+	PaymentMethodKindOnAccount     PaymentMethodKind = "on_account"
+	PaymentMethodKindLoyaltyPoints PaymentMethodKind = "loyalty_points"
+)
+
+func (e *PaymentMethodKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentMethodKind(s)
+	case string:
+		*e = PaymentMethodKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentMethodKind: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentMethodKind struct {
+	PaymentMethodKind PaymentMethodKind `json:"payment_method_kind"`
+	Valid             bool              `json:"valid"` // Valid is true if PaymentMethodKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentMethodKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentMethodKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentMethodKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentMethodKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentMethodKind), nil
+}
+
 type PromotionRuleType string
 
 const (
@@ -371,6 +688,48 @@ func (ns NullPromotionScope) Value() (driver.Value, error) {
 	return string(ns.PromotionScope), nil
 }
 
+type PromotionStacking string
+
+const (
+	PromotionStackingExclusive PromotionStacking = "exclusive"
+	PromotionStackingStackable PromotionStacking = "stackable"
+)
+
+func (e *PromotionStacking) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PromotionStacking(s)
+	case string:
+		*e = PromotionStacking(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PromotionStacking: %T", src)
+	}
+	return nil
+}
+
+type NullPromotionStacking struct {
+	PromotionStacking PromotionStacking `json:"promotion_stacking"`
+	Valid             bool              `json:"valid"` // Valid is true if PromotionStacking is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPromotionStacking) Scan(value interface{}) error {
+	if value == nil {
+		ns.PromotionStacking, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PromotionStacking.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPromotionStacking) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PromotionStacking), nil
+}
+
 type PromotionTargetType string
 
 const (
@@ -413,6 +772,93 @@ func (ns NullPromotionTargetType) Value() (driver.Value, error) {
 	return string(ns.PromotionTargetType), nil
 }
 
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "draft"
+	PurchaseOrderStatusSent              PurchaseOrderStatus = "sent"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "received"
+	PurchaseOrderStatusClosed            PurchaseOrderStatus = "closed"
+)
+
+func (e *PurchaseOrderStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PurchaseOrderStatus(s)
+	case string:
+		*e = PurchaseOrderStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PurchaseOrderStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPurchaseOrderStatus struct {
+	PurchaseOrderStatus PurchaseOrderStatus `json:"purchase_order_status"`
+	Valid               bool                `json:"valid"` // Valid is true if PurchaseOrderStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPurchaseOrderStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PurchaseOrderStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PurchaseOrderStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPurchaseOrderStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PurchaseOrderStatus), nil
+}
+
+type RedemptionStatus string
+
+const (
+	RedemptionStatusRedeemed RedemptionStatus = "redeemed"
+	RedemptionStatusReleased RedemptionStatus = "released"
+)
+
+func (e *RedemptionStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RedemptionStatus(s)
+	case string:
+		*e = RedemptionStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RedemptionStatus: %T", src)
+	}
+	return nil
+}
+
+type NullRedemptionStatus struct {
+	RedemptionStatus RedemptionStatus `json:"redemption_status"`
+	Valid            bool             `json:"valid"` // Valid is true if RedemptionStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRedemptionStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.RedemptionStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RedemptionStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRedemptionStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RedemptionStatus), nil
+}
+
 type ShiftStatus string
 
 const (
@@ -542,6 +988,91 @@ func (ns NullStockChangeType) Value() (driver.Value, error) {
 	return string(ns.StockChangeType), nil
 }
 
+type StockTakeScope string
+
+const (
+	StockTakeScopeFull     StockTakeScope = "full"
+	StockTakeScopeCategory StockTakeScope = "category"
+)
+
+func (e *StockTakeScope) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = StockTakeScope(s)
+	case string:
+		*e = StockTakeScope(s)
+	default:
+		return fmt.Errorf("unsupported scan type for StockTakeScope: %T", src)
+	}
+	return nil
+}
+
+type NullStockTakeScope struct {
+	StockTakeScope StockTakeScope `json:"stock_take_scope"`
+	Valid          bool           `json:"valid"` // Valid is true if StockTakeScope is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullStockTakeScope) Scan(value interface{}) error {
+	if value == nil {
+		ns.StockTakeScope, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.StockTakeScope.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullStockTakeScope) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.StockTakeScope), nil
+}
+
+type StockTakeStatus string
+
+const (
+	StockTakeStatusOpen      StockTakeStatus = "open"
+	StockTakeStatusApproved  StockTakeStatus = "approved"
+	StockTakeStatusCancelled StockTakeStatus = "cancelled"
+)
+
+func (e *StockTakeStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = StockTakeStatus(s)
+	case string:
+		*e = StockTakeStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for StockTakeStatus: %T", src)
+	}
+	return nil
+}
+
+type NullStockTakeStatus struct {
+	StockTakeStatus StockTakeStatus `json:"stock_take_status"`
+	Valid           bool            `json:"valid"` // Valid is true if StockTakeStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullStockTakeStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.StockTakeStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.StockTakeStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullStockTakeStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.StockTakeStatus), nil
+}
+
 type UserOrderColumn string
 
 const (
@@ -665,6 +1196,26 @@ type Category struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
+type CostLayer struct {
+	ID                uuid.UUID          `json:"id"`
+	ProductID         uuid.UUID          `json:"product_id"`
+	Source            CostLayerSource    `json:"source"`
+	ReferenceID       pgtype.UUID        `json:"reference_id"`
+	QuantityReceived  int32              `json:"quantity_received"`
+	QuantityRemaining int32              `json:"quantity_remaining"`
+	UnitCost          pgtype.Numeric     `json:"unit_cost"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+}
+
+type CostLayerConsumption struct {
+	ID          uuid.UUID          `json:"id"`
+	CostLayerID uuid.UUID          `json:"cost_layer_id"`
+	OrderItemID pgtype.UUID        `json:"order_item_id"`
+	Quantity    int32              `json:"quantity"`
+	UnitCost    pgtype.Numeric     `json:"unit_cost"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 type Customer struct {
 	ID        uuid.UUID          `json:"id"`
 	Name      string             `json:"name"`
@@ -676,6 +1227,88 @@ type Customer struct {
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type GoodsReceipt struct {
+	ID                uuid.UUID          `json:"id"`
+	ReceiptNumber     string             `json:"receipt_number"`
+	PurchaseOrderID   uuid.UUID          `json:"purchase_order_id"`
+	SupplierReference *string            `json:"supplier_reference"`
+	Notes             *string            `json:"notes"`
+	ReceivedBy        pgtype.UUID        `json:"received_by"`
+	ReceivedAt        pgtype.Timestamptz `json:"received_at"`
+}
+
+type GoodsReceiptItem struct {
+	ID                  uuid.UUID      `json:"id"`
+	GoodsReceiptID      uuid.UUID      `json:"goods_receipt_id"`
+	PurchaseOrderItemID uuid.UUID      `json:"purchase_order_item_id"`
+	Quantity            int32          `json:"quantity"`
+	UnitCost            pgtype.Numeric `json:"unit_cost"`
+}
+
+type Ingredient struct {
+	ID                uuid.UUID          `json:"id"`
+	Name              string             `json:"name"`
+	Unit              IngredientUnit     `json:"unit"`
+	Stock             int32              `json:"stock"`
+	LowStockThreshold int32              `json:"low_stock_threshold"`
+	CostPerUnit       pgtype.Numeric     `json:"cost_per_unit"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type IngredientStockHistory struct {
+	ID            uuid.UUID          `json:"id"`
+	IngredientID  uuid.UUID          `json:"ingredient_id"`
+	ChangeAmount  int32              `json:"change_amount"`
+	PreviousStock int32              `json:"previous_stock"`
+	CurrentStock  int32              `json:"current_stock"`
+	ChangeType    StockChangeType    `json:"change_type"`
+	ReferenceID   pgtype.UUID        `json:"reference_id"`
+	Note          *string            `json:"note"`
+	CreatedBy     pgtype.UUID        `json:"created_by"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type KitchenStation struct {
+	ID        int32              `json:"id"`
+	Name      string             `json:"name"`
+	SortOrder int32              `json:"sort_order"`
+	IsActive  bool               `json:"is_active"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type KitchenStationCategory struct {
+	CategoryID int32 `json:"category_id"`
+	StationID  int32 `json:"station_id"`
+}
+
+type LoyaltyPointEntry struct {
+	ID          uuid.UUID                 `json:"id"`
+	CustomerID  uuid.UUID                 `json:"customer_id"`
+	OrderID     pgtype.UUID               `json:"order_id"`
+	EntryType   LoyaltyEntryType          `json:"entry_type"`
+	RedeemedAs  NullLoyaltyRedemptionKind `json:"redeemed_as"`
+	Points      int32                     `json:"points"`
+	Remaining   int32                     `json:"remaining"`
+	Amount      int64                     `json:"amount"`
+	ExpiresAt   pgtype.Timestamptz        `json:"expires_at"`
+	Description *string                   `json:"description"`
+	CreatedBy   pgtype.UUID               `json:"created_by"`
+	CreatedAt   pgtype.Timestamptz        `json:"created_at"`
+}
+
+type ModifierGroup struct {
+	ID        uuid.UUID          `json:"id"`
+	Name      string             `json:"name"`
+	MinSelect int32              `json:"min_select"`
+	MaxSelect *int32             `json:"max_select"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
 type Order struct {
 	ID                      uuid.UUID          `json:"id"`
 	UserID                  pgtype.UUID        `json:"user_id"`
@@ -699,18 +1332,34 @@ type Order struct {
 	TaxAmount               int64              `json:"tax_amount"`
 	ServiceChargeAmount     int64              `json:"service_charge_amount"`
 	CustomerID              pgtype.UUID        `json:"customer_id"`
+	TaxRate                 pgtype.Numeric     `json:"tax_rate"`
+	ServiceChargeRate       pgtype.Numeric     `json:"service_charge_rate"`
+	TaxInclusive            bool               `json:"tax_inclusive"`
+	ShiftID                 pgtype.UUID        `json:"shift_id"`
+	ParentOrderID           pgtype.UUID        `json:"parent_order_id"`
+	QueueNumber             *string            `json:"queue_number"`
+	BusinessDate            pgtype.Date        `json:"business_date"`
+	AppliedPromotionCodeID  pgtype.UUID        `json:"applied_promotion_code_id"`
 }
 
 type OrderItem struct {
-	ID              uuid.UUID      `json:"id"`
-	OrderID         uuid.UUID      `json:"order_id"`
-	ProductID       uuid.UUID      `json:"product_id"`
-	Quantity        int32          `json:"quantity"`
-	PriceAtSale     int64          `json:"price_at_sale"`
-	Subtotal        int64          `json:"subtotal"`
-	DiscountAmount  int64          `json:"discount_amount"`
-	NetSubtotal     int64          `json:"net_subtotal"`
-	CostPriceAtSale pgtype.Numeric `json:"cost_price_at_sale"`
+	ID              uuid.UUID          `json:"id"`
+	OrderID         uuid.UUID          `json:"order_id"`
+	ProductID       uuid.UUID          `json:"product_id"`
+	Quantity        int32              `json:"quantity"`
+	PriceAtSale     int64              `json:"price_at_sale"`
+	Subtotal        int64              `json:"subtotal"`
+	DiscountAmount  int64              `json:"discount_amount"`
+	NetSubtotal     int64              `json:"net_subtotal"`
+	CostPriceAtSale pgtype.Numeric     `json:"cost_price_at_sale"`
+	StationID       *int32             `json:"station_id"`
+	PrepStatus      OrderItemStatus    `json:"prep_status"`
+	IsPriority      bool               `json:"is_priority"`
+	QueuedAt        pgtype.Timestamptz `json:"queued_at"`
+	StartedAt       pgtype.Timestamptz `json:"started_at"`
+	ReadyAt         pgtype.Timestamptz `json:"ready_at"`
+	ServedAt        pgtype.Timestamptz `json:"served_at"`
+	VariantID       pgtype.UUID        `json:"variant_id"`
 }
 
 type OrderItemOption struct {
@@ -720,12 +1369,79 @@ type OrderItemOption struct {
 	PriceAtSale     int64     `json:"price_at_sale"`
 }
 
+type OrderPayment struct {
+	ID              uuid.UUID          `json:"id"`
+	OrderID         uuid.UUID          `json:"order_id"`
+	PaymentMethodID int32              `json:"payment_method_id"`
+	Amount          int64              `json:"amount"`
+	TenderedAmount  int64              `json:"tendered_amount"`
+	ChangeAmount    int64              `json:"change_amount"`
+	ReferenceNumber *string            `json:"reference_number"`
+	ShiftID         pgtype.UUID        `json:"shift_id"`
+	CreatedBy       pgtype.UUID        `json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type OrderPromotion struct {
+	OrderID         uuid.UUID          `json:"order_id"`
+	PromotionID     uuid.UUID          `json:"promotion_id"`
+	PromotionCodeID pgtype.UUID        `json:"promotion_code_id"`
+	DiscountAmount  int64              `json:"discount_amount"`
+	AutoApplied     bool               `json:"auto_applied"`
+	Position        int32              `json:"position"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	ScheduleWindow  *string            `json:"schedule_window"`
+}
+
+type OrderQueueCounter struct {
+	BusinessDate pgtype.Date        `json:"business_date"`
+	LastNumber   int32              `json:"last_number"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+type OrderRefund struct {
+	ID              uuid.UUID          `json:"id"`
+	OrderID         uuid.UUID          `json:"order_id"`
+	ShiftID         pgtype.UUID        `json:"shift_id"`
+	PaymentMethodID *int32             `json:"payment_method_id"`
+	Amount          int64              `json:"amount"`
+	Reason          *string            `json:"reason"`
+	CreatedBy       pgtype.UUID        `json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	OrderPaymentID  pgtype.UUID        `json:"order_payment_id"`
+}
+
+type OrderRefundItem struct {
+	ID          uuid.UUID          `json:"id"`
+	RefundID    uuid.UUID          `json:"refund_id"`
+	OrderItemID uuid.UUID          `json:"order_item_id"`
+	Quantity    int32              `json:"quantity"`
+	Amount      int64              `json:"amount"`
+	Restocked   bool               `json:"restocked"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type OrderStatusHistory struct {
+	ID         uuid.UUID          `json:"id"`
+	OrderID    uuid.UUID          `json:"order_id"`
+	FromStatus NullOrderStatus    `json:"from_status"`
+	ToStatus   OrderStatus        `json:"to_status"`
+	ChangedBy  pgtype.UUID        `json:"changed_by"`
+	Note       *string            `json:"note"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
 type PaymentMethod struct {
-	ID        int32              `json:"id"`
-	Name      string             `json:"name"`
-	IsActive  bool               `json:"is_active"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID                int32              `json:"id"`
+	Name              string             `json:"name"`
+	IsActive          bool               `json:"is_active"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	Kind              PaymentMethodKind  `json:"kind"`
+	SortOrder         int32              `json:"sort_order"`
+	OpensCashDrawer   bool               `json:"opens_cash_drawer"`
+	RequiresReference bool               `json:"requires_reference"`
+	AllowsChange      bool               `json:"allows_change"`
 }
 
 type Product struct {
@@ -738,6 +1454,17 @@ type Product struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 	CostPrice pgtype.Numeric     `json:"cost_price"`
+	Sku       *string            `json:"sku"`
+}
+
+type ProductBarcode struct {
+	ID          uuid.UUID          `json:"id"`
+	ProductID   uuid.UUID          `json:"product_id"`
+	VariantID   pgtype.UUID        `json:"variant_id"`
+	Code        string             `json:"code"`
+	Symbology   BarcodeSymbology   `json:"symbology"`
+	IsGenerated bool               `json:"is_generated"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
 type ProductCategory struct {
@@ -755,22 +1482,106 @@ type ProductOption struct {
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
+	ModifierGroupID pgtype.UUID        `json:"modifier_group_id"`
+	IsDefault       bool               `json:"is_default"`
+}
+
+type ProductOptionRecipeItem struct {
+	ProductOptionID      uuid.UUID   `json:"product_option_id"`
+	IngredientID         uuid.UUID   `json:"ingredient_id"`
+	Quantity             int32       `json:"quantity"`
+	ReplacesIngredientID pgtype.UUID `json:"replaces_ingredient_id"`
+}
+
+type ProductRecipeItem struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	Quantity     int32     `json:"quantity"`
+}
+
+type ProductVariant struct {
+	ID        uuid.UUID          `json:"id"`
+	ProductID uuid.UUID          `json:"product_id"`
+	Name      string             `json:"name"`
+	Sku       string             `json:"sku"`
+	Barcode   *string            `json:"barcode"`
+	Price     *int64             `json:"price"`
+	CostPrice pgtype.Numeric     `json:"cost_price"`
+	Stock     int32              `json:"stock"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type ProductVariantAttribute struct {
+	ID        uuid.UUID `json:"id"`
+	ProductID uuid.UUID `json:"product_id"`
+	Name      string    `json:"name"`
+	Position  int32     `json:"position"`
+}
+
+type ProductVariantAttributeValue struct {
+	ID          uuid.UUID `json:"id"`
+	AttributeID uuid.UUID `json:"attribute_id"`
+	Value       string    `json:"value"`
+	Position    int32     `json:"position"`
+}
+
+type ProductVariantValue struct {
+	VariantID uuid.UUID `json:"variant_id"`
+	ValueID   uuid.UUID `json:"value_id"`
 }
 
 type Promotion struct {
-	ID                uuid.UUID          `json:"id"`
-	Name              string             `json:"name"`
-	Description       *string            `json:"description"`
-	Scope             PromotionScope     `json:"scope"`
-	DiscountType      DiscountType       `json:"discount_type"`
-	DiscountValue     pgtype.Numeric     `json:"discount_value"`
-	MaxDiscountAmount pgtype.Numeric     `json:"max_discount_amount"`
-	StartDate         pgtype.Timestamptz `json:"start_date"`
-	EndDate           pgtype.Timestamptz `json:"end_date"`
-	IsActive          bool               `json:"is_active"`
-	CreatedAt         pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+	ID                   uuid.UUID          `json:"id"`
+	Name                 string             `json:"name"`
+	Description          *string            `json:"description"`
+	Scope                PromotionScope     `json:"scope"`
+	DiscountType         DiscountType       `json:"discount_type"`
+	DiscountValue        pgtype.Numeric     `json:"discount_value"`
+	MaxDiscountAmount    pgtype.Numeric     `json:"max_discount_amount"`
+	StartDate            pgtype.Timestamptz `json:"start_date"`
+	EndDate              pgtype.Timestamptz `json:"end_date"`
+	IsActive             bool               `json:"is_active"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt            pgtype.Timestamptz `json:"deleted_at"`
+	TotalRedemptionLimit *int32             `json:"total_redemption_limit"`
+	PerCustomerLimit     *int32             `json:"per_customer_limit"`
+	BudgetAmount         *int64             `json:"budget_amount"`
+	BuyQuantity          *int32             `json:"buy_quantity"`
+	GetQuantity          *int32             `json:"get_quantity"`
+	BundleQuantity       *int32             `json:"bundle_quantity"`
+	AutoApply            bool               `json:"auto_apply"`
+	Stacking             PromotionStacking  `json:"stacking"`
+	Priority             int32              `json:"priority"`
+}
+
+type PromotionCode struct {
+	ID            uuid.UUID          `json:"id"`
+	PromotionID   uuid.UUID          `json:"promotion_id"`
+	Code          string             `json:"code"`
+	BatchID       pgtype.UUID        `json:"batch_id"`
+	MaxUses       *int32             `json:"max_uses"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	DeactivatedAt pgtype.Timestamptz `json:"deactivated_at"`
+}
+
+type PromotionExcludedDate struct {
+	PromotionID  uuid.UUID   `json:"promotion_id"`
+	ExcludedDate pgtype.Date `json:"excluded_date"`
+}
+
+type PromotionRedemption struct {
+	ID              uuid.UUID          `json:"id"`
+	PromotionID     uuid.UUID          `json:"promotion_id"`
+	PromotionCodeID pgtype.UUID        `json:"promotion_code_id"`
+	OrderID         uuid.UUID          `json:"order_id"`
+	CustomerID      pgtype.UUID        `json:"customer_id"`
+	DiscountAmount  int64              `json:"discount_amount"`
+	Status          RedemptionStatus   `json:"status"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	ReleasedAt      pgtype.Timestamptz `json:"released_at"`
 }
 
 type PromotionRule struct {
@@ -783,6 +1594,15 @@ type PromotionRule struct {
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
 
+type PromotionSchedule struct {
+	ID          uuid.UUID          `json:"id"`
+	PromotionID uuid.UUID          `json:"promotion_id"`
+	DaysOfWeek  []int16            `json:"days_of_week"`
+	StartTime   pgtype.Time        `json:"start_time"`
+	EndTime     pgtype.Time        `json:"end_time"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 type PromotionTarget struct {
 	ID          uuid.UUID           `json:"id"`
 	PromotionID uuid.UUID           `json:"promotion_id"`
@@ -792,6 +1612,39 @@ type PromotionTarget struct {
 	UpdatedAt   pgtype.Timestamptz  `json:"updated_at"`
 }
 
+type PromotionTier struct {
+	ID            uuid.UUID          `json:"id"`
+	PromotionID   uuid.UUID          `json:"promotion_id"`
+	MinAmount     int64              `json:"min_amount"`
+	DiscountType  DiscountType       `json:"discount_type"`
+	DiscountValue pgtype.Numeric     `json:"discount_value"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type PurchaseOrder struct {
+	ID           uuid.UUID           `json:"id"`
+	PoNumber     string              `json:"po_number"`
+	SupplierID   uuid.UUID           `json:"supplier_id"`
+	Status       PurchaseOrderStatus `json:"status"`
+	ExpectedDate pgtype.Date         `json:"expected_date"`
+	Notes        *string             `json:"notes"`
+	CreatedBy    pgtype.UUID         `json:"created_by"`
+	SentAt       pgtype.Timestamptz  `json:"sent_at"`
+	ReceivedAt   pgtype.Timestamptz  `json:"received_at"`
+	ClosedAt     pgtype.Timestamptz  `json:"closed_at"`
+	CreatedAt    pgtype.Timestamptz  `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz  `json:"updated_at"`
+}
+
+type PurchaseOrderItem struct {
+	ID               uuid.UUID      `json:"id"`
+	PurchaseOrderID  uuid.UUID      `json:"purchase_order_id"`
+	ProductID        uuid.UUID      `json:"product_id"`
+	QuantityOrdered  int32          `json:"quantity_ordered"`
+	QuantityReceived int32          `json:"quantity_received"`
+	UnitCost         pgtype.Numeric `json:"unit_cost"`
+}
+
 type Setting struct {
 	Key         string           `json:"key"`
 	Value       string           `json:"value"`
@@ -823,6 +1676,51 @@ type StockHistory struct {
 	Note          *string            `json:"note"`
 	CreatedBy     pgtype.UUID        `json:"created_by"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	VariantID     pgtype.UUID        `json:"variant_id"`
+}
+
+type StockTake struct {
+	ID          uuid.UUID          `json:"id"`
+	Reference   string             `json:"reference"`
+	Scope       StockTakeScope     `json:"scope"`
+	CategoryID  *int32             `json:"category_id"`
+	Status      StockTakeStatus    `json:"status"`
+	Notes       *string            `json:"notes"`
+	CreatedBy   pgtype.UUID        `json:"created_by"`
+	ApprovedBy  pgtype.UUID        `json:"approved_by"`
+	ApprovedAt  pgtype.Timestamptz `json:"approved_at"`
+	CancelledAt pgtype.Timestamptz `json:"cancelled_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type StockTakeCount struct {
+	ID              uuid.UUID          `json:"id"`
+	StockTakeItemID uuid.UUID          `json:"stock_take_item_id"`
+	CountedBy       uuid.UUID          `json:"counted_by"`
+	Quantity        int32              `json:"quantity"`
+	CountedAt       pgtype.Timestamptz `json:"counted_at"`
+}
+
+type StockTakeItem struct {
+	ID               uuid.UUID      `json:"id"`
+	StockTakeID      uuid.UUID      `json:"stock_take_id"`
+	ProductID        uuid.UUID      `json:"product_id"`
+	SystemQuantity   int32          `json:"system_quantity"`
+	ExpectedQuantity int32          `json:"expected_quantity"`
+	UnitCost         pgtype.Numeric `json:"unit_cost"`
+}
+
+type Supplier struct {
+	ID          uuid.UUID          `json:"id"`
+	Name        string             `json:"name"`
+	ContactName *string            `json:"contact_name"`
+	Phone       *string            `json:"phone"`
+	Email       *string            `json:"email"`
+	Address     *string            `json:"address"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
 }
 
 type User struct {
@@ -9,15 +9,46 @@ import (
 	"context"
 )
 
+const countOrdersByPaymentMethod = `-- name: CountOrdersByPaymentMethod :one
+SELECT count(*) FROM orders o
+WHERE o.payment_method_id = $1
+   OR EXISTS (SELECT 1 FROM order_payments op WHERE op.order_id = o.id AND op.payment_method_id = $1)
+`
+
+// Menghitung pesanan yang memakai metode pembayaran ini, termasuk sebagai tender split (mencegah penghapusan).
+func (q *Queries) CountOrdersByPaymentMethod(ctx context.Context, paymentMethodID *int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countOrdersByPaymentMethod, paymentMethodID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createPaymentMethod = `-- name: CreatePaymentMethod :one
-INSERT INTO payment_methods (name)
-VALUES ($1)
-RETURNING id, name, is_active, created_at, updated_at
+INSERT INTO payment_methods (name, kind, opens_cash_drawer, requires_reference, allows_change, sort_order)
+VALUES (
+    $1, $2, $3, $4, $5,
+    (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM payment_methods)
+)
+RETURNING id, name, is_active, created_at, updated_at, kind, sort_order, opens_cash_drawer, requires_reference, allows_change
 `
 
-// Membuat metode pembayaran baru.
-func (q *Queries) CreatePaymentMethod(ctx context.Context, name string) (PaymentMethod, error) {
-	row := q.db.QueryRow(ctx, createPaymentMethod, name)
+type CreatePaymentMethodParams struct {
+	Name              string            `json:"name"`
+	Kind              PaymentMethodKind `json:"kind"`
+	OpensCashDrawer   bool              `json:"opens_cash_drawer"`
+	RequiresReference bool              `json:"requires_reference"`
+	AllowsChange      bool              `json:"allows_change"`
+}
+
+// Membuat metode pembayaran baru, ditempatkan di urutan paling akhir.
+func (q *Queries) CreatePaymentMethod(ctx context.Context, arg CreatePaymentMethodParams) (PaymentMethod, error) {
+	row := q.db.QueryRow(ctx, createPaymentMethod,
+		arg.Name,
+		arg.Kind,
+		arg.OpensCashDrawer,
+		arg.RequiresReference,
+		arg.AllowsChange,
+	)
 	var i PaymentMethod
 	err := row.Scan(
 		&i.ID,
@@ -25,12 +56,53 @@ func (q *Queries) CreatePaymentMethod(ctx context.Context, name string) (Payment
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Kind,
+		&i.SortOrder,
+		&i.OpensCashDrawer,
+		&i.RequiresReference,
+		&i.AllowsChange,
+	)
+	return i, err
+}
+
+const deletePaymentMethod = `-- name: DeletePaymentMethod :exec
+DELETE FROM payment_methods WHERE id = $1
+`
+
+// Menghapus metode pembayaran yang belum pernah dipakai.
+func (q *Queries) DeletePaymentMethod(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, deletePaymentMethod, id)
+	return err
+}
+
+const getPaymentMethodByID = `-- name: GetPaymentMethodByID :one
+SELECT id, name, is_active, created_at, updated_at, kind, sort_order, opens_cash_drawer, requires_reference, allows_change
+FROM payment_methods
+WHERE id = $1
+LIMIT 1
+`
+
+// Mengambil satu metode pembayaran berdasarkan ID.
+func (q *Queries) GetPaymentMethodByID(ctx context.Context, id int32) (PaymentMethod, error) {
+	row := q.db.QueryRow(ctx, getPaymentMethodByID, id)
+	var i PaymentMethod
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Kind,
+		&i.SortOrder,
+		&i.OpensCashDrawer,
+		&i.RequiresReference,
+		&i.AllowsChange,
 	)
 	return i, err
 }
 
 const getPaymentMethodByName = `-- name: GetPaymentMethodByName :one
-SELECT id, name, is_active, created_at, updated_at
+SELECT id, name, is_active, created_at, updated_at, kind, sort_order, opens_cash_drawer, requires_reference, allows_change
 FROM payment_methods
 WHERE name = $1
 LIMIT 1
@@ -46,15 +118,58 @@ func (q *Queries) GetPaymentMethodByName(ctx context.Context, name string) (Paym
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Kind,
+		&i.SortOrder,
+		&i.OpensCashDrawer,
+		&i.RequiresReference,
+		&i.AllowsChange,
 	)
 	return i, err
 }
 
+const listAllPaymentMethods = `-- name: ListAllPaymentMethods :many
+SELECT id, name, is_active, created_at, updated_at, kind, sort_order, opens_cash_drawer, requires_reference, allows_change
+FROM payment_methods
+ORDER BY sort_order, name
+`
+
+// Mengambil daftar semua metode pembayaran, termasuk yang nonaktif.
+func (q *Queries) ListAllPaymentMethods(ctx context.Context) ([]PaymentMethod, error) {
+	rows, err := q.db.Query(ctx, listAllPaymentMethods)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PaymentMethod{}
+	for rows.Next() {
+		var i PaymentMethod
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Kind,
+			&i.SortOrder,
+			&i.OpensCashDrawer,
+			&i.RequiresReference,
+			&i.AllowsChange,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPaymentMethods = `-- name: ListPaymentMethods :many
-SELECT id, name, is_active, created_at, updated_at
+SELECT id, name, is_active, created_at, updated_at, kind, sort_order, opens_cash_drawer, requires_reference, allows_change
 FROM payment_methods
 WHERE is_active = true
-ORDER BY name
+ORDER BY sort_order, name
 `
 
 // Mengambil daftar semua metode pembayaran yang aktif.
@@ -73,6 +188,11 @@ func (q *Queries) ListPaymentMethods(ctx context.Context) ([]PaymentMethod, erro
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Kind,
+			&i.SortOrder,
+			&i.OpensCashDrawer,
+			&i.RequiresReference,
+			&i.AllowsChange,
 		); err != nil {
 			return nil, err
 		}
@@ -83,3 +203,67 @@ func (q *Queries) ListPaymentMethods(ctx context.Context) ([]PaymentMethod, erro
 	}
 	return items, nil
 }
+
+const reorderPaymentMethods = `-- name: ReorderPaymentMethods :exec
+UPDATE payment_methods pm
+SET sort_order = o.position, updated_at = now()
+FROM unnest($1::int[]) WITH ORDINALITY AS o(id, position)
+WHERE pm.id = o.id
+`
+
+// Menetapkan urutan tampilan sesuai posisi ID di dalam array.
+func (q *Queries) ReorderPaymentMethods(ctx context.Context, ids []int32) error {
+	_, err := q.db.Exec(ctx, reorderPaymentMethods, ids)
+	return err
+}
+
+const updatePaymentMethod = `-- name: UpdatePaymentMethod :one
+UPDATE payment_methods
+SET
+    name = $2,
+    kind = $3,
+    opens_cash_drawer = $4,
+    requires_reference = $5,
+    allows_change = $6,
+    is_active = $7,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, name, is_active, created_at, updated_at, kind, sort_order, opens_cash_drawer, requires_reference, allows_change
+`
+
+type UpdatePaymentMethodParams struct {
+	ID                int32             `json:"id"`
+	Name              string            `json:"name"`
+	Kind              PaymentMethodKind `json:"kind"`
+	OpensCashDrawer   bool              `json:"opens_cash_drawer"`
+	RequiresReference bool              `json:"requires_reference"`
+	AllowsChange      bool              `json:"allows_change"`
+	IsActive          bool              `json:"is_active"`
+}
+
+// Memperbarui nama, jenis, flag, dan status aktif metode pembayaran.
+func (q *Queries) UpdatePaymentMethod(ctx context.Context, arg UpdatePaymentMethodParams) (PaymentMethod, error) {
+	row := q.db.QueryRow(ctx, updatePaymentMethod,
+		arg.ID,
+		arg.Name,
+		arg.Kind,
+		arg.OpensCashDrawer,
+		arg.RequiresReference,
+		arg.AllowsChange,
+		arg.IsActive,
+	)
+	var i PaymentMethod
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Kind,
+		&i.SortOrder,
+		&i.OpensCashDrawer,
+		&i.RequiresReference,
+		&i.AllowsChange,
+	)
+	return i, err
+}
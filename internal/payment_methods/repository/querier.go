@@ -9,12 +9,24 @@ import (
 )
 
 type Querier interface {
-	// Membuat metode pembayaran baru.
-	CreatePaymentMethod(ctx context.Context, name string) (PaymentMethod, error)
+	// Menghitung pesanan yang memakai metode pembayaran ini, termasuk sebagai tender split (mencegah penghapusan).
+	CountOrdersByPaymentMethod(ctx context.Context, paymentMethodID *int32) (int64, error)
+	// Membuat metode pembayaran baru, ditempatkan di urutan paling akhir.
+	CreatePaymentMethod(ctx context.Context, arg CreatePaymentMethodParams) (PaymentMethod, error)
+	// Menghapus metode pembayaran yang belum pernah dipakai.
+	DeletePaymentMethod(ctx context.Context, id int32) error
+	// Mengambil satu metode pembayaran berdasarkan ID.
+	GetPaymentMethodByID(ctx context.Context, id int32) (PaymentMethod, error)
 	// Mengambil satu metode pembayaran berdasarkan nama untuk pengecekan duplikat.
 	GetPaymentMethodByName(ctx context.Context, name string) (PaymentMethod, error)
+	// Mengambil daftar semua metode pembayaran, termasuk yang nonaktif.
+	ListAllPaymentMethods(ctx context.Context) ([]PaymentMethod, error)
 	// Mengambil daftar semua metode pembayaran yang aktif.
 	ListPaymentMethods(ctx context.Context) ([]PaymentMethod, error)
+	// Menetapkan urutan tampilan sesuai posisi ID di dalam array.
+	ReorderPaymentMethods(ctx context.Context, ids []int32) error
+	// Memperbarui nama, jenis, flag, dan status aktif metode pembayaran.
+	UpdatePaymentMethod(ctx context.Context, arg UpdatePaymentMethodParams) (PaymentMethod, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -1,22 +1,37 @@
 package payment_methods
 
 import (
+	"POS-kasir/internal/activitylog"
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
 	"POS-kasir/internal/payment_methods/repository"
 	"POS-kasir/pkg/logger"
 	"context"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 type IPaymentMethodService interface {
 	ListPaymentMethods(ctx context.Context) ([]PaymentMethodResponse, error)
+	ListAllPaymentMethods(ctx context.Context) ([]PaymentMethodResponse, error)
+	GetPaymentMethodByID(ctx context.Context, id int32) (*PaymentMethodResponse, error)
+	CreatePaymentMethod(ctx context.Context, req CreatePaymentMethodRequest) (*PaymentMethodResponse, error)
+	UpdatePaymentMethod(ctx context.Context, id int32, req UpdatePaymentMethodRequest) (*PaymentMethodResponse, error)
+	ReorderPaymentMethods(ctx context.Context, req ReorderPaymentMethodsRequest) ([]PaymentMethodResponse, error)
+	DeletePaymentMethod(ctx context.Context, id int32) error
 }
 
 type PaymentMethodService struct {
-	repo repository.Querier
-	log  logger.ILogger
+	repo            repository.Querier
+	log             logger.ILogger
+	activityService activitylog.IActivityService
 }
 
-func NewPaymentMethodService(repo repository.Querier, log logger.ILogger) IPaymentMethodService {
-	return &PaymentMethodService{repo: repo, log: log}
+func NewPaymentMethodService(repo repository.Querier, log logger.ILogger, activityService activitylog.IActivityService) IPaymentMethodService {
+	return &PaymentMethodService{repo: repo, log: log, activityService: activityService}
 }
 
 func (s *PaymentMethodService) ListPaymentMethods(ctx context.Context) ([]PaymentMethodResponse, error) {
@@ -28,12 +43,223 @@ func (s *PaymentMethodService) ListPaymentMethods(ctx context.Context) ([]Paymen
 
 	var response []PaymentMethodResponse
 	for _, method := range methods {
-		response = append(response, PaymentMethodResponse{
-			ID:        method.ID,
-			Name:      method.Name,
-			IsActive:  method.IsActive,
-			CreatedAt: method.CreatedAt.Time,
-		})
+		response = append(response, toPaymentMethodResponse(method))
+	}
+	return response, nil
+}
+
+func (s *PaymentMethodService) ListAllPaymentMethods(ctx context.Context) ([]PaymentMethodResponse, error) {
+	methods, err := s.repo.ListAllPaymentMethods(ctx)
+	if err != nil {
+		s.log.Error("Failed to list all payment methods from repository", "error", err)
+		return nil, err
+	}
+
+	var response []PaymentMethodResponse
+	for _, method := range methods {
+		response = append(response, toPaymentMethodResponse(method))
 	}
 	return response, nil
 }
+
+func (s *PaymentMethodService) GetPaymentMethodByID(ctx context.Context, id int32) (*PaymentMethodResponse, error) {
+	method, err := s.repo.GetPaymentMethodByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warnf("GetPaymentMethodByID | Payment method not found: id=%d", id)
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("GetPaymentMethodByID | Failed to get payment method: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	response := toPaymentMethodResponse(method)
+	return &response, nil
+}
+
+func (s *PaymentMethodService) CreatePaymentMethod(ctx context.Context, req CreatePaymentMethodRequest) (*PaymentMethodResponse, error) {
+	if err := s.ensureNameAvailable(ctx, req.Name, 0); err != nil {
+		return nil, err
+	}
+
+	method, err := s.repo.CreatePaymentMethod(ctx, repository.CreatePaymentMethodParams{
+		Name:              req.Name,
+		Kind:              req.Kind,
+		OpensCashDrawer:   req.OpensCashDrawer,
+		RequiresReference: req.RequiresReference,
+		AllowsChange:      req.AllowsChange,
+	})
+	if err != nil {
+		s.log.Errorf("CreatePaymentMethod | Failed to create payment method: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeCREATE, method.ID, map[string]interface{}{
+		"name": method.Name,
+		"kind": method.Kind,
+	})
+
+	response := toPaymentMethodResponse(method)
+	return &response, nil
+}
+
+func (s *PaymentMethodService) UpdatePaymentMethod(ctx context.Context, id int32, req UpdatePaymentMethodRequest) (*PaymentMethodResponse, error) {
+	existing, err := s.repo.GetPaymentMethodByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warnf("UpdatePaymentMethod | Payment method not found: id=%d", id)
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("UpdatePaymentMethod | Failed to get payment method: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	params := repository.UpdatePaymentMethodParams{
+		ID:                existing.ID,
+		Name:              existing.Name,
+		Kind:              existing.Kind,
+		OpensCashDrawer:   existing.OpensCashDrawer,
+		RequiresReference: existing.RequiresReference,
+		AllowsChange:      existing.AllowsChange,
+		IsActive:          existing.IsActive,
+	}
+	if req.Name != nil && *req.Name != existing.Name {
+		if err := s.ensureNameAvailable(ctx, *req.Name, id); err != nil {
+			return nil, err
+		}
+		params.Name = *req.Name
+	}
+	if req.Kind != nil {
+		params.Kind = *req.Kind
+	}
+	if req.OpensCashDrawer != nil {
+		params.OpensCashDrawer = *req.OpensCashDrawer
+	}
+	if req.RequiresReference != nil {
+		params.RequiresReference = *req.RequiresReference
+	}
+	if req.AllowsChange != nil {
+		params.AllowsChange = *req.AllowsChange
+	}
+	if req.IsActive != nil {
+		params.IsActive = *req.IsActive
+	}
+
+	method, err := s.repo.UpdatePaymentMethod(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("UpdatePaymentMethod | Failed to update payment method: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeUPDATE, method.ID, map[string]interface{}{
+		"name":      method.Name,
+		"kind":      method.Kind,
+		"is_active": method.IsActive,
+	})
+
+	response := toPaymentMethodResponse(method)
+	return &response, nil
+}
+
+func (s *PaymentMethodService) ReorderPaymentMethods(ctx context.Context, req ReorderPaymentMethodsRequest) ([]PaymentMethodResponse, error) {
+	seen := make(map[int32]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if seen[id] {
+			return nil, common.ErrInvalidInput
+		}
+		seen[id] = true
+	}
+
+	if err := s.repo.ReorderPaymentMethods(ctx, req.IDs); err != nil {
+		s.log.Errorf("ReorderPaymentMethods | Failed to reorder payment methods: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeUPDATE, 0, map[string]interface{}{
+		"action": "reorder",
+		"ids":    req.IDs,
+	})
+
+	return s.ListAllPaymentMethods(ctx)
+}
+
+func (s *PaymentMethodService) DeletePaymentMethod(ctx context.Context, id int32) error {
+	if _, err := s.repo.GetPaymentMethodByID(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warnf("DeletePaymentMethod | Payment method not found: id=%d", id)
+			return common.ErrNotFound
+		}
+		s.log.Errorf("DeletePaymentMethod | Failed to get payment method: %v", err)
+		return common.ErrInternal
+	}
+
+	orderCount, err := s.repo.CountOrdersByPaymentMethod(ctx, &id)
+	if err != nil {
+		s.log.Errorf("DeletePaymentMethod | Failed to count orders for payment method: %v", err)
+		return common.ErrInternal
+	}
+	if orderCount > 0 {
+		s.log.Warnf("DeletePaymentMethod | Payment method still referenced by orders: id=%d, orders=%d", id, orderCount)
+		return common.ErrPaymentMethodInUse
+	}
+
+	if err := s.repo.DeletePaymentMethod(ctx, id); err != nil {
+		s.log.Errorf("DeletePaymentMethod | Failed to delete payment method: %v", err)
+		return common.ErrInternal
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeDELETE, id, map[string]interface{}{
+		"deleted_payment_method_id": id,
+	})
+	return nil
+}
+
+// ensureNameAvailable rejects names already used by another payment method.
+func (s *PaymentMethodService) ensureNameAvailable(ctx context.Context, name string, selfID int32) error {
+	existing, err := s.repo.GetPaymentMethodByName(ctx, name)
+	if err == nil {
+		if existing.ID != selfID {
+			return common.ErrPaymentMethodExists
+		}
+		return nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		s.log.Errorf("Failed to check payment method name: %v", err)
+		return common.ErrInternal
+	}
+	return nil
+}
+
+func (s *PaymentMethodService) logActivity(ctx context.Context, action activitylog_repo.LogActionType, id int32, details map[string]interface{}) {
+	actorID, ok := ctx.Value(common.UserIDKey).(uuid.UUID)
+	if !ok {
+		s.log.Warnf("PaymentMethodService | Actor user ID not found in context for activity logging")
+	}
+
+	s.activityService.Log(
+		ctx,
+		actorID,
+		action,
+		activitylog_repo.LogEntityTypePAYMENTMETHOD,
+		strconv.Itoa(int(id)),
+		details,
+	)
+}
+
+func toPaymentMethodResponse(method repository.PaymentMethod) PaymentMethodResponse {
+	return PaymentMethodResponse{
+		ID:                method.ID,
+		Name:              method.Name,
+		Kind:              method.Kind,
+		SortOrder:         method.SortOrder,
+		OpensCashDrawer:   method.OpensCashDrawer,
+		RequiresReference: method.RequiresReference,
+		AllowsChange:      method.AllowsChange,
+		IsActive:          method.IsActive,
+		CreatedAt:         method.CreatedAt.Time,
+		UpdatedAt:         method.UpdatedAt.Time,
+	}
+}
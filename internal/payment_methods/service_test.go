@@ -1,6 +1,7 @@
 package payment_methods_test
 
 import (
+	"POS-kasir/internal/common"
 	"POS-kasir/internal/payment_methods"
 	"POS-kasir/internal/payment_methods/repository"
 	"POS-kasir/mocks"
@@ -9,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -20,7 +22,8 @@ func TestPaymentMethodService_ListPaymentMethods(t *testing.T) {
 
 	mockRepo := mocks.NewMockPaymentMethodsRepo(ctrl)
 	mockLogger := mocks.NewMockILogger(ctrl)
-	service := payment_methods.NewPaymentMethodService(mockRepo, mockLogger)
+	mockActivity := mocks.NewMockIActivityService(ctrl)
+	service := payment_methods.NewPaymentMethodService(mockRepo, mockLogger, mockActivity)
 
 	ctx := context.Background()
 	now := time.Now()
@@ -65,3 +68,181 @@ func TestPaymentMethodService_ListPaymentMethods(t *testing.T) {
 		assert.Equal(t, dbErr, err)
 	})
 }
+
+func TestPaymentMethodService_CreatePaymentMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPaymentMethodsRepo(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	mockActivity := mocks.NewMockIActivityService(ctrl)
+	service := payment_methods.NewPaymentMethodService(mockRepo, mockLogger, mockActivity)
+
+	ctx := context.Background()
+	req := payment_methods.CreatePaymentMethodRequest{
+		Name:              "Debit Card",
+		Kind:              repository.PaymentMethodKindCard,
+		RequiresReference: true,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetPaymentMethodByName(ctx, "Debit Card").Return(repository.PaymentMethod{}, pgx.ErrNoRows)
+		mockRepo.EXPECT().CreatePaymentMethod(ctx, repository.CreatePaymentMethodParams{
+			Name:              "Debit Card",
+			Kind:              repository.PaymentMethodKindCard,
+			RequiresReference: true,
+		}).Return(repository.PaymentMethod{
+			ID:                4,
+			Name:              "Debit Card",
+			Kind:              repository.PaymentMethodKindCard,
+			SortOrder:         4,
+			RequiresReference: true,
+			IsActive:          true,
+		}, nil)
+		mockLogger.EXPECT().Warnf(gomock.Any()).AnyTimes()
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "4", gomock.Any())
+
+		resp, err := service.CreatePaymentMethod(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(4), resp.ID)
+		assert.Equal(t, repository.PaymentMethodKindCard, resp.Kind)
+		assert.True(t, resp.RequiresReference)
+		assert.False(t, resp.AllowsChange)
+	})
+
+	t.Run("DuplicateName", func(t *testing.T) {
+		mockRepo.EXPECT().GetPaymentMethodByName(ctx, "Debit Card").Return(repository.PaymentMethod{ID: 9, Name: "Debit Card"}, nil)
+
+		resp, err := service.CreatePaymentMethod(ctx, req)
+
+		assert.ErrorIs(t, err, common.ErrPaymentMethodExists)
+		assert.Nil(t, resp)
+	})
+}
+
+func TestPaymentMethodService_UpdatePaymentMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPaymentMethodsRepo(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	mockActivity := mocks.NewMockIActivityService(ctrl)
+	service := payment_methods.NewPaymentMethodService(mockRepo, mockLogger, mockActivity)
+
+	ctx := context.Background()
+	existing := repository.PaymentMethod{
+		ID:              1,
+		Name:            "Cash",
+		Kind:            repository.PaymentMethodKindCash,
+		OpensCashDrawer: true,
+		AllowsChange:    true,
+		IsActive:        true,
+	}
+
+	t.Run("DeactivateKeepsOtherFields", func(t *testing.T) {
+		inactive := false
+		mockRepo.EXPECT().GetPaymentMethodByID(ctx, int32(1)).Return(existing, nil)
+		mockRepo.EXPECT().UpdatePaymentMethod(ctx, repository.UpdatePaymentMethodParams{
+			ID:              1,
+			Name:            "Cash",
+			Kind:            repository.PaymentMethodKindCash,
+			OpensCashDrawer: true,
+			AllowsChange:    true,
+			IsActive:        false,
+		}).DoAndReturn(func(_ context.Context, arg repository.UpdatePaymentMethodParams) (repository.PaymentMethod, error) {
+			updated := existing
+			updated.IsActive = arg.IsActive
+			return updated, nil
+		})
+		mockLogger.EXPECT().Warnf(gomock.Any()).AnyTimes()
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "1", gomock.Any())
+
+		resp, err := service.UpdatePaymentMethod(ctx, 1, payment_methods.UpdatePaymentMethodRequest{IsActive: &inactive})
+
+		assert.NoError(t, err)
+		assert.False(t, resp.IsActive)
+		assert.True(t, resp.OpensCashDrawer)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockRepo.EXPECT().GetPaymentMethodByID(ctx, int32(99)).Return(repository.PaymentMethod{}, pgx.ErrNoRows)
+		mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+		resp, err := service.UpdatePaymentMethod(ctx, 99, payment_methods.UpdatePaymentMethodRequest{})
+
+		assert.ErrorIs(t, err, common.ErrNotFound)
+		assert.Nil(t, resp)
+	})
+}
+
+func TestPaymentMethodService_DeletePaymentMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPaymentMethodsRepo(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	mockActivity := mocks.NewMockIActivityService(ctrl)
+	service := payment_methods.NewPaymentMethodService(mockRepo, mockLogger, mockActivity)
+
+	ctx := context.Background()
+	id := int32(2)
+
+	t.Run("InUse", func(t *testing.T) {
+		mockRepo.EXPECT().GetPaymentMethodByID(ctx, id).Return(repository.PaymentMethod{ID: id}, nil)
+		mockRepo.EXPECT().CountOrdersByPaymentMethod(ctx, &id).Return(int64(3), nil)
+		mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any(), gomock.Any())
+
+		err := service.DeletePaymentMethod(ctx, id)
+
+		assert.ErrorIs(t, err, common.ErrPaymentMethodInUse)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetPaymentMethodByID(ctx, id).Return(repository.PaymentMethod{ID: id}, nil)
+		mockRepo.EXPECT().CountOrdersByPaymentMethod(ctx, &id).Return(int64(0), nil)
+		mockRepo.EXPECT().DeletePaymentMethod(ctx, id).Return(nil)
+		mockLogger.EXPECT().Warnf(gomock.Any()).AnyTimes()
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "2", gomock.Any())
+
+		err := service.DeletePaymentMethod(ctx, id)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestPaymentMethodService_ReorderPaymentMethods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPaymentMethodsRepo(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	mockActivity := mocks.NewMockIActivityService(ctrl)
+	service := payment_methods.NewPaymentMethodService(mockRepo, mockLogger, mockActivity)
+
+	ctx := context.Background()
+
+	t.Run("DuplicateIDs", func(t *testing.T) {
+		resp, err := service.ReorderPaymentMethods(ctx, payment_methods.ReorderPaymentMethodsRequest{IDs: []int32{1, 2, 1}})
+
+		assert.ErrorIs(t, err, common.ErrInvalidInput)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().ReorderPaymentMethods(ctx, []int32{3, 1, 2}).Return(nil)
+		mockLogger.EXPECT().Warnf(gomock.Any()).AnyTimes()
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+		mockRepo.EXPECT().ListAllPaymentMethods(ctx).Return([]repository.PaymentMethod{
+			{ID: 3, Name: "QRIS Statis", SortOrder: 1},
+			{ID: 1, Name: "Cash", SortOrder: 2},
+			{ID: 2, Name: "QRIS Dinamis", SortOrder: 3},
+		}, nil)
+
+		resp, err := service.ReorderPaymentMethods(ctx, payment_methods.ReorderPaymentMethodsRequest{IDs: []int32{3, 1, 2}})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp, 3)
+		assert.Equal(t, int32(3), resp[0].ID)
+	})
+}
@@ -1,9 +1,19 @@
 -- name: CreatePaymentMethod :one
--- Membuat metode pembayaran baru.
-INSERT INTO payment_methods (name)
-VALUES ($1)
+-- Membuat metode pembayaran baru, ditempatkan di urutan paling akhir.
+INSERT INTO payment_methods (name, kind, opens_cash_drawer, requires_reference, allows_change, sort_order)
+VALUES (
+    $1, $2, $3, $4, $5,
+    (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM payment_methods)
+)
 RETURNING *;
 
+-- name: GetPaymentMethodByID :one
+-- Mengambil satu metode pembayaran berdasarkan ID.
+SELECT *
+FROM payment_methods
+WHERE id = $1
+LIMIT 1;
+
 -- name: GetPaymentMethodByName :one
 -- Mengambil satu metode pembayaran berdasarkan nama untuk pengecekan duplikat.
 SELECT *
@@ -16,4 +26,41 @@ LIMIT 1;
 SELECT *
 FROM payment_methods
 WHERE is_active = true
-ORDER BY name;
\ No newline at end of file
+ORDER BY sort_order, name;
+
+-- name: ListAllPaymentMethods :many
+-- Mengambil daftar semua metode pembayaran, termasuk yang nonaktif.
+SELECT *
+FROM payment_methods
+ORDER BY sort_order, name;
+
+-- name: UpdatePaymentMethod :one
+-- Memperbarui nama, jenis, flag, dan status aktif metode pembayaran.
+UPDATE payment_methods
+SET
+    name = $2,
+    kind = $3,
+    opens_cash_drawer = $4,
+    requires_reference = $5,
+    allows_change = $6,
+    is_active = $7,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ReorderPaymentMethods :exec
+-- Menetapkan urutan tampilan sesuai posisi ID di dalam array.
+UPDATE payment_methods pm
+SET sort_order = o.position, updated_at = now()
+FROM unnest(sqlc.arg(ids)::int[]) WITH ORDINALITY AS o(id, position)
+WHERE pm.id = o.id;
+
+-- name: CountOrdersByPaymentMethod :one
+-- Menghitung pesanan yang memakai metode pembayaran ini, termasuk sebagai tender split (mencegah penghapusan).
+SELECT count(*) FROM orders o
+WHERE o.payment_method_id = $1
+   OR EXISTS (SELECT 1 FROM order_payments op WHERE op.order_id = o.id AND op.payment_method_id = $1);
+
+-- name: DeletePaymentMethod :exec
+-- Menghapus metode pembayaran yang belum pernah dipakai.
+DELETE FROM payment_methods WHERE id = $1;
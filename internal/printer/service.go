@@ -9,6 +9,7 @@ import (
 	"POS-kasir/pkg/logger"
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -134,6 +135,15 @@ func (s *PrinterService) printInvoiceToPrinter(p escpos.Printer, order *orders.O
 	p.SetSize(escpos.NormalSize)
 	p.SetBold(false)
 	p.WriteString(fmt.Sprintf("%s\n", time.Now().Format("02 Jan 2006 15:04")))
+	if order.QueueNumber != "" {
+		// Guests listen for this number, so it stands out on the ticket
+		p.WriteString("Queue No.\n")
+		p.SetBold(true)
+		p.SetSize(escpos.DoubleSizeOn)
+		p.WriteString(order.QueueNumber + "\n")
+		p.SetSize(escpos.NormalSize)
+		p.SetBold(false)
+	}
 	p.WriteString(fmt.Sprintf("Order #%s\n", order.ID.String()[len(order.ID.String())-4:])) // Short ID
 	p.WriteString("Cashier: " + cashierName + "\n")
 	p.WriteString("--------------------------------\n")
@@ -165,13 +175,39 @@ func (s *PrinterService) printInvoiceToPrinter(p escpos.Printer, order *orders.O
 	if order.DiscountAmount > 0 {
 		writeTotalLine(p, "Discount", "-"+formatCurrency(order.DiscountAmount))
 	}
+	if order.ServiceChargeAmount > 0 {
+		writeTotalLine(p, fmt.Sprintf("Service %s%%", formatRate(order.ServiceChargeRate)), formatCurrency(order.ServiceChargeAmount))
+	}
+	if order.TaxAmount > 0 {
+		if order.TaxInclusive {
+			writeTotalLine(p, fmt.Sprintf("Tax %s%% (incl.)", formatRate(order.TaxRate)), formatCurrency(order.TaxAmount))
+		} else {
+			writeTotalLine(p, fmt.Sprintf("Tax %s%%", formatRate(order.TaxRate)), formatCurrency(order.TaxAmount))
+		}
+	}
 	p.SetBold(true)
 	writeTotalLine(p, "TOTAL", formatCurrency(order.NetTotal))
 	p.SetBold(false)
 
 	p.WriteString("--------------------------------\n")
 
-	if order.PaymentMethodID != nil {
+	if len(order.Payments) > 0 {
+		// One line per tender so split payments show how the bill was settled
+		var changeDue int64
+		for _, payment := range order.Payments {
+			writeTotalLine(p, payment.PaymentMethodName, formatCurrency(payment.TenderedAmount))
+			if payment.ReferenceNumber != nil && *payment.ReferenceNumber != "" {
+				p.WriteString("  Ref: " + *payment.ReferenceNumber + "\n")
+			}
+			changeDue += payment.ChangeAmount
+		}
+		if changeDue > 0 {
+			writeTotalLine(p, "Change", formatCurrency(changeDue))
+		}
+		if order.BalanceDue > 0 {
+			writeTotalLine(p, "Balance Due", formatCurrency(order.BalanceDue))
+		}
+	} else if order.PaymentMethodID != nil {
 		p.WriteString("Payment: " + paymentMethodName + "\n")
 
 		if order.CashReceived != nil && *order.CashReceived > 0 {
@@ -184,6 +220,17 @@ func (s *PrinterService) printInvoiceToPrinter(p escpos.Printer, order *orders.O
 		p.WriteString("UNPAID\n")
 	}
 
+	if order.Loyalty != nil {
+		p.WriteString("--------------------------------\n")
+		if order.Loyalty.PointsRedeemed > 0 {
+			writeTotalLine(p, "Points Redeemed", formatPoints(order.Loyalty.PointsRedeemed))
+		}
+		if order.Loyalty.PointsEarned > 0 {
+			writeTotalLine(p, "Points Earned", formatPoints(order.Loyalty.PointsEarned))
+		}
+		writeTotalLine(p, "Points Balance", formatPoints(order.Loyalty.Balance))
+	}
+
 	p.SetAlign(escpos.AlignCenter)
 	p.WriteString("\n")
 	p.WriteString("© 2025 " + branding.AppName + "\n")
@@ -235,3 +282,11 @@ func writeTotalLine(p escpos.Printer, label, value string) {
 func formatCurrency(amount int64) string {
 	return fmt.Sprintf("Rp %d", amount)
 }
+
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64)
+}
+
+func formatPoints(points int64) string {
+	return fmt.Sprintf("%d pts", points)
+}
@@ -108,6 +108,54 @@ func (m *MockSettingsService) UpdatePrinterSettings(ctx context.Context, req set
 	return args.Get(0).(*settings.PrinterSettingsResponse), args.Error(1)
 }
 
+func (m *MockSettingsService) GetTaxSettings(ctx context.Context) (*settings.TaxSettingsResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*settings.TaxSettingsResponse), args.Error(1)
+}
+
+func (m *MockSettingsService) UpdateTaxSettings(ctx context.Context, req settings.UpdateTaxSettingsRequest) (*settings.TaxSettingsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*settings.TaxSettingsResponse), args.Error(1)
+}
+
+func (m *MockSettingsService) GetOperationalSettings(ctx context.Context) (*settings.OperationalSettingsResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*settings.OperationalSettingsResponse), args.Error(1)
+}
+
+func (m *MockSettingsService) UpdateOperationalSettings(ctx context.Context, req settings.UpdateOperationalSettingsRequest) (*settings.OperationalSettingsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*settings.OperationalSettingsResponse), args.Error(1)
+}
+
+func (m *MockSettingsService) GetLoyaltySettings(ctx context.Context) (*settings.LoyaltySettingsResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*settings.LoyaltySettingsResponse), args.Error(1)
+}
+
+func (m *MockSettingsService) UpdateLoyaltySettings(ctx context.Context, req settings.UpdateLoyaltySettingsRequest) (*settings.LoyaltySettingsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*settings.LoyaltySettingsResponse), args.Error(1)
+}
+
 func (m *MockSettingsService) UpdateLogo(ctx context.Context, data []byte, filename string, contentType string) (string, error) {
 	args := m.Called(ctx, data, filename, contentType)
 	return args.String(0), args.Error(1)
@@ -192,6 +240,80 @@ func TestPrinterService_PrintInvoice(t *testing.T) {
 		mockSettingsService.AssertExpectations(t)
 	})
 
+	t.Run("SplitTenderLines", func(t *testing.T) {
+		allowAllLoggerCalls(mockLogger)
+		splitPrinter := new(MockPrinter)
+		splitService := printer.NewPrinterService(mockOrderService, mockSettingsService, mockPayment, mockUserRepo, mockLogger, func(conn string) (escpos.Printer, error) {
+			return splitPrinter, nil
+		})
+
+		order := orders.OrderDetailResponse{
+			ID:              orderID,
+			Status:          orders_repo.OrderStatusPaid,
+			GrossTotal:      50000,
+			NetTotal:        50000,
+			PaymentMethodID: &payMethodID,
+			Payments: []orders.OrderPaymentResponse{
+				{PaymentMethodID: 1, PaymentMethodName: "Cash", Amount: 30000, TenderedAmount: 40000, ChangeAmount: 10000},
+				{PaymentMethodID: 4, PaymentMethodName: "Debit Card", Amount: 20000, TenderedAmount: 20000},
+			},
+		}
+
+		mockSettingsService.On("GetPrinterSettings", ctx).Return(printerSettings, nil).Once()
+		mockOrderService.EXPECT().GetOrder(ctx, orderID).Return(&order, nil)
+		mockSettingsService.On("GetBranding", ctx).Return(branding, nil).Once()
+		mockPayment.EXPECT().ListPaymentMethods(ctx).Return([]payment_methods.PaymentMethodResponse{{ID: 1, Name: "Cash"}}, nil)
+
+		splitPrinter.On("Init").Return(nil)
+		splitPrinter.On("SetAlign", mock.Anything).Return(nil)
+		splitPrinter.On("SetBold", mock.Anything).Return(nil)
+		splitPrinter.On("SetSize", mock.Anything).Return(nil)
+		splitPrinter.On("WriteString", mock.Anything).Return(0, nil)
+		splitPrinter.On("Cut").Return(nil)
+		splitPrinter.On("Close").Return(nil)
+
+		err := splitService.PrintInvoice(ctx, orderID)
+		assert.NoError(t, err)
+		splitPrinter.AssertCalled(t, "WriteString", "Cash                    Rp 40000\n")
+		splitPrinter.AssertCalled(t, "WriteString", "Debit Card              Rp 20000\n")
+		splitPrinter.AssertCalled(t, "WriteString", "Change                  Rp 10000\n")
+	})
+
+	t.Run("LoyaltyPointLines", func(t *testing.T) {
+		allowAllLoggerCalls(mockLogger)
+		loyaltyPrinter := new(MockPrinter)
+		loyaltyService := printer.NewPrinterService(mockOrderService, mockSettingsService, mockPayment, mockUserRepo, mockLogger, func(conn string) (escpos.Printer, error) {
+			return loyaltyPrinter, nil
+		})
+
+		order := orders.OrderDetailResponse{
+			ID:             orderID,
+			Status:         orders_repo.OrderStatusPaid,
+			GrossTotal:     60000,
+			DiscountAmount: 10000,
+			NetTotal:       50000,
+			Loyalty:        &orders.OrderLoyaltyResponse{PointsEarned: 5, PointsRedeemed: 100, DiscountAmount: 10000, Balance: 1405},
+		}
+
+		mockSettingsService.On("GetPrinterSettings", ctx).Return(printerSettings, nil).Once()
+		mockOrderService.EXPECT().GetOrder(ctx, orderID).Return(&order, nil)
+		mockSettingsService.On("GetBranding", ctx).Return(branding, nil).Once()
+
+		loyaltyPrinter.On("Init").Return(nil)
+		loyaltyPrinter.On("SetAlign", mock.Anything).Return(nil)
+		loyaltyPrinter.On("SetBold", mock.Anything).Return(nil)
+		loyaltyPrinter.On("SetSize", mock.Anything).Return(nil)
+		loyaltyPrinter.On("WriteString", mock.Anything).Return(0, nil)
+		loyaltyPrinter.On("Cut").Return(nil)
+		loyaltyPrinter.On("Close").Return(nil)
+
+		err := loyaltyService.PrintInvoice(ctx, orderID)
+		assert.NoError(t, err)
+		loyaltyPrinter.AssertCalled(t, "WriteString", "Points Redeemed          100 pts\n")
+		loyaltyPrinter.AssertCalled(t, "WriteString", "Points Earned              5 pts\n")
+		loyaltyPrinter.AssertCalled(t, "WriteString", "Points Balance          1405 pts\n")
+	})
+
 	t.Run("GetOrderError", func(t *testing.T) {
 		mockSettingsService.On("GetPrinterSettings", ctx).Return(printerSettings, nil).Once()
 		mockOrderService.EXPECT().GetOrder(ctx, orderID).Return(nil, errors.New("db error"))
@@ -290,6 +412,7 @@ func TestPrinterService_GetInvoiceData(t *testing.T) {
 			GrossTotal:      50000,
 			NetTotal:        50000,
 			PaymentMethodID: &payMethodID,
+			QueueNumber:     "A007",
 			Items: []orders.OrderItemResponse{
 				{ProductName: "Item 1", Quantity: 1, PriceAtSale: 50000, Subtotal: 50000},
 			},
@@ -306,6 +429,7 @@ func TestPrinterService_GetInvoiceData(t *testing.T) {
 		assert.NotEmpty(t, data)
 		assert.Contains(t, filename, "invoice_")
 		assert.Contains(t, string(data), "Test App")
+		assert.Contains(t, string(data), "A007")
 
 		mockSettingsService.AssertExpectations(t)
 	})
@@ -0,0 +1,102 @@
+package products
+
+import (
+	"POS-kasir/internal/common"
+	products_repo "POS-kasir/internal/products/repository"
+	"fmt"
+	"strings"
+)
+
+// inStoreBarcodePrefix starts generated EAN-13 codes. GS1 keeps prefixes 20-29 for codes used only inside
+// the store, so they never clash with a maker's barcode.
+const inStoreBarcodePrefix = "20"
+
+// parseBarcode checks a barcode against its symbology. Without a symbology, all-digit codes of 13, 12 and 8
+// digits are read as EAN-13, UPC-A and EAN-8 and anything else as an internal code.
+func parseBarcode(code string, symbology *string) (string, products_repo.BarcodeSymbology, error) {
+	code = strings.TrimSpace(code)
+	if code == "" || len(code) > 64 {
+		return "", "", common.ErrBarcodeInvalid
+	}
+
+	var kind products_repo.BarcodeSymbology
+	if symbology != nil {
+		kind = products_repo.BarcodeSymbology(*symbology)
+	} else {
+		kind = products_repo.BarcodeSymbologyInternal
+		if isDigits(code) {
+			switch len(code) {
+			case 13:
+				kind = products_repo.BarcodeSymbologyEan13
+			case 12:
+				kind = products_repo.BarcodeSymbologyUpcA
+			case 8:
+				kind = products_repo.BarcodeSymbologyEan8
+			}
+		}
+	}
+
+	switch kind {
+	case products_repo.BarcodeSymbologyEan13:
+		if len(code) != 13 || !validCheckDigit(code) {
+			return "", "", common.ErrBarcodeInvalid
+		}
+	case products_repo.BarcodeSymbologyUpcA:
+		if len(code) != 12 || !validCheckDigit(code) {
+			return "", "", common.ErrBarcodeInvalid
+		}
+	case products_repo.BarcodeSymbologyEan8:
+		if len(code) != 8 || !validCheckDigit(code) {
+			return "", "", common.ErrBarcodeInvalid
+		}
+	case products_repo.BarcodeSymbologyInternal:
+		for _, r := range code {
+			if !(r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r == '-') {
+				return "", "", common.ErrBarcodeInvalid
+			}
+		}
+	default:
+		return "", "", common.ErrBarcodeInvalid
+	}
+	return code, kind, nil
+}
+
+// validCheckDigit verifies the last digit of a GTIN (EAN-13, UPC-A, EAN-8).
+func validCheckDigit(code string) bool {
+	if !isDigits(code) {
+		return false
+	}
+	return checkDigit(code[:len(code)-1]) == code[len(code)-1]
+}
+
+// checkDigit computes the GTIN check digit for the digits before it: from the right, digits are weighted
+// 3, 1, 3, ... and the check digit rounds the sum up to a multiple of ten.
+func checkDigit(digits string) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// inStoreBarcode builds the EAN-13 code for a number taken from instore_barcode_seq.
+func inStoreBarcode(number int64) string {
+	digits := fmt.Sprintf("%s%010d", inStoreBarcodePrefix, number%10_000_000_000)
+	return digits + string(checkDigit(digits))
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
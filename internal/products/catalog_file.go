@@ -0,0 +1,345 @@
+package products
+
+import (
+	"POS-kasir/internal/common"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+)
+
+// Columns of a catalog file, in the order an export writes them. An import only needs name; the other
+// columns may be left out or come in any order.
+const (
+	catalogColumnID         = "id"
+	catalogColumnSKU        = "sku"
+	catalogColumnName       = "name"
+	catalogColumnCategories = "categories"
+	catalogColumnPrice      = "price"
+	catalogColumnCostPrice  = "cost_price"
+	catalogColumnStock      = "stock"
+	catalogColumnOptions    = "options"
+	catalogColumnImageURL   = "image_url"
+)
+
+var catalogColumns = []string{
+	catalogColumnID,
+	catalogColumnSKU,
+	catalogColumnName,
+	catalogColumnCategories,
+	catalogColumnPrice,
+	catalogColumnCostPrice,
+	catalogColumnStock,
+	catalogColumnOptions,
+	catalogColumnImageURL,
+}
+
+// Formats of a catalog file.
+const (
+	CatalogFormatCSV  = "csv"
+	CatalogFormatXLSX = "xlsx"
+)
+
+const (
+	// catalogListSeparator separates the categories and the options of a cell, e.g. "Coffee|Drinks" or
+	// "Large:5000|Oat milk:7000".
+	catalogListSeparator = "|"
+	catalogSheet         = "Products"
+	maxImportRows        = 2000
+	maxImportFileSize    = 5 * 1024 * 1024
+)
+
+// importOption is an option listed in the options cell of a row.
+type importOption struct {
+	Name  string
+	Price int64
+}
+
+// importRow is one product row of an import file. Fields are nil when the cell is empty or the column
+// missing, which keeps the product's current value. Errors lists what is wrong with the cells themselves.
+type importRow struct {
+	Line       int
+	ID         *uuid.UUID
+	SKU        *string
+	Name       *string
+	Categories *[]string
+	Price      *int64
+	CostPrice  *float64
+	Stock      *int32
+	Options    *[]importOption
+	ImageURL   *string
+	Errors     []string
+}
+
+// readCatalogFile reads the cells of a CSV file, or of the first sheet of an XLSX file.
+func readCatalogFile(filename string, data []byte) ([][]string, error) {
+	if len(data) == 0 || len(data) > maxImportFileSize {
+		return nil, common.ErrImportFileInvalid
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case "." + CatalogFormatCSV:
+		r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+		r.FieldsPerRecord = -1
+		records, err := r.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", common.ErrImportFileInvalid, err)
+		}
+		return records, nil
+	case "." + CatalogFormatXLSX:
+		f, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", common.ErrImportFileInvalid, err)
+		}
+		defer f.Close()
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, common.ErrImportFileInvalid
+		}
+		records, err := f.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", common.ErrImportFileInvalid, err)
+		}
+		return records, nil
+	}
+	return nil, common.ErrImportFileInvalid
+}
+
+// writeCatalogFile writes the header and rows of an export. XLSX cells keep their numbers as numbers.
+func writeCatalogFile(format string, header []string, rows [][]interface{}) ([]byte, error) {
+	switch format {
+	case CatalogFormatCSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if err := w.Write(header); err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			record := make([]string, len(row))
+			for i, v := range row {
+				record[i] = formatCatalogCell(v)
+			}
+			if err := w.Write(record); err != nil {
+				return nil, err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CatalogFormatXLSX:
+		f := excelize.NewFile()
+		defer f.Close()
+		if err := f.SetSheetName(f.GetSheetName(0), catalogSheet); err != nil {
+			return nil, err
+		}
+		cells := make([]interface{}, len(header))
+		for i, h := range header {
+			cells[i] = h
+		}
+		if err := f.SetSheetRow(catalogSheet, "A1", &cells); err != nil {
+			return nil, err
+		}
+		for i, row := range rows {
+			cell, err := excelize.CoordinatesToCellName(1, i+2)
+			if err != nil {
+				return nil, err
+			}
+			if err := f.SetSheetRow(catalogSheet, cell, &row); err != nil {
+				return nil, err
+			}
+		}
+		buf, err := f.WriteToBuffer()
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("unknown catalog format %q", format)
+}
+
+func formatCatalogCell(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parseImportRows turns the cells of an import file into rows, skipping blank lines. Header names ignore
+// case and unknown columns are ignored.
+func parseImportRows(records [][]string) ([]importRow, error) {
+	if len(records) == 0 {
+		return nil, common.ErrImportFileInvalid
+	}
+
+	columns := make(map[string]int)
+	for i, h := range records[0] {
+		name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(h)), " ", "_")
+		if _, dup := columns[name]; dup && name != "" {
+			return nil, fmt.Errorf("%w: column %q appears twice", common.ErrImportFileInvalid, name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns[catalogColumnName]; !ok {
+		return nil, common.ErrImportFileInvalid
+	}
+
+	var rows []importRow
+	for i, record := range records[1:] {
+		cell := func(column string) string {
+			idx, ok := columns[column]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		if len(rows) == maxImportRows {
+			return nil, fmt.Errorf("%w: at most %d products per file", common.ErrImportFileInvalid, maxImportRows)
+		}
+		rows = append(rows, parseImportRow(i+2, cell))
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no products in the file", common.ErrImportFileInvalid)
+	}
+	return rows, nil
+}
+
+func parseImportRow(line int, cell func(column string) string) importRow {
+	row := importRow{Line: line}
+	fail := func(format string, args ...interface{}) {
+		row.Errors = append(row.Errors, fmt.Sprintf(format, args...))
+	}
+
+	if v := cell(catalogColumnID); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			fail("id is not a valid product ID")
+		} else {
+			row.ID = &id
+		}
+	}
+	if v := cell(catalogColumnSKU); v != "" {
+		if len(v) > 64 {
+			fail("sku is longer than 64 characters")
+		}
+		row.SKU = &v
+	}
+	if v := cell(catalogColumnName); v != "" {
+		if len(v) < 3 || len(v) > 100 {
+			fail("name must be 3 to 100 characters")
+		}
+		row.Name = &v
+	}
+	if v := cell(catalogColumnCategories); v != "" {
+		var names []string
+		seen := make(map[string]bool)
+		for _, name := range strings.Split(v, catalogListSeparator) {
+			name = strings.TrimSpace(name)
+			if name == "" || seen[strings.ToLower(name)] {
+				continue
+			}
+			seen[strings.ToLower(name)] = true
+			names = append(names, name)
+		}
+		row.Categories = &names
+	}
+	if v := cell(catalogColumnPrice); v != "" {
+		price, err := parseCatalogAmount(v)
+		if err != nil || price <= 0 {
+			fail("price must be a number above 0")
+		} else {
+			p := int64(price)
+			row.Price = &p
+		}
+	}
+	if v := cell(catalogColumnCostPrice); v != "" {
+		cost, err := parseCatalogAmount(v)
+		if err != nil || cost < 0 {
+			fail("cost_price must be a number of at least 0")
+		} else {
+			row.CostPrice = &cost
+		}
+	}
+	if v := cell(catalogColumnStock); v != "" {
+		stock, err := parseCatalogAmount(v)
+		if err != nil || stock < 0 || stock != math.Trunc(stock) || stock > math.MaxInt32 {
+			fail("stock must be a whole number of at least 0")
+		} else {
+			s := int32(stock)
+			row.Stock = &s
+		}
+	}
+	if v := cell(catalogColumnOptions); v != "" {
+		options, err := parseImportOptions(v)
+		if err != nil {
+			fail("%v", err)
+		} else {
+			row.Options = &options
+		}
+	}
+	if v := cell(catalogColumnImageURL); v != "" {
+		u, err := url.Parse(v)
+		if err != nil || checkImportImageURL(u) != nil {
+			fail("image_url must be an http or https link")
+		} else {
+			row.ImageURL = &v
+		}
+	}
+	return row
+}
+
+// parseImportOptions reads an options cell such as "Large:5000|Oat milk:7000". An option without a price
+// costs nothing extra.
+func parseImportOptions(v string) ([]importOption, error) {
+	var options []importOption
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(v, catalogListSeparator) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, price := part, 0.0
+		if i := strings.LastIndex(part, ":"); i >= 0 {
+			var err error
+			name = strings.TrimSpace(part[:i])
+			price, err = parseCatalogAmount(strings.TrimSpace(part[i+1:]))
+			if err != nil || price < 0 {
+				return nil, fmt.Errorf("option %q must have a price of at least 0", name)
+			}
+		}
+		if name == "" || len(name) > 100 {
+			return nil, fmt.Errorf("option names must be 1 to 100 characters")
+		}
+		if seen[strings.ToLower(name)] {
+			return nil, fmt.Errorf("option %q is listed twice", name)
+		}
+		seen[strings.ToLower(name)] = true
+		options = append(options, importOption{Name: name, Price: int64(price)})
+	}
+	return options, nil
+}
+
+func parseCatalogAmount(v string) (float64, error) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("not a number: %q", v)
+	}
+	return f, nil
+}
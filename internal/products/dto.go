@@ -7,22 +7,33 @@ import (
 	"github.com/google/uuid"
 )
 
+// CreateProductOptionRequest adds an option, optionally into a modifier group. A default option is picked
+// automatically when an order line picks nothing from its group.
 type CreateProductOptionRequest struct {
-	Name            string  `json:"name" validate:"required,min=1,max=100"`
-	AdditionalPrice float64 `json:"additional_price" validate:"gte=0"`
+	Name            string     `json:"name" validate:"required,min=1,max=100"`
+	AdditionalPrice float64    `json:"additional_price" validate:"gte=0"`
+	ModifierGroupID *uuid.UUID `json:"modifier_group_id,omitempty"`
+	IsDefault       bool       `json:"is_default"`
 }
 
+// CreateProductRequest creates a product. Barcodes are read as EAN-13, UPC-A or EAN-8 by their length, or
+// as internal codes; GenerateBarcode gives a product without any an in-store EAN-13 code.
 type CreateProductRequest struct {
-	Name        string                       `json:"name" validate:"required,min=3,max=100"`
-	CategoryIDs []int32                      `json:"category_ids" validate:"omitempty,dive,gt=0"`
-	Price       float64                      `json:"price" validate:"required,gt=0"`
-	CostPrice   float64                      `json:"cost_price" validate:"required,gte=0"`
-	Stock       int32                        `json:"stock" validate:"required,gte=0"`
-	Options     []CreateProductOptionRequest `json:"options" validate:"dive"`
+	Name            string                       `json:"name" validate:"required,min=3,max=100"`
+	SKU             *string                      `json:"sku" validate:"omitempty,min=1,max=64"`
+	Barcodes        []string                     `json:"barcodes" validate:"omitempty,max=10,dive,required,max=64"`
+	GenerateBarcode bool                         `json:"generate_barcode"`
+	CategoryIDs     []int32                      `json:"category_ids" validate:"omitempty,dive,gt=0"`
+	Price           float64                      `json:"price" validate:"required,gt=0"`
+	CostPrice       float64                      `json:"cost_price" validate:"required,gte=0"`
+	Stock           int32                        `json:"stock" validate:"required,gte=0"`
+	Options         []CreateProductOptionRequest `json:"options" validate:"dive"`
 }
 
+// UpdateProductRequest edits a product. An empty SKU removes it.
 type UpdateProductRequest struct {
 	Name        *string  `json:"name" validate:"omitempty,min=3,max=100"`
+	SKU         *string  `json:"sku" validate:"omitempty,max=64"`
 	CategoryIDs *[]int32 `json:"category_ids" validate:"omitempty,dive,gt=0"`
 	Price       *float64 `json:"price" validate:"omitempty,gt=0"`
 	CostPrice   *float64 `json:"cost_price" validate:"omitempty,gte=0"`
@@ -31,26 +42,125 @@ type UpdateProductRequest struct {
 	ChangeType  *string  `json:"change_type" validate:"omitempty,oneof=sale restock correction return damage"`
 }
 
+// ListProductsRequest filters the product list. Search matches part of a name, or a SKU or barcode exactly.
 type ListProductsRequest struct {
 	pagination.PaginationRequest
 	CategoryID *int32  `query:"category_id" validate:"omitempty,gt=0"`
 }
 
 type CreateProductOptionRequestStandalone struct {
-	Name            string  `json:"name" validate:"required,min=1,max=100"`
-	AdditionalPrice float64 `json:"additional_price" validate:"gte=0"`
+	Name            string     `json:"name" validate:"required,min=1,max=100"`
+	AdditionalPrice float64    `json:"additional_price" validate:"gte=0"`
+	ModifierGroupID *uuid.UUID `json:"modifier_group_id,omitempty"`
+	IsDefault       bool       `json:"is_default"`
 }
 
+// UpdateProductOptionRequest edits an option. ClearModifierGroup takes it out of its modifier group, which
+// also drops its default flag.
 type UpdateProductOptionRequest struct {
-	Name            *string  `json:"name" validate:"omitempty,min=1,max=100"`
-	AdditionalPrice *float64 `json:"additional_price" validate:"omitempty,gte=0"`
+	Name               *string    `json:"name" validate:"omitempty,min=1,max=100"`
+	AdditionalPrice    *float64   `json:"additional_price" validate:"omitempty,gte=0"`
+	ModifierGroupID    *uuid.UUID `json:"modifier_group_id,omitempty"`
+	ClearModifierGroup bool       `json:"clear_modifier_group"`
+	IsDefault          *bool      `json:"is_default"`
 }
 
 type ProductOptionResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	Name            string     `json:"name"`
+	AdditionalPrice float64    `json:"additional_price"`
+	ImageURL        *string    `json:"image_url,omitempty"`
+	ModifierGroupID *uuid.UUID `json:"modifier_group_id,omitempty"`
+	IsDefault       bool       `json:"is_default"`
+}
+
+// CreateModifierGroupRequest defines how many options an order line picks from a group: MinSelect 1 with
+// MaxSelect 1 means exactly one, and no MaxSelect means no upper limit.
+type CreateModifierGroupRequest struct {
+	Name      string `json:"name" validate:"required,min=1,max=100"`
+	MinSelect int32  `json:"min_select" validate:"gte=0"`
+	MaxSelect *int32 `json:"max_select" validate:"omitempty,gte=1"`
+}
+
+// UpdateModifierGroupRequest edits a modifier group. ClearMaxSelect removes the upper limit.
+type UpdateModifierGroupRequest struct {
+	Name           *string `json:"name" validate:"omitempty,min=1,max=100"`
+	MinSelect      *int32  `json:"min_select" validate:"omitempty,gte=0"`
+	MaxSelect      *int32  `json:"max_select" validate:"omitempty,gte=1"`
+	ClearMaxSelect bool    `json:"clear_max_select"`
+}
+
+type ModifierGroupOptionResponse struct {
 	ID              uuid.UUID `json:"id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	ProductName     string    `json:"product_name"`
 	Name            string    `json:"name"`
 	AdditionalPrice float64   `json:"additional_price"`
-	ImageURL        *string   `json:"image_url,omitempty"`
+	IsDefault       bool      `json:"is_default"`
+}
+
+// ModifierGroupResponse is a modifier group; Options lists the options of every product that uses it and is
+// only filled in when a single group is fetched.
+type ModifierGroupResponse struct {
+	ID        uuid.UUID                     `json:"id"`
+	Name      string                        `json:"name"`
+	MinSelect int32                         `json:"min_select"`
+	MaxSelect *int32                        `json:"max_select,omitempty"`
+	Options   []ModifierGroupOptionResponse `json:"options,omitempty"`
+}
+
+type VariantAttributeRequest struct {
+	Name   string   `json:"name" validate:"required,min=1,max=50"`
+	Values []string `json:"values" validate:"required,min=1,dive,required,max=50"`
+}
+
+// GenerateVariantsRequest builds a product's variant matrix, one variant per combination of attribute values
+// (e.g. size x colour). Generating again with new values adds the missing combinations; the attributes
+// themselves are fixed once the product has variants. Price, when set, overrides the product price for the
+// new variants, and CostPrice defaults to the product's.
+type GenerateVariantsRequest struct {
+	SKUPrefix  string                    `json:"sku_prefix" validate:"required,min=1,max=40"`
+	Attributes []VariantAttributeRequest `json:"attributes" validate:"required,min=1,max=3,dive"`
+	Price      *float64                  `json:"price" validate:"omitempty,gt=0"`
+	CostPrice  *float64                  `json:"cost_price" validate:"omitempty,gte=0"`
+}
+
+// UpdateVariantRequest edits one variant. An empty barcode removes it and ClearPrice makes the variant sell
+// at the product price again.
+type UpdateVariantRequest struct {
+	SKU        *string  `json:"sku" validate:"omitempty,min=1,max=64"`
+	Barcode    *string  `json:"barcode" validate:"omitempty,max=64"`
+	Price      *float64 `json:"price" validate:"omitempty,gt=0"`
+	ClearPrice bool     `json:"clear_price"`
+	CostPrice  *float64 `json:"cost_price" validate:"omitempty,gte=0"`
+	Stock      *int32   `json:"stock" validate:"omitempty,gte=0"`
+	Note       *string  `json:"note" validate:"omitempty,max=255"`
+	ChangeType *string  `json:"change_type" validate:"omitempty,oneof=sale restock correction return damage"`
+}
+
+type VariantAttributeResponse struct {
+	ID     uuid.UUID `json:"id"`
+	Name   string    `json:"name"`
+	Values []string  `json:"values"`
+}
+
+// ProductVariantResponse is one variant of a product. Price is what it sells for, PriceOverride is set when
+// that differs from the product price, and Attributes maps each attribute name to the variant's value.
+type ProductVariantResponse struct {
+	ID            uuid.UUID         `json:"id"`
+	Name          string            `json:"name"`
+	SKU           string            `json:"sku"`
+	Barcode       *string           `json:"barcode,omitempty"`
+	Price         float64           `json:"price"`
+	PriceOverride *float64          `json:"price_override,omitempty"`
+	CostPrice     float64           `json:"cost_price"`
+	Stock         int32             `json:"stock"`
+	Attributes    map[string]string `json:"attributes"`
+}
+
+type ProductVariantsResponse struct {
+	Attributes []VariantAttributeResponse `json:"attributes"`
+	Variants   []ProductVariantResponse   `json:"variants"`
 }
 
 type ProductCategoryResponse struct {
@@ -59,22 +169,28 @@ type ProductCategoryResponse struct {
 }
 
 type ProductResponse struct {
-	ID         uuid.UUID                 `json:"id"`
-	Name       string                    `json:"name"`
-	Categories []ProductCategoryResponse `json:"categories,omitempty"`
-	ImageURL   *string                   `json:"image_url,omitempty"`
-	Price      float64                   `json:"price"`
-	CostPrice  float64                   `json:"cost_price"`
-	Stock      int32                     `json:"stock"`
-	CreatedAt  time.Time                 `json:"created_at"`
-	UpdatedAt  time.Time                 `json:"updated_at"`
-	DeletedAt  *time.Time                `json:"deleted_at,omitempty"`
-	Options    []ProductOptionResponse   `json:"options,omitempty"`
+	ID         uuid.UUID                  `json:"id"`
+	Name       string                     `json:"name"`
+	SKU        *string                    `json:"sku,omitempty"`
+	Barcodes   []ProductBarcodeResponse   `json:"barcodes,omitempty"`
+	Categories []ProductCategoryResponse  `json:"categories,omitempty"`
+	ImageURL   *string                    `json:"image_url,omitempty"`
+	Price      float64                    `json:"price"`
+	CostPrice  float64                    `json:"cost_price"`
+	Stock      int32                      `json:"stock"`
+	CreatedAt  time.Time                  `json:"created_at"`
+	UpdatedAt  time.Time                  `json:"updated_at"`
+	DeletedAt  *time.Time                 `json:"deleted_at,omitempty"`
+	Options    []ProductOptionResponse    `json:"options,omitempty"`
+	Groups     []ModifierGroupResponse    `json:"modifier_groups,omitempty"`
+	Attributes []VariantAttributeResponse `json:"variant_attributes,omitempty"`
+	Variants   []ProductVariantResponse   `json:"variants,omitempty"`
 }
 
 type ProductListResponse struct {
 	ID         uuid.UUID                 `json:"id"`
 	Name       string                    `json:"name"`
+	SKU        *string                   `json:"sku,omitempty"`
 	Categories []ProductCategoryResponse `json:"categories,omitempty"`
 	ImageURL   *string                   `json:"image_url,omitempty"`
 	Price      float64                   `json:"price"`
@@ -82,6 +198,88 @@ type ProductListResponse struct {
 	DeletedAt  *time.Time                `json:"deleted_at,omitempty"`
 }
 
+// AddProductBarcodeRequest adds a barcode to a product, or to one of its variants. Either give Code, with
+// Symbology when its length does not tell, or set Generate for a new in-store EAN-13 code.
+type AddProductBarcodeRequest struct {
+	Code      *string    `json:"code" validate:"omitempty,min=1,max=64"`
+	Symbology *string    `json:"symbology" validate:"omitempty,oneof=ean13 ean8 upc_a internal"`
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	Generate  bool       `json:"generate"`
+}
+
+type ProductBarcodeResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	ProductID   uuid.UUID  `json:"product_id"`
+	VariantID   *uuid.UUID `json:"variant_id,omitempty"`
+	Code        string     `json:"code"`
+	Symbology   string     `json:"symbology"`
+	IsGenerated bool       `json:"is_generated"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type GenerateBarcodesResponse struct {
+	Generated int                      `json:"generated"`
+	Barcodes  []ProductBarcodeResponse `json:"barcodes"`
+}
+
+// ProductLookupResponse is what a scanned code stands for. VariantID is set when the code belongs to a
+// variant, whose SKU, price and stock are then shown. MatchedBy is "barcode" or "sku".
+type ProductLookupResponse struct {
+	Code        string     `json:"code"`
+	MatchedBy   string     `json:"matched_by"`
+	ProductID   uuid.UUID  `json:"product_id"`
+	VariantID   *uuid.UUID `json:"variant_id,omitempty"`
+	Name        string     `json:"name"`
+	VariantName *string    `json:"variant_name,omitempty"`
+	SKU         *string    `json:"sku,omitempty"`
+	ImageURL    *string    `json:"image_url,omitempty"`
+	Price       float64    `json:"price"`
+	Stock       int32      `json:"stock"`
+}
+
+// Actions an import takes on a row.
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+	ImportActionSkip   = "skip"
+	ImportActionError  = "error"
+)
+
+// ImportFieldChange is one field an import sets; From is empty for new products.
+type ImportFieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// ImportRowResult is what an import does with one row of the file. Line counts the header as line 1.
+// Warnings are problems that did not stop the row, such as an image that could not be downloaded.
+type ImportRowResult struct {
+	Line      int                 `json:"line"`
+	Action    string              `json:"action"`
+	ProductID *uuid.UUID          `json:"product_id,omitempty"`
+	SKU       *string             `json:"sku,omitempty"`
+	Name      string              `json:"name"`
+	Changes   []ImportFieldChange `json:"changes,omitempty"`
+	Errors    []string            `json:"errors,omitempty"`
+	Warnings  []string            `json:"warnings,omitempty"`
+}
+
+type ImportSummary struct {
+	Total  int `json:"total"`
+	Create int `json:"create"`
+	Update int `json:"update"`
+	Skip   int `json:"skip"`
+	Error  int `json:"error"`
+}
+
+// ImportProductsResponse is the diff of a product import. With DryRun false it has been applied.
+type ImportProductsResponse struct {
+	DryRun  bool              `json:"dry_run"`
+	Summary ImportSummary     `json:"summary"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
 type ListProductsResponse struct {
 	Products   []ProductListResponse `json:"products"`
 	Pagination pagination.Pagination `json:"pagination"`
@@ -93,11 +291,13 @@ type RestoreBulkRequest struct {
 
 type ListStockHistoryRequest struct {
 	pagination.PaginationRequest
+	VariantID *uuid.UUID `query:"variant_id"`
 }
 
 type StockHistoryResponse struct {
 	ID            uuid.UUID  `json:"id"`
 	ProductID     uuid.UUID  `json:"product_id"`
+	VariantID     *uuid.UUID `json:"variant_id,omitempty"`
 	ChangeAmount  int32      `json:"change_amount"`
 	PreviousStock int32      `json:"previous_stock"`
 	CurrentStock  int32      `json:"current_stock"`
@@ -31,6 +31,29 @@ type IPrdHandler interface {
 	RestoreProductHandler(ctx fiber.Ctx) error
 	RestoreProductsBulkHandler(ctx fiber.Ctx) error
 	GetStockHistoryHandler(ctx fiber.Ctx) error
+
+	// Barcodes
+	LookupCodeHandler(ctx fiber.Ctx) error
+	AddProductBarcodeHandler(ctx fiber.Ctx) error
+	DeleteProductBarcodeHandler(ctx fiber.Ctx) error
+	GenerateMissingBarcodesHandler(ctx fiber.Ctx) error
+
+	// Import and Export
+	ImportProductsHandler(ctx fiber.Ctx) error
+	ExportProductsHandler(ctx fiber.Ctx) error
+
+	// Variants
+	GenerateVariantsHandler(ctx fiber.Ctx) error
+	ListVariantsHandler(ctx fiber.Ctx) error
+	UpdateVariantHandler(ctx fiber.Ctx) error
+	DeleteVariantHandler(ctx fiber.Ctx) error
+
+	// Modifier Groups
+	CreateModifierGroupHandler(ctx fiber.Ctx) error
+	ListModifierGroupsHandler(ctx fiber.Ctx) error
+	GetModifierGroupHandler(ctx fiber.Ctx) error
+	UpdateModifierGroupHandler(ctx fiber.Ctx) error
+	DeleteModifierGroupHandler(ctx fiber.Ctx) error
 }
 
 func NewPrdHandler(prdService IPrdService, log logger.ILogger) IPrdHandler {
@@ -98,8 +121,9 @@ func (h *PrdHandler) DeleteProductOptionHandler(ctx fiber.Ctx) error {
 // @Param        option_id  path string true "Option ID" Format(uuid)
 // @Param        body       body UpdateProductOptionRequest true "Product option update request"
 // @Success      200 {object} common.SuccessResponse{data=ProductOptionResponse} "Product option updated successfully"
-// @Failure      400 {object} common.ErrorResponse "Invalid ID format or request body"
+// @Failure      400 {object} common.ErrorResponse "Invalid ID format, request body or modifier group"
 // @Failure      404 {object} common.ErrorResponse "Product or option not found"
+// @Failure      409 {object} common.ErrorResponse "Too many default options in the modifier group"
 // @Failure      500 {object} common.ErrorResponse "Failed to update product option"
 // @x-roles      ["admin", "manager"]
 // @Router       /products/{product_id}/options/{option_id} [patch]
@@ -135,6 +159,12 @@ func (h *PrdHandler) UpdateProductOptionHandler(ctx fiber.Ctx) error {
 		if errors.Is(err, common.ErrNotFound) {
 			return ctx.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Product or option not found"})
 		}
+		if errors.Is(err, common.ErrModifierGroupNotFound) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		if errors.Is(err, common.ErrModifierTooManyDefaults) {
+			return ctx.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
 		h.log.Error("Failed to update product option", "error", err)
 		return ctx.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to update product option"})
 	}
@@ -221,8 +251,9 @@ func (h *PrdHandler) UploadProductOptionImageHandler(ctx fiber.Ctx) error {
 // @Param        product_id path string true "Product ID" Format(uuid)
 // @Param        body       body CreateProductOptionRequestStandalone true "Product option create request"
 // @Success      201 {object} common.SuccessResponse{data=ProductOptionResponse} "Product option created successfully"
-// @Failure      400 {object} common.ErrorResponse "Invalid product ID format or request body"
+// @Failure      400 {object} common.ErrorResponse "Invalid product ID format, request body or modifier group"
 // @Failure      404 {object} common.ErrorResponse "Parent product not found"
+// @Failure      409 {object} common.ErrorResponse "Too many default options in the modifier group"
 // @Failure      500 {object} common.ErrorResponse "Failed to create product option"
 // @x-roles      ["admin", "manager"]
 // @Router       /products/{product_id}/options [post]
@@ -253,6 +284,12 @@ func (h *PrdHandler) CreateProductOptionHandler(ctx fiber.Ctx) error {
 		if errors.Is(err, common.ErrNotFound) {
 			return ctx.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Parent product not found"})
 		}
+		if errors.Is(err, common.ErrModifierGroupNotFound) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		if errors.Is(err, common.ErrModifierTooManyDefaults) {
+			return ctx.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
 		h.log.Error("Failed to create product option", "error", err, "productID", productID)
 		return ctx.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to create product option"})
 	}
@@ -309,6 +346,7 @@ func (h *PrdHandler) DeleteProductHandler(ctx fiber.Ctx) error {
 // @Success      200 {object} common.SuccessResponse{data=ProductResponse} "Product updated successfully"
 // @Failure      400 {object} common.ErrorResponse "Invalid ID format or request body"
 // @Failure      404 {object} common.ErrorResponse "Product not found"
+// @Failure      409 {object} common.ErrorResponse "Product stock is kept by its variants or SKU already in use"
 // @Failure      500 {object} common.ErrorResponse "Failed to update product"
 // @x-roles      ["admin", "manager"]
 // @Router       /products/{id} [patch]
@@ -342,6 +380,9 @@ func (h *PrdHandler) UpdateProductHandler(ctx fiber.Ctx) error {
 		if errors.Is(err, common.ErrCategoryNotFound) {
 			return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Category not found"})
 		}
+		if errors.Is(err, common.ErrProductHasVariants) || errors.Is(err, common.ErrSKUExists) {
+			return ctx.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
 		h.log.Error("Failed to update product", "error", err, "productID", productID)
 		return ctx.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to update product"})
 	}
@@ -395,7 +436,7 @@ func (h *PrdHandler) GetProductHandler(ctx fiber.Ctx) error {
 // @Produce      json
 // @Param        page        query int    false "Page number"
 // @Param        limit       query int    false "Limit the number of products returned"
-// @Param        search      query string false "Search products by name"
+// @Param        search      query string false "Search products by name, or by exact SKU or barcode"
 // @Param        category_id query int    false "Search products by category ID"
 // @Success      200 {object} common.SuccessResponse{data=ListProductsResponse} "Products retrieved successfully"
 // @Failure      400 {object} common.ErrorResponse "Invalid query parameters"
@@ -438,14 +479,14 @@ func (h *PrdHandler) ListProductsHandler(ctx fiber.Ctx) error {
 
 // CreateProductHandler creates a new product
 // @Summary      Create a new product
-// @Description  Create a new product with multiple options (Roles: admin, manager)
+// @Description  Create a new product with multiple options, an optional SKU and barcodes (Roles: admin, manager)
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Param        body body CreateProductRequest true "Product create request"
 // @Success      201 {object} common.SuccessResponse{data=ProductResponse} "Product created successfully"
-// @Failure      400 {object} common.ErrorResponse "Invalid request body"
-// @Failure      409 {object} common.ErrorResponse "Product with same name already exists"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body, modifier group or barcode"
+// @Failure      409 {object} common.ErrorResponse "Too many default options, or SKU or barcode already in use"
 // @Failure      500 {object} common.ErrorResponse "Failed to create product"
 // @x-roles      ["admin", "manager"]
 // @Router       /products [post]
@@ -469,6 +510,14 @@ func (h *PrdHandler) CreateProductHandler(ctx fiber.Ctx) error {
 
 	productResponse, err := h.prdService.CreateProduct(ctx.RequestCtx(), req)
 	if err != nil {
+		if errors.Is(err, common.ErrModifierGroupNotFound) || errors.Is(err, common.ErrBarcodeInvalid) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		if errors.Is(err, common.ErrModifierTooManyDefaults) ||
+			errors.Is(err, common.ErrSKUExists) ||
+			errors.Is(err, common.ErrBarcodeExists) {
+			return ctx.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
 		return ctx.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{
 			Message: "Failed to create product",
 			Error:   err.Error(),
@@ -714,6 +763,7 @@ func (h *PrdHandler) RestoreProductsBulkHandler(ctx fiber.Ctx) error {
 // @Param        id    path  string true  "Product ID" Format(uuid)
 // @Param        page  query int    false "Page number"
 // @Param        limit query int    false "Limit"
+// @Param        variant_id query string false "Only the history of this variant" Format(uuid)
 // @Success      200 {object} common.SuccessResponse{data=PagedStockHistoryResponse} "Stock history retrieved successfully"
 // @Failure      400 {object} common.ErrorResponse "Invalid ID format or query parameters"
 // @Failure      404 {object} common.ErrorResponse "Product not found"
@@ -0,0 +1,164 @@
+package products
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/pkg/validator"
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// LookupCodeHandler finds the product behind a scanned code
+// @Summary      Look up a barcode or SKU
+// @Description  Find the product, or variant, a scanned barcode or typed SKU belongs to. Barcodes match exactly, SKUs ignore case (Roles: admin, manager, cashier)
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        code query string true "Barcode or SKU"
+// @Success      200 {object} common.SuccessResponse{data=ProductLookupResponse} "Product found"
+// @Failure      400 {object} common.ErrorResponse "Code is missing"
+// @Failure      404 {object} common.ErrorResponse "No product or variant has this code"
+// @Failure      500 {object} common.ErrorResponse "Failed to look up code"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /products/lookup [get]
+func (h *PrdHandler) LookupCodeHandler(ctx fiber.Ctx) error {
+	code := ctx.Query("code")
+	if code == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Code is required"})
+	}
+
+	resp, err := h.prdService.LookupCode(ctx.RequestCtx(), code)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return ctx.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: common.ErrProductCodeNotFound.Error()})
+		}
+		h.log.Error("Failed to look up code", "error", err, "code", code)
+		return ctx.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to look up code"})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Product found",
+		Data:    resp,
+	})
+}
+
+// AddProductBarcodeHandler adds a barcode to a product
+// @Summary      Add a barcode to a product
+// @Description  Add a barcode to a product or one of its variants. EAN-13, UPC-A and EAN-8 codes must have a valid check digit; set generate for a new in-store EAN-13 code instead (Roles: admin, manager)
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id   path string                   true "Product ID" Format(uuid)
+// @Param        body body AddProductBarcodeRequest true "Barcode to add"
+// @Success      201 {object} common.SuccessResponse{data=ProductBarcodeResponse} "Barcode added successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid ID format, request body or barcode"
+// @Failure      404 {object} common.ErrorResponse "Product or variant not found"
+// @Failure      409 {object} common.ErrorResponse "Barcode already in use"
+// @Failure      500 {object} common.ErrorResponse "Failed to add barcode"
+// @x-roles      ["admin", "manager"]
+// @Router       /products/{id}/barcodes [post]
+func (h *PrdHandler) AddProductBarcodeHandler(ctx fiber.Ctx) error {
+	productID, err := fiber.Convert(ctx.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warn("Invalid product ID format", "error", err, "id", ctx.Params("id"))
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid product ID format"})
+	}
+
+	var req AddProductBarcodeRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		h.log.Warn("Add barcode request validation failed", "error", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data:    map[string]interface{}{"errors": ve.Errors},
+			})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body", Error: err.Error()})
+	}
+
+	resp, err := h.prdService.AddProductBarcode(ctx.RequestCtx(), productID, req)
+	if err != nil {
+		return h.barcodeError(ctx, err, "Failed to add barcode")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.SuccessResponse{
+		Message: "Barcode added successfully",
+		Data:    resp,
+	})
+}
+
+// DeleteProductBarcodeHandler removes a barcode from a product
+// @Summary      Remove a barcode from a product
+// @Description  Remove one of a product's barcodes (Roles: admin, manager)
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id         path string true "Product ID" Format(uuid)
+// @Param        barcode_id path string true "Barcode ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse "Barcode removed successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid ID format"
+// @Failure      404 {object} common.ErrorResponse "Barcode not found"
+// @Failure      500 {object} common.ErrorResponse "Failed to remove barcode"
+// @x-roles      ["admin", "manager"]
+// @Router       /products/{id}/barcodes/{barcode_id} [delete]
+func (h *PrdHandler) DeleteProductBarcodeHandler(ctx fiber.Ctx) error {
+	productID, err := fiber.Convert(ctx.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warn("Invalid product ID format", "error", err, "id", ctx.Params("id"))
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid product ID format"})
+	}
+	barcodeID, err := fiber.Convert(ctx.Params("barcode_id"), uuid.Parse)
+	if err != nil {
+		h.log.Warn("Invalid barcode ID format", "error", err, "id", ctx.Params("barcode_id"))
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid barcode ID format"})
+	}
+
+	if err := h.prdService.DeleteProductBarcode(ctx.RequestCtx(), productID, barcodeID); err != nil {
+		return h.barcodeError(ctx, err, "Failed to remove barcode")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Barcode removed successfully",
+	})
+}
+
+// GenerateMissingBarcodesHandler gives unlabelled products an in-store barcode
+// @Summary      Generate missing barcodes
+// @Description  Give every product without variants, and every variant, that has no barcode yet a generated in-store EAN-13 code (prefix 20) (Roles: admin, manager)
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} common.SuccessResponse{data=GenerateBarcodesResponse} "Barcodes generated successfully"
+// @Failure      500 {object} common.ErrorResponse "Failed to generate barcodes"
+// @x-roles      ["admin", "manager"]
+// @Router       /products/barcodes/generate [post]
+func (h *PrdHandler) GenerateMissingBarcodesHandler(ctx fiber.Ctx) error {
+	resp, err := h.prdService.GenerateMissingBarcodes(ctx.RequestCtx())
+	if err != nil {
+		return h.barcodeError(ctx, err, "Failed to generate barcodes")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Barcodes generated successfully",
+		Data:    resp,
+	})
+}
+
+func (h *PrdHandler) barcodeError(ctx fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, common.ErrNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Product not found"})
+	case errors.Is(err, common.ErrVariantNotFound),
+		errors.Is(err, common.ErrBarcodeNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, common.ErrBarcodeInvalid):
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, common.ErrBarcodeExists):
+		return ctx.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+	}
+	h.log.Error(message, "error", err)
+	return ctx.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: message})
+}
@@ -0,0 +1,115 @@
+package products
+
+import (
+	"POS-kasir/internal/common"
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// ImportProductsHandler imports products from a spreadsheet
+// @Summary      Import products from CSV or XLSX
+// @Description  Create and update products from a CSV or XLSX file with the columns id, sku, name, categories, price, cost_price, stock, options and image_url; only name is required. Rows match products by id, else by SKU, and empty cells keep the current value. Categories are names separated by "|", options "Name:price" separated by "|"; listed options are added or repriced, others are kept. By default the import is a dry run that returns the diff per row; send dry_run=false to apply it, which only happens when no row has errors. Stock changes are written to the stock history and images are downloaded from image_url (Roles: admin, manager)
+// @Tags         Products
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file    formData file true  "CSV or XLSX file, at most 5MB and 2000 products"
+// @Param        dry_run query    bool false "Only return the diff (default true)"
+// @Success      200 {object} common.SuccessResponse{data=ImportProductsResponse} "Import checked or applied"
+// @Failure      400 {object} common.ErrorResponse "File is missing or unreadable"
+// @Failure      422 {object} common.ErrorResponse{data=ImportProductsResponse} "Some rows have errors; nothing was changed"
+// @Failure      500 {object} common.ErrorResponse "Failed to import products"
+// @x-roles      ["admin", "manager"]
+// @Router       /products/import [post]
+func (h *PrdHandler) ImportProductsHandler(ctx fiber.Ctx) error {
+	dryRun := true
+	if v := ctx.Query("dry_run"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "dry_run must be true or false"})
+		}
+		dryRun = parsed
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		h.log.Warn("Import file is missing in form", "error", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "CSV or XLSX file is required in 'file' field"})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.log.Error("Failed to open uploaded file", "error", err)
+		return ctx.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to process file"})
+	}
+	defer func(file multipart.File) {
+		err := file.Close()
+		if err != nil {
+			h.log.Error("Failed to close file", "error", err)
+		}
+	}(file)
+
+	buf := bytes.NewBuffer(nil)
+	if _, err := io.Copy(buf, io.LimitReader(file, maxImportFileSize+1)); err != nil {
+		h.log.Error("Failed to read file into buffer", "error", err)
+		return ctx.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to read file"})
+	}
+
+	resp, err := h.prdService.ImportProducts(ctx.RequestCtx(), fileHeader.Filename, buf.Bytes(), dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrImportFileInvalid):
+			return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: common.ErrImportFileInvalid.Error(), Error: err.Error()})
+		case errors.Is(err, common.ErrImportHasErrors):
+			return ctx.Status(fiber.StatusUnprocessableEntity).JSON(common.ErrorResponse{Message: err.Error(), Data: resp})
+		}
+		h.log.Error("Failed to import products", "error", err)
+		return ctx.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to import products"})
+	}
+
+	message := "Products imported successfully"
+	if resp.DryRun {
+		message = "Import checked, nothing was changed"
+	}
+	return ctx.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: message,
+		Data:    resp,
+	})
+}
+
+// ExportProductsHandler exports the catalog as a spreadsheet
+// @Summary      Export products to CSV or XLSX
+// @Description  Download every active product in the format the import reads, so it can be edited and imported again (Roles: admin, manager)
+// @Tags         Products
+// @Produce      octet-stream
+// @Param        format query string false "File format" Enums(csv, xlsx) default(csv)
+// @Success      200 {file} file "Catalog file"
+// @Failure      400 {object} common.ErrorResponse "Unknown format"
+// @Failure      500 {object} common.ErrorResponse "Failed to export products"
+// @x-roles      ["admin", "manager"]
+// @Router       /products/export [get]
+func (h *PrdHandler) ExportProductsHandler(ctx fiber.Ctx) error {
+	format := ctx.Query("format", CatalogFormatCSV)
+	contentType := "text/csv"
+	switch format {
+	case CatalogFormatCSV:
+	case CatalogFormatXLSX:
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "format must be csv or xlsx"})
+	}
+
+	data, err := h.prdService.ExportProducts(ctx.RequestCtx(), format)
+	if err != nil {
+		h.log.Error("Failed to export products", "error", err)
+		return ctx.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to export products"})
+	}
+
+	ctx.Set("Content-Type", contentType)
+	ctx.Set("Content-Disposition", "attachment; filename=products."+format)
+	return ctx.Send(data)
+}
@@ -0,0 +1,192 @@
+package products
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/pkg/validator"
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// CreateModifierGroupHandler creates a modifier group
+// @Summary      Create a modifier group
+// @Description  Create a group of options with selection rules, e.g. "Size: pick exactly 1" (min 1, max 1) or "Toppings: up to 3" (max 3). Options of any product join it through modifier_group_id (Roles: admin, manager)
+// @Tags         Modifier Groups
+// @Accept       json
+// @Produce      json
+// @Param        body body CreateModifierGroupRequest true "Modifier group create request"
+// @Success      201 {object} common.SuccessResponse{data=ModifierGroupResponse} "Modifier group created successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body or selection rules"
+// @Failure      409 {object} common.ErrorResponse "Modifier group with this name already exists"
+// @Failure      500 {object} common.ErrorResponse "Failed to create modifier group"
+// @x-roles      ["admin", "manager"]
+// @Router       /modifier-groups [post]
+func (h *PrdHandler) CreateModifierGroupHandler(ctx fiber.Ctx) error {
+	var req CreateModifierGroupRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		h.log.Warn("Create modifier group request validation failed", "error", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data:    map[string]interface{}{"errors": ve.Errors},
+			})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body", Error: err.Error()})
+	}
+
+	resp, err := h.prdService.CreateModifierGroup(ctx.RequestCtx(), req)
+	if err != nil {
+		return h.modifierGroupError(ctx, err, "Failed to create modifier group")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(common.SuccessResponse{
+		Message: "Modifier group created successfully",
+		Data:    resp,
+	})
+}
+
+// ListModifierGroupsHandler lists the modifier groups
+// @Summary      List modifier groups
+// @Description  List the active modifier groups and their selection rules (Roles: admin, manager, cashier)
+// @Tags         Modifier Groups
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} common.SuccessResponse{data=[]ModifierGroupResponse} "Modifier groups retrieved successfully"
+// @Failure      500 {object} common.ErrorResponse "Failed to retrieve modifier groups"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /modifier-groups [get]
+func (h *PrdHandler) ListModifierGroupsHandler(ctx fiber.Ctx) error {
+	resp, err := h.prdService.ListModifierGroups(ctx.RequestCtx())
+	if err != nil {
+		return h.modifierGroupError(ctx, err, "Failed to retrieve modifier groups")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Modifier groups retrieved successfully",
+		Data:    resp,
+	})
+}
+
+// GetModifierGroupHandler gets a modifier group
+// @Summary      Get a modifier group
+// @Description  Get a modifier group with the options of every product that uses it (Roles: admin, manager, cashier)
+// @Tags         Modifier Groups
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Modifier group ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=ModifierGroupResponse} "Modifier group retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid modifier group ID format"
+// @Failure      404 {object} common.ErrorResponse "Modifier group not found"
+// @Failure      500 {object} common.ErrorResponse "Failed to retrieve modifier group"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /modifier-groups/{id} [get]
+func (h *PrdHandler) GetModifierGroupHandler(ctx fiber.Ctx) error {
+	groupID, err := fiber.Convert(ctx.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warn("Invalid modifier group ID format", "error", err, "id", ctx.Params("id"))
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid modifier group ID format"})
+	}
+
+	resp, err := h.prdService.GetModifierGroup(ctx.RequestCtx(), groupID)
+	if err != nil {
+		return h.modifierGroupError(ctx, err, "Failed to retrieve modifier group")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Modifier group retrieved successfully",
+		Data:    resp,
+	})
+}
+
+// UpdateModifierGroupHandler updates a modifier group
+// @Summary      Update a modifier group
+// @Description  Update a modifier group's name or selection rules. The rules apply to every product using the group (Roles: admin, manager)
+// @Tags         Modifier Groups
+// @Accept       json
+// @Produce      json
+// @Param        id   path string                     true "Modifier group ID" Format(uuid)
+// @Param        body body UpdateModifierGroupRequest true "Modifier group update request"
+// @Success      200 {object} common.SuccessResponse{data=ModifierGroupResponse} "Modifier group updated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid ID format, request body or selection rules"
+// @Failure      404 {object} common.ErrorResponse "Modifier group not found"
+// @Failure      409 {object} common.ErrorResponse "Name already in use or a product has more defaults than the new limit"
+// @Failure      500 {object} common.ErrorResponse "Failed to update modifier group"
+// @x-roles      ["admin", "manager"]
+// @Router       /modifier-groups/{id} [patch]
+func (h *PrdHandler) UpdateModifierGroupHandler(ctx fiber.Ctx) error {
+	groupID, err := fiber.Convert(ctx.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warn("Invalid modifier group ID format", "error", err, "id", ctx.Params("id"))
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid modifier group ID format"})
+	}
+
+	var req UpdateModifierGroupRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		h.log.Warn("Update modifier group request validation failed", "error", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data:    map[string]interface{}{"errors": ve.Errors},
+			})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body", Error: err.Error()})
+	}
+
+	resp, err := h.prdService.UpdateModifierGroup(ctx.RequestCtx(), groupID, req)
+	if err != nil {
+		return h.modifierGroupError(ctx, err, "Failed to update modifier group")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Modifier group updated successfully",
+		Data:    resp,
+	})
+}
+
+// DeleteModifierGroupHandler deletes a modifier group
+// @Summary      Delete a modifier group
+// @Description  Remove a modifier group. Its options stay on their products without selection rules (Roles: admin, manager)
+// @Tags         Modifier Groups
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Modifier group ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse "Modifier group deleted successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid modifier group ID format"
+// @Failure      404 {object} common.ErrorResponse "Modifier group not found"
+// @Failure      500 {object} common.ErrorResponse "Failed to delete modifier group"
+// @x-roles      ["admin", "manager"]
+// @Router       /modifier-groups/{id} [delete]
+func (h *PrdHandler) DeleteModifierGroupHandler(ctx fiber.Ctx) error {
+	groupID, err := fiber.Convert(ctx.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warn("Invalid modifier group ID format", "error", err, "id", ctx.Params("id"))
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid modifier group ID format"})
+	}
+
+	if err := h.prdService.DeleteModifierGroup(ctx.RequestCtx(), groupID); err != nil {
+		return h.modifierGroupError(ctx, err, "Failed to delete modifier group")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Modifier group deleted successfully",
+	})
+}
+
+func (h *PrdHandler) modifierGroupError(ctx fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, common.ErrNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Modifier group not found"})
+	case errors.Is(err, common.ErrModifierGroupInvalid):
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, common.ErrModifierGroupExists),
+		errors.Is(err, common.ErrModifierTooManyDefaults):
+		return ctx.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+	}
+	h.log.Error(message, "error", err)
+	return ctx.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: message})
+}
@@ -0,0 +1,194 @@
+package products
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/pkg/validator"
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// GenerateVariantsHandler builds the variant matrix of a product
+// @Summary      Generate product variants
+// @Description  Create one variant per combination of attribute values (e.g. size x colour). Existing combinations are kept; new variants start without stock (Roles: admin, manager)
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        product_id path string true "Product ID" Format(uuid)
+// @Param        body       body GenerateVariantsRequest true "Variant attributes"
+// @Success      200 {object} common.SuccessResponse{data=ProductVariantsResponse} "Product variants generated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid ID format, request body or variant matrix"
+// @Failure      404 {object} common.ErrorResponse "Product not found"
+// @Failure      409 {object} common.ErrorResponse "Product cannot take these variants"
+// @Failure      500 {object} common.ErrorResponse "Failed to generate product variants"
+// @x-roles      ["admin", "manager"]
+// @Router       /products/{product_id}/variants/generate [post]
+func (h *PrdHandler) GenerateVariantsHandler(ctx fiber.Ctx) error {
+	productID, err := fiber.Convert(ctx.Params("product_id"), uuid.Parse)
+	if err != nil {
+		h.log.Warn("Invalid product ID format", "error", err, "product_id", ctx.Params("product_id"))
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid product ID format"})
+	}
+
+	var req GenerateVariantsRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		h.log.Warn("Generate variants request validation failed", "error", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data:    map[string]interface{}{"errors": ve.Errors},
+			})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body", Error: err.Error()})
+	}
+
+	resp, err := h.prdService.GenerateVariants(ctx.RequestCtx(), productID, req)
+	if err != nil {
+		return h.variantError(ctx, err, "Failed to generate product variants")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Product variants generated successfully",
+		Data:    resp,
+	})
+}
+
+// ListVariantsHandler lists the variants of a product
+// @Summary      List product variants
+// @Description  List a product's variant attributes and its active variants (Roles: admin, manager, cashier)
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        product_id path string true "Product ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=ProductVariantsResponse} "Product variants retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid product ID format"
+// @Failure      404 {object} common.ErrorResponse "Product not found"
+// @Failure      500 {object} common.ErrorResponse "Failed to retrieve product variants"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /products/{product_id}/variants [get]
+func (h *PrdHandler) ListVariantsHandler(ctx fiber.Ctx) error {
+	productID, err := fiber.Convert(ctx.Params("product_id"), uuid.Parse)
+	if err != nil {
+		h.log.Warn("Invalid product ID format", "error", err, "product_id", ctx.Params("product_id"))
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid product ID format"})
+	}
+
+	resp, err := h.prdService.ListVariants(ctx.RequestCtx(), productID)
+	if err != nil {
+		return h.variantError(ctx, err, "Failed to retrieve product variants")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Product variants retrieved successfully",
+		Data:    resp,
+	})
+}
+
+// UpdateVariantHandler updates a product variant
+// @Summary      Update a product variant
+// @Description  Update a variant's SKU, barcode, price override, cost price or stock. Stock changes are recorded in the stock history (Roles: admin, manager)
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        product_id path string true "Product ID" Format(uuid)
+// @Param        variant_id path string true "Variant ID" Format(uuid)
+// @Param        body       body UpdateVariantRequest true "Variant update request"
+// @Success      200 {object} common.SuccessResponse{data=ProductVariantResponse} "Product variant updated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid ID format, request body or barcode check digit"
+// @Failure      404 {object} common.ErrorResponse "Product or variant not found"
+// @Failure      409 {object} common.ErrorResponse "SKU or barcode already in use"
+// @Failure      500 {object} common.ErrorResponse "Failed to update product variant"
+// @x-roles      ["admin", "manager"]
+// @Router       /products/{product_id}/variants/{variant_id} [patch]
+func (h *PrdHandler) UpdateVariantHandler(ctx fiber.Ctx) error {
+	productID, err := fiber.Convert(ctx.Params("product_id"), uuid.Parse)
+	if err != nil {
+		h.log.Warn("Invalid product ID format", "error", err, "product_id", ctx.Params("product_id"))
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid product ID format"})
+	}
+	variantID, err := fiber.Convert(ctx.Params("variant_id"), uuid.Parse)
+	if err != nil {
+		h.log.Warn("Invalid variant ID format", "error", err, "variant_id", ctx.Params("variant_id"))
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid variant ID format"})
+	}
+
+	var req UpdateVariantRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		h.log.Warn("Variant update request validation failed", "error", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data:    map[string]interface{}{"errors": ve.Errors},
+			})
+		}
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body", Error: err.Error()})
+	}
+
+	resp, err := h.prdService.UpdateVariant(ctx.RequestCtx(), productID, variantID, req)
+	if err != nil {
+		return h.variantError(ctx, err, "Failed to update product variant")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Product variant updated successfully",
+		Data:    resp,
+	})
+}
+
+// DeleteVariantHandler deletes a product variant
+// @Summary      Delete a product variant
+// @Description  Remove a variant that has no stock left. Past orders keep referring to it (Roles: admin, manager)
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        product_id path string true "Product ID" Format(uuid)
+// @Param        variant_id path string true "Variant ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse "Product variant deleted successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid ID format"
+// @Failure      404 {object} common.ErrorResponse "Product or variant not found"
+// @Failure      409 {object} common.ErrorResponse "Variant still has stock"
+// @Failure      500 {object} common.ErrorResponse "Failed to delete product variant"
+// @x-roles      ["admin", "manager"]
+// @Router       /products/{product_id}/variants/{variant_id} [delete]
+func (h *PrdHandler) DeleteVariantHandler(ctx fiber.Ctx) error {
+	productID, err := fiber.Convert(ctx.Params("product_id"), uuid.Parse)
+	if err != nil {
+		h.log.Warn("Invalid product ID format", "error", err, "product_id", ctx.Params("product_id"))
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid product ID format"})
+	}
+	variantID, err := fiber.Convert(ctx.Params("variant_id"), uuid.Parse)
+	if err != nil {
+		h.log.Warn("Invalid variant ID format", "error", err, "variant_id", ctx.Params("variant_id"))
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid variant ID format"})
+	}
+
+	if err := h.prdService.DeleteVariant(ctx.RequestCtx(), productID, variantID); err != nil {
+		return h.variantError(ctx, err, "Failed to delete product variant")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Product variant deleted successfully",
+	})
+}
+
+func (h *PrdHandler) variantError(ctx fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, common.ErrNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Product or variant not found"})
+	case errors.Is(err, common.ErrVariantInvalid),
+		errors.Is(err, common.ErrBarcodeInvalid):
+		return ctx.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, common.ErrVariantSKUExists),
+		errors.Is(err, common.ErrVariantHasStock),
+		errors.Is(err, common.ErrProductHasStock),
+		errors.Is(err, common.ErrProductMadeToOrder):
+		return ctx.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+	}
+	h.log.Error(message, "error", err)
+	return ctx.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: message})
+}
@@ -16,7 +16,7 @@ const addProductStock = `-- name: AddProductStock :one
 UPDATE products
 SET stock = stock + $1
 WHERE id = $2
-RETURNING id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price
+RETURNING id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, sku
 `
 
 type AddProductStockParams struct {
@@ -37,6 +37,7 @@ func (q *Queries) AddProductStock(ctx context.Context, arg AddProductStockParams
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CostPrice,
+		&i.Sku,
 	)
 	return i, err
 }
@@ -101,7 +102,10 @@ SELECT count(*) FROM products p
 WHERE
     ($1::int IS NULL OR EXISTS (SELECT 1 FROM product_categories pc WHERE pc.product_id = p.id AND pc.category_id = $1))
   AND
-    ($2::text IS NULL OR p.name ILIKE '%' || $2 || '%')
+    ($2::text IS NULL OR p.name ILIKE '%' || $2 || '%'
+        OR lower(p.sku) = lower($2)
+        OR EXISTS (SELECT 1 FROM product_barcodes pb WHERE pb.product_id = p.id AND pb.code = $2)
+        OR EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = p.id AND pv.deleted_at IS NULL AND (lower(pv.sku) = lower($2) OR pv.barcode = $2)))
   AND p.deleted_at IS NULL
 `
 
@@ -125,10 +129,11 @@ INSERT INTO products (
     image_url,
     price,
     stock,
-    cost_price
+    cost_price,
+    sku
 ) VALUES (
-             $1, $2, $3, $4, $5
-         ) RETURNING id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price
+             $1, $2, $3, $4, $5, $6
+         ) RETURNING id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, sku
 `
 
 type CreateProductParams struct {
@@ -137,6 +142,7 @@ type CreateProductParams struct {
 	Price     int64          `json:"price"`
 	Stock     int32          `json:"stock"`
 	CostPrice pgtype.Numeric `json:"cost_price"`
+	Sku       *string        `json:"sku"`
 }
 
 // Queries for Products
@@ -149,6 +155,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		arg.Price,
 		arg.Stock,
 		arg.CostPrice,
+		arg.Sku,
 	)
 	var i Product
 	err := row.Scan(
@@ -161,6 +168,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CostPrice,
+		&i.Sku,
 	)
 	return i, err
 }
@@ -171,17 +179,21 @@ INSERT INTO product_options (
     product_id,
     name,
     additional_price,
-    image_url
+    image_url,
+    modifier_group_id,
+    is_default
 ) VALUES (
-             $1, $2, $3, $4
-         ) RETURNING id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at
+             $1, $2, $3, $4, $5, $6
+         ) RETURNING id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at, modifier_group_id, is_default
 `
 
 type CreateProductOptionParams struct {
-	ProductID       uuid.UUID `json:"product_id"`
-	Name            string    `json:"name"`
-	AdditionalPrice int64     `json:"additional_price"`
-	ImageUrl        *string   `json:"image_url"`
+	ProductID       uuid.UUID   `json:"product_id"`
+	Name            string      `json:"name"`
+	AdditionalPrice int64       `json:"additional_price"`
+	ImageUrl        *string     `json:"image_url"`
+	ModifierGroupID pgtype.UUID `json:"modifier_group_id"`
+	IsDefault       bool        `json:"is_default"`
 }
 
 // Queries for Product Options (Variants)
@@ -192,6 +204,8 @@ func (q *Queries) CreateProductOption(ctx context.Context, arg CreateProductOpti
 		arg.Name,
 		arg.AdditionalPrice,
 		arg.ImageUrl,
+		arg.ModifierGroupID,
+		arg.IsDefault,
 	)
 	var i ProductOption
 	err := row.Scan(
@@ -203,6 +217,8 @@ func (q *Queries) CreateProductOption(ctx context.Context, arg CreateProductOpti
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ModifierGroupID,
+		&i.IsDefault,
 	)
 	return i, err
 }
@@ -211,7 +227,7 @@ const decreaseProductStock = `-- name: DecreaseProductStock :one
 UPDATE products
 SET stock = stock - $1
 WHERE id = $2
-RETURNING id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price
+RETURNING id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, sku
 `
 
 type DecreaseProductStockParams struct {
@@ -232,6 +248,7 @@ func (q *Queries) DecreaseProductStock(ctx context.Context, arg DecreaseProductS
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CostPrice,
+		&i.Sku,
 	)
 	return i, err
 }
@@ -249,7 +266,7 @@ func (q *Queries) DeleteProduct(ctx context.Context, id uuid.UUID) error {
 
 const getDeletedProduct = `-- name: GetDeletedProduct :one
 SELECT
-    p.id, p.name, p.image_url, p.price, p.stock, p.created_at, p.updated_at, p.deleted_at, p.cost_price,
+    p.id, p.name, p.image_url, p.price, p.stock, p.created_at, p.updated_at, p.deleted_at, p.cost_price, p.sku,
     COALESCE(
             (SELECT json_agg(po.*)
              FROM product_options po
@@ -274,6 +291,7 @@ type GetDeletedProductRow struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 	CostPrice pgtype.Numeric     `json:"cost_price"`
+	Sku       *string            `json:"sku"`
 	Options   interface{}        `json:"options"`
 }
 
@@ -290,6 +308,7 @@ func (q *Queries) GetDeletedProduct(ctx context.Context, id uuid.UUID) (GetDelet
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CostPrice,
+		&i.Sku,
 		&i.Options,
 	)
 	return i, err
@@ -297,7 +316,7 @@ func (q *Queries) GetDeletedProduct(ctx context.Context, id uuid.UUID) (GetDelet
 
 const getProductByID = `-- name: GetProductByID :one
 SELECT
-    p.id, p.name, p.image_url, p.price, p.stock, p.created_at, p.updated_at, p.deleted_at, p.cost_price,
+    p.id, p.name, p.image_url, p.price, p.stock, p.created_at, p.updated_at, p.deleted_at, p.cost_price, p.sku,
     COALESCE(
             (SELECT json_agg(po.*)
              FROM product_options po
@@ -329,6 +348,7 @@ type GetProductByIDRow struct {
 	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt  pgtype.Timestamptz `json:"deleted_at"`
 	CostPrice  pgtype.Numeric     `json:"cost_price"`
+	Sku        *string            `json:"sku"`
 	Options    interface{}        `json:"options"`
 	Categories interface{}        `json:"categories"`
 }
@@ -347,6 +367,7 @@ func (q *Queries) GetProductByID(ctx context.Context, id uuid.UUID) (GetProductB
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CostPrice,
+		&i.Sku,
 		&i.Options,
 		&i.Categories,
 	)
@@ -354,7 +375,7 @@ func (q *Queries) GetProductByID(ctx context.Context, id uuid.UUID) (GetProductB
 }
 
 const getProductOption = `-- name: GetProductOption :one
-SELECT id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at FROM product_options
+SELECT id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at, modifier_group_id, is_default FROM product_options
 WHERE id = $1 AND product_id = $2
 LIMIT 1
 `
@@ -377,13 +398,15 @@ func (q *Queries) GetProductOption(ctx context.Context, arg GetProductOptionPara
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ModifierGroupID,
+		&i.IsDefault,
 	)
 	return i, err
 }
 
 const getProductOptionByID = `-- name: GetProductOptionByID :one
 SELECT
-    po.id, po.product_id, po.name, po.additional_price, po.image_url, po.created_at, po.updated_at, po.deleted_at,
+    po.id, po.product_id, po.name, po.additional_price, po.image_url, po.created_at, po.updated_at, po.deleted_at, po.modifier_group_id, po.is_default,
     p.name AS product_name,
     p.image_url AS product_image_url,
     p.price AS product_price,
@@ -410,6 +433,8 @@ type GetProductOptionByIDRow struct {
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
+	ModifierGroupID pgtype.UUID        `json:"modifier_group_id"`
+	IsDefault       bool               `json:"is_default"`
 	ProductName     string             `json:"product_name"`
 	ProductImageUrl *string            `json:"product_image_url"`
 	ProductPrice    int64              `json:"product_price"`
@@ -429,6 +454,8 @@ func (q *Queries) GetProductOptionByID(ctx context.Context, id uuid.UUID) (GetPr
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ModifierGroupID,
+		&i.IsDefault,
 		&i.ProductName,
 		&i.ProductImageUrl,
 		&i.ProductPrice,
@@ -438,7 +465,7 @@ func (q *Queries) GetProductOptionByID(ctx context.Context, id uuid.UUID) (GetPr
 }
 
 const getProductOptionsByIDs = `-- name: GetProductOptionsByIDs :many
-SELECT id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at FROM product_options
+SELECT id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at, modifier_group_id, is_default FROM product_options
 WHERE id = ANY($1::uuid[])
 `
 
@@ -460,6 +487,8 @@ func (q *Queries) GetProductOptionsByIDs(ctx context.Context, dollar_1 []uuid.UU
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.ModifierGroupID,
+			&i.IsDefault,
 		); err != nil {
 			return nil, err
 		}
@@ -473,7 +502,7 @@ func (q *Queries) GetProductOptionsByIDs(ctx context.Context, dollar_1 []uuid.UU
 
 const getProductWithOptions = `-- name: GetProductWithOptions :one
 SELECT
-    p.id, p.name, p.image_url, p.price, p.stock, p.created_at, p.updated_at, p.deleted_at, p.cost_price,
+    p.id, p.name, p.image_url, p.price, p.stock, p.created_at, p.updated_at, p.deleted_at, p.cost_price, p.sku,
     COALESCE(
             (SELECT json_agg(po.*)
              FROM product_options po
@@ -498,6 +527,7 @@ type GetProductWithOptionsRow struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 	CostPrice pgtype.Numeric     `json:"cost_price"`
+	Sku       *string            `json:"sku"`
 	Options   interface{}        `json:"options"`
 }
 
@@ -517,13 +547,14 @@ func (q *Queries) GetProductWithOptions(ctx context.Context, id uuid.UUID) (GetP
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CostPrice,
+		&i.Sku,
 		&i.Options,
 	)
 	return i, err
 }
 
 const getProductsByIDs = `-- name: GetProductsByIDs :many
-SELECT id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price FROM products
+SELECT id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, sku FROM products
 WHERE id = ANY($1::uuid[])
 `
 
@@ -546,6 +577,7 @@ func (q *Queries) GetProductsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([
 			&i.UpdatedAt,
 			&i.DeletedAt,
 			&i.CostPrice,
+			&i.Sku,
 		); err != nil {
 			return nil, err
 		}
@@ -558,7 +590,7 @@ func (q *Queries) GetProductsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([
 }
 
 const getProductsForUpdate = `-- name: GetProductsForUpdate :many
-SELECT id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price FROM products
+SELECT id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, sku FROM products
 WHERE id = ANY($1::uuid[])
 FOR UPDATE
 `
@@ -582,6 +614,7 @@ func (q *Queries) GetProductsForUpdate(ctx context.Context, dollar_1 []uuid.UUID
 			&i.UpdatedAt,
 			&i.DeletedAt,
 			&i.CostPrice,
+			&i.Sku,
 		); err != nil {
 			return nil, err
 		}
@@ -671,7 +704,7 @@ func (q *Queries) ListDeletedProducts(ctx context.Context, arg ListDeletedProduc
 }
 
 const listOptionsForProduct = `-- name: ListOptionsForProduct :many
-SELECT id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at FROM product_options
+SELECT id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at, modifier_group_id, is_default FROM product_options
 WHERE product_id = $1
 ORDER BY name ASC
 `
@@ -695,6 +728,8 @@ func (q *Queries) ListOptionsForProduct(ctx context.Context, productID uuid.UUID
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.ModifierGroupID,
+			&i.IsDefault,
 		); err != nil {
 			return nil, err
 		}
@@ -713,6 +748,7 @@ SELECT
     p.price,
     p.stock,
     p.image_url,
+    p.sku,
     COALESCE(
         (SELECT json_agg(c.*) 
          FROM product_categories pc 
@@ -725,7 +761,10 @@ FROM
 WHERE
     ($3::int IS NULL OR EXISTS (SELECT 1 FROM product_categories pc WHERE pc.product_id = p.id AND pc.category_id = $3))
   AND
-    ($4::text IS NULL OR p.name ILIKE '%' || $4 || '%')
+    ($4::text IS NULL OR p.name ILIKE '%' || $4 || '%'
+        OR lower(p.sku) = lower($4)
+        OR EXISTS (SELECT 1 FROM product_barcodes pb WHERE pb.product_id = p.id AND pb.code = $4)
+        OR EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = p.id AND pv.deleted_at IS NULL AND (lower(pv.sku) = lower($4) OR pv.barcode = $4)))
   AND p.deleted_at IS NULL
 ORDER BY
     p.name ASC
@@ -745,6 +784,7 @@ type ListProductsRow struct {
 	Price      int64       `json:"price"`
 	Stock      int32       `json:"stock"`
 	ImageUrl   *string     `json:"image_url"`
+	Sku        *string     `json:"sku"`
 	Categories interface{} `json:"categories"`
 }
 
@@ -770,6 +810,7 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]L
 			&i.Price,
 			&i.Stock,
 			&i.ImageUrl,
+			&i.Sku,
 			&i.Categories,
 		); err != nil {
 			return nil, err
@@ -834,10 +875,11 @@ SET
     image_url = COALESCE($2, image_url),
     price = COALESCE($3, price),
     stock = COALESCE($4, stock),
-    cost_price = COALESCE($5, cost_price)
+    cost_price = COALESCE($5, cost_price),
+    sku = NULLIF(COALESCE($6, sku), '')
 WHERE
-    id = $6
-RETURNING id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price
+    id = $7
+RETURNING id, name, image_url, price, stock, created_at, updated_at, deleted_at, cost_price, sku
 `
 
 type UpdateProductParams struct {
@@ -846,10 +888,11 @@ type UpdateProductParams struct {
 	Price     *int64         `json:"price"`
 	Stock     *int32         `json:"stock"`
 	CostPrice pgtype.Numeric `json:"cost_price"`
+	Sku       *string        `json:"sku"`
 	ID        uuid.UUID      `json:"id"`
 }
 
-// Updates a product's details. Use COALESCE for optional fields.
+// Updates a product's details. Use COALESCE for optional fields. An empty sku clears it.
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error) {
 	row := q.db.QueryRow(ctx, updateProduct,
 		arg.Name,
@@ -857,6 +900,7 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		arg.Price,
 		arg.Stock,
 		arg.CostPrice,
+		arg.Sku,
 		arg.ID,
 	)
 	var i Product
@@ -870,6 +914,7 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CostPrice,
+		&i.Sku,
 	)
 	return i, err
 }
@@ -879,25 +924,33 @@ UPDATE product_options
 SET
     name = COALESCE($1, name),
     additional_price = COALESCE($2, additional_price),
-    image_url = COALESCE($3, image_url)
+    image_url = COALESCE($3, image_url),
+    modifier_group_id = CASE WHEN $4::bool THEN NULL ELSE COALESCE($5, modifier_group_id) END,
+    is_default = CASE WHEN $4::bool THEN false ELSE COALESCE($6, is_default) END
 WHERE
-    id = $4
-RETURNING id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at
+    id = $7
+RETURNING id, product_id, name, additional_price, image_url, created_at, updated_at, deleted_at, modifier_group_id, is_default
 `
 
 type UpdateProductOptionParams struct {
-	Name            *string   `json:"name"`
-	AdditionalPrice *int64    `json:"additional_price"`
-	ImageUrl        *string   `json:"image_url"`
-	ID              uuid.UUID `json:"id"`
+	Name               *string     `json:"name"`
+	AdditionalPrice    *int64      `json:"additional_price"`
+	ImageUrl           *string     `json:"image_url"`
+	ClearModifierGroup bool        `json:"clear_modifier_group"`
+	ModifierGroupID    pgtype.UUID `json:"modifier_group_id"`
+	IsDefault          *bool       `json:"is_default"`
+	ID                 uuid.UUID   `json:"id"`
 }
 
-// Updates a specific product option.
+// Updates a specific product option. clear_modifier_group takes it out of its modifier group.
 func (q *Queries) UpdateProductOption(ctx context.Context, arg UpdateProductOptionParams) (ProductOption, error) {
 	row := q.db.QueryRow(ctx, updateProductOption,
 		arg.Name,
 		arg.AdditionalPrice,
 		arg.ImageUrl,
+		arg.ClearModifierGroup,
+		arg.ModifierGroupID,
+		arg.IsDefault,
 		arg.ID,
 	)
 	var i ProductOption
@@ -910,6 +963,8 @@ func (q *Queries) UpdateProductOption(ctx context.Context, arg UpdateProductOpti
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ModifierGroupID,
+		&i.IsDefault,
 	)
 	return i, err
 }
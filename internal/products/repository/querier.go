@@ -8,29 +8,45 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
 	AddProductStock(ctx context.Context, arg AddProductStockParams) (Product, error)
 	AssignProductCategory(ctx context.Context, arg AssignProductCategoryParams) error
+	// Checks whether a barcode is taken, by a product_barcodes row or the barcode of an active variant.
+	BarcodeExists(ctx context.Context, code string) (bool, error)
 	CheckCategoryExists(ctx context.Context, id int32) (bool, error)
 	ClearProductCategories(ctx context.Context, productID uuid.UUID) error
 	CountDeletedProducts(ctx context.Context, arg CountDeletedProductsParams) (int64, error)
+	// Counts a product's default options in a modifier group, leaving out exclude_id.
+	CountModifierGroupDefaults(ctx context.Context, arg CountModifierGroupDefaultsParams) (int64, error)
 	// Counts total products for pagination, respecting filters.
 	CountProducts(ctx context.Context, arg CountProductsParams) (int64, error)
-	CountStockHistoryByProduct(ctx context.Context, productID uuid.UUID) (int64, error)
+	CountStockHistoryByProduct(ctx context.Context, arg CountStockHistoryByProductParams) (int64, error)
+	CreateModifierGroup(ctx context.Context, arg CreateModifierGroupParams) (ModifierGroup, error)
 	// Queries for Products
 	// Creates a new product and returns its full details.
 	// Product options should be created separately in a transaction.
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	CreateProductBarcode(ctx context.Context, arg CreateProductBarcodeParams) (ProductBarcode, error)
 	// Queries for Product Options (Variants)
 	// Creates a new option for a specific product.
 	CreateProductOption(ctx context.Context, arg CreateProductOptionParams) (ProductOption, error)
+	CreateProductVariant(ctx context.Context, arg CreateProductVariantParams) (ProductVariant, error)
+	CreateProductVariantValue(ctx context.Context, arg CreateProductVariantValueParams) error
 	CreateStockHistory(ctx context.Context, arg CreateStockHistoryParams) (StockHistory, error)
 	DecreaseProductStock(ctx context.Context, arg DecreaseProductStockParams) (Product, error)
 	// Deletes a product. Its options will be deleted automatically due to 'ON DELETE CASCADE'.
 	DeleteProduct(ctx context.Context, id uuid.UUID) error
+	DeleteProductBarcode(ctx context.Context, id uuid.UUID) error
+	// Takes every option out of a modifier group, so they can be picked freely again.
+	DetachModifierGroupOptions(ctx context.Context, modifierGroupID pgtype.UUID) error
 	GetDeletedProduct(ctx context.Context, id uuid.UUID) (GetDeletedProductRow, error)
+	GetModifierGroup(ctx context.Context, id uuid.UUID) (ModifierGroup, error)
+	// Retrieves the active modifier groups among the given IDs.
+	GetModifierGroupsByIDs(ctx context.Context, ids []uuid.UUID) ([]ModifierGroup, error)
+	GetProductBarcode(ctx context.Context, arg GetProductBarcodeParams) (ProductBarcode, error)
 	// Retrieves a product by its ID, including its options.
 	GetProductByID(ctx context.Context, id uuid.UUID) (GetProductByIDRow, error)
 	// Mengambil satu varian produk berdasarkan ID dan ID produk induknya.
@@ -38,6 +54,7 @@ type Querier interface {
 	// Retrieves a product option by its ID, including its product details.
 	GetProductOptionByID(ctx context.Context, id uuid.UUID) (GetProductOptionByIDRow, error)
 	GetProductOptionsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([]ProductOption, error)
+	GetProductVariantForUpdate(ctx context.Context, arg GetProductVariantForUpdateParams) (ProductVariant, error)
 	// Retrieves a single product and aggregates its options into a JSON array.
 	// This is an efficient way to fetch a product and its variants in one query.
 	// Now filters out soft-deleted options.
@@ -46,21 +63,70 @@ type Querier interface {
 	GetProductsForUpdate(ctx context.Context, dollar_1 []uuid.UUID) ([]Product, error)
 	GetStockHistoryByProduct(ctx context.Context, productID uuid.UUID) ([]StockHistory, error)
 	GetStockHistoryByProductWithPagination(ctx context.Context, arg GetStockHistoryByProductWithPaginationParams) ([]StockHistory, error)
+	// Fetches variants by ID, removed ones included, e.g. to name the lines of past orders.
+	GetVariantsByIDs(ctx context.Context, ids []uuid.UUID) ([]ProductVariant, error)
+	// Lists the active options of every active product.
+	ListCatalogOptions(ctx context.Context) ([]ProductOption, error)
+	// Lists the categories of every active product.
+	ListCatalogProductCategories(ctx context.Context) ([]ListCatalogProductCategoriesRow, error)
+	// Lists every active product with the fields a catalog import or export works with.
+	ListCatalogProducts(ctx context.Context) ([]ListCatalogProductsRow, error)
+	// Lists every category, e.g. to match category names in an import.
+	ListCategories(ctx context.Context) ([]Category, error)
 	ListDeletedProducts(ctx context.Context, arg ListDeletedProductsParams) ([]ListDeletedProductsRow, error)
+	// Lists the active options in a modifier group across all active products.
+	ListModifierGroupOptions(ctx context.Context, modifierGroupID pgtype.UUID) ([]ListModifierGroupOptionsRow, error)
+	// Lists the active modifier groups.
+	ListModifierGroups(ctx context.Context) ([]ModifierGroup, error)
 	// Retrieves all options for a single product.
 	ListOptionsForProduct(ctx context.Context, productID uuid.UUID) ([]ProductOption, error)
+	// Lists a product's barcodes, those of its variants included.
+	ListProductBarcodes(ctx context.Context, productID uuid.UUID) ([]ProductBarcode, error)
+	// Lists the attribute values behind each active variant of a product.
+	ListProductVariantValues(ctx context.Context, productID uuid.UUID) ([]ListProductVariantValuesRow, error)
+	// Lists a product's active variants.
+	ListProductVariants(ctx context.Context, productID uuid.UUID) ([]ProductVariant, error)
 	// Lists products with filtering and pagination.
 	// Does not include variants for performance reasons on a list view.
 	ListProducts(ctx context.Context, arg ListProductsParams) ([]ListProductsRow, error)
+	// Lists the active products without variants, and the active variants, that have no barcode yet.
+	ListUnbarcodedItems(ctx context.Context) ([]ListUnbarcodedItemsRow, error)
+	// Lists the values of every variant attribute of a product.
+	ListVariantAttributeValues(ctx context.Context, productID uuid.UUID) ([]ProductVariantAttributeValue, error)
+	// Lists the attributes (e.g. size, colour) that make up a product's variant matrix.
+	ListVariantAttributes(ctx context.Context, productID uuid.UUID) ([]ProductVariantAttribute, error)
+	// Finds the product, or variant, behind a scanned code. Barcodes win over SKUs; SKUs match case-insensitively.
+	LookupProductCode(ctx context.Context, code string) (LookupProductCodeRow, error)
+	// Returns the most default options any one product has in a modifier group.
+	MaxModifierGroupDefaults(ctx context.Context, modifierGroupID pgtype.UUID) (int32, error)
+	// Checks whether an active modifier group other than exclude_id already uses the name (case-insensitive).
+	ModifierGroupNameExists(ctx context.Context, arg ModifierGroupNameExistsParams) (bool, error)
+	// Takes the next number for a generated in-store barcode.
+	NextInStoreBarcodeNumber(ctx context.Context) (int64, error)
+	ProductHasRecipe(ctx context.Context, productID uuid.UUID) (bool, error)
+	// Checks whether an active product other than exclude_id, or an active variant, already uses the SKU (case-insensitive).
+	ProductSKUExists(ctx context.Context, arg ProductSKUExistsParams) (bool, error)
 	RestoreProduct(ctx context.Context, id uuid.UUID) error
 	RestoreProductsBulk(ctx context.Context, dollar_1 []uuid.UUID) error
+	SoftDeleteModifierGroup(ctx context.Context, id uuid.UUID) error
 	SoftDeleteProduct(ctx context.Context, id uuid.UUID) error
 	// Deletes a single product option.
 	SoftDeleteProductOption(ctx context.Context, id uuid.UUID) error
+	SoftDeleteProductVariant(ctx context.Context, id uuid.UUID) error
+	// Updates a modifier group. clear_max_select removes the upper limit.
+	UpdateModifierGroup(ctx context.Context, arg UpdateModifierGroupParams) (ModifierGroup, error)
 	// Updates a product's details. Use COALESCE for optional fields.
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error)
 	// Updates a specific product option.
 	UpdateProductOption(ctx context.Context, arg UpdateProductOptionParams) (ProductOption, error)
+	// Updates a variant. An empty barcode clears it and clear_price drops the price override.
+	UpdateProductVariant(ctx context.Context, arg UpdateProductVariantParams) (ProductVariant, error)
+	UpsertVariantAttribute(ctx context.Context, arg UpsertVariantAttributeParams) (ProductVariantAttribute, error)
+	UpsertVariantAttributeValue(ctx context.Context, arg UpsertVariantAttributeValueParams) (ProductVariantAttributeValue, error)
+	// Checks whether an active variant other than exclude_id, or a product_barcodes row, already uses the barcode.
+	VariantBarcodeExists(ctx context.Context, arg VariantBarcodeExistsParams) (bool, error)
+	// Checks whether an active variant other than exclude_id, or an active product, already uses the SKU (case-insensitive).
+	VariantSKUExists(ctx context.Context, arg VariantSKUExistsParams) (bool, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -15,10 +15,16 @@ import (
 const countStockHistoryByProduct = `-- name: CountStockHistoryByProduct :one
 SELECT COUNT(*) FROM stock_history
 WHERE product_id = $1
+  AND ($2::uuid IS NULL OR variant_id = $2::uuid)
 `
 
-func (q *Queries) CountStockHistoryByProduct(ctx context.Context, productID uuid.UUID) (int64, error) {
-	row := q.db.QueryRow(ctx, countStockHistoryByProduct, productID)
+type CountStockHistoryByProductParams struct {
+	ProductID uuid.UUID   `json:"product_id"`
+	VariantID pgtype.UUID `json:"variant_id"`
+}
+
+func (q *Queries) CountStockHistoryByProduct(ctx context.Context, arg CountStockHistoryByProductParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countStockHistoryByProduct, arg.ProductID, arg.VariantID)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -33,10 +39,11 @@ INSERT INTO stock_history (
     change_type,
     reference_id,
     note,
-    created_by
+    created_by,
+    variant_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, product_id, change_amount, previous_stock, current_stock, change_type, reference_id, note, created_by, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, product_id, change_amount, previous_stock, current_stock, change_type, reference_id, note, created_by, created_at, variant_id
 `
 
 type CreateStockHistoryParams struct {
@@ -48,6 +55,7 @@ type CreateStockHistoryParams struct {
 	ReferenceID   pgtype.UUID     `json:"reference_id"`
 	Note          *string         `json:"note"`
 	CreatedBy     pgtype.UUID     `json:"created_by"`
+	VariantID     pgtype.UUID     `json:"variant_id"`
 }
 
 func (q *Queries) CreateStockHistory(ctx context.Context, arg CreateStockHistoryParams) (StockHistory, error) {
@@ -60,6 +68,7 @@ func (q *Queries) CreateStockHistory(ctx context.Context, arg CreateStockHistory
 		arg.ReferenceID,
 		arg.Note,
 		arg.CreatedBy,
+		arg.VariantID,
 	)
 	var i StockHistory
 	err := row.Scan(
@@ -73,12 +82,13 @@ func (q *Queries) CreateStockHistory(ctx context.Context, arg CreateStockHistory
 		&i.Note,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.VariantID,
 	)
 	return i, err
 }
 
 const getStockHistoryByProduct = `-- name: GetStockHistoryByProduct :many
-SELECT id, product_id, change_amount, previous_stock, current_stock, change_type, reference_id, note, created_by, created_at FROM stock_history
+SELECT id, product_id, change_amount, previous_stock, current_stock, change_type, reference_id, note, created_by, created_at, variant_id FROM stock_history
 WHERE product_id = $1
 ORDER BY created_at DESC
 `
@@ -103,6 +113,7 @@ func (q *Queries) GetStockHistoryByProduct(ctx context.Context, productID uuid.U
 			&i.Note,
 			&i.CreatedBy,
 			&i.CreatedAt,
+			&i.VariantID,
 		); err != nil {
 			return nil, err
 		}
@@ -115,20 +126,27 @@ func (q *Queries) GetStockHistoryByProduct(ctx context.Context, productID uuid.U
 }
 
 const getStockHistoryByProductWithPagination = `-- name: GetStockHistoryByProductWithPagination :many
-SELECT id, product_id, change_amount, previous_stock, current_stock, change_type, reference_id, note, created_by, created_at FROM stock_history
+SELECT id, product_id, change_amount, previous_stock, current_stock, change_type, reference_id, note, created_by, created_at, variant_id FROM stock_history
 WHERE product_id = $1
+  AND ($2::uuid IS NULL OR variant_id = $2::uuid)
 ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
+LIMIT $3 OFFSET $4
 `
 
 type GetStockHistoryByProductWithPaginationParams struct {
-	ProductID uuid.UUID `json:"product_id"`
-	Limit     int32     `json:"limit"`
-	Offset    int32     `json:"offset"`
+	ProductID uuid.UUID   `json:"product_id"`
+	VariantID pgtype.UUID `json:"variant_id"`
+	Limit     int32       `json:"limit"`
+	Offset    int32       `json:"offset"`
 }
 
 func (q *Queries) GetStockHistoryByProductWithPagination(ctx context.Context, arg GetStockHistoryByProductWithPaginationParams) ([]StockHistory, error) {
-	rows, err := q.db.Query(ctx, getStockHistoryByProductWithPagination, arg.ProductID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, getStockHistoryByProductWithPagination,
+		arg.ProductID,
+		arg.VariantID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -147,6 +165,7 @@ func (q *Queries) GetStockHistoryByProductWithPagination(ctx context.Context, ar
 			&i.Note,
 			&i.CreatedBy,
 			&i.CreatedAt,
+			&i.VariantID,
 		); err != nil {
 			return nil, err
 		}
@@ -6,8 +6,11 @@ import (
 	"POS-kasir/internal/common"
 	"POS-kasir/internal/common/pagination"
 	"POS-kasir/internal/common/store"
+	"POS-kasir/internal/costing"
+	costing_repo "POS-kasir/internal/costing/repository"
 	products_repo "POS-kasir/internal/products/repository"
 	"POS-kasir/pkg/logger"
+	"POS-kasir/pkg/utils"
 
 	"context"
 	"encoding/json"
@@ -34,6 +37,29 @@ type IPrdService interface {
 	UpdateProductOption(ctx context.Context, productID, optionID uuid.UUID, req UpdateProductOptionRequest) (*ProductOptionResponse, error)
 	DeleteProductOption(ctx context.Context, productID, optionID uuid.UUID) error
 
+	// Modifier Groups
+	CreateModifierGroup(ctx context.Context, req CreateModifierGroupRequest) (*ModifierGroupResponse, error)
+	ListModifierGroups(ctx context.Context) ([]ModifierGroupResponse, error)
+	GetModifierGroup(ctx context.Context, groupID uuid.UUID) (*ModifierGroupResponse, error)
+	UpdateModifierGroup(ctx context.Context, groupID uuid.UUID, req UpdateModifierGroupRequest) (*ModifierGroupResponse, error)
+	DeleteModifierGroup(ctx context.Context, groupID uuid.UUID) error
+
+	// Barcodes
+	LookupCode(ctx context.Context, code string) (*ProductLookupResponse, error)
+	AddProductBarcode(ctx context.Context, productID uuid.UUID, req AddProductBarcodeRequest) (*ProductBarcodeResponse, error)
+	DeleteProductBarcode(ctx context.Context, productID, barcodeID uuid.UUID) error
+	GenerateMissingBarcodes(ctx context.Context) (*GenerateBarcodesResponse, error)
+
+	// Import and Export
+	ImportProducts(ctx context.Context, filename string, data []byte, dryRun bool) (*ImportProductsResponse, error)
+	ExportProducts(ctx context.Context, format string) ([]byte, error)
+
+	// Variants
+	GenerateVariants(ctx context.Context, productID uuid.UUID, req GenerateVariantsRequest) (*ProductVariantsResponse, error)
+	ListVariants(ctx context.Context, productID uuid.UUID) (*ProductVariantsResponse, error)
+	UpdateVariant(ctx context.Context, productID, variantID uuid.UUID, req UpdateVariantRequest) (*ProductVariantResponse, error)
+	DeleteVariant(ctx context.Context, productID, variantID uuid.UUID) error
+
 	// Deleted Products Management
 	ListDeletedProducts(ctx context.Context, req ListProductsRequest) (*ListProductsResponse, error)
 	GetDeletedProduct(ctx context.Context, productID uuid.UUID) (*ProductResponse, error)
@@ -97,7 +123,7 @@ func (s *PrdService) DeleteProductOption(ctx context.Context, productID, optionI
 }
 
 func (s *PrdService) UpdateProductOption(ctx context.Context, productID, optionID uuid.UUID, req UpdateProductOptionRequest) (*ProductOptionResponse, error) {
-	_, err := s.repo.GetProductOption(ctx, products_repo.GetProductOptionParams{ID: optionID, ProductID: productID})
+	option, err := s.repo.GetProductOption(ctx, products_repo.GetProductOptionParams{ID: optionID, ProductID: productID})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			s.log.Warnf("Product option not found or does not belong to the product", "optionID", optionID, "productID", productID)
@@ -108,8 +134,32 @@ func (s *PrdService) UpdateProductOption(ctx context.Context, productID, optionI
 	}
 
 	updateParams := products_repo.UpdateProductOptionParams{
-		ID:   optionID,
-		Name: req.Name,
+		ID:                 optionID,
+		Name:               req.Name,
+		ClearModifierGroup: req.ClearModifierGroup,
+		IsDefault:          req.IsDefault,
+	}
+	if req.ModifierGroupID != nil {
+		updateParams.ModifierGroupID = pgtype.UUID{Bytes: *req.ModifierGroupID, Valid: true}
+	}
+
+	// Check the group and default the option ends up with, not just the fields being changed
+	if !req.ClearModifierGroup {
+		groupID := option.ModifierGroupID
+		if req.ModifierGroupID != nil {
+			groupID = updateParams.ModifierGroupID
+		}
+		isDefault := option.IsDefault
+		if req.IsDefault != nil {
+			isDefault = *req.IsDefault
+		}
+		if !groupID.Valid {
+			// Only grouped options can be defaults
+			updateParams.IsDefault = nil
+		}
+		if err := checkOptionGroup(ctx, s.repo, productID, optionID, groupID, isDefault); err != nil {
+			return nil, err
+		}
 	}
 
 	if req.AdditionalPrice != nil {
@@ -156,6 +206,8 @@ func (s *PrdService) UpdateProductOption(ctx context.Context, productID, optionI
 		Name:            updatedOption.Name,
 		AdditionalPrice: additionalPrice,
 		ImageURL:        updatedOption.ImageUrl,
+		ModifierGroupID: utils.NullableUUIDToPointer(updatedOption.ModifierGroupID),
+		IsDefault:       updatedOption.IsDefault,
 	}, nil
 }
 
@@ -225,6 +277,8 @@ func (s *PrdService) UploadProductOptionImage(ctx context.Context, productID uui
 		Name:            updatedOption.Name,
 		AdditionalPrice: additionalPrice,
 		ImageURL:        &publicUrl,
+		ModifierGroupID: utils.NullableUUIDToPointer(updatedOption.ModifierGroupID),
+		IsDefault:       updatedOption.IsDefault,
 	}, nil
 }
 func (s *PrdService) CreateProductOption(ctx context.Context, productID uuid.UUID, req CreateProductOptionRequestStandalone) (*ProductOptionResponse, error) {
@@ -244,6 +298,14 @@ func (s *PrdService) CreateProductOption(ctx context.Context, productID uuid.UUI
 		Name:            req.Name,
 		AdditionalPrice: additionalPrice,
 	}
+	if req.ModifierGroupID != nil {
+		params.ModifierGroupID = pgtype.UUID{Bytes: *req.ModifierGroupID, Valid: true}
+		params.IsDefault = req.IsDefault
+	}
+	if err := checkOptionGroup(ctx, s.repo, productID, uuid.Nil, params.ModifierGroupID, params.IsDefault); err != nil {
+		return nil, err
+	}
+
 	newOption, err := s.repo.CreateProductOption(ctx, params)
 	if err != nil {
 		s.log.Errorf("Failed to create product option in repository", "error", err)
@@ -270,6 +332,8 @@ func (s *PrdService) CreateProductOption(ctx context.Context, productID uuid.UUI
 		Name:            newOption.Name,
 		AdditionalPrice: float64(newOption.AdditionalPrice),
 		ImageURL:        newOption.ImageUrl,
+		ModifierGroupID: utils.NullableUUIDToPointer(newOption.ModifierGroupID),
+		IsDefault:       newOption.IsDefault,
 	}, nil
 }
 func (s *PrdService) DeleteProduct(ctx context.Context, productID uuid.UUID) error {
@@ -334,6 +398,29 @@ func (s *PrdService) UpdateProduct(ctx context.Context, productID uuid.UUID, req
 		return nil, err
 	}
 
+	if req.Stock != nil {
+		variants, err := s.repo.ListProductVariants(ctx, productID)
+		if err != nil {
+			s.log.Errorf("Failed to list product variants", "error", err, "productID", productID)
+			return nil, err
+		}
+		if len(variants) > 0 {
+			return nil, common.ErrProductHasVariants
+		}
+	}
+
+	var sku *string
+	if req.SKU != nil {
+		checked, err := checkProductSKU(ctx, s.repo, productID, *req.SKU)
+		if err != nil {
+			if !errors.Is(err, common.ErrSKUExists) {
+				s.log.Errorf("Failed to check product SKU", "error", err)
+			}
+			return nil, err
+		}
+		sku = &checked
+	}
+
 	if req.CategoryIDs != nil {
 		for _, catID := range *req.CategoryIDs {
 			exists, err := s.repo.CheckCategoryExists(ctx, catID)
@@ -367,6 +454,7 @@ func (s *PrdService) UpdateProduct(ctx context.Context, productID uuid.UUID, req
 		ID:    productID,
 		Name:  req.Name,
 		Stock: req.Stock,
+		Sku:   sku,
 	}
 
 	if req.Price != nil {
@@ -422,6 +510,10 @@ func (s *PrdService) UpdateProduct(ctx context.Context, productID uuid.UUID, req
 				// Don't fail the request if logging fails, but log error
 				s.log.Errorf("Failed to record stock history", "error", err)
 			}
+
+			if err := s.adjustCostLayers(ctx, productID, previousStock, changeAmount); err != nil {
+				s.log.Errorf("Failed to adjust cost layers", "error", err, "productID", productID)
+			}
 		}
 	}
 
@@ -442,6 +534,23 @@ func (s *PrdService) UpdateProduct(ctx context.Context, productID uuid.UUID, req
 	return s.GetProductByID(ctx, productID)
 }
 
+// adjustCostLayers keeps the cost layers in step with a manual stock change: units counted in are layered at
+// the product's current cost and units written off are taken from the oldest layers.
+func (s *PrdService) adjustCostLayers(ctx context.Context, productID uuid.UUID, previousStock, changeAmount int32) error {
+	return s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qCost := costing_repo.New(tx)
+		product, err := products_repo.New(tx).GetProductByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+		if changeAmount > 0 {
+			return costing.Receive(ctx, qCost, productID, previousStock, changeAmount, product.CostPrice, costing_repo.CostLayerSourceAdjustment, pgtype.UUID{})
+		}
+		_, err = costing.Consume(ctx, qCost, productID, -changeAmount, pgtype.UUID{}, product.CostPrice)
+		return err
+	})
+}
+
 func (s *PrdService) RecordStockChange(ctx context.Context, productID uuid.UUID, changeAmount, previousStock, currentStock int32, changeType products_repo.StockChangeType, referenceID pgtype.UUID, note *string, createdBy pgtype.UUID) error {
 	params := products_repo.CreateStockHistoryParams{
 		ProductID:     productID,
@@ -524,6 +633,8 @@ func (s *PrdService) buildProductResponse(ctx context.Context, fullProduct produ
 				Name:            opt.Name,
 				AdditionalPrice: additionalPrice,
 				ImageURL:        opt.ImageUrl,
+				ModifierGroupID: utils.NullableUUIDToPointer(opt.ModifierGroupID),
+				IsDefault:       opt.IsDefault,
 			})
 		}
 	}
@@ -579,18 +690,38 @@ func (s *PrdService) buildProductResponse(ctx context.Context, fullProduct produ
 
 	categories := parseCategoriesJSON(fullProduct.Categories)
 
+	variants, err := s.loadVariants(ctx, fullProduct.ID, fullProduct.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := s.loadProductModifierGroups(ctx, optionsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	barcodes, err := s.loadProductBarcodes(ctx, fullProduct.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ProductResponse{
 		ID:         fullProduct.ID,
 		Name:       fullProduct.Name,
+		SKU:        fullProduct.Sku,
+		Barcodes:   barcodes,
 		Categories: categories,
 
-		ImageURL:  fullProduct.ImageUrl,
-		Price:     productPrice,
-		CostPrice: costPrice,
-		Stock:     fullProduct.Stock,
-		CreatedAt: fullProduct.CreatedAt.Time,
-		UpdatedAt: fullProduct.UpdatedAt.Time,
-		Options:   optionsResponse,
+		ImageURL:   fullProduct.ImageUrl,
+		Price:      productPrice,
+		CostPrice:  costPrice,
+		Stock:      fullProduct.Stock,
+		CreatedAt:  fullProduct.CreatedAt.Time,
+		UpdatedAt:  fullProduct.UpdatedAt.Time,
+		Options:    optionsResponse,
+		Groups:     groups,
+		Attributes: variants.Attributes,
+		Variants:   variants.Variants,
 	}, nil
 }
 
@@ -659,6 +790,7 @@ func (s *PrdService) ListProducts(ctx context.Context, req ListProductsRequest)
 		productsResponse = append(productsResponse, ProductListResponse{
 			ID:         p.ID,
 			Name:       p.Name,
+			SKU:        p.Sku,
 			Categories: categories,
 			ImageURL:   p.ImageUrl,
 			Price:      price,
@@ -697,6 +829,15 @@ func (s *PrdService) CreateProduct(ctx context.Context, req CreateProductRequest
 			Stock:     req.Stock,
 			CostPrice: numericCost,
 		}
+		if req.SKU != nil {
+			sku, err := checkProductSKU(ctx, qtx, uuid.Nil, *req.SKU)
+			if err != nil {
+				return err
+			}
+			if sku != "" {
+				productParams.Sku = &sku
+			}
+		}
 
 		newProduct, err = qtx.CreateProduct(ctx, productParams)
 		if err != nil {
@@ -704,6 +845,13 @@ func (s *PrdService) CreateProduct(ctx context.Context, req CreateProductRequest
 			return err
 		}
 
+		// Stock the product starts with opens its first cost layer
+		err = costing.Receive(ctx, costing_repo.New(tx), newProduct.ID, 0, newProduct.Stock, numericCost, costing_repo.CostLayerSourceOpening, pgtype.UUID{})
+		if err != nil {
+			s.log.Errorf("Failed to open cost layer in transaction", "error", err)
+			return err
+		}
+
 		for _, catID := range req.CategoryIDs {
 			err = qtx.AssignProductCategory(ctx, products_repo.AssignProductCategoryParams{
 				ProductID:  newProduct.ID,
@@ -723,6 +871,14 @@ func (s *PrdService) CreateProduct(ctx context.Context, req CreateProductRequest
 				Name:            opt.Name,
 				AdditionalPrice: additionalPrice,
 			}
+			if opt.ModifierGroupID != nil {
+				optionParams.ModifierGroupID = pgtype.UUID{Bytes: *opt.ModifierGroupID, Valid: true}
+				optionParams.IsDefault = opt.IsDefault
+			}
+			if err := checkOptionGroup(ctx, qtx, newProduct.ID, uuid.Nil, optionParams.ModifierGroupID, optionParams.IsDefault); err != nil {
+				return err
+			}
+
 			createdOpt, err := qtx.CreateProductOption(ctx, optionParams)
 			if err != nil {
 				s.log.Errorf("Failed to create product option in transaction", "error", err)
@@ -731,6 +887,17 @@ func (s *PrdService) CreateProduct(ctx context.Context, req CreateProductRequest
 
 			createdOptions = append(createdOptions, createdOpt)
 		}
+
+		for _, code := range req.Barcodes {
+			if _, err := createBarcode(ctx, qtx, newProduct.ID, pgtype.UUID{}, code, nil); err != nil {
+				return err
+			}
+		}
+		if req.GenerateBarcode && len(req.Barcodes) == 0 {
+			if _, err := createInStoreBarcode(ctx, qtx, newProduct.ID, pgtype.UUID{}); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
 
@@ -967,6 +1134,8 @@ func (s *PrdService) GetDeletedProduct(ctx context.Context, productID uuid.UUID)
 						Name:            o.Name,
 						AdditionalPrice: float64(o.AdditionalPrice),
 						ImageURL:        o.ImageUrl,
+						ModifierGroupID: utils.NullableUUIDToPointer(o.ModifierGroupID),
+						IsDefault:       o.IsDefault,
 					})
 				}
 			}
@@ -983,6 +1152,7 @@ func (s *PrdService) GetDeletedProduct(ctx context.Context, productID uuid.UUID)
 	return &ProductResponse{
 		ID:         row.ID,
 		Name:       row.Name,
+		SKU:        row.Sku,
 		Categories: categories,
 		ImageURL:   row.ImageUrl,
 		Price:      float64(row.Price),
@@ -0,0 +1,317 @@
+package products
+
+import (
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	products_repo "POS-kasir/internal/products/repository"
+	"POS-kasir/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxInStoreBarcodeAttempts bounds the retries when a generated code is already taken, e.g. by a code
+// entered by hand with the in-store prefix.
+const maxInStoreBarcodeAttempts = 5
+
+// LookupCode finds the product, or variant, a scanned barcode or typed SKU belongs to.
+func (s *PrdService) LookupCode(ctx context.Context, code string) (*ProductLookupResponse, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, common.ErrNotFound
+	}
+
+	match, err := s.repo.LookupProductCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("Failed to look up product code", "error", err, "code", code)
+		return nil, err
+	}
+
+	resp := &ProductLookupResponse{
+		Code:      code,
+		MatchedBy: match.MatchedBy,
+		ProductID: match.ProductID,
+		Name:      match.Name,
+		SKU:       match.Sku,
+		Price:     float64(match.Price),
+		Stock:     match.Stock,
+	}
+	if match.VariantID.Valid {
+		resp.VariantID = utils.NullableUUIDToPointer(match.VariantID)
+		resp.VariantName = match.VariantName
+		resp.SKU = match.VariantSku
+		if match.VariantPrice != nil {
+			resp.Price = float64(*match.VariantPrice)
+		}
+		if match.VariantStock != nil {
+			resp.Stock = *match.VariantStock
+		}
+	}
+	if match.ImageUrl != nil && *match.ImageUrl != "" {
+		imageURL, err := s.prdRepo.PrdImageLink(ctx, match.ProductID.String(), *match.ImageUrl)
+		if err != nil {
+			s.log.Warnf("Failed to get public URL for product image", "error", err)
+			imageURL = *match.ImageUrl
+		}
+		resp.ImageURL = &imageURL
+	}
+	return resp, nil
+}
+
+// AddProductBarcode adds a barcode to a product or one of its variants, either as given or generated.
+func (s *PrdService) AddProductBarcode(ctx context.Context, productID uuid.UUID, req AddProductBarcodeRequest) (*ProductBarcodeResponse, error) {
+	if (req.Code != nil) == req.Generate {
+		return nil, common.ErrBarcodeInvalid
+	}
+
+	var barcode products_repo.ProductBarcode
+	err := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := products_repo.New(tx)
+
+		if _, err := qtx.GetProductByID(ctx, productID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return common.ErrNotFound
+			}
+			return fmt.Errorf("failed to get product: %w", err)
+		}
+
+		var variantID pgtype.UUID
+		if req.VariantID != nil {
+			_, err := qtx.GetProductVariantForUpdate(ctx, products_repo.GetProductVariantForUpdateParams{ID: *req.VariantID, ProductID: productID})
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return common.ErrVariantNotFound
+				}
+				return fmt.Errorf("failed to get variant: %w", err)
+			}
+			variantID = pgtype.UUID{Bytes: *req.VariantID, Valid: true}
+		}
+
+		var err error
+		if req.Generate {
+			barcode, err = createInStoreBarcode(ctx, qtx, productID, variantID)
+		} else {
+			barcode, err = createBarcode(ctx, qtx, productID, variantID, *req.Code, req.Symbology)
+		}
+		return err
+	})
+	if err != nil {
+		if !isBarcodeError(err) {
+			s.log.Errorf("Failed to add product barcode", "error", err, "productID", productID)
+		}
+		return nil, err
+	}
+
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeUPDATE,
+		activitylog_repo.LogEntityTypePRODUCT,
+		productID.String(),
+		map[string]interface{}{
+			"added_barcode": barcode.Code,
+			"symbology":     barcode.Symbology,
+			"variant_id":    req.VariantID,
+		},
+	)
+
+	resp := productBarcodeResponse(barcode)
+	return &resp, nil
+}
+
+// DeleteProductBarcode removes one of a product's barcodes.
+func (s *PrdService) DeleteProductBarcode(ctx context.Context, productID, barcodeID uuid.UUID) error {
+	barcode, err := s.repo.GetProductBarcode(ctx, products_repo.GetProductBarcodeParams{ID: barcodeID, ProductID: productID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return common.ErrBarcodeNotFound
+		}
+		s.log.Errorf("Failed to get product barcode", "error", err, "barcodeID", barcodeID)
+		return err
+	}
+
+	if err := s.repo.DeleteProductBarcode(ctx, barcodeID); err != nil {
+		s.log.Errorf("Failed to delete product barcode", "error", err, "barcodeID", barcodeID)
+		return err
+	}
+
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeUPDATE,
+		activitylog_repo.LogEntityTypePRODUCT,
+		productID.String(),
+		map[string]interface{}{
+			"removed_barcode": barcode.Code,
+		},
+	)
+	return nil
+}
+
+// GenerateMissingBarcodes gives every active product without variants, and every active variant, that has no
+// barcode yet an in-store EAN-13 code, so the whole catalog can be labelled and scanned.
+func (s *PrdService) GenerateMissingBarcodes(ctx context.Context) (*GenerateBarcodesResponse, error) {
+	var created []products_repo.ProductBarcode
+	err := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := products_repo.New(tx)
+
+		items, err := qtx.ListUnbarcodedItems(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list products without barcode: %w", err)
+		}
+		for _, item := range items {
+			barcode, err := createInStoreBarcode(ctx, qtx, item.ProductID, item.VariantID)
+			if err != nil {
+				return err
+			}
+			created = append(created, barcode)
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Errorf("Failed to generate missing barcodes", "error", err)
+		return nil, err
+	}
+
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	resp := &GenerateBarcodesResponse{
+		Generated: len(created),
+		Barcodes:  make([]ProductBarcodeResponse, 0, len(created)),
+	}
+	for _, barcode := range created {
+		s.activityService.Log(
+			ctx,
+			actorID,
+			activitylog_repo.LogActionTypeUPDATE,
+			activitylog_repo.LogEntityTypePRODUCT,
+			barcode.ProductID.String(),
+			map[string]interface{}{
+				"added_barcode": barcode.Code,
+				"generated":     true,
+			},
+		)
+		resp.Barcodes = append(resp.Barcodes, productBarcodeResponse(barcode))
+	}
+	return resp, nil
+}
+
+// createBarcode validates a barcode and adds it, refusing codes already used by any product or variant.
+func createBarcode(ctx context.Context, qtx *products_repo.Queries, productID uuid.UUID, variantID pgtype.UUID, code string, symbology *string) (products_repo.ProductBarcode, error) {
+	code, kind, err := parseBarcode(code, symbology)
+	if err != nil {
+		return products_repo.ProductBarcode{}, err
+	}
+
+	taken, err := qtx.BarcodeExists(ctx, code)
+	if err != nil {
+		return products_repo.ProductBarcode{}, fmt.Errorf("failed to check barcode: %w", err)
+	}
+	if taken {
+		return products_repo.ProductBarcode{}, common.ErrBarcodeExists
+	}
+
+	barcode, err := qtx.CreateProductBarcode(ctx, products_repo.CreateProductBarcodeParams{
+		ProductID: productID,
+		VariantID: variantID,
+		Code:      code,
+		Symbology: kind,
+	})
+	if err != nil {
+		return products_repo.ProductBarcode{}, fmt.Errorf("failed to create barcode: %w", err)
+	}
+	return barcode, nil
+}
+
+// createInStoreBarcode adds a generated in-store EAN-13 code, skipping numbers whose code is already taken.
+func createInStoreBarcode(ctx context.Context, qtx *products_repo.Queries, productID uuid.UUID, variantID pgtype.UUID) (products_repo.ProductBarcode, error) {
+	for attempt := 0; attempt < maxInStoreBarcodeAttempts; attempt++ {
+		number, err := qtx.NextInStoreBarcodeNumber(ctx)
+		if err != nil {
+			return products_repo.ProductBarcode{}, fmt.Errorf("failed to take barcode number: %w", err)
+		}
+		code := inStoreBarcode(number)
+
+		taken, err := qtx.BarcodeExists(ctx, code)
+		if err != nil {
+			return products_repo.ProductBarcode{}, fmt.Errorf("failed to check barcode: %w", err)
+		}
+		if taken {
+			continue
+		}
+
+		barcode, err := qtx.CreateProductBarcode(ctx, products_repo.CreateProductBarcodeParams{
+			ProductID:   productID,
+			VariantID:   variantID,
+			Code:        code,
+			Symbology:   products_repo.BarcodeSymbologyEan13,
+			IsGenerated: true,
+		})
+		if err != nil {
+			return products_repo.ProductBarcode{}, fmt.Errorf("failed to create barcode: %w", err)
+		}
+		return barcode, nil
+	}
+	return products_repo.ProductBarcode{}, fmt.Errorf("no free in-store barcode after %d attempts", maxInStoreBarcodeAttempts)
+}
+
+// checkProductSKU trims a SKU and makes sure no other product or variant uses it. An empty SKU is returned
+// as is, for callers that treat it as "remove".
+func checkProductSKU(ctx context.Context, q products_repo.Querier, productID uuid.UUID, sku string) (string, error) {
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		return sku, nil
+	}
+
+	taken, err := q.ProductSKUExists(ctx, products_repo.ProductSKUExistsParams{Sku: sku, ExcludeID: productID})
+	if err != nil {
+		return "", fmt.Errorf("failed to check product SKU: %w", err)
+	}
+	if taken {
+		return "", common.ErrSKUExists
+	}
+	return sku, nil
+}
+
+// loadProductBarcodes returns a product's barcodes, those of its variants included.
+func (s *PrdService) loadProductBarcodes(ctx context.Context, productID uuid.UUID) ([]ProductBarcodeResponse, error) {
+	barcodes, err := s.repo.ListProductBarcodes(ctx, productID)
+	if err != nil {
+		s.log.Errorf("Failed to list product barcodes", "error", err, "productID", productID)
+		return nil, err
+	}
+
+	resp := make([]ProductBarcodeResponse, 0, len(barcodes))
+	for _, b := range barcodes {
+		resp = append(resp, productBarcodeResponse(b))
+	}
+	return resp, nil
+}
+
+func productBarcodeResponse(b products_repo.ProductBarcode) ProductBarcodeResponse {
+	return ProductBarcodeResponse{
+		ID:          b.ID,
+		ProductID:   b.ProductID,
+		VariantID:   utils.NullableUUIDToPointer(b.VariantID),
+		Code:        b.Code,
+		Symbology:   string(b.Symbology),
+		IsGenerated: b.IsGenerated,
+		CreatedAt:   b.CreatedAt.Time,
+	}
+}
+
+func isBarcodeError(err error) bool {
+	return errors.Is(err, common.ErrNotFound) ||
+		errors.Is(err, common.ErrVariantNotFound) ||
+		errors.Is(err, common.ErrBarcodeInvalid) ||
+		errors.Is(err, common.ErrBarcodeExists)
+}
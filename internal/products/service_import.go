@@ -0,0 +1,862 @@
+package products
+
+import (
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/costing"
+	costing_repo "POS-kasir/internal/costing/repository"
+	products_repo "POS-kasir/internal/products/repository"
+	"POS-kasir/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	importStockNote       = "Bulk import"
+	importImageWorkers    = 4
+	importImageTimeout    = 15 * time.Second
+	maxImportImageSize    = 5 * 1024 * 1024
+	importImageUserAgent  = "POS-kasir product import"
+	importImageRedirects  = 5
+	importChangeImage     = "image_url"
+	importChangeOptions   = "options"
+	importChangeStock     = "stock"
+	importChangeCategory  = "categories"
+	importChangeCostPrice = "cost_price"
+)
+
+// importImageClient downloads the images linked from an import file. The links come from an uploaded file, so
+// it only reaches public addresses: the address is checked after the host name is resolved, for every redirect
+// too, and no proxy is used that could reach further.
+var importImageClient = &http.Client{
+	Timeout: importImageTimeout,
+	Transport: &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: importImageTimeout, Control: dialPublicAddress}).DialContext,
+		TLSHandshakeTimeout: importImageTimeout,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= importImageRedirects {
+			return fmt.Errorf("image link redirects more than %d times", importImageRedirects)
+		}
+		return checkImportImageURL(req.URL)
+	},
+}
+
+// checkImportImageURL accepts only http and https links.
+func checkImportImageURL(u *url.URL) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("image_url must be an http or https link")
+	}
+	return nil
+}
+
+// dialPublicAddress refuses connections to the server itself and to private, link-local and other
+// non-public networks.
+func dialPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !isPublicAddress(ip) {
+		return fmt.Errorf("image link points to a non-public address %s", ip)
+	}
+	return nil
+}
+
+// reservedPrefixes are ranges that pass as global unicast but are not reachable from the internet either:
+// "this network" and the carrier-grade NAT range.
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+func isPublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// catalogProduct is an active product with its categories and options, as an import compares it.
+type catalogProduct struct {
+	products_repo.ListCatalogProductsRow
+	Categories []products_repo.ListCatalogProductCategoriesRow
+	Options    []products_repo.ProductOption
+}
+
+// importCatalog is the current catalog an import is planned against.
+type importCatalog struct {
+	products   []*catalogProduct
+	byID       map[uuid.UUID]*catalogProduct
+	bySKU      map[string]*catalogProduct
+	categories map[string]products_repo.Category
+}
+
+// importOptionPrice is a new price for an option a product already has.
+type importOptionPrice struct {
+	ID    uuid.UUID
+	Price int64
+}
+
+// importAction is the planned change for one row: a new product, or the fields to set on an existing one.
+type importAction struct {
+	row          importRow
+	result       ImportRowResult
+	product      *catalogProduct
+	name         *string
+	sku          *string
+	price        *int64
+	costPrice    *float64
+	stock        *int32
+	categoryIDs  *[]int32
+	newOptions   []importOption
+	optionPrices []importOptionPrice
+	imageURL     *string
+}
+
+// ImportProducts reads a CSV or XLSX catalog file and works out, row by row, which products it creates,
+// updates or leaves as they are. Rows are matched to products by id, else by SKU. A dry run only returns that
+// diff; otherwise the whole file is applied in one transaction, and only when no row has errors. Images are
+// downloaded after the products are saved, so a broken link is a warning rather than a failed import.
+func (s *PrdService) ImportProducts(ctx context.Context, filename string, data []byte, dryRun bool) (*ImportProductsResponse, error) {
+	records, err := readCatalogFile(filename, data)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := parseImportRows(records)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		actions, err := s.planImport(ctx, s.repo, rows)
+		if err != nil {
+			s.log.Errorf("Failed to plan product import", "error", err)
+			return nil, err
+		}
+		return importResponse(actions, true), nil
+	}
+
+	actorID, userIdOk := ctx.Value(common.UserIDKey).(uuid.UUID)
+	createdBy := pgtype.UUID{Bytes: actorID, Valid: userIdOk}
+
+	var actions []*importAction
+	err = s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := products_repo.New(tx)
+		qCost := costing_repo.New(tx)
+
+		var err error
+		actions, err = s.planImport(ctx, qtx, rows)
+		if err != nil {
+			return err
+		}
+		for _, action := range actions {
+			if action.result.Action == ImportActionError {
+				return common.ErrImportHasErrors
+			}
+		}
+
+		for _, action := range actions {
+			switch action.result.Action {
+			case ImportActionCreate:
+				err = applyImportCreate(ctx, qtx, qCost, action, createdBy)
+			case ImportActionUpdate:
+				err = applyImportUpdate(ctx, qtx, qCost, action, createdBy)
+			}
+			if err != nil {
+				return fmt.Errorf("line %d: %w", action.row.Line, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, common.ErrImportHasErrors) {
+			return importResponse(actions, false), err
+		}
+		s.log.Errorf("Failed to apply product import", "error", err)
+		return nil, err
+	}
+
+	s.importImages(ctx, actions)
+
+	for _, action := range actions {
+		if action.result.Action != ImportActionCreate && action.result.Action != ImportActionUpdate {
+			continue
+		}
+		logAction := activitylog_repo.LogActionTypeUPDATE
+		if action.result.Action == ImportActionCreate {
+			logAction = activitylog_repo.LogActionTypeCREATE
+		}
+		s.activityService.Log(
+			ctx,
+			actorID,
+			logAction,
+			activitylog_repo.LogEntityTypePRODUCT,
+			action.result.ProductID.String(),
+			map[string]interface{}{
+				"source":       "import",
+				"file":         filename,
+				"line":         action.row.Line,
+				"product_name": action.result.Name,
+				"changes":      action.result.Changes,
+			},
+		)
+	}
+
+	return importResponse(actions, false), nil
+}
+
+// ExportProducts writes the active catalog in the format ImportProducts reads. Products with variants leave
+// stock empty, as their stock is kept per variant.
+func (s *PrdService) ExportProducts(ctx context.Context, format string) ([]byte, error) {
+	catalog, err := loadImportCatalog(ctx, s.repo)
+	if err != nil {
+		s.log.Errorf("Failed to load catalog for export", "error", err)
+		return nil, err
+	}
+
+	rows := make([][]interface{}, 0, len(catalog.products))
+	for _, p := range catalog.products {
+		var sku, stock, imageURL interface{}
+		if p.Sku != nil {
+			sku = *p.Sku
+		}
+		if !p.HasVariants {
+			stock = p.Stock
+		}
+		if p.ImageUrl != nil && *p.ImageUrl != "" {
+			link, err := s.prdRepo.PrdImageLink(ctx, p.ID.String(), *p.ImageUrl)
+			if err != nil || link == "" {
+				s.log.Warnf("Failed to get public URL for product image", "error", err, "productID", p.ID)
+			} else {
+				imageURL = link
+			}
+		}
+		rows = append(rows, []interface{}{
+			p.ID.String(),
+			sku,
+			p.Name,
+			formatImportCategories(p.categoryNames()),
+			p.Price,
+			utils.NumericToFloat64(p.CostPrice),
+			stock,
+			formatImportOptions(p.importOptions()),
+			imageURL,
+		})
+	}
+
+	data, err := writeCatalogFile(format, catalogColumns, rows)
+	if err != nil {
+		s.log.Errorf("Failed to write catalog file", "error", err, "format", format)
+		return nil, err
+	}
+	return data, nil
+}
+
+func loadImportCatalog(ctx context.Context, q products_repo.Querier) (*importCatalog, error) {
+	products, err := q.ListCatalogProducts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+	productCategories, err := q.ListCatalogProductCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product categories: %w", err)
+	}
+	options, err := q.ListCatalogOptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product options: %w", err)
+	}
+	categories, err := q.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	catalog := &importCatalog{
+		products:   make([]*catalogProduct, 0, len(products)),
+		byID:       make(map[uuid.UUID]*catalogProduct, len(products)),
+		bySKU:      make(map[string]*catalogProduct),
+		categories: make(map[string]products_repo.Category, len(categories)),
+	}
+	for _, row := range products {
+		p := &catalogProduct{ListCatalogProductsRow: row}
+		catalog.products = append(catalog.products, p)
+		catalog.byID[p.ID] = p
+		if p.Sku != nil {
+			catalog.bySKU[strings.ToLower(*p.Sku)] = p
+		}
+	}
+	for _, pc := range productCategories {
+		if p, ok := catalog.byID[pc.ProductID]; ok {
+			p.Categories = append(p.Categories, pc)
+		}
+	}
+	for _, opt := range options {
+		if p, ok := catalog.byID[opt.ProductID]; ok {
+			p.Options = append(p.Options, opt)
+		}
+	}
+	for _, c := range categories {
+		catalog.categories[strings.ToLower(c.Name)] = c
+	}
+	return catalog, nil
+}
+
+// planImport matches every row to the catalog and works out what it changes. Row problems go into the
+// row's result; only a failing query is returned as an error.
+func (s *PrdService) planImport(ctx context.Context, q products_repo.Querier, rows []importRow) ([]*importAction, error) {
+	catalog, err := loadImportCatalog(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	skuLines := make(map[string]int)
+	productLines := make(map[uuid.UUID]int)
+	actions := make([]*importAction, 0, len(rows))
+	for _, row := range rows {
+		action := &importAction{row: row}
+		res := &action.result
+		res.Line = row.Line
+		res.SKU = row.SKU
+		res.Errors = append(res.Errors, row.Errors...)
+		if row.Name != nil {
+			res.Name = *row.Name
+		}
+
+		switch {
+		case row.ID != nil:
+			action.product = catalog.byID[*row.ID]
+			if action.product == nil {
+				res.Errors = append(res.Errors, "no active product has this id")
+			}
+		case row.SKU != nil:
+			action.product = catalog.bySKU[strings.ToLower(*row.SKU)]
+		}
+		p := action.product
+		if p != nil {
+			res.ProductID = &p.ID
+			if res.Name == "" {
+				res.Name = p.Name
+			}
+			if line, dup := productLines[p.ID]; dup {
+				res.Errors = append(res.Errors, fmt.Sprintf("the product is also on line %d", line))
+			}
+			productLines[p.ID] = row.Line
+		}
+
+		if row.SKU != nil {
+			key := strings.ToLower(*row.SKU)
+			if line, dup := skuLines[key]; dup {
+				res.Errors = append(res.Errors, fmt.Sprintf("sku is also on line %d", line))
+			}
+			skuLines[key] = row.Line
+
+			if p == nil || p.Sku == nil || *p.Sku != *row.SKU {
+				excludeID := uuid.Nil
+				if p != nil {
+					excludeID = p.ID
+				}
+				if _, err := checkProductSKU(ctx, q, excludeID, *row.SKU); err != nil {
+					if !errors.Is(err, common.ErrSKUExists) {
+						return nil, err
+					}
+					res.Errors = append(res.Errors, err.Error())
+				}
+				action.sku = row.SKU
+			}
+		}
+
+		if row.Categories != nil {
+			ids := make([]int32, 0, len(*row.Categories))
+			for _, name := range *row.Categories {
+				category, ok := catalog.categories[strings.ToLower(name)]
+				if !ok {
+					res.Errors = append(res.Errors, fmt.Sprintf("category %q does not exist", name))
+					continue
+				}
+				ids = append(ids, category.ID)
+			}
+			action.categoryIDs = &ids
+		}
+
+		switch {
+		case p != nil:
+			s.planImportUpdate(ctx, action)
+		case row.ID == nil:
+			planImportCreate(action)
+		}
+
+		switch {
+		case len(res.Errors) > 0:
+			res.Action = ImportActionError
+			res.Changes = nil
+		case p == nil:
+			res.Action = ImportActionCreate
+		case len(res.Changes) == 0:
+			res.Action = ImportActionSkip
+		default:
+			res.Action = ImportActionUpdate
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+// planImportCreate lists the fields of a new product.
+func planImportCreate(action *importAction) {
+	row, res := action.row, &action.result
+	if row.Name == nil {
+		res.Errors = append(res.Errors, "name is required to create a product")
+	}
+	if row.Price == nil {
+		res.Errors = append(res.Errors, "price is required to create a product")
+	}
+
+	action.name = row.Name
+	action.price = row.Price
+	action.costPrice = row.CostPrice
+	action.stock = row.Stock
+	action.imageURL = row.ImageURL
+	if row.Options != nil {
+		action.newOptions = *row.Options
+	}
+
+	add := func(field, to string) {
+		res.Changes = append(res.Changes, ImportFieldChange{Field: field, To: to})
+	}
+	if row.Name != nil {
+		add(catalogColumnName, *row.Name)
+	}
+	if row.SKU != nil {
+		add(catalogColumnSKU, *row.SKU)
+	}
+	if row.Categories != nil {
+		add(importChangeCategory, formatImportCategories(*row.Categories))
+	}
+	if row.Price != nil {
+		add(catalogColumnPrice, strconv.FormatInt(*row.Price, 10))
+	}
+	if row.CostPrice != nil {
+		add(importChangeCostPrice, formatImportAmount(*row.CostPrice))
+	}
+	if row.Stock != nil {
+		add(importChangeStock, strconv.Itoa(int(*row.Stock)))
+	}
+	if row.Options != nil {
+		add(importChangeOptions, formatImportOptions(*row.Options))
+	}
+	if row.ImageURL != nil {
+		add(importChangeImage, *row.ImageURL)
+	}
+}
+
+// planImportUpdate compares a row with the product it matched and keeps only the fields that change.
+// Options listed are added or repriced; options left out are kept.
+func (s *PrdService) planImportUpdate(ctx context.Context, action *importAction) {
+	row, res, p := action.row, &action.result, action.product
+	change := func(field, from, to string) {
+		res.Changes = append(res.Changes, ImportFieldChange{Field: field, From: from, To: to})
+	}
+
+	if row.Name != nil && *row.Name != p.Name {
+		action.name = row.Name
+		change(catalogColumnName, p.Name, *row.Name)
+	}
+	if action.sku != nil {
+		from := ""
+		if p.Sku != nil {
+			from = *p.Sku
+		}
+		change(catalogColumnSKU, from, *action.sku)
+	}
+	if action.categoryIDs != nil && !sameCategories(p.Categories, *action.categoryIDs) {
+		change(importChangeCategory, formatImportCategories(p.categoryNames()), formatImportCategories(*row.Categories))
+	} else {
+		action.categoryIDs = nil
+	}
+	if row.Price != nil && *row.Price != p.Price {
+		action.price = row.Price
+		change(catalogColumnPrice, strconv.FormatInt(p.Price, 10), strconv.FormatInt(*row.Price, 10))
+	}
+	if row.CostPrice != nil {
+		current := utils.NumericToFloat64(p.CostPrice)
+		if math.Abs(*row.CostPrice-current) >= 0.005 {
+			action.costPrice = row.CostPrice
+			change(importChangeCostPrice, formatImportAmount(current), formatImportAmount(*row.CostPrice))
+		}
+	}
+	if row.Stock != nil && *row.Stock != p.Stock {
+		if p.HasVariants {
+			res.Errors = append(res.Errors, common.ErrProductHasVariants.Error())
+		} else {
+			action.stock = row.Stock
+			change(importChangeStock, strconv.Itoa(int(p.Stock)), strconv.Itoa(int(*row.Stock)))
+		}
+	}
+
+	if row.Options != nil {
+		existing := make(map[string]products_repo.ProductOption, len(p.Options))
+		for _, opt := range p.Options {
+			existing[strings.ToLower(opt.Name)] = opt
+		}
+		var changed []importOption
+		for _, opt := range *row.Options {
+			current, ok := existing[strings.ToLower(opt.Name)]
+			switch {
+			case !ok:
+				action.newOptions = append(action.newOptions, opt)
+			case current.AdditionalPrice != opt.Price:
+				action.optionPrices = append(action.optionPrices, importOptionPrice{ID: current.ID, Price: opt.Price})
+			default:
+				continue
+			}
+			changed = append(changed, opt)
+		}
+		if len(changed) > 0 {
+			change(importChangeOptions, formatImportOptions(p.importOptions()), formatImportOptions(changed))
+		}
+	}
+
+	if row.ImageURL != nil && !s.isCurrentImage(ctx, p, *row.ImageURL) {
+		action.imageURL = row.ImageURL
+		from := ""
+		if p.ImageUrl != nil {
+			from = *p.ImageUrl
+		}
+		change(importChangeImage, from, *row.ImageURL)
+	}
+}
+
+// isCurrentImage tells whether an image link points at the image the product already has, as it does when
+// an exported file is imported again. Signed links differ in their query only.
+func (s *PrdService) isCurrentImage(ctx context.Context, p *catalogProduct, imageURL string) bool {
+	if p.ImageUrl == nil || *p.ImageUrl == "" {
+		return false
+	}
+	link, err := s.prdRepo.PrdImageLink(ctx, p.ID.String(), *p.ImageUrl)
+	if err != nil || link == "" {
+		return false
+	}
+	stripQuery := func(u string) string {
+		if i := strings.IndexAny(u, "?#"); i >= 0 {
+			return u[:i]
+		}
+		return u
+	}
+	return stripQuery(link) == stripQuery(imageURL)
+}
+
+func applyImportCreate(ctx context.Context, qtx *products_repo.Queries, qCost *costing_repo.Queries, action *importAction, createdBy pgtype.UUID) error {
+	var cost float64
+	if action.costPrice != nil {
+		cost = *action.costPrice
+	}
+	numericCost := pgtype.Numeric{}
+	numericCost.Scan(fmt.Sprintf("%f", cost))
+
+	params := products_repo.CreateProductParams{
+		Name:      *action.name,
+		Price:     *action.price,
+		CostPrice: numericCost,
+		Sku:       action.sku,
+	}
+	if action.stock != nil {
+		params.Stock = *action.stock
+	}
+	product, err := qtx.CreateProduct(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to create product: %w", err)
+	}
+	action.result.ProductID = &product.ID
+
+	if err := costing.Receive(ctx, qCost, product.ID, 0, product.Stock, numericCost, costing_repo.CostLayerSourceOpening, pgtype.UUID{}); err != nil {
+		return fmt.Errorf("failed to open cost layer: %w", err)
+	}
+	if product.Stock > 0 {
+		_, err := qtx.CreateStockHistory(ctx, products_repo.CreateStockHistoryParams{
+			ProductID:     product.ID,
+			ChangeAmount:  product.Stock,
+			PreviousStock: 0,
+			CurrentStock:  product.Stock,
+			ChangeType:    products_repo.StockChangeTypeRestock,
+			Note:          utils.StringPtr(importStockNote),
+			CreatedBy:     createdBy,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record stock history: %w", err)
+		}
+	}
+
+	if action.categoryIDs != nil {
+		if err := assignImportCategories(ctx, qtx, product.ID, *action.categoryIDs); err != nil {
+			return err
+		}
+	}
+	return createImportOptions(ctx, qtx, product.ID, action.newOptions)
+}
+
+func applyImportUpdate(ctx context.Context, qtx *products_repo.Queries, qCost *costing_repo.Queries, action *importAction, createdBy pgtype.UUID) error {
+	p := action.product
+
+	params := products_repo.UpdateProductParams{
+		ID:    p.ID,
+		Name:  action.name,
+		Price: action.price,
+		Sku:   action.sku,
+	}
+	cost := p.CostPrice
+	if action.costPrice != nil {
+		cost = pgtype.Numeric{}
+		cost.Scan(fmt.Sprintf("%f", *action.costPrice))
+		params.CostPrice = cost
+	}
+
+	// Stock is set as counted in the file, against what the product holds now rather than when it was read
+	var previousStock int32
+	if action.stock != nil {
+		locked, err := qtx.GetProductsForUpdate(ctx, []uuid.UUID{p.ID})
+		if err != nil || len(locked) == 0 {
+			return fmt.Errorf("failed to lock product: %w", err)
+		}
+		previousStock = locked[0].Stock
+		params.Stock = action.stock
+	}
+
+	if _, err := qtx.UpdateProduct(ctx, params); err != nil {
+		return fmt.Errorf("failed to update product: %w", err)
+	}
+
+	if action.stock != nil && *action.stock != previousStock {
+		changeAmount := *action.stock - previousStock
+		changeType := products_repo.StockChangeTypeCorrection
+		if changeAmount > 0 {
+			changeType = products_repo.StockChangeTypeRestock
+		}
+		_, err := qtx.CreateStockHistory(ctx, products_repo.CreateStockHistoryParams{
+			ProductID:     p.ID,
+			ChangeAmount:  changeAmount,
+			PreviousStock: previousStock,
+			CurrentStock:  *action.stock,
+			ChangeType:    changeType,
+			Note:          utils.StringPtr(importStockNote),
+			CreatedBy:     createdBy,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record stock history: %w", err)
+		}
+
+		if changeAmount > 0 {
+			err = costing.Receive(ctx, qCost, p.ID, previousStock, changeAmount, cost, costing_repo.CostLayerSourceAdjustment, pgtype.UUID{})
+		} else {
+			_, err = costing.Consume(ctx, qCost, p.ID, -changeAmount, pgtype.UUID{}, cost)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to adjust cost layers: %w", err)
+		}
+	}
+
+	if action.categoryIDs != nil {
+		if err := qtx.ClearProductCategories(ctx, p.ID); err != nil {
+			return fmt.Errorf("failed to clear categories: %w", err)
+		}
+		if err := assignImportCategories(ctx, qtx, p.ID, *action.categoryIDs); err != nil {
+			return err
+		}
+	}
+
+	for _, opt := range action.optionPrices {
+		price := opt.Price
+		if _, err := qtx.UpdateProductOption(ctx, products_repo.UpdateProductOptionParams{ID: opt.ID, AdditionalPrice: &price}); err != nil {
+			return fmt.Errorf("failed to update option: %w", err)
+		}
+	}
+	return createImportOptions(ctx, qtx, p.ID, action.newOptions)
+}
+
+func assignImportCategories(ctx context.Context, qtx *products_repo.Queries, productID uuid.UUID, categoryIDs []int32) error {
+	for _, catID := range categoryIDs {
+		err := qtx.AssignProductCategory(ctx, products_repo.AssignProductCategoryParams{
+			ProductID:  productID,
+			CategoryID: catID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to assign category: %w", err)
+		}
+	}
+	return nil
+}
+
+func createImportOptions(ctx context.Context, qtx *products_repo.Queries, productID uuid.UUID, options []importOption) error {
+	for _, opt := range options {
+		_, err := qtx.CreateProductOption(ctx, products_repo.CreateProductOptionParams{
+			ProductID:       productID,
+			Name:            opt.Name,
+			AdditionalPrice: opt.Price,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create option %q: %w", opt.Name, err)
+		}
+	}
+	return nil
+}
+
+// importImages downloads the images of an applied import into storage, a few at a time. A failed image is
+// left as a warning on its row.
+func (s *PrdService) importImages(ctx context.Context, actions []*importAction) {
+	jobs := make(chan *importAction)
+	var wg sync.WaitGroup
+	for i := 0; i < importImageWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for action := range jobs {
+				if err := s.importImage(ctx, *action.result.ProductID, *action.imageURL); err != nil {
+					s.log.Warnf("Failed to import product image", "error", err, "productID", action.result.ProductID)
+					action.result.Warnings = append(action.result.Warnings, fmt.Sprintf("image could not be imported: %v", err))
+				}
+			}
+		}()
+	}
+	for _, action := range actions {
+		if action.imageURL != nil && action.result.ProductID != nil {
+			jobs <- action
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func (s *PrdService) importImage(ctx context.Context, productID uuid.UUID, imageURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return err
+	}
+	if err := checkImportImageURL(req.URL); err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", importImageUserAgent)
+
+	resp, err := importImageClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImportImageSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxImportImageSize {
+		return fmt.Errorf("image is larger than 5MB")
+	}
+
+	filename := fmt.Sprintf("products/%s.jpg", productID.String())
+	if _, err := s.prdRepo.UploadImage(ctx, filename, data); err != nil {
+		return fmt.Errorf("could not upload image to storage")
+	}
+	if _, err := s.repo.UpdateProduct(ctx, products_repo.UpdateProductParams{ID: productID, ImageUrl: &filename}); err != nil {
+		return fmt.Errorf("could not update product in database")
+	}
+	return nil
+}
+
+func importResponse(actions []*importAction, dryRun bool) *ImportProductsResponse {
+	resp := &ImportProductsResponse{
+		DryRun: dryRun,
+		Rows:   make([]ImportRowResult, 0, len(actions)),
+	}
+	for _, action := range actions {
+		resp.Rows = append(resp.Rows, action.result)
+		resp.Summary.Total++
+		switch action.result.Action {
+		case ImportActionCreate:
+			resp.Summary.Create++
+		case ImportActionUpdate:
+			resp.Summary.Update++
+		case ImportActionSkip:
+			resp.Summary.Skip++
+		case ImportActionError:
+			resp.Summary.Error++
+		}
+	}
+	return resp
+}
+
+func sameCategories(current []products_repo.ListCatalogProductCategoriesRow, ids []int32) bool {
+	want := make(map[int32]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	if len(want) != len(current) {
+		return false
+	}
+	for _, c := range current {
+		if !want[c.CategoryID] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *catalogProduct) categoryNames() []string {
+	names := make([]string, 0, len(p.Categories))
+	for _, c := range p.Categories {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func (p *catalogProduct) importOptions() []importOption {
+	options := make([]importOption, 0, len(p.Options))
+	for _, opt := range p.Options {
+		options = append(options, importOption{Name: opt.Name, Price: opt.AdditionalPrice})
+	}
+	return options
+}
+
+func formatImportCategories(names []string) string {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, catalogListSeparator)
+}
+
+func formatImportOptions(options []importOption) string {
+	parts := make([]string, 0, len(options))
+	for _, opt := range options {
+		parts = append(parts, fmt.Sprintf("%s:%d", opt.Name, opt.Price))
+	}
+	return strings.Join(parts, catalogListSeparator)
+}
+
+func formatImportAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
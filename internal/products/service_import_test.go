@@ -0,0 +1,336 @@
+package products_test
+
+import (
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	costing_repo "POS-kasir/internal/costing/repository"
+	"POS-kasir/internal/products"
+	products_repo "POS-kasir/internal/products/repository"
+	"POS-kasir/mocks"
+	"POS-kasir/pkg/utils"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var catalogProductColumns = []string{"id", "name", "sku", "price", "cost_price", "stock", "image_url", "has_variants"}
+
+var productColumns = []string{
+	"id", "name", "image_url", "price", "stock", "created_at", "updated_at", "deleted_at", "cost_price", "sku",
+}
+
+var stockHistoryColumns = []string{
+	"id", "product_id", "change_amount", "previous_stock", "current_stock",
+	"change_type", "reference_id", "note", "created_by", "created_at", "variant_id",
+}
+
+type importMocks struct {
+	store    *mocks.MockStore
+	repo     *mocks.MockProductQuerier
+	images   *mocks.MockIProductImageRepository
+	activity *mocks.MockIActivityService
+}
+
+func setupImportTest(t *testing.T) (importMocks, products.IPrdService) {
+	ctrl := gomock.NewController(t)
+	m := importMocks{
+		store:    mocks.NewMockStore(ctrl),
+		repo:     mocks.NewMockProductQuerier(ctrl),
+		images:   mocks.NewMockIProductImageRepository(ctrl),
+		activity: mocks.NewMockIActivityService(ctrl),
+	}
+	mockLogger := mocks.NewMockILogger(ctrl)
+	mockLogger.EXPECT().Warnf(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	service := products.NewPrdService(m.store, m.repo, mockLogger, m.images, m.activity)
+	return m, service
+}
+
+// importCatalog is the catalog the import tests run against: a coffee with a SKU, a category, an option and
+// an image, and a snack without a SKU.
+type importCatalog struct {
+	kopiID, rotiID uuid.UUID
+	products       []products_repo.ListCatalogProductsRow
+	categories     []products_repo.ListCatalogProductCategoriesRow
+	options        []products_repo.ProductOption
+	allCategories  []products_repo.Category
+}
+
+func newImportCatalog(t *testing.T) importCatalog {
+	cost, err := utils.Float64ToNumeric(12000)
+	assert.NoError(t, err)
+	c := importCatalog{kopiID: uuid.New(), rotiID: uuid.New()}
+	c.products = []products_repo.ListCatalogProductsRow{
+		{ID: c.kopiID, Name: "Kopi Susu", Sku: utils.StringPtr("KOP-01"), Price: 25000, CostPrice: cost, Stock: 10, ImageUrl: utils.StringPtr("products/kopi.jpg")},
+		{ID: c.rotiID, Name: "Roti Bakar", Price: 15000, CostPrice: cost, Stock: 0, HasVariants: true},
+	}
+	c.categories = []products_repo.ListCatalogProductCategoriesRow{{ProductID: c.kopiID, CategoryID: 1, Name: "Coffee"}}
+	c.options = []products_repo.ProductOption{{ID: uuid.New(), ProductID: c.kopiID, Name: "Large", AdditionalPrice: 5000}}
+	c.allCategories = []products_repo.Category{{ID: 1, Name: "Coffee"}, {ID: 2, Name: "Drinks"}}
+	return c
+}
+
+func (c importCatalog) expectRepo(ctx context.Context, repo *mocks.MockProductQuerier) {
+	repo.EXPECT().ListCatalogProducts(ctx).Return(c.products, nil)
+	repo.EXPECT().ListCatalogProductCategories(ctx).Return(c.categories, nil)
+	repo.EXPECT().ListCatalogOptions(ctx).Return(c.options, nil)
+	repo.EXPECT().ListCategories(ctx).Return(c.allCategories, nil)
+}
+
+func (c importCatalog) expectTx(mockPgx pgxmock.PgxPoolIface) {
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	productRows := pgxmock.NewRows(catalogProductColumns)
+	for _, p := range c.products {
+		productRows.AddRow(p.ID, p.Name, p.Sku, p.Price, p.CostPrice, p.Stock, p.ImageUrl, p.HasVariants)
+	}
+	categoryRows := pgxmock.NewRows([]string{"product_id", "category_id", "name"})
+	for _, pc := range c.categories {
+		categoryRows.AddRow(pc.ProductID, pc.CategoryID, pc.Name)
+	}
+	optionRows := pgxmock.NewRows([]string{
+		"id", "product_id", "name", "additional_price", "image_url", "created_at", "updated_at", "deleted_at", "modifier_group_id", "is_default",
+	})
+	for _, o := range c.options {
+		optionRows.AddRow(o.ID, o.ProductID, o.Name, o.AdditionalPrice, o.ImageUrl, now, now, pgtype.Timestamptz{}, pgtype.UUID{}, false)
+	}
+	allCategoryRows := pgxmock.NewRows([]string{"id", "name", "created_at", "updated_at"})
+	for _, cat := range c.allCategories {
+		allCategoryRows.AddRow(cat.ID, cat.Name, now, now)
+	}
+
+	mockPgx.ExpectQuery("FROM products p").WillReturnRows(productRows)
+	mockPgx.ExpectQuery("FROM product_categories pc").WillReturnRows(categoryRows)
+	mockPgx.ExpectQuery("FROM product_options po").WillReturnRows(optionRows)
+	mockPgx.ExpectQuery("FROM categories").WillReturnRows(allCategoryRows)
+}
+
+func TestPrdService_ImportProducts(t *testing.T) {
+	t.Run("DryRunDiff", func(t *testing.T) {
+		m, service := setupImportTest(t)
+		ctx := context.Background()
+		catalog := newImportCatalog(t)
+
+		catalog.expectRepo(ctx, m.repo)
+		m.repo.EXPECT().ProductSKUExists(ctx, products_repo.ProductSKUExistsParams{Sku: "TEH-01", ExcludeID: uuid.Nil}).Return(false, nil)
+
+		file := strings.Join([]string{
+			"id,sku,name,categories,price",
+			",TEH-01,Es Teh,Drinks,8000",
+			",KOP-01,Kopi Susu,,27000",
+			catalog.rotiID.String() + ",,Roti Bakar,,15000",
+			",,Donat,Snacks,abc",
+		}, "\n")
+
+		resp, err := service.ImportProducts(ctx, "catalog.csv", []byte(file), true)
+
+		assert.NoError(t, err)
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, products.ImportSummary{Total: 4, Create: 1, Update: 1, Skip: 1, Error: 1}, resp.Summary)
+
+		assert.Equal(t, products.ImportActionCreate, resp.Rows[0].Action)
+		assert.Nil(t, resp.Rows[0].ProductID)
+		assert.Contains(t, resp.Rows[0].Changes, products.ImportFieldChange{Field: "categories", To: "Drinks"})
+
+		assert.Equal(t, products.ImportActionUpdate, resp.Rows[1].Action)
+		assert.Equal(t, catalog.kopiID, *resp.Rows[1].ProductID)
+		assert.Equal(t, []products.ImportFieldChange{{Field: "price", From: "25000", To: "27000"}}, resp.Rows[1].Changes)
+
+		assert.Equal(t, products.ImportActionSkip, resp.Rows[2].Action)
+		assert.Empty(t, resp.Rows[2].Changes)
+
+		assert.Equal(t, products.ImportActionError, resp.Rows[3].Action)
+		assert.Equal(t, 5, resp.Rows[3].Line)
+		assert.Contains(t, resp.Rows[3].Errors, "price must be a number above 0")
+		assert.Contains(t, resp.Rows[3].Errors, `category "Snacks" does not exist`)
+		assert.Empty(t, resp.Rows[3].Changes)
+	})
+
+	t.Run("RowWithErrorsWritesNothing", func(t *testing.T) {
+		m, service := setupImportTest(t)
+		ctx := context.Background()
+		catalog := newImportCatalog(t)
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		m.store.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+			return fn(mockPgx)
+		})
+		// Only the catalog is read: no product is written while another row has errors
+		catalog.expectTx(mockPgx)
+
+		file := strings.Join([]string{
+			"name,price,stock",
+			"Es Teh,8000,12",
+			"Donat,-1,5",
+		}, "\n")
+
+		resp, err := service.ImportProducts(ctx, "catalog.csv", []byte(file), false)
+
+		assert.ErrorIs(t, err, common.ErrImportHasErrors)
+		assert.False(t, resp.DryRun)
+		assert.Equal(t, products.ImportSummary{Total: 2, Create: 1, Error: 1}, resp.Summary)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("StockChangesAreRecorded", func(t *testing.T) {
+		m, service := setupImportTest(t)
+		userID := uuid.New()
+		ctx := context.WithValue(context.Background(), common.UserIDKey, userID)
+		createdBy := pgtype.UUID{Bytes: userID, Valid: true}
+		catalog := newImportCatalog(t)
+		now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		newID := uuid.New()
+		zeroCost, _ := utils.Float64ToNumeric(0)
+		unitCost := catalog.products[0].CostPrice
+		note := utils.StringPtr("Bulk import")
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		m.store.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+			return fn(mockPgx)
+		})
+		catalog.expectTx(mockPgx)
+		mockPgx.ExpectQuery("SELECT EXISTS").
+			WithArgs("TEH-01", uuid.Nil).
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+
+		// The new product opens with its stock as a restock
+		mockPgx.ExpectQuery("INSERT INTO products").
+			WithArgs("Es Teh", pgxmock.AnyArg(), int64(8000), int32(12), pgxmock.AnyArg(), utils.StringPtr("TEH-01")).
+			WillReturnRows(pgxmock.NewRows(productColumns).
+				AddRow(newID, "Es Teh", nil, int64(8000), int32(12), now, now, pgtype.Timestamptz{}, zeroCost, utils.StringPtr("TEH-01")))
+		mockPgx.ExpectExec("INSERT INTO cost_layers").
+			WithArgs(newID, costing_repo.CostLayerSourceOpening, pgtype.UUID{}, int32(12), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPgx.ExpectExec("UPDATE products SET cost_price").
+			WithArgs(int32(0), int32(12), pgxmock.AnyArg(), newID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPgx.ExpectQuery("INSERT INTO stock_history").
+			WithArgs(newID, int32(12), int32(0), int32(12), products_repo.StockChangeTypeRestock, pgtype.UUID{}, note, createdBy, pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(stockHistoryColumns).
+				AddRow(uuid.New(), newID, int32(12), int32(0), int32(12), products_repo.StockChangeTypeRestock, pgtype.UUID{}, note, createdBy, now, pgtype.UUID{}))
+		mockPgx.ExpectExec("INSERT INTO product_categories").
+			WithArgs(newID, int32(2)).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// The counted stock of 4 is set against the 8 on hand when the row is applied, not the 10 read earlier
+		mockPgx.ExpectQuery("WHERE id = ANY").
+			WithArgs([]uuid.UUID{catalog.kopiID}).
+			WillReturnRows(pgxmock.NewRows(productColumns).
+				AddRow(catalog.kopiID, "Kopi Susu", utils.StringPtr("products/kopi.jpg"), int64(25000), int32(8), now, now, pgtype.Timestamptz{}, unitCost, utils.StringPtr("KOP-01")))
+		mockPgx.ExpectQuery("UPDATE products").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), utils.Int32Ptr(4), pgxmock.AnyArg(), pgxmock.AnyArg(), catalog.kopiID).
+			WillReturnRows(pgxmock.NewRows(productColumns).
+				AddRow(catalog.kopiID, "Kopi Susu", utils.StringPtr("products/kopi.jpg"), int64(25000), int32(4), now, now, pgtype.Timestamptz{}, unitCost, utils.StringPtr("KOP-01")))
+		mockPgx.ExpectQuery("INSERT INTO stock_history").
+			WithArgs(catalog.kopiID, int32(-4), int32(8), int32(4), products_repo.StockChangeTypeCorrection, pgtype.UUID{}, note, createdBy, pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(stockHistoryColumns).
+				AddRow(uuid.New(), catalog.kopiID, int32(-4), int32(8), int32(4), products_repo.StockChangeTypeCorrection, pgtype.UUID{}, note, createdBy, now, pgtype.UUID{}))
+		mockPgx.ExpectQuery("INSERT INTO cost_layer_consumptions").
+			WithArgs(catalog.kopiID, int32(4), pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows([]string{"quantity", "unit_cost"}).AddRow(int32(4), unitCost))
+
+		m.activity.EXPECT().Log(ctx, userID, activitylog_repo.LogActionTypeCREATE, activitylog_repo.LogEntityTypePRODUCT, newID.String(), gomock.Any())
+		m.activity.EXPECT().Log(ctx, userID, activitylog_repo.LogActionTypeUPDATE, activitylog_repo.LogEntityTypePRODUCT, catalog.kopiID.String(), gomock.Any())
+
+		file := strings.Join([]string{
+			"id,sku,name,categories,price,stock",
+			",TEH-01,Es Teh,Drinks,8000,12",
+			catalog.kopiID.String() + ",,,,,4",
+		}, "\n")
+
+		resp, err := service.ImportProducts(ctx, "catalog.csv", []byte(file), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, products.ImportSummary{Total: 2, Create: 1, Update: 1}, resp.Summary)
+		assert.Equal(t, newID, *resp.Rows[0].ProductID)
+		assert.Equal(t, []products.ImportFieldChange{{Field: "stock", From: "10", To: "4"}}, resp.Rows[1].Changes)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("ImageFromPrivateAddressIsRefused", func(t *testing.T) {
+		m, service := setupImportTest(t)
+		ctx := context.Background()
+		catalog := newImportCatalog(t)
+		now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		newID := uuid.New()
+		zeroCost, _ := utils.Float64ToNumeric(0)
+
+		var requested atomic.Bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested.Store(true)
+		}))
+		defer server.Close()
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		m.store.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+			return fn(mockPgx)
+		})
+		catalog.expectTx(mockPgx)
+		mockPgx.ExpectQuery("INSERT INTO products").
+			WithArgs("Es Teh", pgxmock.AnyArg(), int64(8000), int32(0), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(productColumns).
+				AddRow(newID, "Es Teh", nil, int64(8000), int32(0), now, now, pgtype.Timestamptz{}, zeroCost, nil))
+		m.activity.EXPECT().Log(ctx, gomock.Any(), activitylog_repo.LogActionTypeCREATE, activitylog_repo.LogEntityTypePRODUCT, newID.String(), gomock.Any())
+
+		file := fmt.Sprintf("name,price,image_url\nEs Teh,8000,%s/teh.jpg\n", server.URL)
+
+		resp, err := service.ImportProducts(ctx, "catalog.csv", []byte(file), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, products.ImportActionCreate, resp.Rows[0].Action)
+		assert.Len(t, resp.Rows[0].Warnings, 1)
+		assert.Contains(t, resp.Rows[0].Warnings[0], "non-public address")
+		assert.False(t, requested.Load())
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+}
+
+func TestPrdService_ExportProducts(t *testing.T) {
+	t.Run("ReimportsAsAllSkips", func(t *testing.T) {
+		m, service := setupImportTest(t)
+		ctx := context.Background()
+		catalog := newImportCatalog(t)
+
+		catalog.expectRepo(ctx, m.repo)
+		m.images.EXPECT().PrdImageLink(ctx, catalog.kopiID.String(), "products/kopi.jpg").Return("https://cdn.example.com/products/kopi.jpg?sig=1", nil)
+
+		data, err := service.ExportProducts(ctx, products.CatalogFormatCSV)
+		assert.NoError(t, err)
+
+		// Signed links change between requests; the import still sees the image the product has
+		catalog.expectRepo(ctx, m.repo)
+		m.images.EXPECT().PrdImageLink(ctx, catalog.kopiID.String(), "products/kopi.jpg").Return("https://cdn.example.com/products/kopi.jpg?sig=2", nil)
+
+		resp, err := service.ImportProducts(ctx, "catalog.csv", data, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, products.ImportSummary{Total: 2, Skip: 2}, resp.Summary)
+		for _, row := range resp.Rows {
+			assert.Empty(t, row.Changes, "line %d", row.Line)
+			assert.Empty(t, row.Errors, "line %d", row.Line)
+		}
+	})
+}
@@ -0,0 +1,312 @@
+package products
+
+import (
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	products_repo "POS-kasir/internal/products/repository"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CreateModifierGroup creates a modifier group. Options of any product join it through their modifier_group_id.
+func (s *PrdService) CreateModifierGroup(ctx context.Context, req CreateModifierGroupRequest) (*ModifierGroupResponse, error) {
+	if !validSelectRange(req.MinSelect, req.MaxSelect) {
+		return nil, common.ErrModifierGroupInvalid
+	}
+
+	exists, err := s.repo.ModifierGroupNameExists(ctx, products_repo.ModifierGroupNameExistsParams{Name: req.Name, ExcludeID: uuid.Nil})
+	if err != nil {
+		s.log.Errorf("Failed to check modifier group name", "error", err)
+		return nil, err
+	}
+	if exists {
+		return nil, common.ErrModifierGroupExists
+	}
+
+	group, err := s.repo.CreateModifierGroup(ctx, products_repo.CreateModifierGroupParams{
+		Name:      req.Name,
+		MinSelect: req.MinSelect,
+		MaxSelect: req.MaxSelect,
+	})
+	if err != nil {
+		s.log.Errorf("Failed to create modifier group in repository", "error", err)
+		return nil, err
+	}
+
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeCREATE,
+		activitylog_repo.LogEntityTypeMODIFIERGROUP,
+		group.ID.String(),
+		map[string]interface{}{
+			"name":       group.Name,
+			"min_select": group.MinSelect,
+			"max_select": group.MaxSelect,
+		},
+	)
+
+	resp := modifierGroupResponse(group)
+	return &resp, nil
+}
+
+// ListModifierGroups lists the active modifier groups without their options.
+func (s *PrdService) ListModifierGroups(ctx context.Context) ([]ModifierGroupResponse, error) {
+	groups, err := s.repo.ListModifierGroups(ctx)
+	if err != nil {
+		s.log.Errorf("Failed to list modifier groups", "error", err)
+		return nil, err
+	}
+
+	resp := make([]ModifierGroupResponse, 0, len(groups))
+	for _, g := range groups {
+		resp = append(resp, modifierGroupResponse(g))
+	}
+	return resp, nil
+}
+
+// GetModifierGroup returns a modifier group with the options of every product that uses it.
+func (s *PrdService) GetModifierGroup(ctx context.Context, groupID uuid.UUID) (*ModifierGroupResponse, error) {
+	group, err := s.repo.GetModifierGroup(ctx, groupID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("Failed to get modifier group", "error", err, "groupID", groupID)
+		return nil, err
+	}
+
+	options, err := s.repo.ListModifierGroupOptions(ctx, pgtype.UUID{Bytes: groupID, Valid: true})
+	if err != nil {
+		s.log.Errorf("Failed to list modifier group options", "error", err, "groupID", groupID)
+		return nil, err
+	}
+
+	resp := modifierGroupResponse(group)
+	for _, o := range options {
+		resp.Options = append(resp.Options, ModifierGroupOptionResponse{
+			ID:              o.ID,
+			ProductID:       o.ProductID,
+			ProductName:     o.ProductName,
+			Name:            o.Name,
+			AdditionalPrice: float64(o.AdditionalPrice),
+			IsDefault:       o.IsDefault,
+		})
+	}
+	return &resp, nil
+}
+
+// UpdateModifierGroup changes a modifier group's name or selection rules. Lowering max_select below the
+// defaults a product already has in the group is refused.
+func (s *PrdService) UpdateModifierGroup(ctx context.Context, groupID uuid.UUID, req UpdateModifierGroupRequest) (*ModifierGroupResponse, error) {
+	var updated products_repo.ModifierGroup
+	err := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := products_repo.New(tx)
+
+		group, err := qtx.GetModifierGroup(ctx, groupID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return common.ErrNotFound
+			}
+			return fmt.Errorf("failed to get modifier group: %w", err)
+		}
+
+		minSelect := group.MinSelect
+		if req.MinSelect != nil {
+			minSelect = *req.MinSelect
+		}
+		maxSelect := group.MaxSelect
+		if req.ClearMaxSelect {
+			maxSelect = nil
+		} else if req.MaxSelect != nil {
+			maxSelect = req.MaxSelect
+		}
+		if !validSelectRange(minSelect, maxSelect) {
+			return common.ErrModifierGroupInvalid
+		}
+
+		if req.Name != nil {
+			exists, err := qtx.ModifierGroupNameExists(ctx, products_repo.ModifierGroupNameExistsParams{Name: *req.Name, ExcludeID: groupID})
+			if err != nil {
+				return fmt.Errorf("failed to check modifier group name: %w", err)
+			}
+			if exists {
+				return common.ErrModifierGroupExists
+			}
+		}
+
+		if maxSelect != nil {
+			defaults, err := qtx.MaxModifierGroupDefaults(ctx, pgtype.UUID{Bytes: groupID, Valid: true})
+			if err != nil {
+				return fmt.Errorf("failed to count modifier group defaults: %w", err)
+			}
+			if defaults > *maxSelect {
+				return common.ErrModifierTooManyDefaults
+			}
+		}
+
+		updated, err = qtx.UpdateModifierGroup(ctx, products_repo.UpdateModifierGroupParams{
+			Name:           req.Name,
+			MinSelect:      req.MinSelect,
+			ClearMaxSelect: req.ClearMaxSelect,
+			MaxSelect:      req.MaxSelect,
+			ID:             groupID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update modifier group: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if !isModifierGroupError(err) {
+			s.log.Errorf("Failed to update modifier group", "error", err, "groupID", groupID)
+		}
+		return nil, err
+	}
+
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeUPDATE,
+		activitylog_repo.LogEntityTypeMODIFIERGROUP,
+		groupID.String(),
+		map[string]interface{}{
+			"updated_fields": req,
+		},
+	)
+
+	resp := modifierGroupResponse(updated)
+	return &resp, nil
+}
+
+// DeleteModifierGroup removes a modifier group. Its options stay on their products but are no longer grouped.
+func (s *PrdService) DeleteModifierGroup(ctx context.Context, groupID uuid.UUID) error {
+	var name string
+	err := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := products_repo.New(tx)
+
+		group, err := qtx.GetModifierGroup(ctx, groupID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return common.ErrNotFound
+			}
+			return fmt.Errorf("failed to get modifier group: %w", err)
+		}
+		name = group.Name
+
+		if err := qtx.DetachModifierGroupOptions(ctx, pgtype.UUID{Bytes: groupID, Valid: true}); err != nil {
+			return fmt.Errorf("failed to detach modifier group options: %w", err)
+		}
+		return qtx.SoftDeleteModifierGroup(ctx, groupID)
+	})
+	if err != nil {
+		if !errors.Is(err, common.ErrNotFound) {
+			s.log.Errorf("Failed to delete modifier group", "error", err, "groupID", groupID)
+		}
+		return err
+	}
+
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeDELETE,
+		activitylog_repo.LogEntityTypeMODIFIERGROUP,
+		groupID.String(),
+		map[string]interface{}{
+			"deleted_group_name": name,
+		},
+	)
+	return nil
+}
+
+// checkOptionGroup makes sure an option can join a modifier group: the group must exist and the product's
+// defaults in it may not exceed max_select. excludeID is the option itself when it already exists.
+func checkOptionGroup(ctx context.Context, q products_repo.Querier, productID, excludeID uuid.UUID, groupID pgtype.UUID, isDefault bool) error {
+	if !groupID.Valid {
+		return nil
+	}
+
+	group, err := q.GetModifierGroup(ctx, groupID.Bytes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return common.ErrModifierGroupNotFound
+		}
+		return fmt.Errorf("failed to get modifier group: %w", err)
+	}
+	if !isDefault || group.MaxSelect == nil {
+		return nil
+	}
+
+	defaults, err := q.CountModifierGroupDefaults(ctx, products_repo.CountModifierGroupDefaultsParams{
+		ProductID:       productID,
+		ModifierGroupID: groupID,
+		ExcludeID:       excludeID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count modifier group defaults: %w", err)
+	}
+	if defaults+1 > int64(*group.MaxSelect) {
+		return common.ErrModifierTooManyDefaults
+	}
+	return nil
+}
+
+// loadProductModifierGroups returns the modifier groups used by a product's options.
+func (s *PrdService) loadProductModifierGroups(ctx context.Context, options []ProductOptionResponse) ([]ModifierGroupResponse, error) {
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, opt := range options {
+		if opt.ModifierGroupID != nil && !seen[*opt.ModifierGroupID] {
+			seen[*opt.ModifierGroupID] = true
+			ids = append(ids, *opt.ModifierGroupID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	groups, err := s.repo.GetModifierGroupsByIDs(ctx, ids)
+	if err != nil {
+		s.log.Errorf("Failed to get product modifier groups", "error", err)
+		return nil, err
+	}
+
+	resp := make([]ModifierGroupResponse, 0, len(groups))
+	for _, g := range groups {
+		resp = append(resp, modifierGroupResponse(g))
+	}
+	return resp, nil
+}
+
+func modifierGroupResponse(g products_repo.ModifierGroup) ModifierGroupResponse {
+	return ModifierGroupResponse{
+		ID:        g.ID,
+		Name:      g.Name,
+		MinSelect: g.MinSelect,
+		MaxSelect: g.MaxSelect,
+	}
+}
+
+// validSelectRange mirrors the modifier_groups check: an upper limit, when set, is at least 1 and not below
+// the minimum.
+func validSelectRange(minSelect int32, maxSelect *int32) bool {
+	if maxSelect == nil {
+		return true
+	}
+	return *maxSelect >= 1 && *maxSelect >= minSelect
+}
+
+func isModifierGroupError(err error) bool {
+	return errors.Is(err, common.ErrNotFound) ||
+		errors.Is(err, common.ErrModifierGroupInvalid) ||
+		errors.Is(err, common.ErrModifierGroupExists) ||
+		errors.Is(err, common.ErrModifierTooManyDefaults)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 func (s *PrdService) GetStockHistory(ctx context.Context, productID uuid.UUID, req ListStockHistoryRequest) (*PagedStockHistoryResponse, error) {
@@ -28,8 +29,13 @@ func (s *PrdService) GetStockHistory(ctx context.Context, productID uuid.UUID, r
 	limit := req.Limit
 	offset := (page - 1) * limit
 
+	var variantID pgtype.UUID
+	if req.VariantID != nil {
+		variantID = pgtype.UUID{Bytes: *req.VariantID, Valid: true}
+	}
 	params := products_repo.GetStockHistoryByProductWithPaginationParams{
 		ProductID: productID,
+		VariantID: variantID,
 		Limit:     int32(limit),
 		Offset:    int32(offset),
 	}
@@ -39,7 +45,10 @@ func (s *PrdService) GetStockHistory(ctx context.Context, productID uuid.UUID, r
 		return nil, fmt.Errorf("failed to fetch stock history: %w", err)
 	}
 
-	count, err := s.repo.CountStockHistoryByProduct(ctx, productID)
+	count, err := s.repo.CountStockHistoryByProduct(ctx, products_repo.CountStockHistoryByProductParams{
+		ProductID: productID,
+		VariantID: variantID,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to count stock history: %w", err)
 	}
@@ -49,6 +58,7 @@ func (s *PrdService) GetStockHistory(ctx context.Context, productID uuid.UUID, r
 		historyResponses = append(historyResponses, StockHistoryResponse{
 			ID:            h.ID,
 			ProductID:     h.ProductID,
+			VariantID:     utils.NullableUUIDToPointer(h.VariantID),
 			ChangeAmount:  h.ChangeAmount,
 			PreviousStock: h.PreviousStock,
 			CurrentStock:  h.CurrentStock,
@@ -0,0 +1,598 @@
+package products
+
+import (
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	products_repo "POS-kasir/internal/products/repository"
+	"POS-kasir/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxVariantsPerProduct caps the variant matrix so a typo in the values cannot create thousands of variants.
+const maxVariantsPerProduct = 100
+
+// Column sizes of product_variants.sku and product_variants.name.
+const (
+	maxVariantSKULength  = 64
+	maxVariantNameLength = 150
+)
+
+// GenerateVariants creates the variants of a product that are missing from its attribute matrix. Existing
+// variants are kept as they are; new ones start without stock.
+func (s *PrdService) GenerateVariants(ctx context.Context, productID uuid.UUID, req GenerateVariantsRequest) (*ProductVariantsResponse, error) {
+	attributes, err := normalizeVariantAttributes(req.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(variantCombinations(attributes)) > maxVariantsPerProduct {
+		return nil, common.ErrVariantInvalid
+	}
+
+	var price *int64
+	if req.Price != nil {
+		p := int64(*req.Price)
+		price = &p
+	}
+
+	var created []string
+	err = s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := products_repo.New(tx)
+
+		product, err := qtx.GetProductByID(ctx, productID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return common.ErrNotFound
+			}
+			return fmt.Errorf("failed to get product: %w", err)
+		}
+
+		hasRecipe, err := qtx.ProductHasRecipe(ctx, productID)
+		if err != nil {
+			return fmt.Errorf("failed to check product recipe: %w", err)
+		}
+		if hasRecipe {
+			return common.ErrProductMadeToOrder
+		}
+
+		existing, err := qtx.ListProductVariants(ctx, productID)
+		if err != nil {
+			return fmt.Errorf("failed to list product variants: %w", err)
+		}
+		if len(existing) == 0 && product.Stock > 0 {
+			return common.ErrProductHasStock
+		}
+
+		knownAttributes, err := qtx.ListVariantAttributes(ctx, productID)
+		if err != nil {
+			return fmt.Errorf("failed to list variant attributes: %w", err)
+		}
+		knownValues, err := qtx.ListVariantAttributeValues(ctx, productID)
+		if err != nil {
+			return fmt.Errorf("failed to list variant attribute values: %w", err)
+		}
+		links, err := qtx.ListProductVariantValues(ctx, productID)
+		if err != nil {
+			return fmt.Errorf("failed to list variant values: %w", err)
+		}
+		canonicalVariantNames(attributes, knownAttributes, knownValues)
+
+		existingKeys := make(map[string]bool, len(existing))
+		if len(existing) > 0 {
+			if !sameAttributeNames(usedVariantAttributes(knownAttributes, knownValues, links), attributes) {
+				return common.ErrVariantInvalid
+			}
+
+			byVariant := make(map[uuid.UUID][]uuid.UUID)
+			for _, link := range links {
+				byVariant[link.VariantID] = append(byVariant[link.VariantID], link.ValueID)
+			}
+			for _, ids := range byVariant {
+				existingKeys[combinationKey(ids)] = true
+			}
+		}
+
+		valueIDs := make([][]uuid.UUID, len(attributes))
+		for i, attr := range attributes {
+			row, err := qtx.UpsertVariantAttribute(ctx, products_repo.UpsertVariantAttributeParams{
+				ProductID: productID,
+				Name:      attr.Name,
+				Position:  int32(i),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to save variant attribute %s: %w", attr.Name, err)
+			}
+			for j, value := range attr.Values {
+				v, err := qtx.UpsertVariantAttributeValue(ctx, products_repo.UpsertVariantAttributeValueParams{
+					AttributeID: row.ID,
+					Value:       value,
+					Position:    int32(j),
+				})
+				if err != nil {
+					return fmt.Errorf("failed to save variant value %s: %w", value, err)
+				}
+				valueIDs[i] = append(valueIDs[i], v.ID)
+			}
+		}
+
+		costPrice := product.CostPrice
+		if req.CostPrice != nil {
+			costPrice, err = utils.Float64ToNumeric(*req.CostPrice)
+			if err != nil {
+				return fmt.Errorf("invalid cost price: %w", err)
+			}
+		}
+
+		for _, combination := range variantCombinations(attributes) {
+			ids := make([]uuid.UUID, len(combination))
+			names := make([]string, len(combination))
+			skuParts := []string{strings.TrimSpace(req.SKUPrefix)}
+			for i, j := range combination {
+				ids[i] = valueIDs[i][j]
+				names[i] = attributes[i].Values[j]
+				skuParts = append(skuParts, strings.Join(strings.Fields(names[i]), ""))
+			}
+			if existingKeys[combinationKey(ids)] {
+				continue
+			}
+
+			sku := strings.ToUpper(strings.Join(skuParts, "-"))
+			name := strings.Join(names, " / ")
+			if len(sku) > maxVariantSKULength || len(name) > maxVariantNameLength {
+				return common.ErrVariantInvalid
+			}
+
+			taken, err := qtx.VariantSKUExists(ctx, products_repo.VariantSKUExistsParams{Sku: sku, ExcludeID: uuid.Nil})
+			if err != nil {
+				return fmt.Errorf("failed to check variant SKU: %w", err)
+			}
+			if taken {
+				return common.ErrVariantSKUExists
+			}
+
+			variant, err := qtx.CreateProductVariant(ctx, products_repo.CreateProductVariantParams{
+				ProductID: productID,
+				Name:      name,
+				Sku:       sku,
+				Price:     price,
+				CostPrice: costPrice,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create variant %s: %w", name, err)
+			}
+			for _, id := range ids {
+				if err := qtx.CreateProductVariantValue(ctx, products_repo.CreateProductVariantValueParams{VariantID: variant.ID, ValueID: id}); err != nil {
+					return fmt.Errorf("failed to link variant %s to its values: %w", name, err)
+				}
+			}
+			created = append(created, name)
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, common.ErrNotFound) && !errors.Is(err, common.ErrProductMadeToOrder) &&
+			!errors.Is(err, common.ErrProductHasStock) && !errors.Is(err, common.ErrVariantInvalid) &&
+			!errors.Is(err, common.ErrVariantSKUExists) {
+			s.log.Errorf("Failed to generate product variants", "error", err, "productID", productID)
+		}
+		return nil, err
+	}
+
+	if len(created) > 0 {
+		actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+		s.activityService.Log(
+			ctx,
+			actorID,
+			activitylog_repo.LogActionTypeCREATE,
+			activitylog_repo.LogEntityTypePRODUCT,
+			productID.String(),
+			map[string]interface{}{
+				"product_id":       productID.String(),
+				"created_variants": created,
+			},
+		)
+	}
+
+	return s.ListVariants(ctx, productID)
+}
+
+func (s *PrdService) ListVariants(ctx context.Context, productID uuid.UUID) (*ProductVariantsResponse, error) {
+	product, err := s.repo.GetProductByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("Failed to get product for variants", "error", err, "productID", productID)
+		return nil, err
+	}
+
+	return s.loadVariants(ctx, productID, product.Price)
+}
+
+// UpdateVariant edits a variant's SKU, barcode, prices or stock. A stock change is recorded in stock_history
+// against the variant, as a restock or correction unless ChangeType says otherwise.
+func (s *PrdService) UpdateVariant(ctx context.Context, productID, variantID uuid.UUID, req UpdateVariantRequest) (*ProductVariantResponse, error) {
+	params := products_repo.UpdateProductVariantParams{
+		ID:         variantID,
+		ClearPrice: req.ClearPrice,
+		Stock:      req.Stock,
+	}
+	if req.SKU != nil {
+		sku := strings.TrimSpace(*req.SKU)
+		if sku == "" {
+			return nil, common.ErrVariantInvalid
+		}
+		params.Sku = &sku
+	}
+	if req.Barcode != nil {
+		barcode := strings.TrimSpace(*req.Barcode)
+		if barcode != "" {
+			code, _, err := parseBarcode(barcode, nil)
+			if err != nil {
+				return nil, err
+			}
+			barcode = code
+		}
+		params.Barcode = &barcode
+	}
+	if req.Price != nil && !req.ClearPrice {
+		price := int64(*req.Price)
+		params.Price = &price
+	}
+	if req.CostPrice != nil {
+		costPrice, err := utils.Float64ToNumeric(*req.CostPrice)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cost price: %w", err)
+		}
+		params.CostPrice = costPrice
+	}
+
+	var createdBy pgtype.UUID
+	actorID, ok := ctx.Value(common.UserIDKey).(uuid.UUID)
+	if ok {
+		createdBy = pgtype.UUID{Bytes: actorID, Valid: true}
+	}
+
+	err := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := products_repo.New(tx)
+
+		variant, err := qtx.GetProductVariantForUpdate(ctx, products_repo.GetProductVariantForUpdateParams{ID: variantID, ProductID: productID})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return common.ErrNotFound
+			}
+			return fmt.Errorf("failed to get variant: %w", err)
+		}
+
+		if params.Sku != nil {
+			taken, err := qtx.VariantSKUExists(ctx, products_repo.VariantSKUExistsParams{Sku: *params.Sku, ExcludeID: variantID})
+			if err != nil {
+				return fmt.Errorf("failed to check variant SKU: %w", err)
+			}
+			if taken {
+				return common.ErrVariantSKUExists
+			}
+		}
+		if params.Barcode != nil && *params.Barcode != "" {
+			taken, err := qtx.VariantBarcodeExists(ctx, products_repo.VariantBarcodeExistsParams{Barcode: params.Barcode, ExcludeID: variantID})
+			if err != nil {
+				return fmt.Errorf("failed to check variant barcode: %w", err)
+			}
+			if taken {
+				return common.ErrVariantSKUExists
+			}
+		}
+
+		updated, err := qtx.UpdateProductVariant(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to update variant: %w", err)
+		}
+
+		changeAmount := updated.Stock - variant.Stock
+		if changeAmount == 0 {
+			return nil
+		}
+		changeType := products_repo.StockChangeTypeCorrection
+		if req.ChangeType != nil {
+			changeType = products_repo.StockChangeType(*req.ChangeType)
+		} else if changeAmount > 0 {
+			changeType = products_repo.StockChangeTypeRestock
+		}
+		_, err = qtx.CreateStockHistory(ctx, products_repo.CreateStockHistoryParams{
+			ProductID:     productID,
+			ChangeAmount:  changeAmount,
+			PreviousStock: variant.Stock,
+			CurrentStock:  updated.Stock,
+			ChangeType:    changeType,
+			Note:          req.Note,
+			CreatedBy:     createdBy,
+			VariantID:     pgtype.UUID{Bytes: variantID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record variant stock history: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, common.ErrNotFound) && !errors.Is(err, common.ErrVariantSKUExists) {
+			s.log.Errorf("Failed to update product variant", "error", err, "variantID", variantID)
+		}
+		return nil, err
+	}
+
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeUPDATE,
+		activitylog_repo.LogEntityTypePRODUCT,
+		productID.String(),
+		map[string]interface{}{
+			"product_id":     productID.String(),
+			"variant_id":     variantID.String(),
+			"updated_fields": req,
+		},
+	)
+
+	variants, err := s.ListVariants(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range variants.Variants {
+		if variants.Variants[i].ID == variantID {
+			return &variants.Variants[i], nil
+		}
+	}
+	return nil, common.ErrNotFound
+}
+
+// DeleteVariant removes a variant that has no stock left. Past orders and stock history keep referring to it.
+func (s *PrdService) DeleteVariant(ctx context.Context, productID, variantID uuid.UUID) error {
+	var name string
+	err := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := products_repo.New(tx)
+
+		variant, err := qtx.GetProductVariantForUpdate(ctx, products_repo.GetProductVariantForUpdateParams{ID: variantID, ProductID: productID})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return common.ErrNotFound
+			}
+			return fmt.Errorf("failed to get variant: %w", err)
+		}
+		if variant.Stock > 0 {
+			return common.ErrVariantHasStock
+		}
+		name = variant.Name
+
+		return qtx.SoftDeleteProductVariant(ctx, variantID)
+	})
+	if err != nil {
+		if !errors.Is(err, common.ErrNotFound) && !errors.Is(err, common.ErrVariantHasStock) {
+			s.log.Errorf("Failed to delete product variant", "error", err, "variantID", variantID)
+		}
+		return err
+	}
+
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeDELETE,
+		activitylog_repo.LogEntityTypePRODUCT,
+		productID.String(),
+		map[string]interface{}{
+			"product_id":           productID.String(),
+			"deleted_variant_id":   variantID.String(),
+			"deleted_variant_name": name,
+		},
+	)
+	return nil
+}
+
+// loadVariants builds the variant matrix of a product. Attributes and values that no active variant uses are
+// left out, so removing every variant leaves the product free to take a new set of attributes.
+func (s *PrdService) loadVariants(ctx context.Context, productID uuid.UUID, productPrice int64) (*ProductVariantsResponse, error) {
+	variants, err := s.repo.ListProductVariants(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product variants: %w", err)
+	}
+	response := &ProductVariantsResponse{
+		Attributes: []VariantAttributeResponse{},
+		Variants:   []ProductVariantResponse{},
+	}
+	if len(variants) == 0 {
+		return response, nil
+	}
+
+	attributes, err := s.repo.ListVariantAttributes(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variant attributes: %w", err)
+	}
+	values, err := s.repo.ListVariantAttributeValues(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variant attribute values: %w", err)
+	}
+	links, err := s.repo.ListProductVariantValues(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variant values: %w", err)
+	}
+
+	attributeNames := make(map[uuid.UUID]string, len(attributes))
+	for _, a := range attributes {
+		attributeNames[a.ID] = a.Name
+	}
+	valueByID := make(map[uuid.UUID]products_repo.ProductVariantAttributeValue, len(values))
+	for _, v := range values {
+		valueByID[v.ID] = v
+	}
+
+	used := make(map[uuid.UUID]bool, len(links))
+	variantValues := make(map[uuid.UUID]map[string]string, len(variants))
+	for _, link := range links {
+		value, ok := valueByID[link.ValueID]
+		if !ok {
+			continue
+		}
+		used[value.ID] = true
+		if variantValues[link.VariantID] == nil {
+			variantValues[link.VariantID] = make(map[string]string)
+		}
+		variantValues[link.VariantID][attributeNames[value.AttributeID]] = value.Value
+	}
+
+	for _, a := range attributes {
+		attr := VariantAttributeResponse{ID: a.ID, Name: a.Name, Values: []string{}}
+		for _, v := range values {
+			if v.AttributeID == a.ID && used[v.ID] {
+				attr.Values = append(attr.Values, v.Value)
+			}
+		}
+		if len(attr.Values) > 0 {
+			response.Attributes = append(response.Attributes, attr)
+		}
+	}
+
+	for _, v := range variants {
+		item := ProductVariantResponse{
+			ID:         v.ID,
+			Name:       v.Name,
+			SKU:        v.Sku,
+			Barcode:    v.Barcode,
+			Price:      float64(productPrice),
+			CostPrice:  utils.NumericToFloat64(v.CostPrice),
+			Stock:      v.Stock,
+			Attributes: variantValues[v.ID],
+		}
+		if v.Price != nil {
+			override := float64(*v.Price)
+			item.Price = override
+			item.PriceOverride = &override
+		}
+		if item.Attributes == nil {
+			item.Attributes = map[string]string{}
+		}
+		response.Variants = append(response.Variants, item)
+	}
+	return response, nil
+}
+
+// usedVariantAttributes returns the names of the attributes that the product's active variants are made of.
+func usedVariantAttributes(attributes []products_repo.ProductVariantAttribute, values []products_repo.ProductVariantAttributeValue, links []products_repo.ListProductVariantValuesRow) []string {
+	usedValues := make(map[uuid.UUID]bool, len(links))
+	for _, link := range links {
+		usedValues[link.ValueID] = true
+	}
+	usedAttributes := make(map[uuid.UUID]bool)
+	for _, v := range values {
+		if usedValues[v.ID] {
+			usedAttributes[v.AttributeID] = true
+		}
+	}
+
+	var names []string
+	for _, a := range attributes {
+		if usedAttributes[a.ID] {
+			names = append(names, a.Name)
+		}
+	}
+	return names
+}
+
+// canonicalVariantNames spells requested attributes and values the way the product already has them, so
+// "size" and "Size" end up as the same attribute.
+func canonicalVariantNames(attributes []VariantAttributeRequest, known []products_repo.ProductVariantAttribute, knownValues []products_repo.ProductVariantAttributeValue) {
+	for i := range attributes {
+		for _, a := range known {
+			if !strings.EqualFold(a.Name, attributes[i].Name) {
+				continue
+			}
+			attributes[i].Name = a.Name
+			for j, value := range attributes[i].Values {
+				for _, v := range knownValues {
+					if v.AttributeID == a.ID && strings.EqualFold(v.Value, value) {
+						attributes[i].Values[j] = v.Value
+					}
+				}
+			}
+		}
+	}
+}
+
+// normalizeVariantAttributes trims names and values and rejects empty or duplicate ones (case-insensitive).
+func normalizeVariantAttributes(attributes []VariantAttributeRequest) ([]VariantAttributeRequest, error) {
+	if len(attributes) == 0 {
+		return nil, common.ErrVariantInvalid
+	}
+
+	seenNames := make(map[string]bool, len(attributes))
+	result := make([]VariantAttributeRequest, 0, len(attributes))
+	for _, attr := range attributes {
+		name := strings.TrimSpace(attr.Name)
+		if name == "" || seenNames[strings.ToLower(name)] || len(attr.Values) == 0 {
+			return nil, common.ErrVariantInvalid
+		}
+		seenNames[strings.ToLower(name)] = true
+
+		seenValues := make(map[string]bool, len(attr.Values))
+		values := make([]string, 0, len(attr.Values))
+		for _, value := range attr.Values {
+			value = strings.TrimSpace(value)
+			if value == "" || seenValues[strings.ToLower(value)] {
+				return nil, common.ErrVariantInvalid
+			}
+			seenValues[strings.ToLower(value)] = true
+			values = append(values, value)
+		}
+		result = append(result, VariantAttributeRequest{Name: name, Values: values})
+	}
+	return result, nil
+}
+
+func sameAttributeNames(existing []string, attributes []VariantAttributeRequest) bool {
+	if len(existing) != len(attributes) {
+		return false
+	}
+	names := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		names[strings.ToLower(name)] = true
+	}
+	for _, attr := range attributes {
+		if !names[strings.ToLower(attr.Name)] {
+			return false
+		}
+	}
+	return true
+}
+
+// variantCombinations lists every combination of attribute values as indexes into each attribute's values.
+func variantCombinations(attributes []VariantAttributeRequest) [][]int {
+	combinations := [][]int{{}}
+	for _, attr := range attributes {
+		next := make([][]int, 0, len(combinations)*len(attr.Values))
+		for _, combination := range variantCombinations(attributes) {
+			for j := range attr.Values {
+				c := make([]int, len(combination), len(combination)+1)
+				copy(c, combination)
+				next = append(next, append(c, j))
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+func combinationKey(valueIDs []uuid.UUID) string {
+	ids := make([]string, len(valueIDs))
+	for i, id := range valueIDs {
+		ids[i] = id.String()
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
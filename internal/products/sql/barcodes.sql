@@ -0,0 +1,92 @@
+-- name: CreateProductBarcode :one
+INSERT INTO product_barcodes (product_id, variant_id, code, symbology, is_generated)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListProductBarcodes :many
+-- Lists a product's barcodes, those of its variants included.
+SELECT * FROM product_barcodes
+WHERE product_id = $1
+ORDER BY created_at, code;
+
+-- name: GetProductBarcode :one
+SELECT * FROM product_barcodes
+WHERE id = $1 AND product_id = $2;
+
+-- name: DeleteProductBarcode :exec
+DELETE FROM product_barcodes
+WHERE id = $1;
+
+-- name: BarcodeExists :one
+-- Checks whether a barcode is taken, by a product_barcodes row or the barcode of an active variant.
+SELECT EXISTS(SELECT 1 FROM product_barcodes WHERE code = sqlc.arg(code))
+    OR EXISTS(SELECT 1 FROM product_variants WHERE barcode = sqlc.arg(code) AND deleted_at IS NULL);
+
+-- name: ProductSKUExists :one
+-- Checks whether an active product other than exclude_id, or an active variant, already uses the SKU (case-insensitive).
+SELECT EXISTS(
+    SELECT 1 FROM products
+    WHERE lower(sku) = lower(sqlc.arg(sku)) AND id <> sqlc.arg(exclude_id) AND deleted_at IS NULL
+) OR EXISTS(
+    SELECT 1 FROM product_variants
+    WHERE lower(sku) = lower(sqlc.arg(sku)) AND deleted_at IS NULL
+);
+
+-- name: NextInStoreBarcodeNumber :one
+-- Takes the next number for a generated in-store barcode.
+SELECT nextval('instore_barcode_seq')::bigint AS number;
+
+-- name: ListUnbarcodedItems :many
+-- Lists the active products without variants, and the active variants, that have no barcode yet.
+SELECT p.id AS product_id, NULL::uuid AS variant_id
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = p.id AND pv.deleted_at IS NULL)
+  AND NOT EXISTS (SELECT 1 FROM product_barcodes pb WHERE pb.product_id = p.id AND pb.variant_id IS NULL)
+UNION ALL
+SELECT pv.product_id, pv.id
+FROM product_variants pv
+JOIN products p ON p.id = pv.product_id AND p.deleted_at IS NULL
+WHERE pv.deleted_at IS NULL
+  AND pv.barcode IS NULL
+  AND NOT EXISTS (SELECT 1 FROM product_barcodes pb WHERE pb.variant_id = pv.id)
+ORDER BY product_id;
+
+-- name: LookupProductCode :one
+-- Finds the product, or variant, behind a scanned code. Barcodes win over SKUs; SKUs match case-insensitively.
+WITH matches AS (
+    SELECT pb.product_id, pb.variant_id, 'barcode' AS matched_by, 1 AS rank
+    FROM product_barcodes pb
+    WHERE pb.code = sqlc.arg(code)
+    UNION ALL
+    SELECT pv.product_id, pv.id, 'barcode', 1
+    FROM product_variants pv
+    WHERE pv.barcode = sqlc.arg(code) AND pv.deleted_at IS NULL
+    UNION ALL
+    SELECT pv.product_id, pv.id, 'sku', 2
+    FROM product_variants pv
+    WHERE lower(pv.sku) = lower(sqlc.arg(code)) AND pv.deleted_at IS NULL
+    UNION ALL
+    SELECT p.id, NULL::uuid, 'sku', 2
+    FROM products p
+    WHERE lower(p.sku) = lower(sqlc.arg(code)) AND p.deleted_at IS NULL
+)
+SELECT
+    m.product_id,
+    m.variant_id,
+    m.matched_by::text AS matched_by,
+    p.name,
+    p.sku,
+    p.price,
+    p.stock,
+    p.image_url,
+    pv.name AS variant_name,
+    pv.sku AS variant_sku,
+    pv.price AS variant_price,
+    pv.stock AS variant_stock
+FROM matches m
+JOIN products p ON p.id = m.product_id AND p.deleted_at IS NULL
+LEFT JOIN product_variants pv ON pv.id = m.variant_id AND pv.deleted_at IS NULL
+WHERE m.variant_id IS NULL OR pv.id IS NOT NULL
+ORDER BY m.rank
+LIMIT 1;
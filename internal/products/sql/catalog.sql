@@ -0,0 +1,38 @@
+-- name: ListCatalogProducts :many
+-- Lists every active product with the fields a catalog import or export works with.
+SELECT
+    p.id,
+    p.name,
+    p.sku,
+    p.price,
+    p.cost_price,
+    p.stock,
+    p.image_url,
+    EXISTS(
+        SELECT 1 FROM product_variants pv
+        WHERE pv.product_id = p.id AND pv.deleted_at IS NULL
+    ) AS has_variants
+FROM products p
+WHERE p.deleted_at IS NULL
+ORDER BY p.name, p.id;
+
+-- name: ListCatalogProductCategories :many
+-- Lists the categories of every active product.
+SELECT pc.product_id, c.id AS category_id, c.name
+FROM product_categories pc
+JOIN categories c ON c.id = pc.category_id
+JOIN products p ON p.id = pc.product_id AND p.deleted_at IS NULL
+ORDER BY pc.product_id, c.name;
+
+-- name: ListCatalogOptions :many
+-- Lists the active options of every active product.
+SELECT po.*
+FROM product_options po
+JOIN products p ON p.id = po.product_id AND p.deleted_at IS NULL
+WHERE po.deleted_at IS NULL
+ORDER BY po.product_id, po.name;
+
+-- name: ListCategories :many
+-- Lists every category, e.g. to match category names in an import.
+SELECT * FROM categories
+ORDER BY name;
@@ -0,0 +1,70 @@
+-- name: CreateModifierGroup :one
+INSERT INTO modifier_groups (name, min_select, max_select)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetModifierGroup :one
+SELECT * FROM modifier_groups
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetModifierGroupsByIDs :many
+-- Retrieves the active modifier groups among the given IDs.
+SELECT * FROM modifier_groups
+WHERE id = ANY(sqlc.arg(ids)::uuid[]) AND deleted_at IS NULL
+ORDER BY name;
+
+-- name: ListModifierGroups :many
+-- Lists the active modifier groups.
+SELECT * FROM modifier_groups
+WHERE deleted_at IS NULL
+ORDER BY name;
+
+-- name: UpdateModifierGroup :one
+-- Updates a modifier group. clear_max_select removes the upper limit.
+UPDATE modifier_groups
+SET
+    name = COALESCE(sqlc.narg(name), name),
+    min_select = COALESCE(sqlc.narg(min_select), min_select),
+    max_select = CASE WHEN sqlc.arg(clear_max_select)::bool THEN NULL ELSE COALESCE(sqlc.narg(max_select), max_select) END
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL
+RETURNING *;
+
+-- name: SoftDeleteModifierGroup :exec
+UPDATE modifier_groups
+SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: DetachModifierGroupOptions :exec
+-- Takes every option out of a modifier group, so they can be picked freely again.
+UPDATE product_options
+SET modifier_group_id = NULL, is_default = false
+WHERE modifier_group_id = $1;
+
+-- name: ModifierGroupNameExists :one
+-- Checks whether an active modifier group other than exclude_id already uses the name (case-insensitive).
+SELECT EXISTS(
+    SELECT 1 FROM modifier_groups
+    WHERE lower(name) = lower(sqlc.arg(name)) AND id <> sqlc.arg(exclude_id) AND deleted_at IS NULL
+);
+
+-- name: ListModifierGroupOptions :many
+-- Lists the active options in a modifier group across all active products.
+SELECT po.id, po.product_id, p.name AS product_name, po.name, po.additional_price, po.is_default
+FROM product_options po
+JOIN products p ON p.id = po.product_id
+WHERE po.modifier_group_id = $1 AND po.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY p.name, po.name;
+
+-- name: CountModifierGroupDefaults :one
+-- Counts a product's default options in a modifier group, leaving out exclude_id.
+SELECT COUNT(*) FROM product_options
+WHERE product_id = sqlc.arg(product_id) AND modifier_group_id = sqlc.arg(modifier_group_id) AND is_default
+  AND id <> sqlc.arg(exclude_id) AND deleted_at IS NULL;
+
+-- name: MaxModifierGroupDefaults :one
+-- Returns the most default options any one product has in a modifier group.
+SELECT COALESCE(MAX(defaults), 0)::int AS max_defaults FROM (
+    SELECT COUNT(*) AS defaults FROM product_options
+    WHERE modifier_group_id = $1 AND is_default AND deleted_at IS NULL
+    GROUP BY product_id
+) d;
@@ -8,9 +8,10 @@ INSERT INTO products (
     image_url,
     price,
     stock,
-    cost_price
+    cost_price,
+    sku
 ) VALUES (
-             $1, $2, $3, $4, $5
+             $1, $2, $3, $4, $5, $6
          ) RETURNING *;
 
 -- name: GetProductWithOptions :one
@@ -34,7 +35,7 @@ LIMIT 1;
 
 
 -- name: ListProducts :many
--- Lists products with filtering and pagination.
+-- Lists products with filtering and pagination. The search text matches names, or a SKU or barcode exactly.
 -- Does not include variants for performance reasons on a list view.
 SELECT
     p.id,
@@ -42,6 +43,7 @@ SELECT
     p.price,
     p.stock,
     p.image_url,
+    p.sku,
     COALESCE(
         (SELECT json_agg(c.*) 
          FROM product_categories pc 
@@ -54,21 +56,25 @@ FROM
 WHERE
     (sqlc.narg(category_id)::int IS NULL OR EXISTS (SELECT 1 FROM product_categories pc WHERE pc.product_id = p.id AND pc.category_id = sqlc.narg(category_id)))
   AND
-    (sqlc.narg(search_text)::text IS NULL OR p.name ILIKE '%' || sqlc.narg(search_text) || '%')
+    (sqlc.narg(search_text)::text IS NULL OR p.name ILIKE '%' || sqlc.narg(search_text) || '%'
+        OR lower(p.sku) = lower(sqlc.narg(search_text))
+        OR EXISTS (SELECT 1 FROM product_barcodes pb WHERE pb.product_id = p.id AND pb.code = sqlc.narg(search_text))
+        OR EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = p.id AND pv.deleted_at IS NULL AND (lower(pv.sku) = lower(sqlc.narg(search_text)) OR pv.barcode = sqlc.narg(search_text))))
   AND p.deleted_at IS NULL
 ORDER BY
     p.name ASC
 LIMIT $1 OFFSET $2;
 
 -- name: UpdateProduct :one
--- Updates a product's details. Use COALESCE for optional fields.
+-- Updates a product's details. Use COALESCE for optional fields. An empty sku clears it.
 UPDATE products
 SET
     name = COALESCE(sqlc.narg(name), name),
     image_url = COALESCE(sqlc.narg(image_url), image_url),
     price = COALESCE(sqlc.narg(price), price),
     stock = COALESCE(sqlc.narg(stock), stock),
-    cost_price = COALESCE(sqlc.narg(cost_price), cost_price)
+    cost_price = COALESCE(sqlc.narg(cost_price), cost_price),
+    sku = NULLIF(COALESCE(sqlc.narg(sku), sku), '')
 WHERE
     id = sqlc.arg(id)
 RETURNING *;
@@ -84,7 +90,10 @@ SELECT count(*) FROM products p
 WHERE
     (sqlc.narg(category_id)::int IS NULL OR EXISTS (SELECT 1 FROM product_categories pc WHERE pc.product_id = p.id AND pc.category_id = sqlc.narg(category_id)))
   AND
-    (sqlc.narg(search_text)::text IS NULL OR p.name ILIKE '%' || sqlc.narg(search_text) || '%')
+    (sqlc.narg(search_text)::text IS NULL OR p.name ILIKE '%' || sqlc.narg(search_text) || '%'
+        OR lower(p.sku) = lower(sqlc.narg(search_text))
+        OR EXISTS (SELECT 1 FROM product_barcodes pb WHERE pb.product_id = p.id AND pb.code = sqlc.narg(search_text))
+        OR EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = p.id AND pv.deleted_at IS NULL AND (lower(pv.sku) = lower(sqlc.narg(search_text)) OR pv.barcode = sqlc.narg(search_text))))
   AND p.deleted_at IS NULL;
 
 
@@ -96,18 +105,22 @@ INSERT INTO product_options (
     product_id,
     name,
     additional_price,
-    image_url
+    image_url,
+    modifier_group_id,
+    is_default
 ) VALUES (
-             $1, $2, $3, $4
+             $1, $2, $3, $4, $5, $6
          ) RETURNING *;
 
 -- name: UpdateProductOption :one
--- Updates a specific product option.
+-- Updates a specific product option. clear_modifier_group takes it out of its modifier group.
 UPDATE product_options
 SET
     name = COALESCE(sqlc.narg(name), name),
     additional_price = COALESCE(sqlc.narg(additional_price), additional_price),
-    image_url = COALESCE(sqlc.narg(image_url), image_url)
+    image_url = COALESCE(sqlc.narg(image_url), image_url),
+    modifier_group_id = CASE WHEN sqlc.arg(clear_modifier_group)::bool THEN NULL ELSE COALESCE(sqlc.narg(modifier_group_id), modifier_group_id) END,
+    is_default = CASE WHEN sqlc.arg(clear_modifier_group)::bool THEN false ELSE COALESCE(sqlc.narg(is_default), is_default) END
 WHERE
     id = sqlc.arg(id)
 RETURNING *;
@@ -7,9 +7,10 @@ INSERT INTO stock_history (
     change_type,
     reference_id,
     note,
-    created_by
+    created_by,
+    variant_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING *;
 
 -- name: GetStockHistoryByProduct :many
@@ -19,10 +20,12 @@ ORDER BY created_at DESC;
 
 -- name: GetStockHistoryByProductWithPagination :many
 SELECT * FROM stock_history
-WHERE product_id = $1
+WHERE product_id = sqlc.arg(product_id)
+  AND (sqlc.narg(variant_id)::uuid IS NULL OR variant_id = sqlc.narg(variant_id)::uuid)
 ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: CountStockHistoryByProduct :one
 SELECT COUNT(*) FROM stock_history
-WHERE product_id = $1;
+WHERE product_id = sqlc.arg(product_id)
+  AND (sqlc.narg(variant_id)::uuid IS NULL OR variant_id = sqlc.narg(variant_id)::uuid);
@@ -0,0 +1,96 @@
+-- name: ListVariantAttributes :many
+-- Lists the attributes (e.g. size, colour) that make up a product's variant matrix.
+SELECT * FROM product_variant_attributes
+WHERE product_id = $1
+ORDER BY position, name;
+
+-- name: ListVariantAttributeValues :many
+-- Lists the values of every variant attribute of a product.
+SELECT v.* FROM product_variant_attribute_values v
+JOIN product_variant_attributes a ON a.id = v.attribute_id
+WHERE a.product_id = $1
+ORDER BY v.position, v.value;
+
+-- name: UpsertVariantAttribute :one
+INSERT INTO product_variant_attributes (product_id, name, position)
+VALUES ($1, $2, $3)
+ON CONFLICT (product_id, name) DO UPDATE SET position = EXCLUDED.position
+RETURNING *;
+
+-- name: UpsertVariantAttributeValue :one
+INSERT INTO product_variant_attribute_values (attribute_id, value, position)
+VALUES ($1, $2, $3)
+ON CONFLICT (attribute_id, value) DO UPDATE SET position = EXCLUDED.position
+RETURNING *;
+
+-- name: ListProductVariants :many
+-- Lists a product's active variants.
+SELECT * FROM product_variants
+WHERE product_id = $1 AND deleted_at IS NULL
+ORDER BY name;
+
+-- name: ListProductVariantValues :many
+-- Lists the attribute values behind each active variant of a product.
+SELECT pvv.variant_id, pvv.value_id
+FROM product_variant_values pvv
+JOIN product_variants pv ON pv.id = pvv.variant_id
+WHERE pv.product_id = $1 AND pv.deleted_at IS NULL;
+
+-- name: CreateProductVariant :one
+INSERT INTO product_variants (product_id, name, sku, barcode, price, cost_price)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: CreateProductVariantValue :exec
+INSERT INTO product_variant_values (variant_id, value_id)
+VALUES ($1, $2);
+
+-- name: GetProductVariantForUpdate :one
+SELECT * FROM product_variants
+WHERE id = $1 AND product_id = $2 AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: UpdateProductVariant :one
+-- Updates a variant. An empty barcode clears it and clear_price drops the price override.
+UPDATE product_variants
+SET
+    sku = COALESCE(sqlc.narg(sku), sku),
+    barcode = NULLIF(COALESCE(sqlc.narg(barcode), barcode), ''),
+    price = CASE WHEN sqlc.arg(clear_price)::bool THEN NULL ELSE COALESCE(sqlc.narg(price), price) END,
+    cost_price = COALESCE(sqlc.narg(cost_price), cost_price),
+    stock = COALESCE(sqlc.narg(stock), stock)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SoftDeleteProductVariant :exec
+UPDATE product_variants
+SET deleted_at = NOW()
+WHERE id = $1;
+
+-- name: VariantSKUExists :one
+-- Checks whether an active variant other than exclude_id, or an active product, already uses the SKU (case-insensitive).
+SELECT EXISTS(
+    SELECT 1 FROM product_variants
+    WHERE lower(sku) = lower(sqlc.arg(sku)) AND id <> sqlc.arg(exclude_id) AND deleted_at IS NULL
+) OR EXISTS(
+    SELECT 1 FROM products
+    WHERE lower(sku) = lower(sqlc.arg(sku)) AND deleted_at IS NULL
+);
+
+-- name: VariantBarcodeExists :one
+-- Checks whether an active variant other than exclude_id, or a product_barcodes row, already uses the barcode.
+SELECT EXISTS(
+    SELECT 1 FROM product_variants
+    WHERE barcode = sqlc.arg(barcode) AND id <> sqlc.arg(exclude_id) AND deleted_at IS NULL
+) OR EXISTS(
+    SELECT 1 FROM product_barcodes
+    WHERE code = sqlc.arg(barcode)
+);
+
+-- name: ProductHasRecipe :one
+SELECT EXISTS(SELECT 1 FROM product_recipe_items WHERE product_id = $1);
+
+-- name: GetVariantsByIDs :many
+-- Fetches variants by ID, removed ones included, e.g. to name the lines of past orders.
+SELECT * FROM product_variants
+WHERE id = ANY($1::uuid[]);
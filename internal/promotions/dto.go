@@ -10,9 +10,11 @@ import (
 )
 
 type CreatePromotionRuleRequest struct {
-	RuleType    repository.PromotionRuleType `json:"rule_type" validate:"required,oneof=MINIMUM_ORDER_AMOUNT REQUIRED_PRODUCT REQUIRED_CATEGORY ALLOWED_PAYMENT_METHOD ALLOWED_ORDER_TYPE"`
-	RuleValue   string                       `json:"rule_value" validate:"required"`
-	Description string                       `json:"description"`
+	RuleType repository.PromotionRuleType `json:"rule_type" validate:"required,oneof=MINIMUM_ORDER_AMOUNT REQUIRED_PRODUCT REQUIRED_CATEGORY ALLOWED_PAYMENT_METHOD ALLOWED_ORDER_TYPE"`
+	// RuleValue for ALLOWED_ORDER_TYPE is a comma separated list of order types (dine_in, takeaway);
+	// for ALLOWED_PAYMENT_METHOD a comma separated list of payment method IDs or names.
+	RuleValue   string `json:"rule_value" validate:"required"`
+	Description string `json:"description"`
 }
 
 type CreatePromotionTargetRequest struct {
@@ -20,32 +22,102 @@ type CreatePromotionTargetRequest struct {
 	TargetID   string                         `json:"target_id" validate:"required"`
 }
 
+// CreatePromotionTierRequest is one step of a tiered promotion, e.g. 10% off from 100000.
+type CreatePromotionTierRequest struct {
+	MinAmount     int64                   `json:"min_amount" validate:"gte=0"`
+	DiscountType  repository.DiscountType `json:"discount_type" validate:"required,oneof=percentage fixed_amount"`
+	DiscountValue int64                   `json:"discount_value" validate:"required,gt=0"`
+}
+
+// CreatePromotionScheduleRequest is a recurring window the promotion runs in, in the store's time zone. Days
+// are 0 (Sunday) to 6 (Saturday); an end time at or before the start time runs past midnight, e.g. 22:00-02:00.
+type CreatePromotionScheduleRequest struct {
+	DaysOfWeek []int  `json:"days_of_week" validate:"required,min=1,unique,dive,min=0,max=6"`
+	StartTime  string `json:"start_time" validate:"required,datetime=15:04" example:"15:00"`
+	EndTime    string `json:"end_time" validate:"required,datetime=15:04,nefield=StartTime" example:"17:00"`
+}
+
+// CreatePromotionRequest creates a promotion. What DiscountValue means depends on DiscountType:
+//   - percentage: percent off the eligible total
+//   - fixed_amount: amount off the eligible total
+//   - buy_x_get_y: percent off (100 = free) the GetQuantity cheapest units of every BuyQuantity+GetQuantity
+//     eligible units, e.g. buy 1 get 1 at 50 for "second cup half price"
+//   - bundle_price: the price of every BundleQuantity eligible units, e.g. any 3 pastries for 50000
+//   - tiered: unused, the highest tier the eligible total reaches sets the discount
+//
+// Eligible means the targets for ITEM scope and the whole order for ORDER scope.
 type CreatePromotionRequest struct {
 	Name              string                         `json:"name" validate:"required,min=3"`
 	Description       string                         `json:"description"`
 	Scope             repository.PromotionScope      `json:"scope" validate:"required,oneof=ORDER ITEM"`
-	DiscountType      repository.DiscountType        `json:"discount_type" validate:"required,oneof=percentage fixed_amount"`
-	DiscountValue     int64                          `json:"discount_value" validate:"required,gt=0"`
+	DiscountType      repository.DiscountType        `json:"discount_type" validate:"required,oneof=percentage fixed_amount buy_x_get_y bundle_price tiered"`
+	DiscountValue     int64                          `json:"discount_value" validate:"required_unless=DiscountType tiered,gte=0"`
 	MaxDiscountAmount *int64                         `json:"max_discount_amount"`
 	StartDate         time.Time                      `json:"start_date" validate:"required"`
 	EndDate           time.Time                      `json:"end_date" validate:"required,gtfield=StartDate"`
 	IsActive          bool                           `json:"is_active"`
 	Rules             []CreatePromotionRuleRequest   `json:"rules" validate:"dive"`
 	Targets           []CreatePromotionTargetRequest `json:"targets" validate:"dive"`
+
+	// Usage limits; left out means unlimited. The budget caps the total discount given across redemptions.
+	TotalRedemptionLimit *int32 `json:"total_redemption_limit" validate:"omitempty,gt=0"`
+	PerCustomerLimit     *int32 `json:"per_customer_limit" validate:"omitempty,gt=0"`
+	BudgetAmount         *int64 `json:"budget_amount" validate:"omitempty,gt=0"`
+
+	// Item mechanics, see DiscountType
+	BuyQuantity    *int32                       `json:"buy_quantity" validate:"omitempty,gt=0"`
+	GetQuantity    *int32                       `json:"get_quantity" validate:"omitempty,gt=0"`
+	BundleQuantity *int32                       `json:"bundle_quantity" validate:"omitempty,gt=1"`
+	Tiers          []CreatePromotionTierRequest `json:"tiers" validate:"dive"`
+
+	// Evaluation: auto-apply promotions are picked for an order without the cashier naming them. An exclusive
+	// promotion (the default) is never combined with others, stackable ones combine; the highest priority wins
+	// first, the larger discount breaks ties.
+	AutoApply bool                         `json:"auto_apply"`
+	Stacking  repository.PromotionStacking `json:"stacking" validate:"omitempty,oneof=exclusive stackable"`
+	Priority  int32                        `json:"priority"`
+
+	// Recurring schedule within StartDate and EndDate; without schedules the promotion runs all day. Excluded
+	// dates (YYYY-MM-DD, store dates) such as public holidays are skipped either way.
+	Schedules     []CreatePromotionScheduleRequest `json:"schedules" validate:"dive"`
+	ExcludedDates []string                         `json:"excluded_dates" validate:"dive,datetime=2006-01-02"`
 }
 
 type UpdatePromotionRequest struct {
 	Name              string                         `json:"name" validate:"required,min=3"`
 	Description       string                         `json:"description"`
 	Scope             repository.PromotionScope      `json:"scope" validate:"required,oneof=ORDER ITEM"`
-	DiscountType      repository.DiscountType        `json:"discount_type" validate:"required,oneof=percentage fixed_amount"`
-	DiscountValue     int64                          `json:"discount_value" validate:"required,gt=0"`
+	DiscountType      repository.DiscountType        `json:"discount_type" validate:"required,oneof=percentage fixed_amount buy_x_get_y bundle_price tiered"`
+	DiscountValue     int64                          `json:"discount_value" validate:"required_unless=DiscountType tiered,gte=0"`
 	MaxDiscountAmount *int64                         `json:"max_discount_amount"`
 	StartDate         time.Time                      `json:"start_date" validate:"required"`
 	EndDate           time.Time                      `json:"end_date" validate:"required,gtfield=StartDate"`
 	IsActive          bool                           `json:"is_active"`
 	Rules             []CreatePromotionRuleRequest   `json:"rules" validate:"dive"`
 	Targets           []CreatePromotionTargetRequest `json:"targets" validate:"dive"`
+
+	// Usage limits; left out means unlimited. The budget caps the total discount given across redemptions.
+	TotalRedemptionLimit *int32 `json:"total_redemption_limit" validate:"omitempty,gt=0"`
+	PerCustomerLimit     *int32 `json:"per_customer_limit" validate:"omitempty,gt=0"`
+	BudgetAmount         *int64 `json:"budget_amount" validate:"omitempty,gt=0"`
+
+	// Item mechanics, see DiscountType
+	BuyQuantity    *int32                       `json:"buy_quantity" validate:"omitempty,gt=0"`
+	GetQuantity    *int32                       `json:"get_quantity" validate:"omitempty,gt=0"`
+	BundleQuantity *int32                       `json:"bundle_quantity" validate:"omitempty,gt=1"`
+	Tiers          []CreatePromotionTierRequest `json:"tiers" validate:"dive"`
+
+	// Evaluation: auto-apply promotions are picked for an order without the cashier naming them. An exclusive
+	// promotion (the default) is never combined with others, stackable ones combine; the highest priority wins
+	// first, the larger discount breaks ties.
+	AutoApply bool                         `json:"auto_apply"`
+	Stacking  repository.PromotionStacking `json:"stacking" validate:"omitempty,oneof=exclusive stackable"`
+	Priority  int32                        `json:"priority"`
+
+	// Recurring schedule within StartDate and EndDate; without schedules the promotion runs all day. Excluded
+	// dates (YYYY-MM-DD, store dates) such as public holidays are skipped either way.
+	Schedules     []CreatePromotionScheduleRequest `json:"schedules" validate:"dive"`
+	ExcludedDates []string                         `json:"excluded_dates" validate:"dive,datetime=2006-01-02"`
 }
 
 type PromotionRuleResponse struct {
@@ -61,6 +133,21 @@ type PromotionTargetResponse struct {
 	TargetID   string                         `json:"target_id"`
 }
 
+type PromotionTierResponse struct {
+	ID            uuid.UUID               `json:"id"`
+	MinAmount     int64                   `json:"min_amount"`
+	DiscountType  repository.DiscountType `json:"discount_type"`
+	DiscountValue int64                   `json:"discount_value"`
+}
+
+type PromotionScheduleResponse struct {
+	ID         uuid.UUID `json:"id"`
+	DaysOfWeek []int     `json:"days_of_week"`
+	StartTime  string    `json:"start_time"`
+	EndTime    string    `json:"end_time"`
+	Label      string    `json:"label" example:"Mon-Fri 15:00-17:00"`
+}
+
 type PromotionResponse struct {
 	ID                uuid.UUID                 `json:"id"`
 	Name              string                    `json:"name"`
@@ -77,6 +164,25 @@ type PromotionResponse struct {
 	DeletedAt         *time.Time                `json:"deleted_at,omitempty"`
 	Rules             []PromotionRuleResponse   `json:"rules"`
 	Targets           []PromotionTargetResponse `json:"targets"`
+
+	TotalRedemptionLimit *int32 `json:"total_redemption_limit,omitempty"`
+	PerCustomerLimit     *int32 `json:"per_customer_limit,omitempty"`
+	BudgetAmount         *int64 `json:"budget_amount,omitempty"`
+
+	BuyQuantity    *int32                  `json:"buy_quantity,omitempty"`
+	GetQuantity    *int32                  `json:"get_quantity,omitempty"`
+	BundleQuantity *int32                  `json:"bundle_quantity,omitempty"`
+	Tiers          []PromotionTierResponse `json:"tiers,omitempty"`
+
+	AutoApply bool                         `json:"auto_apply"`
+	Stacking  repository.PromotionStacking `json:"stacking"`
+	Priority  int32                        `json:"priority"`
+
+	Schedules     []PromotionScheduleResponse `json:"schedules,omitempty"`
+	ExcludedDates []string                    `json:"excluded_dates,omitempty"`
+	// AvailableNow is whether the promotion is active, within its dates and on schedule at the store's
+	// current time
+	AvailableNow bool `json:"available_now"`
 }
 
 type ListPromotionsRequest struct {
@@ -89,3 +195,46 @@ type PagedPromotionResponse struct {
 	Promotions []PromotionResponse   `json:"promotions"`
 	Pagination pagination.Pagination `json:"pagination"`
 }
+
+// CreatePromotionCodeRequest creates one code shared by everyone, e.g. a campaign code printed on a flyer.
+// MaxUses caps how often the code itself can be redeemed; the promotion's own limits apply on top.
+type CreatePromotionCodeRequest struct {
+	Code    string `json:"code" validate:"required,min=3,max=32,alphanum"`
+	MaxUses *int32 `json:"max_uses" validate:"omitempty,gt=0"`
+}
+
+// GeneratePromotionCodesRequest generates a batch of unique single-use codes.
+type GeneratePromotionCodesRequest struct {
+	Count  int    `json:"count" validate:"required,min=1,max=1000"`
+	Prefix string `json:"prefix" validate:"omitempty,max=16,alphanum"`
+	// Length of the random part of each code, 8 when left out
+	Length int `json:"length" validate:"omitempty,min=6,max=16"`
+}
+
+type PromotionCodeResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	PromotionID     uuid.UUID  `json:"promotion_id"`
+	Code            string     `json:"code"`
+	BatchID         *uuid.UUID `json:"batch_id,omitempty"`
+	MaxUses         *int32     `json:"max_uses,omitempty"`
+	RedemptionCount int64      `json:"redemption_count"`
+	IsActive        bool       `json:"is_active"`
+	CreatedAt       time.Time  `json:"created_at"`
+	DeactivatedAt   *time.Time `json:"deactivated_at,omitempty"`
+}
+
+type GeneratePromotionCodesResponse struct {
+	BatchID uuid.UUID               `json:"batch_id"`
+	Codes   []PromotionCodeResponse `json:"codes"`
+}
+
+type ListPromotionCodesRequest struct {
+	Page    *int       `query:"page"`
+	Limit   *int       `query:"limit"`
+	BatchID *uuid.UUID `query:"batch_id"`
+}
+
+type PagedPromotionCodeResponse struct {
+	Codes      []PromotionCodeResponse `json:"codes"`
+	Pagination pagination.Pagination   `json:"pagination"`
+}
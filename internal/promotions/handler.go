@@ -17,6 +17,12 @@ type IPromotionHandler interface {
 	DeletePromotionHandler(c fiber.Ctx) error
 	ListPromotionsHandler(c fiber.Ctx) error
 	RestorePromotionHandler(c fiber.Ctx) error
+
+	// Codes
+	CreatePromotionCodeHandler(c fiber.Ctx) error
+	GeneratePromotionCodesHandler(c fiber.Ctx) error
+	ListPromotionCodesHandler(c fiber.Ctx) error
+	DeactivatePromotionCodeHandler(c fiber.Ctx) error
 }
 
 type PromotionHandler struct {
@@ -33,13 +39,13 @@ func NewPromotionHandler(service IPromotionService, log logger.ILogger) IPromoti
 
 // CreatePromotionHandler creates a new promotion
 // @Summary      Create a new promotion
-// @Description  Create a new promotion with rules and targets (Roles: admin, manager)
+// @Description  Create a new promotion with rules and targets. Besides percentage and fixed_amount discounts it supports buy_x_get_y (buy_quantity, get_quantity, discount_value percent off), bundle_price (bundle_quantity units for discount_value) and tiered (tiers) promotions. auto_apply promotions are picked for orders automatically; stacking (exclusive or stackable) and priority decide how promotions combine. schedules limit the promotion to recurring day-of-week and time-of-day windows (e.g. happy hour) and excluded_dates skip days such as holidays, both in the store's time zone (Roles: admin, manager)
 // @Tags         Promotions
 // @Accept       json
 // @Produce      json
 // @Param        request body CreatePromotionRequest true "Promotion details"
 // @Success      201 {object} common.SuccessResponse{data=PromotionResponse} "Promotion created successfully"
-// @Failure      400 {object} common.ErrorResponse "Invalid request body or validation failed"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body, validation failed or settings missing for the discount type"
 // @Failure      500 {object} common.ErrorResponse "Internal server error"
 // @x-roles      ["admin", "manager"]
 // @Router       /promotions [post]
@@ -65,6 +71,9 @@ func (h *PromotionHandler) CreatePromotionHandler(c fiber.Ctx) error {
 
 	promo, err := h.service.CreatePromotion(c.RequestCtx(), req)
 	if err != nil {
+		if errors.Is(err, common.ErrPromotionInvalid) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
 		h.log.Errorf("Failed to create promotion", "error", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to create promotion"})
 	}
@@ -77,14 +86,14 @@ func (h *PromotionHandler) CreatePromotionHandler(c fiber.Ctx) error {
 
 // UpdatePromotionHandler updates a promotion
 // @Summary      Update a promotion
-// @Description  Update details of an existing promotion by its ID (Roles: admin, manager)
+// @Description  Update details of an existing promotion by its ID. Rules, targets, tiers, schedules and excluded dates are replaced by the ones in the request (Roles: admin, manager)
 // @Tags         Promotions
 // @Accept       json
 // @Produce      json
 // @Param        id      path string true "Promotion ID" Format(uuid)
 // @Param        request body UpdatePromotionRequest true "Promotion details"
 // @Success      200 {object} common.SuccessResponse{data=PromotionResponse} "Promotion updated successfully"
-// @Failure      400 {object} common.ErrorResponse "Invalid project ID format or request body"
+// @Failure      400 {object} common.ErrorResponse "Invalid project ID format or request body, or settings missing for the discount type"
 // @Failure      404 {object} common.ErrorResponse "Promotion not found"
 // @Failure      500 {object} common.ErrorResponse "Internal server error"
 // @x-roles      ["admin", "manager"]
@@ -117,6 +126,9 @@ func (h *PromotionHandler) UpdatePromotionHandler(c fiber.Ctx) error {
 
 	promo, err := h.service.UpdatePromotion(c.RequestCtx(), id, req)
 	if err != nil {
+		if errors.Is(err, common.ErrPromotionInvalid) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+		}
 		if errors.Is(err, common.ErrNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Promotion not found"})
 		}
@@ -197,7 +209,7 @@ func (h *PromotionHandler) DeletePromotionHandler(c fiber.Ctx) error {
 
 // ListPromotionsHandler lists all promotions
 // @Summary      List all promotions
-// @Description  Get a list of promotions with pagination and optional trash filter (Roles: admin, manager, cashier)
+// @Description  Get a list of promotions with pagination and optional trash filter. available_now tells whether a promotion can be applied at the moment, given its dates and schedule (Roles: admin, manager, cashier)
 // @Tags         Promotions
 // @Accept       json
 // @Produce      json
@@ -0,0 +1,193 @@
+package promotions
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/pkg/validator"
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// CreatePromotionCodeHandler creates a shared code for a promotion
+// @Summary      Create a shared promotion code
+// @Description  Create one code anyone can use to apply the promotion, e.g. a campaign code. Codes are case-insensitive and stored upper-case; max_uses caps the redemptions of this code on top of the promotion's own limits (Roles: admin, manager)
+// @Tags         Promotions
+// @Accept       json
+// @Produce      json
+// @Param        id      path string                     true "Promotion ID" Format(uuid)
+// @Param        request body CreatePromotionCodeRequest true "Code details"
+// @Success      201 {object} common.SuccessResponse{data=PromotionCodeResponse} "Promotion code created successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid promotion ID format or request body"
+// @Failure      404 {object} common.ErrorResponse "Promotion not found"
+// @Failure      409 {object} common.ErrorResponse "Code is already taken"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin", "manager"]
+// @Router       /promotions/{id}/codes [post]
+func (h *PromotionHandler) CreatePromotionCodeHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warnf("Invalid promotion ID format", "error", err, "id", c.Params("id"))
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid promotion ID format"})
+	}
+
+	var req CreatePromotionCodeRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return h.bindError(c, err, "Invalid request body")
+	}
+
+	code, err := h.service.CreatePromotionCode(c.RequestCtx(), id, req)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Promotion not found"})
+		}
+		if errors.Is(err, common.ErrPromotionCodeExists) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		h.log.Errorf("Failed to create promotion code", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to create promotion code"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(common.SuccessResponse{
+		Message: "Promotion code created successfully",
+		Data:    code,
+	})
+}
+
+// GeneratePromotionCodesHandler generates a batch of single-use codes
+// @Summary      Generate single-use promotion codes
+// @Description  Generate a batch of unique random codes that can each be redeemed once, e.g. to hand out per customer. The codes share a batch_id to list them by (Roles: admin, manager)
+// @Tags         Promotions
+// @Accept       json
+// @Produce      json
+// @Param        id      path string                        true "Promotion ID" Format(uuid)
+// @Param        request body GeneratePromotionCodesRequest true "Batch details"
+// @Success      201 {object} common.SuccessResponse{data=GeneratePromotionCodesResponse} "Promotion codes generated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid promotion ID format or request body"
+// @Failure      404 {object} common.ErrorResponse "Promotion not found"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin", "manager"]
+// @Router       /promotions/{id}/codes/batch [post]
+func (h *PromotionHandler) GeneratePromotionCodesHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warnf("Invalid promotion ID format", "error", err, "id", c.Params("id"))
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid promotion ID format"})
+	}
+
+	var req GeneratePromotionCodesRequest
+	if err := c.Bind().Body(&req); err != nil {
+		return h.bindError(c, err, "Invalid request body")
+	}
+
+	resp, err := h.service.GeneratePromotionCodes(c.RequestCtx(), id, req)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Promotion not found"})
+		}
+		h.log.Errorf("Failed to generate promotion codes", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to generate promotion codes"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(common.SuccessResponse{
+		Message: "Promotion codes generated successfully",
+		Data:    resp,
+	})
+}
+
+// ListPromotionCodesHandler lists the codes of a promotion
+// @Summary      List promotion codes
+// @Description  List the codes of a promotion with how often each was redeemed, optionally of one generated batch (Roles: admin, manager)
+// @Tags         Promotions
+// @Accept       json
+// @Produce      json
+// @Param        id       path  string true  "Promotion ID" Format(uuid)
+// @Param        batch_id query string false "Only codes of this batch" Format(uuid)
+// @Param        page     query int    false "Page number"
+// @Param        limit    query int    false "Items per page"
+// @Success      200 {object} common.SuccessResponse{data=PagedPromotionCodeResponse} "Promotion codes retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid promotion ID format or query parameters"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin", "manager"]
+// @Router       /promotions/{id}/codes [get]
+func (h *PromotionHandler) ListPromotionCodesHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warnf("Invalid promotion ID format", "error", err, "id", c.Params("id"))
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid promotion ID format"})
+	}
+
+	var req ListPromotionCodesRequest
+	if err := c.Bind().Query(&req); err != nil {
+		return h.bindError(c, err, "Invalid query parameters")
+	}
+
+	resp, err := h.service.ListPromotionCodes(c.RequestCtx(), id, req)
+	if err != nil {
+		h.log.Errorf("Failed to list promotion codes", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to list promotion codes"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Promotion codes retrieved successfully",
+		Data:    resp,
+	})
+}
+
+// DeactivatePromotionCodeHandler deactivates a promotion code
+// @Summary      Deactivate a promotion code
+// @Description  Stop a code from being applied to new orders. Orders that already carry it keep it, and the code stays reserved (Roles: admin, manager)
+// @Tags         Promotions
+// @Accept       json
+// @Produce      json
+// @Param        id      path string true "Promotion ID" Format(uuid)
+// @Param        code_id path string true "Promotion code ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=PromotionCodeResponse} "Promotion code deactivated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid ID format"
+// @Failure      404 {object} common.ErrorResponse "Code not found or already deactivated"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin", "manager"]
+// @Router       /promotions/{id}/codes/{code_id} [delete]
+func (h *PromotionHandler) DeactivatePromotionCodeHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		h.log.Warnf("Invalid promotion ID format", "error", err, "id", c.Params("id"))
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid promotion ID format"})
+	}
+	codeID, err := fiber.Convert(c.Params("code_id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid promotion code ID format"})
+	}
+
+	code, err := h.service.DeactivatePromotionCode(c.RequestCtx(), id, codeID)
+	if err != nil {
+		if errors.Is(err, common.ErrPromotionCodeNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		h.log.Errorf("Failed to deactivate promotion code", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to deactivate promotion code"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Promotion code deactivated successfully",
+		Data:    code,
+	})
+}
+
+func (h *PromotionHandler) bindError(c fiber.Ctx, err error, message string) error {
+	h.log.Warnf("Promotion code request validation failed", "error", err)
+	var ve *validator.ValidationErrors
+	if errors.As(err, &ve) {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+			Message: "Validation failed",
+			Error:   ve.Error(),
+			Data: map[string]interface{}{
+				"errors": ve.Errors,
+			},
+		})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+		Message: message,
+		Error:   err.Error(),
+	})
+}
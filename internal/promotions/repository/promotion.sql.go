@@ -12,6 +12,24 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countPromotionCodes = `-- name: CountPromotionCodes :one
+SELECT COUNT(*) FROM promotion_codes
+WHERE promotion_id = $1
+  AND ($2::uuid IS NULL OR batch_id = $2)
+`
+
+type CountPromotionCodesParams struct {
+	PromotionID uuid.UUID   `json:"promotion_id"`
+	BatchID     pgtype.UUID `json:"batch_id"`
+}
+
+func (q *Queries) CountPromotionCodes(ctx context.Context, arg CountPromotionCodesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPromotionCodes, arg.PromotionID, arg.BatchID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countPromotions = `-- name: CountPromotions :one
 SELECT COUNT(*) FROM promotions WHERE deleted_at IS NULL
 `
@@ -44,22 +62,40 @@ INSERT INTO promotions (
     max_discount_amount,
     start_date,
     end_date,
-    is_active
+    is_active,
+    total_redemption_limit,
+    per_customer_limit,
+    budget_amount,
+    buy_quantity,
+    get_quantity,
+    bundle_quantity,
+    auto_apply,
+    stacking,
+    priority
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
-) RETURNING id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+) RETURNING id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority
 `
 
 type CreatePromotionParams struct {
-	Name              string             `json:"name"`
-	Description       *string            `json:"description"`
-	Scope             PromotionScope     `json:"scope"`
-	DiscountType      DiscountType       `json:"discount_type"`
-	DiscountValue     pgtype.Numeric     `json:"discount_value"`
-	MaxDiscountAmount pgtype.Numeric     `json:"max_discount_amount"`
-	StartDate         pgtype.Timestamptz `json:"start_date"`
-	EndDate           pgtype.Timestamptz `json:"end_date"`
-	IsActive          bool               `json:"is_active"`
+	Name                 string             `json:"name"`
+	Description          *string            `json:"description"`
+	Scope                PromotionScope     `json:"scope"`
+	DiscountType         DiscountType       `json:"discount_type"`
+	DiscountValue        pgtype.Numeric     `json:"discount_value"`
+	MaxDiscountAmount    pgtype.Numeric     `json:"max_discount_amount"`
+	StartDate            pgtype.Timestamptz `json:"start_date"`
+	EndDate              pgtype.Timestamptz `json:"end_date"`
+	IsActive             bool               `json:"is_active"`
+	TotalRedemptionLimit *int32             `json:"total_redemption_limit"`
+	PerCustomerLimit     *int32             `json:"per_customer_limit"`
+	BudgetAmount         *int64             `json:"budget_amount"`
+	BuyQuantity          *int32             `json:"buy_quantity"`
+	GetQuantity          *int32             `json:"get_quantity"`
+	BundleQuantity       *int32             `json:"bundle_quantity"`
+	AutoApply            bool               `json:"auto_apply"`
+	Stacking             PromotionStacking  `json:"stacking"`
+	Priority             int32              `json:"priority"`
 }
 
 func (q *Queries) CreatePromotion(ctx context.Context, arg CreatePromotionParams) (Promotion, error) {
@@ -73,6 +109,15 @@ func (q *Queries) CreatePromotion(ctx context.Context, arg CreatePromotionParams
 		arg.StartDate,
 		arg.EndDate,
 		arg.IsActive,
+		arg.TotalRedemptionLimit,
+		arg.PerCustomerLimit,
+		arg.BudgetAmount,
+		arg.BuyQuantity,
+		arg.GetQuantity,
+		arg.BundleQuantity,
+		arg.AutoApply,
+		arg.Stacking,
+		arg.Priority,
 	)
 	var i Promotion
 	err := row.Scan(
@@ -89,10 +134,70 @@ func (q *Queries) CreatePromotion(ctx context.Context, arg CreatePromotionParams
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.TotalRedemptionLimit,
+		&i.PerCustomerLimit,
+		&i.BudgetAmount,
+		&i.BuyQuantity,
+		&i.GetQuantity,
+		&i.BundleQuantity,
+		&i.AutoApply,
+		&i.Stacking,
+		&i.Priority,
 	)
 	return i, err
 }
 
+const createPromotionCode = `-- name: CreatePromotionCode :one
+INSERT INTO promotion_codes (promotion_id, code, batch_id, max_uses)
+VALUES ($1, upper($2), $3, $4)
+ON CONFLICT (code) DO NOTHING
+RETURNING id, promotion_id, code, batch_id, max_uses, created_at, deactivated_at
+`
+
+type CreatePromotionCodeParams struct {
+	PromotionID uuid.UUID   `json:"promotion_id"`
+	Code        string      `json:"code"`
+	BatchID     pgtype.UUID `json:"batch_id"`
+	MaxUses     *int32      `json:"max_uses"`
+}
+
+// Creates a code; returns no row when the code is already taken.
+func (q *Queries) CreatePromotionCode(ctx context.Context, arg CreatePromotionCodeParams) (PromotionCode, error) {
+	row := q.db.QueryRow(ctx, createPromotionCode,
+		arg.PromotionID,
+		arg.Code,
+		arg.BatchID,
+		arg.MaxUses,
+	)
+	var i PromotionCode
+	err := row.Scan(
+		&i.ID,
+		&i.PromotionID,
+		&i.Code,
+		&i.BatchID,
+		&i.MaxUses,
+		&i.CreatedAt,
+		&i.DeactivatedAt,
+	)
+	return i, err
+}
+
+const createPromotionExcludedDate = `-- name: CreatePromotionExcludedDate :exec
+INSERT INTO promotion_excluded_dates (promotion_id, excluded_date)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type CreatePromotionExcludedDateParams struct {
+	PromotionID  uuid.UUID   `json:"promotion_id"`
+	ExcludedDate pgtype.Date `json:"excluded_date"`
+}
+
+func (q *Queries) CreatePromotionExcludedDate(ctx context.Context, arg CreatePromotionExcludedDateParams) error {
+	_, err := q.db.Exec(ctx, createPromotionExcludedDate, arg.PromotionID, arg.ExcludedDate)
+	return err
+}
+
 const createPromotionRule = `-- name: CreatePromotionRule :one
 INSERT INTO promotion_rules (
     promotion_id,
@@ -131,6 +236,43 @@ func (q *Queries) CreatePromotionRule(ctx context.Context, arg CreatePromotionRu
 	return i, err
 }
 
+const createPromotionSchedule = `-- name: CreatePromotionSchedule :one
+INSERT INTO promotion_schedules (
+    promotion_id,
+    days_of_week,
+    start_time,
+    end_time
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, promotion_id, days_of_week, start_time, end_time, created_at
+`
+
+type CreatePromotionScheduleParams struct {
+	PromotionID uuid.UUID   `json:"promotion_id"`
+	DaysOfWeek  []int16     `json:"days_of_week"`
+	StartTime   pgtype.Time `json:"start_time"`
+	EndTime     pgtype.Time `json:"end_time"`
+}
+
+func (q *Queries) CreatePromotionSchedule(ctx context.Context, arg CreatePromotionScheduleParams) (PromotionSchedule, error) {
+	row := q.db.QueryRow(ctx, createPromotionSchedule,
+		arg.PromotionID,
+		arg.DaysOfWeek,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	var i PromotionSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.PromotionID,
+		&i.DaysOfWeek,
+		&i.StartTime,
+		&i.EndTime,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createPromotionTarget = `-- name: CreatePromotionTarget :one
 INSERT INTO promotion_targets (
     promotion_id,
@@ -161,6 +303,70 @@ func (q *Queries) CreatePromotionTarget(ctx context.Context, arg CreatePromotion
 	return i, err
 }
 
+const createPromotionTier = `-- name: CreatePromotionTier :one
+INSERT INTO promotion_tiers (
+    promotion_id,
+    min_amount,
+    discount_type,
+    discount_value
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, promotion_id, min_amount, discount_type, discount_value, created_at
+`
+
+type CreatePromotionTierParams struct {
+	PromotionID   uuid.UUID      `json:"promotion_id"`
+	MinAmount     int64          `json:"min_amount"`
+	DiscountType  DiscountType   `json:"discount_type"`
+	DiscountValue pgtype.Numeric `json:"discount_value"`
+}
+
+func (q *Queries) CreatePromotionTier(ctx context.Context, arg CreatePromotionTierParams) (PromotionTier, error) {
+	row := q.db.QueryRow(ctx, createPromotionTier,
+		arg.PromotionID,
+		arg.MinAmount,
+		arg.DiscountType,
+		arg.DiscountValue,
+	)
+	var i PromotionTier
+	err := row.Scan(
+		&i.ID,
+		&i.PromotionID,
+		&i.MinAmount,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deactivatePromotionCode = `-- name: DeactivatePromotionCode :one
+UPDATE promotion_codes
+SET deactivated_at = NOW()
+WHERE id = $1 AND promotion_id = $2 AND deactivated_at IS NULL
+RETURNING id, promotion_id, code, batch_id, max_uses, created_at, deactivated_at
+`
+
+type DeactivatePromotionCodeParams struct {
+	ID          uuid.UUID `json:"id"`
+	PromotionID uuid.UUID `json:"promotion_id"`
+}
+
+func (q *Queries) DeactivatePromotionCode(ctx context.Context, arg DeactivatePromotionCodeParams) (PromotionCode, error) {
+	row := q.db.QueryRow(ctx, deactivatePromotionCode, arg.ID, arg.PromotionID)
+	var i PromotionCode
+	err := row.Scan(
+		&i.ID,
+		&i.PromotionID,
+		&i.Code,
+		&i.BatchID,
+		&i.MaxUses,
+		&i.CreatedAt,
+		&i.DeactivatedAt,
+	)
+	return i, err
+}
+
 const deletePromotion = `-- name: DeletePromotion :exec
 UPDATE promotions
 SET deleted_at = NOW()
@@ -172,6 +378,16 @@ func (q *Queries) DeletePromotion(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deletePromotionExcludedDatesByPromotionID = `-- name: DeletePromotionExcludedDatesByPromotionID :exec
+DELETE FROM promotion_excluded_dates
+WHERE promotion_id = $1
+`
+
+func (q *Queries) DeletePromotionExcludedDatesByPromotionID(ctx context.Context, promotionID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deletePromotionExcludedDatesByPromotionID, promotionID)
+	return err
+}
+
 const deletePromotionRulesByPromotionID = `-- name: DeletePromotionRulesByPromotionID :exec
 DELETE FROM promotion_rules
 WHERE promotion_id = $1
@@ -182,6 +398,16 @@ func (q *Queries) DeletePromotionRulesByPromotionID(ctx context.Context, promoti
 	return err
 }
 
+const deletePromotionSchedulesByPromotionID = `-- name: DeletePromotionSchedulesByPromotionID :exec
+DELETE FROM promotion_schedules
+WHERE promotion_id = $1
+`
+
+func (q *Queries) DeletePromotionSchedulesByPromotionID(ctx context.Context, promotionID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deletePromotionSchedulesByPromotionID, promotionID)
+	return err
+}
+
 const deletePromotionTargetsByPromotionID = `-- name: DeletePromotionTargetsByPromotionID :exec
 DELETE FROM promotion_targets
 WHERE promotion_id = $1
@@ -192,8 +418,18 @@ func (q *Queries) DeletePromotionTargetsByPromotionID(ctx context.Context, promo
 	return err
 }
 
+const deletePromotionTiersByPromotionID = `-- name: DeletePromotionTiersByPromotionID :exec
+DELETE FROM promotion_tiers
+WHERE promotion_id = $1
+`
+
+func (q *Queries) DeletePromotionTiersByPromotionID(ctx context.Context, promotionID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deletePromotionTiersByPromotionID, promotionID)
+	return err
+}
+
 const getActivePromotionByID = `-- name: GetActivePromotionByID :one
-SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at FROM promotions
+SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority FROM promotions
 WHERE id = $1 AND is_active = true AND deleted_at IS NULL AND start_date <= NOW() AND end_date >= NOW()
 LIMIT 1
 `
@@ -215,12 +451,21 @@ func (q *Queries) GetActivePromotionByID(ctx context.Context, id uuid.UUID) (Pro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.TotalRedemptionLimit,
+		&i.PerCustomerLimit,
+		&i.BudgetAmount,
+		&i.BuyQuantity,
+		&i.GetQuantity,
+		&i.BundleQuantity,
+		&i.AutoApply,
+		&i.Stacking,
+		&i.Priority,
 	)
 	return i, err
 }
 
 const getPromotionByID = `-- name: GetPromotionByID :one
-SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at FROM promotions
+SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority FROM promotions
 WHERE id = $1
 LIMIT 1
 `
@@ -243,10 +488,49 @@ func (q *Queries) GetPromotionByID(ctx context.Context, id uuid.UUID) (Promotion
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.TotalRedemptionLimit,
+		&i.PerCustomerLimit,
+		&i.BudgetAmount,
+		&i.BuyQuantity,
+		&i.GetQuantity,
+		&i.BundleQuantity,
+		&i.AutoApply,
+		&i.Stacking,
+		&i.Priority,
 	)
 	return i, err
 }
 
+const getPromotionExcludedDates = `-- name: GetPromotionExcludedDates :many
+SELECT promotion_id, excluded_date FROM promotion_excluded_dates
+WHERE promotion_id = $1
+ORDER BY excluded_date
+`
+
+// Mengambil tanggal-tanggal di mana promosi tidak berlaku.
+func (q *Queries) GetPromotionExcludedDates(ctx context.Context, promotionID uuid.UUID) ([]PromotionExcludedDate, error) {
+	rows, err := q.db.Query(ctx, getPromotionExcludedDates, promotionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PromotionExcludedDate{}
+	for rows.Next() {
+		var i PromotionExcludedDate
+		if err := rows.Scan(
+			&i.PromotionID,
+			&i.ExcludedDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPromotionRules = `-- name: GetPromotionRules :many
 SELECT id, promotion_id, rule_type, rule_value, description, created_at, updated_at FROM promotion_rules
 WHERE promotion_id = $1
@@ -281,6 +565,40 @@ func (q *Queries) GetPromotionRules(ctx context.Context, promotionID uuid.UUID)
 	return items, nil
 }
 
+const getPromotionSchedules = `-- name: GetPromotionSchedules :many
+SELECT id, promotion_id, days_of_week, start_time, end_time, created_at FROM promotion_schedules
+WHERE promotion_id = $1
+ORDER BY start_time, id
+`
+
+// Mengambil jadwal berulang sebuah promosi.
+func (q *Queries) GetPromotionSchedules(ctx context.Context, promotionID uuid.UUID) ([]PromotionSchedule, error) {
+	rows, err := q.db.Query(ctx, getPromotionSchedules, promotionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PromotionSchedule{}
+	for rows.Next() {
+		var i PromotionSchedule
+		if err := rows.Scan(
+			&i.ID,
+			&i.PromotionID,
+			&i.DaysOfWeek,
+			&i.StartTime,
+			&i.EndTime,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPromotionTargets = `-- name: GetPromotionTargets :many
 SELECT id, promotion_id, target_type, target_id, created_at, updated_at FROM promotion_targets
 WHERE promotion_id = $1
@@ -314,8 +632,108 @@ func (q *Queries) GetPromotionTargets(ctx context.Context, promotionID uuid.UUID
 	return items, nil
 }
 
+const getPromotionTiers = `-- name: GetPromotionTiers :many
+SELECT id, promotion_id, min_amount, discount_type, discount_value, created_at FROM promotion_tiers
+WHERE promotion_id = $1
+ORDER BY min_amount
+`
+
+// Mengambil semua tingkatan diskon sebuah promosi, dari ambang terendah.
+func (q *Queries) GetPromotionTiers(ctx context.Context, promotionID uuid.UUID) ([]PromotionTier, error) {
+	rows, err := q.db.Query(ctx, getPromotionTiers, promotionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PromotionTier{}
+	for rows.Next() {
+		var i PromotionTier
+		if err := rows.Scan(
+			&i.ID,
+			&i.PromotionID,
+			&i.MinAmount,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPromotionCodes = `-- name: ListPromotionCodes :many
+SELECT
+    pc.id, pc.promotion_id, pc.code, pc.batch_id, pc.max_uses, pc.created_at, pc.deactivated_at,
+    COUNT(pr.id) AS redemption_count
+FROM promotion_codes pc
+LEFT JOIN promotion_redemptions pr ON pr.promotion_code_id = pc.id AND pr.status = 'redeemed'
+WHERE pc.promotion_id = $1
+  AND ($2::uuid IS NULL OR pc.batch_id = $2)
+GROUP BY pc.id
+ORDER BY pc.created_at DESC, pc.code
+LIMIT $3 OFFSET $4
+`
+
+type ListPromotionCodesParams struct {
+	PromotionID uuid.UUID   `json:"promotion_id"`
+	BatchID     pgtype.UUID `json:"batch_id"`
+	Limit       int32       `json:"limit"`
+	Offset      int32       `json:"offset"`
+}
+
+type ListPromotionCodesRow struct {
+	ID              uuid.UUID          `json:"id"`
+	PromotionID     uuid.UUID          `json:"promotion_id"`
+	Code            string             `json:"code"`
+	BatchID         pgtype.UUID        `json:"batch_id"`
+	MaxUses         *int32             `json:"max_uses"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	DeactivatedAt   pgtype.Timestamptz `json:"deactivated_at"`
+	RedemptionCount int64              `json:"redemption_count"`
+}
+
+// Lists a promotion's codes with how often each was redeemed, optionally of one batch.
+func (q *Queries) ListPromotionCodes(ctx context.Context, arg ListPromotionCodesParams) ([]ListPromotionCodesRow, error) {
+	rows, err := q.db.Query(ctx, listPromotionCodes,
+		arg.PromotionID,
+		arg.BatchID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPromotionCodesRow{}
+	for rows.Next() {
+		var i ListPromotionCodesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PromotionID,
+			&i.Code,
+			&i.BatchID,
+			&i.MaxUses,
+			&i.CreatedAt,
+			&i.DeactivatedAt,
+			&i.RedemptionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPromotions = `-- name: ListPromotions :many
-SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at FROM promotions
+SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority FROM promotions
 WHERE deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -349,6 +767,15 @@ func (q *Queries) ListPromotions(ctx context.Context, arg ListPromotionsParams)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.TotalRedemptionLimit,
+			&i.PerCustomerLimit,
+			&i.BudgetAmount,
+			&i.BuyQuantity,
+			&i.GetQuantity,
+			&i.BundleQuantity,
+			&i.AutoApply,
+			&i.Stacking,
+			&i.Priority,
 		); err != nil {
 			return nil, err
 		}
@@ -361,7 +788,7 @@ func (q *Queries) ListPromotions(ctx context.Context, arg ListPromotionsParams)
 }
 
 const listTrashPromotions = `-- name: ListTrashPromotions :many
-SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at FROM promotions
+SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority FROM promotions
 WHERE deleted_at IS NOT NULL
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -395,6 +822,15 @@ func (q *Queries) ListTrashPromotions(ctx context.Context, arg ListTrashPromotio
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.TotalRedemptionLimit,
+			&i.PerCustomerLimit,
+			&i.BudgetAmount,
+			&i.BuyQuantity,
+			&i.GetQuantity,
+			&i.BundleQuantity,
+			&i.AutoApply,
+			&i.Stacking,
+			&i.Priority,
 		); err != nil {
 			return nil, err
 		}
@@ -429,22 +865,40 @@ SET
     start_date = $8,
     end_date = $9,
     is_active = $10,
+    total_redemption_limit = $11,
+    per_customer_limit = $12,
+    budget_amount = $13,
+    buy_quantity = $14,
+    get_quantity = $15,
+    bundle_quantity = $16,
+    auto_apply = $17,
+    stacking = $18,
+    priority = $19,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at
+RETURNING id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority
 `
 
 type UpdatePromotionParams struct {
-	ID                uuid.UUID          `json:"id"`
-	Name              string             `json:"name"`
-	Description       *string            `json:"description"`
-	Scope             PromotionScope     `json:"scope"`
-	DiscountType      DiscountType       `json:"discount_type"`
-	DiscountValue     pgtype.Numeric     `json:"discount_value"`
-	MaxDiscountAmount pgtype.Numeric     `json:"max_discount_amount"`
-	StartDate         pgtype.Timestamptz `json:"start_date"`
-	EndDate           pgtype.Timestamptz `json:"end_date"`
-	IsActive          bool               `json:"is_active"`
+	ID                   uuid.UUID          `json:"id"`
+	Name                 string             `json:"name"`
+	Description          *string            `json:"description"`
+	Scope                PromotionScope     `json:"scope"`
+	DiscountType         DiscountType       `json:"discount_type"`
+	DiscountValue        pgtype.Numeric     `json:"discount_value"`
+	MaxDiscountAmount    pgtype.Numeric     `json:"max_discount_amount"`
+	StartDate            pgtype.Timestamptz `json:"start_date"`
+	EndDate              pgtype.Timestamptz `json:"end_date"`
+	IsActive             bool               `json:"is_active"`
+	TotalRedemptionLimit *int32             `json:"total_redemption_limit"`
+	PerCustomerLimit     *int32             `json:"per_customer_limit"`
+	BudgetAmount         *int64             `json:"budget_amount"`
+	BuyQuantity          *int32             `json:"buy_quantity"`
+	GetQuantity          *int32             `json:"get_quantity"`
+	BundleQuantity       *int32             `json:"bundle_quantity"`
+	AutoApply            bool               `json:"auto_apply"`
+	Stacking             PromotionStacking  `json:"stacking"`
+	Priority             int32              `json:"priority"`
 }
 
 func (q *Queries) UpdatePromotion(ctx context.Context, arg UpdatePromotionParams) (Promotion, error) {
@@ -459,6 +913,15 @@ func (q *Queries) UpdatePromotion(ctx context.Context, arg UpdatePromotionParams
 		arg.StartDate,
 		arg.EndDate,
 		arg.IsActive,
+		arg.TotalRedemptionLimit,
+		arg.PerCustomerLimit,
+		arg.BudgetAmount,
+		arg.BuyQuantity,
+		arg.GetQuantity,
+		arg.BundleQuantity,
+		arg.AutoApply,
+		arg.Stacking,
+		arg.Priority,
 	)
 	var i Promotion
 	err := row.Scan(
@@ -475,6 +938,15 @@ func (q *Queries) UpdatePromotion(ctx context.Context, arg UpdatePromotionParams
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.TotalRedemptionLimit,
+		&i.PerCustomerLimit,
+		&i.BudgetAmount,
+		&i.BuyQuantity,
+		&i.GetQuantity,
+		&i.BundleQuantity,
+		&i.AutoApply,
+		&i.Stacking,
+		&i.Priority,
 	)
 	return i, err
 }
@@ -11,21 +11,39 @@ import (
 )
 
 type Querier interface {
+	CountPromotionCodes(ctx context.Context, arg CountPromotionCodesParams) (int64, error)
 	CountPromotions(ctx context.Context) (int64, error)
 	CountTrashPromotions(ctx context.Context) (int64, error)
 	CreatePromotion(ctx context.Context, arg CreatePromotionParams) (Promotion, error)
+	// Creates a code; returns no row when the code is already taken.
+	CreatePromotionCode(ctx context.Context, arg CreatePromotionCodeParams) (PromotionCode, error)
+	CreatePromotionExcludedDate(ctx context.Context, arg CreatePromotionExcludedDateParams) error
 	CreatePromotionRule(ctx context.Context, arg CreatePromotionRuleParams) (PromotionRule, error)
+	CreatePromotionSchedule(ctx context.Context, arg CreatePromotionScheduleParams) (PromotionSchedule, error)
 	CreatePromotionTarget(ctx context.Context, arg CreatePromotionTargetParams) (PromotionTarget, error)
+	CreatePromotionTier(ctx context.Context, arg CreatePromotionTierParams) (PromotionTier, error)
+	DeactivatePromotionCode(ctx context.Context, arg DeactivatePromotionCodeParams) (PromotionCode, error)
 	DeletePromotion(ctx context.Context, id uuid.UUID) error
+	DeletePromotionExcludedDatesByPromotionID(ctx context.Context, promotionID uuid.UUID) error
 	DeletePromotionRulesByPromotionID(ctx context.Context, promotionID uuid.UUID) error
+	DeletePromotionSchedulesByPromotionID(ctx context.Context, promotionID uuid.UUID) error
 	DeletePromotionTargetsByPromotionID(ctx context.Context, promotionID uuid.UUID) error
+	DeletePromotionTiersByPromotionID(ctx context.Context, promotionID uuid.UUID) error
 	GetActivePromotionByID(ctx context.Context, id uuid.UUID) (Promotion, error)
 	// Mengambil detail promosi berdasarkan ID.
 	GetPromotionByID(ctx context.Context, id uuid.UUID) (Promotion, error)
+	// Mengambil tanggal-tanggal di mana promosi tidak berlaku.
+	GetPromotionExcludedDates(ctx context.Context, promotionID uuid.UUID) ([]PromotionExcludedDate, error)
 	// Mengambil semua aturan untuk sebuah promosi.
 	GetPromotionRules(ctx context.Context, promotionID uuid.UUID) ([]PromotionRule, error)
+	// Mengambil jadwal berulang sebuah promosi.
+	GetPromotionSchedules(ctx context.Context, promotionID uuid.UUID) ([]PromotionSchedule, error)
 	// Mengambil semua target untuk sebuah promosi.
 	GetPromotionTargets(ctx context.Context, promotionID uuid.UUID) ([]PromotionTarget, error)
+	// Mengambil semua tingkatan diskon sebuah promosi, dari ambang terendah.
+	GetPromotionTiers(ctx context.Context, promotionID uuid.UUID) ([]PromotionTier, error)
+	// Lists a promotion's codes with how often each was redeemed, optionally of one batch.
+	ListPromotionCodes(ctx context.Context, arg ListPromotionCodesParams) ([]ListPromotionCodesRow, error)
 	ListPromotions(ctx context.Context, arg ListPromotionsParams) ([]Promotion, error)
 	ListTrashPromotions(ctx context.Context, arg ListTrashPromotionsParams) ([]Promotion, error)
 	RestorePromotion(ctx context.Context, id uuid.UUID) error
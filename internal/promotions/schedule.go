@@ -0,0 +1,146 @@
+package promotions
+
+import (
+	"POS-kasir/internal/common"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// ScheduleTimeLayout is how window times are written in requests and responses
+	ScheduleTimeLayout = "15:04"
+	// ScheduleDateLayout is how excluded dates are written in requests and responses
+	ScheduleDateLayout = "2006-01-02"
+)
+
+// Window is a recurring stretch of the week a promotion runs in, in the store's time zone. Start and End are
+// offsets from midnight; a window that ends at or before its start runs past midnight into the next day.
+type Window struct {
+	Days  []time.Weekday
+	Start time.Duration
+	End   time.Duration
+}
+
+// Schedule is when a promotion runs within its start and end dates: during any of its windows, but never on
+// an excluded store date. A schedule without windows runs all day.
+type Schedule struct {
+	Windows       []Window
+	ExcludedDates []time.Time
+}
+
+// NewWindow reads a stored window.
+func NewWindow(days []int16, start, end pgtype.Time) Window {
+	w := Window{
+		Start: time.Duration(start.Microseconds) * time.Microsecond,
+		End:   time.Duration(end.Microseconds) * time.Microsecond,
+	}
+	for _, d := range days {
+		w.Days = append(w.Days, time.Weekday(d))
+	}
+	return w
+}
+
+// Match returns the window t falls in, or nil for a schedule without windows. t must be in the store's
+// location, so that days, times and dates are the store's. When the schedule does not allow t the error wraps
+// common.ErrPromotionNotApplicable.
+func (s Schedule) Match(t time.Time) (*Window, error) {
+	today := t.Format(ScheduleDateLayout)
+	for _, d := range s.ExcludedDates {
+		if d.Format(ScheduleDateLayout) == today {
+			return nil, fmt.Errorf("%w: promotion does not run on %s", common.ErrPromotionNotApplicable, today)
+		}
+	}
+	if len(s.Windows) == 0 {
+		return nil, nil
+	}
+	for i := range s.Windows {
+		if s.Windows[i].Contains(t) {
+			return &s.Windows[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: promotion does not run at this time", common.ErrPromotionNotApplicable)
+}
+
+// Contains reports whether t falls in the window. A window past midnight covers the evening of its days and
+// the early hours of the day after.
+func (w Window) Contains(t time.Time) bool {
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if w.Start < w.End {
+		return w.runsOn(t.Weekday()) && clock >= w.Start && clock < w.End
+	}
+	return (w.runsOn(t.Weekday()) && clock >= w.Start) || (w.runsOn((t.Weekday()+6)%7) && clock < w.End)
+}
+
+func (w Window) runsOn(day time.Weekday) bool {
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// String labels the window for reports, e.g. "Mon-Fri 15:00-17:00" or "Daily 22:00-02:00".
+func (w Window) String() string {
+	return fmt.Sprintf("%s %s-%s", w.dayLabel(), clockLabel(w.Start), clockLabel(w.End))
+}
+
+// dayLabel lists the days from Monday, collapsing runs of three or more days into a range.
+func (w Window) dayLabel() string {
+	week := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+	var on []time.Weekday
+	for _, d := range week {
+		if w.runsOn(d) {
+			on = append(on, d)
+		}
+	}
+	if len(on) == len(week) {
+		return "Daily"
+	}
+
+	var parts []string
+	for i := 0; i < len(on); {
+		j := i
+		for j+1 < len(on) && (on[j]+1)%7 == on[j+1] {
+			j++
+		}
+		switch {
+		case j-i >= 2:
+			parts = append(parts, on[i].String()[:3]+"-"+on[j].String()[:3])
+		default:
+			for k := i; k <= j; k++ {
+				parts = append(parts, on[k].String()[:3])
+			}
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+func clockLabel(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// parseScheduleTime reads a window time such as "15:00".
+func parseScheduleTime(value string) (pgtype.Time, error) {
+	t, err := time.Parse(ScheduleTimeLayout, value)
+	if err != nil {
+		return pgtype.Time{}, err
+	}
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	return pgtype.Time{Microseconds: offset.Microseconds(), Valid: true}, nil
+}
+
+// NewSchedule puts a promotion's stored windows and excluded dates together.
+func NewSchedule(windows []Window, excludedDates []pgtype.Date) Schedule {
+	s := Schedule{Windows: windows}
+	for _, d := range excludedDates {
+		if d.Valid {
+			s.ExcludedDates = append(s.ExcludedDates, d.Time)
+		}
+	}
+	return s
+}
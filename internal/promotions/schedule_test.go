@@ -0,0 +1,81 @@
+package promotions_test
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/promotions"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Match(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	at := func(day, hour, minute int) time.Time {
+		// 2026-03-02 is a Monday
+		return time.Date(2026, time.March, day, hour, minute, 0, 0, jakarta)
+	}
+	clock := func(hour, minute int) pgtype.Time {
+		return pgtype.Time{Microseconds: (time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute).Microseconds(), Valid: true}
+	}
+
+	happyHour := promotions.NewWindow([]int16{1, 2, 3, 4, 5}, clock(15, 0), clock(17, 0))
+	lateNight := promotions.NewWindow([]int16{5, 6}, clock(22, 0), clock(2, 0))
+
+	t.Run("WithinWindow", func(t *testing.T) {
+		schedule := promotions.NewSchedule([]promotions.Window{happyHour, lateNight}, nil)
+
+		window, err := schedule.Match(at(4, 15, 30))
+		require.NoError(t, err)
+		assert.Equal(t, "Mon-Fri 15:00-17:00", window.String())
+
+		_, err = schedule.Match(at(4, 17, 0))
+		assert.ErrorIs(t, err, common.ErrPromotionNotApplicable)
+
+		// Saturday afternoon is outside the weekday window
+		_, err = schedule.Match(at(7, 15, 30))
+		assert.ErrorIs(t, err, common.ErrPromotionNotApplicable)
+	})
+
+	t.Run("PastMidnight", func(t *testing.T) {
+		schedule := promotions.NewSchedule([]promotions.Window{lateNight}, nil)
+
+		// Saturday 01:00 belongs to Friday night
+		window, err := schedule.Match(at(7, 1, 0))
+		require.NoError(t, err)
+		assert.Equal(t, "Fri,Sat 22:00-02:00", window.String())
+
+		// Friday 01:00 belongs to Thursday night, which has no window
+		_, err = schedule.Match(at(6, 1, 0))
+		assert.ErrorIs(t, err, common.ErrPromotionNotApplicable)
+	})
+
+	t.Run("ExcludedDate", func(t *testing.T) {
+		holiday := pgtype.Date{Time: time.Date(2026, time.March, 4, 0, 0, 0, 0, time.UTC), Valid: true}
+
+		schedule := promotions.NewSchedule([]promotions.Window{happyHour}, []pgtype.Date{holiday})
+		_, err := schedule.Match(at(4, 15, 30))
+		assert.ErrorIs(t, err, common.ErrPromotionNotApplicable)
+
+		// Excluded dates apply to promotions that run all day too
+		allDay := promotions.NewSchedule(nil, []pgtype.Date{holiday})
+		_, err = allDay.Match(at(4, 9, 0))
+		assert.ErrorIs(t, err, common.ErrPromotionNotApplicable)
+
+		window, err := allDay.Match(at(5, 9, 0))
+		assert.NoError(t, err)
+		assert.Nil(t, window)
+	})
+}
+
+func TestWindow_String(t *testing.T) {
+	noon := pgtype.Time{Microseconds: (12 * time.Hour).Microseconds(), Valid: true}
+	two := pgtype.Time{Microseconds: (14 * time.Hour).Microseconds(), Valid: true}
+
+	assert.Equal(t, "Daily 12:00-14:00", promotions.NewWindow([]int16{0, 1, 2, 3, 4, 5, 6}, noon, two).String())
+	assert.Equal(t, "Sat,Sun 12:00-14:00", promotions.NewWindow([]int16{6, 0}, noon, two).String())
+	assert.Equal(t, "Mon,Wed,Fri-Sun 12:00-14:00", promotions.NewWindow([]int16{1, 3, 5, 6, 0}, noon, two).String())
+}
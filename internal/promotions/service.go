@@ -7,9 +7,11 @@ import (
 	"POS-kasir/internal/common/pagination"
 	"POS-kasir/internal/common/store"
 	"POS-kasir/internal/promotions/repository"
+	"POS-kasir/internal/settings"
 	"POS-kasir/pkg/logger"
 	"POS-kasir/pkg/utils"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -23,6 +25,12 @@ type IPromotionService interface {
 	GetPromotion(ctx context.Context, id uuid.UUID) (*PromotionResponse, error)
 	ListPromotions(ctx context.Context, req ListPromotionsRequest) (*PagedPromotionResponse, error)
 	RestorePromotion(ctx context.Context, id uuid.UUID) error
+
+	// Codes
+	CreatePromotionCode(ctx context.Context, promotionID uuid.UUID, req CreatePromotionCodeRequest) (*PromotionCodeResponse, error)
+	GeneratePromotionCodes(ctx context.Context, promotionID uuid.UUID, req GeneratePromotionCodesRequest) (*GeneratePromotionCodesResponse, error)
+	ListPromotionCodes(ctx context.Context, promotionID uuid.UUID, req ListPromotionCodesRequest) (*PagedPromotionCodeResponse, error)
+	DeactivatePromotionCode(ctx context.Context, promotionID, codeID uuid.UUID) (*PromotionCodeResponse, error)
 }
 
 type PromotionService struct {
@@ -30,21 +38,28 @@ type PromotionService struct {
 	store           store.Store
 	log             logger.ILogger
 	activityService activitylog.IActivityService
+	settingsService settings.ISettingsService
 }
 
-func NewPromotionService(store store.Store, repo repository.Querier, log logger.ILogger, activityService activitylog.IActivityService) IPromotionService {
+func NewPromotionService(store store.Store, repo repository.Querier, log logger.ILogger, activityService activitylog.IActivityService, settingsService settings.ISettingsService) IPromotionService {
 	return &PromotionService{
 		repo:            repo,
 		store:           store,
 		log:             log,
 		activityService: activityService,
+		settingsService: settingsService,
 	}
 }
 
 func (s *PromotionService) CreatePromotion(ctx context.Context, req CreatePromotionRequest) (*PromotionResponse, error) {
+	mechanics, err := validateMechanics(req.DiscountType, req.DiscountValue, req.BuyQuantity, req.GetQuantity, req.BundleQuantity, req.Tiers)
+	if err != nil {
+		return nil, err
+	}
+
 	var promoID uuid.UUID
 
-	err := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+	err = s.store.ExecTx(ctx, func(tx pgx.Tx) error {
 		qtx := repository.New(tx)
 
 		var description *string
@@ -53,21 +68,37 @@ func (s *PromotionService) CreatePromotion(ctx context.Context, req CreatePromot
 		}
 
 		promo, err := qtx.CreatePromotion(ctx, repository.CreatePromotionParams{
-			Name:              req.Name,
-			Description:       description,
-			Scope:             req.Scope,
-			DiscountType:      req.DiscountType,
-			DiscountValue:     utils.Int64ToNumeric(req.DiscountValue),
-			MaxDiscountAmount: utils.Int64PtrToNumeric(req.MaxDiscountAmount),
-			StartDate:         pgtype.Timestamptz{Time: req.StartDate, Valid: true},
-			EndDate:           pgtype.Timestamptz{Time: req.EndDate, Valid: true},
-			IsActive:          req.IsActive,
+			Name:                 req.Name,
+			Description:          description,
+			Scope:                req.Scope,
+			DiscountType:         req.DiscountType,
+			DiscountValue:        utils.Int64ToNumeric(req.DiscountValue),
+			MaxDiscountAmount:    utils.Int64PtrToNumeric(req.MaxDiscountAmount),
+			StartDate:            pgtype.Timestamptz{Time: req.StartDate, Valid: true},
+			EndDate:              pgtype.Timestamptz{Time: req.EndDate, Valid: true},
+			IsActive:             req.IsActive,
+			TotalRedemptionLimit: req.TotalRedemptionLimit,
+			PerCustomerLimit:     req.PerCustomerLimit,
+			BudgetAmount:         req.BudgetAmount,
+			BuyQuantity:          mechanics.BuyQuantity,
+			GetQuantity:          mechanics.GetQuantity,
+			BundleQuantity:       mechanics.BundleQuantity,
+			AutoApply:            req.AutoApply,
+			Stacking:             stackingOrDefault(req.Stacking),
+			Priority:             req.Priority,
 		})
 		if err != nil {
 			return err
 		}
 		promoID = promo.ID
 
+		if err := createTiers(ctx, qtx, promo.ID, mechanics.Tiers); err != nil {
+			return err
+		}
+		if err := createSchedule(ctx, qtx, promo.ID, req.Schedules, req.ExcludedDates); err != nil {
+			return err
+		}
+
 		for _, r := range req.Rules {
 			var ruleDesc *string
 			if r.Description != "" {
@@ -123,7 +154,12 @@ func (s *PromotionService) CreatePromotion(ctx context.Context, req CreatePromot
 }
 
 func (s *PromotionService) UpdatePromotion(ctx context.Context, id uuid.UUID, req UpdatePromotionRequest) (*PromotionResponse, error) {
-	err := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+	mechanics, err := validateMechanics(req.DiscountType, req.DiscountValue, req.BuyQuantity, req.GetQuantity, req.BundleQuantity, req.Tiers)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.ExecTx(ctx, func(tx pgx.Tx) error {
 		qtx := repository.New(tx)
 		var description *string
 		if req.Description != "" {
@@ -131,21 +167,47 @@ func (s *PromotionService) UpdatePromotion(ctx context.Context, id uuid.UUID, re
 		}
 
 		_, err := qtx.UpdatePromotion(ctx, repository.UpdatePromotionParams{
-			ID:                id,
-			Name:              req.Name,
-			Description:       description,
-			Scope:             req.Scope,
-			DiscountType:      req.DiscountType,
-			DiscountValue:     utils.Int64ToNumeric(req.DiscountValue),
-			MaxDiscountAmount: utils.Int64PtrToNumeric(req.MaxDiscountAmount),
-			StartDate:         pgtype.Timestamptz{Time: req.StartDate, Valid: true},
-			EndDate:           pgtype.Timestamptz{Time: req.EndDate, Valid: true},
-			IsActive:          req.IsActive,
+			ID:                   id,
+			Name:                 req.Name,
+			Description:          description,
+			Scope:                req.Scope,
+			DiscountType:         req.DiscountType,
+			DiscountValue:        utils.Int64ToNumeric(req.DiscountValue),
+			MaxDiscountAmount:    utils.Int64PtrToNumeric(req.MaxDiscountAmount),
+			StartDate:            pgtype.Timestamptz{Time: req.StartDate, Valid: true},
+			EndDate:              pgtype.Timestamptz{Time: req.EndDate, Valid: true},
+			IsActive:             req.IsActive,
+			TotalRedemptionLimit: req.TotalRedemptionLimit,
+			PerCustomerLimit:     req.PerCustomerLimit,
+			BudgetAmount:         req.BudgetAmount,
+			BuyQuantity:          mechanics.BuyQuantity,
+			GetQuantity:          mechanics.GetQuantity,
+			BundleQuantity:       mechanics.BundleQuantity,
+			AutoApply:            req.AutoApply,
+			Stacking:             stackingOrDefault(req.Stacking),
+			Priority:             req.Priority,
 		})
 		if err != nil {
 			return err
 		}
 
+		if err := qtx.DeletePromotionTiersByPromotionID(ctx, id); err != nil {
+			return err
+		}
+		if err := createTiers(ctx, qtx, id, mechanics.Tiers); err != nil {
+			return err
+		}
+
+		if err := qtx.DeletePromotionSchedulesByPromotionID(ctx, id); err != nil {
+			return err
+		}
+		if err := qtx.DeletePromotionExcludedDatesByPromotionID(ctx, id); err != nil {
+			return err
+		}
+		if err := createSchedule(ctx, qtx, id, req.Schedules, req.ExcludedDates); err != nil {
+			return err
+		}
+
 		if err := qtx.DeletePromotionRulesByPromotionID(ctx, id); err != nil {
 			return err
 		}
@@ -229,7 +291,27 @@ func (s *PromotionService) GetPromotion(ctx context.Context, id uuid.UUID) (*Pro
 		return nil, err
 	}
 
-	return s.mapToDetailResponse(promo, rules, targets), nil
+	tiers, err := s.repo.GetPromotionTiers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.repo.GetPromotionSchedules(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	excludedDates, err := s.repo.GetPromotionExcludedDates(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := s.storeNow(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapToDetailResponse(promo, rules, targets, tiers, schedules, excludedDates, now), nil
 }
 
 func (s *PromotionService) ListPromotions(ctx context.Context, req ListPromotionsRequest) (*PagedPromotionResponse, error) {
@@ -271,11 +353,19 @@ func (s *PromotionService) ListPromotions(ctx context.Context, req ListPromotion
 		return nil, err
 	}
 
+	now, err := s.storeNow(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var promoResponses []PromotionResponse
 	for _, p := range promos {
 		rules, _ := s.repo.GetPromotionRules(ctx, p.ID)
 		targets, _ := s.repo.GetPromotionTargets(ctx, p.ID)
-		promoResponses = append(promoResponses, *s.mapToDetailResponse(p, rules, targets))
+		tiers, _ := s.repo.GetPromotionTiers(ctx, p.ID)
+		schedules, _ := s.repo.GetPromotionSchedules(ctx, p.ID)
+		excludedDates, _ := s.repo.GetPromotionExcludedDates(ctx, p.ID)
+		promoResponses = append(promoResponses, *s.mapToDetailResponse(p, rules, targets, tiers, schedules, excludedDates, now))
 	}
 
 	return &PagedPromotionResponse{
@@ -310,6 +400,10 @@ func (s *PromotionService) mapToDetailResponse(
 	p repository.Promotion,
 	rules []repository.PromotionRule,
 	targets []repository.PromotionTarget,
+	tiers []repository.PromotionTier,
+	schedules []repository.PromotionSchedule,
+	excludedDates []repository.PromotionExcludedDate,
+	now time.Time,
 ) *PromotionResponse {
 
 	ruleResponses := make([]PromotionRuleResponse, len(rules))
@@ -335,6 +429,46 @@ func (s *PromotionService) mapToDetailResponse(
 		}
 	}
 
+	var tierResponses []PromotionTierResponse
+	for _, t := range tiers {
+		tierResponses = append(tierResponses, PromotionTierResponse{
+			ID:            t.ID,
+			MinAmount:     t.MinAmount,
+			DiscountType:  t.DiscountType,
+			DiscountValue: utils.NumericToInt64(t.DiscountValue),
+		})
+	}
+
+	var scheduleResponses []PromotionScheduleResponse
+	windows := make([]Window, 0, len(schedules))
+	for _, sch := range schedules {
+		window := NewWindow(sch.DaysOfWeek, sch.StartTime, sch.EndTime)
+		windows = append(windows, window)
+
+		days := make([]int, len(sch.DaysOfWeek))
+		for i, d := range sch.DaysOfWeek {
+			days[i] = int(d)
+		}
+		scheduleResponses = append(scheduleResponses, PromotionScheduleResponse{
+			ID:         sch.ID,
+			DaysOfWeek: days,
+			StartTime:  clockLabel(window.Start),
+			EndTime:    clockLabel(window.End),
+			Label:      window.String(),
+		})
+	}
+
+	var dateResponses []string
+	dates := make([]pgtype.Date, 0, len(excludedDates))
+	for _, d := range excludedDates {
+		dates = append(dates, d.ExcludedDate)
+		dateResponses = append(dateResponses, d.ExcludedDate.Time.Format(ScheduleDateLayout))
+	}
+
+	_, offSchedule := NewSchedule(windows, dates).Match(now)
+	availableNow := p.IsActive && !p.DeletedAt.Valid && offSchedule == nil &&
+		!now.Before(p.StartDate.Time) && !now.After(p.EndDate.Time)
+
 	var desc string
 	if p.Description != nil {
 		desc = *p.Description
@@ -355,5 +489,132 @@ func (s *PromotionService) mapToDetailResponse(
 		UpdatedAt:         p.UpdatedAt.Time,
 		Rules:             ruleResponses,
 		Targets:           targetResponses,
+
+		TotalRedemptionLimit: p.TotalRedemptionLimit,
+		PerCustomerLimit:     p.PerCustomerLimit,
+		BudgetAmount:         p.BudgetAmount,
+
+		BuyQuantity:    p.BuyQuantity,
+		GetQuantity:    p.GetQuantity,
+		BundleQuantity: p.BundleQuantity,
+		Tiers:          tierResponses,
+
+		AutoApply: p.AutoApply,
+		Stacking:  p.Stacking,
+		Priority:  p.Priority,
+
+		Schedules:     scheduleResponses,
+		ExcludedDates: dateResponses,
+		AvailableNow:  availableNow,
+	}
+}
+
+// promotionMechanics is what is stored for a promotion's discount type; settings of other types are dropped.
+type promotionMechanics struct {
+	BuyQuantity    *int32
+	GetQuantity    *int32
+	BundleQuantity *int32
+	Tiers          []CreatePromotionTierRequest
+}
+
+func validateMechanics(discountType repository.DiscountType, discountValue int64, buy, get, bundle *int32, tiers []CreatePromotionTierRequest) (promotionMechanics, error) {
+	var m promotionMechanics
+	switch discountType {
+	case repository.DiscountTypeBuyXGetY:
+		if buy == nil || get == nil || discountValue > 100 {
+			return m, common.ErrPromotionInvalid
+		}
+		m.BuyQuantity, m.GetQuantity = buy, get
+	case repository.DiscountTypeBundlePrice:
+		if bundle == nil {
+			return m, common.ErrPromotionInvalid
+		}
+		m.BundleQuantity = bundle
+	case repository.DiscountTypeTiered:
+		if len(tiers) == 0 {
+			return m, common.ErrPromotionInvalid
+		}
+		seen := make(map[int64]bool, len(tiers))
+		for _, t := range tiers {
+			if seen[t.MinAmount] || (t.DiscountType == repository.DiscountTypePercentage && t.DiscountValue > 100) {
+				return m, common.ErrPromotionInvalid
+			}
+			seen[t.MinAmount] = true
+		}
+		m.Tiers = tiers
+	}
+	return m, nil
+}
+
+// stackingOrDefault makes a promotion exclusive unless it is explicitly stackable.
+func stackingOrDefault(stacking repository.PromotionStacking) repository.PromotionStacking {
+	if stacking == "" {
+		return repository.PromotionStackingExclusive
+	}
+	return stacking
+}
+
+func createTiers(ctx context.Context, qtx *repository.Queries, promotionID uuid.UUID, tiers []CreatePromotionTierRequest) error {
+	for _, t := range tiers {
+		_, err := qtx.CreatePromotionTier(ctx, repository.CreatePromotionTierParams{
+			PromotionID:   promotionID,
+			MinAmount:     t.MinAmount,
+			DiscountType:  t.DiscountType,
+			DiscountValue: utils.Int64ToNumeric(t.DiscountValue),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createSchedule stores a promotion's recurring windows and excluded dates; both are validated by the request.
+func createSchedule(ctx context.Context, qtx *repository.Queries, promotionID uuid.UUID, schedules []CreatePromotionScheduleRequest, excludedDates []string) error {
+	for _, sch := range schedules {
+		start, err := parseScheduleTime(sch.StartTime)
+		if err != nil {
+			return err
+		}
+		end, err := parseScheduleTime(sch.EndTime)
+		if err != nil {
+			return err
+		}
+		days := make([]int16, len(sch.DaysOfWeek))
+		for i, d := range sch.DaysOfWeek {
+			days[i] = int16(d)
+		}
+		if _, err := qtx.CreatePromotionSchedule(ctx, repository.CreatePromotionScheduleParams{
+			PromotionID: promotionID,
+			DaysOfWeek:  days,
+			StartTime:   start,
+			EndTime:     end,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, value := range excludedDates {
+		date, err := time.Parse(ScheduleDateLayout, value)
+		if err != nil {
+			return err
+		}
+		if err := qtx.CreatePromotionExcludedDate(ctx, repository.CreatePromotionExcludedDateParams{
+			PromotionID:  promotionID,
+			ExcludedDate: pgtype.Date{Time: date, Valid: true},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeNow is the current time in the store's time zone, which promotion schedules are written in.
+func (s *PromotionService) storeNow(ctx context.Context) (time.Time, error) {
+	operational, err := s.settingsService.GetOperationalSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to load operational settings", "error", err)
+		return time.Time{}, err
 	}
+	return time.Now().In(operational.Location()), nil
 }
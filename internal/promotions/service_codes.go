@@ -0,0 +1,243 @@
+package promotions
+
+import (
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/common/pagination"
+	"POS-kasir/internal/promotions/repository"
+	"POS-kasir/pkg/utils"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// codeAlphabet leaves out 0, O, 1 and I, which are easily mixed up when a code is read out or typed.
+	codeAlphabet          = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	defaultCodeLength     = 8
+	maxCodeCollisionTries = 5
+)
+
+func (s *PromotionService) CreatePromotionCode(ctx context.Context, promotionID uuid.UUID, req CreatePromotionCodeRequest) (*PromotionCodeResponse, error) {
+	if _, err := s.repo.GetPromotionByID(ctx, promotionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
+		}
+		return nil, err
+	}
+
+	code, err := s.repo.CreatePromotionCode(ctx, repository.CreatePromotionCodeParams{
+		PromotionID: promotionID,
+		Code:        req.Code,
+		MaxUses:     req.MaxUses,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrPromotionCodeExists
+		}
+		s.log.Error("Failed to create promotion code", "error", err, "promotionID", promotionID)
+		return nil, err
+	}
+
+	actorID, _ := ctx.Value("user_id").(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeCREATE,
+		activitylog_repo.LogEntityTypePROMOTION,
+		promotionID.String(),
+		map[string]interface{}{
+			"promotion_code_id": code.ID,
+			"code":              code.Code,
+			"max_uses":          req.MaxUses,
+		},
+	)
+
+	resp := mapCodeResponse(code, 0)
+	return &resp, nil
+}
+
+// GeneratePromotionCodes creates a batch of random single-use codes in one transaction. A generated code
+// that is already taken is drawn again.
+func (s *PromotionService) GeneratePromotionCodes(ctx context.Context, promotionID uuid.UUID, req GeneratePromotionCodesRequest) (*GeneratePromotionCodesResponse, error) {
+	if _, err := s.repo.GetPromotionByID(ctx, promotionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
+		}
+		return nil, err
+	}
+
+	length := req.Length
+	if length == 0 {
+		length = defaultCodeLength
+	}
+	prefix := strings.ToUpper(req.Prefix)
+	batchID := uuid.New()
+	singleUse := int32(1)
+
+	resp := &GeneratePromotionCodesResponse{BatchID: batchID, Codes: make([]PromotionCodeResponse, 0, req.Count)}
+	err := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+		for len(resp.Codes) < req.Count {
+			var code repository.PromotionCode
+			for try := 0; ; try++ {
+				if try == maxCodeCollisionTries {
+					return fmt.Errorf("no free code found after %d tries, use a longer code", maxCodeCollisionTries)
+				}
+				random, err := randomCode(length)
+				if err != nil {
+					return err
+				}
+				code, err = qtx.CreatePromotionCode(ctx, repository.CreatePromotionCodeParams{
+					PromotionID: promotionID,
+					Code:        prefix + random,
+					BatchID:     pgtype.UUID{Bytes: batchID, Valid: true},
+					MaxUses:     &singleUse,
+				})
+				if err == nil {
+					break
+				}
+				if !errors.Is(err, pgx.ErrNoRows) {
+					return err
+				}
+			}
+			resp.Codes = append(resp.Codes, mapCodeResponse(code, 0))
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("Failed to generate promotion codes", "error", err, "promotionID", promotionID)
+		return nil, err
+	}
+
+	actorID, _ := ctx.Value("user_id").(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeCREATE,
+		activitylog_repo.LogEntityTypePROMOTION,
+		promotionID.String(),
+		map[string]interface{}{
+			"promotion_code_batch_id": batchID,
+			"generated_codes":         req.Count,
+		},
+	)
+
+	return resp, nil
+}
+
+func (s *PromotionService) ListPromotionCodes(ctx context.Context, promotionID uuid.UUID, req ListPromotionCodesRequest) (*PagedPromotionCodeResponse, error) {
+	page := 1
+	if req.Page != nil && *req.Page > 0 {
+		page = *req.Page
+	}
+	limit := 50
+	if req.Limit != nil && *req.Limit > 0 {
+		limit = *req.Limit
+	}
+
+	var batchID pgtype.UUID
+	if req.BatchID != nil {
+		batchID = pgtype.UUID{Bytes: *req.BatchID, Valid: true}
+	}
+
+	total, err := s.repo.CountPromotionCodes(ctx, repository.CountPromotionCodesParams{PromotionID: promotionID, BatchID: batchID})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.ListPromotionCodes(ctx, repository.ListPromotionCodesParams{
+		PromotionID: promotionID,
+		BatchID:     batchID,
+		Limit:       int32(limit),
+		Offset:      int32((page - 1) * limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]PromotionCodeResponse, len(rows))
+	for i, r := range rows {
+		codes[i] = mapCodeResponse(repository.PromotionCode{
+			ID:            r.ID,
+			PromotionID:   r.PromotionID,
+			Code:          r.Code,
+			BatchID:       r.BatchID,
+			MaxUses:       r.MaxUses,
+			CreatedAt:     r.CreatedAt,
+			DeactivatedAt: r.DeactivatedAt,
+		}, r.RedemptionCount)
+	}
+
+	return &PagedPromotionCodeResponse{
+		Codes:      codes,
+		Pagination: pagination.BuildPagination(page, int(total), limit),
+	}, nil
+}
+
+// DeactivatePromotionCode stops a code from being applied. Orders that already carry it keep it, and the
+// code stays reserved so it cannot be handed out again.
+func (s *PromotionService) DeactivatePromotionCode(ctx context.Context, promotionID, codeID uuid.UUID) (*PromotionCodeResponse, error) {
+	code, err := s.repo.DeactivatePromotionCode(ctx, repository.DeactivatePromotionCodeParams{ID: codeID, PromotionID: promotionID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrPromotionCodeNotFound
+		}
+		s.log.Error("Failed to deactivate promotion code", "error", err, "codeID", codeID)
+		return nil, err
+	}
+
+	actorID, _ := ctx.Value("user_id").(uuid.UUID)
+	s.activityService.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeDELETE,
+		activitylog_repo.LogEntityTypePROMOTION,
+		promotionID.String(),
+		map[string]interface{}{
+			"promotion_code_id": code.ID,
+			"code":              code.Code,
+		},
+	)
+
+	resp := mapCodeResponse(code, 0)
+	return &resp, nil
+}
+
+func randomCode(length int) (string, error) {
+	var b strings.Builder
+	max := big.NewInt(int64(len(codeAlphabet)))
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(codeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+func mapCodeResponse(c repository.PromotionCode, redemptions int64) PromotionCodeResponse {
+	var deactivatedAt *time.Time
+	if c.DeactivatedAt.Valid {
+		deactivatedAt = &c.DeactivatedAt.Time
+	}
+	return PromotionCodeResponse{
+		ID:              c.ID,
+		PromotionID:     c.PromotionID,
+		Code:            c.Code,
+		BatchID:         utils.NullableUUIDToPointer(c.BatchID),
+		MaxUses:         c.MaxUses,
+		RedemptionCount: redemptions,
+		IsActive:        !c.DeactivatedAt.Valid,
+		CreatedAt:       c.CreatedAt.Time,
+		DeactivatedAt:   deactivatedAt,
+	}
+}
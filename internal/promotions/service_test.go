@@ -5,6 +5,7 @@ import (
 	common "POS-kasir/internal/common"
 	"POS-kasir/internal/promotions"
 	promo_repo "POS-kasir/internal/promotions/repository"
+	"POS-kasir/internal/settings"
 	"POS-kasir/mocks"
 	"POS-kasir/pkg/utils"
 	"context"
@@ -41,7 +42,7 @@ func TestPromotionService_CreatePromotion(t *testing.T) {
 	assert.NoError(t, err)
 
 	repo := promo_repo.New(mockDB)
-	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService)
+	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService, newMockOperationalSettings(ctrl))
 
 	ctx := context.WithValue(context.Background(), "user_id", uuid.New())
 	userID := ctx.Value("user_id").(uuid.UUID)
@@ -85,9 +86,18 @@ func TestPromotionService_CreatePromotion(t *testing.T) {
 				pgtype.Timestamptz{Time: req.StartDate, Valid: true},
 				pgtype.Timestamptz{Time: req.EndDate, Valid: true},
 				req.IsActive,
+				req.TotalRedemptionLimit,
+				req.PerCustomerLimit,
+				req.BudgetAmount,
+				(*int32)(nil),
+				(*int32)(nil),
+				(*int32)(nil),
+				req.AutoApply,
+				promo_repo.PromotionStackingExclusive,
+				req.Priority,
 			).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at"}).
-				AddRow(promoID, req.Name, &req.Description, req.Scope, req.DiscountType, utils.Int64ToNumeric(req.DiscountValue), utils.Int64PtrToNumeric(req.MaxDiscountAmount), pgtype.Timestamptz{Time: req.StartDate, Valid: true}, pgtype.Timestamptz{Time: req.EndDate, Valid: true}, req.IsActive, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at", "total_redemption_limit", "per_customer_limit", "budget_amount", "buy_quantity", "get_quantity", "bundle_quantity", "auto_apply", "stacking", "priority"}).
+				AddRow(promoID, req.Name, &req.Description, req.Scope, req.DiscountType, utils.Int64ToNumeric(req.DiscountValue), utils.Int64PtrToNumeric(req.MaxDiscountAmount), pgtype.Timestamptz{Time: req.StartDate, Valid: true}, pgtype.Timestamptz{Time: req.EndDate, Valid: true}, req.IsActive, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}, nil, nil, nil, nil, nil, nil, false, promo_repo.PromotionStackingExclusive, int32(0)))
 
 		// Expect INSERT Promotion Rule on mockTx (recorded on mockDB)
 		mockDB.ExpectQuery("INSERT INTO promotion_rules").
@@ -105,10 +115,10 @@ func TestPromotionService_CreatePromotion(t *testing.T) {
 
 		// Expect GetPromotion queries on mockDB
 		// 1. GetPromotionByID
-		mockDB.ExpectQuery("SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at FROM promotions WHERE id = \\$1 LIMIT 1").
+		mockDB.ExpectQuery("SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority FROM promotions WHERE id = \\$1 LIMIT 1").
 			WithArgs(promoID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at"}).
-				AddRow(promoID, req.Name, &req.Description, req.Scope, req.DiscountType, utils.Int64ToNumeric(req.DiscountValue), utils.Int64PtrToNumeric(req.MaxDiscountAmount), pgtype.Timestamptz{Time: req.StartDate, Valid: true}, pgtype.Timestamptz{Time: req.EndDate, Valid: true}, req.IsActive, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at", "total_redemption_limit", "per_customer_limit", "budget_amount", "buy_quantity", "get_quantity", "bundle_quantity", "auto_apply", "stacking", "priority"}).
+				AddRow(promoID, req.Name, &req.Description, req.Scope, req.DiscountType, utils.Int64ToNumeric(req.DiscountValue), utils.Int64PtrToNumeric(req.MaxDiscountAmount), pgtype.Timestamptz{Time: req.StartDate, Valid: true}, pgtype.Timestamptz{Time: req.EndDate, Valid: true}, req.IsActive, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}, nil, nil, nil, nil, nil, nil, false, promo_repo.PromotionStackingExclusive, int32(0)))
 
 		// 2. GetPromotionRules
 		mockDB.ExpectQuery("SELECT id, promotion_id, rule_type, rule_value, description, created_at, updated_at FROM promotion_rules WHERE promotion_id = \\$1").
@@ -121,6 +131,11 @@ func TestPromotionService_CreatePromotion(t *testing.T) {
 			WithArgs(promoID).
 			WillReturnRows(pgxmock.NewRows([]string{"id", "promotion_id", "target_type", "target_id", "created_at", "updated_at"})) // Empty targets
 
+		mockDB.ExpectQuery("SELECT id, promotion_id, min_amount, discount_type, discount_value, created_at FROM promotion_tiers").
+			WithArgs(promoID).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "promotion_id", "min_amount", "discount_type", "discount_value", "created_at"}))
+		expectNoSchedule(mockDB, promoID)
+
 		resp, err := service.CreatePromotion(ctx, req)
 		assert.NoError(t, err)
 		assert.NotNil(t, resp)
@@ -158,7 +173,7 @@ func TestPromotionService_UpdatePromotion(t *testing.T) {
 	assert.NoError(t, err)
 
 	repo := promo_repo.New(mockDB)
-	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService)
+	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService, newMockOperationalSettings(ctrl))
 
 	ctx := context.WithValue(context.Background(), "user_id", uuid.New())
 	userID := ctx.Value("user_id").(uuid.UUID)
@@ -196,11 +211,23 @@ func TestPromotionService_UpdatePromotion(t *testing.T) {
 				pgtype.Timestamptz{Time: req.StartDate, Valid: true},
 				pgtype.Timestamptz{Time: req.EndDate, Valid: true},
 				req.IsActive,
+				req.TotalRedemptionLimit,
+				req.PerCustomerLimit,
+				req.BudgetAmount,
+				(*int32)(nil),
+				(*int32)(nil),
+				(*int32)(nil),
+				req.AutoApply,
+				promo_repo.PromotionStackingExclusive,
+				req.Priority,
 			).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at"}).
-				AddRow(promoID, req.Name, &req.Description, req.Scope, req.DiscountType, utils.Int64ToNumeric(req.DiscountValue), utils.Int64PtrToNumeric(req.MaxDiscountAmount), pgtype.Timestamptz{Time: req.StartDate, Valid: true}, pgtype.Timestamptz{Time: req.EndDate, Valid: true}, req.IsActive, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at", "total_redemption_limit", "per_customer_limit", "budget_amount", "buy_quantity", "get_quantity", "bundle_quantity", "auto_apply", "stacking", "priority"}).
+				AddRow(promoID, req.Name, &req.Description, req.Scope, req.DiscountType, utils.Int64ToNumeric(req.DiscountValue), utils.Int64PtrToNumeric(req.MaxDiscountAmount), pgtype.Timestamptz{Time: req.StartDate, Valid: true}, pgtype.Timestamptz{Time: req.EndDate, Valid: true}, req.IsActive, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}, nil, nil, nil, nil, nil, nil, false, promo_repo.PromotionStackingExclusive, int32(0)))
 
 		// Delete old rules/targets
+		mockDB.ExpectExec("DELETE FROM promotion_tiers").WithArgs(promoID).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mockDB.ExpectExec("DELETE FROM promotion_schedules").WithArgs(promoID).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mockDB.ExpectExec("DELETE FROM promotion_excluded_dates").WithArgs(promoID).WillReturnResult(pgxmock.NewResult("DELETE", 0))
 		mockDB.ExpectExec("DELETE FROM promotion_rules").WithArgs(promoID).WillReturnResult(pgxmock.NewResult("DELETE", 1))
 		mockDB.ExpectExec("DELETE FROM promotion_targets").WithArgs(promoID).WillReturnResult(pgxmock.NewResult("DELETE", 1))
 
@@ -209,10 +236,10 @@ func TestPromotionService_UpdatePromotion(t *testing.T) {
 		mockActivityService.EXPECT().Log(ctx, userID, repository.LogActionTypeUPDATE, repository.LogEntityTypePROMOTION, promoID.String(), gomock.Any())
 
 		// GetPromotion queries on mockDB
-		mockDB.ExpectQuery("SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at FROM promotions WHERE id = \\$1 LIMIT 1").
+		mockDB.ExpectQuery("SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority FROM promotions WHERE id = \\$1 LIMIT 1").
 			WithArgs(promoID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at"}).
-				AddRow(promoID, req.Name, &req.Description, req.Scope, req.DiscountType, utils.Int64ToNumeric(req.DiscountValue), utils.Int64PtrToNumeric(req.MaxDiscountAmount), pgtype.Timestamptz{Time: req.StartDate, Valid: true}, pgtype.Timestamptz{Time: req.EndDate, Valid: true}, req.IsActive, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at", "total_redemption_limit", "per_customer_limit", "budget_amount", "buy_quantity", "get_quantity", "bundle_quantity", "auto_apply", "stacking", "priority"}).
+				AddRow(promoID, req.Name, &req.Description, req.Scope, req.DiscountType, utils.Int64ToNumeric(req.DiscountValue), utils.Int64PtrToNumeric(req.MaxDiscountAmount), pgtype.Timestamptz{Time: req.StartDate, Valid: true}, pgtype.Timestamptz{Time: req.EndDate, Valid: true}, req.IsActive, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}, nil, nil, nil, nil, nil, nil, false, promo_repo.PromotionStackingExclusive, int32(0)))
 
 		mockDB.ExpectQuery("SELECT id, promotion_id, rule_type, rule_value, description, created_at, updated_at FROM promotion_rules").
 			WithArgs(promoID).
@@ -222,6 +249,11 @@ func TestPromotionService_UpdatePromotion(t *testing.T) {
 			WithArgs(promoID).
 			WillReturnRows(pgxmock.NewRows([]string{}))
 
+		mockDB.ExpectQuery("SELECT id, promotion_id, min_amount, discount_type, discount_value, created_at FROM promotion_tiers").
+			WithArgs(promoID).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "promotion_id", "min_amount", "discount_type", "discount_value", "created_at"}))
+		expectNoSchedule(mockDB, promoID)
+
 		resp, err := service.UpdatePromotion(ctx, promoID, req)
 		assert.NoError(t, err)
 		assert.Equal(t, req.Name, resp.Name)
@@ -267,7 +299,7 @@ func TestPromotionService_ListPromotions(t *testing.T) {
 	defer mockDB.Close()
 
 	repo := promo_repo.New(mockDB)
-	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService)
+	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService, newMockOperationalSettings(ctrl))
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -277,10 +309,10 @@ func TestPromotionService_ListPromotions(t *testing.T) {
 		mockDB.ExpectQuery("SELECT COUNT\\(\\*\\) FROM promotions WHERE deleted_at IS NULL").
 			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(1)))
 
-		mockDB.ExpectQuery("SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at FROM promotions WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
+		mockDB.ExpectQuery("SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority FROM promotions WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT \\$1 OFFSET \\$2").
 			WithArgs(int32(limit), int32(0)).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at"}).
-				AddRow(uuid.New(), "Promo 1", utils.StringPtr("Desc"), promo_repo.PromotionScopeORDER, promo_repo.DiscountTypePercentage, utils.Int64ToNumeric(10), utils.Int64ToNumeric(5000), pgtype.Timestamptz{Time: time.Now(), Valid: true}, pgtype.Timestamptz{Time: time.Now(), Valid: true}, true, pgtype.Timestamptz{Time: time.Now(), Valid: true}, pgtype.Timestamptz{Time: time.Now(), Valid: true}, pgtype.Timestamptz{}))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at", "total_redemption_limit", "per_customer_limit", "budget_amount", "buy_quantity", "get_quantity", "bundle_quantity", "auto_apply", "stacking", "priority"}).
+				AddRow(uuid.New(), "Promo 1", utils.StringPtr("Desc"), promo_repo.PromotionScopeORDER, promo_repo.DiscountTypePercentage, utils.Int64ToNumeric(10), utils.Int64ToNumeric(5000), pgtype.Timestamptz{Time: time.Now(), Valid: true}, pgtype.Timestamptz{Time: time.Now(), Valid: true}, true, pgtype.Timestamptz{Time: time.Now(), Valid: true}, pgtype.Timestamptz{Time: time.Now(), Valid: true}, pgtype.Timestamptz{}, nil, nil, nil, nil, nil, nil, false, promo_repo.PromotionStackingExclusive, int32(0)))
 
 		// Get rules and targets for the promotion
 		mockDB.ExpectQuery("SELECT id, promotion_id, rule_type, rule_value, description, created_at, updated_at FROM promotion_rules").
@@ -289,6 +321,11 @@ func TestPromotionService_ListPromotions(t *testing.T) {
 			WithArgs(mock.Anything). // Hard to predict ID here without capturing it, but regex match on query is enough usually. Or match arg type.
 			WillReturnRows(pgxmock.NewRows([]string{}))
 
+		mockDB.ExpectQuery("SELECT id, promotion_id, min_amount, discount_type, discount_value, created_at FROM promotion_tiers").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "promotion_id", "min_amount", "discount_type", "discount_value", "created_at"}))
+		expectNoSchedule(mockDB, pgxmock.AnyArg())
+
 		resp, err := service.ListPromotions(ctx, promotions.ListPromotionsRequest{Page: &page, Limit: &limit})
 		assert.NoError(t, err)
 		assert.Len(t, resp.Promotions, 1)
@@ -307,7 +344,7 @@ func TestPromotionService_DeletePromotion(t *testing.T) {
 	defer mockDB.Close()
 
 	repo := promo_repo.New(mockDB)
-	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService)
+	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService, newMockOperationalSettings(ctrl))
 	ctx := context.Background()
 	promoID := uuid.New()
 
@@ -343,16 +380,16 @@ func TestPromotionService_GetPromotion(t *testing.T) {
 	defer mockDB.Close()
 
 	repo := promo_repo.New(mockDB)
-	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService)
+	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService, newMockOperationalSettings(ctrl))
 	ctx := context.Background()
 	promoID := uuid.New()
 	now := time.Now()
 
 	t.Run("Success", func(t *testing.T) {
-		mockDB.ExpectQuery("SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at FROM promotions WHERE id = \\$1 LIMIT 1").
+		mockDB.ExpectQuery("SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority FROM promotions WHERE id = \\$1 LIMIT 1").
 			WithArgs(promoID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at"}).
-				AddRow(promoID, "Promo Get", utils.StringPtr("Desc"), promo_repo.PromotionScopeORDER, promo_repo.DiscountTypePercentage, utils.Int64ToNumeric(10), nil, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, true, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at", "total_redemption_limit", "per_customer_limit", "budget_amount", "buy_quantity", "get_quantity", "bundle_quantity", "auto_apply", "stacking", "priority"}).
+				AddRow(promoID, "Promo Get", utils.StringPtr("Desc"), promo_repo.PromotionScopeORDER, promo_repo.DiscountTypePercentage, utils.Int64ToNumeric(10), nil, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, true, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}, nil, nil, nil, nil, nil, nil, false, promo_repo.PromotionStackingExclusive, int32(0)))
 
 		mockDB.ExpectQuery("SELECT id, promotion_id, rule_type, rule_value, description, created_at, updated_at FROM promotion_rules WHERE promotion_id = \\$1").
 			WithArgs(promoID).
@@ -362,13 +399,18 @@ func TestPromotionService_GetPromotion(t *testing.T) {
 			WithArgs(promoID).
 			WillReturnRows(pgxmock.NewRows([]string{"id", "promotion_id", "target_type", "target_id", "created_at", "updated_at"}))
 
+		mockDB.ExpectQuery("SELECT id, promotion_id, min_amount, discount_type, discount_value, created_at FROM promotion_tiers").
+			WithArgs(promoID).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "promotion_id", "min_amount", "discount_type", "discount_value", "created_at"}))
+		expectNoSchedule(mockDB, promoID)
+
 		resp, err := service.GetPromotion(ctx, promoID)
 		assert.NoError(t, err)
 		assert.Equal(t, "Promo Get", resp.Name)
 	})
 
 	t.Run("NotFound", func(t *testing.T) {
-		mockDB.ExpectQuery("SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at FROM promotions WHERE id = \\$1 LIMIT 1").
+		mockDB.ExpectQuery("SELECT id, name, description, scope, discount_type, discount_value, max_discount_amount, start_date, end_date, is_active, created_at, updated_at, deleted_at, total_redemption_limit, per_customer_limit, budget_amount, buy_quantity, get_quantity, bundle_quantity, auto_apply, stacking, priority FROM promotions WHERE id = \\$1 LIMIT 1").
 			WithArgs(promoID).
 			WillReturnError(pgx.ErrNoRows)
 
@@ -391,7 +433,7 @@ func TestPromotionService_RestorePromotion(t *testing.T) {
 	defer mockDB.Close()
 
 	repo := promo_repo.New(mockDB)
-	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService)
+	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService, newMockOperationalSettings(ctrl))
 	ctx := context.WithValue(context.Background(), "user_id", uuid.New())
 	userID := ctx.Value("user_id").(uuid.UUID)
 	promoID := uuid.New()
@@ -407,3 +449,86 @@ func TestPromotionService_RestorePromotion(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestPromotionService_PromotionCodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	mockActivityService := mocks.NewMockIActivityService(ctrl)
+	mockDB, err := pgxmock.NewPool()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	repo := promo_repo.New(mockDB)
+	service := promotions.NewPromotionService(mockStore, repo, mockLogger, mockActivityService, newMockOperationalSettings(ctrl))
+	ctx := context.WithValue(context.Background(), "user_id", uuid.New())
+	promoID := uuid.New()
+	codeID := uuid.New()
+	now := time.Now()
+
+	promotionColumns := []string{"id", "name", "description", "scope", "discount_type", "discount_value", "max_discount_amount", "start_date", "end_date", "is_active", "created_at", "updated_at", "deleted_at", "total_redemption_limit", "per_customer_limit", "budget_amount", "buy_quantity", "get_quantity", "bundle_quantity", "auto_apply", "stacking", "priority"}
+	promotionRow := func() *pgxmock.Rows {
+		return pgxmock.NewRows(promotionColumns).
+			AddRow(promoID, "Launch", nil, promo_repo.PromotionScopeORDER, promo_repo.DiscountTypePercentage, utils.Int64ToNumeric(10), utils.Int64PtrToNumeric(nil), pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true}, true, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}, nil, nil, nil, nil, nil, nil, false, promo_repo.PromotionStackingExclusive, int32(0))
+	}
+	codeColumns := []string{"id", "promotion_id", "code", "batch_id", "max_uses", "created_at", "deactivated_at"}
+
+	t.Run("CreateCode", func(t *testing.T) {
+		maxUses := int32(100)
+		mockDB.ExpectQuery("FROM promotions").WithArgs(promoID).WillReturnRows(promotionRow())
+		mockDB.ExpectQuery("INSERT INTO promotion_codes").
+			WithArgs(promoID, "launch100", pgtype.UUID{}, &maxUses).
+			WillReturnRows(pgxmock.NewRows(codeColumns).AddRow(codeID, promoID, "LAUNCH100", pgtype.UUID{}, &maxUses, pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{}))
+		mockActivityService.EXPECT().Log(ctx, gomock.Any(), repository.LogActionTypeCREATE, repository.LogEntityTypePROMOTION, promoID.String(), gomock.Any())
+
+		resp, err := service.CreatePromotionCode(ctx, promoID, promotions.CreatePromotionCodeRequest{Code: "launch100", MaxUses: &maxUses})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "LAUNCH100", resp.Code)
+		assert.True(t, resp.IsActive)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("CreateCodeTaken", func(t *testing.T) {
+		mockDB.ExpectQuery("FROM promotions").WithArgs(promoID).WillReturnRows(promotionRow())
+		// ON CONFLICT DO NOTHING returns no row for a taken code
+		mockDB.ExpectQuery("INSERT INTO promotion_codes").
+			WithArgs(promoID, "LAUNCH100", pgtype.UUID{}, (*int32)(nil)).
+			WillReturnRows(pgxmock.NewRows(codeColumns))
+
+		resp, err := service.CreatePromotionCode(ctx, promoID, promotions.CreatePromotionCodeRequest{Code: "LAUNCH100"})
+
+		assert.ErrorIs(t, err, common.ErrPromotionCodeExists)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("DeactivateCodeNotFound", func(t *testing.T) {
+		mockDB.ExpectQuery("UPDATE promotion_codes").
+			WithArgs(codeID, promoID).
+			WillReturnError(pgx.ErrNoRows)
+
+		resp, err := service.DeactivatePromotionCode(ctx, promoID, codeID)
+
+		assert.ErrorIs(t, err, common.ErrPromotionCodeNotFound)
+		assert.Nil(t, resp)
+	})
+}
+
+func newMockOperationalSettings(ctrl *gomock.Controller) *mocks.MockISettingsService {
+	mockSettings := mocks.NewMockISettingsService(ctrl)
+	mockSettings.EXPECT().GetOperationalSettings(gomock.Any()).Return(&settings.OperationalSettingsResponse{
+		Timezone: "Asia/Jakarta",
+	}, nil).AnyTimes()
+	return mockSettings
+}
+
+func expectNoSchedule(mockDB pgxmock.PgxPoolIface, promoID any) {
+	mockDB.ExpectQuery("SELECT id, promotion_id, days_of_week, start_time, end_time, created_at FROM promotion_schedules").
+		WithArgs(promoID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "promotion_id", "days_of_week", "start_time", "end_time", "created_at"}))
+	mockDB.ExpectQuery("SELECT promotion_id, excluded_date FROM promotion_excluded_dates").
+		WithArgs(promoID).
+		WillReturnRows(pgxmock.NewRows([]string{"promotion_id", "excluded_date"}))
+}
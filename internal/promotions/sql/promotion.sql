@@ -8,9 +8,18 @@ INSERT INTO promotions (
     max_discount_amount,
     start_date,
     end_date,
-    is_active
+    is_active,
+    total_redemption_limit,
+    per_customer_limit,
+    budget_amount,
+    buy_quantity,
+    get_quantity,
+    bundle_quantity,
+    auto_apply,
+    stacking,
+    priority
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
 ) RETURNING *;
 
 -- name: UpdatePromotion :one
@@ -25,6 +34,15 @@ SET
     start_date = $8,
     end_date = $9,
     is_active = $10,
+    total_redemption_limit = $11,
+    per_customer_limit = $12,
+    budget_amount = $13,
+    buy_quantity = $14,
+    get_quantity = $15,
+    bundle_quantity = $16,
+    auto_apply = $17,
+    stacking = $18,
+    priority = $19,
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -106,3 +124,92 @@ WHERE promotion_id = $1;
 -- name: DeletePromotionTargetsByPromotionID :exec
 DELETE FROM promotion_targets
 WHERE promotion_id = $1;
+
+
+-- name: CreatePromotionTier :one
+INSERT INTO promotion_tiers (
+    promotion_id,
+    min_amount,
+    discount_type,
+    discount_value
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetPromotionTiers :many
+-- Mengambil semua tingkatan diskon sebuah promosi, dari ambang terendah.
+SELECT * FROM promotion_tiers
+WHERE promotion_id = $1
+ORDER BY min_amount;
+
+-- name: DeletePromotionTiersByPromotionID :exec
+DELETE FROM promotion_tiers
+WHERE promotion_id = $1;
+
+
+-- name: CreatePromotionSchedule :one
+INSERT INTO promotion_schedules (
+    promotion_id,
+    days_of_week,
+    start_time,
+    end_time
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetPromotionSchedules :many
+-- Mengambil jadwal berulang sebuah promosi.
+SELECT * FROM promotion_schedules
+WHERE promotion_id = $1
+ORDER BY start_time, id;
+
+-- name: DeletePromotionSchedulesByPromotionID :exec
+DELETE FROM promotion_schedules
+WHERE promotion_id = $1;
+
+-- name: CreatePromotionExcludedDate :exec
+INSERT INTO promotion_excluded_dates (promotion_id, excluded_date)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: GetPromotionExcludedDates :many
+-- Mengambil tanggal-tanggal di mana promosi tidak berlaku.
+SELECT * FROM promotion_excluded_dates
+WHERE promotion_id = $1
+ORDER BY excluded_date;
+
+-- name: DeletePromotionExcludedDatesByPromotionID :exec
+DELETE FROM promotion_excluded_dates
+WHERE promotion_id = $1;
+
+
+-- name: CreatePromotionCode :one
+-- Creates a code; returns no row when the code is already taken.
+INSERT INTO promotion_codes (promotion_id, code, batch_id, max_uses)
+VALUES (sqlc.arg(promotion_id), upper(sqlc.arg(code)), sqlc.narg(batch_id), sqlc.narg(max_uses))
+ON CONFLICT (code) DO NOTHING
+RETURNING *;
+
+-- name: ListPromotionCodes :many
+-- Lists a promotion's codes with how often each was redeemed, optionally of one batch.
+SELECT
+    pc.*,
+    COUNT(pr.id) AS redemption_count
+FROM promotion_codes pc
+LEFT JOIN promotion_redemptions pr ON pr.promotion_code_id = pc.id AND pr.status = 'redeemed'
+WHERE pc.promotion_id = sqlc.arg(promotion_id)
+  AND (sqlc.narg(batch_id)::uuid IS NULL OR pc.batch_id = sqlc.narg(batch_id))
+GROUP BY pc.id
+ORDER BY pc.created_at DESC, pc.code
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountPromotionCodes :one
+SELECT COUNT(*) FROM promotion_codes
+WHERE promotion_id = sqlc.arg(promotion_id)
+  AND (sqlc.narg(batch_id)::uuid IS NULL OR batch_id = sqlc.narg(batch_id));
+
+-- name: DeactivatePromotionCode :one
+UPDATE promotion_codes
+SET deactivated_at = NOW()
+WHERE id = $1 AND promotion_id = $2 AND deactivated_at IS NULL
+RETURNING *;
@@ -0,0 +1,137 @@
+package purchasing
+
+import (
+	"POS-kasir/internal/common/pagination"
+	"POS-kasir/internal/purchasing/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateSupplierRequest struct {
+	Name        string  `json:"name" validate:"required,min=2,max=100"`
+	ContactName *string `json:"contact_name" validate:"omitempty,max=100"`
+	Phone       *string `json:"phone" validate:"omitempty,max=20"`
+	Email       *string `json:"email" validate:"omitempty,email,max=255"`
+	Address     *string `json:"address" validate:"omitempty"`
+}
+
+type UpdateSupplierRequest struct {
+	Name        string  `json:"name" validate:"required,min=2,max=100"`
+	ContactName *string `json:"contact_name" validate:"omitempty,max=100"`
+	Phone       *string `json:"phone" validate:"omitempty,max=20"`
+	Email       *string `json:"email" validate:"omitempty,email,max=255"`
+	Address     *string `json:"address" validate:"omitempty"`
+}
+
+type ListSuppliersRequest struct {
+	pagination.PaginationRequest
+}
+
+type SupplierResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	ContactName *string   `json:"contact_name,omitempty"`
+	Phone       *string   `json:"phone,omitempty"`
+	Email       *string   `json:"email,omitempty"`
+	Address     *string   `json:"address,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type PagedSupplierResponse struct {
+	Suppliers  []SupplierResponse    `json:"suppliers"`
+	Pagination pagination.Pagination `json:"pagination"`
+}
+
+type PurchaseOrderItemRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int32     `json:"quantity" validate:"required,gt=0"`
+	UnitCost  float64   `json:"unit_cost" validate:"gte=0"`
+}
+
+// PurchaseOrderRequest creates a draft purchase order, or replaces one while it is still a draft.
+type PurchaseOrderRequest struct {
+	SupplierID   uuid.UUID                  `json:"supplier_id" validate:"required"`
+	ExpectedDate *string                    `json:"expected_date" validate:"omitempty,datetime=2006-01-02"`
+	Notes        *string                    `json:"notes" validate:"omitempty,max=500"`
+	Items        []PurchaseOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type ListPurchaseOrdersRequest struct {
+	pagination.PaginationRequest
+	Status     *repository.PurchaseOrderStatus `query:"status" validate:"omitempty,oneof=draft sent partially_received received closed"`
+	SupplierID *uuid.UUID                      `query:"supplier_id"`
+}
+
+// GoodsReceiptItemRequest receives part or all of what is still outstanding on a PO line. UnitCost is the
+// invoiced cost; when omitted the PO price is used.
+type GoodsReceiptItemRequest struct {
+	PurchaseOrderItemID uuid.UUID `json:"purchase_order_item_id" validate:"required"`
+	Quantity            int32     `json:"quantity" validate:"required,gt=0"`
+	UnitCost            *float64  `json:"unit_cost" validate:"omitempty,gte=0"`
+}
+
+type ReceivePurchaseOrderRequest struct {
+	SupplierReference *string                   `json:"supplier_reference" validate:"omitempty,max=100"`
+	Notes             *string                   `json:"notes" validate:"omitempty,max=500"`
+	Items             []GoodsReceiptItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type PurchaseOrderItemResponse struct {
+	ID                  uuid.UUID `json:"id"`
+	ProductID           uuid.UUID `json:"product_id"`
+	ProductName         string    `json:"product_name"`
+	QuantityOrdered     int32     `json:"quantity_ordered"`
+	QuantityReceived    int32     `json:"quantity_received"`
+	QuantityOutstanding int32     `json:"quantity_outstanding"`
+	UnitCost            float64   `json:"unit_cost"`
+	LineTotal           float64   `json:"line_total"`
+}
+
+type GoodsReceiptItemResponse struct {
+	ID                  uuid.UUID `json:"id"`
+	PurchaseOrderItemID uuid.UUID `json:"purchase_order_item_id"`
+	ProductID           uuid.UUID `json:"product_id"`
+	ProductName         string    `json:"product_name"`
+	Quantity            int32     `json:"quantity"`
+	UnitCost            float64   `json:"unit_cost"`
+}
+
+type GoodsReceiptResponse struct {
+	ID                uuid.UUID                  `json:"id"`
+	ReceiptNumber     string                     `json:"receipt_number"`
+	SupplierReference *string                    `json:"supplier_reference,omitempty"`
+	Notes             *string                    `json:"notes,omitempty"`
+	ReceivedBy        *uuid.UUID                 `json:"received_by,omitempty"`
+	ReceivedAt        time.Time                  `json:"received_at"`
+	Items             []GoodsReceiptItemResponse `json:"items"`
+}
+
+type PurchaseOrderResponse struct {
+	ID           uuid.UUID                      `json:"id"`
+	PoNumber     string                         `json:"po_number"`
+	SupplierID   uuid.UUID                      `json:"supplier_id"`
+	SupplierName string                         `json:"supplier_name"`
+	Status       repository.PurchaseOrderStatus `json:"status"`
+	ExpectedDate *string                        `json:"expected_date,omitempty"`
+	Notes        *string                        `json:"notes,omitempty"`
+	TotalCost    float64                        `json:"total_cost"`
+	CreatedBy    *uuid.UUID                     `json:"created_by,omitempty"`
+	SentAt       *time.Time                     `json:"sent_at,omitempty"`
+	ReceivedAt   *time.Time                     `json:"received_at,omitempty"`
+	ClosedAt     *time.Time                     `json:"closed_at,omitempty"`
+	CreatedAt    time.Time                      `json:"created_at"`
+	UpdatedAt    time.Time                      `json:"updated_at"`
+}
+
+type PurchaseOrderDetailResponse struct {
+	PurchaseOrderResponse
+	Items    []PurchaseOrderItemResponse `json:"items"`
+	Receipts []GoodsReceiptResponse      `json:"receipts"`
+}
+
+type PagedPurchaseOrderResponse struct {
+	PurchaseOrders []PurchaseOrderResponse `json:"purchase_orders"`
+	Pagination     pagination.Pagination   `json:"pagination"`
+}
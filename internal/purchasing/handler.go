@@ -0,0 +1,469 @@
+package purchasing
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/pkg/logger"
+	"POS-kasir/pkg/validator"
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type IPurchasingHandler interface {
+	ListSuppliersHandler(c fiber.Ctx) error
+	GetSupplierHandler(c fiber.Ctx) error
+	CreateSupplierHandler(c fiber.Ctx) error
+	UpdateSupplierHandler(c fiber.Ctx) error
+	DeleteSupplierHandler(c fiber.Ctx) error
+	ListPurchaseOrdersHandler(c fiber.Ctx) error
+	GetPurchaseOrderHandler(c fiber.Ctx) error
+	CreatePurchaseOrderHandler(c fiber.Ctx) error
+	UpdatePurchaseOrderHandler(c fiber.Ctx) error
+	SendPurchaseOrderHandler(c fiber.Ctx) error
+	ClosePurchaseOrderHandler(c fiber.Ctx) error
+	ReceivePurchaseOrderHandler(c fiber.Ctx) error
+}
+
+type PurchasingHandler struct {
+	service IPurchasingService
+	log     logger.ILogger
+}
+
+func NewPurchasingHandler(service IPurchasingService, log logger.ILogger) IPurchasingHandler {
+	return &PurchasingHandler{service: service, log: log}
+}
+
+// ListSuppliersHandler
+// @Summary      List suppliers
+// @Description  Get suppliers, optionally filtered by name (Roles: admin, manager, cashier)
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        search query string false "Search by name"
+// @Success      200 {object} common.SuccessResponse{data=PagedSupplierResponse} "Suppliers retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid query parameters"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /suppliers [get]
+func (h *PurchasingHandler) ListSuppliersHandler(c fiber.Ctx) error {
+	var req ListSuppliersRequest
+	if err := c.Bind().Query(&req); err != nil {
+		h.log.Warnf("ListSuppliersHandler | Failed to parse query parameters: %v", err)
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid query parameters"})
+	}
+
+	suppliers, err := h.service.ListSuppliers(c.RequestCtx(), req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve suppliers"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Suppliers retrieved successfully",
+		Data:    suppliers,
+	})
+}
+
+// GetSupplierHandler
+// @Summary      Get supplier by ID
+// @Description  Get a single supplier with its contact details (Roles: admin, manager, cashier)
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Supplier ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=SupplierResponse} "Supplier retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid supplier ID"
+// @Failure      404 {object} common.ErrorResponse "Supplier not found"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /suppliers/{id} [get]
+func (h *PurchasingHandler) GetSupplierHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid supplier ID format"})
+	}
+
+	supplier, err := h.service.GetSupplier(c.RequestCtx(), id)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Supplier not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve supplier"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Supplier retrieved successfully",
+		Data:    supplier,
+	})
+}
+
+// CreateSupplierHandler
+// @Summary      Create supplier
+// @Description  Create a supplier to order stock from (Roles: admin, manager)
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Param        request body CreateSupplierRequest true "Supplier details"
+// @Success      201 {object} common.SuccessResponse{data=SupplierResponse} "Supplier created successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body"
+// @Failure      409 {object} common.ErrorResponse "Supplier with this name already exists"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /suppliers [post]
+func (h *PurchasingHandler) CreateSupplierHandler(c fiber.Ctx) error {
+	var req CreateSupplierRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("CreateSupplierHandler | Failed to parse request body: %v", err)
+		return h.bindError(c, err)
+	}
+
+	supplier, err := h.service.CreateSupplier(c.RequestCtx(), req)
+	if err != nil {
+		if errors.Is(err, common.ErrSupplierExists) {
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to create supplier"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(common.SuccessResponse{
+		Message: "Supplier created successfully",
+		Data:    supplier,
+	})
+}
+
+// UpdateSupplierHandler
+// @Summary      Update supplier
+// @Description  Replace a supplier's name and contact details (Roles: admin, manager)
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Supplier ID" Format(uuid)
+// @Param        request body UpdateSupplierRequest true "Supplier details"
+// @Success      200 {object} common.SuccessResponse{data=SupplierResponse} "Supplier updated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid supplier ID or request body"
+// @Failure      404 {object} common.ErrorResponse "Supplier not found"
+// @Failure      409 {object} common.ErrorResponse "Supplier with this name already exists"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /suppliers/{id} [put]
+func (h *PurchasingHandler) UpdateSupplierHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid supplier ID format"})
+	}
+
+	var req UpdateSupplierRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("UpdateSupplierHandler | Failed to parse request body: %v", err)
+		return h.bindError(c, err)
+	}
+
+	supplier, err := h.service.UpdateSupplier(c.RequestCtx(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Supplier not found"})
+		case errors.Is(err, common.ErrSupplierExists):
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to update supplier"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Supplier updated successfully",
+		Data:    supplier,
+	})
+}
+
+// DeleteSupplierHandler
+// @Summary      Delete supplier
+// @Description  Delete a supplier that has no purchase orders waiting to be sent or received (Roles: admin, manager)
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Supplier ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse "Supplier deleted successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid supplier ID"
+// @Failure      404 {object} common.ErrorResponse "Supplier not found"
+// @Failure      409 {object} common.ErrorResponse "Supplier has open purchase orders"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /suppliers/{id} [delete]
+func (h *PurchasingHandler) DeleteSupplierHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid supplier ID format"})
+	}
+
+	if err := h.service.DeleteSupplier(c.RequestCtx(), id); err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Supplier not found"})
+		case errors.Is(err, common.ErrSupplierInUse):
+			return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to delete supplier"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{Message: "Supplier deleted successfully"})
+}
+
+// ListPurchaseOrdersHandler
+// @Summary      List purchase orders
+// @Description  Get purchase orders newest first with their supplier and total cost, filtered by status or supplier (Roles: admin, manager, cashier)
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        status query string false "Filter by status" Enums(draft, sent, partially_received, received, closed)
+// @Param        supplier_id query string false "Filter by supplier" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=PagedPurchaseOrderResponse} "Purchase orders retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid query parameters"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /purchase-orders [get]
+func (h *PurchasingHandler) ListPurchaseOrdersHandler(c fiber.Ctx) error {
+	var req ListPurchaseOrdersRequest
+	if err := c.Bind().Query(&req); err != nil {
+		h.log.Warnf("ListPurchaseOrdersHandler | Failed to parse query parameters: %v", err)
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid query parameters"})
+	}
+
+	orders, err := h.service.ListPurchaseOrders(c.RequestCtx(), req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve purchase orders"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Purchase orders retrieved successfully",
+		Data:    orders,
+	})
+}
+
+// GetPurchaseOrderHandler
+// @Summary      Get purchase order by ID
+// @Description  Get a purchase order with its lines, what is still outstanding and every goods receipt booked against it (Roles: admin, manager, cashier)
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Purchase Order ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=PurchaseOrderDetailResponse} "Purchase order retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid purchase order ID"
+// @Failure      404 {object} common.ErrorResponse "Purchase order not found"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /purchase-orders/{id} [get]
+func (h *PurchasingHandler) GetPurchaseOrderHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid purchase order ID format"})
+	}
+
+	po, err := h.service.GetPurchaseOrder(c.RequestCtx(), id)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Purchase order not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve purchase order"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Purchase order retrieved successfully",
+		Data:    po,
+	})
+}
+
+// CreatePurchaseOrderHandler
+// @Summary      Create purchase order
+// @Description  Create a draft purchase order. Each product may appear once, with the quantity and agreed unit cost (Roles: admin, manager)
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Param        request body PurchaseOrderRequest true "Purchase order details"
+// @Success      201 {object} common.SuccessResponse{data=PurchaseOrderDetailResponse} "Purchase order created successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body, unknown supplier or products"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /purchase-orders [post]
+func (h *PurchasingHandler) CreatePurchaseOrderHandler(c fiber.Ctx) error {
+	var req PurchaseOrderRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("CreatePurchaseOrderHandler | Failed to parse request body: %v", err)
+		return h.bindError(c, err)
+	}
+
+	po, err := h.service.CreatePurchaseOrder(c.RequestCtx(), req)
+	if err != nil {
+		return h.purchaseOrderError(c, err, "Failed to create purchase order")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(common.SuccessResponse{
+		Message: "Purchase order created successfully",
+		Data:    po,
+	})
+}
+
+// UpdatePurchaseOrderHandler
+// @Summary      Update purchase order
+// @Description  Replace the supplier, expected date, notes and lines of a purchase order that is still a draft (Roles: admin, manager)
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Purchase Order ID" Format(uuid)
+// @Param        request body PurchaseOrderRequest true "Purchase order details"
+// @Success      200 {object} common.SuccessResponse{data=PurchaseOrderDetailResponse} "Purchase order updated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid purchase order ID, request body, unknown supplier or products"
+// @Failure      404 {object} common.ErrorResponse "Purchase order not found"
+// @Failure      409 {object} common.ErrorResponse "Purchase order is no longer a draft"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /purchase-orders/{id} [put]
+func (h *PurchasingHandler) UpdatePurchaseOrderHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid purchase order ID format"})
+	}
+
+	var req PurchaseOrderRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("UpdatePurchaseOrderHandler | Failed to parse request body: %v", err)
+		return h.bindError(c, err)
+	}
+
+	po, err := h.service.UpdatePurchaseOrder(c.RequestCtx(), id, req)
+	if err != nil {
+		return h.purchaseOrderError(c, err, "Failed to update purchase order")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Purchase order updated successfully",
+		Data:    po,
+	})
+}
+
+// SendPurchaseOrderHandler
+// @Summary      Send purchase order
+// @Description  Mark a draft purchase order as sent to the supplier. Its lines can no longer be changed and it can be received (Roles: admin, manager)
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Purchase Order ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=PurchaseOrderDetailResponse} "Purchase order sent successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid purchase order ID"
+// @Failure      404 {object} common.ErrorResponse "Purchase order not found"
+// @Failure      409 {object} common.ErrorResponse "Purchase order is not a draft"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /purchase-orders/{id}/send [post]
+func (h *PurchasingHandler) SendPurchaseOrderHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid purchase order ID format"})
+	}
+
+	po, err := h.service.SendPurchaseOrder(c.RequestCtx(), id)
+	if err != nil {
+		return h.purchaseOrderError(c, err, "Failed to send purchase order")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Purchase order sent successfully",
+		Data:    po,
+	})
+}
+
+// ClosePurchaseOrderHandler
+// @Summary      Close purchase order
+// @Description  Close a purchase order. A draft is cancelled; a sent order stops waiting for what is still outstanding (Roles: admin, manager)
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Purchase Order ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=PurchaseOrderDetailResponse} "Purchase order closed successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid purchase order ID"
+// @Failure      404 {object} common.ErrorResponse "Purchase order not found"
+// @Failure      409 {object} common.ErrorResponse "Purchase order is already closed"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /purchase-orders/{id}/close [post]
+func (h *PurchasingHandler) ClosePurchaseOrderHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid purchase order ID format"})
+	}
+
+	po, err := h.service.ClosePurchaseOrder(c.RequestCtx(), id)
+	if err != nil {
+		return h.purchaseOrderError(c, err, "Failed to close purchase order")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Purchase order closed successfully",
+		Data:    po,
+	})
+}
+
+// ReceivePurchaseOrderHandler
+// @Summary      Receive goods
+// @Description  Book a goods receipt against a sent purchase order. Received quantities are added to product stock as restocks referencing the purchase order, and each product's cost becomes the received unit cost. The order becomes partially_received or received (Roles: admin, manager)
+// @Tags         Purchasing
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Purchase Order ID" Format(uuid)
+// @Param        request body ReceivePurchaseOrderRequest true "Received lines"
+// @Success      200 {object} common.SuccessResponse{data=PurchaseOrderDetailResponse} "Goods received successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid purchase order ID, request body, or lines exceeding what is outstanding"
+// @Failure      404 {object} common.ErrorResponse "Purchase order not found"
+// @Failure      409 {object} common.ErrorResponse "Purchase order is not waiting for goods"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /purchase-orders/{id}/receive [post]
+func (h *PurchasingHandler) ReceivePurchaseOrderHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid purchase order ID format"})
+	}
+
+	var req ReceivePurchaseOrderRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("ReceivePurchaseOrderHandler | Failed to parse request body: %v", err)
+		return h.bindError(c, err)
+	}
+
+	po, err := h.service.ReceivePurchaseOrder(c.RequestCtx(), id, req)
+	if err != nil {
+		return h.purchaseOrderError(c, err, "Failed to receive goods")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Goods received successfully",
+		Data:    po,
+	})
+}
+
+func (h *PurchasingHandler) bindError(c fiber.Ctx, err error) error {
+	var ve *validator.ValidationErrors
+	if errors.As(err, &ve) {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+			Message: "Validation failed",
+			Error:   ve.Error(),
+			Data:    map[string]interface{}{"errors": ve.Errors},
+		})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+}
+
+func (h *PurchasingHandler) purchaseOrderError(c fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, common.ErrNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Purchase order not found"})
+	case errors.Is(err, common.ErrSupplierNotFound), errors.Is(err, common.ErrPurchaseOrderInvalid), errors.Is(err, common.ErrGoodsReceiptInvalid):
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, common.ErrPurchaseOrderStatus):
+		return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: fallback})
+}
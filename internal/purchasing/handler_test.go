@@ -0,0 +1,107 @@
+package purchasing_test
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/purchasing"
+	"POS-kasir/internal/purchasing/repository"
+	"POS-kasir/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurchasingHandler_CreateSupplierHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIPurchasingService(ctrl)
+	handler := purchasing.NewPurchasingHandler(mockService, mocks.NewMockILogger(ctrl))
+
+	app := fiber.New()
+	app.Post("/suppliers", handler.CreateSupplierHandler)
+
+	body := `{"name":"Kopi Nusantara"}`
+	expectedReq := purchasing.CreateSupplierRequest{Name: "Kopi Nusantara"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().CreateSupplier(gomock.Any(), expectedReq).
+			Return(&purchasing.SupplierResponse{ID: uuid.New(), Name: "Kopi Nusantara"}, nil)
+
+		req := httptest.NewRequest("POST", "/suppliers", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("NameTaken", func(t *testing.T) {
+		mockService.EXPECT().CreateSupplier(gomock.Any(), expectedReq).Return(nil, common.ErrSupplierExists)
+
+		req := httptest.NewRequest("POST", "/suppliers", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestPurchasingHandler_ReceivePurchaseOrderHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIPurchasingService(ctrl)
+	handler := purchasing.NewPurchasingHandler(mockService, mocks.NewMockILogger(ctrl))
+
+	app := fiber.New()
+	app.Post("/purchase-orders/:id/receive", handler.ReceivePurchaseOrderHandler)
+
+	id := uuid.New()
+	itemID := uuid.New()
+	body := `{"items":[{"purchase_order_item_id":"` + itemID.String() + `","quantity":4}]}`
+	expectedReq := purchasing.ReceivePurchaseOrderRequest{Items: []purchasing.GoodsReceiptItemRequest{{PurchaseOrderItemID: itemID, Quantity: 4}}}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().ReceivePurchaseOrder(gomock.Any(), id, expectedReq).
+			Return(&purchasing.PurchaseOrderDetailResponse{PurchaseOrderResponse: purchasing.PurchaseOrderResponse{ID: id, Status: repository.PurchaseOrderStatusPartiallyReceived}}, nil)
+
+		req := httptest.NewRequest("POST", "/purchase-orders/"+id.String()+"/receive", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("MoreThanOutstanding", func(t *testing.T) {
+		mockService.EXPECT().ReceivePurchaseOrder(gomock.Any(), id, expectedReq).Return(nil, common.ErrGoodsReceiptInvalid)
+
+		req := httptest.NewRequest("POST", "/purchase-orders/"+id.String()+"/receive", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("NotWaitingForGoods", func(t *testing.T) {
+		mockService.EXPECT().ReceivePurchaseOrder(gomock.Any(), id, expectedReq).Return(nil, common.ErrPurchaseOrderStatus)
+
+		req := httptest.NewRequest("POST", "/purchase-orders/"+id.String()+"/receive", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/purchase-orders/not-a-uuid/receive", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
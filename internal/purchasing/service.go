@@ -0,0 +1,748 @@
+package purchasing
+
+import (
+	"POS-kasir/internal/activitylog"
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/common/pagination"
+	"POS-kasir/internal/common/store"
+	"POS-kasir/internal/costing"
+	costing_repo "POS-kasir/internal/costing/repository"
+	"POS-kasir/internal/purchasing/repository"
+	"POS-kasir/pkg/logger"
+	"POS-kasir/pkg/utils"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type IPurchasingService interface {
+	ListSuppliers(ctx context.Context, req ListSuppliersRequest) (*PagedSupplierResponse, error)
+	GetSupplier(ctx context.Context, id uuid.UUID) (*SupplierResponse, error)
+	CreateSupplier(ctx context.Context, req CreateSupplierRequest) (*SupplierResponse, error)
+	UpdateSupplier(ctx context.Context, id uuid.UUID, req UpdateSupplierRequest) (*SupplierResponse, error)
+	DeleteSupplier(ctx context.Context, id uuid.UUID) error
+
+	ListPurchaseOrders(ctx context.Context, req ListPurchaseOrdersRequest) (*PagedPurchaseOrderResponse, error)
+	GetPurchaseOrder(ctx context.Context, id uuid.UUID) (*PurchaseOrderDetailResponse, error)
+	CreatePurchaseOrder(ctx context.Context, req PurchaseOrderRequest) (*PurchaseOrderDetailResponse, error)
+	UpdatePurchaseOrder(ctx context.Context, id uuid.UUID, req PurchaseOrderRequest) (*PurchaseOrderDetailResponse, error)
+	SendPurchaseOrder(ctx context.Context, id uuid.UUID) (*PurchaseOrderDetailResponse, error)
+	ClosePurchaseOrder(ctx context.Context, id uuid.UUID) (*PurchaseOrderDetailResponse, error)
+	ReceivePurchaseOrder(ctx context.Context, id uuid.UUID, req ReceivePurchaseOrderRequest) (*PurchaseOrderDetailResponse, error)
+}
+
+type PurchasingService struct {
+	store           store.Store
+	repo            repository.Querier
+	activityService activitylog.IActivityService
+	log             logger.ILogger
+}
+
+func NewPurchasingService(store store.Store, repo repository.Querier, activityService activitylog.IActivityService, log logger.ILogger) IPurchasingService {
+	return &PurchasingService{
+		store:           store,
+		repo:            repo,
+		activityService: activityService,
+		log:             log,
+	}
+}
+
+// purchaseOrderTransitions lists the statuses a purchase order may move to by hand. Receiving moves a sent
+// order to partially_received or received on its own; any order that is not closed yet can be closed,
+// which cancels a draft or gives up on what is still outstanding.
+var purchaseOrderTransitions = map[repository.PurchaseOrderStatus][]repository.PurchaseOrderStatus{
+	repository.PurchaseOrderStatusDraft:             {repository.PurchaseOrderStatusSent, repository.PurchaseOrderStatusClosed},
+	repository.PurchaseOrderStatusSent:              {repository.PurchaseOrderStatusClosed},
+	repository.PurchaseOrderStatusPartiallyReceived: {repository.PurchaseOrderStatusClosed},
+	repository.PurchaseOrderStatusReceived:          {repository.PurchaseOrderStatusClosed},
+}
+
+func canTransition(from, to repository.PurchaseOrderStatus) bool {
+	for _, next := range purchaseOrderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *PurchasingService) ListSuppliers(ctx context.Context, req ListSuppliersRequest) (*PagedSupplierResponse, error) {
+	req.SetDefaults()
+
+	var search *string
+	if req.Search != "" {
+		search = &req.Search
+	}
+
+	suppliers, err := s.repo.ListSuppliers(ctx, repository.ListSuppliersParams{
+		Limit:      int32(req.Limit),
+		Offset:     int32((req.Page - 1) * req.Limit),
+		SearchText: search,
+	})
+	if err != nil {
+		s.log.Errorf("ListSuppliers | Failed to list suppliers: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	total, err := s.repo.CountSuppliers(ctx, search)
+	if err != nil {
+		s.log.Errorf("ListSuppliers | Failed to count suppliers: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	response := make([]SupplierResponse, 0, len(suppliers))
+	for _, supplier := range suppliers {
+		response = append(response, toSupplierResponse(supplier))
+	}
+
+	return &PagedSupplierResponse{
+		Suppliers:  response,
+		Pagination: pagination.BuildPagination(req.Page, int(total), req.Limit),
+	}, nil
+}
+
+func (s *PurchasingService) GetSupplier(ctx context.Context, id uuid.UUID) (*SupplierResponse, error) {
+	supplier, err := s.getSupplier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toSupplierResponse(supplier)
+	return &response, nil
+}
+
+func (s *PurchasingService) CreateSupplier(ctx context.Context, req CreateSupplierRequest) (*SupplierResponse, error) {
+	if err := s.ensureSupplierNameAvailable(ctx, req.Name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	supplier, err := s.repo.CreateSupplier(ctx, repository.CreateSupplierParams{
+		Name:        req.Name,
+		ContactName: req.ContactName,
+		Phone:       req.Phone,
+		Email:       req.Email,
+		Address:     req.Address,
+	})
+	if err != nil {
+		s.log.Errorf("CreateSupplier | Failed to create supplier: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeCREATE, activitylog_repo.LogEntityTypeSUPPLIER, supplier.ID, map[string]interface{}{
+		"name": supplier.Name,
+	})
+
+	response := toSupplierResponse(supplier)
+	return &response, nil
+}
+
+func (s *PurchasingService) UpdateSupplier(ctx context.Context, id uuid.UUID, req UpdateSupplierRequest) (*SupplierResponse, error) {
+	if _, err := s.getSupplier(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.ensureSupplierNameAvailable(ctx, req.Name, id); err != nil {
+		return nil, err
+	}
+
+	supplier, err := s.repo.UpdateSupplier(ctx, repository.UpdateSupplierParams{
+		ID:          id,
+		Name:        req.Name,
+		ContactName: req.ContactName,
+		Phone:       req.Phone,
+		Email:       req.Email,
+		Address:     req.Address,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("UpdateSupplier | Failed to update supplier: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeUPDATE, activitylog_repo.LogEntityTypeSUPPLIER, id, map[string]interface{}{
+		"name": supplier.Name,
+	})
+
+	response := toSupplierResponse(supplier)
+	return &response, nil
+}
+
+func (s *PurchasingService) DeleteSupplier(ctx context.Context, id uuid.UUID) error {
+	supplier, err := s.getSupplier(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	open, err := s.repo.CountOpenPurchaseOrdersBySupplier(ctx, id)
+	if err != nil {
+		s.log.Errorf("DeleteSupplier | Failed to count open purchase orders: %v", err)
+		return common.ErrInternal
+	}
+	if open > 0 {
+		return common.ErrSupplierInUse
+	}
+
+	if err := s.repo.SoftDeleteSupplier(ctx, id); err != nil {
+		s.log.Errorf("DeleteSupplier | Failed to delete supplier: %v", err)
+		return common.ErrInternal
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeDELETE, activitylog_repo.LogEntityTypeSUPPLIER, id, map[string]interface{}{
+		"deleted_supplier_name": supplier.Name,
+	})
+	return nil
+}
+
+func (s *PurchasingService) ListPurchaseOrders(ctx context.Context, req ListPurchaseOrdersRequest) (*PagedPurchaseOrderResponse, error) {
+	req.SetDefaults()
+
+	var status repository.NullPurchaseOrderStatus
+	if req.Status != nil {
+		status = repository.NullPurchaseOrderStatus{PurchaseOrderStatus: *req.Status, Valid: true}
+	}
+	var supplierID pgtype.UUID
+	if req.SupplierID != nil {
+		supplierID = pgtype.UUID{Bytes: *req.SupplierID, Valid: true}
+	}
+
+	orders, err := s.repo.ListPurchaseOrders(ctx, repository.ListPurchaseOrdersParams{
+		Limit:      int32(req.Limit),
+		Offset:     int32((req.Page - 1) * req.Limit),
+		Status:     status,
+		SupplierID: supplierID,
+	})
+	if err != nil {
+		s.log.Errorf("ListPurchaseOrders | Failed to list purchase orders: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	total, err := s.repo.CountPurchaseOrders(ctx, repository.CountPurchaseOrdersParams{
+		Status:     status,
+		SupplierID: supplierID,
+	})
+	if err != nil {
+		s.log.Errorf("ListPurchaseOrders | Failed to count purchase orders: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	response := make([]PurchaseOrderResponse, 0, len(orders))
+	for _, po := range orders {
+		response = append(response, toPurchaseOrderResponse(repository.GetPurchaseOrderRow(po)))
+	}
+
+	return &PagedPurchaseOrderResponse{
+		PurchaseOrders: response,
+		Pagination:     pagination.BuildPagination(req.Page, int(total), req.Limit),
+	}, nil
+}
+
+func (s *PurchasingService) GetPurchaseOrder(ctx context.Context, id uuid.UUID) (*PurchaseOrderDetailResponse, error) {
+	po, err := s.repo.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("GetPurchaseOrder | Failed to get purchase order: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	items, err := s.repo.ListPurchaseOrderItems(ctx, id)
+	if err != nil {
+		s.log.Errorf("GetPurchaseOrder | Failed to list purchase order items: %v", err)
+		return nil, common.ErrInternal
+	}
+	receipts, err := s.repo.ListGoodsReceipts(ctx, id)
+	if err != nil {
+		s.log.Errorf("GetPurchaseOrder | Failed to list goods receipts: %v", err)
+		return nil, common.ErrInternal
+	}
+	receiptItems, err := s.repo.ListGoodsReceiptItemsByPurchaseOrder(ctx, id)
+	if err != nil {
+		s.log.Errorf("GetPurchaseOrder | Failed to list goods receipt items: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	return buildPurchaseOrderDetail(po, items, receipts, receiptItems), nil
+}
+
+func (s *PurchasingService) CreatePurchaseOrder(ctx context.Context, req PurchaseOrderRequest) (*PurchaseOrderDetailResponse, error) {
+	if err := s.validatePurchaseOrder(ctx, req); err != nil {
+		return nil, err
+	}
+
+	var po repository.PurchaseOrder
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+
+		var err error
+		po, err = qtx.CreatePurchaseOrder(ctx, repository.CreatePurchaseOrderParams{
+			SupplierID:   req.SupplierID,
+			ExpectedDate: toDate(req.ExpectedDate),
+			Notes:        req.Notes,
+			CreatedBy:    actorUUID(ctx),
+		})
+		if err != nil {
+			return err
+		}
+		return createItems(ctx, qtx, po.ID, req.Items)
+	})
+	if txErr != nil {
+		s.log.Errorf("CreatePurchaseOrder | Failed to create purchase order: %v", txErr)
+		return nil, common.ErrInternal
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeCREATE, activitylog_repo.LogEntityTypePURCHASEORDER, po.ID, map[string]interface{}{
+		"po_number":   po.PoNumber,
+		"supplier_id": po.SupplierID.String(),
+		"items":       len(req.Items),
+	})
+
+	return s.GetPurchaseOrder(ctx, po.ID)
+}
+
+func (s *PurchasingService) UpdatePurchaseOrder(ctx context.Context, id uuid.UUID, req PurchaseOrderRequest) (*PurchaseOrderDetailResponse, error) {
+	if err := s.validatePurchaseOrder(ctx, req); err != nil {
+		return nil, err
+	}
+
+	var po repository.PurchaseOrder
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+
+		current, err := qtx.GetPurchaseOrderForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if current.Status != repository.PurchaseOrderStatusDraft {
+			return common.ErrPurchaseOrderStatus
+		}
+
+		po, err = qtx.UpdatePurchaseOrder(ctx, repository.UpdatePurchaseOrderParams{
+			ID:           id,
+			SupplierID:   req.SupplierID,
+			ExpectedDate: toDate(req.ExpectedDate),
+			Notes:        req.Notes,
+		})
+		if err != nil {
+			return err
+		}
+		if err := qtx.DeletePurchaseOrderItems(ctx, id); err != nil {
+			return err
+		}
+		return createItems(ctx, qtx, id, req.Items)
+	})
+	if txErr != nil {
+		return nil, s.txError("UpdatePurchaseOrder", txErr)
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeUPDATE, activitylog_repo.LogEntityTypePURCHASEORDER, id, map[string]interface{}{
+		"po_number":   po.PoNumber,
+		"supplier_id": po.SupplierID.String(),
+		"items":       len(req.Items),
+	})
+
+	return s.GetPurchaseOrder(ctx, id)
+}
+
+func (s *PurchasingService) SendPurchaseOrder(ctx context.Context, id uuid.UUID) (*PurchaseOrderDetailResponse, error) {
+	return s.changeStatus(ctx, "SendPurchaseOrder", id, repository.PurchaseOrderStatusSent)
+}
+
+func (s *PurchasingService) ClosePurchaseOrder(ctx context.Context, id uuid.UUID) (*PurchaseOrderDetailResponse, error) {
+	return s.changeStatus(ctx, "ClosePurchaseOrder", id, repository.PurchaseOrderStatusClosed)
+}
+
+func (s *PurchasingService) changeStatus(ctx context.Context, op string, id uuid.UUID, status repository.PurchaseOrderStatus) (*PurchaseOrderDetailResponse, error) {
+	var previous repository.PurchaseOrderStatus
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+
+		current, err := qtx.GetPurchaseOrderForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !canTransition(current.Status, status) {
+			return common.ErrPurchaseOrderStatus
+		}
+		previous = current.Status
+
+		_, err = qtx.UpdatePurchaseOrderStatus(ctx, repository.UpdatePurchaseOrderStatusParams{
+			Status: status,
+			ID:     id,
+		})
+		return err
+	})
+	if txErr != nil {
+		return nil, s.txError(op, txErr)
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeUPDATE, activitylog_repo.LogEntityTypePURCHASEORDER, id, map[string]interface{}{
+		"old_status": previous,
+		"new_status": status,
+	})
+
+	return s.GetPurchaseOrder(ctx, id)
+}
+
+// ReceivePurchaseOrder books one delivery. Each line adds to the product's stock with a restock entry in
+// stock_history that references the purchase order, and the product cost becomes the received unit cost.
+func (s *PurchasingService) ReceivePurchaseOrder(ctx context.Context, id uuid.UUID, req ReceivePurchaseOrderRequest) (*PurchaseOrderDetailResponse, error) {
+	actor := actorUUID(ctx)
+
+	var receipt repository.GoodsReceipt
+	var status repository.PurchaseOrderStatus
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+		qCost := costing_repo.New(tx)
+
+		po, err := qtx.GetPurchaseOrderForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if po.Status != repository.PurchaseOrderStatusSent && po.Status != repository.PurchaseOrderStatusPartiallyReceived {
+			return common.ErrPurchaseOrderStatus
+		}
+
+		items, err := qtx.GetPurchaseOrderItemsForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		itemMap := make(map[uuid.UUID]repository.PurchaseOrderItem, len(items))
+		for _, item := range items {
+			itemMap[item.ID] = item
+		}
+
+		productIDs := make([]uuid.UUID, 0, len(req.Items))
+		seen := make(map[uuid.UUID]bool, len(req.Items))
+		for _, line := range req.Items {
+			item, ok := itemMap[line.PurchaseOrderItemID]
+			if !ok || seen[line.PurchaseOrderItemID] || line.Quantity > item.QuantityOrdered-item.QuantityReceived {
+				return common.ErrGoodsReceiptInvalid
+			}
+			seen[line.PurchaseOrderItemID] = true
+			productIDs = append(productIDs, item.ProductID)
+		}
+
+		receipt, err = qtx.CreateGoodsReceipt(ctx, repository.CreateGoodsReceiptParams{
+			PurchaseOrderID:   id,
+			SupplierReference: req.SupplierReference,
+			Notes:             req.Notes,
+			ReceivedBy:        actor,
+		})
+		if err != nil {
+			return err
+		}
+
+		products, err := qtx.GetProductStockForUpdate(ctx, productIDs)
+		if err != nil {
+			return err
+		}
+		stock := make(map[uuid.UUID]int32, len(products))
+		for _, product := range products {
+			stock[product.ID] = product.Stock
+		}
+
+		note := "Goods receipt " + receipt.ReceiptNumber + " for " + po.PoNumber
+		for _, line := range req.Items {
+			item := itemMap[line.PurchaseOrderItemID]
+			unitCost := item.UnitCost
+			if line.UnitCost != nil {
+				if unitCost, err = utils.Float64ToNumeric(*line.UnitCost); err != nil {
+					return err
+				}
+			}
+
+			if err := qtx.CreateGoodsReceiptItem(ctx, repository.CreateGoodsReceiptItemParams{
+				GoodsReceiptID:      receipt.ID,
+				PurchaseOrderItemID: item.ID,
+				Quantity:            line.Quantity,
+				UnitCost:            unitCost,
+			}); err != nil {
+				return err
+			}
+			if err := qtx.AddPurchaseOrderItemReceived(ctx, repository.AddPurchaseOrderItemReceivedParams{
+				Quantity: line.Quantity,
+				ID:       item.ID,
+			}); err != nil {
+				return err
+			}
+			item.QuantityReceived += line.Quantity
+			itemMap[item.ID] = item
+
+			if err := qtx.ReceiveProductStock(ctx, repository.ReceiveProductStockParams{
+				Quantity: line.Quantity,
+				ID:       item.ProductID,
+			}); err != nil {
+				return err
+			}
+
+			// The delivery becomes a cost layer at the price paid and moves the product's average cost
+			previousStock := stock[item.ProductID]
+			stock[item.ProductID] = previousStock + line.Quantity
+			if err := costing.Receive(ctx, qCost, item.ProductID, previousStock, line.Quantity, unitCost, costing_repo.CostLayerSourcePurchase, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+				return err
+			}
+
+			if _, err := qtx.CreateStockHistory(ctx, repository.CreateStockHistoryParams{
+				ProductID:     item.ProductID,
+				ChangeAmount:  line.Quantity,
+				PreviousStock: previousStock,
+				CurrentStock:  previousStock + line.Quantity,
+				ChangeType:    repository.StockChangeTypeRestock,
+				ReferenceID:   pgtype.UUID{Bytes: id, Valid: true},
+				Note:          &note,
+				CreatedBy:     actor,
+			}); err != nil {
+				return err
+			}
+		}
+
+		status = repository.PurchaseOrderStatusReceived
+		for _, item := range itemMap {
+			if item.QuantityReceived < item.QuantityOrdered {
+				status = repository.PurchaseOrderStatusPartiallyReceived
+				break
+			}
+		}
+		if status == po.Status {
+			return nil
+		}
+		_, err = qtx.UpdatePurchaseOrderStatus(ctx, repository.UpdatePurchaseOrderStatusParams{
+			Status: status,
+			ID:     id,
+		})
+		return err
+	})
+	if txErr != nil {
+		return nil, s.txError("ReceivePurchaseOrder", txErr)
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeUPDATE, activitylog_repo.LogEntityTypePURCHASEORDER, id, map[string]interface{}{
+		"receipt_number": receipt.ReceiptNumber,
+		"lines":          len(req.Items),
+		"new_status":     status,
+	})
+
+	return s.GetPurchaseOrder(ctx, id)
+}
+
+// validatePurchaseOrder checks the supplier and products before a draft is written.
+func (s *PurchasingService) validatePurchaseOrder(ctx context.Context, req PurchaseOrderRequest) error {
+	if _, err := s.repo.GetSupplier(ctx, req.SupplierID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return common.ErrSupplierNotFound
+		}
+		s.log.Errorf("validatePurchaseOrder | Failed to get supplier: %v", err)
+		return common.ErrInternal
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(req.Items))
+	seen := make(map[uuid.UUID]bool, len(req.Items))
+	for _, item := range req.Items {
+		if seen[item.ProductID] {
+			return common.ErrPurchaseOrderInvalid
+		}
+		seen[item.ProductID] = true
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	products, err := s.repo.GetPurchasableProducts(ctx, productIDs)
+	if err != nil {
+		s.log.Errorf("validatePurchaseOrder | Failed to get products: %v", err)
+		return common.ErrInternal
+	}
+	if len(products) != len(productIDs) {
+		return common.ErrPurchaseOrderInvalid
+	}
+	return nil
+}
+
+func (s *PurchasingService) txError(op string, err error) error {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return common.ErrNotFound
+	case errors.Is(err, common.ErrPurchaseOrderStatus), errors.Is(err, common.ErrGoodsReceiptInvalid):
+		return err
+	}
+	s.log.Errorf("%s | Transaction failed: %v", op, err)
+	return common.ErrInternal
+}
+
+func (s *PurchasingService) getSupplier(ctx context.Context, id uuid.UUID) (repository.Supplier, error) {
+	supplier, err := s.repo.GetSupplier(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warnf("getSupplier | Supplier not found: id=%s", id)
+			return supplier, common.ErrNotFound
+		}
+		s.log.Errorf("getSupplier | Failed to get supplier: %v", err)
+		return supplier, common.ErrInternal
+	}
+	return supplier, nil
+}
+
+// ensureSupplierNameAvailable rejects a name already used by another supplier; exceptID is the supplier being renamed.
+func (s *PurchasingService) ensureSupplierNameAvailable(ctx context.Context, name string, exceptID uuid.UUID) error {
+	existing, err := s.repo.GetSupplierByName(ctx, name)
+	if err == nil {
+		if existing.ID != exceptID {
+			return common.ErrSupplierExists
+		}
+		return nil
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	s.log.Errorf("ensureSupplierNameAvailable | Failed to check supplier name: %v", err)
+	return common.ErrInternal
+}
+
+func (s *PurchasingService) logActivity(ctx context.Context, action activitylog_repo.LogActionType, entity activitylog_repo.LogEntityType, id uuid.UUID, details map[string]interface{}) {
+	actorID, ok := ctx.Value(common.UserIDKey).(uuid.UUID)
+	if !ok {
+		s.log.Warnf("PurchasingService | Actor user ID not found in context for activity logging")
+	}
+
+	s.activityService.Log(
+		ctx,
+		actorID,
+		action,
+		entity,
+		id.String(),
+		details,
+	)
+}
+
+func createItems(ctx context.Context, qtx *repository.Queries, purchaseOrderID uuid.UUID, items []PurchaseOrderItemRequest) error {
+	for _, item := range items {
+		unitCost, err := utils.Float64ToNumeric(item.UnitCost)
+		if err != nil {
+			return err
+		}
+		if err := qtx.CreatePurchaseOrderItem(ctx, repository.CreatePurchaseOrderItemParams{
+			PurchaseOrderID: purchaseOrderID,
+			ProductID:       item.ProductID,
+			QuantityOrdered: item.Quantity,
+			UnitCost:        unitCost,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildPurchaseOrderDetail(po repository.GetPurchaseOrderRow, items []repository.ListPurchaseOrderItemsRow, receipts []repository.GoodsReceipt, receiptItems []repository.ListGoodsReceiptItemsByPurchaseOrderRow) *PurchaseOrderDetailResponse {
+	response := &PurchaseOrderDetailResponse{
+		PurchaseOrderResponse: toPurchaseOrderResponse(po),
+		Items:                 make([]PurchaseOrderItemResponse, 0, len(items)),
+		Receipts:              make([]GoodsReceiptResponse, 0, len(receipts)),
+	}
+	for _, item := range items {
+		unitCost := utils.NumericToFloat64(item.UnitCost)
+		response.Items = append(response.Items, PurchaseOrderItemResponse{
+			ID:                  item.ID,
+			ProductID:           item.ProductID,
+			ProductName:         item.ProductName,
+			QuantityOrdered:     item.QuantityOrdered,
+			QuantityReceived:    item.QuantityReceived,
+			QuantityOutstanding: item.QuantityOrdered - item.QuantityReceived,
+			UnitCost:            unitCost,
+			LineTotal:           unitCost * float64(item.QuantityOrdered),
+		})
+	}
+
+	byReceipt := make(map[uuid.UUID][]GoodsReceiptItemResponse)
+	for _, item := range receiptItems {
+		byReceipt[item.GoodsReceiptID] = append(byReceipt[item.GoodsReceiptID], GoodsReceiptItemResponse{
+			ID:                  item.ID,
+			PurchaseOrderItemID: item.PurchaseOrderItemID,
+			ProductID:           item.ProductID,
+			ProductName:         item.ProductName,
+			Quantity:            item.Quantity,
+			UnitCost:            utils.NumericToFloat64(item.UnitCost),
+		})
+	}
+	for _, receipt := range receipts {
+		lines := byReceipt[receipt.ID]
+		if lines == nil {
+			lines = []GoodsReceiptItemResponse{}
+		}
+		response.Receipts = append(response.Receipts, GoodsReceiptResponse{
+			ID:                receipt.ID,
+			ReceiptNumber:     receipt.ReceiptNumber,
+			SupplierReference: receipt.SupplierReference,
+			Notes:             receipt.Notes,
+			ReceivedBy:        utils.NullableUUIDToPointer(receipt.ReceivedBy),
+			ReceivedAt:        receipt.ReceivedAt.Time,
+			Items:             lines,
+		})
+	}
+	return response
+}
+
+func toPurchaseOrderResponse(po repository.GetPurchaseOrderRow) PurchaseOrderResponse {
+	response := PurchaseOrderResponse{
+		ID:           po.ID,
+		PoNumber:     po.PoNumber,
+		SupplierID:   po.SupplierID,
+		SupplierName: po.SupplierName,
+		Status:       po.Status,
+		Notes:        po.Notes,
+		TotalCost:    utils.NumericToFloat64(po.TotalCost),
+		CreatedBy:    utils.NullableUUIDToPointer(po.CreatedBy),
+		SentAt:       timePtr(po.SentAt),
+		ReceivedAt:   timePtr(po.ReceivedAt),
+		ClosedAt:     timePtr(po.ClosedAt),
+		CreatedAt:    po.CreatedAt.Time,
+		UpdatedAt:    po.UpdatedAt.Time,
+	}
+	if po.ExpectedDate.Valid {
+		response.ExpectedDate = utils.StringPtr(po.ExpectedDate.Time.Format("2006-01-02"))
+	}
+	return response
+}
+
+func toSupplierResponse(supplier repository.Supplier) SupplierResponse {
+	return SupplierResponse{
+		ID:          supplier.ID,
+		Name:        supplier.Name,
+		ContactName: supplier.ContactName,
+		Phone:       supplier.Phone,
+		Email:       supplier.Email,
+		Address:     supplier.Address,
+		CreatedAt:   supplier.CreatedAt.Time,
+		UpdatedAt:   supplier.UpdatedAt.Time,
+	}
+}
+
+// toDate parses a YYYY-MM-DD value already checked by the request validator.
+func toDate(value *string) pgtype.Date {
+	if value == nil {
+		return pgtype.Date{}
+	}
+	day, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return pgtype.Date{}
+	}
+	return pgtype.Date{Time: day, Valid: true}
+}
+
+func timePtr(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	return &ts.Time
+}
+
+func actorUUID(ctx context.Context) pgtype.UUID {
+	if actorID, ok := ctx.Value(common.UserIDKey).(uuid.UUID); ok {
+		return pgtype.UUID{Bytes: actorID, Valid: true}
+	}
+	return pgtype.UUID{}
+}
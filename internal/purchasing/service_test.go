@@ -0,0 +1,315 @@
+package purchasing_test
+
+import (
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	costing_repo "POS-kasir/internal/costing/repository"
+	"POS-kasir/internal/purchasing"
+	"POS-kasir/internal/purchasing/repository"
+	"POS-kasir/mocks"
+	"POS-kasir/pkg/utils"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var purchaseOrderColumns = []string{
+	"id", "po_number", "supplier_id", "status", "expected_date", "notes", "created_by",
+	"sent_at", "received_at", "closed_at", "created_at", "updated_at",
+}
+
+var purchaseOrderItemColumns = []string{
+	"id", "purchase_order_id", "product_id", "quantity_ordered", "quantity_received", "unit_cost",
+}
+
+var goodsReceiptColumns = []string{
+	"id", "receipt_number", "purchase_order_id", "supplier_reference", "notes", "received_by", "received_at",
+}
+
+var stockHistoryColumns = []string{
+	"id", "product_id", "change_amount", "previous_stock", "current_stock",
+	"change_type", "reference_id", "note", "created_by", "created_at", "variant_id",
+}
+
+func allowAllLoggerCalls(mockLogger *mocks.MockILogger) {
+	mockLogger.EXPECT().Warnf(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+}
+
+func setupTest(t *testing.T) (*mocks.MockStore, *mocks.MockPurchasingRepo, *mocks.MockIActivityService, purchasing.IPurchasingService) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	mockRepo := mocks.NewMockPurchasingRepo(ctrl)
+	mockActivity := mocks.NewMockIActivityService(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	allowAllLoggerCalls(mockLogger)
+
+	service := purchasing.NewPurchasingService(mockStore, mockRepo, mockActivity, mockLogger)
+	return mockStore, mockRepo, mockActivity, service
+}
+
+func purchaseOrderRow(id, supplierID uuid.UUID, status repository.PurchaseOrderStatus) []interface{} {
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	return []interface{}{id, "PO-000001", supplierID, status, pgtype.Date{}, nil, pgtype.UUID{}, now, pgtype.Timestamptz{}, pgtype.Timestamptz{}, now, now}
+}
+
+func TestPurchasingService_CreateSupplier(t *testing.T) {
+	req := purchasing.CreateSupplierRequest{Name: "Kopi Nusantara"}
+
+	t.Run("Success", func(t *testing.T) {
+		_, mockRepo, mockActivity, service := setupTest(t)
+		ctx := context.Background()
+		id := uuid.New()
+
+		mockRepo.EXPECT().GetSupplierByName(ctx, "Kopi Nusantara").Return(repository.Supplier{}, pgx.ErrNoRows)
+		mockRepo.EXPECT().CreateSupplier(ctx, repository.CreateSupplierParams{Name: "Kopi Nusantara"}).
+			Return(repository.Supplier{ID: id, Name: "Kopi Nusantara"}, nil)
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), activitylog_repo.LogActionTypeCREATE, activitylog_repo.LogEntityTypeSUPPLIER, id.String(), gomock.Any())
+
+		resp, err := service.CreateSupplier(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, id, resp.ID)
+	})
+
+	t.Run("NameTaken", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetSupplierByName(ctx, "Kopi Nusantara").Return(repository.Supplier{ID: uuid.New()}, nil)
+
+		resp, err := service.CreateSupplier(ctx, req)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrSupplierExists)
+	})
+}
+
+func TestPurchasingService_DeleteSupplier(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("OpenPurchaseOrders", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetSupplier(ctx, id).Return(repository.Supplier{ID: id, Name: "Kopi Nusantara"}, nil)
+		mockRepo.EXPECT().CountOpenPurchaseOrdersBySupplier(ctx, id).Return(int64(2), nil)
+
+		err := service.DeleteSupplier(ctx, id)
+
+		assert.ErrorIs(t, err, common.ErrSupplierInUse)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		_, mockRepo, mockActivity, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetSupplier(ctx, id).Return(repository.Supplier{ID: id, Name: "Kopi Nusantara"}, nil)
+		mockRepo.EXPECT().CountOpenPurchaseOrdersBySupplier(ctx, id).Return(int64(0), nil)
+		mockRepo.EXPECT().SoftDeleteSupplier(ctx, id).Return(nil)
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), activitylog_repo.LogActionTypeDELETE, activitylog_repo.LogEntityTypeSUPPLIER, id.String(), gomock.Any())
+
+		assert.NoError(t, service.DeleteSupplier(ctx, id))
+	})
+}
+
+func TestPurchasingService_CreatePurchaseOrder(t *testing.T) {
+	supplierID := uuid.New()
+	productID := uuid.New()
+
+	t.Run("DuplicateProduct", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetSupplier(ctx, supplierID).Return(repository.Supplier{ID: supplierID}, nil)
+
+		resp, err := service.CreatePurchaseOrder(ctx, purchasing.PurchaseOrderRequest{
+			SupplierID: supplierID,
+			Items: []purchasing.PurchaseOrderItemRequest{
+				{ProductID: productID, Quantity: 10, UnitCost: 12000},
+				{ProductID: productID, Quantity: 5, UnitCost: 12000},
+			},
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrPurchaseOrderInvalid)
+	})
+
+	t.Run("UnknownSupplier", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockRepo.EXPECT().GetSupplier(ctx, supplierID).Return(repository.Supplier{}, pgx.ErrNoRows)
+
+		resp, err := service.CreatePurchaseOrder(ctx, purchasing.PurchaseOrderRequest{
+			SupplierID: supplierID,
+			Items:      []purchasing.PurchaseOrderItemRequest{{ProductID: productID, Quantity: 10, UnitCost: 12000}},
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrSupplierNotFound)
+	})
+}
+
+func TestPurchasingService_SendPurchaseOrder(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("ClosedOrderCannotBeSent", func(t *testing.T) {
+		mockStore, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		mockStore.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+			return fn(mockPgx)
+		})
+		mockPgx.ExpectQuery("SELECT .* FROM purchase_orders").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(purchaseOrderColumns).AddRow(purchaseOrderRow(id, uuid.New(), repository.PurchaseOrderStatusClosed)...))
+
+		resp, err := service.SendPurchaseOrder(ctx, id)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrPurchaseOrderStatus)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+}
+
+func TestPurchasingService_ReceivePurchaseOrder(t *testing.T) {
+	id := uuid.New()
+	supplierID := uuid.New()
+	itemID := uuid.New()
+	productID := uuid.New()
+	unitCost, _ := utils.Float64ToNumeric(12000)
+
+	t.Run("PartialReceiptRestocksProduct", func(t *testing.T) {
+		mockStore, mockRepo, mockActivity, service := setupTest(t)
+		ctx := context.Background()
+		now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		receiptID := uuid.New()
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		mockStore.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+			return fn(mockPgx)
+		})
+		mockPgx.ExpectQuery("SELECT .* FROM purchase_orders").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(purchaseOrderColumns).AddRow(purchaseOrderRow(id, supplierID, repository.PurchaseOrderStatusSent)...))
+		mockPgx.ExpectQuery("SELECT .* FROM purchase_order_items").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(purchaseOrderItemColumns).AddRow(itemID, id, productID, int32(10), int32(0), unitCost))
+		mockPgx.ExpectQuery("INSERT INTO goods_receipts").
+			WithArgs(id, pgxmock.AnyArg(), pgxmock.AnyArg(), pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(goodsReceiptColumns).AddRow(receiptID, "GRN-000001", id, nil, nil, pgtype.UUID{}, now))
+		mockPgx.ExpectQuery("SELECT id, stock FROM products").
+			WithArgs([]uuid.UUID{productID}).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "stock"}).AddRow(productID, int32(3)))
+		mockPgx.ExpectExec("INSERT INTO goods_receipt_items").
+			WithArgs(receiptID, itemID, int32(4), unitCost).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPgx.ExpectExec("UPDATE purchase_order_items").
+			WithArgs(int32(4), itemID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPgx.ExpectExec("UPDATE products SET stock").
+			WithArgs(int32(4), productID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		// The delivery is layered at the price paid and folded into the average cost of the 3 units on hand
+		mockPgx.ExpectExec("INSERT INTO cost_layers").
+			WithArgs(productID, costing_repo.CostLayerSourcePurchase, pgtype.UUID{Bytes: id, Valid: true}, int32(4), unitCost).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPgx.ExpectExec("UPDATE products SET cost_price").
+			WithArgs(int32(3), int32(4), unitCost, productID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPgx.ExpectQuery("INSERT INTO stock_history").
+			WithArgs(productID, int32(4), int32(3), int32(7), repository.StockChangeTypeRestock, pgtype.UUID{Bytes: id, Valid: true}, pgxmock.AnyArg(), pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(stockHistoryColumns).
+				AddRow(uuid.New(), productID, int32(4), int32(3), int32(7), repository.StockChangeTypeRestock, pgtype.UUID{Bytes: id, Valid: true}, nil, pgtype.UUID{}, now, nil))
+		mockPgx.ExpectQuery("UPDATE purchase_orders").
+			WithArgs(repository.PurchaseOrderStatusPartiallyReceived, id).
+			WillReturnRows(pgxmock.NewRows(purchaseOrderColumns).AddRow(purchaseOrderRow(id, supplierID, repository.PurchaseOrderStatusPartiallyReceived)...))
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), activitylog_repo.LogActionTypeUPDATE, activitylog_repo.LogEntityTypePURCHASEORDER, id.String(), gomock.Any())
+
+		mockRepo.EXPECT().GetPurchaseOrder(ctx, id).Return(repository.GetPurchaseOrderRow{ID: id, SupplierID: supplierID, Status: repository.PurchaseOrderStatusPartiallyReceived}, nil)
+		mockRepo.EXPECT().ListPurchaseOrderItems(ctx, id).Return([]repository.ListPurchaseOrderItemsRow{
+			{ID: itemID, ProductID: productID, QuantityOrdered: 10, QuantityReceived: 4, UnitCost: unitCost},
+		}, nil)
+		mockRepo.EXPECT().ListGoodsReceipts(ctx, id).Return([]repository.GoodsReceipt{{ID: receiptID, ReceiptNumber: "GRN-000001"}}, nil)
+		mockRepo.EXPECT().ListGoodsReceiptItemsByPurchaseOrder(ctx, id).Return([]repository.ListGoodsReceiptItemsByPurchaseOrderRow{
+			{GoodsReceiptID: receiptID, PurchaseOrderItemID: itemID, ProductID: productID, Quantity: 4, UnitCost: unitCost},
+		}, nil)
+
+		resp, err := service.ReceivePurchaseOrder(ctx, id, purchasing.ReceivePurchaseOrderRequest{
+			Items: []purchasing.GoodsReceiptItemRequest{{PurchaseOrderItemID: itemID, Quantity: 4}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, repository.PurchaseOrderStatusPartiallyReceived, resp.Status)
+		assert.Equal(t, int32(6), resp.Items[0].QuantityOutstanding)
+		assert.Len(t, resp.Receipts[0].Items, 1)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("MoreThanOutstanding", func(t *testing.T) {
+		mockStore, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		mockStore.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+			return fn(mockPgx)
+		})
+		mockPgx.ExpectQuery("SELECT .* FROM purchase_orders").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(purchaseOrderColumns).AddRow(purchaseOrderRow(id, supplierID, repository.PurchaseOrderStatusPartiallyReceived)...))
+		mockPgx.ExpectQuery("SELECT .* FROM purchase_order_items").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(purchaseOrderItemColumns).AddRow(itemID, id, productID, int32(10), int32(8), unitCost))
+
+		resp, err := service.ReceivePurchaseOrder(ctx, id, purchasing.ReceivePurchaseOrderRequest{
+			Items: []purchasing.GoodsReceiptItemRequest{{PurchaseOrderItemID: itemID, Quantity: 3}},
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrGoodsReceiptInvalid)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("DraftCannotBeReceived", func(t *testing.T) {
+		mockStore, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		mockStore.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+			return fn(mockPgx)
+		})
+		mockPgx.ExpectQuery("SELECT .* FROM purchase_orders").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(purchaseOrderColumns).AddRow(purchaseOrderRow(id, supplierID, repository.PurchaseOrderStatusDraft)...))
+
+		resp, err := service.ReceivePurchaseOrder(ctx, id, purchasing.ReceivePurchaseOrderRequest{
+			Items: []purchasing.GoodsReceiptItemRequest{{PurchaseOrderItemID: itemID, Quantity: 1}},
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrPurchaseOrderStatus)
+	})
+}
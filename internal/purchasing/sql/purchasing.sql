@@ -0,0 +1,196 @@
+-- name: ListSuppliers :many
+SELECT * FROM suppliers
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(search_text)::text IS NULL OR name ILIKE '%' || sqlc.narg(search_text) || '%')
+ORDER BY name ASC
+LIMIT $1 OFFSET $2;
+
+-- name: CountSuppliers :one
+SELECT COUNT(*) FROM suppliers
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(search_text)::text IS NULL OR name ILIKE '%' || sqlc.narg(search_text) || '%');
+
+-- name: GetSupplier :one
+SELECT * FROM suppliers
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetSupplierByName :one
+-- Dipakai untuk memastikan nama pemasok unik (tanpa membedakan huruf besar/kecil).
+SELECT * FROM suppliers
+WHERE lower(name) = lower(sqlc.arg(name)::text) AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: CreateSupplier :one
+INSERT INTO suppliers (name, contact_name, phone, email, address)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: UpdateSupplier :one
+UPDATE suppliers
+SET
+    name = $2,
+    contact_name = $3,
+    phone = $4,
+    email = $5,
+    address = $6
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: SoftDeleteSupplier :exec
+UPDATE suppliers SET deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: CountOpenPurchaseOrdersBySupplier :one
+-- PO yang belum selesai menahan pemasok agar tidak dihapus.
+SELECT COUNT(*) FROM purchase_orders
+WHERE supplier_id = $1
+  AND status IN ('draft', 'sent', 'partially_received');
+
+-- name: ListPurchaseOrders :many
+SELECT
+    po.*,
+    s.name AS supplier_name,
+    COALESCE((SELECT SUM(poi.quantity_ordered * poi.unit_cost) FROM purchase_order_items poi WHERE poi.purchase_order_id = po.id), 0)::numeric AS total_cost
+FROM purchase_orders po
+JOIN suppliers s ON s.id = po.supplier_id
+WHERE (sqlc.narg(status)::purchase_order_status IS NULL OR po.status = sqlc.narg(status))
+  AND (sqlc.narg(supplier_id)::uuid IS NULL OR po.supplier_id = sqlc.narg(supplier_id))
+ORDER BY po.created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: CountPurchaseOrders :one
+SELECT COUNT(*) FROM purchase_orders
+WHERE (sqlc.narg(status)::purchase_order_status IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(supplier_id)::uuid IS NULL OR supplier_id = sqlc.narg(supplier_id));
+
+-- name: GetPurchaseOrder :one
+SELECT
+    po.*,
+    s.name AS supplier_name,
+    COALESCE((SELECT SUM(poi.quantity_ordered * poi.unit_cost) FROM purchase_order_items poi WHERE poi.purchase_order_id = po.id), 0)::numeric AS total_cost
+FROM purchase_orders po
+JOIN suppliers s ON s.id = po.supplier_id
+WHERE po.id = $1;
+
+-- name: GetPurchaseOrderForUpdate :one
+-- Mengunci PO selama perubahan status dan penerimaan barang.
+SELECT * FROM purchase_orders
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (supplier_id, expected_date, notes, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: UpdatePurchaseOrder :one
+-- Hanya PO berstatus draft yang boleh diubah.
+UPDATE purchase_orders
+SET
+    supplier_id = $2,
+    expected_date = $3,
+    notes = $4
+WHERE id = $1 AND status = 'draft'
+RETURNING *;
+
+-- name: UpdatePurchaseOrderStatus :one
+-- Mencatat waktu kirim, terima penuh dan tutup sesuai status baru.
+UPDATE purchase_orders
+SET
+    status = sqlc.arg(status)::purchase_order_status,
+    sent_at = CASE WHEN sqlc.arg(status)::purchase_order_status = 'sent' THEN now() ELSE sent_at END,
+    received_at = CASE WHEN sqlc.arg(status)::purchase_order_status = 'received' THEN now() ELSE received_at END,
+    closed_at = CASE WHEN sqlc.arg(status)::purchase_order_status = 'closed' THEN now() ELSE closed_at END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListPurchaseOrderItems :many
+SELECT
+    poi.*,
+    p.name AS product_name
+FROM purchase_order_items poi
+JOIN products p ON p.id = poi.product_id
+WHERE poi.purchase_order_id = $1
+ORDER BY p.name;
+
+-- name: GetPurchaseOrderItemsForUpdate :many
+SELECT * FROM purchase_order_items
+WHERE purchase_order_id = $1
+ORDER BY id
+FOR UPDATE;
+
+-- name: DeletePurchaseOrderItems :exec
+DELETE FROM purchase_order_items
+WHERE purchase_order_id = $1;
+
+-- name: CreatePurchaseOrderItem :exec
+INSERT INTO purchase_order_items (purchase_order_id, product_id, quantity_ordered, unit_cost)
+VALUES ($1, $2, $3, $4);
+
+-- name: AddPurchaseOrderItemReceived :exec
+UPDATE purchase_order_items
+SET quantity_received = quantity_received + sqlc.arg(quantity)::int
+WHERE id = sqlc.arg(id);
+
+-- name: CreateGoodsReceipt :one
+INSERT INTO goods_receipts (purchase_order_id, supplier_reference, notes, received_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: CreateGoodsReceiptItem :exec
+INSERT INTO goods_receipt_items (goods_receipt_id, purchase_order_item_id, quantity, unit_cost)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListGoodsReceipts :many
+SELECT * FROM goods_receipts
+WHERE purchase_order_id = $1
+ORDER BY received_at;
+
+-- name: ListGoodsReceiptItemsByPurchaseOrder :many
+SELECT
+    gri.id,
+    gri.goods_receipt_id,
+    gri.purchase_order_item_id,
+    poi.product_id,
+    p.name AS product_name,
+    gri.quantity,
+    gri.unit_cost
+FROM goods_receipt_items gri
+JOIN goods_receipts gr ON gr.id = gri.goods_receipt_id
+JOIN purchase_order_items poi ON poi.id = gri.purchase_order_item_id
+JOIN products p ON p.id = poi.product_id
+WHERE gr.purchase_order_id = $1
+ORDER BY gr.received_at, p.name;
+
+-- name: GetPurchasableProducts :many
+-- Produk aktif yang boleh dimasukkan ke PO. Produk bervarian menyimpan stok per varian, jadi tidak bisa diterima lewat PO.
+SELECT id, name FROM products p
+WHERE p.id = ANY(sqlc.arg(ids)::uuid[]) AND p.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = p.id AND pv.deleted_at IS NULL);
+
+-- name: GetProductStockForUpdate :many
+-- Mengunci stok produk yang diterima, urut id agar tidak deadlock.
+SELECT id, stock FROM products
+WHERE id = ANY(sqlc.arg(ids)::uuid[])
+ORDER BY id
+FOR UPDATE;
+
+-- name: ReceiveProductStock :exec
+-- Menambah stok dari penerimaan barang; harga pokok dihitung lewat lapisan biaya.
+UPDATE products
+SET stock = stock + sqlc.arg(quantity)::int
+WHERE id = sqlc.arg(id);
+
+-- name: CreateStockHistory :one
+INSERT INTO stock_history (
+    product_id,
+    change_amount,
+    previous_stock,
+    current_stock,
+    change_type,
+    reference_id,
+    note,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
@@ -89,10 +89,14 @@ type LowStockRequest struct {
 	Export    string `json:"export" query:"export"`
 }
 
+// LowStockProductResponse is a product running low, or one variant of it for products sold in variants.
 type LowStockProductResponse struct {
-	ProductID   string `json:"product_id"`
-	ProductName string `json:"product_name"`
-	Stock       int32  `json:"stock"`
+	ProductID   string  `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	VariantID   *string `json:"variant_id,omitempty"`
+	VariantName *string `json:"variant_name,omitempty"`
+	SKU         *string `json:"sku,omitempty"`
+	Stock       int32   `json:"stock"`
 }
 
 type PromotionPerformanceResponse struct {
@@ -101,6 +105,26 @@ type PromotionPerformanceResponse struct {
 	UsageCount              int64   `json:"usage_count"`
 	TotalDiscountGiven      float64 `json:"total_discount_given"`
 	TotalSalesWithPromotion float64 `json:"total_sales_with_promotion"`
+	// Codes breaks the redemptions down per promotion code; promotions applied by ID have none
+	Codes []PromotionCodePerformanceResponse `json:"codes"`
+	// Windows breaks the usage down per schedule window the promotion was granted in
+	Windows []PromotionWindowPerformanceResponse `json:"windows"`
+}
+
+type PromotionCodePerformanceResponse struct {
+	PromotionCodeID    string  `json:"promotion_code_id"`
+	Code               string  `json:"code"`
+	BatchID            *string `json:"batch_id,omitempty"`
+	RedemptionCount    int64   `json:"redemption_count"`
+	TotalDiscountGiven int64   `json:"total_discount_given"`
+}
+
+type PromotionWindowPerformanceResponse struct {
+	// Window is the label of the schedule window, e.g. "Mon-Fri 15:00-17:00"; null for uses outside any schedule
+	Window                  *string `json:"window"`
+	UsageCount              int64   `json:"usage_count"`
+	TotalDiscountGiven      int64   `json:"total_discount_given"`
+	TotalSalesWithPromotion int64   `json:"total_sales_with_promotion"`
 }
 
 type ShiftSummaryResponse struct {
@@ -114,3 +138,45 @@ type ShiftSummaryResponse struct {
 	ExpectedCashEnd *int64    `json:"expected_cash_end"`
 	CashDifference  int64     `json:"cash_difference"`
 }
+
+type OutstandingPurchaseOrderResponse struct {
+	PurchaseOrderID  string     `json:"purchase_order_id"`
+	PoNumber         string     `json:"po_number"`
+	SupplierName     string     `json:"supplier_name"`
+	Status           string     `json:"status"`
+	ExpectedDate     *string    `json:"expected_date"`
+	SentAt           *time.Time `json:"sent_at"`
+	QuantityOrdered  int64      `json:"quantity_ordered"`
+	QuantityReceived int64      `json:"quantity_received"`
+	OutstandingValue float64    `json:"outstanding_value"`
+	Overdue          bool       `json:"overdue"`
+}
+
+type SupplierSpendResponse struct {
+	SupplierID         string  `json:"supplier_id"`
+	SupplierName       string  `json:"supplier_name"`
+	ReceiptCount       int64   `json:"receipt_count"`
+	PurchaseOrderCount int64   `json:"purchase_order_count"`
+	TotalQuantity      int64   `json:"total_quantity"`
+	TotalSpend         float64 `json:"total_spend"`
+}
+
+// InventoryValuationResponse values the stock on hand with the store's costing method. Every product carries
+// both figures so switching methods can be compared before the setting is changed.
+type InventoryValuationResponse struct {
+	CostingMethod string                   `json:"costing_method" example:"fifo"`
+	TotalValue    float64                  `json:"total_value"`
+	Items         []InventoryValuationItem `json:"items"`
+}
+
+type InventoryValuationItem struct {
+	ProductID     string  `json:"product_id"`
+	ProductName   string  `json:"product_name"`
+	Stock         int32   `json:"stock"`
+	AverageCost   float64 `json:"average_cost"`
+	LayerQuantity int64   `json:"layer_quantity"`
+	OpenLayers    int64   `json:"open_layers"`
+	AverageValue  float64 `json:"average_value"`
+	FifoValue     float64 `json:"fifo_value"`
+	Value         float64 `json:"value"`
+}
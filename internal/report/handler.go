@@ -23,6 +23,9 @@ type IRptHandler interface {
 	GetLowStockProductsHandler(c fiber.Ctx) error
 	GetPromotionPerformanceHandler(c fiber.Ctx) error
 	GetShiftSummaryHandler(c fiber.Ctx) error
+	GetOutstandingPurchaseOrdersHandler(c fiber.Ctx) error
+	GetSupplierSpendHandler(c fiber.Ctx) error
+	GetInventoryValuationHandler(c fiber.Ctx) error
 }
 
 type RptHandler struct {
@@ -608,7 +611,7 @@ func (r *RptHandler) GetLowStockProductsHandler(c fiber.Ctx) error {
 
 // GetPromotionPerformanceHandler retrieves promotion performance
 // @Summary      Get promotion performance
-// @Description  Get metrics of promotions usage
+// @Description  Get metrics of promotions usage, with the redemptions of each promotion code and the usage in each schedule window (e.g. happy hour)
 // @Tags         Reports
 // @Accept       json
 // @Produce      json
@@ -697,3 +700,111 @@ func (r *RptHandler) GetShiftSummaryHandler(c fiber.Ctx) error {
 		Data:    results,
 	})
 }
+
+// GetOutstandingPurchaseOrdersHandler retrieves purchase orders still waiting for goods
+// @Summary      Get outstanding purchase orders
+// @Description  Get sent and partially received purchase orders with what is still to be delivered and its value, earliest expected first. Orders past their expected date are flagged overdue
+// @Tags         Reports
+// @Accept       json
+// @Produce      json
+// @Param        export    query string false "Export format (csv)"
+// @Success      200 {object} common.SuccessResponse{data=[]OutstandingPurchaseOrderResponse} "Outstanding purchase orders retrieved successfully"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin", "manager"]
+// @Router       /reports/purchase-orders/outstanding [get]
+func (r *RptHandler) GetOutstandingPurchaseOrdersHandler(c fiber.Ctx) error {
+	results, err := r.Service.GetOutstandingPurchaseOrders(c.RequestCtx())
+	if err != nil {
+		r.log.Error("Failed to get outstanding purchase orders", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to get outstanding purchase orders"})
+	}
+
+	if c.Query("export") == "csv" {
+		csvData, _ := utils.GenerateCSV(results)
+		c.Set("Content-Type", "text/csv")
+		c.Set("Content-Disposition", "attachment; filename=outstanding_purchase_orders.csv")
+		return c.Send(csvData)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Outstanding purchase orders retrieved successfully",
+		Data:    results,
+	})
+}
+
+// GetSupplierSpendHandler retrieves what was spent per supplier
+// @Summary      Get supplier spend
+// @Description  Get the value of goods received from each supplier within a date range, with receipt and purchase order counts
+// @Tags         Reports
+// @Accept       json
+// @Produce      json
+// @Param        start_date query string true "Start Date (YYYY-MM-DD)"
+// @Param        end_date   query string true "End Date (YYYY-MM-DD)"
+// @Param        export    query string false "Export format (csv)"
+// @Success      200 {object} common.SuccessResponse{data=[]SupplierSpendResponse} "Supplier spend retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid query parameters"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin", "manager"]
+// @Router       /reports/supplier-spend [get]
+func (r *RptHandler) GetSupplierSpendHandler(c fiber.Ctx) error {
+	var req SalesReportRequest
+	if err := c.Bind().Query(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid query parameters"})
+	}
+	startDate, _ := time.Parse("2006-01-02", req.StartDate)
+	endDate, _ := time.Parse("2006-01-02", req.EndDate)
+
+	serviceReq := &SalesReportServiceRequest{
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+
+	results, err := r.Service.GetSupplierSpend(c.RequestCtx(), serviceReq)
+	if err != nil {
+		r.log.Error("Failed to get supplier spend", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to get supplier spend"})
+	}
+
+	if req.Export == "csv" {
+		csvData, _ := utils.GenerateCSV(results)
+		c.Set("Content-Type", "text/csv")
+		c.Set("Content-Disposition", "attachment; filename=supplier_spend.csv")
+		return c.Send(csvData)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Supplier spend retrieved successfully",
+		Data:    results,
+	})
+}
+
+// GetInventoryValuationHandler values the stock on hand
+// @Summary      Get inventory valuation
+// @Description  Get the value of the stock on hand per product from its cost layers, under the store's costing method (moving average or FIFO). Both values are returned per product
+// @Tags         Reports
+// @Accept       json
+// @Produce      json
+// @Param        export    query string false "Export format (csv)"
+// @Success      200 {object} common.SuccessResponse{data=InventoryValuationResponse} "Inventory valuation retrieved successfully"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin", "manager"]
+// @Router       /reports/inventory-valuation [get]
+func (r *RptHandler) GetInventoryValuationHandler(c fiber.Ctx) error {
+	results, err := r.Service.GetInventoryValuation(c.RequestCtx())
+	if err != nil {
+		r.log.Error("Failed to get inventory valuation", "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to get inventory valuation"})
+	}
+
+	if c.Query("export") == "csv" {
+		csvData, _ := utils.GenerateCSV(results.Items)
+		c.Set("Content-Type", "text/csv")
+		c.Set("Content-Disposition", "attachment; filename=inventory_valuation.csv")
+		return c.Send(csvData)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Inventory valuation retrieved successfully",
+		Data:    results,
+	})
+}
@@ -15,14 +15,21 @@ type Querier interface {
 	GetCashierPerformance(ctx context.Context, arg GetCashierPerformanceParams) ([]GetCashierPerformanceRow, error)
 	GetCategorySales(ctx context.Context, arg GetCategorySalesParams) ([]GetCategorySalesRow, error)
 	GetDashboardSummary(ctx context.Context, arg GetDashboardSummaryParams) (GetDashboardSummaryRow, error)
+	GetInventoryValuation(ctx context.Context) ([]GetInventoryValuationRow, error)
 	GetLowStockProducts(ctx context.Context, stock int32) ([]GetLowStockProductsRow, error)
+	GetOutstandingPurchaseOrders(ctx context.Context) ([]GetOutstandingPurchaseOrdersRow, error)
 	GetPaymentMethodSales(ctx context.Context, arg GetPaymentMethodSalesParams) ([]GetPaymentMethodSalesRow, error)
+	// Kuantitas dan pendapatan dikurangi item yang direfund; HPP hanya dikurangi untuk item yang dikembalikan ke stok.
 	GetProductProfitReports(ctx context.Context, arg GetProductProfitReportsParams) ([]GetProductProfitReportsRow, error)
 	GetProductSalesPerformance(ctx context.Context, arg GetProductSalesPerformanceParams) ([]GetProductSalesPerformanceRow, error)
+	// Pendapatan dikurangi refund; HPP dikurangi biaya item refund yang dikembalikan ke stok.
 	GetProfitSummary(ctx context.Context, arg GetProfitSummaryParams) ([]GetProfitSummaryRow, error)
+	GetPromotionCodePerformance(ctx context.Context, arg GetPromotionCodePerformanceParams) ([]GetPromotionCodePerformanceRow, error)
 	GetPromotionPerformance(ctx context.Context, arg GetPromotionPerformanceParams) ([]GetPromotionPerformanceRow, error)
+	GetPromotionWindowPerformance(ctx context.Context, arg GetPromotionWindowPerformanceParams) ([]GetPromotionWindowPerformanceRow, error)
 	GetSalesSummary(ctx context.Context, arg GetSalesSummaryParams) ([]GetSalesSummaryRow, error)
 	GetShiftSummary(ctx context.Context, arg GetShiftSummaryParams) ([]GetShiftSummaryRow, error)
+	GetSupplierSpend(ctx context.Context, arg GetSupplierSpendParams) ([]GetSupplierSpendRow, error)
 }
 
 var _ Querier = (*Queries)(nil)
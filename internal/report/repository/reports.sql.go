@@ -82,7 +82,7 @@ SELECT
     u.id AS user_id,
     u.username,
     COUNT(o.id) AS order_count,
-    COALESCE(SUM(o.net_total), 0) AS total_sales
+    COALESCE(SUM(o.net_total - (SELECT COALESCE(SUM(r.amount), 0) FROM order_refunds r WHERE r.order_id = o.id)), 0) AS total_sales
 FROM orders o
          JOIN users u ON o.user_id = u.id
 WHERE o.created_at::date BETWEEN $1 AND $2
@@ -183,7 +183,7 @@ func (q *Queries) GetCategorySales(ctx context.Context, arg GetCategorySalesPara
 
 const getDashboardSummary = `-- name: GetDashboardSummary :one
 SELECT
-    COALESCE(SUM(net_total), 0) AS total_sales,
+    COALESCE(SUM(orders.net_total - (SELECT COALESCE(SUM(r.amount), 0) FROM order_refunds r WHERE r.order_id = orders.id)), 0) AS total_sales,
     COUNT(*) AS total_orders,
     COUNT(DISTINCT user_id) AS unique_cashiers,
     (SELECT COUNT(*) FROM products WHERE deleted_at IS NULL) AS total_products
@@ -216,19 +216,88 @@ func (q *Queries) GetDashboardSummary(ctx context.Context, arg GetDashboardSumma
 	return i, err
 }
 
+const getInventoryValuation = `-- name: GetInventoryValuation :many
+SELECT
+    p.id AS product_id,
+    p.name AS product_name,
+    p.stock,
+    p.cost_price,
+    COALESCE(SUM(cl.quantity_remaining), 0)::bigint AS layer_quantity,
+    COALESCE(SUM(cl.quantity_remaining * cl.unit_cost), 0)::numeric AS layer_value,
+    COUNT(cl.id) AS open_layers
+FROM products p
+         LEFT JOIN cost_layers cl ON cl.product_id = p.id AND cl.quantity_remaining > 0
+WHERE p.deleted_at IS NULL
+GROUP BY p.id
+HAVING p.stock <> 0 OR COALESCE(SUM(cl.quantity_remaining), 0) > 0
+ORDER BY p.name
+`
+
+type GetInventoryValuationRow struct {
+	ProductID     uuid.UUID      `json:"product_id"`
+	ProductName   string         `json:"product_name"`
+	Stock         int32          `json:"stock"`
+	CostPrice     pgtype.Numeric `json:"cost_price"`
+	LayerQuantity int64          `json:"layer_quantity"`
+	LayerValue    pgtype.Numeric `json:"layer_value"`
+	OpenLayers    int64          `json:"open_layers"`
+}
+
+func (q *Queries) GetInventoryValuation(ctx context.Context) ([]GetInventoryValuationRow, error) {
+	rows, err := q.db.Query(ctx, getInventoryValuation)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetInventoryValuationRow{}
+	for rows.Next() {
+		var i GetInventoryValuationRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.Stock,
+			&i.CostPrice,
+			&i.LayerQuantity,
+			&i.LayerValue,
+			&i.OpenLayers,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLowStockProducts = `-- name: GetLowStockProducts :many
 SELECT
-    id, name, stock
-FROM products
-WHERE stock <= $1
-  AND deleted_at IS NULL
+    p.id, p.name, p.stock,
+    NULL::uuid AS variant_id, NULL::text AS variant_name, NULL::text AS sku
+FROM products p
+WHERE p.stock <= $1
+  AND p.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = p.id AND pv.deleted_at IS NULL)
+UNION ALL
+SELECT
+    p.id, p.name, pv.stock,
+    pv.id AS variant_id, pv.name AS variant_name, pv.sku
+FROM product_variants pv
+JOIN products p ON p.id = pv.product_id
+WHERE pv.stock <= $1
+  AND pv.deleted_at IS NULL
+  AND p.deleted_at IS NULL
 ORDER BY stock ASC
 `
 
 type GetLowStockProductsRow struct {
-	ID    uuid.UUID `json:"id"`
-	Name  string    `json:"name"`
-	Stock int32     `json:"stock"`
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	Stock       int32       `json:"stock"`
+	VariantID   pgtype.UUID `json:"variant_id"`
+	VariantName *string     `json:"variant_name"`
+	Sku         *string     `json:"sku"`
 }
 
 func (q *Queries) GetLowStockProducts(ctx context.Context, stock int32) ([]GetLowStockProductsRow, error) {
@@ -240,7 +309,75 @@ func (q *Queries) GetLowStockProducts(ctx context.Context, stock int32) ([]GetLo
 	items := []GetLowStockProductsRow{}
 	for rows.Next() {
 		var i GetLowStockProductsRow
-		if err := rows.Scan(&i.ID, &i.Name, &i.Stock); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Stock,
+			&i.VariantID,
+			&i.VariantName,
+			&i.Sku,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOutstandingPurchaseOrders = `-- name: GetOutstandingPurchaseOrders :many
+SELECT
+    po.id AS purchase_order_id,
+    po.po_number,
+    s.name AS supplier_name,
+    po.status,
+    po.expected_date,
+    po.sent_at,
+    COALESCE(SUM(poi.quantity_ordered), 0)::bigint AS quantity_ordered,
+    COALESCE(SUM(poi.quantity_received), 0)::bigint AS quantity_received,
+    COALESCE(SUM((poi.quantity_ordered - poi.quantity_received) * poi.unit_cost), 0)::numeric AS outstanding_value
+FROM purchase_orders po
+         JOIN suppliers s ON po.supplier_id = s.id
+         JOIN purchase_order_items poi ON poi.purchase_order_id = po.id
+WHERE po.status IN ('sent', 'partially_received')
+GROUP BY po.id, s.name
+ORDER BY po.expected_date ASC NULLS LAST, po.created_at ASC
+`
+
+type GetOutstandingPurchaseOrdersRow struct {
+	PurchaseOrderID  uuid.UUID           `json:"purchase_order_id"`
+	PoNumber         string              `json:"po_number"`
+	SupplierName     string              `json:"supplier_name"`
+	Status           PurchaseOrderStatus `json:"status"`
+	ExpectedDate     pgtype.Date         `json:"expected_date"`
+	SentAt           pgtype.Timestamptz  `json:"sent_at"`
+	QuantityOrdered  int64               `json:"quantity_ordered"`
+	QuantityReceived int64               `json:"quantity_received"`
+	OutstandingValue pgtype.Numeric      `json:"outstanding_value"`
+}
+
+func (q *Queries) GetOutstandingPurchaseOrders(ctx context.Context) ([]GetOutstandingPurchaseOrdersRow, error) {
+	rows, err := q.db.Query(ctx, getOutstandingPurchaseOrders)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOutstandingPurchaseOrdersRow{}
+	for rows.Next() {
+		var i GetOutstandingPurchaseOrdersRow
+		if err := rows.Scan(
+			&i.PurchaseOrderID,
+			&i.PoNumber,
+			&i.SupplierName,
+			&i.Status,
+			&i.ExpectedDate,
+			&i.SentAt,
+			&i.QuantityOrdered,
+			&i.QuantityReceived,
+			&i.OutstandingValue,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -255,10 +392,11 @@ const getPaymentMethodSales = `-- name: GetPaymentMethodSales :many
 SELECT
     pm.id AS payment_method_id,
     pm.name AS payment_method_name,
-    COUNT(o.id) AS order_count,
-    COALESCE(SUM(o.net_total), 0) AS total_sales
-FROM orders o
-         JOIN payment_methods pm ON o.payment_method_id = pm.id
+    COUNT(DISTINCT o.id) AS order_count,
+    COALESCE(SUM(op.amount), 0) AS total_sales
+FROM order_payments op
+         JOIN orders o ON op.order_id = o.id
+         JOIN payment_methods pm ON op.payment_method_id = pm.id
 WHERE o.created_at::date BETWEEN $1 AND $2
   AND o.status IN ('paid', 'served')
 GROUP BY pm.id, pm.name
@@ -362,15 +500,73 @@ func (q *Queries) GetProductSalesPerformance(ctx context.Context, arg GetProduct
 	return items, nil
 }
 
+const getPromotionCodePerformance = `-- name: GetPromotionCodePerformance :many
+SELECT
+    pr.promotion_id,
+    pc.id AS promotion_code_id,
+    pc.code,
+    pc.batch_id,
+    COUNT(pr.id) AS redemption_count,
+    COALESCE(SUM(pr.discount_amount), 0)::bigint AS total_discount_given
+FROM promotion_redemptions pr
+JOIN promotion_codes pc ON pc.id = pr.promotion_code_id
+WHERE pr.created_at::date BETWEEN $1 AND $2
+  AND pr.status = 'redeemed'
+GROUP BY pr.promotion_id, pc.id, pc.code, pc.batch_id
+ORDER BY redemption_count DESC, pc.code
+`
+
+type GetPromotionCodePerformanceParams struct {
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	CreatedAt_2 pgtype.Timestamptz `json:"created_at_2"`
+}
+
+type GetPromotionCodePerformanceRow struct {
+	PromotionID        uuid.UUID   `json:"promotion_id"`
+	PromotionCodeID    uuid.UUID   `json:"promotion_code_id"`
+	Code               string      `json:"code"`
+	BatchID            pgtype.UUID `json:"batch_id"`
+	RedemptionCount    int64       `json:"redemption_count"`
+	TotalDiscountGiven int64       `json:"total_discount_given"`
+}
+
+func (q *Queries) GetPromotionCodePerformance(ctx context.Context, arg GetPromotionCodePerformanceParams) ([]GetPromotionCodePerformanceRow, error) {
+	rows, err := q.db.Query(ctx, getPromotionCodePerformance, arg.CreatedAt, arg.CreatedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPromotionCodePerformanceRow{}
+	for rows.Next() {
+		var i GetPromotionCodePerformanceRow
+		if err := rows.Scan(
+			&i.PromotionID,
+			&i.PromotionCodeID,
+			&i.Code,
+			&i.BatchID,
+			&i.RedemptionCount,
+			&i.TotalDiscountGiven,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPromotionPerformance = `-- name: GetPromotionPerformance :many
 SELECT
     p.id AS promotion_id,
     p.name AS promotion_name,
     COUNT(o.id) AS usage_count,
-    COALESCE(SUM(o.discount_amount), 0) AS total_discount_given,
+    COALESCE(SUM(op.discount_amount), 0) AS total_discount_given,
     COALESCE(SUM(o.net_total), 0) AS total_sales_with_promotion
-FROM orders o
-JOIN promotions p ON o.applied_promotion_id = p.id
+FROM order_promotions op
+JOIN orders o ON o.id = op.order_id
+JOIN promotions p ON p.id = op.promotion_id
 WHERE o.created_at::date BETWEEN $1 AND $2
   AND o.status IN ('paid', 'served')
 GROUP BY p.id, p.name
@@ -416,14 +612,68 @@ func (q *Queries) GetPromotionPerformance(ctx context.Context, arg GetPromotionP
 	return items, nil
 }
 
+const getPromotionWindowPerformance = `-- name: GetPromotionWindowPerformance :many
+SELECT
+    op.promotion_id,
+    op.schedule_window,
+    COUNT(o.id) AS usage_count,
+    COALESCE(SUM(op.discount_amount), 0)::bigint AS total_discount_given,
+    COALESCE(SUM(o.net_total), 0)::bigint AS total_sales_with_promotion
+FROM order_promotions op
+JOIN orders o ON o.id = op.order_id
+WHERE o.created_at::date BETWEEN $1 AND $2
+  AND o.status IN ('paid', 'served')
+GROUP BY op.promotion_id, op.schedule_window
+ORDER BY usage_count DESC, op.schedule_window NULLS LAST
+`
+
+type GetPromotionWindowPerformanceParams struct {
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	CreatedAt_2 pgtype.Timestamptz `json:"created_at_2"`
+}
+
+type GetPromotionWindowPerformanceRow struct {
+	PromotionID             uuid.UUID `json:"promotion_id"`
+	ScheduleWindow          *string   `json:"schedule_window"`
+	UsageCount              int64     `json:"usage_count"`
+	TotalDiscountGiven      int64     `json:"total_discount_given"`
+	TotalSalesWithPromotion int64     `json:"total_sales_with_promotion"`
+}
+
+func (q *Queries) GetPromotionWindowPerformance(ctx context.Context, arg GetPromotionWindowPerformanceParams) ([]GetPromotionWindowPerformanceRow, error) {
+	rows, err := q.db.Query(ctx, getPromotionWindowPerformance, arg.CreatedAt, arg.CreatedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPromotionWindowPerformanceRow{}
+	for rows.Next() {
+		var i GetPromotionWindowPerformanceRow
+		if err := rows.Scan(
+			&i.PromotionID,
+			&i.ScheduleWindow,
+			&i.UsageCount,
+			&i.TotalDiscountGiven,
+			&i.TotalSalesWithPromotion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getSalesSummary = `-- name: GetSalesSummary :many
 SELECT
-    created_at::date AS date,
+    orders.created_at::date AS date,
     COUNT(*) AS order_count,
-    COALESCE(SUM(net_total), 0) AS total_sales
+    COALESCE(SUM(orders.net_total - (SELECT COALESCE(SUM(r.amount), 0) FROM order_refunds r WHERE r.order_id = orders.id)), 0) AS total_sales
 FROM orders
-WHERE created_at::date BETWEEN $1 AND $2
-  AND status IN ('paid', 'served')
+WHERE orders.created_at::date BETWEEN $1 AND $2
+  AND orders.status IN ('paid', 'served')
 GROUP BY date
 ORDER BY date
 `
@@ -522,3 +772,61 @@ func (q *Queries) GetShiftSummary(ctx context.Context, arg GetShiftSummaryParams
 	}
 	return items, nil
 }
+
+const getSupplierSpend = `-- name: GetSupplierSpend :many
+SELECT
+    s.id AS supplier_id,
+    s.name AS supplier_name,
+    COUNT(DISTINCT gr.id) AS receipt_count,
+    COUNT(DISTINCT gr.purchase_order_id) AS purchase_order_count,
+    COALESCE(SUM(gri.quantity), 0)::bigint AS total_quantity,
+    COALESCE(SUM(gri.quantity * gri.unit_cost), 0)::numeric AS total_spend
+FROM goods_receipts gr
+         JOIN purchase_orders po ON gr.purchase_order_id = po.id
+         JOIN suppliers s ON po.supplier_id = s.id
+         JOIN goods_receipt_items gri ON gri.goods_receipt_id = gr.id
+WHERE gr.received_at::date BETWEEN $1 AND $2
+GROUP BY s.id, s.name
+ORDER BY total_spend DESC
+`
+
+type GetSupplierSpendParams struct {
+	ReceivedAt   pgtype.Timestamptz `json:"received_at"`
+	ReceivedAt_2 pgtype.Timestamptz `json:"received_at_2"`
+}
+
+type GetSupplierSpendRow struct {
+	SupplierID         uuid.UUID      `json:"supplier_id"`
+	SupplierName       string         `json:"supplier_name"`
+	ReceiptCount       int64          `json:"receipt_count"`
+	PurchaseOrderCount int64          `json:"purchase_order_count"`
+	TotalQuantity      int64          `json:"total_quantity"`
+	TotalSpend         pgtype.Numeric `json:"total_spend"`
+}
+
+func (q *Queries) GetSupplierSpend(ctx context.Context, arg GetSupplierSpendParams) ([]GetSupplierSpendRow, error) {
+	rows, err := q.db.Query(ctx, getSupplierSpend, arg.ReceivedAt, arg.ReceivedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetSupplierSpendRow{}
+	for rows.Next() {
+		var i GetSupplierSpendRow
+		if err := rows.Scan(
+			&i.SupplierID,
+			&i.SupplierName,
+			&i.ReceiptCount,
+			&i.PurchaseOrderCount,
+			&i.TotalQuantity,
+			&i.TotalSpend,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
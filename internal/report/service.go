@@ -4,13 +4,17 @@ import (
 	"POS-kasir/internal/activitylog"
 	"POS-kasir/internal/common/pagination"
 	"POS-kasir/internal/common/store"
+	"POS-kasir/internal/costing"
 	"POS-kasir/internal/report/repository"
+	"POS-kasir/internal/settings"
 	"POS-kasir/pkg/logger"
+	"POS-kasir/pkg/utils"
 	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"POS-kasir/pkg/cache"
@@ -29,13 +33,17 @@ type IRptService interface {
 	GetLowStockProducts(ctx context.Context, req *LowStockRequest) (*[]LowStockProductResponse, error)
 	GetPromotionPerformanceReport(ctx context.Context, req *SalesReportServiceRequest) (*[]PromotionPerformanceResponse, error)
 	GetShiftSummaryReport(ctx context.Context, req *SalesReportServiceRequest) (*[]ShiftSummaryResponse, error)
+	GetOutstandingPurchaseOrders(ctx context.Context) (*[]OutstandingPurchaseOrderResponse, error)
+	GetSupplierSpend(ctx context.Context, req *SalesReportServiceRequest) (*[]SupplierSpendResponse, error)
+	GetInventoryValuation(ctx context.Context) (*InventoryValuationResponse, error)
 }
 
-func NewRptService(store store.Store, repo repository.Querier, activityLogService activitylog.IActivityService, log logger.ILogger, redisCache cache.Cache) IRptService {
+func NewRptService(store store.Store, repo repository.Querier, activityLogService activitylog.IActivityService, settingsService settings.ISettingsService, log logger.ILogger, redisCache cache.Cache) IRptService {
 	return &RptService{
 		repo:               repo,
 		Store:              store,
 		ActivityLogService: activityLogService,
+		SettingsService:    settingsService,
 		Log:                log,
 		Cache:              redisCache,
 	}
@@ -45,6 +53,7 @@ type RptService struct {
 	repo               repository.Querier
 	Store              store.Store
 	ActivityLogService activitylog.IActivityService
+	SettingsService    settings.ISettingsService
 	Log                logger.ILogger
 	Cache              cache.Cache
 }
@@ -455,11 +464,18 @@ func (r *RptService) GetLowStockProducts(ctx context.Context, req *LowStockReque
 
 	var response []LowStockProductResponse
 	for _, p := range products {
-		response = append(response, LowStockProductResponse{
+		item := LowStockProductResponse{
 			ProductID:   p.ID.String(),
 			ProductName: p.Name,
+			VariantName: p.VariantName,
+			SKU:         p.Sku,
 			Stock:       p.Stock,
-		})
+		}
+		if variantID := utils.NullableUUIDToPointer(p.VariantID); variantID != nil {
+			id := variantID.String()
+			item.VariantID = &id
+		}
+		response = append(response, item)
 	}
 
 	return &response, nil
@@ -477,6 +493,48 @@ func (r *RptService) GetPromotionPerformanceReport(ctx context.Context, req *Sal
 		return nil, err
 	}
 
+	codeRows, err := r.repo.GetPromotionCodePerformance(ctx, repository.GetPromotionCodePerformanceParams{
+		CreatedAt:   params.CreatedAt,
+		CreatedAt_2: params.CreatedAt_2,
+	})
+	if err != nil {
+		r.Log.Error("Failed to get promotion code performance", "error", err)
+		return nil, err
+	}
+	codes := make(map[uuid.UUID][]PromotionCodePerformanceResponse)
+	for _, c := range codeRows {
+		var batchID *string
+		if c.BatchID.Valid {
+			id := uuid.UUID(c.BatchID.Bytes).String()
+			batchID = &id
+		}
+		codes[c.PromotionID] = append(codes[c.PromotionID], PromotionCodePerformanceResponse{
+			PromotionCodeID:    c.PromotionCodeID.String(),
+			Code:               c.Code,
+			BatchID:            batchID,
+			RedemptionCount:    c.RedemptionCount,
+			TotalDiscountGiven: c.TotalDiscountGiven,
+		})
+	}
+
+	windowRows, err := r.repo.GetPromotionWindowPerformance(ctx, repository.GetPromotionWindowPerformanceParams{
+		CreatedAt:   params.CreatedAt,
+		CreatedAt_2: params.CreatedAt_2,
+	})
+	if err != nil {
+		r.Log.Error("Failed to get promotion window performance", "error", err)
+		return nil, err
+	}
+	windows := make(map[uuid.UUID][]PromotionWindowPerformanceResponse)
+	for _, w := range windowRows {
+		windows[w.PromotionID] = append(windows[w.PromotionID], PromotionWindowPerformanceResponse{
+			Window:                  w.ScheduleWindow,
+			UsageCount:              w.UsageCount,
+			TotalDiscountGiven:      w.TotalDiscountGiven,
+			TotalSalesWithPromotion: w.TotalSalesWithPromotion,
+		})
+	}
+
 	var response []PromotionPerformanceResponse
 	for _, p := range promotions {
 		var totalDiscount, totalSales float64
@@ -495,6 +553,8 @@ func (r *RptService) GetPromotionPerformanceReport(ctx context.Context, req *Sal
 			UsageCount:              p.UsageCount,
 			TotalDiscountGiven:      totalDiscount,
 			TotalSalesWithPromotion: totalSales,
+			Codes:                   codes[p.PromotionID],
+			Windows:                 windows[p.PromotionID],
 		})
 	}
 
@@ -530,3 +590,107 @@ func (r *RptService) GetShiftSummaryReport(ctx context.Context, req *SalesReport
 
 	return &response, nil
 }
+
+func (r *RptService) GetOutstandingPurchaseOrders(ctx context.Context) (*[]OutstandingPurchaseOrderResponse, error) {
+	orders, err := r.repo.GetOutstandingPurchaseOrders(ctx)
+	if err != nil {
+		r.Log.Error("Failed to get outstanding purchase orders", "error", err)
+		return nil, err
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	response := make([]OutstandingPurchaseOrderResponse, len(orders))
+	for i, po := range orders {
+		response[i] = OutstandingPurchaseOrderResponse{
+			PurchaseOrderID:  po.PurchaseOrderID.String(),
+			PoNumber:         po.PoNumber,
+			SupplierName:     po.SupplierName,
+			Status:           string(po.Status),
+			QuantityOrdered:  po.QuantityOrdered,
+			QuantityReceived: po.QuantityReceived,
+			OutstandingValue: utils.NumericToFloat64(po.OutstandingValue),
+		}
+		if po.ExpectedDate.Valid {
+			expected := po.ExpectedDate.Time.Format("2006-01-02")
+			response[i].ExpectedDate = &expected
+			response[i].Overdue = po.ExpectedDate.Time.Before(today)
+		}
+		if po.SentAt.Valid {
+			response[i].SentAt = &po.SentAt.Time
+		}
+	}
+
+	return &response, nil
+}
+
+func (r *RptService) GetSupplierSpend(ctx context.Context, req *SalesReportServiceRequest) (*[]SupplierSpendResponse, error) {
+	params := repository.GetSupplierSpendParams{
+		ReceivedAt:   pgtype.Timestamptz{Time: req.StartDate, Valid: true},
+		ReceivedAt_2: pgtype.Timestamptz{Time: req.EndDate, Valid: true},
+	}
+
+	suppliers, err := r.repo.GetSupplierSpend(ctx, params)
+	if err != nil {
+		r.Log.Error("Failed to get supplier spend", "error", err)
+		return nil, err
+	}
+
+	response := make([]SupplierSpendResponse, len(suppliers))
+	for i, s := range suppliers {
+		response[i] = SupplierSpendResponse{
+			SupplierID:         s.SupplierID.String(),
+			SupplierName:       s.SupplierName,
+			ReceiptCount:       s.ReceiptCount,
+			PurchaseOrderCount: s.PurchaseOrderCount,
+			TotalQuantity:      s.TotalQuantity,
+			TotalSpend:         utils.NumericToFloat64(s.TotalSpend),
+		}
+	}
+
+	return &response, nil
+}
+
+func (r *RptService) GetInventoryValuation(ctx context.Context) (*InventoryValuationResponse, error) {
+	operational, err := r.SettingsService.GetOperationalSettings(ctx)
+	if err != nil {
+		r.Log.Error("Failed to load operational settings", "error", err)
+		return nil, err
+	}
+
+	products, err := r.repo.GetInventoryValuation(ctx)
+	if err != nil {
+		r.Log.Error("Failed to get inventory valuation", "error", err)
+		return nil, err
+	}
+
+	response := &InventoryValuationResponse{
+		CostingMethod: operational.CostingMethod,
+		Items:         make([]InventoryValuationItem, len(products)),
+	}
+	for i, p := range products {
+		averageCost := utils.NumericToFloat64(p.CostPrice)
+		item := InventoryValuationItem{
+			ProductID:     p.ProductID.String(),
+			ProductName:   p.ProductName,
+			Stock:         p.Stock,
+			AverageCost:   averageCost,
+			LayerQuantity: p.LayerQuantity,
+			OpenLayers:    p.OpenLayers,
+			AverageValue:  float64(p.Stock) * averageCost,
+			FifoValue:     utils.NumericToFloat64(p.LayerValue),
+		}
+		// Stock the layers do not cover, e.g. counted before layers were kept, is valued at the average cost
+		if uncovered := int64(p.Stock) - p.LayerQuantity; uncovered > 0 {
+			item.FifoValue += float64(uncovered) * averageCost
+		}
+
+		item.Value = item.AverageValue
+		if operational.CostingMethod == costing.MethodFIFO {
+			item.Value = item.FifoValue
+		}
+		response.TotalValue += item.Value
+		response.Items[i] = item
+	}
+
+	return response, nil
+}
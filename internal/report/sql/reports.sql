@@ -1,6 +1,6 @@
 -- name: GetDashboardSummary :one
 SELECT
-    COALESCE(SUM(net_total), 0) AS total_sales,
+    COALESCE(SUM(orders.net_total - (SELECT COALESCE(SUM(r.amount), 0) FROM order_refunds r WHERE r.order_id = orders.id)), 0) AS total_sales,
     COUNT(*) AS total_orders,
     COUNT(DISTINCT user_id) AS unique_cashiers,
     (SELECT COUNT(*) FROM products WHERE deleted_at IS NULL) AS total_products
@@ -10,12 +10,12 @@ WHERE orders.created_at::date BETWEEN $1 AND $2
 
 -- name: GetSalesSummary :many
 SELECT
-    created_at::date AS date,
+    orders.created_at::date AS date,
     COUNT(*) AS order_count,
-    COALESCE(SUM(net_total), 0) AS total_sales
+    COALESCE(SUM(orders.net_total - (SELECT COALESCE(SUM(r.amount), 0) FROM order_refunds r WHERE r.order_id = orders.id)), 0) AS total_sales
 FROM orders
-WHERE created_at::date BETWEEN $1 AND $2
-  AND status IN ('paid', 'served')
+WHERE orders.created_at::date BETWEEN $1 AND $2
+  AND orders.status IN ('paid', 'served')
 GROUP BY date
 ORDER BY date;
 
@@ -61,10 +61,11 @@ ORDER BY total_revenue DESC;
 SELECT
     pm.id AS payment_method_id,
     pm.name AS payment_method_name,
-    COUNT(o.id) AS order_count,
-    COALESCE(SUM(o.net_total), 0) AS total_sales
-FROM orders o
-         JOIN payment_methods pm ON o.payment_method_id = pm.id
+    COUNT(DISTINCT o.id) AS order_count,
+    COALESCE(SUM(op.amount), 0) AS total_sales
+FROM order_payments op
+         JOIN orders o ON op.order_id = o.id
+         JOIN payment_methods pm ON op.payment_method_id = pm.id
 WHERE o.created_at::date BETWEEN $1 AND $2
   AND o.status IN ('paid', 'served')
 GROUP BY pm.id, pm.name
@@ -75,7 +76,7 @@ SELECT
     u.id AS user_id,
     u.username,
     COUNT(o.id) AS order_count,
-    COALESCE(SUM(o.net_total), 0) AS total_sales
+    COALESCE(SUM(o.net_total - (SELECT COALESCE(SUM(r.amount), 0) FROM order_refunds r WHERE r.order_id = o.id)), 0) AS total_sales
 FROM orders o
          JOIN users u ON o.user_id = u.id
 WHERE o.created_at::date BETWEEN $1 AND $2
@@ -97,10 +98,21 @@ ORDER BY cancelled_orders DESC;
 
 -- name: GetLowStockProducts :many
 SELECT
-    id, name, stock
-FROM products
-WHERE stock <= $1
-  AND deleted_at IS NULL
+    p.id, p.name, p.stock,
+    NULL::uuid AS variant_id, NULL::text AS variant_name, NULL::text AS sku
+FROM products p
+WHERE p.stock <= $1
+  AND p.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = p.id AND pv.deleted_at IS NULL)
+UNION ALL
+SELECT
+    p.id, p.name, pv.stock,
+    pv.id AS variant_id, pv.name AS variant_name, pv.sku
+FROM product_variants pv
+JOIN products p ON p.id = pv.product_id
+WHERE pv.stock <= $1
+  AND pv.deleted_at IS NULL
+  AND p.deleted_at IS NULL
 ORDER BY stock ASC;
 
 -- name: GetPromotionPerformance :many
@@ -108,15 +120,45 @@ SELECT
     p.id AS promotion_id,
     p.name AS promotion_name,
     COUNT(o.id) AS usage_count,
-    COALESCE(SUM(o.discount_amount), 0) AS total_discount_given,
+    COALESCE(SUM(op.discount_amount), 0) AS total_discount_given,
     COALESCE(SUM(o.net_total), 0) AS total_sales_with_promotion
-FROM orders o
-JOIN promotions p ON o.applied_promotion_id = p.id
+FROM order_promotions op
+JOIN orders o ON o.id = op.order_id
+JOIN promotions p ON p.id = op.promotion_id
 WHERE o.created_at::date BETWEEN $1 AND $2
   AND o.status IN ('paid', 'served')
 GROUP BY p.id, p.name
 ORDER BY usage_count DESC;
 
+-- name: GetPromotionWindowPerformance :many
+SELECT
+    op.promotion_id,
+    op.schedule_window,
+    COUNT(o.id) AS usage_count,
+    COALESCE(SUM(op.discount_amount), 0)::bigint AS total_discount_given,
+    COALESCE(SUM(o.net_total), 0)::bigint AS total_sales_with_promotion
+FROM order_promotions op
+JOIN orders o ON o.id = op.order_id
+WHERE o.created_at::date BETWEEN $1 AND $2
+  AND o.status IN ('paid', 'served')
+GROUP BY op.promotion_id, op.schedule_window
+ORDER BY usage_count DESC, op.schedule_window NULLS LAST;
+
+-- name: GetPromotionCodePerformance :many
+SELECT
+    pr.promotion_id,
+    pc.id AS promotion_code_id,
+    pc.code,
+    pc.batch_id,
+    COUNT(pr.id) AS redemption_count,
+    COALESCE(SUM(pr.discount_amount), 0)::bigint AS total_discount_given
+FROM promotion_redemptions pr
+JOIN promotion_codes pc ON pc.id = pr.promotion_code_id
+WHERE pr.created_at::date BETWEEN $1 AND $2
+  AND pr.status = 'redeemed'
+GROUP BY pr.promotion_id, pc.id, pc.code, pc.batch_id
+ORDER BY redemption_count DESC, pc.code;
+
 -- name: GetShiftSummary :many
 SELECT
     s.id AS shift_id,
@@ -131,4 +173,54 @@ SELECT
 FROM shifts s
 JOIN users u ON s.user_id = u.id
 WHERE s.start_time::date BETWEEN $1 AND $2
-ORDER BY s.start_time DESC;
\ No newline at end of file
+ORDER BY s.start_time DESC;
+
+-- name: GetOutstandingPurchaseOrders :many
+SELECT
+    po.id AS purchase_order_id,
+    po.po_number,
+    s.name AS supplier_name,
+    po.status,
+    po.expected_date,
+    po.sent_at,
+    COALESCE(SUM(poi.quantity_ordered), 0)::bigint AS quantity_ordered,
+    COALESCE(SUM(poi.quantity_received), 0)::bigint AS quantity_received,
+    COALESCE(SUM((poi.quantity_ordered - poi.quantity_received) * poi.unit_cost), 0)::numeric AS outstanding_value
+FROM purchase_orders po
+         JOIN suppliers s ON po.supplier_id = s.id
+         JOIN purchase_order_items poi ON poi.purchase_order_id = po.id
+WHERE po.status IN ('sent', 'partially_received')
+GROUP BY po.id, s.name
+ORDER BY po.expected_date ASC NULLS LAST, po.created_at ASC;
+
+-- name: GetSupplierSpend :many
+SELECT
+    s.id AS supplier_id,
+    s.name AS supplier_name,
+    COUNT(DISTINCT gr.id) AS receipt_count,
+    COUNT(DISTINCT gr.purchase_order_id) AS purchase_order_count,
+    COALESCE(SUM(gri.quantity), 0)::bigint AS total_quantity,
+    COALESCE(SUM(gri.quantity * gri.unit_cost), 0)::numeric AS total_spend
+FROM goods_receipts gr
+         JOIN purchase_orders po ON gr.purchase_order_id = po.id
+         JOIN suppliers s ON po.supplier_id = s.id
+         JOIN goods_receipt_items gri ON gri.goods_receipt_id = gr.id
+WHERE gr.received_at::date BETWEEN $1 AND $2
+GROUP BY s.id, s.name
+ORDER BY total_spend DESC;
+
+-- name: GetInventoryValuation :many
+SELECT
+    p.id AS product_id,
+    p.name AS product_name,
+    p.stock,
+    p.cost_price,
+    COALESCE(SUM(cl.quantity_remaining), 0)::bigint AS layer_quantity,
+    COALESCE(SUM(cl.quantity_remaining * cl.unit_cost), 0)::numeric AS layer_value,
+    COUNT(cl.id) AS open_layers
+FROM products p
+         LEFT JOIN cost_layers cl ON cl.product_id = p.id AND cl.quantity_remaining > 0
+WHERE p.deleted_at IS NULL
+GROUP BY p.id
+HAVING p.stock <> 0 OR COALESCE(SUM(cl.quantity_remaining), 0) > 0
+ORDER BY p.name;
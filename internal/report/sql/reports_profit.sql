@@ -1,35 +1,54 @@
-
 -- name: GetProfitSummary :many
+-- Pendapatan dikurangi refund; HPP dikurangi biaya item refund yang dikembalikan ke stok.
 SELECT
-    created_at::date AS date,
-    COALESCE(SUM(net_total), 0) AS total_revenue,
+    o.created_at::date AS date,
+    COALESCE(SUM(o.net_total - (SELECT COALESCE(SUM(r.amount), 0) FROM order_refunds r WHERE r.order_id = o.id)), 0) AS total_revenue,
     COALESCE(SUM(
-        (SELECT SUM(oi.cost_price_at_sale * oi.quantity)
+        (SELECT SUM(oi.cost_price_at_sale * (oi.quantity - COALESCE(rq.restocked_quantity, 0)))
          FROM order_items oi
+         LEFT JOIN (
+             SELECT ri.order_item_id, SUM(ri.quantity) FILTER (WHERE ri.restocked) AS restocked_quantity
+             FROM order_refund_items ri
+             GROUP BY ri.order_item_id
+         ) rq ON rq.order_item_id = oi.id
          WHERE oi.order_id = o.id)
     ), 0) AS total_cogs,
-    COALESCE(SUM(net_total), 0) - COALESCE(SUM(
-        (SELECT SUM(oi.cost_price_at_sale * oi.quantity)
+    COALESCE(SUM(o.net_total - (SELECT COALESCE(SUM(r.amount), 0) FROM order_refunds r WHERE r.order_id = o.id)), 0) - COALESCE(SUM(
+        (SELECT SUM(oi.cost_price_at_sale * (oi.quantity - COALESCE(rq.restocked_quantity, 0)))
          FROM order_items oi
+         LEFT JOIN (
+             SELECT ri.order_item_id, SUM(ri.quantity) FILTER (WHERE ri.restocked) AS restocked_quantity
+             FROM order_refund_items ri
+             GROUP BY ri.order_item_id
+         ) rq ON rq.order_item_id = oi.id
          WHERE oi.order_id = o.id)
     ), 0) AS gross_profit
 FROM orders o
-WHERE created_at::date BETWEEN $1 AND $2
-  AND status IN ('paid', 'served')
+WHERE o.created_at::date BETWEEN $1 AND $2
+  AND o.status IN ('paid', 'served')
 GROUP BY date
 ORDER BY date;
 
 -- name: GetProductProfitReports :many
+-- Kuantitas dan pendapatan dikurangi item yang direfund; HPP hanya dikurangi untuk item yang dikembalikan ke stok.
 SELECT
     p.id AS product_id,
     p.name AS product_name,
-    SUM(oi.quantity) AS total_sold,
-    SUM(oi.net_subtotal) AS total_revenue,
-    SUM(oi.cost_price_at_sale * oi.quantity) AS total_cogs,
-    SUM(oi.net_subtotal) - SUM(oi.cost_price_at_sale * oi.quantity) AS gross_profit
+    SUM(oi.quantity - COALESCE(rq.refunded_quantity, 0)) AS total_sold,
+    SUM(oi.net_subtotal - oi.net_subtotal * COALESCE(rq.refunded_quantity, 0) / oi.quantity) AS total_revenue,
+    SUM(oi.cost_price_at_sale * (oi.quantity - COALESCE(rq.restocked_quantity, 0))) AS total_cogs,
+    SUM(oi.net_subtotal - oi.net_subtotal * COALESCE(rq.refunded_quantity, 0) / oi.quantity) - SUM(oi.cost_price_at_sale * (oi.quantity - COALESCE(rq.restocked_quantity, 0))) AS gross_profit
 FROM order_items oi
 JOIN products p ON oi.product_id = p.id
 JOIN orders o ON oi.order_id = o.id
+LEFT JOIN (
+    SELECT
+        ri.order_item_id,
+        SUM(ri.quantity) AS refunded_quantity,
+        SUM(ri.quantity) FILTER (WHERE ri.restocked) AS restocked_quantity
+    FROM order_refund_items ri
+    GROUP BY ri.order_item_id
+) rq ON rq.order_item_id = oi.id
 WHERE o.created_at::date BETWEEN $1 AND $2
   AND o.status IN ('paid', 'served')
 GROUP BY p.id, p.name
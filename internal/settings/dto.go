@@ -1,6 +1,10 @@
 package settings
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type SettingResponse struct {
 	Key         string    `json:"key"`
@@ -38,3 +42,109 @@ type UpdatePrinterSettingsRequest struct {
 	AutoPrint   *bool  `json:"auto_print" validate:"required"`
 	PrintMethod string `json:"print_method" validate:"required,oneof=BE FE"`
 }
+
+type TaxSettingsResponse struct {
+	TaxEnabled              bool     `json:"tax_enabled"`
+	TaxRate                 float64  `json:"tax_rate"`
+	TaxInclusive            bool     `json:"tax_inclusive"`
+	TaxOrderTypes           []string `json:"tax_order_types"`
+	TaxExemptCategoryIDs    []int32  `json:"tax_exempt_category_ids"`
+	ServiceChargeEnabled    bool     `json:"service_charge_enabled"`
+	ServiceChargeRate       float64  `json:"service_charge_rate"`
+	ServiceChargeOrderTypes []string `json:"service_charge_order_types"`
+	ServiceChargeTaxable    bool     `json:"service_charge_taxable"`
+	RoundingMode            string   `json:"rounding_mode"`
+}
+
+type UpdateTaxSettingsRequest struct {
+	TaxEnabled              *bool    `json:"tax_enabled" validate:"required"`
+	TaxRate                 float64  `json:"tax_rate" validate:"gte=0,lte=100"`
+	TaxInclusive            *bool    `json:"tax_inclusive" validate:"required"`
+	TaxOrderTypes           []string `json:"tax_order_types" validate:"dive,oneof=dine_in takeaway"`
+	TaxExemptCategoryIDs    []int32  `json:"tax_exempt_category_ids" validate:"dive,gt=0"`
+	ServiceChargeEnabled    *bool    `json:"service_charge_enabled" validate:"required"`
+	ServiceChargeRate       float64  `json:"service_charge_rate" validate:"gte=0,lte=100"`
+	ServiceChargeOrderTypes []string `json:"service_charge_order_types" validate:"dive,oneof=dine_in takeaway"`
+	ServiceChargeTaxable    *bool    `json:"service_charge_taxable" validate:"required"`
+	RoundingMode            string   `json:"rounding_mode" validate:"required,oneof=round floor ceil"`
+}
+
+// OperationalSettingsResponse holds the store's time zone, how daily queue numbers are issued and how the
+// cost of goods sold is measured.
+type OperationalSettingsResponse struct {
+	Timezone          string `json:"timezone"`
+	QueuePrefix       string `json:"queue_prefix"`
+	QueueResetTime    string `json:"queue_reset_time"`
+	QueueNumberDigits int    `json:"queue_number_digits"`
+	CostingMethod     string `json:"costing_method" example:"average"`
+}
+
+type UpdateOperationalSettingsRequest struct {
+	Timezone          string `json:"timezone" validate:"required,timezone"`
+	QueuePrefix       string `json:"queue_prefix" validate:"omitempty,max=10"`
+	QueueResetTime    string `json:"queue_reset_time" validate:"required,datetime=15:04"`
+	QueueNumberDigits int    `json:"queue_number_digits" validate:"gte=1,lte=6"`
+	CostingMethod     string `json:"costing_method" validate:"omitempty,oneof=average fifo"`
+}
+
+// Location returns the store's time zone, falling back to UTC when it cannot be loaded.
+func (r *OperationalSettingsResponse) Location() *time.Location {
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// LoyaltySettingsResponse holds the rules customers earn and redeem loyalty points by. A paid order earns one
+// point per SpendPerPoint spent, times the multiplier of the item's category, plus the bonus of each promotion
+// applied to it; a redeemed point is worth PointValue.
+type LoyaltySettingsResponse struct {
+	Enabled             bool                        `json:"enabled"`
+	SpendPerPoint       int64                       `json:"spend_per_point" example:"10000"`
+	PointValue          int64                       `json:"point_value" example:"100"`
+	MinRedeemPoints     int64                       `json:"min_redeem_points"`
+	ExpiryDays          int                         `json:"expiry_days" example:"365"`
+	CategoryMultipliers []LoyaltyCategoryMultiplier `json:"category_multipliers"`
+	PromotionBonuses    []LoyaltyPromotionBonus     `json:"promotion_bonuses"`
+}
+
+type LoyaltyCategoryMultiplier struct {
+	CategoryID int32   `json:"category_id" validate:"gt=0"`
+	Multiplier float64 `json:"multiplier" validate:"gte=0,lte=100" example:"2"`
+}
+
+type LoyaltyPromotionBonus struct {
+	PromotionID uuid.UUID `json:"promotion_id" validate:"required"`
+	BonusPoints int64     `json:"bonus_points" validate:"gt=0" example:"50"`
+}
+
+type UpdateLoyaltySettingsRequest struct {
+	Enabled             *bool                       `json:"enabled" validate:"required"`
+	SpendPerPoint       int64                       `json:"spend_per_point" validate:"gt=0"`
+	PointValue          int64                       `json:"point_value" validate:"gt=0"`
+	MinRedeemPoints     int64                       `json:"min_redeem_points" validate:"gte=0"`
+	ExpiryDays          int                         `json:"expiry_days" validate:"gte=0"`
+	CategoryMultipliers []LoyaltyCategoryMultiplier `json:"category_multipliers" validate:"dive"`
+	PromotionBonuses    []LoyaltyPromotionBonus     `json:"promotion_bonuses" validate:"dive"`
+}
+
+// CategoryMultiplier is the multiplier of a category, 1 when it has none.
+func (r *LoyaltySettingsResponse) CategoryMultiplier(categoryID int32) float64 {
+	for _, m := range r.CategoryMultipliers {
+		if m.CategoryID == categoryID {
+			return m.Multiplier
+		}
+	}
+	return 1
+}
+
+// PromotionBonus is the bonus points a promotion awards, 0 when it awards none.
+func (r *LoyaltySettingsResponse) PromotionBonus(promotionID uuid.UUID) int64 {
+	for _, b := range r.PromotionBonuses {
+		if b.PromotionID == promotionID {
+			return b.BonusPoints
+		}
+	}
+	return 0
+}
@@ -248,4 +248,235 @@ func (h *SettingsHandler) UpdatePrinterSettingsHandler(c fiber.Ctx) error {
 	})
 }
 
+// GetTaxSettingsHandler gets tax and service charge settings
+// @Summary      Get tax settings
+// @Description  Retrieve tax and service charge rules used to calculate order totals (Roles: authenticated)
+// @Tags         Settings
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} common.SuccessResponse{data=TaxSettingsResponse} "Tax settings fetched successfully"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /settings/tax [get]
+func (h *SettingsHandler) GetTaxSettingsHandler(c fiber.Ctx) error {
+	ctx := c.RequestCtx()
+
+	resp, err := h.service.GetTaxSettings(ctx)
+	if err != nil {
+		h.log.Errorf("Failed to fetch tax settings", "error", err)
+		return c.Status(http.StatusInternalServerError).JSON(common.ErrorResponse{
+			Message: "Failed to fetch tax settings",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(common.SuccessResponse{
+		Message: "Tax settings fetched successfully",
+		Data:    resp,
+	})
+}
+
+// UpdateTaxSettingsHandler updates tax and service charge settings
+// @Summary      Update tax settings
+// @Description  Update tax rate, service charge, per-order-type rules, category exemptions and rounding mode (Roles: admin)
+// @Tags         Settings
+// @Accept       json
+// @Produce      json
+// @Param        request body UpdateTaxSettingsRequest true "Tax settings update request"
+// @Success      200 {object} common.SuccessResponse{data=TaxSettingsResponse} "Tax settings updated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body or validation failure"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin"]
+// @Router       /settings/tax [put]
+func (h *SettingsHandler) UpdateTaxSettingsHandler(c fiber.Ctx) error {
+	ctx := c.RequestCtx()
+	var req UpdateTaxSettingsRequest
+
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("Update tax settings validation failed", "error", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data: map[string]interface{}{
+					"errors": ve.Errors,
+				},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	resp, err := h.service.UpdateTaxSettings(ctx, req)
+	if err != nil {
+		h.log.Errorf("Failed to update tax settings", "error", err)
+		return c.Status(http.StatusInternalServerError).JSON(common.ErrorResponse{
+			Message: "Failed to update tax settings",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(common.SuccessResponse{
+		Message: "Tax settings updated successfully",
+		Data:    resp,
+	})
+}
+
+// GetOperationalSettingsHandler gets the store time zone and queue number settings
+// @Summary      Get operational settings
+// @Description  Retrieve the store time zone and how daily queue numbers are issued (prefix, reset time, digits) (Roles: authenticated)
+// @Tags         Settings
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} common.SuccessResponse{data=OperationalSettingsResponse} "Operational settings fetched successfully"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /settings/operations [get]
+func (h *SettingsHandler) GetOperationalSettingsHandler(c fiber.Ctx) error {
+	ctx := c.RequestCtx()
+
+	resp, err := h.service.GetOperationalSettings(ctx)
+	if err != nil {
+		h.log.Errorf("Failed to fetch operational settings", "error", err)
+		return c.Status(http.StatusInternalServerError).JSON(common.ErrorResponse{
+			Message: "Failed to fetch operational settings",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(common.SuccessResponse{
+		Message: "Operational settings fetched successfully",
+		Data:    resp,
+	})
+}
+
+// UpdateOperationalSettingsHandler updates the store time zone and queue number settings
+// @Summary      Update operational settings
+// @Description  Update the store time zone, the queue number prefix and padding, and the local time at which queue numbers reset (Roles: admin)
+// @Tags         Settings
+// @Accept       json
+// @Produce      json
+// @Param        request body UpdateOperationalSettingsRequest true "Operational settings update request"
+// @Success      200 {object} common.SuccessResponse{data=OperationalSettingsResponse} "Operational settings updated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body or validation failure"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin"]
+// @Router       /settings/operations [put]
+func (h *SettingsHandler) UpdateOperationalSettingsHandler(c fiber.Ctx) error {
+	ctx := c.RequestCtx()
+	var req UpdateOperationalSettingsRequest
+
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("Update operational settings validation failed", "error", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data: map[string]interface{}{
+					"errors": ve.Errors,
+				},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	resp, err := h.service.UpdateOperationalSettings(ctx, req)
+	if err != nil {
+		h.log.Errorf("Failed to update operational settings", "error", err)
+		return c.Status(http.StatusInternalServerError).JSON(common.ErrorResponse{
+			Message: "Failed to update operational settings",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(common.SuccessResponse{
+		Message: "Operational settings updated successfully",
+		Data:    resp,
+	})
+}
+
+// GetLoyaltySettingsHandler retrieves the loyalty points rules
+// @Summary      Get loyalty settings
+// @Description  Retrieve how customers earn loyalty points (spend per point, category multipliers, promotion bonuses), what a point is worth when redeemed and when points expire (Roles: authenticated)
+// @Tags         Settings
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} common.SuccessResponse{data=LoyaltySettingsResponse} "Loyalty settings fetched successfully"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /settings/loyalty [get]
+func (h *SettingsHandler) GetLoyaltySettingsHandler(c fiber.Ctx) error {
+	ctx := c.RequestCtx()
+
+	resp, err := h.service.GetLoyaltySettings(ctx)
+	if err != nil {
+		h.log.Errorf("Failed to fetch loyalty settings", "error", err)
+		return c.Status(http.StatusInternalServerError).JSON(common.ErrorResponse{
+			Message: "Failed to fetch loyalty settings",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(common.SuccessResponse{
+		Message: "Loyalty settings fetched successfully",
+		Data:    resp,
+	})
+}
+
+// UpdateLoyaltySettingsHandler updates the loyalty points rules
+// @Summary      Update loyalty settings
+// @Description  Update the loyalty points rules. expiry_days 0 keeps points forever; category multipliers and promotion bonuses replace the current ones (Roles: admin)
+// @Tags         Settings
+// @Accept       json
+// @Produce      json
+// @Param        request body UpdateLoyaltySettingsRequest true "Loyalty settings update request"
+// @Success      200 {object} common.SuccessResponse{data=LoyaltySettingsResponse} "Loyalty settings updated successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body or validation failure"
+// @Failure      500 {object} common.ErrorResponse "Internal server error"
+// @x-roles      ["admin"]
+// @Router       /settings/loyalty [put]
+func (h *SettingsHandler) UpdateLoyaltySettingsHandler(c fiber.Ctx) error {
+	ctx := c.RequestCtx()
+	var req UpdateLoyaltySettingsRequest
+
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("Update loyalty settings validation failed", "error", err)
+		var ve *validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+				Message: "Validation failed",
+				Error:   ve.Error(),
+				Data: map[string]interface{}{
+					"errors": ve.Errors,
+				},
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	resp, err := h.service.UpdateLoyaltySettings(ctx, req)
+	if err != nil {
+		h.log.Errorf("Failed to update loyalty settings", "error", err)
+		return c.Status(http.StatusInternalServerError).JSON(common.ErrorResponse{
+			Message: "Failed to update loyalty settings",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(common.SuccessResponse{
+		Message: "Loyalty settings updated successfully",
+		Data:    resp,
+	})
+}
+
 // fiber:context-methods migrated
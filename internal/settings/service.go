@@ -11,6 +11,8 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -22,6 +24,12 @@ type ISettingsService interface {
 	GetPrinterSettings(ctx context.Context) (*PrinterSettingsResponse, error)
 	UpdatePrinterSettings(ctx context.Context, req UpdatePrinterSettingsRequest) (*PrinterSettingsResponse, error)
 	UpdateLogo(ctx context.Context, data []byte, filename string, contentType string) (string, error)
+	GetTaxSettings(ctx context.Context) (*TaxSettingsResponse, error)
+	UpdateTaxSettings(ctx context.Context, req UpdateTaxSettingsRequest) (*TaxSettingsResponse, error)
+	GetOperationalSettings(ctx context.Context) (*OperationalSettingsResponse, error)
+	UpdateOperationalSettings(ctx context.Context, req UpdateOperationalSettingsRequest) (*OperationalSettingsResponse, error)
+	GetLoyaltySettings(ctx context.Context) (*LoyaltySettingsResponse, error)
+	UpdateLoyaltySettings(ctx context.Context, req UpdateLoyaltySettingsRequest) (*LoyaltySettingsResponse, error)
 }
 
 type SettingsService struct {
@@ -280,3 +288,377 @@ func (s *SettingsService) UpdatePrinterSettings(ctx context.Context, req UpdateP
 
 	return s.GetPrinterSettings(ctx)
 }
+
+func (s *SettingsService) GetTaxSettings(ctx context.Context) (*TaxSettingsResponse, error) {
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to fetch settings", "error", err)
+		return nil, err
+	}
+
+	response := &TaxSettingsResponse{
+		TaxEnabled:              true,
+		TaxRate:                 11,
+		TaxInclusive:            false,
+		TaxOrderTypes:           []string{"dine_in", "takeaway"},
+		TaxExemptCategoryIDs:    []int32{},
+		ServiceChargeEnabled:    false,
+		ServiceChargeRate:       0,
+		ServiceChargeOrderTypes: []string{"dine_in"},
+		ServiceChargeTaxable:    true,
+		RoundingMode:            "round",
+	}
+
+	for _, setting := range settings {
+		switch setting.Key {
+		case "tax_enabled":
+			response.TaxEnabled = setting.Value == "true"
+		case "tax_rate":
+			if rate, err := strconv.ParseFloat(setting.Value, 64); err == nil {
+				response.TaxRate = rate
+			} else {
+				s.log.Warnf("Invalid tax_rate setting: %s", setting.Value)
+			}
+		case "tax_inclusive":
+			response.TaxInclusive = setting.Value == "true"
+		case "tax_order_types":
+			response.TaxOrderTypes = splitList(setting.Value)
+		case "tax_exempt_category_ids":
+			response.TaxExemptCategoryIDs = parseCategoryIDs(setting.Value)
+		case "service_charge_enabled":
+			response.ServiceChargeEnabled = setting.Value == "true"
+		case "service_charge_rate":
+			if rate, err := strconv.ParseFloat(setting.Value, 64); err == nil {
+				response.ServiceChargeRate = rate
+			} else {
+				s.log.Warnf("Invalid service_charge_rate setting: %s", setting.Value)
+			}
+		case "service_charge_order_types":
+			response.ServiceChargeOrderTypes = splitList(setting.Value)
+		case "service_charge_taxable":
+			response.ServiceChargeTaxable = setting.Value == "true"
+		case "tax_rounding_mode":
+			response.RoundingMode = setting.Value
+		}
+	}
+
+	return response, nil
+}
+
+func (s *SettingsService) UpdateTaxSettings(ctx context.Context, req UpdateTaxSettingsRequest) (*TaxSettingsResponse, error) {
+	categoryIDs := make([]string, len(req.TaxExemptCategoryIDs))
+	for i, id := range req.TaxExemptCategoryIDs {
+		categoryIDs[i] = strconv.Itoa(int(id))
+	}
+
+	values := map[string]string{
+		"tax_enabled":                strconv.FormatBool(*req.TaxEnabled),
+		"tax_rate":                   strconv.FormatFloat(req.TaxRate, 'f', -1, 64),
+		"tax_inclusive":              strconv.FormatBool(*req.TaxInclusive),
+		"tax_order_types":            strings.Join(req.TaxOrderTypes, ","),
+		"tax_exempt_category_ids":    strings.Join(categoryIDs, ","),
+		"service_charge_enabled":     strconv.FormatBool(*req.ServiceChargeEnabled),
+		"service_charge_rate":        strconv.FormatFloat(req.ServiceChargeRate, 'f', -1, 64),
+		"service_charge_order_types": strings.Join(req.ServiceChargeOrderTypes, ","),
+		"service_charge_taxable":     strconv.FormatBool(*req.ServiceChargeTaxable),
+		"tax_rounding_mode":          req.RoundingMode,
+	}
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+		for key, value := range values {
+			_, err := qtx.UpsertSetting(ctx, repository.UpsertSettingParams{
+				Key:   key,
+				Value: value,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if txErr != nil {
+		s.log.Error("Failed to update tax settings", "error", txErr)
+		return nil, txErr
+	}
+
+	// Activity Log
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	logDetails := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		logDetails[key] = value
+	}
+	s.activitylog.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeUPDATE,
+		activitylog_repo.LogEntityTypeSETTINGS,
+		"settings",
+		logDetails,
+	)
+
+	return s.GetTaxSettings(ctx)
+}
+
+func (s *SettingsService) GetOperationalSettings(ctx context.Context) (*OperationalSettingsResponse, error) {
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to fetch settings", "error", err)
+		return nil, err
+	}
+
+	response := &OperationalSettingsResponse{
+		Timezone:          "Asia/Jakarta",
+		QueuePrefix:       "",
+		QueueResetTime:    "00:00",
+		QueueNumberDigits: 3,
+		CostingMethod:     "average",
+	}
+
+	for _, setting := range settings {
+		switch setting.Key {
+		case "store_timezone":
+			response.Timezone = setting.Value
+		case "queue_prefix":
+			response.QueuePrefix = setting.Value
+		case "queue_reset_time":
+			response.QueueResetTime = setting.Value
+		case "queue_number_digits":
+			if digits, err := strconv.Atoi(setting.Value); err == nil && digits > 0 {
+				response.QueueNumberDigits = digits
+			} else {
+				s.log.Warnf("Invalid queue_number_digits setting: %s", setting.Value)
+			}
+		case "costing_method":
+			response.CostingMethod = setting.Value
+		}
+	}
+
+	return response, nil
+}
+
+func (s *SettingsService) UpdateOperationalSettings(ctx context.Context, req UpdateOperationalSettingsRequest) (*OperationalSettingsResponse, error) {
+	values := map[string]string{
+		"store_timezone":      req.Timezone,
+		"queue_prefix":        strings.TrimSpace(req.QueuePrefix),
+		"queue_reset_time":    req.QueueResetTime,
+		"queue_number_digits": strconv.Itoa(req.QueueNumberDigits),
+	}
+	if req.CostingMethod != "" {
+		values["costing_method"] = req.CostingMethod
+	}
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+		for key, value := range values {
+			_, err := qtx.UpsertSetting(ctx, repository.UpsertSettingParams{
+				Key:   key,
+				Value: value,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if txErr != nil {
+		s.log.Error("Failed to update operational settings", "error", txErr)
+		return nil, txErr
+	}
+
+	// Activity Log
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	logDetails := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		logDetails[key] = value
+	}
+	s.activitylog.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeUPDATE,
+		activitylog_repo.LogEntityTypeSETTINGS,
+		"settings",
+		logDetails,
+	)
+
+	return s.GetOperationalSettings(ctx)
+}
+
+func (s *SettingsService) GetLoyaltySettings(ctx context.Context) (*LoyaltySettingsResponse, error) {
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		s.log.Error("Failed to fetch settings", "error", err)
+		return nil, err
+	}
+
+	response := &LoyaltySettingsResponse{
+		Enabled:             false,
+		SpendPerPoint:       10000,
+		PointValue:          100,
+		MinRedeemPoints:     0,
+		ExpiryDays:          365,
+		CategoryMultipliers: []LoyaltyCategoryMultiplier{},
+		PromotionBonuses:    []LoyaltyPromotionBonus{},
+	}
+
+	for _, setting := range settings {
+		switch setting.Key {
+		case "loyalty_enabled":
+			response.Enabled = setting.Value == "true"
+		case "loyalty_spend_per_point":
+			if amount, err := strconv.ParseInt(setting.Value, 10, 64); err == nil && amount > 0 {
+				response.SpendPerPoint = amount
+			} else {
+				s.log.Warnf("Invalid loyalty_spend_per_point setting: %s", setting.Value)
+			}
+		case "loyalty_point_value":
+			if amount, err := strconv.ParseInt(setting.Value, 10, 64); err == nil && amount > 0 {
+				response.PointValue = amount
+			} else {
+				s.log.Warnf("Invalid loyalty_point_value setting: %s", setting.Value)
+			}
+		case "loyalty_min_redeem_points":
+			if points, err := strconv.ParseInt(setting.Value, 10, 64); err == nil && points >= 0 {
+				response.MinRedeemPoints = points
+			} else {
+				s.log.Warnf("Invalid loyalty_min_redeem_points setting: %s", setting.Value)
+			}
+		case "loyalty_expiry_days":
+			if days, err := strconv.Atoi(setting.Value); err == nil && days >= 0 {
+				response.ExpiryDays = days
+			} else {
+				s.log.Warnf("Invalid loyalty_expiry_days setting: %s", setting.Value)
+			}
+		case "loyalty_category_multipliers":
+			response.CategoryMultipliers = parseCategoryMultipliers(setting.Value)
+		case "loyalty_promotion_bonuses":
+			response.PromotionBonuses = parsePromotionBonuses(setting.Value)
+		}
+	}
+
+	return response, nil
+}
+
+func (s *SettingsService) UpdateLoyaltySettings(ctx context.Context, req UpdateLoyaltySettingsRequest) (*LoyaltySettingsResponse, error) {
+	multipliers := make([]string, len(req.CategoryMultipliers))
+	for i, m := range req.CategoryMultipliers {
+		multipliers[i] = fmt.Sprintf("%d:%s", m.CategoryID, strconv.FormatFloat(m.Multiplier, 'f', -1, 64))
+	}
+	bonuses := make([]string, len(req.PromotionBonuses))
+	for i, b := range req.PromotionBonuses {
+		bonuses[i] = fmt.Sprintf("%s:%d", b.PromotionID, b.BonusPoints)
+	}
+
+	values := map[string]string{
+		"loyalty_enabled":              strconv.FormatBool(*req.Enabled),
+		"loyalty_spend_per_point":      strconv.FormatInt(req.SpendPerPoint, 10),
+		"loyalty_point_value":          strconv.FormatInt(req.PointValue, 10),
+		"loyalty_min_redeem_points":    strconv.FormatInt(req.MinRedeemPoints, 10),
+		"loyalty_expiry_days":          strconv.Itoa(req.ExpiryDays),
+		"loyalty_category_multipliers": strings.Join(multipliers, ","),
+		"loyalty_promotion_bonuses":    strings.Join(bonuses, ","),
+	}
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+		for key, value := range values {
+			_, err := qtx.UpsertSetting(ctx, repository.UpsertSettingParams{
+				Key:   key,
+				Value: value,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if txErr != nil {
+		s.log.Error("Failed to update loyalty settings", "error", txErr)
+		return nil, txErr
+	}
+
+	// Activity Log
+	actorID, _ := ctx.Value(common.UserIDKey).(uuid.UUID)
+	logDetails := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		logDetails[key] = value
+	}
+	s.activitylog.Log(
+		ctx,
+		actorID,
+		activitylog_repo.LogActionTypeUPDATE,
+		activitylog_repo.LogEntityTypeSETTINGS,
+		"settings",
+		logDetails,
+	)
+
+	return s.GetLoyaltySettings(ctx)
+}
+
+func splitList(value string) []string {
+	result := []string{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+func parseCategoryIDs(value string) []int32 {
+	result := []int32{}
+	for _, part := range splitList(value) {
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			continue
+		}
+		result = append(result, int32(id))
+	}
+	return result
+}
+
+// parseCategoryMultipliers reads "category_id:multiplier" pairs, skipping malformed ones.
+func parseCategoryMultipliers(value string) []LoyaltyCategoryMultiplier {
+	result := []LoyaltyCategoryMultiplier{}
+	for _, part := range splitList(value) {
+		id, multiplier, ok := strings.Cut(part, ":")
+		if !ok {
+			continue
+		}
+		categoryID, err := strconv.Atoi(strings.TrimSpace(id))
+		if err != nil {
+			continue
+		}
+		m, err := strconv.ParseFloat(strings.TrimSpace(multiplier), 64)
+		if err != nil || m < 0 {
+			continue
+		}
+		result = append(result, LoyaltyCategoryMultiplier{CategoryID: int32(categoryID), Multiplier: m})
+	}
+	return result
+}
+
+// parsePromotionBonuses reads "promotion_id:points" pairs, skipping malformed ones.
+func parsePromotionBonuses(value string) []LoyaltyPromotionBonus {
+	result := []LoyaltyPromotionBonus{}
+	for _, part := range splitList(value) {
+		id, points, ok := strings.Cut(part, ":")
+		if !ok {
+			continue
+		}
+		promotionID, err := uuid.Parse(strings.TrimSpace(id))
+		if err != nil {
+			continue
+		}
+		bonus, err := strconv.ParseInt(strings.TrimSpace(points), 10, 64)
+		if err != nil || bonus <= 0 {
+			continue
+		}
+		result = append(result, LoyaltyPromotionBonus{PromotionID: promotionID, BonusPoints: bonus})
+	}
+	return result
+}
@@ -27,6 +27,31 @@ type ShiftResponse struct {
 	ActualCashEnd   *int64                 `json:"actual_cash_end,omitempty"`
 	Difference      *int64                 `json:"difference,omitempty"` // Actual - Expected
 	Status          repository.ShiftStatus `json:"status"`
+	Reconciliation  *ShiftReconciliation   `json:"reconciliation,omitempty"`
+}
+
+// ShiftReconciliation itemizes how the expected drawer amount was derived.
+type ShiftReconciliation struct {
+	SalesByPaymentMethod   []PaymentMethodTotal `json:"sales_by_payment_method"`
+	RefundsByPaymentMethod []PaymentMethodTotal `json:"refunds_by_payment_method"`
+	StartCash              int64                `json:"start_cash"`
+	CashReceived           int64                `json:"cash_received"`
+	ChangeGiven            int64                `json:"change_given"`
+	CashSales              int64                `json:"cash_sales"` // CashReceived - ChangeGiven
+	CashRefunds            int64                `json:"cash_refunds"`
+	CashIn                 int64                `json:"cash_in"`
+	CashOut                int64                `json:"cash_out"` // Payouts and drops
+	ExpectedCash           int64                `json:"expected_cash"`
+	ActualCash             int64                `json:"actual_cash"`
+	OverShort              int64                `json:"over_short"` // Actual - Expected
+}
+
+type PaymentMethodTotal struct {
+	PaymentMethodID   int32  `json:"payment_method_id"`
+	PaymentMethodName string `json:"payment_method_name"`
+	IsCash            bool   `json:"is_cash"`
+	Count             int64  `json:"count"`
+	Amount            int64  `json:"amount"`
 }
 
 type CashTransactionRequest struct {
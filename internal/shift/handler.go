@@ -81,7 +81,7 @@ func (h *handler) StartShiftHandler(c fiber.Ctx) error {
 
 // EndShiftHandler handles the request to end the current shift
 // @Summary      End current shift
-// @Description  Close the active shift session for the authenticated user (Roles: admin, manager, cashier)
+// @Description  Close the active shift session and return the cash reconciliation (sales and refunds by payment method, cash in/out, over/short) (Roles: admin, manager, cashier)
 // @Tags         Shifts
 // @Accept       json
 // @Produce      json
@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
@@ -19,6 +20,8 @@ type Querier interface {
 	GetOpenShiftByUserID(ctx context.Context, userID uuid.UUID) (Shift, error)
 	GetOpenShifts(ctx context.Context) ([]Shift, error)
 	GetShiftByID(ctx context.Context, id uuid.UUID) (Shift, error)
+	GetShiftRefundsByPaymentMethod(ctx context.Context, shiftID pgtype.UUID) ([]GetShiftRefundsByPaymentMethodRow, error)
+	GetShiftSalesByPaymentMethod(ctx context.Context, shiftID pgtype.UUID) ([]GetShiftSalesByPaymentMethodRow, error)
 	GetUserPasswordHash(ctx context.Context, id uuid.UUID) (string, error)
 }
 
@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createCashTransaction = `-- name: CreateCashTransaction :one
@@ -253,6 +254,108 @@ func (q *Queries) GetShiftByID(ctx context.Context, id uuid.UUID) (Shift, error)
 	return i, err
 }
 
+const getShiftRefundsByPaymentMethod = `-- name: GetShiftRefundsByPaymentMethod :many
+SELECT
+    pm.id AS payment_method_id,
+    pm.name AS payment_method_name,
+    (pm.kind = 'cash')::boolean AS is_cash,
+    COUNT(r.id)::bigint AS refund_count,
+    COALESCE(SUM(r.amount), 0)::bigint AS total_amount
+FROM order_refunds r
+JOIN payment_methods pm ON r.payment_method_id = pm.id
+WHERE r.shift_id = $1
+GROUP BY pm.id, pm.name
+ORDER BY pm.name
+`
+
+type GetShiftRefundsByPaymentMethodRow struct {
+	PaymentMethodID   int32  `json:"payment_method_id"`
+	PaymentMethodName string `json:"payment_method_name"`
+	IsCash            bool   `json:"is_cash"`
+	RefundCount       int64  `json:"refund_count"`
+	TotalAmount       int64  `json:"total_amount"`
+}
+
+func (q *Queries) GetShiftRefundsByPaymentMethod(ctx context.Context, shiftID pgtype.UUID) ([]GetShiftRefundsByPaymentMethodRow, error) {
+	rows, err := q.db.Query(ctx, getShiftRefundsByPaymentMethod, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetShiftRefundsByPaymentMethodRow{}
+	for rows.Next() {
+		var i GetShiftRefundsByPaymentMethodRow
+		if err := rows.Scan(
+			&i.PaymentMethodID,
+			&i.PaymentMethodName,
+			&i.IsCash,
+			&i.RefundCount,
+			&i.TotalAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShiftSalesByPaymentMethod = `-- name: GetShiftSalesByPaymentMethod :many
+SELECT
+    pm.id AS payment_method_id,
+    pm.name AS payment_method_name,
+    (pm.kind = 'cash')::boolean AS is_cash,
+    COUNT(DISTINCT op.order_id)::bigint AS order_count,
+    COALESCE(SUM(op.amount), 0)::bigint AS total_amount,
+    COALESCE(SUM(op.tendered_amount), 0)::bigint AS cash_received,
+    COALESCE(SUM(op.change_amount), 0)::bigint AS change_given
+FROM order_payments op
+JOIN payment_methods pm ON op.payment_method_id = pm.id
+WHERE op.shift_id = $1
+GROUP BY pm.id, pm.name
+ORDER BY pm.name
+`
+
+type GetShiftSalesByPaymentMethodRow struct {
+	PaymentMethodID   int32  `json:"payment_method_id"`
+	PaymentMethodName string `json:"payment_method_name"`
+	IsCash            bool   `json:"is_cash"`
+	OrderCount        int64  `json:"order_count"`
+	TotalAmount       int64  `json:"total_amount"`
+	CashReceived      int64  `json:"cash_received"`
+	ChangeGiven       int64  `json:"change_given"`
+}
+
+func (q *Queries) GetShiftSalesByPaymentMethod(ctx context.Context, shiftID pgtype.UUID) ([]GetShiftSalesByPaymentMethodRow, error) {
+	rows, err := q.db.Query(ctx, getShiftSalesByPaymentMethod, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetShiftSalesByPaymentMethodRow{}
+	for rows.Next() {
+		var i GetShiftSalesByPaymentMethodRow
+		if err := rows.Scan(
+			&i.PaymentMethodID,
+			&i.PaymentMethodName,
+			&i.IsCash,
+			&i.OrderCount,
+			&i.TotalAmount,
+			&i.CashReceived,
+			&i.ChangeGiven,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserPasswordHash = `-- name: GetUserPasswordHash :one
 SELECT password_hash FROM users
 WHERE id = $1 LIMIT 1
@@ -2,14 +2,18 @@ package shift
 
 import (
 	"POS-kasir/internal/common"
+	"POS-kasir/internal/common/middleware"
 	repository "POS-kasir/internal/shift/repository"
 	"POS-kasir/pkg/logger"
 	"POS-kasir/pkg/utils"
+
+	ws "POS-kasir/internal/websocket"
 	"context"
 	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Service interface {
@@ -24,13 +28,15 @@ type service struct {
 	repo  repository.Querier
 	log   logger.ILogger
 	cache *Cache
+	wsHub *ws.Hub
 }
 
-func NewService(repo repository.Querier, log logger.ILogger, cache *Cache) Service {
+func NewService(repo repository.Querier, log logger.ILogger, cache *Cache, wsHub *ws.Hub) Service {
 	return &service{
 		repo:  repo,
 		log:   log,
 		cache: cache,
+		wsHub: wsHub,
 	}
 }
 
@@ -68,7 +74,10 @@ func (s *service) StartShift(ctx context.Context, userID uuid.UUID, req StartShi
 		s.cache.SetOpen(userID, true)
 	}
 
-	return s.mapShiftToResponse(shift), nil
+	res := s.mapShiftToResponse(shift)
+	s.publishShiftEvent(ws.EventShiftStarted, userID, res)
+
+	return res, nil
 }
 
 func (s *service) EndShift(ctx context.Context, userID uuid.UUID, req EndShiftRequest) (*ShiftResponse, error) {
@@ -91,36 +100,12 @@ func (s *service) EndShift(ctx context.Context, userID uuid.UUID, req EndShiftRe
 		return nil, errors.New("no open shift found")
 	}
 
-	// Calculate expected cash end
-	// Start Cash + Cash In - Cash Out + Sales (Cash Payment method)
-	// For MVP, we will simplify: Start Cash + Cash In - Cash Out.
-	// Ideally we need to query orders with payment_method_id corresponding to CASH for this shift.
-	// Since we don't have direct link between orders and shifts yet (implied by time and user),
-	// we would need to sum order totals where user_id = shift.user_id AND created_at >= shift.start_time AND payment_method is CASH.
-
-	// For now, let's just sum cash transactions. Note: This is partial implementation.
-	// TODO: Include Sales in Expected Cash Calculation.
-	cashIn, err := s.repo.GetCashTotalByShiftIDAndType(ctx, repository.GetCashTotalByShiftIDAndTypeParams{
-		ShiftID: shift.ID,
-		Type:    repository.CashTransactionTypeCashIn,
-	})
-	if err != nil {
-		s.log.Errorf("EndShift | Failed to get cash in total: %v", err)
-		return nil, err
-	}
-
-	cashOut, err := s.repo.GetCashTotalByShiftIDAndType(ctx, repository.GetCashTotalByShiftIDAndTypeParams{
-		ShiftID: shift.ID,
-		Type:    repository.CashTransactionTypeCashOut,
-	})
+	recon, err := s.reconcile(ctx, shift)
 	if err != nil {
-		s.log.Errorf("EndShift | Failed to get cash out total: %v", err)
+		s.log.Errorf("EndShift | Failed to reconcile shift: %v", err)
 		return nil, err
 	}
-
-	expectedCashEnd := shift.StartCash + cashIn - cashOut
-	// Note: We need to add Order Sales (Cash) here.
-	// Assuming for now simple Shift management without strict order reconciliation in this step as it requires Order Repository dependency.
+	expectedCashEnd := recon.ExpectedCash
 
 	updatedShift, err := s.repo.EndShift(ctx, repository.EndShiftParams{
 		ID:              shift.ID,
@@ -137,12 +122,32 @@ func (s *service) EndShift(ctx context.Context, userID uuid.UUID, req EndShiftRe
 	diff := req.ActualCashEnd - expectedCashEnd
 	res.Difference = &diff
 
+	recon.ActualCash = req.ActualCashEnd
+	recon.OverShort = diff
+	res.Reconciliation = recon
+
 	// Update cache (Clear)
 	s.cache.Clear(userID)
 
+	s.publishShiftEvent(ws.EventShiftEnded, userID, res)
+
 	return res, nil
 }
 
+// publishShiftEvent lets managers follow every shift while a cashier only hears about their own.
+func (s *service) publishShiftEvent(eventType string, userID uuid.UUID, res *ShiftResponse) {
+	if s.wsHub == nil {
+		return
+	}
+	s.wsHub.Publish(ws.Message{
+		Type:    eventType,
+		Payload: res,
+		Topics:  []string{ws.TopicShifts},
+		MinRole: middleware.UserRoleManager,
+		UserID:  &userID,
+	})
+}
+
 func (s *service) GetOpenShift(ctx context.Context, userID uuid.UUID) (*ShiftResponse, error) {
 	shift, err := s.repo.GetOpenShiftByUserID(ctx, userID)
 	if err != nil {
@@ -195,22 +200,17 @@ func (s *service) AutoCloseShifts(ctx context.Context) error {
 	for _, shift := range shifts {
 		s.log.Infof("AutoCloseShifts | Closing shift %v for user %v", shift.ID, shift.UserID)
 
-		// Calculate expected cash
-		cashIn, _ := s.repo.GetCashTotalByShiftIDAndType(ctx, repository.GetCashTotalByShiftIDAndTypeParams{
-			ShiftID: shift.ID,
-			Type:    repository.CashTransactionTypeCashIn,
-		})
-		cashOut, _ := s.repo.GetCashTotalByShiftIDAndType(ctx, repository.GetCashTotalByShiftIDAndTypeParams{
-			ShiftID: shift.ID,
-			Type:    repository.CashTransactionTypeCashOut,
-		})
-
-		expectedCashEnd := shift.StartCash + cashIn - cashOut
+		recon, err := s.reconcile(ctx, shift)
+		if err != nil {
+			s.log.Errorf("AutoCloseShifts | Failed to reconcile shift %v: %v", shift.ID, err)
+			continue
+		}
+		expectedCashEnd := recon.ExpectedCash
 
 		// For auto-close, we assume Actual = Expected to avoid difference.
 		// Or we can just leave actual as nil? The schema allows nil.
 		// But EndShift in repo sets actual_cash_end.
-		_, err := s.repo.EndShift(ctx, repository.EndShiftParams{
+		_, err = s.repo.EndShift(ctx, repository.EndShiftParams{
 			ID:              shift.ID,
 			ExpectedCashEnd: &expectedCashEnd,
 			ActualCashEnd:   &expectedCashEnd,
@@ -227,6 +227,78 @@ func (s *service) AutoCloseShifts(ctx context.Context) error {
 	return nil
 }
 
+// reconcile computes the expected drawer amount for a shift:
+// start cash + cash sales (received - change) - cash refunds + cash in - cash out.
+func (s *service) reconcile(ctx context.Context, shift repository.Shift) (*ShiftReconciliation, error) {
+	shiftID := pgtype.UUID{Bytes: shift.ID, Valid: true}
+
+	sales, err := s.repo.GetShiftSalesByPaymentMethod(ctx, shiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := s.repo.GetShiftRefundsByPaymentMethod(ctx, shiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	cashIn, err := s.repo.GetCashTotalByShiftIDAndType(ctx, repository.GetCashTotalByShiftIDAndTypeParams{
+		ShiftID: shift.ID,
+		Type:    repository.CashTransactionTypeCashIn,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cashOut, err := s.repo.GetCashTotalByShiftIDAndType(ctx, repository.GetCashTotalByShiftIDAndTypeParams{
+		ShiftID: shift.ID,
+		Type:    repository.CashTransactionTypeCashOut,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recon := &ShiftReconciliation{
+		SalesByPaymentMethod:   make([]PaymentMethodTotal, 0, len(sales)),
+		RefundsByPaymentMethod: make([]PaymentMethodTotal, 0, len(refunds)),
+		StartCash:              shift.StartCash,
+		CashIn:                 cashIn,
+		CashOut:                cashOut,
+	}
+
+	for _, row := range sales {
+		recon.SalesByPaymentMethod = append(recon.SalesByPaymentMethod, PaymentMethodTotal{
+			PaymentMethodID:   row.PaymentMethodID,
+			PaymentMethodName: row.PaymentMethodName,
+			IsCash:            row.IsCash,
+			Count:             row.OrderCount,
+			Amount:            row.TotalAmount,
+		})
+		if row.IsCash {
+			recon.CashReceived += row.CashReceived
+			recon.ChangeGiven += row.ChangeGiven
+		}
+	}
+
+	for _, row := range refunds {
+		recon.RefundsByPaymentMethod = append(recon.RefundsByPaymentMethod, PaymentMethodTotal{
+			PaymentMethodID:   row.PaymentMethodID,
+			PaymentMethodName: row.PaymentMethodName,
+			IsCash:            row.IsCash,
+			Count:             row.RefundCount,
+			Amount:            row.TotalAmount,
+		})
+		if row.IsCash {
+			recon.CashRefunds += row.TotalAmount
+		}
+	}
+
+	recon.CashSales = recon.CashReceived - recon.ChangeGiven
+	recon.ExpectedCash = shift.StartCash + recon.CashSales - recon.CashRefunds + cashIn - cashOut
+
+	return recon, nil
+}
+
 func (s *service) mapShiftToResponse(shift repository.Shift) *ShiftResponse {
 	var endTime *time.Time
 	if shift.EndTime.Valid {
@@ -48,3 +48,31 @@ WHERE id = $1 LIMIT 1;
 -- name: GetOpenShifts :many
 SELECT * FROM shifts
 WHERE status = 'open';
+
+-- name: GetShiftSalesByPaymentMethod :many
+SELECT
+    pm.id AS payment_method_id,
+    pm.name AS payment_method_name,
+    (pm.kind = 'cash')::boolean AS is_cash,
+    COUNT(DISTINCT op.order_id)::bigint AS order_count,
+    COALESCE(SUM(op.amount), 0)::bigint AS total_amount,
+    COALESCE(SUM(op.tendered_amount), 0)::bigint AS cash_received,
+    COALESCE(SUM(op.change_amount), 0)::bigint AS change_given
+FROM order_payments op
+JOIN payment_methods pm ON op.payment_method_id = pm.id
+WHERE op.shift_id = $1
+GROUP BY pm.id, pm.name
+ORDER BY pm.name;
+
+-- name: GetShiftRefundsByPaymentMethod :many
+SELECT
+    pm.id AS payment_method_id,
+    pm.name AS payment_method_name,
+    (pm.kind = 'cash')::boolean AS is_cash,
+    COUNT(r.id)::bigint AS refund_count,
+    COALESCE(SUM(r.amount), 0)::bigint AS total_amount
+FROM order_refunds r
+JOIN payment_methods pm ON r.payment_method_id = pm.id
+WHERE r.shift_id = $1
+GROUP BY pm.id, pm.name
+ORDER BY pm.name;
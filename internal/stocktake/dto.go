@@ -0,0 +1,86 @@
+package stocktake
+
+import (
+	"POS-kasir/internal/common/pagination"
+	"POS-kasir/internal/stocktake/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateStockTakeRequest starts a count of the whole store, or of the products in one category. CategoryID
+// is required for the category scope and ignored for a full count.
+type CreateStockTakeRequest struct {
+	Scope      repository.StockTakeScope `json:"scope" validate:"required,oneof=full category"`
+	CategoryID *int32                    `json:"category_id" validate:"omitempty,gt=0"`
+	Notes      *string                   `json:"notes" validate:"omitempty,max=500"`
+}
+
+type ListStockTakesRequest struct {
+	pagination.PaginationRequest
+	Status *repository.StockTakeStatus `query:"status" validate:"omitempty,oneof=open approved cancelled"`
+}
+
+type StockTakeCountItemRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int32     `json:"quantity" validate:"gte=0"`
+}
+
+// RecordCountsRequest holds what one staff member counted. Counting a product again replaces their earlier
+// count; counts by different staff (e.g. shelf and back room) add up.
+type RecordCountsRequest struct {
+	Items []StockTakeCountItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type StockTakeResponse struct {
+	ID           uuid.UUID                  `json:"id"`
+	Reference    string                     `json:"reference"`
+	Scope        repository.StockTakeScope  `json:"scope"`
+	CategoryID   *int32                     `json:"category_id,omitempty"`
+	CategoryName *string                    `json:"category_name,omitempty"`
+	Status       repository.StockTakeStatus `json:"status"`
+	Notes        *string                    `json:"notes,omitempty"`
+	ItemCount    int32                      `json:"item_count"`
+	CountedCount int32                      `json:"counted_count"`
+	CreatedBy    *uuid.UUID                 `json:"created_by,omitempty"`
+	ApprovedBy   *uuid.UUID                 `json:"approved_by,omitempty"`
+	ApprovedAt   *time.Time                 `json:"approved_at,omitempty"`
+	CancelledAt  *time.Time                 `json:"cancelled_at,omitempty"`
+	CreatedAt    time.Time                  `json:"created_at"`
+	UpdatedAt    time.Time                  `json:"updated_at"`
+}
+
+type StockTakeCountResponse struct {
+	CountedBy     uuid.UUID `json:"counted_by"`
+	CountedByName string    `json:"counted_by_name"`
+	Quantity      int32     `json:"quantity"`
+	CountedAt     time.Time `json:"counted_at"`
+}
+
+// StockTakeItemResponse compares a product's count with its stock. SoldDuringCount is how much the stock
+// moved between the snapshot and the last count, and Variance is measured against ExpectedQuantity, the
+// stock when the product was last counted. Counted, Variance and VarianceCost are empty until it is counted.
+type StockTakeItemResponse struct {
+	ProductID        uuid.UUID                `json:"product_id"`
+	ProductName      string                   `json:"product_name"`
+	SystemQuantity   int32                    `json:"system_quantity"`
+	SoldDuringCount  int32                    `json:"sold_during_count"`
+	ExpectedQuantity int32                    `json:"expected_quantity"`
+	CountedQuantity  *int32                   `json:"counted_quantity,omitempty"`
+	Variance         *int32                   `json:"variance,omitempty"`
+	UnitCost         float64                  `json:"unit_cost"`
+	VarianceCost     *float64                 `json:"variance_cost,omitempty"`
+	Counts           []StockTakeCountResponse `json:"counts"`
+}
+
+type StockTakeDetailResponse struct {
+	StockTakeResponse
+	TotalVariance     int32                   `json:"total_variance"`
+	TotalVarianceCost float64                 `json:"total_variance_cost"`
+	Items             []StockTakeItemResponse `json:"items"`
+}
+
+type PagedStockTakeResponse struct {
+	StockTakes []StockTakeResponse   `json:"stock_takes"`
+	Pagination pagination.Pagination `json:"pagination"`
+}
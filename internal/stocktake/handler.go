@@ -0,0 +1,248 @@
+package stocktake
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/pkg/logger"
+	"POS-kasir/pkg/validator"
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type IStockTakeHandler interface {
+	ListStockTakesHandler(c fiber.Ctx) error
+	GetStockTakeHandler(c fiber.Ctx) error
+	CreateStockTakeHandler(c fiber.Ctx) error
+	RecordCountsHandler(c fiber.Ctx) error
+	ApproveStockTakeHandler(c fiber.Ctx) error
+	CancelStockTakeHandler(c fiber.Ctx) error
+}
+
+type StockTakeHandler struct {
+	service IStockTakeService
+	log     logger.ILogger
+}
+
+func NewStockTakeHandler(service IStockTakeService, log logger.ILogger) IStockTakeHandler {
+	return &StockTakeHandler{service: service, log: log}
+}
+
+// ListStockTakesHandler
+// @Summary      List stock takes
+// @Description  Get stock take sessions with how many of their products have been counted (Roles: admin, manager, cashier)
+// @Tags         Stock Takes
+// @Accept       json
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        status query string false "Filter by status" Enums(open, approved, cancelled)
+// @Success      200 {object} common.SuccessResponse{data=PagedStockTakeResponse} "Stock takes retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid query parameters"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /stock-takes [get]
+func (h *StockTakeHandler) ListStockTakesHandler(c fiber.Ctx) error {
+	var req ListStockTakesRequest
+	if err := c.Bind().Query(&req); err != nil {
+		h.log.Warnf("ListStockTakesHandler | Failed to parse query parameters: %v", err)
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid query parameters"})
+	}
+
+	stockTakes, err := h.service.ListStockTakes(c.RequestCtx(), req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: "Failed to retrieve stock takes"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Stock takes retrieved successfully",
+		Data:    stockTakes,
+	})
+}
+
+// GetStockTakeHandler
+// @Summary      Get stock take by ID
+// @Description  Get a stock take with each product's system, expected and counted quantity, its variance in units and cost, and who counted it (Roles: admin, manager, cashier)
+// @Tags         Stock Takes
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Stock Take ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=StockTakeDetailResponse} "Stock take retrieved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid stock take ID"
+// @Failure      404 {object} common.ErrorResponse "Stock take not found"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /stock-takes/{id} [get]
+func (h *StockTakeHandler) GetStockTakeHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid stock take ID format"})
+	}
+
+	st, err := h.service.GetStockTake(c.RequestCtx(), id)
+	if err != nil {
+		return h.stockTakeError(c, err, "Failed to retrieve stock take")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Stock take retrieved successfully",
+		Data:    st,
+	})
+}
+
+// CreateStockTakeHandler
+// @Summary      Start stock take
+// @Description  Open a physical count of the whole store or one category. The stock and cost of every product in scope is snapshotted; a product can only be in one open stock take (Roles: admin, manager)
+// @Tags         Stock Takes
+// @Accept       json
+// @Produce      json
+// @Param        request body CreateStockTakeRequest true "Stock take scope"
+// @Success      201 {object} common.SuccessResponse{data=StockTakeDetailResponse} "Stock take started successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid request body, unknown category or nothing to count"
+// @Failure      409 {object} common.ErrorResponse "Products are already being counted in another open stock take"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /stock-takes [post]
+func (h *StockTakeHandler) CreateStockTakeHandler(c fiber.Ctx) error {
+	var req CreateStockTakeRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("CreateStockTakeHandler | Failed to parse request body: %v", err)
+		return h.bindError(c, err)
+	}
+
+	st, err := h.service.CreateStockTake(c.RequestCtx(), req)
+	if err != nil {
+		return h.stockTakeError(c, err, "Failed to start stock take")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(common.SuccessResponse{
+		Message: "Stock take started successfully",
+		Data:    st,
+	})
+}
+
+// RecordCountsHandler
+// @Summary      Record counts
+// @Description  Record what the current user counted. Recounting a product replaces their earlier count, and counts by different staff add up. Each product's expected quantity moves to its stock at this moment so sales during the count are not treated as variance (Roles: admin, manager, cashier)
+// @Tags         Stock Takes
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Stock Take ID" Format(uuid)
+// @Param        request body RecordCountsRequest true "Counted quantities"
+// @Success      200 {object} common.SuccessResponse{data=StockTakeDetailResponse} "Counts recorded successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid stock take ID, request body, or products outside the stock take"
+// @Failure      404 {object} common.ErrorResponse "Stock take not found"
+// @Failure      409 {object} common.ErrorResponse "Stock take is no longer open"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager", "cashier"]
+// @Router       /stock-takes/{id}/counts [post]
+func (h *StockTakeHandler) RecordCountsHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid stock take ID format"})
+	}
+
+	var req RecordCountsRequest
+	if err := c.Bind().Body(&req); err != nil {
+		h.log.Warnf("RecordCountsHandler | Failed to parse request body: %v", err)
+		return h.bindError(c, err)
+	}
+
+	st, err := h.service.RecordCounts(c.RequestCtx(), id, req)
+	if err != nil {
+		return h.stockTakeError(c, err, "Failed to record counts")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Counts recorded successfully",
+		Data:    st,
+	})
+}
+
+// ApproveStockTakeHandler
+// @Summary      Approve stock take
+// @Description  Post the variance of every counted product to its stock as a correction in stock_history referencing the stock take, in one transaction. Uncounted products keep their stock (Roles: admin, manager)
+// @Tags         Stock Takes
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Stock Take ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=StockTakeDetailResponse} "Stock take approved successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid stock take ID"
+// @Failure      404 {object} common.ErrorResponse "Stock take not found"
+// @Failure      409 {object} common.ErrorResponse "Stock take is no longer open"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /stock-takes/{id}/approve [post]
+func (h *StockTakeHandler) ApproveStockTakeHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid stock take ID format"})
+	}
+
+	st, err := h.service.ApproveStockTake(c.RequestCtx(), id)
+	if err != nil {
+		return h.stockTakeError(c, err, "Failed to approve stock take")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Stock take approved successfully",
+		Data:    st,
+	})
+}
+
+// CancelStockTakeHandler
+// @Summary      Cancel stock take
+// @Description  Close an open stock take without changing any stock (Roles: admin, manager)
+// @Tags         Stock Takes
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Stock Take ID" Format(uuid)
+// @Success      200 {object} common.SuccessResponse{data=StockTakeDetailResponse} "Stock take cancelled successfully"
+// @Failure      400 {object} common.ErrorResponse "Invalid stock take ID"
+// @Failure      404 {object} common.ErrorResponse "Stock take not found"
+// @Failure      409 {object} common.ErrorResponse "Stock take is no longer open"
+// @Failure      500 {object} common.ErrorResponse "Internal Server Error"
+// @x-roles      ["admin", "manager"]
+// @Router       /stock-takes/{id}/cancel [post]
+func (h *StockTakeHandler) CancelStockTakeHandler(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid stock take ID format"})
+	}
+
+	st, err := h.service.CancelStockTake(c.RequestCtx(), id)
+	if err != nil {
+		return h.stockTakeError(c, err, "Failed to cancel stock take")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(common.SuccessResponse{
+		Message: "Stock take cancelled successfully",
+		Data:    st,
+	})
+}
+
+func (h *StockTakeHandler) bindError(c fiber.Ctx, err error) error {
+	var ve *validator.ValidationErrors
+	if errors.As(err, &ve) {
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{
+			Message: "Validation failed",
+			Error:   ve.Error(),
+			Data:    map[string]interface{}{"errors": ve.Errors},
+		})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: "Invalid request body"})
+}
+
+func (h *StockTakeHandler) stockTakeError(c fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, common.ErrNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(common.ErrorResponse{Message: "Stock take not found"})
+	case errors.Is(err, common.ErrCategoryNotFound), errors.Is(err, common.ErrStockTakeInvalid), errors.Is(err, common.ErrStockTakeEmpty):
+		return c.Status(fiber.StatusBadRequest).JSON(common.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, common.ErrStockTakeStatus), errors.Is(err, common.ErrStockTakeOverlap):
+		return c.Status(fiber.StatusConflict).JSON(common.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, common.ErrUnauthorized):
+		return c.Status(fiber.StatusUnauthorized).JSON(common.ErrorResponse{Message: "Unauthorized"})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(common.ErrorResponse{Message: fallback})
+}
@@ -0,0 +1,128 @@
+package stocktake_test
+
+import (
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/stocktake"
+	"POS-kasir/internal/stocktake/repository"
+	"POS-kasir/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStockTakeHandler_CreateStockTakeHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIStockTakeService(ctrl)
+	handler := stocktake.NewStockTakeHandler(mockService, mocks.NewMockILogger(ctrl))
+
+	app := fiber.New()
+	app.Post("/stock-takes", handler.CreateStockTakeHandler)
+
+	body := `{"scope":"full"}`
+	expectedReq := stocktake.CreateStockTakeRequest{Scope: repository.StockTakeScopeFull}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().CreateStockTake(gomock.Any(), expectedReq).
+			Return(&stocktake.StockTakeDetailResponse{StockTakeResponse: stocktake.StockTakeResponse{ID: uuid.New(), Status: repository.StockTakeStatusOpen}}, nil)
+
+		req := httptest.NewRequest("POST", "/stock-takes", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("Overlap", func(t *testing.T) {
+		mockService.EXPECT().CreateStockTake(gomock.Any(), expectedReq).Return(nil, common.ErrStockTakeOverlap)
+
+		req := httptest.NewRequest("POST", "/stock-takes", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestStockTakeHandler_RecordCountsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIStockTakeService(ctrl)
+	handler := stocktake.NewStockTakeHandler(mockService, mocks.NewMockILogger(ctrl))
+
+	app := fiber.New()
+	app.Post("/stock-takes/:id/counts", handler.RecordCountsHandler)
+
+	id := uuid.New()
+	productID := uuid.New()
+	body := `{"items":[{"product_id":"` + productID.String() + `","quantity":7}]}`
+	expectedReq := stocktake.RecordCountsRequest{Items: []stocktake.StockTakeCountItemRequest{{ProductID: productID, Quantity: 7}}}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().RecordCounts(gomock.Any(), id, expectedReq).
+			Return(&stocktake.StockTakeDetailResponse{StockTakeResponse: stocktake.StockTakeResponse{ID: id}}, nil)
+
+		req := httptest.NewRequest("POST", "/stock-takes/"+id.String()+"/counts", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("ProductOutsideStockTake", func(t *testing.T) {
+		mockService.EXPECT().RecordCounts(gomock.Any(), id, expectedReq).Return(nil, common.ErrStockTakeInvalid)
+
+		req := httptest.NewRequest("POST", "/stock-takes/"+id.String()+"/counts", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("NotOpen", func(t *testing.T) {
+		mockService.EXPECT().RecordCounts(gomock.Any(), id, expectedReq).Return(nil, common.ErrStockTakeStatus)
+
+		req := httptest.NewRequest("POST", "/stock-takes/"+id.String()+"/counts", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestStockTakeHandler_ApproveStockTakeHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIStockTakeService(ctrl)
+	handler := stocktake.NewStockTakeHandler(mockService, mocks.NewMockILogger(ctrl))
+
+	app := fiber.New()
+	app.Post("/stock-takes/:id/approve", handler.ApproveStockTakeHandler)
+
+	id := uuid.New()
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService.EXPECT().ApproveStockTake(gomock.Any(), id).Return(nil, common.ErrNotFound)
+
+		req := httptest.NewRequest("POST", "/stock-takes/"+id.String()+"/approve", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/stock-takes/not-a-uuid/approve", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
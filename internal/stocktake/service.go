@@ -0,0 +1,491 @@
+package stocktake
+
+import (
+	"POS-kasir/internal/activitylog"
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	"POS-kasir/internal/common/pagination"
+	"POS-kasir/internal/common/store"
+	"POS-kasir/internal/costing"
+	costing_repo "POS-kasir/internal/costing/repository"
+	"POS-kasir/internal/stocktake/repository"
+	"POS-kasir/pkg/logger"
+	"POS-kasir/pkg/utils"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type IStockTakeService interface {
+	ListStockTakes(ctx context.Context, req ListStockTakesRequest) (*PagedStockTakeResponse, error)
+	GetStockTake(ctx context.Context, id uuid.UUID) (*StockTakeDetailResponse, error)
+	CreateStockTake(ctx context.Context, req CreateStockTakeRequest) (*StockTakeDetailResponse, error)
+	RecordCounts(ctx context.Context, id uuid.UUID, req RecordCountsRequest) (*StockTakeDetailResponse, error)
+	ApproveStockTake(ctx context.Context, id uuid.UUID) (*StockTakeDetailResponse, error)
+	CancelStockTake(ctx context.Context, id uuid.UUID) (*StockTakeDetailResponse, error)
+}
+
+type StockTakeService struct {
+	store           store.Store
+	repo            repository.Querier
+	activityService activitylog.IActivityService
+	log             logger.ILogger
+}
+
+func NewStockTakeService(store store.Store, repo repository.Querier, activityService activitylog.IActivityService, log logger.ILogger) IStockTakeService {
+	return &StockTakeService{
+		store:           store,
+		repo:            repo,
+		activityService: activityService,
+		log:             log,
+	}
+}
+
+func (s *StockTakeService) ListStockTakes(ctx context.Context, req ListStockTakesRequest) (*PagedStockTakeResponse, error) {
+	req.SetDefaults()
+
+	var status repository.NullStockTakeStatus
+	if req.Status != nil {
+		status = repository.NullStockTakeStatus{StockTakeStatus: *req.Status, Valid: true}
+	}
+
+	stockTakes, err := s.repo.ListStockTakes(ctx, repository.ListStockTakesParams{
+		Limit:  int32(req.Limit),
+		Offset: int32((req.Page - 1) * req.Limit),
+		Status: status,
+	})
+	if err != nil {
+		s.log.Errorf("ListStockTakes | Failed to list stock takes: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	total, err := s.repo.CountStockTakes(ctx, status)
+	if err != nil {
+		s.log.Errorf("ListStockTakes | Failed to count stock takes: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	response := make([]StockTakeResponse, 0, len(stockTakes))
+	for _, st := range stockTakes {
+		response = append(response, toStockTakeResponse(repository.GetStockTakeRow(st)))
+	}
+
+	return &PagedStockTakeResponse{
+		StockTakes: response,
+		Pagination: pagination.BuildPagination(req.Page, int(total), req.Limit),
+	}, nil
+}
+
+func (s *StockTakeService) GetStockTake(ctx context.Context, id uuid.UUID) (*StockTakeDetailResponse, error) {
+	st, err := s.repo.GetStockTake(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, common.ErrNotFound
+		}
+		s.log.Errorf("GetStockTake | Failed to get stock take: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	items, err := s.repo.ListStockTakeItems(ctx, id)
+	if err != nil {
+		s.log.Errorf("GetStockTake | Failed to list stock take items: %v", err)
+		return nil, common.ErrInternal
+	}
+	counts, err := s.repo.ListStockTakeCounts(ctx, id)
+	if err != nil {
+		s.log.Errorf("GetStockTake | Failed to list stock take counts: %v", err)
+		return nil, common.ErrInternal
+	}
+
+	return buildStockTakeDetail(st, items, counts), nil
+}
+
+// CreateStockTake opens a count and snapshots the stock and cost of every product in scope. A product can
+// only be counted in one open stock take at a time.
+func (s *StockTakeService) CreateStockTake(ctx context.Context, req CreateStockTakeRequest) (*StockTakeDetailResponse, error) {
+	var categoryID *int32
+	if req.Scope == repository.StockTakeScopeCategory {
+		if req.CategoryID == nil {
+			return nil, common.ErrCategoryNotFound
+		}
+		exists, err := s.repo.CategoryExists(ctx, *req.CategoryID)
+		if err != nil {
+			s.log.Errorf("CreateStockTake | Failed to check category: %v", err)
+			return nil, common.ErrInternal
+		}
+		if !exists {
+			return nil, common.ErrCategoryNotFound
+		}
+		categoryID = req.CategoryID
+	}
+
+	var st repository.StockTake
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+
+		var err error
+		st, err = qtx.CreateStockTake(ctx, repository.CreateStockTakeParams{
+			Scope:      req.Scope,
+			CategoryID: categoryID,
+			Notes:      req.Notes,
+			CreatedBy:  actorUUID(ctx),
+		})
+		if err != nil {
+			return err
+		}
+
+		products, err := qtx.SnapshotStockTakeItems(ctx, repository.SnapshotStockTakeItemsParams{
+			StockTakeID: st.ID,
+			CategoryID:  categoryID,
+		})
+		if err != nil {
+			return err
+		}
+		if products == 0 {
+			return common.ErrStockTakeEmpty
+		}
+
+		overlaps, err := qtx.CountStockTakeOverlaps(ctx, st.ID)
+		if err != nil {
+			return err
+		}
+		if overlaps > 0 {
+			return common.ErrStockTakeOverlap
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, s.txError("CreateStockTake", txErr)
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeCREATE, st.ID, map[string]interface{}{
+		"reference":   st.Reference,
+		"scope":       st.Scope,
+		"category_id": categoryID,
+	})
+
+	return s.GetStockTake(ctx, st.ID)
+}
+
+// RecordCounts stores the caller's counts. Each counted product's expected quantity moves to its stock at
+// this moment, so sales and deliveries while the count is open are not mistaken for variance.
+func (s *StockTakeService) RecordCounts(ctx context.Context, id uuid.UUID, req RecordCountsRequest) (*StockTakeDetailResponse, error) {
+	counterID, ok := ctx.Value(common.UserIDKey).(uuid.UUID)
+	if !ok {
+		return nil, common.ErrUnauthorized
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(req.Items))
+	seen := make(map[uuid.UUID]bool, len(req.Items))
+	for _, line := range req.Items {
+		if seen[line.ProductID] {
+			return nil, common.ErrStockTakeInvalid
+		}
+		seen[line.ProductID] = true
+		productIDs = append(productIDs, line.ProductID)
+	}
+
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+
+		st, err := qtx.GetStockTakeForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if st.Status != repository.StockTakeStatusOpen {
+			return common.ErrStockTakeStatus
+		}
+
+		items, err := qtx.GetStockTakeItemsByProducts(ctx, repository.GetStockTakeItemsByProductsParams{
+			StockTakeID: id,
+			ProductIds:  productIDs,
+		})
+		if err != nil {
+			return err
+		}
+		if len(items) != len(productIDs) {
+			return common.ErrStockTakeInvalid
+		}
+		itemMap := make(map[uuid.UUID]repository.StockTakeItem, len(items))
+		for _, item := range items {
+			itemMap[item.ProductID] = item
+		}
+
+		products, err := qtx.GetProductStockForUpdate(ctx, productIDs)
+		if err != nil {
+			return err
+		}
+		stock := make(map[uuid.UUID]int32, len(products))
+		for _, product := range products {
+			stock[product.ID] = product.Stock
+		}
+
+		for _, line := range req.Items {
+			item := itemMap[line.ProductID]
+			if err := qtx.UpsertStockTakeCount(ctx, repository.UpsertStockTakeCountParams{
+				StockTakeItemID: item.ID,
+				CountedBy:       counterID,
+				Quantity:        line.Quantity,
+			}); err != nil {
+				return err
+			}
+			if err := qtx.SetStockTakeItemExpected(ctx, repository.SetStockTakeItemExpectedParams{
+				ID:               item.ID,
+				ExpectedQuantity: stock[line.ProductID],
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, s.txError("RecordCounts", txErr)
+	}
+
+	return s.GetStockTake(ctx, id)
+}
+
+// ApproveStockTake posts the variance of every counted product as a correction in stock_history, all in one
+// transaction. The variance is added to the current stock rather than overwriting it, so sales after the last
+// count stay booked. Products nobody counted keep their stock.
+func (s *StockTakeService) ApproveStockTake(ctx context.Context, id uuid.UUID) (*StockTakeDetailResponse, error) {
+	actor := actorUUID(ctx)
+
+	var st repository.StockTake
+	var adjusted int
+	var totalVariance int32
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+		qCost := costing_repo.New(tx)
+
+		var err error
+		st, err = qtx.GetStockTakeForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if st.Status != repository.StockTakeStatusOpen {
+			return common.ErrStockTakeStatus
+		}
+
+		items, err := qtx.ListStockTakeItems(ctx, id)
+		if err != nil {
+			return err
+		}
+		counted := make([]repository.ListStockTakeItemsRow, 0, len(items))
+		productIDs := make([]uuid.UUID, 0, len(items))
+		for _, item := range items {
+			if item.CountEntries > 0 && item.CountedQuantity != item.ExpectedQuantity {
+				counted = append(counted, item)
+				productIDs = append(productIDs, item.ProductID)
+			}
+		}
+
+		products, err := qtx.GetProductStockForUpdate(ctx, productIDs)
+		if err != nil {
+			return err
+		}
+		stock := make(map[uuid.UUID]int32, len(products))
+		for _, product := range products {
+			stock[product.ID] = product.Stock
+		}
+
+		reference := pgtype.UUID{Bytes: id, Valid: true}
+		note := "Stock take " + st.Reference
+		for _, item := range counted {
+			previousStock := stock[item.ProductID]
+			change := item.CountedQuantity - item.ExpectedQuantity
+			// Stock cannot go below zero, whatever was sold since the last count
+			if previousStock+change < 0 {
+				change = -previousStock
+			}
+			if change == 0 {
+				continue
+			}
+
+			if err := qtx.AdjustProductStock(ctx, repository.AdjustProductStockParams{
+				ChangeAmount: change,
+				ID:           item.ProductID,
+			}); err != nil {
+				return err
+			}
+			if change > 0 {
+				err = costing.Receive(ctx, qCost, item.ProductID, previousStock, change, item.UnitCost, costing_repo.CostLayerSourceAdjustment, reference)
+			} else {
+				_, err = costing.Consume(ctx, qCost, item.ProductID, -change, pgtype.UUID{}, item.UnitCost)
+			}
+			if err != nil {
+				return err
+			}
+
+			if _, err := qtx.CreateStockHistory(ctx, repository.CreateStockHistoryParams{
+				ProductID:     item.ProductID,
+				ChangeAmount:  change,
+				PreviousStock: previousStock,
+				CurrentStock:  previousStock + change,
+				ChangeType:    repository.StockChangeTypeCorrection,
+				ReferenceID:   reference,
+				Note:          &note,
+				CreatedBy:     actor,
+			}); err != nil {
+				return err
+			}
+			adjusted++
+			totalVariance += change
+		}
+
+		return qtx.ApproveStockTake(ctx, repository.ApproveStockTakeParams{
+			ID:         id,
+			ApprovedBy: actor,
+		})
+	})
+	if txErr != nil {
+		return nil, s.txError("ApproveStockTake", txErr)
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeUPDATE, id, map[string]interface{}{
+		"reference":         st.Reference,
+		"new_status":        repository.StockTakeStatusApproved,
+		"products_adjusted": adjusted,
+		"total_variance":    totalVariance,
+	})
+
+	return s.GetStockTake(ctx, id)
+}
+
+// CancelStockTake closes an open count without touching stock.
+func (s *StockTakeService) CancelStockTake(ctx context.Context, id uuid.UUID) (*StockTakeDetailResponse, error) {
+	var st repository.StockTake
+	txErr := s.store.ExecTx(ctx, func(tx pgx.Tx) error {
+		qtx := repository.New(tx)
+
+		var err error
+		st, err = qtx.GetStockTakeForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if st.Status != repository.StockTakeStatusOpen {
+			return common.ErrStockTakeStatus
+		}
+		return qtx.CancelStockTake(ctx, id)
+	})
+	if txErr != nil {
+		return nil, s.txError("CancelStockTake", txErr)
+	}
+
+	s.logActivity(ctx, activitylog_repo.LogActionTypeUPDATE, id, map[string]interface{}{
+		"reference":  st.Reference,
+		"new_status": repository.StockTakeStatusCancelled,
+	})
+
+	return s.GetStockTake(ctx, id)
+}
+
+func (s *StockTakeService) txError(op string, err error) error {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return common.ErrNotFound
+	case errors.Is(err, common.ErrStockTakeStatus), errors.Is(err, common.ErrStockTakeInvalid),
+		errors.Is(err, common.ErrStockTakeEmpty), errors.Is(err, common.ErrStockTakeOverlap):
+		return err
+	}
+	s.log.Errorf("%s | Transaction failed: %v", op, err)
+	return common.ErrInternal
+}
+
+func (s *StockTakeService) logActivity(ctx context.Context, action activitylog_repo.LogActionType, id uuid.UUID, details map[string]interface{}) {
+	actorID, ok := ctx.Value(common.UserIDKey).(uuid.UUID)
+	if !ok {
+		s.log.Warnf("StockTakeService | Actor user ID not found in context for activity logging")
+	}
+
+	s.activityService.Log(
+		ctx,
+		actorID,
+		action,
+		activitylog_repo.LogEntityTypeSTOCKTAKE,
+		id.String(),
+		details,
+	)
+}
+
+func buildStockTakeDetail(st repository.GetStockTakeRow, items []repository.ListStockTakeItemsRow, counts []repository.ListStockTakeCountsRow) *StockTakeDetailResponse {
+	byItem := make(map[uuid.UUID][]StockTakeCountResponse)
+	for _, count := range counts {
+		byItem[count.StockTakeItemID] = append(byItem[count.StockTakeItemID], StockTakeCountResponse{
+			CountedBy:     count.CountedBy,
+			CountedByName: count.CountedByName,
+			Quantity:      count.Quantity,
+			CountedAt:     count.CountedAt.Time,
+		})
+	}
+
+	response := &StockTakeDetailResponse{
+		StockTakeResponse: toStockTakeResponse(st),
+		Items:             make([]StockTakeItemResponse, 0, len(items)),
+	}
+	for _, item := range items {
+		line := StockTakeItemResponse{
+			ProductID:        item.ProductID,
+			ProductName:      item.ProductName,
+			SystemQuantity:   item.SystemQuantity,
+			SoldDuringCount:  item.SystemQuantity - item.ExpectedQuantity,
+			ExpectedQuantity: item.ExpectedQuantity,
+			UnitCost:         utils.NumericToFloat64(item.UnitCost),
+			Counts:           byItem[item.ID],
+		}
+		if line.Counts == nil {
+			line.Counts = []StockTakeCountResponse{}
+		}
+		if item.CountEntries > 0 {
+			counted := item.CountedQuantity
+			variance := counted - item.ExpectedQuantity
+			varianceCost := float64(variance) * line.UnitCost
+			line.CountedQuantity = &counted
+			line.Variance = &variance
+			line.VarianceCost = &varianceCost
+
+			response.TotalVariance += variance
+			response.TotalVarianceCost += varianceCost
+		}
+		response.Items = append(response.Items, line)
+	}
+	return response
+}
+
+func toStockTakeResponse(st repository.GetStockTakeRow) StockTakeResponse {
+	return StockTakeResponse{
+		ID:           st.ID,
+		Reference:    st.Reference,
+		Scope:        st.Scope,
+		CategoryID:   st.CategoryID,
+		CategoryName: st.CategoryName,
+		Status:       st.Status,
+		Notes:        st.Notes,
+		ItemCount:    st.ItemCount,
+		CountedCount: st.CountedCount,
+		CreatedBy:    utils.NullableUUIDToPointer(st.CreatedBy),
+		ApprovedBy:   utils.NullableUUIDToPointer(st.ApprovedBy),
+		ApprovedAt:   timePtr(st.ApprovedAt),
+		CancelledAt:  timePtr(st.CancelledAt),
+		CreatedAt:    st.CreatedAt.Time,
+		UpdatedAt:    st.UpdatedAt.Time,
+	}
+}
+
+func timePtr(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	return &ts.Time
+}
+
+func actorUUID(ctx context.Context) pgtype.UUID {
+	if actorID, ok := ctx.Value(common.UserIDKey).(uuid.UUID); ok {
+		return pgtype.UUID{Bytes: actorID, Valid: true}
+	}
+	return pgtype.UUID{}
+}
@@ -0,0 +1,334 @@
+package stocktake_test
+
+import (
+	activitylog_repo "POS-kasir/internal/activitylog/repository"
+	"POS-kasir/internal/common"
+	costing_repo "POS-kasir/internal/costing/repository"
+	"POS-kasir/internal/stocktake"
+	"POS-kasir/internal/stocktake/repository"
+	"POS-kasir/mocks"
+	"POS-kasir/pkg/utils"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var stockTakeColumns = []string{
+	"id", "reference", "scope", "category_id", "status", "notes", "created_by",
+	"approved_by", "approved_at", "cancelled_at", "created_at", "updated_at",
+}
+
+var stockTakeItemColumns = []string{
+	"id", "stock_take_id", "product_id", "system_quantity", "expected_quantity", "unit_cost",
+}
+
+var stockTakeItemRowColumns = []string{
+	"id", "stock_take_id", "product_id", "system_quantity", "expected_quantity", "unit_cost",
+	"product_name", "counted_quantity", "count_entries",
+}
+
+var stockHistoryColumns = []string{
+	"id", "product_id", "change_amount", "previous_stock", "current_stock",
+	"change_type", "reference_id", "note", "created_by", "created_at", "variant_id",
+}
+
+func allowAllLoggerCalls(mockLogger *mocks.MockILogger) {
+	mockLogger.EXPECT().Warnf(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+}
+
+func setupTest(t *testing.T) (*mocks.MockStore, *mocks.MockStockTakeRepo, *mocks.MockIActivityService, stocktake.IStockTakeService) {
+	ctrl := gomock.NewController(t)
+	mockStore := mocks.NewMockStore(ctrl)
+	mockRepo := mocks.NewMockStockTakeRepo(ctrl)
+	mockActivity := mocks.NewMockIActivityService(ctrl)
+	mockLogger := mocks.NewMockILogger(ctrl)
+	allowAllLoggerCalls(mockLogger)
+
+	service := stocktake.NewStockTakeService(mockStore, mockRepo, mockActivity, mockLogger)
+	return mockStore, mockRepo, mockActivity, service
+}
+
+func stockTakeRow(id uuid.UUID, status repository.StockTakeStatus) []interface{} {
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	return []interface{}{id, "ST-000001", repository.StockTakeScopeFull, nil, status, nil, pgtype.UUID{}, pgtype.UUID{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, now, now}
+}
+
+func execTx(mockStore *mocks.MockStore, ctx context.Context, mockPgx pgxmock.PgxPoolIface) {
+	mockStore.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(pgx.Tx) error) error {
+		return fn(mockPgx)
+	})
+}
+
+func expectDetail(mockRepo *mocks.MockStockTakeRepo, ctx context.Context, id uuid.UUID, status repository.StockTakeStatus) {
+	mockRepo.EXPECT().GetStockTake(ctx, id).Return(repository.GetStockTakeRow{ID: id, Reference: "ST-000001", Status: status}, nil)
+	mockRepo.EXPECT().ListStockTakeItems(ctx, id).Return([]repository.ListStockTakeItemsRow{}, nil)
+	mockRepo.EXPECT().ListStockTakeCounts(ctx, id).Return([]repository.ListStockTakeCountsRow{}, nil)
+}
+
+func TestStockTakeService_CreateStockTake(t *testing.T) {
+	t.Run("UnknownCategory", func(t *testing.T) {
+		_, mockRepo, _, service := setupTest(t)
+		ctx := context.Background()
+		categoryID := int32(7)
+
+		mockRepo.EXPECT().CategoryExists(ctx, categoryID).Return(false, nil)
+
+		resp, err := service.CreateStockTake(ctx, stocktake.CreateStockTakeRequest{
+			Scope:      repository.StockTakeScopeCategory,
+			CategoryID: &categoryID,
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrCategoryNotFound)
+	})
+
+	t.Run("ProductsAlreadyBeingCounted", func(t *testing.T) {
+		mockStore, _, _, service := setupTest(t)
+		ctx := context.Background()
+		id := uuid.New()
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		execTx(mockStore, ctx, mockPgx)
+		mockPgx.ExpectQuery("INSERT INTO stock_takes").
+			WithArgs(repository.StockTakeScopeFull, (*int32)(nil), (*string)(nil), pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(stockTakeColumns).AddRow(stockTakeRow(id, repository.StockTakeStatusOpen)...))
+		mockPgx.ExpectExec("INSERT INTO stock_take_items").
+			WithArgs(id, (*int32)(nil)).
+			WillReturnResult(pgxmock.NewResult("INSERT", 12))
+		mockPgx.ExpectQuery("SELECT COUNT\\(\\*\\) FROM stock_take_items").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(3)))
+
+		resp, err := service.CreateStockTake(ctx, stocktake.CreateStockTakeRequest{Scope: repository.StockTakeScopeFull})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrStockTakeOverlap)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+}
+
+func TestStockTakeService_RecordCounts(t *testing.T) {
+	id := uuid.New()
+	itemID := uuid.New()
+	productID := uuid.New()
+	counterID := uuid.New()
+	unitCost, _ := utils.Float64ToNumeric(5000)
+
+	t.Run("ExpectedFollowsCurrentStock", func(t *testing.T) {
+		mockStore, mockRepo, _, service := setupTest(t)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, counterID)
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		execTx(mockStore, ctx, mockPgx)
+		mockPgx.ExpectQuery("SELECT .* FROM stock_takes").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(stockTakeColumns).AddRow(stockTakeRow(id, repository.StockTakeStatusOpen)...))
+		mockPgx.ExpectQuery("SELECT .* FROM stock_take_items").
+			WithArgs(id, []uuid.UUID{productID}).
+			WillReturnRows(pgxmock.NewRows(stockTakeItemColumns).AddRow(itemID, id, productID, int32(10), int32(10), unitCost))
+		// Two were sold since the count started, so the count is compared with 8
+		mockPgx.ExpectQuery("SELECT id, stock FROM products").
+			WithArgs([]uuid.UUID{productID}).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "stock"}).AddRow(productID, int32(8)))
+		mockPgx.ExpectExec("INSERT INTO stock_take_counts").
+			WithArgs(itemID, counterID, int32(7)).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPgx.ExpectExec("UPDATE stock_take_items").
+			WithArgs(itemID, int32(8)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		expectDetail(mockRepo, ctx, id, repository.StockTakeStatusOpen)
+
+		resp, err := service.RecordCounts(ctx, id, stocktake.RecordCountsRequest{
+			Items: []stocktake.StockTakeCountItemRequest{{ProductID: productID, Quantity: 7}},
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("ProductOutsideStockTake", func(t *testing.T) {
+		mockStore, _, _, service := setupTest(t)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, counterID)
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		execTx(mockStore, ctx, mockPgx)
+		mockPgx.ExpectQuery("SELECT .* FROM stock_takes").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(stockTakeColumns).AddRow(stockTakeRow(id, repository.StockTakeStatusOpen)...))
+		mockPgx.ExpectQuery("SELECT .* FROM stock_take_items").
+			WithArgs(id, []uuid.UUID{productID}).
+			WillReturnRows(pgxmock.NewRows(stockTakeItemColumns))
+
+		resp, err := service.RecordCounts(ctx, id, stocktake.RecordCountsRequest{
+			Items: []stocktake.StockTakeCountItemRequest{{ProductID: productID, Quantity: 7}},
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrStockTakeInvalid)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("ApprovedStockTakeIsClosed", func(t *testing.T) {
+		mockStore, _, _, service := setupTest(t)
+		ctx := context.WithValue(context.Background(), common.UserIDKey, counterID)
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		execTx(mockStore, ctx, mockPgx)
+		mockPgx.ExpectQuery("SELECT .* FROM stock_takes").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(stockTakeColumns).AddRow(stockTakeRow(id, repository.StockTakeStatusApproved)...))
+
+		resp, err := service.RecordCounts(ctx, id, stocktake.RecordCountsRequest{
+			Items: []stocktake.StockTakeCountItemRequest{{ProductID: productID, Quantity: 7}},
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrStockTakeStatus)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+}
+
+func TestStockTakeService_ApproveStockTake(t *testing.T) {
+	id := uuid.New()
+	reference := pgtype.UUID{Bytes: id, Valid: true}
+	shortID, overID, uncountedID := uuid.New(), uuid.New(), uuid.New()
+	unitCost, _ := utils.Float64ToNumeric(5000)
+
+	t.Run("PostsVarianceAsCorrections", func(t *testing.T) {
+		mockStore, mockRepo, mockActivity, service := setupTest(t)
+		ctx := context.Background()
+		now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		execTx(mockStore, ctx, mockPgx)
+		mockPgx.ExpectQuery("SELECT .* FROM stock_takes").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(stockTakeColumns).AddRow(stockTakeRow(id, repository.StockTakeStatusOpen)...))
+		mockPgx.ExpectQuery("SELECT .* FROM stock_take_items sti").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(stockTakeItemRowColumns).
+				AddRow(uuid.New(), id, overID, int32(4), int32(4), unitCost, "Gula", int32(6), int32(2)).
+				AddRow(uuid.New(), id, shortID, int32(10), int32(9), unitCost, "Kopi", int32(7), int32(1)).
+				AddRow(uuid.New(), id, uncountedID, int32(5), int32(5), unitCost, "Teh", int32(0), int32(0)))
+		// One more Kopi was sold after it was counted
+		mockPgx.ExpectQuery("SELECT id, stock FROM products").
+			WithArgs([]uuid.UUID{overID, shortID}).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "stock"}).AddRow(overID, int32(4)).AddRow(shortID, int32(8)))
+
+		mockPgx.ExpectExec("UPDATE products").
+			WithArgs(int32(2), overID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPgx.ExpectExec("INSERT INTO cost_layers").
+			WithArgs(overID, costing_repo.CostLayerSourceAdjustment, reference, int32(2), unitCost).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPgx.ExpectExec("UPDATE products SET cost_price").
+			WithArgs(int32(4), int32(2), unitCost, overID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPgx.ExpectQuery("INSERT INTO stock_history").
+			WithArgs(overID, int32(2), int32(4), int32(6), repository.StockChangeTypeCorrection, reference, pgxmock.AnyArg(), pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(stockHistoryColumns).
+				AddRow(uuid.New(), overID, int32(2), int32(4), int32(6), repository.StockChangeTypeCorrection, reference, nil, pgtype.UUID{}, now, nil))
+
+		mockPgx.ExpectExec("UPDATE products").
+			WithArgs(int32(-2), shortID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPgx.ExpectQuery("WITH locked AS").
+			WithArgs(shortID, int32(2), pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows([]string{"quantity", "unit_cost"}).AddRow(int32(2), unitCost))
+		mockPgx.ExpectQuery("INSERT INTO stock_history").
+			WithArgs(shortID, int32(-2), int32(8), int32(6), repository.StockChangeTypeCorrection, reference, pgxmock.AnyArg(), pgtype.UUID{}).
+			WillReturnRows(pgxmock.NewRows(stockHistoryColumns).
+				AddRow(uuid.New(), shortID, int32(-2), int32(8), int32(6), repository.StockChangeTypeCorrection, reference, nil, pgtype.UUID{}, now, nil))
+
+		mockPgx.ExpectExec("UPDATE stock_takes").
+			WithArgs(id, pgtype.UUID{}).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockActivity.EXPECT().Log(ctx, gomock.Any(), activitylog_repo.LogActionTypeUPDATE, activitylog_repo.LogEntityTypeSTOCKTAKE, id.String(), gomock.Any())
+		expectDetail(mockRepo, ctx, id, repository.StockTakeStatusApproved)
+
+		resp, err := service.ApproveStockTake(ctx, id)
+
+		assert.NoError(t, err)
+		assert.Equal(t, repository.StockTakeStatusApproved, resp.Status)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+
+	t.Run("CancelledStockTakeCannotBeApproved", func(t *testing.T) {
+		mockStore, _, _, service := setupTest(t)
+		ctx := context.Background()
+
+		mockPgx, err := pgxmock.NewPool()
+		assert.NoError(t, err)
+		defer mockPgx.Close()
+
+		execTx(mockStore, ctx, mockPgx)
+		mockPgx.ExpectQuery("SELECT .* FROM stock_takes").
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(stockTakeColumns).AddRow(stockTakeRow(id, repository.StockTakeStatusCancelled)...))
+
+		resp, err := service.ApproveStockTake(ctx, id)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, common.ErrStockTakeStatus)
+		assert.NoError(t, mockPgx.ExpectationsWereMet())
+	})
+}
+
+func TestStockTakeService_GetStockTake(t *testing.T) {
+	id := uuid.New()
+	itemID := uuid.New()
+	counterID := uuid.New()
+	unitCost, _ := utils.Float64ToNumeric(5000)
+
+	_, mockRepo, _, service := setupTest(t)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetStockTake(ctx, id).Return(repository.GetStockTakeRow{ID: id, Status: repository.StockTakeStatusOpen, ItemCount: 2, CountedCount: 1}, nil)
+	mockRepo.EXPECT().ListStockTakeItems(ctx, id).Return([]repository.ListStockTakeItemsRow{
+		{ID: itemID, ProductID: uuid.New(), ProductName: "Kopi", SystemQuantity: 10, ExpectedQuantity: 8, UnitCost: unitCost, CountedQuantity: 5, CountEntries: 2},
+		{ID: uuid.New(), ProductID: uuid.New(), ProductName: "Teh", SystemQuantity: 5, ExpectedQuantity: 5, UnitCost: unitCost},
+	}, nil)
+	mockRepo.EXPECT().ListStockTakeCounts(ctx, id).Return([]repository.ListStockTakeCountsRow{
+		{StockTakeItemID: itemID, CountedBy: counterID, CountedByName: "andi", Quantity: 3},
+		{StockTakeItemID: itemID, CountedBy: uuid.New(), CountedByName: "budi", Quantity: 2},
+	}, nil)
+
+	resp, err := service.GetStockTake(ctx, id)
+
+	assert.NoError(t, err)
+	kopi := resp.Items[0]
+	assert.Equal(t, int32(2), kopi.SoldDuringCount)
+	assert.Equal(t, int32(-3), *kopi.Variance)
+	assert.Equal(t, float64(-15000), *kopi.VarianceCost)
+	assert.Len(t, kopi.Counts, 2)
+	assert.Nil(t, resp.Items[1].Variance)
+	assert.Empty(t, resp.Items[1].Counts)
+	assert.Equal(t, int32(-3), resp.TotalVariance)
+	assert.Equal(t, float64(-15000), resp.TotalVarianceCost)
+}
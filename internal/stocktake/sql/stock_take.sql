@@ -0,0 +1,141 @@
+-- name: ListStockTakes :many
+SELECT
+    st.*,
+    c.name AS category_name,
+    (SELECT COUNT(*) FROM stock_take_items sti WHERE sti.stock_take_id = st.id)::int AS item_count,
+    (SELECT COUNT(*) FROM stock_take_items sti WHERE sti.stock_take_id = st.id
+        AND EXISTS (SELECT 1 FROM stock_take_counts stc WHERE stc.stock_take_item_id = sti.id))::int AS counted_count
+FROM stock_takes st
+LEFT JOIN categories c ON c.id = st.category_id
+WHERE (sqlc.narg(status)::stock_take_status IS NULL OR st.status = sqlc.narg(status))
+ORDER BY st.created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: CountStockTakes :one
+SELECT COUNT(*) FROM stock_takes
+WHERE (sqlc.narg(status)::stock_take_status IS NULL OR status = sqlc.narg(status));
+
+-- name: GetStockTake :one
+SELECT
+    st.*,
+    c.name AS category_name,
+    (SELECT COUNT(*) FROM stock_take_items sti WHERE sti.stock_take_id = st.id)::int AS item_count,
+    (SELECT COUNT(*) FROM stock_take_items sti WHERE sti.stock_take_id = st.id
+        AND EXISTS (SELECT 1 FROM stock_take_counts stc WHERE stc.stock_take_item_id = sti.id))::int AS counted_count
+FROM stock_takes st
+LEFT JOIN categories c ON c.id = st.category_id
+WHERE st.id = $1;
+
+-- name: GetStockTakeForUpdate :one
+-- Mengunci sesi stock opname selama hitungan dicatat, disetujui atau dibatalkan.
+SELECT * FROM stock_takes
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CategoryExists :one
+SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1);
+
+-- name: CreateStockTake :one
+INSERT INTO stock_takes (scope, category_id, notes, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: SnapshotStockTakeItems :execrows
+-- Mencatat stok sistem dan harga pokok produk aktif saat opname dimulai. Produk yang dibuat dari resep atau punya varian tidak punya stok sendiri.
+INSERT INTO stock_take_items (stock_take_id, product_id, system_quantity, expected_quantity, unit_cost)
+SELECT sqlc.arg(stock_take_id)::uuid, p.id, p.stock, p.stock, p.cost_price
+FROM products p
+WHERE p.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM product_recipe_items pri WHERE pri.product_id = p.id)
+  AND NOT EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = p.id AND pv.deleted_at IS NULL)
+  AND (sqlc.narg(category_id)::int IS NULL OR EXISTS (
+    SELECT 1 FROM product_categories pc WHERE pc.product_id = p.id AND pc.category_id = sqlc.narg(category_id)
+  ));
+
+-- name: CountStockTakeOverlaps :one
+-- Produk yang juga sedang dihitung di sesi opname lain yang masih terbuka.
+SELECT COUNT(*) FROM stock_take_items sti
+JOIN stock_take_items other ON other.product_id = sti.product_id AND other.stock_take_id <> sti.stock_take_id
+JOIN stock_takes st ON st.id = other.stock_take_id
+WHERE sti.stock_take_id = $1 AND st.status = 'open';
+
+-- name: ListStockTakeItems :many
+-- Hitungan semua petugas untuk satu produk dijumlahkan.
+SELECT
+    sti.*,
+    p.name AS product_name,
+    COALESCE(SUM(stc.quantity), 0)::int AS counted_quantity,
+    COUNT(stc.id)::int AS count_entries
+FROM stock_take_items sti
+JOIN products p ON p.id = sti.product_id
+LEFT JOIN stock_take_counts stc ON stc.stock_take_item_id = sti.id
+WHERE sti.stock_take_id = $1
+GROUP BY sti.id, p.name
+ORDER BY p.name;
+
+-- name: ListStockTakeCounts :many
+SELECT
+    stc.id,
+    stc.stock_take_item_id,
+    stc.counted_by,
+    u.username AS counted_by_name,
+    stc.quantity,
+    stc.counted_at
+FROM stock_take_counts stc
+JOIN stock_take_items sti ON sti.id = stc.stock_take_item_id
+JOIN users u ON u.id = stc.counted_by
+WHERE sti.stock_take_id = $1
+ORDER BY stc.counted_at;
+
+-- name: GetStockTakeItemsByProducts :many
+SELECT * FROM stock_take_items
+WHERE stock_take_id = sqlc.arg(stock_take_id) AND product_id = ANY(sqlc.arg(product_ids)::uuid[]);
+
+-- name: UpsertStockTakeCount :exec
+-- Setiap petugas punya satu hitungan per produk; hitungan ulang menggantikan yang lama.
+INSERT INTO stock_take_counts (stock_take_item_id, counted_by, quantity)
+VALUES ($1, $2, $3)
+ON CONFLICT (stock_take_item_id, counted_by)
+DO UPDATE SET quantity = EXCLUDED.quantity, counted_at = now();
+
+-- name: SetStockTakeItemExpected :exec
+-- Stok sistem saat produk terakhir dihitung, agar penjualan selama opname tidak terbaca sebagai selisih.
+UPDATE stock_take_items
+SET expected_quantity = $2
+WHERE id = $1;
+
+-- name: GetProductStockForUpdate :many
+-- Mengunci stok produk yang dihitung, urut id agar tidak deadlock.
+SELECT id, stock FROM products
+WHERE id = ANY(sqlc.arg(ids)::uuid[])
+ORDER BY id
+FOR UPDATE;
+
+-- name: AdjustProductStock :exec
+UPDATE products
+SET stock = stock + sqlc.arg(change_amount)::int
+WHERE id = sqlc.arg(id);
+
+-- name: CreateStockHistory :one
+INSERT INTO stock_history (
+    product_id,
+    change_amount,
+    previous_stock,
+    current_stock,
+    change_type,
+    reference_id,
+    note,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: ApproveStockTake :exec
+UPDATE stock_takes
+SET status = 'approved', approved_by = $2, approved_at = now()
+WHERE id = $1;
+
+-- name: CancelStockTake :exec
+UPDATE stock_takes
+SET status = 'cancelled', cancelled_at = now()
+WHERE id = $1;
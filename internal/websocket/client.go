@@ -1,9 +1,14 @@
 package websocket
 
 import (
+	"POS-kasir/internal/common/middleware"
+	"encoding/json"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/gofiber/contrib/v3/websocket"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
@@ -30,13 +35,134 @@ type Client struct {
 
 	// Buffered channel of outbound messages.
 	send chan []byte
+
+	// Who is connected, taken from the JWT claims of the upgrade request.
+	userID uuid.UUID
+	role   middleware.UserRole
+
+	// Topics the client listens to.
+	subscriptions map[string]bool
+	mu            sync.Mutex
+
+	// Last sequence number the client saw before reconnecting; missed events are replayed on register.
+	replaySince *uint64
+}
+
+// controlMessage is what a client sends to change its subscriptions or ask for missed events.
+type controlMessage struct {
+	Action string   `json:"action"`
+	Topics []string `json:"topics"`
+	Since  uint64   `json:"since"`
+}
+
+const (
+	actionSubscribe   = "subscribe"
+	actionUnsubscribe = "unsubscribe"
+	actionReplay      = "replay"
+)
+
+// NewClient creates a client subscribed to topics, falling back to the orders feed when none are given.
+// Topics the role may not subscribe to are skipped. A non-nil since replays the events missed after it.
+func NewClient(hub *Hub, conn *websocket.Conn, userID uuid.UUID, role middleware.UserRole, topics []string, since *uint64) *Client {
+	client := &Client{
+		hub:           hub,
+		conn:          conn,
+		send:          make(chan []byte, 256),
+		userID:        userID,
+		role:          role,
+		subscriptions: make(map[string]bool),
+		replaySince:   since,
+	}
+
+	if len(topics) == 0 {
+		topics = []string{TopicOrders}
+	}
+	if _, err := client.subscribe(topics); err != nil {
+		logrus.Warnf("WebSocket client %s: %v", userID, err)
+	}
+	return client
+}
+
+// subscribe adds every valid topic and reports the first one that was refused.
+func (c *Client) subscribe(topics []string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var firstErr error
+	for _, topic := range topics {
+		if err := validateTopic(topic, c.role); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		c.subscriptions[topic] = true
+	}
+	return c.topicsLocked(), firstErr
 }
 
-func NewClient(hub *Hub, conn *websocket.Conn) *Client {
-	return &Client{
-		hub:  hub,
-		conn: conn,
-		send: make(chan []byte, 256),
+func (c *Client) unsubscribe(topics []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, topic := range topics {
+		delete(c.subscriptions, topic)
+	}
+	return c.topicsLocked()
+}
+
+func (c *Client) topicsLocked() []string {
+	topics := make([]string, 0, len(c.subscriptions))
+	for topic := range c.subscriptions {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// wants reports whether the event is on one of the client's topics and meant for its role or user.
+func (c *Client) wants(entry historyEntry) bool {
+	if entry.minRole != "" && roleAtLeast(c.role, entry.minRole) {
+		return c.subscribedToAny(entry.topics)
+	}
+	if entry.userID != nil {
+		return *entry.userID == c.userID && c.subscribedToAny(entry.topics)
+	}
+	return entry.minRole == "" && c.subscribedToAny(entry.topics)
+}
+
+func (c *Client) subscribedToAny(topics []string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, topic := range topics {
+		if c.subscriptions[topic] {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) handleControl(raw []byte) {
+	var msg controlMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		c.hub.sendControl(c, EventError, map[string]interface{}{"message": "invalid message"})
+		return
+	}
+
+	switch msg.Action {
+	case actionSubscribe:
+		topics, err := c.subscribe(msg.Topics)
+		if err != nil {
+			c.hub.sendControl(c, EventError, map[string]interface{}{"message": err.Error()})
+		}
+		c.hub.sendControl(c, EventSubscribed, map[string]interface{}{"topics": topics})
+	case actionUnsubscribe:
+		c.hub.sendControl(c, EventSubscribed, map[string]interface{}{"topics": c.unsubscribe(msg.Topics)})
+	case actionReplay:
+		c.hub.Replay(c, msg.Since)
+	default:
+		c.hub.sendControl(c, EventError, map[string]interface{}{"message": "unknown action"})
 	}
 }
 
@@ -63,8 +189,7 @@ func (c *Client) ReadPump() {
 			}
 			break
 		}
-		// In a read-only sync architecture, we just discard incoming messages for now
-		_ = message
+		c.handleControl(message)
 	}
 }
 
@@ -1,9 +1,14 @@
 package websocket
 
 import (
+	"POS-kasir/internal/common/middleware"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
@@ -11,21 +16,78 @@ import (
 const (
 	EventOrderCreated = "ORDER_CREATED"
 	EventOrderUpdated = "ORDER_UPDATED"
+	// EventKitchenTicketUpdated carries a station's ticket after one of its items changed preparation status.
+	EventKitchenTicketUpdated = "KDS_TICKET_UPDATED"
+	// EventStockLow is sent when a sale takes a product's stock down to the low stock threshold.
+	EventStockLow     = "STOCK_LOW"
+	EventShiftStarted = "SHIFT_STARTED"
+	EventShiftEnded   = "SHIFT_ENDED"
+	// EventCallingBoardUpdated carries the full calling board whenever an order moves on or off it.
+	EventCallingBoardUpdated = "CALLING_BOARD_UPDATED"
+
+	// Control events answer a client's own requests; they carry no sequence number.
+	EventSubscribed     = "SUBSCRIBED"
+	EventResyncRequired = "RESYNC_REQUIRED"
+	EventError          = "ERROR"
+)
+
+// Topics a client can subscribe to. Per-order and per-station topics are built with OrderTopic and KitchenStationTopic.
+const (
+	TopicOrders  = "orders"
+	TopicKitchen = "kds"
+	TopicStock   = "stock"
+	TopicShifts  = "shifts"
+	// TopicCallingBoard feeds customer-facing screens that call out ready queue numbers.
+	TopicCallingBoard = "calling_board"
+
+	orderTopicPrefix   = "order:"
+	kitchenTopicPrefix = "kds:"
 )
 
+// historySize is how many published events are kept for replay; it stays below the client send buffer
+// so a full replay always fits.
+const historySize = 200
+
+// topicMinRole lists topics that need more than a cashier login to subscribe to.
+var topicMinRole = map[string]middleware.UserRole{
+	TopicStock: middleware.UserRoleManager,
+}
+
 // Event represents a WebSocket message payload.
 type Event struct {
+	Seq     uint64      `json:"seq,omitempty"`
+	Topics  []string    `json:"topics,omitempty"`
 	Type    string      `json:"type"`
 	Payload interface{} `json:"payload"`
 }
 
+// Message is an event handed to the hub together with where it goes and who may see it.
+type Message struct {
+	Type    string
+	Payload interface{}
+	Topics  []string
+	// MinRole limits delivery to clients with at least this role; empty means every role.
+	MinRole middleware.UserRole
+	// UserID also lets this user receive the event when their role is below MinRole.
+	// Without MinRole the event goes to this user only.
+	UserID *uuid.UUID
+}
+
+type historyEntry struct {
+	seq     uint64
+	topics  []string
+	minRole middleware.UserRole
+	userID  *uuid.UUID
+	data    []byte
+}
+
 // Hub maintains the set of active clients and broadcasts messages to the clients.
 type Hub struct {
 	// Registered clients.
 	clients map[*Client]bool
 
-	// Inbound messages from the clients (if we need to handle them).
-	broadcast chan []byte
+	// Outbound messages waiting for a sequence number.
+	broadcast chan Message
 
 	// Register requests from the clients.
 	register chan *Client
@@ -33,6 +95,10 @@ type Hub struct {
 	// Unregister requests from clients.
 	unregister chan *Client
 
+	// Last sequence number handed out and the most recent events, oldest first.
+	seq     uint64
+	history []historyEntry
+
 	mu sync.Mutex
 }
 
@@ -42,14 +108,18 @@ var (
 )
 
 func InitHub() *Hub {
-	DefaultHub = &Hub{
-		broadcast:  make(chan []byte),
+	DefaultHub = newHub()
+	go DefaultHub.run()
+	return DefaultHub
+}
+
+func newHub() *Hub {
+	return &Hub{
+		broadcast:  make(chan Message),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
 		clients:    make(map[*Client]bool),
 	}
-	go DefaultHub.run()
-	return DefaultHub
 }
 
 func (h *Hub) run() {
@@ -58,26 +128,21 @@ func (h *Hub) run() {
 		case client := <-h.register:
 			h.mu.Lock()
 			h.clients[client] = true
+			if client.replaySince != nil {
+				h.replayLocked(client, *client.replaySince)
+			}
 			h.mu.Unlock()
 			logrus.Infof("WebSocket client connected. Total clients: %d", len(h.clients))
 		case client := <-h.unregister:
 			h.mu.Lock()
 			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				close(client.send)
+				h.removeLocked(client)
 				logrus.Infof("WebSocket client disconnected. Total clients: %d", len(h.clients))
 			}
 			h.mu.Unlock()
-		case message := <-h.broadcast:
+		case msg := <-h.broadcast:
 			h.mu.Lock()
-			for client := range h.clients {
-				select {
-				case client.send <- message:
-				default:
-					close(client.send)
-					delete(h.clients, client)
-				}
-			}
+			h.publishLocked(msg)
 			h.mu.Unlock()
 		}
 	}
@@ -93,18 +158,158 @@ func (h *Hub) Unregister(client *Client) {
 	h.unregister <- client
 }
 
-// BroadcastEvent is a helper to broadcast a JSON event
-func (h *Hub) BroadcastEvent(eventType string, payload interface{}) {
-	event := Event{
+// Publish numbers the event and delivers it to every client subscribed to one of its topics
+// that is allowed to see it.
+func (h *Hub) Publish(msg Message) {
+	h.broadcast <- msg
+}
+
+// Replay resends the buffered events after since that the client may see. When events after since
+// have already left the buffer the client is told to resync instead.
+func (h *Hub) Replay(client *Client, since uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[client]; ok {
+		h.replayLocked(client, since)
+	}
+}
+
+// OrderEvent addresses an order event to the orders feed and to the order's own topic.
+func OrderEvent(eventType string, orderID uuid.UUID, payload interface{}) Message {
+	return Message{
 		Type:    eventType,
 		Payload: payload,
+		Topics:  []string{TopicOrders, OrderTopic(orderID)},
 	}
-	
-	bytes, err := json.Marshal(event)
+}
+
+// KitchenEvent addresses a KDS event to the kitchen feed and, when the item is routed, to its station.
+func KitchenEvent(eventType string, stationID *int32, payload interface{}) Message {
+	topics := []string{TopicKitchen}
+	if stationID != nil {
+		topics = append(topics, KitchenStationTopic(*stationID))
+	}
+	return Message{Type: eventType, Payload: payload, Topics: topics}
+}
+
+// CallingBoardEvent addresses a calling board snapshot to the customer-facing screens.
+func CallingBoardEvent(board interface{}) Message {
+	return Message{Type: EventCallingBoardUpdated, Payload: board, Topics: []string{TopicCallingBoard}}
+}
+
+func OrderTopic(orderID uuid.UUID) string {
+	return orderTopicPrefix + orderID.String()
+}
+
+func KitchenStationTopic(stationID int32) string {
+	return kitchenTopicPrefix + strconv.Itoa(int(stationID))
+}
+
+// validateTopic checks that the topic exists and that the role may subscribe to it.
+func validateTopic(topic string, role middleware.UserRole) error {
+	switch {
+	case topic == TopicOrders, topic == TopicKitchen, topic == TopicStock, topic == TopicShifts, topic == TopicCallingBoard:
+	case strings.HasPrefix(topic, orderTopicPrefix):
+		if _, err := uuid.Parse(strings.TrimPrefix(topic, orderTopicPrefix)); err != nil {
+			return fmt.Errorf("invalid order topic %q", topic)
+		}
+	case strings.HasPrefix(topic, kitchenTopicPrefix):
+		if id, err := strconv.Atoi(strings.TrimPrefix(topic, kitchenTopicPrefix)); err != nil || id <= 0 {
+			return fmt.Errorf("invalid kitchen station topic %q", topic)
+		}
+	default:
+		return fmt.Errorf("unknown topic %q", topic)
+	}
+
+	if minRole, ok := topicMinRole[topic]; ok && !roleAtLeast(role, minRole) {
+		return fmt.Errorf("role %s cannot subscribe to %q", role, topic)
+	}
+	return nil
+}
+
+func roleAtLeast(role, minRole middleware.UserRole) bool {
+	level, ok := middleware.RoleLevel[role]
+	return ok && level >= middleware.RoleLevel[minRole]
+}
+
+func (h *Hub) publishLocked(msg Message) {
+	h.seq++
+	bytes, err := json.Marshal(Event{
+		Seq:     h.seq,
+		Topics:  msg.Topics,
+		Type:    msg.Type,
+		Payload: msg.Payload,
+	})
+	if err != nil {
+		logrus.Errorf("Failed to marshal websocket event: %v", err)
+		return
+	}
+
+	entry := historyEntry{seq: h.seq, topics: msg.Topics, minRole: msg.MinRole, userID: msg.UserID, data: bytes}
+	h.history = append(h.history, entry)
+	if len(h.history) > historySize {
+		h.history = h.history[len(h.history)-historySize:]
+	}
+
+	for client := range h.clients {
+		if client.wants(entry) {
+			h.sendLocked(client, entry.data)
+		}
+	}
+}
+
+func (h *Hub) replayLocked(client *Client, since uint64) {
+	if since >= h.seq {
+		return
+	}
+
+	// The buffer no longer reaches back to since, so some events are gone for good.
+	if len(h.history) == 0 || h.history[0].seq > since+1 {
+		h.sendControlLocked(client, EventResyncRequired, map[string]interface{}{"latest_seq": h.seq})
+		return
+	}
+
+	for _, entry := range h.history {
+		if entry.seq > since && client.wants(entry) {
+			if !h.sendLocked(client, entry.data) {
+				return
+			}
+		}
+	}
+}
+
+// sendControl answers a client request outside the numbered event stream.
+func (h *Hub) sendControl(client *Client, eventType string, payload interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[client]; ok {
+		h.sendControlLocked(client, eventType, payload)
+	}
+}
+
+func (h *Hub) sendControlLocked(client *Client, eventType string, payload interface{}) {
+	bytes, err := json.Marshal(Event{Type: eventType, Payload: payload})
 	if err != nil {
 		logrus.Errorf("Failed to marshal websocket event: %v", err)
 		return
 	}
-	
-	h.broadcast <- bytes
+	h.sendLocked(client, bytes)
+}
+
+// sendLocked queues a message for the client and drops clients that cannot keep up.
+func (h *Hub) sendLocked(client *Client, message []byte) bool {
+	select {
+	case client.send <- message:
+		return true
+	default:
+		h.removeLocked(client)
+		return false
+	}
+}
+
+func (h *Hub) removeLocked(client *Client) {
+	if _, ok := h.clients[client]; ok {
+		delete(h.clients, client)
+		close(client.send)
+	}
 }
@@ -0,0 +1,130 @@
+package websocket
+
+import (
+	"POS-kasir/internal/common/middleware"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startHub(t *testing.T) *Hub {
+	t.Helper()
+	hub := newHub()
+	go hub.run()
+	return hub
+}
+
+func connect(hub *Hub, role middleware.UserRole, topics []string, since *uint64) *Client {
+	client := NewClient(hub, nil, uuid.New(), role, topics, since)
+	hub.Register(client)
+	return client
+}
+
+func receive(t *testing.T, client *Client) Event {
+	t.Helper()
+	select {
+	case message := <-client.send:
+		var event Event
+		require.NoError(t, json.Unmarshal(message, &event))
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("expected a websocket event")
+		return Event{}
+	}
+}
+
+func expectNothing(t *testing.T, client *Client) {
+	t.Helper()
+	select {
+	case message := <-client.send:
+		t.Fatalf("unexpected websocket event: %s", message)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHub_DeliversBySubscribedTopic(t *testing.T) {
+	hub := startHub(t)
+	cashier := connect(hub, middleware.UserRoleCashier, nil, nil)
+	grill := connect(hub, middleware.UserRoleCashier, []string{KitchenStationTopic(2)}, nil)
+	orderID := uuid.New()
+
+	hub.Publish(OrderEvent(EventOrderUpdated, orderID, map[string]interface{}{"order_id": orderID}))
+	station := int32(2)
+	hub.Publish(KitchenEvent(EventKitchenTicketUpdated, &station, map[string]interface{}{"order_id": orderID}))
+
+	// Clients without topics fall back to the orders feed
+	event := receive(t, cashier)
+	assert.Equal(t, EventOrderUpdated, event.Type)
+	assert.Equal(t, uint64(1), event.Seq)
+	assert.Contains(t, event.Topics, OrderTopic(orderID))
+	expectNothing(t, cashier)
+
+	event = receive(t, grill)
+	assert.Equal(t, EventKitchenTicketUpdated, event.Type)
+	assert.Equal(t, uint64(2), event.Seq)
+	expectNothing(t, grill)
+}
+
+func TestHub_FiltersByRoleAndUser(t *testing.T) {
+	hub := startHub(t)
+	manager := connect(hub, middleware.UserRoleManager, []string{TopicShifts}, nil)
+	owner := connect(hub, middleware.UserRoleCashier, []string{TopicShifts}, nil)
+	otherCashier := connect(hub, middleware.UserRoleCashier, []string{TopicShifts}, nil)
+
+	hub.Publish(Message{
+		Type:    EventShiftStarted,
+		Topics:  []string{TopicShifts},
+		MinRole: middleware.UserRoleManager,
+		UserID:  &owner.userID,
+	})
+
+	assert.Equal(t, EventShiftStarted, receive(t, manager).Type)
+	assert.Equal(t, EventShiftStarted, receive(t, owner).Type)
+	expectNothing(t, otherCashier)
+}
+
+func TestHub_ReplaysMissedEvents(t *testing.T) {
+	hub := startHub(t)
+	for i := 0; i < 3; i++ {
+		hub.Publish(OrderEvent(EventOrderCreated, uuid.New(), nil))
+	}
+
+	since := uint64(1)
+	client := connect(hub, middleware.UserRoleCashier, []string{TopicOrders}, &since)
+
+	assert.Equal(t, uint64(2), receive(t, client).Seq)
+	assert.Equal(t, uint64(3), receive(t, client).Seq)
+	expectNothing(t, client)
+}
+
+func TestHub_AsksForResyncWhenReplayIsTooOld(t *testing.T) {
+	hub := startHub(t)
+	for i := 0; i < historySize+5; i++ {
+		hub.Publish(OrderEvent(EventOrderCreated, uuid.New(), nil))
+	}
+
+	since := uint64(2)
+	client := connect(hub, middleware.UserRoleCashier, []string{TopicOrders}, &since)
+
+	event := receive(t, client)
+	assert.Equal(t, EventResyncRequired, event.Type)
+	assert.Zero(t, event.Seq)
+	expectNothing(t, client)
+}
+
+func TestValidateTopic(t *testing.T) {
+	assert.NoError(t, validateTopic(TopicOrders, middleware.UserRoleCashier))
+	assert.NoError(t, validateTopic(OrderTopic(uuid.New()), middleware.UserRoleCashier))
+	assert.NoError(t, validateTopic(KitchenStationTopic(3), middleware.UserRoleCashier))
+	assert.NoError(t, validateTopic(TopicStock, middleware.UserRoleManager))
+	assert.NoError(t, validateTopic(TopicCallingBoard, middleware.UserRoleCashier))
+
+	assert.Error(t, validateTopic(TopicStock, middleware.UserRoleCashier))
+	assert.Error(t, validateTopic("order:not-a-uuid", middleware.UserRoleAdmin))
+	assert.Error(t, validateTopic("kds:0", middleware.UserRoleAdmin))
+	assert.Error(t, validateTopic("payments", middleware.UserRoleAdmin))
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: POS-kasir/internal/costing/repository (interfaces: Querier)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_costing_repo.go -mock_names Querier=MockCostingRepo POS-kasir/internal/costing/repository Querier
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	repository "POS-kasir/internal/costing/repository"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCostingRepo is a mock of Querier interface.
+type MockCostingRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCostingRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockCostingRepoMockRecorder is the mock recorder for MockCostingRepo.
+type MockCostingRepoMockRecorder struct {
+	mock *MockCostingRepo
+}
+
+// NewMockCostingRepo creates a new mock instance.
+func NewMockCostingRepo(ctrl *gomock.Controller) *MockCostingRepo {
+	mock := &MockCostingRepo{ctrl: ctrl}
+	mock.recorder = &MockCostingRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCostingRepo) EXPECT() *MockCostingRepoMockRecorder {
+	return m.recorder
+}
+
+// ApplyAverageCost mocks base method.
+func (m *MockCostingRepo) ApplyAverageCost(ctx context.Context, arg repository.ApplyAverageCostParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyAverageCost", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyAverageCost indicates an expected call of ApplyAverageCost.
+func (mr *MockCostingRepoMockRecorder) ApplyAverageCost(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyAverageCost", reflect.TypeOf((*MockCostingRepo)(nil).ApplyAverageCost), ctx, arg)
+}
+
+// ConsumeCostLayers mocks base method.
+func (m *MockCostingRepo) ConsumeCostLayers(ctx context.Context, arg repository.ConsumeCostLayersParams) ([]repository.ConsumeCostLayersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeCostLayers", ctx, arg)
+	ret0, _ := ret[0].([]repository.ConsumeCostLayersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeCostLayers indicates an expected call of ConsumeCostLayers.
+func (mr *MockCostingRepoMockRecorder) ConsumeCostLayers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeCostLayers", reflect.TypeOf((*MockCostingRepo)(nil).ConsumeCostLayers), ctx, arg)
+}
+
+// CreateCostLayer mocks base method.
+func (m *MockCostingRepo) CreateCostLayer(ctx context.Context, arg repository.CreateCostLayerParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCostLayer", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCostLayer indicates an expected call of CreateCostLayer.
+func (mr *MockCostingRepoMockRecorder) CreateCostLayer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCostLayer", reflect.TypeOf((*MockCostingRepo)(nil).CreateCostLayer), ctx, arg)
+}
+
+// RestoreCostLayers mocks base method.
+func (m *MockCostingRepo) RestoreCostLayers(ctx context.Context, arg repository.RestoreCostLayersParams) ([]repository.RestoreCostLayersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCostLayers", ctx, arg)
+	ret0, _ := ret[0].([]repository.RestoreCostLayersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCostLayers indicates an expected call of RestoreCostLayers.
+func (mr *MockCostingRepoMockRecorder) RestoreCostLayers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCostLayers", reflect.TypeOf((*MockCostingRepo)(nil).RestoreCostLayers), ctx, arg)
+}
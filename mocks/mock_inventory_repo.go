@@ -0,0 +1,353 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: POS-kasir/internal/inventory/repository (interfaces: Querier)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_inventory_repo.go -mock_names Querier=MockInventoryRepo POS-kasir/internal/inventory/repository Querier
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	repository "POS-kasir/internal/inventory/repository"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInventoryRepo is a mock of Querier interface.
+type MockInventoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockInventoryRepoMockRecorder is the mock recorder for MockInventoryRepo.
+type MockInventoryRepoMockRecorder struct {
+	mock *MockInventoryRepo
+}
+
+// NewMockInventoryRepo creates a new mock instance.
+func NewMockInventoryRepo(ctrl *gomock.Controller) *MockInventoryRepo {
+	mock := &MockInventoryRepo{ctrl: ctrl}
+	mock.recorder = &MockInventoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryRepo) EXPECT() *MockInventoryRepoMockRecorder {
+	return m.recorder
+}
+
+// CountIngredientStockHistory mocks base method.
+func (m *MockInventoryRepo) CountIngredientStockHistory(ctx context.Context, ingredientID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountIngredientStockHistory", ctx, ingredientID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountIngredientStockHistory indicates an expected call of CountIngredientStockHistory.
+func (mr *MockInventoryRepoMockRecorder) CountIngredientStockHistory(ctx, ingredientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountIngredientStockHistory", reflect.TypeOf((*MockInventoryRepo)(nil).CountIngredientStockHistory), ctx, ingredientID)
+}
+
+// CountIngredients mocks base method.
+func (m *MockInventoryRepo) CountIngredients(ctx context.Context, arg repository.CountIngredientsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountIngredients", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountIngredients indicates an expected call of CountIngredients.
+func (mr *MockInventoryRepoMockRecorder) CountIngredients(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountIngredients", reflect.TypeOf((*MockInventoryRepo)(nil).CountIngredients), ctx, arg)
+}
+
+// CountRecipesUsingIngredient mocks base method.
+func (m *MockInventoryRepo) CountRecipesUsingIngredient(ctx context.Context, ingredientID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecipesUsingIngredient", ctx, ingredientID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecipesUsingIngredient indicates an expected call of CountRecipesUsingIngredient.
+func (mr *MockInventoryRepoMockRecorder) CountRecipesUsingIngredient(ctx, ingredientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecipesUsingIngredient", reflect.TypeOf((*MockInventoryRepo)(nil).CountRecipesUsingIngredient), ctx, ingredientID)
+}
+
+// CreateIngredient mocks base method.
+func (m *MockInventoryRepo) CreateIngredient(ctx context.Context, arg repository.CreateIngredientParams) (repository.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredient", ctx, arg)
+	ret0, _ := ret[0].(repository.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredient indicates an expected call of CreateIngredient.
+func (mr *MockInventoryRepoMockRecorder) CreateIngredient(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredient", reflect.TypeOf((*MockInventoryRepo)(nil).CreateIngredient), ctx, arg)
+}
+
+// CreateIngredientStockHistory mocks base method.
+func (m *MockInventoryRepo) CreateIngredientStockHistory(ctx context.Context, arg repository.CreateIngredientStockHistoryParams) (repository.IngredientStockHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredientStockHistory", ctx, arg)
+	ret0, _ := ret[0].(repository.IngredientStockHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredientStockHistory indicates an expected call of CreateIngredientStockHistory.
+func (mr *MockInventoryRepoMockRecorder) CreateIngredientStockHistory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredientStockHistory", reflect.TypeOf((*MockInventoryRepo)(nil).CreateIngredientStockHistory), ctx, arg)
+}
+
+// CreateOptionRecipeItem mocks base method.
+func (m *MockInventoryRepo) CreateOptionRecipeItem(ctx context.Context, arg repository.CreateOptionRecipeItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOptionRecipeItem", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOptionRecipeItem indicates an expected call of CreateOptionRecipeItem.
+func (mr *MockInventoryRepoMockRecorder) CreateOptionRecipeItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOptionRecipeItem", reflect.TypeOf((*MockInventoryRepo)(nil).CreateOptionRecipeItem), ctx, arg)
+}
+
+// CreateProductRecipeItem mocks base method.
+func (m *MockInventoryRepo) CreateProductRecipeItem(ctx context.Context, arg repository.CreateProductRecipeItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductRecipeItem", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProductRecipeItem indicates an expected call of CreateProductRecipeItem.
+func (mr *MockInventoryRepoMockRecorder) CreateProductRecipeItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductRecipeItem", reflect.TypeOf((*MockInventoryRepo)(nil).CreateProductRecipeItem), ctx, arg)
+}
+
+// DeleteOptionRecipe mocks base method.
+func (m *MockInventoryRepo) DeleteOptionRecipe(ctx context.Context, productOptionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOptionRecipe", ctx, productOptionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOptionRecipe indicates an expected call of DeleteOptionRecipe.
+func (mr *MockInventoryRepoMockRecorder) DeleteOptionRecipe(ctx, productOptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOptionRecipe", reflect.TypeOf((*MockInventoryRepo)(nil).DeleteOptionRecipe), ctx, productOptionID)
+}
+
+// DeleteProductRecipe mocks base method.
+func (m *MockInventoryRepo) DeleteProductRecipe(ctx context.Context, productID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductRecipe", ctx, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductRecipe indicates an expected call of DeleteProductRecipe.
+func (mr *MockInventoryRepoMockRecorder) DeleteProductRecipe(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductRecipe", reflect.TypeOf((*MockInventoryRepo)(nil).DeleteProductRecipe), ctx, productID)
+}
+
+// GetIngredient mocks base method.
+func (m *MockInventoryRepo) GetIngredient(ctx context.Context, id uuid.UUID) (repository.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredient", ctx, id)
+	ret0, _ := ret[0].(repository.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredient indicates an expected call of GetIngredient.
+func (mr *MockInventoryRepoMockRecorder) GetIngredient(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredient", reflect.TypeOf((*MockInventoryRepo)(nil).GetIngredient), ctx, id)
+}
+
+// GetIngredientByName mocks base method.
+func (m *MockInventoryRepo) GetIngredientByName(ctx context.Context, name string) (repository.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredientByName", ctx, name)
+	ret0, _ := ret[0].(repository.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredientByName indicates an expected call of GetIngredientByName.
+func (mr *MockInventoryRepoMockRecorder) GetIngredientByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredientByName", reflect.TypeOf((*MockInventoryRepo)(nil).GetIngredientByName), ctx, name)
+}
+
+// GetIngredientForUpdate mocks base method.
+func (m *MockInventoryRepo) GetIngredientForUpdate(ctx context.Context, id uuid.UUID) (repository.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredientForUpdate", ctx, id)
+	ret0, _ := ret[0].(repository.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredientForUpdate indicates an expected call of GetIngredientForUpdate.
+func (mr *MockInventoryRepoMockRecorder) GetIngredientForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredientForUpdate", reflect.TypeOf((*MockInventoryRepo)(nil).GetIngredientForUpdate), ctx, id)
+}
+
+// GetIngredientsByIDs mocks base method.
+func (m *MockInventoryRepo) GetIngredientsByIDs(ctx context.Context, ids []uuid.UUID) ([]repository.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredientsByIDs", ctx, ids)
+	ret0, _ := ret[0].([]repository.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredientsByIDs indicates an expected call of GetIngredientsByIDs.
+func (mr *MockInventoryRepoMockRecorder) GetIngredientsByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredientsByIDs", reflect.TypeOf((*MockInventoryRepo)(nil).GetIngredientsByIDs), ctx, ids)
+}
+
+// GetRecipeProduct mocks base method.
+func (m *MockInventoryRepo) GetRecipeProduct(ctx context.Context, id uuid.UUID) (repository.GetRecipeProductRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeProduct", ctx, id)
+	ret0, _ := ret[0].(repository.GetRecipeProductRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeProduct indicates an expected call of GetRecipeProduct.
+func (mr *MockInventoryRepoMockRecorder) GetRecipeProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeProduct", reflect.TypeOf((*MockInventoryRepo)(nil).GetRecipeProduct), ctx, id)
+}
+
+// ListIngredientStockHistory mocks base method.
+func (m *MockInventoryRepo) ListIngredientStockHistory(ctx context.Context, arg repository.ListIngredientStockHistoryParams) ([]repository.IngredientStockHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredientStockHistory", ctx, arg)
+	ret0, _ := ret[0].([]repository.IngredientStockHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredientStockHistory indicates an expected call of ListIngredientStockHistory.
+func (mr *MockInventoryRepoMockRecorder) ListIngredientStockHistory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredientStockHistory", reflect.TypeOf((*MockInventoryRepo)(nil).ListIngredientStockHistory), ctx, arg)
+}
+
+// ListIngredients mocks base method.
+func (m *MockInventoryRepo) ListIngredients(ctx context.Context, arg repository.ListIngredientsParams) ([]repository.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredients", ctx, arg)
+	ret0, _ := ret[0].([]repository.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredients indicates an expected call of ListIngredients.
+func (mr *MockInventoryRepoMockRecorder) ListIngredients(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredients", reflect.TypeOf((*MockInventoryRepo)(nil).ListIngredients), ctx, arg)
+}
+
+// ListOptionRecipeItemsByProduct mocks base method.
+func (m *MockInventoryRepo) ListOptionRecipeItemsByProduct(ctx context.Context, productID uuid.UUID) ([]repository.ListOptionRecipeItemsByProductRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOptionRecipeItemsByProduct", ctx, productID)
+	ret0, _ := ret[0].([]repository.ListOptionRecipeItemsByProductRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOptionRecipeItemsByProduct indicates an expected call of ListOptionRecipeItemsByProduct.
+func (mr *MockInventoryRepoMockRecorder) ListOptionRecipeItemsByProduct(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOptionRecipeItemsByProduct", reflect.TypeOf((*MockInventoryRepo)(nil).ListOptionRecipeItemsByProduct), ctx, productID)
+}
+
+// ListProductRecipeItems mocks base method.
+func (m *MockInventoryRepo) ListProductRecipeItems(ctx context.Context, productID uuid.UUID) ([]repository.ListProductRecipeItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductRecipeItems", ctx, productID)
+	ret0, _ := ret[0].([]repository.ListProductRecipeItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductRecipeItems indicates an expected call of ListProductRecipeItems.
+func (mr *MockInventoryRepoMockRecorder) ListProductRecipeItems(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductRecipeItems", reflect.TypeOf((*MockInventoryRepo)(nil).ListProductRecipeItems), ctx, productID)
+}
+
+// ListRecipeProductOptions mocks base method.
+func (m *MockInventoryRepo) ListRecipeProductOptions(ctx context.Context, productID uuid.UUID) ([]repository.ListRecipeProductOptionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecipeProductOptions", ctx, productID)
+	ret0, _ := ret[0].([]repository.ListRecipeProductOptionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecipeProductOptions indicates an expected call of ListRecipeProductOptions.
+func (mr *MockInventoryRepoMockRecorder) ListRecipeProductOptions(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipeProductOptions", reflect.TypeOf((*MockInventoryRepo)(nil).ListRecipeProductOptions), ctx, productID)
+}
+
+// SoftDeleteIngredient mocks base method.
+func (m *MockInventoryRepo) SoftDeleteIngredient(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteIngredient", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDeleteIngredient indicates an expected call of SoftDeleteIngredient.
+func (mr *MockInventoryRepoMockRecorder) SoftDeleteIngredient(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteIngredient", reflect.TypeOf((*MockInventoryRepo)(nil).SoftDeleteIngredient), ctx, id)
+}
+
+// UpdateIngredient mocks base method.
+func (m *MockInventoryRepo) UpdateIngredient(ctx context.Context, arg repository.UpdateIngredientParams) (repository.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIngredient", ctx, arg)
+	ret0, _ := ret[0].(repository.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIngredient indicates an expected call of UpdateIngredient.
+func (mr *MockInventoryRepoMockRecorder) UpdateIngredient(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIngredient", reflect.TypeOf((*MockInventoryRepo)(nil).UpdateIngredient), ctx, arg)
+}
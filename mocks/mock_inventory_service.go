@@ -0,0 +1,177 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: POS-kasir/internal/inventory (interfaces: IInventoryService)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_inventory_service.go POS-kasir/internal/inventory IInventoryService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	inventory "POS-kasir/internal/inventory"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIInventoryService is a mock of IInventoryService interface.
+type MockIInventoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIInventoryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIInventoryServiceMockRecorder is the mock recorder for MockIInventoryService.
+type MockIInventoryServiceMockRecorder struct {
+	mock *MockIInventoryService
+}
+
+// NewMockIInventoryService creates a new mock instance.
+func NewMockIInventoryService(ctrl *gomock.Controller) *MockIInventoryService {
+	mock := &MockIInventoryService{ctrl: ctrl}
+	mock.recorder = &MockIInventoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIInventoryService) EXPECT() *MockIInventoryServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateIngredient mocks base method.
+func (m *MockIInventoryService) CreateIngredient(ctx context.Context, req inventory.CreateIngredientRequest) (*inventory.IngredientResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredient", ctx, req)
+	ret0, _ := ret[0].(*inventory.IngredientResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredient indicates an expected call of CreateIngredient.
+func (mr *MockIInventoryServiceMockRecorder) CreateIngredient(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredient", reflect.TypeOf((*MockIInventoryService)(nil).CreateIngredient), ctx, req)
+}
+
+// DeleteIngredient mocks base method.
+func (m *MockIInventoryService) DeleteIngredient(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIngredient", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIngredient indicates an expected call of DeleteIngredient.
+func (mr *MockIInventoryServiceMockRecorder) DeleteIngredient(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredient", reflect.TypeOf((*MockIInventoryService)(nil).DeleteIngredient), ctx, id)
+}
+
+// GetIngredient mocks base method.
+func (m *MockIInventoryService) GetIngredient(ctx context.Context, id uuid.UUID) (*inventory.IngredientResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredient", ctx, id)
+	ret0, _ := ret[0].(*inventory.IngredientResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredient indicates an expected call of GetIngredient.
+func (mr *MockIInventoryServiceMockRecorder) GetIngredient(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredient", reflect.TypeOf((*MockIInventoryService)(nil).GetIngredient), ctx, id)
+}
+
+// GetProductRecipe mocks base method.
+func (m *MockIInventoryService) GetProductRecipe(ctx context.Context, productID uuid.UUID) (*inventory.ProductRecipeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductRecipe", ctx, productID)
+	ret0, _ := ret[0].(*inventory.ProductRecipeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductRecipe indicates an expected call of GetProductRecipe.
+func (mr *MockIInventoryServiceMockRecorder) GetProductRecipe(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductRecipe", reflect.TypeOf((*MockIInventoryService)(nil).GetProductRecipe), ctx, productID)
+}
+
+// GetStockHistory mocks base method.
+func (m *MockIInventoryService) GetStockHistory(ctx context.Context, id uuid.UUID, req inventory.ListIngredientStockHistoryRequest) (*inventory.PagedIngredientStockHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockHistory", ctx, id, req)
+	ret0, _ := ret[0].(*inventory.PagedIngredientStockHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockHistory indicates an expected call of GetStockHistory.
+func (mr *MockIInventoryServiceMockRecorder) GetStockHistory(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockHistory", reflect.TypeOf((*MockIInventoryService)(nil).GetStockHistory), ctx, id, req)
+}
+
+// ListIngredients mocks base method.
+func (m *MockIInventoryService) ListIngredients(ctx context.Context, req inventory.ListIngredientsRequest) (*inventory.ListIngredientsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredients", ctx, req)
+	ret0, _ := ret[0].(*inventory.ListIngredientsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredients indicates an expected call of ListIngredients.
+func (mr *MockIInventoryServiceMockRecorder) ListIngredients(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredients", reflect.TypeOf((*MockIInventoryService)(nil).ListIngredients), ctx, req)
+}
+
+// SetOptionRecipe mocks base method.
+func (m *MockIInventoryService) SetOptionRecipe(ctx context.Context, productID, optionID uuid.UUID, req inventory.SetOptionRecipeRequest) (*inventory.ProductRecipeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOptionRecipe", ctx, productID, optionID, req)
+	ret0, _ := ret[0].(*inventory.ProductRecipeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOptionRecipe indicates an expected call of SetOptionRecipe.
+func (mr *MockIInventoryServiceMockRecorder) SetOptionRecipe(ctx, productID, optionID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOptionRecipe", reflect.TypeOf((*MockIInventoryService)(nil).SetOptionRecipe), ctx, productID, optionID, req)
+}
+
+// SetProductRecipe mocks base method.
+func (m *MockIInventoryService) SetProductRecipe(ctx context.Context, productID uuid.UUID, req inventory.SetProductRecipeRequest) (*inventory.ProductRecipeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductRecipe", ctx, productID, req)
+	ret0, _ := ret[0].(*inventory.ProductRecipeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductRecipe indicates an expected call of SetProductRecipe.
+func (mr *MockIInventoryServiceMockRecorder) SetProductRecipe(ctx, productID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductRecipe", reflect.TypeOf((*MockIInventoryService)(nil).SetProductRecipe), ctx, productID, req)
+}
+
+// UpdateIngredient mocks base method.
+func (m *MockIInventoryService) UpdateIngredient(ctx context.Context, id uuid.UUID, req inventory.UpdateIngredientRequest) (*inventory.IngredientResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIngredient", ctx, id, req)
+	ret0, _ := ret[0].(*inventory.IngredientResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIngredient indicates an expected call of UpdateIngredient.
+func (mr *MockIInventoryServiceMockRecorder) UpdateIngredient(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIngredient", reflect.TypeOf((*MockIInventoryService)(nil).UpdateIngredient), ctx, id, req)
+}
@@ -0,0 +1,250 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: POS-kasir/internal/kitchen/repository (interfaces: Querier)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_kitchen_repo.go -mock_names Querier=MockKitchenRepo POS-kasir/internal/kitchen/repository Querier
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	repository "POS-kasir/internal/kitchen/repository"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKitchenRepo is a mock of Querier interface.
+type MockKitchenRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockKitchenRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockKitchenRepoMockRecorder is the mock recorder for MockKitchenRepo.
+type MockKitchenRepoMockRecorder struct {
+	mock *MockKitchenRepo
+}
+
+// NewMockKitchenRepo creates a new mock instance.
+func NewMockKitchenRepo(ctrl *gomock.Controller) *MockKitchenRepo {
+	mock := &MockKitchenRepo{ctrl: ctrl}
+	mock.recorder = &MockKitchenRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKitchenRepo) EXPECT() *MockKitchenRepoMockRecorder {
+	return m.recorder
+}
+
+// AssignKitchenStationCategories mocks base method.
+func (m *MockKitchenRepo) AssignKitchenStationCategories(ctx context.Context, arg repository.AssignKitchenStationCategoriesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignKitchenStationCategories", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignKitchenStationCategories indicates an expected call of AssignKitchenStationCategories.
+func (mr *MockKitchenRepoMockRecorder) AssignKitchenStationCategories(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignKitchenStationCategories", reflect.TypeOf((*MockKitchenRepo)(nil).AssignKitchenStationCategories), ctx, arg)
+}
+
+// ClearKitchenStationCategories mocks base method.
+func (m *MockKitchenRepo) ClearKitchenStationCategories(ctx context.Context, stationID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearKitchenStationCategories", ctx, stationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearKitchenStationCategories indicates an expected call of ClearKitchenStationCategories.
+func (mr *MockKitchenRepoMockRecorder) ClearKitchenStationCategories(ctx, stationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearKitchenStationCategories", reflect.TypeOf((*MockKitchenRepo)(nil).ClearKitchenStationCategories), ctx, stationID)
+}
+
+// CountExistingCategories mocks base method.
+func (m *MockKitchenRepo) CountExistingCategories(ctx context.Context, categoryIds []int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountExistingCategories", ctx, categoryIds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountExistingCategories indicates an expected call of CountExistingCategories.
+func (mr *MockKitchenRepoMockRecorder) CountExistingCategories(ctx, categoryIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountExistingCategories", reflect.TypeOf((*MockKitchenRepo)(nil).CountExistingCategories), ctx, categoryIds)
+}
+
+// CreateKitchenStation mocks base method.
+func (m *MockKitchenRepo) CreateKitchenStation(ctx context.Context, arg repository.CreateKitchenStationParams) (repository.KitchenStation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKitchenStation", ctx, arg)
+	ret0, _ := ret[0].(repository.KitchenStation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKitchenStation indicates an expected call of CreateKitchenStation.
+func (mr *MockKitchenRepoMockRecorder) CreateKitchenStation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKitchenStation", reflect.TypeOf((*MockKitchenRepo)(nil).CreateKitchenStation), ctx, arg)
+}
+
+// DeleteKitchenStation mocks base method.
+func (m *MockKitchenRepo) DeleteKitchenStation(ctx context.Context, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKitchenStation", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKitchenStation indicates an expected call of DeleteKitchenStation.
+func (mr *MockKitchenRepoMockRecorder) DeleteKitchenStation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKitchenStation", reflect.TypeOf((*MockKitchenRepo)(nil).DeleteKitchenStation), ctx, id)
+}
+
+// GetKitchenOrderItem mocks base method.
+func (m *MockKitchenRepo) GetKitchenOrderItem(ctx context.Context, id uuid.UUID) (repository.GetKitchenOrderItemRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKitchenOrderItem", ctx, id)
+	ret0, _ := ret[0].(repository.GetKitchenOrderItemRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKitchenOrderItem indicates an expected call of GetKitchenOrderItem.
+func (mr *MockKitchenRepoMockRecorder) GetKitchenOrderItem(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKitchenOrderItem", reflect.TypeOf((*MockKitchenRepo)(nil).GetKitchenOrderItem), ctx, id)
+}
+
+// GetKitchenStation mocks base method.
+func (m *MockKitchenRepo) GetKitchenStation(ctx context.Context, id int32) (repository.GetKitchenStationRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKitchenStation", ctx, id)
+	ret0, _ := ret[0].(repository.GetKitchenStationRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKitchenStation indicates an expected call of GetKitchenStation.
+func (mr *MockKitchenRepoMockRecorder) GetKitchenStation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKitchenStation", reflect.TypeOf((*MockKitchenRepo)(nil).GetKitchenStation), ctx, id)
+}
+
+// GetKitchenStationByName mocks base method.
+func (m *MockKitchenRepo) GetKitchenStationByName(ctx context.Context, name string) (repository.KitchenStation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKitchenStationByName", ctx, name)
+	ret0, _ := ret[0].(repository.KitchenStation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKitchenStationByName indicates an expected call of GetKitchenStationByName.
+func (mr *MockKitchenRepoMockRecorder) GetKitchenStationByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKitchenStationByName", reflect.TypeOf((*MockKitchenRepo)(nil).GetKitchenStationByName), ctx, name)
+}
+
+// GetOrderPrepSummary mocks base method.
+func (m *MockKitchenRepo) GetOrderPrepSummary(ctx context.Context, orderID uuid.UUID) (repository.GetOrderPrepSummaryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderPrepSummary", ctx, orderID)
+	ret0, _ := ret[0].(repository.GetOrderPrepSummaryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderPrepSummary indicates an expected call of GetOrderPrepSummary.
+func (mr *MockKitchenRepoMockRecorder) GetOrderPrepSummary(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderPrepSummary", reflect.TypeOf((*MockKitchenRepo)(nil).GetOrderPrepSummary), ctx, orderID)
+}
+
+// ListKitchenStations mocks base method.
+func (m *MockKitchenRepo) ListKitchenStations(ctx context.Context) ([]repository.ListKitchenStationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKitchenStations", ctx)
+	ret0, _ := ret[0].([]repository.ListKitchenStationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKitchenStations indicates an expected call of ListKitchenStations.
+func (mr *MockKitchenRepoMockRecorder) ListKitchenStations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKitchenStations", reflect.TypeOf((*MockKitchenRepo)(nil).ListKitchenStations), ctx)
+}
+
+// ListKitchenTicketItems mocks base method.
+func (m *MockKitchenRepo) ListKitchenTicketItems(ctx context.Context, arg repository.ListKitchenTicketItemsParams) ([]repository.ListKitchenTicketItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKitchenTicketItems", ctx, arg)
+	ret0, _ := ret[0].([]repository.ListKitchenTicketItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKitchenTicketItems indicates an expected call of ListKitchenTicketItems.
+func (mr *MockKitchenRepoMockRecorder) ListKitchenTicketItems(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKitchenTicketItems", reflect.TypeOf((*MockKitchenRepo)(nil).ListKitchenTicketItems), ctx, arg)
+}
+
+// SetOrderItemPriority mocks base method.
+func (m *MockKitchenRepo) SetOrderItemPriority(ctx context.Context, arg repository.SetOrderItemPriorityParams) (repository.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrderItemPriority", ctx, arg)
+	ret0, _ := ret[0].(repository.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOrderItemPriority indicates an expected call of SetOrderItemPriority.
+func (mr *MockKitchenRepoMockRecorder) SetOrderItemPriority(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrderItemPriority", reflect.TypeOf((*MockKitchenRepo)(nil).SetOrderItemPriority), ctx, arg)
+}
+
+// UpdateKitchenStation mocks base method.
+func (m *MockKitchenRepo) UpdateKitchenStation(ctx context.Context, arg repository.UpdateKitchenStationParams) (repository.KitchenStation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateKitchenStation", ctx, arg)
+	ret0, _ := ret[0].(repository.KitchenStation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateKitchenStation indicates an expected call of UpdateKitchenStation.
+func (mr *MockKitchenRepoMockRecorder) UpdateKitchenStation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKitchenStation", reflect.TypeOf((*MockKitchenRepo)(nil).UpdateKitchenStation), ctx, arg)
+}
+
+// UpdateOrderItemPrepStatus mocks base method.
+func (m *MockKitchenRepo) UpdateOrderItemPrepStatus(ctx context.Context, arg repository.UpdateOrderItemPrepStatusParams) (repository.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderItemPrepStatus", ctx, arg)
+	ret0, _ := ret[0].(repository.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderItemPrepStatus indicates an expected call of UpdateOrderItemPrepStatus.
+func (mr *MockKitchenRepoMockRecorder) UpdateOrderItemPrepStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderItemPrepStatus", reflect.TypeOf((*MockKitchenRepo)(nil).UpdateOrderItemPrepStatus), ctx, arg)
+}
@@ -0,0 +1,177 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: POS-kasir/internal/kitchen (interfaces: IKitchenService)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_kitchen_service.go POS-kasir/internal/kitchen IKitchenService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	kitchen "POS-kasir/internal/kitchen"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIKitchenService is a mock of IKitchenService interface.
+type MockIKitchenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIKitchenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIKitchenServiceMockRecorder is the mock recorder for MockIKitchenService.
+type MockIKitchenServiceMockRecorder struct {
+	mock *MockIKitchenService
+}
+
+// NewMockIKitchenService creates a new mock instance.
+func NewMockIKitchenService(ctrl *gomock.Controller) *MockIKitchenService {
+	mock := &MockIKitchenService{ctrl: ctrl}
+	mock.recorder = &MockIKitchenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIKitchenService) EXPECT() *MockIKitchenServiceMockRecorder {
+	return m.recorder
+}
+
+// BumpItem mocks base method.
+func (m *MockIKitchenService) BumpItem(ctx context.Context, itemID uuid.UUID) (*kitchen.KitchenItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BumpItem", ctx, itemID)
+	ret0, _ := ret[0].(*kitchen.KitchenItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BumpItem indicates an expected call of BumpItem.
+func (mr *MockIKitchenServiceMockRecorder) BumpItem(ctx, itemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BumpItem", reflect.TypeOf((*MockIKitchenService)(nil).BumpItem), ctx, itemID)
+}
+
+// CreateStation mocks base method.
+func (m *MockIKitchenService) CreateStation(ctx context.Context, req kitchen.CreateKitchenStationRequest) (*kitchen.KitchenStationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStation", ctx, req)
+	ret0, _ := ret[0].(*kitchen.KitchenStationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStation indicates an expected call of CreateStation.
+func (mr *MockIKitchenServiceMockRecorder) CreateStation(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStation", reflect.TypeOf((*MockIKitchenService)(nil).CreateStation), ctx, req)
+}
+
+// DeleteStation mocks base method.
+func (m *MockIKitchenService) DeleteStation(ctx context.Context, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStation", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStation indicates an expected call of DeleteStation.
+func (mr *MockIKitchenServiceMockRecorder) DeleteStation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStation", reflect.TypeOf((*MockIKitchenService)(nil).DeleteStation), ctx, id)
+}
+
+// GetStation mocks base method.
+func (m *MockIKitchenService) GetStation(ctx context.Context, id int32) (*kitchen.KitchenStationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStation", ctx, id)
+	ret0, _ := ret[0].(*kitchen.KitchenStationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStation indicates an expected call of GetStation.
+func (mr *MockIKitchenServiceMockRecorder) GetStation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStation", reflect.TypeOf((*MockIKitchenService)(nil).GetStation), ctx, id)
+}
+
+// ListStations mocks base method.
+func (m *MockIKitchenService) ListStations(ctx context.Context) ([]kitchen.KitchenStationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStations", ctx)
+	ret0, _ := ret[0].([]kitchen.KitchenStationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStations indicates an expected call of ListStations.
+func (mr *MockIKitchenServiceMockRecorder) ListStations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStations", reflect.TypeOf((*MockIKitchenService)(nil).ListStations), ctx)
+}
+
+// ListTickets mocks base method.
+func (m *MockIKitchenService) ListTickets(ctx context.Context, req kitchen.ListKitchenTicketsRequest) ([]kitchen.KitchenTicketResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTickets", ctx, req)
+	ret0, _ := ret[0].([]kitchen.KitchenTicketResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTickets indicates an expected call of ListTickets.
+func (mr *MockIKitchenServiceMockRecorder) ListTickets(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTickets", reflect.TypeOf((*MockIKitchenService)(nil).ListTickets), ctx, req)
+}
+
+// RecallItem mocks base method.
+func (m *MockIKitchenService) RecallItem(ctx context.Context, itemID uuid.UUID) (*kitchen.KitchenItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecallItem", ctx, itemID)
+	ret0, _ := ret[0].(*kitchen.KitchenItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecallItem indicates an expected call of RecallItem.
+func (mr *MockIKitchenServiceMockRecorder) RecallItem(ctx, itemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecallItem", reflect.TypeOf((*MockIKitchenService)(nil).RecallItem), ctx, itemID)
+}
+
+// SetItemPriority mocks base method.
+func (m *MockIKitchenService) SetItemPriority(ctx context.Context, itemID uuid.UUID, req kitchen.SetItemPriorityRequest) (*kitchen.KitchenItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemPriority", ctx, itemID, req)
+	ret0, _ := ret[0].(*kitchen.KitchenItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetItemPriority indicates an expected call of SetItemPriority.
+func (mr *MockIKitchenServiceMockRecorder) SetItemPriority(ctx, itemID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemPriority", reflect.TypeOf((*MockIKitchenService)(nil).SetItemPriority), ctx, itemID, req)
+}
+
+// UpdateStation mocks base method.
+func (m *MockIKitchenService) UpdateStation(ctx context.Context, id int32, req kitchen.UpdateKitchenStationRequest) (*kitchen.KitchenStationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStation", ctx, id, req)
+	ret0, _ := ret[0].(*kitchen.KitchenStationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStation indicates an expected call of UpdateStation.
+func (mr *MockIKitchenServiceMockRecorder) UpdateStation(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStation", reflect.TypeOf((*MockIKitchenService)(nil).UpdateStation), ctx, id, req)
+}
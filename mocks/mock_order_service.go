@@ -43,6 +43,21 @@ func (m *MockIOrderService) EXPECT() *MockIOrderServiceMockRecorder {
 	return m.recorder
 }
 
+// AddOrderPayment mocks base method.
+func (m *MockIOrderService) AddOrderPayment(ctx context.Context, orderID uuid.UUID, req orders.AddOrderPaymentRequest) (*orders.OrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrderPayment", ctx, orderID, req)
+	ret0, _ := ret[0].(*orders.OrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOrderPayment indicates an expected call of AddOrderPayment.
+func (mr *MockIOrderServiceMockRecorder) AddOrderPayment(ctx, orderID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrderPayment", reflect.TypeOf((*MockIOrderService)(nil).AddOrderPayment), ctx, orderID, req)
+}
+
 // ApplyPromotion mocks base method.
 func (m *MockIOrderService) ApplyPromotion(ctx context.Context, orderID uuid.UUID, req orders.ApplyPromotionRequest) (*orders.OrderDetailResponse, error) {
 	m.ctrl.T.Helper()
@@ -58,6 +73,21 @@ func (mr *MockIOrderServiceMockRecorder) ApplyPromotion(ctx, orderID, req any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPromotion", reflect.TypeOf((*MockIOrderService)(nil).ApplyPromotion), ctx, orderID, req)
 }
 
+// AutoApplyPromotions mocks base method.
+func (m *MockIOrderService) AutoApplyPromotions(ctx context.Context, orderID uuid.UUID) (*orders.OrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoApplyPromotions", ctx, orderID)
+	ret0, _ := ret[0].(*orders.OrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoApplyPromotions indicates an expected call of AutoApplyPromotions.
+func (mr *MockIOrderServiceMockRecorder) AutoApplyPromotions(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoApplyPromotions", reflect.TypeOf((*MockIOrderService)(nil).AutoApplyPromotions), ctx, orderID)
+}
+
 // CancelOrder mocks base method.
 func (m *MockIOrderService) CancelOrder(ctx context.Context, orderID uuid.UUID, req orders.CancelOrderRequest) error {
 	m.ctrl.T.Helper()
@@ -72,6 +102,21 @@ func (mr *MockIOrderServiceMockRecorder) CancelOrder(ctx, orderID, req any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockIOrderService)(nil).CancelOrder), ctx, orderID, req)
 }
 
+// CancelPointsRedemption mocks base method.
+func (m *MockIOrderService) CancelPointsRedemption(ctx context.Context, orderID uuid.UUID) (*orders.OrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPointsRedemption", ctx, orderID)
+	ret0, _ := ret[0].(*orders.OrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPointsRedemption indicates an expected call of CancelPointsRedemption.
+func (mr *MockIOrderServiceMockRecorder) CancelPointsRedemption(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPointsRedemption", reflect.TypeOf((*MockIOrderService)(nil).CancelPointsRedemption), ctx, orderID)
+}
+
 // ConfirmManualPayment mocks base method.
 func (m *MockIOrderService) ConfirmManualPayment(ctx context.Context, orderID uuid.UUID, req orders.ConfirmManualPaymentRequest) (*orders.OrderDetailResponse, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +147,36 @@ func (mr *MockIOrderServiceMockRecorder) CreateOrder(ctx, req any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockIOrderService)(nil).CreateOrder), ctx, req)
 }
 
+// EvaluatePromotions mocks base method.
+func (m *MockIOrderService) EvaluatePromotions(ctx context.Context, orderID uuid.UUID) (*orders.PromotionEvaluationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluatePromotions", ctx, orderID)
+	ret0, _ := ret[0].(*orders.PromotionEvaluationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EvaluatePromotions indicates an expected call of EvaluatePromotions.
+func (mr *MockIOrderServiceMockRecorder) EvaluatePromotions(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluatePromotions", reflect.TypeOf((*MockIOrderService)(nil).EvaluatePromotions), ctx, orderID)
+}
+
+// GetCallingBoard mocks base method.
+func (m *MockIOrderService) GetCallingBoard(ctx context.Context) (*orders.CallingBoardResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCallingBoard", ctx)
+	ret0, _ := ret[0].(*orders.CallingBoardResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCallingBoard indicates an expected call of GetCallingBoard.
+func (mr *MockIOrderServiceMockRecorder) GetCallingBoard(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCallingBoard", reflect.TypeOf((*MockIOrderService)(nil).GetCallingBoard), ctx)
+}
+
 // GetOrder mocks base method.
 func (m *MockIOrderService) GetOrder(ctx context.Context, orderID uuid.UUID) (*orders.OrderDetailResponse, error) {
 	m.ctrl.T.Helper()
@@ -161,6 +236,48 @@ func (mr *MockIOrderServiceMockRecorder) ListOrders(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockIOrderService)(nil).ListOrders), ctx, req)
 }
 
+// MergeOrders mocks base method.
+func (m *MockIOrderService) MergeOrders(ctx context.Context, targetOrderID uuid.UUID, req orders.MergeOrdersRequest) (*orders.OrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeOrders", ctx, targetOrderID, req)
+	ret0, _ := ret[0].(*orders.OrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeOrders indicates an expected call of MergeOrders.
+func (mr *MockIOrderServiceMockRecorder) MergeOrders(ctx, targetOrderID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeOrders", reflect.TypeOf((*MockIOrderService)(nil).MergeOrders), ctx, targetOrderID, req)
+}
+
+// PublishCallingBoard mocks base method.
+func (m *MockIOrderService) PublishCallingBoard(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishCallingBoard", ctx)
+}
+
+// PublishCallingBoard indicates an expected call of PublishCallingBoard.
+func (mr *MockIOrderServiceMockRecorder) PublishCallingBoard(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishCallingBoard", reflect.TypeOf((*MockIOrderService)(nil).PublishCallingBoard), ctx)
+}
+
+// RedeemPoints mocks base method.
+func (m *MockIOrderService) RedeemPoints(ctx context.Context, orderID uuid.UUID, req orders.RedeemPointsRequest) (*orders.OrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemPoints", ctx, orderID, req)
+	ret0, _ := ret[0].(*orders.OrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemPoints indicates an expected call of RedeemPoints.
+func (mr *MockIOrderServiceMockRecorder) RedeemPoints(ctx, orderID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemPoints", reflect.TypeOf((*MockIOrderService)(nil).RedeemPoints), ctx, orderID, req)
+}
+
 // RefundOrder mocks base method.
 func (m *MockIOrderService) RefundOrder(ctx context.Context, orderID uuid.UUID, req orders.RefundOrderRequest) (*orders.OrderDetailResponse, error) {
 	m.ctrl.T.Helper()
@@ -176,6 +293,51 @@ func (mr *MockIOrderServiceMockRecorder) RefundOrder(ctx, orderID, req any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundOrder", reflect.TypeOf((*MockIOrderService)(nil).RefundOrder), ctx, orderID, req)
 }
 
+// ReopenOrder mocks base method.
+func (m *MockIOrderService) ReopenOrder(ctx context.Context, orderID uuid.UUID, req orders.ReopenOrderRequest) (*orders.OrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenOrder", ctx, orderID, req)
+	ret0, _ := ret[0].(*orders.OrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenOrder indicates an expected call of ReopenOrder.
+func (mr *MockIOrderServiceMockRecorder) ReopenOrder(ctx, orderID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenOrder", reflect.TypeOf((*MockIOrderService)(nil).ReopenOrder), ctx, orderID, req)
+}
+
+// ScanOrderItem mocks base method.
+func (m *MockIOrderService) ScanOrderItem(ctx context.Context, orderID uuid.UUID, req orders.ScanOrderItemRequest) (*orders.OrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanOrderItem", ctx, orderID, req)
+	ret0, _ := ret[0].(*orders.OrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanOrderItem indicates an expected call of ScanOrderItem.
+func (mr *MockIOrderServiceMockRecorder) ScanOrderItem(ctx, orderID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanOrderItem", reflect.TypeOf((*MockIOrderService)(nil).ScanOrderItem), ctx, orderID, req)
+}
+
+// SplitOrder mocks base method.
+func (m *MockIOrderService) SplitOrder(ctx context.Context, orderID uuid.UUID, req orders.SplitOrderRequest) (*orders.SplitOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SplitOrder", ctx, orderID, req)
+	ret0, _ := ret[0].(*orders.SplitOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SplitOrder indicates an expected call of SplitOrder.
+func (mr *MockIOrderServiceMockRecorder) SplitOrder(ctx, orderID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitOrder", reflect.TypeOf((*MockIOrderService)(nil).SplitOrder), ctx, orderID, req)
+}
+
 // UpdateOperationalStatus mocks base method.
 func (m *MockIOrderService) UpdateOperationalStatus(ctx context.Context, orderID uuid.UUID, req orders.UpdateOrderStatusRequest) (*orders.OrderDetailResponse, error) {
 	m.ctrl.T.Helper()
@@ -15,6 +15,7 @@ import (
 	reflect "reflect"
 
 	uuid "github.com/google/uuid"
+	pgtype "github.com/jackc/pgx/v5/pgtype"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -57,6 +58,35 @@ func (mr *MockOrderQuerierMockRecorder) AddProductStock(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductStock", reflect.TypeOf((*MockOrderQuerier)(nil).AddProductStock), ctx, arg)
 }
 
+// AddVariantStock mocks base method.
+func (m *MockOrderQuerier) AddVariantStock(ctx context.Context, arg repository.AddVariantStockParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVariantStock", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddVariantStock indicates an expected call of AddVariantStock.
+func (mr *MockOrderQuerierMockRecorder) AddVariantStock(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVariantStock", reflect.TypeOf((*MockOrderQuerier)(nil).AddVariantStock), ctx, arg)
+}
+
+// AdjustIngredientStock mocks base method.
+func (m *MockOrderQuerier) AdjustIngredientStock(ctx context.Context, arg repository.AdjustIngredientStockParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustIngredientStock", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustIngredientStock indicates an expected call of AdjustIngredientStock.
+func (mr *MockOrderQuerierMockRecorder) AdjustIngredientStock(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustIngredientStock", reflect.TypeOf((*MockOrderQuerier)(nil).AdjustIngredientStock), ctx, arg)
+}
+
 // BatchCreateOrderItemOptions mocks base method.
 func (m *MockOrderQuerier) BatchCreateOrderItemOptions(ctx context.Context, arg []repository.BatchCreateOrderItemOptionsParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +131,21 @@ func (mr *MockOrderQuerierMockRecorder) BatchDecreaseProductStock(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDecreaseProductStock", reflect.TypeOf((*MockOrderQuerier)(nil).BatchDecreaseProductStock), ctx, arg)
 }
 
+// BumpOrderVersion mocks base method.
+func (m *MockOrderQuerier) BumpOrderVersion(ctx context.Context, arg repository.BumpOrderVersionParams) (repository.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BumpOrderVersion", ctx, arg)
+	ret0, _ := ret[0].(repository.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BumpOrderVersion indicates an expected call of BumpOrderVersion.
+func (mr *MockOrderQuerierMockRecorder) BumpOrderVersion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BumpOrderVersion", reflect.TypeOf((*MockOrderQuerier)(nil).BumpOrderVersion), ctx, arg)
+}
+
 // CancelOrder mocks base method.
 func (m *MockOrderQuerier) CancelOrder(ctx context.Context, arg repository.CancelOrderParams) (repository.Order, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +161,48 @@ func (mr *MockOrderQuerierMockRecorder) CancelOrder(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderQuerier)(nil).CancelOrder), ctx, arg)
 }
 
+// ClearOrderPaymentGateway mocks base method.
+func (m *MockOrderQuerier) ClearOrderPaymentGateway(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearOrderPaymentGateway", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearOrderPaymentGateway indicates an expected call of ClearOrderPaymentGateway.
+func (mr *MockOrderQuerierMockRecorder) ClearOrderPaymentGateway(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearOrderPaymentGateway", reflect.TypeOf((*MockOrderQuerier)(nil).ClearOrderPaymentGateway), ctx, id)
+}
+
+// ConsumePointLot mocks base method.
+func (m *MockOrderQuerier) ConsumePointLot(ctx context.Context, arg repository.ConsumePointLotParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumePointLot", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumePointLot indicates an expected call of ConsumePointLot.
+func (mr *MockOrderQuerierMockRecorder) ConsumePointLot(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumePointLot", reflect.TypeOf((*MockOrderQuerier)(nil).ConsumePointLot), ctx, arg)
+}
+
+// CopyOrderItemOptions mocks base method.
+func (m *MockOrderQuerier) CopyOrderItemOptions(ctx context.Context, arg repository.CopyOrderItemOptionsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyOrderItemOptions", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyOrderItemOptions indicates an expected call of CopyOrderItemOptions.
+func (mr *MockOrderQuerierMockRecorder) CopyOrderItemOptions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyOrderItemOptions", reflect.TypeOf((*MockOrderQuerier)(nil).CopyOrderItemOptions), ctx, arg)
+}
+
 // CountOrders mocks base method.
 func (m *MockOrderQuerier) CountOrders(ctx context.Context, arg repository.CountOrdersParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +218,36 @@ func (mr *MockOrderQuerierMockRecorder) CountOrders(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOrders", reflect.TypeOf((*MockOrderQuerier)(nil).CountOrders), ctx, arg)
 }
 
+// CreateIngredientStockHistory mocks base method.
+func (m *MockOrderQuerier) CreateIngredientStockHistory(ctx context.Context, arg repository.CreateIngredientStockHistoryParams) (repository.IngredientStockHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngredientStockHistory", ctx, arg)
+	ret0, _ := ret[0].(repository.IngredientStockHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngredientStockHistory indicates an expected call of CreateIngredientStockHistory.
+func (mr *MockOrderQuerierMockRecorder) CreateIngredientStockHistory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredientStockHistory", reflect.TypeOf((*MockOrderQuerier)(nil).CreateIngredientStockHistory), ctx, arg)
+}
+
+// CreateLoyaltyPointEntry mocks base method.
+func (m *MockOrderQuerier) CreateLoyaltyPointEntry(ctx context.Context, arg repository.CreateLoyaltyPointEntryParams) (repository.LoyaltyPointEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoyaltyPointEntry", ctx, arg)
+	ret0, _ := ret[0].(repository.LoyaltyPointEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLoyaltyPointEntry indicates an expected call of CreateLoyaltyPointEntry.
+func (mr *MockOrderQuerierMockRecorder) CreateLoyaltyPointEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoyaltyPointEntry", reflect.TypeOf((*MockOrderQuerier)(nil).CreateLoyaltyPointEntry), ctx, arg)
+}
+
 // CreateOrder mocks base method.
 func (m *MockOrderQuerier) CreateOrder(ctx context.Context, arg repository.CreateOrderParams) (repository.Order, error) {
 	m.ctrl.T.Helper()
@@ -176,6 +293,108 @@ func (mr *MockOrderQuerierMockRecorder) CreateOrderItemOption(ctx, arg any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItemOption", reflect.TypeOf((*MockOrderQuerier)(nil).CreateOrderItemOption), ctx, arg)
 }
 
+// CreateOrderPayment mocks base method.
+func (m *MockOrderQuerier) CreateOrderPayment(ctx context.Context, arg repository.CreateOrderPaymentParams) (repository.OrderPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderPayment", ctx, arg)
+	ret0, _ := ret[0].(repository.OrderPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderPayment indicates an expected call of CreateOrderPayment.
+func (mr *MockOrderQuerierMockRecorder) CreateOrderPayment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderPayment", reflect.TypeOf((*MockOrderQuerier)(nil).CreateOrderPayment), ctx, arg)
+}
+
+// CreateOrderPromotion mocks base method.
+func (m *MockOrderQuerier) CreateOrderPromotion(ctx context.Context, arg repository.CreateOrderPromotionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderPromotion", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrderPromotion indicates an expected call of CreateOrderPromotion.
+func (mr *MockOrderQuerierMockRecorder) CreateOrderPromotion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderPromotion", reflect.TypeOf((*MockOrderQuerier)(nil).CreateOrderPromotion), ctx, arg)
+}
+
+// CreateOrderRefund mocks base method.
+func (m *MockOrderQuerier) CreateOrderRefund(ctx context.Context, arg repository.CreateOrderRefundParams) (repository.OrderRefund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderRefund", ctx, arg)
+	ret0, _ := ret[0].(repository.OrderRefund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderRefund indicates an expected call of CreateOrderRefund.
+func (mr *MockOrderQuerierMockRecorder) CreateOrderRefund(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderRefund", reflect.TypeOf((*MockOrderQuerier)(nil).CreateOrderRefund), ctx, arg)
+}
+
+// CreateOrderRefundItem mocks base method.
+func (m *MockOrderQuerier) CreateOrderRefundItem(ctx context.Context, arg repository.CreateOrderRefundItemParams) (repository.OrderRefundItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderRefundItem", ctx, arg)
+	ret0, _ := ret[0].(repository.OrderRefundItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderRefundItem indicates an expected call of CreateOrderRefundItem.
+func (mr *MockOrderQuerierMockRecorder) CreateOrderRefundItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderRefundItem", reflect.TypeOf((*MockOrderQuerier)(nil).CreateOrderRefundItem), ctx, arg)
+}
+
+// CreateOrderStatusHistory mocks base method.
+func (m *MockOrderQuerier) CreateOrderStatusHistory(ctx context.Context, arg repository.CreateOrderStatusHistoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderStatusHistory", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrderStatusHistory indicates an expected call of CreateOrderStatusHistory.
+func (mr *MockOrderQuerierMockRecorder) CreateOrderStatusHistory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderStatusHistory", reflect.TypeOf((*MockOrderQuerier)(nil).CreateOrderStatusHistory), ctx, arg)
+}
+
+// CreatePromotionRedemption mocks base method.
+func (m *MockOrderQuerier) CreatePromotionRedemption(ctx context.Context, arg repository.CreatePromotionRedemptionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotionRedemption", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePromotionRedemption indicates an expected call of CreatePromotionRedemption.
+func (mr *MockOrderQuerierMockRecorder) CreatePromotionRedemption(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotionRedemption", reflect.TypeOf((*MockOrderQuerier)(nil).CreatePromotionRedemption), ctx, arg)
+}
+
+// CreateSplitOrder mocks base method.
+func (m *MockOrderQuerier) CreateSplitOrder(ctx context.Context, id uuid.UUID) (repository.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSplitOrder", ctx, id)
+	ret0, _ := ret[0].(repository.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSplitOrder indicates an expected call of CreateSplitOrder.
+func (mr *MockOrderQuerierMockRecorder) CreateSplitOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSplitOrder", reflect.TypeOf((*MockOrderQuerier)(nil).CreateSplitOrder), ctx, id)
+}
+
 // CreateStockHistory mocks base method.
 func (m *MockOrderQuerier) CreateStockHistory(ctx context.Context, arg repository.CreateStockHistoryParams) (repository.StockHistory, error) {
 	m.ctrl.T.Helper()
@@ -206,6 +425,21 @@ func (mr *MockOrderQuerierMockRecorder) DecreaseProductStock(ctx, arg any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseProductStock", reflect.TypeOf((*MockOrderQuerier)(nil).DecreaseProductStock), ctx, arg)
 }
 
+// DecreaseVariantStock mocks base method.
+func (m *MockOrderQuerier) DecreaseVariantStock(ctx context.Context, arg repository.DecreaseVariantStockParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecreaseVariantStock", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecreaseVariantStock indicates an expected call of DecreaseVariantStock.
+func (mr *MockOrderQuerierMockRecorder) DecreaseVariantStock(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseVariantStock", reflect.TypeOf((*MockOrderQuerier)(nil).DecreaseVariantStock), ctx, arg)
+}
+
 // DeleteOrderItem mocks base method.
 func (m *MockOrderQuerier) DeleteOrderItem(ctx context.Context, arg repository.DeleteOrderItemParams) error {
 	m.ctrl.T.Helper()
@@ -248,6 +482,110 @@ func (mr *MockOrderQuerierMockRecorder) DeleteOrderItemsByOrderID(ctx, orderID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderItemsByOrderID", reflect.TypeOf((*MockOrderQuerier)(nil).DeleteOrderItemsByOrderID), ctx, orderID)
 }
 
+// DeleteOrderPromotions mocks base method.
+func (m *MockOrderQuerier) DeleteOrderPromotions(ctx context.Context, orderID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrderPromotions", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrderPromotions indicates an expected call of DeleteOrderPromotions.
+func (mr *MockOrderQuerierMockRecorder) DeleteOrderPromotions(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderPromotions", reflect.TypeOf((*MockOrderQuerier)(nil).DeleteOrderPromotions), ctx, orderID)
+}
+
+// GetActivePaymentMethodByKind mocks base method.
+func (m *MockOrderQuerier) GetActivePaymentMethodByKind(ctx context.Context, kind repository.PaymentMethodKind) (repository.PaymentMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivePaymentMethodByKind", ctx, kind)
+	ret0, _ := ret[0].(repository.PaymentMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivePaymentMethodByKind indicates an expected call of GetActivePaymentMethodByKind.
+func (mr *MockOrderQuerierMockRecorder) GetActivePaymentMethodByKind(ctx, kind any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePaymentMethodByKind", reflect.TypeOf((*MockOrderQuerier)(nil).GetActivePaymentMethodByKind), ctx, kind)
+}
+
+// GetActivePromotionCode mocks base method.
+func (m *MockOrderQuerier) GetActivePromotionCode(ctx context.Context, code string) (repository.PromotionCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivePromotionCode", ctx, code)
+	ret0, _ := ret[0].(repository.PromotionCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivePromotionCode indicates an expected call of GetActivePromotionCode.
+func (mr *MockOrderQuerierMockRecorder) GetActivePromotionCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePromotionCode", reflect.TypeOf((*MockOrderQuerier)(nil).GetActivePromotionCode), ctx, code)
+}
+
+// GetCancellationReasonByReason mocks base method.
+func (m *MockOrderQuerier) GetCancellationReasonByReason(ctx context.Context, reason string) (repository.CancellationReason, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCancellationReasonByReason", ctx, reason)
+	ret0, _ := ret[0].(repository.CancellationReason)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCancellationReasonByReason indicates an expected call of GetCancellationReasonByReason.
+func (mr *MockOrderQuerierMockRecorder) GetCancellationReasonByReason(ctx, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCancellationReasonByReason", reflect.TypeOf((*MockOrderQuerier)(nil).GetCancellationReasonByReason), ctx, reason)
+}
+
+// GetCustomerPointsBalance mocks base method.
+func (m *MockOrderQuerier) GetCustomerPointsBalance(ctx context.Context, customerID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerPointsBalance", ctx, customerID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerPointsBalance indicates an expected call of GetCustomerPointsBalance.
+func (mr *MockOrderQuerierMockRecorder) GetCustomerPointsBalance(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerPointsBalance", reflect.TypeOf((*MockOrderQuerier)(nil).GetCustomerPointsBalance), ctx, customerID)
+}
+
+// GetIngredientsForUpdate mocks base method.
+func (m *MockOrderQuerier) GetIngredientsForUpdate(ctx context.Context, ids []uuid.UUID) ([]repository.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredientsForUpdate", ctx, ids)
+	ret0, _ := ret[0].([]repository.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredientsForUpdate indicates an expected call of GetIngredientsForUpdate.
+func (mr *MockOrderQuerierMockRecorder) GetIngredientsForUpdate(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredientsForUpdate", reflect.TypeOf((*MockOrderQuerier)(nil).GetIngredientsForUpdate), ctx, ids)
+}
+
+// GetOptionRecipes mocks base method.
+func (m *MockOrderQuerier) GetOptionRecipes(ctx context.Context, optionIds []uuid.UUID) ([]repository.ProductOptionRecipeItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptionRecipes", ctx, optionIds)
+	ret0, _ := ret[0].([]repository.ProductOptionRecipeItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptionRecipes indicates an expected call of GetOptionRecipes.
+func (mr *MockOrderQuerierMockRecorder) GetOptionRecipes(ctx, optionIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptionRecipes", reflect.TypeOf((*MockOrderQuerier)(nil).GetOptionRecipes), ctx, optionIds)
+}
+
 // GetOptionsForProducts mocks base method.
 func (m *MockOrderQuerier) GetOptionsForProducts(ctx context.Context, dollar_1 []uuid.UUID) ([]repository.ProductOption, error) {
 	m.ctrl.T.Helper()
@@ -263,6 +601,21 @@ func (mr *MockOrderQuerierMockRecorder) GetOptionsForProducts(ctx, dollar_1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptionsForProducts", reflect.TypeOf((*MockOrderQuerier)(nil).GetOptionsForProducts), ctx, dollar_1)
 }
 
+// GetOrder mocks base method.
+func (m *MockOrderQuerier) GetOrder(ctx context.Context, id uuid.UUID) (repository.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, id)
+	ret0, _ := ret[0].(repository.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderQuerierMockRecorder) GetOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderQuerier)(nil).GetOrder), ctx, id)
+}
+
 // GetOrderByGatewayRef mocks base method.
 func (m *MockOrderQuerier) GetOrderByGatewayRef(ctx context.Context, paymentGatewayReference *string) (repository.Order, error) {
 	m.ctrl.T.Helper()
@@ -323,6 +676,36 @@ func (mr *MockOrderQuerierMockRecorder) GetOrderItemsByOrderID(ctx, orderID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemsByOrderID", reflect.TypeOf((*MockOrderQuerier)(nil).GetOrderItemsByOrderID), ctx, orderID)
 }
 
+// GetOrderLoyaltySummary mocks base method.
+func (m *MockOrderQuerier) GetOrderLoyaltySummary(ctx context.Context, orderID pgtype.UUID) (repository.GetOrderLoyaltySummaryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderLoyaltySummary", ctx, orderID)
+	ret0, _ := ret[0].(repository.GetOrderLoyaltySummaryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderLoyaltySummary indicates an expected call of GetOrderLoyaltySummary.
+func (mr *MockOrderQuerierMockRecorder) GetOrderLoyaltySummary(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderLoyaltySummary", reflect.TypeOf((*MockOrderQuerier)(nil).GetOrderLoyaltySummary), ctx, orderID)
+}
+
+// GetOrderPromotions mocks base method.
+func (m *MockOrderQuerier) GetOrderPromotions(ctx context.Context, orderID uuid.UUID) ([]repository.GetOrderPromotionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderPromotions", ctx, orderID)
+	ret0, _ := ret[0].([]repository.GetOrderPromotionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderPromotions indicates an expected call of GetOrderPromotions.
+func (mr *MockOrderQuerierMockRecorder) GetOrderPromotions(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderPromotions", reflect.TypeOf((*MockOrderQuerier)(nil).GetOrderPromotions), ctx, orderID)
+}
+
 // GetOrderWithDetails mocks base method.
 func (m *MockOrderQuerier) GetOrderWithDetails(ctx context.Context, id uuid.UUID) (repository.GetOrderWithDetailsRow, error) {
 	m.ctrl.T.Helper()
@@ -338,6 +721,51 @@ func (mr *MockOrderQuerierMockRecorder) GetOrderWithDetails(ctx, id any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderWithDetails", reflect.TypeOf((*MockOrderQuerier)(nil).GetOrderWithDetails), ctx, id)
 }
 
+// GetOrderableOptions mocks base method.
+func (m *MockOrderQuerier) GetOrderableOptions(ctx context.Context, productIds []uuid.UUID) ([]repository.GetOrderableOptionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderableOptions", ctx, productIds)
+	ret0, _ := ret[0].([]repository.GetOrderableOptionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderableOptions indicates an expected call of GetOrderableOptions.
+func (mr *MockOrderQuerierMockRecorder) GetOrderableOptions(ctx, productIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderableOptions", reflect.TypeOf((*MockOrderQuerier)(nil).GetOrderableOptions), ctx, productIds)
+}
+
+// GetPaymentMethodByID mocks base method.
+func (m *MockOrderQuerier) GetPaymentMethodByID(ctx context.Context, id int32) (repository.PaymentMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentMethodByID", ctx, id)
+	ret0, _ := ret[0].(repository.PaymentMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentMethodByID indicates an expected call of GetPaymentMethodByID.
+func (mr *MockOrderQuerierMockRecorder) GetPaymentMethodByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentMethodByID", reflect.TypeOf((*MockOrderQuerier)(nil).GetPaymentMethodByID), ctx, id)
+}
+
+// GetProductByCode mocks base method.
+func (m *MockOrderQuerier) GetProductByCode(ctx context.Context, code string) (repository.GetProductByCodeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductByCode", ctx, code)
+	ret0, _ := ret[0].(repository.GetProductByCodeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductByCode indicates an expected call of GetProductByCode.
+func (mr *MockOrderQuerierMockRecorder) GetProductByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByCode", reflect.TypeOf((*MockOrderQuerier)(nil).GetProductByCode), ctx, code)
+}
+
 // GetProductByID mocks base method.
 func (m *MockOrderQuerier) GetProductByID(ctx context.Context, id uuid.UUID) (repository.Product, error) {
 	m.ctrl.T.Helper()
@@ -353,6 +781,21 @@ func (mr *MockOrderQuerierMockRecorder) GetProductByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockOrderQuerier)(nil).GetProductByID), ctx, id)
 }
 
+// GetProductCategoryIDs mocks base method.
+func (m *MockOrderQuerier) GetProductCategoryIDs(ctx context.Context, productIds []uuid.UUID) ([]repository.GetProductCategoryIDsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductCategoryIDs", ctx, productIds)
+	ret0, _ := ret[0].([]repository.GetProductCategoryIDsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductCategoryIDs indicates an expected call of GetProductCategoryIDs.
+func (mr *MockOrderQuerierMockRecorder) GetProductCategoryIDs(ctx, productIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductCategoryIDs", reflect.TypeOf((*MockOrderQuerier)(nil).GetProductCategoryIDs), ctx, productIds)
+}
+
 // GetProductOptionsByIDs mocks base method.
 func (m *MockOrderQuerier) GetProductOptionsByIDs(ctx context.Context, ids []uuid.UUID) ([]repository.ProductOption, error) {
 	m.ctrl.T.Helper()
@@ -368,6 +811,21 @@ func (mr *MockOrderQuerierMockRecorder) GetProductOptionsByIDs(ctx, ids any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductOptionsByIDs", reflect.TypeOf((*MockOrderQuerier)(nil).GetProductOptionsByIDs), ctx, ids)
 }
 
+// GetProductRecipes mocks base method.
+func (m *MockOrderQuerier) GetProductRecipes(ctx context.Context, productIds []uuid.UUID) ([]repository.ProductRecipeItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductRecipes", ctx, productIds)
+	ret0, _ := ret[0].([]repository.ProductRecipeItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductRecipes indicates an expected call of GetProductRecipes.
+func (mr *MockOrderQuerierMockRecorder) GetProductRecipes(ctx, productIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductRecipes", reflect.TypeOf((*MockOrderQuerier)(nil).GetProductRecipes), ctx, productIds)
+}
+
 // GetProductsByIDs mocks base method.
 func (m *MockOrderQuerier) GetProductsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([]repository.Product, error) {
 	m.ctrl.T.Helper()
@@ -413,6 +871,51 @@ func (mr *MockOrderQuerierMockRecorder) GetPromotionByID(ctx, id any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionByID", reflect.TypeOf((*MockOrderQuerier)(nil).GetPromotionByID), ctx, id)
 }
 
+// GetPromotionCodeByID mocks base method.
+func (m *MockOrderQuerier) GetPromotionCodeByID(ctx context.Context, id uuid.UUID) (repository.PromotionCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotionCodeByID", ctx, id)
+	ret0, _ := ret[0].(repository.PromotionCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotionCodeByID indicates an expected call of GetPromotionCodeByID.
+func (mr *MockOrderQuerierMockRecorder) GetPromotionCodeByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionCodeByID", reflect.TypeOf((*MockOrderQuerier)(nil).GetPromotionCodeByID), ctx, id)
+}
+
+// GetPromotionExcludedDates mocks base method.
+func (m *MockOrderQuerier) GetPromotionExcludedDates(ctx context.Context, promotionID uuid.UUID) ([]repository.PromotionExcludedDate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotionExcludedDates", ctx, promotionID)
+	ret0, _ := ret[0].([]repository.PromotionExcludedDate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotionExcludedDates indicates an expected call of GetPromotionExcludedDates.
+func (mr *MockOrderQuerierMockRecorder) GetPromotionExcludedDates(ctx, promotionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionExcludedDates", reflect.TypeOf((*MockOrderQuerier)(nil).GetPromotionExcludedDates), ctx, promotionID)
+}
+
+// GetPromotionRedemptionStats mocks base method.
+func (m *MockOrderQuerier) GetPromotionRedemptionStats(ctx context.Context, arg repository.GetPromotionRedemptionStatsParams) (repository.GetPromotionRedemptionStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotionRedemptionStats", ctx, arg)
+	ret0, _ := ret[0].(repository.GetPromotionRedemptionStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotionRedemptionStats indicates an expected call of GetPromotionRedemptionStats.
+func (mr *MockOrderQuerierMockRecorder) GetPromotionRedemptionStats(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionRedemptionStats", reflect.TypeOf((*MockOrderQuerier)(nil).GetPromotionRedemptionStats), ctx, arg)
+}
+
 // GetPromotionRules mocks base method.
 func (m *MockOrderQuerier) GetPromotionRules(ctx context.Context, promotionID uuid.UUID) ([]repository.PromotionRule, error) {
 	m.ctrl.T.Helper()
@@ -428,6 +931,21 @@ func (mr *MockOrderQuerierMockRecorder) GetPromotionRules(ctx, promotionID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionRules", reflect.TypeOf((*MockOrderQuerier)(nil).GetPromotionRules), ctx, promotionID)
 }
 
+// GetPromotionSchedules mocks base method.
+func (m *MockOrderQuerier) GetPromotionSchedules(ctx context.Context, promotionID uuid.UUID) ([]repository.PromotionSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotionSchedules", ctx, promotionID)
+	ret0, _ := ret[0].([]repository.PromotionSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotionSchedules indicates an expected call of GetPromotionSchedules.
+func (mr *MockOrderQuerierMockRecorder) GetPromotionSchedules(ctx, promotionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionSchedules", reflect.TypeOf((*MockOrderQuerier)(nil).GetPromotionSchedules), ctx, promotionID)
+}
+
 // GetPromotionTargets mocks base method.
 func (m *MockOrderQuerier) GetPromotionTargets(ctx context.Context, promotionID uuid.UUID) ([]repository.PromotionTarget, error) {
 	m.ctrl.T.Helper()
@@ -443,6 +961,141 @@ func (mr *MockOrderQuerierMockRecorder) GetPromotionTargets(ctx, promotionID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionTargets", reflect.TypeOf((*MockOrderQuerier)(nil).GetPromotionTargets), ctx, promotionID)
 }
 
+// GetPromotionTiers mocks base method.
+func (m *MockOrderQuerier) GetPromotionTiers(ctx context.Context, promotionID uuid.UUID) ([]repository.PromotionTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotionTiers", ctx, promotionID)
+	ret0, _ := ret[0].([]repository.PromotionTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotionTiers indicates an expected call of GetPromotionTiers.
+func (mr *MockOrderQuerierMockRecorder) GetPromotionTiers(ctx, promotionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionTiers", reflect.TypeOf((*MockOrderQuerier)(nil).GetPromotionTiers), ctx, promotionID)
+}
+
+// GetRefundedItemQuantities mocks base method.
+func (m *MockOrderQuerier) GetRefundedItemQuantities(ctx context.Context, orderID uuid.UUID) ([]repository.GetRefundedItemQuantitiesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefundedItemQuantities", ctx, orderID)
+	ret0, _ := ret[0].([]repository.GetRefundedItemQuantitiesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefundedItemQuantities indicates an expected call of GetRefundedItemQuantities.
+func (mr *MockOrderQuerierMockRecorder) GetRefundedItemQuantities(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefundedItemQuantities", reflect.TypeOf((*MockOrderQuerier)(nil).GetRefundedItemQuantities), ctx, orderID)
+}
+
+// GetVariantsForOrder mocks base method.
+func (m *MockOrderQuerier) GetVariantsForOrder(ctx context.Context, productIds []uuid.UUID) ([]repository.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantsForOrder", ctx, productIds)
+	ret0, _ := ret[0].([]repository.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantsForOrder indicates an expected call of GetVariantsForOrder.
+func (mr *MockOrderQuerierMockRecorder) GetVariantsForOrder(ctx, productIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantsForOrder", reflect.TypeOf((*MockOrderQuerier)(nil).GetVariantsForOrder), ctx, productIds)
+}
+
+// ListActivePromotions mocks base method.
+func (m *MockOrderQuerier) ListActivePromotions(ctx context.Context) ([]repository.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivePromotions", ctx)
+	ret0, _ := ret[0].([]repository.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActivePromotions indicates an expected call of ListActivePromotions.
+func (mr *MockOrderQuerierMockRecorder) ListActivePromotions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivePromotions", reflect.TypeOf((*MockOrderQuerier)(nil).ListActivePromotions), ctx)
+}
+
+// ListCallingBoardOrders mocks base method.
+func (m *MockOrderQuerier) ListCallingBoardOrders(ctx context.Context, businessDate pgtype.Date) ([]repository.ListCallingBoardOrdersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCallingBoardOrders", ctx, businessDate)
+	ret0, _ := ret[0].([]repository.ListCallingBoardOrdersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCallingBoardOrders indicates an expected call of ListCallingBoardOrders.
+func (mr *MockOrderQuerierMockRecorder) ListCallingBoardOrders(ctx, businessDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCallingBoardOrders", reflect.TypeOf((*MockOrderQuerier)(nil).ListCallingBoardOrders), ctx, businessDate)
+}
+
+// ListCustomerPointLots mocks base method.
+func (m *MockOrderQuerier) ListCustomerPointLots(ctx context.Context, customerID uuid.UUID) ([]repository.LoyaltyPointEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomerPointLots", ctx, customerID)
+	ret0, _ := ret[0].([]repository.LoyaltyPointEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomerPointLots indicates an expected call of ListCustomerPointLots.
+func (mr *MockOrderQuerierMockRecorder) ListCustomerPointLots(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomerPointLots", reflect.TypeOf((*MockOrderQuerier)(nil).ListCustomerPointLots), ctx, customerID)
+}
+
+// ListOrderItemOptionIDs mocks base method.
+func (m *MockOrderQuerier) ListOrderItemOptionIDs(ctx context.Context, orderID uuid.UUID) ([]repository.ListOrderItemOptionIDsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderItemOptionIDs", ctx, orderID)
+	ret0, _ := ret[0].([]repository.ListOrderItemOptionIDsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderItemOptionIDs indicates an expected call of ListOrderItemOptionIDs.
+func (mr *MockOrderQuerierMockRecorder) ListOrderItemOptionIDs(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderItemOptionIDs", reflect.TypeOf((*MockOrderQuerier)(nil).ListOrderItemOptionIDs), ctx, orderID)
+}
+
+// ListOrderPayments mocks base method.
+func (m *MockOrderQuerier) ListOrderPayments(ctx context.Context, orderID uuid.UUID) ([]repository.OrderPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderPayments", ctx, orderID)
+	ret0, _ := ret[0].([]repository.OrderPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderPayments indicates an expected call of ListOrderPayments.
+func (mr *MockOrderQuerierMockRecorder) ListOrderPayments(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderPayments", reflect.TypeOf((*MockOrderQuerier)(nil).ListOrderPayments), ctx, orderID)
+}
+
+// ListOrderRefunds mocks base method.
+func (m *MockOrderQuerier) ListOrderRefunds(ctx context.Context, orderID uuid.UUID) ([]repository.OrderRefund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderRefunds", ctx, orderID)
+	ret0, _ := ret[0].([]repository.OrderRefund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderRefunds indicates an expected call of ListOrderRefunds.
+func (mr *MockOrderQuerierMockRecorder) ListOrderRefunds(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderRefunds", reflect.TypeOf((*MockOrderQuerier)(nil).ListOrderRefunds), ctx, orderID)
+}
+
 // ListOrders mocks base method.
 func (m *MockOrderQuerier) ListOrders(ctx context.Context, arg repository.ListOrdersParams) ([]repository.ListOrdersRow, error) {
 	m.ctrl.T.Helper()
@@ -458,6 +1111,80 @@ func (mr *MockOrderQuerierMockRecorder) ListOrders(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrderQuerier)(nil).ListOrders), ctx, arg)
 }
 
+// LockCustomer mocks base method.
+func (m *MockOrderQuerier) LockCustomer(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCustomer", ctx, id)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockCustomer indicates an expected call of LockCustomer.
+func (mr *MockOrderQuerierMockRecorder) LockCustomer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCustomer", reflect.TypeOf((*MockOrderQuerier)(nil).LockCustomer), ctx, id)
+}
+
+// LockPromotion mocks base method.
+func (m *MockOrderQuerier) LockPromotion(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPromotion", ctx, id)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPromotion indicates an expected call of LockPromotion.
+func (mr *MockOrderQuerierMockRecorder) LockPromotion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPromotion", reflect.TypeOf((*MockOrderQuerier)(nil).LockPromotion), ctx, id)
+}
+
+// MoveOrderItem mocks base method.
+func (m *MockOrderQuerier) MoveOrderItem(ctx context.Context, arg repository.MoveOrderItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveOrderItem", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveOrderItem indicates an expected call of MoveOrderItem.
+func (mr *MockOrderQuerierMockRecorder) MoveOrderItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveOrderItem", reflect.TypeOf((*MockOrderQuerier)(nil).MoveOrderItem), ctx, arg)
+}
+
+// NextQueueNumber mocks base method.
+func (m *MockOrderQuerier) NextQueueNumber(ctx context.Context, businessDate pgtype.Date) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextQueueNumber", ctx, businessDate)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextQueueNumber indicates an expected call of NextQueueNumber.
+func (mr *MockOrderQuerierMockRecorder) NextQueueNumber(ctx, businessDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextQueueNumber", reflect.TypeOf((*MockOrderQuerier)(nil).NextQueueNumber), ctx, businessDate)
+}
+
+// PromotionHasCodes mocks base method.
+func (m *MockOrderQuerier) PromotionHasCodes(ctx context.Context, promotionID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromotionHasCodes", ctx, promotionID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromotionHasCodes indicates an expected call of PromotionHasCodes.
+func (mr *MockOrderQuerierMockRecorder) PromotionHasCodes(ctx, promotionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromotionHasCodes", reflect.TypeOf((*MockOrderQuerier)(nil).PromotionHasCodes), ctx, promotionID)
+}
+
 // RefundOrder mocks base method.
 func (m *MockOrderQuerier) RefundOrder(ctx context.Context, id uuid.UUID) (repository.Order, error) {
 	m.ctrl.T.Helper()
@@ -473,6 +1200,48 @@ func (mr *MockOrderQuerierMockRecorder) RefundOrder(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundOrder", reflect.TypeOf((*MockOrderQuerier)(nil).RefundOrder), ctx, id)
 }
 
+// ReleasePromotionRedemptions mocks base method.
+func (m *MockOrderQuerier) ReleasePromotionRedemptions(ctx context.Context, orderID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleasePromotionRedemptions", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleasePromotionRedemptions indicates an expected call of ReleasePromotionRedemptions.
+func (mr *MockOrderQuerierMockRecorder) ReleasePromotionRedemptions(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleasePromotionRedemptions", reflect.TypeOf((*MockOrderQuerier)(nil).ReleasePromotionRedemptions), ctx, orderID)
+}
+
+// SetOrderItemCost mocks base method.
+func (m *MockOrderQuerier) SetOrderItemCost(ctx context.Context, arg repository.SetOrderItemCostParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrderItemCost", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOrderItemCost indicates an expected call of SetOrderItemCost.
+func (mr *MockOrderQuerierMockRecorder) SetOrderItemCost(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrderItemCost", reflect.TypeOf((*MockOrderQuerier)(nil).SetOrderItemCost), ctx, arg)
+}
+
+// SetOrderItemDiscounts mocks base method.
+func (m *MockOrderQuerier) SetOrderItemDiscounts(ctx context.Context, arg repository.SetOrderItemDiscountsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrderItemDiscounts", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOrderItemDiscounts indicates an expected call of SetOrderItemDiscounts.
+func (mr *MockOrderQuerierMockRecorder) SetOrderItemDiscounts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrderItemDiscounts", reflect.TypeOf((*MockOrderQuerier)(nil).SetOrderItemDiscounts), ctx, arg)
+}
+
 // UpdateOrderAppliedPromotion mocks base method.
 func (m *MockOrderQuerier) UpdateOrderAppliedPromotion(ctx context.Context, arg repository.UpdateOrderAppliedPromotionParams) error {
 	m.ctrl.T.Helper()
@@ -545,6 +1314,20 @@ func (mr *MockOrderQuerierMockRecorder) UpdateOrderPaymentUrl(ctx, arg any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderPaymentUrl", reflect.TypeOf((*MockOrderQuerier)(nil).UpdateOrderPaymentUrl), ctx, arg)
 }
 
+// UpdateOrderPromotionDiscount mocks base method.
+func (m *MockOrderQuerier) UpdateOrderPromotionDiscount(ctx context.Context, arg repository.UpdateOrderPromotionDiscountParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderPromotionDiscount", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrderPromotionDiscount indicates an expected call of UpdateOrderPromotionDiscount.
+func (mr *MockOrderQuerierMockRecorder) UpdateOrderPromotionDiscount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderPromotionDiscount", reflect.TypeOf((*MockOrderQuerier)(nil).UpdateOrderPromotionDiscount), ctx, arg)
+}
+
 // UpdateOrderStatus mocks base method.
 func (m *MockOrderQuerier) UpdateOrderStatus(ctx context.Context, arg repository.UpdateOrderStatusParams) (repository.Order, error) {
 	m.ctrl.T.Helper()
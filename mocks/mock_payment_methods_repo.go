@@ -41,19 +41,63 @@ func (m *MockPaymentMethodsRepo) EXPECT() *MockPaymentMethodsRepoMockRecorder {
 	return m.recorder
 }
 
+// CountOrdersByPaymentMethod mocks base method.
+func (m *MockPaymentMethodsRepo) CountOrdersByPaymentMethod(ctx context.Context, paymentMethodID *int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOrdersByPaymentMethod", ctx, paymentMethodID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOrdersByPaymentMethod indicates an expected call of CountOrdersByPaymentMethod.
+func (mr *MockPaymentMethodsRepoMockRecorder) CountOrdersByPaymentMethod(ctx, paymentMethodID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOrdersByPaymentMethod", reflect.TypeOf((*MockPaymentMethodsRepo)(nil).CountOrdersByPaymentMethod), ctx, paymentMethodID)
+}
+
 // CreatePaymentMethod mocks base method.
-func (m *MockPaymentMethodsRepo) CreatePaymentMethod(ctx context.Context, name string) (repository.PaymentMethod, error) {
+func (m *MockPaymentMethodsRepo) CreatePaymentMethod(ctx context.Context, arg repository.CreatePaymentMethodParams) (repository.PaymentMethod, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePaymentMethod", ctx, name)
+	ret := m.ctrl.Call(m, "CreatePaymentMethod", ctx, arg)
 	ret0, _ := ret[0].(repository.PaymentMethod)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePaymentMethod indicates an expected call of CreatePaymentMethod.
-func (mr *MockPaymentMethodsRepoMockRecorder) CreatePaymentMethod(ctx, name any) *gomock.Call {
+func (mr *MockPaymentMethodsRepoMockRecorder) CreatePaymentMethod(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentMethod", reflect.TypeOf((*MockPaymentMethodsRepo)(nil).CreatePaymentMethod), ctx, arg)
+}
+
+// DeletePaymentMethod mocks base method.
+func (m *MockPaymentMethodsRepo) DeletePaymentMethod(ctx context.Context, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePaymentMethod", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePaymentMethod indicates an expected call of DeletePaymentMethod.
+func (mr *MockPaymentMethodsRepoMockRecorder) DeletePaymentMethod(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaymentMethod", reflect.TypeOf((*MockPaymentMethodsRepo)(nil).DeletePaymentMethod), ctx, id)
+}
+
+// GetPaymentMethodByID mocks base method.
+func (m *MockPaymentMethodsRepo) GetPaymentMethodByID(ctx context.Context, id int32) (repository.PaymentMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentMethodByID", ctx, id)
+	ret0, _ := ret[0].(repository.PaymentMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentMethodByID indicates an expected call of GetPaymentMethodByID.
+func (mr *MockPaymentMethodsRepoMockRecorder) GetPaymentMethodByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentMethod", reflect.TypeOf((*MockPaymentMethodsRepo)(nil).CreatePaymentMethod), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentMethodByID", reflect.TypeOf((*MockPaymentMethodsRepo)(nil).GetPaymentMethodByID), ctx, id)
 }
 
 // GetPaymentMethodByName mocks base method.
@@ -71,6 +115,21 @@ func (mr *MockPaymentMethodsRepoMockRecorder) GetPaymentMethodByName(ctx, name a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentMethodByName", reflect.TypeOf((*MockPaymentMethodsRepo)(nil).GetPaymentMethodByName), ctx, name)
 }
 
+// ListAllPaymentMethods mocks base method.
+func (m *MockPaymentMethodsRepo) ListAllPaymentMethods(ctx context.Context) ([]repository.PaymentMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllPaymentMethods", ctx)
+	ret0, _ := ret[0].([]repository.PaymentMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllPaymentMethods indicates an expected call of ListAllPaymentMethods.
+func (mr *MockPaymentMethodsRepoMockRecorder) ListAllPaymentMethods(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllPaymentMethods", reflect.TypeOf((*MockPaymentMethodsRepo)(nil).ListAllPaymentMethods), ctx)
+}
+
 // ListPaymentMethods mocks base method.
 func (m *MockPaymentMethodsRepo) ListPaymentMethods(ctx context.Context) ([]repository.PaymentMethod, error) {
 	m.ctrl.T.Helper()
@@ -85,3 +144,32 @@ func (mr *MockPaymentMethodsRepoMockRecorder) ListPaymentMethods(ctx any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentMethods", reflect.TypeOf((*MockPaymentMethodsRepo)(nil).ListPaymentMethods), ctx)
 }
+
+// ReorderPaymentMethods mocks base method.
+func (m *MockPaymentMethodsRepo) ReorderPaymentMethods(ctx context.Context, ids []int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderPaymentMethods", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderPaymentMethods indicates an expected call of ReorderPaymentMethods.
+func (mr *MockPaymentMethodsRepoMockRecorder) ReorderPaymentMethods(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderPaymentMethods", reflect.TypeOf((*MockPaymentMethodsRepo)(nil).ReorderPaymentMethods), ctx, ids)
+}
+
+// UpdatePaymentMethod mocks base method.
+func (m *MockPaymentMethodsRepo) UpdatePaymentMethod(ctx context.Context, arg repository.UpdatePaymentMethodParams) (repository.PaymentMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentMethod", ctx, arg)
+	ret0, _ := ret[0].(repository.PaymentMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentMethod indicates an expected call of UpdatePaymentMethod.
+func (mr *MockPaymentMethodsRepoMockRecorder) UpdatePaymentMethod(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentMethod", reflect.TypeOf((*MockPaymentMethodsRepo)(nil).UpdatePaymentMethod), ctx, arg)
+}
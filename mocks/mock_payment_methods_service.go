@@ -40,6 +40,65 @@ func (m *MockIPaymentMethodService) EXPECT() *MockIPaymentMethodServiceMockRecor
 	return m.recorder
 }
 
+// CreatePaymentMethod mocks base method.
+func (m *MockIPaymentMethodService) CreatePaymentMethod(ctx context.Context, req payment_methods.CreatePaymentMethodRequest) (*payment_methods.PaymentMethodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentMethod", ctx, req)
+	ret0, _ := ret[0].(*payment_methods.PaymentMethodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentMethod indicates an expected call of CreatePaymentMethod.
+func (mr *MockIPaymentMethodServiceMockRecorder) CreatePaymentMethod(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentMethod", reflect.TypeOf((*MockIPaymentMethodService)(nil).CreatePaymentMethod), ctx, req)
+}
+
+// DeletePaymentMethod mocks base method.
+func (m *MockIPaymentMethodService) DeletePaymentMethod(ctx context.Context, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePaymentMethod", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePaymentMethod indicates an expected call of DeletePaymentMethod.
+func (mr *MockIPaymentMethodServiceMockRecorder) DeletePaymentMethod(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaymentMethod", reflect.TypeOf((*MockIPaymentMethodService)(nil).DeletePaymentMethod), ctx, id)
+}
+
+// GetPaymentMethodByID mocks base method.
+func (m *MockIPaymentMethodService) GetPaymentMethodByID(ctx context.Context, id int32) (*payment_methods.PaymentMethodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentMethodByID", ctx, id)
+	ret0, _ := ret[0].(*payment_methods.PaymentMethodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentMethodByID indicates an expected call of GetPaymentMethodByID.
+func (mr *MockIPaymentMethodServiceMockRecorder) GetPaymentMethodByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentMethodByID", reflect.TypeOf((*MockIPaymentMethodService)(nil).GetPaymentMethodByID), ctx, id)
+}
+
+// ListAllPaymentMethods mocks base method.
+func (m *MockIPaymentMethodService) ListAllPaymentMethods(ctx context.Context) ([]payment_methods.PaymentMethodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllPaymentMethods", ctx)
+	ret0, _ := ret[0].([]payment_methods.PaymentMethodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllPaymentMethods indicates an expected call of ListAllPaymentMethods.
+func (mr *MockIPaymentMethodServiceMockRecorder) ListAllPaymentMethods(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllPaymentMethods", reflect.TypeOf((*MockIPaymentMethodService)(nil).ListAllPaymentMethods), ctx)
+}
+
 // ListPaymentMethods mocks base method.
 func (m *MockIPaymentMethodService) ListPaymentMethods(ctx context.Context) ([]payment_methods.PaymentMethodResponse, error) {
 	m.ctrl.T.Helper()
@@ -54,3 +113,33 @@ func (mr *MockIPaymentMethodServiceMockRecorder) ListPaymentMethods(ctx any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentMethods", reflect.TypeOf((*MockIPaymentMethodService)(nil).ListPaymentMethods), ctx)
 }
+
+// ReorderPaymentMethods mocks base method.
+func (m *MockIPaymentMethodService) ReorderPaymentMethods(ctx context.Context, req payment_methods.ReorderPaymentMethodsRequest) ([]payment_methods.PaymentMethodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderPaymentMethods", ctx, req)
+	ret0, _ := ret[0].([]payment_methods.PaymentMethodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderPaymentMethods indicates an expected call of ReorderPaymentMethods.
+func (mr *MockIPaymentMethodServiceMockRecorder) ReorderPaymentMethods(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderPaymentMethods", reflect.TypeOf((*MockIPaymentMethodService)(nil).ReorderPaymentMethods), ctx, req)
+}
+
+// UpdatePaymentMethod mocks base method.
+func (m *MockIPaymentMethodService) UpdatePaymentMethod(ctx context.Context, id int32, req payment_methods.UpdatePaymentMethodRequest) (*payment_methods.PaymentMethodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentMethod", ctx, id, req)
+	ret0, _ := ret[0].(*payment_methods.PaymentMethodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentMethod indicates an expected call of UpdatePaymentMethod.
+func (mr *MockIPaymentMethodServiceMockRecorder) UpdatePaymentMethod(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentMethod", reflect.TypeOf((*MockIPaymentMethodService)(nil).UpdatePaymentMethod), ctx, id, req)
+}
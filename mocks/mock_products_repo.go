@@ -15,6 +15,7 @@ import (
 	reflect "reflect"
 
 	uuid "github.com/google/uuid"
+	pgtype "github.com/jackc/pgx/v5/pgtype"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -71,6 +72,21 @@ func (mr *MockProductQuerierMockRecorder) AssignProductCategory(ctx, arg any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignProductCategory", reflect.TypeOf((*MockProductQuerier)(nil).AssignProductCategory), ctx, arg)
 }
 
+// BarcodeExists mocks base method.
+func (m *MockProductQuerier) BarcodeExists(ctx context.Context, code string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BarcodeExists", ctx, code)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BarcodeExists indicates an expected call of BarcodeExists.
+func (mr *MockProductQuerierMockRecorder) BarcodeExists(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BarcodeExists", reflect.TypeOf((*MockProductQuerier)(nil).BarcodeExists), ctx, code)
+}
+
 // CheckCategoryExists mocks base method.
 func (m *MockProductQuerier) CheckCategoryExists(ctx context.Context, id int32) (bool, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +131,21 @@ func (mr *MockProductQuerierMockRecorder) CountDeletedProducts(ctx, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDeletedProducts", reflect.TypeOf((*MockProductQuerier)(nil).CountDeletedProducts), ctx, arg)
 }
 
+// CountModifierGroupDefaults mocks base method.
+func (m *MockProductQuerier) CountModifierGroupDefaults(ctx context.Context, arg repository.CountModifierGroupDefaultsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountModifierGroupDefaults", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountModifierGroupDefaults indicates an expected call of CountModifierGroupDefaults.
+func (mr *MockProductQuerierMockRecorder) CountModifierGroupDefaults(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountModifierGroupDefaults", reflect.TypeOf((*MockProductQuerier)(nil).CountModifierGroupDefaults), ctx, arg)
+}
+
 // CountProducts mocks base method.
 func (m *MockProductQuerier) CountProducts(ctx context.Context, arg repository.CountProductsParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -131,18 +162,33 @@ func (mr *MockProductQuerierMockRecorder) CountProducts(ctx, arg any) *gomock.Ca
 }
 
 // CountStockHistoryByProduct mocks base method.
-func (m *MockProductQuerier) CountStockHistoryByProduct(ctx context.Context, productID uuid.UUID) (int64, error) {
+func (m *MockProductQuerier) CountStockHistoryByProduct(ctx context.Context, arg repository.CountStockHistoryByProductParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountStockHistoryByProduct", ctx, productID)
+	ret := m.ctrl.Call(m, "CountStockHistoryByProduct", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountStockHistoryByProduct indicates an expected call of CountStockHistoryByProduct.
-func (mr *MockProductQuerierMockRecorder) CountStockHistoryByProduct(ctx, productID any) *gomock.Call {
+func (mr *MockProductQuerierMockRecorder) CountStockHistoryByProduct(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStockHistoryByProduct", reflect.TypeOf((*MockProductQuerier)(nil).CountStockHistoryByProduct), ctx, productID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStockHistoryByProduct", reflect.TypeOf((*MockProductQuerier)(nil).CountStockHistoryByProduct), ctx, arg)
+}
+
+// CreateModifierGroup mocks base method.
+func (m *MockProductQuerier) CreateModifierGroup(ctx context.Context, arg repository.CreateModifierGroupParams) (repository.ModifierGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModifierGroup", ctx, arg)
+	ret0, _ := ret[0].(repository.ModifierGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateModifierGroup indicates an expected call of CreateModifierGroup.
+func (mr *MockProductQuerierMockRecorder) CreateModifierGroup(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModifierGroup", reflect.TypeOf((*MockProductQuerier)(nil).CreateModifierGroup), ctx, arg)
 }
 
 // CreateProduct mocks base method.
@@ -160,6 +206,21 @@ func (mr *MockProductQuerierMockRecorder) CreateProduct(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockProductQuerier)(nil).CreateProduct), ctx, arg)
 }
 
+// CreateProductBarcode mocks base method.
+func (m *MockProductQuerier) CreateProductBarcode(ctx context.Context, arg repository.CreateProductBarcodeParams) (repository.ProductBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductBarcode", ctx, arg)
+	ret0, _ := ret[0].(repository.ProductBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductBarcode indicates an expected call of CreateProductBarcode.
+func (mr *MockProductQuerierMockRecorder) CreateProductBarcode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductBarcode", reflect.TypeOf((*MockProductQuerier)(nil).CreateProductBarcode), ctx, arg)
+}
+
 // CreateProductOption mocks base method.
 func (m *MockProductQuerier) CreateProductOption(ctx context.Context, arg repository.CreateProductOptionParams) (repository.ProductOption, error) {
 	m.ctrl.T.Helper()
@@ -175,6 +236,35 @@ func (mr *MockProductQuerierMockRecorder) CreateProductOption(ctx, arg any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductOption", reflect.TypeOf((*MockProductQuerier)(nil).CreateProductOption), ctx, arg)
 }
 
+// CreateProductVariant mocks base method.
+func (m *MockProductQuerier) CreateProductVariant(ctx context.Context, arg repository.CreateProductVariantParams) (repository.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductVariant", ctx, arg)
+	ret0, _ := ret[0].(repository.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductVariant indicates an expected call of CreateProductVariant.
+func (mr *MockProductQuerierMockRecorder) CreateProductVariant(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductVariant", reflect.TypeOf((*MockProductQuerier)(nil).CreateProductVariant), ctx, arg)
+}
+
+// CreateProductVariantValue mocks base method.
+func (m *MockProductQuerier) CreateProductVariantValue(ctx context.Context, arg repository.CreateProductVariantValueParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductVariantValue", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProductVariantValue indicates an expected call of CreateProductVariantValue.
+func (mr *MockProductQuerierMockRecorder) CreateProductVariantValue(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductVariantValue", reflect.TypeOf((*MockProductQuerier)(nil).CreateProductVariantValue), ctx, arg)
+}
+
 // CreateStockHistory mocks base method.
 func (m *MockProductQuerier) CreateStockHistory(ctx context.Context, arg repository.CreateStockHistoryParams) (repository.StockHistory, error) {
 	m.ctrl.T.Helper()
@@ -219,6 +309,34 @@ func (mr *MockProductQuerierMockRecorder) DeleteProduct(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductQuerier)(nil).DeleteProduct), ctx, id)
 }
 
+// DeleteProductBarcode mocks base method.
+func (m *MockProductQuerier) DeleteProductBarcode(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductBarcode", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductBarcode indicates an expected call of DeleteProductBarcode.
+func (mr *MockProductQuerierMockRecorder) DeleteProductBarcode(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductBarcode", reflect.TypeOf((*MockProductQuerier)(nil).DeleteProductBarcode), ctx, id)
+}
+
+// DetachModifierGroupOptions mocks base method.
+func (m *MockProductQuerier) DetachModifierGroupOptions(ctx context.Context, modifierGroupID pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachModifierGroupOptions", ctx, modifierGroupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachModifierGroupOptions indicates an expected call of DetachModifierGroupOptions.
+func (mr *MockProductQuerierMockRecorder) DetachModifierGroupOptions(ctx, modifierGroupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachModifierGroupOptions", reflect.TypeOf((*MockProductQuerier)(nil).DetachModifierGroupOptions), ctx, modifierGroupID)
+}
+
 // GetDeletedProduct mocks base method.
 func (m *MockProductQuerier) GetDeletedProduct(ctx context.Context, id uuid.UUID) (repository.GetDeletedProductRow, error) {
 	m.ctrl.T.Helper()
@@ -234,6 +352,51 @@ func (mr *MockProductQuerierMockRecorder) GetDeletedProduct(ctx, id any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedProduct", reflect.TypeOf((*MockProductQuerier)(nil).GetDeletedProduct), ctx, id)
 }
 
+// GetModifierGroup mocks base method.
+func (m *MockProductQuerier) GetModifierGroup(ctx context.Context, id uuid.UUID) (repository.ModifierGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModifierGroup", ctx, id)
+	ret0, _ := ret[0].(repository.ModifierGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModifierGroup indicates an expected call of GetModifierGroup.
+func (mr *MockProductQuerierMockRecorder) GetModifierGroup(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModifierGroup", reflect.TypeOf((*MockProductQuerier)(nil).GetModifierGroup), ctx, id)
+}
+
+// GetModifierGroupsByIDs mocks base method.
+func (m *MockProductQuerier) GetModifierGroupsByIDs(ctx context.Context, ids []uuid.UUID) ([]repository.ModifierGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModifierGroupsByIDs", ctx, ids)
+	ret0, _ := ret[0].([]repository.ModifierGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModifierGroupsByIDs indicates an expected call of GetModifierGroupsByIDs.
+func (mr *MockProductQuerierMockRecorder) GetModifierGroupsByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModifierGroupsByIDs", reflect.TypeOf((*MockProductQuerier)(nil).GetModifierGroupsByIDs), ctx, ids)
+}
+
+// GetProductBarcode mocks base method.
+func (m *MockProductQuerier) GetProductBarcode(ctx context.Context, arg repository.GetProductBarcodeParams) (repository.ProductBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductBarcode", ctx, arg)
+	ret0, _ := ret[0].(repository.ProductBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductBarcode indicates an expected call of GetProductBarcode.
+func (mr *MockProductQuerierMockRecorder) GetProductBarcode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductBarcode", reflect.TypeOf((*MockProductQuerier)(nil).GetProductBarcode), ctx, arg)
+}
+
 // GetProductByID mocks base method.
 func (m *MockProductQuerier) GetProductByID(ctx context.Context, id uuid.UUID) (repository.GetProductByIDRow, error) {
 	m.ctrl.T.Helper()
@@ -294,6 +457,21 @@ func (mr *MockProductQuerierMockRecorder) GetProductOptionsByIDs(ctx, dollar_1 a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductOptionsByIDs", reflect.TypeOf((*MockProductQuerier)(nil).GetProductOptionsByIDs), ctx, dollar_1)
 }
 
+// GetProductVariantForUpdate mocks base method.
+func (m *MockProductQuerier) GetProductVariantForUpdate(ctx context.Context, arg repository.GetProductVariantForUpdateParams) (repository.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductVariantForUpdate", ctx, arg)
+	ret0, _ := ret[0].(repository.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductVariantForUpdate indicates an expected call of GetProductVariantForUpdate.
+func (mr *MockProductQuerierMockRecorder) GetProductVariantForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductVariantForUpdate", reflect.TypeOf((*MockProductQuerier)(nil).GetProductVariantForUpdate), ctx, arg)
+}
+
 // GetProductWithOptions mocks base method.
 func (m *MockProductQuerier) GetProductWithOptions(ctx context.Context, id uuid.UUID) (repository.GetProductWithOptionsRow, error) {
 	m.ctrl.T.Helper()
@@ -369,6 +547,81 @@ func (mr *MockProductQuerierMockRecorder) GetStockHistoryByProductWithPagination
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockHistoryByProductWithPagination", reflect.TypeOf((*MockProductQuerier)(nil).GetStockHistoryByProductWithPagination), ctx, arg)
 }
 
+// GetVariantsByIDs mocks base method.
+func (m *MockProductQuerier) GetVariantsByIDs(ctx context.Context, ids []uuid.UUID) ([]repository.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantsByIDs", ctx, ids)
+	ret0, _ := ret[0].([]repository.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantsByIDs indicates an expected call of GetVariantsByIDs.
+func (mr *MockProductQuerierMockRecorder) GetVariantsByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantsByIDs", reflect.TypeOf((*MockProductQuerier)(nil).GetVariantsByIDs), ctx, ids)
+}
+
+// ListCatalogOptions mocks base method.
+func (m *MockProductQuerier) ListCatalogOptions(ctx context.Context) ([]repository.ProductOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCatalogOptions", ctx)
+	ret0, _ := ret[0].([]repository.ProductOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCatalogOptions indicates an expected call of ListCatalogOptions.
+func (mr *MockProductQuerierMockRecorder) ListCatalogOptions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCatalogOptions", reflect.TypeOf((*MockProductQuerier)(nil).ListCatalogOptions), ctx)
+}
+
+// ListCatalogProductCategories mocks base method.
+func (m *MockProductQuerier) ListCatalogProductCategories(ctx context.Context) ([]repository.ListCatalogProductCategoriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCatalogProductCategories", ctx)
+	ret0, _ := ret[0].([]repository.ListCatalogProductCategoriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCatalogProductCategories indicates an expected call of ListCatalogProductCategories.
+func (mr *MockProductQuerierMockRecorder) ListCatalogProductCategories(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCatalogProductCategories", reflect.TypeOf((*MockProductQuerier)(nil).ListCatalogProductCategories), ctx)
+}
+
+// ListCatalogProducts mocks base method.
+func (m *MockProductQuerier) ListCatalogProducts(ctx context.Context) ([]repository.ListCatalogProductsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCatalogProducts", ctx)
+	ret0, _ := ret[0].([]repository.ListCatalogProductsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCatalogProducts indicates an expected call of ListCatalogProducts.
+func (mr *MockProductQuerierMockRecorder) ListCatalogProducts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCatalogProducts", reflect.TypeOf((*MockProductQuerier)(nil).ListCatalogProducts), ctx)
+}
+
+// ListCategories mocks base method.
+func (m *MockProductQuerier) ListCategories(ctx context.Context) ([]repository.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", ctx)
+	ret0, _ := ret[0].([]repository.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockProductQuerierMockRecorder) ListCategories(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockProductQuerier)(nil).ListCategories), ctx)
+}
+
 // ListDeletedProducts mocks base method.
 func (m *MockProductQuerier) ListDeletedProducts(ctx context.Context, arg repository.ListDeletedProductsParams) ([]repository.ListDeletedProductsRow, error) {
 	m.ctrl.T.Helper()
@@ -384,6 +637,36 @@ func (mr *MockProductQuerierMockRecorder) ListDeletedProducts(ctx, arg any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedProducts", reflect.TypeOf((*MockProductQuerier)(nil).ListDeletedProducts), ctx, arg)
 }
 
+// ListModifierGroupOptions mocks base method.
+func (m *MockProductQuerier) ListModifierGroupOptions(ctx context.Context, modifierGroupID pgtype.UUID) ([]repository.ListModifierGroupOptionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModifierGroupOptions", ctx, modifierGroupID)
+	ret0, _ := ret[0].([]repository.ListModifierGroupOptionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModifierGroupOptions indicates an expected call of ListModifierGroupOptions.
+func (mr *MockProductQuerierMockRecorder) ListModifierGroupOptions(ctx, modifierGroupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModifierGroupOptions", reflect.TypeOf((*MockProductQuerier)(nil).ListModifierGroupOptions), ctx, modifierGroupID)
+}
+
+// ListModifierGroups mocks base method.
+func (m *MockProductQuerier) ListModifierGroups(ctx context.Context) ([]repository.ModifierGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModifierGroups", ctx)
+	ret0, _ := ret[0].([]repository.ModifierGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModifierGroups indicates an expected call of ListModifierGroups.
+func (mr *MockProductQuerierMockRecorder) ListModifierGroups(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModifierGroups", reflect.TypeOf((*MockProductQuerier)(nil).ListModifierGroups), ctx)
+}
+
 // ListOptionsForProduct mocks base method.
 func (m *MockProductQuerier) ListOptionsForProduct(ctx context.Context, productID uuid.UUID) ([]repository.ProductOption, error) {
 	m.ctrl.T.Helper()
@@ -399,6 +682,51 @@ func (mr *MockProductQuerierMockRecorder) ListOptionsForProduct(ctx, productID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOptionsForProduct", reflect.TypeOf((*MockProductQuerier)(nil).ListOptionsForProduct), ctx, productID)
 }
 
+// ListProductBarcodes mocks base method.
+func (m *MockProductQuerier) ListProductBarcodes(ctx context.Context, productID uuid.UUID) ([]repository.ProductBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductBarcodes", ctx, productID)
+	ret0, _ := ret[0].([]repository.ProductBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductBarcodes indicates an expected call of ListProductBarcodes.
+func (mr *MockProductQuerierMockRecorder) ListProductBarcodes(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductBarcodes", reflect.TypeOf((*MockProductQuerier)(nil).ListProductBarcodes), ctx, productID)
+}
+
+// ListProductVariantValues mocks base method.
+func (m *MockProductQuerier) ListProductVariantValues(ctx context.Context, productID uuid.UUID) ([]repository.ListProductVariantValuesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductVariantValues", ctx, productID)
+	ret0, _ := ret[0].([]repository.ListProductVariantValuesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductVariantValues indicates an expected call of ListProductVariantValues.
+func (mr *MockProductQuerierMockRecorder) ListProductVariantValues(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductVariantValues", reflect.TypeOf((*MockProductQuerier)(nil).ListProductVariantValues), ctx, productID)
+}
+
+// ListProductVariants mocks base method.
+func (m *MockProductQuerier) ListProductVariants(ctx context.Context, productID uuid.UUID) ([]repository.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductVariants", ctx, productID)
+	ret0, _ := ret[0].([]repository.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductVariants indicates an expected call of ListProductVariants.
+func (mr *MockProductQuerierMockRecorder) ListProductVariants(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductVariants", reflect.TypeOf((*MockProductQuerier)(nil).ListProductVariants), ctx, productID)
+}
+
 // ListProducts mocks base method.
 func (m *MockProductQuerier) ListProducts(ctx context.Context, arg repository.ListProductsParams) ([]repository.ListProductsRow, error) {
 	m.ctrl.T.Helper()
@@ -414,6 +742,141 @@ func (mr *MockProductQuerierMockRecorder) ListProducts(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockProductQuerier)(nil).ListProducts), ctx, arg)
 }
 
+// ListUnbarcodedItems mocks base method.
+func (m *MockProductQuerier) ListUnbarcodedItems(ctx context.Context) ([]repository.ListUnbarcodedItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnbarcodedItems", ctx)
+	ret0, _ := ret[0].([]repository.ListUnbarcodedItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnbarcodedItems indicates an expected call of ListUnbarcodedItems.
+func (mr *MockProductQuerierMockRecorder) ListUnbarcodedItems(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnbarcodedItems", reflect.TypeOf((*MockProductQuerier)(nil).ListUnbarcodedItems), ctx)
+}
+
+// ListVariantAttributeValues mocks base method.
+func (m *MockProductQuerier) ListVariantAttributeValues(ctx context.Context, productID uuid.UUID) ([]repository.ProductVariantAttributeValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVariantAttributeValues", ctx, productID)
+	ret0, _ := ret[0].([]repository.ProductVariantAttributeValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVariantAttributeValues indicates an expected call of ListVariantAttributeValues.
+func (mr *MockProductQuerierMockRecorder) ListVariantAttributeValues(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVariantAttributeValues", reflect.TypeOf((*MockProductQuerier)(nil).ListVariantAttributeValues), ctx, productID)
+}
+
+// ListVariantAttributes mocks base method.
+func (m *MockProductQuerier) ListVariantAttributes(ctx context.Context, productID uuid.UUID) ([]repository.ProductVariantAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVariantAttributes", ctx, productID)
+	ret0, _ := ret[0].([]repository.ProductVariantAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVariantAttributes indicates an expected call of ListVariantAttributes.
+func (mr *MockProductQuerierMockRecorder) ListVariantAttributes(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVariantAttributes", reflect.TypeOf((*MockProductQuerier)(nil).ListVariantAttributes), ctx, productID)
+}
+
+// LookupProductCode mocks base method.
+func (m *MockProductQuerier) LookupProductCode(ctx context.Context, code string) (repository.LookupProductCodeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupProductCode", ctx, code)
+	ret0, _ := ret[0].(repository.LookupProductCodeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupProductCode indicates an expected call of LookupProductCode.
+func (mr *MockProductQuerierMockRecorder) LookupProductCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupProductCode", reflect.TypeOf((*MockProductQuerier)(nil).LookupProductCode), ctx, code)
+}
+
+// MaxModifierGroupDefaults mocks base method.
+func (m *MockProductQuerier) MaxModifierGroupDefaults(ctx context.Context, modifierGroupID pgtype.UUID) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxModifierGroupDefaults", ctx, modifierGroupID)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MaxModifierGroupDefaults indicates an expected call of MaxModifierGroupDefaults.
+func (mr *MockProductQuerierMockRecorder) MaxModifierGroupDefaults(ctx, modifierGroupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxModifierGroupDefaults", reflect.TypeOf((*MockProductQuerier)(nil).MaxModifierGroupDefaults), ctx, modifierGroupID)
+}
+
+// ModifierGroupNameExists mocks base method.
+func (m *MockProductQuerier) ModifierGroupNameExists(ctx context.Context, arg repository.ModifierGroupNameExistsParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifierGroupNameExists", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifierGroupNameExists indicates an expected call of ModifierGroupNameExists.
+func (mr *MockProductQuerierMockRecorder) ModifierGroupNameExists(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifierGroupNameExists", reflect.TypeOf((*MockProductQuerier)(nil).ModifierGroupNameExists), ctx, arg)
+}
+
+// NextInStoreBarcodeNumber mocks base method.
+func (m *MockProductQuerier) NextInStoreBarcodeNumber(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextInStoreBarcodeNumber", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextInStoreBarcodeNumber indicates an expected call of NextInStoreBarcodeNumber.
+func (mr *MockProductQuerierMockRecorder) NextInStoreBarcodeNumber(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextInStoreBarcodeNumber", reflect.TypeOf((*MockProductQuerier)(nil).NextInStoreBarcodeNumber), ctx)
+}
+
+// ProductHasRecipe mocks base method.
+func (m *MockProductQuerier) ProductHasRecipe(ctx context.Context, productID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductHasRecipe", ctx, productID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductHasRecipe indicates an expected call of ProductHasRecipe.
+func (mr *MockProductQuerierMockRecorder) ProductHasRecipe(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductHasRecipe", reflect.TypeOf((*MockProductQuerier)(nil).ProductHasRecipe), ctx, productID)
+}
+
+// ProductSKUExists mocks base method.
+func (m *MockProductQuerier) ProductSKUExists(ctx context.Context, arg repository.ProductSKUExistsParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductSKUExists", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductSKUExists indicates an expected call of ProductSKUExists.
+func (mr *MockProductQuerierMockRecorder) ProductSKUExists(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductSKUExists", reflect.TypeOf((*MockProductQuerier)(nil).ProductSKUExists), ctx, arg)
+}
+
 // RestoreProduct mocks base method.
 func (m *MockProductQuerier) RestoreProduct(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -442,6 +905,20 @@ func (mr *MockProductQuerierMockRecorder) RestoreProductsBulk(ctx, dollar_1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProductsBulk", reflect.TypeOf((*MockProductQuerier)(nil).RestoreProductsBulk), ctx, dollar_1)
 }
 
+// SoftDeleteModifierGroup mocks base method.
+func (m *MockProductQuerier) SoftDeleteModifierGroup(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteModifierGroup", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDeleteModifierGroup indicates an expected call of SoftDeleteModifierGroup.
+func (mr *MockProductQuerierMockRecorder) SoftDeleteModifierGroup(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteModifierGroup", reflect.TypeOf((*MockProductQuerier)(nil).SoftDeleteModifierGroup), ctx, id)
+}
+
 // SoftDeleteProduct mocks base method.
 func (m *MockProductQuerier) SoftDeleteProduct(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -470,6 +947,35 @@ func (mr *MockProductQuerierMockRecorder) SoftDeleteProductOption(ctx, id any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteProductOption", reflect.TypeOf((*MockProductQuerier)(nil).SoftDeleteProductOption), ctx, id)
 }
 
+// SoftDeleteProductVariant mocks base method.
+func (m *MockProductQuerier) SoftDeleteProductVariant(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteProductVariant", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDeleteProductVariant indicates an expected call of SoftDeleteProductVariant.
+func (mr *MockProductQuerierMockRecorder) SoftDeleteProductVariant(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteProductVariant", reflect.TypeOf((*MockProductQuerier)(nil).SoftDeleteProductVariant), ctx, id)
+}
+
+// UpdateModifierGroup mocks base method.
+func (m *MockProductQuerier) UpdateModifierGroup(ctx context.Context, arg repository.UpdateModifierGroupParams) (repository.ModifierGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateModifierGroup", ctx, arg)
+	ret0, _ := ret[0].(repository.ModifierGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateModifierGroup indicates an expected call of UpdateModifierGroup.
+func (mr *MockProductQuerierMockRecorder) UpdateModifierGroup(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateModifierGroup", reflect.TypeOf((*MockProductQuerier)(nil).UpdateModifierGroup), ctx, arg)
+}
+
 // UpdateProduct mocks base method.
 func (m *MockProductQuerier) UpdateProduct(ctx context.Context, arg repository.UpdateProductParams) (repository.Product, error) {
 	m.ctrl.T.Helper()
@@ -499,3 +1005,78 @@ func (mr *MockProductQuerierMockRecorder) UpdateProductOption(ctx, arg any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductOption", reflect.TypeOf((*MockProductQuerier)(nil).UpdateProductOption), ctx, arg)
 }
+
+// UpdateProductVariant mocks base method.
+func (m *MockProductQuerier) UpdateProductVariant(ctx context.Context, arg repository.UpdateProductVariantParams) (repository.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductVariant", ctx, arg)
+	ret0, _ := ret[0].(repository.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductVariant indicates an expected call of UpdateProductVariant.
+func (mr *MockProductQuerierMockRecorder) UpdateProductVariant(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductVariant", reflect.TypeOf((*MockProductQuerier)(nil).UpdateProductVariant), ctx, arg)
+}
+
+// UpsertVariantAttribute mocks base method.
+func (m *MockProductQuerier) UpsertVariantAttribute(ctx context.Context, arg repository.UpsertVariantAttributeParams) (repository.ProductVariantAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertVariantAttribute", ctx, arg)
+	ret0, _ := ret[0].(repository.ProductVariantAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertVariantAttribute indicates an expected call of UpsertVariantAttribute.
+func (mr *MockProductQuerierMockRecorder) UpsertVariantAttribute(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertVariantAttribute", reflect.TypeOf((*MockProductQuerier)(nil).UpsertVariantAttribute), ctx, arg)
+}
+
+// UpsertVariantAttributeValue mocks base method.
+func (m *MockProductQuerier) UpsertVariantAttributeValue(ctx context.Context, arg repository.UpsertVariantAttributeValueParams) (repository.ProductVariantAttributeValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertVariantAttributeValue", ctx, arg)
+	ret0, _ := ret[0].(repository.ProductVariantAttributeValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertVariantAttributeValue indicates an expected call of UpsertVariantAttributeValue.
+func (mr *MockProductQuerierMockRecorder) UpsertVariantAttributeValue(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertVariantAttributeValue", reflect.TypeOf((*MockProductQuerier)(nil).UpsertVariantAttributeValue), ctx, arg)
+}
+
+// VariantBarcodeExists mocks base method.
+func (m *MockProductQuerier) VariantBarcodeExists(ctx context.Context, arg repository.VariantBarcodeExistsParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VariantBarcodeExists", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VariantBarcodeExists indicates an expected call of VariantBarcodeExists.
+func (mr *MockProductQuerierMockRecorder) VariantBarcodeExists(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariantBarcodeExists", reflect.TypeOf((*MockProductQuerier)(nil).VariantBarcodeExists), ctx, arg)
+}
+
+// VariantSKUExists mocks base method.
+func (m *MockProductQuerier) VariantSKUExists(ctx context.Context, arg repository.VariantSKUExistsParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VariantSKUExists", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VariantSKUExists indicates an expected call of VariantSKUExists.
+func (mr *MockProductQuerierMockRecorder) VariantSKUExists(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariantSKUExists", reflect.TypeOf((*MockProductQuerier)(nil).VariantSKUExists), ctx, arg)
+}
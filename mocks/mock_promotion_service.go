@@ -57,6 +57,36 @@ func (mr *MockIPromotionServiceMockRecorder) CreatePromotion(ctx, req any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockIPromotionService)(nil).CreatePromotion), ctx, req)
 }
 
+// CreatePromotionCode mocks base method.
+func (m *MockIPromotionService) CreatePromotionCode(ctx context.Context, promotionID uuid.UUID, req promotions.CreatePromotionCodeRequest) (*promotions.PromotionCodeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotionCode", ctx, promotionID, req)
+	ret0, _ := ret[0].(*promotions.PromotionCodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotionCode indicates an expected call of CreatePromotionCode.
+func (mr *MockIPromotionServiceMockRecorder) CreatePromotionCode(ctx, promotionID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotionCode", reflect.TypeOf((*MockIPromotionService)(nil).CreatePromotionCode), ctx, promotionID, req)
+}
+
+// DeactivatePromotionCode mocks base method.
+func (m *MockIPromotionService) DeactivatePromotionCode(ctx context.Context, promotionID, codeID uuid.UUID) (*promotions.PromotionCodeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivatePromotionCode", ctx, promotionID, codeID)
+	ret0, _ := ret[0].(*promotions.PromotionCodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivatePromotionCode indicates an expected call of DeactivatePromotionCode.
+func (mr *MockIPromotionServiceMockRecorder) DeactivatePromotionCode(ctx, promotionID, codeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivatePromotionCode", reflect.TypeOf((*MockIPromotionService)(nil).DeactivatePromotionCode), ctx, promotionID, codeID)
+}
+
 // DeletePromotion mocks base method.
 func (m *MockIPromotionService) DeletePromotion(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -71,6 +101,21 @@ func (mr *MockIPromotionServiceMockRecorder) DeletePromotion(ctx, id any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePromotion", reflect.TypeOf((*MockIPromotionService)(nil).DeletePromotion), ctx, id)
 }
 
+// GeneratePromotionCodes mocks base method.
+func (m *MockIPromotionService) GeneratePromotionCodes(ctx context.Context, promotionID uuid.UUID, req promotions.GeneratePromotionCodesRequest) (*promotions.GeneratePromotionCodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GeneratePromotionCodes", ctx, promotionID, req)
+	ret0, _ := ret[0].(*promotions.GeneratePromotionCodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GeneratePromotionCodes indicates an expected call of GeneratePromotionCodes.
+func (mr *MockIPromotionServiceMockRecorder) GeneratePromotionCodes(ctx, promotionID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePromotionCodes", reflect.TypeOf((*MockIPromotionService)(nil).GeneratePromotionCodes), ctx, promotionID, req)
+}
+
 // GetPromotion mocks base method.
 func (m *MockIPromotionService) GetPromotion(ctx context.Context, id uuid.UUID) (*promotions.PromotionResponse, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +131,21 @@ func (mr *MockIPromotionServiceMockRecorder) GetPromotion(ctx, id any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotion", reflect.TypeOf((*MockIPromotionService)(nil).GetPromotion), ctx, id)
 }
 
+// ListPromotionCodes mocks base method.
+func (m *MockIPromotionService) ListPromotionCodes(ctx context.Context, promotionID uuid.UUID, req promotions.ListPromotionCodesRequest) (*promotions.PagedPromotionCodeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPromotionCodes", ctx, promotionID, req)
+	ret0, _ := ret[0].(*promotions.PagedPromotionCodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPromotionCodes indicates an expected call of ListPromotionCodes.
+func (mr *MockIPromotionServiceMockRecorder) ListPromotionCodes(ctx, promotionID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPromotionCodes", reflect.TypeOf((*MockIPromotionService)(nil).ListPromotionCodes), ctx, promotionID, req)
+}
+
 // ListPromotions mocks base method.
 func (m *MockIPromotionService) ListPromotions(ctx context.Context, req promotions.ListPromotionsRequest) (*promotions.PagedPromotionResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,457 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: POS-kasir/internal/purchasing/repository (interfaces: Querier)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_purchasing_repo.go -mock_names Querier=MockPurchasingRepo POS-kasir/internal/purchasing/repository Querier
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	repository "POS-kasir/internal/purchasing/repository"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPurchasingRepo is a mock of Querier interface.
+type MockPurchasingRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchasingRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockPurchasingRepoMockRecorder is the mock recorder for MockPurchasingRepo.
+type MockPurchasingRepoMockRecorder struct {
+	mock *MockPurchasingRepo
+}
+
+// NewMockPurchasingRepo creates a new mock instance.
+func NewMockPurchasingRepo(ctrl *gomock.Controller) *MockPurchasingRepo {
+	mock := &MockPurchasingRepo{ctrl: ctrl}
+	mock.recorder = &MockPurchasingRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchasingRepo) EXPECT() *MockPurchasingRepoMockRecorder {
+	return m.recorder
+}
+
+// AddPurchaseOrderItemReceived mocks base method.
+func (m *MockPurchasingRepo) AddPurchaseOrderItemReceived(ctx context.Context, arg repository.AddPurchaseOrderItemReceivedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPurchaseOrderItemReceived", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPurchaseOrderItemReceived indicates an expected call of AddPurchaseOrderItemReceived.
+func (mr *MockPurchasingRepoMockRecorder) AddPurchaseOrderItemReceived(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPurchaseOrderItemReceived", reflect.TypeOf((*MockPurchasingRepo)(nil).AddPurchaseOrderItemReceived), ctx, arg)
+}
+
+// CountOpenPurchaseOrdersBySupplier mocks base method.
+func (m *MockPurchasingRepo) CountOpenPurchaseOrdersBySupplier(ctx context.Context, supplierID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenPurchaseOrdersBySupplier", ctx, supplierID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenPurchaseOrdersBySupplier indicates an expected call of CountOpenPurchaseOrdersBySupplier.
+func (mr *MockPurchasingRepoMockRecorder) CountOpenPurchaseOrdersBySupplier(ctx, supplierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenPurchaseOrdersBySupplier", reflect.TypeOf((*MockPurchasingRepo)(nil).CountOpenPurchaseOrdersBySupplier), ctx, supplierID)
+}
+
+// CountPurchaseOrders mocks base method.
+func (m *MockPurchasingRepo) CountPurchaseOrders(ctx context.Context, arg repository.CountPurchaseOrdersParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPurchaseOrders", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPurchaseOrders indicates an expected call of CountPurchaseOrders.
+func (mr *MockPurchasingRepoMockRecorder) CountPurchaseOrders(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPurchaseOrders", reflect.TypeOf((*MockPurchasingRepo)(nil).CountPurchaseOrders), ctx, arg)
+}
+
+// CountSuppliers mocks base method.
+func (m *MockPurchasingRepo) CountSuppliers(ctx context.Context, searchText *string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSuppliers", ctx, searchText)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSuppliers indicates an expected call of CountSuppliers.
+func (mr *MockPurchasingRepoMockRecorder) CountSuppliers(ctx, searchText any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSuppliers", reflect.TypeOf((*MockPurchasingRepo)(nil).CountSuppliers), ctx, searchText)
+}
+
+// CreateGoodsReceipt mocks base method.
+func (m *MockPurchasingRepo) CreateGoodsReceipt(ctx context.Context, arg repository.CreateGoodsReceiptParams) (repository.GoodsReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoodsReceipt", ctx, arg)
+	ret0, _ := ret[0].(repository.GoodsReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoodsReceipt indicates an expected call of CreateGoodsReceipt.
+func (mr *MockPurchasingRepoMockRecorder) CreateGoodsReceipt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoodsReceipt", reflect.TypeOf((*MockPurchasingRepo)(nil).CreateGoodsReceipt), ctx, arg)
+}
+
+// CreateGoodsReceiptItem mocks base method.
+func (m *MockPurchasingRepo) CreateGoodsReceiptItem(ctx context.Context, arg repository.CreateGoodsReceiptItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoodsReceiptItem", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGoodsReceiptItem indicates an expected call of CreateGoodsReceiptItem.
+func (mr *MockPurchasingRepoMockRecorder) CreateGoodsReceiptItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoodsReceiptItem", reflect.TypeOf((*MockPurchasingRepo)(nil).CreateGoodsReceiptItem), ctx, arg)
+}
+
+// CreatePurchaseOrder mocks base method.
+func (m *MockPurchasingRepo) CreatePurchaseOrder(ctx context.Context, arg repository.CreatePurchaseOrderParams) (repository.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrder", ctx, arg)
+	ret0, _ := ret[0].(repository.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrder indicates an expected call of CreatePurchaseOrder.
+func (mr *MockPurchasingRepoMockRecorder) CreatePurchaseOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrder", reflect.TypeOf((*MockPurchasingRepo)(nil).CreatePurchaseOrder), ctx, arg)
+}
+
+// CreatePurchaseOrderItem mocks base method.
+func (m *MockPurchasingRepo) CreatePurchaseOrderItem(ctx context.Context, arg repository.CreatePurchaseOrderItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrderItem", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePurchaseOrderItem indicates an expected call of CreatePurchaseOrderItem.
+func (mr *MockPurchasingRepoMockRecorder) CreatePurchaseOrderItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrderItem", reflect.TypeOf((*MockPurchasingRepo)(nil).CreatePurchaseOrderItem), ctx, arg)
+}
+
+// CreateStockHistory mocks base method.
+func (m *MockPurchasingRepo) CreateStockHistory(ctx context.Context, arg repository.CreateStockHistoryParams) (repository.StockHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockHistory", ctx, arg)
+	ret0, _ := ret[0].(repository.StockHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockHistory indicates an expected call of CreateStockHistory.
+func (mr *MockPurchasingRepoMockRecorder) CreateStockHistory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockHistory", reflect.TypeOf((*MockPurchasingRepo)(nil).CreateStockHistory), ctx, arg)
+}
+
+// CreateSupplier mocks base method.
+func (m *MockPurchasingRepo) CreateSupplier(ctx context.Context, arg repository.CreateSupplierParams) (repository.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSupplier", ctx, arg)
+	ret0, _ := ret[0].(repository.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSupplier indicates an expected call of CreateSupplier.
+func (mr *MockPurchasingRepoMockRecorder) CreateSupplier(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupplier", reflect.TypeOf((*MockPurchasingRepo)(nil).CreateSupplier), ctx, arg)
+}
+
+// DeletePurchaseOrderItems mocks base method.
+func (m *MockPurchasingRepo) DeletePurchaseOrderItems(ctx context.Context, purchaseOrderID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePurchaseOrderItems", ctx, purchaseOrderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePurchaseOrderItems indicates an expected call of DeletePurchaseOrderItems.
+func (mr *MockPurchasingRepoMockRecorder) DeletePurchaseOrderItems(ctx, purchaseOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePurchaseOrderItems", reflect.TypeOf((*MockPurchasingRepo)(nil).DeletePurchaseOrderItems), ctx, purchaseOrderID)
+}
+
+// GetProductStockForUpdate mocks base method.
+func (m *MockPurchasingRepo) GetProductStockForUpdate(ctx context.Context, ids []uuid.UUID) ([]repository.GetProductStockForUpdateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductStockForUpdate", ctx, ids)
+	ret0, _ := ret[0].([]repository.GetProductStockForUpdateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductStockForUpdate indicates an expected call of GetProductStockForUpdate.
+func (mr *MockPurchasingRepoMockRecorder) GetProductStockForUpdate(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductStockForUpdate", reflect.TypeOf((*MockPurchasingRepo)(nil).GetProductStockForUpdate), ctx, ids)
+}
+
+// GetPurchasableProducts mocks base method.
+func (m *MockPurchasingRepo) GetPurchasableProducts(ctx context.Context, ids []uuid.UUID) ([]repository.GetPurchasableProductsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchasableProducts", ctx, ids)
+	ret0, _ := ret[0].([]repository.GetPurchasableProductsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchasableProducts indicates an expected call of GetPurchasableProducts.
+func (mr *MockPurchasingRepoMockRecorder) GetPurchasableProducts(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchasableProducts", reflect.TypeOf((*MockPurchasingRepo)(nil).GetPurchasableProducts), ctx, ids)
+}
+
+// GetPurchaseOrder mocks base method.
+func (m *MockPurchasingRepo) GetPurchaseOrder(ctx context.Context, id uuid.UUID) (repository.GetPurchaseOrderRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrder", ctx, id)
+	ret0, _ := ret[0].(repository.GetPurchaseOrderRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrder indicates an expected call of GetPurchaseOrder.
+func (mr *MockPurchasingRepoMockRecorder) GetPurchaseOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrder", reflect.TypeOf((*MockPurchasingRepo)(nil).GetPurchaseOrder), ctx, id)
+}
+
+// GetPurchaseOrderForUpdate mocks base method.
+func (m *MockPurchasingRepo) GetPurchaseOrderForUpdate(ctx context.Context, id uuid.UUID) (repository.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrderForUpdate", ctx, id)
+	ret0, _ := ret[0].(repository.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrderForUpdate indicates an expected call of GetPurchaseOrderForUpdate.
+func (mr *MockPurchasingRepoMockRecorder) GetPurchaseOrderForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrderForUpdate", reflect.TypeOf((*MockPurchasingRepo)(nil).GetPurchaseOrderForUpdate), ctx, id)
+}
+
+// GetPurchaseOrderItemsForUpdate mocks base method.
+func (m *MockPurchasingRepo) GetPurchaseOrderItemsForUpdate(ctx context.Context, purchaseOrderID uuid.UUID) ([]repository.PurchaseOrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrderItemsForUpdate", ctx, purchaseOrderID)
+	ret0, _ := ret[0].([]repository.PurchaseOrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrderItemsForUpdate indicates an expected call of GetPurchaseOrderItemsForUpdate.
+func (mr *MockPurchasingRepoMockRecorder) GetPurchaseOrderItemsForUpdate(ctx, purchaseOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrderItemsForUpdate", reflect.TypeOf((*MockPurchasingRepo)(nil).GetPurchaseOrderItemsForUpdate), ctx, purchaseOrderID)
+}
+
+// GetSupplier mocks base method.
+func (m *MockPurchasingRepo) GetSupplier(ctx context.Context, id uuid.UUID) (repository.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplier", ctx, id)
+	ret0, _ := ret[0].(repository.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplier indicates an expected call of GetSupplier.
+func (mr *MockPurchasingRepoMockRecorder) GetSupplier(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplier", reflect.TypeOf((*MockPurchasingRepo)(nil).GetSupplier), ctx, id)
+}
+
+// GetSupplierByName mocks base method.
+func (m *MockPurchasingRepo) GetSupplierByName(ctx context.Context, name string) (repository.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplierByName", ctx, name)
+	ret0, _ := ret[0].(repository.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplierByName indicates an expected call of GetSupplierByName.
+func (mr *MockPurchasingRepoMockRecorder) GetSupplierByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplierByName", reflect.TypeOf((*MockPurchasingRepo)(nil).GetSupplierByName), ctx, name)
+}
+
+// ListGoodsReceiptItemsByPurchaseOrder mocks base method.
+func (m *MockPurchasingRepo) ListGoodsReceiptItemsByPurchaseOrder(ctx context.Context, purchaseOrderID uuid.UUID) ([]repository.ListGoodsReceiptItemsByPurchaseOrderRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoodsReceiptItemsByPurchaseOrder", ctx, purchaseOrderID)
+	ret0, _ := ret[0].([]repository.ListGoodsReceiptItemsByPurchaseOrderRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoodsReceiptItemsByPurchaseOrder indicates an expected call of ListGoodsReceiptItemsByPurchaseOrder.
+func (mr *MockPurchasingRepoMockRecorder) ListGoodsReceiptItemsByPurchaseOrder(ctx, purchaseOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoodsReceiptItemsByPurchaseOrder", reflect.TypeOf((*MockPurchasingRepo)(nil).ListGoodsReceiptItemsByPurchaseOrder), ctx, purchaseOrderID)
+}
+
+// ListGoodsReceipts mocks base method.
+func (m *MockPurchasingRepo) ListGoodsReceipts(ctx context.Context, purchaseOrderID uuid.UUID) ([]repository.GoodsReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoodsReceipts", ctx, purchaseOrderID)
+	ret0, _ := ret[0].([]repository.GoodsReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoodsReceipts indicates an expected call of ListGoodsReceipts.
+func (mr *MockPurchasingRepoMockRecorder) ListGoodsReceipts(ctx, purchaseOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoodsReceipts", reflect.TypeOf((*MockPurchasingRepo)(nil).ListGoodsReceipts), ctx, purchaseOrderID)
+}
+
+// ListPurchaseOrderItems mocks base method.
+func (m *MockPurchasingRepo) ListPurchaseOrderItems(ctx context.Context, purchaseOrderID uuid.UUID) ([]repository.ListPurchaseOrderItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchaseOrderItems", ctx, purchaseOrderID)
+	ret0, _ := ret[0].([]repository.ListPurchaseOrderItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchaseOrderItems indicates an expected call of ListPurchaseOrderItems.
+func (mr *MockPurchasingRepoMockRecorder) ListPurchaseOrderItems(ctx, purchaseOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrderItems", reflect.TypeOf((*MockPurchasingRepo)(nil).ListPurchaseOrderItems), ctx, purchaseOrderID)
+}
+
+// ListPurchaseOrders mocks base method.
+func (m *MockPurchasingRepo) ListPurchaseOrders(ctx context.Context, arg repository.ListPurchaseOrdersParams) ([]repository.ListPurchaseOrdersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchaseOrders", ctx, arg)
+	ret0, _ := ret[0].([]repository.ListPurchaseOrdersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchaseOrders indicates an expected call of ListPurchaseOrders.
+func (mr *MockPurchasingRepoMockRecorder) ListPurchaseOrders(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrders", reflect.TypeOf((*MockPurchasingRepo)(nil).ListPurchaseOrders), ctx, arg)
+}
+
+// ListSuppliers mocks base method.
+func (m *MockPurchasingRepo) ListSuppliers(ctx context.Context, arg repository.ListSuppliersParams) ([]repository.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSuppliers", ctx, arg)
+	ret0, _ := ret[0].([]repository.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSuppliers indicates an expected call of ListSuppliers.
+func (mr *MockPurchasingRepoMockRecorder) ListSuppliers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuppliers", reflect.TypeOf((*MockPurchasingRepo)(nil).ListSuppliers), ctx, arg)
+}
+
+// ReceiveProductStock mocks base method.
+func (m *MockPurchasingRepo) ReceiveProductStock(ctx context.Context, arg repository.ReceiveProductStockParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveProductStock", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReceiveProductStock indicates an expected call of ReceiveProductStock.
+func (mr *MockPurchasingRepoMockRecorder) ReceiveProductStock(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveProductStock", reflect.TypeOf((*MockPurchasingRepo)(nil).ReceiveProductStock), ctx, arg)
+}
+
+// SoftDeleteSupplier mocks base method.
+func (m *MockPurchasingRepo) SoftDeleteSupplier(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteSupplier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDeleteSupplier indicates an expected call of SoftDeleteSupplier.
+func (mr *MockPurchasingRepoMockRecorder) SoftDeleteSupplier(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteSupplier", reflect.TypeOf((*MockPurchasingRepo)(nil).SoftDeleteSupplier), ctx, id)
+}
+
+// UpdatePurchaseOrder mocks base method.
+func (m *MockPurchasingRepo) UpdatePurchaseOrder(ctx context.Context, arg repository.UpdatePurchaseOrderParams) (repository.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePurchaseOrder", ctx, arg)
+	ret0, _ := ret[0].(repository.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePurchaseOrder indicates an expected call of UpdatePurchaseOrder.
+func (mr *MockPurchasingRepoMockRecorder) UpdatePurchaseOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePurchaseOrder", reflect.TypeOf((*MockPurchasingRepo)(nil).UpdatePurchaseOrder), ctx, arg)
+}
+
+// UpdatePurchaseOrderStatus mocks base method.
+func (m *MockPurchasingRepo) UpdatePurchaseOrderStatus(ctx context.Context, arg repository.UpdatePurchaseOrderStatusParams) (repository.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePurchaseOrderStatus", ctx, arg)
+	ret0, _ := ret[0].(repository.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePurchaseOrderStatus indicates an expected call of UpdatePurchaseOrderStatus.
+func (mr *MockPurchasingRepoMockRecorder) UpdatePurchaseOrderStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePurchaseOrderStatus", reflect.TypeOf((*MockPurchasingRepo)(nil).UpdatePurchaseOrderStatus), ctx, arg)
+}
+
+// UpdateSupplier mocks base method.
+func (m *MockPurchasingRepo) UpdateSupplier(ctx context.Context, arg repository.UpdateSupplierParams) (repository.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSupplier", ctx, arg)
+	ret0, _ := ret[0].(repository.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSupplier indicates an expected call of UpdateSupplier.
+func (mr *MockPurchasingRepoMockRecorder) UpdateSupplier(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSupplier", reflect.TypeOf((*MockPurchasingRepo)(nil).UpdateSupplier), ctx, arg)
+}
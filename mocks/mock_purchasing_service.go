@@ -0,0 +1,222 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: POS-kasir/internal/purchasing (interfaces: IPurchasingService)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_purchasing_service.go POS-kasir/internal/purchasing IPurchasingService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	purchasing "POS-kasir/internal/purchasing"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIPurchasingService is a mock of IPurchasingService interface.
+type MockIPurchasingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPurchasingServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIPurchasingServiceMockRecorder is the mock recorder for MockIPurchasingService.
+type MockIPurchasingServiceMockRecorder struct {
+	mock *MockIPurchasingService
+}
+
+// NewMockIPurchasingService creates a new mock instance.
+func NewMockIPurchasingService(ctrl *gomock.Controller) *MockIPurchasingService {
+	mock := &MockIPurchasingService{ctrl: ctrl}
+	mock.recorder = &MockIPurchasingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPurchasingService) EXPECT() *MockIPurchasingServiceMockRecorder {
+	return m.recorder
+}
+
+// ClosePurchaseOrder mocks base method.
+func (m *MockIPurchasingService) ClosePurchaseOrder(ctx context.Context, id uuid.UUID) (*purchasing.PurchaseOrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePurchaseOrder", ctx, id)
+	ret0, _ := ret[0].(*purchasing.PurchaseOrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClosePurchaseOrder indicates an expected call of ClosePurchaseOrder.
+func (mr *MockIPurchasingServiceMockRecorder) ClosePurchaseOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePurchaseOrder", reflect.TypeOf((*MockIPurchasingService)(nil).ClosePurchaseOrder), ctx, id)
+}
+
+// CreatePurchaseOrder mocks base method.
+func (m *MockIPurchasingService) CreatePurchaseOrder(ctx context.Context, req purchasing.PurchaseOrderRequest) (*purchasing.PurchaseOrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrder", ctx, req)
+	ret0, _ := ret[0].(*purchasing.PurchaseOrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrder indicates an expected call of CreatePurchaseOrder.
+func (mr *MockIPurchasingServiceMockRecorder) CreatePurchaseOrder(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrder", reflect.TypeOf((*MockIPurchasingService)(nil).CreatePurchaseOrder), ctx, req)
+}
+
+// CreateSupplier mocks base method.
+func (m *MockIPurchasingService) CreateSupplier(ctx context.Context, req purchasing.CreateSupplierRequest) (*purchasing.SupplierResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSupplier", ctx, req)
+	ret0, _ := ret[0].(*purchasing.SupplierResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSupplier indicates an expected call of CreateSupplier.
+func (mr *MockIPurchasingServiceMockRecorder) CreateSupplier(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupplier", reflect.TypeOf((*MockIPurchasingService)(nil).CreateSupplier), ctx, req)
+}
+
+// DeleteSupplier mocks base method.
+func (m *MockIPurchasingService) DeleteSupplier(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSupplier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSupplier indicates an expected call of DeleteSupplier.
+func (mr *MockIPurchasingServiceMockRecorder) DeleteSupplier(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSupplier", reflect.TypeOf((*MockIPurchasingService)(nil).DeleteSupplier), ctx, id)
+}
+
+// GetPurchaseOrder mocks base method.
+func (m *MockIPurchasingService) GetPurchaseOrder(ctx context.Context, id uuid.UUID) (*purchasing.PurchaseOrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrder", ctx, id)
+	ret0, _ := ret[0].(*purchasing.PurchaseOrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrder indicates an expected call of GetPurchaseOrder.
+func (mr *MockIPurchasingServiceMockRecorder) GetPurchaseOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrder", reflect.TypeOf((*MockIPurchasingService)(nil).GetPurchaseOrder), ctx, id)
+}
+
+// GetSupplier mocks base method.
+func (m *MockIPurchasingService) GetSupplier(ctx context.Context, id uuid.UUID) (*purchasing.SupplierResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplier", ctx, id)
+	ret0, _ := ret[0].(*purchasing.SupplierResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplier indicates an expected call of GetSupplier.
+func (mr *MockIPurchasingServiceMockRecorder) GetSupplier(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplier", reflect.TypeOf((*MockIPurchasingService)(nil).GetSupplier), ctx, id)
+}
+
+// ListPurchaseOrders mocks base method.
+func (m *MockIPurchasingService) ListPurchaseOrders(ctx context.Context, req purchasing.ListPurchaseOrdersRequest) (*purchasing.PagedPurchaseOrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchaseOrders", ctx, req)
+	ret0, _ := ret[0].(*purchasing.PagedPurchaseOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchaseOrders indicates an expected call of ListPurchaseOrders.
+func (mr *MockIPurchasingServiceMockRecorder) ListPurchaseOrders(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrders", reflect.TypeOf((*MockIPurchasingService)(nil).ListPurchaseOrders), ctx, req)
+}
+
+// ListSuppliers mocks base method.
+func (m *MockIPurchasingService) ListSuppliers(ctx context.Context, req purchasing.ListSuppliersRequest) (*purchasing.PagedSupplierResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSuppliers", ctx, req)
+	ret0, _ := ret[0].(*purchasing.PagedSupplierResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSuppliers indicates an expected call of ListSuppliers.
+func (mr *MockIPurchasingServiceMockRecorder) ListSuppliers(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuppliers", reflect.TypeOf((*MockIPurchasingService)(nil).ListSuppliers), ctx, req)
+}
+
+// ReceivePurchaseOrder mocks base method.
+func (m *MockIPurchasingService) ReceivePurchaseOrder(ctx context.Context, id uuid.UUID, req purchasing.ReceivePurchaseOrderRequest) (*purchasing.PurchaseOrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceivePurchaseOrder", ctx, id, req)
+	ret0, _ := ret[0].(*purchasing.PurchaseOrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceivePurchaseOrder indicates an expected call of ReceivePurchaseOrder.
+func (mr *MockIPurchasingServiceMockRecorder) ReceivePurchaseOrder(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceivePurchaseOrder", reflect.TypeOf((*MockIPurchasingService)(nil).ReceivePurchaseOrder), ctx, id, req)
+}
+
+// SendPurchaseOrder mocks base method.
+func (m *MockIPurchasingService) SendPurchaseOrder(ctx context.Context, id uuid.UUID) (*purchasing.PurchaseOrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPurchaseOrder", ctx, id)
+	ret0, _ := ret[0].(*purchasing.PurchaseOrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendPurchaseOrder indicates an expected call of SendPurchaseOrder.
+func (mr *MockIPurchasingServiceMockRecorder) SendPurchaseOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPurchaseOrder", reflect.TypeOf((*MockIPurchasingService)(nil).SendPurchaseOrder), ctx, id)
+}
+
+// UpdatePurchaseOrder mocks base method.
+func (m *MockIPurchasingService) UpdatePurchaseOrder(ctx context.Context, id uuid.UUID, req purchasing.PurchaseOrderRequest) (*purchasing.PurchaseOrderDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePurchaseOrder", ctx, id, req)
+	ret0, _ := ret[0].(*purchasing.PurchaseOrderDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePurchaseOrder indicates an expected call of UpdatePurchaseOrder.
+func (mr *MockIPurchasingServiceMockRecorder) UpdatePurchaseOrder(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePurchaseOrder", reflect.TypeOf((*MockIPurchasingService)(nil).UpdatePurchaseOrder), ctx, id, req)
+}
+
+// UpdateSupplier mocks base method.
+func (m *MockIPurchasingService) UpdateSupplier(ctx context.Context, id uuid.UUID, req purchasing.UpdateSupplierRequest) (*purchasing.SupplierResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSupplier", ctx, id, req)
+	ret0, _ := ret[0].(*purchasing.SupplierResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSupplier indicates an expected call of UpdateSupplier.
+func (mr *MockIPurchasingServiceMockRecorder) UpdateSupplier(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSupplier", reflect.TypeOf((*MockIPurchasingService)(nil).UpdateSupplier), ctx, id, req)
+}
@@ -0,0 +1,308 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: POS-kasir/internal/stocktake/repository (interfaces: Querier)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_stocktake_repo.go -mock_names Querier=MockStockTakeRepo POS-kasir/internal/stocktake/repository Querier
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	repository "POS-kasir/internal/stocktake/repository"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStockTakeRepo is a mock of Querier interface.
+type MockStockTakeRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockTakeRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockStockTakeRepoMockRecorder is the mock recorder for MockStockTakeRepo.
+type MockStockTakeRepoMockRecorder struct {
+	mock *MockStockTakeRepo
+}
+
+// NewMockStockTakeRepo creates a new mock instance.
+func NewMockStockTakeRepo(ctrl *gomock.Controller) *MockStockTakeRepo {
+	mock := &MockStockTakeRepo{ctrl: ctrl}
+	mock.recorder = &MockStockTakeRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockTakeRepo) EXPECT() *MockStockTakeRepoMockRecorder {
+	return m.recorder
+}
+
+// AdjustProductStock mocks base method.
+func (m *MockStockTakeRepo) AdjustProductStock(ctx context.Context, arg repository.AdjustProductStockParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustProductStock", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustProductStock indicates an expected call of AdjustProductStock.
+func (mr *MockStockTakeRepoMockRecorder) AdjustProductStock(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustProductStock", reflect.TypeOf((*MockStockTakeRepo)(nil).AdjustProductStock), ctx, arg)
+}
+
+// ApproveStockTake mocks base method.
+func (m *MockStockTakeRepo) ApproveStockTake(ctx context.Context, arg repository.ApproveStockTakeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveStockTake", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveStockTake indicates an expected call of ApproveStockTake.
+func (mr *MockStockTakeRepoMockRecorder) ApproveStockTake(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveStockTake", reflect.TypeOf((*MockStockTakeRepo)(nil).ApproveStockTake), ctx, arg)
+}
+
+// CancelStockTake mocks base method.
+func (m *MockStockTakeRepo) CancelStockTake(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelStockTake", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelStockTake indicates an expected call of CancelStockTake.
+func (mr *MockStockTakeRepoMockRecorder) CancelStockTake(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelStockTake", reflect.TypeOf((*MockStockTakeRepo)(nil).CancelStockTake), ctx, id)
+}
+
+// CategoryExists mocks base method.
+func (m *MockStockTakeRepo) CategoryExists(ctx context.Context, id int32) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CategoryExists", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CategoryExists indicates an expected call of CategoryExists.
+func (mr *MockStockTakeRepoMockRecorder) CategoryExists(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategoryExists", reflect.TypeOf((*MockStockTakeRepo)(nil).CategoryExists), ctx, id)
+}
+
+// CountStockTakeOverlaps mocks base method.
+func (m *MockStockTakeRepo) CountStockTakeOverlaps(ctx context.Context, stockTakeID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStockTakeOverlaps", ctx, stockTakeID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStockTakeOverlaps indicates an expected call of CountStockTakeOverlaps.
+func (mr *MockStockTakeRepoMockRecorder) CountStockTakeOverlaps(ctx, stockTakeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStockTakeOverlaps", reflect.TypeOf((*MockStockTakeRepo)(nil).CountStockTakeOverlaps), ctx, stockTakeID)
+}
+
+// CountStockTakes mocks base method.
+func (m *MockStockTakeRepo) CountStockTakes(ctx context.Context, status repository.NullStockTakeStatus) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStockTakes", ctx, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStockTakes indicates an expected call of CountStockTakes.
+func (mr *MockStockTakeRepoMockRecorder) CountStockTakes(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStockTakes", reflect.TypeOf((*MockStockTakeRepo)(nil).CountStockTakes), ctx, status)
+}
+
+// CreateStockHistory mocks base method.
+func (m *MockStockTakeRepo) CreateStockHistory(ctx context.Context, arg repository.CreateStockHistoryParams) (repository.StockHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockHistory", ctx, arg)
+	ret0, _ := ret[0].(repository.StockHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockHistory indicates an expected call of CreateStockHistory.
+func (mr *MockStockTakeRepoMockRecorder) CreateStockHistory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockHistory", reflect.TypeOf((*MockStockTakeRepo)(nil).CreateStockHistory), ctx, arg)
+}
+
+// CreateStockTake mocks base method.
+func (m *MockStockTakeRepo) CreateStockTake(ctx context.Context, arg repository.CreateStockTakeParams) (repository.StockTake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockTake", ctx, arg)
+	ret0, _ := ret[0].(repository.StockTake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockTake indicates an expected call of CreateStockTake.
+func (mr *MockStockTakeRepoMockRecorder) CreateStockTake(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockTake", reflect.TypeOf((*MockStockTakeRepo)(nil).CreateStockTake), ctx, arg)
+}
+
+// GetProductStockForUpdate mocks base method.
+func (m *MockStockTakeRepo) GetProductStockForUpdate(ctx context.Context, ids []uuid.UUID) ([]repository.GetProductStockForUpdateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductStockForUpdate", ctx, ids)
+	ret0, _ := ret[0].([]repository.GetProductStockForUpdateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductStockForUpdate indicates an expected call of GetProductStockForUpdate.
+func (mr *MockStockTakeRepoMockRecorder) GetProductStockForUpdate(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductStockForUpdate", reflect.TypeOf((*MockStockTakeRepo)(nil).GetProductStockForUpdate), ctx, ids)
+}
+
+// GetStockTake mocks base method.
+func (m *MockStockTakeRepo) GetStockTake(ctx context.Context, id uuid.UUID) (repository.GetStockTakeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockTake", ctx, id)
+	ret0, _ := ret[0].(repository.GetStockTakeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockTake indicates an expected call of GetStockTake.
+func (mr *MockStockTakeRepoMockRecorder) GetStockTake(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockTake", reflect.TypeOf((*MockStockTakeRepo)(nil).GetStockTake), ctx, id)
+}
+
+// GetStockTakeForUpdate mocks base method.
+func (m *MockStockTakeRepo) GetStockTakeForUpdate(ctx context.Context, id uuid.UUID) (repository.StockTake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockTakeForUpdate", ctx, id)
+	ret0, _ := ret[0].(repository.StockTake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockTakeForUpdate indicates an expected call of GetStockTakeForUpdate.
+func (mr *MockStockTakeRepoMockRecorder) GetStockTakeForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockTakeForUpdate", reflect.TypeOf((*MockStockTakeRepo)(nil).GetStockTakeForUpdate), ctx, id)
+}
+
+// GetStockTakeItemsByProducts mocks base method.
+func (m *MockStockTakeRepo) GetStockTakeItemsByProducts(ctx context.Context, arg repository.GetStockTakeItemsByProductsParams) ([]repository.StockTakeItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockTakeItemsByProducts", ctx, arg)
+	ret0, _ := ret[0].([]repository.StockTakeItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockTakeItemsByProducts indicates an expected call of GetStockTakeItemsByProducts.
+func (mr *MockStockTakeRepoMockRecorder) GetStockTakeItemsByProducts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockTakeItemsByProducts", reflect.TypeOf((*MockStockTakeRepo)(nil).GetStockTakeItemsByProducts), ctx, arg)
+}
+
+// ListStockTakeCounts mocks base method.
+func (m *MockStockTakeRepo) ListStockTakeCounts(ctx context.Context, stockTakeID uuid.UUID) ([]repository.ListStockTakeCountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockTakeCounts", ctx, stockTakeID)
+	ret0, _ := ret[0].([]repository.ListStockTakeCountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockTakeCounts indicates an expected call of ListStockTakeCounts.
+func (mr *MockStockTakeRepoMockRecorder) ListStockTakeCounts(ctx, stockTakeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockTakeCounts", reflect.TypeOf((*MockStockTakeRepo)(nil).ListStockTakeCounts), ctx, stockTakeID)
+}
+
+// ListStockTakeItems mocks base method.
+func (m *MockStockTakeRepo) ListStockTakeItems(ctx context.Context, stockTakeID uuid.UUID) ([]repository.ListStockTakeItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockTakeItems", ctx, stockTakeID)
+	ret0, _ := ret[0].([]repository.ListStockTakeItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockTakeItems indicates an expected call of ListStockTakeItems.
+func (mr *MockStockTakeRepoMockRecorder) ListStockTakeItems(ctx, stockTakeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockTakeItems", reflect.TypeOf((*MockStockTakeRepo)(nil).ListStockTakeItems), ctx, stockTakeID)
+}
+
+// ListStockTakes mocks base method.
+func (m *MockStockTakeRepo) ListStockTakes(ctx context.Context, arg repository.ListStockTakesParams) ([]repository.ListStockTakesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockTakes", ctx, arg)
+	ret0, _ := ret[0].([]repository.ListStockTakesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockTakes indicates an expected call of ListStockTakes.
+func (mr *MockStockTakeRepoMockRecorder) ListStockTakes(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockTakes", reflect.TypeOf((*MockStockTakeRepo)(nil).ListStockTakes), ctx, arg)
+}
+
+// SetStockTakeItemExpected mocks base method.
+func (m *MockStockTakeRepo) SetStockTakeItemExpected(ctx context.Context, arg repository.SetStockTakeItemExpectedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStockTakeItemExpected", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStockTakeItemExpected indicates an expected call of SetStockTakeItemExpected.
+func (mr *MockStockTakeRepoMockRecorder) SetStockTakeItemExpected(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStockTakeItemExpected", reflect.TypeOf((*MockStockTakeRepo)(nil).SetStockTakeItemExpected), ctx, arg)
+}
+
+// SnapshotStockTakeItems mocks base method.
+func (m *MockStockTakeRepo) SnapshotStockTakeItems(ctx context.Context, arg repository.SnapshotStockTakeItemsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotStockTakeItems", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotStockTakeItems indicates an expected call of SnapshotStockTakeItems.
+func (mr *MockStockTakeRepoMockRecorder) SnapshotStockTakeItems(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotStockTakeItems", reflect.TypeOf((*MockStockTakeRepo)(nil).SnapshotStockTakeItems), ctx, arg)
+}
+
+// UpsertStockTakeCount mocks base method.
+func (m *MockStockTakeRepo) UpsertStockTakeCount(ctx context.Context, arg repository.UpsertStockTakeCountParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertStockTakeCount", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertStockTakeCount indicates an expected call of UpsertStockTakeCount.
+func (mr *MockStockTakeRepoMockRecorder) UpsertStockTakeCount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertStockTakeCount", reflect.TypeOf((*MockStockTakeRepo)(nil).UpsertStockTakeCount), ctx, arg)
+}
@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: POS-kasir/internal/stocktake (interfaces: IStockTakeService)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination mocks/mock_stocktake_service.go POS-kasir/internal/stocktake IStockTakeService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	stocktake "POS-kasir/internal/stocktake"
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIStockTakeService is a mock of IStockTakeService interface.
+type MockIStockTakeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStockTakeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIStockTakeServiceMockRecorder is the mock recorder for MockIStockTakeService.
+type MockIStockTakeServiceMockRecorder struct {
+	mock *MockIStockTakeService
+}
+
+// NewMockIStockTakeService creates a new mock instance.
+func NewMockIStockTakeService(ctrl *gomock.Controller) *MockIStockTakeService {
+	mock := &MockIStockTakeService{ctrl: ctrl}
+	mock.recorder = &MockIStockTakeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStockTakeService) EXPECT() *MockIStockTakeServiceMockRecorder {
+	return m.recorder
+}
+
+// ApproveStockTake mocks base method.
+func (m *MockIStockTakeService) ApproveStockTake(ctx context.Context, id uuid.UUID) (*stocktake.StockTakeDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveStockTake", ctx, id)
+	ret0, _ := ret[0].(*stocktake.StockTakeDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveStockTake indicates an expected call of ApproveStockTake.
+func (mr *MockIStockTakeServiceMockRecorder) ApproveStockTake(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveStockTake", reflect.TypeOf((*MockIStockTakeService)(nil).ApproveStockTake), ctx, id)
+}
+
+// CancelStockTake mocks base method.
+func (m *MockIStockTakeService) CancelStockTake(ctx context.Context, id uuid.UUID) (*stocktake.StockTakeDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelStockTake", ctx, id)
+	ret0, _ := ret[0].(*stocktake.StockTakeDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelStockTake indicates an expected call of CancelStockTake.
+func (mr *MockIStockTakeServiceMockRecorder) CancelStockTake(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelStockTake", reflect.TypeOf((*MockIStockTakeService)(nil).CancelStockTake), ctx, id)
+}
+
+// CreateStockTake mocks base method.
+func (m *MockIStockTakeService) CreateStockTake(ctx context.Context, req stocktake.CreateStockTakeRequest) (*stocktake.StockTakeDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockTake", ctx, req)
+	ret0, _ := ret[0].(*stocktake.StockTakeDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockTake indicates an expected call of CreateStockTake.
+func (mr *MockIStockTakeServiceMockRecorder) CreateStockTake(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockTake", reflect.TypeOf((*MockIStockTakeService)(nil).CreateStockTake), ctx, req)
+}
+
+// GetStockTake mocks base method.
+func (m *MockIStockTakeService) GetStockTake(ctx context.Context, id uuid.UUID) (*stocktake.StockTakeDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockTake", ctx, id)
+	ret0, _ := ret[0].(*stocktake.StockTakeDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockTake indicates an expected call of GetStockTake.
+func (mr *MockIStockTakeServiceMockRecorder) GetStockTake(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockTake", reflect.TypeOf((*MockIStockTakeService)(nil).GetStockTake), ctx, id)
+}
+
+// ListStockTakes mocks base method.
+func (m *MockIStockTakeService) ListStockTakes(ctx context.Context, req stocktake.ListStockTakesRequest) (*stocktake.PagedStockTakeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockTakes", ctx, req)
+	ret0, _ := ret[0].(*stocktake.PagedStockTakeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockTakes indicates an expected call of ListStockTakes.
+func (mr *MockIStockTakeServiceMockRecorder) ListStockTakes(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockTakes", reflect.TypeOf((*MockIStockTakeService)(nil).ListStockTakes), ctx, req)
+}
+
+// RecordCounts mocks base method.
+func (m *MockIStockTakeService) RecordCounts(ctx context.Context, id uuid.UUID, req stocktake.RecordCountsRequest) (*stocktake.StockTakeDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordCounts", ctx, id, req)
+	ret0, _ := ret[0].(*stocktake.StockTakeDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordCounts indicates an expected call of RecordCounts.
+func (mr *MockIStockTakeServiceMockRecorder) RecordCounts(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordCounts", reflect.TypeOf((*MockIStockTakeService)(nil).RecordCounts), ctx, id, req)
+}
@@ -12,13 +12,15 @@ import (
 func SeedPaymentMethods(ctx context.Context, q payment_methods_repo.Querier, log logger.ILogger) error {
 	log.Info("Seeding payment methods...")
 
-	defaultMethods := []string{
-		"Cash",
-		"QRIS Dinamis",
-		"QRIS Statis",
+	defaultMethods := []payment_methods_repo.CreatePaymentMethodParams{
+		{Name: "Cash", Kind: payment_methods_repo.PaymentMethodKindCash, OpensCashDrawer: true, AllowsChange: true},
+		{Name: "QRIS Dinamis", Kind: payment_methods_repo.PaymentMethodKindGateway},
+		{Name: "QRIS Statis", Kind: payment_methods_repo.PaymentMethodKindEWallet},
+		{Name: "Loyalty Points", Kind: payment_methods_repo.PaymentMethodKindLoyaltyPoints},
 	}
 
-	for _, methodName := range defaultMethods {
+	for _, method := range defaultMethods {
+		methodName := method.Name
 
 		_, err := q.GetPaymentMethodByName(ctx, methodName)
 		if err == nil {
@@ -31,7 +33,7 @@ func SeedPaymentMethods(ctx context.Context, q payment_methods_repo.Querier, log
 			return err
 		}
 
-		_, createErr := q.CreatePaymentMethod(ctx, methodName)
+		_, createErr := q.CreatePaymentMethod(ctx, method)
 		if createErr != nil {
 			log.Errorf("Failed to seed payment method '%s': %v", methodName, createErr)
 			return createErr
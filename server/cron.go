@@ -25,6 +25,21 @@ func SetupCron(app *App, container *AppContainer) {
 		app.Logger.Errorf("Failed to setup shift auto-close cron: %v", err)
 	}
 
+	// Expire lapsed loyalty points at 03:30 every day
+	_, err = c.AddFunc("30 3 * * *", func() {
+		app.Logger.Info("Cron | Starting loyalty points expiry job...")
+		expired, err := container.CustomerService.ExpirePoints(context.Background())
+		if err != nil {
+			app.Logger.Errorf("Cron | Loyalty points expiry job failed: %v", err)
+		} else {
+			app.Logger.Infof("Cron | Loyalty points expiry job completed, %d lots expired", expired)
+		}
+	})
+
+	if err != nil {
+		app.Logger.Errorf("Failed to setup loyalty points expiry cron: %v", err)
+	}
+
 	// Daily Database Reset (for portfolio demo consistency)
 	if app.Config.EnableDbWipe {
 		_, err = c.AddFunc(app.Config.WipeCronSchedule, func() {
@@ -3,9 +3,12 @@ package server
 import (
 	"POS-kasir/internal/common/middleware"
 	ws "POS-kasir/internal/websocket"
+	"strconv"
+	"strings"
 
 	"github.com/gofiber/contrib/v3/websocket"
 	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
 )
 
 func SetupRoutes(app *App, container *AppContainer) {
@@ -27,7 +30,21 @@ func SetupRoutes(app *App, container *AppContainer) {
 	idempotencyMiddleware := middleware.Idempotency(app.RedisCache)
 
 	api.Get("/ws", authMiddleware, websocket.New(func(c *websocket.Conn) {
-		client := ws.NewClient(container.WSHub, c)
+		userID, _ := c.Locals("user_id").(uuid.UUID)
+		role, _ := c.Locals("role").(middleware.UserRole)
+
+		var topics []string
+		if raw := c.Query("topics"); raw != "" {
+			topics = strings.Split(raw, ",")
+		}
+
+		// A reconnecting client passes the last sequence number it saw to receive what it missed.
+		var since *uint64
+		if seq, err := strconv.ParseUint(c.Query("since"), 10, 64); err == nil {
+			since = &seq
+		}
+
+		client := ws.NewClient(container.WSHub, c, userID, role, topics, since)
 		container.WSHub.Register(client)
 
 		go client.WritePump()
@@ -65,9 +82,21 @@ func SetupRoutes(app *App, container *AppContainer) {
 	api.Get("/products/trash/:id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleAdmin), container.ProductHandler.GetDeletedProductHandler)
 	api.Post("/products/trash/:id/restore", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleAdmin), container.ProductHandler.RestoreProductHandler)
 
+	// Barcodes and SKU lookup; registered before /products/:id so "lookup" is not read as an ID
+	api.Get("/products/lookup", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.ProductHandler.LookupCodeHandler)
+	api.Post("/products/barcodes/generate", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.GenerateMissingBarcodesHandler)
+	api.Post("/products/:id/barcodes", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.AddProductBarcodeHandler)
+	api.Delete("/products/:id/barcodes/:barcode_id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.DeleteProductBarcodeHandler)
+
+	// Spreadsheet import and export; also registered before /products/:id
+	api.Post("/products/import", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.ImportProductsHandler)
+	api.Get("/products/export", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.ExportProductsHandler)
+
 	api.Get("/products", authMiddleware, container.ProductHandler.ListProductsHandler)
 	api.Get("/products/:id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.ProductHandler.GetProductHandler)
 	api.Get("/products/:id/stock-history", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.GetStockHistoryHandler)
+	api.Get("/products/:id/recipe", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.InventoryHandler.GetProductRecipeHandler)
+	api.Put("/products/:id/recipe", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.InventoryHandler.SetProductRecipeHandler)
 	api.Patch("/products/:id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.UpdateProductHandler)
 	api.Delete("/products/:id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleAdmin), container.ProductHandler.DeleteProductHandler)
 
@@ -75,27 +104,107 @@ func SetupRoutes(app *App, container *AppContainer) {
 	api.Post("/products/:product_id/options/:option_id/image", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.UploadProductOptionImageHandler)
 	api.Patch("/products/:product_id/options/:option_id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.UpdateProductOptionHandler)
 	api.Delete("/products/:product_id/options/:option_id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.DeleteProductOptionHandler)
+	api.Put("/products/:product_id/options/:option_id/recipe", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.InventoryHandler.SetOptionRecipeHandler)
+
+	api.Get("/products/:product_id/variants", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.ProductHandler.ListVariantsHandler)
+	api.Post("/products/:product_id/variants/generate", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.GenerateVariantsHandler)
+	api.Patch("/products/:product_id/variants/:variant_id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.UpdateVariantHandler)
+	api.Delete("/products/:product_id/variants/:variant_id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.DeleteVariantHandler)
+
+	api.Get("/modifier-groups", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.ProductHandler.ListModifierGroupsHandler)
+	api.Get("/modifier-groups/:id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.ProductHandler.GetModifierGroupHandler)
+	api.Post("/modifier-groups", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.CreateModifierGroupHandler)
+	api.Patch("/modifier-groups/:id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.UpdateModifierGroupHandler)
+	api.Delete("/modifier-groups/:id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ProductHandler.DeleteModifierGroupHandler)
 
 	api.Get("/payment-methods", authMiddleware, container.PaymentMethodHandler.ListPaymentMethodsHandler)
+	api.Post("/payment-methods", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleAdmin), container.PaymentMethodHandler.CreatePaymentMethodHandler)
+	api.Put("/payment-methods/reorder", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleAdmin), container.PaymentMethodHandler.ReorderPaymentMethodsHandler)
+	api.Get("/payment-methods/:id", authMiddleware, container.PaymentMethodHandler.GetPaymentMethodHandler)
+	api.Put("/payment-methods/:id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleAdmin), container.PaymentMethodHandler.UpdatePaymentMethodHandler)
+	api.Delete("/payment-methods/:id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleAdmin), container.PaymentMethodHandler.DeletePaymentMethodHandler)
 	api.Get("/cancellation-reasons", authMiddleware, container.CancellationReasonHandler.ListCancellationReasonsHandler)
 	api.Get("/activity-logs", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleAdmin), container.ActivityLogHandler.GetActivityLogs)
 
 	api.Post("/orders", authMiddleware, middleware.RequireIdempotencyKey(), idempotencyMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), middleware.ShiftMiddleware(container.ShiftRepo, app.Cache, app.Logger), container.OrderHandler.CreateOrderHandler)
 	api.Get("/orders", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.ListOrdersHandler)
+	api.Get("/orders/calling-board", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.GetCallingBoardHandler)
 	api.Get("/orders/:id", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.GetOrderHandler)
 	api.Patch("/orders/:id/items", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.UpdateOrderItemsHandler)
+	api.Post("/orders/:id/scan", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.ScanOrderItemHandler)
 
 	api.Post("/orders/:id/cancel", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.CancelOrderHandler)
 	api.Post("/orders/:id/refund", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.RefundOrderHandler)
 	api.Post("/orders/:id/apply-promotion", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.ApplyPromotionHandler)
+	api.Get("/orders/:id/promotions/evaluate", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.EvaluatePromotionsHandler)
+	api.Post("/orders/:id/promotions/auto-apply", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.AutoApplyPromotionsHandler)
+	api.Post("/orders/:id/loyalty/redeem", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.RedeemPointsHandler)
+	api.Delete("/orders/:id/loyalty/redeem", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.CancelPointsRedemptionHandler)
 	api.Post("/orders/:id/pay/midtrans", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.InitiateMidtransPaymentHandler)
 	api.Post("/orders/:id/pay/manual", authMiddleware, middleware.RequireIdempotencyKey(), idempotencyMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.ConfirmManualPaymentHandler)
+	api.Post("/orders/:id/split", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.SplitOrderHandler)
+	api.Post("/orders/:id/merge", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.MergeOrdersHandler)
+	api.Post("/orders/:id/payments", authMiddleware, middleware.RequireIdempotencyKey(), idempotencyMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.AddOrderPaymentHandler)
 	api.Post("/orders/:id/update-status", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.OrderHandler.UpdateOperationalStatusHandler)
+	api.Post("/orders/:id/reopen", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.OrderHandler.ReopenOrderHandler)
 
 	api.Post("/orders/:id/print", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.PrinterHandler.PrintInvoiceHandler)
 	api.Get("/orders/:id/print-data", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier), container.PrinterHandler.GetInvoiceDataHandler)
 	api.Post("/payments/midtrans-notification", container.OrderHandler.MidtransNotificationHandler)
 
+	kdsGroup := api.Group("/kds", authMiddleware)
+	{
+		kdsGroup.Get("/stations", middleware.RoleMiddleware(middleware.UserRoleCashier), container.KitchenHandler.ListStationsHandler)
+		kdsGroup.Post("/stations", middleware.RoleMiddleware(middleware.UserRoleManager), container.KitchenHandler.CreateStationHandler)
+		kdsGroup.Get("/stations/:id", middleware.RoleMiddleware(middleware.UserRoleCashier), container.KitchenHandler.GetStationHandler)
+		kdsGroup.Put("/stations/:id", middleware.RoleMiddleware(middleware.UserRoleManager), container.KitchenHandler.UpdateStationHandler)
+		kdsGroup.Delete("/stations/:id", middleware.RoleMiddleware(middleware.UserRoleManager), container.KitchenHandler.DeleteStationHandler)
+		kdsGroup.Get("/tickets", middleware.RoleMiddleware(middleware.UserRoleCashier), container.KitchenHandler.ListTicketsHandler)
+		kdsGroup.Post("/items/:id/bump", middleware.RoleMiddleware(middleware.UserRoleCashier), container.KitchenHandler.BumpItemHandler)
+		kdsGroup.Post("/items/:id/recall", middleware.RoleMiddleware(middleware.UserRoleCashier), container.KitchenHandler.RecallItemHandler)
+		kdsGroup.Post("/items/:id/priority", middleware.RoleMiddleware(middleware.UserRoleCashier), container.KitchenHandler.SetItemPriorityHandler)
+	}
+
+	inventoryGroup := api.Group("/inventory", authMiddleware)
+	{
+		inventoryGroup.Get("/ingredients", middleware.RoleMiddleware(middleware.UserRoleCashier), container.InventoryHandler.ListIngredientsHandler)
+		inventoryGroup.Post("/ingredients", middleware.RoleMiddleware(middleware.UserRoleManager), container.InventoryHandler.CreateIngredientHandler)
+		inventoryGroup.Get("/ingredients/:id", middleware.RoleMiddleware(middleware.UserRoleCashier), container.InventoryHandler.GetIngredientHandler)
+		inventoryGroup.Patch("/ingredients/:id", middleware.RoleMiddleware(middleware.UserRoleManager), container.InventoryHandler.UpdateIngredientHandler)
+		inventoryGroup.Delete("/ingredients/:id", middleware.RoleMiddleware(middleware.UserRoleManager), container.InventoryHandler.DeleteIngredientHandler)
+		inventoryGroup.Get("/ingredients/:id/stock-history", middleware.RoleMiddleware(middleware.UserRoleManager), container.InventoryHandler.GetStockHistoryHandler)
+	}
+
+	supplierGroup := api.Group("/suppliers", authMiddleware)
+	{
+		supplierGroup.Get("/", middleware.RoleMiddleware(middleware.UserRoleCashier), container.PurchasingHandler.ListSuppliersHandler)
+		supplierGroup.Post("/", middleware.RoleMiddleware(middleware.UserRoleManager), container.PurchasingHandler.CreateSupplierHandler)
+		supplierGroup.Get("/:id", middleware.RoleMiddleware(middleware.UserRoleCashier), container.PurchasingHandler.GetSupplierHandler)
+		supplierGroup.Put("/:id", middleware.RoleMiddleware(middleware.UserRoleManager), container.PurchasingHandler.UpdateSupplierHandler)
+		supplierGroup.Delete("/:id", middleware.RoleMiddleware(middleware.UserRoleManager), container.PurchasingHandler.DeleteSupplierHandler)
+	}
+
+	purchaseOrderGroup := api.Group("/purchase-orders", authMiddleware)
+	{
+		purchaseOrderGroup.Get("/", middleware.RoleMiddleware(middleware.UserRoleCashier), container.PurchasingHandler.ListPurchaseOrdersHandler)
+		purchaseOrderGroup.Post("/", middleware.RoleMiddleware(middleware.UserRoleManager), container.PurchasingHandler.CreatePurchaseOrderHandler)
+		purchaseOrderGroup.Get("/:id", middleware.RoleMiddleware(middleware.UserRoleCashier), container.PurchasingHandler.GetPurchaseOrderHandler)
+		purchaseOrderGroup.Put("/:id", middleware.RoleMiddleware(middleware.UserRoleManager), container.PurchasingHandler.UpdatePurchaseOrderHandler)
+		purchaseOrderGroup.Post("/:id/send", middleware.RoleMiddleware(middleware.UserRoleManager), container.PurchasingHandler.SendPurchaseOrderHandler)
+		purchaseOrderGroup.Post("/:id/close", middleware.RoleMiddleware(middleware.UserRoleManager), container.PurchasingHandler.ClosePurchaseOrderHandler)
+		purchaseOrderGroup.Post("/:id/receive", middleware.RoleMiddleware(middleware.UserRoleManager), container.PurchasingHandler.ReceivePurchaseOrderHandler)
+	}
+
+	stockTakeGroup := api.Group("/stock-takes", authMiddleware)
+	{
+		stockTakeGroup.Get("/", middleware.RoleMiddleware(middleware.UserRoleCashier), container.StockTakeHandler.ListStockTakesHandler)
+		stockTakeGroup.Post("/", middleware.RoleMiddleware(middleware.UserRoleManager), container.StockTakeHandler.CreateStockTakeHandler)
+		stockTakeGroup.Get("/:id", middleware.RoleMiddleware(middleware.UserRoleCashier), container.StockTakeHandler.GetStockTakeHandler)
+		stockTakeGroup.Post("/:id/counts", middleware.RoleMiddleware(middleware.UserRoleCashier), container.StockTakeHandler.RecordCountsHandler)
+		stockTakeGroup.Post("/:id/approve", middleware.RoleMiddleware(middleware.UserRoleManager), container.StockTakeHandler.ApproveStockTakeHandler)
+		stockTakeGroup.Post("/:id/cancel", middleware.RoleMiddleware(middleware.UserRoleManager), container.StockTakeHandler.CancelStockTakeHandler)
+	}
+
 	api.Get("/reports/dashboard-summary", authMiddleware, container.ReportHandler.GetDashboardSummaryHandler)
 	api.Get("/reports/sales", authMiddleware, container.ReportHandler.GetSalesReportsHandler)
 	api.Get("/reports/products", authMiddleware, container.ReportHandler.GetProductPerformanceHandler)
@@ -107,6 +216,9 @@ func SetupRoutes(app *App, container *AppContainer) {
 	api.Get("/reports/low-stock", authMiddleware, container.ReportHandler.GetLowStockProductsHandler)
 	api.Get("/reports/promotions", authMiddleware, container.ReportHandler.GetPromotionPerformanceHandler)
 	api.Get("/reports/shift-summary", authMiddleware, container.ReportHandler.GetShiftSummaryHandler)
+	api.Get("/reports/purchase-orders/outstanding", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ReportHandler.GetOutstandingPurchaseOrdersHandler)
+	api.Get("/reports/supplier-spend", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ReportHandler.GetSupplierSpendHandler)
+	api.Get("/reports/inventory-valuation", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleManager), container.ReportHandler.GetInventoryValuationHandler)
 
 	promotionsReadGroup := api.Group("/promotions", authMiddleware, middleware.RoleMiddleware(middleware.UserRoleCashier))
 	{
@@ -120,6 +232,10 @@ func SetupRoutes(app *App, container *AppContainer) {
 		promotionsWriteGroup.Put("/:id", container.PromotionHandler.UpdatePromotionHandler)
 		promotionsWriteGroup.Delete("/:id", container.PromotionHandler.DeletePromotionHandler)
 		promotionsWriteGroup.Post("/:id/restore", container.PromotionHandler.RestorePromotionHandler)
+		promotionsWriteGroup.Get("/:id/codes", container.PromotionHandler.ListPromotionCodesHandler)
+		promotionsWriteGroup.Post("/:id/codes", container.PromotionHandler.CreatePromotionCodeHandler)
+		promotionsWriteGroup.Post("/:id/codes/batch", container.PromotionHandler.GeneratePromotionCodesHandler)
+		promotionsWriteGroup.Delete("/:id/codes/:code_id", container.PromotionHandler.DeactivatePromotionCodeHandler)
 	}
 
 	settingsGroup := api.Group("/settings", authMiddleware)
@@ -132,6 +248,15 @@ func SetupRoutes(app *App, container *AppContainer) {
 		settingsGroup.Put("/printer", middleware.RoleMiddleware(middleware.UserRoleAdmin), container.SettingsHandler.UpdatePrinterSettingsHandler)
 		settingsGroup.Get("/printer/discover", middleware.RoleMiddleware(middleware.UserRoleAdmin), container.PrinterHandler.DiscoverPrintersHandler)
 		settingsGroup.Post("/printer/test", middleware.RoleMiddleware(middleware.UserRoleAdmin), container.PrinterHandler.TestPrintHandler)
+
+		settingsGroup.Get("/tax", container.SettingsHandler.GetTaxSettingsHandler)
+		settingsGroup.Put("/tax", middleware.RoleMiddleware(middleware.UserRoleAdmin), container.SettingsHandler.UpdateTaxSettingsHandler)
+
+		settingsGroup.Get("/operations", container.SettingsHandler.GetOperationalSettingsHandler)
+		settingsGroup.Put("/operations", middleware.RoleMiddleware(middleware.UserRoleAdmin), container.SettingsHandler.UpdateOperationalSettingsHandler)
+
+		settingsGroup.Get("/loyalty", container.SettingsHandler.GetLoyaltySettingsHandler)
+		settingsGroup.Put("/loyalty", middleware.RoleMiddleware(middleware.UserRoleAdmin), container.SettingsHandler.UpdateLoyaltySettingsHandler)
 	}
 
 	shiftGroup := api.Group("/shifts", authMiddleware)
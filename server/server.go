@@ -12,6 +12,10 @@ import (
 	"POS-kasir/internal/common/store"
 	"POS-kasir/internal/customers"
 	customers_repo "POS-kasir/internal/customers/repository"
+	"POS-kasir/internal/inventory"
+	inventory_repo "POS-kasir/internal/inventory/repository"
+	"POS-kasir/internal/kitchen"
+	kitchen_repo "POS-kasir/internal/kitchen/repository"
 	"POS-kasir/internal/orders"
 	orders_repo "POS-kasir/internal/orders/repository"
 	"POS-kasir/internal/payment_methods"
@@ -21,12 +25,16 @@ import (
 	products_repo "POS-kasir/internal/products/repository"
 	"POS-kasir/internal/promotions"
 	promotions_repo "POS-kasir/internal/promotions/repository"
+	"POS-kasir/internal/purchasing"
+	purchasing_repo "POS-kasir/internal/purchasing/repository"
 	"POS-kasir/internal/report"
 	report_repo "POS-kasir/internal/report/repository"
 	"POS-kasir/internal/settings"
 	settings_repo "POS-kasir/internal/settings/repository"
 	"POS-kasir/internal/shift"
 	shift_repo "POS-kasir/internal/shift/repository"
+	"POS-kasir/internal/stocktake"
+	stocktake_repo "POS-kasir/internal/stocktake/repository"
 	"POS-kasir/internal/user"
 	user_repo "POS-kasir/internal/user/repository"
 	"POS-kasir/pkg/cache"
@@ -87,9 +95,14 @@ type AppContainer struct {
 	UserHandler               user.IUsrHandler
 	CategoryHandler           categories.ICtgHandler
 	CustomerHandler           customers.ICustomerHandler
+	CustomerService           customers.ICustomerService
 	ProductHandler            products.IPrdHandler
 	OrderHandler              orders.IOrderHandler
 	PaymentMethodHandler      payment_methods.IPaymentMethodHandler
+	KitchenHandler            kitchen.IKitchenHandler
+	InventoryHandler          inventory.IInventoryHandler
+	PurchasingHandler         purchasing.IPurchasingHandler
+	StockTakeHandler          stocktake.IStockTakeHandler
 	CancellationReasonHandler cancellation_reasons.ICancellationReasonHandler
 	ReportHandler             report.IRptHandler
 	PromotionHandler          promotions.IPromotionHandler
@@ -207,14 +220,39 @@ func BuildAppContainer(app *App) *AppContainer {
 	// Shift Module Repo
 	shiftRepo := shift_repo.New(app.DB.GetPool())
 
+	// Settings Module
+	settingsRepo := settings_repo.New(app.DB.GetPool())
+	settingsService := settings.NewSettingsService(app.Store, activityService, settingsRepo, app.R2, app.Logger)
+	settingsHandler := settings.NewSettingsHandler(settingsService, app.Logger)
+
 	// Order & Payment Module
 	ordersRepo := orders_repo.New(app.DB.GetPool())
-	orderService := orders.NewOrderService(app.Store, ordersRepo, productsRepo, app.MidtransService, activityService, app.Logger, wsHub)
+	orderService := orders.NewOrderService(app.Store, ordersRepo, productsRepo, app.MidtransService, activityService, settingsService, app.Logger, wsHub)
 	orderHandler := orders.NewOrderHandler(orderService, app.Logger)
 
+	// Kitchen Display Module
+	kitchenRepo := kitchen_repo.New(app.DB.GetPool())
+	kitchenService := kitchen.NewKitchenService(app.Store, kitchenRepo, orderService, activityService, app.Logger, wsHub)
+	kitchenHandler := kitchen.NewKitchenHandler(kitchenService, app.Logger)
+
+	// Inventory Module
+	inventoryRepo := inventory_repo.New(app.DB.GetPool())
+	inventoryService := inventory.NewInventoryService(app.Store, inventoryRepo, activityService, app.Logger)
+	inventoryHandler := inventory.NewInventoryHandler(inventoryService, app.Logger)
+
+	// Purchasing Module
+	purchasingRepo := purchasing_repo.New(app.DB.GetPool())
+	purchasingService := purchasing.NewPurchasingService(app.Store, purchasingRepo, activityService, app.Logger)
+	purchasingHandler := purchasing.NewPurchasingHandler(purchasingService, app.Logger)
+
+	// Stock Take Module
+	stockTakeRepo := stocktake_repo.New(app.DB.GetPool())
+	stockTakeService := stocktake.NewStockTakeService(app.Store, stockTakeRepo, activityService, app.Logger)
+	stockTakeHandler := stocktake.NewStockTakeHandler(stockTakeService, app.Logger)
+
 	// Payment Method Module
 	paymentMethodRepo := payment_methods_repo.New(app.DB.GetPool())
-	paymentMethodService := payment_methods.NewPaymentMethodService(paymentMethodRepo, app.Logger)
+	paymentMethodService := payment_methods.NewPaymentMethodService(paymentMethodRepo, app.Logger, activityService)
 	paymentMethodHandler := payment_methods.NewPaymentMethodHandler(paymentMethodService, app.Logger)
 
 	// Cancellation Reason Module
@@ -224,25 +262,20 @@ func BuildAppContainer(app *App) *AppContainer {
 
 	// report module
 	reportRepo := report_repo.New(app.DB.GetPool())
-	reportService := report.NewRptService(app.Store, reportRepo, activityService, app.Logger, app.RedisCache)
+	reportService := report.NewRptService(app.Store, reportRepo, activityService, settingsService, app.Logger, app.RedisCache)
 	reportHandler := report.NewRptHandler(reportService, app.Logger)
 
 	// Promotion Module
 	promotionsRepo := promotions_repo.New(app.DB.GetPool())
-	promotionService := promotions.NewPromotionService(app.Store, promotionsRepo, app.Logger, activityService)
+	promotionService := promotions.NewPromotionService(app.Store, promotionsRepo, app.Logger, activityService, settingsService)
 	promotionHandler := promotions.NewPromotionHandler(promotionService, app.Logger)
 
-	// Settings Module
-	settingsRepo := settings_repo.New(app.DB.GetPool())
-	settingsService := settings.NewSettingsService(app.Store, activityService, settingsRepo, app.R2, app.Logger)
-	settingsHandler := settings.NewSettingsHandler(settingsService, app.Logger)
-
 	// Printer Module
 	printerService := printer.NewPrinterService(orderService, settingsService, paymentMethodService, userRepo, app.Logger, escpos.NewPrinter)
 	printerHandler := printer.NewPrinterHandler(printerService)
 
 	// Shift Module
-	shiftService := shift.NewService(shiftRepo, app.Logger, app.Cache)
+	shiftService := shift.NewService(shiftRepo, app.Logger, app.Cache, wsHub)
 	shiftHandler := shift.NewHandler(shiftService, app.Logger)
 
 	return &AppContainer{
@@ -250,9 +283,14 @@ func BuildAppContainer(app *App) *AppContainer {
 		UserHandler:               userHandler,
 		CategoryHandler:           categoryHandler,
 		CustomerHandler:           customerHandler,
+		CustomerService:           customerService,
 		ProductHandler:            prdHandler,
 		OrderHandler:              orderHandler,
 		PaymentMethodHandler:      paymentMethodHandler,
+		KitchenHandler:            kitchenHandler,
+		InventoryHandler:          inventoryHandler,
+		PurchasingHandler:         purchasingHandler,
+		StockTakeHandler:          stockTakeHandler,
 		CancellationReasonHandler: cancellationReasonHandler,
 		ReportHandler:             reportHandler,
 		PromotionHandler:          promotionHandler,
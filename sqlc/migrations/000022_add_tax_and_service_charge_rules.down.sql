@@ -0,0 +1,16 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_inclusive;
+ALTER TABLE orders DROP COLUMN IF EXISTS service_charge_rate;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_rate;
+
+DELETE FROM settings WHERE key IN (
+    'tax_enabled',
+    'tax_rate',
+    'tax_inclusive',
+    'tax_order_types',
+    'tax_exempt_category_ids',
+    'service_charge_enabled',
+    'service_charge_rate',
+    'service_charge_order_types',
+    'service_charge_taxable',
+    'tax_rounding_mode'
+);
@@ -0,0 +1,18 @@
+-- Tax & service charge rules (dibaca oleh OrderService setiap kali total pesanan dihitung ulang)
+INSERT INTO settings (key, value, description) VALUES
+('tax_enabled', 'true', 'Whether tax is applied to orders'),
+('tax_rate', '11', 'Tax rate in percent'),
+('tax_inclusive', 'false', 'Whether product prices already include tax'),
+('tax_order_types', 'dine_in,takeaway', 'Comma separated order types that are taxed'),
+('tax_exempt_category_ids', '', 'Comma separated category IDs exempt from tax'),
+('service_charge_enabled', 'false', 'Whether service charge is applied to orders'),
+('service_charge_rate', '0', 'Service charge rate in percent'),
+('service_charge_order_types', 'dine_in', 'Comma separated order types that receive a service charge'),
+('service_charge_taxable', 'true', 'Whether tax is also charged on the service charge'),
+('tax_rounding_mode', 'round', 'Rounding mode for tax and service charge (round, floor, ceil)')
+ON CONFLICT (key) DO NOTHING;
+
+-- Snapshot tarif yang dipakai saat total dihitung, supaya struk lama tetap akurat
+ALTER TABLE orders ADD COLUMN tax_rate NUMERIC(5,2) NOT NULL DEFAULT 0 CHECK (tax_rate >= 0);
+ALTER TABLE orders ADD COLUMN service_charge_rate NUMERIC(5,2) NOT NULL DEFAULT 0 CHECK (service_charge_rate >= 0);
+ALTER TABLE orders ADD COLUMN tax_inclusive BOOLEAN NOT NULL DEFAULT false;
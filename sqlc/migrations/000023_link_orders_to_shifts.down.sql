@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS order_refunds;
+
+DROP INDEX IF EXISTS idx_orders_shift_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS shift_id;
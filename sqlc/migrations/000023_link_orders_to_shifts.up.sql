@@ -0,0 +1,24 @@
+ALTER TABLE orders ADD COLUMN shift_id UUID REFERENCES shifts(id) ON DELETE SET NULL;
+CREATE INDEX idx_orders_shift_id ON orders(shift_id);
+
+-- Backfill: attach existing orders to the shift their creator had open at the time
+UPDATE orders o
+SET shift_id = s.id
+FROM shifts s
+WHERE o.user_id = s.user_id
+  AND o.created_at >= s.start_time
+  AND (s.end_time IS NULL OR o.created_at <= s.end_time);
+
+CREATE TABLE order_refunds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    shift_id UUID REFERENCES shifts(id) ON DELETE SET NULL,
+    payment_method_id INTEGER REFERENCES payment_methods(id),
+    amount BIGINT NOT NULL CHECK (amount >= 0),
+    reason TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_order_refunds_order_id ON order_refunds(order_id);
+CREATE INDEX idx_order_refunds_shift_id ON order_refunds(shift_id);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_payment_methods_kind;
+
+ALTER TABLE payment_methods
+    DROP COLUMN IF EXISTS allows_change,
+    DROP COLUMN IF EXISTS requires_reference,
+    DROP COLUMN IF EXISTS opens_cash_drawer,
+    DROP COLUMN IF EXISTS sort_order,
+    DROP COLUMN IF EXISTS kind;
+
+DROP TYPE IF EXISTS payment_method_kind;
@@ -0,0 +1,23 @@
+CREATE TYPE payment_method_kind AS ENUM ('cash', 'card', 'e_wallet', 'gateway', 'voucher', 'on_account');
+
+ALTER TABLE payment_methods
+    ADD COLUMN kind payment_method_kind,
+    ADD COLUMN sort_order INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN opens_cash_drawer BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN requires_reference BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN allows_change BOOLEAN NOT NULL DEFAULT false;
+
+-- Backfill the methods created by the default seeder
+UPDATE payment_methods
+SET kind = CASE
+        WHEN LOWER(name) = 'cash' THEN 'cash'::payment_method_kind
+        WHEN LOWER(name) = 'qris dinamis' THEN 'gateway'::payment_method_kind
+        ELSE 'e_wallet'::payment_method_kind
+    END,
+    opens_cash_drawer = (LOWER(name) = 'cash'),
+    allows_change = (LOWER(name) = 'cash'),
+    sort_order = id;
+
+ALTER TABLE payment_methods ALTER COLUMN kind SET NOT NULL;
+
+CREATE INDEX idx_payment_methods_kind ON payment_methods(kind);
@@ -0,0 +1,31 @@
+CREATE TABLE order_payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    payment_method_id INTEGER NOT NULL REFERENCES payment_methods(id),
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    tendered_amount BIGINT NOT NULL CHECK (tendered_amount >= amount),
+    change_amount BIGINT NOT NULL DEFAULT 0 CHECK (change_amount >= 0),
+    reference_number VARCHAR(255),
+    shift_id UUID REFERENCES shifts(id) ON DELETE SET NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_order_payments_order_id ON order_payments(order_id);
+CREATE INDEX idx_order_payments_shift_id ON order_payments(shift_id);
+
+-- Backfill: every paid order becomes a single tender line
+INSERT INTO order_payments (order_id, payment_method_id, amount, tendered_amount, change_amount, reference_number, shift_id, created_by, created_at)
+SELECT
+    o.id,
+    o.payment_method_id,
+    o.net_total,
+    GREATEST(COALESCE(o.cash_received, o.net_total), o.net_total),
+    GREATEST(COALESCE(o.cash_received, o.net_total), o.net_total) - o.net_total,
+    o.payment_gateway_reference,
+    o.shift_id,
+    o.user_id,
+    o.updated_at
+FROM orders o
+WHERE o.payment_method_id IS NOT NULL
+  AND o.net_total > 0;
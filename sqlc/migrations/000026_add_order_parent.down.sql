@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_orders_parent_order_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS parent_order_id;
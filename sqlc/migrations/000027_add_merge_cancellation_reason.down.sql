@@ -0,0 +1 @@
+DELETE FROM cancellation_reasons WHERE reason = 'Order Merged';
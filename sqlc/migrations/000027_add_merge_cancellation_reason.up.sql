@@ -0,0 +1,4 @@
+-- System reason used when an order is cancelled because it was merged into another order
+INSERT INTO cancellation_reasons (reason, description)
+VALUES ('Order Merged', 'System: items were moved into another order')
+ON CONFLICT (reason) DO NOTHING;
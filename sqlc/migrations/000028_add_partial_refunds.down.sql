@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS order_refund_items;
+
+DROP INDEX IF EXISTS idx_order_refunds_order_payment_id;
+ALTER TABLE order_refunds DROP COLUMN IF EXISTS order_payment_id;
@@ -0,0 +1,23 @@
+ALTER TABLE order_refunds ADD COLUMN order_payment_id UUID REFERENCES order_payments(id) ON DELETE SET NULL;
+CREATE INDEX idx_order_refunds_order_payment_id ON order_refunds(order_payment_id);
+
+-- Backfill: whole-order refunds were written one per tender with the tender's full amount
+UPDATE order_refunds r
+SET order_payment_id = op.id
+FROM order_payments op
+WHERE op.order_id = r.order_id
+  AND op.payment_method_id = r.payment_method_id
+  AND op.amount = r.amount;
+
+CREATE TABLE order_refund_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    refund_id UUID NOT NULL REFERENCES order_refunds(id) ON DELETE CASCADE,
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    amount BIGINT NOT NULL CHECK (amount >= 0),
+    restocked BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_order_refund_items_refund_id ON order_refund_items(refund_id);
+CREATE INDEX idx_order_refund_items_order_item_id ON order_refund_items(order_item_id);
@@ -0,0 +1,16 @@
+CREATE TABLE order_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status order_status,
+    to_status order_status NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_order_status_history_order_id ON order_status_history(order_id);
+
+-- Backfill: existing orders start their history at the status they are in today
+INSERT INTO order_status_history (order_id, from_status, to_status, changed_by, note, created_at)
+SELECT o.id, NULL, o.status, o.user_id, 'Backfilled from existing order', o.created_at
+FROM orders o;
@@ -0,0 +1,18 @@
+DROP TRIGGER IF EXISTS order_items_assign_station ON order_items;
+DROP FUNCTION IF EXISTS assign_order_item_station();
+
+DROP INDEX IF EXISTS idx_order_items_station_prep;
+
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS served_at,
+    DROP COLUMN IF EXISTS ready_at,
+    DROP COLUMN IF EXISTS started_at,
+    DROP COLUMN IF EXISTS queued_at,
+    DROP COLUMN IF EXISTS is_priority,
+    DROP COLUMN IF EXISTS prep_status,
+    DROP COLUMN IF EXISTS station_id;
+
+DROP TABLE IF EXISTS kitchen_station_categories;
+DROP TABLE IF EXISTS kitchen_stations;
+DROP TYPE IF EXISTS order_item_status;
+-- KITCHEN_STATION stays in log_entity_type; enum values cannot be dropped safely.
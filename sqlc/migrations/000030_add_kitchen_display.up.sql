@@ -0,0 +1,80 @@
+ALTER TYPE log_entity_type ADD VALUE 'KITCHEN_STATION';
+
+CREATE TYPE order_item_status AS ENUM ('queued', 'cooking', 'ready', 'served');
+
+CREATE TABLE kitchen_stations (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) UNIQUE NOT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TRIGGER kitchen_stations_set_updated_at
+    BEFORE UPDATE ON kitchen_stations
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+-- Each category is prepared at most at one station
+CREATE TABLE kitchen_station_categories (
+    category_id INTEGER PRIMARY KEY REFERENCES categories(id) ON DELETE CASCADE,
+    station_id INTEGER NOT NULL REFERENCES kitchen_stations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_kitchen_station_categories_station_id ON kitchen_station_categories(station_id);
+
+ALTER TABLE order_items
+    ADD COLUMN station_id INTEGER REFERENCES kitchen_stations(id) ON DELETE SET NULL,
+    ADD COLUMN prep_status order_item_status NOT NULL DEFAULT 'queued',
+    ADD COLUMN is_priority BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN queued_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN started_at TIMESTAMPTZ,
+    ADD COLUMN ready_at TIMESTAMPTZ,
+    ADD COLUMN served_at TIMESTAMPTZ;
+
+CREATE INDEX idx_order_items_station_prep ON order_items(station_id, prep_status);
+
+-- Route new order lines to the station of the first active station mapped to one of the product's categories
+CREATE OR REPLACE FUNCTION assign_order_item_station()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF NEW.station_id IS NULL THEN
+    SELECT ks.id INTO NEW.station_id
+    FROM product_categories pc
+    JOIN kitchen_station_categories ksc ON ksc.category_id = pc.category_id
+    JOIN kitchen_stations ks ON ks.id = ksc.station_id
+    WHERE pc.product_id = NEW.product_id AND ks.is_active
+    ORDER BY ks.sort_order, ks.id
+    LIMIT 1;
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER order_items_assign_station
+    BEFORE INSERT ON order_items
+    FOR EACH ROW
+    EXECUTE FUNCTION assign_order_item_station();
+
+-- Backfill: lines of orders that already left the kitchen are treated as served
+UPDATE order_items oi
+SET prep_status = 'served',
+    queued_at = o.created_at,
+    started_at = o.created_at,
+    ready_at = o.updated_at,
+    served_at = o.updated_at
+FROM orders o
+WHERE o.id = oi.order_id AND o.status IN ('served', 'paid', 'cancelled');
+
+UPDATE order_items oi
+SET prep_status = 'cooking',
+    queued_at = o.created_at,
+    started_at = o.updated_at
+FROM orders o
+WHERE o.id = oi.order_id AND o.status = 'in_progress';
+
+UPDATE order_items oi
+SET queued_at = o.created_at
+FROM orders o
+WHERE o.id = oi.order_id AND o.status = 'open';
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_orders_business_date;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS business_date,
+    DROP COLUMN IF EXISTS queue_number;
+
+DROP TABLE IF EXISTS order_queue_counters;
+
+DELETE FROM settings WHERE key IN (
+    'store_timezone',
+    'queue_prefix',
+    'queue_reset_time',
+    'queue_number_digits'
+);
@@ -0,0 +1,48 @@
+-- Nomor antrian harian: prefix, jam reset hari bisnis, dan zona waktu toko
+INSERT INTO settings (key, value, description) VALUES
+('store_timezone', 'Asia/Jakarta', 'IANA time zone the store operates in'),
+('queue_prefix', '', 'Prefix printed before the daily queue number'),
+('queue_reset_time', '00:00', 'Local time (HH:MM) at which the queue number starts again from 1'),
+('queue_number_digits', '3', 'Queue numbers are zero padded to this many digits')
+ON CONFLICT (key) DO NOTHING;
+
+-- One counter row per business day; CreateOrder increments it inside the order transaction
+CREATE TABLE order_queue_counters (
+    business_date DATE PRIMARY KEY,
+    last_number INTEGER NOT NULL DEFAULT 0 CHECK (last_number >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE orders
+    ADD COLUMN queue_number VARCHAR(20),
+    ADD COLUMN business_date DATE;
+
+CREATE INDEX idx_orders_business_date ON orders(business_date);
+
+-- Backfill: number existing orders per calendar day in the default store time zone
+WITH numbered AS (
+    SELECT
+        id,
+        (created_at AT TIME ZONE 'Asia/Jakarta')::date AS business_date,
+        row_number() OVER (PARTITION BY (created_at AT TIME ZONE 'Asia/Jakarta')::date ORDER BY created_at, id) AS seq
+    FROM orders
+    WHERE parent_order_id IS NULL
+)
+UPDATE orders o
+SET business_date = n.business_date,
+    queue_number = lpad(n.seq::text, 3, '0')
+FROM numbered n
+WHERE o.id = n.id;
+
+-- Split children keep the number of the order they were split from
+UPDATE orders c
+SET business_date = p.business_date,
+    queue_number = p.queue_number
+FROM orders p
+WHERE c.parent_order_id = p.id;
+
+INSERT INTO order_queue_counters (business_date, last_number)
+SELECT business_date, count(*)
+FROM orders
+WHERE business_date IS NOT NULL AND parent_order_id IS NULL
+GROUP BY business_date;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS ingredient_stock_history;
+DROP TABLE IF EXISTS product_option_recipe_items;
+DROP TABLE IF EXISTS product_recipe_items;
+DROP TABLE IF EXISTS ingredients;
+DROP TYPE IF EXISTS ingredient_unit;
+-- INGREDIENT stays in log_entity_type; enum values cannot be dropped safely.
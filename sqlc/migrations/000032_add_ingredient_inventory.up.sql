@@ -0,0 +1,63 @@
+ALTER TYPE log_entity_type ADD VALUE 'INGREDIENT';
+
+-- Ingredients are counted in their smallest practical unit: grams, millilitres or pieces
+CREATE TYPE ingredient_unit AS ENUM ('g', 'ml', 'pcs');
+
+CREATE TABLE ingredients (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    unit ingredient_unit NOT NULL,
+    stock INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    low_stock_threshold INTEGER NOT NULL DEFAULT 0 CHECK (low_stock_threshold >= 0),
+    cost_per_unit NUMERIC(12,4) NOT NULL DEFAULT 0 CHECK (cost_per_unit >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_ingredients_name ON ingredients(lower(name)) WHERE deleted_at IS NULL;
+
+CREATE TRIGGER ingredients_set_updated_at
+    BEFORE UPDATE ON ingredients
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+-- What one unit of a product consumes
+CREATE TABLE product_recipe_items (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    ingredient_id UUID NOT NULL REFERENCES ingredients(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (product_id, ingredient_id)
+);
+
+CREATE INDEX idx_product_recipe_items_ingredient_id ON product_recipe_items(ingredient_id);
+
+-- What choosing an option adds on top of the product recipe. With replaces_ingredient_id set the option
+-- swaps that ingredient of the product recipe for this one (e.g. oat milk instead of milk).
+CREATE TABLE product_option_recipe_items (
+    product_option_id UUID NOT NULL REFERENCES product_options(id) ON DELETE CASCADE,
+    ingredient_id UUID NOT NULL REFERENCES ingredients(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    replaces_ingredient_id UUID REFERENCES ingredients(id) ON DELETE RESTRICT,
+    PRIMARY KEY (product_option_id, ingredient_id),
+    CHECK (replaces_ingredient_id IS NULL OR replaces_ingredient_id <> ingredient_id)
+);
+
+CREATE INDEX idx_product_option_recipe_items_ingredient_id ON product_option_recipe_items(ingredient_id);
+
+-- Ingredient ledger, the counterpart of stock_history
+CREATE TABLE ingredient_stock_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ingredient_id UUID NOT NULL REFERENCES ingredients(id) ON DELETE CASCADE,
+    change_amount INTEGER NOT NULL,
+    previous_stock INTEGER NOT NULL,
+    current_stock INTEGER NOT NULL,
+    change_type stock_change_type NOT NULL,
+    reference_id UUID,
+    note TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_ingredient_stock_history_ingredient_id ON ingredient_stock_history(ingredient_id);
+CREATE INDEX idx_ingredient_stock_history_reference_id ON ingredient_stock_history(reference_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS goods_receipt_items;
+DROP TABLE IF EXISTS goods_receipts;
+DROP SEQUENCE IF EXISTS goods_receipt_number_seq;
+DROP TABLE IF EXISTS purchase_order_items;
+DROP TABLE IF EXISTS purchase_orders;
+DROP SEQUENCE IF EXISTS purchase_order_number_seq;
+DROP TABLE IF EXISTS suppliers;
+DROP TYPE IF EXISTS purchase_order_status;
+-- SUPPLIER and PURCHASE_ORDER stay in log_entity_type; enum values cannot be dropped safely.
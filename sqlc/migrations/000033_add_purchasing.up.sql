@@ -0,0 +1,85 @@
+ALTER TYPE log_entity_type ADD VALUE 'SUPPLIER';
+ALTER TYPE log_entity_type ADD VALUE 'PURCHASE_ORDER';
+
+CREATE TYPE purchase_order_status AS ENUM ('draft', 'sent', 'partially_received', 'received', 'closed');
+
+CREATE TABLE suppliers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    contact_name VARCHAR(100),
+    phone VARCHAR(20),
+    email VARCHAR(255),
+    address TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_suppliers_name ON suppliers(lower(name)) WHERE deleted_at IS NULL;
+
+CREATE TRIGGER suppliers_set_updated_at
+    BEFORE UPDATE ON suppliers
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+CREATE SEQUENCE purchase_order_number_seq;
+
+CREATE TABLE purchase_orders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    po_number VARCHAR(20) NOT NULL UNIQUE DEFAULT 'PO-' || lpad(nextval('purchase_order_number_seq')::text, 6, '0'),
+    supplier_id UUID NOT NULL REFERENCES suppliers(id) ON DELETE RESTRICT,
+    status purchase_order_status NOT NULL DEFAULT 'draft',
+    expected_date DATE,
+    notes TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    sent_at TIMESTAMPTZ,
+    received_at TIMESTAMPTZ,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_purchase_orders_supplier_id ON purchase_orders(supplier_id);
+CREATE INDEX idx_purchase_orders_status ON purchase_orders(status);
+
+CREATE TRIGGER purchase_orders_set_updated_at
+    BEFORE UPDATE ON purchase_orders
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+CREATE TABLE purchase_order_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    quantity_ordered INTEGER NOT NULL CHECK (quantity_ordered > 0),
+    quantity_received INTEGER NOT NULL DEFAULT 0 CHECK (quantity_received >= 0 AND quantity_received <= quantity_ordered),
+    unit_cost NUMERIC(12,2) NOT NULL CHECK (unit_cost >= 0),
+    UNIQUE (purchase_order_id, product_id)
+);
+
+CREATE SEQUENCE goods_receipt_number_seq;
+
+-- One delivery against a purchase order; a PO can be received over several deliveries
+CREATE TABLE goods_receipts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    receipt_number VARCHAR(20) NOT NULL UNIQUE DEFAULT 'GRN-' || lpad(nextval('goods_receipt_number_seq')::text, 6, '0'),
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    supplier_reference VARCHAR(100),
+    notes TEXT,
+    received_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_goods_receipts_purchase_order_id ON goods_receipts(purchase_order_id);
+CREATE INDEX idx_goods_receipts_received_at ON goods_receipts(received_at);
+
+-- unit_cost is what the supplier actually invoiced, which may differ from the PO price
+CREATE TABLE goods_receipt_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    goods_receipt_id UUID NOT NULL REFERENCES goods_receipts(id) ON DELETE CASCADE,
+    purchase_order_item_id UUID NOT NULL REFERENCES purchase_order_items(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_cost NUMERIC(12,2) NOT NULL CHECK (unit_cost >= 0)
+);
+
+CREATE INDEX idx_goods_receipt_items_goods_receipt_id ON goods_receipt_items(goods_receipt_id);
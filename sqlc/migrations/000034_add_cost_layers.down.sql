@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS cost_layer_consumptions;
+DROP TABLE IF EXISTS cost_layers;
+DROP TYPE IF EXISTS cost_layer_source;
+
+DELETE FROM settings WHERE key = 'costing_method';
@@ -0,0 +1,38 @@
+-- Metode harga pokok toko: average (rata-rata bergerak) atau fifo (lapisan biaya)
+INSERT INTO settings (key, value, description) VALUES
+('costing_method', 'average', 'How cost of goods sold is taken from restock costs: average or fifo')
+ON CONFLICT (key) DO NOTHING;
+
+CREATE TYPE cost_layer_source AS ENUM ('opening', 'purchase', 'adjustment', 'return');
+
+-- Every unit that enters stock belongs to a layer with the cost it came in at; sales take from the oldest layer first
+CREATE TABLE cost_layers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    source cost_layer_source NOT NULL,
+    reference_id UUID,
+    quantity_received INTEGER NOT NULL CHECK (quantity_received > 0),
+    quantity_remaining INTEGER NOT NULL CHECK (quantity_remaining >= 0 AND quantity_remaining <= quantity_received),
+    unit_cost NUMERIC(12,2) NOT NULL CHECK (unit_cost >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_cost_layers_open ON cost_layers(product_id, created_at, id) WHERE quantity_remaining > 0;
+
+-- What each sale took from which layer, so a cancellation or refund can put it back at the same cost
+CREATE TABLE cost_layer_consumptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    cost_layer_id UUID NOT NULL REFERENCES cost_layers(id) ON DELETE CASCADE,
+    order_item_id UUID REFERENCES order_items(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    unit_cost NUMERIC(12,2) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_cost_layer_consumptions_order_item ON cost_layer_consumptions(order_item_id);
+
+-- Stock on hand today becomes one opening layer at the current cost price
+INSERT INTO cost_layers (product_id, source, quantity_received, quantity_remaining, unit_cost)
+SELECT id, 'opening', stock, stock, cost_price
+FROM products
+WHERE stock > 0 AND deleted_at IS NULL;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS stock_take_counts;
+DROP TABLE IF EXISTS stock_take_items;
+DROP TABLE IF EXISTS stock_takes;
+DROP SEQUENCE IF EXISTS stock_take_number_seq;
+DROP TYPE IF EXISTS stock_take_scope;
+DROP TYPE IF EXISTS stock_take_status;
+-- STOCK_TAKE stays in log_entity_type; enum values cannot be dropped safely.
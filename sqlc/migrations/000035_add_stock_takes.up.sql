@@ -0,0 +1,52 @@
+ALTER TYPE log_entity_type ADD VALUE 'STOCK_TAKE';
+
+CREATE TYPE stock_take_status AS ENUM ('open', 'approved', 'cancelled');
+CREATE TYPE stock_take_scope AS ENUM ('full', 'category');
+
+CREATE SEQUENCE stock_take_number_seq;
+
+CREATE TABLE stock_takes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reference VARCHAR(20) NOT NULL UNIQUE DEFAULT 'ST-' || lpad(nextval('stock_take_number_seq')::text, 6, '0'),
+    scope stock_take_scope NOT NULL,
+    category_id INTEGER REFERENCES categories(id) ON DELETE SET NULL,
+    status stock_take_status NOT NULL DEFAULT 'open',
+    notes TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    approved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    approved_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_stock_takes_status ON stock_takes(status);
+
+CREATE TRIGGER stock_takes_set_updated_at
+    BEFORE UPDATE ON stock_takes
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+-- system_quantity is the stock when the count started; expected_quantity is the stock when the product was
+-- last counted, so sales and deliveries during the count are not mistaken for variance
+CREATE TABLE stock_take_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    stock_take_id UUID NOT NULL REFERENCES stock_takes(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    system_quantity INTEGER NOT NULL,
+    expected_quantity INTEGER NOT NULL,
+    unit_cost NUMERIC(12,2) NOT NULL DEFAULT 0,
+    UNIQUE (stock_take_id, product_id)
+);
+
+CREATE INDEX idx_stock_take_items_product_id ON stock_take_items(product_id);
+
+-- Each counter keeps one count per product (e.g. shelf and back room); a product's counted quantity is their sum
+CREATE TABLE stock_take_counts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    stock_take_item_id UUID NOT NULL REFERENCES stock_take_items(id) ON DELETE CASCADE,
+    counted_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    counted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (stock_take_item_id, counted_by)
+);
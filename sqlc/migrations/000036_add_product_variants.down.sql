@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_stock_history_variant_id;
+DROP INDEX IF EXISTS idx_order_items_variant_id;
+ALTER TABLE stock_history DROP COLUMN IF EXISTS variant_id;
+ALTER TABLE order_items DROP COLUMN IF EXISTS variant_id;
+DROP TRIGGER IF EXISTS product_variants_sync_stock ON product_variants;
+DROP FUNCTION IF EXISTS sync_product_variant_stock();
+DROP TABLE IF EXISTS product_variant_values;
+DROP TABLE IF EXISTS product_variants;
+DROP TABLE IF EXISTS product_variant_attribute_values;
+DROP TABLE IF EXISTS product_variant_attributes;
@@ -0,0 +1,80 @@
+-- Attributes (e.g. size, colour) and their values define a product's variant matrix
+CREATE TABLE product_variant_attributes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (product_id, name)
+);
+
+CREATE TABLE product_variant_attribute_values (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    attribute_id UUID NOT NULL REFERENCES product_variant_attributes(id) ON DELETE CASCADE,
+    value VARCHAR(50) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (attribute_id, value)
+);
+
+-- A sellable combination of attribute values. price overrides the product price when set.
+CREATE TABLE product_variants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    name VARCHAR(150) NOT NULL,
+    sku VARCHAR(64) NOT NULL,
+    barcode VARCHAR(64),
+    price BIGINT CHECK (price >= 0),
+    cost_price NUMERIC(12,2) NOT NULL DEFAULT 0 CHECK (cost_price >= 0),
+    stock INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_product_variants_product_id ON product_variants(product_id);
+CREATE UNIQUE INDEX idx_product_variants_sku ON product_variants(lower(sku)) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_product_variants_barcode ON product_variants(barcode) WHERE deleted_at IS NULL AND barcode IS NOT NULL;
+
+CREATE TRIGGER product_variants_set_updated_at
+    BEFORE UPDATE ON product_variants
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+CREATE TABLE product_variant_values (
+    variant_id UUID NOT NULL REFERENCES product_variants(id) ON DELETE CASCADE,
+    value_id UUID NOT NULL REFERENCES product_variant_attribute_values(id) ON DELETE CASCADE,
+    PRIMARY KEY (variant_id, value_id)
+);
+
+-- A product with variants stocks nothing itself; its stock is the sum of its active variants
+CREATE OR REPLACE FUNCTION sync_product_variant_stock()
+RETURNS TRIGGER AS $$
+DECLARE
+    pid UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        pid := OLD.product_id;
+    ELSE
+        pid := NEW.product_id;
+    END IF;
+
+    UPDATE products
+    SET stock = COALESCE((SELECT SUM(stock) FROM product_variants WHERE product_id = pid AND deleted_at IS NULL), 0)
+    WHERE id = pid;
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER product_variants_sync_stock
+    AFTER INSERT OR UPDATE OF stock, deleted_at OR DELETE ON product_variants
+    FOR EACH ROW
+    EXECUTE FUNCTION sync_product_variant_stock();
+
+ALTER TABLE order_items ADD COLUMN variant_id UUID REFERENCES product_variants(id) ON DELETE SET NULL;
+ALTER TABLE stock_history ADD COLUMN variant_id UUID REFERENCES product_variants(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_order_items_variant_id ON order_items(variant_id);
+CREATE INDEX idx_stock_history_variant_id ON stock_history(variant_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_product_options_modifier_group_id;
+ALTER TABLE product_options DROP COLUMN IF EXISTS is_default;
+ALTER TABLE product_options DROP COLUMN IF EXISTS modifier_group_id;
+DROP TABLE IF EXISTS modifier_groups;
+-- MODIFIER_GROUP stays in log_entity_type; enum values cannot be dropped safely.
@@ -0,0 +1,28 @@
+ALTER TYPE log_entity_type ADD VALUE 'MODIFIER_GROUP';
+
+-- A modifier group sets how many options a customer picks from it (e.g. size: exactly 1, toppings: up to 3).
+-- Groups are shared: every product whose options point at a group follows the same rules.
+-- max_select NULL means no upper limit.
+CREATE TABLE modifier_groups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    min_select INTEGER NOT NULL DEFAULT 0 CHECK (min_select >= 0),
+    max_select INTEGER CHECK (max_select IS NULL OR max_select >= GREATEST(min_select, 1)),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_modifier_groups_name ON modifier_groups(lower(name)) WHERE deleted_at IS NULL;
+
+CREATE TRIGGER modifier_groups_set_updated_at
+    BEFORE UPDATE ON modifier_groups
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+-- Default options are added to an order line when nothing is picked from their group
+ALTER TABLE product_options
+    ADD COLUMN modifier_group_id UUID REFERENCES modifier_groups(id) ON DELETE SET NULL,
+    ADD COLUMN is_default BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_product_options_modifier_group_id ON product_options(modifier_group_id);
@@ -0,0 +1,5 @@
+DROP SEQUENCE IF EXISTS instore_barcode_seq;
+DROP TABLE IF EXISTS product_barcodes;
+DROP TYPE IF EXISTS barcode_symbology;
+DROP INDEX IF EXISTS idx_products_sku;
+ALTER TABLE products DROP COLUMN IF EXISTS sku;
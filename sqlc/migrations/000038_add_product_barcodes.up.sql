@@ -0,0 +1,23 @@
+ALTER TABLE products ADD COLUMN sku VARCHAR(64);
+
+CREATE UNIQUE INDEX idx_products_sku ON products(lower(sku)) WHERE deleted_at IS NULL AND sku IS NOT NULL;
+
+CREATE TYPE barcode_symbology AS ENUM ('ean13', 'ean8', 'upc_a', 'internal');
+
+-- A product (or one of its variants) can carry several barcodes, e.g. the maker's EAN-13 and an in-store code.
+-- Codes stay reserved while their product is soft-deleted so a restore cannot collide.
+CREATE TABLE product_barcodes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id UUID REFERENCES product_variants(id) ON DELETE CASCADE,
+    code VARCHAR(64) NOT NULL,
+    symbology barcode_symbology NOT NULL,
+    is_generated BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_product_barcodes_code ON product_barcodes(code);
+CREATE INDEX idx_product_barcodes_product_id ON product_barcodes(product_id);
+
+-- Numbers behind generated in-store EAN-13 codes (prefix 20, reserved by GS1 for restricted circulation)
+CREATE SEQUENCE instore_barcode_seq;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TYPE IF EXISTS redemption_status;
+ALTER TABLE orders DROP COLUMN IF EXISTS applied_promotion_code_id;
+DROP TABLE IF EXISTS promotion_codes;
+ALTER TABLE promotions
+    DROP COLUMN IF EXISTS budget_amount,
+    DROP COLUMN IF EXISTS per_customer_limit,
+    DROP COLUMN IF EXISTS total_redemption_limit;
@@ -0,0 +1,42 @@
+-- Usage limits of a promotion; NULL means unlimited. The budget caps the total discount given.
+ALTER TABLE promotions
+    ADD COLUMN total_redemption_limit INTEGER CHECK (total_redemption_limit > 0),
+    ADD COLUMN per_customer_limit INTEGER CHECK (per_customer_limit > 0),
+    ADD COLUMN budget_amount BIGINT CHECK (budget_amount > 0);
+
+-- A code is either one shared code of a campaign or one of a batch of generated single-use codes.
+-- Codes are stored upper-case; deactivated codes stay reserved.
+CREATE TABLE promotion_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    code VARCHAR(64) NOT NULL,
+    batch_id UUID,
+    max_uses INTEGER CHECK (max_uses > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deactivated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_promotion_codes_code ON promotion_codes(code);
+CREATE INDEX idx_promotion_codes_promotion_id ON promotion_codes(promotion_id, batch_id);
+
+ALTER TABLE orders ADD COLUMN applied_promotion_code_id UUID REFERENCES promotion_codes(id);
+
+CREATE TYPE redemption_status AS ENUM ('redeemed', 'released');
+
+-- A redemption is written when an order with a promotion is settled, and released when the order is
+-- cancelled or fully refunded. Only redeemed rows count towards the limits.
+CREATE TABLE promotion_redemptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    promotion_id UUID NOT NULL REFERENCES promotions(id),
+    promotion_code_id UUID REFERENCES promotion_codes(id),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    customer_id UUID REFERENCES customers(id) ON DELETE SET NULL,
+    discount_amount BIGINT NOT NULL,
+    status redemption_status NOT NULL DEFAULT 'redeemed',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    released_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_promotion_redemptions_order ON promotion_redemptions(order_id) WHERE status = 'redeemed';
+CREATE INDEX idx_promotion_redemptions_promotion ON promotion_redemptions(promotion_id, status);
+CREATE INDEX idx_promotion_redemptions_code ON promotion_redemptions(promotion_code_id) WHERE promotion_code_id IS NOT NULL;
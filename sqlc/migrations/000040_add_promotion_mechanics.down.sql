@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS promotion_tiers;
+ALTER TABLE promotions
+    DROP COLUMN IF EXISTS bundle_quantity,
+    DROP COLUMN IF EXISTS get_quantity,
+    DROP COLUMN IF EXISTS buy_quantity;
+-- buy_x_get_y, bundle_price and tiered stay in discount_type; enum values cannot be dropped safely.
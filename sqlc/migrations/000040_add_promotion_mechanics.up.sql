@@ -0,0 +1,22 @@
+ALTER TYPE discount_type ADD VALUE 'buy_x_get_y';
+ALTER TYPE discount_type ADD VALUE 'bundle_price';
+ALTER TYPE discount_type ADD VALUE 'tiered';
+
+-- buy_x_get_y: for every buy_quantity eligible units, get_quantity more are discount_value percent off
+-- (100 = free). bundle_price: every bundle_quantity eligible units together cost discount_value.
+ALTER TABLE promotions
+    ADD COLUMN buy_quantity INTEGER CHECK (buy_quantity > 0),
+    ADD COLUMN get_quantity INTEGER CHECK (get_quantity > 0),
+    ADD COLUMN bundle_quantity INTEGER CHECK (bundle_quantity > 1);
+
+-- tiered: the highest tier whose min_amount the eligible total reaches sets the discount.
+CREATE TABLE promotion_tiers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    min_amount BIGINT NOT NULL CHECK (min_amount >= 0),
+    discount_type discount_type NOT NULL,
+    discount_value NUMERIC(12,2) NOT NULL CHECK (discount_value > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_promotion_tiers_min_amount ON promotion_tiers(promotion_id, min_amount);
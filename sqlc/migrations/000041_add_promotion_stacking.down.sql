@@ -0,0 +1,12 @@
+DROP INDEX idx_promotion_redemptions_order;
+CREATE UNIQUE INDEX idx_promotion_redemptions_order ON promotion_redemptions(order_id) WHERE status = 'redeemed';
+
+DROP TABLE IF EXISTS order_promotions;
+
+DROP INDEX IF EXISTS idx_promotions_auto_apply;
+ALTER TABLE promotions
+    DROP COLUMN IF EXISTS priority,
+    DROP COLUMN IF EXISTS stacking,
+    DROP COLUMN IF EXISTS auto_apply;
+
+DROP TYPE IF EXISTS promotion_stacking;
@@ -0,0 +1,35 @@
+CREATE TYPE promotion_stacking AS ENUM ('exclusive', 'stackable');
+
+-- auto_apply promotions are picked by the evaluation engine without the cashier naming them. An exclusive
+-- promotion is never combined with another; stackable ones combine with each other. priority decides first
+-- which promotions win, the larger discount breaks ties.
+ALTER TABLE promotions
+    ADD COLUMN auto_apply BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN stacking promotion_stacking NOT NULL DEFAULT 'exclusive',
+    ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_promotions_auto_apply ON promotions(priority DESC) WHERE auto_apply AND deleted_at IS NULL;
+
+-- The promotions applied to an order and the part of orders.discount_amount each one gives, in the order they
+-- were applied. orders.applied_promotion_id keeps the first of them.
+CREATE TABLE order_promotions (
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    promotion_id UUID NOT NULL REFERENCES promotions(id),
+    promotion_code_id UUID REFERENCES promotion_codes(id),
+    discount_amount BIGINT NOT NULL CHECK (discount_amount >= 0),
+    auto_applied BOOLEAN NOT NULL DEFAULT false,
+    position INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (order_id, promotion_id)
+);
+
+CREATE INDEX idx_order_promotions_promotion ON order_promotions(promotion_id);
+
+INSERT INTO order_promotions (order_id, promotion_id, promotion_code_id, discount_amount, position, created_at)
+SELECT id, applied_promotion_id, applied_promotion_code_id, discount_amount, 0, created_at
+FROM orders
+WHERE applied_promotion_id IS NOT NULL;
+
+-- An order now redeems each of its promotions
+DROP INDEX idx_promotion_redemptions_order;
+CREATE UNIQUE INDEX idx_promotion_redemptions_order ON promotion_redemptions(order_id, promotion_id) WHERE status = 'redeemed';
@@ -0,0 +1,4 @@
+ALTER TABLE order_promotions DROP COLUMN IF EXISTS schedule_window;
+
+DROP TABLE IF EXISTS promotion_excluded_dates;
+DROP TABLE IF EXISTS promotion_schedules;
@@ -0,0 +1,24 @@
+-- Recurring windows a promotion runs in on top of its start and end dates, e.g. 15:00-17:00 on weekdays.
+-- Days follow time.Weekday (0 = Sunday) and times are in the store's time zone. A window that ends at or
+-- before its start runs past midnight, on the day it starts. A promotion without windows runs all day.
+CREATE TABLE promotion_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    days_of_week SMALLINT[] NOT NULL CHECK (cardinality(days_of_week) > 0 AND days_of_week <@ ARRAY[0, 1, 2, 3, 4, 5, 6]::SMALLINT[]),
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL CHECK (end_time <> start_time),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_promotion_schedules_promotion_id ON promotion_schedules(promotion_id);
+
+-- Store dates a promotion does not run on, such as public holidays.
+CREATE TABLE promotion_excluded_dates (
+    promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    excluded_date DATE NOT NULL,
+    PRIMARY KEY (promotion_id, excluded_date)
+);
+
+-- The window an order's promotion was applied in, kept as its label so reports survive schedule edits.
+-- NULL when the promotion had no windows.
+ALTER TABLE order_promotions ADD COLUMN schedule_window VARCHAR(64);
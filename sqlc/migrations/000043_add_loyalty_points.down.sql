@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS loyalty_point_entries;
+DROP TYPE IF EXISTS loyalty_redemption_kind;
+DROP TYPE IF EXISTS loyalty_entry_type;
+-- loyalty_points stays in payment_method_kind; enum values cannot be dropped safely.
+DELETE FROM settings WHERE key IN (
+    'loyalty_enabled',
+    'loyalty_spend_per_point',
+    'loyalty_point_value',
+    'loyalty_min_redeem_points',
+    'loyalty_expiry_days',
+    'loyalty_category_multipliers',
+    'loyalty_promotion_bonuses'
+);
@@ -0,0 +1,37 @@
+-- Program poin loyalti: aturan perolehan dan penukaran disimpan di settings
+INSERT INTO settings (key, value, description) VALUES
+('loyalty_enabled', 'false', 'Whether customers earn and redeem loyalty points'),
+('loyalty_spend_per_point', '10000', 'Amount a customer spends to earn one point'),
+('loyalty_point_value', '100', 'Amount one point is worth when redeemed'),
+('loyalty_min_redeem_points', '0', 'Fewest points that can be redeemed at once'),
+('loyalty_expiry_days', '365', 'Days after which earned points expire; 0 keeps them forever'),
+('loyalty_category_multipliers', '', 'Comma separated category_id:multiplier pairs, e.g. 3:2,5:1.5'),
+('loyalty_promotion_bonuses', '', 'Comma separated promotion_id:points pairs awarded when the promotion is applied to a paid order')
+ON CONFLICT (key) DO NOTHING;
+
+-- Points redeemed as a tender are recorded as payments with a method of this kind
+ALTER TYPE payment_method_kind ADD VALUE 'loyalty_points';
+
+CREATE TYPE loyalty_entry_type AS ENUM ('earn', 'redeem', 'expire', 'reversal');
+CREATE TYPE loyalty_redemption_kind AS ENUM ('discount', 'tender');
+
+-- The ledger: a customer's balance is the sum of points. Entries that add points are lots; remaining is what
+-- is left of a lot after redemptions, taken oldest expiry first, and is what expires at expires_at.
+CREATE TABLE loyalty_point_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    entry_type loyalty_entry_type NOT NULL,
+    redeemed_as loyalty_redemption_kind,
+    points INTEGER NOT NULL CHECK (points <> 0),
+    remaining INTEGER NOT NULL DEFAULT 0 CHECK (remaining >= 0 AND remaining <= GREATEST(points, 0)),
+    amount BIGINT NOT NULL DEFAULT 0 CHECK (amount >= 0),
+    expires_at TIMESTAMPTZ,
+    description TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_loyalty_point_entries_customer ON loyalty_point_entries(customer_id, created_at DESC);
+CREATE INDEX idx_loyalty_point_entries_order ON loyalty_point_entries(order_id);
+CREATE INDEX idx_loyalty_point_entries_lots ON loyalty_point_entries(customer_id, expires_at) WHERE remaining > 0;
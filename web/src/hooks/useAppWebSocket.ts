@@ -1,6 +1,8 @@
 import { useEffect, useRef, useState, useCallback } from 'react';
 
 type WebSocketEvent = {
+  seq?: number;
+  topics?: string[];
   type: string;
   payload: any;
 };
@@ -10,11 +12,16 @@ export const useAppWebSocket = (onMessage: (event: WebSocketEvent) => void) => {
   const wsRef = useRef<WebSocket | null>(null);
   const reconnectTimeoutRef = useRef<ReturnType<typeof setTimeout> | null>(null);
   const reconnectCountRef = useRef(0);
+  // Last sequence number received, so a reconnect can ask the server for the events it missed
+  const lastSeqRef = useRef<number | null>(null);
 
   const connect = useCallback(() => {
     // Determine the WS URL from the API URL
     const apiUrl = import.meta.env.VITE_API_URL || 'http://localhost:8080/api/v1';
-    const wsUrl = apiUrl.replace(/^http/, 'ws') + '/ws';
+    let wsUrl = apiUrl.replace(/^http/, 'ws') + '/ws';
+    if (lastSeqRef.current !== null) {
+      wsUrl += `?since=${lastSeqRef.current}`;
+    }
 
     try {
       const ws = new WebSocket(wsUrl);
@@ -28,6 +35,9 @@ export const useAppWebSocket = (onMessage: (event: WebSocketEvent) => void) => {
       ws.onmessage = (event) => {
         try {
           const data = JSON.parse(event.data) as WebSocketEvent;
+          if (data.seq) {
+            lastSeqRef.current = data.seq;
+          }
           onMessage(data);
         } catch (error) {
           console.error('[WebSocket] Error parsing message:', error);
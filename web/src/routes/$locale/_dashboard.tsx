@@ -43,7 +43,7 @@ function DashboardLayout() {
     const queryClient = useQueryClient()
 
     useAppWebSocket((event) => {
-        if (event.type === 'ORDER_CREATED' || event.type === 'ORDER_UPDATED') {
+        if (event.type === 'ORDER_CREATED' || event.type === 'ORDER_UPDATED' || event.type === 'RESYNC_REQUIRED') {
             queryClient.invalidateQueries({ queryKey: ['orders'] })
         }
     })